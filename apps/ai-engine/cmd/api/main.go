@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -17,16 +18,14 @@ import (
 	"github.com/qolzam/telar/apps/ai-engine/internal/generator"
 	"github.com/qolzam/telar/apps/ai-engine/internal/knowledge"
 	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/pgvector"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
 	"github.com/qolzam/telar/apps/ai-engine/internal/platform/weaviate"
+	"github.com/qolzam/telar/apps/ai-engine/internal/version"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/openai"
 )
 
-const (
-	serviceName    = "ai-engine"
-	serviceVersion = "v1.0.0"
-)
-
 func main() {
 	if err := godotenv.Load(); err != nil {
 		log.Printf("Warning: No .env file found or failed to load: %v", err)
@@ -89,98 +88,105 @@ func main() {
 		log.Fatalf("Invalid EMBEDDING_PROVIDER specified: %s (supported: ollama, openai, groq, openrouter)", embeddingProvider)
 	}
 
-	var completionClient llms.Model
-	completionProvider := cfg.LLM.CompletionProvider
-	if completionProvider == "" {
-		// fall back to legacy provider field for backward compatibility
-		completionProvider = cfg.LLM.Provider
+	switch cfg.Cache.Backend {
+	case "memory", "redis":
+		var store llm.EmbeddingCacheStore = llm.NewMemoryEmbeddingCache(cfg.Cache.MaxEntries)
+		if cfg.Cache.Backend == "redis" {
+			redisCache, err := llm.NewRedisEmbeddingCache(cfg.Cache.RedisURL)
+			if err != nil {
+				log.Fatalf("Failed to create Redis embedding cache: %v", err)
+			}
+			defer redisCache.Close()
+			store = redisCache
+		}
+		// Embeddings from another provider or model live under another namespace
+		namespace := fmt.Sprintf("%s/%s/%s", embeddingProvider, cfg.LLM.EmbeddingModelName(), cfg.Cache.Version)
+		embeddingClient = llm.NewCachedEmbeddingClient(embeddingClient, store, llm.EmbeddingCacheConfig{
+			Namespace: namespace,
+			TTL:       cfg.Cache.TTL,
+		})
+		log.Printf("✓ Embedding cache: %s (ttl: %s, version: %s)", cfg.Cache.Backend, cfg.Cache.TTL, cfg.Cache.Version)
+	default:
+		log.Printf("Embedding cache disabled")
 	}
 
-	log.Printf("Initializing completion client with provider: %s", completionProvider)
-	switch completionProvider {
-	case "openai":
-		apiKey := cfg.LLM.OpenAIAPIKey
-		baseURL := cfg.LLM.OpenAIBaseURL
-		model := cfg.LLM.OpenAIModel
-		
-		llmClient, err := openai.New(
-			openai.WithToken(apiKey),
-			openai.WithBaseURL(baseURL),
-			openai.WithModel(model),
-		)
+	var usageStore llm.UsageStore = llm.NewMemoryUsageStore()
+	if cfg.Usage.Store == "redis" {
+		redisUsage, err := llm.NewRedisUsageStore(cfg.Usage.RedisURL)
 		if err != nil {
-			log.Fatalf("Failed to create OpenAI client: %v", err)
+			log.Fatalf("Failed to create Redis usage store: %v", err)
 		}
-		completionClient = llmClient
-		log.Printf("✓ Completion provider: OpenAI (base: %s, model: %s)", baseURL, model)
-	case "openrouter":
-		apiKey := cfg.LLM.OpenAIAPIKey
-		baseURL := "https://openrouter.ai/api/v1"
-		model := cfg.LLM.OpenAIModel
-		
-		if cfg.LLM.OpenAIBaseURL != "https://api.openai.com/v1" {
-			baseURL = cfg.LLM.OpenAIBaseURL
-		}
-		
-		llmClient, err := openai.New(
-			openai.WithToken(apiKey),
-			openai.WithBaseURL(baseURL),
-			openai.WithModel(model),
-		)
+		defer redisUsage.Close()
+		usageStore = redisUsage
+	}
+	usageTracker := llm.NewUsageTracker(usageStore, llm.Budgets{
+		Monthly:     cfg.Usage.MonthlyBudget,
+		Community:   cfg.Usage.CommunityBudget,
+		Communities: cfg.Usage.CommunityBudgets,
+		Action:      cfg.Usage.BudgetAction,
+	}, cfg.Usage.RecentRequests)
+
+	completionProvider := cfg.LLM.CompletionProviderName()
+	retryPolicy := llm.RetryPolicy{
+		MaxAttempts:      cfg.Failover.MaxAttempts,
+		BaseDelay:        cfg.Failover.BaseDelay,
+		MaxDelay:         cfg.Failover.MaxDelay,
+		Timeout:          cfg.Failover.RequestTimeout,
+		BreakerThreshold: cfg.Failover.BreakerThreshold,
+		BreakerCooldown:  cfg.Failover.BreakerCooldown,
+	}
+	providers := make([]llm.CompletionProvider, 0, 1+len(cfg.Failover.FallbackProviders))
+	for _, name := range append([]string{completionProvider}, cfg.Failover.FallbackProviders...) {
+		log.Printf("Initializing completion client with provider: %s", name)
+		model, err := newCompletionModel(cfg, name)
 		if err != nil {
-			log.Fatalf("Failed to create OpenRouter client: %v", err)
+			log.Fatalf("Failed to create %s completion client: %v", name, err)
 		}
-		completionClient = llmClient
-		log.Printf("✓ Completion provider: OpenRouter (base: %s, model: %s)", baseURL, model)
-	case "groq":
-		groqClient, err := llm.NewGroqClient(llm.GroqConfig{
-			APIKey:          cfg.LLM.GroqAPIKey,
-			CompletionModel: cfg.LLM.GroqModel,
-		})
+		providers = append(providers, llm.CompletionProvider{Name: name, Model: llm.NewMeteredModel(name, model, usageTracker)})
+	}
+	var downgradeModel llms.Model
+	if cfg.Usage.BudgetAction == llm.BudgetDowngrade {
+		name := cfg.Usage.DowngradeProvider
+		model, err := newCompletionModel(cfg, name)
 		if err != nil {
-			log.Fatalf("Failed to create Groq completion client: %v", err)
+			log.Fatalf("Failed to create %s downgrade completion client: %v", name, err)
 		}
-		completionClient = llm.NewGroqLangChainAdapter(groqClient)
-		log.Printf("✓ Completion provider: Groq (model: %s)", cfg.LLM.GroqModel)
-	case "ollama":
-		ollamaClient := llm.NewOllamaClient(llm.OllamaConfig{
-			BaseURL:         cfg.LLM.OllamaBaseURL,
-			EmbeddingModel:  cfg.LLM.EmbeddingModel,
-			CompletionModel: cfg.LLM.CompletionModel,
-		})
-		completionClient = llm.NewOllamaLangChainAdapter(ollamaClient)
-		log.Printf("✓ Completion provider: Ollama (base: %s, model: %s)", cfg.LLM.OllamaBaseURL, cfg.LLM.CompletionModel)
-	default:
-		log.Fatalf("Invalid COMPLETION_PROVIDER specified: %s (supported: openai, openrouter, ollama, groq)", completionProvider)
+		downgradeModel = llm.NewFailoverModel([]llm.CompletionProvider{{Name: name, Model: llm.NewMeteredModel(name, model, usageTracker)}}, retryPolicy)
+	}
+	var completionClient llms.Model = llm.NewBudgetedModel(llm.NewFailoverModel(providers, retryPolicy), downgradeModel, usageTracker)
+	log.Printf("✓ Token usage: %s store (monthly budget: %d, over budget: %s)", cfg.Usage.Store, cfg.Usage.MonthlyBudget, cfg.Usage.BudgetAction)
+	if len(cfg.Failover.FallbackProviders) > 0 {
+		log.Printf("✓ Completion failover: %s → %s", completionProvider, strings.Join(cfg.Failover.FallbackProviders, " → "))
 	}
 
-	log.Printf("Initializing Weaviate client at: %s", cfg.Weaviate.URL)
-	weaviateClient, err := weaviate.NewClient(weaviate.Config{
-		URL:    cfg.Weaviate.URL,
-		APIKey: cfg.Weaviate.APIKey,
-	})
+	vectorStore, err := newVectorStore(cfg)
 	if err != nil {
-		log.Fatalf("Failed to create Weaviate client: %v", err)
+		log.Fatalf("Failed to create %s client: %v", cfg.Vector.StoreName(), err)
 	}
 
-	if err := weaviateClient.EnsureSchema(ctx); err != nil {
-		log.Printf("Warning: Failed to ensure Weaviate schema: %v", err)
+	if err := vectorStore.EnsureSchema(ctx); err != nil {
+		log.Printf("Warning: Failed to ensure %s schema: %v", vectorStore.Name(), err)
 	}
 
 	log.Printf("Initializing knowledge service...")
-	knowledgeService := knowledge.NewService(embeddingClient, completionClient, weaviateClient, knowledge.Config{
+	knowledgeService := knowledge.NewService(embeddingClient, completionClient, vectorStore, knowledge.Config{
 		EmbeddingModel: cfg.LLM.EmbeddingModel,
 	})
 	log.Println("✓ Knowledge service initialized")
 
-	log.Printf("Initializing generator service...")
-	generatorService := generator.NewService(completionClient, cfg.LLM.MaxConcurrent)
-	log.Printf("✓ Generator service initialized (max concurrent: %d)", cfg.LLM.MaxConcurrent)
-
 	log.Printf("Initializing analyzer service...")
 	analyzerService := analyzer.NewService(completionClient)
 	log.Printf("✓ Analyzer service initialized")
 
+	log.Printf("Initializing generator service...")
+	generatorService := generator.NewService(completionClient, cfg.LLM.MaxConcurrent)
+	generatorService.SetOutputGuard(generator.NewGuard(analyzerService, generator.GuardConfig{
+		Policy:           cfg.Guard.Policy,
+		MaxRegenerations: cfg.Guard.MaxRegenerations,
+	}))
+	log.Printf("✓ Generator service initialized (max concurrent: %d, output guard: %s)", cfg.LLM.MaxConcurrent, cfg.Guard.Policy)
+
+
 	log.Println("Performing health checks...")
 	if err := knowledgeService.HealthCheck(ctx); err != nil {
 		log.Printf("Warning: Health check failed: %v", err)
@@ -189,13 +195,13 @@ func main() {
 		log.Println("All health checks passed")
 	}
 
-	app := api.Router(knowledgeService, generatorService, analyzerService, cfg)
+	app := api.Router(knowledgeService, generatorService, analyzerService, usageTracker, cfg)
 
 	go func() {
 		addr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
-		log.Printf("Starting %s %s on %s", serviceName, serviceVersion, addr)
+		log.Printf("Starting %s %s (%s) on %s", version.ServiceName, version.Version, version.Revision(), addr)
 		log.Printf("Architecture: Fully Configurable (Embedding: %s, Completion: %s)", embeddingProvider, completionProvider)
-		log.Printf("Weaviate URL: %s", cfg.Weaviate.URL)
+		log.Printf("Vector store: %s", vectorStore.Name())
 		
 		if err := app.Listen(addr); err != nil {
 			log.Fatalf("Failed to start server: %v", err)
@@ -219,3 +225,84 @@ func main() {
 
 	log.Println("Server stopped")
 }
+
+// newVectorStore creates the client of the configured vector store
+func newVectorStore(cfg *config.Config) (vectorstore.Store, error) {
+	switch cfg.Vector.StoreName() {
+	case "pgvector":
+		log.Printf("Initializing pgvector store in table %s (%d dimensions, %s index)", cfg.PGVector.Table, cfg.PGVector.Dimensions, cfg.PGVector.Index)
+		return pgvector.NewClient(pgvector.Config{
+			DSN:        cfg.PGVector.DSN,
+			Table:      cfg.PGVector.Table,
+			Dimensions: cfg.PGVector.Dimensions,
+			Index:      cfg.PGVector.Index,
+			Lists:      cfg.PGVector.Lists,
+		})
+	default:
+		log.Printf("Initializing Weaviate client at: %s", cfg.Weaviate.URL)
+		return weaviate.NewClient(weaviate.Config{
+			URL:    cfg.Weaviate.URL,
+			APIKey: cfg.Weaviate.APIKey,
+		})
+	}
+}
+
+// newCompletionModel creates the completion client of one provider
+func newCompletionModel(cfg *config.Config, provider string) (llms.Model, error) {
+	switch provider {
+	case "openai":
+		apiKey := cfg.LLM.OpenAIAPIKey
+		baseURL := cfg.LLM.OpenAIBaseURL
+		model := cfg.LLM.OpenAIModel
+
+		llmClient, err := openai.New(
+			openai.WithToken(apiKey),
+			openai.WithBaseURL(baseURL),
+			openai.WithModel(model),
+		)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("✓ Completion provider: OpenAI (base: %s, model: %s)", baseURL, model)
+		return llmClient, nil
+	case "openrouter":
+		apiKey := cfg.LLM.OpenAIAPIKey
+		baseURL := "https://openrouter.ai/api/v1"
+		model := cfg.LLM.OpenAIModel
+
+		if cfg.LLM.OpenAIBaseURL != "https://api.openai.com/v1" {
+			baseURL = cfg.LLM.OpenAIBaseURL
+		}
+
+		llmClient, err := openai.New(
+			openai.WithToken(apiKey),
+			openai.WithBaseURL(baseURL),
+			openai.WithModel(model),
+		)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("✓ Completion provider: OpenRouter (base: %s, model: %s)", baseURL, model)
+		return llmClient, nil
+	case "groq":
+		groqClient, err := llm.NewGroqClient(llm.GroqConfig{
+			APIKey:          cfg.LLM.GroqAPIKey,
+			CompletionModel: cfg.LLM.GroqModel,
+		})
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("✓ Completion provider: Groq (model: %s)", cfg.LLM.GroqModel)
+		return llm.NewGroqLangChainAdapter(groqClient), nil
+	case "ollama":
+		ollamaClient := llm.NewOllamaClient(llm.OllamaConfig{
+			BaseURL:         cfg.LLM.OllamaBaseURL,
+			EmbeddingModel:  cfg.LLM.EmbeddingModel,
+			CompletionModel: cfg.LLM.CompletionModel,
+		})
+		log.Printf("✓ Completion provider: Ollama (base: %s, model: %s)", cfg.LLM.OllamaBaseURL, cfg.LLM.CompletionModel)
+		return llm.NewOllamaLangChainAdapter(ollamaClient), nil
+	default:
+		return nil, fmt.Errorf("invalid completion provider %s (supported: openai, openrouter, ollama, groq)", provider)
+	}
+}
@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+)
+
+// ModerationFlagThreshold is the toxicity or spam score at or above which content is flagged
+const ModerationFlagThreshold = 0.7
+
+// ModerationRequest is a request to score a post or comment before it reaches moderators
+type ModerationRequest struct {
+	Content string `json:"content"`
+	// Kind is "post" or "comment"; it tells the model how much context to expect
+	Kind string `json:"kind,omitempty"`
+}
+
+// ModerationResult holds the toxicity and spam scores of a piece of content, each
+// between 0 and 1
+type ModerationResult struct {
+	Toxicity  float64 `json:"toxicity"`
+	Spam      float64 `json:"spam"`
+	Flagged   bool    `json:"flagged"`
+	Reason    string  `json:"reason,omitempty"`
+	Timestamp string  `json:"timestamp"`
+}
+
+var moderationPrompt = prompts.NewPromptTemplate(
+	`You are a content moderation classifier for a social network. Score the following {{.kind}} for toxicity and spam.
+
+Content:
+"""
+{{.content}}
+"""
+
+Toxicity covers hate speech, harassment, insults and threats. Spam covers advertising, scams, link farming and repeated low-effort text.
+
+You MUST respond with ONLY a valid JSON object in this exact format, with no additional text:
+{
+  "toxicity": 0.0-1.0,
+  "spam": 0.0-1.0,
+  "reason": "one short sentence on the highest score, empty string if both are low"
+}`,
+	[]string{"kind", "content"},
+)
+
+// ScoreModeration scores content for toxicity and spam. Content is flagged when either
+// score reaches ModerationFlagThreshold; the model's scores are clamped to [0, 1].
+func (s *Service) ScoreModeration(ctx context.Context, req ModerationRequest) (*ModerationResult, error) {
+	scoreCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+
+	kind := req.Kind
+	if kind != "post" && kind != "comment" {
+		kind = "post"
+	}
+	formattedPrompt, err := moderationPrompt.Format(map[string]any{
+		"kind":    kind,
+		"content": req.Content,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to format moderation prompt: %w", err)
+	}
+
+	response, err := llms.GenerateFromSinglePrompt(scoreCtx, s.compClient, formattedPrompt)
+	if err != nil {
+		return nil, fmt.Errorf("llm moderation scoring failed: %w", err)
+	}
+
+	var result ModerationResult
+	if err := decodeJSONResponse(response, &result); err != nil {
+		log.Printf("Failed to parse moderation scores as JSON. Raw response: %s", response)
+		return nil, fmt.Errorf("failed to parse moderation scores: %w", err)
+	}
+	result.Toxicity = clampScore(result.Toxicity)
+	result.Spam = clampScore(result.Spam)
+	result.Flagged = result.Toxicity >= ModerationFlagThreshold || result.Spam >= ModerationFlagThreshold
+	if !result.Flagged {
+		result.Reason = ""
+	}
+	result.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	return &result, nil
+}
+
+// decodeJSONResponse unmarshals an LLM response, dropping the markdown code fence some
+// models wrap JSON in
+func decodeJSONResponse(response string, out interface{}) error {
+	cleaned := strings.TrimSpace(response)
+	cleaned = strings.TrimPrefix(cleaned, "```json")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSuffix(cleaned, "```")
+	return json.Unmarshal([]byte(strings.TrimSpace(cleaned)), out)
+}
+
+func clampScore(score float64) float64 {
+	if score < 0 {
+		return 0
+	}
+	if score > 1 {
+		return 1
+	}
+	return score
+}
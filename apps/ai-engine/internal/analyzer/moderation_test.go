@@ -0,0 +1,116 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// fakeModel answers every prompt with a fixed response and remembers the prompt
+type fakeModel struct {
+	response string
+	err      error
+	prompt   string
+}
+
+func (m *fakeModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	for _, message := range messages {
+		for _, part := range message.Parts {
+			if text, ok := part.(llms.TextContent); ok {
+				m.prompt += text.Text
+			}
+		}
+	}
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.response}}}, nil
+}
+
+func (m *fakeModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func TestScoreModeration_FlagsHighScores(t *testing.T) {
+	model := &fakeModel{response: "```json\n{\"toxicity\": 0.92, \"spam\": 0.1, \"reason\": \"Insults another user\"}\n```"}
+	service := NewService(model)
+
+	result, err := service.ScoreModeration(context.Background(), ModerationRequest{Content: "you are an idiot", Kind: "comment"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Flagged {
+		t.Error("Expected content to be flagged")
+	}
+	if result.Toxicity != 0.92 || result.Spam != 0.1 {
+		t.Errorf("Unexpected scores: toxicity %v, spam %v", result.Toxicity, result.Spam)
+	}
+	if result.Reason != "Insults another user" {
+		t.Errorf("Unexpected reason %q", result.Reason)
+	}
+	if !strings.Contains(model.prompt, "comment") || !strings.Contains(model.prompt, "you are an idiot") {
+		t.Errorf("Prompt does not carry the kind and content: %s", model.prompt)
+	}
+}
+
+func TestScoreModeration_ClampsAndClearsReason(t *testing.T) {
+	service := NewService(&fakeModel{response: `{"toxicity": -0.5, "spam": 0.3, "reason": "Mildly promotional"}`})
+
+	result, err := service.ScoreModeration(context.Background(), ModerationRequest{Content: "check my blog"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Flagged {
+		t.Error("Expected content not to be flagged")
+	}
+	if result.Toxicity != 0 {
+		t.Errorf("Expected toxicity clamped to 0, got %v", result.Toxicity)
+	}
+	if result.Reason != "" {
+		t.Errorf("Expected no reason for unflagged content, got %q", result.Reason)
+	}
+}
+
+func TestScoreModeration_Errors(t *testing.T) {
+	_, err := NewService(&fakeModel{response: "I cannot help with that"}).ScoreModeration(context.Background(), ModerationRequest{Content: "text"})
+	if err == nil || !strings.Contains(err.Error(), "failed to parse moderation scores") {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+
+	_, err = NewService(&fakeModel{err: errors.New("provider down")}).ScoreModeration(context.Background(), ModerationRequest{Content: "text"})
+	if err == nil || !strings.Contains(err.Error(), "provider down") {
+		t.Errorf("Expected provider error, got %v", err)
+	}
+}
+
+func TestClientScoreModeration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/analyze/moderation" {
+			t.Errorf("Expected path /api/v1/analyze/moderation, got %s", r.URL.Path)
+		}
+		var req ModerationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if req.Content != "buy now" || req.Kind != "post" {
+			t.Errorf("Unexpected request %+v", req)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(ModerationResult{Spam: 0.95, Flagged: true, Reason: "Advertising"})
+	}))
+	defer server.Close()
+
+	result, err := NewClient(ClientConfig{BaseURL: server.URL}).ScoreModeration(context.Background(), ModerationRequest{Content: "buy now", Kind: "post"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !result.Flagged || result.Spam != 0.95 {
+		t.Errorf("Unexpected result %+v", result)
+	}
+}
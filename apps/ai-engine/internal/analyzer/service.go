@@ -2,10 +2,8 @@ package analyzer
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/tmc/langchaingo/llms"
@@ -97,17 +95,9 @@ Flag the content (is_flagged: true) if ANY score exceeds 0.7 or if you have high
 		return nil, fmt.Errorf("llm analysis failed: %w", err)
 	}
 
-	// Parse the JSON response
+	// Parse the JSON response; some LLMs may add markdown code blocks
 	var result AnalysisResult
-	
-	// Clean the response - some LLMs may add markdown code blocks
-	cleanedResponse := strings.TrimSpace(response)
-	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```json")
-	cleanedResponse = strings.TrimPrefix(cleanedResponse, "```")
-	cleanedResponse = strings.TrimSuffix(cleanedResponse, "```")
-	cleanedResponse = strings.TrimSpace(cleanedResponse)
-
-	if err := json.Unmarshal([]byte(cleanedResponse), &result); err != nil {
+	if err := decodeJSONResponse(response, &result); err != nil {
 		log.Printf("Failed to parse LLM response as JSON. Raw response: %s", response)
 		return nil, fmt.Errorf("failed to parse analysis result: %w. Raw response: %s", err, response)
 	}
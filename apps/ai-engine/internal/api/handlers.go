@@ -1,10 +1,14 @@
 package api
 
 import (
+	"context"
+	"errors"
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -12,27 +16,35 @@ import (
 	"github.com/qolzam/telar/apps/ai-engine/internal/config"
 	"github.com/qolzam/telar/apps/ai-engine/internal/generator"
 	"github.com/qolzam/telar/apps/ai-engine/internal/knowledge"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+	"github.com/qolzam/telar/apps/ai-engine/internal/version"
 )
 
+// systemInfoTimeout bounds the dependency lookups of a system info request
+const systemInfoTimeout = 3 * time.Second
+
 // Handler contains HTTP handlers for AI Engine endpoints
 type Handler struct {
 	knowledgeService *knowledge.Service
 	generatorService *generator.Service
 	analyzerService  *analyzer.Service
+	usageTracker     *llm.UsageTracker
 	config           *config.Config
 }
 
 // NewHandler creates a new handler instance
-func NewHandler(knowledgeService *knowledge.Service, generatorService *generator.Service, analyzerService *analyzer.Service, config *config.Config) *Handler {
+func NewHandler(knowledgeService *knowledge.Service, generatorService *generator.Service, analyzerService *analyzer.Service, usageTracker *llm.UsageTracker, config *config.Config) *Handler {
 	return &Handler{
 		knowledgeService: knowledgeService,
 		generatorService: generatorService,
 		analyzerService:  analyzerService,
+		usageTracker:     usageTracker,
 		config:           config,
 	}
 }
 
 type IngestRequest struct {
+	ID       string            `json:"id,omitempty"` // Optional UUID; re-ingesting the same ID replaces the document
 	Text     string            `json:"text" binding:"required"`
 	Metadata map[string]string `json:"metadata,omitempty"`
 }
@@ -47,6 +59,7 @@ type QueryRequest struct {
 	Question string            `json:"question" binding:"required"`
 	Limit    int               `json:"limit,omitempty"`
 	Context  map[string]string `json:"context,omitempty"`
+	Stream   bool              `json:"stream,omitempty"` // Answer with Server-Sent Events
 }
 
 type QueryResponse struct {
@@ -62,8 +75,10 @@ type SourceChunk struct {
 }
 
 type HealthResponse struct {
-	Status   string            `json:"status"`
-	Services map[string]string `json:"services"`
+	Status              string                   `json:"status"`
+	Services            map[string]string        `json:"services"`
+	EmbeddingCache      *llm.EmbeddingCacheStats `json:"embedding_cache,omitempty"`
+	CompletionProviders []llm.ProviderState      `json:"completion_providers,omitempty"`
 }
 
 type StatusResponse struct {
@@ -72,6 +87,32 @@ type StatusResponse struct {
 	CompletionProvider string `json:"completion_provider"`
 }
 
+// SystemInfoResponse describes the running build and what it is connected to
+type SystemInfoResponse struct {
+	Service       string         `json:"service"`
+	Version       string         `json:"version"`
+	GitSHA        string         `json:"git_sha,omitempty"`
+	GoVersion     string         `json:"go_version"`
+	Completion    ProviderInfo   `json:"completion"`
+	Embedding     ProviderInfo   `json:"embedding"`
+	VectorStore   DependencyInfo `json:"vector_store"`
+	MaxConcurrent int            `json:"max_concurrent"`
+}
+
+// ProviderInfo names an LLM provider and the model used with it
+type ProviderInfo struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model,omitempty"`
+}
+
+// DependencyInfo is the version of a connected service, or why it could not be read
+type DependencyInfo struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // "up" or "down"
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
 // GenerateRequest represents a request to generate conversation starters
 type GenerateRequest struct {
 	Topic string `json:"topic" binding:"required"`
@@ -107,6 +148,16 @@ func (h *Handler) Ingest(c *fiber.Ctx) error {
 	}
 
 	docID := uuid.New().String()
+	if req.ID != "" {
+		parsed, err := uuid.Parse(req.ID)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":   "Invalid document id",
+				"details": "The 'id' field must be a UUID",
+			})
+		}
+		docID = parsed.String()
+	}
 
 	docReq := &knowledge.DocumentRequest{
 		ID:       docID,
@@ -155,6 +206,16 @@ func (h *Handler) Query(c *fiber.Ctx) error {
 		Context: req.Context,
 	}
 
+	if streamRequested(c, req.Stream) {
+		return streamSSE(c, func(ctx context.Context, onChunk func(ctx context.Context, chunk []byte) error) (interface{}, error) {
+			result, err := h.knowledgeService.QueryKnowledgeStream(ctx, queryReq, onChunk)
+			if err != nil {
+				return nil, err
+			}
+			return newQueryResponse(result), nil
+		})
+	}
+
 	result, err := h.knowledgeService.QueryKnowledge(c.Context(), queryReq)
 	if err != nil {
 		log.Printf("Failed to query knowledge: %v", err)
@@ -173,6 +234,11 @@ func (h *Handler) Query(c *fiber.Ctx) error {
 		})
 	}
 
+	return c.JSON(newQueryResponse(result))
+}
+
+// newQueryResponse is the API form of a knowledge answer and its sources
+func newQueryResponse(result *knowledge.QueryResponse) QueryResponse {
 	var sources []SourceChunk
 	for _, source := range result.Sources {
 		sources = append(sources, SourceChunk{
@@ -183,12 +249,10 @@ func (h *Handler) Query(c *fiber.Ctx) error {
 		})
 	}
 
-	response := QueryResponse{
+	return QueryResponse{
 		Answer:  result.Answer,
 		Sources: sources,
 	}
-
-	return c.JSON(response)
 }
 
 // GenerateConversationStarters creates engaging prompts for a community.
@@ -196,15 +260,26 @@ func (h *Handler) GenerateConversationStarters(c *fiber.Ctx) error {
 	var req struct {
 		CommunityTopic string `json:"community_topic"`
 		Style          string `json:"style"`
+		Stream         bool   `json:"stream"` // Stream the model output with Server-Sent Events
 	}
 	if err := c.BodyParser(&req); err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "invalid request body"})
 	}
 
+	if streamRequested(c, req.Stream) {
+		return streamSSE(c, func(ctx context.Context, onChunk func(ctx context.Context, chunk []byte) error) (interface{}, error) {
+			return h.generatorService.GenerateConversationStartersStream(ctx, req.CommunityTopic, req.Style, onChunk)
+		})
+	}
+
 	starters, err := h.generatorService.GenerateConversationStarters(c.Context(), req.CommunityTopic, req.Style)
 	if err != nil {
 		log.Printf("Generator service error: %v", err)
 		
+		if errors.Is(err, generator.ErrContentBlocked) {
+			return contentBlocked(c, err)
+		}
+		
 		if strings.Contains(err.Error(), "server is currently processing too many requests") {
 			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
 				"error": "server is currently processing too many requests",
@@ -219,6 +294,57 @@ func (h *Handler) GenerateConversationStarters(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(starters)
 }
 
+// GenerateThreadSummary digests a post and its comments into a short summary and key points
+func (h *Handler) GenerateThreadSummary(c *fiber.Ctx) error {
+	var req generator.ThreadSummaryRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(req.Post.Text) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Post text is required",
+			"details": "The 'post.text' field cannot be empty",
+		})
+	}
+
+	summary, err := h.generatorService.SummarizeThread(c.Context(), req)
+	if err != nil {
+		log.Printf("Thread summary failed: %v", err)
+
+		if errors.Is(err, generator.ErrContentBlocked) {
+			return contentBlocked(c, err)
+		}
+
+		if strings.Contains(err.Error(), "server is currently processing too many requests") {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error":       "server is currently processing too many requests",
+				"details":     "Please try again in a moment. The server is limiting concurrent requests to prevent overload.",
+				"retry_after": "5 seconds",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to summarize thread",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(summary)
+}
+
+// contentBlocked answers a generation whose output moderation blocked
+func contentBlocked(c *fiber.Ctx, err error) error {
+	return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+		"error":   "Generated content was blocked by moderation",
+		"details": err.Error(),
+		"code":    "CONTENT_BLOCKED",
+	})
+}
+
 // GetConcurrentStatus returns the current concurrent request status
 func (h *Handler) GetConcurrentStatus(c *fiber.Ctx) error {
 	status := h.generatorService.GetConcurrentStatus()
@@ -265,6 +391,34 @@ func (h *Handler) GetModelConfig(c *fiber.Ctx) error {
 	})
 }
 
+// GetSystemInfo reports the build, the configured providers and models, and the
+// version of the vector store
+func (h *Handler) GetSystemInfo(c *fiber.Ctx) error {
+	llmConfig := h.config.LLM
+	info := SystemInfoResponse{
+		Service:       version.ServiceName,
+		Version:       version.Version,
+		GitSHA:        version.Revision(),
+		GoVersion:     runtime.Version(),
+		Completion:    ProviderInfo{Provider: llmConfig.CompletionProviderName(), Model: llmConfig.CompletionModelName()},
+		Embedding:     ProviderInfo{Provider: llmConfig.EmbeddingProviderName(), Model: llmConfig.EmbeddingModel},
+		VectorStore:   DependencyInfo{Name: h.knowledgeService.VectorStoreName(), Status: "up"},
+		MaxConcurrent: llmConfig.MaxConcurrent,
+	}
+
+	ctx, cancel := context.WithTimeout(c.Context(), systemInfoTimeout)
+	defer cancel()
+	storeVersion, err := h.knowledgeService.VectorStoreVersion(ctx)
+	if err != nil {
+		log.Printf("Failed to read vector store version: %v", err)
+		info.VectorStore.Status = "down"
+		info.VectorStore.Error = err.Error()
+	}
+	info.VectorStore.Version = storeVersion
+
+	return c.JSON(info)
+}
+
 // Health returns service health status and dependency checks
 func (h *Handler) Health(c *fiber.Ctx) error {
 	services := map[string]string{
@@ -277,23 +431,53 @@ func (h *Handler) Health(c *fiber.Ctx) error {
 		services["details"] = err.Error()
 
 		return c.Status(fiber.StatusServiceUnavailable).JSON(HealthResponse{
-			Status:   "unhealthy",
-			Services: services,
+			Status:              "unhealthy",
+			Services:            services,
+			EmbeddingCache:      h.embeddingCacheStats(),
+			CompletionProviders: h.completionProviderStates(),
 		})
 	}
 
 	services["knowledge"] = "healthy"
 	services["llm"] = "healthy"
-	services["weaviate"] = "healthy"
+	services[h.knowledgeService.VectorStoreName()] = "healthy"
 
 	response := HealthResponse{
-		Status:   "healthy",
-		Services: services,
+		Status:              "healthy",
+		Services:            services,
+		EmbeddingCache:      h.embeddingCacheStats(),
+		CompletionProviders: h.completionProviderStates(),
 	}
 
 	return c.JSON(response)
 }
 
+func (h *Handler) embeddingCacheStats() *llm.EmbeddingCacheStats {
+	stats, ok := h.knowledgeService.EmbeddingCacheStats()
+	if !ok {
+		return nil
+	}
+	return &stats
+}
+
+func (h *Handler) completionProviderStates() []llm.ProviderState {
+	states, _ := h.knowledgeService.CompletionProviderStates()
+	return states
+}
+
+// FlushEmbeddingCache drops every cached embedding, for example after the embedding
+// model was replaced under the same name
+func (h *Handler) FlushEmbeddingCache(c *fiber.Ctx) error {
+	if err := h.knowledgeService.FlushEmbeddingCache(c.Context()); err != nil {
+		log.Printf("Failed to flush embedding cache: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to flush embedding cache",
+			"details": err.Error(),
+		})
+	}
+	return c.JSON(fiber.Map{"status": "flushed"})
+}
+
 // GetStatus returns the current configuration status
 func (h *Handler) GetStatus(c *fiber.Ctx) error {
 	embeddingProvider := os.Getenv("EMBEDDING_PROVIDER")
@@ -375,3 +559,186 @@ func (h *Handler) AnalyzeContent(c *fiber.Ctx) error {
 
 	return c.JSON(result)
 }
+
+// AnalyzeModeration scores a post or comment for toxicity and spam, for the API to
+// flag content into its moderation queue
+func (h *Handler) AnalyzeModeration(c *fiber.Ctx) error {
+	var req analyzer.ModerationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(req.Content) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Content is required",
+			"details": "The 'content' field cannot be empty",
+		})
+	}
+
+	result, err := h.analyzerService.ScoreModeration(c.Context(), req)
+	if err != nil {
+		log.Printf("Moderation scoring failed: %v", err)
+
+		if strings.Contains(err.Error(), "ollama service is not available") {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error":   "AI service temporarily unavailable",
+				"details": "Ollama LLM service is not running. Please ensure Ollama is started and accessible.",
+				"code":    "OLLAMA_UNAVAILABLE",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to score content",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(result)
+}
+
+// Similarity scores how semantically close a text is to each candidate text
+func (h *Handler) Similarity(c *fiber.Ctx) error {
+	var req knowledge.SimilarityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+	}
+
+	if strings.TrimSpace(req.Text) == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Text is required",
+			"details": "The 'text' field cannot be empty",
+		})
+	}
+
+	result, err := h.knowledgeService.Similarity(c.Context(), &req)
+	if err != nil {
+		log.Printf("Similarity scoring failed: %v", err)
+
+		if strings.Contains(err.Error(), "ollama service is not available") {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error":   "AI service temporarily unavailable",
+				"details": "Ollama LLM service is not running. Please ensure Ollama is started and accessible.",
+				"code":    "OLLAMA_UNAVAILABLE",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to score similarity",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(result)
+}
+
+// KnowledgeSearch returns the ingested posts closest in meaning to a query
+func (h *Handler) KnowledgeSearch(c *fiber.Ctx) error {
+	var req knowledge.SearchRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+	}
+
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Query is required",
+			"details": "The 'query' field cannot be empty",
+		})
+	}
+	if req.From > 0 && req.To > 0 && req.From >= req.To {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid date range",
+			"details": "'from' must be before 'to'",
+		})
+	}
+
+	result, err := h.knowledgeService.Search(c.Context(), &req)
+	if err != nil {
+		log.Printf("Knowledge search failed: %v", err)
+
+		if strings.Contains(err.Error(), "ollama service is not available") {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error":   "AI service temporarily unavailable",
+				"details": "Ollama LLM service is not running. Please ensure Ollama is started and accessible.",
+				"code":    "OLLAMA_UNAVAILABLE",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to search posts",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(result)
+}
+
+// IngestPost stores the current text of a post in the knowledge base, chunked and
+// embedded, replacing whatever an earlier version of the post left there
+func (h *Handler) IngestPost(c *fiber.Ctx) error {
+	postID, err := uuid.Parse(c.Params("postId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid post id",
+			"details": "The post id must be a UUID",
+		})
+	}
+
+	var req knowledge.PostIngestRequest
+	if err := c.BodyParser(&req); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid request payload",
+			"details": err.Error(),
+		})
+	}
+
+	result, err := h.knowledgeService.IngestPost(c.Context(), postID.String(), &req)
+	if err != nil {
+		log.Printf("Failed to ingest post %s: %v", postID, err)
+
+		if strings.Contains(err.Error(), "ollama service is not available") {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+				"error":   "AI service temporarily unavailable",
+				"details": "Ollama LLM service is not running. Please ensure Ollama is started and accessible.",
+				"code":    "OLLAMA_UNAVAILABLE",
+			})
+		}
+
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to ingest post",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(result)
+}
+
+// DeletePost removes every chunk of a post from the knowledge base
+func (h *Handler) DeletePost(c *fiber.Ctx) error {
+	postID, err := uuid.Parse(c.Params("postId"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid post id",
+			"details": "The post id must be a UUID",
+		})
+	}
+
+	if err := h.knowledgeService.DeletePost(c.Context(), postID.String()); err != nil {
+		log.Printf("Failed to delete post %s: %v", postID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to delete post",
+			"details": err.Error(),
+		})
+	}
+
+	return c.JSON(fiber.Map{"status": "deleted", "post_id": postID.String()})
+}
@@ -5,27 +5,35 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/qolzam/telar/apps/ai-engine/internal/analyzer"
 	"github.com/qolzam/telar/apps/ai-engine/internal/config"
 	"github.com/qolzam/telar/apps/ai-engine/internal/generator"
 	"github.com/qolzam/telar/apps/ai-engine/internal/knowledge"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+	"github.com/qolzam/telar/apps/ai-engine/internal/version"
 )
 
 // Router creates and configures the Fiber application with middleware and routes
-func Router(knowledgeService *knowledge.Service, generatorService *generator.Service, analyzerService *analyzer.Service, config *config.Config) *fiber.App {
-	handler := NewHandler(knowledgeService, generatorService, analyzerService, config)
+func Router(knowledgeService *knowledge.Service, generatorService *generator.Service, analyzerService *analyzer.Service, usageTracker *llm.UsageTracker, config *config.Config) *fiber.App {
+	handler := NewHandler(knowledgeService, generatorService, analyzerService, usageTracker, config)
 
 	app := fiber.New(fiber.Config{
-		AppName: "AI Engine v1.0.0",
+		AppName: "AI Engine " + version.Version,
 	})
 
-	app.Use(logger.New())
+	// Keep the X-Request-ID the API sent so both services' logs share it
+	app.Use(requestid.New())
+	app.Use(logger.New(logger.Config{
+		Format: "${time} ${locals:requestid} ${status} - ${latency} ${method} ${path}\n",
+	}))
 	app.Use(recover.New())
 	app.Use(cors.New(cors.Config{
 		AllowOrigins: "*",
 		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Content-Type,Authorization",
+		AllowHeaders: "Content-Type,Authorization,X-Request-ID," + requestTimeoutHeader + "," + communityHeader,
 	}))
+	app.Use(requestTimeout)
 
 	// API routes
 	app.Get("/health", handler.Health)
@@ -36,13 +44,26 @@ func Router(knowledgeService *knowledge.Service, generatorService *generator.Ser
 		Index: "index.html",
 	})
 
-	v1 := app.Group("/api/v1")
+	// Completions made outside /api/v1, like health checks, are counted but not budgeted
+	v1 := app.Group("/api/v1", usageScope)
+	budget := enforceBudget(usageTracker)
 	v1.Post("/ingest", handler.Ingest)
-	v1.Post("/query", handler.Query)
-	v1.Post("/generate/conversation-starters", handler.GenerateConversationStarters)
+	v1.Post("/query", budget, handler.Query)
+	v1.Post("/generate/conversation-starters", budget, handler.GenerateConversationStarters)
+	v1.Post("/generate/thread-summary", budget, handler.GenerateThreadSummary)
 	v1.Get("/concurrent-status", handler.GetConcurrentStatus)
 	v1.Get("/model-config", handler.GetModelConfig)
-	v1.Post("/analyze/content", handler.AnalyzeContent)
+	v1.Get("/system/info", handler.GetSystemInfo)
+	v1.Get("/usage", handler.GetUsage)
+	v1.Delete("/embedding-cache", handler.FlushEmbeddingCache)
+	v1.Post("/analyze/content", budget, handler.AnalyzeContent)
+	v1.Post("/analyze/moderation", budget, handler.AnalyzeModeration)
+	v1.Post("/similarity", handler.Similarity)
+	// Search only embeds, but it is the one user-facing AI feature, so a spent budget
+	// turns it away too and the API falls back to an empty result
+	v1.Post("/knowledge/search", budget, handler.KnowledgeSearch)
+	v1.Put("/knowledge/posts/:postId", handler.IngestPost)
+	v1.Delete("/knowledge/posts/:postId", handler.DeletePost)
 
 	return app
 }
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+)
+
+// Server-Sent Events of a streamed response
+const (
+	eventToken = "token" // {"text": "..."}: the next piece of the model output
+	eventDone  = "done"  // The same body the endpoint answers without streaming
+	eventError = "error" // {"error": "..."}: generation failed; the stream ends
+)
+
+// streamRequested reports whether the client asked for Server-Sent Events, with
+// "stream": true in the body or ?stream=true
+func streamRequested(c *fiber.Ctx, bodyFlag bool) bool {
+	return bodyFlag || c.QueryBool("stream")
+}
+
+// streamFunc produces a response, handing each piece of model output to onChunk
+type streamFunc func(ctx context.Context, onChunk func(ctx context.Context, chunk []byte) error) (interface{}, error)
+
+// streamSSE answers with an event stream: a token event per chunk run produces, then a
+// done event with its result, or an error event when it fails. The stream outlives the
+// handler, so run gets a context of its own, cancelled once the client goes away.
+func streamSSE(c *fiber.Ctx, run streamFunc) error {
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no") // Keep reverse proxies from holding the events back
+
+	timeout, hasTimeout := c.Locals(llm.RequestTimeoutKey).(time.Duration)
+	scope, hasScope := c.Locals(llm.UsageScopeKey).(llm.UsageScope)
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		if hasTimeout {
+			ctx = llm.WithRequestTimeout(ctx, timeout)
+		}
+		if hasScope {
+			ctx = llm.WithUsageScope(ctx, scope)
+		}
+
+		result, err := run(ctx, func(ctx context.Context, chunk []byte) error {
+			if err := writeEvent(w, eventToken, fiber.Map{"text": string(chunk)}); err != nil {
+				cancel()
+				return fmt.Errorf("client went away: %w", err)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("Streamed request failed: %v", err)
+			writeEvent(w, eventError, fiber.Map{"error": err.Error()})
+			return
+		}
+		writeEvent(w, eventDone, result)
+	})
+	return nil
+}
+
+// writeEvent writes one event with a JSON payload and flushes it to the client
+func writeEvent(w *bufio.Writer, event string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", event, err)
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload); err != nil {
+		return err
+	}
+	return w.Flush()
+}
@@ -0,0 +1,66 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func streamApp(run streamFunc) *fiber.App {
+	app := fiber.New()
+	app.Post("/stream", func(c *fiber.Ctx) error {
+		if !streamRequested(c, false) {
+			return c.JSON(fiber.Map{"buffered": true})
+		}
+		return streamSSE(c, run)
+	})
+	return app
+}
+
+func TestStreamSSE(t *testing.T) {
+	app := streamApp(func(ctx context.Context, onChunk func(ctx context.Context, chunk []byte) error) (interface{}, error) {
+		for _, chunk := range []string{"Hel", "lo"} {
+			if err := onChunk(ctx, []byte(chunk)); err != nil {
+				return nil, err
+			}
+		}
+		return fiber.Map{"answer": "Hello"}, nil
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/stream?stream=true", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "event: token\ndata: {\"text\":\"Hel\"}\n\n"+
+		"event: token\ndata: {\"text\":\"lo\"}\n\n"+
+		"event: done\ndata: {\"answer\":\"Hello\"}\n\n", string(body))
+
+	resp, err = app.Test(httptest.NewRequest("POST", "/stream", nil))
+	require.NoError(t, err)
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"buffered":true}`, string(body))
+}
+
+func TestStreamSSE_Error(t *testing.T) {
+	app := streamApp(func(ctx context.Context, onChunk func(ctx context.Context, chunk []byte) error) (interface{}, error) {
+		if err := onChunk(ctx, []byte("partial")); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("model crashed")
+	})
+
+	resp, err := app.Test(httptest.NewRequest("POST", "/stream?stream=true", nil))
+	require.NoError(t, err)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "event: token\ndata: {\"text\":\"partial\"}\n\n"+
+		"event: error\ndata: {\"error\":\"model crashed\"}\n\n", string(body))
+}
@@ -0,0 +1,30 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+)
+
+// requestTimeoutHeader overrides how long one completion call may take for a request,
+// as a Go duration such as "20s"
+const requestTimeoutHeader = "X-LLM-Timeout"
+
+// requestTimeout puts the timeout override of the request where the completion client
+// finds it through the request context
+func requestTimeout(c *fiber.Ctx) error {
+	header := c.Get(requestTimeoutHeader)
+	if header == "" {
+		return c.Next()
+	}
+	timeout, err := time.ParseDuration(header)
+	if err != nil || timeout <= 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":   "Invalid " + requestTimeoutHeader + " header",
+			"details": "The timeout must be a positive duration such as 20s",
+		})
+	}
+	c.Locals(llm.RequestTimeoutKey, timeout)
+	return c.Next()
+}
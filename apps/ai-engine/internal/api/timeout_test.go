@@ -0,0 +1,41 @@
+package api
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	app := fiber.New()
+	app.Use(requestTimeout)
+	app.Get("/", func(c *fiber.Ctx) error {
+		// Services derive their contexts from the request context
+		ctx, cancel := context.WithCancel(c.Context())
+		defer cancel()
+		timeout, _ := ctx.Value(llm.RequestTimeoutKey).(time.Duration)
+		return c.SendString(timeout.String())
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(requestTimeoutHeader, "20s")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "20s", string(body))
+
+	req = httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(requestTimeoutHeader, "soon")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusBadRequest, resp.StatusCode)
+}
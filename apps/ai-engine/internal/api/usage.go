@@ -0,0 +1,54 @@
+package api
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+)
+
+// communityHeader names the community a request's completions count against
+const communityHeader = "X-Community-ID"
+
+// usageScope puts who the request is made for where the usage tracker finds it through
+// the request context
+func usageScope(c *fiber.Ctx) error {
+	requestID, _ := c.Locals("requestid").(string)
+	c.Locals(llm.UsageScopeKey, llm.UsageScope{RequestID: requestID, Community: c.Get(communityHeader)})
+	return c.Next()
+}
+
+// enforceBudget turns completion requests away once a budget they count against is
+// spent, before a stream starts. Over-budget requests pass when they are downgraded.
+func enforceBudget(tracker *llm.UsageTracker) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if tracker == nil || tracker.Action() != llm.BudgetReject {
+			return c.Next()
+		}
+		if err := tracker.Check(c.Context()); err != nil {
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"error":   "Token budget exceeded",
+				"details": err.Error(),
+				"code":    "BUDGET_EXCEEDED",
+			})
+		}
+		return c.Next()
+	}
+}
+
+// GetUsage returns this month's token usage by community and provider, the state of
+// each budget and the latest completions
+func (h *Handler) GetUsage(c *fiber.Ctx) error {
+	if h.usageTracker == nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Usage tracking is disabled"})
+	}
+	report, err := h.usageTracker.Report(c.Context())
+	if err != nil {
+		log.Printf("Failed to report token usage: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error":   "Failed to read token usage",
+			"details": err.Error(),
+		})
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,35 @@
+package api
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnforceBudget(t *testing.T) {
+	tracker := llm.NewUsageTracker(llm.NewMemoryUsageStore(), llm.Budgets{Communities: map[string]int64{"c1": 5}}, 0)
+	tracker.Record(llm.WithUsageScope(context.Background(), llm.UsageScope{Community: "c1"}), "groq", llm.TokenUsage{TotalTokens: 5}, false)
+
+	app := fiber.New()
+	app.Use(usageScope)
+	app.Post("/", enforceBudget(tracker), func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	req := httptest.NewRequest("POST", "/", nil)
+	req.Header.Set(communityHeader, "c1")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+
+	req = httptest.NewRequest("POST", "/", nil)
+	req.Header.Set(communityHeader, "c2")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -11,7 +13,13 @@ import (
 type Config struct {
 	Server   ServerConfig   `json:"server"`
 	LLM      LLMConfig      `json:"llm"`
+	Vector   VectorConfig   `json:"vector"`
 	Weaviate WeaviateConfig `json:"weaviate"`
+	PGVector PGVectorConfig `json:"pgvector"`
+	Cache    CacheConfig    `json:"cache"`
+	Failover FailoverConfig `json:"failover"`
+	Usage    UsageConfig    `json:"usage"`
+	Guard    GuardConfig    `json:"guard"`
 }
 
 // ServerConfig contains HTTP server settings
@@ -38,12 +46,114 @@ type LLMConfig struct {
 	MaxConcurrent      int    `json:"max_concurrent,omitempty"`
 }
 
+// EmbeddingProviderName is the embedding provider in use, ollama unless configured
+func (c LLMConfig) EmbeddingProviderName() string {
+	if c.EmbeddingProvider == "" {
+		return "ollama"
+	}
+	return c.EmbeddingProvider
+}
+
+// EmbeddingModelName is the model the embedding provider embeds with
+func (c LLMConfig) EmbeddingModelName() string {
+	switch c.EmbeddingProviderName() {
+	case "openai":
+		return "text-embedding-3-small" // Fixed by the OpenAI embedder
+	case "openrouter":
+		return c.OpenAIModel
+	default:
+		return c.EmbeddingModel
+	}
+}
+
+// CompletionProviderName is the completion provider in use; the legacy provider field
+// stands in when it is not configured
+func (c LLMConfig) CompletionProviderName() string {
+	if c.CompletionProvider == "" {
+		return c.Provider
+	}
+	return c.CompletionProvider
+}
+
+// CompletionModelName is the model the completion provider is configured with
+func (c LLMConfig) CompletionModelName() string {
+	switch c.CompletionProviderName() {
+	case "openai", "openrouter":
+		return c.OpenAIModel
+	case "groq":
+		return c.GroqModel
+	default:
+		return c.CompletionModel
+	}
+}
+
+// VectorConfig selects the vector database the knowledge base is kept in
+type VectorConfig struct {
+	Store string `json:"store"` // "weaviate" or "pgvector"
+}
+
+// StoreName is the vector store in use, weaviate unless configured
+func (c VectorConfig) StoreName() string {
+	if c.Store == "" {
+		return "weaviate"
+	}
+	return c.Store
+}
+
 // WeaviateConfig contains vector database settings
 type WeaviateConfig struct {
 	URL    string `json:"url"`
 	APIKey string `json:"api_key,omitempty"`
 }
 
+// PGVectorConfig contains the settings of Postgres with the pgvector extension as the
+// vector database
+type PGVectorConfig struct {
+	DSN        string `json:"dsn,omitempty"`
+	Table      string `json:"table"`
+	Dimensions int    `json:"dimensions"` // Must match the embedding model
+	Index      string `json:"index"`      // "hnsw" or "ivfflat"
+	Lists      int    `json:"lists"`      // Clusters of an ivfflat index
+}
+
+// CacheConfig contains embedding cache settings
+type CacheConfig struct {
+	Backend    string        `json:"backend"` // "memory", "redis" or "none"
+	TTL        time.Duration `json:"ttl"`
+	MaxEntries int           `json:"max_entries,omitempty"` // Memory backend only
+	RedisURL   string        `json:"redis_url,omitempty"`
+	Version    string        `json:"version"` // Changing it invalidates every cached embedding
+}
+
+// FailoverConfig contains retry, fallback and circuit breaker settings of completions
+type FailoverConfig struct {
+	FallbackProviders []string      `json:"fallback_providers,omitempty"` // Tried in order after the completion provider
+	MaxAttempts       int           `json:"max_attempts"`                 // Per provider
+	BaseDelay         time.Duration `json:"base_delay"`
+	MaxDelay          time.Duration `json:"max_delay"`
+	RequestTimeout    time.Duration `json:"request_timeout"`
+	BreakerThreshold  int           `json:"breaker_threshold"`
+	BreakerCooldown   time.Duration `json:"breaker_cooldown"`
+}
+
+// UsageConfig contains token accounting and monthly budget settings
+type UsageConfig struct {
+	Store             string           `json:"store"` // "memory" or "redis"
+	RedisURL          string           `json:"redis_url,omitempty"`
+	MonthlyBudget     int64            `json:"monthly_budget"`              // Tokens of all completions; 0 for no limit
+	CommunityBudget   int64            `json:"community_budget"`            // Tokens of each community; 0 for no limit
+	CommunityBudgets  map[string]int64 `json:"community_budgets,omitempty"` // Overrides of single communities
+	BudgetAction      string           `json:"budget_action"`               // "reject" or "downgrade"
+	DowngradeProvider string           `json:"downgrade_provider,omitempty"`
+	RecentRequests    int              `json:"recent_requests"` // Completions listed by the usage endpoint
+}
+
+// GuardConfig contains moderation settings of generated content
+type GuardConfig struct {
+	Policy           string `json:"policy"` // "off", "warn", "block" or "regenerate"
+	MaxRegenerations int    `json:"max_regenerations"`
+}
+
 // Load reads configuration from environment variables with sensible defaults
 func Load() (*Config, error) {
 	viper.SetDefault("PORT", "8000")
@@ -60,10 +170,37 @@ func Load() (*Config, error) {
 	viper.SetDefault("OPENAI_BASE_URL", "https://api.openai.com/v1")
 	viper.SetDefault("OPENAI_MODEL", "gpt-3.5-turbo")
 	viper.SetDefault("MAX_CONCURRENT", "2")
+	viper.SetDefault("VECTOR_STORE", "weaviate")
 	viper.SetDefault("WEAVIATE_URL", "http://localhost:8080")
+	viper.SetDefault("PGVECTOR_TABLE", "ai_documents")
+	viper.SetDefault("PGVECTOR_DIMENSIONS", "768")
+	viper.SetDefault("PGVECTOR_INDEX", "hnsw")
+	viper.SetDefault("PGVECTOR_IVFFLAT_LISTS", "100")
+	viper.SetDefault("EMBEDDING_CACHE_BACKEND", "memory")
+	viper.SetDefault("EMBEDDING_CACHE_TTL", "168h")
+	viper.SetDefault("EMBEDDING_CACHE_MAX_ENTRIES", "10000")
+	viper.SetDefault("EMBEDDING_CACHE_VERSION", "1")
+	viper.SetDefault("LLM_RETRY_MAX_ATTEMPTS", "3")
+	viper.SetDefault("LLM_RETRY_BASE_DELAY", "500ms")
+	viper.SetDefault("LLM_RETRY_MAX_DELAY", "5s")
+	viper.SetDefault("LLM_REQUEST_TIMEOUT", "60s")
+	viper.SetDefault("LLM_BREAKER_THRESHOLD", "5")
+	viper.SetDefault("LLM_BREAKER_COOLDOWN", "30s")
+	viper.SetDefault("USAGE_STORE", "memory")
+	viper.SetDefault("USAGE_MONTHLY_TOKEN_BUDGET", "0")
+	viper.SetDefault("USAGE_COMMUNITY_TOKEN_BUDGET", "0")
+	viper.SetDefault("USAGE_BUDGET_ACTION", "reject")
+	viper.SetDefault("USAGE_RECENT_REQUESTS", "50")
+	viper.SetDefault("GENERATION_GUARD_POLICY", "off")
+	viper.SetDefault("GENERATION_GUARD_MAX_REGENERATIONS", "2")
 
 	viper.AutomaticEnv()
 
+	communityBudgets, err := parseBudgets(viper.GetString("USAGE_COMMUNITY_TOKEN_BUDGETS"))
+	if err != nil {
+		return nil, fmt.Errorf("configuration validation failed: USAGE_COMMUNITY_TOKEN_BUDGETS: %w", err)
+	}
+
 	config := &Config{
 		Server: ServerConfig{
 			Port:         viper.GetString("PORT"),
@@ -85,10 +222,50 @@ func Load() (*Config, error) {
 			CompletionModel:    viper.GetString("COMPLETION_MODEL"),
 			MaxConcurrent:      viper.GetInt("MAX_CONCURRENT"),
 		},
+		Vector: VectorConfig{
+			Store: viper.GetString("VECTOR_STORE"),
+		},
 		Weaviate: WeaviateConfig{
 			URL:    viper.GetString("WEAVIATE_URL"),
 			APIKey: viper.GetString("WEAVIATE_API_KEY"),
 		},
+		PGVector: PGVectorConfig{
+			DSN:        viper.GetString("POSTGRES_DSN"),
+			Table:      viper.GetString("PGVECTOR_TABLE"),
+			Dimensions: viper.GetInt("PGVECTOR_DIMENSIONS"),
+			Index:      viper.GetString("PGVECTOR_INDEX"),
+			Lists:      viper.GetInt("PGVECTOR_IVFFLAT_LISTS"),
+		},
+		Cache: CacheConfig{
+			Backend:    viper.GetString("EMBEDDING_CACHE_BACKEND"),
+			TTL:        viper.GetDuration("EMBEDDING_CACHE_TTL"),
+			MaxEntries: viper.GetInt("EMBEDDING_CACHE_MAX_ENTRIES"),
+			RedisURL:   viper.GetString("EMBEDDING_CACHE_REDIS_URL"),
+			Version:    viper.GetString("EMBEDDING_CACHE_VERSION"),
+		},
+		Failover: FailoverConfig{
+			FallbackProviders: splitList(viper.GetString("COMPLETION_FALLBACK_PROVIDERS")),
+			MaxAttempts:       viper.GetInt("LLM_RETRY_MAX_ATTEMPTS"),
+			BaseDelay:         viper.GetDuration("LLM_RETRY_BASE_DELAY"),
+			MaxDelay:          viper.GetDuration("LLM_RETRY_MAX_DELAY"),
+			RequestTimeout:    viper.GetDuration("LLM_REQUEST_TIMEOUT"),
+			BreakerThreshold:  viper.GetInt("LLM_BREAKER_THRESHOLD"),
+			BreakerCooldown:   viper.GetDuration("LLM_BREAKER_COOLDOWN"),
+		},
+		Usage: UsageConfig{
+			Store:             viper.GetString("USAGE_STORE"),
+			RedisURL:          viper.GetString("USAGE_REDIS_URL"),
+			MonthlyBudget:     viper.GetInt64("USAGE_MONTHLY_TOKEN_BUDGET"),
+			CommunityBudget:   viper.GetInt64("USAGE_COMMUNITY_TOKEN_BUDGET"),
+			CommunityBudgets:  communityBudgets,
+			BudgetAction:      viper.GetString("USAGE_BUDGET_ACTION"),
+			DowngradeProvider: viper.GetString("USAGE_DOWNGRADE_PROVIDER"),
+			RecentRequests:    viper.GetInt("USAGE_RECENT_REQUESTS"),
+		},
+		Guard: GuardConfig{
+			Policy:           viper.GetString("GENERATION_GUARD_POLICY"),
+			MaxRegenerations: viper.GetInt("GENERATION_GUARD_MAX_REGENERATIONS"),
+		},
 	}
 	
 	if err := config.validate(); err != nil {
@@ -134,7 +311,86 @@ func (c *Config) validate() error {
 		completionProvider = c.LLM.Provider
 	}
 	
-	switch completionProvider {
+	if err := c.validateCompletionProvider(completionProvider); err != nil {
+		return err
+	}
+	for _, provider := range c.Failover.FallbackProviders {
+		if err := c.validateCompletionProvider(provider); err != nil {
+			return fmt.Errorf("COMPLETION_FALLBACK_PROVIDERS: %w", err)
+		}
+	}
+	
+	switch c.Vector.StoreName() {
+	case "weaviate":
+		if c.Weaviate.URL == "" {
+			return fmt.Errorf("WEAVIATE_URL is required")
+		}
+	case "pgvector":
+		if c.PGVector.DSN == "" {
+			return fmt.Errorf("POSTGRES_DSN is required when using the pgvector vector store")
+		}
+		if c.PGVector.Dimensions <= 0 {
+			return fmt.Errorf("PGVECTOR_DIMENSIONS must be positive")
+		}
+		switch c.PGVector.Index {
+		case "hnsw", "ivfflat":
+		default:
+			return fmt.Errorf("unsupported pgvector index: %s (supported: hnsw, ivfflat)", c.PGVector.Index)
+		}
+	default:
+		return fmt.Errorf("unsupported vector store: %s (supported: weaviate, pgvector)", c.Vector.StoreName())
+	}
+
+	switch c.Cache.Backend {
+	case "redis":
+		if c.Cache.RedisURL == "" {
+			return fmt.Errorf("EMBEDDING_CACHE_REDIS_URL is required when using the Redis embedding cache")
+		}
+	case "memory", "none":
+	default:
+		return fmt.Errorf("unsupported embedding cache backend: %s (supported: memory, redis, none)", c.Cache.Backend)
+	}
+
+	switch c.Usage.Store {
+	case "redis":
+		if c.Usage.RedisURL == "" {
+			return fmt.Errorf("USAGE_REDIS_URL is required when using the Redis usage store")
+		}
+	case "memory":
+	default:
+		return fmt.Errorf("unsupported usage store: %s (supported: memory, redis)", c.Usage.Store)
+	}
+	if c.Usage.MonthlyBudget < 0 || c.Usage.CommunityBudget < 0 {
+		return fmt.Errorf("token budgets must not be negative")
+	}
+	switch c.Usage.BudgetAction {
+	case "downgrade":
+		if c.Usage.DowngradeProvider == "" {
+			return fmt.Errorf("USAGE_DOWNGRADE_PROVIDER is required when over-budget completions are downgraded")
+		}
+		if err := c.validateCompletionProvider(c.Usage.DowngradeProvider); err != nil {
+			return fmt.Errorf("USAGE_DOWNGRADE_PROVIDER: %w", err)
+		}
+	case "reject":
+	default:
+		return fmt.Errorf("unsupported budget action: %s (supported: reject, downgrade)", c.Usage.BudgetAction)
+	}
+
+	switch c.Guard.Policy {
+	case "off", "warn", "block", "regenerate":
+	default:
+		return fmt.Errorf("unsupported generation guard policy: %s (supported: off, warn, block, regenerate)", c.Guard.Policy)
+	}
+	if c.Guard.MaxRegenerations < 0 {
+		return fmt.Errorf("GENERATION_GUARD_MAX_REGENERATIONS must not be negative")
+	}
+	
+	return nil
+}
+
+// validateCompletionProvider ensures the settings a completion provider needs are present
+func (c *Config) validateCompletionProvider(provider string) error {
+	switch provider {
 	case "openai":
 		if c.LLM.OpenAIAPIKey == "" {
 			return fmt.Errorf("OPENAI_API_KEY is required when using OpenAI completion provider")
@@ -149,12 +405,32 @@ func (c *Config) validate() error {
 		}
 	case "ollama":
 	default:
-		return fmt.Errorf("unsupported completion provider: %s (supported: ollama, groq, openai, openrouter)", completionProvider)
-	}
-	
-	if c.Weaviate.URL == "" {
-		return fmt.Errorf("WEAVIATE_URL is required")
+		return fmt.Errorf("unsupported completion provider: %s (supported: ollama, groq, openai, openrouter)", provider)
 	}
-	
 	return nil
-}
\ No newline at end of file
+}
+
+// splitList splits a comma separated setting, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// parseBudgets parses a comma separated list of community:tokens entries
+func parseBudgets(value string) (map[string]int64, error) {
+	budgets := make(map[string]int64)
+	for _, item := range splitList(value) {
+		community, tokens, ok := strings.Cut(item, ":")
+		budget, err := strconv.ParseInt(strings.TrimSpace(tokens), 10, 64)
+		if !ok || strings.TrimSpace(community) == "" || err != nil || budget < 0 {
+			return nil, fmt.Errorf("invalid budget %q, expected community:tokens", item)
+		}
+		budgets[strings.TrimSpace(community)] = budget
+	}
+	return budgets, nil
+}
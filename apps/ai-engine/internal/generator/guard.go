@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+
+	"github.com/qolzam/telar/apps/ai-engine/internal/analyzer"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
+)
+
+// ErrContentBlocked is returned when generated content is flagged by moderation and the
+// guard policy does not let it through
+var ErrContentBlocked = errors.New("generated content was blocked by moderation")
+
+// What the output guard does with flagged content
+const (
+	GuardOff        = "off"        // Outputs are not checked
+	GuardWarn       = "warn"       // Flagged outputs are returned and audit logged
+	GuardBlock      = "block"      // Flagged outputs fail with ErrContentBlocked
+	GuardRegenerate = "regenerate" // Flagged outputs are generated again, then blocked
+)
+
+// Moderator scores text for toxicity and spam; the analyzer service is one
+type Moderator interface {
+	ScoreModeration(ctx context.Context, req analyzer.ModerationRequest) (*analyzer.ModerationResult, error)
+}
+
+// GuardConfig sets how the output guard treats flagged content
+type GuardConfig struct {
+	Policy           string
+	MaxRegenerations int // New tries of a flagged output under GuardRegenerate
+}
+
+// GuardStats counts what the output guard did since startup
+type GuardStats struct {
+	Policy      string `json:"policy"`
+	Checked     int64  `json:"checked"`
+	Flagged     int64  `json:"flagged"`
+	Blocked     int64  `json:"blocked"`
+	Regenerated int64  `json:"regenerated"`
+	Unchecked   int64  `json:"unchecked"` // Outputs returned because moderation failed
+}
+
+// Guard runs generated content through moderation before it is returned. Every flagged
+// output is written to the audit log. When the moderator fails, the output is returned
+// and the failure logged, so an unavailable moderator does not stop generation.
+type Guard struct {
+	moderator Moderator
+	config    GuardConfig
+
+	checked, flagged, blocked, regenerated, unchecked atomic.Int64
+}
+
+// NewGuard checks outputs with moderator according to config
+func NewGuard(moderator Moderator, config GuardConfig) *Guard {
+	if config.Policy == "" {
+		config.Policy = GuardOff
+	}
+	if config.MaxRegenerations < 0 {
+		config.MaxRegenerations = 0
+	}
+	return &Guard{moderator: moderator, config: config}
+}
+
+// enabled reports whether outputs are checked at all
+func (g *Guard) enabled() bool {
+	return g != nil && g.config.Policy != GuardOff
+}
+
+// withholdsOutput reports whether an output may be replaced or dropped after it was
+// generated, so it must not be streamed before it was checked
+func (g *Guard) withholdsOutput() bool {
+	return g.enabled() && g.config.Policy != GuardWarn
+}
+
+// run calls generate until its output passes moderation, as the policy allows. kind
+// names the output in the audit log.
+func (g *Guard) run(ctx context.Context, kind string, generate func() (string, error)) (string, error) {
+	for attempt := 1; ; attempt++ {
+		output, err := generate()
+		if err != nil || !g.enabled() {
+			return output, err
+		}
+
+		g.checked.Add(1)
+		result, err := g.moderator.ScoreModeration(ctx, analyzer.ModerationRequest{Content: output, Kind: "post"})
+		if err != nil {
+			g.unchecked.Add(1)
+			log.Printf("Output guard could not score %s, returning it unchecked: %v", kind, err)
+			return output, nil
+		}
+		if !result.Flagged {
+			return output, nil
+		}
+
+		g.flagged.Add(1)
+		action := g.config.Policy
+		if action == GuardRegenerate && attempt > g.config.MaxRegenerations {
+			action = GuardBlock
+		}
+		audit(ctx, kind, action, attempt, result)
+
+		switch action {
+		case GuardWarn:
+			return output, nil
+		case GuardRegenerate:
+			g.regenerated.Add(1)
+		default:
+			g.blocked.Add(1)
+			return "", fmt.Errorf("%w: %s", ErrContentBlocked, result.Reason)
+		}
+	}
+}
+
+// Stats returns what the guard did since startup
+func (g *Guard) Stats() GuardStats {
+	if g == nil {
+		return GuardStats{Policy: GuardOff}
+	}
+	return GuardStats{
+		Policy:      g.config.Policy,
+		Checked:     g.checked.Load(),
+		Flagged:     g.flagged.Load(),
+		Blocked:     g.blocked.Load(),
+		Regenerated: g.regenerated.Load(),
+		Unchecked:   g.unchecked.Load(),
+	}
+}
+
+// audit records a flagged output with the request it was generated for
+func audit(ctx context.Context, kind, action string, attempt int, result *analyzer.ModerationResult) {
+	scope, _ := ctx.Value(llm.UsageScopeKey).(llm.UsageScope)
+	log.Printf("AUDIT output guard flagged %s: action=%s attempt=%d toxicity=%.2f spam=%.2f request_id=%s community=%s reason=%q",
+		kind, action, attempt, result.Toxicity, result.Spam, scope.RequestID, scope.Community, result.Reason)
+}
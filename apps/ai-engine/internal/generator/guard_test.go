@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/qolzam/telar/apps/ai-engine/internal/analyzer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// scriptedModerator flags the outputs listed, in order
+type scriptedModerator struct {
+	flags []bool
+	err   error
+	calls int
+}
+
+func (m *scriptedModerator) ScoreModeration(ctx context.Context, req analyzer.ModerationRequest) (*analyzer.ModerationResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	flagged := m.flags[m.calls]
+	m.calls++
+	return &analyzer.ModerationResult{Flagged: flagged, Toxicity: 0.9, Reason: "insulting"}, nil
+}
+
+// streamingModel hands its response to the streaming function, when there is one
+type streamingModel struct {
+	response string
+}
+
+func (m *streamingModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *streamingModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.StreamingFunc != nil {
+		if err := opts.StreamingFunc(ctx, []byte(m.response)); err != nil {
+			return nil, err
+		}
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.response}}}, nil
+}
+
+func TestOutputGuard(t *testing.T) {
+	ctx := context.Background()
+	starters := `["What do you cook on weeknights?", "Which knife do you use most?", "What dish took you longest to learn?"]`
+
+	t.Run("warn returns flagged output", func(t *testing.T) {
+		svc := NewService(&fakeModel{response: starters}, 1)
+		svc.SetOutputGuard(NewGuard(&scriptedModerator{flags: []bool{true}}, GuardConfig{Policy: GuardWarn}))
+
+		got, err := svc.GenerateConversationStarters(ctx, "cooking", "casual")
+		require.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.Equal(t, GuardStats{Policy: GuardWarn, Checked: 1, Flagged: 1}, svc.guard.Stats())
+	})
+
+	t.Run("block fails flagged output", func(t *testing.T) {
+		svc := NewService(&fakeModel{response: `{"summary": "s", "key_points": []}`}, 1)
+		svc.SetOutputGuard(NewGuard(&scriptedModerator{flags: []bool{true}}, GuardConfig{Policy: GuardBlock}))
+
+		_, err := svc.SummarizeThread(ctx, ThreadSummaryRequest{Post: ThreadMessage{Text: "post"}})
+		assert.True(t, errors.Is(err, ErrContentBlocked))
+		assert.Equal(t, int64(1), svc.guard.Stats().Blocked)
+	})
+
+	t.Run("regenerate retries until the output passes", func(t *testing.T) {
+		moderator := &scriptedModerator{flags: []bool{true, false}}
+		svc := NewService(&fakeModel{response: starters}, 1)
+		svc.SetOutputGuard(NewGuard(moderator, GuardConfig{Policy: GuardRegenerate, MaxRegenerations: 2}))
+
+		_, err := svc.GenerateConversationStarters(ctx, "cooking", "casual")
+		require.NoError(t, err)
+		assert.Equal(t, 2, moderator.calls)
+		assert.Equal(t, int64(1), svc.guard.Stats().Regenerated)
+	})
+
+	t.Run("regenerate blocks once out of tries", func(t *testing.T) {
+		moderator := &scriptedModerator{flags: []bool{true, true}}
+		svc := NewService(&fakeModel{response: starters}, 1)
+		svc.SetOutputGuard(NewGuard(moderator, GuardConfig{Policy: GuardRegenerate, MaxRegenerations: 1}))
+
+		_, err := svc.GenerateConversationStarters(ctx, "cooking", "casual")
+		assert.True(t, errors.Is(err, ErrContentBlocked))
+		assert.Equal(t, 2, moderator.calls)
+	})
+
+	t.Run("returns output the moderator cannot score", func(t *testing.T) {
+		svc := NewService(&fakeModel{response: starters}, 1)
+		svc.SetOutputGuard(NewGuard(&scriptedModerator{err: errors.New("ollama service is not available")}, GuardConfig{Policy: GuardBlock}))
+
+		_, err := svc.GenerateConversationStarters(ctx, "cooking", "casual")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), svc.guard.Stats().Unchecked)
+	})
+
+	t.Run("withholds streams it may block", func(t *testing.T) {
+		svc := NewService(&streamingModel{response: starters}, 1)
+		svc.SetOutputGuard(NewGuard(&scriptedModerator{flags: []bool{false}}, GuardConfig{Policy: GuardBlock}))
+
+		streamed := false
+		got, err := svc.GenerateConversationStartersStream(ctx, "cooking", "casual", func(ctx context.Context, chunk []byte) error {
+			streamed = true
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Len(t, got, 3)
+		assert.False(t, streamed)
+	})
+}
@@ -3,6 +3,7 @@ package generator
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -17,6 +18,7 @@ type Service struct {
 	semaphore      chan struct{} 
 	maxConcurrent  int
 	requestTimeout time.Duration
+	guard          *Guard
 }
 
 // Request represents a queued generation request
@@ -52,6 +54,11 @@ func NewService(compClient llms.Model, maxConcurrent int) *Service {
 	}
 }
 
+// SetOutputGuard runs every generated output through guard before it is returned
+func (s *Service) SetOutputGuard(guard *Guard) {
+	s.guard = guard
+}
+
 // NewQueue creates a new request queue.
 func NewQueue(bufferSize int) *Queue {
 	return &Queue{
@@ -89,24 +96,48 @@ func (q *Queue) Close() {
 
 // GenerateConversationStarters creates engaging prompts for a community with concurrent request limiting.
 func (s *Service) GenerateConversationStarters(ctx context.Context, topic, style string) ([]string, error) {
-	// Try to acquire semaphore with timeout
+	return s.generateConversationStarters(ctx, topic, style)
+}
+
+// GenerateConversationStartersStream is GenerateConversationStarters with the raw model
+// output streamed to onChunk as it is produced; the starters are parsed once it is complete.
+// An output guard that may block or regenerate the output holds the stream back, so
+// only the checked starters are returned.
+func (s *Service) GenerateConversationStartersStream(ctx context.Context, topic, style string, onChunk func(ctx context.Context, chunk []byte) error) ([]string, error) {
+	if s.guard.withholdsOutput() {
+		return s.generateConversationStarters(ctx, topic, style)
+	}
+	return s.generateConversationStarters(ctx, topic, style, llms.WithStreamingFunc(onChunk))
+}
+
+func (s *Service) generateConversationStarters(ctx context.Context, topic, style string, options ...llms.CallOption) ([]string, error) {
+	release, err := s.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	genCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+
+	return s.generateConversationStartersInternal(genCtx, topic, style, options...)
+}
+
+// acquire takes one of the MaxConcurrent generation slots, waiting up to five seconds
+// for one to free up. The caller must call release when done.
+func (s *Service) acquire(ctx context.Context) (release func(), err error) {
 	select {
 	case s.semaphore <- struct{}{}:
-		defer func() { <-s.semaphore }() 
+		return func() { <-s.semaphore }, nil
 	case <-time.After(5 * time.Second):
 		return nil, fmt.Errorf("server is currently processing too many requests, please try again in a moment")
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	}
-
-	genCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
-	defer cancel()
-
-	return s.generateConversationStartersInternal(genCtx, topic, style)
 }
 
 // generateConversationStartersInternal performs the actual generation work.
-func (s *Service) generateConversationStartersInternal(ctx context.Context, topic, style string) ([]string, error) {
+func (s *Service) generateConversationStartersInternal(ctx context.Context, topic, style string, options ...llms.CallOption) ([]string, error) {
 	// A robust, instruction-following prompt template.
 	prompt := prompts.NewPromptTemplate(
 		`You are an expert community manager. Your task is to generate three high-quality, open-ended discussion prompts for a community of '{{.topic}}'.
@@ -129,7 +160,12 @@ func (s *Service) generateConversationStartersInternal(ctx context.Context, topi
 	}
 
 	// Call your existing, provider-agnostic completion client.
-	response, err := llms.GenerateFromSinglePrompt(ctx, s.compClient, formattedPrompt)
+	response, err := s.guard.run(ctx, "conversation starters", func() (string, error) {
+		return llms.GenerateFromSinglePrompt(ctx, s.compClient, formattedPrompt, options...)
+	})
+	if errors.Is(err, ErrContentBlocked) {
+		return nil, err
+	}
 	if err != nil {
 		return nil, fmt.Errorf("llm client failed to generate starters: %w", err)
 	}
@@ -155,5 +191,6 @@ func (s *Service) GetConcurrentStatus() map[string]interface{} {
 		"available_slots":   availableSlots,
 		"request_timeout":   s.requestTimeout.String(),
 		"can_accept_request": availableSlots > 0,
+		"output_guard":       s.guard.Stats(),
 	}
 }
@@ -0,0 +1,215 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+)
+
+const (
+	threadTokenBudget  = 3000 // Tokens of thread text a summary prompt may carry
+	postTokenShare     = 2    // The post gets at most 1/postTokenShare of the budget
+	commentTokenLimit  = 300  // Tokens kept of any single comment
+	defaultKeyPoints   = 3
+	maxKeyPoints       = 10
+	charsPerToken      = 4 // Rough English average; good enough to size prompts
+	truncationEllipsis = "…"
+)
+
+// ThreadMessage is the post or one comment of a thread
+type ThreadMessage struct {
+	Author string `json:"author,omitempty"`
+	Text   string `json:"text"`
+}
+
+// ThreadSummaryRequest asks for a digest of a post and its comments. Comments are taken
+// in the order given, so the caller decides which ones matter most when the thread does
+// not fit the token budget.
+type ThreadSummaryRequest struct {
+	Post      ThreadMessage   `json:"post"`
+	Comments  []ThreadMessage `json:"comments,omitempty"`
+	KeyPoints int             `json:"key_points,omitempty"` // How many key points to ask for; defaults to 3
+}
+
+// ThreadSummary is a short digest of a thread and its key points
+type ThreadSummary struct {
+	Summary      string   `json:"summary"`
+	KeyPoints    []string `json:"key_points"`
+	CommentsUsed int      `json:"comments_used"` // Comments that fit the token budget
+	Truncated    bool     `json:"truncated"`     // Whether any text was cut or left out
+}
+
+var threadSummaryPrompt = prompts.NewPromptTemplate(
+	`You are summarizing a discussion thread from a social network for someone who has not read it.
+
+Post:
+"""
+{{.post}}
+"""
+
+Comments:
+"""
+{{.comments}}
+"""
+
+Write a neutral digest of at most three sentences covering what the post asks or says and where the discussion landed, then list the {{.points}} most important points raised. Do not invent anything that is not in the thread.
+
+You MUST respond with ONLY a valid JSON object in this exact format, with no additional text:
+{
+  "summary": "the digest",
+  "key_points": ["point one", "point two"]
+}`,
+	[]string{"post", "comments", "points"},
+)
+
+// SummarizeThread digests a post and its comments into a short summary and key points.
+// The thread is cut to fit the token budget first, and the request shares the
+// MaxConcurrent generation slots with every other generation.
+func (s *Service) SummarizeThread(ctx context.Context, req ThreadSummaryRequest) (*ThreadSummary, error) {
+	if strings.TrimSpace(req.Post.Text) == "" {
+		return nil, fmt.Errorf("post text is required")
+	}
+	points := req.KeyPoints
+	if points <= 0 {
+		points = defaultKeyPoints
+	}
+	if points > maxKeyPoints {
+		points = maxKeyPoints
+	}
+
+	thread := FitThread(req.Post, req.Comments, threadTokenBudget)
+	formattedPrompt, err := threadSummaryPrompt.Format(map[string]any{
+		"post":     thread.Post,
+		"comments": thread.Comments,
+		"points":   points,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to format thread summary prompt: %w", err)
+	}
+
+	release, err := s.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	genCtx, cancel := context.WithTimeout(ctx, s.requestTimeout)
+	defer cancel()
+
+	response, err := s.guard.run(genCtx, "thread summary", func() (string, error) {
+		return llms.GenerateFromSinglePrompt(genCtx, s.compClient, formattedPrompt)
+	})
+	if errors.Is(err, ErrContentBlocked) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("llm client failed to summarize thread: %w", err)
+	}
+
+	var summary ThreadSummary
+	if err := decodeJSONResponse(response, &summary); err != nil {
+		log.Printf("Failed to parse thread summary as JSON. Raw response: %s", response)
+		return nil, fmt.Errorf("failed to parse thread summary: %w", err)
+	}
+	if len(summary.KeyPoints) > points {
+		summary.KeyPoints = summary.KeyPoints[:points]
+	}
+	if summary.KeyPoints == nil {
+		summary.KeyPoints = []string{}
+	}
+	summary.CommentsUsed = thread.CommentsUsed
+	summary.Truncated = thread.Truncated
+	return &summary, nil
+}
+
+// FittedThread is a thread's text cut to a token budget
+type FittedThread struct {
+	Post         string
+	Comments     string // One "- author: text" line per comment
+	CommentsUsed int
+	Truncated    bool
+}
+
+// FitThread renders a thread within budget tokens. The post keeps up to half of the
+// budget, then comments are added in order, each cut to commentTokenLimit, until the
+// next one no longer fits; the rest are left out.
+func FitThread(post ThreadMessage, comments []ThreadMessage, budget int) FittedThread {
+	var thread FittedThread
+	var cut bool
+
+	thread.Post, cut = truncateTokens(renderMessage(post), budget/postTokenShare)
+	thread.Truncated = cut
+	remaining := budget - EstimateTokens(thread.Post)
+
+	var lines []string
+	for _, comment := range comments {
+		if strings.TrimSpace(comment.Text) == "" {
+			continue
+		}
+		line, cut := truncateTokens("- "+renderMessage(comment), commentTokenLimit)
+		tokens := EstimateTokens(line)
+		if tokens > remaining {
+			thread.Truncated = true
+			break
+		}
+		thread.Truncated = thread.Truncated || cut
+		remaining -= tokens
+		lines = append(lines, line)
+	}
+
+	thread.CommentsUsed = len(lines)
+	thread.Comments = strings.Join(lines, "\n")
+	if thread.Comments == "" {
+		thread.Comments = "(no comments)"
+	}
+	return thread
+}
+
+// EstimateTokens approximates how many tokens text costs from its length
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + charsPerToken - 1) / charsPerToken
+}
+
+// truncateTokens cuts text to about limit tokens at a word boundary and reports
+// whether it did
+func truncateTokens(text string, limit int) (string, bool) {
+	if EstimateTokens(text) <= limit {
+		return text, false
+	}
+	runes := []rune(text)
+	maxRunes := limit*charsPerToken - utf8.RuneCountInString(truncationEllipsis)
+	if maxRunes <= 0 {
+		return truncationEllipsis, true
+	}
+	cut := string(runes[:maxRunes])
+	if space := strings.LastIndexAny(cut, " \n\t"); space > len(cut)/2 {
+		cut = cut[:space]
+	}
+	return strings.TrimSpace(cut) + truncationEllipsis, true
+}
+
+// renderMessage puts the author in front of the text, with whitespace runs collapsed
+func renderMessage(msg ThreadMessage) string {
+	text := strings.Join(strings.Fields(msg.Text), " ")
+	if msg.Author == "" {
+		return text
+	}
+	return msg.Author + ": " + text
+}
+
+// decodeJSONResponse unmarshals an LLM response, dropping the markdown code fence some
+// models wrap JSON in
+func decodeJSONResponse(response string, out interface{}) error {
+	cleaned := strings.TrimSpace(response)
+	cleaned = strings.TrimPrefix(cleaned, "```json")
+	cleaned = strings.TrimPrefix(cleaned, "```")
+	cleaned = strings.TrimSuffix(cleaned, "```")
+	return json.Unmarshal([]byte(strings.TrimSpace(cleaned)), out)
+}
@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// fakeModel answers every prompt with a fixed response and remembers the last prompt
+type fakeModel struct {
+	response string
+	prompt   string
+	block    chan struct{} // When set, generation waits until it is closed
+}
+
+func (m *fakeModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return m.response, nil
+}
+
+func (m *fakeModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	if m.block != nil {
+		<-m.block
+	}
+	m.prompt = messages[0].Parts[0].(llms.TextContent).Text
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: m.response}}}, nil
+}
+
+func TestSummarizeThread(t *testing.T) {
+	model := &fakeModel{response: "```json\n" + `{"summary": "Readers compared Go web frameworks.", "key_points": ["Fiber is fast", "net/http is enough", "Chi is minimal", "extra"]}` + "\n```"}
+	svc := NewService(model, 1)
+
+	summary, err := svc.SummarizeThread(context.Background(), ThreadSummaryRequest{
+		Post:     ThreadMessage{Author: "ana", Text: "Which Go web framework do you use?"},
+		Comments: []ThreadMessage{{Author: "bo", Text: "Fiber, it is fast"}, {Text: "  "}, {Author: "cy", Text: "Just net/http"}},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Readers compared Go web frameworks.", summary.Summary)
+	assert.Equal(t, []string{"Fiber is fast", "net/http is enough", "Chi is minimal"}, summary.KeyPoints)
+	assert.Equal(t, 2, summary.CommentsUsed)
+	assert.False(t, summary.Truncated)
+	assert.Contains(t, model.prompt, "ana: Which Go web framework do you use?")
+	assert.Contains(t, model.prompt, "- bo: Fiber, it is fast\n- cy: Just net/http")
+	assert.Contains(t, model.prompt, "the 3 most important points")
+}
+
+func TestSummarizeThread_RequiresPostText(t *testing.T) {
+	_, err := NewService(&fakeModel{}, 1).SummarizeThread(context.Background(), ThreadSummaryRequest{})
+	assert.Error(t, err)
+}
+
+func TestSummarizeThread_SharesConcurrencyLimit(t *testing.T) {
+	model := &fakeModel{response: `{"summary": "s", "key_points": []}`, block: make(chan struct{})}
+	svc := NewService(model, 1)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.SummarizeThread(context.Background(), ThreadSummaryRequest{Post: ThreadMessage{Text: "first"}})
+	}()
+	require.Eventually(t, func() bool { return len(svc.semaphore) == 1 }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := svc.SummarizeThread(ctx, ThreadSummaryRequest{Post: ThreadMessage{Text: "second"}})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	close(model.block)
+	<-done
+	assert.Equal(t, 0, len(svc.semaphore))
+}
+
+func TestFitThread(t *testing.T) {
+	t.Run("keeps a thread that fits", func(t *testing.T) {
+		thread := FitThread(ThreadMessage{Text: "post"}, []ThreadMessage{{Author: "a", Text: "one"}}, 100)
+		assert.Equal(t, "post", thread.Post)
+		assert.Equal(t, "- a: one", thread.Comments)
+		assert.Equal(t, 1, thread.CommentsUsed)
+		assert.False(t, thread.Truncated)
+	})
+
+	t.Run("cuts a long post to half the budget", func(t *testing.T) {
+		thread := FitThread(ThreadMessage{Text: strings.Repeat("word ", 200)}, nil, 100)
+		assert.LessOrEqual(t, EstimateTokens(thread.Post), 50)
+		assert.True(t, strings.HasSuffix(thread.Post, "word…"))
+		assert.Equal(t, "(no comments)", thread.Comments)
+		assert.True(t, thread.Truncated)
+	})
+
+	t.Run("drops comments past the budget", func(t *testing.T) {
+		comment := ThreadMessage{Text: strings.Repeat("x", 4*20)} // About 21 tokens with the "- " prefix
+		thread := FitThread(ThreadMessage{Text: "post"}, []ThreadMessage{comment, comment, comment, comment}, 60)
+		assert.Equal(t, 2, thread.CommentsUsed)
+		assert.True(t, thread.Truncated)
+		assert.LessOrEqual(t, EstimateTokens(thread.Post)+EstimateTokens(thread.Comments), 60)
+	})
+
+	t.Run("cuts a long comment", func(t *testing.T) {
+		thread := FitThread(ThreadMessage{Text: "post"}, []ThreadMessage{{Text: strings.Repeat("long ", 1000)}}, threadTokenBudget)
+		assert.Equal(t, 1, thread.CommentsUsed)
+		assert.LessOrEqual(t, EstimateTokens(thread.Comments), commentTokenLimit)
+		assert.True(t, thread.Truncated)
+	})
+}
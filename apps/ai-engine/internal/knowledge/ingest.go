@@ -0,0 +1,122 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
+)
+
+const (
+	chunkWords   = 200 // Words per chunk
+	chunkOverlap = 40  // Words a chunk repeats from the end of the previous one
+)
+
+// PostIngestRequest carries the current text of a post and the metadata semantic search
+// filters on
+type PostIngestRequest struct {
+	Text        string `json:"text"`
+	OwnerUserID string `json:"owner_user_id,omitempty"` // Empty for anonymous posts
+	CommunityID string `json:"community_id,omitempty"`
+	CreatedDate int64  `json:"created_date,omitempty"` // Unix milliseconds
+}
+
+// PostIngestResponse reports how many chunks the post was stored as
+type PostIngestResponse struct {
+	PostID string `json:"post_id"`
+	Chunks int    `json:"chunks"`
+}
+
+// IngestPost chunks a post, embeds each chunk and upserts them, then removes the chunks
+// an earlier, longer version left behind. Re-ingesting an unchanged post rewrites the
+// same documents. A post with no text is removed.
+func (s *Service) IngestPost(ctx context.Context, postID string, req *PostIngestRequest) (*PostIngestResponse, error) {
+	post, err := uuid.Parse(postID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid post ID %q: %w", postID, err)
+	}
+
+	chunks := ChunkText(req.Text, chunkWords, chunkOverlap)
+	for i, chunk := range chunks {
+		embedding, err := s.embedClient.GenerateEmbeddings(ctx, chunk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate embeddings for chunk %d: %w", i, err)
+		}
+
+		metadata := map[string]string{
+			"source": "post",
+			"postId": post.String(),
+			"chunk":  strconv.Itoa(i),
+		}
+		if req.OwnerUserID != "" {
+			metadata["ownerUserId"] = req.OwnerUserID
+		}
+		if req.CommunityID != "" {
+			metadata["communityId"] = req.CommunityID
+		}
+		if req.CreatedDate > 0 {
+			metadata["createdDate"] = strconv.FormatInt(req.CreatedDate, 10)
+		}
+
+		doc := &vectorstore.Document{ID: ChunkID(post, i), Text: chunk, Metadata: metadata}
+		if err := s.vectorClient.StoreDocument(ctx, doc, embedding); err != nil {
+			return nil, fmt.Errorf("failed to store chunk %d: %w", i, err)
+		}
+	}
+
+	if len(chunks) == 0 {
+		if err := s.DeletePost(ctx, post.String()); err != nil {
+			return nil, err
+		}
+	} else if err := s.vectorClient.DeletePostChunks(ctx, post.String(), len(chunks)); err != nil {
+		return nil, err
+	}
+
+	log.Printf("Ingested post %s as %d chunks", post, len(chunks))
+	return &PostIngestResponse{PostID: post.String(), Chunks: len(chunks)}, nil
+}
+
+// DeletePost removes every chunk of a post, including a whole-post document stored
+// before posts were chunked
+func (s *Service) DeletePost(ctx context.Context, postID string) error {
+	if err := s.vectorClient.DeletePostChunks(ctx, postID, 0); err != nil {
+		return err
+	}
+	return s.vectorClient.DeleteDocument(ctx, postID)
+}
+
+// ChunkID is the document ID of a post chunk. The first chunk keeps the post's own ID,
+// so it replaces a whole-post document ingested under that ID.
+func ChunkID(postID uuid.UUID, chunk int) string {
+	if chunk == 0 {
+		return postID.String()
+	}
+	return uuid.NewSHA1(postID, []byte(strconv.Itoa(chunk))).String()
+}
+
+// ChunkText splits text into chunks of at most size words, each repeating the last
+// overlap words of the one before so a sentence cut at a boundary is still embedded
+// whole once. Whitespace runs collapse to single spaces.
+func ChunkText(text string, size, overlap int) []string {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	var chunks []string
+	for start := 0; ; start += size - overlap {
+		end := start + size
+		if end >= len(words) {
+			return append(chunks, strings.Join(words[start:], " "))
+		}
+		chunks = append(chunks, strings.Join(words[start:end], " "))
+	}
+}
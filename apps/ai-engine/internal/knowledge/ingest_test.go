@@ -0,0 +1,48 @@
+package knowledge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkText(t *testing.T) {
+	t.Run("returns nothing for blank text", func(t *testing.T) {
+		assert.Empty(t, ChunkText(" \n\t", 10, 2))
+	})
+
+	t.Run("keeps short text in one chunk", func(t *testing.T) {
+		assert.Equal(t, []string{"Go is fun"}, ChunkText("Go  is\nfun", 10, 2))
+	})
+
+	t.Run("overlaps consecutive chunks", func(t *testing.T) {
+		text := "one two three four five six seven eight"
+		assert.Equal(t, []string{
+			"one two three four",
+			"three four five six",
+			"five six seven eight",
+		}, ChunkText(text, 4, 2))
+	})
+
+	t.Run("ignores an overlap as large as the chunk", func(t *testing.T) {
+		assert.Equal(t, []string{"a b", "c d", "e"}, ChunkText("a b c d e", 2, 2))
+	})
+
+	t.Run("bounds every chunk", func(t *testing.T) {
+		text := strings.Repeat("word ", 1000)
+		for _, chunk := range ChunkText(text, chunkWords, chunkOverlap) {
+			assert.LessOrEqual(t, len(strings.Fields(chunk)), chunkWords)
+		}
+	})
+}
+
+func TestChunkID(t *testing.T) {
+	post := uuid.New()
+
+	assert.Equal(t, post.String(), ChunkID(post, 0), "first chunk replaces a whole-post document")
+	assert.Equal(t, ChunkID(post, 1), ChunkID(post, 1), "IDs are stable across ingestions")
+	assert.NotEqual(t, ChunkID(post, 1), ChunkID(post, 2))
+	assert.NotEqual(t, ChunkID(post, 1), ChunkID(uuid.New(), 1))
+}
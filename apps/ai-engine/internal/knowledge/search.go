@@ -0,0 +1,112 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
+)
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+	snippetLength      = 200 // Characters
+	chunksPerHit       = 3   // Chunks fetched per requested post, as long posts match several times
+)
+
+// SearchRequest asks for the posts closest in meaning to a query. Empty filters match
+// every post.
+type SearchRequest struct {
+	Query       string `json:"query"`
+	Limit       int    `json:"limit,omitempty"`
+	CommunityID string `json:"community_id,omitempty"`
+	AuthorID    string `json:"author_id,omitempty"`
+	From        int64  `json:"from,omitempty"` // Unix milliseconds, inclusive
+	To          int64  `json:"to,omitempty"`   // Unix milliseconds, exclusive
+}
+
+// SearchHit is a post matched by semantic search
+type SearchHit struct {
+	PostID  string  `json:"post_id"`
+	Snippet string  `json:"snippet"`
+	Score   float32 `json:"score"` // Weaviate certainty, 0 to 1
+}
+
+// SearchResponse lists the matched posts, best match first
+type SearchResponse struct {
+	Results []SearchHit `json:"results"`
+}
+
+// Search embeds the query and returns the ingested posts nearest to it that match the
+// filters. Only documents ingested with source "post" are searched.
+func (s *Service) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	embedding, err := s.embedClient.GenerateEmbeddings(ctx, req.Query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate query embeddings: %w", err)
+	}
+
+	results, err := s.vectorClient.SearchFiltered(ctx, embedding, limit*chunksPerHit, vectorstore.SearchFilter{
+		Source:        "post",
+		OwnerUserID:   req.AuthorID,
+		CommunityID:   req.CommunityID,
+		CreatedAfter:  req.From,
+		CreatedBefore: req.To,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	return &SearchResponse{Results: postHits(results, limit)}, nil
+}
+
+// postHits keeps the best matching chunk of each post, up to limit posts. Results must
+// be ordered best match first; documents without a post ID are whole posts stored under
+// the post's own ID.
+func postHits(results []*vectorstore.SearchResult, limit int) []SearchHit {
+	hits := make([]SearchHit, 0, limit)
+	seen := make(map[string]bool, len(results))
+	for _, result := range results {
+		postID := result.Document.Metadata["postId"]
+		if postID == "" {
+			postID = result.Document.ID
+		}
+		if seen[postID] {
+			continue
+		}
+		seen[postID] = true
+		hits = append(hits, SearchHit{
+			PostID:  postID,
+			Snippet: Snippet(result.Document.Text, snippetLength),
+			Score:   result.Score,
+		})
+		if len(hits) == limit {
+			break
+		}
+	}
+	return hits
+}
+
+// Snippet shortens text to at most max characters, cutting at the last word boundary
+// and marking the cut with an ellipsis. Whitespace runs collapse to single spaces.
+func Snippet(text string, max int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if utf8.RuneCountInString(text) <= max {
+		return text
+	}
+	runes := []rune(text)
+	cut := string(runes[:max])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+	return strings.TrimRight(cut, " ,.;:") + "…"
+}
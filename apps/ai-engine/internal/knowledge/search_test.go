@@ -0,0 +1,60 @@
+package knowledge
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
+)
+
+func TestSnippet(t *testing.T) {
+	t.Run("keeps short text", func(t *testing.T) {
+		assert.Equal(t, "Go is fun", Snippet("Go  is\nfun", 20))
+	})
+
+	t.Run("cuts at a word boundary", func(t *testing.T) {
+		assert.Equal(t, "The quick brown…", Snippet("The quick brown fox jumps", 18))
+	})
+
+	t.Run("drops punctuation before the ellipsis", func(t *testing.T) {
+		assert.Equal(t, "Hello…", Snippet("Hello, world and everyone", 8))
+	})
+
+	t.Run("counts characters, not bytes", func(t *testing.T) {
+		text := strings.Repeat("é", 10)
+		assert.Equal(t, text, Snippet(text, 10))
+		assert.Equal(t, strings.Repeat("é", 5)+"…", Snippet(text, 5))
+	})
+}
+
+func TestPostHits(t *testing.T) {
+	chunk := func(id, postID, text string, score float32) *vectorstore.SearchResult {
+		metadata := map[string]string{"source": "post"}
+		if postID != "" {
+			metadata["postId"] = postID
+		}
+		return &vectorstore.SearchResult{Document: &vectorstore.Document{ID: id, Text: text, Metadata: metadata}, Score: score}
+	}
+	results := []*vectorstore.SearchResult{
+		chunk("c1", "post-a", "best chunk of a", 0.9),
+		chunk("c2", "post-a", "other chunk of a", 0.8),
+		chunk("post-b", "", "whole post b", 0.7),
+		chunk("c3", "post-c", "chunk of c", 0.6),
+	}
+
+	t.Run("keeps the best chunk per post", func(t *testing.T) {
+		assert.Equal(t, []SearchHit{
+			{PostID: "post-a", Snippet: "best chunk of a", Score: 0.9},
+			{PostID: "post-b", Snippet: "whole post b", Score: 0.7},
+			{PostID: "post-c", Snippet: "chunk of c", Score: 0.6},
+		}, postHits(results, 10))
+	})
+
+	t.Run("stops at the limit", func(t *testing.T) {
+		hits := postHits(results, 2)
+		assert.Len(t, hits, 2)
+		assert.Equal(t, "post-b", hits[1].PostID)
+	})
+}
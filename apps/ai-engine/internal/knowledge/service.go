@@ -7,7 +7,7 @@ import (
 	"strings"
 
 	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
-	"github.com/qolzam/telar/apps/ai-engine/internal/platform/weaviate"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/prompts"
 )
@@ -16,7 +16,7 @@ import (
 type Service struct {
 	embedClient    llm.EmbeddingClient
 	compClient     llms.Model
-	vectorClient   *weaviate.Client
+	vectorClient   vectorstore.Store
 	embeddingModel string
 }
 
@@ -34,7 +34,7 @@ type QueryRequest struct {
 // QueryResponse represents a knowledge query response
 type QueryResponse struct {
 	Answer         string                   `json:"answer"`
-	Sources        []*weaviate.SearchResult `json:"sources"`
+	Sources        []*vectorstore.SearchResult `json:"sources"`
 	ContextUsed    string                   `json:"context_used"`
 	RelevanceScore float32                  `json:"relevance_score"`
 }
@@ -62,7 +62,7 @@ type GenerationResponse struct {
 }
 
 // NewService creates a new knowledge service instance
-func NewService(embedClient llm.EmbeddingClient, compClient llms.Model, vectorClient *weaviate.Client, config Config) *Service {
+func NewService(embedClient llm.EmbeddingClient, compClient llms.Model, vectorClient vectorstore.Store, config Config) *Service {
 	return &Service{
 		embedClient:    embedClient,
 		compClient:     compClient,
@@ -80,7 +80,7 @@ func (s *Service) StoreDocument(ctx context.Context, req *DocumentRequest) error
 		return fmt.Errorf("failed to generate embeddings: %w", err)
 	}
 
-	doc := &weaviate.Document{
+	doc := &vectorstore.Document{
 		ID:       req.ID,
 		Text:     req.Text,
 		Metadata: req.Metadata,
@@ -96,6 +96,16 @@ func (s *Service) StoreDocument(ctx context.Context, req *DocumentRequest) error
 
 // QueryKnowledge performs RAG: retrieves relevant documents and generates contextual answers
 func (s *Service) QueryKnowledge(ctx context.Context, req *QueryRequest) (*QueryResponse, error) {
+	return s.queryKnowledge(ctx, req)
+}
+
+// QueryKnowledgeStream is QueryKnowledge with the answer streamed: onChunk receives each
+// piece of it as the model produces it, and the response still carries the whole answer
+func (s *Service) QueryKnowledgeStream(ctx context.Context, req *QueryRequest, onChunk func(ctx context.Context, chunk []byte) error) (*QueryResponse, error) {
+	return s.queryKnowledge(ctx, req, llms.WithStreamingFunc(onChunk))
+}
+
+func (s *Service) queryKnowledge(ctx context.Context, req *QueryRequest, options ...llms.CallOption) (*QueryResponse, error) {
 	log.Printf("Processing knowledge query: %s", req.Query)
 
 	queryEmbedding, err := s.embedClient.GenerateEmbeddings(ctx, req.Query)
@@ -134,7 +144,7 @@ func (s *Service) QueryKnowledge(ctx context.Context, req *QueryRequest) (*Query
 		return nil, fmt.Errorf("failed to format prompt template: %w", err)
 	}
 
-	completion, err := s.generateCompletion(ctx, formattedPrompt)
+	completion, err := s.generateCompletion(ctx, formattedPrompt, options...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate completion: %w", err)
 	}
@@ -347,8 +357,52 @@ func (s *Service) transformToStatement(question, topic string) string {
 		strings.Split(lowerQ, " ")[0], topic)
 }
 
-func (s *Service) generateCompletion(ctx context.Context, prompt string) (string, error) {
-	return llms.GenerateFromSinglePrompt(ctx, s.compClient, prompt)
+func (s *Service) generateCompletion(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, s.compClient, prompt, options...)
+}
+
+// VectorStoreName identifies the vector database, e.g. "weaviate"
+func (s *Service) VectorStoreName() string {
+	return s.vectorClient.Name()
+}
+
+// VectorStoreVersion returns the version of the vector database
+func (s *Service) VectorStoreVersion(ctx context.Context) (string, error) {
+	return s.vectorClient.Version(ctx)
+}
+
+// EmbeddingCacheStats returns the hit rate of the embedding cache; ok is false when
+// embeddings are not cached
+func (s *Service) EmbeddingCacheStats() (stats llm.EmbeddingCacheStats, ok bool) {
+	cache, ok := s.embedClient.(*llm.CachedEmbeddingClient)
+	if !ok {
+		return stats, false
+	}
+	return cache.Stats(), true
+}
+
+// FlushEmbeddingCache drops every cached embedding, so the next ingestion or query of
+// any text calls the embedding provider again
+func (s *Service) FlushEmbeddingCache(ctx context.Context) error {
+	cache, ok := s.embedClient.(*llm.CachedEmbeddingClient)
+	if !ok {
+		return nil
+	}
+	return cache.Flush(ctx)
+}
+
+// CompletionProviderStates returns the circuit breaker state of each completion
+// provider; ok is false when completions do not fail over
+func (s *Service) CompletionProviderStates() (states []llm.ProviderState, ok bool) {
+	model := s.compClient
+	if budgeted, ok := model.(*llm.BudgetedModel); ok {
+		model = budgeted.Unwrap()
+	}
+	failover, ok := model.(*llm.FailoverModel)
+	if !ok {
+		return nil, false
+	}
+	return failover.States(), true
 }
 
 // HealthCheck verifies connectivity to all external dependencies
@@ -7,7 +7,7 @@ import (
 	"testing"
 
 	"github.com/qolzam/telar/apps/ai-engine/internal/platform/llm"
-	"github.com/qolzam/telar/apps/ai-engine/internal/platform/weaviate"
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/tmc/langchaingo/llms"
@@ -16,8 +16,8 @@ import (
 
 // VectorClientInterface defines the interface we need for mocking Weaviate operations
 type VectorClientInterface interface {
-	StoreDocument(ctx context.Context, doc *weaviate.Document, embedding []float32) error
-	SearchSimilar(ctx context.Context, embedding []float32, limit int) ([]*weaviate.SearchResult, error)
+	StoreDocument(ctx context.Context, doc *vectorstore.Document, embedding []float32) error
+	SearchSimilar(ctx context.Context, embedding []float32, limit int) ([]*vectorstore.SearchResult, error)
 	Health(ctx context.Context) error
 	EnsureSchema(ctx context.Context) error
 }
@@ -55,7 +55,7 @@ func (s *TestableService) StoreDocument(ctx context.Context, req *DocumentReques
 		return fmt.Errorf("failed to generate embedding: %w", err)
 	}
 
-	doc := &weaviate.Document{
+	doc := &vectorstore.Document{
 		ID:       req.ID,
 		Text:     req.Text,
 		Metadata: req.Metadata,
@@ -88,7 +88,7 @@ func (s *TestableService) QueryKnowledge(ctx context.Context, req *QueryRequest)
 	if len(searchResults) == 0 {
 		return &QueryResponse{
 			Answer:         "I don't have enough information to answer your question.",
-			Sources:        []*weaviate.SearchResult{},
+			Sources:        []*vectorstore.SearchResult{},
 			ContextUsed:    "",
 			RelevanceScore: 0.0,
 		}, nil
@@ -192,14 +192,14 @@ type MockWeaviateClient struct {
 	mock.Mock
 }
 
-func (m *MockWeaviateClient) StoreDocument(ctx context.Context, doc *weaviate.Document, embedding []float32) error {
+func (m *MockWeaviateClient) StoreDocument(ctx context.Context, doc *vectorstore.Document, embedding []float32) error {
 	args := m.Called(ctx, doc, embedding)
 	return args.Error(0)
 }
 
-func (m *MockWeaviateClient) SearchSimilar(ctx context.Context, embedding []float32, limit int) ([]*weaviate.SearchResult, error) {
+func (m *MockWeaviateClient) SearchSimilar(ctx context.Context, embedding []float32, limit int) ([]*vectorstore.SearchResult, error) {
 	args := m.Called(ctx, embedding, limit)
-	return args.Get(0).([]*weaviate.SearchResult), args.Error(1)
+	return args.Get(0).([]*vectorstore.SearchResult), args.Error(1)
 }
 
 func (m *MockWeaviateClient) Health(ctx context.Context) error {
@@ -222,9 +222,9 @@ func TestQueryKnowledge(t *testing.T) {
 	question := "What is Telar?"
 	expectedAnswer := "Telar is a cool project."
 	mockEmbedding := []float32{0.1, 0.2, 0.3}
-	mockDocs := []*weaviate.SearchResult{
+	mockDocs := []*vectorstore.SearchResult{
 		{
-			Document: &weaviate.Document{
+			Document: &vectorstore.Document{
 				ID:   "doc1",
 				Text: "Telar is a social platform built with Go and React.",
 				Metadata: map[string]string{
@@ -234,7 +234,7 @@ func TestQueryKnowledge(t *testing.T) {
 			Score: 0.95,
 		},
 		{
-			Document: &weaviate.Document{
+			Document: &vectorstore.Document{
 				ID:   "doc2",
 				Text: "The Telar platform supports AI-powered features.",
 				Metadata: map[string]string{
@@ -293,7 +293,7 @@ func TestStoreDocument(t *testing.T) {
 
 	// Setup expectations
 	mockEmbedder.On("GenerateEmbeddings", mock.Anything, docReq.Text).Return(mockEmbedding, nil)
-	mockWeaviate.On("StoreDocument", mock.Anything, mock.AnythingOfType("*weaviate.Document"), mockEmbedding).Return(nil)
+	mockWeaviate.On("StoreDocument", mock.Anything, mock.AnythingOfType("*vectorstore.Document"), mockEmbedding).Return(nil)
 
 	// Instantiate service
 	service := NewTestableService(mockEmbedder, mockCompleter, mockWeaviate, Config{
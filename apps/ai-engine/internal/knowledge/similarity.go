@@ -0,0 +1,55 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// SimilarityRequest asks how semantically close a text is to a set of candidates
+type SimilarityRequest struct {
+	Text       string   `json:"text"`
+	Candidates []string `json:"candidates"`
+}
+
+// SimilarityResponse holds one cosine similarity score per candidate, in request order
+type SimilarityResponse struct {
+	Scores []float32 `json:"scores"`
+}
+
+// Similarity embeds the text and each candidate and returns their cosine similarities
+func (s *Service) Similarity(ctx context.Context, req *SimilarityRequest) (*SimilarityResponse, error) {
+	base, err := s.embedClient.GenerateEmbeddings(ctx, req.Text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate embeddings: %w", err)
+	}
+
+	scores := make([]float32, len(req.Candidates))
+	for i, candidate := range req.Candidates {
+		embedding, err := s.embedClient.GenerateEmbeddings(ctx, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate embeddings for candidate %d: %w", i, err)
+		}
+		scores[i] = CosineSimilarity(base, embedding)
+	}
+
+	return &SimilarityResponse{Scores: scores}, nil
+}
+
+// CosineSimilarity returns the cosine of the angle between a and b.
+// Vectors of different length or zero magnitude yield 0.
+func CosineSimilarity(a, b []float32) float32 {
+	if len(a) == 0 || len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return float32(dot / (math.Sqrt(normA) * math.Sqrt(normB)))
+}
@@ -2,6 +2,7 @@ package llm
 
 import (
 	"context"
+	"strings"
 
 	"github.com/tmc/langchaingo/llms"
 )
@@ -24,30 +25,10 @@ func (a *OllamaLangChainAdapter) Call(ctx context.Context, prompt string, option
 	return a.client.GenerateCompletion(ctx, prompt)
 }
 
-// GenerateContent implements the main LangChainGo interface
+// GenerateContent implements the main LangChainGo interface. A streaming function in
+// the options (llms.WithStreamingFunc) receives the completion as Ollama streams it.
 func (a *OllamaLangChainAdapter) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
-	// For simplicity, we'll convert the messages to a single prompt
-	var prompt string
-	for _, msg := range messages {
-		for _, part := range msg.Parts {
-			if textPart, ok := part.(llms.TextContent); ok {
-				prompt += textPart.Text + "\n"
-			}
-		}
-	}
-
-	response, err := a.client.GenerateCompletion(ctx, prompt)
-	if err != nil {
-		return nil, err
-	}
-
-	return &llms.ContentResponse{
-		Choices: []*llms.ContentChoice{
-			{
-				Content: response,
-			},
-		},
-	}, nil
+	return generateContent(ctx, a.client, messages, options)
 }
 
 // GroqLangChainAdapter adapts our GroqClient to implement the LangChainGo llms.Model interface
@@ -68,19 +49,36 @@ func (a *GroqLangChainAdapter) Call(ctx context.Context, prompt string, options
 	return a.client.GenerateCompletion(ctx, prompt)
 }
 
-// GenerateContent implements the main LangChainGo interface
+// GenerateContent implements the main LangChainGo interface. A streaming function in
+// the options (llms.WithStreamingFunc) receives the completion as Groq streams it.
 func (a *GroqLangChainAdapter) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
-	// For simplicity, we'll convert the messages to a single prompt
-	var prompt string
+	return generateContent(ctx, a.client, messages, options)
+}
+
+// generateContent flattens the messages into a single prompt, as our clients take one,
+// and streams the completion when the options carry a streaming function
+func generateContent(ctx context.Context, client StreamingCompletionClient, messages []llms.MessageContent, options []llms.CallOption) (*llms.ContentResponse, error) {
+	var prompt strings.Builder
 	for _, msg := range messages {
 		for _, part := range msg.Parts {
 			if textPart, ok := part.(llms.TextContent); ok {
-				prompt += textPart.Text + "\n"
+				prompt.WriteString(textPart.Text + "\n")
 			}
 		}
 	}
 
-	response, err := a.client.GenerateCompletion(ctx, prompt)
+	var opts llms.CallOptions
+	for _, option := range options {
+		option(&opts)
+	}
+
+	var response string
+	var err error
+	if opts.StreamingFunc != nil {
+		response, err = client.StreamCompletion(ctx, prompt.String(), opts.StreamingFunc)
+	} else {
+		response, err = client.GenerateCompletion(ctx, prompt.String())
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,229 @@
+package llm
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// EmbeddingCacheStore keeps embeddings under a content key until their TTL runs out
+type EmbeddingCacheStore interface {
+	// Get returns the embedding stored under key; found is false on a miss
+	Get(ctx context.Context, key string) (embedding []float32, found bool, err error)
+	Set(ctx context.Context, key string, embedding []float32, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+	// Flush drops every embedding in the store
+	Flush(ctx context.Context) error
+	// Name identifies the backend in the cache statistics
+	Name() string
+}
+
+// EmbeddingCacheConfig contains embedding cache settings
+type EmbeddingCacheConfig struct {
+	// Namespace is part of every key, so embeddings made by another provider, model
+	// or cache version are never returned
+	Namespace string
+	TTL       time.Duration // 0 keeps embeddings until the store evicts them
+}
+
+// EmbeddingCacheStats counts how often the cache answered instead of the provider
+type EmbeddingCacheStats struct {
+	Backend string  `json:"backend"`
+	Hits    int64   `json:"hits"`
+	Misses  int64   `json:"misses"`
+	Errors  int64   `json:"errors"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// CachedEmbeddingClient answers repeated texts from a cache keyed by a hash of their
+// content, so re-ingesting an unchanged post or repeating a query does not call the
+// provider. A failing cache is logged and bypassed.
+type CachedEmbeddingClient struct {
+	client EmbeddingClient
+	store  EmbeddingCacheStore
+	config EmbeddingCacheConfig
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	errors atomic.Int64
+}
+
+var _ EmbeddingClient = (*CachedEmbeddingClient)(nil)
+
+// NewCachedEmbeddingClient puts store in front of client
+func NewCachedEmbeddingClient(client EmbeddingClient, store EmbeddingCacheStore, config EmbeddingCacheConfig) *CachedEmbeddingClient {
+	return &CachedEmbeddingClient{client: client, store: store, config: config}
+}
+
+// GenerateEmbeddings returns the cached embedding of text, generating and storing it
+// on a miss
+func (c *CachedEmbeddingClient) GenerateEmbeddings(ctx context.Context, text string) ([]float32, error) {
+	key := c.key(text)
+	embedding, found, err := c.store.Get(ctx, key)
+	if err != nil {
+		c.errors.Add(1)
+		log.Printf("Embedding cache read failed, calling the provider: %v", err)
+	} else if found {
+		c.hits.Add(1)
+		return embedding, nil
+	}
+	c.misses.Add(1)
+
+	embedding, err = c.client.GenerateEmbeddings(ctx, text)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.store.Set(ctx, key, embedding, c.config.TTL); err != nil {
+		c.errors.Add(1)
+		log.Printf("Embedding cache write failed: %v", err)
+	}
+	return embedding, nil
+}
+
+// Invalidate drops the cached embedding of text
+func (c *CachedEmbeddingClient) Invalidate(ctx context.Context, text string) error {
+	return c.store.Delete(ctx, c.key(text))
+}
+
+// Flush drops every cached embedding
+func (c *CachedEmbeddingClient) Flush(ctx context.Context) error {
+	return c.store.Flush(ctx)
+}
+
+// Stats returns the hit and miss counts since start
+func (c *CachedEmbeddingClient) Stats() EmbeddingCacheStats {
+	stats := EmbeddingCacheStats{
+		Backend: c.store.Name(),
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Errors:  c.errors.Load(),
+	}
+	if total := stats.Hits + stats.Misses; total > 0 {
+		stats.HitRate = float64(stats.Hits) / float64(total)
+	}
+	return stats
+}
+
+// Health checks the provider; the cache is optional, so it is not checked
+func (c *CachedEmbeddingClient) Health(ctx context.Context) error {
+	return c.client.Health(ctx)
+}
+
+func (c *CachedEmbeddingClient) key(text string) string {
+	sum := sha256.Sum256([]byte(c.config.Namespace + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+// MemoryEmbeddingCache keeps embeddings in process, evicting the least recently used
+// once it holds maxEntries
+type MemoryEmbeddingCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[string]*list.Element
+	order      *list.List // Most recently used first
+	now        func() time.Time
+}
+
+type memoryEmbedding struct {
+	key       string
+	embedding []float32
+	expiresAt time.Time // Zero when the entry does not expire
+}
+
+var _ EmbeddingCacheStore = (*MemoryEmbeddingCache)(nil)
+
+// NewMemoryEmbeddingCache creates an in-process cache of up to maxEntries embeddings,
+// 10000 when not positive
+func NewMemoryEmbeddingCache(maxEntries int) *MemoryEmbeddingCache {
+	if maxEntries <= 0 {
+		maxEntries = 10000
+	}
+	return &MemoryEmbeddingCache{
+		maxEntries: maxEntries,
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+// Get implements EmbeddingCacheStore
+func (m *MemoryEmbeddingCache) Get(ctx context.Context, key string) ([]float32, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	element, ok := m.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*memoryEmbedding)
+	if !entry.expiresAt.IsZero() && !m.now().Before(entry.expiresAt) {
+		m.remove(element)
+		return nil, false, nil
+	}
+	m.order.MoveToFront(element)
+	return entry.embedding, true, nil
+}
+
+// Set implements EmbeddingCacheStore
+func (m *MemoryEmbeddingCache) Set(ctx context.Context, key string, embedding []float32, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := &memoryEmbedding{key: key, embedding: embedding}
+	if ttl > 0 {
+		entry.expiresAt = m.now().Add(ttl)
+	}
+	if element, ok := m.entries[key]; ok {
+		element.Value = entry
+		m.order.MoveToFront(element)
+		return nil
+	}
+	m.entries[key] = m.order.PushFront(entry)
+	for m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+	}
+	return nil
+}
+
+// Delete implements EmbeddingCacheStore
+func (m *MemoryEmbeddingCache) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if element, ok := m.entries[key]; ok {
+		m.remove(element)
+	}
+	return nil
+}
+
+// Flush implements EmbeddingCacheStore
+func (m *MemoryEmbeddingCache) Flush(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.entries = make(map[string]*list.Element)
+	m.order.Init()
+	return nil
+}
+
+// Name implements EmbeddingCacheStore
+func (m *MemoryEmbeddingCache) Name() string {
+	return "memory"
+}
+
+// Len returns the number of cached embeddings, expired ones included until read
+func (m *MemoryEmbeddingCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+func (m *MemoryEmbeddingCache) remove(element *list.Element) {
+	m.order.Remove(element)
+	delete(m.entries, element.Value.(*memoryEmbedding).key)
+}
@@ -0,0 +1,96 @@
+package llm
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const redisEmbeddingPrefix = "ai-engine:embedding:"
+
+// RedisEmbeddingCache keeps embeddings in Redis so every AI engine instance, and a
+// restarted one, shares them
+type RedisEmbeddingCache struct {
+	client *redis.Client
+}
+
+var _ EmbeddingCacheStore = (*RedisEmbeddingCache)(nil)
+
+// NewRedisEmbeddingCache connects to the Redis server at url (redis://[:password@]host:port/db)
+func NewRedisEmbeddingCache(url string) (*RedisEmbeddingCache, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid embedding cache Redis URL: %w", err)
+	}
+	return &RedisEmbeddingCache{client: redis.NewClient(options)}, nil
+}
+
+// Get implements EmbeddingCacheStore
+func (r *RedisEmbeddingCache) Get(ctx context.Context, key string) ([]float32, bool, error) {
+	value, err := r.client.Get(ctx, redisEmbeddingPrefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if len(value)%4 != 0 {
+		return nil, false, fmt.Errorf("cached embedding %s is corrupt", key)
+	}
+	embedding := make([]float32, len(value)/4)
+	for i := range embedding {
+		embedding[i] = math.Float32frombits(binary.LittleEndian.Uint32(value[i*4:]))
+	}
+	return embedding, true, nil
+}
+
+// Set implements EmbeddingCacheStore. Embeddings are stored as little-endian float32s.
+func (r *RedisEmbeddingCache) Set(ctx context.Context, key string, embedding []float32, ttl time.Duration) error {
+	value := make([]byte, len(embedding)*4)
+	for i, v := range embedding {
+		binary.LittleEndian.PutUint32(value[i*4:], math.Float32bits(v))
+	}
+	return r.client.Set(ctx, redisEmbeddingPrefix+key, value, ttl).Err()
+}
+
+// Delete implements EmbeddingCacheStore
+func (r *RedisEmbeddingCache) Delete(ctx context.Context, key string) error {
+	return r.client.Del(ctx, redisEmbeddingPrefix+key).Err()
+}
+
+// Flush implements EmbeddingCacheStore, deleting only the embedding keys
+func (r *RedisEmbeddingCache) Flush(ctx context.Context) error {
+	iter := r.client.Scan(ctx, 0, redisEmbeddingPrefix+"*", 500).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == 500 {
+			if err := r.client.Del(ctx, keys...).Err(); err != nil {
+				return err
+			}
+			keys = keys[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		return r.client.Del(ctx, keys...).Err()
+	}
+	return nil
+}
+
+// Name implements EmbeddingCacheStore
+func (r *RedisEmbeddingCache) Name() string {
+	return "redis"
+}
+
+// Close closes the Redis connection
+func (r *RedisEmbeddingCache) Close() error {
+	return r.client.Close()
+}
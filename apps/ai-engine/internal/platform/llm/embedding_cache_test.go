@@ -0,0 +1,105 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingEmbedder returns the length of the text as its embedding
+type countingEmbedder struct {
+	calls int
+}
+
+func (e *countingEmbedder) GenerateEmbeddings(ctx context.Context, text string) ([]float32, error) {
+	e.calls++
+	return []float32{float32(len(text))}, nil
+}
+
+func (e *countingEmbedder) Health(ctx context.Context) error { return nil }
+
+// failingStore fails every operation
+type failingStore struct{ MemoryEmbeddingCache }
+
+func (*failingStore) Get(ctx context.Context, key string) ([]float32, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+
+func (*failingStore) Set(ctx context.Context, key string, embedding []float32, ttl time.Duration) error {
+	return errors.New("connection refused")
+}
+
+func TestCachedEmbeddingClient(t *testing.T) {
+	ctx := context.Background()
+	embedder := &countingEmbedder{}
+	cache := NewCachedEmbeddingClient(embedder, NewMemoryEmbeddingCache(10), EmbeddingCacheConfig{Namespace: "ollama/nomic-embed-text/1"})
+
+	first, err := cache.GenerateEmbeddings(ctx, "hello")
+	require.NoError(t, err)
+	second, err := cache.GenerateEmbeddings(ctx, "hello")
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, embedder.calls)
+
+	_, err = cache.GenerateEmbeddings(ctx, "hello, world")
+	require.NoError(t, err)
+	assert.Equal(t, 2, embedder.calls)
+	assert.Equal(t, EmbeddingCacheStats{Backend: "memory", Hits: 1, Misses: 2, HitRate: 1.0 / 3}, cache.Stats())
+
+	t.Run("invalidates one text", func(t *testing.T) {
+		require.NoError(t, cache.Invalidate(ctx, "hello"))
+		_, err := cache.GenerateEmbeddings(ctx, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, 3, embedder.calls)
+	})
+
+	t.Run("flushes every text", func(t *testing.T) {
+		require.NoError(t, cache.Flush(ctx))
+		_, err := cache.GenerateEmbeddings(ctx, "hello, world")
+		require.NoError(t, err)
+		assert.Equal(t, 4, embedder.calls)
+	})
+
+	t.Run("does not share embeddings across namespaces", func(t *testing.T) {
+		other := NewCachedEmbeddingClient(embedder, cache.store, EmbeddingCacheConfig{Namespace: "openai/text-embedding-3-small/1"})
+		_, err := other.GenerateEmbeddings(ctx, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, 5, embedder.calls)
+	})
+
+	t.Run("falls back to the provider when the store fails", func(t *testing.T) {
+		failing := NewCachedEmbeddingClient(embedder, &failingStore{}, EmbeddingCacheConfig{})
+		embedding, err := failing.GenerateEmbeddings(ctx, "hello")
+		require.NoError(t, err)
+		assert.Equal(t, []float32{5}, embedding)
+		assert.Equal(t, int64(2), failing.Stats().Errors)
+	})
+}
+
+func TestMemoryEmbeddingCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	cache := NewMemoryEmbeddingCache(2)
+	cache.now = func() time.Time { return now }
+
+	require.NoError(t, cache.Set(ctx, "a", []float32{1}, time.Minute))
+	require.NoError(t, cache.Set(ctx, "b", []float32{2}, 0))
+	_, found, _ := cache.Get(ctx, "a") // a is now the most recently used
+	assert.True(t, found)
+
+	require.NoError(t, cache.Set(ctx, "c", []float32{3}, 0))
+	_, found, _ = cache.Get(ctx, "b")
+	assert.False(t, found, "the least recently used entry is evicted")
+	assert.Equal(t, 2, cache.Len())
+
+	now = now.Add(time.Minute)
+	_, found, _ = cache.Get(ctx, "a")
+	assert.False(t, found, "expired entries are not returned")
+	embedding, found, _ := cache.Get(ctx, "c")
+	assert.True(t, found)
+	assert.Equal(t, []float32{3}, embedding)
+}
@@ -0,0 +1,299 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// ErrCircuitOpen is returned for a provider that failed too often to be called until
+// its cooldown ends
+var ErrCircuitOpen = errors.New("circuit open")
+
+// Circuit breaker states of a completion provider
+const (
+	CircuitClosed   = "closed"    // Calls go through
+	CircuitOpen     = "open"      // Calls are skipped until the cooldown ends
+	CircuitHalfOpen = "half-open" // One trial call decides whether to close again
+)
+
+// CompletionProvider is one completion model the failover client can call
+type CompletionProvider struct {
+	Name  string
+	Model llms.Model
+}
+
+// RetryPolicy controls how a completion is retried and when a provider is skipped
+type RetryPolicy struct {
+	MaxAttempts      int           // Calls per provider, the first included; 1 when not positive
+	BaseDelay        time.Duration // Wait before the first retry, doubled for each one after
+	MaxDelay         time.Duration // Longest wait between retries; 0 for no cap
+	Timeout          time.Duration // Limit of one call; 0 for none
+	BreakerThreshold int           // Failed calls in a row that open a circuit; 0 disables it
+	BreakerCooldown  time.Duration // How long an open circuit skips its provider
+}
+
+// ProviderState is the circuit breaker state of a completion provider
+type ProviderState struct {
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Failures int    `json:"failures"`
+}
+
+// FailoverModel calls completion providers in order: it retries rate limits, server
+// errors and timeouts of a provider with exponential backoff, then moves on to the
+// next one. A provider whose circuit is open is skipped. A streamed completion is
+// neither retried nor failed over once the first chunk was handed out.
+type FailoverModel struct {
+	providers []*failoverProvider
+	policy    RetryPolicy
+	now       func() time.Time
+	sleep     func(ctx context.Context, d time.Duration) error
+}
+
+type failoverProvider struct {
+	CompletionProvider
+	breaker *circuitBreaker
+}
+
+var _ llms.Model = (*FailoverModel)(nil)
+
+// NewFailoverModel calls providers in the order given, the primary first
+func NewFailoverModel(providers []CompletionProvider, policy RetryPolicy) *FailoverModel {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	m := &FailoverModel{policy: policy, now: time.Now, sleep: sleepContext}
+	for _, p := range providers {
+		m.providers = append(m.providers, &failoverProvider{
+			CompletionProvider: p,
+			breaker:            &circuitBreaker{threshold: policy.BreakerThreshold, cooldown: policy.BreakerCooldown},
+		})
+	}
+	return m
+}
+
+// Call implements the deprecated Call method for backwards compatibility
+func (m *FailoverModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// GenerateContent implements llms.Model
+func (m *FailoverModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	var opts llms.CallOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	streamed := false
+	if onChunk := opts.StreamingFunc; onChunk != nil {
+		// A later option wins, so this one sees every chunk
+		options = append(options[:len(options):len(options)], llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			streamed = true
+			return onChunk(ctx, chunk)
+		}))
+	}
+	timeout := m.policy.Timeout
+	if override, ok := requestTimeout(ctx); ok {
+		timeout = override
+	}
+
+	var errs []error
+	for _, p := range m.providers {
+		if !p.breaker.allow(m.now()) {
+			errs = append(errs, fmt.Errorf("%s: %w", p.Name, ErrCircuitOpen))
+			continue
+		}
+		response, err := m.generate(ctx, p, messages, options, timeout, &streamed)
+		if err == nil {
+			return response, nil
+		}
+		if ctx.Err() != nil || streamed || !IsRetryable(err) {
+			return nil, err
+		}
+		log.Printf("Completion provider %s failed, trying the next one: %v", p.Name, err)
+		errs = append(errs, fmt.Errorf("%s: %w", p.Name, err))
+	}
+	return nil, fmt.Errorf("all completion providers failed: %w", errors.Join(errs...))
+}
+
+// generate calls one provider up to MaxAttempts times
+func (m *FailoverModel) generate(ctx context.Context, p *failoverProvider, messages []llms.MessageContent, options []llms.CallOption, timeout time.Duration, streamed *bool) (*llms.ContentResponse, error) {
+	for attempt := 1; ; attempt++ {
+		response, err := m.attempt(ctx, p.Model, messages, options, timeout)
+		if err == nil {
+			p.breaker.record(true, m.now())
+			return response, nil
+		}
+		if ctx.Err() != nil {
+			// The caller gave up; that says nothing about the provider
+			p.breaker.release()
+			return nil, err
+		}
+		if !IsRetryable(err) {
+			// The provider answered; the request is at fault
+			p.breaker.record(true, m.now())
+			return nil, err
+		}
+		if *streamed || attempt >= m.policy.MaxAttempts {
+			p.breaker.record(false, m.now())
+			return nil, err
+		}
+		if err := m.sleep(ctx, m.backoff(attempt)); err != nil {
+			p.breaker.release()
+			return nil, err
+		}
+	}
+}
+
+func (m *FailoverModel) attempt(ctx context.Context, model llms.Model, messages []llms.MessageContent, options []llms.CallOption, timeout time.Duration) (*llms.ContentResponse, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return model.GenerateContent(ctx, messages, options...)
+}
+
+// backoff is the wait after the given failed attempt
+func (m *FailoverModel) backoff(attempt int) time.Duration {
+	delay := m.policy.BaseDelay << (attempt - 1)
+	if m.policy.MaxDelay > 0 && (delay > m.policy.MaxDelay || delay <= 0) {
+		delay = m.policy.MaxDelay
+	}
+	return delay
+}
+
+// States returns the circuit breaker state of each provider, in failover order
+func (m *FailoverModel) States() []ProviderState {
+	states := make([]ProviderState, len(m.providers))
+	for i, p := range m.providers {
+		state, failures := p.breaker.snapshot(m.now())
+		states[i] = ProviderState{Provider: p.Name, State: state, Failures: failures}
+	}
+	return states
+}
+
+// statusPattern finds the HTTP status in the errors of our clients ("status 429") and
+// of langchaingo's ("status code: 503")
+var statusPattern = regexp.MustCompile(`status(?: code)?:? (\d{3})`)
+
+// IsRetryable reports whether a completion error may pass on another try: rate limits,
+// server errors, timeouts and failed connections are; other client errors are not
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	match := statusPattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return true // No answer from the provider at all
+	}
+	status, _ := strconv.Atoi(match[1])
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+type requestTimeoutKey struct{}
+
+// RequestTimeoutKey is the context key of a per-request timeout override. The HTTP API
+// stores a time.Duration under it in the request locals, which the request context reads.
+var RequestTimeoutKey = requestTimeoutKey{}
+
+// WithRequestTimeout overrides the per-call timeout of the failover client for ctx
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, RequestTimeoutKey, timeout)
+}
+
+func requestTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(RequestTimeoutKey).(time.Duration)
+	return timeout, ok && timeout > 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// circuitBreaker opens after threshold failed calls in a row and, once the cooldown
+// has passed, lets a single trial call through to decide whether to close again
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     string
+	failures  int
+	openedAt  time.Time
+}
+
+func (b *circuitBreaker) allow(now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case CircuitOpen:
+		if now.Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = CircuitHalfOpen
+		return true
+	case CircuitHalfOpen:
+		return false // The trial call is still running
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) record(ok bool, now time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ok {
+		b.state = CircuitClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.threshold > 0 && (b.state == CircuitHalfOpen || b.failures >= b.threshold) {
+		b.state = CircuitOpen
+		b.openedAt = now
+	}
+}
+
+// release ends a call that decided nothing, letting another trial through
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitHalfOpen {
+		b.state = CircuitOpen
+		b.openedAt = time.Time{}
+	}
+}
+
+func (b *circuitBreaker) snapshot(now time.Time) (string, int) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == "" {
+		return CircuitClosed, b.failures
+	}
+	if b.state == CircuitOpen && now.Sub(b.openedAt) >= b.cooldown {
+		return CircuitHalfOpen, b.failures
+	}
+	return b.state, b.failures
+}
@@ -0,0 +1,190 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// scriptedModel fails with the queued errors, then answers with its name
+type scriptedModel struct {
+	name  string
+	errs  []error
+	calls int
+}
+
+func (s *scriptedModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, s, prompt, options...)
+}
+
+func (s *scriptedModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	s.calls++
+	if len(s.errs) > 0 {
+		err := s.errs[0]
+		s.errs = s.errs[1:]
+		return nil, err
+	}
+	var opts llms.CallOptions
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.StreamingFunc != nil {
+		if err := opts.StreamingFunc(ctx, []byte(s.name)); err != nil {
+			return nil, err
+		}
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: s.name}}}, nil
+}
+
+func statusError(provider string, status int) error {
+	return fmt.Errorf("%s API returned status %d: unavailable", provider, status)
+}
+
+func newTestFailover(policy RetryPolicy, models ...*scriptedModel) (*FailoverModel, *[]time.Duration, *time.Time) {
+	var providers []CompletionProvider
+	for _, model := range models {
+		providers = append(providers, CompletionProvider{Name: model.name, Model: model})
+	}
+	m := NewFailoverModel(providers, policy)
+	var waits []time.Duration
+	now := time.Unix(1700000000, 0)
+	m.now = func() time.Time { return now }
+	m.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return m, &waits, &now
+}
+
+func TestFailoverModel(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 150 * time.Millisecond}
+
+	t.Run("retries rate limits with backoff", func(t *testing.T) {
+		groq := &scriptedModel{name: "groq", errs: []error{statusError("groq", 429), statusError("groq", 503)}}
+		m, waits, _ := newTestFailover(policy, groq)
+
+		answer, err := llms.GenerateFromSinglePrompt(ctx, m, "hi")
+		require.NoError(t, err)
+		assert.Equal(t, "groq", answer)
+		assert.Equal(t, 3, groq.calls)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 150 * time.Millisecond}, *waits)
+	})
+
+	t.Run("fails over when the primary keeps failing", func(t *testing.T) {
+		groq := &scriptedModel{name: "groq", errs: []error{statusError("groq", 500), statusError("groq", 500), statusError("groq", 500)}}
+		ollama := &scriptedModel{name: "ollama"}
+		m, _, _ := newTestFailover(policy, groq, ollama)
+
+		answer, err := llms.GenerateFromSinglePrompt(ctx, m, "hi")
+		require.NoError(t, err)
+		assert.Equal(t, "ollama", answer)
+		assert.Equal(t, 3, groq.calls)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		groq := &scriptedModel{name: "groq", errs: []error{statusError("groq", 400)}}
+		ollama := &scriptedModel{name: "ollama"}
+		m, _, _ := newTestFailover(policy, groq, ollama)
+
+		_, err := llms.GenerateFromSinglePrompt(ctx, m, "hi")
+		assert.Error(t, err)
+		assert.Equal(t, 1, groq.calls)
+		assert.Zero(t, ollama.calls)
+	})
+
+	t.Run("does not fail over a started stream", func(t *testing.T) {
+		groq := &scriptedModel{name: "groq"}
+		m, _, _ := newTestFailover(policy, groq, &scriptedModel{name: "ollama"})
+		var chunks []string
+		_, err := llms.GenerateFromSinglePrompt(ctx, m, "hi", llms.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			chunks = append(chunks, string(chunk))
+			return statusError("client", 503)
+		}))
+		assert.Error(t, err)
+		assert.Equal(t, []string{"groq"}, chunks)
+		assert.Equal(t, 1, groq.calls)
+	})
+
+	t.Run("opens the circuit of a failing provider", func(t *testing.T) {
+		groq := &scriptedModel{name: "groq"}
+		for i := 0; i < 4; i++ {
+			groq.errs = append(groq.errs, statusError("groq", 502))
+		}
+		ollama := &scriptedModel{name: "ollama"}
+		m, _, now := newTestFailover(RetryPolicy{MaxAttempts: 1, BreakerThreshold: 2, BreakerCooldown: time.Minute}, groq, ollama)
+
+		for i := 0; i < 3; i++ {
+			answer, err := llms.GenerateFromSinglePrompt(ctx, m, "hi")
+			require.NoError(t, err)
+			assert.Equal(t, "ollama", answer)
+		}
+		assert.Equal(t, 2, groq.calls, "an open circuit skips the provider")
+		assert.Equal(t, []ProviderState{{Provider: "groq", State: CircuitOpen, Failures: 2}, {Provider: "ollama", State: CircuitClosed}}, m.States())
+
+		*now = now.Add(time.Minute)
+		_, err := llms.GenerateFromSinglePrompt(ctx, m, "hi")
+		require.NoError(t, err)
+		assert.Equal(t, 3, groq.calls, "the trial call after the cooldown fails")
+		assert.Equal(t, CircuitOpen, m.States()[0].State)
+
+		*now = now.Add(time.Minute)
+		groq.errs = nil
+		answer, err := llms.GenerateFromSinglePrompt(ctx, m, "hi")
+		require.NoError(t, err)
+		assert.Equal(t, "groq", answer)
+		assert.Equal(t, CircuitClosed, m.States()[0].State)
+	})
+
+	t.Run("reports every failure when all providers fail", func(t *testing.T) {
+		m, _, _ := newTestFailover(RetryPolicy{}, &scriptedModel{name: "groq", errs: []error{statusError("groq", 503)}},
+			&scriptedModel{name: "ollama", errs: []error{errors.New("ollama service is not available")}})
+
+		_, err := llms.GenerateFromSinglePrompt(ctx, m, "hi")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "groq: groq API returned status 503")
+		assert.Contains(t, err.Error(), "ollama: ollama service is not available")
+	})
+}
+
+func TestFailoverModelRequestTimeout(t *testing.T) {
+	var deadline time.Duration
+	model := &deadlineModel{seen: &deadline}
+	m := NewFailoverModel([]CompletionProvider{{Name: "ollama", Model: model}}, RetryPolicy{Timeout: time.Minute})
+
+	ctx := WithRequestTimeout(context.Background(), 5*time.Second)
+	_, err := llms.GenerateFromSinglePrompt(ctx, m, "hi")
+	require.NoError(t, err)
+	assert.InDelta(t, 5*time.Second, deadline, float64(time.Second))
+}
+
+// deadlineModel records how long it was given
+type deadlineModel struct {
+	seen *time.Duration
+}
+
+func (d *deadlineModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, d, prompt, options...)
+}
+
+func (d *deadlineModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	if deadline, ok := ctx.Deadline(); ok {
+		*d.seen = time.Until(deadline)
+	}
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "ok"}}}, nil
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, IsRetryable(errors.New("API returned unexpected status code: 429: rate limited")))
+	assert.True(t, IsRetryable(errors.New("ollama API request failed with status 503: loading model")))
+	assert.True(t, IsRetryable(errors.New("dial tcp: connection refused")))
+	assert.True(t, IsRetryable(fmt.Errorf("groq: %w", context.DeadlineExceeded)))
+	assert.False(t, IsRetryable(errors.New("groq API returned status 401: invalid key")))
+	assert.False(t, IsRetryable(context.Canceled))
+}
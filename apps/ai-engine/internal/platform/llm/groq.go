@@ -1,26 +1,32 @@
 package llm
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
+const groqBaseURL = "https://api.groq.com/openai/v1"
 
 type GroqClient struct {
 	apiKey          string
+	baseURL         string
 	httpClient      *http.Client
 	completionModel string
 }
 
-var _ CompletionClient = (*GroqClient)(nil)
+var _ StreamingCompletionClient = (*GroqClient)(nil)
 
 // GroqConfig contains Groq client configuration
 type GroqConfig struct {
 	APIKey          string
+	BaseURL         string // Defaults to the Groq cloud API
 	CompletionModel string
 	Timeout         time.Duration
 }
@@ -36,9 +42,13 @@ func NewGroqClient(config GroqConfig) (*GroqClient, error) {
 	if config.Timeout == 0 {
 		config.Timeout = 30 * time.Second
 	}
+	if config.BaseURL == "" {
+		config.BaseURL = groqBaseURL
+	}
 	
 	return &GroqClient{
 		apiKey:          config.APIKey,
+		baseURL:         config.BaseURL,
 		httpClient:      &http.Client{Timeout: config.Timeout},
 		completionModel: config.CompletionModel,
 	}, nil
@@ -66,7 +76,7 @@ type groqCompletionResponse struct {
 
 // GenerateCompletion sends a prompt to the Groq API and gets a completion.
 func (c *GroqClient) GenerateCompletion(ctx context.Context, prompt string) (string, error) {
-	apiURL := "https://api.groq.com/openai/v1/chat/completions"
+	apiURL := c.baseURL + "/chat/completions"
 
 	reqBody := groqCompletionRequest{
 		Model: c.completionModel,
@@ -112,6 +122,77 @@ func (c *GroqClient) GenerateCompletion(ctx context.Context, prompt string) (str
 	return groqResp.Choices[0].Message.Content, nil
 }
 
+// groqStreamChunk is one server-sent event of a streamed completion
+type groqStreamChunk struct {
+	Choices []struct {
+		Delta message `json:"delta"`
+	} `json:"choices"`
+}
+
+// StreamCompletion generates a completion with Groq's OpenAI-compatible streaming API,
+// which answers with server-sent events ending in "data: [DONE]"
+func (c *GroqClient) StreamCompletion(ctx context.Context, prompt string, onChunk func(ctx context.Context, chunk []byte) error) (string, error) {
+	jsonBody, err := json.Marshal(groqCompletionRequest{
+		Model: c.completionModel,
+		Messages: []message{
+			{Role: "user", Content: prompt},
+		},
+		Stream: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal groq request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/chat/completions", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create groq request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send request to groq: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("groq API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var completion strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data:")
+		if !ok {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			return completion.String(), nil
+		}
+
+		var chunk groqStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			return "", fmt.Errorf("failed to decode groq stream: %w", err)
+		}
+		if len(chunk.Choices) == 0 || chunk.Choices[0].Delta.Content == "" {
+			continue
+		}
+		completion.WriteString(chunk.Choices[0].Delta.Content)
+		if err := onChunk(ctx, []byte(chunk.Choices[0].Delta.Content)); err != nil {
+			return "", err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read groq stream: %w", err)
+	}
+	return completion.String(), nil
+}
 
 // Health checks Groq service availability
 func (c *GroqClient) Health(ctx context.Context) error {
@@ -8,6 +8,14 @@ type CompletionClient interface {
 	Health(ctx context.Context) error 
 }
 
+// StreamingCompletionClient generates a completion piece by piece, handing each chunk
+// to onChunk as the provider sends it. It returns the whole completion; an error from
+// onChunk stops the generation.
+type StreamingCompletionClient interface {
+	CompletionClient
+	StreamCompletion(ctx context.Context, prompt string, onChunk func(ctx context.Context, chunk []byte) error) (string, error)
+}
+
 // EmbeddingClient is responsible for turning text into vector embeddings.
 type EmbeddingClient interface {
 	GenerateEmbeddings(ctx context.Context, text string) ([]float32, error)
@@ -0,0 +1,134 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// charsPerToken estimates token counts for providers that report none
+const charsPerToken = 4
+
+// MeteredModel records the tokens of each completion of one provider with a
+// UsageTracker. It reads the counts the provider reports (langchaingo's OpenAI client
+// puts them in the generation info) and estimates them from the text otherwise.
+type MeteredModel struct {
+	provider string
+	model    llms.Model
+	tracker  *UsageTracker
+}
+
+var _ llms.Model = (*MeteredModel)(nil)
+
+// NewMeteredModel records the completions of model as provider's
+func NewMeteredModel(provider string, model llms.Model, tracker *UsageTracker) *MeteredModel {
+	return &MeteredModel{provider: provider, model: model, tracker: tracker}
+}
+
+// Call implements the deprecated Call method for backwards compatibility
+func (m *MeteredModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// GenerateContent implements llms.Model
+func (m *MeteredModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	response, err := m.model.GenerateContent(ctx, messages, options...)
+	if err != nil {
+		return nil, err
+	}
+	usage, estimated := tokenUsage(messages, response)
+	m.tracker.Record(ctx, m.provider, usage, estimated)
+	return response, nil
+}
+
+// tokenUsage returns the counts the provider reported, or estimates them
+func tokenUsage(messages []llms.MessageContent, response *llms.ContentResponse) (TokenUsage, bool) {
+	var usage TokenUsage
+	reported := false
+	var completionChars int
+	for _, choice := range response.Choices {
+		completionChars += len(choice.Content)
+		if prompt, ok := generationCount(choice.GenerationInfo, "PromptTokens"); ok {
+			// Every choice repeats the prompt count
+			usage.PromptTokens = prompt
+			reported = true
+		}
+		if completion, ok := generationCount(choice.GenerationInfo, "CompletionTokens"); ok {
+			usage.CompletionTokens += completion
+			reported = true
+		}
+	}
+	if !reported {
+		var promptChars int
+		for _, message := range messages {
+			for _, part := range message.Parts {
+				if text, ok := part.(llms.TextContent); ok {
+					promptChars += len(text.Text)
+				}
+			}
+		}
+		usage.PromptTokens = estimateTokens(promptChars)
+		usage.CompletionTokens = estimateTokens(completionChars)
+	}
+	usage.TotalTokens = usage.PromptTokens + usage.CompletionTokens
+	return usage, !reported
+}
+
+func generationCount(info map[string]any, key string) (int64, bool) {
+	switch v := info[key].(type) {
+	case int:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		return int64(v), true
+	}
+	return 0, false
+}
+
+func estimateTokens(chars int) int64 {
+	return int64((chars + charsPerToken - 1) / charsPerToken)
+}
+
+// BudgetedModel enforces the budgets of a UsageTracker before each completion. Once a
+// budget is spent it fails with ErrBudgetExceeded or, given a downgrade model, hands
+// the completion to that one instead.
+type BudgetedModel struct {
+	model     llms.Model
+	downgrade llms.Model
+	tracker   *UsageTracker
+}
+
+var _ llms.Model = (*BudgetedModel)(nil)
+
+// NewBudgetedModel guards model with the budgets of tracker. downgrade may be nil to
+// reject completions over budget.
+func NewBudgetedModel(model, downgrade llms.Model, tracker *UsageTracker) *BudgetedModel {
+	return &BudgetedModel{model: model, downgrade: downgrade, tracker: tracker}
+}
+
+// Call implements the deprecated Call method for backwards compatibility
+func (m *BudgetedModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+// GenerateContent implements llms.Model
+func (m *BudgetedModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	if err := m.tracker.Check(ctx); err != nil {
+		if m.downgrade == nil {
+			return nil, err
+		}
+		response, err := m.downgrade.GenerateContent(ctx, messages, options...)
+		if err != nil {
+			return nil, fmt.Errorf("downgraded completion: %w", err)
+		}
+		return response, nil
+	}
+	return m.model.GenerateContent(ctx, messages, options...)
+}
+
+// Unwrap returns the model completions within budget go to
+func (m *BudgetedModel) Unwrap() llms.Model {
+	return m.model
+}
@@ -1,12 +1,14 @@
 package llm
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -20,7 +22,7 @@ type OllamaClient struct {
 
 // Ensure OllamaClient satisfies both interfaces
 var _ EmbeddingClient = (*OllamaClient)(nil)
-var _ CompletionClient = (*OllamaClient)(nil)
+var _ StreamingCompletionClient = (*OllamaClient)(nil)
 
 // OllamaConfig contains Ollama client configuration
 type OllamaConfig struct {
@@ -157,6 +159,67 @@ func (c *OllamaClient) GenerateCompletion(ctx context.Context, prompt string) (s
 	return genResp.Response, nil
 }
 
+// StreamCompletion generates a completion with Ollama's streaming API, which answers
+// with one JSON object per line, each carrying the next piece of the response
+func (c *OllamaClient) StreamCompletion(ctx context.Context, prompt string, onChunk func(ctx context.Context, chunk []byte) error) (string, error) {
+	url := fmt.Sprintf("%s/api/generate", c.baseURL)
+
+	jsonBody, err := json.Marshal(ollamaGenerateRequest{
+		Model:  c.completionModel,
+		Prompt: prompt,
+		Stream: true,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonBody))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("ollama service is not available - please ensure ollama is running at %s: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("ollama API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var completion strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var genResp ollamaGenerateResponse
+		if err := json.Unmarshal(line, &genResp); err != nil {
+			return "", fmt.Errorf("failed to decode ollama stream: %w", err)
+		}
+		if genResp.Response != "" {
+			completion.WriteString(genResp.Response)
+			if err := onChunk(ctx, []byte(genResp.Response)); err != nil {
+				return "", err
+			}
+		}
+		if genResp.Done {
+			return completion.String(), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read ollama stream: %w", err)
+	}
+	return completion.String(), nil
+}
+
 // Health checks Ollama service availability
 func (c *OllamaClient) Health(ctx context.Context) error {
 	url := fmt.Sprintf("%s/api/tags", c.baseURL)
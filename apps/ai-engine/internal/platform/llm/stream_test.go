@@ -0,0 +1,116 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// collect returns an onChunk that records every chunk
+func collect(chunks *[]string) func(ctx context.Context, chunk []byte) error {
+	return func(ctx context.Context, chunk []byte) error {
+		*chunks = append(*chunks, string(chunk))
+		return nil
+	}
+}
+
+func TestOllamaStreamCompletion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaGenerateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "/api/generate", r.URL.Path)
+		assert.True(t, req.Stream)
+
+		fmt.Fprintln(w, `{"response":"Hello","done":false}`)
+		fmt.Fprintln(w, `{"response":", world","done":false}`)
+		fmt.Fprintln(w, `{"response":"","done":true}`)
+	}))
+	defer server.Close()
+
+	client := NewOllamaClient(OllamaConfig{BaseURL: server.URL})
+	var chunks []string
+	completion, err := client.StreamCompletion(context.Background(), "hi", collect(&chunks))
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, world", completion)
+	assert.Equal(t, []string{"Hello", ", world"}, chunks)
+}
+
+func TestGroqStreamCompletion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req groqCompletionRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer key", r.Header.Get("Authorization"))
+		assert.True(t, req.Stream)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"role\":\"assistant\"}}]}\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"Go \"}}]}\n\n")
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "data: {\"choices\":[{\"delta\":{\"content\":\"rocks\"}}]}\n\n")
+		fmt.Fprint(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	client, err := NewGroqClient(GroqConfig{APIKey: "key", BaseURL: server.URL})
+	require.NoError(t, err)
+	var chunks []string
+	completion, err := client.StreamCompletion(context.Background(), "hi", collect(&chunks))
+	require.NoError(t, err)
+	assert.Equal(t, "Go rocks", completion)
+	assert.Equal(t, []string{"Go ", "rocks"}, chunks)
+}
+
+func TestStreamCompletion_StopsWhenConsumerFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"response":"one","done":false}`)
+		fmt.Fprintln(w, `{"response":"two","done":false}`)
+		fmt.Fprintln(w, `{"response":"","done":true}`)
+	}))
+	defer server.Close()
+
+	client := NewOllamaClient(OllamaConfig{BaseURL: server.URL})
+	calls := 0
+	_, err := client.StreamCompletion(context.Background(), "hi", func(ctx context.Context, chunk []byte) error {
+		calls++
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, 1, calls)
+}
+
+func TestAdapterStreamsWithStreamingFunc(t *testing.T) {
+	streamed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req ollamaGenerateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		streamed = req.Stream
+		if req.Stream {
+			fmt.Fprintln(w, `{"response":"streamed","done":true}`)
+			return
+		}
+		fmt.Fprint(w, `{"response":"buffered","done":true}`)
+	}))
+	defer server.Close()
+
+	adapter := NewOllamaLangChainAdapter(NewOllamaClient(OllamaConfig{BaseURL: server.URL}))
+
+	completion, err := llms.GenerateFromSinglePrompt(context.Background(), adapter, "hi")
+	require.NoError(t, err)
+	assert.Equal(t, "buffered", completion)
+	assert.False(t, streamed)
+
+	var chunks []string
+	completion, err = llms.GenerateFromSinglePrompt(context.Background(), adapter, "hi", llms.WithStreamingFunc(collect(&chunks)))
+	require.NoError(t, err)
+	assert.Equal(t, "streamed", completion)
+	assert.True(t, streamed)
+	assert.Equal(t, []string{"streamed"}, chunks)
+}
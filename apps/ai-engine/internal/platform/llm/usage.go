@@ -0,0 +1,313 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned for a completion once a monthly token budget it counts
+// against is spent
+var ErrBudgetExceeded = errors.New("monthly token budget exceeded")
+
+// What happens to completions once a budget is spent
+const (
+	BudgetReject    = "reject"    // They fail with ErrBudgetExceeded
+	BudgetDowngrade = "downgrade" // They go to the downgrade provider instead
+)
+
+// DefaultCommunity stands in for requests that name no community
+const DefaultCommunity = "default"
+
+// usagePeriodLayout names the month usage is counted in, in UTC
+const usagePeriodLayout = "2006-01"
+
+// TokenUsage counts the tokens of completions
+type TokenUsage struct {
+	PromptTokens     int64 `json:"prompt_tokens"`
+	CompletionTokens int64 `json:"completion_tokens"`
+	TotalTokens      int64 `json:"total_tokens"`
+}
+
+func (u *TokenUsage) add(other TokenUsage) {
+	u.PromptTokens += other.PromptTokens
+	u.CompletionTokens += other.CompletionTokens
+	u.TotalTokens += other.TotalTokens
+}
+
+// UsageTotals are the tokens used in one period, overall and by community and provider
+type UsageTotals struct {
+	Total       TokenUsage            `json:"total"`
+	Communities map[string]TokenUsage `json:"communities"`
+	Providers   map[string]TokenUsage `json:"providers"`
+}
+
+func newUsageTotals() *UsageTotals {
+	return &UsageTotals{Communities: map[string]TokenUsage{}, Providers: map[string]TokenUsage{}}
+}
+
+func (t *UsageTotals) add(community, provider string, usage TokenUsage) {
+	t.Total.add(usage)
+	c := t.Communities[community]
+	c.add(usage)
+	t.Communities[community] = c
+	p := t.Providers[provider]
+	p.add(usage)
+	t.Providers[provider] = p
+}
+
+// UsageStore keeps token counters by period
+type UsageStore interface {
+	// Add counts usage of provider by community in period
+	Add(ctx context.Context, period, community, provider string, usage TokenUsage) error
+	// Totals returns the counters of period, empty when nothing was used in it
+	Totals(ctx context.Context, period string) (*UsageTotals, error)
+	// Name identifies the backend in the usage report
+	Name() string
+}
+
+// UsageScope is who a completion is made for
+type UsageScope struct {
+	RequestID string
+	Community string
+}
+
+type usageScopeKey struct{}
+
+// UsageScopeKey is the context key of the usage scope of an API request. The HTTP API
+// stores a UsageScope under it in the request locals, which the request context reads.
+var UsageScopeKey = usageScopeKey{}
+
+// WithUsageScope counts the completions made with ctx against scope
+func WithUsageScope(ctx context.Context, scope UsageScope) context.Context {
+	return context.WithValue(ctx, UsageScopeKey, scope)
+}
+
+func usageScope(ctx context.Context) (UsageScope, bool) {
+	scope, ok := ctx.Value(UsageScopeKey).(UsageScope)
+	if scope.Community == "" {
+		scope.Community = DefaultCommunity
+	}
+	return scope, ok
+}
+
+// Budgets are monthly token limits; 0 is no limit
+type Budgets struct {
+	Monthly     int64            // All completions
+	Community   int64            // Each community without a budget of its own
+	Communities map[string]int64 // Budgets of single communities
+	Action      string           // BudgetReject or BudgetDowngrade
+}
+
+// communityBudget is the budget of community, 0 for none
+func (b Budgets) communityBudget(community string) int64 {
+	if budget, ok := b.Communities[community]; ok {
+		return budget
+	}
+	return b.Community
+}
+
+// RequestUsage is the usage of one completion
+type RequestUsage struct {
+	RequestID string     `json:"request_id,omitempty"`
+	Community string     `json:"community"`
+	Provider  string     `json:"provider"`
+	Usage     TokenUsage `json:"usage"`
+	Estimated bool       `json:"estimated"` // The provider reported no counts; they were estimated from the text
+	At        time.Time  `json:"at"`
+}
+
+// BudgetStatus is how much of a budget is spent
+type BudgetStatus struct {
+	Scope     string `json:"scope"` // "monthly" or "community:<id>"
+	Limit     int64  `json:"limit"`
+	Used      int64  `json:"used"`
+	Remaining int64  `json:"remaining"`
+	Exceeded  bool   `json:"exceeded"`
+}
+
+// UsageReport is the token usage of the current month
+type UsageReport struct {
+	Period       string `json:"period"`
+	Backend      string `json:"backend"`
+	BudgetAction string `json:"budget_action"`
+	UsageTotals
+	Budgets []BudgetStatus `json:"budgets"`
+	Recent  []RequestUsage `json:"recent"` // The latest completions of this instance, newest first
+}
+
+// UsageTracker counts the tokens completions use and enforces monthly budgets on them.
+// A failing store is logged and does not block completions.
+type UsageTracker struct {
+	store   UsageStore
+	budgets Budgets
+
+	mu        sync.Mutex
+	recent    []RequestUsage // Ring of the latest completions
+	next      int
+	maxRecent int
+
+	now func() time.Time
+}
+
+// NewUsageTracker counts usage in store and remembers the latest maxRecent completions
+func NewUsageTracker(store UsageStore, budgets Budgets, maxRecent int) *UsageTracker {
+	if budgets.Action == "" {
+		budgets.Action = BudgetReject
+	}
+	return &UsageTracker{store: store, budgets: budgets, maxRecent: maxRecent, now: time.Now}
+}
+
+func (t *UsageTracker) period() string {
+	return t.now().UTC().Format(usagePeriodLayout)
+}
+
+// Record counts usage of provider against the scope of ctx
+func (t *UsageTracker) Record(ctx context.Context, provider string, usage TokenUsage, estimated bool) {
+	scope, _ := usageScope(ctx)
+	if err := t.store.Add(ctx, t.period(), scope.Community, provider, usage); err != nil {
+		log.Printf("Failed to record token usage: %v", err)
+	}
+	if t.maxRecent <= 0 {
+		return
+	}
+
+	entry := RequestUsage{RequestID: scope.RequestID, Community: scope.Community, Provider: provider, Usage: usage, Estimated: estimated, At: t.now()}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.recent) < t.maxRecent {
+		t.recent = append(t.recent, entry)
+	} else {
+		t.recent[t.next] = entry
+	}
+	t.next = (t.next + 1) % t.maxRecent
+}
+
+// Check returns ErrBudgetExceeded when a budget the completions of ctx count against is
+// spent. Completions outside an API request, like health checks, are not limited.
+func (t *UsageTracker) Check(ctx context.Context) error {
+	scope, ok := usageScope(ctx)
+	if !ok || (t.budgets.Monthly <= 0 && t.budgets.communityBudget(scope.Community) <= 0) {
+		return nil
+	}
+	totals, err := t.store.Totals(ctx, t.period())
+	if err != nil {
+		log.Printf("Failed to read token usage, not enforcing budgets: %v", err)
+		return nil
+	}
+	if limit := t.budgets.Monthly; limit > 0 && totals.Total.TotalTokens >= limit {
+		return fmt.Errorf("%w: %d of %d tokens used this month", ErrBudgetExceeded, totals.Total.TotalTokens, limit)
+	}
+	if limit := t.budgets.communityBudget(scope.Community); limit > 0 {
+		if used := totals.Communities[scope.Community].TotalTokens; used >= limit {
+			return fmt.Errorf("%w: community %s used %d of %d tokens this month", ErrBudgetExceeded, scope.Community, used, limit)
+		}
+	}
+	return nil
+}
+
+// Action is what happens to completions once a budget is spent
+func (t *UsageTracker) Action() string {
+	return t.budgets.Action
+}
+
+// Report returns this month's usage, the state of each budget and the latest completions
+func (t *UsageTracker) Report(ctx context.Context) (*UsageReport, error) {
+	period := t.period()
+	totals, err := t.store.Totals(ctx, period)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token usage: %w", err)
+	}
+
+	report := &UsageReport{Period: period, Backend: t.store.Name(), BudgetAction: t.budgets.Action, UsageTotals: *totals, Budgets: []BudgetStatus{}}
+	if t.budgets.Monthly > 0 {
+		report.Budgets = append(report.Budgets, budgetStatus("monthly", t.budgets.Monthly, totals.Total.TotalTokens))
+	}
+	communities := make(map[string]bool)
+	for community := range totals.Communities {
+		communities[community] = true
+	}
+	for community := range t.budgets.Communities {
+		communities[community] = true
+	}
+	names := make([]string, 0, len(communities))
+	for community := range communities {
+		names = append(names, community)
+	}
+	sort.Strings(names)
+	for _, community := range names {
+		if limit := t.budgets.communityBudget(community); limit > 0 {
+			report.Budgets = append(report.Budgets, budgetStatus("community:"+community, limit, totals.Communities[community].TotalTokens))
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	report.Recent = make([]RequestUsage, 0, len(t.recent))
+	for i := 1; i <= len(t.recent); i++ {
+		report.Recent = append(report.Recent, t.recent[(t.next-i+len(t.recent))%len(t.recent)])
+	}
+	return report, nil
+}
+
+func budgetStatus(scope string, limit, used int64) BudgetStatus {
+	remaining := limit - used
+	if remaining < 0 {
+		remaining = 0
+	}
+	return BudgetStatus{Scope: scope, Limit: limit, Used: used, Remaining: remaining, Exceeded: used >= limit}
+}
+
+// MemoryUsageStore keeps token counters in process; they start over on restart
+type MemoryUsageStore struct {
+	mu      sync.Mutex
+	periods map[string]*UsageTotals
+}
+
+var _ UsageStore = (*MemoryUsageStore)(nil)
+
+// NewMemoryUsageStore creates an empty in-process usage store
+func NewMemoryUsageStore() *MemoryUsageStore {
+	return &MemoryUsageStore{periods: make(map[string]*UsageTotals)}
+}
+
+// Add implements UsageStore
+func (m *MemoryUsageStore) Add(ctx context.Context, period, community, provider string, usage TokenUsage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals, ok := m.periods[period]
+	if !ok {
+		totals = newUsageTotals()
+		m.periods[period] = totals
+	}
+	totals.add(community, provider, usage)
+	return nil
+}
+
+// Totals implements UsageStore, returning a copy
+func (m *MemoryUsageStore) Totals(ctx context.Context, period string) (*UsageTotals, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totals := newUsageTotals()
+	if stored, ok := m.periods[period]; ok {
+		totals.Total = stored.Total
+		for community, usage := range stored.Communities {
+			totals.Communities[community] = usage
+		}
+		for provider, usage := range stored.Providers {
+			totals.Providers[provider] = usage
+		}
+	}
+	return totals, nil
+}
+
+// Name implements UsageStore
+func (m *MemoryUsageStore) Name() string {
+	return "memory"
+}
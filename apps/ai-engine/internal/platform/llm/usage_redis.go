@@ -0,0 +1,103 @@
+package llm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const redisUsagePrefix = "ai-engine:usage:"
+
+// redisUsageRetention keeps a month's counters for about a year after it ends
+const redisUsageRetention = 400 * 24 * time.Hour
+
+// RedisUsageStore keeps token counters in Redis, one hash per month, so every AI engine
+// instance counts against the same budgets
+type RedisUsageStore struct {
+	client *redis.Client
+}
+
+var _ UsageStore = (*RedisUsageStore)(nil)
+
+// NewRedisUsageStore connects to the Redis server at url (redis://[:password@]host:port/db)
+func NewRedisUsageStore(url string) (*RedisUsageStore, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid usage Redis URL: %w", err)
+	}
+	return &RedisUsageStore{client: redis.NewClient(options)}, nil
+}
+
+// Add implements UsageStore. Counters are hash fields named <scope>|<name>|<kind>, with
+// scope "total", "community" or "provider".
+func (r *RedisUsageStore) Add(ctx context.Context, period, community, provider string, usage TokenUsage) error {
+	key := redisUsagePrefix + period
+	_, err := r.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, field := range []string{"total|", "community|" + community, "provider|" + provider} {
+			pipe.HIncrBy(ctx, key, field+"|prompt", usage.PromptTokens)
+			pipe.HIncrBy(ctx, key, field+"|completion", usage.CompletionTokens)
+			pipe.HIncrBy(ctx, key, field+"|total", usage.TotalTokens)
+		}
+		pipe.Expire(ctx, key, redisUsageRetention)
+		return nil
+	})
+	return err
+}
+
+// Totals implements UsageStore
+func (r *RedisUsageStore) Totals(ctx context.Context, period string) (*UsageTotals, error) {
+	fields, err := r.client.HGetAll(ctx, redisUsagePrefix+period).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make(map[string]*TokenUsage)
+	for field, value := range fields {
+		i := strings.LastIndex(field, "|")
+		count, err := strconv.ParseInt(value, 10, 64)
+		if i < 0 || err != nil {
+			continue
+		}
+		counter, ok := counters[field[:i]]
+		if !ok {
+			counter = &TokenUsage{}
+			counters[field[:i]] = counter
+		}
+		switch field[i+1:] {
+		case "prompt":
+			counter.PromptTokens = count
+		case "completion":
+			counter.CompletionTokens = count
+		case "total":
+			counter.TotalTokens = count
+		}
+	}
+
+	totals := newUsageTotals()
+	for name, counter := range counters {
+		scope, id, _ := strings.Cut(name, "|")
+		switch scope {
+		case "total":
+			totals.Total = *counter
+		case "community":
+			totals.Communities[id] = *counter
+		case "provider":
+			totals.Providers[id] = *counter
+		}
+	}
+	return totals, nil
+}
+
+// Name implements UsageStore
+func (r *RedisUsageStore) Name() string {
+	return "redis"
+}
+
+// Close closes the Redis connection
+func (r *RedisUsageStore) Close() error {
+	return r.client.Close()
+}
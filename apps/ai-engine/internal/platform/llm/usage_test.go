@@ -0,0 +1,138 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms"
+)
+
+func newTestTracker(budgets Budgets, recent int) *UsageTracker {
+	tracker := NewUsageTracker(NewMemoryUsageStore(), budgets, recent)
+	now := time.Date(2026, 3, 31, 23, 0, 0, 0, time.UTC)
+	tracker.now = func() time.Time { return now }
+	return tracker
+}
+
+func TestUsageTracker(t *testing.T) {
+	ctx := WithUsageScope(context.Background(), UsageScope{RequestID: "r1", Community: "c1"})
+
+	t.Run("counts by community and provider", func(t *testing.T) {
+		tracker := newTestTracker(Budgets{}, 2)
+		tracker.Record(ctx, "groq", TokenUsage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15}, false)
+		tracker.Record(context.Background(), "ollama", TokenUsage{PromptTokens: 3, CompletionTokens: 1, TotalTokens: 4}, true)
+		tracker.Record(ctx, "groq", TokenUsage{PromptTokens: 1, CompletionTokens: 1, TotalTokens: 2}, false)
+
+		report, err := tracker.Report(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "2026-03", report.Period)
+		assert.Equal(t, "memory", report.Backend)
+		assert.Equal(t, TokenUsage{PromptTokens: 14, CompletionTokens: 7, TotalTokens: 21}, report.Total)
+		assert.Equal(t, map[string]TokenUsage{
+			"c1":             {PromptTokens: 11, CompletionTokens: 6, TotalTokens: 17},
+			DefaultCommunity: {PromptTokens: 3, CompletionTokens: 1, TotalTokens: 4},
+		}, report.Communities)
+		assert.Equal(t, int64(17), report.Providers["groq"].TotalTokens)
+
+		require.Len(t, report.Recent, 2, "only the latest completions are kept")
+		assert.Equal(t, int64(2), report.Recent[0].Usage.TotalTokens)
+		assert.Equal(t, "r1", report.Recent[0].RequestID)
+		assert.True(t, report.Recent[1].Estimated)
+	})
+
+	t.Run("enforces the monthly budget", func(t *testing.T) {
+		tracker := newTestTracker(Budgets{Monthly: 20}, 0)
+		assert.NoError(t, tracker.Check(ctx))
+		tracker.Record(ctx, "groq", TokenUsage{TotalTokens: 20}, false)
+		assert.ErrorIs(t, tracker.Check(ctx), ErrBudgetExceeded)
+		assert.NoError(t, tracker.Check(context.Background()), "completions outside requests are not limited")
+
+		tracker.now = func() time.Time { return time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC) }
+		assert.NoError(t, tracker.Check(ctx), "a new month starts over")
+	})
+
+	t.Run("enforces community budgets", func(t *testing.T) {
+		tracker := newTestTracker(Budgets{Community: 10, Communities: map[string]int64{"big": 100}}, 0)
+		big := WithUsageScope(context.Background(), UsageScope{Community: "big"})
+		tracker.Record(ctx, "groq", TokenUsage{TotalTokens: 10}, false)
+		tracker.Record(big, "groq", TokenUsage{TotalTokens: 50}, false)
+
+		assert.ErrorIs(t, tracker.Check(ctx), ErrBudgetExceeded)
+		assert.NoError(t, tracker.Check(big))
+
+		report, err := tracker.Report(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []BudgetStatus{
+			{Scope: "community:big", Limit: 100, Used: 50, Remaining: 50},
+			{Scope: "community:c1", Limit: 10, Used: 10, Remaining: 0, Exceeded: true},
+		}, report.Budgets)
+	})
+}
+
+// countingModel answers with a fixed text and the generation info given
+type countingModel struct {
+	info  map[string]any
+	calls int
+}
+
+func (m *countingModel) Call(ctx context.Context, prompt string, options ...llms.CallOption) (string, error) {
+	return llms.GenerateFromSinglePrompt(ctx, m, prompt, options...)
+}
+
+func (m *countingModel) GenerateContent(ctx context.Context, messages []llms.MessageContent, options ...llms.CallOption) (*llms.ContentResponse, error) {
+	m.calls++
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: "twelve chars", GenerationInfo: m.info}}}, nil
+}
+
+func TestMeteredModel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reads reported counts", func(t *testing.T) {
+		tracker := newTestTracker(Budgets{}, 1)
+		model := NewMeteredModel("openai", &countingModel{info: map[string]any{"PromptTokens": 7, "CompletionTokens": 3}}, tracker)
+		_, err := llms.GenerateFromSinglePrompt(ctx, model, "hello")
+		require.NoError(t, err)
+
+		report, err := tracker.Report(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, TokenUsage{PromptTokens: 7, CompletionTokens: 3, TotalTokens: 10}, report.Providers["openai"])
+		assert.False(t, report.Recent[0].Estimated)
+	})
+
+	t.Run("estimates missing counts", func(t *testing.T) {
+		tracker := newTestTracker(Budgets{}, 1)
+		model := NewMeteredModel("groq", &countingModel{}, tracker)
+		_, err := llms.GenerateFromSinglePrompt(ctx, model, "hello")
+		require.NoError(t, err)
+
+		report, err := tracker.Report(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, TokenUsage{PromptTokens: 2, CompletionTokens: 3, TotalTokens: 5}, report.Providers["groq"])
+		assert.True(t, report.Recent[0].Estimated)
+	})
+}
+
+func TestBudgetedModel(t *testing.T) {
+	ctx := WithUsageScope(context.Background(), UsageScope{Community: "c1"})
+	tracker := newTestTracker(Budgets{Monthly: 10}, 0)
+	tracker.Record(ctx, "groq", TokenUsage{TotalTokens: 10}, false)
+	primary := &countingModel{}
+
+	_, err := llms.GenerateFromSinglePrompt(ctx, NewBudgetedModel(primary, nil, tracker), "hi")
+	assert.True(t, errors.Is(err, ErrBudgetExceeded))
+	assert.Zero(t, primary.calls)
+
+	downgrade := &countingModel{}
+	_, err = llms.GenerateFromSinglePrompt(ctx, NewBudgetedModel(primary, downgrade, tracker), "hi")
+	require.NoError(t, err)
+	assert.Zero(t, primary.calls)
+	assert.Equal(t, 1, downgrade.calls)
+
+	_, err = llms.GenerateFromSinglePrompt(context.Background(), NewBudgetedModel(primary, nil, tracker), "hi")
+	require.NoError(t, err)
+	assert.Equal(t, 1, primary.calls)
+}
@@ -0,0 +1,340 @@
+package pgvector
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	_ "github.com/lib/pq"
+
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
+)
+
+// Index methods of the approximate nearest neighbour index
+const (
+	IndexHNSW    = "hnsw"
+	IndexIVFFlat = "ivfflat"
+)
+
+// tableName limits the documents table to a plain, optionally schema qualified, identifier
+var tableName = regexp.MustCompile(`^[a-z_][a-z0-9_]*(\.[a-z_][a-z0-9_]*)?$`)
+
+// Client keeps documents and their embeddings in a Postgres table with the pgvector
+// extension
+type Client struct {
+	db         *sql.DB
+	table      string
+	dimensions int
+	index      string
+	lists      int
+}
+
+// Config contains the Postgres connection and the documents table settings
+type Config struct {
+	DSN        string
+	Table      string // e.g. ai_documents or ai.documents
+	Dimensions int    // Length of the embeddings of the configured model
+	Index      string // IndexHNSW or IndexIVFFlat
+	Lists      int    // Clusters of an ivfflat index
+}
+
+var _ vectorstore.Store = (*Client)(nil)
+
+// NewClient opens a connection pool to Postgres; it does not connect until first used
+func NewClient(config Config) (*Client, error) {
+	if !tableName.MatchString(config.Table) {
+		return nil, fmt.Errorf("invalid pgvector table name %q", config.Table)
+	}
+	if config.Dimensions <= 0 {
+		return nil, errors.New("pgvector dimensions must be positive")
+	}
+	switch config.Index {
+	case IndexHNSW, IndexIVFFlat:
+	default:
+		return nil, fmt.Errorf("unsupported pgvector index: %s (supported: hnsw, ivfflat)", config.Index)
+	}
+
+	db, err := sql.Open("postgres", config.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Postgres connection: %w", err)
+	}
+	return &Client{
+		db:         db,
+		table:      config.Table,
+		dimensions: config.Dimensions,
+		index:      config.Index,
+		lists:      config.Lists,
+	}, nil
+}
+
+// Name identifies pgvector as the vector store
+func (c *Client) Name() string {
+	return "pgvector"
+}
+
+// EnsureSchema installs the extension and creates the documents table with its indexes.
+// An existing table whose embeddings have other dimensions is refused: the embedding
+// model changed and its documents have to be ingested again into a new table.
+func (c *Client) EnsureSchema(ctx context.Context) error {
+	if _, err := c.db.ExecContext(ctx, `CREATE EXTENSION IF NOT EXISTS vector`); err != nil {
+		return fmt.Errorf("failed to create vector extension: %w", err)
+	}
+
+	for _, statement := range c.schema() {
+		if _, err := c.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create schema: %w", err)
+		}
+	}
+
+	// pgvector keeps the dimensions of a vector column as its type modifier
+	var dimensions int
+	query := `SELECT atttypmod FROM pg_attribute WHERE attrelid = $1::regclass AND attname = 'embedding'`
+	if err := c.db.QueryRowContext(ctx, query, c.table).Scan(&dimensions); err != nil {
+		return fmt.Errorf("failed to read embedding dimensions: %w", err)
+	}
+	if dimensions != c.dimensions {
+		return fmt.Errorf("table %s holds embeddings of %d dimensions, the embedding model produces %d", c.table, dimensions, c.dimensions)
+	}
+	return nil
+}
+
+// schema returns the statements creating the documents table and its indexes
+func (c *Client) schema() []string {
+	indexPrefix := strings.ReplaceAll(c.table, ".", "_")
+	annIndex := fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_embedding_idx ON %s USING hnsw (embedding vector_cosine_ops)`, indexPrefix, c.table)
+	if c.index == IndexIVFFlat {
+		lists := c.lists
+		if lists <= 0 {
+			lists = 100
+		}
+		annIndex = fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_embedding_idx ON %s USING ivfflat (embedding vector_cosine_ops) WITH (lists = %d)`, indexPrefix, c.table, lists)
+	}
+
+	return []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			id TEXT PRIMARY KEY,
+			text TEXT NOT NULL,
+			source TEXT NOT NULL DEFAULT 'unknown',
+			post_id TEXT,
+			chunk INTEGER,
+			owner_user_id TEXT,
+			community_id TEXT,
+			created_date BIGINT,
+			embedding vector(%d) NOT NULL
+		)`, c.table, c.dimensions),
+		fmt.Sprintf(`CREATE INDEX IF NOT EXISTS %s_post_id_idx ON %s (post_id, chunk)`, indexPrefix, c.table),
+		annIndex,
+	}
+}
+
+// StoreDocument upserts a document; a document without ID gets a random one
+func (c *Client) StoreDocument(ctx context.Context, doc *vectorstore.Document, embedding []float32) error {
+	id := doc.ID
+	if id == "" {
+		id = uuid.NewString()
+	}
+	source, ok := doc.Metadata[vectorstore.MetaSource]
+	if !ok {
+		source = "unknown"
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %s (id, text, source, post_id, chunk, owner_user_id, community_id, created_date, embedding)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9::vector)
+		ON CONFLICT (id) DO UPDATE SET
+			text = EXCLUDED.text,
+			source = EXCLUDED.source,
+			post_id = EXCLUDED.post_id,
+			chunk = EXCLUDED.chunk,
+			owner_user_id = EXCLUDED.owner_user_id,
+			community_id = EXCLUDED.community_id,
+			created_date = EXCLUDED.created_date,
+			embedding = EXCLUDED.embedding`, c.table)
+	_, err := c.db.ExecContext(ctx, query,
+		id,
+		doc.Text,
+		source,
+		nullString(doc.Metadata[vectorstore.MetaPostID]),
+		nullInt(doc.Metadata[vectorstore.MetaChunk]),
+		nullString(doc.Metadata[vectorstore.MetaOwnerUserID]),
+		nullString(doc.Metadata[vectorstore.MetaCommunityID]),
+		nullInt(doc.Metadata[vectorstore.MetaCreatedDate]),
+		encodeVector(embedding),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to store document: %w", err)
+	}
+	return nil
+}
+
+// SearchSimilar finds documents similar to the query embedding using vector similarity search
+func (c *Client) SearchSimilar(ctx context.Context, embedding []float32, limit int) ([]*vectorstore.SearchResult, error) {
+	return c.SearchFiltered(ctx, embedding, limit, vectorstore.SearchFilter{})
+}
+
+// SearchFiltered finds the documents nearest to the query embedding by cosine distance
+// among those matching the filter. Scores are the certainty Weaviate reports, so
+// thresholds hold across stores.
+func (c *Client) SearchFiltered(ctx context.Context, embedding []float32, limit int, filter vectorstore.SearchFilter) ([]*vectorstore.SearchResult, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+
+	where, args := whereClause(filter, []interface{}{encodeVector(embedding)})
+	args = append(args, limit)
+	query := fmt.Sprintf(`
+		SELECT id, text, source, COALESCE(post_id, ''), COALESCE(owner_user_id, ''),
+			COALESCE(community_id, ''), created_date, 1 - (embedding <=> $1::vector) / 2
+		FROM %s%s
+		ORDER BY embedding <=> $1::vector
+		LIMIT $%d`, c.table, where, len(args))
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform vector search: %w", err)
+	}
+	defer rows.Close()
+
+	var searchResults []*vectorstore.SearchResult
+	for rows.Next() {
+		var (
+			id, text, source, postID, ownerUserID, communityID string
+			createdDate                                        sql.NullInt64
+			score                                              float64
+		)
+		if err := rows.Scan(&id, &text, &source, &postID, &ownerUserID, &communityID, &createdDate, &score); err != nil {
+			return nil, fmt.Errorf("failed to read search result: %w", err)
+		}
+
+		metadata := map[string]string{
+			vectorstore.MetaSource: source,
+		}
+		for key, value := range map[string]string{
+			vectorstore.MetaPostID:      postID,
+			vectorstore.MetaOwnerUserID: ownerUserID,
+			vectorstore.MetaCommunityID: communityID,
+		} {
+			if value != "" {
+				metadata[key] = value
+			}
+		}
+		if createdDate.Valid {
+			metadata[vectorstore.MetaCreatedDate] = strconv.FormatInt(createdDate.Int64, 10)
+		}
+
+		searchResults = append(searchResults, &vectorstore.SearchResult{
+			Document: &vectorstore.Document{ID: id, Text: text, Metadata: metadata},
+			Score:    float32(score),
+		})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to perform vector search: %w", err)
+	}
+	return searchResults, nil
+}
+
+// DeleteDocument removes a document by ID; a missing document is not an error
+func (c *Client) DeleteDocument(ctx context.Context, id string) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, c.table)
+	if _, err := c.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	return nil
+}
+
+// DeletePostChunks removes the chunks of a post from position from onwards; from 0
+// removes every chunk of the post
+func (c *Client) DeletePostChunks(ctx context.Context, postID string, from int) error {
+	query := fmt.Sprintf(`DELETE FROM %s WHERE post_id = $1 AND chunk >= $2`, c.table)
+	args := []interface{}{postID, from}
+	if from <= 0 {
+		query = fmt.Sprintf(`DELETE FROM %s WHERE post_id = $1`, c.table)
+		args = args[:1]
+	}
+	if _, err := c.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete post chunks: %w", err)
+	}
+	return nil
+}
+
+// Health verifies Postgres connectivity
+func (c *Client) Health(ctx context.Context) error {
+	if err := c.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("postgres health check failed: %w", err)
+	}
+	return nil
+}
+
+// Version returns the version of the installed pgvector extension
+func (c *Client) Version(ctx context.Context) (string, error) {
+	var version string
+	err := c.db.QueryRowContext(ctx, `SELECT extversion FROM pg_extension WHERE extname = 'vector'`).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", errors.New("the vector extension is not installed")
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read pgvector version: %w", err)
+	}
+	return version, nil
+}
+
+// whereClause builds the SQL filter, numbering its parameters after args; empty when the
+// filter matches every document
+func whereClause(f vectorstore.SearchFilter, args []interface{}) (string, []interface{}) {
+	var conditions []string
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	for _, field := range []struct{ column, value string }{
+		{"source", f.Source},
+		{"post_id", f.PostID},
+		{"owner_user_id", f.OwnerUserID},
+		{"community_id", f.CommunityID},
+	} {
+		if field.value != "" {
+			add(field.column+" = $%d", field.value)
+		}
+	}
+	if f.CreatedAfter > 0 {
+		add("created_date >= $%d", f.CreatedAfter)
+	}
+	if f.CreatedBefore > 0 {
+		add("created_date < $%d", f.CreatedBefore)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// encodeVector writes an embedding in pgvector's text format, e.g. [0.1,0.2]
+func encodeVector(embedding []float32) string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, value := range embedding {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(value), 'f', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// nullString stores an empty metadata value as NULL
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// nullInt stores a missing or malformed numeric metadata value as NULL
+func nullInt(value string) sql.NullInt64 {
+	n, err := strconv.ParseInt(value, 10, 64)
+	return sql.NullInt64{Int64: n, Valid: err == nil}
+}
@@ -0,0 +1,57 @@
+package pgvector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
+)
+
+func TestWhereClause(t *testing.T) {
+	t.Run("matches everything when empty", func(t *testing.T) {
+		where, args := whereClause(vectorstore.SearchFilter{}, []interface{}{"[1]"})
+		assert.Empty(t, where)
+		assert.Equal(t, []interface{}{"[1]"}, args)
+	})
+
+	t.Run("numbers conditions after the query vector", func(t *testing.T) {
+		where, args := whereClause(vectorstore.SearchFilter{
+			Source:        "post",
+			OwnerUserID:   "author",
+			CreatedAfter:  1000,
+			CreatedBefore: 2000,
+		}, []interface{}{"[1]"})
+
+		assert.Equal(t, " WHERE source = $2 AND owner_user_id = $3 AND created_date >= $4 AND created_date < $5", where)
+		assert.Equal(t, []interface{}{"[1]", "post", "author", int64(1000), int64(2000)}, args)
+	})
+}
+
+func TestEncodeVector(t *testing.T) {
+	assert.Equal(t, "[]", encodeVector(nil))
+	assert.Equal(t, "[0.1,-2,3.5]", encodeVector([]float32{0.1, -2, 3.5}))
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("creates the configured ANN index", func(t *testing.T) {
+		client, err := NewClient(Config{Table: "ai.documents", Dimensions: 768, Index: IndexIVFFlat, Lists: 50})
+		require.NoError(t, err)
+
+		schema := client.schema()
+		assert.Contains(t, schema[0], "CREATE TABLE IF NOT EXISTS ai.documents")
+		assert.Contains(t, schema[0], "embedding vector(768) NOT NULL")
+		assert.Equal(t, "CREATE INDEX IF NOT EXISTS ai_documents_embedding_idx ON ai.documents USING ivfflat (embedding vector_cosine_ops) WITH (lists = 50)", schema[2])
+	})
+
+	t.Run("refuses table names that are not plain identifiers", func(t *testing.T) {
+		_, err := NewClient(Config{Table: "documents; DROP TABLE users", Dimensions: 768, Index: IndexHNSW})
+		assert.Error(t, err)
+	})
+
+	t.Run("refuses unknown index methods", func(t *testing.T) {
+		_, err := NewClient(Config{Table: "documents", Dimensions: 768, Index: "flat"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,63 @@
+// Package vectorstore defines the store the knowledge base keeps document embeddings in.
+// Weaviate and Postgres with pgvector implement it; VECTOR_STORE selects one.
+package vectorstore
+
+import "context"
+
+// Store saves documents with their embeddings and finds the documents nearest to a
+// query embedding
+type Store interface {
+	// Name identifies the store in health and system information, e.g. "weaviate"
+	Name() string
+	// EnsureSchema creates what the store needs to hold documents, or adds what an older
+	// schema is missing
+	EnsureSchema(ctx context.Context) error
+	// StoreDocument saves a document; a document with the ID of a stored one replaces it
+	StoreDocument(ctx context.Context, doc *Document, embedding []float32) error
+	// SearchSimilar finds the documents most similar to the query embedding
+	SearchSimilar(ctx context.Context, embedding []float32, limit int) ([]*SearchResult, error)
+	// SearchFiltered finds the documents most similar to the query embedding among those
+	// matching the filter
+	SearchFiltered(ctx context.Context, embedding []float32, limit int, filter SearchFilter) ([]*SearchResult, error)
+	// DeleteDocument removes a document by ID; a missing document is not an error
+	DeleteDocument(ctx context.Context, id string) error
+	// DeletePostChunks removes the chunks of a post from position from onwards; from 0
+	// removes every chunk of the post
+	DeletePostChunks(ctx context.Context, postID string, from int) error
+	// Health verifies the store is reachable and ready
+	Health(ctx context.Context) error
+	// Version returns the version of the store
+	Version(ctx context.Context) (string, error)
+}
+
+type Document struct {
+	ID       string            `json:"id"`
+	Text     string            `json:"text"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type SearchResult struct {
+	Document *Document `json:"document"`
+	Score    float32   `json:"score"` // Similarity from 0 to 1, 1 for the same direction
+}
+
+// SearchFilter narrows a vector search to documents whose stored metadata matches.
+// Empty fields and zero dates do not filter.
+type SearchFilter struct {
+	Source        string
+	PostID        string
+	OwnerUserID   string
+	CommunityID   string
+	CreatedAfter  int64 // Unix milliseconds, inclusive
+	CreatedBefore int64 // Unix milliseconds, exclusive
+}
+
+// Metadata keys stores keep as their own fields so searches can filter on them
+const (
+	MetaSource      = "source"
+	MetaPostID      = "postId"
+	MetaChunk       = "chunk" // Position of the chunk within its post, from 0
+	MetaOwnerUserID = "ownerUserId"
+	MetaCommunityID = "communityId"
+	MetaCreatedDate = "createdDate" // Unix milliseconds
+)
@@ -2,13 +2,20 @@ package weaviate
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 
 	"github.com/weaviate/weaviate-go-client/v4/weaviate"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/auth"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/fault"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
 	"github.com/weaviate/weaviate/entities/models"
+
+	"github.com/qolzam/telar/apps/ai-engine/internal/platform/vectorstore"
 )
 
 // Client wraps the Weaviate Go client with AI Engine specific functionality
@@ -22,15 +29,62 @@ type Config struct {
 	APIKey string
 }
 
-type Document struct {
-	ID       string            `json:"id"`
-	Text     string            `json:"text"`
-	Metadata map[string]string `json:"metadata,omitempty"`
-}
+// Types shared by every vector store
+type (
+	Document     = vectorstore.Document
+	SearchResult = vectorstore.SearchResult
+	SearchFilter = vectorstore.SearchFilter
+)
+
+// Metadata keys stored as their own properties so searches can filter on them
+const (
+	metaPostID      = vectorstore.MetaPostID
+	metaChunk       = vectorstore.MetaChunk
+	metaOwnerUserID = vectorstore.MetaOwnerUserID
+	metaCommunityID = vectorstore.MetaCommunityID
+	metaCreatedDate = vectorstore.MetaCreatedDate
+)
 
-type SearchResult struct {
-	Document *Document `json:"document"`
-	Score    float32   `json:"score"`
+var _ vectorstore.Store = (*Client)(nil)
+
+// documentProperties is the Document class schema; EnsureSchema adds any property an
+// older class is missing
+var documentProperties = []*models.Property{
+	{
+		Name:        "text",
+		DataType:    []string{"text"},
+		Description: "The main content of the document",
+	},
+	{
+		Name:        "source",
+		DataType:    []string{"text"},
+		Description: "The source of the document (e.g., URL, filename)",
+	},
+	{
+		Name:        metaPostID,
+		DataType:    []string{"text"},
+		Description: "The post the document was chunked from",
+	},
+	{
+		Name:        metaChunk,
+		DataType:    []string{"int"},
+		Description: "The position of the chunk within its post",
+	},
+	{
+		Name:        metaOwnerUserID,
+		DataType:    []string{"text"},
+		Description: "The author of the document, when it is a post",
+	},
+	{
+		Name:        metaCommunityID,
+		DataType:    []string{"text"},
+		Description: "The community the document was posted in",
+	},
+	{
+		Name:        metaCreatedDate,
+		DataType:    []string{"int"},
+		Description: "When the document was created, in Unix milliseconds",
+	},
 }
 
 // NewClient creates a new Weaviate client instance
@@ -69,15 +123,51 @@ func (c *Client) StoreDocument(ctx context.Context, doc *Document, embedding []f
 		"text":   doc.Text,
 		"source": source,
 	}
+	for _, key := range []string{metaPostID, metaOwnerUserID, metaCommunityID} {
+		if value := doc.Metadata[key]; value != "" {
+			properties[key] = value
+		}
+	}
+	for _, key := range []string{metaChunk, metaCreatedDate} {
+		if value, err := strconv.ParseInt(doc.Metadata[key], 10, 64); err == nil {
+			properties[key] = value
+		}
+	}
 
-	_, err := c.client.Data().Creator().
+	// Documents with a caller-supplied ID are upserted so re-ingesting the same
+	// source (e.g. a post) replaces the previous version instead of duplicating it
+	if doc.ID != "" {
+		exists, err := c.client.Data().Checker().
+			WithClassName("Document").
+			WithID(doc.ID).
+			Do(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check document existence: %w", err)
+		}
+		if exists {
+			err = c.client.Data().Updater().
+				WithClassName("Document").
+				WithID(doc.ID).
+				WithProperties(properties).
+				WithVector(embedding).
+				Do(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to update document: %w", err)
+			}
+			return nil
+		}
+	}
+
+	creator := c.client.Data().Creator().
 		WithClassName("Document").
-		// withID is optional, Weaviate can generate one
 		WithProperties(properties).
-		WithVector(embedding).
-		Do(ctx)
+		WithVector(embedding)
+	// withID is optional, Weaviate can generate one
+	if doc.ID != "" {
+		creator = creator.WithID(doc.ID)
+	}
 
-	if err != nil {
+	if _, err := creator.Do(ctx); err != nil {
 		return fmt.Errorf("failed to store document: %w", err)
 	}
 
@@ -86,6 +176,12 @@ func (c *Client) StoreDocument(ctx context.Context, doc *Document, embedding []f
 
 // SearchSimilar finds documents similar to the query embedding using vector similarity search
 func (c *Client) SearchSimilar(ctx context.Context, embedding []float32, limit int) ([]*SearchResult, error) {
+	return c.SearchFiltered(ctx, embedding, limit, SearchFilter{})
+}
+
+// SearchFiltered finds the documents most similar to the query embedding among those
+// matching the filter
+func (c *Client) SearchFiltered(ctx context.Context, embedding []float32, limit int, filter SearchFilter) ([]*SearchResult, error) {
 	className := "Document"
 	if limit <= 0 {
 		limit = 5
@@ -95,6 +191,10 @@ func (c *Client) SearchSimilar(ctx context.Context, embedding []float32, limit i
 	fields := []graphql.Field{
 		graphql.Field{Name: "text"},
 		graphql.Field{Name: "source"}, // source is stored directly, not in metadata
+		graphql.Field{Name: metaPostID},
+		graphql.Field{Name: metaOwnerUserID},
+		graphql.Field{Name: metaCommunityID},
+		graphql.Field{Name: metaCreatedDate},
 		graphql.Field{Name: "_additional", Fields: []graphql.Field{
 			{Name: "id"},
 			{Name: "certainty"}, // certainty is Weaviate's score (0 to 1)
@@ -106,12 +206,15 @@ func (c *Client) SearchSimilar(ctx context.Context, embedding []float32, limit i
 		WithVector(embedding)
 
 	// execute the query
-	response, err := c.client.GraphQL().Get().
+	query := c.client.GraphQL().Get().
 		WithClassName(className).
 		WithFields(fields...).
 		WithNearVector(nearVector).
-		WithLimit(limit).
-		Do(ctx)
+		WithLimit(limit)
+	if where := where(filter); where != nil {
+		query = query.WithWhere(where)
+	}
+	response, err := query.Do(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to perform vector search: %w", err)
 	}
@@ -137,13 +240,24 @@ func (c *Client) SearchSimilar(ctx context.Context, embedding []float32, limit i
 					certainty = float32(additional["certainty"].(float64))
 				}
 
+				metadata := map[string]string{
+					"source": source,
+				}
+				for _, key := range []string{metaPostID, metaOwnerUserID, metaCommunityID} {
+					if value, ok := docMap[key].(string); ok && value != "" {
+						metadata[key] = value
+					}
+				}
+				// GraphQL returns ints as JSON numbers
+				if created, ok := docMap[metaCreatedDate].(float64); ok {
+					metadata[metaCreatedDate] = strconv.FormatInt(int64(created), 10)
+				}
+
 				searchResults = append(searchResults, &SearchResult{
 					Document: &Document{
-						ID:   id,
-						Text: text,
-						Metadata: map[string]string{
-							"source": source,
-						},
+						ID:       id,
+						Text:     text,
+						Metadata: metadata,
 					},
 					Score: certainty,
 				})
@@ -154,6 +268,11 @@ func (c *Client) SearchSimilar(ctx context.Context, embedding []float32, limit i
 	return searchResults, nil
 }
 
+// Name identifies Weaviate as the vector store
+func (c *Client) Name() string {
+	return "weaviate"
+}
+
 // Health verifies Weaviate service connectivity and readiness
 func (c *Client) Health(ctx context.Context) error {
 	ready, err := c.client.Misc().ReadyChecker().Do(ctx)
@@ -168,6 +287,45 @@ func (c *Client) Health(ctx context.Context) error {
 	return nil
 }
 
+// Version returns the version of the Weaviate server
+func (c *Client) Version(ctx context.Context) (string, error) {
+	meta, err := c.client.Misc().MetaGetter().Do(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read weaviate version: %w", err)
+	}
+	return meta.Version, nil
+}
+
+// DeleteDocument removes a document by ID; a missing document is not an error
+func (c *Client) DeleteDocument(ctx context.Context, id string) error {
+	err := c.client.Data().Deleter().
+		WithClassName("Document").
+		WithID(id).
+		Do(ctx)
+	var clientErr *fault.WeaviateClientError
+	if errors.As(err, &clientErr) && clientErr.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+	return nil
+}
+
+// DeletePostChunks removes the chunks of a post from position from onwards; from 0
+// removes every chunk of the post
+func (c *Client) DeletePostChunks(ctx context.Context, postID string, from int) error {
+	where := chunksFrom(postID, from)
+	_, err := c.client.Batch().ObjectsBatchDeleter().
+		WithClassName("Document").
+		WithWhere(where).
+		Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete post chunks: %w", err)
+	}
+	return nil
+}
+
 // EnsureSchema creates the required Weaviate schema for AI Engine
 func (c *Client) EnsureSchema(ctx context.Context) error {
 	className := "Document"
@@ -178,8 +336,8 @@ func (c *Client) EnsureSchema(ctx context.Context) error {
 		return fmt.Errorf("failed to check class existence: %w", err)
 	}
 	if exists {
-		// class already exists, no need to create it
-		return nil
+		// class already exists, only add the properties it predates
+		return c.addMissingProperties(ctx, className)
 	}
 
 	// define the class object
@@ -187,18 +345,7 @@ func (c *Client) EnsureSchema(ctx context.Context) error {
 		Class:       className,
 		Description: "A document containing text and metadata for the AI Engine",
 		Vectorizer:  "none", // VERY IMPORTANT: We provide our own vectors
-		Properties: []*models.Property{
-			{
-				Name:        "text",
-				DataType:    []string{"text"},
-				Description: "The main content of the document",
-			},
-			{
-				Name:        "source",
-				DataType:    []string{"text"},
-				Description: "The source of the document (e.g., URL, filename)",
-			},
-		},
+		Properties:  documentProperties,
 	}
 
 	// create the class
@@ -209,3 +356,83 @@ func (c *Client) EnsureSchema(ctx context.Context) error {
 
 	return nil
 }
+
+// addMissingProperties adds the filterable properties to a Document class created
+// before they existed; documents stored earlier simply have no value for them
+func (c *Client) addMissingProperties(ctx context.Context, className string) error {
+	class, err := c.client.Schema().ClassGetter().WithClassName(className).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get schema: %w", err)
+	}
+	existing := make(map[string]bool, len(class.Properties))
+	for _, property := range class.Properties {
+		existing[property.Name] = true
+	}
+	for _, property := range documentProperties {
+		if existing[property.Name] {
+			continue
+		}
+		err := c.client.Schema().PropertyCreator().WithClassName(className).WithProperty(property).Do(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to add property %s: %w", property.Name, err)
+		}
+	}
+	return nil
+}
+
+// where builds the Weaviate filter; nil when the filter matches every document
+func where(f SearchFilter) *filters.WhereBuilder {
+	var operands []*filters.WhereBuilder
+	for _, field := range []struct{ path, value string }{
+		{"source", f.Source},
+		{metaPostID, f.PostID},
+		{metaOwnerUserID, f.OwnerUserID},
+		{metaCommunityID, f.CommunityID},
+	} {
+		if field.value != "" {
+			operands = append(operands, filters.Where().
+				WithPath([]string{field.path}).
+				WithOperator(filters.Equal).
+				WithValueText(field.value))
+		}
+	}
+	if f.CreatedAfter > 0 {
+		operands = append(operands, filters.Where().
+			WithPath([]string{metaCreatedDate}).
+			WithOperator(filters.GreaterThanEqual).
+			WithValueInt(f.CreatedAfter))
+	}
+	if f.CreatedBefore > 0 {
+		operands = append(operands, filters.Where().
+			WithPath([]string{metaCreatedDate}).
+			WithOperator(filters.LessThan).
+			WithValueInt(f.CreatedBefore))
+	}
+
+	switch len(operands) {
+	case 0:
+		return nil
+	case 1:
+		return operands[0]
+	default:
+		return filters.Where().WithOperator(filters.And).WithOperands(operands)
+	}
+}
+
+// chunksFrom matches the chunks of a post from position from onwards
+func chunksFrom(postID string, from int) *filters.WhereBuilder {
+	post := filters.Where().
+		WithPath([]string{metaPostID}).
+		WithOperator(filters.Equal).
+		WithValueText(postID)
+	if from <= 0 {
+		return post
+	}
+	return filters.Where().WithOperator(filters.And).WithOperands([]*filters.WhereBuilder{
+		post,
+		filters.Where().
+			WithPath([]string{metaChunk}).
+			WithOperator(filters.GreaterThanEqual).
+			WithValueInt(int64(from)),
+	})
+}
@@ -0,0 +1,56 @@
+package weaviate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
+)
+
+func TestSearchFilterWhere(t *testing.T) {
+	t.Run("matches everything when empty", func(t *testing.T) {
+		assert.Nil(t, where(SearchFilter{}))
+	})
+
+	t.Run("uses a single condition directly", func(t *testing.T) {
+		where := where(SearchFilter{Source: "post"}).Build()
+		assert.Equal(t, []string{"source"}, where.Path)
+		assert.Equal(t, "Equal", string(where.Operator))
+	})
+
+	t.Run("joins conditions with And", func(t *testing.T) {
+		where := where(SearchFilter{
+			Source:        "post",
+			OwnerUserID:   "author",
+			CreatedAfter:  1000,
+			CreatedBefore: 2000,
+		}).Build()
+
+		assert.Equal(t, string(filters.And), string(where.Operator))
+		var paths []string
+		for _, operand := range where.Operands {
+			paths = append(paths, operand.Path[0]+" "+string(operand.Operator))
+		}
+		assert.Equal(t, []string{
+			"source Equal",
+			"ownerUserId Equal",
+			"createdDate GreaterThanEqual",
+			"createdDate LessThan",
+		}, paths)
+	})
+}
+
+func TestChunksFrom(t *testing.T) {
+	t.Run("matches every chunk of the post from 0", func(t *testing.T) {
+		where := chunksFrom("post-1", 0).Build()
+		assert.Equal(t, []string{"postId"}, where.Path)
+		assert.Equal(t, "Equal", string(where.Operator))
+	})
+
+	t.Run("matches the trailing chunks", func(t *testing.T) {
+		where := chunksFrom("post-1", 3).Build()
+		assert.Equal(t, string(filters.And), string(where.Operator))
+		assert.Equal(t, []string{"chunk"}, where.Operands[1].Path)
+		assert.Equal(t, "GreaterThanEqual", string(where.Operands[1].Operator))
+	})
+}
@@ -0,0 +1,32 @@
+// Package version identifies the running build of the AI engine. Release builds stamp
+// it with -ldflags:
+//
+//	go build -ldflags "-X github.com/qolzam/telar/apps/ai-engine/internal/version.Version=v1.2.0 -X github.com/qolzam/telar/apps/ai-engine/internal/version.GitSHA=$(git rev-parse HEAD)"
+package version
+
+import "runtime/debug"
+
+const ServiceName = "ai-engine"
+
+var (
+	// Version is the release of this build
+	Version = "v1.0.0"
+	// GitSHA is the commit this build was made from; see Revision
+	GitSHA = ""
+)
+
+// Revision returns GitSHA, or the commit the Go toolchain recorded when the binary
+// was built from a checkout without -ldflags. It is empty when neither is known.
+func Revision() string {
+	if GitSHA != "" {
+		return GitSHA
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return ""
+}
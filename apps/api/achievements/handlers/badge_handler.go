@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/achievements/errors"
+	"github.com/qolzam/telar/apps/api/achievements/models"
+	"github.com/qolzam/telar/apps/api/achievements/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type BadgeHandler struct {
+	service services.Service
+}
+
+func NewBadgeHandler(service services.Service) *BadgeHandler {
+	return &BadgeHandler{service: service}
+}
+
+// Catalog lists every badge and the rule that earns it.
+// Endpoint: GET /achievements/badges
+func (h *BadgeHandler) Catalog(c *fiber.Ctx) error {
+	return c.Status(http.StatusOK).JSON(models.BadgeCatalogResponse{Badges: h.service.Badges()})
+}
+
+// UserBadges lists the badges a user earned, newest first.
+// Endpoint: GET /achievements/users/:userId/badges
+func (h *BadgeHandler) UserBadges(c *fiber.Ctx) error {
+	userID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+	return h.respondUserBadges(c, userID)
+}
+
+// MyBadges lists the current user's badges, newest first.
+// Endpoint: GET /achievements/me/badges
+func (h *BadgeHandler) MyBadges(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	return h.respondUserBadges(c, user.UserID)
+}
+
+func (h *BadgeHandler) respondUserBadges(c *fiber.Ctx, userID uuid.UUID) error {
+	resp, err := h.service.ListUserBadges(c.Context(), userID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
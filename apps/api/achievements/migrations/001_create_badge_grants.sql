@@ -0,0 +1,11 @@
+-- Badges a user has earned. Badge definitions live in code; a row is written once, the
+-- first time the user meets the badge's rule, and never revoked.
+CREATE TABLE IF NOT EXISTS badge_grants (
+    user_id UUID NOT NULL,
+    badge_id VARCHAR(64) NOT NULL,
+    granted_date BIGINT NOT NULL,
+
+    PRIMARY KEY (user_id, badge_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_badge_grants_user_date ON badge_grants(user_id, granted_date DESC, badge_id DESC);
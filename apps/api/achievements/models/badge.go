@@ -0,0 +1,117 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// Metrics a badge rule can test. Anonymous posts, and comments made as the hidden author
+// of one, are not counted so badges never reveal who wrote them.
+const (
+	MetricPosts          = "posts"            // Live posts written
+	MetricComments       = "comments"         // Live comments written
+	MetricUpvotes        = "upvotes"          // Upvotes received from other users on live posts
+	MetricAccountAgeDays = "account_age_days" // Whole days since the profile was created
+)
+
+var knownMetrics = map[string]bool{
+	MetricPosts:          true,
+	MetricComments:       true,
+	MetricUpvotes:        true,
+	MetricAccountAgeDays: true,
+}
+
+// Badge is a badge definition. Rule is an expression of the form "<metric> >= <n>".
+type Badge struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Rule        string `json:"rule"`
+}
+
+// Rule is a parsed badge rule: the badge is earned once Metric reaches Threshold
+type Rule struct {
+	Metric    string
+	Threshold int64
+}
+
+// ParseRule parses a rule expression such as "upvotes >= 100"
+func ParseRule(expr string) (Rule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 3 || fields[1] != ">=" {
+		return Rule{}, fmt.Errorf("rule %q: expected \"<metric> >= <n>\"", expr)
+	}
+	if !knownMetrics[fields[0]] {
+		return Rule{}, fmt.Errorf("rule %q: unknown metric %q", expr, fields[0])
+	}
+	threshold, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil || threshold < 1 {
+		return Rule{}, fmt.Errorf("rule %q: threshold must be a positive integer", expr)
+	}
+	return Rule{Metric: fields[0], Threshold: threshold}, nil
+}
+
+// Met reports whether the metric values satisfy the rule
+func (r Rule) Met(metrics map[string]int64) bool {
+	return metrics[r.Metric] >= r.Threshold
+}
+
+// DefaultBadges is the badge catalog
+func DefaultBadges() []Badge {
+	return []Badge{
+		{Id: "first-post", Name: "First Post", Description: "Published a first post", Rule: "posts >= 1"},
+		{Id: "first-comment", Name: "First Comment", Description: "Joined a conversation", Rule: "comments >= 1"},
+		{Id: "prolific", Name: "Prolific", Description: "Published 50 posts", Rule: "posts >= 50"},
+		{Id: "well-liked", Name: "Well Liked", Description: "Received 100 upvotes", Rule: "upvotes >= 100"},
+		{Id: "anniversary", Name: "Anniversary", Description: "A member for one year", Rule: "account_age_days >= 365"},
+	}
+}
+
+// UserStats are the raw counts behind a user's metrics
+type UserStats struct {
+	Posts      int64
+	Comments   int64
+	Upvotes    int64
+	JoinedDate int64 // Profile creation, Unix milliseconds; 0 when the user has no profile
+}
+
+// Metrics converts the stats to the metric values rules are tested against
+func (s UserStats) Metrics(now time.Time) map[string]int64 {
+	metrics := map[string]int64{
+		MetricPosts:    s.Posts,
+		MetricComments: s.Comments,
+		MetricUpvotes:  s.Upvotes,
+	}
+	if s.JoinedDate > 0 {
+		metrics[MetricAccountAgeDays] = int64(now.Sub(time.UnixMilli(s.JoinedDate)) / (24 * time.Hour))
+	}
+	return metrics
+}
+
+// BadgeGrant records that a user earned a badge
+type BadgeGrant struct {
+	UserId      uuid.UUID `json:"userId" db:"user_id"`
+	BadgeId     string    `json:"badgeId" db:"badge_id"`
+	GrantedDate int64     `json:"grantedDate" db:"granted_date"`
+}
+
+// UserBadge is an earned badge as listed on a profile
+type UserBadge struct {
+	Badge
+	GrantedDate int64 `json:"grantedDate"`
+}
+
+// UserBadgesResponse lists a user's badges, newest first
+type UserBadgesResponse struct {
+	UserId string      `json:"userId"`
+	Badges []UserBadge `json:"badges"`
+}
+
+// BadgeCatalogResponse lists every badge that can be earned
+type BadgeCatalogResponse struct {
+	Badges []Badge `json:"badges"`
+}
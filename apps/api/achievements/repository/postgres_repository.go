@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/achievements/models"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) BadgeRepository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) BadgeRepository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) Grant(ctx context.Context, userID uuid.UUID, badgeID string, grantedDate int64) (bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %sbadge_grants (user_id, badge_id, granted_date)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, badge_id) DO NOTHING
+	`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, userID, badgeID, grantedDate)
+	if err != nil {
+		return false, fmt.Errorf("insert badge grant: %w", err)
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("insert badge grant: %w", err)
+	}
+	return inserted > 0, nil
+}
+
+func (r *postgresRepository) ListGrants(ctx context.Context, userID uuid.UUID) ([]models.BadgeGrant, error) {
+	query := fmt.Sprintf(`
+		SELECT user_id, badge_id, granted_date FROM %sbadge_grants
+		WHERE user_id = $1
+		ORDER BY granted_date DESC, badge_id DESC
+	`, r.schemaPrefix())
+
+	var grants []models.BadgeGrant
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &grants, query, userID); err != nil {
+		return nil, fmt.Errorf("list badge grants: %w", err)
+	}
+	return grants, nil
+}
+
+func (r *postgresRepository) ListGrantsBefore(ctx context.Context, userID uuid.UUID, beforeDate int64, beforeBadgeID string, limit int) ([]models.BadgeGrant, error) {
+	query := fmt.Sprintf(`SELECT user_id, badge_id, granted_date FROM %sbadge_grants WHERE user_id = $1`, r.schemaPrefix())
+	args := []interface{}{userID}
+	if beforeDate > 0 {
+		query += ` AND (granted_date, badge_id COLLATE "C") < ($2, $3)`
+		args = append(args, beforeDate, beforeBadgeID)
+	}
+	// Byte order on badge_id matches how the activity timeline sorts object IDs
+	query += fmt.Sprintf(` ORDER BY granted_date DESC, badge_id COLLATE "C" DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	var grants []models.BadgeGrant
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &grants, query, args...); err != nil {
+		return nil, fmt.Errorf("list badge grants: %w", err)
+	}
+	return grants, nil
+}
+
+// Stats counts live, non-anonymous posts, comments other than those made as the hidden
+// author of an anonymous post, and upvotes other users cast on the user's live,
+// non-anonymous posts.
+func (r *postgresRepository) Stats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			(SELECT COUNT(*) FROM %[1]sposts
+				WHERE owner_user_id = $1 AND is_deleted = FALSE AND is_anonymous = FALSE) AS posts,
+			(SELECT COUNT(*) FROM %[1]scomments c JOIN %[1]sposts p ON p.id = c.post_id
+				WHERE c.owner_user_id = $1 AND c.is_deleted = FALSE AND p.is_deleted = FALSE
+				AND NOT (p.is_anonymous AND p.owner_user_id = c.owner_user_id)) AS comments,
+			(SELECT COUNT(*) FROM %[1]svotes v JOIN %[1]sposts p ON p.id = v.post_id
+				WHERE p.owner_user_id = $1 AND p.is_deleted = FALSE AND p.is_anonymous = FALSE
+				AND v.vote_type_id = 1 AND v.owner_user_id <> $1) AS upvotes,
+			COALESCE((SELECT created_date FROM %[1]sprofiles WHERE user_id = $1), 0) AS joined_date
+	`, r.schemaPrefix())
+
+	var row struct {
+		Posts      int64 `db:"posts"`
+		Comments   int64 `db:"comments"`
+		Upvotes    int64 `db:"upvotes"`
+		JoinedDate int64 `db:"joined_date"`
+	}
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, userID); err != nil {
+		return nil, fmt.Errorf("load badge stats: %w", err)
+	}
+	return &models.UserStats{Posts: row.Posts, Comments: row.Comments, Upvotes: row.Upvotes, JoinedDate: row.JoinedDate}, nil
+}
+
+func (r *postgresRepository) PostOwner(ctx context.Context, postID uuid.UUID) (uuid.UUID, error) {
+	query := fmt.Sprintf(`SELECT owner_user_id FROM %sposts WHERE id = $1 AND is_deleted = FALSE AND is_anonymous = FALSE`, r.schemaPrefix())
+
+	var ownerID uuid.UUID
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &ownerID, query, postID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, nil
+		}
+		return uuid.Nil, fmt.Errorf("find post owner: %w", err)
+	}
+	return ownerID, nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM %sbadge_grants WHERE user_id = $1`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("delete badges of user: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/achievements/models"
+)
+
+// BadgeRepository defines data access for badge grants and the stats badge rules test.
+type BadgeRepository interface {
+	// Grant records that userID earned badgeID; returns true when the grant is new.
+	Grant(ctx context.Context, userID uuid.UUID, badgeID string, grantedDate int64) (bool, error)
+
+	// ListGrants returns every badge userID earned, newest first.
+	ListGrants(ctx context.Context, userID uuid.UUID) ([]models.BadgeGrant, error)
+
+	// ListGrantsBefore returns userID's grants older than (beforeDate, beforeBadgeID),
+	// newest first; a zero beforeDate starts from the newest.
+	ListGrantsBefore(ctx context.Context, userID uuid.UUID, beforeDate int64, beforeBadgeID string, limit int) ([]models.BadgeGrant, error)
+
+	// Stats returns the counts badge rules are evaluated against.
+	Stats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error)
+
+	// PostOwner returns the author of a live, non-anonymous post, or uuid.Nil when there
+	// is none.
+	PostOwner(ctx context.Context, postID uuid.UUID) (uuid.UUID, error)
+
+	// DeleteByUser deletes the badges granted to userID.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,37 @@
+package achievements
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/achievements/handlers"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+type Handlers struct {
+	BadgeHandler *handlers.BadgeHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires the badge catalog and per-user badge listings.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/achievements", createDualAuthMiddleware(routerCfg))
+	group.Get("/badges", handlers.BadgeHandler.Catalog)
+	group.Get("/me/badges", handlers.BadgeHandler.MyBadges)
+	group.Get("/users/:userId/badges", handlers.BadgeHandler.UserBadges)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/achievements/repository"
+	commentsModels "github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// evaluation names the user whose badges to re-check: directly, or as the author of a post
+type evaluation struct {
+	userID uuid.UUID
+	postID uuid.UUID
+}
+
+// Evaluator re-checks badges when domain events show a user's stats may have changed:
+// new public posts and comments for their author, and upvotes for the post's author.
+// Events are queued and evaluated in the background; when the queue is full they are
+// dropped, and the user's next event catches the badge up.
+type Evaluator struct {
+	service Service
+	repo    repository.BadgeRepository
+	queue   chan evaluation
+}
+
+// NewEvaluator creates an evaluator holding up to queueSize pending events
+func NewEvaluator(service Service, repo repository.BadgeRepository, queueSize int) *Evaluator {
+	if queueSize <= 0 {
+		queueSize = 256
+	}
+	return &Evaluator{service: service, repo: repo, queue: make(chan evaluation, queueSize)}
+}
+
+// Deliver queues the evaluation an event calls for; it never blocks. Subscribe it to the
+// event bus.
+func (e *Evaluator) Deliver(event events.Event) {
+	var next evaluation
+	switch data := event.Data.(type) {
+	case postsModels.PostResponse:
+		// Anonymous posts carry no author and earn nothing
+		next.userID = uuid.FromStringOrNil(data.OwnerUserId)
+	case commentsModels.CommentResponse:
+		next.userID = uuid.FromStringOrNil(data.OwnerUserId)
+	case events.Vote:
+		next.postID = uuid.FromStringOrNil(data.PostId)
+	}
+	if next.userID == uuid.Nil && next.postID == uuid.Nil {
+		return
+	}
+
+	select {
+	case e.queue <- next:
+	default:
+		log.Warn("Badge evaluation queue full; dropping %s event", event.Type)
+	}
+}
+
+// Start evaluates queued events until ctx is cancelled
+func (e *Evaluator) Start(ctx context.Context) {
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case next := <-e.queue:
+				e.evaluate(ctx, next)
+			}
+		}
+	}()
+}
+
+func (e *Evaluator) evaluate(ctx context.Context, next evaluation) {
+	userID := next.userID
+	if userID == uuid.Nil {
+		ownerID, err := e.repo.PostOwner(ctx, next.postID)
+		if err != nil {
+			log.Error("Badge evaluation failed to resolve author of post %s: %v", next.postID, err)
+			return
+		}
+		if ownerID == uuid.Nil {
+			return
+		}
+		userID = ownerID
+	}
+
+	granted, err := e.service.Evaluate(ctx, userID)
+	if err != nil {
+		log.Error("Badge evaluation failed for user %s: %v", userID, err)
+	}
+	for _, badge := range granted {
+		log.Info("User %s earned badge %s", userID, badge.Id)
+	}
+}
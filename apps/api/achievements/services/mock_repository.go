@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/achievements/models"
+	"github.com/qolzam/telar/apps/api/achievements/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the achievements repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.BadgeRepository = (*MockRepository)(nil)
+
+func (m *MockRepository) Grant(ctx context.Context, userID uuid.UUID, badgeID string, grantedDate int64) (bool, error) {
+	args := m.Called(ctx, userID, badgeID, grantedDate)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ListGrants(ctx context.Context, userID uuid.UUID) ([]models.BadgeGrant, error) {
+	args := m.Called(ctx, userID)
+	grants, _ := args.Get(0).([]models.BadgeGrant)
+	return grants, args.Error(1)
+}
+
+func (m *MockRepository) ListGrantsBefore(ctx context.Context, userID uuid.UUID, beforeDate int64, beforeBadgeID string, limit int) ([]models.BadgeGrant, error) {
+	args := m.Called(ctx, userID, beforeDate, beforeBadgeID, limit)
+	grants, _ := args.Get(0).([]models.BadgeGrant)
+	return grants, args.Error(1)
+}
+
+func (m *MockRepository) Stats(ctx context.Context, userID uuid.UUID) (*models.UserStats, error) {
+	args := m.Called(ctx, userID)
+	stats, _ := args.Get(0).(*models.UserStats)
+	return stats, args.Error(1)
+}
+
+func (m *MockRepository) PostOwner(ctx context.Context, postID uuid.UUID) (uuid.UUID, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
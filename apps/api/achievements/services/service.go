@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	achievementErrors "github.com/qolzam/telar/apps/api/achievements/errors"
+	"github.com/qolzam/telar/apps/api/achievements/models"
+	"github.com/qolzam/telar/apps/api/achievements/repository"
+	"github.com/qolzam/telar/apps/api/internal/events"
+)
+
+// Service defines badge operations.
+type Service interface {
+	// Badges returns the badge catalog.
+	Badges() []models.Badge
+
+	// ListUserBadges returns the badges userID earned, newest first.
+	ListUserBadges(ctx context.Context, userID uuid.UUID) (*models.UserBadgesResponse, error)
+
+	// Evaluate grants userID every badge whose rule they now meet and notifies them of
+	// each; returns the newly granted badges.
+	Evaluate(ctx context.Context, userID uuid.UUID) ([]models.Badge, error)
+}
+
+type service struct {
+	repo   repository.BadgeRepository
+	badges []models.Badge
+	rules  map[string]models.Rule
+	now    func() time.Time
+}
+
+// NewService constructs an achievements service over a badge catalog, rejecting
+// duplicate badge IDs and rules that do not parse.
+func NewService(repo repository.BadgeRepository, badges []models.Badge) (Service, error) {
+	rules := make(map[string]models.Rule, len(badges))
+	for _, badge := range badges {
+		if badge.Id == "" {
+			return nil, fmt.Errorf("badge %q has no id", badge.Name)
+		}
+		if _, dup := rules[badge.Id]; dup {
+			return nil, fmt.Errorf("duplicate badge id %q", badge.Id)
+		}
+		rule, err := models.ParseRule(badge.Rule)
+		if err != nil {
+			return nil, fmt.Errorf("badge %q: %w", badge.Id, err)
+		}
+		rules[badge.Id] = rule
+	}
+	return &service{repo: repo, badges: badges, rules: rules, now: time.Now}, nil
+}
+
+func (s *service) Badges() []models.Badge {
+	return s.badges
+}
+
+func (s *service) ListUserBadges(ctx context.Context, userID uuid.UUID) (*models.UserBadgesResponse, error) {
+	if userID == uuid.Nil {
+		return nil, achievementErrors.ErrInvalidRequest
+	}
+	grants, err := s.repo.ListGrants(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", achievementErrors.ErrDatabaseOperation, err)
+	}
+
+	byID := make(map[string]models.Badge, len(s.badges))
+	for _, badge := range s.badges {
+		byID[badge.Id] = badge
+	}
+	response := &models.UserBadgesResponse{UserId: userID.String(), Badges: make([]models.UserBadge, 0, len(grants))}
+	for _, grant := range grants {
+		// Grants of badges since removed from the catalog are not shown
+		if badge, ok := byID[grant.BadgeId]; ok {
+			response.Badges = append(response.Badges, models.UserBadge{Badge: badge, GrantedDate: grant.GrantedDate})
+		}
+	}
+	return response, nil
+}
+
+func (s *service) Evaluate(ctx context.Context, userID uuid.UUID) ([]models.Badge, error) {
+	grants, err := s.repo.ListGrants(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", achievementErrors.ErrDatabaseOperation, err)
+	}
+	earned := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		earned[grant.BadgeId] = true
+	}
+	if len(earned) >= len(s.badges) {
+		return nil, nil
+	}
+
+	stats, err := s.repo.Stats(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", achievementErrors.ErrDatabaseOperation, err)
+	}
+	now := s.now().UTC()
+	metrics := stats.Metrics(now)
+
+	var granted []models.Badge
+	for _, badge := range s.badges {
+		if earned[badge.Id] || !s.rules[badge.Id].Met(metrics) {
+			continue
+		}
+		created, err := s.repo.Grant(ctx, userID, badge.Id, now.UnixMilli())
+		if err != nil {
+			return granted, fmt.Errorf("%w: %v", achievementErrors.ErrDatabaseOperation, err)
+		}
+		// A concurrent evaluation may have granted it first; only one notifies
+		if created {
+			granted = append(granted, badge)
+			s.notify(ctx, userID, badge, now.UnixMilli())
+		}
+	}
+	return granted, nil
+}
+
+func (s *service) notify(ctx context.Context, userID uuid.UUID, badge models.Badge, now int64) {
+	if !events.HasSubscribers() {
+		return
+	}
+	events.Publish(ctx, events.Event{
+		Type: events.TypeNotification,
+		Data: events.Notification{
+			Kind:    events.NotificationBadge,
+			BadgeId: badge.Id,
+			Preview: badge.Name,
+		},
+		CreatedDate: now,
+		Recipients:  []uuid.UUID{userID},
+	})
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/achievements/models"
+	commentsModels "github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRule(t *testing.T) {
+	rule, err := models.ParseRule("upvotes >= 100")
+	require.NoError(t, err)
+	assert.Equal(t, models.Rule{Metric: models.MetricUpvotes, Threshold: 100}, rule)
+	assert.True(t, rule.Met(map[string]int64{models.MetricUpvotes: 100}))
+	assert.False(t, rule.Met(map[string]int64{models.MetricUpvotes: 99}))
+
+	for _, bad := range []string{"", "upvotes > 100", "karma >= 1", "posts >= 0", "posts >= many"} {
+		_, err := models.ParseRule(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestNewService(t *testing.T) {
+	_, err := NewService(new(MockRepository), models.DefaultBadges())
+	require.NoError(t, err, "the default catalog must be valid")
+
+	_, err = NewService(new(MockRepository), []models.Badge{{Id: "a", Rule: "posts >= 1"}, {Id: "a", Rule: "posts >= 2"}})
+	assert.Error(t, err)
+	_, err = NewService(new(MockRepository), []models.Badge{{Id: "a", Rule: "posts"}})
+	assert.Error(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := new(MockRepository)
+	repo.On("ListGrants", ctx, userID).Return([]models.BadgeGrant{{UserId: userID, BadgeId: "first-post"}}, nil)
+	repo.On("Stats", ctx, userID).Return(&models.UserStats{
+		Posts:      3,
+		Upvotes:    100,
+		JoinedDate: now.AddDate(-1, 0, -1).UnixMilli(),
+	}, nil)
+	repo.On("Grant", ctx, userID, "well-liked", now.UnixMilli()).Return(true, nil).Once()
+	repo.On("Grant", ctx, userID, "anniversary", now.UnixMilli()).Return(false, nil).Once()
+
+	svc, err := NewService(repo, models.DefaultBadges())
+	require.NoError(t, err)
+	svc.(*service).now = func() time.Time { return now }
+
+	var notified []events.Notification
+	unsubscribe := events.Subscribe(func(event events.Event) {
+		if event.Type == events.TypeNotification {
+			require.Equal(t, []uuid.UUID{userID}, event.Recipients)
+			notified = append(notified, event.Data.(events.Notification))
+		}
+	})
+	defer unsubscribe()
+
+	granted, err := svc.Evaluate(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, granted, 1, "an already held grant is neither repeated nor notified")
+	assert.Equal(t, "well-liked", granted[0].Id)
+	require.Len(t, notified, 1)
+	assert.Equal(t, events.NotificationBadge, notified[0].Kind)
+	assert.Equal(t, "well-liked", notified[0].BadgeId)
+	repo.AssertExpectations(t)
+}
+
+func TestListUserBadges(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	repo := new(MockRepository)
+	repo.On("ListGrants", ctx, userID).Return([]models.BadgeGrant{
+		{UserId: userID, BadgeId: "retired", GrantedDate: 3},
+		{UserId: userID, BadgeId: "first-post", GrantedDate: 2},
+	}, nil)
+
+	svc, err := NewService(repo, models.DefaultBadges())
+	require.NoError(t, err)
+
+	response, err := svc.ListUserBadges(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, response.Badges, 1)
+	assert.Equal(t, "First Post", response.Badges[0].Name)
+	assert.Equal(t, int64(2), response.Badges[0].GrantedDate)
+}
+
+func TestEvaluatorDeliver(t *testing.T) {
+	author := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+	evaluator := NewEvaluator(nil, nil, 4)
+
+	evaluator.Deliver(events.Event{Type: events.TypePostCreated, Data: postsModels.PostResponse{OwnerUserId: author.String()}})
+	evaluator.Deliver(events.Event{Type: events.TypePostCreated, Data: postsModels.PostResponse{Anonymous: true}})
+	evaluator.Deliver(events.Event{Type: events.TypeCommentCreated, Data: commentsModels.CommentResponse{OwnerUserId: author.String()}})
+	evaluator.Deliver(events.Event{Type: events.TypeVoteCast, Data: events.Vote{PostId: postID.String()}})
+
+	require.Len(t, evaluator.queue, 3, "anonymous posts are not evaluated")
+	assert.Equal(t, evaluation{userID: author}, <-evaluator.queue)
+	assert.Equal(t, evaluation{userID: author}, <-evaluator.queue)
+	assert.Equal(t, evaluation{postID: postID}, <-evaluator.queue)
+}
+
+func TestEvaluatorResolvesVoteAuthor(t *testing.T) {
+	ctx := context.Background()
+	author := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+
+	repo := new(MockRepository)
+	repo.On("PostOwner", ctx, postID).Return(author, nil)
+	repo.On("ListGrants", ctx, author).Return([]models.BadgeGrant(nil), nil)
+	repo.On("Stats", ctx, author).Return(&models.UserStats{}, nil)
+
+	svc, err := NewService(repo, models.DefaultBadges())
+	require.NoError(t, err)
+	NewEvaluator(svc, repo, 1).evaluate(ctx, evaluation{postID: postID})
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "Grant", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/analytics/errors"
+	"github.com/qolzam/telar/apps/api/analytics/models"
+	"github.com/qolzam/telar/apps/api/analytics/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type EventsHandler struct {
+	service services.Service
+}
+
+func NewEventsHandler(service services.Service) *EventsHandler {
+	return &EventsHandler{service: service}
+}
+
+// Record stores a batch of client telemetry events (impressions, dwell time, clicks).
+// Invalid events are reported per index; the rest of the batch is still stored.
+// Endpoint: POST /events
+func (h *EventsHandler) Record(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var batch models.EventBatch
+	decoder := json.NewDecoder(bytes.NewReader(c.Body()))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&batch); err != nil {
+		return errors.HandleValidationError(c, "invalid event batch: "+err.Error())
+	}
+
+	result, err := h.service.RecordEvents(c.Context(), user.UserID, c.Get(fiber.HeaderUserAgent), &batch)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusAccepted).JSON(result)
+}
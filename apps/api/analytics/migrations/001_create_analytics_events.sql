@@ -0,0 +1,44 @@
+-- Client telemetry (impressions, dwell time, clicks) recorded by POST /events.
+-- Range partitioned by month on occurred_at so old months can be detached or dropped
+-- without vacuuming a single large table. Aggregation jobs read these rows and weight
+-- each one by 1 / sample_rate to undo client-event sampling.
+CREATE TABLE IF NOT EXISTS analytics_events (
+    id UUID NOT NULL,
+    event_type TEXT NOT NULL,
+    post_id UUID NOT NULL,
+    user_id UUID,
+    session_id TEXT NOT NULL DEFAULT '',
+    surface TEXT NOT NULL DEFAULT '',
+    target TEXT NOT NULL DEFAULT '',
+    dwell_ms BIGINT NOT NULL DEFAULT 0,
+    sample_rate REAL NOT NULL DEFAULT 1,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    received_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (id, occurred_at)
+) PARTITION BY RANGE (occurred_at);
+
+-- Catches rows for months whose partition has not been created yet
+CREATE TABLE IF NOT EXISTS analytics_events_default PARTITION OF analytics_events DEFAULT;
+
+-- Monthly partitions are created ahead of time by the API; seed the current and next month
+DO $$
+DECLARE
+    month_start DATE;
+BEGIN
+    FOR i IN 0..1 LOOP
+        month_start := (date_trunc('month', NOW()) + make_interval(months => i))::DATE;
+        EXECUTE format(
+            'CREATE TABLE IF NOT EXISTS %I PARTITION OF analytics_events FOR VALUES FROM (%L) TO (%L)',
+            'analytics_events_' || to_char(month_start, 'YYYYMM'),
+            month_start,
+            (month_start + INTERVAL '1 month')::DATE
+        );
+    END LOOP;
+END $$;
+
+-- Per-post rollups (impressions, CTR, average dwell) over a time window
+CREATE INDEX IF NOT EXISTS idx_analytics_events_post_occurred ON analytics_events(post_id, occurred_at);
+
+-- Per-type scans for global aggregation jobs
+CREATE INDEX IF NOT EXISTS idx_analytics_events_type_occurred ON analytics_events(event_type, occurred_at);
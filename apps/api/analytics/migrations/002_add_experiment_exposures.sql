@@ -0,0 +1,9 @@
+-- Experiment exposure events (type "exposure") record which variant a user saw.
+-- They are not always tied to a post, so post_id becomes optional.
+ALTER TABLE analytics_events ALTER COLUMN post_id DROP NOT NULL;
+ALTER TABLE analytics_events ADD COLUMN IF NOT EXISTS experiment_key TEXT NOT NULL DEFAULT '';
+ALTER TABLE analytics_events ADD COLUMN IF NOT EXISTS variant TEXT NOT NULL DEFAULT '';
+
+-- Per-experiment exposure counts by variant
+CREATE INDEX IF NOT EXISTS idx_analytics_events_experiment ON analytics_events(experiment_key, variant, occurred_at)
+    WHERE event_type = 'exposure';
@@ -0,0 +1,46 @@
+package models
+
+// EventType is the kind of client telemetry event
+type EventType string
+
+const (
+	// EventImpression is recorded when a post is shown on screen
+	EventImpression EventType = "impression"
+	// EventDwell reports how long a post stayed on screen
+	EventDwell EventType = "dwell"
+	// EventClick is recorded when the reader opens a post or one of its links
+	EventClick EventType = "click"
+	// EventExposure is recorded when the reader first sees an experiment variant
+	EventExposure EventType = "exposure"
+)
+
+// Event is one client telemetry event in a POST /events batch
+type Event struct {
+	Type          EventType `json:"type"`
+	PostId        string    `json:"postId,omitempty"`        // Required except on exposure events
+	Surface       string    `json:"surface,omitempty"`       // Where the post was shown, e.g. "feed", "detail", "search"
+	Target        string    `json:"target,omitempty"`        // Clicked element; required for click events
+	DwellMs       int64     `json:"dwellMs,omitempty"`       // Time on screen; required for dwell events
+	ExperimentKey string    `json:"experimentKey,omitempty"` // Required for exposure events
+	Variant       string    `json:"variant,omitempty"`       // Assigned variant; required for exposure events
+	OccurredAt    int64     `json:"occurredAt,omitempty"`    // Client time in Unix milliseconds; defaults to receipt time
+}
+
+// EventBatch is the POST /events request body
+type EventBatch struct {
+	SessionId string  `json:"sessionId,omitempty"`
+	Events    []Event `json:"events"`
+}
+
+// RejectedEvent explains why an event in a batch failed validation
+type RejectedEvent struct {
+	Index  int    `json:"index"`
+	Reason string `json:"reason"`
+}
+
+// BatchResult reports what happened to each event in a batch
+type BatchResult struct {
+	Accepted int             `json:"accepted"`           // Stored
+	Dropped  int             `json:"dropped"`            // Valid but skipped by sampling, de-duplication or bot filtering
+	Rejected []RejectedEvent `json:"rejected,omitempty"` // Failed validation
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+
+	// partitions remembers the months whose partition is known to exist
+	partitions sync.Map
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) InsertEvents(ctx context.Context, events []StoredEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	for _, e := range events {
+		r.ensurePartition(ctx, e.OccurredAt)
+	}
+
+	const columns = 12
+	placeholders := make([]string, 0, len(events))
+	args := make([]interface{}, 0, len(events)*columns)
+	for i, e := range events {
+		base := i * columns
+		placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			base+1, base+2, base+3, base+4, base+5, base+6, base+7, base+8, base+9, base+10, base+11, base+12))
+		args = append(args, e.ID, e.Type, e.PostID, e.UserID, e.SessionID, e.Surface, e.Target, e.DwellMs, e.ExperimentKey, e.Variant, e.SampleRate, e.OccurredAt)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %sanalytics_events
+			(id, event_type, post_id, user_id, session_id, surface, target, dwell_ms, experiment_key, variant, sample_rate, occurred_at)
+		VALUES %s
+		ON CONFLICT DO NOTHING
+	`, r.schemaPrefix(), strings.Join(placeholders, ", "))
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("insert analytics events: %w", err)
+	}
+	return nil
+}
+
+// ensurePartition creates the monthly partition for t once per process. Failures are
+// logged only: rows still land in the default partition.
+func (r *postgresRepository) ensurePartition(ctx context.Context, t time.Time) {
+	start := time.Date(t.UTC().Year(), t.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	name := "analytics_events_" + start.Format("200601")
+	if _, ok := r.partitions.Load(name); ok {
+		return
+	}
+
+	query := fmt.Sprintf(
+		`CREATE TABLE IF NOT EXISTS %s%s PARTITION OF %sanalytics_events FOR VALUES FROM ('%s') TO ('%s')`,
+		r.schemaPrefix(), name, r.schemaPrefix(), start.Format("2006-01-02"), start.AddDate(0, 1, 0).Format("2006-01-02"),
+	)
+	if _, err := r.client.DB().ExecContext(ctx, query); err != nil {
+		log.Warn("Could not create analytics partition %s: %v", name, err)
+		return
+	}
+	r.partitions.Store(name, struct{}{})
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := fmt.Sprintf(`UPDATE %sanalytics_events SET user_id = NULL WHERE user_id = $1`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("detach events of user: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// Repository defines data access for analytics events.
+type Repository interface {
+	// InsertEvents stores a batch of validated events in one statement.
+	InsertEvents(ctx context.Context, events []StoredEvent) error
+
+	// DeleteByUser detaches userID's events from them; they stay counted as anonymous events.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// StoredEvent is a validated event as written to analytics_events.
+type StoredEvent struct {
+	ID            uuid.UUID  `db:"id"`
+	Type          string     `db:"event_type"`
+	PostID        *uuid.UUID `db:"post_id"`
+	UserID        *uuid.UUID `db:"user_id"`
+	SessionID     string     `db:"session_id"`
+	Surface       string     `db:"surface"`
+	Target        string     `db:"target"`
+	DwellMs       int64      `db:"dwell_ms"`
+	ExperimentKey string     `db:"experiment_key"`
+	Variant       string     `db:"variant"`
+	SampleRate    float64    `db:"sample_rate"`
+	OccurredAt    time.Time  `db:"occurred_at"`
+}
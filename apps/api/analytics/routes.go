@@ -0,0 +1,42 @@
+package analytics
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/analytics/handlers"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	"github.com/qolzam/telar/apps/api/internal/middleware/ratelimit"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+type Handlers struct {
+	EventsHandler *handlers.EventsHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires analytics event endpoints.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	// Clients batch events, so a modest per-IP limit is enough and caps abuse
+	limiter := ratelimit.NewWithConfig(cfg.Analytics.RateLimitPerMinute > 0, cfg.Analytics.RateLimitPerMinute, time.Minute, "analytics events")
+
+	app.Post("/events", limiter, dualAuthMiddleware, handlers.EventsHandler.Record)
+}
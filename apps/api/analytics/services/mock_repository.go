@@ -0,0 +1,27 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/analytics/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the analytics repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) InsertEvents(ctx context.Context, events []repository.StoredEvent) error {
+	args := m.Called(ctx, events)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"regexp"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	analyticsErrors "github.com/qolzam/telar/apps/api/analytics/errors"
+	"github.com/qolzam/telar/apps/api/analytics/models"
+	"github.com/qolzam/telar/apps/api/analytics/repository"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+const (
+	maxDwell        = 30 * time.Minute
+	maxClockSkew    = 5 * time.Minute
+	maxEventAge     = 24 * time.Hour
+	maxSessionIDLen = 64
+	maxSurfaceLen   = 32
+	maxTargetLen    = 64
+	maxKeyLen       = 64
+)
+
+// botUserAgent matches crawlers, headless browsers and HTTP libraries. Their events are
+// dropped silently so scripted traffic cannot inflate impression counts.
+var botUserAgent = regexp.MustCompile(`(?i)bot|crawl|spider|slurp|headless|phantomjs|selenium|puppeteer|playwright|curl|wget|python-requests|go-http-client|okhttp|scrapy|httpclient|facebookexternalhit`)
+
+// Service defines analytics event operations.
+type Service interface {
+	// RecordEvents validates, filters and stores a batch of client events.
+	RecordEvents(ctx context.Context, userID uuid.UUID, userAgent string, batch *models.EventBatch) (*models.BatchResult, error)
+}
+
+type service struct {
+	repo   repository.Repository
+	config platformconfig.AnalyticsConfig
+	sample func() float64
+	now    func() time.Time
+}
+
+// NewService constructs an analytics service.
+func NewService(repo repository.Repository, cfg platformconfig.AnalyticsConfig) Service {
+	return &service{repo: repo, config: cfg, sample: rand.Float64, now: time.Now}
+}
+
+func (s *service) RecordEvents(ctx context.Context, userID uuid.UUID, userAgent string, batch *models.EventBatch) (*models.BatchResult, error) {
+	if !s.config.EventsEnabled {
+		return nil, analyticsErrors.ErrEventsDisabled
+	}
+	if batch == nil || len(batch.Events) == 0 {
+		return nil, fmt.Errorf("%w: events are required", analyticsErrors.ErrInvalidRequest)
+	}
+	if s.config.MaxBatchSize > 0 && len(batch.Events) > s.config.MaxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d events per batch", analyticsErrors.ErrBatchTooLarge, s.config.MaxBatchSize)
+	}
+	if len(batch.SessionId) > maxSessionIDLen {
+		return nil, fmt.Errorf("%w: sessionId is too long", analyticsErrors.ErrInvalidRequest)
+	}
+
+	result := &models.BatchResult{}
+	if isBot(userAgent) {
+		result.Dropped = len(batch.Events)
+		return result, nil
+	}
+
+	now := s.now()
+	var owner *uuid.UUID
+	if userID != uuid.Nil {
+		owner = &userID
+	}
+
+	seen := make(map[string]struct{}, len(batch.Events))
+	stored := make([]repository.StoredEvent, 0, len(batch.Events))
+	for i, e := range batch.Events {
+		postID, occurredAt, err := validateEvent(e, now)
+		if err != nil {
+			result.Rejected = append(result.Rejected, models.RejectedEvent{Index: i, Reason: err.Error()})
+			continue
+		}
+
+		key := dedupeKey(e)
+		if _, dup := seen[key]; dup {
+			result.Dropped++
+			continue
+		}
+		seen[key] = struct{}{}
+
+		rate := s.sampleRate(e.Type)
+		if rate <= 0 || (rate < 1 && s.sample() >= rate) {
+			result.Dropped++
+			continue
+		}
+
+		id, err := uuid.NewV4()
+		if err != nil {
+			return nil, fmt.Errorf("generate event id: %w", err)
+		}
+		stored = append(stored, repository.StoredEvent{
+			ID:            id,
+			Type:          string(e.Type),
+			PostID:        postID,
+			UserID:        owner,
+			SessionID:     batch.SessionId,
+			Surface:       e.Surface,
+			Target:        e.Target,
+			DwellMs:       e.DwellMs,
+			ExperimentKey: e.ExperimentKey,
+			Variant:       e.Variant,
+			SampleRate:    rate,
+			OccurredAt:    occurredAt,
+		})
+	}
+
+	if len(stored) > 0 {
+		if err := s.repo.InsertEvents(ctx, stored); err != nil {
+			return nil, fmt.Errorf("%w: %v", analyticsErrors.ErrDatabaseOperation, err)
+		}
+	}
+	result.Accepted = len(stored)
+	return result, nil
+}
+
+// sampleRate returns the configured fraction of events of this type to keep
+func (s *service) sampleRate(t models.EventType) float64 {
+	var rate float64
+	switch t {
+	case models.EventImpression:
+		rate = s.config.ImpressionSampleRate
+	case models.EventDwell:
+		rate = s.config.DwellSampleRate
+	case models.EventClick:
+		rate = s.config.ClickSampleRate
+	case models.EventExposure:
+		// Experiment analysis needs every exposure; sampling would bias variant counts
+		rate = 1
+	}
+	if rate > 1 {
+		return 1
+	}
+	return rate
+}
+
+// validateEvent checks an event against the schema for its type. The post ID is nil
+// only for exposure events that were not tied to a post.
+func validateEvent(e models.Event, now time.Time) (*uuid.UUID, time.Time, error) {
+	var postID *uuid.UUID
+	if e.PostId != "" || e.Type != models.EventExposure {
+		id, err := uuid.FromString(e.PostId)
+		if err != nil || id == uuid.Nil {
+			return nil, time.Time{}, fmt.Errorf("postId must be a valid UUID")
+		}
+		postID = &id
+	}
+	if len(e.Surface) > maxSurfaceLen {
+		return nil, time.Time{}, fmt.Errorf("surface must be at most %d characters", maxSurfaceLen)
+	}
+	if len(e.Target) > maxTargetLen {
+		return nil, time.Time{}, fmt.Errorf("target must be at most %d characters", maxTargetLen)
+	}
+	if len(e.ExperimentKey) > maxKeyLen || len(e.Variant) > maxKeyLen {
+		return nil, time.Time{}, fmt.Errorf("experimentKey and variant must be at most %d characters", maxKeyLen)
+	}
+	if e.Type != models.EventExposure && (e.ExperimentKey != "" || e.Variant != "") {
+		return nil, time.Time{}, fmt.Errorf("experimentKey and variant are only allowed on exposure events")
+	}
+
+	switch e.Type {
+	case models.EventImpression:
+		if e.DwellMs != 0 {
+			return nil, time.Time{}, fmt.Errorf("dwellMs is only allowed on dwell events")
+		}
+	case models.EventDwell:
+		if e.DwellMs <= 0 || e.DwellMs > maxDwell.Milliseconds() {
+			return nil, time.Time{}, fmt.Errorf("dwellMs must be between 1 and %d", maxDwell.Milliseconds())
+		}
+	case models.EventClick:
+		if strings.TrimSpace(e.Target) == "" {
+			return nil, time.Time{}, fmt.Errorf("target is required for click events")
+		}
+		if e.DwellMs != 0 {
+			return nil, time.Time{}, fmt.Errorf("dwellMs is only allowed on dwell events")
+		}
+	case models.EventExposure:
+		if strings.TrimSpace(e.ExperimentKey) == "" || strings.TrimSpace(e.Variant) == "" {
+			return nil, time.Time{}, fmt.Errorf("experimentKey and variant are required for exposure events")
+		}
+		if e.DwellMs != 0 {
+			return nil, time.Time{}, fmt.Errorf("dwellMs is only allowed on dwell events")
+		}
+	default:
+		return nil, time.Time{}, fmt.Errorf("unknown event type %q", e.Type)
+	}
+
+	occurredAt := now
+	if e.OccurredAt != 0 {
+		occurredAt = time.UnixMilli(e.OccurredAt)
+		if occurredAt.After(now.Add(maxClockSkew)) || occurredAt.Before(now.Add(-maxEventAge)) {
+			return nil, time.Time{}, fmt.Errorf("occurredAt must be within the last 24 hours")
+		}
+	}
+	return postID, occurredAt.UTC(), nil
+}
+
+// dedupeKey identifies repeats within a batch. Impressions count once per post and
+// surface, so re-rendering a feed item while scrolling does not inflate them.
+func dedupeKey(e models.Event) string {
+	switch e.Type {
+	case models.EventImpression:
+		return string(e.Type) + "|" + e.PostId + "|" + e.Surface
+	case models.EventExposure:
+		return string(e.Type) + "|" + e.ExperimentKey + "|" + e.Variant
+	}
+	return fmt.Sprintf("%s|%s|%s|%s|%d|%d", e.Type, e.PostId, e.Surface, e.Target, e.DwellMs, e.OccurredAt)
+}
+
+// isBot reports whether the user agent looks automated; an empty one counts as automated
+func isBot(userAgent string) bool {
+	userAgent = strings.TrimSpace(userAgent)
+	return userAgent == "" || botUserAgent.MatchString(userAgent)
+}
@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	analyticsErrors "github.com/qolzam/telar/apps/api/analytics/errors"
+	"github.com/qolzam/telar/apps/api/analytics/models"
+	"github.com/qolzam/telar/apps/api/analytics/repository"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const browserUA = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_0) AppleWebKit/605.1.15 Safari/605.1.15"
+
+func newTestService(repo repository.Repository, cfg platformconfig.AnalyticsConfig, now time.Time) *service {
+	svc := NewService(repo, cfg).(*service)
+	svc.now = func() time.Time { return now }
+	svc.sample = func() float64 { return 0.5 }
+	return svc
+}
+
+func defaultConfig() platformconfig.AnalyticsConfig {
+	return platformconfig.AnalyticsConfig{
+		EventsEnabled:        true,
+		MaxBatchSize:         10,
+		ImpressionSampleRate: 1,
+		DwellSampleRate:      1,
+		ClickSampleRate:      1,
+	}
+}
+
+func TestRecordEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4()).String()
+
+	t.Run("stores valid events and reports rejected ones", func(t *testing.T) {
+		var stored []repository.StoredEvent
+		mockRepo := new(MockRepository)
+		mockRepo.On("InsertEvents", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).([]repository.StoredEvent)
+		}).Return(nil).Once()
+
+		svc := newTestService(mockRepo, defaultConfig(), now)
+		result, err := svc.RecordEvents(ctx, userID, browserUA, &models.EventBatch{
+			SessionId: "s1",
+			Events: []models.Event{
+				{Type: models.EventImpression, PostId: postID, Surface: "feed"},
+				{Type: models.EventImpression, PostId: postID, Surface: "feed"},
+				{Type: models.EventDwell, PostId: postID, DwellMs: 4200, OccurredAt: now.Add(-time.Minute).UnixMilli()},
+				{Type: models.EventClick, PostId: postID},
+				{Type: "share", PostId: postID},
+				{Type: models.EventDwell, PostId: postID, DwellMs: 10, OccurredAt: now.Add(-48 * time.Hour).UnixMilli()},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Accepted)
+		assert.Equal(t, 1, result.Dropped)
+		require.Len(t, result.Rejected, 3)
+		assert.Equal(t, 3, result.Rejected[0].Index)
+		assert.Equal(t, 4, result.Rejected[1].Index)
+		assert.Equal(t, 5, result.Rejected[2].Index)
+
+		require.Len(t, stored, 2)
+		assert.Equal(t, "impression", stored[0].Type)
+		assert.Equal(t, now, stored[0].OccurredAt)
+		assert.Equal(t, userID, *stored[0].UserID)
+		assert.Equal(t, "s1", stored[0].SessionID)
+		assert.Equal(t, int64(4200), stored[1].DwellMs)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("drops bot traffic without storing", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := newTestService(mockRepo, defaultConfig(), now)
+
+		for _, ua := range []string{"", "Googlebot/2.1", "curl/8.0", "Mozilla/5.0 HeadlessChrome/120.0"} {
+			result, err := svc.RecordEvents(ctx, userID, ua, &models.EventBatch{
+				Events: []models.Event{{Type: models.EventImpression, PostId: postID}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, 0, result.Accepted, ua)
+			assert.Equal(t, 1, result.Dropped, ua)
+		}
+		mockRepo.AssertNotCalled(t, "InsertEvents", mock.Anything, mock.Anything)
+	})
+
+	t.Run("samples by event type and records the rate", func(t *testing.T) {
+		var stored []repository.StoredEvent
+		mockRepo := new(MockRepository)
+		mockRepo.On("InsertEvents", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).([]repository.StoredEvent)
+		}).Return(nil).Once()
+
+		cfg := defaultConfig()
+		cfg.ImpressionSampleRate = 0.25
+		cfg.ClickSampleRate = 0.75
+		svc := newTestService(mockRepo, cfg, now)
+
+		result, err := svc.RecordEvents(ctx, userID, browserUA, &models.EventBatch{
+			Events: []models.Event{
+				{Type: models.EventImpression, PostId: postID},
+				{Type: models.EventClick, PostId: postID, Target: "link"},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Accepted)
+		assert.Equal(t, 1, result.Dropped)
+		require.Len(t, stored, 1)
+		assert.Equal(t, 0.75, stored[0].SampleRate)
+	})
+
+	t.Run("stores exposures unsampled and without a post", func(t *testing.T) {
+		var stored []repository.StoredEvent
+		mockRepo := new(MockRepository)
+		mockRepo.On("InsertEvents", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).([]repository.StoredEvent)
+		}).Return(nil).Once()
+
+		cfg := defaultConfig()
+		cfg.ImpressionSampleRate = 0
+		svc := newTestService(mockRepo, cfg, now)
+
+		result, err := svc.RecordEvents(ctx, userID, browserUA, &models.EventBatch{
+			Events: []models.Event{
+				{Type: models.EventExposure, ExperimentKey: "feed-ranking", Variant: "treatment"},
+				{Type: models.EventExposure, ExperimentKey: "feed-ranking", Variant: "treatment"},
+				{Type: models.EventExposure, ExperimentKey: "feed-ranking"},
+				{Type: models.EventImpression, PostId: postID, Variant: "treatment"},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Accepted)
+		assert.Equal(t, 1, result.Dropped)
+		require.Len(t, result.Rejected, 2)
+		require.Len(t, stored, 1)
+		assert.Nil(t, stored[0].PostID)
+		assert.Equal(t, "feed-ranking", stored[0].ExperimentKey)
+		assert.Equal(t, "treatment", stored[0].Variant)
+		assert.Equal(t, float64(1), stored[0].SampleRate)
+	})
+
+	t.Run("rejects oversized batches", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), defaultConfig(), now)
+		events := make([]models.Event, 11)
+		_, err := svc.RecordEvents(ctx, userID, browserUA, &models.EventBatch{Events: events})
+		assert.True(t, errors.Is(err, analyticsErrors.ErrBatchTooLarge))
+
+		_, err = svc.RecordEvents(ctx, userID, browserUA, &models.EventBatch{})
+		assert.True(t, errors.Is(err, analyticsErrors.ErrInvalidRequest))
+	})
+
+	t.Run("maps storage failures", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("InsertEvents", ctx, mock.Anything).Return(errors.New("db down")).Once()
+
+		svc := newTestService(mockRepo, defaultConfig(), now)
+		_, err := svc.RecordEvents(ctx, userID, browserUA, &models.EventBatch{
+			Events: []models.Event{{Type: models.EventImpression, PostId: postID}},
+		})
+		assert.True(t, errors.Is(err, analyticsErrors.ErrDatabaseOperation))
+	})
+}
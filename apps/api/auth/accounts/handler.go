@@ -0,0 +1,116 @@
+package accounts
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(s *Service) *Handler {
+	return &Handler{svc: s}
+}
+
+func currentUser(c *fiber.Ctx) (types.UserContext, bool) {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	return user, ok && user.UserID != uuid.Nil
+}
+
+// Link handles POST /auth/accounts/link
+func (h *Handler) Link(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	model := &LinkAccountModel{}
+	if err := c.BodyParser(model); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+	if model.Username == "" {
+		return errors.HandleMissingFieldError(c, "username")
+	}
+	if model.Password == "" {
+		return errors.HandleMissingFieldError(c, "password")
+	}
+
+	account, err := h.svc.Link(c.Context(), user, model.Username, model.Password, model.Code)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(account)
+}
+
+// List handles GET /auth/accounts
+func (h *Handler) List(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	accounts, err := h.svc.ListLinked(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{"accounts": accounts})
+}
+
+// Unlink handles DELETE /auth/accounts/:accountId
+func (h *Handler) Unlink(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+	accountID, err := uuid.FromString(c.Params("accountId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "accountId")
+	}
+
+	if err := h.svc.Unlink(c.Context(), user.UserID, accountID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Switch handles POST /auth/switch/:accountId and responds in the login response shape
+func (h *Handler) Switch(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+	accountID, err := uuid.FromString(c.Params("accountId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "accountId")
+	}
+
+	result, err := h.svc.Switch(c.Context(), user, accountID, c.Get(fiber.HeaderUserAgent), c.IP())
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{
+		"user":        result.User,
+		"accessToken": result.AccessToken,
+		"tokenType":   "Bearer",
+		"expires_in":  strconv.Itoa(0),
+	})
+}
+
+// Sessions handles GET /auth/sessions
+func (h *Handler) Sessions(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	sessions, err := h.svc.ListSessions(c.Context(), user)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{"sessions": sessions})
+}
@@ -0,0 +1,58 @@
+package accounts
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// LinkAccountModel is the request body for POST /auth/accounts/link.
+// The credentials are those of the account being linked; Code is its second factor
+// code, needed when it has MFA enabled.
+type LinkAccountModel struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Code     string `json:"code,omitempty"`
+}
+
+// AccountSummary identifies an account by its public profile
+type AccountSummary struct {
+	UserId     uuid.UUID `json:"userId"`
+	FullName   string    `json:"fullName"`
+	SocialName string    `json:"socialName"`
+	Avatar     string    `json:"avatar"`
+}
+
+// LinkedAccount is an account the current user can switch to
+type LinkedAccount struct {
+	AccountSummary
+	LinkedDate int64 `json:"linkedDate"`
+}
+
+// Session is an issued session together with the identity it acts as
+type Session struct {
+	ObjectId    uuid.UUID       `json:"objectId"`
+	Identity    *AccountSummary `json:"identity"`
+	LoginUserId uuid.UUID       `json:"loginUserId"`
+	Switched    bool            `json:"switched"`
+	Current     bool            `json:"current"`
+	UserAgent   string          `json:"userAgent"`
+	IPAddress   string          `json:"ipAddress"`
+	CreatedDate int64           `json:"createdDate"`
+}
+
+// accountProfile is the user object returned with a switched token, in the same shape as login
+type accountProfile struct {
+	ObjectId    uuid.UUID `json:"objectId"`
+	FullName    string    `json:"fullName"`
+	SocialName  string    `json:"socialName"`
+	Email       string    `json:"email"`
+	Avatar      string    `json:"avatar"`
+	Banner      string    `json:"banner"`
+	TagLine     string    `json:"tagLine"`
+	CreatedDate int64     `json:"createdDate"`
+}
+
+// SwitchResult is a token issued for a linked account
+type SwitchResult struct {
+	User        *accountProfile
+	AccessToken string
+}
@@ -0,0 +1,312 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/auth/models"
+	"github.com/qolzam/telar/apps/api/auth/repository"
+	tokenutil "github.com/qolzam/telar/apps/api/internal/auth/tokens"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/internal/utils"
+	profileServices "github.com/qolzam/telar/apps/api/profile/services"
+	"github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	// MaxLinkedAccounts caps how many accounts a single account can be linked to
+	MaxLinkedAccounts = 5
+	// sessionListLimit caps the number of sessions returned by ListSessions
+	sessionListLimit = 50
+)
+
+type Service struct {
+	authRepo    repository.AuthRepository
+	accountRepo repository.AccountRepository
+	profiles    profileServices.ProfileServiceClient
+	config      *ServiceConfig
+}
+
+type ServiceConfig struct {
+	WebDomain  string
+	PrivateKey string
+
+	// Experiments, when set, embeds the user's experiment variants in switched tokens
+	Experiments interfaces.ExperimentAssigner
+
+	// MFA, when set, makes linking an account with a second factor take a current code
+	MFA CodeVerifier
+}
+
+// CodeVerifier checks an account's second factor; the mfa service satisfies it
+type CodeVerifier interface {
+	Required(ctx context.Context, userID uuid.UUID) (bool, error)
+	VerifyCode(ctx context.Context, userID uuid.UUID, code string) error
+}
+
+// NewService creates the account linking service. profiles may be nil, in which
+// case tokens and listings fall back to the account's username.
+func NewService(authRepo repository.AuthRepository, accountRepo repository.AccountRepository, profiles profileServices.ProfileServiceClient, config *ServiceConfig) *Service {
+	return &Service{
+		authRepo:    authRepo,
+		accountRepo: accountRepo,
+		profiles:    profiles,
+		config:      config,
+	}
+}
+
+// Link links the current account to the account owning the given credentials.
+// Knowing the other account's password, and its second factor code when it has one,
+// is the proof of ownership.
+func (s *Service) Link(ctx context.Context, user types.UserContext, username, password, code string) (*LinkedAccount, error) {
+	other, err := s.authRepo.FindByUsername(ctx, username)
+	if err != nil || other == nil {
+		return nil, errors.ErrInvalidCredentials
+	}
+	if utils.CompareHash(other.Password, []byte(password)) != nil {
+		return nil, errors.ErrInvalidCredentials
+	}
+	if other.ObjectId == user.UserID {
+		return nil, errors.NewValidationError("An account cannot be linked to itself")
+	}
+	if !other.EmailVerified && !other.PhoneVerified {
+		return nil, errors.NewValidationError("User is not verified!")
+	}
+	if s.config.MFA != nil {
+		required, err := s.config.MFA.Required(ctx, other.ObjectId)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			if code == "" {
+				return nil, errors.NewValidationError("The account has MFA enabled; a code is required")
+			}
+			if err := s.config.MFA.VerifyCode(ctx, other.ObjectId, code); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	linked, err := s.accountRepo.AreLinked(ctx, user.UserID, other.ObjectId)
+	if err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	now := utils.UTCNowUnix()
+	if !linked {
+		for _, id := range []uuid.UUID{user.UserID, other.ObjectId} {
+			links, err := s.accountRepo.FindLinked(ctx, id)
+			if err != nil {
+				return nil, errors.WrapDatabaseError(err)
+			}
+			if len(links) >= MaxLinkedAccounts {
+				return nil, errors.NewValidationError(fmt.Sprintf("An account can be linked to at most %d accounts", MaxLinkedAccounts))
+			}
+		}
+		if err := s.accountRepo.LinkAccounts(ctx, user.UserID, other.ObjectId, user.UserID, now); err != nil {
+			return nil, errors.WrapDatabaseError(err)
+		}
+	}
+
+	summaries := s.summaries(ctx, []uuid.UUID{other.ObjectId})
+	return &LinkedAccount{AccountSummary: summaries[other.ObjectId], LinkedDate: now}, nil
+}
+
+// Unlink removes the link between the current account and accountID
+func (s *Service) Unlink(ctx context.Context, userID, accountID uuid.UUID) error {
+	removed, err := s.accountRepo.UnlinkAccounts(ctx, userID, accountID)
+	if err != nil {
+		return errors.WrapDatabaseError(err)
+	}
+	if !removed {
+		return errors.NewUserNotFoundError("Linked account not found")
+	}
+	return nil
+}
+
+// ListLinked returns the accounts the current account can switch to
+func (s *Service) ListLinked(ctx context.Context, userID uuid.UUID) ([]LinkedAccount, error) {
+	links, err := s.accountRepo.FindLinked(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	ids := make([]uuid.UUID, 0, len(links))
+	for _, link := range links {
+		ids = append(ids, link.UserId)
+	}
+	summaries := s.summaries(ctx, ids)
+
+	accounts := make([]LinkedAccount, 0, len(links))
+	for _, link := range links {
+		accounts = append(accounts, LinkedAccount{AccountSummary: summaries[link.UserId], LinkedDate: link.CreatedDate})
+	}
+	return accounts, nil
+}
+
+// Switch issues a token acting as accountID for a user signed in as another,
+// linked account. The "loginUid" claim keeps the account that originally signed in.
+func (s *Service) Switch(ctx context.Context, user types.UserContext, accountID uuid.UUID, userAgent, ipAddress string) (*SwitchResult, error) {
+	if accountID == user.UserID {
+		return nil, errors.NewValidationError("Already signed in as this account")
+	}
+	linked, err := s.accountRepo.AreLinked(ctx, user.UserID, accountID)
+	if err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	if !linked {
+		return nil, errors.ErrPermissionDenied
+	}
+
+	target, err := s.authRepo.FindByID(ctx, accountID)
+	if err != nil || target == nil {
+		return nil, errors.ErrUserNotFound
+	}
+	if target.Suspended {
+		return nil, errors.ErrPermissionDenied
+	}
+
+	loginUserID := user.LoginUserID
+	if loginUserID == uuid.Nil {
+		loginUserID = user.UserID
+	}
+
+	profile := s.readProfile(ctx, target)
+	sessionID := uuid.Must(uuid.NewV4())
+	claim := map[string]interface{}{
+		"displayName":   profile.FullName,
+		"socialName":    profile.SocialName,
+		"email":         profile.Email,
+		"avatar":        profile.Avatar,
+		"banner":        profile.Banner,
+		"tagLine":       profile.TagLine,
+		types.HeaderUID: target.ObjectId.String(),
+		"role":          target.Role,
+		"createdDate":   profile.CreatedDate,
+		"jti":           sessionID.String(),
+		"loginUid":      loginUserID.String(),
+	}
+	if s.config.Experiments != nil {
+		if assignments := s.config.Experiments.AssignmentsForUser(ctx, target.ObjectId, target.Role); len(assignments) > 0 {
+			claim["experiments"] = assignments
+		}
+	}
+
+	profileInfo := map[string]string{"id": target.ObjectId.String(), "login": target.Username, "name": profile.FullName, "audience": s.config.WebDomain}
+	accessToken, err := tokenutil.CreateTokenWithKey("telar", profileInfo, "Telar", claim, s.config.PrivateKey)
+	if err != nil {
+		return nil, errors.WrapSystemError(err)
+	}
+
+	s.RecordSession(ctx, sessionID, loginUserID, target.ObjectId, userAgent, ipAddress)
+	return &SwitchResult{User: profile, AccessToken: accessToken}, nil
+}
+
+// RecordSession stores an issued session for the session list. Failures are
+// logged rather than returned so they never block a sign-in.
+func (s *Service) RecordSession(ctx context.Context, sessionID, loginUserID, identityUserID uuid.UUID, userAgent, ipAddress string) {
+	session := &models.AuthSession{
+		ObjectId:       sessionID,
+		LoginUserId:    loginUserID,
+		IdentityUserId: identityUserID,
+		UserAgent:      userAgent,
+		IPAddress:      ipAddress,
+		CreatedDate:    utils.UTCNowUnix(),
+	}
+	if err := s.accountRepo.CreateSession(ctx, session); err != nil {
+		log.ErrorWithContext(ctx, "[accounts] failed to record session %s: %v", sessionID, err)
+	}
+}
+
+// ListSessions returns the current user's sessions, each with the identity it acts as
+func (s *Service) ListSessions(ctx context.Context, user types.UserContext) ([]Session, error) {
+	owner := user.LoginUserID
+	if owner == uuid.Nil {
+		owner = user.UserID
+	}
+	records, err := s.accountRepo.FindSessions(ctx, owner, sessionListLimit)
+	if err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.IdentityUserId)
+	}
+	summaries := s.summaries(ctx, ids)
+
+	sessions := make([]Session, 0, len(records))
+	for _, record := range records {
+		identity := summaries[record.IdentityUserId]
+		sessions = append(sessions, Session{
+			ObjectId:    record.ObjectId,
+			Identity:    &identity,
+			LoginUserId: record.LoginUserId,
+			Switched:    record.LoginUserId != record.IdentityUserId,
+			Current:     user.SessionID != "" && record.ObjectId.String() == user.SessionID,
+			UserAgent:   record.UserAgent,
+			IPAddress:   record.IPAddress,
+			CreatedDate: record.CreatedDate,
+		})
+	}
+	return sessions, nil
+}
+
+// readProfile loads the profile embedded in a switched token, falling back to the
+// username like login does when the profile service is unavailable
+func (s *Service) readProfile(ctx context.Context, user *models.UserAuth) *accountProfile {
+	if s.profiles != nil {
+		if profile, err := s.profiles.GetProfile(ctx, user.ObjectId); err == nil && profile != nil {
+			return &accountProfile{
+				ObjectId:    profile.ObjectId,
+				FullName:    profile.FullName,
+				SocialName:  profile.SocialName,
+				Email:       profile.Email,
+				Avatar:      profile.Avatar,
+				Banner:      profile.Banner,
+				TagLine:     profile.Tagline,
+				CreatedDate: profile.CreatedDate,
+			}
+		}
+	}
+	return &accountProfile{
+		ObjectId:   user.ObjectId,
+		FullName:   user.Username,
+		SocialName: user.Username,
+		Email:      user.Username,
+	}
+}
+
+// summaries resolves account IDs to their public profiles; IDs without a profile
+// keep a summary carrying only the ID
+func (s *Service) summaries(ctx context.Context, ids []uuid.UUID) map[uuid.UUID]AccountSummary {
+	result := make(map[uuid.UUID]AccountSummary, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := result[id]; !ok {
+			result[id] = AccountSummary{UserId: id}
+			unique = append(unique, id)
+		}
+	}
+	if s.profiles == nil || len(unique) == 0 {
+		return result
+	}
+	profiles, err := s.profiles.GetProfilesByIds(ctx, unique)
+	if err != nil {
+		log.WarnWithContext(ctx, "[accounts] failed to load profiles: %v", err)
+		return result
+	}
+	for _, profile := range profiles {
+		if profile == nil {
+			continue
+		}
+		result[profile.ObjectId] = AccountSummary{
+			UserId:     profile.ObjectId,
+			FullName:   profile.FullName,
+			SocialName: profile.SocialName,
+			Avatar:     profile.Avatar,
+		}
+	}
+	return result
+}
@@ -0,0 +1,253 @@
+package accounts
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+	authErrors "github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/auth/models"
+	"github.com/qolzam/telar/apps/api/auth/repository"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/internal/utils"
+)
+
+// fakeAuthRepo serves users from memory; unused AuthRepository methods panic
+type fakeAuthRepo struct {
+	repository.AuthRepository
+	users []*models.UserAuth
+}
+
+func (f *fakeAuthRepo) FindByUsername(ctx context.Context, username string) (*models.UserAuth, error) {
+	for _, u := range f.users {
+		if u.Username == username {
+			return u, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (f *fakeAuthRepo) FindByID(ctx context.Context, userID uuid.UUID) (*models.UserAuth, error) {
+	for _, u := range f.users {
+		if u.ObjectId == userID {
+			return u, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+type fakeAccountRepo struct {
+	links    map[[2]uuid.UUID]int64
+	sessions []*models.AuthSession
+}
+
+func newFakeAccountRepo() *fakeAccountRepo {
+	return &fakeAccountRepo{links: map[[2]uuid.UUID]int64{}}
+}
+
+func pairKey(a, b uuid.UUID) [2]uuid.UUID {
+	if a.String() > b.String() {
+		a, b = b, a
+	}
+	return [2]uuid.UUID{a, b}
+}
+
+func (f *fakeAccountRepo) LinkAccounts(ctx context.Context, userID, otherID, linkedBy uuid.UUID, createdDate int64) error {
+	f.links[pairKey(userID, otherID)] = createdDate
+	return nil
+}
+
+func (f *fakeAccountRepo) UnlinkAccounts(ctx context.Context, userID, otherID uuid.UUID) (bool, error) {
+	key := pairKey(userID, otherID)
+	_, ok := f.links[key]
+	delete(f.links, key)
+	return ok, nil
+}
+
+func (f *fakeAccountRepo) AreLinked(ctx context.Context, userID, otherID uuid.UUID) (bool, error) {
+	_, ok := f.links[pairKey(userID, otherID)]
+	return ok, nil
+}
+
+func (f *fakeAccountRepo) FindLinked(ctx context.Context, userID uuid.UUID) ([]models.AccountLink, error) {
+	var links []models.AccountLink
+	for key, created := range f.links {
+		switch userID {
+		case key[0]:
+			links = append(links, models.AccountLink{UserId: key[1], CreatedDate: created})
+		case key[1]:
+			links = append(links, models.AccountLink{UserId: key[0], CreatedDate: created})
+		}
+	}
+	return links, nil
+}
+
+func (f *fakeAccountRepo) CreateSession(ctx context.Context, session *models.AuthSession) error {
+	f.sessions = append(f.sessions, session)
+	return nil
+}
+
+func (f *fakeAccountRepo) FindSessions(ctx context.Context, userID uuid.UUID, limit int) ([]*models.AuthSession, error) {
+	var sessions []*models.AuthSession
+	for _, s := range f.sessions {
+		if s.LoginUserId == userID || s.IdentityUserId == userID {
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+func testPrivateKey(t *testing.T) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+}
+
+func newTestUser(t *testing.T, username, password string) *models.UserAuth {
+	hash, err := utils.Hash(password)
+	if err != nil {
+		t.Fatalf("hash: %v", err)
+	}
+	return &models.UserAuth{
+		ObjectId:      uuid.Must(uuid.NewV4()),
+		Username:      username,
+		Password:      hash,
+		EmailVerified: true,
+		Role:          "user",
+	}
+}
+
+func newTestService(t *testing.T, users ...*models.UserAuth) (*Service, *fakeAccountRepo) {
+	accountRepo := newFakeAccountRepo()
+	svc := NewService(&fakeAuthRepo{users: users}, accountRepo, nil, &ServiceConfig{PrivateKey: testPrivateKey(t)})
+	return svc, accountRepo
+}
+
+func TestLink_RequiresOtherAccountCredentials(t *testing.T) {
+	alice := newTestUser(t, "alice@example.com", "alice-secret")
+	bob := newTestUser(t, "bob@example.com", "bob-secret")
+	svc, repo := newTestService(t, alice, bob)
+	ctx := context.Background()
+	current := types.UserContext{UserID: alice.ObjectId}
+
+	if _, err := svc.Link(ctx, current, "bob@example.com", "wrong", ""); !errors.Is(err, authErrors.ErrInvalidCredentials) {
+		t.Fatalf("expected invalid credentials, got %v", err)
+	}
+	if _, err := svc.Link(ctx, current, "alice@example.com", "alice-secret", ""); err == nil {
+		t.Fatalf("expected self-link to be rejected")
+	}
+
+	account, err := svc.Link(ctx, current, "bob@example.com", "bob-secret", "")
+	if err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	if account.UserId != bob.ObjectId {
+		t.Fatalf("expected linked account %s, got %s", bob.ObjectId, account.UserId)
+	}
+	if linked, _ := repo.AreLinked(ctx, bob.ObjectId, alice.ObjectId); !linked {
+		t.Fatalf("expected accounts to be linked both ways")
+	}
+}
+
+func TestLink_EnforcesMaximum(t *testing.T) {
+	alice := newTestUser(t, "alice@example.com", "alice-secret")
+	bob := newTestUser(t, "bob@example.com", "bob-secret")
+	svc, repo := newTestService(t, alice, bob)
+	for i := 0; i < MaxLinkedAccounts; i++ {
+		_ = repo.LinkAccounts(context.Background(), alice.ObjectId, uuid.Must(uuid.NewV4()), alice.ObjectId, 1)
+	}
+
+	_, err := svc.Link(context.Background(), types.UserContext{UserID: alice.ObjectId}, "bob@example.com", "bob-secret", "")
+	var authErr *authErrors.AuthError
+	if !errors.As(err, &authErr) || authErr.Code != authErrors.CodeValidationFailed {
+		t.Fatalf("expected validation error at the link limit, got %v", err)
+	}
+}
+
+func TestSwitch_RequiresLink(t *testing.T) {
+	alice := newTestUser(t, "alice@example.com", "alice-secret")
+	bob := newTestUser(t, "bob@example.com", "bob-secret")
+	svc, _ := newTestService(t, alice, bob)
+
+	_, err := svc.Switch(context.Background(), types.UserContext{UserID: alice.ObjectId}, bob.ObjectId, "", "")
+	if !errors.Is(err, authErrors.ErrPermissionDenied) {
+		t.Fatalf("expected permission denied, got %v", err)
+	}
+}
+
+func TestSwitch_IssuesTokenKeepingLoginIdentity(t *testing.T) {
+	alice := newTestUser(t, "alice@example.com", "alice-secret")
+	bob := newTestUser(t, "bob@example.com", "bob-secret")
+	carol := newTestUser(t, "carol@example.com", "carol-secret")
+	svc, repo := newTestService(t, alice, bob, carol)
+	ctx := context.Background()
+	_ = repo.LinkAccounts(ctx, alice.ObjectId, bob.ObjectId, alice.ObjectId, 1)
+	_ = repo.LinkAccounts(ctx, bob.ObjectId, carol.ObjectId, bob.ObjectId, 1)
+
+	result, err := svc.Switch(ctx, types.UserContext{UserID: alice.ObjectId}, bob.ObjectId, "test-agent", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("switch: %v", err)
+	}
+	claim := tokenClaim(t, result.AccessToken)
+	if claim[types.HeaderUID] != bob.ObjectId.String() || claim["loginUid"] != alice.ObjectId.String() {
+		t.Fatalf("unexpected identity claims: %v", claim)
+	}
+
+	// Switching again from the linked account keeps the original login account
+	asBob := types.UserContext{UserID: bob.ObjectId, LoginUserID: alice.ObjectId, SessionID: claim["jti"].(string)}
+	result, err = svc.Switch(ctx, asBob, carol.ObjectId, "", "")
+	if err != nil {
+		t.Fatalf("second switch: %v", err)
+	}
+	if claim := tokenClaim(t, result.AccessToken); claim["loginUid"] != alice.ObjectId.String() {
+		t.Fatalf("expected loginUid %s, got %v", alice.ObjectId, claim["loginUid"])
+	}
+
+	sessions, err := svc.ListSessions(ctx, asBob)
+	if err != nil {
+		t.Fatalf("list sessions: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	current := 0
+	for _, session := range sessions {
+		if !session.Switched || session.LoginUserId != alice.ObjectId {
+			t.Fatalf("expected switched sessions signed in by alice, got %+v", session)
+		}
+		if session.Current {
+			current++
+			if session.Identity.UserId != bob.ObjectId {
+				t.Fatalf("expected current session to act as bob, got %s", session.Identity.UserId)
+			}
+		}
+	}
+	if current != 1 {
+		t.Fatalf("expected exactly one current session, got %d", current)
+	}
+}
+
+func tokenClaim(t *testing.T, token string) map[string]interface{} {
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		t.Fatalf("parse token: %v", err)
+	}
+	claim, ok := claims["claim"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("token has no claim map")
+	}
+	return claim
+}
@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"strconv"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/qolzam/telar/apps/api/auth/errors"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
@@ -66,9 +68,16 @@ func (h *AdminHandler) Login(c *fiber.Ctx) error {
 	if password == "" {
 		password = c.FormValue("password")
 	}
-	token, err := h.adminService.Login(c.Context(), email, password)
+	result, err := h.adminService.Login(c.Context(), email, password)
 	if err != nil {
 		return errors.HandleServiceError(c, err)
 	}
-	return c.JSON(fiber.Map{"token": token})
+	if result.ChallengeToken != "" {
+		return c.JSON(fiber.Map{
+			"mfaRequired":    true,
+			"challengeToken": result.ChallengeToken,
+			"expires_in":     strconv.Itoa(int(result.ChallengeTTL.Seconds())),
+		})
+	}
+	return c.JSON(fiber.Map{"token": result.Token})
 }
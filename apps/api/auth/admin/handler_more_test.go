@@ -69,6 +69,8 @@ func TestAdminHandler_Login_Success_Coverage(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
@@ -158,6 +160,8 @@ func TestAdminHandler_Login_Error_WrongPassword(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
@@ -244,6 +248,8 @@ func TestAdminHandler_Check_OK(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
@@ -310,6 +316,8 @@ func TestAdminHandler_Signup_Status(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
@@ -457,6 +465,8 @@ func TestAdminHandler_Check_And_Signup_InternalError(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
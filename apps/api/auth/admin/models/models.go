@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 )
 
 // AdminLog represents an admin action audit log entry
@@ -19,6 +20,7 @@ type AdminLog struct {
 	TargetType *string                `json:"targetType,omitempty" db:"target_type"`
 	TargetID   *uuid.UUID             `json:"targetId,omitempty" db:"target_id"`
 	Details    map[string]interface{} `json:"details,omitempty" db:"details"`
+	RequestID  string                 `json:"requestId,omitempty" db:"request_id"`
 	CreatedAt  time.Time              `json:"createdAt" db:"created_at"`
 	CreatedDate int64                 `json:"createdDate" db:"created_date"`
 }
@@ -46,3 +48,61 @@ type SystemStats struct {
 	PendingInvitations int64 `json:"pendingInvitations"`
 }
 
+
+// User is a user account as shown to admins
+type User struct {
+	ObjectId      uuid.UUID `json:"objectId"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	EmailVerified bool      `json:"emailVerified"`
+	PhoneVerified bool      `json:"phoneVerified"`
+	Suspended     bool      `json:"suspended"`
+	SuspendedDate int64     `json:"suspendedDate,omitempty"`
+	CreatedDate   int64     `json:"createdDate"`
+	LastUpdated   int64     `json:"lastUpdated"`
+}
+
+// UserQuery filters and pages the user list
+type UserQuery struct {
+	Email       string // Case-insensitive part of the email
+	Role        string
+	Verified    *bool
+	Suspended   *bool
+	CreatedFrom *int64 // Unix seconds, inclusive
+	CreatedTo   *int64 // Unix seconds, inclusive
+	Page        int
+	Limit       int
+}
+
+// UserList is a page of users
+type UserList struct {
+	Users []*User `json:"users"`
+	Total int64   `json:"total"`
+	Page  int     `json:"page"`
+	Limit int     `json:"limit"`
+}
+
+// SuspendUserRequest suspends (or, with suspended false, reinstates) a user
+type SuspendUserRequest struct {
+	Suspended *bool  `json:"suspended"` // Defaults to true
+	Reason    string `json:"reason"`
+}
+
+// UpdateRoleRequest changes a user's role
+type UpdateRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// DeleteUserResult summarizes a deleted account
+type DeleteUserResult struct {
+	ObjectId     uuid.UUID `json:"objectId"`
+	Email        string    `json:"email"`
+	PostsDeleted int64     `json:"postsDeleted"`
+}
+
+// RequestTrace is what is known about one request, looked up by its request ID
+type RequestTrace struct {
+	RequestID    string            `json:"requestId"`
+	Logs         []log.TraceRecord `json:"logs"`         // Records this process still holds in memory
+	AuditEntries []*AdminLog       `json:"auditEntries"` // Admin actions the request made
+}
@@ -44,8 +44,8 @@ func (r *postgresAdminRepository) getExecutor(ctx context.Context) sqlx.ExtConte
 // LogAction records an admin action in the audit trail
 func (r *postgresAdminRepository) LogAction(ctx context.Context, log *models.AdminLog) error {
 	query := `
-		INSERT INTO admin_logs (id, admin_id, action, target_type, target_id, details, created_at, created_date)
-		VALUES (:id, :admin_id, :action, :target_type, :target_id, :details, :created_at, :created_date)
+		INSERT INTO admin_logs (id, admin_id, action, target_type, target_id, details, request_id, created_at, created_date)
+		VALUES (:id, :admin_id, :action, :target_type, :target_id, :details, :request_id, :created_at, :created_date)
 	`
 
 	var detailsJSON []byte
@@ -64,6 +64,7 @@ func (r *postgresAdminRepository) LogAction(ctx context.Context, log *models.Adm
 		"target_type": log.TargetType,
 		"target_id":   log.TargetID,
 		"details":     detailsJSON,
+		"request_id":  sql.NullString{String: log.RequestID, Valid: log.RequestID != ""},
 		"created_at":  log.CreatedAt,
 		"created_date": log.CreatedDate,
 	}
@@ -78,7 +79,7 @@ func (r *postgresAdminRepository) LogAction(ctx context.Context, log *models.Adm
 
 // GetAdminLogs retrieves admin logs with filtering and pagination
 func (r *postgresAdminRepository) GetAdminLogs(ctx context.Context, filter AdminLogFilter, limit, offset int) ([]*models.AdminLog, error) {
-	query := `SELECT id, admin_id, action, target_type, target_id, details, created_at, created_date
+	query := `SELECT id, admin_id, action, target_type, target_id, details, request_id, created_at, created_date
 		FROM admin_logs WHERE 1=1`
 	args := map[string]interface{}{}
 
@@ -98,6 +99,10 @@ func (r *postgresAdminRepository) GetAdminLogs(ctx context.Context, filter Admin
 		query += ` AND target_id = :target_id`
 		args["target_id"] = *filter.TargetID
 	}
+	if filter.RequestID != nil {
+		query += ` AND request_id = :request_id`
+		args["request_id"] = *filter.RequestID
+	}
 	if filter.FromDate != nil {
 		query += ` AND created_date >= :from_date`
 		args["from_date"] = *filter.FromDate
@@ -123,6 +128,7 @@ func (r *postgresAdminRepository) GetAdminLogs(ctx context.Context, filter Admin
 		var detailsJSON []byte
 		var targetType sql.NullString
 		var targetID sql.NullString
+		var requestID sql.NullString
 
 		err := rows.Scan(
 			&log.ID,
@@ -131,6 +137,7 @@ func (r *postgresAdminRepository) GetAdminLogs(ctx context.Context, filter Admin
 			&targetType,
 			&targetID,
 			&detailsJSON,
+			&requestID,
 			&log.CreatedAt,
 			&log.CreatedDate,
 		)
@@ -147,6 +154,8 @@ func (r *postgresAdminRepository) GetAdminLogs(ctx context.Context, filter Admin
 			}
 		}
 
+		log.RequestID = requestID.String
+
 		if len(detailsJSON) > 0 {
 			if err := json.Unmarshal(detailsJSON, &log.Details); err != nil {
 				return nil, fmt.Errorf("failed to unmarshal details: %w", err)
@@ -185,6 +194,11 @@ func (r *postgresAdminRepository) CountAdminLogs(ctx context.Context, filter Adm
 		args = append(args, *filter.TargetID)
 		argIndex++
 	}
+	if filter.RequestID != nil {
+		query += fmt.Sprintf(` AND request_id = $%d`, argIndex)
+		args = append(args, *filter.RequestID)
+		argIndex++
+	}
 	if filter.FromDate != nil {
 		query += fmt.Sprintf(` AND created_date >= $%d`, argIndex)
 		args = append(args, *filter.FromDate)
@@ -50,6 +50,7 @@ type AdminLogFilter struct {
 	Action     *string
 	TargetType *string
 	TargetID   *uuid.UUID
+	RequestID  *string
 	FromDate   *int64
 	ToDate     *int64
 }
@@ -60,6 +60,7 @@ func TestPostgresAdminRepository_Integration(t *testing.T) {
 			target_type VARCHAR(50),
 			target_id UUID,
 			details JSONB,
+			request_id VARCHAR(128),
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT
 		);
@@ -110,6 +111,7 @@ func TestPostgresAdminRepository_Integration(t *testing.T) {
 			Details: map[string]interface{}{
 				"email": "test@example.com",
 			},
+			RequestID:   "req-admin-1",
 			CreatedAt:   now,
 			CreatedDate: now.Unix(),
 		}
@@ -128,6 +130,12 @@ func TestPostgresAdminRepository_Integration(t *testing.T) {
 		require.Greater(t, len(logs), 0)
 		require.Equal(t, adminID1, logs[0].AdminID)
 		require.Equal(t, "create_user", logs[0].Action)
+		require.Equal(t, "req-admin-1", logs[0].RequestID)
+
+		requestID := "req-admin-1"
+		logs, err = adminRepo.GetAdminLogs(ctx, AdminLogFilter{RequestID: &requestID}, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, logs, 1)
 	})
 
 	// 8. Test CountAdminLogs
@@ -0,0 +1,16 @@
+package admin
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/auth/errors"
+)
+
+// LookupRequest handles GET /admin/requests/:id - the logs and audit entries of a
+// request, for support to follow up on a request ID a user quotes
+func (h *AdminHandler) LookupRequest(c *fiber.Ctx) error {
+	trace, err := h.adminService.LookupRequest(c.Context(), c.Params("id"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(trace)
+}
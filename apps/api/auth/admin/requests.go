@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	adminModels "github.com/qolzam/telar/apps/api/auth/admin/models"
+	adminRepository "github.com/qolzam/telar/apps/api/auth/admin/repository"
+	authErrors "github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// maxTraceAuditEntries bounds the audit entries returned for one request
+const maxTraceAuditEntries = 100
+
+// LookupRequest returns the log records and audit entries correlated with a request
+// ID, as quoted from an error response or the X-Request-ID header. Logs come from the
+// in-memory buffer of this process, so older requests and requests served by other
+// services may only have audit entries.
+func (s *Service) LookupRequest(ctx context.Context, requestID string) (*adminModels.RequestTrace, error) {
+	if requestID == "" || len(requestID) > requestid.MaxRequestIDLength {
+		return nil, authErrors.NewValidationError(fmt.Sprintf("request id must be 1 to %d characters", requestid.MaxRequestIDLength))
+	}
+
+	entries, err := s.adminRepo.GetAdminLogs(ctx, adminRepository.AdminLogFilter{RequestID: &requestID}, maxTraceAuditEntries, 0)
+	if err != nil {
+		return nil, authErrors.WrapDatabaseError(err)
+	}
+
+	trace := &adminModels.RequestTrace{
+		RequestID:    requestID,
+		Logs:         log.Traced(requestID),
+		AuditEntries: entries,
+	}
+	if trace.Logs == nil {
+		trace.Logs = []log.TraceRecord{}
+	}
+	if trace.AuditEntries == nil {
+		trace.AuditEntries = []*adminModels.AdminLog{}
+	}
+	return trace, nil
+}
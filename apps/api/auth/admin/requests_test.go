@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupRequest(t *testing.T) {
+	log.Retain(10)
+	t.Cleanup(func() { log.Retain(0) })
+	s, _, _, adminID, userID := newUserAdminTest(t)
+
+	ctx := log.WithRequestID(context.Background(), "req-support-1")
+	log.InfoWithContext(ctx, "suspending user")
+	_, err := s.SetSuspended(ctx, adminID, userID, true, "spam")
+	require.NoError(t, err)
+	_, err = s.UpdateRole(log.WithRequestID(context.Background(), "req-other"), adminID, userID, "admin")
+	require.NoError(t, err)
+
+	trace, err := s.LookupRequest(context.Background(), "req-support-1")
+	require.NoError(t, err)
+	assert.Equal(t, "req-support-1", trace.RequestID)
+	require.Len(t, trace.Logs, 1)
+	assert.Equal(t, "suspending user", trace.Logs[0].Message)
+	require.Len(t, trace.AuditEntries, 1)
+	assert.Equal(t, actionUserSuspend, trace.AuditEntries[0].Action)
+
+	trace, err = s.LookupRequest(context.Background(), "req-unknown")
+	require.NoError(t, err)
+	assert.NotNil(t, trace.Logs)
+	assert.NotNil(t, trace.AuditEntries)
+
+	_, err = s.LookupRequest(context.Background(), strings.Repeat("a", 129))
+	assert.Error(t, err)
+}
@@ -27,6 +27,8 @@ type Service struct {
 	adminRepo   adminRepository.AdminRepository
 	privateKey  string
 	config      *platformconfig.Config
+	userDeleter UserDeleter  // nil until SetUserDeleter
+	mfa         SecondFactor // nil until SetSecondFactor
 }
 
 // NewService creates a service with repositories injected
@@ -112,7 +114,7 @@ func (s *Service) CreateAdmin(ctx context.Context, fullName, email, password str
 		}
 		if err := s.authRepo.CreateUser(txCtx, ua); err != nil {
 			// Check for unique constraint violation
-			if err.Error() == "username already exists" {
+			if errors.Is(err, authRepository.ErrUsernameExists) {
 				return authErrors.ErrUserAlreadyExists
 			}
 			return fmt.Errorf("failed to create user auth: %w", err)
@@ -182,27 +184,64 @@ func (s *Service) CreateAdmin(ctx context.Context, fullName, email, password str
 	return token, nil
 }
 
-func (s *Service) Login(ctx context.Context, email, password string) (string, error) {
+// SecondFactor holds back the token of admins with a second factor; the mfa service
+// satisfies it
+type SecondFactor interface {
+	Required(ctx context.Context, userID uuid.UUID) (bool, error)
+	IssueChallenge(userID uuid.UUID) (string, time.Duration)
+}
+
+// SetSecondFactor makes admins with MFA enabled finish signing in through
+// POST /auth/login/mfa
+func (s *Service) SetSecondFactor(mfa SecondFactor) {
+	s.mfa = mfa
+}
+
+// LoginResult is either the admin token or, for admins with a second factor, the
+// challenge to complete at POST /auth/login/mfa
+type LoginResult struct {
+	Token          string
+	ChallengeToken string
+	ChallengeTTL   time.Duration
+}
+
+// Login checks an admin's credentials and issues their token, unless their second
+// factor is required first
+func (s *Service) Login(ctx context.Context, email, password string) (*LoginResult, error) {
 	if s.authRepo == nil {
-		return "", fmt.Errorf("auth repository not available")
+		return nil, fmt.Errorf("auth repository not available")
 	}
-	
+
 	// Find user by username
 	user, err := s.authRepo.FindByUsername(ctx, email)
 	if err != nil {
-		return "", authErrors.WrapUserNotFoundError(fmt.Errorf("admin not found: %w", err))
+		return nil, authErrors.WrapUserNotFoundError(fmt.Errorf("admin not found: %w", err))
 	}
-	
+
 	// Verify it's an admin
 	if user.Role != "admin" {
-		return "", authErrors.WrapUserNotFoundError(fmt.Errorf("admin not found"))
+		return nil, authErrors.WrapUserNotFoundError(fmt.Errorf("admin not found"))
 	}
-	
+
 	// Verify password
 	if utils.CompareHash(user.Password, []byte(password)) != nil {
-		return "", authErrors.WrapAuthenticationError(fmt.Errorf("password does not match"))
+		return nil, authErrors.WrapAuthenticationError(fmt.Errorf("password does not match"))
 	}
-	
+	if user.Suspended {
+		return nil, authErrors.ErrPermissionDenied
+	}
+
+	if s.mfa != nil {
+		required, err := s.mfa.Required(ctx, user.ObjectId)
+		if err != nil {
+			return nil, authErrors.WrapSystemError(fmt.Errorf("check second factor: %w", err))
+		}
+		if required {
+			challengeToken, ttl := s.mfa.IssueChallenge(user.ObjectId)
+			return &LoginResult{ChallengeToken: challengeToken, ChallengeTTL: ttl}, nil
+		}
+	}
+
 	claim := map[string]interface{}{
 		"displayName":   email,
 		"socialName":    generateSocialName(email, user.ObjectId.String()),
@@ -217,7 +256,11 @@ func (s *Service) Login(ctx context.Context, email, password string) (string, er
 		"name":     email,
 		"audience": "",
 	}
-	return s.createTelarToken(profileInfo, claim)
+	token, err := s.createTelarToken(profileInfo, claim)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{Token: token}, nil
 }
 
 // helpers
@@ -2,7 +2,12 @@ package admin
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	authModels "github.com/qolzam/telar/apps/api/auth/models"
 
 	authRepository "github.com/qolzam/telar/apps/api/auth/repository"
 	adminRepository "github.com/qolzam/telar/apps/api/auth/admin/repository"
@@ -64,3 +69,41 @@ func TestAdminHelpers_GenerateSocialName(t *testing.T) {
 		t.Fatalf("expected non-empty social name")
 	}
 }
+
+type fakeSecondFactor struct {
+	required map[uuid.UUID]bool
+}
+
+func (f *fakeSecondFactor) Required(_ context.Context, userID uuid.UUID) (bool, error) {
+	return f.required[userID], nil
+}
+
+func (f *fakeSecondFactor) IssueChallenge(userID uuid.UUID) (string, time.Duration) {
+	return "challenge-" + userID.String(), 5 * time.Minute
+}
+
+func (r *fakeAuthRepo) FindByUsername(_ context.Context, username string) (*authModels.UserAuth, error) {
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func TestLogin_HoldsBackTokenForSecondFactor(t *testing.T) {
+	s, authRepo, _, adminID, _ := newUserAdminTest(t)
+	hash, err := hashForTest("Adm1n!Pass")
+	require.NoError(t, err)
+	authRepo.users[adminID].Password = hash
+	s.SetSecondFactor(&fakeSecondFactor{required: map[uuid.UUID]bool{adminID: true}})
+
+	result, err := s.Login(context.Background(), "admin@example.com", "Adm1n!Pass")
+	require.NoError(t, err)
+	require.Empty(t, result.Token)
+	require.Equal(t, "challenge-"+adminID.String(), result.ChallengeToken)
+	require.Equal(t, 5*time.Minute, result.ChallengeTTL)
+
+	_, err = s.Login(context.Background(), "admin@example.com", "Wrong!Pass")
+	require.Error(t, err)
+}
@@ -0,0 +1,138 @@
+package admin
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	adminModels "github.com/qolzam/telar/apps/api/auth/admin/models"
+	"github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+// ListUsers handles GET /admin/users - list users, filtered by email, role, verified,
+// suspended and a createdFrom/createdTo range (unix seconds), paged by page and limit
+func (h *AdminHandler) ListUsers(c *fiber.Ctx) error {
+	query := adminModels.UserQuery{
+		Email: c.Query("email"),
+		Role:  c.Query("role"),
+		Page:  c.QueryInt("page", 1),
+		Limit: c.QueryInt("limit", defaultUserPageSize),
+	}
+
+	var err error
+	if query.Verified, err = queryBool(c, "verified"); err != nil {
+		return errors.HandleInvalidFieldError(c, "verified", "must be true or false")
+	}
+	if query.Suspended, err = queryBool(c, "suspended"); err != nil {
+		return errors.HandleInvalidFieldError(c, "suspended", "must be true or false")
+	}
+	if query.CreatedFrom, err = queryInt64(c, "createdFrom"); err != nil {
+		return errors.HandleInvalidFieldError(c, "createdFrom", "must be a unix timestamp")
+	}
+	if query.CreatedTo, err = queryInt64(c, "createdTo"); err != nil {
+		return errors.HandleInvalidFieldError(c, "createdTo", "must be a unix timestamp")
+	}
+
+	list, err := h.adminService.ListUsers(c.Context(), query)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(list)
+}
+
+// SuspendUser handles PUT /admin/users/:id/suspend - suspend a user, or reinstate
+// them with {"suspended": false}
+func (h *AdminHandler) SuspendUser(c *fiber.Ctx) error {
+	admin, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Authentication required")
+	}
+	userID, err := uuid.FromString(c.Params("id"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "user id")
+	}
+
+	var req adminModels.SuspendUserRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return errors.HandleInvalidRequestError(c, "Invalid request body")
+		}
+	}
+	suspended := req.Suspended == nil || *req.Suspended
+
+	user, err := h.adminService.SetSuspended(c.Context(), admin.UserID, userID, suspended, req.Reason)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(user)
+}
+
+// UpdateUserRole handles PUT /admin/users/:id/role - change a user's role
+func (h *AdminHandler) UpdateUserRole(c *fiber.Ctx) error {
+	admin, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Authentication required")
+	}
+	userID, err := uuid.FromString(c.Params("id"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "user id")
+	}
+
+	var req adminModels.UpdateRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+	if req.Role == "" {
+		return errors.HandleMissingFieldError(c, "role")
+	}
+
+	user, err := h.adminService.UpdateRole(c.Context(), admin.UserID, userID, req.Role)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(user)
+}
+
+// DeleteUser handles DELETE /admin/users/:id - permanently delete a user with their
+// profile, posts and comments
+func (h *AdminHandler) DeleteUser(c *fiber.Ctx) error {
+	admin, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Authentication required")
+	}
+	userID, err := uuid.FromString(c.Params("id"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "user id")
+	}
+
+	result, err := h.adminService.DeleteUser(c.Context(), admin.UserID, userID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(result)
+}
+
+func queryBool(c *fiber.Ctx, key string) (*bool, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
+
+func queryInt64(c *fiber.Ctx, key string) (*int64, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
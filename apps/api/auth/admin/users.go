@@ -0,0 +1,236 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	adminModels "github.com/qolzam/telar/apps/api/auth/admin/models"
+	authErrors "github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/auth/models"
+	authRepository "github.com/qolzam/telar/apps/api/auth/repository"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/orchestrator/userdeletion"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	defaultUserPageSize = 20
+	maxUserPageSize     = 100
+)
+
+// assignableRoles are the roles an admin can give a user
+var assignableRoles = map[string]bool{types.UserRole: true, types.ModeratorRole: true, types.AdminRole: true}
+
+// Audit trail actions on users
+const (
+	actionUserSuspend    = "user.suspend"
+	actionUserReinstate  = "user.reinstate"
+	actionUserRoleUpdate = "user.role_update"
+	actionUserDelete     = "user.delete"
+)
+
+// UserDeleter deletes an account with its profile, posts and comments
+type UserDeleter interface {
+	DeleteUser(ctx context.Context, userID, deletedBy uuid.UUID) (*userdeletion.Result, error)
+}
+
+// SetUserDeleter enables hard-deleting users. Without it DeleteUser fails.
+func (s *Service) SetUserDeleter(deleter UserDeleter) {
+	s.userDeleter = deleter
+}
+
+// ListUsers returns a page of users matching the query, newest first
+func (s *Service) ListUsers(ctx context.Context, query adminModels.UserQuery) (*adminModels.UserList, error) {
+	if query.CreatedFrom != nil && query.CreatedTo != nil && *query.CreatedFrom > *query.CreatedTo {
+		return nil, authErrors.NewValidationError("createdFrom must not be after createdTo")
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultUserPageSize
+	}
+	query.Limit = min(query.Limit, maxUserPageSize)
+
+	filter := authRepository.UserFilter{
+		Email:       query.Email,
+		Verified:    query.Verified,
+		Suspended:   query.Suspended,
+		CreatedFrom: query.CreatedFrom,
+		CreatedTo:   query.CreatedTo,
+	}
+	if query.Role != "" {
+		filter.Role = &query.Role
+	}
+
+	users, err := s.authRepo.FindUsers(ctx, filter, query.Limit, (query.Page-1)*query.Limit)
+	if err != nil {
+		return nil, authErrors.WrapDatabaseError(err)
+	}
+	total, err := s.authRepo.CountUsers(ctx, filter)
+	if err != nil {
+		return nil, authErrors.WrapDatabaseError(err)
+	}
+
+	list := &adminModels.UserList{
+		Users: make([]*adminModels.User, len(users)),
+		Total: total,
+		Page:  query.Page,
+		Limit: query.Limit,
+	}
+	for i, user := range users {
+		list.Users[i] = toAdminUser(user)
+	}
+	return list, nil
+}
+
+// SetSuspended suspends or reinstates a user. Suspended users cannot sign in, and the
+// tokens they hold are revoked so they lose access on their next request.
+func (s *Service) SetSuspended(ctx context.Context, adminID, userID uuid.UUID, suspended bool, reason string) (*adminModels.User, error) {
+	if suspended && adminID == userID {
+		return nil, authErrors.NewValidationError("Admins cannot suspend themselves")
+	}
+
+	action := actionUserReinstate
+	if suspended {
+		action = actionUserSuspend
+	}
+	var details map[string]interface{}
+	if reason != "" {
+		details = map[string]interface{}{"reason": reason}
+	}
+
+	return s.updateUser(ctx, adminID, userID, action, details, func(txCtx context.Context) error {
+		now := time.Now().Unix()
+		if err := s.authRepo.UpdateSuspended(txCtx, userID, suspended, now); err != nil {
+			return err
+		}
+		if !suspended {
+			return nil
+		}
+		return s.authRepo.RevokeTokens(txCtx, userID, now)
+	})
+}
+
+// SuspendUser suspends a user on behalf of a moderator acting on a report. Admins and
+// moderators cannot be suspended this way.
+func (s *Service) SuspendUser(ctx context.Context, moderatorID, userID uuid.UUID, reason string) error {
+	user, err := s.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		if err.Error() == "user not found" {
+			return authErrors.ErrUserNotFound
+		}
+		return authErrors.WrapDatabaseError(err)
+	}
+	if user.Role == types.AdminRole || user.Role == types.ModeratorRole {
+		return sharedInterfaces.ErrProtectedAccount
+	}
+	_, err = s.SetSuspended(ctx, moderatorID, userID, true, reason)
+	return err
+}
+
+// UpdateRole changes a user's role. Tokens issued before carry the old role, so they are
+// revoked and the user signs in again with the new one.
+func (s *Service) UpdateRole(ctx context.Context, adminID, userID uuid.UUID, role string) (*adminModels.User, error) {
+	if !assignableRoles[role] {
+		return nil, authErrors.WrapValidationError(fmt.Errorf("role must be user, moderator or admin"), "role")
+	}
+	if adminID == userID && role != types.AdminRole {
+		return nil, authErrors.NewValidationError("Admins cannot remove their own admin role")
+	}
+
+	return s.updateUser(ctx, adminID, userID, actionUserRoleUpdate, map[string]interface{}{"role": role}, func(txCtx context.Context) error {
+		if err := s.authRepo.UpdateRole(txCtx, userID, role); err != nil {
+			return err
+		}
+		return s.authRepo.RevokeTokens(txCtx, userID, time.Now().Unix())
+	})
+}
+
+// updateUser applies update and records it in the audit trail in one transaction,
+// then returns the updated user
+func (s *Service) updateUser(ctx context.Context, adminID, userID uuid.UUID, action string, details map[string]interface{}, update func(context.Context) error) (*adminModels.User, error) {
+	err := s.authRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := update(txCtx); err != nil {
+			return err
+		}
+		return s.logUserAction(txCtx, adminID, userID, action, details)
+	})
+	if err != nil {
+		if err.Error() == "user not found" {
+			return nil, authErrors.ErrUserNotFound
+		}
+		return nil, authErrors.WrapDatabaseError(err)
+	}
+
+	user, err := s.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, authErrors.WrapDatabaseError(err)
+	}
+	return toAdminUser(user), nil
+}
+
+// DeleteUser permanently deletes a user with their profile, posts and comments
+func (s *Service) DeleteUser(ctx context.Context, adminID, userID uuid.UUID) (*adminModels.DeleteUserResult, error) {
+	if s.userDeleter == nil {
+		return nil, authErrors.NewSystemError("user deletion is not available")
+	}
+	if adminID == userID {
+		return nil, authErrors.NewValidationError("Admins cannot delete themselves")
+	}
+
+	result, err := s.userDeleter.DeleteUser(ctx, userID, adminID)
+	if err != nil {
+		if errors.Is(err, userdeletion.ErrUserNotFound) {
+			return nil, authErrors.ErrUserNotFound
+		}
+		return nil, authErrors.WrapDatabaseError(err)
+	}
+
+	// The account is gone either way; a missing audit entry is only logged
+	details := map[string]interface{}{"email": result.Username, "postsDeleted": result.PostsDeleted}
+	if err := s.logUserAction(ctx, adminID, userID, actionUserDelete, details); err != nil {
+		log.Warn("Failed to record deletion of user %s: %v", userID, err)
+	}
+
+	return &adminModels.DeleteUserResult{
+		ObjectId:     userID,
+		Email:        result.Username,
+		PostsDeleted: result.PostsDeleted,
+	}, nil
+}
+
+// logUserAction records an admin action on a user in the audit trail
+func (s *Service) logUserAction(ctx context.Context, adminID, userID uuid.UUID, action string, details map[string]interface{}) error {
+	targetType := "user"
+	now := time.Now()
+	return s.adminRepo.LogAction(ctx, &adminModels.AdminLog{
+		ID:          uuid.Must(uuid.NewV4()),
+		AdminID:     adminID,
+		Action:      action,
+		TargetType:  &targetType,
+		TargetID:    &userID,
+		Details:     details,
+		RequestID:   log.RequestID(ctx),
+		CreatedAt:   now,
+		CreatedDate: now.Unix(),
+	})
+}
+
+func toAdminUser(user *models.UserAuth) *adminModels.User {
+	return &adminModels.User{
+		ObjectId:      user.ObjectId,
+		Email:         user.Username,
+		Role:          user.Role,
+		EmailVerified: user.EmailVerified,
+		PhoneVerified: user.PhoneVerified,
+		Suspended:     user.Suspended,
+		SuspendedDate: user.SuspendedDate,
+		CreatedDate:   user.CreatedDate,
+		LastUpdated:   user.LastUpdated,
+	}
+}
@@ -0,0 +1,271 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	adminModels "github.com/qolzam/telar/apps/api/auth/admin/models"
+	adminRepository "github.com/qolzam/telar/apps/api/auth/admin/repository"
+	authErrors "github.com/qolzam/telar/apps/api/auth/errors"
+	authModels "github.com/qolzam/telar/apps/api/auth/models"
+	authRepository "github.com/qolzam/telar/apps/api/auth/repository"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/orchestrator/userdeletion"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeAuthRepo keeps users in memory; methods the user admin does not use panic
+type fakeAuthRepo struct {
+	authRepository.AuthRepository
+	users      map[uuid.UUID]*authModels.UserAuth
+	revoked    map[uuid.UUID]int64 // token cutoff per user
+	lastFilter authRepository.UserFilter
+	lastLimit  int
+	lastOffset int
+}
+
+func (r *fakeAuthRepo) FindByID(_ context.Context, userID uuid.UUID) (*authModels.UserAuth, error) {
+	user, ok := r.users[userID]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+func (r *fakeAuthRepo) FindUsers(_ context.Context, filter authRepository.UserFilter, limit, offset int) ([]*authModels.UserAuth, error) {
+	r.lastFilter, r.lastLimit, r.lastOffset = filter, limit, offset
+	var users []*authModels.UserAuth
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+func (r *fakeAuthRepo) CountUsers(_ context.Context, _ authRepository.UserFilter) (int64, error) {
+	return int64(len(r.users)), nil
+}
+
+func (r *fakeAuthRepo) UpdateRole(_ context.Context, userID uuid.UUID, role string) error {
+	user, ok := r.users[userID]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+	user.Role = role
+	return nil
+}
+
+func (r *fakeAuthRepo) UpdateSuspended(_ context.Context, userID uuid.UUID, suspended bool, suspendedDate int64) error {
+	user, ok := r.users[userID]
+	if !ok {
+		return fmt.Errorf("user not found")
+	}
+	user.Suspended = suspended
+	user.SuspendedDate = 0
+	if suspended {
+		user.SuspendedDate = suspendedDate
+	}
+	return nil
+}
+
+func (r *fakeAuthRepo) RevokeTokens(_ context.Context, userID uuid.UUID, issuedBefore int64) error {
+	if _, ok := r.users[userID]; !ok {
+		return fmt.Errorf("user not found")
+	}
+	if r.revoked == nil {
+		r.revoked = make(map[uuid.UUID]int64)
+	}
+	r.revoked[userID] = max(r.revoked[userID], issuedBefore)
+	return nil
+}
+
+func (r *fakeAuthRepo) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeAdminRepo struct {
+	adminRepository.AdminRepository
+	logs []*adminModels.AdminLog
+}
+
+func (r *fakeAdminRepo) LogAction(_ context.Context, log *adminModels.AdminLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *fakeAdminRepo) GetAdminLogs(_ context.Context, filter adminRepository.AdminLogFilter, limit, _ int) ([]*adminModels.AdminLog, error) {
+	var logs []*adminModels.AdminLog
+	for _, log := range r.logs {
+		if filter.RequestID == nil || log.RequestID == *filter.RequestID {
+			logs = append(logs, log)
+		}
+	}
+	return logs[:min(len(logs), limit)], nil
+}
+
+type fakeUserDeleter struct {
+	deleted map[uuid.UUID]uuid.UUID // user -> deleted by
+	users   map[uuid.UUID]*authModels.UserAuth
+}
+
+func (d *fakeUserDeleter) DeleteUser(_ context.Context, userID, deletedBy uuid.UUID) (*userdeletion.Result, error) {
+	user, ok := d.users[userID]
+	if !ok {
+		return nil, userdeletion.ErrUserNotFound
+	}
+	delete(d.users, userID)
+	d.deleted[userID] = deletedBy
+	return &userdeletion.Result{Username: user.Username, PostsDeleted: 3}, nil
+}
+
+func newUserAdminTest(t *testing.T) (*Service, *fakeAuthRepo, *fakeAdminRepo, uuid.UUID, uuid.UUID) {
+	t.Helper()
+	adminID, userID := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	authRepo := &fakeAuthRepo{users: map[uuid.UUID]*authModels.UserAuth{
+		adminID: {ObjectId: adminID, Username: "admin@example.com", Role: "admin", EmailVerified: true},
+		userID:  {ObjectId: userID, Username: "user@example.com", Role: "user", EmailVerified: true},
+	}}
+	adminRepo := &fakeAdminRepo{}
+	s := NewService(authRepo, nil, adminRepo, "", createTestPlatformConfig())
+	s.SetUserDeleter(&fakeUserDeleter{deleted: map[uuid.UUID]uuid.UUID{}, users: authRepo.users})
+	return s, authRepo, adminRepo, adminID, userID
+}
+
+func TestListUsers_FiltersAndPages(t *testing.T) {
+	s, authRepo, _, _, _ := newUserAdminTest(t)
+	verified := true
+	from, to := int64(100), int64(200)
+
+	list, err := s.ListUsers(context.Background(), adminModels.UserQuery{
+		Email: "example", Role: "user", Verified: &verified, CreatedFrom: &from, CreatedTo: &to, Page: 3, Limit: 500,
+	})
+	require.NoError(t, err)
+	assert.Len(t, list.Users, 2)
+	assert.EqualValues(t, 2, list.Total)
+	assert.Equal(t, 3, list.Page)
+	assert.Equal(t, maxUserPageSize, list.Limit)
+	assert.Equal(t, maxUserPageSize, authRepo.lastLimit)
+	assert.Equal(t, 2*maxUserPageSize, authRepo.lastOffset)
+	assert.Equal(t, "example", authRepo.lastFilter.Email)
+	require.NotNil(t, authRepo.lastFilter.Role)
+	assert.Equal(t, "user", *authRepo.lastFilter.Role)
+
+	_, err = s.ListUsers(context.Background(), adminModels.UserQuery{CreatedFrom: &to, CreatedTo: &from})
+	assert.Error(t, err, "an inverted created range is rejected")
+}
+
+func TestSetSuspended(t *testing.T) {
+	s, authRepo, adminRepo, adminID, userID := newUserAdminTest(t)
+	ctx := context.Background()
+
+	user, err := s.SetSuspended(ctx, adminID, userID, true, "spam")
+	require.NoError(t, err)
+	assert.True(t, user.Suspended)
+	assert.NotZero(t, user.SuspendedDate)
+	assert.Equal(t, user.SuspendedDate, authRepo.revoked[userID], "tokens issued before the suspension are revoked")
+	require.Len(t, adminRepo.logs, 1)
+	assert.Equal(t, actionUserSuspend, adminRepo.logs[0].Action)
+	assert.Equal(t, userID, *adminRepo.logs[0].TargetID)
+	assert.Equal(t, "spam", adminRepo.logs[0].Details["reason"])
+
+	user, err = s.SetSuspended(ctx, adminID, userID, false, "")
+	require.NoError(t, err)
+	assert.False(t, user.Suspended)
+	assert.Zero(t, user.SuspendedDate)
+	assert.Equal(t, actionUserReinstate, adminRepo.logs[1].Action)
+
+	_, err = s.SetSuspended(ctx, adminID, adminID, true, "")
+	assert.Error(t, err, "admins cannot suspend themselves")
+
+	_, err = s.SetSuspended(ctx, adminID, uuid.Must(uuid.NewV4()), true, "")
+	assert.ErrorIs(t, err, authErrors.ErrUserNotFound)
+}
+
+func TestUpdateRole(t *testing.T) {
+	s, authRepo, adminRepo, adminID, userID := newUserAdminTest(t)
+	ctx := context.Background()
+
+	user, err := s.UpdateRole(ctx, adminID, userID, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "admin", user.Role)
+	assert.NotZero(t, authRepo.revoked[userID], "tokens carrying the old role are revoked")
+	assert.Equal(t, actionUserRoleUpdate, adminRepo.logs[0].Action)
+
+	_, err = s.UpdateRole(ctx, adminID, userID, "owner")
+	assert.Error(t, err, "unknown roles are rejected")
+	_, err = s.UpdateRole(ctx, adminID, adminID, "user")
+	assert.Error(t, err, "admins cannot demote themselves")
+}
+
+func TestDeleteUser(t *testing.T) {
+	s, authRepo, adminRepo, adminID, userID := newUserAdminTest(t)
+	ctx := context.Background()
+
+	result, err := s.DeleteUser(ctx, adminID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", result.Email)
+	assert.EqualValues(t, 3, result.PostsDeleted)
+	assert.NotContains(t, authRepo.users, userID)
+	require.Len(t, adminRepo.logs, 1)
+	assert.Equal(t, actionUserDelete, adminRepo.logs[0].Action)
+
+	_, err = s.DeleteUser(ctx, adminID, userID)
+	assert.ErrorIs(t, err, authErrors.ErrUserNotFound)
+	_, err = s.DeleteUser(ctx, adminID, adminID)
+	assert.Error(t, err, "admins cannot delete themselves")
+}
+
+func TestUserHandlers(t *testing.T) {
+	s, _, _, adminID, userID := newUserAdminTest(t)
+	h := NewAdminHandler(s, createTestPlatformConfig().JWT, createTestPlatformConfig().HMAC)
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(types.UserCtxName, types.UserContext{UserID: adminID, SystemRole: "admin"})
+		return c.Next()
+	})
+	app.Get("/admin/users", h.ListUsers)
+	app.Put("/admin/users/:id/suspend", h.SuspendUser)
+	app.Put("/admin/users/:id/role", h.UpdateUserRole)
+	app.Delete("/admin/users/:id", h.DeleteUser)
+
+	do := func(method, target, body string) (int, map[string]interface{}) {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		var out map[string]interface{}
+		_ = json.NewDecoder(resp.Body).Decode(&out)
+		return resp.StatusCode, out
+	}
+
+	status, out := do("GET", "/admin/users?verified=true&page=1&limit=10", "")
+	assert.Equal(t, 200, status)
+	assert.Len(t, out["users"], 2)
+	status, _ = do("GET", "/admin/users?verified=maybe", "")
+	assert.Equal(t, 400, status)
+
+	status, out = do("PUT", "/admin/users/"+userID.String()+"/suspend", "")
+	assert.Equal(t, 200, status)
+	assert.Equal(t, true, out["suspended"], "an empty body suspends")
+	status, out = do("PUT", "/admin/users/"+userID.String()+"/suspend", `{"suspended":false}`)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, false, out["suspended"])
+
+	status, out = do("PUT", "/admin/users/"+userID.String()+"/role", `{"role":"admin"}`)
+	assert.Equal(t, 200, status)
+	assert.Equal(t, "admin", out["role"])
+	status, _ = do("PUT", "/admin/users/not-a-uuid/role", `{"role":"admin"}`)
+	assert.Equal(t, 400, status)
+
+	status, _ = do("DELETE", "/admin/users/"+userID.String(), "")
+	assert.Equal(t, 200, status)
+	status, _ = do("DELETE", "/admin/users/"+userID.String(), "")
+	assert.Equal(t, 404, status)
+}
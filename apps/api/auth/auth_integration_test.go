@@ -53,6 +53,8 @@ func applyAuthAndProfileMigrations(t *testing.T, ctx context.Context, pgClient *
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
@@ -445,6 +447,8 @@ func TestAuth_Complete_Refactored_Flow(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
@@ -27,6 +27,7 @@ const (
 	CodeUserAlreadyExists    = "USER_ALREADY_EXISTS"
 	CodeVerificationFailed   = "VERIFICATION_FAILED"
 	CodeRateLimitExceeded    = "RATE_LIMIT_EXCEEDED"
+	CodeEmailDomainDenied    = "EMAIL_DOMAIN_NOT_ALLOWED"
 )
 
 // Auth service specific errors
@@ -42,6 +43,7 @@ var (
 	ErrSystemError          = errors.New("system error occurred")
 	ErrAuthenticationFailed = errors.New("authentication failed")
 	ErrRateLimitExceeded    = errors.New("rate limit exceeded")
+	ErrEmailDomainDenied    = errors.New("email domain not allowed")
 )
 
 // ErrorResponse represents the standardized error response format
@@ -186,6 +188,11 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 			Code:    CodeVerificationFailed,
 			Message: "Verification failed",
 		})
+	case errors.Is(err, ErrEmailDomainDenied):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
+			Code:    CodeEmailDomainDenied,
+			Message: "Signups from this email domain are not allowed",
+		})
 	case errors.Is(err, ErrDatabaseError):
 		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{
 			Code:    CodeDatabaseError,
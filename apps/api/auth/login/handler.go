@@ -1,14 +1,17 @@
 package login
 
 import (
+	"context"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	uuid "github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/auth/errors"
 	tokenutil "github.com/qolzam/telar/apps/api/internal/auth/tokens"
 	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 type Handler struct {
@@ -27,6 +30,28 @@ type HandlerConfig struct {
 	HeaderCookieName    string
 	PayloadCookieName   string
 	SignatureCookieName string
+
+	// Experiments, when set, embeds the user's experiment variants in the token
+	Experiments interfaces.ExperimentAssigner
+
+	// Sessions, when set, records each issued token for the session list
+	Sessions SessionRecorder
+
+	// MFA, when set, holds back the token of users with a second factor until they
+	// complete a challenge at POST /auth/login/mfa
+	MFA SecondFactor
+}
+
+// SecondFactor gates sign-in on a second factor; the mfa service satisfies it
+type SecondFactor interface {
+	Required(ctx context.Context, userID uuid.UUID) (bool, error)
+	IssueChallenge(userID uuid.UUID) (string, time.Duration)
+	CompleteChallenge(ctx context.Context, token, code string) (uuid.UUID, error)
+}
+
+// SessionRecorder records issued sessions; it never fails the sign-in
+type SessionRecorder interface {
+	RecordSession(ctx context.Context, sessionID, loginUserID, identityUserID uuid.UUID, userAgent, ipAddress string)
 }
 
 func NewHandler(s *Service, config *HandlerConfig) *Handler {
@@ -88,13 +113,77 @@ func (h *Handler) Handle(c *fiber.Ctx) error {
 	if h.svc.ComparePassword(foundUser.Password, model.Password) != nil {
 		return errors.HandleAuthenticationError(c, "Password doesn't match!")
 	}
+	if foundUser.Suspended {
+		return errors.HandlePermissionError(c, "User is suspended!")
+	}
+
+	if h.config.MFA != nil {
+		required, err := h.config.MFA.Required(c.Context(), foundUser.ObjectId)
+		if err != nil {
+			return errors.HandleSystemError(c, "Can not check second factor!")
+		}
+		if required {
+			challengeToken, ttl := h.config.MFA.IssueChallenge(foundUser.ObjectId)
+			return c.JSON(fiber.Map{
+				"mfaRequired":    true,
+				"challengeToken": challengeToken,
+				"expires_in":     strconv.Itoa(int(ttl.Seconds())),
+			})
+		}
+	}
+
+	return h.signIn(c, foundUser)
+}
+
+// VerifyMFA completes a sign-in held back for a second factor, taking the challenge
+// token from the login response and a TOTP or recovery code.
+// Endpoint: POST /auth/login/mfa
+func (h *Handler) VerifyMFA(c *fiber.Ctx) error {
+	if h.config.MFA == nil {
+		return errors.HandleInvalidRequestError(c, "Second factor sign-in is not available")
+	}
+
+	model := &MFALoginModel{}
+	if c.Is("json") {
+		_ = c.BodyParser(model)
+	}
+	if model.ChallengeToken == "" {
+		model.ChallengeToken = c.FormValue("challengeToken")
+	}
+	if model.Code == "" {
+		model.Code = c.FormValue("code")
+	}
+	if model.ChallengeToken == "" {
+		return errors.HandleMissingFieldError(c, "challengeToken")
+	}
+	if model.Code == "" {
+		return errors.HandleMissingFieldError(c, "code")
+	}
 
+	userID, err := h.config.MFA.CompleteChallenge(c.Context(), model.ChallengeToken, model.Code)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	foundUser, err := h.svc.FindUserByID(c.Context(), userID)
+	if err != nil || foundUser == nil {
+		return errors.HandleUserNotFoundError(c, "User not found!")
+	}
+	if foundUser.Suspended {
+		return errors.HandlePermissionError(c, "User is suspended!")
+	}
+
+	return h.signIn(c, foundUser)
+}
+
+// signIn issues the access token of a user who passed every check
+func (h *Handler) signIn(c *fiber.Ctx, foundUser *userAuth) error {
 	profile, _, err := h.svc.ReadProfileAndLanguage(c.Context(), *foundUser)
 	if err != nil || profile == nil {
 		return errors.HandleSystemError(c, "Can not find user profile!")
 	}
 
 	// Create token session using existing token util (in legacy it writes cookie + returns accessToken)
+	sessionID := uuid.Must(uuid.NewV4())
 	tokenModel := map[string]interface{}{
 		"claim": map[string]interface{}{
 			"displayName":   profile.FullName,
@@ -106,14 +195,24 @@ func (h *Handler) Handle(c *fiber.Ctx) error {
 			types.HeaderUID: foundUser.ObjectId.String(),
 			"role":          foundUser.Role,
 			"createdDate":   profile.CreatedDate,
-			"jti":           uuid.Must(uuid.NewV4()).String(),
+			"jti":           sessionID.String(),
 		},
 	}
 
+	if h.config.Experiments != nil {
+		if assignments := h.config.Experiments.AssignmentsForUser(c.Context(), foundUser.ObjectId, foundUser.Role); len(assignments) > 0 {
+			tokenModel["claim"].(map[string]interface{})["experiments"] = assignments
+		}
+	}
+
 	// Create ES256 token (no cookies, no URL redirects)
 	profileInfo := map[string]string{"id": foundUser.ObjectId.String(), "login": foundUser.Username, "name": profile.FullName, "audience": h.webDomain}
 	accessToken, _ := tokenutil.CreateTokenWithKey("telar", profileInfo, "Telar", tokenModel["claim"].(map[string]interface{}), h.privateKey)
 
+	if h.config.Sessions != nil {
+		h.config.Sessions.RecordSession(c.Context(), sessionID, foundUser.ObjectId, foundUser.ObjectId, c.Get(fiber.HeaderUserAgent), c.IP())
+	}
+
 	return c.JSON(fiber.Map{
 		"user":        profile,
 		"accessToken": accessToken,
@@ -84,6 +84,8 @@ func TestLogin_Handle_SPA_POST_RedirectBranch_Coverage(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
@@ -178,6 +180,8 @@ func TestLogin_Handle_SSR_POST_SetsRedirect(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
@@ -298,6 +302,8 @@ func TestLogin_Github_Google_Redirects(t *testing.T) {
 			role VARCHAR(50) DEFAULT 'user',
 			email_verified BOOLEAN DEFAULT FALSE,
 			phone_verified BOOLEAN DEFAULT FALSE,
+			suspended BOOLEAN NOT NULL DEFAULT FALSE,
+			suspended_date BIGINT NOT NULL DEFAULT 0,
 			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
 			created_date BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())::BIGINT,
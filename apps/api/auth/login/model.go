@@ -11,3 +11,9 @@ type LoginRequest struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 }
+
+// MFALoginModel is the request body of POST /auth/login/mfa
+type MFALoginModel struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+}
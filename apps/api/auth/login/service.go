@@ -52,6 +52,7 @@ type userAuth struct {
 	EmailVerified bool      `json:"emailVerified" bson:"emailVerified" db:"emailVerified"`
 	PhoneVerified bool      `json:"phoneVerified" bson:"phoneVerified" db:"phoneVerified"`
 	Role          string    `json:"role" bson:"role" db:"role"`
+	Suspended     bool      `json:"suspended" bson:"suspended" db:"suspended"`
 }
 
 func (s *Service) FindUserByUsername(ctx context.Context, username string) (*userAuth, error) {
@@ -70,6 +71,27 @@ func (s *Service) FindUserByUsername(ctx context.Context, username string) (*use
 		EmailVerified: userAuthModel.EmailVerified,
 		PhoneVerified: userAuthModel.PhoneVerified,
 		Role:          userAuthModel.Role,
+		Suspended:     userAuthModel.Suspended,
+	}, nil
+}
+
+// FindUserByID looks up a user by ID, as when completing a second factor challenge
+func (s *Service) FindUserByID(ctx context.Context, userID uuid.UUID) (*userAuth, error) {
+	if s.authRepo == nil {
+		return nil, fmt.Errorf("auth repository not available")
+	}
+	userAuthModel, err := s.authRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &userAuth{
+		ObjectId:      userAuthModel.ObjectId,
+		Username:      userAuthModel.Username,
+		Password:      userAuthModel.Password,
+		EmailVerified: userAuthModel.EmailVerified,
+		PhoneVerified: userAuthModel.PhoneVerified,
+		Role:          userAuthModel.Role,
+		Suspended:     userAuthModel.Suspended,
 	}, nil
 }
 
@@ -131,6 +153,9 @@ func (s *Service) AuthenticateUser(ctx context.Context, username, password strin
 	if err := s.ComparePassword(user.Password, password); err != nil {
 		return "", errors.WrapAuthenticationError(fmt.Errorf("invalid password"))
 	}
+	if user.Suspended {
+		return "", errors.ErrPermissionDenied
+	}
 
 	// Generate authentication token
 	claim := map[string]interface{}{
@@ -0,0 +1,111 @@
+package mfa
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type Handler struct {
+	svc *Service
+}
+
+func NewHandler(s *Service) *Handler {
+	return &Handler{svc: s}
+}
+
+func currentUser(c *fiber.Ctx) (types.UserContext, bool) {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	return user, ok && user.UserID != uuid.Nil
+}
+
+// codeFromBody reads the code every state-changing endpoint except setup requires
+func codeFromBody(c *fiber.Ctx) (string, bool) {
+	model := &CodeModel{}
+	if err := c.BodyParser(model); err != nil || model.Code == "" {
+		return "", false
+	}
+	return model.Code, true
+}
+
+// Status handles GET /auth/mfa
+func (h *Handler) Status(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	status, err := h.svc.Status(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(status)
+}
+
+// Setup handles POST /auth/mfa/setup
+func (h *Handler) Setup(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	result, err := h.svc.Setup(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.Status(fiber.StatusCreated).JSON(result)
+}
+
+// Enable handles POST /auth/mfa/enable
+func (h *Handler) Enable(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+	code, ok := codeFromBody(c)
+	if !ok {
+		return errors.HandleMissingFieldError(c, "code")
+	}
+
+	result, err := h.svc.Enable(c.Context(), user.UserID, code)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(result)
+}
+
+// Disable handles POST /auth/mfa/disable
+func (h *Handler) Disable(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+	code, ok := codeFromBody(c)
+	if !ok {
+		return errors.HandleMissingFieldError(c, "code")
+	}
+
+	if err := h.svc.Disable(c.Context(), user.UserID, code); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RecoveryCodes handles POST /auth/mfa/recovery-codes
+func (h *Handler) RecoveryCodes(c *fiber.Ctx) error {
+	user, ok := currentUser(c)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+	code, ok := codeFromBody(c)
+	if !ok {
+		return errors.HandleMissingFieldError(c, "code")
+	}
+
+	result, err := h.svc.RegenerateRecoveryCodes(c.Context(), user.UserID, code)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(result)
+}
@@ -0,0 +1,25 @@
+package mfa
+
+// CodeModel is the request body of endpoints that need a current code: a 6-digit TOTP
+// code or an unused recovery code
+type CodeModel struct {
+	Code string `json:"code"`
+}
+
+// SetupResult is a new secret waiting to be confirmed with POST /auth/mfa/enable
+type SetupResult struct {
+	Secret     string `json:"secret"`
+	OTPAuthURL string `json:"otpauthUrl"`
+}
+
+// RecoveryCodesResult carries freshly generated recovery codes; they are shown only once
+type RecoveryCodesResult struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// Status reports whether MFA is on for the current user
+type Status struct {
+	Enabled                bool  `json:"enabled"`
+	EnabledDate            int64 `json:"enabledDate,omitempty"`
+	RecoveryCodesRemaining int   `json:"recoveryCodesRemaining"`
+}
@@ -0,0 +1,238 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/auth/repository"
+)
+
+const (
+	// recoveryCodeCount is how many recovery codes enrollment and regeneration issue
+	recoveryCodeCount = 10
+	// defaultChallengeTTL is how long a login challenge may wait for its code
+	defaultChallengeTTL = 5 * time.Minute
+)
+
+// recoveryAlphabet leaves out characters that are easy to misread
+const recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+type Service struct {
+	repo     repository.MFARepository
+	authRepo repository.AuthRepository
+	config   *ServiceConfig
+	now      func() time.Time
+}
+
+type ServiceConfig struct {
+	// Issuer names the service in authenticator apps
+	Issuer string
+	// ChallengeSecret signs login challenge tokens
+	ChallengeSecret string
+	// ChallengeTTL is how long a login challenge stays valid; defaults to five minutes
+	ChallengeTTL time.Duration
+}
+
+// NewService creates the second factor service. authRepo labels secrets with the
+// account's username in authenticator apps.
+func NewService(repo repository.MFARepository, authRepo repository.AuthRepository, config *ServiceConfig) *Service {
+	if config.Issuer == "" {
+		config.Issuer = "Telar"
+	}
+	if config.ChallengeTTL <= 0 {
+		config.ChallengeTTL = defaultChallengeTTL
+	}
+	return &Service{repo: repo, authRepo: authRepo, config: config, now: time.Now}
+}
+
+// Setup starts enrollment with a new secret, replacing any earlier unconfirmed one
+func (s *Service) Setup(ctx context.Context, userID uuid.UUID) (*SetupResult, error) {
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, errors.WrapSystemError(err)
+	}
+	saved, err := s.repo.SavePendingSecret(ctx, userID, secret, s.now().UTC().UnixMilli())
+	if err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	if !saved {
+		return nil, errors.NewValidationError("MFA is already enabled")
+	}
+
+	account := userID.String()
+	if user, err := s.authRepo.FindByID(ctx, userID); err == nil && user != nil {
+		account = user.Username
+	}
+	return &SetupResult{Secret: secret, OTPAuthURL: otpauthURL(s.config.Issuer, account, secret)}, nil
+}
+
+// Enable confirms enrollment with a code from the new secret and returns the recovery codes
+func (s *Service) Enable(ctx context.Context, userID uuid.UUID, code string) (*RecoveryCodesResult, error) {
+	mfa, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	if mfa == nil {
+		return nil, errors.NewValidationError("MFA setup has not been started")
+	}
+	if mfa.Enabled {
+		return nil, errors.NewValidationError("MFA is already enabled")
+	}
+	if _, ok := matchTOTP(mfa.Secret, normalizeCode(code), s.now()); !ok {
+		return nil, errors.ErrInvalidCredentials
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, errors.WrapSystemError(err)
+	}
+	if err := s.repo.Enable(ctx, userID, s.now().UTC().UnixMilli(), hashes); err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	return &RecoveryCodesResult{RecoveryCodes: codes}, nil
+}
+
+// Disable turns MFA off; it takes a current code so a stolen session alone cannot
+func (s *Service) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return err
+	}
+	if err := s.repo.Disable(ctx, userID); err != nil {
+		return errors.WrapDatabaseError(err)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes after checking a current code
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*RecoveryCodesResult, error) {
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, errors.WrapSystemError(err)
+	}
+	if err := s.repo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	return &RecoveryCodesResult{RecoveryCodes: codes}, nil
+}
+
+// Status reports whether MFA is enabled and how many recovery codes remain
+func (s *Service) Status(ctx context.Context, userID uuid.UUID) (*Status, error) {
+	mfa, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	if mfa == nil || !mfa.Enabled {
+		return &Status{}, nil
+	}
+	remaining, err := s.repo.CountRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapDatabaseError(err)
+	}
+	return &Status{Enabled: true, EnabledDate: mfa.EnabledDate, RecoveryCodesRemaining: remaining}, nil
+}
+
+// Required reports whether signing in as the user needs a second factor
+func (s *Service) Required(ctx context.Context, userID uuid.UUID) (bool, error) {
+	mfa, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return false, errors.WrapDatabaseError(err)
+	}
+	return mfa != nil && mfa.Enabled, nil
+}
+
+// IssueChallenge returns a login challenge token for a user who passed the password
+// check, and how long it stays valid
+func (s *Service) IssueChallenge(userID uuid.UUID) (string, time.Duration) {
+	return signChallenge(s.config.ChallengeSecret, userID, s.now().Add(s.config.ChallengeTTL)), s.config.ChallengeTTL
+}
+
+// CompleteChallenge checks a login challenge token and the code submitted with it, and
+// returns the user to sign in
+func (s *Service) CompleteChallenge(ctx context.Context, token, code string) (uuid.UUID, error) {
+	userID, err := parseChallenge(s.config.ChallengeSecret, token, s.now())
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err := s.VerifyCode(ctx, userID, code); err != nil {
+		return uuid.Nil, err
+	}
+	return userID, nil
+}
+
+// VerifyCode accepts a TOTP code, once per time step, or an unused recovery code, which
+// is used up
+func (s *Service) VerifyCode(ctx context.Context, userID uuid.UUID, code string) error {
+	mfa, err := s.repo.FindByUserID(ctx, userID)
+	if err != nil {
+		return errors.WrapDatabaseError(err)
+	}
+	if mfa == nil || !mfa.Enabled {
+		return errors.NewValidationError("MFA is not enabled")
+	}
+
+	code = normalizeCode(code)
+	if step, ok := matchTOTP(mfa.Secret, code, s.now()); ok {
+		claimed, err := s.repo.ClaimStep(ctx, userID, step)
+		if err != nil {
+			return errors.WrapDatabaseError(err)
+		}
+		if !claimed {
+			return errors.ErrInvalidCredentials
+		}
+		return nil
+	}
+
+	used, err := s.repo.UseRecoveryCode(ctx, userID, hashRecoveryCode(code), s.now().UTC().UnixMilli())
+	if err != nil {
+		return errors.WrapDatabaseError(err)
+	}
+	if !used {
+		return errors.ErrInvalidCredentials
+	}
+	return nil
+}
+
+// normalizeCode drops the spaces and dashes users type or paste with codes
+func normalizeCode(code string) string {
+	return strings.ToLower(strings.NewReplacer(" ", "", "-", "").Replace(code))
+}
+
+// newRecoveryCodes returns recovery codes formatted as xxxxx-xxxxx and their hashes
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	raw := make([]byte, 10)
+	for i := 0; i < recoveryCodeCount; i++ {
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		var b strings.Builder
+		for j, v := range raw {
+			if j == 5 {
+				b.WriteByte('-')
+			}
+			b.WriteByte(recoveryAlphabet[int(v)%len(recoveryAlphabet)])
+		}
+		code := b.String()
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(normalizeCode(code)))
+	}
+	return codes, hashes, nil
+}
+
+// hashRecoveryCode digests a normalized recovery code. Codes carry about 50 random bits,
+// so a fast hash is enough and lets a code be looked up directly.
+func hashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,248 @@
+package mfa
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	authErrors "github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/auth/models"
+	"github.com/qolzam/telar/apps/api/auth/repository"
+)
+
+// fakeAuthRepo finds no users, so secrets are labelled with the user ID
+type fakeAuthRepo struct {
+	repository.AuthRepository
+}
+
+func (f *fakeAuthRepo) FindByID(ctx context.Context, userID uuid.UUID) (*models.UserAuth, error) {
+	return nil, errors.New("not found")
+}
+
+type fakeMFARepo struct {
+	mfa   map[uuid.UUID]*models.UserMFA
+	codes map[uuid.UUID]map[string]bool // hash -> used
+}
+
+func newFakeMFARepo() *fakeMFARepo {
+	return &fakeMFARepo{mfa: map[uuid.UUID]*models.UserMFA{}, codes: map[uuid.UUID]map[string]bool{}}
+}
+
+func (f *fakeMFARepo) SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string, createdDate int64) (bool, error) {
+	if m := f.mfa[userID]; m != nil && m.Enabled {
+		return false, nil
+	}
+	f.mfa[userID] = &models.UserMFA{UserId: userID, Secret: secret, CreatedDate: createdDate}
+	return true, nil
+}
+
+func (f *fakeMFARepo) FindByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	return f.mfa[userID], nil
+}
+
+func (f *fakeMFARepo) Enable(ctx context.Context, userID uuid.UUID, enabledDate int64, codeHashes []string) error {
+	f.mfa[userID].Enabled = true
+	f.mfa[userID].EnabledDate = enabledDate
+	return f.ReplaceRecoveryCodes(ctx, userID, codeHashes)
+}
+
+func (f *fakeMFARepo) Disable(ctx context.Context, userID uuid.UUID) error {
+	delete(f.mfa, userID)
+	delete(f.codes, userID)
+	return nil
+}
+
+func (f *fakeMFARepo) ClaimStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	m := f.mfa[userID]
+	if m.LastUsedStep >= step {
+		return false, nil
+	}
+	m.LastUsedStep = step
+	return true, nil
+}
+
+func (f *fakeMFARepo) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	f.codes[userID] = map[string]bool{}
+	for _, hash := range codeHashes {
+		f.codes[userID][hash] = false
+	}
+	return nil
+}
+
+func (f *fakeMFARepo) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedDate int64) (bool, error) {
+	used, ok := f.codes[userID][codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	f.codes[userID][codeHash] = true
+	return true, nil
+}
+
+func (f *fakeMFARepo) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	count := 0
+	for _, used := range f.codes[userID] {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func newTestService(now time.Time) (*Service, *fakeMFARepo) {
+	repo := newFakeMFARepo()
+	svc := NewService(repo, &fakeAuthRepo{}, &ServiceConfig{ChallengeSecret: "test-secret"})
+	svc.now = func() time.Time { return now }
+	return svc, repo
+}
+
+func codeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := totpCode(secret, totpStep(at))
+	if err != nil {
+		t.Fatalf("totpCode: %v", err)
+	}
+	return code
+}
+
+// enroll runs setup and enable for a new user at the service's current time and returns
+// the secret and recovery codes
+func enroll(t *testing.T, svc *Service, userID uuid.UUID) (string, []string) {
+	t.Helper()
+	setup, err := svc.Setup(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	result, err := svc.Enable(context.Background(), userID, codeAt(t, setup.Secret, svc.now()))
+	if err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	return setup.Secret, result.RecoveryCodes
+}
+
+func TestTOTPCode_MatchesRFC6238Vectors(t *testing.T) {
+	// RFC 6238 appendix B, SHA1 secret "12345678901234567890", truncated to 6 digits
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	vectors := map[int64]string{
+		59:         "287082",
+		1111111109: "081804",
+		1234567890: "005924",
+		2000000000: "279037",
+	}
+	for unix, want := range vectors {
+		if got := codeAt(t, secret, time.Unix(unix, 0)); got != want {
+			t.Errorf("code at %d = %s, want %s", unix, got, want)
+		}
+	}
+}
+
+func TestEnable_RequiresCodeFromNewSecret(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	svc, _ := newTestService(now)
+	userID := uuid.Must(uuid.NewV4())
+	ctx := context.Background()
+
+	if _, err := svc.Enable(ctx, userID, "123456"); err == nil {
+		t.Fatal("expected enabling without setup to fail")
+	}
+	setup, err := svc.Setup(ctx, userID)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	if _, err := svc.Enable(ctx, userID, codeAt(t, setup.Secret, now.Add(time.Hour))); !errors.Is(err, authErrors.ErrInvalidCredentials) {
+		t.Fatalf("expected a stale code to be rejected, got %v", err)
+	}
+	if required, _ := svc.Required(ctx, userID); required {
+		t.Fatal("MFA must not be required before enrollment is confirmed")
+	}
+
+	result, err := svc.Enable(ctx, userID, codeAt(t, setup.Secret, now))
+	if err != nil {
+		t.Fatalf("Enable: %v", err)
+	}
+	if len(result.RecoveryCodes) != recoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %d", recoveryCodeCount, len(result.RecoveryCodes))
+	}
+	if required, _ := svc.Required(ctx, userID); !required {
+		t.Fatal("expected MFA to be required once enabled")
+	}
+	if _, err := svc.Setup(ctx, userID); err == nil {
+		t.Fatal("expected setup to be refused while MFA is enabled")
+	}
+}
+
+func TestVerifyCode_RejectsReplayedStep(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	svc, _ := newTestService(now)
+	userID := uuid.Must(uuid.NewV4())
+	ctx := context.Background()
+	secret, _ := enroll(t, svc, userID)
+	now = now.Add(time.Minute)
+	svc.now = func() time.Time { return now }
+
+	code := codeAt(t, secret, now)
+	if err := svc.VerifyCode(ctx, userID, code); err != nil {
+		t.Fatalf("VerifyCode: %v", err)
+	}
+	if err := svc.VerifyCode(ctx, userID, code); !errors.Is(err, authErrors.ErrInvalidCredentials) {
+		t.Fatalf("expected a replayed code to be rejected, got %v", err)
+	}
+	// The previous step is within the skew window but older than the one used
+	if err := svc.VerifyCode(ctx, userID, codeAt(t, secret, now.Add(-totpPeriod))); !errors.Is(err, authErrors.ErrInvalidCredentials) {
+		t.Fatalf("expected an earlier step to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyCode_RecoveryCodesAreSingleUse(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	svc, _ := newTestService(now)
+	userID := uuid.Must(uuid.NewV4())
+	ctx := context.Background()
+	_, codes := enroll(t, svc, userID)
+
+	// Codes are accepted however the user formats them
+	if err := svc.VerifyCode(ctx, userID, " "+codes[0]+" "); err != nil {
+		t.Fatalf("VerifyCode: %v", err)
+	}
+	if err := svc.VerifyCode(ctx, userID, codes[0]); !errors.Is(err, authErrors.ErrInvalidCredentials) {
+		t.Fatalf("expected a used recovery code to be rejected, got %v", err)
+	}
+	status, err := svc.Status(ctx, userID)
+	if err != nil {
+		t.Fatalf("Status: %v", err)
+	}
+	if status.RecoveryCodesRemaining != recoveryCodeCount-1 {
+		t.Fatalf("expected %d recovery codes left, got %d", recoveryCodeCount-1, status.RecoveryCodesRemaining)
+	}
+}
+
+func TestCompleteChallenge(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	svc, _ := newTestService(now)
+	userID := uuid.Must(uuid.NewV4())
+	ctx := context.Background()
+	secret, _ := enroll(t, svc, userID)
+	now = now.Add(time.Minute)
+	svc.now = func() time.Time { return now }
+
+	token, ttl := svc.IssueChallenge(userID)
+	if ttl != defaultChallengeTTL {
+		t.Fatalf("expected default ttl, got %v", ttl)
+	}
+	if _, err := svc.CompleteChallenge(ctx, token+"x", codeAt(t, secret, now)); !errors.Is(err, authErrors.ErrTokenInvalid) {
+		t.Fatalf("expected a tampered token to be rejected, got %v", err)
+	}
+	got, err := svc.CompleteChallenge(ctx, token, codeAt(t, secret, now))
+	if err != nil {
+		t.Fatalf("CompleteChallenge: %v", err)
+	}
+	if got != userID {
+		t.Fatalf("expected user %s, got %s", userID, got)
+	}
+
+	svc.now = func() time.Time { return now.Add(ttl + time.Second) }
+	if _, err := svc.CompleteChallenge(ctx, token, codeAt(t, secret, now.Add(ttl))); !errors.Is(err, authErrors.ErrTokenExpired) {
+		t.Fatalf("expected an expired token to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+-- Migration: 005_create_linked_accounts.sql
+-- Description: Creates linked_accounts and auth_sessions tables for account switching
+-- Dependencies: Requires user_auths table (003_create_auth_tables.sql)
+
+-- Table: linked_accounts
+-- Purpose: Accounts a user can switch between without signing in again.
+-- Each link is stored once with user_a < user_b.
+CREATE TABLE IF NOT EXISTS linked_accounts (
+    user_a UUID NOT NULL REFERENCES user_auths(id) ON DELETE CASCADE,
+    user_b UUID NOT NULL REFERENCES user_auths(id) ON DELETE CASCADE,
+    linked_by UUID NOT NULL,
+    created_date BIGINT NOT NULL,
+
+    PRIMARY KEY (user_a, user_b),
+    CHECK (user_a < user_b)
+);
+
+CREATE INDEX IF NOT EXISTS idx_linked_accounts_user_b ON linked_accounts(user_b);
+
+-- Table: auth_sessions
+-- Purpose: Sessions issued by login and account switching. id is the token's jti;
+-- identity_user_id is the account the token acts as and login_user_id the account that signed in.
+CREATE TABLE IF NOT EXISTS auth_sessions (
+    id UUID PRIMARY KEY,
+    login_user_id UUID NOT NULL,
+    identity_user_id UUID NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address VARCHAR(64) NOT NULL DEFAULT '',
+    created_date BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_login_user ON auth_sessions(login_user_id, created_date DESC);
+CREATE INDEX IF NOT EXISTS idx_auth_sessions_identity_user ON auth_sessions(identity_user_id, created_date DESC);
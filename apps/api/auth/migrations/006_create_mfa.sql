@@ -0,0 +1,26 @@
+-- Migration: 006_create_mfa.sql
+-- Description: Creates user_mfa and mfa_recovery_codes tables for TOTP second factors
+-- Dependencies: Requires user_auths table (003_create_auth_tables.sql)
+
+-- Table: user_mfa
+-- Purpose: One TOTP secret per user. A row with enabled = FALSE is an enrollment waiting
+-- for its first code. last_used_step is the newest 30-second step accepted, so a code
+-- cannot be replayed.
+CREATE TABLE IF NOT EXISTS user_mfa (
+    user_id UUID PRIMARY KEY REFERENCES user_auths(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    created_date BIGINT NOT NULL,
+    enabled_date BIGINT NOT NULL DEFAULT 0,
+    last_used_step BIGINT NOT NULL DEFAULT 0
+);
+
+-- Table: mfa_recovery_codes
+-- Purpose: Single-use recovery codes, stored as SHA-256 hex digests
+CREATE TABLE IF NOT EXISTS mfa_recovery_codes (
+    user_id UUID NOT NULL REFERENCES user_auths(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_date BIGINT NOT NULL DEFAULT 0,
+
+    PRIMARY KEY (user_id, code_hash)
+);
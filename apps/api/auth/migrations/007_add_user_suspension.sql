@@ -0,0 +1,11 @@
+-- Migration: 007_add_user_suspension.sql
+-- Description: Lets admins suspend accounts; suspended users cannot sign in
+-- Dependencies: Requires user_auths table (003_create_auth_tables.sql)
+
+ALTER TABLE user_auths
+    ADD COLUMN IF NOT EXISTS suspended BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS suspended_date BIGINT NOT NULL DEFAULT 0;
+
+-- Admin user listing filters by role and sorts by signup time
+CREATE INDEX IF NOT EXISTS idx_user_auths_role ON user_auths(role);
+CREATE INDEX IF NOT EXISTS idx_user_auths_created_date ON user_auths(created_date DESC);
@@ -0,0 +1,9 @@
+-- Migration: 008_add_admin_log_request_id.sql
+-- Description: Records the request that made each admin action, so support can look
+--              up the audit entries of a request ID quoted from an error response
+-- Dependencies: Requires admin_logs table (004_create_admin_tables.sql)
+
+ALTER TABLE admin_logs
+    ADD COLUMN IF NOT EXISTS request_id VARCHAR(128);
+
+CREATE INDEX IF NOT EXISTS idx_admin_logs_request_id ON admin_logs(request_id) WHERE request_id IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration: 009_add_user_tokens_valid_after.sql
+-- Description: Revokes the access tokens of suspended users and users whose role changed
+-- Dependencies: Requires user_auths table (003_create_auth_tables.sql)
+
+-- Tokens issued at or before this Unix time (seconds) are refused; 0 revokes nothing
+ALTER TABLE user_auths
+    ADD COLUMN IF NOT EXISTS tokens_valid_after BIGINT NOT NULL DEFAULT 0;
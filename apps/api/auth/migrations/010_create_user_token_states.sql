@@ -0,0 +1,8 @@
+-- Migration: 010_create_user_token_states.sql
+-- Description: Exposes what access tokens are checked against, so the roles of other
+-- modules can check tokens without reading user_auths
+-- Dependencies: Requires tokens_valid_after (009_add_user_tokens_valid_after.sql)
+
+-- The view reads user_auths with its owner's privileges; roles are granted the view only
+CREATE OR REPLACE VIEW user_token_states AS
+    SELECT id, suspended, tokens_valid_after FROM user_auths;
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// AccountLink is a link from one account to another it can switch to
+type AccountLink struct {
+	UserId      uuid.UUID `json:"userId" db:"user_id"`
+	LinkedBy    uuid.UUID `json:"linkedBy" db:"linked_by"`
+	CreatedDate int64     `json:"createdDate" db:"created_date"`
+}
+
+// AuthSession is a session issued by login or by switching accounts
+type AuthSession struct {
+	ObjectId       uuid.UUID `json:"objectId" db:"id"`
+	LoginUserId    uuid.UUID `json:"loginUserId" db:"login_user_id"`
+	IdentityUserId uuid.UUID `json:"identityUserId" db:"identity_user_id"`
+	UserAgent      string    `json:"userAgent" db:"user_agent"`
+	IPAddress      string    `json:"ipAddress" db:"ip_address"`
+	CreatedDate    int64     `json:"createdDate" db:"created_date"`
+}
@@ -12,6 +12,8 @@ type UserAuth struct {
 	Role          string    `json:"role" bson:"role"`
 	EmailVerified bool      `json:"emailVerified" bson:"emailVerified"`
 	PhoneVerified bool      `json:"phoneVerified" bson:"phoneVerified"`
+	Suspended     bool      `json:"suspended" bson:"suspended"`
+	SuspendedDate int64     `json:"suspendedDate" bson:"suspendedDate"` // 0 unless suspended
 	CreatedDate   int64     `json:"createdDate" bson:"createdDate"`
 	LastUpdated   int64     `json:"lastUpdated" bson:"lastUpdated"`
 }
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"github.com/gofrs/uuid"
+)
+
+// UserMFA is a user's TOTP second factor; Enabled is false until enrollment is confirmed
+type UserMFA struct {
+	UserId       uuid.UUID `json:"userId" db:"user_id"`
+	Secret       string    `json:"-" db:"secret"`
+	Enabled      bool      `json:"enabled" db:"enabled"`
+	CreatedDate  int64     `json:"createdDate" db:"created_date"`
+	EnabledDate  int64     `json:"enabledDate" db:"enabled_date"`
+	LastUsedStep int64     `json:"-" db:"last_used_step"`
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/auth/models"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+// postgresAccountRepository implements AccountRepository using raw SQL queries
+type postgresAccountRepository struct {
+	client *postgres.Client
+}
+
+// NewPostgresAccountRepository creates a new PostgreSQL repository for linked accounts
+func NewPostgresAccountRepository(client *postgres.Client) AccountRepository {
+	return &postgresAccountRepository{
+		client: client,
+	}
+}
+
+// getExecutor returns either the transaction from context or the DB connection
+func (r *postgresAccountRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// orderedPair returns the two IDs in the order links are stored, matching the user_a < user_b check
+func orderedPair(userID, otherID uuid.UUID) (uuid.UUID, uuid.UUID) {
+	if bytes.Compare(userID.Bytes(), otherID.Bytes()) > 0 {
+		return otherID, userID
+	}
+	return userID, otherID
+}
+
+// LinkAccounts links two accounts
+func (r *postgresAccountRepository) LinkAccounts(ctx context.Context, userID, otherID, linkedBy uuid.UUID, createdDate int64) error {
+	a, b := orderedPair(userID, otherID)
+	query := `
+		INSERT INTO linked_accounts (user_a, user_b, linked_by, created_date)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_a, user_b) DO NOTHING
+	`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, a, b, linkedBy, createdDate); err != nil {
+		return fmt.Errorf("failed to link accounts: %w", err)
+	}
+	return nil
+}
+
+// UnlinkAccounts removes the link between two accounts
+func (r *postgresAccountRepository) UnlinkAccounts(ctx context.Context, userID, otherID uuid.UUID) (bool, error) {
+	a, b := orderedPair(userID, otherID)
+	result, err := r.getExecutor(ctx).ExecContext(ctx, `DELETE FROM linked_accounts WHERE user_a = $1 AND user_b = $2`, a, b)
+	if err != nil {
+		return false, fmt.Errorf("failed to unlink accounts: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// AreLinked reports whether two accounts are linked
+func (r *postgresAccountRepository) AreLinked(ctx context.Context, userID, otherID uuid.UUID) (bool, error) {
+	a, b := orderedPair(userID, otherID)
+	var linked bool
+	query := `SELECT EXISTS (SELECT 1 FROM linked_accounts WHERE user_a = $1 AND user_b = $2)`
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &linked, query, a, b); err != nil {
+		return false, fmt.Errorf("failed to check account link: %w", err)
+	}
+	return linked, nil
+}
+
+// FindLinked returns the accounts linked to a user
+func (r *postgresAccountRepository) FindLinked(ctx context.Context, userID uuid.UUID) ([]models.AccountLink, error) {
+	query := `
+		SELECT CASE WHEN user_a = $1 THEN user_b ELSE user_a END AS user_id, linked_by, created_date
+		FROM linked_accounts
+		WHERE user_a = $1 OR user_b = $1
+		ORDER BY created_date ASC
+	`
+	var links []models.AccountLink
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &links, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to find linked accounts: %w", err)
+	}
+	return links, nil
+}
+
+// CreateSession records an issued session
+func (r *postgresAccountRepository) CreateSession(ctx context.Context, session *models.AuthSession) error {
+	query := `
+		INSERT INTO auth_sessions (id, login_user_id, identity_user_id, user_agent, ip_address, created_date)
+		VALUES (:id, :login_user_id, :identity_user_id, :user_agent, :ip_address, :created_date)
+	`
+	if _, err := sqlx.NamedExecContext(ctx, r.getExecutor(ctx), query, session); err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	return nil
+}
+
+// FindSessions returns the newest sessions a user signed in to or acts as
+func (r *postgresAccountRepository) FindSessions(ctx context.Context, userID uuid.UUID, limit int) ([]*models.AuthSession, error) {
+	query := `
+		SELECT id, login_user_id, identity_user_id, user_agent, ip_address, created_date
+		FROM auth_sessions
+		WHERE login_user_id = $1 OR identity_user_id = $1
+		ORDER BY created_date DESC
+		LIMIT $2
+	`
+	var sessions []*models.AuthSession
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &sessions, query, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to find sessions: %w", err)
+	}
+	return sessions, nil
+}
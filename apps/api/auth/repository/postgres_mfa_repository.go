@@ -0,0 +1,186 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/auth/models"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+// postgresMFARepository implements MFARepository using raw SQL queries
+type postgresMFARepository struct {
+	client *postgres.Client
+}
+
+// NewPostgresMFARepository creates a new PostgreSQL repository for second factors
+func NewPostgresMFARepository(client *postgres.Client) MFARepository {
+	return &postgresMFARepository{
+		client: client,
+	}
+}
+
+// getExecutor returns either the transaction from context or the DB connection
+func (r *postgresMFARepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// SavePendingSecret upserts a pending enrollment unless MFA is already enabled
+func (r *postgresMFARepository) SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string, createdDate int64) (bool, error) {
+	query := `
+		INSERT INTO user_mfa (user_id, secret, enabled, created_date)
+		VALUES ($1, $2, FALSE, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, created_date = EXCLUDED.created_date, last_used_step = 0
+		WHERE user_mfa.enabled = FALSE
+	`
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, userID, secret, createdDate)
+	if err != nil {
+		return false, fmt.Errorf("failed to save mfa secret: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// FindByUserID returns the user's second factor
+func (r *postgresMFARepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error) {
+	query := `
+		SELECT user_id, secret, enabled, created_date, enabled_date, last_used_step
+		FROM user_mfa
+		WHERE user_id = $1
+	`
+	var mfa models.UserMFA
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &mfa, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find mfa: %w", err)
+	}
+	return &mfa, nil
+}
+
+// Enable confirms enrollment and stores the recovery codes atomically
+func (r *postgresMFARepository) Enable(ctx context.Context, userID uuid.UUID, enabledDate int64, codeHashes []string) error {
+	return r.withTransaction(ctx, func(txCtx context.Context) error {
+		result, err := r.getExecutor(txCtx).ExecContext(txCtx,
+			`UPDATE user_mfa SET enabled = TRUE, enabled_date = $2 WHERE user_id = $1 AND enabled = FALSE`,
+			userID, enabledDate)
+		if err != nil {
+			return fmt.Errorf("failed to enable mfa: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		if rows == 0 {
+			return fmt.Errorf("failed to enable mfa: no pending enrollment")
+		}
+		return r.ReplaceRecoveryCodes(txCtx, userID, codeHashes)
+	})
+}
+
+// Disable removes the second factor and its recovery codes
+func (r *postgresMFARepository) Disable(ctx context.Context, userID uuid.UUID) error {
+	return r.withTransaction(ctx, func(txCtx context.Context) error {
+		if _, err := r.getExecutor(txCtx).ExecContext(txCtx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+		if _, err := r.getExecutor(txCtx).ExecContext(txCtx, `DELETE FROM user_mfa WHERE user_id = $1`, userID); err != nil {
+			return fmt.Errorf("failed to disable mfa: %w", err)
+		}
+		return nil
+	})
+}
+
+// ClaimStep advances last_used_step, refusing steps at or before it
+func (r *postgresMFARepository) ClaimStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	result, err := r.getExecutor(ctx).ExecContext(ctx,
+		`UPDATE user_mfa SET last_used_step = $2 WHERE user_id = $1 AND last_used_step < $2`,
+		userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim totp step: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ReplaceRecoveryCodes swaps the user's recovery codes for new ones
+func (r *postgresMFARepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, `DELETE FROM mfa_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+	for _, hash := range codeHashes {
+		if _, err := r.getExecutor(ctx).ExecContext(ctx,
+			`INSERT INTO mfa_recovery_codes (user_id, code_hash) VALUES ($1, $2) ON CONFLICT DO NOTHING`,
+			userID, hash); err != nil {
+			return fmt.Errorf("failed to store recovery code: %w", err)
+		}
+	}
+	return nil
+}
+
+// UseRecoveryCode marks an unused recovery code as used
+func (r *postgresMFARepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedDate int64) (bool, error) {
+	result, err := r.getExecutor(ctx).ExecContext(ctx,
+		`UPDATE mfa_recovery_codes SET used_date = $3 WHERE user_id = $1 AND code_hash = $2 AND used_date = 0`,
+		userID, codeHash, usedDate)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// CountRecoveryCodes counts the user's unused recovery codes
+func (r *postgresMFARepository) CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &count,
+		`SELECT COUNT(*) FROM mfa_recovery_codes WHERE user_id = $1 AND used_date = 0`, userID); err != nil {
+		return 0, fmt.Errorf("failed to count recovery codes: %w", err)
+	}
+	return count, nil
+}
+
+// withTransaction runs fn in a transaction, joining the caller's when there is one
+func (r *postgresMFARepository) withTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	uuid "github.com/gofrs/uuid"
@@ -89,7 +90,7 @@ func (r *postgresAuthRepository) CreateUser(ctx context.Context, userAuth *model
 	if err != nil {
 		// Check for unique constraint violation on username
 		if isUniqueConstraintError(err, "username") {
-			return fmt.Errorf("username already exists")
+			return ErrUsernameExists
 		}
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -102,7 +103,7 @@ func (r *postgresAuthRepository) FindByUsername(ctx context.Context, username st
 	query := `
 		SELECT 
 			id, username, password_hash, role, email_verified, phone_verified,
-			created_at, updated_at, created_date, last_updated
+			suspended, suspended_date, created_at, updated_at, created_date, last_updated
 		FROM user_auths 
 		WHERE username = $1`
 
@@ -113,6 +114,8 @@ func (r *postgresAuthRepository) FindByUsername(ctx context.Context, username st
 		Role          string     `db:"role"`
 		EmailVerified bool       `db:"email_verified"`
 		PhoneVerified bool       `db:"phone_verified"`
+		Suspended     bool       `db:"suspended"`
+		SuspendedDate int64      `db:"suspended_date"`
 		CreatedAt     time.Time  `db:"created_at"`
 		UpdatedAt     time.Time  `db:"updated_at"`
 		CreatedDate   int64      `db:"created_date"`
@@ -134,6 +137,8 @@ func (r *postgresAuthRepository) FindByUsername(ctx context.Context, username st
 		Role:          result.Role,
 		EmailVerified: result.EmailVerified,
 		PhoneVerified: result.PhoneVerified,
+		Suspended:     result.Suspended,
+		SuspendedDate: result.SuspendedDate,
 		CreatedDate:   result.CreatedDate,
 		LastUpdated:   result.LastUpdated,
 	}, nil
@@ -144,7 +149,7 @@ func (r *postgresAuthRepository) FindByID(ctx context.Context, userID uuid.UUID)
 	query := `
 		SELECT 
 			id, username, password_hash, role, email_verified, phone_verified,
-			created_at, updated_at, created_date, last_updated
+			suspended, suspended_date, created_at, updated_at, created_date, last_updated
 		FROM user_auths 
 		WHERE id = $1`
 
@@ -155,6 +160,8 @@ func (r *postgresAuthRepository) FindByID(ctx context.Context, userID uuid.UUID)
 		Role          string     `db:"role"`
 		EmailVerified bool       `db:"email_verified"`
 		PhoneVerified bool       `db:"phone_verified"`
+		Suspended     bool       `db:"suspended"`
+		SuspendedDate int64      `db:"suspended_date"`
 		CreatedAt     time.Time  `db:"created_at"`
 		UpdatedAt     time.Time  `db:"updated_at"`
 		CreatedDate   int64      `db:"created_date"`
@@ -176,6 +183,8 @@ func (r *postgresAuthRepository) FindByID(ctx context.Context, userID uuid.UUID)
 		Role:          result.Role,
 		EmailVerified: result.EmailVerified,
 		PhoneVerified: result.PhoneVerified,
+		Suspended:     result.Suspended,
+		SuspendedDate: result.SuspendedDate,
 		CreatedDate:   result.CreatedDate,
 		LastUpdated:   result.LastUpdated,
 	}, nil
@@ -186,7 +195,7 @@ func (r *postgresAuthRepository) FindByRole(ctx context.Context, role string) (*
 	query := `
 		SELECT 
 			id, username, password_hash, role, email_verified, phone_verified,
-			created_at, updated_at, created_date, last_updated
+			suspended, suspended_date, created_at, updated_at, created_date, last_updated
 		FROM user_auths 
 		WHERE role = $1
 		LIMIT 1`
@@ -198,6 +207,8 @@ func (r *postgresAuthRepository) FindByRole(ctx context.Context, role string) (*
 		Role          string     `db:"role"`
 		EmailVerified bool       `db:"email_verified"`
 		PhoneVerified bool       `db:"phone_verified"`
+		Suspended     bool       `db:"suspended"`
+		SuspendedDate int64      `db:"suspended_date"`
 		CreatedAt     time.Time  `db:"created_at"`
 		UpdatedAt     time.Time  `db:"updated_at"`
 		CreatedDate   int64      `db:"created_date"`
@@ -219,6 +230,8 @@ func (r *postgresAuthRepository) FindByRole(ctx context.Context, role string) (*
 		Role:          result.Role,
 		EmailVerified: result.EmailVerified,
 		PhoneVerified: result.PhoneVerified,
+		Suspended:     result.Suspended,
+		SuspendedDate: result.SuspendedDate,
 		CreatedDate:   result.CreatedDate,
 		LastUpdated:   result.LastUpdated,
 	}, nil
@@ -299,8 +312,198 @@ func (r *postgresAuthRepository) UpdatePhoneVerified(ctx context.Context, userID
 	return nil
 }
 
-// Delete deletes a user authentication record
+// FindUsers lists users matching the filter, newest first
+func (r *postgresAuthRepository) FindUsers(ctx context.Context, filter UserFilter, limit, offset int) ([]*models.UserAuth, error) {
+	where, args := buildUserWhere(filter)
+	query := fmt.Sprintf(`
+		SELECT
+			id, username, password_hash, role, email_verified, phone_verified,
+			suspended, suspended_date, created_date, last_updated
+		FROM user_auths
+		%s
+		ORDER BY created_date DESC, id
+		LIMIT $%d OFFSET $%d`, where, len(args)+1, len(args)+2)
+	args = append(args, limit, offset)
+
+	var rows []struct {
+		ID            uuid.UUID `db:"id"`
+		Username      string    `db:"username"`
+		PasswordHash  []byte    `db:"password_hash"`
+		Role          string    `db:"role"`
+		EmailVerified bool      `db:"email_verified"`
+		PhoneVerified bool      `db:"phone_verified"`
+		Suspended     bool      `db:"suspended"`
+		SuspendedDate int64     `db:"suspended_date"`
+		CreatedDate   int64     `db:"created_date"`
+		LastUpdated   int64     `db:"last_updated"`
+	}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to find users: %w", err)
+	}
+
+	users := make([]*models.UserAuth, len(rows))
+	for i, row := range rows {
+		users[i] = &models.UserAuth{
+			ObjectId:      row.ID,
+			Username:      row.Username,
+			Password:      row.PasswordHash,
+			Role:          row.Role,
+			EmailVerified: row.EmailVerified,
+			PhoneVerified: row.PhoneVerified,
+			Suspended:     row.Suspended,
+			SuspendedDate: row.SuspendedDate,
+			CreatedDate:   row.CreatedDate,
+			LastUpdated:   row.LastUpdated,
+		}
+	}
+	return users, nil
+}
+
+// CountUsers counts users matching the filter
+func (r *postgresAuthRepository) CountUsers(ctx context.Context, filter UserFilter) (int64, error) {
+	where, args := buildUserWhere(filter)
+
+	var count int64
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &count, `SELECT COUNT(*) FROM user_auths `+where, args...); err != nil {
+		return 0, fmt.Errorf("failed to count users: %w", err)
+	}
+	return count, nil
+}
+
+// buildUserWhere returns the WHERE clause of a user filter and its arguments
+func buildUserWhere(filter UserFilter) (string, []interface{}) {
+	where := `WHERE 1=1`
+	var args []interface{}
+	add := func(condition string, value interface{}) {
+		args = append(args, value)
+		where += fmt.Sprintf(" AND "+condition, len(args))
+	}
+
+	if filter.Email != "" {
+		add(`username ILIKE $%d ESCAPE '\'`, "%"+likeEscaper.Replace(filter.Email)+"%")
+	}
+	if filter.Role != nil {
+		add(`role = $%d`, *filter.Role)
+	}
+	if filter.Verified != nil {
+		add(`(email_verified OR phone_verified) = $%d`, *filter.Verified)
+	}
+	if filter.Suspended != nil {
+		add(`suspended = $%d`, *filter.Suspended)
+	}
+	if filter.CreatedFrom != nil {
+		add(`created_date >= $%d`, *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		add(`created_date <= $%d`, *filter.CreatedTo)
+	}
+	return where, args
+}
+
+// likeEscaper escapes the LIKE wildcards in user input
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// UpdateRole changes a user's role
+func (r *postgresAuthRepository) UpdateRole(ctx context.Context, userID uuid.UUID, role string) error {
+	query := `
+		UPDATE user_auths
+		SET role = $1,
+		    updated_at = NOW(),
+		    last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT
+		WHERE id = $2`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, role, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// RevokeTokens moves the user's token cutoff forward; it never moves back
+func (r *postgresAuthRepository) RevokeTokens(ctx context.Context, userID uuid.UUID, issuedBefore int64) error {
+	query := `
+		UPDATE user_auths
+		SET tokens_valid_after = GREATEST(tokens_valid_after, $1)
+		WHERE id = $2`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, issuedBefore, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke tokens: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// FindTokenState retrieves a user's suspension and token cutoff. It reads the
+// user_token_states view, the only part of the auth schema other module roles can read.
+func (r *postgresAuthRepository) FindTokenState(ctx context.Context, userID uuid.UUID) (*TokenState, error) {
+	query := `SELECT suspended, tokens_valid_after FROM user_token_states WHERE id = $1`
+
+	var state TokenState
+	err := sqlx.GetContext(ctx, r.getExecutor(ctx), &state, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("user not found")
+		}
+		return nil, fmt.Errorf("failed to find token state: %w", err)
+	}
+	return &state, nil
+}
+
+// UpdateSuspended suspends or reinstates a user
+func (r *postgresAuthRepository) UpdateSuspended(ctx context.Context, userID uuid.UUID, suspended bool, suspendedDate int64) error {
+	if !suspended {
+		suspendedDate = 0
+	}
+	query := `
+		UPDATE user_auths
+		SET suspended = $1,
+		    suspended_date = $2,
+		    updated_at = NOW(),
+		    last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT
+		WHERE id = $3`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, suspended, suspendedDate, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update suspension: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
+// Delete deletes a user authentication record and the sessions the user signed in or acted as.
+// auth_sessions has no foreign key to user_auths, so its rows go first.
 func (r *postgresAuthRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	sessionsQuery := `DELETE FROM auth_sessions WHERE login_user_id = $1 OR identity_user_id = $1`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, sessionsQuery, userID); err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+
 	query := `DELETE FROM user_auths WHERE id = $1`
 
 	result, err := r.getExecutor(ctx).ExecContext(ctx, query, userID)
@@ -7,11 +7,16 @@ package repository
 
 import (
 	"context"
+	"errors"
 
 	uuid "github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/auth/models"
 )
 
+// ErrUsernameExists is returned by CreateUser when the username is already taken; the
+// unique constraint on user_auths.username makes it the deciding check under concurrency
+var ErrUsernameExists = errors.New("username already exists")
+
 // AuthRepository defines the interface for user authentication database operations
 // This is a domain-specific repository that knows exactly what a "UserAuth" is
 // and how to execute optimized SQL queries for that specific domain.
@@ -40,7 +45,25 @@ type AuthRepository interface {
 	// UpdatePhoneVerified updates the phone verification status
 	UpdatePhoneVerified(ctx context.Context, userID uuid.UUID, verified bool) error
 
-	// Delete deletes a user authentication record
+	// FindUsers lists users matching the filter, newest first
+	FindUsers(ctx context.Context, filter UserFilter, limit, offset int) ([]*models.UserAuth, error)
+
+	// CountUsers counts users matching the filter
+	CountUsers(ctx context.Context, filter UserFilter) (int64, error)
+
+	// UpdateRole changes a user's role
+	UpdateRole(ctx context.Context, userID uuid.UUID, role string) error
+
+	// UpdateSuspended suspends or reinstates a user; suspendedDate is stored as 0 on reinstatement
+	UpdateSuspended(ctx context.Context, userID uuid.UUID, suspended bool, suspendedDate int64) error
+
+	// RevokeTokens refuses the user's access tokens issued at or before issuedBefore (Unix seconds)
+	RevokeTokens(ctx context.Context, userID uuid.UUID, issuedBefore int64) error
+
+	// FindTokenState reads what decides whether the user's access tokens are still honoured
+	FindTokenState(ctx context.Context, userID uuid.UUID) (*TokenState, error)
+
+	// Delete deletes a user authentication record and the user's sessions
 	Delete(ctx context.Context, userID uuid.UUID) error
 
 	// WithTransaction executes a function within a database transaction
@@ -48,6 +71,22 @@ type AuthRepository interface {
 	WithTransaction(ctx context.Context, fn func(context.Context) error) error
 }
 
+// TokenState is what the auth middleware checks a signed token against on every request
+type TokenState struct {
+	Suspended        bool  `db:"suspended"`
+	TokensValidAfter int64 `db:"tokens_valid_after"` // Unix seconds; tokens issued at or before it are revoked
+}
+
+// UserFilter defines filtering criteria for listing users
+type UserFilter struct {
+	Email       string // Case-insensitive part of the username
+	Role        *string
+	Verified    *bool // Email or phone verified
+	Suspended   *bool
+	CreatedFrom *int64 // Unix seconds, inclusive
+	CreatedTo   *int64 // Unix seconds, inclusive
+}
+
 // VerificationRepository defines the interface for verification code database operations
 // This handles email/phone verification codes and password reset tokens
 type VerificationRepository interface {
@@ -92,3 +131,54 @@ type VerificationRepository interface {
 	UpdateUserID(ctx context.Context, verificationID uuid.UUID, userID uuid.UUID) error
 }
 
+
+// AccountRepository defines the interface for linked accounts and the sessions they issue
+type AccountRepository interface {
+	// LinkAccounts links two accounts; linking an already linked pair is a no-op
+	LinkAccounts(ctx context.Context, userID, otherID, linkedBy uuid.UUID, createdDate int64) error
+
+	// UnlinkAccounts removes the link between two accounts and reports whether it existed
+	UnlinkAccounts(ctx context.Context, userID, otherID uuid.UUID) (bool, error)
+
+	// AreLinked reports whether two accounts are linked
+	AreLinked(ctx context.Context, userID, otherID uuid.UUID) (bool, error)
+
+	// FindLinked returns the accounts linked to a user, oldest link first
+	FindLinked(ctx context.Context, userID uuid.UUID) ([]models.AccountLink, error)
+
+	// CreateSession records an issued session
+	CreateSession(ctx context.Context, session *models.AuthSession) error
+
+	// FindSessions returns the newest sessions a user signed in to or acts as
+	FindSessions(ctx context.Context, userID uuid.UUID, limit int) ([]*models.AuthSession, error)
+}
+
+// MFARepository defines the interface for TOTP second factors and their recovery codes
+type MFARepository interface {
+	// SavePendingSecret starts or restarts enrollment with a new secret. It returns false,
+	// leaving everything unchanged, when the user already has MFA enabled.
+	SavePendingSecret(ctx context.Context, userID uuid.UUID, secret string, createdDate int64) (bool, error)
+
+	// FindByUserID returns the user's second factor, or nil when they have none
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*models.UserMFA, error)
+
+	// Enable confirms enrollment and stores the recovery code hashes in one transaction
+	Enable(ctx context.Context, userID uuid.UUID, enabledDate int64, codeHashes []string) error
+
+	// Disable removes the second factor and its recovery codes
+	Disable(ctx context.Context, userID uuid.UUID) error
+
+	// ClaimStep records a TOTP time step as used; returns false when it, or a later
+	// step, was already used
+	ClaimStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+
+	// ReplaceRecoveryCodes discards the user's recovery codes and stores new hashes
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+
+	// UseRecoveryCode marks an unused recovery code as used; returns false when no unused
+	// code has the hash
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string, usedDate int64) (bool, error)
+
+	// CountRecoveryCodes returns how many unused recovery codes the user has left
+	CountRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
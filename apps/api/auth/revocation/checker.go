@@ -0,0 +1,99 @@
+// Package revocation tells the auth middleware when a signed token must no longer be
+// honoured: its user was suspended or deleted, or their tokens were revoked after it
+// was issued (e.g. on a role change).
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	authRepository "github.com/qolzam/telar/apps/api/auth/repository"
+)
+
+// DefaultTTL is how long a user's token state is reused before it is read again; other
+// instances see a suspension or role change at most this late
+const DefaultTTL = 10 * time.Second
+
+var (
+	ErrAccountSuspended = errors.New("account is suspended")
+	ErrAccountNotFound  = errors.New("account not found")
+	ErrTokenRevoked     = errors.New("token was revoked")
+)
+
+// StateReader reads a user's token state; the auth repository satisfies it
+type StateReader interface {
+	FindTokenState(ctx context.Context, userID uuid.UUID) (*authRepository.TokenState, error)
+}
+
+type cachedState struct {
+	state    *authRepository.TokenState // nil when the user does not exist
+	loadedAt time.Time
+}
+
+// Checker checks tokens against the user's current state, read through a short cache
+type Checker struct {
+	reader StateReader
+	ttl    time.Duration
+	now    func() time.Time
+
+	mu     sync.Mutex
+	states map[uuid.UUID]cachedState
+}
+
+// NewChecker checks tokens against states read from reader, each reused for ttl
+func NewChecker(reader StateReader, ttl time.Duration) *Checker {
+	return &Checker{reader: reader, ttl: ttl, now: time.Now, states: make(map[uuid.UUID]cachedState)}
+}
+
+// CheckToken implements authjwt.AccountChecker
+func (c *Checker) CheckToken(ctx context.Context, userID uuid.UUID, issuedAt int64) error {
+	state, err := c.state(ctx, userID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case state == nil:
+		return ErrAccountNotFound
+	case state.Suspended:
+		return ErrAccountSuspended
+	case state.TokensValidAfter > 0 && issuedAt <= state.TokensValidAfter:
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// state returns the user's token state, reading it again once the cached copy is older than the TTL
+func (c *Checker) state(ctx context.Context, userID uuid.UUID) (*authRepository.TokenState, error) {
+	now := c.now()
+	c.mu.Lock()
+	cached, ok := c.states[userID]
+	c.mu.Unlock()
+	if ok && now.Sub(cached.loadedAt) < c.ttl {
+		return cached.state, nil
+	}
+
+	state, err := c.reader.FindTokenState(ctx, userID)
+	if err != nil {
+		if err.Error() != "user not found" {
+			return nil, fmt.Errorf("read token state: %w", err)
+		}
+		state = nil
+	}
+
+	c.mu.Lock()
+	// Drop expired entries now and then so users who left do not pile up
+	if len(c.states) > 10000 {
+		for id, entry := range c.states {
+			if now.Sub(entry.loadedAt) >= c.ttl {
+				delete(c.states, id)
+			}
+		}
+	}
+	c.states[userID] = cachedState{state: state, loadedAt: now}
+	c.mu.Unlock()
+	return state, nil
+}
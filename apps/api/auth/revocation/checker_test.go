@@ -0,0 +1,63 @@
+package revocation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	authRepository "github.com/qolzam/telar/apps/api/auth/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeStates struct {
+	states map[uuid.UUID]*authRepository.TokenState
+	reads  int
+}
+
+func (f *fakeStates) FindTokenState(_ context.Context, userID uuid.UUID) (*authRepository.TokenState, error) {
+	f.reads++
+	state, ok := f.states[userID]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	copied := *state
+	return &copied, nil
+}
+
+func TestCheckToken(t *testing.T) {
+	ctx := context.Background()
+	active, suspended, demoted := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	reader := &fakeStates{states: map[uuid.UUID]*authRepository.TokenState{
+		active:    {},
+		suspended: {Suspended: true, TokensValidAfter: 1000},
+		demoted:   {TokensValidAfter: 1000},
+	}}
+	checker := NewChecker(reader, DefaultTTL)
+
+	assert.NoError(t, checker.CheckToken(ctx, active, 0), "tokens without iat stand while nothing was revoked")
+	assert.ErrorIs(t, checker.CheckToken(ctx, suspended, 2000), ErrAccountSuspended)
+	assert.ErrorIs(t, checker.CheckToken(ctx, demoted, 999), ErrTokenRevoked)
+	assert.ErrorIs(t, checker.CheckToken(ctx, demoted, 1000), ErrTokenRevoked, "tokens from the second of the revocation are refused")
+	assert.NoError(t, checker.CheckToken(ctx, demoted, 1001), "tokens issued after the revocation stand")
+	assert.ErrorIs(t, checker.CheckToken(ctx, uuid.Must(uuid.NewV4()), 2000), ErrAccountNotFound)
+}
+
+func TestCheckToken_CachesStates(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	reader := &fakeStates{states: map[uuid.UUID]*authRepository.TokenState{userID: {}}}
+	now := time.Unix(5000, 0)
+	checker := NewChecker(reader, DefaultTTL)
+	checker.now = func() time.Time { return now }
+
+	assert.NoError(t, checker.CheckToken(ctx, userID, 4000))
+	reader.states[userID].Suspended = true
+	assert.NoError(t, checker.CheckToken(ctx, userID, 4000), "the cached state is reused within the TTL")
+	assert.Equal(t, 1, reader.reads)
+
+	now = now.Add(DefaultTTL)
+	assert.ErrorIs(t, checker.CheckToken(ctx, userID, 4000), ErrAccountSuspended)
+	assert.Equal(t, 2, reader.reads)
+}
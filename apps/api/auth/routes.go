@@ -2,15 +2,19 @@ package auth
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/auth/accounts"
 	"github.com/qolzam/telar/apps/api/auth/admin"
 	"github.com/qolzam/telar/apps/api/auth/jwks"
 	"github.com/qolzam/telar/apps/api/auth/login"
+	"github.com/qolzam/telar/apps/api/auth/mfa"
 	"github.com/qolzam/telar/apps/api/auth/oauth"
 	"github.com/qolzam/telar/apps/api/auth/password"
 	"github.com/qolzam/telar/apps/api/auth/signup"
 	"github.com/qolzam/telar/apps/api/auth/verification"
+	adminmw "github.com/qolzam/telar/apps/api/internal/middleware/admin"
 	authhmac "github.com/qolzam/telar/apps/api/internal/middleware/authhmac"
 	authjwt "github.com/qolzam/telar/apps/api/internal/middleware/authjwt"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
 	"github.com/qolzam/telar/apps/api/internal/middleware/ratelimit"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/internal/types"
@@ -25,6 +29,12 @@ type AuthHandlers struct {
 	PasswordHandler *password.PasswordHandler
 	OAuthHandler    *oauth.Handler
 	JWKSHandler     *jwks.Handler
+
+	// AccountsHandler serves linked accounts and account switching; routes are skipped when nil
+	AccountsHandler *accounts.Handler
+
+	// MFAHandler serves second factor enrollment; routes are skipped when nil
+	MFAHandler *mfa.Handler
 }
 
 // NewAuthHandlers creates a new AuthHandlers with injected dependencies
@@ -89,7 +99,25 @@ func RegisterRoutes(app *fiber.App, handlers *AuthHandlers, cfg *platformconfig.
 		PublicKey:     cfg.JWT.PublicKey,
 	}
 
-	// Admin (HMAC only with rate limiting)
+	// User administration and request lookup (admins signed in with a session or HMAC).
+	// Registered before the /admin group so its HMAC-only middleware does not run for
+	// these routes.
+	adminSession := []fiber.Handler{
+		dualauth.CreateDualAuthMiddleware(dualauth.Config{
+			PayloadSecret: routerConfig.PayloadSecret,
+			PublicKey:     routerConfig.PublicKey,
+		}),
+		adminmw.New(adminmw.Config{}),
+	}
+	users := group.Group("/admin/users", adminSession...)
+	users.Get("/", handlers.AdminHandler.ListUsers)
+	users.Put("/:id/suspend", handlers.AdminHandler.SuspendUser)
+	users.Put("/:id/role", handlers.AdminHandler.UpdateUserRole)
+	users.Delete("/:id", handlers.AdminHandler.DeleteUser)
+
+	requests := group.Group("/admin/requests", adminSession...)
+	requests.Get("/:id", handlers.AdminHandler.LookupRequest)
+
 	// Admin (HMAC only with rate limiting)
 	admin := group.Group("/admin",
 		authHMACMiddleware(false, *routerConfig),
@@ -179,9 +207,48 @@ func RegisterRoutes(app *fiber.App, handlers *AuthHandlers, cfg *platformconfig.
 		),
 		handlers.LoginHandler.Handle,
 	)
+	login.Post("/mfa",
+		ratelimit.NewWithConfig(
+			cfg.RateLimits.Login.Enabled,
+			cfg.RateLimits.Login.Max,
+			cfg.RateLimits.Login.Duration,
+			"login",
+		),
+		handlers.LoginHandler.VerifyMFA,
+	)
 	login.Get("/github", handlers.LoginHandler.Github)
 	login.Get("/google", handlers.LoginHandler.Google)
 
+	// Linked accounts and account switching (authenticated)
+	if handlers.AccountsHandler != nil {
+		loginLimiter := ratelimit.NewWithConfig(
+			cfg.RateLimits.Login.Enabled,
+			cfg.RateLimits.Login.Max,
+			cfg.RateLimits.Login.Duration,
+			"account switching",
+		)
+		group.Get("/accounts", authJWTMiddleware(*routerConfig), handlers.AccountsHandler.List)
+		group.Post("/accounts/link", authJWTMiddleware(*routerConfig), loginLimiter, handlers.AccountsHandler.Link)
+		group.Delete("/accounts/:accountId", authJWTMiddleware(*routerConfig), handlers.AccountsHandler.Unlink)
+		group.Post("/switch/:accountId", authJWTMiddleware(*routerConfig), loginLimiter, handlers.AccountsHandler.Switch)
+		group.Get("/sessions", authJWTMiddleware(*routerConfig), handlers.AccountsHandler.Sessions)
+	}
+
+	// Second factor enrollment (authenticated)
+	if handlers.MFAHandler != nil {
+		mfaLimiter := ratelimit.NewWithConfig(
+			cfg.RateLimits.Login.Enabled,
+			cfg.RateLimits.Login.Max,
+			cfg.RateLimits.Login.Duration,
+			"mfa",
+		)
+		group.Get("/mfa", authJWTMiddleware(*routerConfig), handlers.MFAHandler.Status)
+		group.Post("/mfa/setup", authJWTMiddleware(*routerConfig), mfaLimiter, handlers.MFAHandler.Setup)
+		group.Post("/mfa/enable", authJWTMiddleware(*routerConfig), mfaLimiter, handlers.MFAHandler.Enable)
+		group.Post("/mfa/disable", authJWTMiddleware(*routerConfig), mfaLimiter, handlers.MFAHandler.Disable)
+		group.Post("/mfa/recovery-codes", authJWTMiddleware(*routerConfig), mfaLimiter, handlers.MFAHandler.RecoveryCodes)
+	}
+
 	group.Get("/oauth2/authorized", handlers.OAuthHandler.Authorized) // OAuth callbacks don't need rate limiting
 
 	// JWKS endpoint (public, no authentication required)
@@ -0,0 +1,60 @@
+package signup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Email domain lists, as labelled in the metrics
+const (
+	listAllow = "allow"
+	listDeny  = "deny"
+)
+
+// WithEmailDomains screens email signups against the deployment's allowed and denied
+// domains; without a provider every domain can sign up.
+func (s *Service) WithEmailDomains(provider sharedInterfaces.EmailDomainRulesProvider) *Service {
+	s.emailDomains = provider
+	return s
+}
+
+// checkEmailDomain rejects an email whose domain is denied, or missing from a non-empty
+// allow list. Each list consulted is counted in the metrics.
+func (s *Service) checkEmailDomain(ctx context.Context, email string) error {
+	if s.emailDomains == nil {
+		return nil
+	}
+	rules := s.emailDomains.EmailDomainRules(ctx)
+	domain := strings.ToLower(strings.TrimSpace(email[strings.LastIndexByte(email, '@')+1:]))
+
+	if len(rules.Deny) > 0 {
+		if matchesDomain(domain, rules.Deny) {
+			metrics.Default().ObserveEmailDomainCheck(listDeny, metrics.EmailDomainRejected)
+			return fmt.Errorf("%w: %s is denied", errors.ErrEmailDomainDenied, domain)
+		}
+		metrics.Default().ObserveEmailDomainCheck(listDeny, metrics.EmailDomainAllowed)
+	}
+	if len(rules.Allow) > 0 {
+		if !matchesDomain(domain, rules.Allow) {
+			metrics.Default().ObserveEmailDomainCheck(listAllow, metrics.EmailDomainRejected)
+			return fmt.Errorf("%w: %s is not on the allow list", errors.ErrEmailDomainDenied, domain)
+		}
+		metrics.Default().ObserveEmailDomainCheck(listAllow, metrics.EmailDomainAllowed)
+	}
+	return nil
+}
+
+// matchesDomain reports whether domain is one of entries or a subdomain of one
+func matchesDomain(domain string, entries []string) bool {
+	for _, entry := range entries {
+		if domain == entry || strings.HasSuffix(domain, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,69 @@
+package signup
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/auth/errors"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// staticEmailDomains serves fixed email domain lists
+type staticEmailDomains sharedInterfaces.EmailDomainRules
+
+func (r staticEmailDomains) EmailDomainRules(ctx context.Context) sharedInterfaces.EmailDomainRules {
+	return sharedInterfaces.EmailDomainRules(r)
+}
+
+func TestCheckEmailDomain(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("admits every domain without lists", func(t *testing.T) {
+		svc := NewService(nil, &ServiceConfig{}).WithEmailDomains(staticEmailDomains{})
+		assert.NoError(t, svc.checkEmailDomain(ctx, "jane@anywhere.io"))
+	})
+
+	t.Run("admits only allowed domains and their subdomains", func(t *testing.T) {
+		svc := NewService(nil, &ServiceConfig{}).WithEmailDomains(staticEmailDomains{Allow: []string{"example.com"}})
+		assert.NoError(t, svc.checkEmailDomain(ctx, "jane@example.com"))
+		assert.NoError(t, svc.checkEmailDomain(ctx, "jane@Mail.Example.com"))
+		assert.ErrorIs(t, svc.checkEmailDomain(ctx, "jane@notexample.com"), errors.ErrEmailDomainDenied)
+	})
+
+	t.Run("denies listed domains even when allowed", func(t *testing.T) {
+		svc := NewService(nil, &ServiceConfig{}).WithEmailDomains(staticEmailDomains{
+			Allow: []string{"example.com"},
+			Deny:  []string{"contractors.example.com"},
+		})
+		assert.ErrorIs(t, svc.checkEmailDomain(ctx, "jo@contractors.example.com"), errors.ErrEmailDomainDenied)
+	})
+}
+
+func TestInitiateEmailVerification_DeniedDomain(t *testing.T) {
+	m := metrics.New(nil)
+	metrics.SetDefault(m)
+	t.Cleanup(func() { metrics.SetDefault(nil) })
+
+	svc := NewService(nil, &ServiceConfig{}).WithEmailDomains(staticEmailDomains{Deny: []string{"mailinator.com"}})
+	_, err := svc.InitiateEmailVerification(context.Background(), EmailVerificationRequest{
+		UserId:       uuid.Must(uuid.NewV4()),
+		EmailTo:      "throwaway@mailinator.com",
+		UserPassword: "correct horse battery staple",
+	})
+	assert.ErrorIs(t, err, errors.ErrEmailDomainDenied)
+
+	families, err := m.Registry().Gather()
+	require.NoError(t, err)
+	var rejected float64
+	for _, family := range families {
+		if family.GetName() == "signup_email_domain_checks_total" {
+			rejected = family.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	assert.Equal(t, 1.0, rejected)
+}
@@ -14,6 +14,7 @@ import (
 	platformemail "github.com/qolzam/telar/apps/api/internal/platform/email"
 
 	"github.com/qolzam/telar/apps/api/internal/utils"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -53,7 +54,8 @@ type PhoneVerificationResponse struct {
 type Service struct {
 	verificationRepo repository.VerificationRepository
 	config           *ServiceConfig
-	emailSender      platformemail.Sender // optional; if nil, no email is sent
+	emailSender      platformemail.Sender                      // optional; if nil, no email is sent
+	emailDomains     sharedInterfaces.EmailDomainRulesProvider // optional; if nil, every domain can sign up
 }
 
 type ServiceConfig struct {
@@ -94,6 +96,19 @@ func (s *Service) InitiateEmailVerification(ctx context.Context, input EmailVeri
 		Details:   fmt.Sprintf("Email signup attempt for %s", input.EmailTo),
 	})
 
+	if err := s.checkEmailDomain(ctx, input.EmailTo); err != nil {
+		security.LogSecurityEvent(security.SecurityEvent{
+			EventType: security.EventTypeSignupFailure,
+			UserID:    input.UserId.String(),
+			IPAddress: input.RemoteIpAddress,
+			UserAgent: input.UserAgent,
+			Success:   false,
+			ErrorCode: errors.CodeEmailDomainDenied,
+			Details:   err.Error(),
+		})
+		return nil, err
+	}
+
 	// Generate secure verification ID
 	verifyId := uuid.Must(uuid.NewV4())
 
@@ -13,6 +13,7 @@ import (
 	"github.com/qolzam/telar/apps/api/auth/verification"
 	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // Enhanced security validation configuration
@@ -320,9 +321,8 @@ func ValidateAndSanitizeSocialName(socialName string) (*SecurityValidationResult
 		return result, nil
 	}
 
-	// 6. Check for reserved words
-	reservedWords := []string{"admin", "root", "system", "api", "www", "mail", "ftp", "null", "undefined"}
-	for _, word := range reservedWords {
+	// 6. Check for reserved words; the profile service also applies the names admins reserved
+	for _, word := range sharedInterfaces.DefaultSocialNameRules.Reserved {
 		if sanitized == word {
 			result.IsValid = false
 			result.Errors = append(result.Errors, "socialName cannot be a reserved word")
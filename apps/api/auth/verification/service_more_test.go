@@ -37,6 +37,10 @@ func (m *mockProfileCreator) GetProfilesByIds(ctx context.Context, userIds []uui
 	return nil, nil
 }
 
+func (m *mockProfileCreator) GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*profileModels.Profile, error) {
+	return nil, nil
+}
+
 func TestVerificationService_SuccessBranch_Coverage(t *testing.T) {
 	if !testutil.ShouldRunDatabaseTests() {
 		t.Skip("set RUN_DB_TESTS=1 to run database tests")
@@ -31,6 +31,10 @@ func (m *mockProfileCreatorForTest) GetProfilesByIds(ctx context.Context, userId
 	return nil, nil
 }
 
+func (m *mockProfileCreatorForTest) GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*profileModels.Profile, error) {
+	return nil, nil
+}
+
 func TestVerificationService_All_Coverage(t *testing.T) {
 	if !testutil.ShouldRunDatabaseTests() {
 		t.Skip("set RUN_DB_TESTS=1 to run database tests")
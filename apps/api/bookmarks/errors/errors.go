@@ -13,6 +13,9 @@ var (
 	ErrInvalidUUID        = errors.New("invalid uuid")
 	ErrMissingUserContext = errors.New("missing user context")
 	ErrDatabaseOperation  = errors.New("database operation failed")
+	ErrCollectionNotFound = errors.New("bookmark collection not found")
+	ErrCollectionExists   = errors.New("bookmark collection already exists")
+	ErrCollectionLimit    = errors.New("bookmark collection limit reached")
 )
 
 const (
@@ -20,6 +23,9 @@ const (
 	CodeInvalidUUID    = "INVALID_UUID"
 	CodeMissingUserCtx = "MISSING_USER_CONTEXT"
 	CodeDatabaseError  = "DATABASE_ERROR"
+	CodeNotFound       = "COLLECTION_NOT_FOUND"
+	CodeConflict       = "COLLECTION_EXISTS"
+	CodeLimitReached   = "COLLECTION_LIMIT_REACHED"
 	CodeInternalError  = "INTERNAL_ERROR"
 )
 
@@ -41,6 +47,12 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidUUID, Message: err.Error(), Details: err.Error()})
 	case errors.Is(err, ErrMissingUserContext):
 		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrCollectionNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrCollectionExists):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeConflict, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrCollectionLimit):
+		return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponse{Code: CodeLimitReached, Message: err.Error(), Details: err.Error()})
 	case errors.Is(err, ErrDatabaseOperation):
 		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeDatabaseError, Message: err.Error(), Details: err.Error()})
 	default:
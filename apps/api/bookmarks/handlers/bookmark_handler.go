@@ -9,6 +9,7 @@ import (
 	"github.com/qolzam/telar/apps/api/bookmarks/errors"
 	"github.com/qolzam/telar/apps/api/bookmarks/services"
 	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/models"
 )
 
 type BookmarkHandler struct {
@@ -45,8 +46,9 @@ func (h *BookmarkHandler) Toggle(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(fiber.Map{"isBookmarked": bookmarked})
 }
 
-// List returns bookmarked posts for the current user with cursor pagination.
-// Endpoint: GET /bookmarks?cursor=...&limit=...
+// List returns bookmarked posts for the current user with cursor pagination,
+// optionally restricted to one of their collections.
+// Endpoint: GET /bookmarks?collection=...&cursor=...&limit=...
 func (h *BookmarkHandler) List(c *fiber.Ctx) error {
 	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
 	if !ok {
@@ -63,10 +65,146 @@ func (h *BookmarkHandler) List(c *fiber.Ctx) error {
 	}
 
 	ctxWithUser := context.WithValue(c.Context(), types.UserCtxName, user)
-	resp, err := h.service.ListBookmarks(ctxWithUser, user.UserID, cursor, limit)
+	var resp *models.PostsListResponse
+	var err error
+	if collectionParam := c.Query("collection"); collectionParam != "" {
+		collectionID, parseErr := uuid.FromString(collectionParam)
+		if parseErr != nil {
+			return errors.HandleUUIDError(c, "collection")
+		}
+		resp, err = h.service.ListCollectionBookmarks(ctxWithUser, user.UserID, collectionID, cursor, limit)
+	} else {
+		resp, err = h.service.ListBookmarks(ctxWithUser, user.UserID, cursor, limit)
+	}
 	if err != nil {
 		return errors.HandleServiceError(c, err)
 	}
 
 	return c.Status(http.StatusOK).JSON(resp)
 }
+
+// CollectionRequest is the body of collection create and rename requests.
+type CollectionRequest struct {
+	Name string `json:"name"`
+}
+
+// PostCollectionsRequest lists the collections a bookmarked post is filed in.
+type PostCollectionsRequest struct {
+	CollectionIDs []string `json:"collectionIds"`
+}
+
+// ListCollections returns the current user's collections.
+// Endpoint: GET /bookmarks/collections
+func (h *BookmarkHandler) ListCollections(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	collections, err := h.service.ListCollections(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"collections": collections})
+}
+
+// CreateCollection adds a collection for the current user.
+// Endpoint: POST /bookmarks/collections
+func (h *BookmarkHandler) CreateCollection(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	collection, err := h.service.CreateCollection(c.Context(), user.UserID, req.Name)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(collection)
+}
+
+// RenameCollection renames one of the current user's collections.
+// Endpoint: PUT /bookmarks/collections/:collectionId
+func (h *BookmarkHandler) RenameCollection(c *fiber.Ctx) error {
+	collectionID, err := uuid.FromString(c.Params("collectionId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "collectionId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req CollectionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	if err := h.service.RenameCollection(c.Context(), user.UserID, collectionID, req.Name); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// DeleteCollection removes one of the current user's collections; its bookmarks stay.
+// Endpoint: DELETE /bookmarks/collections/:collectionId
+func (h *BookmarkHandler) DeleteCollection(c *fiber.Ctx) error {
+	collectionID, err := uuid.FromString(c.Params("collectionId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "collectionId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	if err := h.service.DeleteCollection(c.Context(), user.UserID, collectionID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// SetPostCollections bookmarks a post and files it in the given collections.
+// Endpoint: PUT /bookmarks/:postId/collections
+func (h *BookmarkHandler) SetPostCollections(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req PostCollectionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	collectionIDs := make([]uuid.UUID, 0, len(req.CollectionIDs))
+	for _, raw := range req.CollectionIDs {
+		id, err := uuid.FromString(raw)
+		if err != nil {
+			return errors.HandleUUIDError(c, "collectionIds")
+		}
+		collectionIDs = append(collectionIDs, id)
+	}
+
+	if err := h.service.SetPostCollections(c.Context(), user.UserID, postID, collectionIDs); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,25 @@
+-- Bookmark collections: named groups a user files bookmarks into
+CREATE TABLE IF NOT EXISTS bookmark_collections (
+    id UUID PRIMARY KEY,
+    owner_user_id UUID NOT NULL REFERENCES user_auths(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Names are unique per user regardless of case
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmark_collections_owner_name ON bookmark_collections(owner_user_id, lower(name));
+
+-- A bookmark can be filed in several collections. Removing the bookmark or the
+-- collection removes the filing; removing a collection keeps the bookmarks.
+CREATE TABLE IF NOT EXISTS bookmark_collection_items (
+    collection_id UUID NOT NULL REFERENCES bookmark_collections(id) ON DELETE CASCADE,
+    owner_user_id UUID NOT NULL,
+    post_id UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    PRIMARY KEY (collection_id, post_id),
+    FOREIGN KEY (owner_user_id, post_id) REFERENCES bookmarks(owner_user_id, post_id) ON DELETE CASCADE
+);
+
+-- Collection names shown on a user's posts
+CREATE INDEX IF NOT EXISTS idx_bookmark_collection_items_owner_post ON bookmark_collection_items(owner_user_id, post_id);
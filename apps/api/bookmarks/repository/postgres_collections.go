@@ -0,0 +1,251 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+func (r *postgresRepository) FindCollectionBookmarks(ctx context.Context, userID, collectionID uuid.UUID, cursor string, limit int) ([]BookmarkEntry, string, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	// Ordered by bookmark time so collection pages match the main list
+	query := `
+		SELECT b.post_id, b.created_at
+		FROM %[1]sbookmark_collection_items i
+		JOIN %[1]sbookmarks b ON b.owner_user_id = i.owner_user_id AND b.post_id = i.post_id
+		WHERE i.collection_id = $1 AND i.owner_user_id = $2
+	`
+	args := []interface{}{collectionID, userID}
+	if cursor != "" {
+		decoded, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid cursor: %w", err)
+		}
+		query += ` AND (b.created_at, b.post_id) < ($3, $4)`
+		args = append(args, decoded.CreatedAt, decoded.PostID)
+	}
+	query += fmt.Sprintf(` ORDER BY b.created_at DESC, b.post_id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit+1)
+
+	var rows []BookmarkEntry
+	sqlStr := fmt.Sprintf(query, r.schemaPrefix())
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, sqlStr, args...); err != nil {
+		return nil, "", fmt.Errorf("find collection bookmarks: %w", err)
+	}
+
+	nextCursor := ""
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[len(rows)-1]
+		nextCursor = encodeCursor(last.CreatedAt, last.PostID)
+	}
+
+	return rows, nextCursor, nil
+}
+
+func (r *postgresRepository) CreateCollection(ctx context.Context, collection *Collection) error {
+	query := `
+		INSERT INTO %sbookmark_collections (id, owner_user_id, name, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	sqlStr := r.prefixSchema(query)
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, sqlStr, collection.ID, collection.OwnerUserID, collection.Name, collection.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return ErrCollectionExists
+		}
+		return fmt.Errorf("insert bookmark collection: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) RenameCollection(ctx context.Context, userID, collectionID uuid.UUID, name string) error {
+	query := `
+		UPDATE %sbookmark_collections
+		SET name = $3
+		WHERE id = $1 AND owner_user_id = $2
+	`
+
+	sqlStr := r.prefixSchema(query)
+	result, err := r.getExecutor(ctx).ExecContext(ctx, sqlStr, collectionID, userID, name)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrCollectionExists
+		}
+		return fmt.Errorf("rename bookmark collection: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteCollection(ctx context.Context, userID, collectionID uuid.UUID) (bool, error) {
+	query := `
+		DELETE FROM %sbookmark_collections
+		WHERE id = $1 AND owner_user_id = $2
+	`
+
+	sqlStr := r.prefixSchema(query)
+	result, err := r.getExecutor(ctx).ExecContext(ctx, sqlStr, collectionID, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete bookmark collection: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) ListCollections(ctx context.Context, userID uuid.UUID) ([]Collection, error) {
+	query := `
+		SELECT c.id, c.owner_user_id, c.name, c.created_at, COUNT(i.post_id) AS bookmark_count
+		FROM %[1]sbookmark_collections c
+		LEFT JOIN %[1]sbookmark_collection_items i ON i.collection_id = c.id
+		WHERE c.owner_user_id = $1
+		GROUP BY c.id
+		ORDER BY lower(c.name), c.id
+	`
+
+	collections := []Collection{}
+	sqlStr := fmt.Sprintf(query, r.schemaPrefix())
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &collections, sqlStr, userID); err != nil {
+		return nil, fmt.Errorf("list bookmark collections: %w", err)
+	}
+	return collections, nil
+}
+
+func (r *postgresRepository) SetPostCollections(ctx context.Context, userID, postID uuid.UUID, collectionIDs []uuid.UUID) error {
+	idStrings := make([]string, len(collectionIDs))
+	for i, id := range collectionIDs {
+		idStrings[i] = id.String()
+	}
+
+	return r.inTransaction(ctx, func(txCtx context.Context) error {
+		exec := r.getExecutor(txCtx)
+
+		if len(collectionIDs) > 0 {
+			var owned int
+			countQuery := r.prefixSchema(`
+				SELECT COUNT(*)
+				FROM %sbookmark_collections
+				WHERE owner_user_id = $1 AND id = ANY($2::uuid[])
+			`)
+			if err := sqlx.GetContext(txCtx, exec, &owned, countQuery, userID, pq.Array(idStrings)); err != nil {
+				return fmt.Errorf("check bookmark collections: %w", err)
+			}
+			if owned != len(collectionIDs) {
+				return ErrCollectionNotFound
+			}
+
+			if _, err := r.AddBookmark(txCtx, userID, postID); err != nil {
+				return err
+			}
+		}
+
+		deleteQuery := r.prefixSchema(`
+			DELETE FROM %sbookmark_collection_items
+			WHERE owner_user_id = $1 AND post_id = $2 AND NOT (collection_id = ANY($3::uuid[]))
+		`)
+		if _, err := exec.ExecContext(txCtx, deleteQuery, userID, postID, pq.Array(idStrings)); err != nil {
+			return fmt.Errorf("unlink bookmark collections: %w", err)
+		}
+
+		if len(collectionIDs) == 0 {
+			return nil
+		}
+		insertQuery := r.prefixSchema(`
+			INSERT INTO %sbookmark_collection_items (collection_id, owner_user_id, post_id)
+			SELECT unnest($3::uuid[]), $1, $2
+			ON CONFLICT DO NOTHING
+		`)
+		if _, err := exec.ExecContext(txCtx, insertQuery, userID, postID, pq.Array(idStrings)); err != nil {
+			return fmt.Errorf("link bookmark collections: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *postgresRepository) GetCollectionNames(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	if len(postIDs) == 0 {
+		return map[uuid.UUID][]string{}, nil
+	}
+
+	idStrings := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		idStrings[i] = id.String()
+	}
+
+	query := `
+		SELECT i.post_id, c.name
+		FROM %[1]sbookmark_collection_items i
+		JOIN %[1]sbookmark_collections c ON c.id = i.collection_id
+		WHERE i.owner_user_id = $1 AND i.post_id = ANY($2::uuid[])
+		ORDER BY lower(c.name)
+	`
+
+	var rows []struct {
+		PostID uuid.UUID `db:"post_id"`
+		Name   string    `db:"name"`
+	}
+	sqlStr := fmt.Sprintf(query, r.schemaPrefix())
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, sqlStr, userID, pq.Array(idStrings)); err != nil {
+		return nil, fmt.Errorf("get bookmark collection names: %w", err)
+	}
+
+	names := make(map[uuid.UUID][]string)
+	for _, row := range rows {
+		names[row.PostID] = append(names[row.PostID], row.Name)
+	}
+	return names, nil
+}
+
+// WithTransaction runs fn in the transaction carried by ctx, or in a new one
+func (r *postgresRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return r.inTransaction(ctx, fn)
+}
+
+// inTransaction runs fn in the transaction carried by ctx, or in a new one
+func (r *postgresRepository) inTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.client.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("transaction error: %w, rollback error: %v", err, rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505" // unique_violation
+}
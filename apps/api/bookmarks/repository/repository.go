@@ -2,11 +2,18 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	uuid "github.com/gofrs/uuid"
 )
 
+// Errors returned by the collection methods.
+var (
+	ErrCollectionNotFound = errors.New("bookmark collection not found")
+	ErrCollectionExists   = errors.New("bookmark collection already exists")
+)
+
 // Repository defines data access for bookmarks.
 type Repository interface {
 	// AddBookmark stores a bookmark; returns true when a new row was inserted.
@@ -20,6 +27,47 @@ type Repository interface {
 
 	// FindMyBookmarks returns ordered bookmark entries with cursor pagination.
 	FindMyBookmarks(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]BookmarkEntry, string, error)
+
+	// FindCollectionBookmarks pages through the bookmarks filed in one of the user's
+	// collections, in the same order and cursor format as FindMyBookmarks.
+	FindCollectionBookmarks(ctx context.Context, userID, collectionID uuid.UUID, cursor string, limit int) ([]BookmarkEntry, string, error)
+
+	// CreateCollection stores a new collection; returns ErrCollectionExists when the
+	// user already has one with the same name (case-insensitive).
+	CreateCollection(ctx context.Context, collection *Collection) error
+
+	// RenameCollection renames one of the user's collections; returns
+	// ErrCollectionNotFound or ErrCollectionExists.
+	RenameCollection(ctx context.Context, userID, collectionID uuid.UUID, name string) error
+
+	// DeleteCollection removes one of the user's collections. Its bookmarks stay.
+	// Returns true when a row was deleted.
+	DeleteCollection(ctx context.Context, userID, collectionID uuid.UUID) (bool, error)
+
+	// ListCollections returns the user's collections by name, with bookmark counts.
+	ListCollections(ctx context.Context, userID uuid.UUID) ([]Collection, error)
+
+	// SetPostCollections bookmarks the post if needed and files it in exactly the given
+	// collections, in one transaction. Returns ErrCollectionNotFound, changing nothing,
+	// when any of them is not the user's.
+	SetPostCollections(ctx context.Context, userID, postID uuid.UUID, collectionIDs []uuid.UUID) error
+
+	// GetCollectionNames returns, for each of the posts filed in the user's collections,
+	// the names of those collections. Posts in none are absent.
+	GetCollectionNames(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+
+	// WithTransaction runs fn in the transaction carried by ctx, or in a new one that
+	// commits when fn succeeds.
+	WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error
+}
+
+// Collection is a named group of a user's bookmarks.
+type Collection struct {
+	ID            uuid.UUID `db:"id" json:"objectId"`
+	OwnerUserID   uuid.UUID `db:"owner_user_id" json:"ownerUserId"`
+	Name          string    `db:"name" json:"name"`
+	BookmarkCount int       `db:"bookmark_count" json:"bookmarkCount"`
+	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
 }
 
 // BookmarkEntry is a lightweight projection of a bookmark row.
@@ -57,6 +57,23 @@ func TestPostgresRepository_Integration(t *testing.T) {
 			PRIMARY KEY (owner_user_id, post_id)
 		);
 		CREATE INDEX IF NOT EXISTS idx_bookmarks_owner_created ON bookmarks(owner_user_id, created_at DESC);
+
+		CREATE TABLE IF NOT EXISTS bookmark_collections (
+			id UUID PRIMARY KEY,
+			owner_user_id UUID NOT NULL REFERENCES user_auths(id) ON DELETE CASCADE,
+			name TEXT NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		);
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_bookmark_collections_owner_name ON bookmark_collections(owner_user_id, lower(name));
+
+		CREATE TABLE IF NOT EXISTS bookmark_collection_items (
+			collection_id UUID NOT NULL REFERENCES bookmark_collections(id) ON DELETE CASCADE,
+			owner_user_id UUID NOT NULL,
+			post_id UUID NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (collection_id, post_id),
+			FOREIGN KEY (owner_user_id, post_id) REFERENCES bookmarks(owner_user_id, post_id) ON DELETE CASCADE
+		);
 	`
 	_, err = client.DB().ExecContext(ctx, migrationSQL)
 	require.NoError(t, err)
@@ -142,4 +159,43 @@ func TestPostgresRepository_Integration(t *testing.T) {
 			require.False(t, firstPageIDs[entry.PostID], "Second page should not contain items from first page")
 		}
 	})
+
+	t.Run("Collections", func(t *testing.T) {
+		reading := &Collection{ID: uuid.Must(uuid.NewV4()), OwnerUserID: userID, Name: "Reading", CreatedAt: time.Now().UTC()}
+		require.NoError(t, repo.CreateCollection(ctx, reading))
+
+		duplicate := &Collection{ID: uuid.Must(uuid.NewV4()), OwnerUserID: userID, Name: "reading", CreatedAt: time.Now().UTC()}
+		require.ErrorIs(t, repo.CreateCollection(ctx, duplicate), ErrCollectionExists)
+
+		// Filing a post bookmarks it
+		require.NoError(t, repo.SetPostCollections(ctx, userID, postID, []uuid.UUID{reading.ID}))
+		bookmarked, err := repo.GetMapByUserAndPosts(ctx, userID, []uuid.UUID{postID})
+		require.NoError(t, err)
+		require.True(t, bookmarked[postID])
+
+		names, err := repo.GetCollectionNames(ctx, userID, []uuid.UUID{postID, otherPostID})
+		require.NoError(t, err)
+		require.Equal(t, []string{"Reading"}, names[postID])
+		require.NotContains(t, names, otherPostID)
+
+		entries, _, err := repo.FindCollectionBookmarks(ctx, userID, reading.ID, "", 10)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		require.Equal(t, postID, entries[0].PostID)
+
+		require.ErrorIs(t, repo.SetPostCollections(ctx, userID, postID, []uuid.UUID{uuid.Must(uuid.NewV4())}), ErrCollectionNotFound)
+
+		collections, err := repo.ListCollections(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, collections, 1)
+		require.Equal(t, 1, collections[0].BookmarkCount)
+
+		// Deleting the collection keeps the bookmark
+		deleted, err := repo.DeleteCollection(ctx, userID, reading.ID)
+		require.NoError(t, err)
+		require.True(t, deleted)
+		bookmarked, err = repo.GetMapByUserAndPosts(ctx, userID, []uuid.UUID{postID})
+		require.NoError(t, err)
+		require.True(t, bookmarked[postID])
+	})
 }
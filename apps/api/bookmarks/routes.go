@@ -35,6 +35,12 @@ func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Conf
 	group := app.Group("/bookmarks")
 	userGroup := group.Group("", dualAuthMiddleware)
 
+	userGroup.Get("/collections", handlers.BookmarkHandler.ListCollections)
+	userGroup.Post("/collections", handlers.BookmarkHandler.CreateCollection)
+	userGroup.Put("/collections/:collectionId", handlers.BookmarkHandler.RenameCollection)
+	userGroup.Delete("/collections/:collectionId", handlers.BookmarkHandler.DeleteCollection)
+
 	userGroup.Post("/:postId/toggle", handlers.BookmarkHandler.Toggle)
+	userGroup.Put("/:postId/collections", handlers.BookmarkHandler.SetPostCollections)
 	userGroup.Get("/", handlers.BookmarkHandler.List)
 }
@@ -37,3 +37,49 @@ func (m *MockRepository) FindMyBookmarks(ctx context.Context, userID uuid.UUID,
 	args := m.Called(ctx, userID, cursor, limit)
 	return args.Get(0).([]repository.BookmarkEntry), args.String(1), args.Error(2)
 }
+
+func (m *MockRepository) FindCollectionBookmarks(ctx context.Context, userID, collectionID uuid.UUID, cursor string, limit int) ([]repository.BookmarkEntry, string, error) {
+	args := m.Called(ctx, userID, collectionID, cursor, limit)
+	return args.Get(0).([]repository.BookmarkEntry), args.String(1), args.Error(2)
+}
+
+func (m *MockRepository) CreateCollection(ctx context.Context, collection *repository.Collection) error {
+	args := m.Called(ctx, collection)
+	return args.Error(0)
+}
+
+func (m *MockRepository) RenameCollection(ctx context.Context, userID, collectionID uuid.UUID, name string) error {
+	args := m.Called(ctx, userID, collectionID, name)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteCollection(ctx context.Context, userID, collectionID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID, collectionID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ListCollections(ctx context.Context, userID uuid.UUID) ([]repository.Collection, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repository.Collection), args.Error(1)
+}
+
+func (m *MockRepository) SetPostCollections(ctx context.Context, userID, postID uuid.UUID, collectionIDs []uuid.UUID) error {
+	args := m.Called(ctx, userID, postID, collectionIDs)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetCollectionNames(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	args := m.Called(ctx, userID, postIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]string), args.Error(1)
+}
+
+// WithTransaction runs fn with ctx unchanged, so expectations set on ctx still match.
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(txCtx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetEventOutbox makes bookmarks announce their addition and removal through the
+// transactional outbox, which the sync endpoint reads to bring offline clients up to date
+func (s *service) SetEventOutbox(outbox sharedInterfaces.EventOutbox) {
+	s.outbox = outbox
+}
+
+// recordBookmarkChanged runs in the transaction adding or removing the user's bookmark
+// of a post
+func (s *service) recordBookmarkChanged(txCtx context.Context, userID, postID uuid.UUID, added bool) error {
+	if s.outbox == nil {
+		return nil
+	}
+	eventType := sharedInterfaces.OutboxBookmarkRemoved
+	if added {
+		eventType = sharedInterfaces.OutboxBookmarkAdded
+	}
+	return s.outbox.Append(txCtx, eventType, userID.String(), sharedInterfaces.BookmarkChangedEvent{
+		UserId: userID,
+		PostId: postID,
+	})
+}
@@ -2,11 +2,17 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	uuid "github.com/gofrs/uuid"
+	bookmarkErrors "github.com/qolzam/telar/apps/api/bookmarks/errors"
 	"github.com/qolzam/telar/apps/api/bookmarks/repository"
 	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // Service defines bookmark operations.
@@ -16,17 +22,48 @@ type Service interface {
 
 	// ListBookmarks returns hydrated posts bookmarked by the user with cursor pagination.
 	ListBookmarks(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.PostsListResponse, error)
+
+	// ListCollectionBookmarks is ListBookmarks restricted to one of the user's collections.
+	ListCollectionBookmarks(ctx context.Context, userID, collectionID uuid.UUID, cursor string, limit int) (*models.PostsListResponse, error)
+
+	// CreateCollection adds a named collection for the user.
+	CreateCollection(ctx context.Context, userID uuid.UUID, name string) (*repository.Collection, error)
+
+	// RenameCollection renames one of the user's collections.
+	RenameCollection(ctx context.Context, userID, collectionID uuid.UUID, name string) error
+
+	// DeleteCollection removes one of the user's collections, keeping its bookmarks.
+	DeleteCollection(ctx context.Context, userID, collectionID uuid.UUID) error
+
+	// ListCollections returns the user's collections with their bookmark counts.
+	ListCollections(ctx context.Context, userID uuid.UUID) ([]repository.Collection, error)
+
+	// SetPostCollections bookmarks a post and files it in exactly the given collections.
+	// An empty list takes the post out of every collection and keeps the bookmark.
+	SetPostCollections(ctx context.Context, userID, postID uuid.UUID, collectionIDs []uuid.UUID) error
+
+	// SetEventOutbox writes bookmark added and removed events to the outbox in the
+	// bookmark's transaction. Without one, bookmark changes are not announced.
+	SetEventOutbox(outbox sharedInterfaces.EventOutbox)
 }
 
+// Collection limits
+const (
+	maxCollectionsPerUser   = 50
+	maxCollectionNameLength = 60
+)
+
 type service struct {
 	repo        repository.Repository
 	postService postProvider
+	outbox      sharedInterfaces.EventOutbox // nil until SetEventOutbox
 }
 
 // postProvider captures the subset of PostService we need to hydrate responses.
 type postProvider interface {
 	GetPostsByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error)
 	ConvertPostToResponse(ctx context.Context, post *models.Post) models.PostResponse
+	ApplySupporterAccess(ctx context.Context, posts []models.PostResponse)
 }
 
 // NewService constructs a bookmark service.
@@ -39,32 +76,55 @@ func (s *service) ToggleBookmark(ctx context.Context, userID, postID uuid.UUID)
 		return false, fmt.Errorf("bookmark repository is not configured")
 	}
 
-	created, err := s.repo.AddBookmark(ctx, userID, postID)
+	var bookmarked bool
+	err := s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err := s.repo.AddBookmark(txCtx, userID, postID)
+		if err != nil {
+			return fmt.Errorf("add bookmark: %w", err)
+		}
+		if !created {
+			// Already existed; remove to toggle off.
+			if _, err := s.repo.RemoveBookmark(txCtx, userID, postID); err != nil {
+				return fmt.Errorf("remove bookmark: %w", err)
+			}
+		}
+		bookmarked = created
+		return s.recordBookmarkChanged(txCtx, userID, postID, created)
+	})
 	if err != nil {
-		return false, fmt.Errorf("add bookmark: %w", err)
+		return false, err
 	}
-	if created {
-		return true, nil
+	return bookmarked, nil
+}
+
+func (s *service) ListBookmarks(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.PostsListResponse, error) {
+	if s.repo == nil || s.postService == nil {
+		return nil, fmt.Errorf("bookmark service dependencies are not configured")
 	}
 
-	// Already existed; remove to toggle off.
-	_, err = s.repo.RemoveBookmark(ctx, userID, postID)
+	entries, nextCursor, err := s.repo.FindMyBookmarks(ctx, userID, cursor, limit)
 	if err != nil {
-		return false, fmt.Errorf("remove bookmark: %w", err)
+		return nil, fmt.Errorf("find bookmarks: %w", err)
 	}
-	return false, nil
+
+	return s.hydrate(ctx, userID, entries, nextCursor)
 }
 
-func (s *service) ListBookmarks(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.PostsListResponse, error) {
+func (s *service) ListCollectionBookmarks(ctx context.Context, userID, collectionID uuid.UUID, cursor string, limit int) (*models.PostsListResponse, error) {
 	if s.repo == nil || s.postService == nil {
 		return nil, fmt.Errorf("bookmark service dependencies are not configured")
 	}
 
-	entries, nextCursor, err := s.repo.FindMyBookmarks(ctx, userID, cursor, limit)
+	entries, nextCursor, err := s.repo.FindCollectionBookmarks(ctx, userID, collectionID, cursor, limit)
 	if err != nil {
-		return nil, fmt.Errorf("find bookmarks: %w", err)
+		return nil, fmt.Errorf("find collection bookmarks: %w", err)
 	}
 
+	return s.hydrate(ctx, userID, entries, nextCursor)
+}
+
+// hydrate turns a page of bookmarks into post responses, in bookmark order
+func (s *service) hydrate(ctx context.Context, userID uuid.UUID, entries []repository.BookmarkEntry, nextCursor string) (*models.PostsListResponse, error) {
 	if len(entries) == 0 {
 		return &models.PostsListResponse{Posts: []models.PostResponse{}, NextCursor: nextCursor, HasNext: nextCursor != ""}, nil
 	}
@@ -79,6 +139,11 @@ func (s *service) ListBookmarks(ctx context.Context, userID uuid.UUID, cursor st
 		return nil, fmt.Errorf("get posts: %w", err)
 	}
 
+	collectionNames, err := s.repo.GetCollectionNames(ctx, userID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get collection names: %w", err)
+	}
+
 	// Map for lookup
 	postMap := make(map[uuid.UUID]*models.Post, len(posts))
 	for _, p := range posts {
@@ -93,8 +158,10 @@ func (s *service) ListBookmarks(ctx context.Context, userID uuid.UUID, cursor st
 		}
 		resp := s.postService.ConvertPostToResponse(ctx, p)
 		resp.IsBookmarked = true
+		resp.Collections = collectionNames[e.PostID]
 		responses = append(responses, resp)
 	}
+	s.postService.ApplySupporterAccess(ctx, responses)
 
 	return &models.PostsListResponse{
 		Posts:      responses,
@@ -102,3 +169,106 @@ func (s *service) ListBookmarks(ctx context.Context, userID uuid.UUID, cursor st
 		HasNext:    nextCursor != "",
 	}, nil
 }
+
+func (s *service) CreateCollection(ctx context.Context, userID uuid.UUID, name string) (*repository.Collection, error) {
+	name, err := normalizeCollectionName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListCollections(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+	if len(existing) >= maxCollectionsPerUser {
+		return nil, fmt.Errorf("%w: at most %d collections", bookmarkErrors.ErrCollectionLimit, maxCollectionsPerUser)
+	}
+
+	collection := &repository.Collection{
+		ID:          uuid.Must(uuid.NewV4()),
+		OwnerUserID: userID,
+		Name:        name,
+		CreatedAt:   time.Now().UTC(),
+	}
+	if err := s.repo.CreateCollection(ctx, collection); err != nil {
+		return nil, collectionError("create collection", err)
+	}
+	return collection, nil
+}
+
+func (s *service) RenameCollection(ctx context.Context, userID, collectionID uuid.UUID, name string) error {
+	name, err := normalizeCollectionName(name)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.RenameCollection(ctx, userID, collectionID, name); err != nil {
+		return collectionError("rename collection", err)
+	}
+	return nil
+}
+
+func (s *service) DeleteCollection(ctx context.Context, userID, collectionID uuid.UUID) error {
+	deleted, err := s.repo.DeleteCollection(ctx, userID, collectionID)
+	if err != nil {
+		return fmt.Errorf("delete collection: %w", err)
+	}
+	if !deleted {
+		return bookmarkErrors.ErrCollectionNotFound
+	}
+	return nil
+}
+
+func (s *service) ListCollections(ctx context.Context, userID uuid.UUID) ([]repository.Collection, error) {
+	collections, err := s.repo.ListCollections(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list collections: %w", err)
+	}
+	return collections, nil
+}
+
+func (s *service) SetPostCollections(ctx context.Context, userID, postID uuid.UUID, collectionIDs []uuid.UUID) error {
+	if len(collectionIDs) > maxCollectionsPerUser {
+		return fmt.Errorf("%w: at most %d collections", bookmarkErrors.ErrInvalidRequest, maxCollectionsPerUser)
+	}
+
+	seen := make(map[uuid.UUID]bool, len(collectionIDs))
+	unique := make([]uuid.UUID, 0, len(collectionIDs))
+	for _, id := range collectionIDs {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+
+	return s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.SetPostCollections(txCtx, userID, postID, unique); err != nil {
+			return collectionError("set post collections", err)
+		}
+		if len(unique) == 0 {
+			return nil
+		}
+		// Filing a post bookmarks it, if it was not already
+		return s.recordBookmarkChanged(txCtx, userID, postID, true)
+	})
+}
+
+// normalizeCollectionName trims a collection name and checks its length
+func normalizeCollectionName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || utf8.RuneCountInString(name) > maxCollectionNameLength {
+		return "", fmt.Errorf("%w: collection name must be 1-%d characters", bookmarkErrors.ErrInvalidRequest, maxCollectionNameLength)
+	}
+	return name, nil
+}
+
+// collectionError maps repository collection errors to their API errors
+func collectionError(op string, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrCollectionNotFound):
+		return bookmarkErrors.ErrCollectionNotFound
+	case errors.Is(err, repository.ErrCollectionExists):
+		return bookmarkErrors.ErrCollectionExists
+	default:
+		return fmt.Errorf("%s: %w", op, err)
+	}
+}
@@ -3,15 +3,30 @@ package services
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	uuid "github.com/gofrs/uuid"
+	bookmarkErrors "github.com/qolzam/telar/apps/api/bookmarks/errors"
 	"github.com/qolzam/telar/apps/api/bookmarks/repository"
 	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
+// recordingOutbox records appended events
+type recordingOutbox struct {
+	types []string
+	data  []interface{}
+}
+
+func (o *recordingOutbox) Append(ctx context.Context, eventType string, key string, data interface{}) error {
+	o.types = append(o.types, eventType)
+	o.data = append(o.data, data)
+	return nil
+}
+
 func TestToggleBookmark(t *testing.T) {
 	ctx := context.Background()
 	userID := uuid.Must(uuid.NewV4())
@@ -42,6 +57,24 @@ func TestToggleBookmark(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("announces changes through the outbox", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("AddBookmark", ctx, userID, postID).Return(true, nil).Once()
+		mockRepo.On("AddBookmark", ctx, userID, postID).Return(false, nil).Once()
+		mockRepo.On("RemoveBookmark", ctx, userID, postID).Return(true, nil).Once()
+		outbox := &recordingOutbox{}
+
+		svc := NewService(mockRepo, nil)
+		svc.SetEventOutbox(outbox)
+		_, err := svc.ToggleBookmark(ctx, userID, postID)
+		require.NoError(t, err)
+		_, err = svc.ToggleBookmark(ctx, userID, postID)
+		require.NoError(t, err)
+
+		require.Equal(t, []string{sharedInterfaces.OutboxBookmarkAdded, sharedInterfaces.OutboxBookmarkRemoved}, outbox.types)
+		require.Equal(t, sharedInterfaces.BookmarkChangedEvent{UserId: userID, PostId: postID}, outbox.data[1])
+	})
+
 	t.Run("propagates add errors", func(t *testing.T) {
 		mockRepo := new(MockRepository)
 		mockRepo.On("AddBookmark", ctx, userID, postID).Return(false, errors.New("db down")).Once()
@@ -68,6 +101,7 @@ func TestListBookmarks(t *testing.T) {
 	mockPost := &models.Post{ObjectId: postID}
 	mockPostSvc.On("GetPostsByIDs", ctx, []uuid.UUID{postID}).Return([]*models.Post{mockPost}, nil).Once()
 	mockPostSvc.On("ConvertPostToResponse", ctx, mockPost).Return(models.PostResponse{ObjectId: postID.String()}).Once()
+	mockRepo.On("GetCollectionNames", ctx, userID, []uuid.UUID{postID}).Return(map[uuid.UUID][]string{postID: {"Reading"}}, nil).Once()
 
 	svc := NewService(mockRepo, mockPostSvc)
 	resp, err := svc.ListBookmarks(ctx, userID, "", 10)
@@ -75,10 +109,135 @@ func TestListBookmarks(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, resp.Posts, 1)
 	require.True(t, resp.Posts[0].IsBookmarked)
+	require.Equal(t, []string{"Reading"}, resp.Posts[0].Collections)
+	mockRepo.AssertExpectations(t)
+	mockPostSvc.AssertExpectations(t)
+}
+
+func TestListCollectionBookmarks(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	collectionID := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+
+	mockRepo := new(MockRepository)
+	mockPostSvc := new(MockPostService)
+
+	entry := repository.BookmarkEntry{PostID: postID}
+	mockRepo.On("FindCollectionBookmarks", ctx, userID, collectionID, "", 10).Return([]repository.BookmarkEntry{entry}, "next", nil).Once()
+	mockRepo.On("GetCollectionNames", ctx, userID, []uuid.UUID{postID}).Return(map[uuid.UUID][]string{postID: {"Recipes", "Weekend"}}, nil).Once()
+
+	mockPost := &models.Post{ObjectId: postID}
+	mockPostSvc.On("GetPostsByIDs", ctx, []uuid.UUID{postID}).Return([]*models.Post{mockPost}, nil).Once()
+	mockPostSvc.On("ConvertPostToResponse", ctx, mockPost).Return(models.PostResponse{ObjectId: postID.String()}).Once()
+
+	svc := NewService(mockRepo, mockPostSvc)
+	resp, err := svc.ListCollectionBookmarks(ctx, userID, collectionID, "", 10)
+
+	require.NoError(t, err)
+	require.Len(t, resp.Posts, 1)
+	require.Equal(t, []string{"Recipes", "Weekend"}, resp.Posts[0].Collections)
+	require.True(t, resp.HasNext)
 	mockRepo.AssertExpectations(t)
 	mockPostSvc.AssertExpectations(t)
 }
 
+func TestCreateCollection(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+
+	t.Run("trims and stores the name", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("ListCollections", ctx, userID).Return([]repository.Collection{}, nil).Once()
+		mockRepo.On("CreateCollection", ctx, mock.MatchedBy(func(c *repository.Collection) bool {
+			return c.Name == "Reading" && c.OwnerUserID == userID && c.ID != uuid.Nil
+		})).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		collection, err := svc.CreateCollection(ctx, userID, "  Reading ")
+
+		require.NoError(t, err)
+		require.Equal(t, "Reading", collection.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects empty and long names", func(t *testing.T) {
+		svc := NewService(new(MockRepository), nil)
+
+		_, err := svc.CreateCollection(ctx, userID, "   ")
+		require.ErrorIs(t, err, bookmarkErrors.ErrInvalidRequest)
+
+		_, err = svc.CreateCollection(ctx, userID, strings.Repeat("a", maxCollectionNameLength+1))
+		require.ErrorIs(t, err, bookmarkErrors.ErrInvalidRequest)
+	})
+
+	t.Run("enforces the per-user limit", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("ListCollections", ctx, userID).Return(make([]repository.Collection, maxCollectionsPerUser), nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.CreateCollection(ctx, userID, "One more")
+
+		require.ErrorIs(t, err, bookmarkErrors.ErrCollectionLimit)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("maps duplicate names", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("ListCollections", ctx, userID).Return([]repository.Collection{}, nil).Once()
+		mockRepo.On("CreateCollection", ctx, mock.Anything).Return(repository.ErrCollectionExists).Once()
+
+		svc := NewService(mockRepo, nil)
+		_, err := svc.CreateCollection(ctx, userID, "Reading")
+
+		require.ErrorIs(t, err, bookmarkErrors.ErrCollectionExists)
+	})
+}
+
+func TestDeleteCollection(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	collectionID := uuid.Must(uuid.NewV4())
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("DeleteCollection", ctx, userID, collectionID).Return(false, nil).Once()
+
+	svc := NewService(mockRepo, nil)
+	err := svc.DeleteCollection(ctx, userID, collectionID)
+
+	require.ErrorIs(t, err, bookmarkErrors.ErrCollectionNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSetPostCollections(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+	first := uuid.Must(uuid.NewV4())
+	second := uuid.Must(uuid.NewV4())
+
+	t.Run("drops duplicate ids", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("SetPostCollections", ctx, userID, postID, []uuid.UUID{first, second}).Return(nil).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.SetPostCollections(ctx, userID, postID, []uuid.UUID{first, second, first})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("maps collections the user does not own", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("SetPostCollections", ctx, userID, postID, []uuid.UUID{first}).Return(repository.ErrCollectionNotFound).Once()
+
+		svc := NewService(mockRepo, nil)
+		err := svc.SetPostCollections(ctx, userID, postID, []uuid.UUID{first})
+
+		require.ErrorIs(t, err, bookmarkErrors.ErrCollectionNotFound)
+	})
+}
+
 type MockPostService struct {
 	mock.Mock
 }
@@ -92,3 +251,5 @@ func (m *MockPostService) ConvertPostToResponse(ctx context.Context, post *model
 	args := m.Called(ctx, post)
 	return args.Get(0).(models.PostResponse)
 }
+
+func (m *MockPostService) ApplySupporterAccess(ctx context.Context, posts []models.PostResponse) {}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrPostNotFound       = errors.New("post not found")
+	ErrEventNotFound      = errors.New("event not found")
+	ErrForbidden          = errors.New("only the post owner can change its event")
+	ErrInvalidToken       = errors.New("invalid calendar token")
+	ErrMembershipRequired = errors.New("community membership required")
+	ErrInvalidRequest     = errors.New("invalid request")
+	ErrMissingUserContext = errors.New("missing user context")
+	ErrDatabaseOperation  = errors.New("database operation failed")
+)
+
+const (
+	CodePostNotFound       = "POST_NOT_FOUND"
+	CodeEventNotFound      = "EVENT_NOT_FOUND"
+	CodeForbidden          = "FORBIDDEN"
+	CodeInvalidToken       = "INVALID_TOKEN"
+	CodeMembershipRequired = "MEMBERSHIP_REQUIRED"
+	CodeInvalidRequest     = "INVALID_REQUEST"
+	CodeMissingUserCtx     = "MISSING_USER_CONTEXT"
+	CodeDatabaseError      = "DATABASE_ERROR"
+	CodeInternalError      = "INTERNAL_ERROR"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func HandleServiceError(c *fiber.Ctx, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, ErrPostNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodePostNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrEventNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeEventNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrForbidden):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodeForbidden, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidToken):
+		return c.Status(http.StatusUnauthorized).JSON(ErrorResponse{Code: CodeInvalidToken, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrMembershipRequired):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodeMembershipRequired, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrMissingUserContext):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrDatabaseOperation):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeDatabaseError, Message: err.Error(), Details: err.Error()})
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Code: CodeInternalError, Message: "An unexpected error occurred", Details: err.Error()})
+	}
+}
+
+func HandleValidationError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: message, Details: message})
+}
+
+func HandleUserContextError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: message, Details: message})
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/calendar/errors"
+	"github.com/qolzam/telar/apps/api/calendar/models"
+	"github.com/qolzam/telar/apps/api/calendar/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type CalendarHandler struct {
+	service services.Service
+}
+
+func NewCalendarHandler(service services.Service) *CalendarHandler {
+	return &CalendarHandler{service: service}
+}
+
+// SetEvent makes one of the current user's posts an event, or reschedules it.
+// Endpoint: PUT /calendar/posts/:postId
+func (h *CalendarHandler) SetEvent(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid postId")
+	}
+	var req models.SetEventRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	event, err := h.service.SetEvent(c.Context(), user.UserID, postID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(event)
+}
+
+// GetEvent returns the event attached to a post.
+// Endpoint: GET /calendar/posts/:postId
+func (h *CalendarHandler) GetEvent(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid postId")
+	}
+
+	event, err := h.service.GetEvent(c.Context(), user.UserID, postID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(event)
+}
+
+// DeleteEvent turns one of the current user's event posts back into a plain post.
+// Endpoint: DELETE /calendar/posts/:postId
+func (h *CalendarHandler) DeleteEvent(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid postId")
+	}
+
+	if err := h.service.DeleteEvent(c.Context(), user.UserID, postID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// Calendar lists a month's events per day.
+// Endpoint: GET /calendar?month=YYYY-MM&tz=Area/City
+func (h *CalendarHandler) Calendar(c *fiber.Ctx) error {
+	calendar, err := h.service.Calendar(c.Context(), c.Query("month"), c.Query("tz"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(calendar)
+}
+
+// Subscribe issues the current user a new iCal feed URL, revoking the previous one.
+// Endpoint: POST /calendar/subscription
+func (h *CalendarHandler) Subscribe(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	subscription, err := h.service.Subscribe(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(subscription)
+}
+
+// Unsubscribe revokes the current user's iCal feed URL.
+// Endpoint: DELETE /calendar/subscription
+func (h *CalendarHandler) Unsubscribe(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	if err := h.service.Unsubscribe(c.Context(), user.UserID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// Feed serves the iCal feed to calendar apps, authenticated by the URL's token.
+// Endpoint: GET /calendar/feed.ics?token=
+func (h *CalendarHandler) Feed(c *fiber.Ctx) error {
+	feed, err := h.service.Feed(c.Context(), c.Query("token"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return c.Status(http.StatusOK).Send(feed)
+}
+
+// CommunityCalendar lists a month's events per day among the posts in a community.
+// Endpoint: GET /communities/:communityId/calendar?month=YYYY-MM&tz=Area/City
+func (h *CalendarHandler) CommunityCalendar(c *fiber.Ctx) error {
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	calendar, err := h.service.CommunityCalendar(c.Context(), communityID, c.Query("month"), c.Query("tz"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(calendar)
+}
+
+// SubscribeCommunity issues the current member a new iCal feed URL for a community's
+// events, revoking the previous one.
+// Endpoint: POST /communities/:communityId/calendar/subscription
+func (h *CalendarHandler) SubscribeCommunity(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	subscription, err := h.service.SubscribeCommunity(c.Context(), communityID, user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(subscription)
+}
+
+// UnsubscribeCommunity revokes the current user's iCal feed URL for a community.
+// Endpoint: DELETE /communities/:communityId/calendar/subscription
+func (h *CalendarHandler) UnsubscribeCommunity(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	if err := h.service.UnsubscribeCommunity(c.Context(), communityID, user.UserID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// CommunityFeed serves a community's iCal feed to calendar apps, authenticated by the
+// URL's token.
+// Endpoint: GET /communities/:communityId/calendar/feed.ics?token=
+func (h *CalendarHandler) CommunityFeed(c *fiber.Ctx) error {
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	feed, err := h.service.CommunityFeed(c.Context(), communityID, c.Query("token"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderCacheControl, "private, max-age=300")
+	return c.Status(http.StatusOK).Send(feed)
+}
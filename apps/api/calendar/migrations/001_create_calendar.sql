@@ -0,0 +1,26 @@
+-- Events: a date, time and place attached to a post. Times are Unix milliseconds; an
+-- all-day event covers the UTC days from starts_at up to ends_at. The calendar joins
+-- posts so deleted, archived and non-public posts drop out without extra bookkeeping.
+CREATE TABLE IF NOT EXISTS post_events (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    owner_user_id UUID NOT NULL,
+    title TEXT NOT NULL,
+    starts_at BIGINT NOT NULL,
+    ends_at BIGINT NOT NULL,
+    all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    location TEXT NOT NULL DEFAULT '',
+    created_date BIGINT NOT NULL,
+    last_updated BIGINT NOT NULL,
+    CHECK (ends_at >= starts_at)
+);
+
+-- Month views and feeds select events overlapping a range
+CREATE INDEX IF NOT EXISTS idx_post_events_range ON post_events(starts_at, ends_at);
+
+-- Personal iCal subscription tokens. Calendar apps cannot send bearer tokens, so the
+-- feed URL carries one; only its SHA-256 is stored and issuing a new one revokes the old.
+CREATE TABLE IF NOT EXISTS calendar_tokens (
+    user_id UUID PRIMARY KEY,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_date BIGINT NOT NULL
+);
@@ -0,0 +1,13 @@
+-- Community iCal subscription tokens: a member subscribing to a community's calendar gets
+-- a feed URL of its own, separate from their personal feed. As with calendar_tokens only
+-- the SHA-256 is stored and issuing a new one revokes the old.
+CREATE TABLE IF NOT EXISTS community_calendar_tokens (
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    token_hash TEXT NOT NULL UNIQUE,
+    created_date BIGINT NOT NULL,
+    PRIMARY KEY (community_id, user_id)
+);
+
+-- Account deletion removes a user's tokens in every community
+CREATE INDEX IF NOT EXISTS idx_community_calendar_tokens_user ON community_calendar_tokens(user_id);
@@ -0,0 +1,48 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Event is the date, time and place attached to a post
+type Event struct {
+	PostId      uuid.UUID `json:"postId" db:"post_id"`
+	OwnerUserId uuid.UUID `json:"ownerUserId" db:"owner_user_id"`
+	Title       string    `json:"title" db:"title"`
+	StartsAt    int64     `json:"startsAt" db:"starts_at"` // Unix milliseconds
+	EndsAt      int64     `json:"endsAt" db:"ends_at"`     // Unix milliseconds; equals StartsAt for events without a length
+	AllDay      bool      `json:"allDay" db:"all_day"`     // Whole UTC days: StartsAt is a midnight, EndsAt the midnight after the last day
+	Location    string    `json:"location,omitempty" db:"location"`
+	CreatedDate int64     `json:"createdDate" db:"created_date"`
+	LastUpdated int64     `json:"lastUpdated" db:"last_updated"`
+	URLKey      string    `json:"urlKey,omitempty" db:"url_key"` // The post's URL key, for links
+}
+
+// SetEventRequest is the body of PUT /calendar/posts/:postId
+type SetEventRequest struct {
+	Title    string `json:"title"`
+	StartsAt int64  `json:"startsAt"`
+	EndsAt   int64  `json:"endsAt"` // 0 for events without a length
+	AllDay   bool   `json:"allDay"`
+	Location string `json:"location"`
+}
+
+// CalendarDay lists the events on one day of a month
+type CalendarDay struct {
+	Date   string   `json:"date"` // YYYY-MM-DD in the calendar's time zone
+	Events []*Event `json:"events"`
+}
+
+// Calendar is the GET /calendar response. Days without events are left out;
+// an event spanning several days is listed on each of them.
+type Calendar struct {
+	Month    string        `json:"month"` // YYYY-MM
+	TimeZone string        `json:"timeZone"`
+	Days     []CalendarDay `json:"days"`
+}
+
+// Subscription is the POST /calendar/subscription response
+type Subscription struct {
+	URL         string `json:"url"` // Secret; anyone holding it can read the feed
+	CreatedDate int64  `json:"createdDate"`
+}
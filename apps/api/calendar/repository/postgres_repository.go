@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/calendar/models"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+const eventColumns = `e.post_id, e.owner_user_id, e.title, e.starts_at, e.ends_at, e.all_day, e.location, e.created_date, e.last_updated, p.url_key`
+
+func (r *postgresRepository) SaveEvent(ctx context.Context, event *models.Event) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %spost_events (post_id, owner_user_id, title, starts_at, ends_at, all_day, location, created_date, last_updated)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (post_id) DO UPDATE
+		SET title = EXCLUDED.title,
+			starts_at = EXCLUDED.starts_at,
+			ends_at = EXCLUDED.ends_at,
+			all_day = EXCLUDED.all_day,
+			location = EXCLUDED.location,
+			last_updated = EXCLUDED.last_updated
+	`, r.schemaPrefix())
+
+	_, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		event.PostId, event.OwnerUserId, event.Title, event.StartsAt, event.EndsAt,
+		event.AllDay, event.Location, event.CreatedDate, event.LastUpdated)
+	if err != nil {
+		return fmt.Errorf("save event: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetEvent(ctx context.Context, postID uuid.UUID) (*models.Event, error) {
+	query := fmt.Sprintf(`SELECT %[1]s FROM %[2]spost_events e JOIN %[2]sposts p ON p.id = e.post_id WHERE e.post_id = $1`, eventColumns, r.schemaPrefix())
+
+	var event models.Event
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &event, query, postID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get event: %w", err)
+	}
+	return &event, nil
+}
+
+func (r *postgresRepository) DeleteEvent(ctx context.Context, postID uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`DELETE FROM %spost_events WHERE post_id = $1`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, postID)
+	if err != nil {
+		return false, fmt.Errorf("delete event: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// ListBetween treats an event without a length as occupying its start instant
+func (r *postgresRepository) ListBetween(ctx context.Context, from, to int64, limit int) ([]*models.Event, error) {
+	query := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM %[2]spost_events e
+		JOIN %[2]sposts p ON p.id = e.post_id
+		WHERE e.starts_at < $2 AND (e.ends_at > $1 OR e.starts_at >= $1)
+			AND p.is_deleted = FALSE AND p.is_archived = FALSE AND p.permission = 'Public'
+		ORDER BY e.starts_at ASC, e.post_id ASC
+		LIMIT $3
+	`, eventColumns, r.schemaPrefix())
+
+	events := []*models.Event{}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &events, query, from, to, limit); err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+	return events, nil
+}
+
+// ListCommunityBetween lists the events of posts made in or shared into communityID
+func (r *postgresRepository) ListCommunityBetween(ctx context.Context, communityID uuid.UUID, from, to int64, limit int) ([]*models.Event, error) {
+	query := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM %[2]spost_events e
+		JOIN %[2]sposts p ON p.id = e.post_id
+		JOIN %[2]spost_community_placements pl ON pl.post_id = e.post_id AND pl.community_id = $4
+		WHERE e.starts_at < $2 AND (e.ends_at > $1 OR e.starts_at >= $1)
+			AND p.is_deleted = FALSE AND p.is_archived = FALSE AND p.permission = 'Public'
+		ORDER BY e.starts_at ASC, e.post_id ASC
+		LIMIT $3
+	`, eventColumns, r.schemaPrefix())
+
+	events := []*models.Event{}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &events, query, from, to, limit, communityID); err != nil {
+		return nil, fmt.Errorf("list community events: %w", err)
+	}
+	return events, nil
+}
+
+func (r *postgresRepository) SaveToken(ctx context.Context, userID uuid.UUID, tokenHash string, now int64) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %scalendar_tokens (user_id, token_hash, created_date)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_date = EXCLUDED.created_date
+	`, r.schemaPrefix())
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID, tokenHash, now); err != nil {
+		return fmt.Errorf("save calendar token: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) FindTokenUser(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	query := fmt.Sprintf(`SELECT user_id FROM %scalendar_tokens WHERE token_hash = $1`, r.schemaPrefix())
+
+	var userID uuid.UUID
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &userID, query, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrNotFound
+		}
+		return uuid.Nil, fmt.Errorf("find calendar token: %w", err)
+	}
+	return userID, nil
+}
+
+func (r *postgresRepository) DeleteToken(ctx context.Context, userID uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`DELETE FROM %scalendar_tokens WHERE user_id = $1`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete calendar token: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) SaveCommunityToken(ctx context.Context, communityID, userID uuid.UUID, tokenHash string, now int64) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %scommunity_calendar_tokens (community_id, user_id, token_hash, created_date)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (community_id, user_id) DO UPDATE
+		SET token_hash = EXCLUDED.token_hash, created_date = EXCLUDED.created_date
+	`, r.schemaPrefix())
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, communityID, userID, tokenHash, now); err != nil {
+		return fmt.Errorf("save community calendar token: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) FindCommunityTokenUser(ctx context.Context, communityID uuid.UUID, tokenHash string) (uuid.UUID, error) {
+	query := fmt.Sprintf(`SELECT user_id FROM %scommunity_calendar_tokens WHERE community_id = $1 AND token_hash = $2`, r.schemaPrefix())
+
+	var userID uuid.UUID
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &userID, query, communityID, tokenHash); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, ErrNotFound
+		}
+		return uuid.Nil, fmt.Errorf("find community calendar token: %w", err)
+	}
+	return userID, nil
+}
+
+func (r *postgresRepository) DeleteCommunityToken(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`DELETE FROM %scommunity_calendar_tokens WHERE community_id = $1 AND user_id = $2`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, communityID, userID)
+	if err != nil {
+		return false, fmt.Errorf("delete community calendar token: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	for _, table := range []string{"calendar_tokens", "community_calendar_tokens"} {
+		query := fmt.Sprintf(`DELETE FROM %s%s WHERE user_id = $1`, r.schemaPrefix(), table)
+		if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+			return fmt.Errorf("delete %s of user: %w", table, err)
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
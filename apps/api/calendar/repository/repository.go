@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/calendar/models"
+)
+
+// ErrNotFound is returned when an event or calendar token does not exist
+var ErrNotFound = errors.New("not found")
+
+// Repository defines data access for post events and personal and community calendar
+// tokens.
+type Repository interface {
+	// SaveEvent creates or replaces the event of event.PostId.
+	SaveEvent(ctx context.Context, event *models.Event) error
+
+	// GetEvent returns the event of postID or ErrNotFound.
+	GetEvent(ctx context.Context, postID uuid.UUID) (*models.Event, error)
+
+	// DeleteEvent removes the event of postID; returns false when there was none.
+	DeleteEvent(ctx context.Context, postID uuid.UUID) (bool, error)
+
+	// ListBetween returns up to limit events overlapping [from, to) whose posts are
+	// public and still listed, earliest first.
+	ListBetween(ctx context.Context, from, to int64, limit int) ([]*models.Event, error)
+
+	// ListCommunityBetween returns up to limit events overlapping [from, to) whose posts
+	// are placed in communityID, public and still listed, earliest first.
+	ListCommunityBetween(ctx context.Context, communityID uuid.UUID, from, to int64, limit int) ([]*models.Event, error)
+
+	// SaveToken stores userID's calendar token hash, replacing any earlier one.
+	SaveToken(ctx context.Context, userID uuid.UUID, tokenHash string, now int64) error
+
+	// FindTokenUser returns the user owning tokenHash or ErrNotFound.
+	FindTokenUser(ctx context.Context, tokenHash string) (uuid.UUID, error)
+
+	// DeleteToken removes userID's calendar token; returns false when there was none.
+	DeleteToken(ctx context.Context, userID uuid.UUID) (bool, error)
+
+	// SaveCommunityToken stores userID's token hash for communityID's calendar, replacing
+	// any earlier one.
+	SaveCommunityToken(ctx context.Context, communityID, userID uuid.UUID, tokenHash string, now int64) error
+
+	// FindCommunityTokenUser returns the user owning tokenHash for communityID's calendar
+	// or ErrNotFound.
+	FindCommunityTokenUser(ctx context.Context, communityID uuid.UUID, tokenHash string) (uuid.UUID, error)
+
+	// DeleteCommunityToken removes userID's token for communityID's calendar; returns false
+	// when there was none.
+	DeleteCommunityToken(ctx context.Context, communityID, userID uuid.UUID) (bool, error)
+
+	// DeleteByUser deletes userID's personal and community calendar tokens.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,52 @@
+package calendar
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/calendar/handlers"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+type Handlers struct {
+	CalendarHandler *handlers.CalendarHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires event posts, the calendar and its iCal subscription feed, and the
+// calendar and feed of each community.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/calendar")
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	// Calendar apps cannot send credentials; the feed URL carries a token instead
+	group.Get("/feed.ics", handlers.CalendarHandler.Feed)
+
+	group.Put("/posts/:postId", dualAuthMiddleware, handlers.CalendarHandler.SetEvent)
+	group.Get("/posts/:postId", dualAuthMiddleware, handlers.CalendarHandler.GetEvent)
+	group.Delete("/posts/:postId", dualAuthMiddleware, handlers.CalendarHandler.DeleteEvent)
+	group.Get("", dualAuthMiddleware, handlers.CalendarHandler.Calendar)
+	group.Post("/subscription", dualAuthMiddleware, handlers.CalendarHandler.Subscribe)
+	group.Delete("/subscription", dualAuthMiddleware, handlers.CalendarHandler.Unsubscribe)
+
+	communityGroup := app.Group("/communities/:communityId/calendar")
+	communityGroup.Get("/feed.ics", handlers.CalendarHandler.CommunityFeed)
+	communityGroup.Get("", dualAuthMiddleware, handlers.CalendarHandler.CommunityCalendar)
+	communityGroup.Post("/subscription", dualAuthMiddleware, handlers.CalendarHandler.SubscribeCommunity)
+	communityGroup.Delete("/subscription", dualAuthMiddleware, handlers.CalendarHandler.UnsubscribeCommunity)
+}
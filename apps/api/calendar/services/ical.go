@@ -0,0 +1,80 @@
+package services
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/qolzam/telar/apps/api/calendar/models"
+)
+
+const (
+	icalDateTime = "20060102T150405Z"
+	icalDate     = "20060102"
+	// icalLineLength is the longest content line RFC 5545 allows, in octets
+	icalLineLength = 75
+)
+
+var icalEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+// renderICal writes events as an RFC 5545 calendar
+func renderICal(events []*models.Event, webURL string, now time.Time) []byte {
+	var buf bytes.Buffer
+	line := func(content string) {
+		foldLine(&buf, content)
+	}
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Telar//Events//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	line("X-WR-CALNAME:Events")
+	line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	line("X-PUBLISHED-TTL:PT1H")
+	for _, event := range events {
+		line("BEGIN:VEVENT")
+		line("UID:" + event.PostId.String() + "@telar")
+		line("DTSTAMP:" + now.UTC().Format(icalDateTime))
+		line("LAST-MODIFIED:" + time.UnixMilli(event.LastUpdated).UTC().Format(icalDateTime))
+		if event.AllDay {
+			line("DTSTART;VALUE=DATE:" + time.UnixMilli(event.StartsAt).UTC().Format(icalDate))
+			line("DTEND;VALUE=DATE:" + time.UnixMilli(event.EndsAt).UTC().Format(icalDate))
+		} else {
+			line("DTSTART:" + time.UnixMilli(event.StartsAt).UTC().Format(icalDateTime))
+			if event.EndsAt > event.StartsAt {
+				line("DTEND:" + time.UnixMilli(event.EndsAt).UTC().Format(icalDateTime))
+			}
+		}
+		line("SUMMARY:" + icalEscaper.Replace(event.Title))
+		if event.Location != "" {
+			line("LOCATION:" + icalEscaper.Replace(event.Location))
+		}
+		if webURL != "" && event.URLKey != "" {
+			line("URL:" + strings.TrimRight(webURL, "/") + "/posts/" + url.PathEscape(event.URLKey))
+		}
+		line("END:VEVENT")
+	}
+	line("END:VCALENDAR")
+	return buf.Bytes()
+}
+
+// foldLine writes content, splitting it into lines of at most icalLineLength octets
+// without breaking UTF-8 sequences; continuation lines start with a space
+func foldLine(buf *bytes.Buffer, content string) {
+	limit := icalLineLength
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(content[cut]) {
+			cut--
+		}
+		buf.WriteString(content[:cut])
+		buf.WriteString("\r\n ")
+		content = content[cut:]
+		limit = icalLineLength - 1
+	}
+	buf.WriteString(content)
+	buf.WriteString("\r\n")
+}
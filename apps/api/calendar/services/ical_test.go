@@ -0,0 +1,64 @@
+package services
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/calendar/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderICal(t *testing.T) {
+	postID := uuid.Must(uuid.FromString("6ba7b810-9dad-11d1-80b4-00c04fd430c8"))
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	events := []*models.Event{
+		{
+			PostId:      postID,
+			Title:       "Meetup; snacks, drinks",
+			StartsAt:    millis(2026, 10, 24, 18, 0),
+			EndsAt:      millis(2026, 10, 24, 20, 0),
+			Location:    "Room 1\nTown hall",
+			LastUpdated: now.UnixMilli(),
+			URLKey:      "autumn meetup",
+		},
+		{
+			PostId:   postID,
+			Title:    "Festival",
+			StartsAt: millis(2026, 10, 30, 0, 0),
+			EndsAt:   millis(2026, 11, 2, 0, 0),
+			AllDay:   true,
+		},
+	}
+
+	feed := string(renderICal(events, "https://example.com/", now))
+	assert.True(t, strings.HasPrefix(feed, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(feed, "END:VCALENDAR\r\n"))
+	assert.Contains(t, feed, "UID:"+postID.String()+"@telar\r\n")
+	assert.Contains(t, feed, "DTSTART:20261024T180000Z\r\nDTEND:20261024T200000Z\r\n")
+	assert.Contains(t, feed, `SUMMARY:Meetup\; snacks\, drinks`+"\r\n")
+	assert.Contains(t, feed, `LOCATION:Room 1\nTown hall`+"\r\n")
+	assert.Contains(t, feed, "URL:https://example.com/posts/autumn%20meetup\r\n")
+	assert.Contains(t, feed, "DTSTART;VALUE=DATE:20261030\r\nDTEND;VALUE=DATE:20261102\r\n")
+}
+
+func TestFoldLine(t *testing.T) {
+	var buf bytes.Buffer
+	content := "SUMMARY:" + strings.Repeat("é", 100)
+	foldLine(&buf, content)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	assert.Greater(t, len(lines), 1)
+	var unfolded strings.Builder
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), icalLineLength)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+			line = line[1:]
+		}
+		unfolded.WriteString(line)
+	}
+	assert.Equal(t, content, unfolded.String())
+}
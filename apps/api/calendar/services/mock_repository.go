@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/calendar/models"
+	"github.com/qolzam/telar/apps/api/calendar/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the calendar repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) SaveEvent(ctx context.Context, event *models.Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetEvent(ctx context.Context, postID uuid.UUID) (*models.Event, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Event), args.Error(1)
+}
+
+func (m *MockRepository) DeleteEvent(ctx context.Context, postID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ListBetween(ctx context.Context, from, to int64, limit int) ([]*models.Event, error) {
+	args := m.Called(ctx, from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
+func (m *MockRepository) ListCommunityBetween(ctx context.Context, communityID uuid.UUID, from, to int64, limit int) ([]*models.Event, error) {
+	args := m.Called(ctx, communityID, from, to, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Event), args.Error(1)
+}
+
+func (m *MockRepository) SaveToken(ctx context.Context, userID uuid.UUID, tokenHash string, now int64) error {
+	args := m.Called(ctx, userID, tokenHash, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindTokenUser(ctx context.Context, tokenHash string) (uuid.UUID, error) {
+	args := m.Called(ctx, tokenHash)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) DeleteToken(ctx context.Context, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) SaveCommunityToken(ctx context.Context, communityID, userID uuid.UUID, tokenHash string, now int64) error {
+	args := m.Called(ctx, communityID, userID, tokenHash, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindCommunityTokenUser(ctx context.Context, communityID uuid.UUID, tokenHash string) (uuid.UUID, error) {
+	args := m.Called(ctx, communityID, tokenHash)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) DeleteCommunityToken(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, communityID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
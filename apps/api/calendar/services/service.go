@@ -0,0 +1,448 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	calendarErrors "github.com/qolzam/telar/apps/api/calendar/errors"
+	"github.com/qolzam/telar/apps/api/calendar/models"
+	"github.com/qolzam/telar/apps/api/calendar/repository"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	maxTitleLength    = 200
+	maxLocationLength = 300
+	maxEventLength    = 31 * 24 * time.Hour
+	// calendarLimit bounds the events loaded for one month view
+	calendarLimit = 1000
+	day           = 24 * time.Hour
+)
+
+// PostProvider finds the posts events are attached to; the post service satisfies it.
+type PostProvider interface {
+	GetPost(ctx context.Context, postID uuid.UUID) (*postsModels.Post, error)
+}
+
+// Links are the public URLs events point at.
+type Links struct {
+	FeedURL string // iCal feed endpoint, e.g. https://api.example.com/calendar/feed.ics
+	APIURL  string // API base URL; community feeds are at APIURL/communities/:communityId/calendar/feed.ics
+	WebURL  string // Web app base URL; posts are at WebURL/posts/:urlKey
+}
+
+// listEvents lists up to limit events overlapping [from, to), earliest first
+type listEvents func(ctx context.Context, from, to int64, limit int) ([]*models.Event, error)
+
+// Service defines event posts, the calendar and iCal subscriptions.
+type Service interface {
+	// SetEvent makes postID an event, or reschedules it. Only the post owner may.
+	SetEvent(ctx context.Context, userID, postID uuid.UUID, req *models.SetEventRequest) (*models.Event, error)
+
+	// GetEvent returns the event of postID; events of non-public posts only to their owner.
+	GetEvent(ctx context.Context, viewerID, postID uuid.UUID) (*models.Event, error)
+
+	// DeleteEvent turns postID back into a plain post. Only the post owner may.
+	DeleteEvent(ctx context.Context, userID, postID uuid.UUID) error
+
+	// Calendar lists the events of month (YYYY-MM, default the current one) per day
+	// in timeZone (an IANA name, default UTC).
+	Calendar(ctx context.Context, month, timeZone string) (*models.Calendar, error)
+
+	// Subscribe issues userID a new iCal feed URL, revoking the previous one.
+	Subscribe(ctx context.Context, userID uuid.UUID) (*models.Subscription, error)
+
+	// Unsubscribe revokes userID's iCal feed URL.
+	Unsubscribe(ctx context.Context, userID uuid.UUID) error
+
+	// Feed renders the iCal feed for the holder of token.
+	Feed(ctx context.Context, token string) ([]byte, error)
+
+	// CommunityCalendar lists the events of posts placed in communityID like Calendar.
+	CommunityCalendar(ctx context.Context, communityID uuid.UUID, month, timeZone string) (*models.Calendar, error)
+
+	// SubscribeCommunity issues userID a new iCal feed URL for communityID's events,
+	// revoking the previous one. Only members may subscribe.
+	SubscribeCommunity(ctx context.Context, communityID, userID uuid.UUID) (*models.Subscription, error)
+
+	// UnsubscribeCommunity revokes userID's iCal feed URL for communityID.
+	UnsubscribeCommunity(ctx context.Context, communityID, userID uuid.UUID) error
+
+	// CommunityFeed renders communityID's iCal feed for the holder of token while they
+	// are still a member.
+	CommunityFeed(ctx context.Context, communityID uuid.UUID, token string) ([]byte, error)
+
+	// SetCommunityChecker lets members subscribe to community calendars; without a
+	// checker nobody may.
+	SetCommunityChecker(checker sharedInterfaces.CommunityChecker)
+}
+
+type service struct {
+	repo        repository.Repository
+	posts       PostProvider
+	communities sharedInterfaces.CommunityChecker
+	cfg         platformconfig.CalendarConfig
+	links       Links
+	now         func() time.Time
+}
+
+// NewService constructs a calendar service.
+func NewService(repo repository.Repository, posts PostProvider, cfg platformconfig.CalendarConfig, links Links) Service {
+	return &service{repo: repo, posts: posts, cfg: cfg, links: links, now: time.Now}
+}
+
+// ownedPost returns postID when userID owns it
+func (s *service) ownedPost(ctx context.Context, userID, postID uuid.UUID) (*postsModels.Post, error) {
+	post, err := s.posts.GetPost(ctx, postID)
+	if errors.Is(err, postsErrors.ErrPostNotFound) || (err == nil && post.Deleted) {
+		return nil, calendarErrors.ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	if post.OwnerUserId != userID {
+		return nil, calendarErrors.ErrForbidden
+	}
+	return post, nil
+}
+
+func (s *service) SetEvent(ctx context.Context, userID, postID uuid.UUID, req *models.SetEventRequest) (*models.Event, error) {
+	post, err := s.ownedPost(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	// The calendar shows who organizes an event
+	if post.Anonymous {
+		return nil, fmt.Errorf("%w: anonymous posts cannot be events", calendarErrors.ErrInvalidRequest)
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if title == "" {
+		title = defaultTitle(post.Body)
+	}
+	location := strings.TrimSpace(req.Location)
+	switch {
+	case title == "":
+		return nil, fmt.Errorf("%w: title is required", calendarErrors.ErrInvalidRequest)
+	case utf8.RuneCountInString(title) > maxTitleLength:
+		return nil, fmt.Errorf("%w: title must be at most %d characters", calendarErrors.ErrInvalidRequest, maxTitleLength)
+	case utf8.RuneCountInString(location) > maxLocationLength:
+		return nil, fmt.Errorf("%w: location must be at most %d characters", calendarErrors.ErrInvalidRequest, maxLocationLength)
+	case req.StartsAt <= 0:
+		return nil, fmt.Errorf("%w: startsAt is required", calendarErrors.ErrInvalidRequest)
+	case req.EndsAt != 0 && req.EndsAt < req.StartsAt:
+		return nil, fmt.Errorf("%w: endsAt must not be before startsAt", calendarErrors.ErrInvalidRequest)
+	}
+
+	startsAt, endsAt := eventBounds(req)
+	if time.Duration(endsAt-startsAt)*time.Millisecond > maxEventLength {
+		return nil, fmt.Errorf("%w: events may last at most %d days", calendarErrors.ErrInvalidRequest, int(maxEventLength/day))
+	}
+
+	now := s.now().UnixMilli()
+	event := &models.Event{
+		PostId:      postID,
+		OwnerUserId: post.OwnerUserId,
+		Title:       title,
+		StartsAt:    startsAt,
+		EndsAt:      endsAt,
+		AllDay:      req.AllDay,
+		Location:    location,
+		CreatedDate: now,
+		LastUpdated: now,
+		URLKey:      post.URLKey,
+	}
+	if existing, err := s.repo.GetEvent(ctx, postID); err == nil {
+		event.CreatedDate = existing.CreatedDate
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	if err := s.repo.SaveEvent(ctx, event); err != nil {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	return event, nil
+}
+
+// eventBounds normalizes the requested times. Events without an end last no time;
+// all-day events run from UTC midnight to the midnight after their last day.
+func eventBounds(req *models.SetEventRequest) (int64, int64) {
+	startsAt, endsAt := req.StartsAt, req.EndsAt
+	if endsAt == 0 {
+		endsAt = startsAt
+	}
+	if !req.AllDay {
+		return startsAt, endsAt
+	}
+	start := time.UnixMilli(startsAt).UTC().Truncate(day)
+	end := time.UnixMilli(endsAt).UTC().Truncate(day).Add(day)
+	return start.UnixMilli(), end.UnixMilli()
+}
+
+// defaultTitle is the first line of body, shortened to fit a title
+func defaultTitle(body string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	line = strings.TrimSpace(line)
+	if utf8.RuneCountInString(line) <= maxTitleLength {
+		return line
+	}
+	return strings.TrimSpace(string([]rune(line)[:maxTitleLength-1])) + "…"
+}
+
+func (s *service) GetEvent(ctx context.Context, viewerID, postID uuid.UUID) (*models.Event, error) {
+	post, err := s.posts.GetPost(ctx, postID)
+	if errors.Is(err, postsErrors.ErrPostNotFound) || (err == nil && post.Deleted) {
+		return nil, calendarErrors.ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	if post.Permission != "" && post.Permission != "Public" && post.OwnerUserId != viewerID {
+		return nil, calendarErrors.ErrEventNotFound
+	}
+
+	event, err := s.repo.GetEvent(ctx, postID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, calendarErrors.ErrEventNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	return event, nil
+}
+
+func (s *service) DeleteEvent(ctx context.Context, userID, postID uuid.UUID) error {
+	if _, err := s.ownedPost(ctx, userID, postID); err != nil {
+		return err
+	}
+	deleted, err := s.repo.DeleteEvent(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	if !deleted {
+		return calendarErrors.ErrEventNotFound
+	}
+	return nil
+}
+
+func (s *service) SetCommunityChecker(checker sharedInterfaces.CommunityChecker) {
+	s.communities = checker
+}
+
+func (s *service) Calendar(ctx context.Context, month, timeZone string) (*models.Calendar, error) {
+	return s.monthCalendar(ctx, month, timeZone, s.repo.ListBetween)
+}
+
+func (s *service) CommunityCalendar(ctx context.Context, communityID uuid.UUID, month, timeZone string) (*models.Calendar, error) {
+	return s.monthCalendar(ctx, month, timeZone, communityEvents(s.repo, communityID))
+}
+
+// communityEvents lists the events of posts placed in communityID
+func communityEvents(repo repository.Repository, communityID uuid.UUID) listEvents {
+	return func(ctx context.Context, from, to int64, limit int) ([]*models.Event, error) {
+		return repo.ListCommunityBetween(ctx, communityID, from, to, limit)
+	}
+}
+
+// monthCalendar groups the events list returns for month per day in timeZone
+func (s *service) monthCalendar(ctx context.Context, month, timeZone string, list listEvents) (*models.Calendar, error) {
+	loc := time.UTC
+	if timeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timeZone); err != nil {
+			return nil, fmt.Errorf("%w: unknown time zone %q", calendarErrors.ErrInvalidRequest, timeZone)
+		}
+	}
+
+	var first time.Time
+	if month == "" {
+		now := s.now().In(loc)
+		first = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+	} else {
+		var err error
+		if first, err = time.ParseInLocation("2006-01", month, loc); err != nil {
+			return nil, fmt.Errorf("%w: month must be YYYY-MM", calendarErrors.ErrInvalidRequest)
+		}
+	}
+	next := first.AddDate(0, 1, 0)
+
+	// All-day events keep their UTC dates whatever the zone, so look a day past both
+	// ends of the month and drop the days outside it below
+	events, err := list(ctx, first.Add(-day).UnixMilli(), next.Add(day).UnixMilli(), calendarLimit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+
+	prefix := first.Format("2006-01")
+	byDate := map[string][]*models.Event{}
+	for _, event := range events {
+		for _, date := range eventDates(event, loc) {
+			if strings.HasPrefix(date, prefix) {
+				byDate[date] = append(byDate[date], event)
+			}
+		}
+	}
+
+	calendar := &models.Calendar{Month: prefix, TimeZone: loc.String(), Days: []models.CalendarDay{}}
+	for date, dayEvents := range byDate {
+		calendar.Days = append(calendar.Days, models.CalendarDay{Date: date, Events: dayEvents})
+	}
+	sort.Slice(calendar.Days, func(i, j int) bool { return calendar.Days[i].Date < calendar.Days[j].Date })
+	return calendar, nil
+}
+
+// eventDates returns the YYYY-MM-DD dates event falls on in loc
+func eventDates(event *models.Event, loc *time.Location) []string {
+	start, last := time.UnixMilli(event.StartsAt).In(loc), time.UnixMilli(event.EndsAt-1).In(loc)
+	if event.AllDay {
+		start, last = start.UTC(), last.UTC()
+	}
+	if event.EndsAt <= event.StartsAt {
+		last = start
+	}
+
+	var dates []string
+	d := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for end := last.Format("2006-01-02"); ; d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		dates = append(dates, date)
+		if date >= end {
+			return dates
+		}
+	}
+}
+
+func (s *service) Subscribe(ctx context.Context, userID uuid.UUID) (*models.Subscription, error) {
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UnixMilli()
+	if err := s.repo.SaveToken(ctx, userID, hashToken(token), now); err != nil {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	return &models.Subscription{URL: s.links.FeedURL + "?token=" + token, CreatedDate: now}, nil
+}
+
+func (s *service) Unsubscribe(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.repo.DeleteToken(ctx, userID); err != nil {
+		return fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+func (s *service) Feed(ctx context.Context, token string) ([]byte, error) {
+	if token == "" {
+		return nil, calendarErrors.ErrInvalidToken
+	}
+	if _, err := s.repo.FindTokenUser(ctx, hashToken(token)); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, calendarErrors.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+
+	return s.feed(ctx, s.repo.ListBetween)
+}
+
+func (s *service) SubscribeCommunity(ctx context.Context, communityID, userID uuid.UUID) (*models.Subscription, error) {
+	if err := s.checkMember(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().UnixMilli()
+	if err := s.repo.SaveCommunityToken(ctx, communityID, userID, hashToken(token), now); err != nil {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	feedURL := fmt.Sprintf("%s/communities/%s/calendar/feed.ics?token=%s", strings.TrimRight(s.links.APIURL, "/"), communityID, token)
+	return &models.Subscription{URL: feedURL, CreatedDate: now}, nil
+}
+
+func (s *service) UnsubscribeCommunity(ctx context.Context, communityID, userID uuid.UUID) error {
+	if _, err := s.repo.DeleteCommunityToken(ctx, communityID, userID); err != nil {
+		return fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+func (s *service) CommunityFeed(ctx context.Context, communityID uuid.UUID, token string) ([]byte, error) {
+	if token == "" {
+		return nil, calendarErrors.ErrInvalidToken
+	}
+	userID, err := s.repo.FindCommunityTokenUser(ctx, communityID, hashToken(token))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, calendarErrors.ErrInvalidToken
+		}
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	// Leaving the community ends the subscription
+	if err := s.checkMember(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.feed(ctx, communityEvents(s.repo, communityID))
+}
+
+// checkMember lets only members of communityID subscribe to its calendar
+func (s *service) checkMember(ctx context.Context, communityID, userID uuid.UUID) error {
+	if s.communities == nil {
+		return calendarErrors.ErrMembershipRequired
+	}
+	member, err := s.communities.IsCommunityMember(ctx, communityID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	if !member {
+		return calendarErrors.ErrMembershipRequired
+	}
+	return nil
+}
+
+// feed renders the events list returns from FeedPast ago on
+func (s *service) feed(ctx context.Context, list listEvents) ([]byte, error) {
+	limit := s.cfg.FeedLimit
+	if limit <= 0 {
+		limit = 500
+	}
+	now := s.now()
+	events, err := list(ctx, now.Add(-s.cfg.FeedPast).UnixMilli(), math.MaxInt64, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", calendarErrors.ErrDatabaseOperation, err)
+	}
+	return renderICal(events, s.links.WebURL, now), nil
+}
+
+// newToken returns a random calendar token to put in a feed URL
+func newToken() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("generate calendar token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(secret), nil
+}
+
+// hashToken is what is stored for a calendar token, so a database leak does not
+// expose working feed URLs
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,291 @@
+package services
+
+import (
+	"context"
+	"math"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	calendarErrors "github.com/qolzam/telar/apps/api/calendar/errors"
+	"github.com/qolzam/telar/apps/api/calendar/models"
+	"github.com/qolzam/telar/apps/api/calendar/repository"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testConfig = platformconfig.CalendarConfig{Enabled: true, FeedLimit: 50, FeedPast: 24 * time.Hour}
+	testLinks  = Links{FeedURL: "https://api.example.com/calendar/feed.ics", APIURL: "https://api.example.com", WebURL: "https://example.com"}
+)
+
+// stubMembers are the members of every community
+type stubMembers map[uuid.UUID]bool
+
+func (m stubMembers) IsCommunityMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return m[userID], nil
+}
+
+func (m stubMembers) IsCommunityModerator(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (m stubMembers) IsCommunityArchived(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (m stubMembers) AllowsAnonymousPosts(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (m stubMembers) RequiresApproval(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+type stubPosts map[uuid.UUID]*postsModels.Post
+
+func (p stubPosts) GetPost(ctx context.Context, postID uuid.UUID) (*postsModels.Post, error) {
+	if post, ok := p[postID]; ok {
+		return post, nil
+	}
+	return nil, postsErrors.ErrPostNotFound
+}
+
+func newTestService(repo *MockRepository, posts stubPosts, now time.Time) *service {
+	svc := NewService(repo, posts, testConfig, testLinks).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func millis(year int, month time.Month, day, hour, min int) int64 {
+	return time.Date(year, month, day, hour, min, 0, 0, time.UTC).UnixMilli()
+}
+
+func TestSetEvent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	ownerID := uuid.Must(uuid.NewV4())
+	post := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: ownerID, Body: "Autumn meetup\nBring snacks", URLKey: "autumn-meetup"}
+	anonymous := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: ownerID, Anonymous: true}
+	posts := stubPosts{post.ObjectId: post, anonymous.ObjectId: anonymous}
+
+	t.Run("titles a new event after the post's first line", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetEvent", ctx, post.ObjectId).Return(nil, repository.ErrNotFound).Once()
+		repo.On("SaveEvent", ctx, mock.AnythingOfType("*models.Event")).Return(nil).Once()
+
+		event, err := newTestService(repo, posts, now).SetEvent(ctx, ownerID, post.ObjectId, &models.SetEventRequest{
+			StartsAt: millis(2026, 10, 24, 18, 0),
+			Location: "  Town hall ",
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		assert.Equal(t, "Autumn meetup", event.Title)
+		assert.Equal(t, "Town hall", event.Location)
+		assert.Equal(t, event.StartsAt, event.EndsAt)
+		assert.Equal(t, now.UnixMilli(), event.CreatedDate)
+		assert.Equal(t, "autumn-meetup", event.URLKey)
+	})
+
+	t.Run("rescheduling keeps the creation date", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetEvent", ctx, post.ObjectId).Return(&models.Event{CreatedDate: 42}, nil).Once()
+		repo.On("SaveEvent", ctx, mock.AnythingOfType("*models.Event")).Return(nil).Once()
+
+		event, err := newTestService(repo, posts, now).SetEvent(ctx, ownerID, post.ObjectId, &models.SetEventRequest{
+			Title:    "Meetup",
+			StartsAt: millis(2026, 10, 24, 18, 0),
+			EndsAt:   millis(2026, 10, 24, 20, 0),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(42), event.CreatedDate)
+		assert.Equal(t, now.UnixMilli(), event.LastUpdated)
+	})
+
+	t.Run("all-day events span whole UTC days", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetEvent", ctx, post.ObjectId).Return(nil, repository.ErrNotFound).Once()
+		repo.On("SaveEvent", ctx, mock.AnythingOfType("*models.Event")).Return(nil).Once()
+
+		event, err := newTestService(repo, posts, now).SetEvent(ctx, ownerID, post.ObjectId, &models.SetEventRequest{
+			StartsAt: millis(2026, 10, 24, 9, 30),
+			EndsAt:   millis(2026, 10, 25, 15, 0),
+			AllDay:   true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, millis(2026, 10, 24, 0, 0), event.StartsAt)
+		assert.Equal(t, millis(2026, 10, 26, 0, 0), event.EndsAt)
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		cases := map[string]*models.SetEventRequest{
+			"no start":       {Title: "x"},
+			"ends too early": {Title: "x", StartsAt: millis(2026, 10, 24, 18, 0), EndsAt: millis(2026, 10, 24, 17, 0)},
+			"too long":       {Title: "x", StartsAt: millis(2026, 10, 1, 0, 0), EndsAt: millis(2026, 11, 2, 0, 0)},
+			"long title":     {Title: strings.Repeat("x", maxTitleLength+1), StartsAt: millis(2026, 10, 24, 18, 0)},
+		}
+		for name, req := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := newTestService(new(MockRepository), posts, now).SetEvent(ctx, ownerID, post.ObjectId, req)
+				assert.ErrorIs(t, err, calendarErrors.ErrInvalidRequest)
+			})
+		}
+	})
+
+	t.Run("only the owner may schedule a post", func(t *testing.T) {
+		_, err := newTestService(new(MockRepository), posts, now).SetEvent(ctx, uuid.Must(uuid.NewV4()), post.ObjectId, &models.SetEventRequest{StartsAt: 1})
+		assert.ErrorIs(t, err, calendarErrors.ErrForbidden)
+	})
+
+	t.Run("anonymous posts cannot be events", func(t *testing.T) {
+		_, err := newTestService(new(MockRepository), posts, now).SetEvent(ctx, ownerID, anonymous.ObjectId, &models.SetEventRequest{StartsAt: 1})
+		assert.ErrorIs(t, err, calendarErrors.ErrInvalidRequest)
+	})
+
+	t.Run("missing posts", func(t *testing.T) {
+		_, err := newTestService(new(MockRepository), posts, now).SetEvent(ctx, ownerID, uuid.Must(uuid.NewV4()), &models.SetEventRequest{StartsAt: 1})
+		assert.ErrorIs(t, err, calendarErrors.ErrPostNotFound)
+	})
+}
+
+func TestGetEvent_HidesNonPublicPosts(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.Must(uuid.NewV4())
+	post := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: ownerID, Permission: "OnlyMe"}
+	event := &models.Event{PostId: post.ObjectId}
+	repo := new(MockRepository)
+	repo.On("GetEvent", ctx, post.ObjectId).Return(event, nil).Once()
+	svc := newTestService(repo, stubPosts{post.ObjectId: post}, time.Now())
+
+	_, err := svc.GetEvent(ctx, uuid.Must(uuid.NewV4()), post.ObjectId)
+	assert.ErrorIs(t, err, calendarErrors.ErrEventNotFound)
+
+	got, err := svc.GetEvent(ctx, ownerID, post.ObjectId)
+	require.NoError(t, err)
+	assert.Equal(t, event, got)
+}
+
+func TestCalendar(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	meetup := &models.Event{Title: "Meetup", StartsAt: millis(2026, 10, 24, 18, 0), EndsAt: millis(2026, 10, 24, 20, 0)}
+	festival := &models.Event{Title: "Festival", StartsAt: millis(2026, 10, 30, 0, 0), EndsAt: millis(2026, 11, 2, 0, 0), AllDay: true}
+	lateNight := &models.Event{Title: "Late", StartsAt: millis(2026, 10, 31, 23, 30), EndsAt: millis(2026, 10, 31, 23, 30)}
+
+	t.Run("groups the current month's events per day", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListBetween", ctx, millis(2026, 9, 30, 0, 0), millis(2026, 11, 2, 0, 0), calendarLimit).
+			Return([]*models.Event{meetup, festival, lateNight}, nil).Once()
+
+		calendar, err := newTestService(repo, nil, now).Calendar(ctx, "", "")
+		require.NoError(t, err)
+		assert.Equal(t, "2026-10", calendar.Month)
+		assert.Equal(t, "UTC", calendar.TimeZone)
+		require.Len(t, calendar.Days, 3)
+		assert.Equal(t, "2026-10-24", calendar.Days[0].Date)
+		assert.Equal(t, []*models.Event{meetup}, calendar.Days[0].Events)
+		assert.Equal(t, "2026-10-30", calendar.Days[1].Date)
+		assert.Equal(t, "2026-10-31", calendar.Days[2].Date)
+		assert.Equal(t, []*models.Event{festival, lateNight}, calendar.Days[2].Events)
+	})
+
+	t.Run("uses the requested time zone for timed events only", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListBetween", ctx, mock.Anything, mock.Anything, calendarLimit).
+			Return([]*models.Event{festival, lateNight}, nil).Once()
+
+		calendar, err := newTestService(repo, nil, now).Calendar(ctx, "2026-11", "Europe/Berlin")
+		require.NoError(t, err)
+		require.Len(t, calendar.Days, 1)
+		assert.Equal(t, "2026-11-01", calendar.Days[0].Date)
+		assert.Equal(t, []*models.Event{festival, lateNight}, calendar.Days[0].Events)
+	})
+
+	t.Run("rejects bad parameters", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), nil, now)
+		_, err := svc.Calendar(ctx, "October", "")
+		assert.ErrorIs(t, err, calendarErrors.ErrInvalidRequest)
+		_, err = svc.Calendar(ctx, "", "Mars/Olympus")
+		assert.ErrorIs(t, err, calendarErrors.ErrInvalidRequest)
+	})
+}
+
+func TestSubscriptionFeed(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	userID := uuid.Must(uuid.NewV4())
+
+	var storedHash string
+	repo := new(MockRepository)
+	repo.On("SaveToken", ctx, userID, mock.AnythingOfType("string"), now.UnixMilli()).
+		Run(func(args mock.Arguments) { storedHash = args.String(2) }).Return(nil).Once()
+	svc := newTestService(repo, nil, now)
+
+	subscription, err := svc.Subscribe(ctx, userID)
+	require.NoError(t, err)
+	feedURL, err := url.Parse(subscription.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "/calendar/feed.ics", feedURL.Path)
+	token := feedURL.Query().Get("token")
+	require.NotEmpty(t, token)
+	assert.Equal(t, hashToken(token), storedHash)
+	assert.NotContains(t, storedHash, token)
+
+	repo.On("FindTokenUser", ctx, storedHash).Return(userID, nil).Once()
+	repo.On("ListBetween", ctx, now.Add(-24*time.Hour).UnixMilli(), int64(math.MaxInt64), 50).
+		Return([]*models.Event{{PostId: uuid.Must(uuid.NewV4()), Title: "Meetup", StartsAt: millis(2026, 10, 24, 18, 0), EndsAt: millis(2026, 10, 24, 20, 0)}}, nil).Once()
+	feed, err := svc.Feed(ctx, token)
+	require.NoError(t, err)
+	assert.Contains(t, string(feed), "SUMMARY:Meetup\r\n")
+
+	repo.On("FindTokenUser", ctx, hashToken("stale")).Return(uuid.Nil, repository.ErrNotFound).Once()
+	_, err = svc.Feed(ctx, "stale")
+	assert.ErrorIs(t, err, calendarErrors.ErrInvalidToken)
+	_, err = svc.Feed(ctx, "")
+	assert.ErrorIs(t, err, calendarErrors.ErrInvalidToken)
+	repo.AssertExpectations(t)
+}
+
+func TestCommunitySubscriptionFeed(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	communityID := uuid.Must(uuid.NewV4())
+	memberID := uuid.Must(uuid.NewV4())
+	members := stubMembers{memberID: true}
+
+	var storedHash string
+	repo := new(MockRepository)
+	repo.On("SaveCommunityToken", ctx, communityID, memberID, mock.AnythingOfType("string"), now.UnixMilli()).
+		Run(func(args mock.Arguments) { storedHash = args.String(3) }).Return(nil).Once()
+	svc := newTestService(repo, nil, now)
+	svc.SetCommunityChecker(members)
+
+	_, err := svc.SubscribeCommunity(ctx, communityID, uuid.Must(uuid.NewV4()))
+	assert.ErrorIs(t, err, calendarErrors.ErrMembershipRequired, "only members subscribe")
+
+	subscription, err := svc.SubscribeCommunity(ctx, communityID, memberID)
+	require.NoError(t, err)
+	feedURL, err := url.Parse(subscription.URL)
+	require.NoError(t, err)
+	assert.Equal(t, "/communities/"+communityID.String()+"/calendar/feed.ics", feedURL.Path)
+	token := feedURL.Query().Get("token")
+	assert.Equal(t, hashToken(token), storedHash)
+
+	repo.On("FindCommunityTokenUser", ctx, communityID, storedHash).Return(memberID, nil)
+	repo.On("ListCommunityBetween", ctx, communityID, now.Add(-24*time.Hour).UnixMilli(), int64(math.MaxInt64), 50).
+		Return([]*models.Event{{PostId: uuid.Must(uuid.NewV4()), Title: "Meetup", StartsAt: millis(2026, 10, 24, 18, 0), EndsAt: millis(2026, 10, 24, 20, 0)}}, nil).Once()
+	feed, err := svc.CommunityFeed(ctx, communityID, token)
+	require.NoError(t, err)
+	assert.Contains(t, string(feed), "SUMMARY:Meetup\r\n")
+
+	delete(members, memberID)
+	_, err = svc.CommunityFeed(ctx, communityID, token)
+	assert.ErrorIs(t, err, calendarErrors.ErrMembershipRequired, "leaving the community ends the subscription")
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,95 @@
+// Command datamigrate copies the legacy JSONB collections to their typed tables and
+// checks the result. Run it while DATA_MIGRATION_DUAL_WRITE mirrors new writes:
+//
+//	datamigrate -mode backfill -collections userAuth,userProfile
+//	datamigrate -mode verify -collections userAuth,userProfile
+//
+// A backfill resumes from its checkpoint; verify exits non-zero until both sides agree.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/bootstrap"
+	"github.com/qolzam/telar/apps/api/internal/database/jsonbmigrate"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+func main() {
+	var mode, collections, legacySchema string
+	var backfill jsonbmigrate.BackfillOptions
+	var verify jsonbmigrate.VerifyOptions
+	var timeout time.Duration
+	flag.StringVar(&mode, "mode", "verify", "backfill or verify")
+	flag.StringVar(&collections, "collections", strings.Join(jsonbmigrate.Collections(), ","), "comma separated legacy collections to migrate")
+	flag.StringVar(&legacySchema, "legacy-schema", "public", "schema of the legacy collection tables")
+	flag.IntVar(&backfill.BatchSize, "batch", 500, "rows read per batch; a backfill checkpoints after each")
+	flag.DurationVar(&backfill.Pause, "pause", 0, "wait between backfill batches")
+	flag.BoolVar(&backfill.Restart, "restart", false, "ignore the backfill checkpoint and copy from the first row")
+	flag.IntVar(&verify.MaxSamples, "samples", 20, "mismatches listed per collection by verify")
+	flag.DurationVar(&timeout, "timeout", 6*time.Hour, "overall timeout for the run")
+	flag.Parse()
+	verify.BatchSize = backfill.BatchSize
+
+	var names []string
+	for _, name := range strings.Split(collections, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	mappings, err := jsonbmigrate.Lookup(names)
+	if err != nil {
+		log.Fatalf("Invalid -collections: %v", err)
+	}
+
+	cfg, err := platformconfig.LoadFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load platform config: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	pgClient, err := bootstrap.NewPostgresClient(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to create postgres client: %v", err)
+	}
+	defer pgClient.Close()
+
+	db := pgClient.DB()
+	migrator := jsonbmigrate.NewMigrator(db, jsonbmigrate.NewPostgresSource(db, legacySchema), jsonbmigrate.NewPostgresCheckpoints(db))
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	switch mode {
+	case "backfill":
+		for _, m := range mappings {
+			checkpoint, err := migrator.Backfill(ctx, m, backfill)
+			if err != nil {
+				log.Fatalf("Backfill of %s stopped: %v", m.Collection(), err)
+			}
+			log.Printf("Backfilled %s into %s: %d copied, %d failed", m.Collection(), m.Table(), checkpoint.Copied, checkpoint.Failed)
+		}
+	case "verify":
+		clean := true
+		for _, m := range mappings {
+			report, err := migrator.Verify(ctx, m, verify)
+			if err != nil {
+				log.Fatalf("Verify of %s stopped: %v", m.Collection(), err)
+			}
+			encoder.Encode(report)
+			clean = clean && report.Clean()
+		}
+		if !clean {
+			os.Exit(1)
+		}
+	default:
+		log.Fatalf("Unknown -mode %q: use backfill or verify", mode)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+)
+
+// modulePath is the import path of the API module the generator runs in
+const modulePath = "github.com/qolzam/telar/apps/api/"
+
+// docIndex reads the doc comments of Go types and fields from the module's sources,
+// so the generated declarations carry them
+type docIndex struct {
+	root     string                       // API module directory
+	packages map[string]map[string]string // by import path: "Type" and "Type.Field" to doc
+}
+
+func newDocIndex(root string) *docIndex {
+	return &docIndex{root: root, packages: make(map[string]map[string]string)}
+}
+
+func (d *docIndex) typeDoc(t reflect.Type) string {
+	return d.lookup(t.PkgPath(), t.Name())
+}
+
+func (d *docIndex) fieldDoc(owner reflect.Type, field string) string {
+	if owner.Name() == "" {
+		return ""
+	}
+	return d.lookup(owner.PkgPath(), owner.Name()+"."+field)
+}
+
+func (d *docIndex) lookup(pkgPath, key string) string {
+	docs, ok := d.packages[pkgPath]
+	if !ok {
+		docs = d.parse(pkgPath)
+		d.packages[pkgPath] = docs
+	}
+	return docs[key]
+}
+
+// parse collects the docs of the package's type declarations. Packages outside the
+// module, or that fail to parse, have none.
+func (d *docIndex) parse(pkgPath string) map[string]string {
+	docs := make(map[string]string)
+	if !strings.HasPrefix(pkgPath, modulePath) {
+		return docs
+	}
+	dir := filepath.Join(d.root, filepath.FromSlash(strings.TrimPrefix(pkgPath, modulePath)))
+	filter := func(info os.FileInfo) bool { return !strings.HasSuffix(info.Name(), "_test.go") }
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, filter, parser.ParseComments)
+	if err != nil {
+		return docs
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					doc := typeSpec.Doc
+					if doc == nil && len(gen.Specs) == 1 {
+						doc = gen.Doc
+					}
+					docs[typeSpec.Name.Name] = commentText(doc)
+
+					structType, ok := typeSpec.Type.(*ast.StructType)
+					if !ok {
+						continue
+					}
+					for _, f := range structType.Fields.List {
+						text := commentText(f.Doc)
+						if text == "" {
+							text = commentText(f.Comment)
+						}
+						for _, name := range f.Names {
+							docs[typeSpec.Name.Name+"."+name.Name] = text
+						}
+					}
+				}
+			}
+		}
+	}
+	return docs
+}
+
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	return strings.TrimSpace(group.Text())
+}
+
+// goName names t the way the module's developers would, e.g. "posts/models.PostResponse"
+func goName(t reflect.Type) string {
+	return strings.TrimPrefix(t.PkgPath(), modulePath) + "." + t.Name()
+}
+
+// moduleOf returns the feature module declaring t, e.g. "posts" for posts/models
+func moduleOf(t reflect.Type) string {
+	parts := strings.Split(strings.TrimPrefix(t.PkgPath(), modulePath), "/")
+	if parts[0] == "pkg" && len(parts) > 1 {
+		return parts[1]
+	}
+	return parts[0]
+}
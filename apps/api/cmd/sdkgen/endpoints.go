@@ -0,0 +1,197 @@
+package main
+
+import (
+	"reflect"
+
+	"github.com/qolzam/telar/apps/api/auth/login"
+	commentModels "github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/pkg/client"
+	postModels "github.com/qolzam/telar/apps/api/posts/models"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	voteHandlers "github.com/qolzam/telar/apps/api/votes/handlers"
+	voteModels "github.com/qolzam/telar/apps/api/votes/models"
+)
+
+// endpoint describes one API call of the generated client
+type endpoint struct {
+	Service  string       // Client namespace, e.g. "posts"
+	Name     string       // Method name within the namespace
+	Doc      string       // First line of the method's JSDoc
+	Method   string       // HTTP method
+	Path     string       // Route as registered with fiber; :name segments become arguments
+	Query    []queryParam // Query string parameters
+	Body     reflect.Type // Request body, nil for none
+	Response reflect.Type // Response body, nil when the response carries nothing of use
+}
+
+// queryParam is a query string parameter. Slices are sent comma separated.
+type queryParam struct {
+	Name     string
+	Type     reflect.Type
+	Required bool
+}
+
+func typeOf[T any]() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+func query[T any](name string) queryParam {
+	return queryParam{Name: name, Type: typeOf[T]()}
+}
+
+func requiredQuery[T any](name string) queryParam {
+	return queryParam{Name: name, Type: typeOf[T](), Required: true}
+}
+
+// endpoints lists the calls the TypeScript client exposes. Request and response types
+// are the Go types the handlers decode and encode, so changing a model changes the
+// generated SDK; keep paths in step with the modules' routes.go.
+var endpoints = []endpoint{
+	{
+		Service: "auth", Name: "login", Doc: "Signs in with a username (email) and password",
+		Method: "POST", Path: "/auth/login",
+		Body: typeOf[login.LoginRequest](), Response: typeOf[client.Session](),
+	},
+	{
+		Service: "auth", Name: "verifyMfa", Doc: "Completes a sign-in held back for a second factor",
+		Method: "POST", Path: "/auth/login/mfa",
+		Body: typeOf[login.MFALoginModel](), Response: typeOf[client.Session](),
+	},
+
+	{
+		Service: "posts", Name: "create", Doc: "Publishes a post as the signed-in user",
+		Method: "POST", Path: "/posts/",
+		Body: typeOf[postModels.CreatePostRequest](), Response: typeOf[client.CreatedPost](),
+	},
+	{
+		Service: "posts", Name: "get", Doc: "Reads a post",
+		Method: "GET", Path: "/posts/:postId",
+		Response: typeOf[postModels.PostResponse](),
+	},
+	{
+		Service: "posts", Name: "getByUrlKey", Doc: "Reads a post by its URL key",
+		Method: "GET", Path: "/posts/urlkey/:urlkey",
+		Response: typeOf[postModels.PostResponse](),
+	},
+	{
+		Service: "posts", Name: "list", Doc: "Pages through posts, newest first unless sorted otherwise",
+		Method: "GET", Path: "/posts/",
+		Query: []queryParam{
+			query[string]("cursor"),
+			query[string]("snapshot"),
+			query[int]("limit"),
+			query[string]("owner"),
+			query[int]("postType"),
+			query[[]string]("tags"),
+			query[string]("search"),
+			query[string]("sortBy"),
+			query[string]("sortOrder"),
+		},
+		Response: typeOf[postModels.PostsListResponse](),
+	},
+	{
+		Service: "posts", Name: "update", Doc: "Changes the fields set in the request on a post",
+		Method: "PUT", Path: "/posts/",
+		Body: typeOf[postModels.UpdatePostRequest](),
+	},
+	{
+		Service: "posts", Name: "delete", Doc: "Deletes a post",
+		Method: "DELETE", Path: "/posts/:postId",
+	},
+
+	{
+		Service: "comments", Name: "create", Doc: "Comments on a post, or replies to a comment when parentCommentId is set",
+		Method: "POST", Path: "/comments/",
+		Body: typeOf[commentModels.CreateCommentRequest](), Response: typeOf[commentModels.CommentResponse](),
+	},
+	{
+		Service: "comments", Name: "get", Doc: "Reads a comment",
+		Method: "GET", Path: "/comments/:commentId",
+		Response: typeOf[commentModels.CommentResponse](),
+	},
+	{
+		Service: "comments", Name: "listByPost", Doc: "Pages through the root comments of a post",
+		Method: "GET", Path: "/comments/",
+		Query: []queryParam{
+			requiredQuery[string]("postId"),
+			query[string]("cursor"),
+			query[int]("limit"),
+			query[string]("sort"),
+		},
+		Response: typeOf[commentModels.CommentsListResponse](),
+	},
+	{
+		Service: "comments", Name: "listReplies", Doc: "Pages through the replies to a comment",
+		Method: "GET", Path: "/comments/:commentId/replies",
+		Query: []queryParam{
+			query[string]("cursor"),
+			query[int]("limit"),
+		},
+		Response: typeOf[commentModels.CommentsListResponse](),
+	},
+	{
+		Service: "comments", Name: "update", Doc: "Edits the text of a comment",
+		Method: "PUT", Path: "/comments/",
+		Body: typeOf[commentModels.UpdateCommentRequest](), Response: typeOf[commentModels.CommentResponse](),
+	},
+	{
+		Service: "comments", Name: "delete", Doc: "Deletes a comment and its replies",
+		Method: "DELETE", Path: "/comments/id/:commentId/post/:postId",
+	},
+	{
+		Service: "comments", Name: "toggleLike", Doc: "Likes a comment, or takes the like back",
+		Method: "POST", Path: "/comments/:commentId/like",
+		Response: typeOf[commentModels.CommentResponse](),
+	},
+
+	{
+		Service: "profiles", Name: "me", Doc: "Reads the signed-in user's profile",
+		Method: "GET", Path: "/profile/my",
+		Response: typeOf[profileModels.Profile](),
+	},
+	{
+		Service: "profiles", Name: "get", Doc: "Reads a user's profile",
+		Method: "GET", Path: "/profile/id/:userId",
+		Response: typeOf[profileModels.Profile](),
+	},
+	{
+		Service: "profiles", Name: "getBySocialName", Doc: "Reads a profile by social name",
+		Method: "GET", Path: "/profile/social/:name",
+		Response: typeOf[profileModels.Profile](),
+	},
+	{
+		Service: "profiles", Name: "getMany", Doc: "Reads the profiles of several users; unknown users are left out",
+		Method: "POST", Path: "/profile/ids",
+		Body: typeOf[[]string](), Response: typeOf[[]profileModels.Profile](),
+	},
+	{
+		Service: "profiles", Name: "search", Doc: "Finds profiles by name",
+		Method: "GET", Path: "/profile/search",
+		Query: []queryParam{
+			requiredQuery[string]("q"),
+			query[int]("limit"),
+		},
+		Response: typeOf[[]profileModels.Profile](),
+	},
+	{
+		Service: "profiles", Name: "update", Doc: "Changes the fields set in the request on the signed-in user's profile",
+		Method: "PUT", Path: "/profile/",
+		Body: typeOf[profileModels.UpdateProfileRequest](),
+	},
+
+	{
+		Service: "votes", Name: "set", Doc: "Sets the signed-in user's vote on a post",
+		Method: "POST", Path: "/posts/:postId/vote",
+		Body: typeOf[voteHandlers.SetVoteRequest](), Response: typeOf[voteModels.VoteChange](),
+	},
+	{
+		Service: "votes", Name: "listVoters", Doc: "Pages through the voters of a post",
+		Method: "GET", Path: "/posts/:postId/voters",
+		Query: []queryParam{
+			query[string]("type"),
+			query[string]("cursor"),
+			query[int]("limit"),
+		},
+		Response: typeOf[voteModels.VoterListResponse](),
+	},
+}
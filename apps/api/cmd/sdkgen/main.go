@@ -0,0 +1,93 @@
+// Command sdkgen generates the TypeScript types and fetch client of packages/sdk from
+// the Go request and response models, so the web app cannot drift from the API.
+//
+// Run it from apps/api ("make sdk-generate" does). With -check it writes nothing and
+// fails when the committed files differ from what it would generate, for CI.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+func main() {
+	root := flag.String("root", ".", "API module directory, read for doc comments")
+	out := flag.String("out", "../../packages/sdk/src/generated", "directory the TypeScript files are written to")
+	check := flag.Bool("check", false, "fail if the files in -out are not up to date instead of writing them")
+	flag.Parse()
+
+	files, err := generate(*root, endpoints)
+	if err != nil {
+		log.Fatalf("Failed to generate the SDK: %v", err)
+	}
+
+	if *check {
+		stale := staleFiles(*out, files)
+		if len(stale) > 0 {
+			log.Fatalf("Generated SDK is out of date (%s); run make sdk-generate", strings.Join(stale, ", "))
+		}
+		return
+	}
+
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatalf("Failed to create %s: %v", *out, err)
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(*out, name), content, 0o644); err != nil {
+			log.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	log.Printf("Wrote %d files to %s", len(files), *out)
+}
+
+// generate returns the generated files by name
+func generate(root string, endpoints []endpoint) (map[string][]byte, error) {
+	ts := newTypeScript(newDocIndex(root))
+	for _, e := range endpoints {
+		if e.Body != nil {
+			ts.collect(e.Body)
+		}
+		for _, param := range e.Query {
+			ts.collect(param.Type)
+		}
+		if e.Response != nil {
+			ts.collect(e.Response)
+		}
+	}
+	ts.resolveNames()
+
+	var models strings.Builder
+	models.WriteString(header)
+	models.WriteString("\n")
+	ts.declarations(&models)
+
+	var client strings.Builder
+	if err := writeClient(&client, ts, endpoints); err != nil {
+		return nil, err
+	}
+
+	index := header + "\nexport * from './models';\nexport * from './client';\n"
+	return map[string][]byte{
+		"models.ts": []byte(models.String()),
+		"client.ts": []byte(client.String()),
+		"index.ts":  []byte(index),
+	}, nil
+}
+
+// staleFiles lists the files in dir that differ from files, sorted
+func staleFiles(dir string, files map[string][]byte) []string {
+	var stale []string
+	for name, content := range files {
+		existing, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || !bytes.Equal(existing, content) {
+			stale = append(stale, name)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Widget struct {
+	ID       uuid.UUID         `json:"id"`
+	Name     string            `json:"name"`
+	Parts    []Part            `json:"parts,omitempty"`
+	Owner    *Part             `json:"owner"`
+	Labels   map[string]string `json:"labels"`
+	Count    int64             `json:"count,string"`
+	Created  time.Time         `json:"created"`
+	Hidden   string            `json:"-"`
+	internal string
+	Embedded
+}
+
+type Part struct {
+	Weight float64 `json:"weight"`
+}
+
+type Embedded struct {
+	Flag bool `json:"flag,omitempty"`
+}
+
+func TestDeclarations(t *testing.T) {
+	ts := newTypeScript(newDocIndex("."))
+	ts.collect(typeOf[Widget]())
+	ts.resolveNames()
+
+	var b strings.Builder
+	ts.declarations(&b)
+	out := b.String()
+
+	assert.Contains(t, out, "export interface Widget {\n  id: string;\n  name: string;\n  parts?: Part[];\n  owner: Part | null;\n  labels: Record<string, string>;\n  count: string;\n  created: string;\n  flag?: boolean;\n}")
+	assert.Contains(t, out, "/** @see Go: cmd/sdkgen.Part */\nexport interface Part {\n  weight: number;\n}")
+	assert.NotContains(t, out, "hidden")
+	assert.NotContains(t, out, "internal")
+	assert.NotContains(t, out, "interface Embedded")
+}
+
+func TestWriteMethod(t *testing.T) {
+	ts := newTypeScript(newDocIndex("."))
+	ts.collect(typeOf[Widget]())
+	ts.resolveNames()
+	ts.used = make(map[string]bool)
+
+	var b strings.Builder
+	require.NoError(t, writeMethod(&b, ts, endpoint{
+		Service: "widgets", Name: "update", Doc: "Updates a widget",
+		Method: "PUT", Path: "/widgets/:widgetId/parts/:partId",
+		Query:    []queryParam{requiredQuery[string]("mode"), query[[]string]("tags")},
+		Body:     typeOf[Part](),
+		Response: typeOf[[]Widget](),
+	}))
+
+	assert.Equal(t, `      /**
+       * Updates a widget
+       *
+       * PUT /widgets/:widgetId/parts/:partId
+       */
+      update: (widgetId: string, partId: string, body: Part, query: { mode: string; tags?: string[] }) =>
+        send<Widget[]>(options, 'PUT', `+"`/widgets/${encodeURIComponent(widgetId)}/parts/${encodeURIComponent(partId)}`"+`, query, body),
+`, b.String())
+	assert.Equal(t, map[string]bool{"Part": true, "Widget": true}, ts.used)
+}
+
+func TestGenerate(t *testing.T) {
+	files, err := generate("../..", endpoints)
+	require.NoError(t, err)
+
+	models := string(files["models.ts"])
+	assert.Contains(t, models, "/**\n * Album represents the post album structure\n *\n * @see Go: posts/models.Album\n */\nexport interface Album {")
+	assert.Contains(t, models, "  /** Unix milliseconds; the post is archived and leaves feeds after this time */\n  visibleUntil?: number;")
+	assert.Contains(t, string(files["client.ts"]), "export function createClient(options: ClientOptions)")
+}
+
+// TestGeneratedSDKUpToDate fails when a model changed without regenerating the SDK
+func TestGeneratedSDKUpToDate(t *testing.T) {
+	dir := "../../../../packages/sdk/src/generated"
+	if _, err := os.Stat(dir); err != nil {
+		t.Skip("packages/sdk is not checked out")
+	}
+
+	files, err := generate("../..", endpoints)
+	require.NoError(t, err)
+	assert.Empty(t, staleFiles(dir, files), "run make sdk-generate")
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	rawMessageType    = reflect.TypeFor[json.RawMessage]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// typeScript turns Go types into TypeScript declarations. Named structs become
+// interfaces declared once; everything else is written inline.
+type typeScript struct {
+	docs     *docIndex
+	declared map[reflect.Type]bool
+	order    []reflect.Type
+	names    map[reflect.Type]string
+	used     map[string]bool // Names expr referred to, when not nil
+}
+
+func newTypeScript(docs *docIndex) *typeScript {
+	return &typeScript{docs: docs, declared: make(map[reflect.Type]bool)}
+}
+
+// collect registers the named structs t refers to, depth first
+func (ts *typeScript) collect(t reflect.Type) {
+	t = indirect(t)
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if !isScalar(t) {
+			ts.collect(t.Elem())
+		}
+	case reflect.Map:
+		ts.collect(t.Elem())
+	case reflect.Struct:
+		if isScalar(t) || ts.declared[t] {
+			return
+		}
+		if t.Name() != "" {
+			ts.declared[t] = true
+			ts.order = append(ts.order, t)
+		}
+		for _, field := range fields(t) {
+			ts.collect(field.Type)
+		}
+	}
+}
+
+// resolveNames names every collected struct after its Go type, prefixed with its
+// package when two packages declare the same name
+func (ts *typeScript) resolveNames() {
+	count := make(map[string]int)
+	for _, t := range ts.order {
+		count[t.Name()]++
+	}
+	ts.names = make(map[reflect.Type]string, len(ts.order))
+	for _, t := range ts.order {
+		name := t.Name()
+		if count[name] > 1 {
+			name = exportName(moduleOf(t)) + name
+		}
+		ts.names[t] = name
+	}
+	sort.SliceStable(ts.order, func(i, j int) bool {
+		return ts.names[ts.order[i]] < ts.names[ts.order[j]]
+	})
+}
+
+// declarations writes an interface for every collected struct
+func (ts *typeScript) declarations(b *strings.Builder) {
+	for i, t := range ts.order {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		writeDoc(b, "", ts.docs.typeDoc(t), "@see Go: "+goName(t))
+		fmt.Fprintf(b, "export interface %s {\n", ts.names[t])
+		for _, field := range fields(t) {
+			writeDoc(b, "  ", ts.docs.fieldDoc(field.Owner, field.GoName))
+			fmt.Fprintf(b, "  %s;\n", ts.property(field))
+		}
+		b.WriteString("}\n")
+	}
+}
+
+// property returns the declaration of field inside an interface
+func (ts *typeScript) property(field field) string {
+	expr := ts.expr(field.Type, field.AsString)
+	switch {
+	case field.Optional:
+		return fmt.Sprintf("%s?: %s", propertyName(field.Name), expr)
+	case field.Type.Kind() == reflect.Pointer:
+		return fmt.Sprintf("%s: %s | null", propertyName(field.Name), expr)
+	default:
+		return fmt.Sprintf("%s: %s", propertyName(field.Name), expr)
+	}
+}
+
+// expr returns the TypeScript type of values of t as encoding/json writes them.
+// asString is set for fields tagged with the ",string" option.
+func (ts *typeScript) expr(t reflect.Type, asString bool) string {
+	if t.Kind() == reflect.Pointer {
+		return ts.expr(t.Elem(), asString)
+	}
+	switch {
+	case t == timeType:
+		return "string"
+	case t == rawMessageType:
+		return "unknown"
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return "unknown"
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return "string"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if asString {
+			return "string"
+		}
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if asString {
+			return "string"
+		}
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "string" // base64
+		}
+		return arrayOf(ts.expr(t.Elem(), false))
+	case reflect.Map:
+		return fmt.Sprintf("Record<string, %s>", ts.expr(t.Elem(), false))
+	case reflect.Struct:
+		if name, ok := ts.names[t]; ok {
+			if ts.used != nil {
+				ts.used[name] = true
+			}
+			return name
+		}
+		var b strings.Builder
+		b.WriteString("{ ")
+		for _, field := range fields(t) {
+			fmt.Fprintf(&b, "%s; ", ts.property(field))
+		}
+		b.WriteString("}")
+		return b.String()
+	default:
+		return "unknown"
+	}
+}
+
+// field is a struct field as encoding/json sees it
+type field struct {
+	Name     string       // JSON property name
+	GoName   string       // Go field name, for its doc comment
+	Owner    reflect.Type // Struct declaring the field; differs from the walked type for embedded fields
+	Type     reflect.Type
+	Optional bool // Left out when empty (omitempty, omitzero)
+	AsString bool // ",string" option
+}
+
+// fields lists the JSON properties of struct t, promoting embedded structs' fields
+// like encoding/json does
+func fields(t reflect.Type) []field {
+	var out []field
+	seen := make(map[string]bool)
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, opts, _ := strings.Cut(tag, ",")
+			if sf.Anonymous && name == "" && indirect(sf.Type).Kind() == reflect.Struct {
+				walk(indirect(sf.Type))
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+			out = append(out, field{
+				Name:     name,
+				GoName:   sf.Name,
+				Owner:    t,
+				Type:     sf.Type,
+				Optional: hasOption(opts, "omitempty") || hasOption(opts, "omitzero"),
+				AsString: hasOption(opts, "string"),
+			})
+		}
+	}
+	walk(t)
+	return out
+}
+
+func hasOption(opts, option string) bool {
+	for _, opt := range strings.Split(opts, ",") {
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+// isScalar reports whether encoding/json writes t as a single value despite its kind,
+// such as time.Time and uuid.UUID
+func isScalar(t reflect.Type) bool {
+	return t == timeType || t == rawMessageType ||
+		t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+		t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType)
+}
+
+func arrayOf(elem string) string {
+	if strings.ContainsAny(elem, " |") {
+		return "Array<" + elem + ">"
+	}
+	return elem + "[]"
+}
+
+// propertyName quotes JSON names that are not TypeScript identifiers
+func propertyName(name string) string {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && r >= '0' && r <= '9') {
+			return fmt.Sprintf("%q", name)
+		}
+	}
+	return name
+}
+
+func exportName(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// writeDoc writes a JSDoc block of the non-empty paragraphs
+func writeDoc(b *strings.Builder, indent string, paragraphs ...string) {
+	var lines []string
+	for _, paragraph := range paragraphs {
+		if paragraph = strings.TrimSpace(paragraph); paragraph == "" {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		for _, line := range strings.Split(paragraph, "\n") {
+			lines = append(lines, strings.ReplaceAll(strings.TrimRight(line, " "), "*/", "*\\/"))
+		}
+	}
+	switch len(lines) {
+	case 0:
+	case 1:
+		fmt.Fprintf(b, "%s/** %s */\n", indent, lines[0])
+	default:
+		fmt.Fprintf(b, "%s/**\n", indent)
+		for _, line := range lines {
+			if line == "" {
+				fmt.Fprintf(b, "%s *\n", indent)
+			} else {
+				fmt.Fprintf(b, "%s * %s\n", indent, line)
+			}
+		}
+		fmt.Fprintf(b, "%s */\n", indent)
+	}
+}
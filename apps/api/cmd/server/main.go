@@ -2,537 +2,114 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"os"
-	"strings"
 
-	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/qolzam/telar/apps/api/auth"
-	adminUC "github.com/qolzam/telar/apps/api/auth/admin"
-	adminRepository "github.com/qolzam/telar/apps/api/auth/admin/repository"
-	jwksUC "github.com/qolzam/telar/apps/api/auth/jwks"
-	loginUC "github.com/qolzam/telar/apps/api/auth/login"
-	oauthUC "github.com/qolzam/telar/apps/api/auth/oauth"
-	passwordUC "github.com/qolzam/telar/apps/api/auth/password"
-	authRepository "github.com/qolzam/telar/apps/api/auth/repository"
-	signupUC "github.com/qolzam/telar/apps/api/auth/signup"
-	verifyUC "github.com/qolzam/telar/apps/api/auth/verification"
-	"github.com/qolzam/telar/apps/api/bookmarks"
-	bookmarksHandlers "github.com/qolzam/telar/apps/api/bookmarks/handlers"
-	bookmarksRepository "github.com/qolzam/telar/apps/api/bookmarks/repository"
-	bookmarksServices "github.com/qolzam/telar/apps/api/bookmarks/services"
-	"github.com/qolzam/telar/apps/api/comments"
-	commentHandlers "github.com/qolzam/telar/apps/api/comments/handlers"
-	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
-	commentServices "github.com/qolzam/telar/apps/api/comments/services"
-	dbi "github.com/qolzam/telar/apps/api/internal/database/interfaces"
-	"github.com/qolzam/telar/apps/api/internal/database/postgres"
-	requestid "github.com/qolzam/telar/apps/api/internal/middleware/requestid"
-	platform "github.com/qolzam/telar/apps/api/internal/platform"
-	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
-	platformemail "github.com/qolzam/telar/apps/api/internal/platform/email"
-	"github.com/qolzam/telar/apps/api/internal/recaptcha"
-	"github.com/qolzam/telar/apps/api/internal/testutil"
-	signupOrchestrator "github.com/qolzam/telar/apps/api/orchestrator/signup"
-	"github.com/qolzam/telar/apps/api/posts"
-	"github.com/qolzam/telar/apps/api/posts/handlers"
-	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
-	postsServices "github.com/qolzam/telar/apps/api/posts/services"
-	"github.com/qolzam/telar/apps/api/profile"
-	profileRepository "github.com/qolzam/telar/apps/api/profile/repository"
-	profileServices "github.com/qolzam/telar/apps/api/profile/services"
-	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
-	"github.com/qolzam/telar/apps/api/votes"
-	votesHandlers "github.com/qolzam/telar/apps/api/votes/handlers"
-	votesRepository "github.com/qolzam/telar/apps/api/votes/repository"
-	votesServices "github.com/qolzam/telar/apps/api/votes/services"
-	"github.com/qolzam/telar/apps/api/storage"
-	storageHandlers "github.com/qolzam/telar/apps/api/storage/handlers"
-	storageProvider "github.com/qolzam/telar/apps/api/storage/provider"
-	storageRepository "github.com/qolzam/telar/apps/api/storage/repository"
-	storageServices "github.com/qolzam/telar/apps/api/storage/services"
+	"github.com/qolzam/telar/apps/api/internal/bootstrap"
+	"github.com/qolzam/telar/apps/api/internal/bootstrap/authmodule"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 )
 
 func main() {
-	cfg, err := platformconfig.LoadFromEnv()
+	cfg, err := bootstrap.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load platform config: %v", err)
+		log.Fatal("Failed to load platform config: %v", err)
 	}
 
-	app := fiber.New(fiber.Config{
-		// Disable default error handler that might interfere with custom responses
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			code := fiber.StatusInternalServerError
-			if e, ok := err.(*fiber.Error); ok {
-				code = e.Code
-			}
-			log.Printf("[ErrorHandler] Path: %s, Error: %v, Code: %d, ResponseSet: %d bytes",
-				c.Path(), err, code, len(c.Response().Body()))
-
-			// If response already set by handler, don't override it
-			if len(c.Response().Body()) > 0 {
-				log.Printf("[ErrorHandler] Response already set, passing through")
-				return nil
-			}
-
-			return c.Status(code).JSON(fiber.Map{
-				"error": err.Error(),
-			})
-		},
-	})
-
-	payloadSecret := cfg.HMAC.Secret
-	publicKey := cfg.JWT.PublicKey
-	privateKey := cfg.JWT.PrivateKey
-	webDomain := cfg.App.WebDomain
-	smtpEmail := cfg.Email.SMTPEmail
-	refEmail := cfg.Email.RefEmail
-	refEmailPass := cfg.Email.RefEmailPass
-
-	// Split comma-separated origins into a slice for Fiber CORS middleware
-	originList := strings.Split(webDomain, ",")
-	for i, origin := range originList {
-		originList[i] = strings.TrimSpace(origin)
-	}
-
-	// Create a map for fast origin lookup
-	allowedOrigins := make(map[string]bool)
-	for _, origin := range originList {
-		allowedOrigins[origin] = true
-	}
-
-	// Request ID middleware (must be early in the chain)
-	app.Use(requestid.New())
-
-	// CORS Configuration for Browser Direct Access
-	// Use AllowOriginsFunc to properly handle multiple origins
-	// IMPORTANT: When AllowCredentials is true, AllowOrigins cannot be "*"
-	// The function returns true if origin is in the allowed list, or if origin is empty (same-origin request)
-	app.Use(cors.New(cors.Config{
-		AllowOriginsFunc: func(origin string) bool {
-			// Empty origin means same-origin request (should be allowed)
-			if origin == "" {
-				return true
-			}
-			return allowedOrigins[origin]
-		},
-		AllowCredentials: true,
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Request-ID",
-		AllowMethods:     "GET, POST, PUT, DELETE, PATCH, OPTIONS",
-	}))
-
-	baseService, err := platform.NewBaseService(context.Background(), cfg)
+	ctx := context.Background()
+	infra, err := bootstrap.NewInfra(ctx, cfg)
 	if err != nil {
-		log.Fatalf("Failed to create base service: %v", err)
+		log.Fatal("Failed to initialize infrastructure: %v", err)
 	}
 
-	// Profile service will be initialized after repositories are created (see below)
-	var profileService profileServices.ProfileService
-	var profileCreator profileServices.ProfileServiceClient
-	var profileHandler *profile.ProfileHandler
-	var profileHandlers *profile.ProfileHandlers
-
-	// Admin service will be created after repositories are set up (see below)
-	var adminService *adminUC.Service
-	var adminHandler *adminUC.AdminHandler
-
-	// Create postgres client for repositories (used by orchestrator, signup service, and admin service)
-	ctx := context.Background()
-	pgConfig := &dbi.PostgreSQLConfig{
-		Host:               cfg.Database.Postgres.Host,
-		Port:               cfg.Database.Postgres.Port,
-		Username:           cfg.Database.Postgres.Username,
-		Password:           cfg.Database.Postgres.Password,
-		Database:           cfg.Database.Postgres.Database,
-		SSLMode:            cfg.Database.Postgres.SSLMode,
-		MaxOpenConnections: cfg.Database.Postgres.MaxOpenConns,
-		MaxIdleConnections: cfg.Database.Postgres.MaxIdleConns,
-		MaxLifetime:        int(cfg.Database.Postgres.ConnMaxLifetime.Seconds()),
-		ConnectTimeout:     10,
-	}
-	pgClient, err := postgres.NewClient(ctx, pgConfig, pgConfig.Database)
+	// Cross-service events go through the transactional outbox when it is enabled
+	eventOutbox, err := bootstrap.NewOutbox(ctx, infra)
 	if err != nil {
-		log.Fatalf("Failed to create postgres client for repositories: %v", err)
+		log.Fatal("Failed to create outbox: %v", err)
 	}
 
-	signupServiceConfig := &signupUC.ServiceConfig{
-		JWTConfig: platformconfig.JWTConfig{
-			PublicKey:  publicKey,
-			PrivateKey: privateKey,
-		},
-		HMACConfig: platformconfig.HMACConfig{
-			Secret: payloadSecret,
-		},
-		AppConfig: platformconfig.AppConfig{
-			WebDomain: webDomain,
-		},
+	// Streaks are recorded from analytics events by a background job and shown on profiles
+	streaksModule := bootstrap.NewStreaksModule(ctx, infra)
+	profileModule := bootstrap.NewProfileModule(ctx, infra, eventOutbox).WithStreaks(streaksModule.Service)
+	profileClient, err := bootstrap.NewProfileClient(cfg, profileModule.Service)
+	if err != nil {
+		log.Fatal("Failed to create profile client: %v", err)
 	}
-	// Create repositories early (will be reused)
-	authRepo := authRepository.NewPostgresAuthRepository(pgClient)
-	verifRepo := authRepository.NewPostgresVerificationRepository(pgClient)
-	profileRepo := profileRepository.NewPostgresProfileRepository(pgClient)
 
-	// Create signup service with verification repository
-	signupService := signupUC.NewService(verifRepo, signupServiceConfig)
-	if smtpHost := cfg.Email.SMTPHost; smtpHost != "" {
-		smtpPort := fmt.Sprintf("%d", cfg.Email.SMTPPort)
-		smtpUser := cfg.Email.SMTPUser
-		smtpPass := cfg.Email.SMTPPass
-		sender, err := platformemail.NewSMTPSender(smtpHost, smtpPort, smtpUser, smtpPass)
-		if err == nil {
-			signupService = signupService.WithEmailSender(sender)
-		}
+	// Experiments are created before auth, which embeds assignments in tokens
+	experimentsModule := bootstrap.NewExperimentsModule(infra)
+	authModule, err := authmodule.New(ctx, infra, authmodule.Deps{
+		Profiles:    profileClient,
+		Experiments: experimentsModule.Service,
+		Outbox:      eventOutbox.Events(),
+	})
+	if err != nil {
+		log.Fatal("Failed to create auth module: %v", err)
 	}
 
-	// SECURITY: Fail Closed - Enforce Recaptcha configuration
-	recaptchaKey := cfg.Security.RecaptchaKey
-	recaptchaDisabled := cfg.Security.RecaptchaDisabled
-
-	var recaptchaVerifier recaptcha.Verifier
-	var errRecaptcha error
-
-	if recaptchaKey == "" {
-		if !recaptchaDisabled {
-			// CRITICAL: Fail Closed. Do not allow server to start insecurely.
-			log.Fatalf("SECURITY ERROR: RECAPTCHA_KEY is missing. Configure it or set RECAPTCHA_DISABLED=true in config.")
-		}
-		// Explicitly Disabled (Dev/Test Mode)
-		log.Printf("SECURITY WARNING: Recaptcha is explicitly disabled via configuration. Using FakeVerifier.")
-		recaptchaVerifier = &testutil.FakeRecaptchaVerifier{ShouldSucceed: true}
-	} else {
-		// Production Mode
-		recaptchaVerifier, errRecaptcha = recaptcha.NewGoogleVerifier(recaptchaKey)
-		if errRecaptcha != nil {
-			log.Fatalf("Failed to initialize Google Recaptcha: %v", errRecaptcha)
-		}
+	// Posts and comments record the users they @mention
+	mentionsModule, err := bootstrap.NewMentionsModule(ctx, infra, profileClient, eventOutbox)
+	if err != nil {
+		log.Fatal("Failed to create mentions module: %v", err)
 	}
 
-	// Inject verifier into handler
-	signupHandler := signupUC.NewHandler(signupService, recaptchaVerifier, privateKey)
-
-	loginServiceConfig := &loginUC.ServiceConfig{
-		JWTConfig: platformconfig.JWTConfig{
-			PublicKey:  publicKey,
-			PrivateKey: privateKey,
-		},
-		HMACConfig: platformconfig.HMACConfig{
-			Secret: payloadSecret,
-		},
+	// Posts and comments reach each other directly, or over gRPC in microservices mode
+	commentCounter, err := bootstrap.NewCommentCounter(infra)
+	if err != nil {
+		log.Fatal("Failed to create comment counter: %v", err)
 	}
-	// Login service will be created after repositories are set up (see below)
-	var loginService *loginUC.Service
-	var loginHandler *loginUC.Handler
-
-	verifyServiceConfig := &verifyUC.ServiceConfig{
-		JWTConfig: platformconfig.JWTConfig{
-			PublicKey:  publicKey,
-			PrivateKey: privateKey,
-		},
-		HMACConfig: platformconfig.HMACConfig{
-			Secret: payloadSecret,
-		},
-		AppConfig: platformconfig.AppConfig{
-			OrgName:   "Telar",
-			WebDomain: webDomain,
-		},
+	postStatsUpdater, err := bootstrap.NewPostStatsUpdater(infra)
+	if err != nil {
+		log.Fatal("Failed to create post stats updater: %v", err)
 	}
-
-	// Create remaining repositories (using same DB connection pool)
-	adminRepo := adminRepository.NewPostgresAdminRepository(pgClient)
-	postRepo := postsRepository.NewPostgresRepository(pgClient)
-	commentRepo := commentRepository.NewPostgresCommentRepository(pgClient)
-	voteRepo := votesRepository.NewPostgresVoteRepository(pgClient)
-	bookmarkRepo := bookmarksRepository.NewPostgresRepository(pgClient)
-
-	// Initialize Profile service with repository (now that repositories are available)
-	profileService = profileServices.NewProfileService(profileRepo, cfg)
-
-	// Initialize profile handler (now that profileService is available)
-	profileHandler = profile.NewProfileHandler(profileService, platformconfig.JWTConfig{
-		PublicKey:  publicKey,
-		PrivateKey: privateKey,
-	}, platformconfig.HMACConfig{
-		Secret: payloadSecret,
+	postsModule, err := bootstrap.NewPostsModule(ctx, infra, bootstrap.PostsDeps{
+		Profiles: profileModule.Service,
+		Comments: commentCounter,
+		Mentions: mentionsModule,
+		Outbox:   eventOutbox,
 	})
-	profileHandlers = &profile.ProfileHandlers{
-		ProfileHandler: profileHandler,
-	}
-
-	// Decide which adapter to use based on deployment mode (now that profileService is initialized)
-	deploymentMode := os.Getenv("DEPLOYMENT_MODE")
-	if deploymentMode == "microservices" {
-		log.Println("🔌 Wiring Profile service using gRPC Adapter")
-		profileServiceAddr := os.Getenv("PROFILE_SERVICE_GRPC_ADDR")
-		if profileServiceAddr == "" {
-			profileServiceAddr = "localhost:50051"
-		}
-
-		grpcCreator, err := profile.NewGrpcAdapter(profileServiceAddr)
-		if err != nil {
-			log.Fatalf("Failed to create gRPC profile creator: %v", err)
-		}
-		profileCreator = grpcCreator
-		log.Printf("✅ Profile gRPC client connected to %s", profileServiceAddr)
-	} else {
-		log.Println("🔌 Wiring Profile service using Direct Call Adapter")
-		profileCreator = profile.NewDirectCallAdapter(profileService)
-		log.Println("✅ Profile direct call adapter initialized")
+	if err != nil {
+		log.Fatal("Failed to create posts module: %v", err)
 	}
-
-	// Create admin service with repositories (now that repositories are available)
-	adminService = adminUC.NewService(authRepo, profileRepo, adminRepo, privateKey, cfg)
-	adminHandler = adminUC.NewAdminHandler(adminService, platformconfig.JWTConfig{
-		PublicKey:  publicKey,
-		PrivateKey: privateKey,
-	}, platformconfig.HMACConfig{
-		Secret: payloadSecret,
+	commentsModule, err := bootstrap.NewCommentsModule(ctx, infra, bootstrap.CommentsDeps{
+		PostStats:     postStatsUpdater,
+		Mentions:      mentionsModule,
+		Outbox:        eventOutbox,
+		Notifications: true,
 	})
-
-	// Create login service with AuthRepository and ProfileCreator (now that authRepo and profileCreator are available)
-	loginService = loginUC.NewServiceWithProfileCreator(authRepo, profileCreator, loginServiceConfig)
-
-	// Create login handler now that loginService is initialized
-	loginHandlerConfig := &loginUC.HandlerConfig{
-		WebDomain:           webDomain,
-		PrivateKey:          privateKey,
-		HeaderCookieName:    "telar-header",
-		PayloadCookieName:   "telar-payload",
-		SignatureCookieName: "telar-signature",
-	}
-	loginHandler = loginUC.NewHandler(loginService, loginHandlerConfig)
-
-	// Create signup orchestrator
-	signupOrchestrator := signupOrchestrator.NewService(authRepo, profileRepo, verifRepo)
-
-	verifyService := verifyUC.NewServiceWithRepositoriesAndKeys(
-		verifRepo,
-		authRepo,
-		verifyServiceConfig,
-		privateKey,
-		cfg.App.OrgName,
-		cfg.App.WebDomain,
-		profileCreator,
-	)
-	// Inject orchestrator into verification service
-	verifyService.SetSignupOrchestrator(signupOrchestrator)
-
-	verifyHandlerConfig := &verifyUC.HandlerConfig{
-		PublicKey: publicKey,
-		OrgName:   "Telar",
-		WebDomain: webDomain,
-	}
-	verifyHandler := verifyUC.NewHandler(verifyService, verifyHandlerConfig)
-
-	passwordServiceConfig := &passwordUC.ServiceConfig{
-		JWTConfig: platformconfig.JWTConfig{
-			PublicKey:  publicKey,
-			PrivateKey: privateKey,
-		},
-		HMACConfig: platformconfig.HMACConfig{
-			Secret: payloadSecret,
-		},
-		EmailConfig: platformconfig.EmailConfig{
-			SMTPEmail:    smtpEmail,
-			RefEmail:     refEmail,
-			RefEmailPass: refEmailPass,
-		},
-		AppConfig: platformconfig.AppConfig{
-			WebDomain: webDomain,
-		},
-	}
-	// Create password service with repositories
-	passwordService := passwordUC.NewServiceWithRepositories(authRepo, verifRepo, passwordServiceConfig)
-
-	smtpHost := cfg.Email.SMTPHost
-	smtpPort := fmt.Sprintf("%d", cfg.Email.SMTPPort)
-	smtpUser := cfg.Email.SMTPUser
-	smtpPass := cfg.Email.SMTPPass
-	if smtpHost != "" {
-		sender, err := platformemail.NewSMTPSender(smtpHost, smtpPort, smtpUser, smtpPass)
-		if err != nil {
-			log.Printf("WARN: failed to initialize SMTP sender: %v", err)
-		} else {
-			passwordService = passwordService.WithEmailSender(sender)
-		}
-	}
-
-	passwordHandlerConfig := &passwordUC.HandlerConfig{
-		RefEmail:     refEmail,
-		RefEmailPass: refEmailPass,
-		SMTPEmail:    smtpEmail,
-		WebDomain:    webDomain,
-	}
-	passwordHandler, err := passwordUC.NewPasswordHandler(passwordService, passwordHandlerConfig)
 	if err != nil {
-		log.Fatalf("Failed to create password handler: %v", err)
+		log.Fatal("Failed to create comments module: %v", err)
 	}
 
-	oauthConfig := oauthUC.NewOAuthConfig(
-		webDomain,
-		"",
-		"",
-		"",
-		"",
+	achievementsModule, err := bootstrap.NewAchievementsModule(ctx, infra)
+	if err != nil {
+		log.Fatal("Failed to create achievements module: %v", err)
+	}
+
+	server := bootstrap.NewServer("Telar API Server", cfg).WithBrowserAccess().WithOutbox(eventOutbox).With(
+		authModule,
+		profileModule,
+		postsModule,
+		commentsModule,
+		bootstrap.NewVotesModule(ctx, infra, profileModule.Service, eventOutbox.Events()),
+		bootstrap.NewBookmarksModule(infra, postsModule.Service, eventOutbox.Events()),
+		bootstrap.NewSyncModule(eventOutbox, postsModule.Service, commentsModule.Service),
+		bootstrap.NewThanksModule(ctx, infra, postsModule.Service),
+		bootstrap.NewCalendarModule(infra, postsModule.Service),
+		bootstrap.NewCommunitiesModule(ctx, infra, postsModule.Service),
+		bootstrap.NewModerationModule(infra, postsModule, commentsModule, authModule.Suspender),
+		bootstrap.NewAnalyticsModule(infra),
+		experimentsModule,
+		bootstrap.NewFollowsModule(infra, profileModule.Service),
+		mentionsModule,
+		achievementsModule,
+		bootstrap.NewLeaderboardsModule(ctx, infra),
+		streaksModule,
+		bootstrap.NewRealtimeModule(infra),
+		bootstrap.NewSettingsModule(infra),
+		bootstrap.NewWebhooksModule(ctx, infra),
+		bootstrap.NewSystemModule(infra),
+		bootstrap.NewStorageModule(ctx, infra, postsModule.Service),
 	)
-
-	oauthServiceConfig := &oauthUC.ServiceConfig{
-		OAuthConfig: oauthConfig,
-		JWTConfig: platformconfig.JWTConfig{
-			PublicKey:  publicKey,
-			PrivateKey: privateKey,
-		},
-		HMACConfig: platformconfig.HMACConfig{
-			Secret: payloadSecret,
-		},
-		AppConfig: platformconfig.AppConfig{
-			WebDomain: webDomain,
-		},
-	}
-	oauthService := oauthUC.NewService(baseService, oauthServiceConfig)
-
-	stateStore := oauthUC.NewMemoryStateStore()
-	oauthHandlerConfig := &oauthUC.HandlerConfig{
-		WebDomain:  webDomain,
-		PrivateKey: privateKey,
-	}
-	oauthHandler := oauthUC.NewHandler(oauthService, oauthHandlerConfig, stateStore)
-
-	jwksHandler := jwksUC.NewHandler(publicKey, "telar-auth-key-1")
-
-	authHandlers := &auth.AuthHandlers{
-		AdminHandler:    adminHandler,
-		SignupHandler:   signupHandler,
-		LoginHandler:    loginHandler,
-		VerifyHandler:   verifyHandler,
-		PasswordHandler: passwordHandler,
-		OAuthHandler:    oauthHandler,
-		JWKSHandler:     jwksHandler,
+	if err := server.Listen(":9099"); err != nil {
+		log.Fatal("Server stopped: %v", err)
 	}
-
-	auth.RegisterRoutes(app, authHandlers, cfg)
-	profile.RegisterRoutes(app, profileHandlers, cfg)
-
-	// We'll re-initialize them after setting up the adapters
-	var commentsService commentServices.CommentService
-	var postsService postsServices.PostService
-
-	var commentCounter sharedInterfaces.CommentCounter
-	var postStatsUpdater sharedInterfaces.PostStatsUpdater
-
-	// Decide which adapters to use based on deployment mode (reuse same env var)
-	if deploymentMode == "microservices" {
-		log.Println("🔌 Wiring Posts and Comments services using gRPC Adapters")
-
-		commentsServiceAddr := os.Getenv("COMMENTS_SERVICE_GRPC_ADDR")
-		if commentsServiceAddr == "" {
-			commentsServiceAddr = "localhost:50052"
-		}
-
-		postsServiceAddr := os.Getenv("POSTS_SERVICE_GRPC_ADDR")
-		if postsServiceAddr == "" {
-			postsServiceAddr = "localhost:50053"
-		}
-
-		grpcCounter, err := comments.NewGrpcCounter(commentsServiceAddr)
-		if err != nil {
-			log.Fatalf("Failed to create gRPC comment counter: %v", err)
-		}
-		commentCounter = grpcCounter
-		log.Printf("✅ Comments gRPC client connected to %s", commentsServiceAddr)
-
-		grpcStatsUpdater, err := posts.NewGrpcStatsUpdater(postsServiceAddr)
-		if err != nil {
-			log.Fatalf("Failed to create gRPC post stats updater: %v", err)
-		}
-		postStatsUpdater = grpcStatsUpdater
-		log.Printf("✅ Posts gRPC client connected to %s", postsServiceAddr)
-	} else {
-		log.Println("🔌 Wiring Posts and Comments services using Direct Call Adapters")
-
-		// Create temporary service instances to get adapters
-		tempCommentsService := commentServices.NewCommentService(commentRepo, postRepo, cfg, nil)
-		tempPostsService := postsServices.NewPostService(postRepo, voteRepo, bookmarkRepo, cfg, nil, commentRepo)
-
-		// Create direct call adapters
-		commentCounter = comments.NewDirectCallCounter(tempCommentsService)
-		postStatsUpdater = posts.NewDirectCallStatsUpdater(tempPostsService)
-		log.Println("✅ Direct call adapters initialized")
-	}
-
-	// Re-initialize services with cross-service dependencies
-	commentsService = commentServices.NewCommentService(commentRepo, postRepo, cfg, postStatsUpdater)
-	postsService = postsServices.NewPostService(postRepo, voteRepo, bookmarkRepo, cfg, commentCounter, commentRepo)
-
-	// Index creation is now handled by SQL migrations
-	log.Println("✅ Posts service initialized (indexes managed via SQL migrations)")
-
-	postsHandler := handlers.NewPostHandler(postsService, cfg.JWT, cfg.HMAC)
-
-	postsHandlers := &posts.PostsHandlers{
-		PostHandler: postsHandler,
-	}
-
-	posts.RegisterRoutes(app, postsHandlers, cfg)
-
-	commentHandler := commentHandlers.NewCommentHandler(commentsService, cfg.JWT, cfg.HMAC)
-
-	commentRoutes := &comments.CommentsHandlers{
-		CommentHandler: commentHandler,
-	}
-
-	comments.RegisterRoutes(app, commentRoutes, cfg)
-
-	// Initialize votes service
-	votesService := votesServices.NewVoteService(voteRepo, postRepo)
-	log.Println("✅ Votes service initialized")
-
-	votesHandler := votesHandlers.NewVoteHandler(votesService, cfg.JWT, cfg.HMAC)
-
-	votesHandlers := &votes.VotesHandlers{
-		VoteHandler: votesHandler,
-	}
-
-	votes.RegisterRoutes(app, votesHandlers, cfg)
-
-	bookmarkService := bookmarksServices.NewService(bookmarkRepo, postsService)
-	bookmarkHandler := bookmarksHandlers.NewBookmarkHandler(bookmarkService)
-	bookmarkHandlers := &bookmarks.Handlers{
-		BookmarkHandler: bookmarkHandler,
-	}
-	bookmarks.RegisterRoutes(app, bookmarkHandlers, cfg)
-
-	// Initialize storage service
-	if cfg.Storage.BucketName != "" && cfg.Storage.AccessKeyID != "" {
-		// Create R2 provider
-		blobProvider, err := storageProvider.NewR2Provider(&cfg.Storage)
-		if err != nil {
-			log.Printf("⚠️  Warning: Failed to initialize storage provider: %v", err)
-			log.Println("⚠️  Storage endpoints will not be available")
-		} else {
-			// Create storage repository
-			storageRepo := storageRepository.NewPostgresRepository(pgClient)
-			
-			// Create storage service
-			storageService := storageServices.NewStorageService(storageRepo, blobProvider, cfg.Storage.BucketName, &cfg.Storage)
-			
-			// Create storage handler
-			storageHandler := storageHandlers.NewStorageHandler(storageService)
-			
-			// Create handlers struct
-			storageHandlersGroup := &storage.StorageHandlers{
-				StorageHandler: storageHandler,
-			}
-			
-			// Register routes
-			storage.RegisterRoutes(app, storageHandlersGroup, cfg)
-			log.Println("✅ Storage service initialized")
-		}
-	} else {
-		log.Println("⚠️  Storage configuration not found, storage endpoints disabled")
-	}
-
-	log.Printf("Starting Telar API Server (Auth + Profile + Posts + Comments + Votes + Bookmarks + Storage) on port 9099")
-	log.Fatal(app.Listen(":9099"))
 }
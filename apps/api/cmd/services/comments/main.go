@@ -2,62 +2,68 @@ package main
 
 import (
 	"context"
-	"log"
+	"os"
 
-	"github.com/gofiber/fiber/v2"
 	"github.com/qolzam/telar/apps/api/comments"
-	"github.com/qolzam/telar/apps/api/comments/handlers"
-	commentsServices "github.com/qolzam/telar/apps/api/comments/services"
-	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
-	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
-	"github.com/qolzam/telar/apps/api/internal/database/postgres"
-	dbi "github.com/qolzam/telar/apps/api/internal/database/interfaces"
-	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/bootstrap"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	profileRepository "github.com/qolzam/telar/apps/api/profile/repository"
+	profileServices "github.com/qolzam/telar/apps/api/profile/services"
+	pb "github.com/qolzam/telar/protos/gen/go/commentspb"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	cfg, err := platformconfig.LoadFromEnv()
+	cfg, err := bootstrap.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load platform config: %v", err)
+		log.Fatal("Failed to load platform config: %v", err)
 	}
 
-	app := fiber.New()
-
-	// Create postgres client for repositories
 	ctx := context.Background()
-	pgConfig := &dbi.PostgreSQLConfig{
-		Host:               cfg.Database.Postgres.Host,
-		Port:               cfg.Database.Postgres.Port,
-		Username:           cfg.Database.Postgres.Username,
-		Password:           cfg.Database.Postgres.Password,
-		Database:           cfg.Database.Postgres.Database,
-		SSLMode:            cfg.Database.Postgres.SSLMode,
-		MaxOpenConnections: cfg.Database.Postgres.MaxOpenConns,
-		MaxIdleConnections: cfg.Database.Postgres.MaxIdleConns,
-		MaxLifetime:        int(cfg.Database.Postgres.ConnMaxLifetime.Seconds()),
-		ConnectTimeout:     10,
-	}
-	pgClient, err := postgres.NewClient(ctx, pgConfig, pgConfig.Database)
+	infra, err := bootstrap.NewModuleInfra(ctx, cfg, "comments")
 	if err != nil {
-		log.Fatalf("Failed to create postgres client: %v", err)
+		log.Fatal("Failed to initialize infrastructure: %v", err)
 	}
 
-	// Initialize repositories
-	commentRepo := commentRepository.NewPostgresCommentRepository(pgClient)
-	postRepo := postsRepository.NewPostgresRepository(pgClient)
-
-	// Initialize services
-	commentsService := commentsServices.NewCommentService(commentRepo, postRepo, cfg, nil) // nil for postStatsUpdater for now
-
-	commentsHandler := handlers.NewCommentHandler(commentsService, cfg.JWT, cfg.HMAC)
+	// With the outbox, the posts service applies comment counts from comment events and
+	// comments follow profile changes
+	eventOutbox, err := bootstrap.NewOutbox(ctx, infra)
+	if err != nil {
+		log.Fatal("Failed to create outbox: %v", err)
+	}
 
-	commentsHandlers := &comments.CommentsHandlers{
-		CommentHandler: commentsHandler,
+	// Comments record their @mentions, which the posts service serves and keeps up to
+	// date; mentioned social names are resolved by the profile service
+	profileService := profileServices.NewProfileService(profileRepository.NewPostgresProfileRepository(infra.DB), cfg)
+	profileClient, err := bootstrap.NewProfileClient(cfg, profileService)
+	if err != nil {
+		log.Fatal("Failed to create profile client: %v", err)
+	}
+	mentionsModule, err := bootstrap.NewMentionsModule(ctx, infra, profileClient, nil)
+	if err != nil {
+		log.Fatal("Failed to create mentions module: %v", err)
+	}
+	commentsModule, err := bootstrap.NewCommentsModule(ctx, infra, bootstrap.CommentsDeps{
+		Mentions: mentionsModule,
+		Outbox:   eventOutbox,
+	})
+	if err != nil {
+		log.Fatal("Failed to create comments module: %v", err)
 	}
 
-	comments.RegisterRoutes(app, commentsHandlers, cfg)
+	// Start gRPC server if in microservices mode: the posts service counts comments through it
+	if os.Getenv("START_GRPC_SERVER") == "true" {
+		grpcPort := os.Getenv("GRPC_PORT")
+		if grpcPort == "" {
+			grpcPort = "50052"
+		}
+		bootstrap.ServeGRPC("Comments", grpcPort, cfg.GRPC, func(server *grpc.Server) {
+			pb.RegisterCommentsServiceServer(server, comments.NewGrpcServer(commentsModule.Service))
+		})
+	}
 
-	log.Printf("Starting Comments Service on port 8083")
-	log.Fatal(app.Listen(":8083"))
+	server := bootstrap.NewServer("Comments Service", cfg).WithOutbox(eventOutbox).With(commentsModule)
+	if err := server.Listen(":8083"); err != nil {
+		log.Fatal("Server stopped: %v", err)
+	}
 }
-
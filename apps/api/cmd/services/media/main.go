@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"os/signal"
+	"syscall"
+
+	"github.com/qolzam/telar/apps/api/internal/bootstrap"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+func main() {
+	cfg, err := bootstrap.LoadConfig()
+	if err != nil {
+		log.Fatal("Failed to load platform config: %v", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	infra, err := bootstrap.NewInfra(ctx, cfg)
+	if err != nil {
+		log.Fatal("Failed to initialize infrastructure: %v", err)
+	}
+
+	// The pipeline claims confirmed uploads from the files table, so it runs alongside
+	// the API servers, which should set STORAGE_IMAGE_PIPELINE_EMBEDDED=false
+	worker, err := bootstrap.NewImagePipeline(infra, bootstrap.NewPostImageUpdater(infra))
+	if err != nil {
+		log.Fatal("Failed to create image pipeline: %v", err)
+	}
+
+	log.Info("Media service processing uploads every %s", cfg.Storage.ImagePollInterval)
+	worker.Run(ctx)
+	log.Info("Media service stopped")
+}
@@ -2,65 +2,72 @@ package main
 
 import (
 	"context"
-	"log"
+	"os"
 
-	"github.com/gofiber/fiber/v2"
-	bookmarksRepository "github.com/qolzam/telar/apps/api/bookmarks/repository"
-	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
-	dbi "github.com/qolzam/telar/apps/api/internal/database/interfaces"
-	"github.com/qolzam/telar/apps/api/internal/database/postgres"
-	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/bootstrap"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 	"github.com/qolzam/telar/apps/api/posts"
-	"github.com/qolzam/telar/apps/api/posts/handlers"
-	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
-	postsServices "github.com/qolzam/telar/apps/api/posts/services"
-	votesRepository "github.com/qolzam/telar/apps/api/votes/repository"
+	profileRepository "github.com/qolzam/telar/apps/api/profile/repository"
+	profileServices "github.com/qolzam/telar/apps/api/profile/services"
+	pb "github.com/qolzam/telar/protos/gen/go/postspb"
+	"google.golang.org/grpc"
 )
 
 func main() {
-	cfg, err := platformconfig.LoadFromEnv()
+	cfg, err := bootstrap.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load platform config: %v", err)
+		log.Fatal("Failed to load platform config: %v", err)
 	}
 
-	app := fiber.New()
-
-	// Create postgres client for repositories
 	ctx := context.Background()
-	pgConfig := &dbi.PostgreSQLConfig{
-		Host:               cfg.Database.Postgres.Host,
-		Port:               cfg.Database.Postgres.Port,
-		Username:           cfg.Database.Postgres.Username,
-		Password:           cfg.Database.Postgres.Password,
-		Database:           cfg.Database.Postgres.Database,
-		SSLMode:            cfg.Database.Postgres.SSLMode,
-		MaxOpenConnections: cfg.Database.Postgres.MaxOpenConns,
-		MaxIdleConnections: cfg.Database.Postgres.MaxIdleConns,
-		MaxLifetime:        int(cfg.Database.Postgres.ConnMaxLifetime.Seconds()),
-		ConnectTimeout:     10,
-	}
-	pgClient, err := postgres.NewClient(ctx, pgConfig, pgConfig.Database)
+	infra, err := bootstrap.NewModuleInfra(ctx, cfg, "posts")
 	if err != nil {
-		log.Fatalf("Failed to create postgres client: %v", err)
+		log.Fatal("Failed to initialize infrastructure: %v", err)
 	}
 
-	// Create repositories
-	postRepo := postsRepository.NewPostgresRepository(pgClient)
-	voteRepo := votesRepository.NewPostgresVoteRepository(pgClient)
-	commentRepo := commentRepository.NewPostgresCommentRepository(pgClient)
-	bookmarkRepo := bookmarksRepository.NewPostgresRepository(pgClient)
-
-	// Create post service with repository
-	postsService := postsServices.NewPostService(postRepo, voteRepo, bookmarkRepo, cfg, nil, commentRepo)
+	// With the outbox, comment counts follow the events of the comments service, the AI
+	// knowledge base follows post events, and posts and mentions follow profile changes
+	eventOutbox, err := bootstrap.NewOutbox(ctx, infra)
+	if err != nil {
+		log.Fatal("Failed to create outbox: %v", err)
+	}
 
-	postsHandler := handlers.NewPostHandler(postsService, cfg.JWT, cfg.HMAC)
+	// Mentions are served here; mentioned social names are resolved by the profile service
+	profileService := profileServices.NewProfileService(profileRepository.NewPostgresProfileRepository(infra.DB), cfg)
+	profileClient, err := bootstrap.NewProfileClient(cfg, profileService)
+	if err != nil {
+		log.Fatal("Failed to create profile client: %v", err)
+	}
+	mentionsModule, err := bootstrap.NewMentionsModule(ctx, infra, profileClient, eventOutbox)
+	if err != nil {
+		log.Fatal("Failed to create mentions module: %v", err)
+	}
 
-	postsHandlers := &posts.PostsHandlers{
-		PostHandler: postsHandler,
+	// The posts service also hosts delegation grants and supporter tiers; it reads the
+	// acting account's profile directly
+	postsModule, err := bootstrap.NewPostsModule(ctx, infra, bootstrap.PostsDeps{
+		Profiles: profileService,
+		Mentions: mentionsModule,
+		Outbox:   eventOutbox,
+	})
+	if err != nil {
+		log.Fatal("Failed to create posts module: %v", err)
 	}
 
-	posts.RegisterRoutes(app, postsHandlers, cfg)
+	// Start gRPC server if in microservices mode: the comments service updates comment counts through it
+	if os.Getenv("START_GRPC_SERVER") == "true" {
+		grpcPort := os.Getenv("GRPC_PORT")
+		if grpcPort == "" {
+			grpcPort = "50053"
+		}
+		bootstrap.ServeGRPC("Posts", grpcPort, cfg.GRPC, func(server *grpc.Server) {
+			pb.RegisterPostsServiceServer(server, posts.NewGrpcServer(postsModule.Service))
+		})
+	}
 
-	log.Printf("Starting Posts Service on port 8082")
-	log.Fatal(app.Listen(":8082"))
+	// Communities are served here, next to the posts made in them
+	server := bootstrap.NewServer("Posts Service", cfg).WithOutbox(eventOutbox).With(postsModule, mentionsModule, bootstrap.NewCommunitiesModule(ctx, infra, postsModule.Service))
+	if err := server.Listen(":8082"); err != nil {
+		log.Fatal("Server stopped: %v", err)
+	}
 }
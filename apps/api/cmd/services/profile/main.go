@@ -2,65 +2,34 @@ package main
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"net"
 	"os"
 
-	"github.com/gofiber/fiber/v2"
-	"github.com/qolzam/telar/apps/api/internal/database/postgres"
-	dbi "github.com/qolzam/telar/apps/api/internal/database/interfaces"
-	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/bootstrap"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 	"github.com/qolzam/telar/apps/api/profile"
-	profileRepository "github.com/qolzam/telar/apps/api/profile/repository"
-	"github.com/qolzam/telar/apps/api/profile/services"
 	pb "github.com/qolzam/telar/protos/gen/go/profilepb"
 	"google.golang.org/grpc"
 )
 
 func main() {
-	cfg, err := platformconfig.LoadFromEnv()
+	cfg, err := bootstrap.LoadConfig()
 	if err != nil {
-		log.Fatalf("Failed to load platform config: %v", err)
+		log.Fatal("Failed to load platform config: %v", err)
 	}
 
-	app := fiber.New()
-
-	// Create postgres client for repositories
 	ctx := context.Background()
-	pgConfig := &dbi.PostgreSQLConfig{
-		Host:               cfg.Database.Postgres.Host,
-		Port:               cfg.Database.Postgres.Port,
-		Username:           cfg.Database.Postgres.Username,
-		Password:           cfg.Database.Postgres.Password,
-		Database:           cfg.Database.Postgres.Database,
-		SSLMode:            cfg.Database.Postgres.SSLMode,
-		MaxOpenConnections: cfg.Database.Postgres.MaxOpenConns,
-		MaxIdleConnections: cfg.Database.Postgres.MaxIdleConns,
-		MaxLifetime:        int(cfg.Database.Postgres.ConnMaxLifetime.Seconds()),
-		ConnectTimeout:     10,
-	}
-	pgClient, err := postgres.NewClient(ctx, pgConfig, pgConfig.Database)
+	infra, err := bootstrap.NewModuleInfra(ctx, cfg, "profile")
 	if err != nil {
-		log.Fatalf("Failed to create postgres client: %v", err)
+		log.Fatal("Failed to initialize infrastructure: %v", err)
 	}
 
-	// Create repository
-	profileRepo := profileRepository.NewPostgresProfileRepository(pgClient)
-
-	// Create profile service with repository
-	profileService := services.NewProfileService(profileRepo, cfg)
-
-	// Create profile service client adapter for gRPC
-	profileServiceClient := profile.NewDirectCallAdapter(profileService)
-
-	profileHandler := profile.NewProfileHandler(profileService, cfg.JWT, cfg.HMAC)
-
-	profileHandlers := &profile.ProfileHandlers{
-		ProfileHandler: profileHandler,
+	// With the outbox, name and avatar changes are announced to the posts and comments
+	// services, which copy them into their records
+	eventOutbox, err := bootstrap.NewOutbox(ctx, infra)
+	if err != nil {
+		log.Fatal("Failed to create outbox: %v", err)
 	}
-
-	profile.RegisterRoutes(app, profileHandlers, cfg)
+	profileModule := bootstrap.NewProfileModule(ctx, infra, eventOutbox)
 
 	// Start gRPC server if in microservices mode
 	if os.Getenv("START_GRPC_SERVER") == "true" {
@@ -68,26 +37,13 @@ func main() {
 		if grpcPort == "" {
 			grpcPort = "50051"
 		}
-
-		go func() {
-			lis, err := net.Listen("tcp", fmt.Sprintf(":%s", grpcPort))
-			if err != nil {
-				log.Fatalf("Failed to listen on gRPC port %s: %v", grpcPort, err)
-			}
-
-			grpcServer := grpc.NewServer()
-			pb.RegisterProfileServiceServer(grpcServer, profile.NewGrpcServer(profileServiceClient))
-
-			log.Printf("🚀 Profile gRPC Server listening on port %s", grpcPort)
-			if err := grpcServer.Serve(lis); err != nil {
-				log.Fatalf("Failed to serve gRPC: %v", err)
-			}
-		}()
+		bootstrap.ServeGRPC("Profile", grpcPort, cfg.GRPC, func(server *grpc.Server) {
+			pb.RegisterProfileServiceServer(server, profile.NewGrpcServer(profile.NewDirectCallAdapter(profileModule.Service)))
+		})
 	}
 
-	log.Printf("Starting Profile Service on port 8081")
-	log.Fatal(app.Listen(":8081"))
+	server := bootstrap.NewServer("Profile Service", cfg).WithOutbox(eventOutbox).With(profileModule)
+	if err := server.Listen(":8081"); err != nil {
+		log.Fatal("Server stopped: %v", err)
+	}
 }
-
-
-
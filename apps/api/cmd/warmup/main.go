@@ -0,0 +1,106 @@
+// Command warmup walks recent public posts after a deploy or cache flush and
+// pre-populates feed/search caches, pre-renders SEO pages, writes a sitemap and
+// re-embeds post content into the AI engine vector store.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	bookmarksRepository "github.com/qolzam/telar/apps/api/bookmarks/repository"
+	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
+	"github.com/qolzam/telar/apps/api/internal/bootstrap"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
+	postsServices "github.com/qolzam/telar/apps/api/posts/services"
+	settingsRepository "github.com/qolzam/telar/apps/api/settings/repository"
+	settingsServices "github.com/qolzam/telar/apps/api/settings/services"
+	votesRepository "github.com/qolzam/telar/apps/api/votes/repository"
+)
+
+func main() {
+	var opts warmupOptions
+	var searches string
+	flag.IntVar(&opts.Limit, "limit", 1000, "maximum number of recent public posts to walk")
+	flag.DurationVar(&opts.Since, "since", 30*24*time.Hour, "only walk posts created within this window (0 walks everything up to -limit)")
+	flag.IntVar(&opts.FeedPages, "pages", 3, "number of feed pages to warm per feed")
+	flag.IntVar(&opts.TopTags, "tags", 10, "number of most used tags whose feeds are warmed")
+	flag.StringVar(&searches, "searches", "", "comma separated search terms whose results are warmed")
+	flag.StringVar(&opts.SitemapPath, "sitemap", "", "write sitemap.xml to this path (empty to skip)")
+	flag.StringVar(&opts.WebURL, "web-url", "", "public web URL used for sitemap and SEO pre-render (defaults to WEB_DOMAIN)")
+	flag.IntVar(&opts.Concurrency, "concurrency", 4, "parallel requests for SEO pre-render and embedding")
+	flag.BoolVar(&opts.SkipCache, "skip-cache", false, "skip feed and search cache warmup")
+	flag.BoolVar(&opts.SkipRender, "skip-render", false, "skip SEO page pre-render")
+	flag.BoolVar(&opts.SkipEmbed, "skip-embed", false, "skip pre-embedding posts into the AI engine")
+	flag.DurationVar(&opts.Timeout, "timeout", 30*time.Minute, "overall timeout for the run")
+	flag.Parse()
+
+	for _, term := range strings.Split(searches, ",") {
+		if term = strings.TrimSpace(term); term != "" {
+			opts.Searches = append(opts.Searches, term)
+		}
+	}
+
+	cfg, err := platformconfig.LoadFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load platform config: %v", err)
+	}
+	if opts.WebURL == "" {
+		opts.WebURL = cfg.App.WebDomain
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), opts.Timeout)
+	defer cancel()
+
+	pgClient, err := bootstrap.NewPostgresClient(ctx, cfg)
+	if err != nil {
+		log.Fatalf("Failed to create postgres client: %v", err)
+	}
+
+	postRepo := postsRepository.NewPostgresRepository(pgClient)
+	voteRepo := votesRepository.NewPostgresVoteRepository(pgClient)
+	commentRepo := commentRepository.NewPostgresCommentRepository(pgClient)
+	bookmarkRepo := bookmarksRepository.NewPostgresRepository(pgClient)
+
+	posts := postsServices.NewPostService(postRepo, voteRepo, bookmarkRepo, cfg, nil, commentRepo)
+	// Warm the feed order the API servers serve by default
+	posts.SetFeedDefaults(settingsServices.NewService(settingsRepository.NewPostgresRepository(pgClient), cfg.App))
+	w := &warmer{
+		opts:  opts,
+		repo:  postRepo,
+		posts: posts,
+	}
+
+	if !opts.SkipCache {
+		// The in-process memory cache dies with this command, so warming only helps shared backends
+		switch strings.ToLower(os.Getenv("CACHE_BACKEND")) {
+		case "redis", "hybrid":
+		default:
+			log.Printf("CACHE_BACKEND is not redis or hybrid; cache warmup will not be visible to the API servers")
+		}
+	}
+
+	if !opts.SkipEmbed {
+		if cfg.AIEngine.URL == "" {
+			log.Printf("AI_ENGINE_URL is not set, skipping embeddings")
+			w.opts.SkipEmbed = true
+		} else {
+			client, err := aiengine.NewClient(cfg.AIEngine.URL, cfg.AIEngine.Timeout)
+			if err != nil {
+				log.Fatalf("Failed to create AI engine client: %v", err)
+			}
+			w.embedder = client
+		}
+	}
+
+	stats, err := w.Run(ctx)
+	if err != nil {
+		log.Fatalf("Warmup failed: %v", err)
+	}
+	log.Printf("Warmup finished: %s", stats)
+}
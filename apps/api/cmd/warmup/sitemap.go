@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// maxSitemapURLs is the per-file limit defined by the sitemap protocol
+const maxSitemapURLs = 50000
+
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+type sitemapURL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// writeSitemap writes a sitemap.xml listing the public page of each post and returns the URL count
+func writeSitemap(w io.Writer, webURL string, posts []*models.Post) (int, error) {
+	set := sitemapURLSet{Xmlns: sitemapNamespace}
+	for _, post := range posts {
+		if len(set.URLs) == maxSitemapURLs {
+			break
+		}
+		entry := sitemapURL{Loc: postURL(webURL, post.URLKey)}
+		if lastMod := postLastModified(post); !lastMod.IsZero() {
+			entry.LastMod = lastMod.UTC().Format(time.RFC3339)
+		}
+		set.URLs = append(set.URLs, entry)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return 0, err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(set); err != nil {
+		return 0, err
+	}
+	return len(set.URLs), nil
+}
+
+// postURL builds the public web URL of a post page
+func postURL(webURL, urlKey string) string {
+	return strings.TrimRight(webURL, "/") + "/posts/" + url.PathEscape(urlKey)
+}
+
+func postLastModified(post *models.Post) time.Time {
+	if !post.UpdatedAt.IsZero() {
+		return post.UpdatedAt
+	}
+	return post.CreatedAt
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteSitemap(t *testing.T) {
+	updated := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	posts := []*models.Post{
+		{URLKey: "hello-world", UpdatedAt: updated},
+		{URLKey: "a b", CreatedAt: updated.Add(-time.Hour)},
+	}
+
+	var buf bytes.Buffer
+	n, err := writeSitemap(&buf, "https://example.com/", posts)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	out := buf.String()
+	assert.Contains(t, out, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, out, "<loc>https://example.com/posts/hello-world</loc>")
+	assert.Contains(t, out, "<lastmod>2024-05-01T12:00:00Z</lastmod>")
+	assert.Contains(t, out, "<loc>https://example.com/posts/a%20b</loc>")
+}
+
+func TestTopTags(t *testing.T) {
+	posts := []*models.Post{
+		{Tags: []string{"go", "db"}},
+		{Tags: []string{"go", "web"}},
+		{Tags: []string{"web", "go", " "}},
+	}
+
+	assert.Equal(t, []string{"go", "web"}, topTags(posts, 2))
+	assert.Nil(t, topTags(posts, 0))
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
+	postsServices "github.com/qolzam/telar/apps/api/posts/services"
+)
+
+const (
+	walkBatchSize  = 100
+	feedPageLimit  = 10 // page size used by the web feed
+	searchPageSize = 20 // default page size of the cursor search endpoint
+)
+
+// warmupOptions controls which warmup steps run and how much they cover
+type warmupOptions struct {
+	Limit       int
+	Since       time.Duration
+	FeedPages   int
+	TopTags     int
+	Searches    []string
+	SitemapPath string
+	WebURL      string
+	Concurrency int
+	SkipCache   bool
+	SkipRender  bool
+	SkipEmbed   bool
+	Timeout     time.Duration
+}
+
+// embedder stores post content in the AI engine vector store
+type embedder interface {
+	IngestPost(ctx context.Context, postID string, doc aiengine.PostDocument) error
+}
+
+// warmer runs the warmup steps against the posts repository and service
+type warmer struct {
+	opts       warmupOptions
+	repo       postsRepository.PostRepository
+	posts      postsServices.PostService
+	embedder   embedder
+	httpClient *http.Client
+}
+
+// warmupStats summarizes a warmup run
+type warmupStats struct {
+	Posts       int
+	FeedPages   int
+	Rendered    int
+	RenderFails int
+	Embedded    int
+	EmbedFails  int
+	SitemapURLs int
+}
+
+func (s warmupStats) String() string {
+	return fmt.Sprintf("posts=%d feedPages=%d rendered=%d renderFailures=%d embedded=%d embedFailures=%d sitemapURLs=%d",
+		s.Posts, s.FeedPages, s.Rendered, s.RenderFails, s.Embedded, s.EmbedFails, s.SitemapURLs)
+}
+
+// Run executes all enabled steps. Individual page or post failures are logged and
+// counted; only failures that stop the walk itself are returned.
+func (w *warmer) Run(ctx context.Context) (warmupStats, error) {
+	var stats warmupStats
+
+	posts, err := w.recentPublicPosts(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.Posts = len(posts)
+	log.Printf("Found %d recent public posts", len(posts))
+
+	if w.opts.SitemapPath != "" {
+		n, err := w.writeSitemapFile(posts)
+		if err != nil {
+			return stats, err
+		}
+		stats.SitemapURLs = n
+	}
+
+	if !w.opts.SkipCache {
+		stats.FeedPages = w.warmCaches(ctx, posts)
+	}
+
+	if !w.opts.SkipRender && w.opts.WebURL != "" {
+		stats.Rendered, stats.RenderFails = w.forEachPost(ctx, posts, w.renderPost)
+	}
+
+	if !w.opts.SkipEmbed && w.embedder != nil {
+		stats.Embedded, stats.EmbedFails = w.forEachPost(ctx, posts, w.embedPost)
+	}
+
+	return stats, nil
+}
+
+// recentPublicPosts walks non-deleted posts newest first and keeps the public ones
+func (w *warmer) recentPublicPosts(ctx context.Context) ([]*models.Post, error) {
+	var cutoff time.Time
+	if w.opts.Since > 0 {
+		cutoff = time.Now().Add(-w.opts.Since)
+	}
+
+	notDeleted := false
+	filter := postsRepository.PostFilter{Deleted: &notDeleted}
+
+	var result []*models.Post
+	for offset := 0; len(result) < w.opts.Limit; offset += walkBatchSize {
+		batch, err := w.repo.Find(ctx, filter, walkBatchSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk posts: %w", err)
+		}
+		for _, post := range batch {
+			if !cutoff.IsZero() && post.CreatedAt.Before(cutoff) {
+				return result, nil
+			}
+			if post.Permission != "Public" || post.URLKey == "" {
+				continue
+			}
+			result = append(result, post)
+			if len(result) == w.opts.Limit {
+				break
+			}
+		}
+		if len(batch) < walkBatchSize {
+			break
+		}
+	}
+	return result, nil
+}
+
+// warmCaches requests the pages users hit first so they are served from cache:
+// the main feed, feeds of the most used tags and configured searches
+func (w *warmer) warmCaches(ctx context.Context, posts []*models.Post) int {
+	pages := w.warmFeed(ctx, nil)
+	for _, tag := range topTags(posts, w.opts.TopTags) {
+		pages += w.warmFeed(ctx, []string{tag})
+	}
+
+	for _, term := range w.opts.Searches {
+		filter := &models.PostQueryFilter{
+			SortField:     "createdDate",
+			SortDirection: "desc",
+			Limit:         searchPageSize,
+		}
+		if _, err := w.posts.SearchPostsWithCursor(ctx, term, filter); err != nil {
+			log.Printf("Failed to warm search %q: %v", term, err)
+			continue
+		}
+		pages++
+	}
+	return pages
+}
+
+// warmFeed follows nextCursor for the configured number of pages, building each
+// filter the same way the cursor query handler does so cache keys match
+func (w *warmer) warmFeed(ctx context.Context, tags []string) int {
+	cursor := ""
+	for page := 0; page < w.opts.FeedPages; page++ {
+		filter := &models.PostQueryFilter{
+			SortDirection: "desc",
+			Limit:         feedPageLimit,
+			Tags:          tags,
+			Cursor:        cursor,
+		}
+		result, err := w.posts.QueryPostsWithCursor(ctx, filter)
+		if err != nil {
+			log.Printf("Failed to warm feed page %d (tags=%v): %v", page+1, tags, err)
+			return page
+		}
+		if !result.HasNext || result.NextCursor == "" {
+			return page + 1
+		}
+		cursor = result.NextCursor
+	}
+	return w.opts.FeedPages
+}
+
+// renderPost fetches the public post page so the web app renders and caches its SEO HTML
+func (w *warmer) renderPost(ctx context.Context, post *models.Post) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, postURL(w.opts.WebURL, post.URLKey), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := w.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// embedPost replaces the post's chunks in the knowledge base, so repeated runs do not
+// duplicate documents. It stores the same document as the posts service's ingestion.
+func (w *warmer) embedPost(ctx context.Context, post *models.Post) error {
+	doc, ok := postsServices.KnowledgeDocument(post, time.Now().UnixMilli())
+	if !ok {
+		return nil
+	}
+	return w.embedder.IngestPost(ctx, post.ObjectId.String(), doc)
+}
+
+// forEachPost runs fn for every post with bounded concurrency and returns success/failure counts
+func (w *warmer) forEachPost(ctx context.Context, posts []*models.Post, fn func(context.Context, *models.Post) error) (int, int) {
+	workers := w.opts.Concurrency
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var (
+		mu        sync.Mutex
+		succeeded int
+		failed    int
+		wg        sync.WaitGroup
+	)
+	jobs := make(chan *models.Post)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for post := range jobs {
+				err := fn(ctx, post)
+				mu.Lock()
+				if err != nil {
+					failed++
+					log.Printf("Warmup failed for post %s: %v", post.ObjectId, err)
+				} else {
+					succeeded++
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, post := range posts {
+		if ctx.Err() != nil {
+			break
+		}
+		jobs <- post
+	}
+	close(jobs)
+	wg.Wait()
+	return succeeded, failed
+}
+
+func (w *warmer) writeSitemapFile(posts []*models.Post) (int, error) {
+	f, err := os.Create(w.opts.SitemapPath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create sitemap: %w", err)
+	}
+	n, err := writeSitemap(f, w.opts.WebURL, posts)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to write sitemap: %w", err)
+	}
+	log.Printf("Wrote %d URLs to %s", n, w.opts.SitemapPath)
+	return n, nil
+}
+
+func (w *warmer) client() *http.Client {
+	if w.httpClient == nil {
+		w.httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	return w.httpClient
+}
+
+// topTags returns up to n tags ordered by how many of the posts use them
+func topTags(posts []*models.Post, n int) []string {
+	if n <= 0 {
+		return nil
+	}
+	counts := make(map[string]int)
+	for _, post := range posts {
+		for _, tag := range post.Tags {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				counts[tag]++
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(counts))
+	for tag := range counts {
+		tags = append(tags, tag)
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if counts[tags[i]] != counts[tags[j]] {
+			return counts[tags[i]] > counts[tags[j]]
+		}
+		return tags[i] < tags[j]
+	})
+	if len(tags) > n {
+		tags = tags[:n]
+	}
+	return tags
+}
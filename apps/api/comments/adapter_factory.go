@@ -3,6 +3,7 @@ package comments
 import (
 	"github.com/qolzam/telar/apps/api/comments/internal/adapters"
 	"github.com/qolzam/telar/apps/api/comments/services"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
@@ -11,8 +12,13 @@ func NewDirectCallCounter(service services.CommentService) sharedInterfaces.Comm
 	return adapters.NewDirectCallCounter(service)
 }
 
-// NewGrpcCounter creates a gRPC client adapter for microservices deployment mode
-func NewGrpcCounter(targetAddress string) (sharedInterfaces.CommentCounter, error) {
-	return adapters.NewGrpcCounter(targetAddress)
+// NewGrpcCounter creates a gRPC client adapter for microservices deployment mode;
+// cfg sets up TLS, call authentication, keepalive and retries
+func NewGrpcCounter(targetAddress string, cfg platformconfig.GRPCConfig) (sharedInterfaces.CommentCounter, error) {
+	return adapters.NewGrpcCounter(targetAddress, cfg)
 }
 
+// NewContentModerator lets the moderation module look up and hide reported comments
+func NewContentModerator(service services.CommentService) sharedInterfaces.ContentModerator {
+	return adapters.NewContentModerator(service)
+}
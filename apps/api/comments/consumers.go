@@ -0,0 +1,37 @@
+package comments
+
+import (
+	"context"
+
+	"github.com/qolzam/telar/apps/api/comments/services"
+	"github.com/qolzam/telar/apps/api/internal/outbox"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// NotificationsConsumer is the outbox consumer group notifying users of new comments
+const NotificationsConsumer = "notifications"
+
+// SubscribeNotifications publishes the realtime events of comments announced through the
+// outbox: the comment on the post's topic and notifications to the post owner and the
+// user replied to. Subscribe it in the process serving the realtime gateway.
+func SubscribeNotifications(ctx context.Context, broker outbox.Broker, repo outbox.Repository, service services.CommentService) error {
+	eventTypes := []string{sharedInterfaces.OutboxCommentCreated}
+	return outbox.Subscribe(ctx, broker, repo, NotificationsConsumer, eventTypes, func(txCtx context.Context, msg outbox.Message) error {
+		var event sharedInterfaces.CommentCreatedEvent
+		if err := msg.Decode(&event); err != nil {
+			log.Error("Skipping outbox event: %v", err)
+			return nil
+		}
+		return service.PublishCommentCreated(txCtx, event)
+	})
+}
+
+// SubscribeProfilePropagation copies the names and avatars users change to into their
+// comments, as announced through the outbox
+func SubscribeProfilePropagation(ctx context.Context, broker outbox.Broker, repo outbox.Repository, progress propagation.Repository, maxAttempts int, service services.CommentService) error {
+	return propagation.Subscribe(ctx, broker, repo, progress, propagation.TargetComments, maxAttempts, func(txCtx context.Context, event sharedInterfaces.ProfileUpdatedEvent) error {
+		return service.UpdateCommentProfile(txCtx, event.UserId, event.FullName, event.Avatar)
+	})
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
 )
 
 // Comment service specific errors
@@ -18,6 +20,12 @@ var (
 	ErrInvalidUserContext       = errors.New("invalid user context")
 	ErrUserNotFound             = errors.New("user does not exist")
 	ErrPostNotFound             = errors.New("post does not exist")
+	ErrCommentsClosed           = errors.New("comments are closed on this post")
+	ErrSlowMode                 = errors.New("slow mode is on")
+	ErrMembershipRequired       = errors.New("community membership required")
+	ErrRulesNotAcknowledged     = errors.New("community rules not acknowledged")
+	ErrCommunityArchived        = errors.New("community is archived")
+	ErrInvalidReaction          = errors.New("unknown reaction type")
 
 	// Request and validation errors
 	ErrInvalidRequest       = errors.New("invalid request")
@@ -55,6 +63,20 @@ func (e *CommentError) Unwrap() error {
 	return e.Cause
 }
 
+// SlowModeError rejects a comment posted too soon after the user's previous one on a
+// live thread
+type SlowModeError struct {
+	RetryAfter int // Seconds until the user may comment again
+}
+
+func (e *SlowModeError) Error() string {
+	return fmt.Sprintf("%s: wait %d seconds between comments", ErrSlowMode, e.RetryAfter)
+}
+
+func (e *SlowModeError) Unwrap() error {
+	return ErrSlowMode
+}
+
 // NewCommentError creates a new CommentError
 func NewCommentError(code, message string, cause error) *CommentError {
 	return &CommentError{
@@ -66,12 +88,18 @@ func NewCommentError(code, message string, cause error) *CommentError {
 
 // Error codes
 const (
-	CodeCommentNotFound  = "COMMENT_NOT_FOUND"
-	CodeUnauthorized     = "UNAUTHORIZED"
-	CodeInvalidData      = "INVALID_DATA"
-	CodeValidationFailed = "VALIDATION_FAILED"
-	CodeDatabaseError    = "DATABASE_ERROR"
-	CodeInternalError    = "INTERNAL_ERROR"
+	CodeCommentNotFound      = "COMMENT_NOT_FOUND"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeInvalidData          = "INVALID_DATA"
+	CodeValidationFailed     = "VALIDATION_FAILED"
+	CodeDatabaseError        = "DATABASE_ERROR"
+	CodeInternalError        = "INTERNAL_ERROR"
+	CodeCommentsClosed       = "COMMENTS_CLOSED"
+	CodeSlowMode             = "SLOW_MODE"
+	CodeMembershipRequired   = "MEMBERSHIP_REQUIRED"
+	CodeRulesNotAcknowledged = "RULES_NOT_ACKNOWLEDGED"
+	CodeCommunityArchived    = "COMMUNITY_ARCHIVED"
+	CodeInvalidReaction      = "INVALID_REACTION"
 
 	// Request and validation codes
 	CodeInvalidRequest       = "INVALID_REQUEST"
@@ -129,6 +157,46 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 			Message: "Post not found",
 			Details: err.Error(),
 		})
+	case errors.Is(err, ErrCommentsClosed):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeCommentsClosed,
+			Message: "Comments are closed on this post",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrSlowMode):
+		var slowMode *SlowModeError
+		if errors.As(err, &slowMode) && slowMode.RetryAfter > 0 {
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(slowMode.RetryAfter))
+		}
+		return c.Status(http.StatusTooManyRequests).JSON(ErrorResponse{
+			Code:    CodeSlowMode,
+			Message: "Slow mode is on; wait before commenting again",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrMembershipRequired):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
+			Code:    CodeMembershipRequired,
+			Message: "Join the community before you comment on its posts",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrRulesNotAcknowledged):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
+			Code:    CodeRulesNotAcknowledged,
+			Message: "Acknowledge the community rules before you comment",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrCommunityArchived):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeCommunityArchived,
+			Message: "The community is archived; its posts take no new comments",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrInvalidReaction):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{
+			Code:    CodeInvalidReaction,
+			Message: "Unknown reaction type",
+			Details: err.Error(),
+		})
 	case errors.Is(err, ErrCommentAlreadyExists):
 		return c.Status(http.StatusConflict).JSON(ErrorResponse{
 			Code:    "DUPLICATE_KEY",
@@ -153,6 +221,16 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 			Message: "Access forbidden",
 			Details: err.Error(),
 		})
+	case errors.Is(err, ErrValidationFailed):
+		var details interface{} = err.Error()
+		if fields, ok := validate.Fields(err); ok {
+			details = fiber.Map{"fields": fields}
+		}
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{
+			Code:    CodeValidationFailed,
+			Message: "Validation failed",
+			Details: details,
+		})
 	case errors.Is(err, ErrDatabaseOperation):
 		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{
 			Code:    CodeDatabaseOperation,
@@ -8,6 +8,8 @@ import (
 	"github.com/qolzam/telar/apps/api/comments/services"
 	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 	pb "github.com/qolzam/telar/protos/gen/go/commentspb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // grpcServer implements the CommentsServiceServer interface generated from proto.
@@ -25,7 +27,7 @@ func NewGrpcServer(svc services.CommentService) pb.CommentsServiceServer {
 func (s *grpcServer) GetRootCommentCount(ctx context.Context, req *pb.GetRootCommentCountRequest) (*pb.GetRootCommentCountResponse, error) {
 	postID, err := uuid.FromString(req.PostId)
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.InvalidArgument, "invalid post ID: %v", err)
 	}
 
 	// The service's GetRootCommentCount method implements CommentCounter interface
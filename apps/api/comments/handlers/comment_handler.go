@@ -11,6 +11,7 @@ import (
 	"github.com/qolzam/telar/apps/api/comments/models"
 	"github.com/qolzam/telar/apps/api/comments/services"
 	"github.com/qolzam/telar/apps/api/comments/validation"
+	"github.com/qolzam/telar/apps/api/internal/pkg/fieldset"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 )
@@ -107,6 +108,15 @@ func (h *CommentHandler) GetCommentsByPost(c *fiber.Ctx) error {
 		return errors.HandleInvalidRequestError(c, "Invalid post ID")
 	}
 
+	fields, err := parseCommentFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+	ownerIDs, err := parseOwnersMode(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "owners", err.Error())
+	}
+
 	// Parse pagination parameters (prefer cursor-based pagination for performance)
 	filter := &models.CommentQueryFilter{
 		Limit: 10,
@@ -123,6 +133,17 @@ func (h *CommentHandler) GetCommentsByPost(c *fiber.Ctx) error {
 		}
 	}
 
+	// Root comments follow the deployment's default order unless the request picks one
+	switch c.Query("sort") {
+	case "":
+	case "newest":
+		filter.SortDirection = "desc"
+	case "oldest":
+		filter.SortDirection = "asc"
+	default:
+		return errors.HandleInvalidFieldError(c, "sort", "must be newest or oldest")
+	}
+
 	// Validate filter
 	if err := validation.ValidateCommentQueryFilter(filter); err != nil {
 		return errors.HandleValidationError(c, err.Error())
@@ -140,6 +161,9 @@ func (h *CommentHandler) GetCommentsByPost(c *fiber.Ctx) error {
 	if err != nil {
 		return errors.HandleServiceError(c, err)
 	}
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok {
+		comments.Comments = h.commentService.DropMutedComments(ctx, user.UserID, comments.Comments)
+	}
 
 
 	// Enrich with reply counts and user votes (BULK OPERATIONS - avoids N+1 queries)
@@ -152,7 +176,7 @@ func (h *CommentHandler) GetCommentsByPost(c *fiber.Ctx) error {
 	}
 
 	// Bulk-load reply counts (single query instead of N queries)
-	if len(commentIDs) > 0 {
+	if len(commentIDs) > 0 && fields.Has("replyCount") {
 
 		replyCountMap, err := h.commentService.GetReplyCountsBulk(ctx, commentIDs)
 		if err == nil {
@@ -169,7 +193,7 @@ func (h *CommentHandler) GetCommentsByPost(c *fiber.Ctx) error {
 	}
 
 	// Bulk-load user votes if user is authenticated (single query using ANY operator)
-	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok && len(commentIDs) > 0 {
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok && len(commentIDs) > 0 && fields.Has("isLiked") {
 
 		voteMap, err := h.commentService.GetUserVotesForComments(ctx, commentIDs, user.UserID)
 		if err == nil {
@@ -184,8 +208,13 @@ func (h *CommentHandler) GetCommentsByPost(c *fiber.Ctx) error {
 		}
 	}
 
+	// Bulk-load reaction counts and the viewer's reactions (single grouped query)
+	if len(commentIDs) > 0 && (fields.Has("reactions") || fields.Has("myReactions")) {
+		h.enrichWithReactions(c, comments.Comments)
+	}
+
 	// Return full CommentsListResponse object (includes nextCursor, hasNext for cursor pagination)
-	return c.Status(http.StatusOK).JSON(comments)
+	return respondCommentsList(c, comments, fields, ownerIDs)
 }
 
 // GetComment handles retrieving a specific comment
@@ -230,7 +259,68 @@ func (h *CommentHandler) GetComment(c *fiber.Ctx) error {
 		}
 	}
 
-	return c.Status(http.StatusOK).JSON(response)
+	responses := []models.CommentResponse{response}
+	h.enrichWithReactions(c, responses)
+
+	return c.Status(http.StatusOK).JSON(responses[0])
+}
+
+// GetCommentContext resolves a comment permalink for notification deep links
+// Endpoint: GET /comments/:commentId/context?limit=10
+// limit must match the page size the client uses for GET /comments and replies
+func (h *CommentHandler) GetCommentContext(c *fiber.Ctx) error {
+	commentID, err := uuid.FromString(c.Params("commentId"))
+	if err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid comment ID")
+	}
+
+	limit := 10
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 && parsedLimit <= 100 {
+			limit = parsedLimit
+		}
+	}
+
+	var viewerID uuid.UUID
+	user, authenticated := c.Locals(types.UserCtxName).(types.UserContext)
+	if authenticated {
+		viewerID = user.UserID
+	}
+
+	result, err := h.commentService.GetCommentContext(c.Context(), commentID, viewerID, limit)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	thread := append(append([]*models.Comment{}, result.Parents...), result.Comment)
+	responses := make([]models.CommentResponse, len(thread))
+	ids := make([]uuid.UUID, len(thread))
+	for i, comment := range thread {
+		responses[i] = h.convertCommentToResponse(comment)
+		ids[i] = comment.ObjectId
+	}
+
+	// Same enrichment as the list endpoints, in bulk for the whole chain
+	if replyCounts, err := h.commentService.GetReplyCountsBulk(c.Context(), ids); err == nil {
+		for i, id := range ids {
+			responses[i].ReplyCount = int(replyCounts[id])
+		}
+	}
+	if authenticated {
+		if voteMap, err := h.commentService.GetUserVotesForComments(c.Context(), ids, user.UserID); err == nil {
+			for i, id := range ids {
+				responses[i].IsLiked = voteMap[id]
+			}
+		}
+	}
+	h.enrichWithReactions(c, responses)
+
+	return c.Status(http.StatusOK).JSON(models.CommentContextResponse{
+		Comment:  responses[len(responses)-1],
+		Parents:  responses[:len(responses)-1],
+		Post:     result.Post,
+		Location: result.Location,
+	})
 }
 
 // DeleteComment handles comment deletion
@@ -361,7 +451,7 @@ func (h *CommentHandler) convertCommentToResponse(comment *models.Comment) model
 	response := models.CommentResponse{
 		ObjectId:         comment.ObjectId.String(),
 		Score:            comment.Score,
-		OwnerUserId:      comment.OwnerUserId.String(),
+		OwnerUserId:      comment.PublicOwnerID(),
 		OwnerDisplayName: comment.OwnerDisplayName,
 		OwnerAvatar:      comment.OwnerAvatar,
 		PostId:           comment.PostId.String(),
@@ -385,6 +475,7 @@ func (h *CommentHandler) convertCommentToResponse(comment *models.Comment) model
 		DeletedDate:      comment.DeletedDate,
 		CreatedDate:      comment.CreatedDate,
 		LastUpdated:      comment.LastUpdated,
+		IsAcceptedAnswer: comment.IsAcceptedAnswer,
 		ReplyCount:       0, // Default to 0 for new comments - can be enriched later if needed
 		IsLiked:          false, // Default to false - should be enriched by caller if needed
 	}
@@ -420,6 +511,86 @@ func (h *CommentHandler) ToggleLike(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(response)
 }
 
+// AddReaction handles adding the user's reaction to a comment
+// Endpoint: POST /comments/:commentId/reactions with body {"type": "love"}
+func (h *CommentHandler) AddReaction(c *fiber.Ctx) error {
+	commentID, err := uuid.FromString(c.Params("commentId"))
+	if err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid comment ID")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	var req models.AddReactionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+
+	summary, err := h.commentService.AddReaction(c.Context(), commentID, user.UserID, req.Type)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(summary)
+}
+
+// RemoveReaction handles removing the user's reaction from a comment
+// Endpoint: DELETE /comments/:commentId/reactions/:type
+func (h *CommentHandler) RemoveReaction(c *fiber.Ctx) error {
+	commentID, err := uuid.FromString(c.Params("commentId"))
+	if err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid comment ID")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	summary, err := h.commentService.RemoveReaction(c.Context(), commentID, user.UserID, c.Params("type"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(summary)
+}
+
+// enrichWithReactions sets reaction counts, and the current user's reactions when
+// authenticated, on the given comments with a single bulk query
+func (h *CommentHandler) enrichWithReactions(c *fiber.Ctx, comments []models.CommentResponse) {
+	ids := make([]uuid.UUID, 0, len(comments))
+	for i := range comments {
+		if id, err := uuid.FromString(comments[i].ObjectId); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	var viewerID uuid.UUID
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok {
+		viewerID = user.UserID
+	}
+
+	summaries, err := h.commentService.GetReactionsForComments(c.Context(), ids, viewerID)
+	if err != nil {
+		return
+	}
+	for i := range comments {
+		id, _ := uuid.FromString(comments[i].ObjectId)
+		if summary, ok := summaries[id]; ok {
+			comments[i].Reactions = summary.Counts
+			if len(summary.Mine) > 0 {
+				comments[i].MyReactions = summary.Mine
+			}
+		}
+	}
+}
+
 // GetReplies handles fetching replies for a specific comment with cursor-based pagination
 func (h *CommentHandler) GetReplies(c *fiber.Ctx) error {
 	parentStr := c.Params("commentId")
@@ -438,11 +609,23 @@ func (h *CommentHandler) GetReplies(c *fiber.Ctx) error {
 
 	cursor := c.Query("cursor")
 
+	fields, err := parseCommentFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+	ownerIDs, err := parseOwnersMode(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "owners", err.Error())
+	}
+
 	// Use cursor-based pagination (always)
 	result, err := h.commentService.QueryRepliesWithCursor(c.Context(), parentID, cursor, limit)
 	if err != nil {
 		return errors.HandleServiceError(c, err)
 	}
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok {
+		result.Comments = h.commentService.DropMutedComments(c.Context(), user.UserID, result.Comments)
+	}
 
 	// Enrich with user votes if user is authenticated (BULK OPERATIONS - avoids N+1 queries)
 	commentIDs := make([]uuid.UUID, 0, len(result.Comments))
@@ -454,7 +637,7 @@ func (h *CommentHandler) GetReplies(c *fiber.Ctx) error {
 	}
 
 	// Bulk-load user votes if user is authenticated (single query using ANY operator)
-	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok && len(commentIDs) > 0 {
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok && len(commentIDs) > 0 && fields.Has("isLiked") {
 		voteMap, err := h.commentService.GetUserVotesForComments(c.Context(), commentIDs, user.UserID)
 		if err == nil {
 			// Set IsLiked for each reply
@@ -467,6 +650,80 @@ func (h *CommentHandler) GetReplies(c *fiber.Ctx) error {
 		}
 	}
 
+	// Bulk-load reaction counts and the viewer's reactions (single grouped query)
+	if len(commentIDs) > 0 && (fields.Has("reactions") || fields.Has("myReactions")) {
+		h.enrichWithReactions(c, result.Comments)
+	}
+
 	// Return full CommentsListResponse object (includes nextCursor, hasNext for cursor pagination)
-	return c.Status(http.StatusOK).JSON(result)
+	return respondCommentsList(c, result, fields, ownerIDs)
+}
+
+// commentResponseFields are the field names accepted by ?fields= on comment list endpoints
+var commentResponseFields = fieldset.JSONFields(models.CommentResponse{})
+
+// parseCommentFields reads the sparse fieldset from the "fields" query parameter
+func parseCommentFields(c *fiber.Ctx) (fieldset.Set, error) {
+	return fieldset.Parse(c.Query("fields"), commentResponseFields)
+}
+
+// projectedCommentsListResponse is a CommentsListResponse whose comments carry only the requested fields
+type projectedCommentsListResponse struct {
+	*models.CommentsListResponse
+	Comments []map[string]interface{} `json:"comments"`
+}
+
+// Owner modes of comment lists, picked with ?owners=
+const (
+	ownersEmbedded = "embedded" // Comments carry their owner's display name and avatar as stored
+	ownersIDs      = "ids"      // Comments carry owner IDs only, to be hydrated from POST /profile/batch
+)
+
+// parseOwnersMode reports whether the "owners" query parameter asks for owner IDs only
+func parseOwnersMode(c *fiber.Ctx) (bool, error) {
+	switch c.Query("owners") {
+	case "", ownersEmbedded:
+		return false, nil
+	case ownersIDs:
+		return true, nil
+	default:
+		return false, fmt.Errorf("must be %s or %s", ownersEmbedded, ownersIDs)
+	}
+}
+
+// hasVisibleOwner reports whether a comment names its owner; comments by the hidden
+// author of an anonymous post carry the post's alias instead
+func hasVisibleOwner(comment *models.CommentResponse) bool {
+	return comment.OwnerUserId != "" && comment.OwnerUserId != uuid.Nil.String()
+}
+
+// respondCommentsList writes a comment list, reduced to the sparse fieldset when one was requested.
+// With ownerIDs the stored owner names and avatars are left out and the list names its owners once.
+func respondCommentsList(c *fiber.Ctx, result *models.CommentsListResponse, fields fieldset.Set, ownerIDs bool) error {
+	if ownerIDs {
+		seen := make(map[string]bool)
+		result.OwnerIds = []string{}
+		for i := range result.Comments {
+			if id := result.Comments[i].OwnerUserId; hasVisibleOwner(&result.Comments[i]) && !seen[id] {
+				seen[id] = true
+				result.OwnerIds = append(result.OwnerIds, id)
+			}
+		}
+	}
+	if fields == nil && !ownerIDs {
+		return c.Status(http.StatusOK).JSON(result)
+	}
+	comments, err := fieldset.Project(result.Comments, fields, "objectId")
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	if ownerIDs {
+		for i := range comments {
+			if hasVisibleOwner(&result.Comments[i]) {
+				delete(comments[i], "ownerDisplayName")
+				delete(comments[i], "ownerAvatar")
+			}
+		}
+	}
+	return c.Status(http.StatusOK).JSON(projectedCommentsListResponse{CommentsListResponse: result, Comments: comments})
 }
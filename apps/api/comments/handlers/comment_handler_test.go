@@ -17,6 +17,7 @@ import (
 	"github.com/qolzam/telar/apps/api/comments/models"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/internal/types"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -30,6 +31,7 @@ type MockCommentService struct {
 	queryCommentsFunc                func(ctx context.Context, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error)
 	queryCommentsWithCursorFunc      func(ctx context.Context, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error)
 	queryRepliesWithCursorFunc       func(ctx context.Context, parentID uuid.UUID, cursor string, limit int) (*models.CommentsListResponse, error)
+	getCommentContextFunc            func(ctx context.Context, commentID, viewerID uuid.UUID, limit int) (*models.CommentContext, error)
 	updateCommentFunc                func(ctx context.Context, commentID uuid.UUID, req *models.UpdateCommentRequest, user *types.UserContext) (*models.Comment, error)
 	updateCommentProfileFunc         func(ctx context.Context, userID uuid.UUID, displayName, avatar string) error
 	incrementScoreFunc               func(ctx context.Context, commentID uuid.UUID, delta int, user *types.UserContext) error
@@ -39,6 +41,8 @@ type MockCommentService struct {
 	validateCommentOwnershipFunc     func(ctx context.Context, commentID uuid.UUID, userID uuid.UUID) error
 	createIndexFunc                  func(ctx context.Context, indexes map[string]interface{}) error
 	deleteByOwnerFunc                func(ctx context.Context, owner uuid.UUID, objectId uuid.UUID) error
+	addReactionFunc                  func(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (*models.ReactionSummary, error)
+	dropMutedCommentsFunc            func(ctx context.Context, viewerID uuid.UUID, comments []models.CommentResponse) []models.CommentResponse
 
 	// Mock state for testing
 	shouldFail   bool
@@ -72,6 +76,13 @@ func (m *MockCommentService) GetComment(ctx context.Context, commentID uuid.UUID
 	return nil, nil
 }
 
+func (m *MockCommentService) GetCommentsByIDs(ctx context.Context, commentIDs []uuid.UUID) ([]*models.Comment, error) {
+	if m.shouldFail {
+		return nil, m.failureError
+	}
+	return []*models.Comment{}, nil
+}
+
 func (m *MockCommentService) GetCommentsByPost(ctx context.Context, postID uuid.UUID, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error) {
 	if m.getCommentsByPostFunc != nil {
 		return m.getCommentsByPostFunc(ctx, postID, filter)
@@ -122,6 +133,13 @@ func (m *MockCommentService) QueryRepliesWithCursor(ctx context.Context, parentI
 	return &models.CommentsListResponse{Comments: []models.CommentResponse{}, HasNext: false}, nil
 }
 
+func (m *MockCommentService) GetCommentContext(ctx context.Context, commentID, viewerID uuid.UUID, limit int) (*models.CommentContext, error) {
+	if m.getCommentContextFunc != nil {
+		return m.getCommentContextFunc(ctx, commentID, viewerID, limit)
+	}
+	return nil, commentErrors.ErrCommentNotFound
+}
+
 func (m *MockCommentService) UpdateComment(ctx context.Context, commentID uuid.UUID, req *models.UpdateCommentRequest, user *types.UserContext) (*models.Comment, error) {
 	if m.updateCommentFunc != nil {
 		return m.updateCommentFunc(ctx, commentID, req, user)
@@ -139,6 +157,10 @@ func (m *MockCommentService) UpdateCommentProfile(ctx context.Context, userID uu
 	return nil
 }
 
+func (m *MockCommentService) RepairOwnerProfiles(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
 func (m *MockCommentService) IncrementScore(ctx context.Context, commentID uuid.UUID, delta int, user *types.UserContext) error {
 	if m.incrementScoreFunc != nil {
 		return m.incrementScoreFunc(ctx, commentID, delta, user)
@@ -177,6 +199,14 @@ func (m *MockCommentService) DeleteByOwner(ctx context.Context, owner uuid.UUID,
 	return nil
 }
 
+func (m *MockCommentService) CommentOwner(ctx context.Context, commentID uuid.UUID) (uuid.UUID, error) {
+	return uuid.Nil, nil
+}
+
+func (m *MockCommentService) HideComment(ctx context.Context, commentID uuid.UUID) error {
+	return nil
+}
+
 func (m *MockCommentService) ValidateCommentOwnership(ctx context.Context, commentID uuid.UUID, userID uuid.UUID) error {
 	if m.validateCommentOwnershipFunc != nil {
 		return m.validateCommentOwnershipFunc(ctx, commentID, userID)
@@ -195,6 +225,48 @@ func (m *MockCommentService) GetUserVotesForComments(ctx context.Context, commen
 	return make(map[uuid.UUID]bool), nil
 }
 
+func (m *MockCommentService) AddReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (*models.ReactionSummary, error) {
+	if m.addReactionFunc != nil {
+		return m.addReactionFunc(ctx, commentID, userID, reactionType)
+	}
+	return &models.ReactionSummary{}, nil
+}
+
+func (m *MockCommentService) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (*models.ReactionSummary, error) {
+	return &models.ReactionSummary{}, nil
+}
+
+func (m *MockCommentService) GetReactionsForComments(ctx context.Context, commentIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error) {
+	return make(map[uuid.UUID]*models.ReactionSummary), nil
+}
+
+func (m *MockCommentService) SetRulesChecker(checker sharedInterfaces.RulesChecker) {}
+
+func (m *MockCommentService) SetCommunityChecker(checker sharedInterfaces.CommunityChecker) {}
+
+func (m *MockCommentService) SetFeedDefaults(provider sharedInterfaces.FeedDefaultsProvider) {}
+
+func (m *MockCommentService) DropMutedComments(ctx context.Context, viewerID uuid.UUID, comments []models.CommentResponse) []models.CommentResponse {
+	if m.dropMutedCommentsFunc != nil {
+		return m.dropMutedCommentsFunc(ctx, viewerID, comments)
+	}
+	return comments
+}
+
+func (m *MockCommentService) SetKeywordMuter(muter sharedInterfaces.KeywordMuter) {}
+
+func (m *MockCommentService) SetMentionRecorder(recorder sharedInterfaces.MentionRecorder) {}
+
+func (m *MockCommentService) SetContentScreener(screener sharedInterfaces.ContentScreener) {}
+
+func (m *MockCommentService) SetContentLimits(provider sharedInterfaces.ContentLimitsProvider) {}
+
+func (m *MockCommentService) SetEventOutbox(outbox sharedInterfaces.EventOutbox) {}
+
+func (m *MockCommentService) PublishCommentCreated(ctx context.Context, event sharedInterfaces.CommentCreatedEvent) error {
+	return nil
+}
+
 // Legacy map-based methods removed from mock - use type-safe methods instead
 
 // Test cases
@@ -734,6 +806,50 @@ func TestCommentHandler_GetCommentsByPost_Success(t *testing.T) {
 	assert.Equal(t, 2, len(response.Comments))
 }
 
+func TestCommentHandler_GetCommentsByPost_OwnerIDs(t *testing.T) {
+	postID, _ := uuid.NewV4()
+	ownerID, _ := uuid.NewV4()
+
+	mockService := &MockCommentService{
+		queryCommentsWithCursorFunc: func(ctx context.Context, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error) {
+			return &models.CommentsListResponse{
+				Comments: []models.CommentResponse{
+					{ObjectId: "c1", OwnerUserId: ownerID.String(), OwnerDisplayName: "Old Name", OwnerAvatar: "old.png", Text: "First"},
+					{ObjectId: "c2", OwnerUserId: ownerID.String(), OwnerDisplayName: "Old Name", OwnerAvatar: "old.png", Text: "Second"},
+					{ObjectId: "c3", OwnerUserId: uuid.Nil.String(), OwnerDisplayName: "Quiet Fox", Text: "Masked"},
+				},
+			}, nil
+		},
+	}
+
+	handler := handlers.NewCommentHandler(mockService, platformconfig.JWTConfig{}, platformconfig.HMACConfig{})
+	app := fiber.New()
+	app.Get("/comments", handler.GetCommentsByPost)
+
+	req := httptest.NewRequest("GET", "/comments?owners=ids&postId="+postID.String(), nil)
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	var response struct {
+		Comments []map[string]interface{} `json:"comments"`
+		OwnerIds []string                 `json:"ownerIds"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	require.Len(t, response.Comments, 3)
+	assert.Equal(t, []string{ownerID.String()}, response.OwnerIds)
+	assert.Equal(t, ownerID.String(), response.Comments[0]["ownerUserId"])
+	assert.NotContains(t, response.Comments[0], "ownerDisplayName")
+	assert.NotContains(t, response.Comments[1], "ownerAvatar")
+	// The alias of a hidden author cannot be hydrated, so it stays
+	assert.Equal(t, "Quiet Fox", response.Comments[2]["ownerDisplayName"])
+
+	req = httptest.NewRequest("GET", "/comments?owners=names&postId="+postID.String(), nil)
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, 400, resp.StatusCode)
+}
+
 func TestCommentHandler_GetCommentsByPost_WithPagination(t *testing.T) {
 	// Setup
 	postID, _ := uuid.NewV4()
@@ -969,3 +1085,93 @@ func TestCommentHandler_ErrorResponseFormats(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentHandler_GetCommentContext(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+	rootID := uuid.Must(uuid.NewV4())
+	replyID := uuid.Must(uuid.NewV4())
+
+	var gotLimit int
+	mockService := &MockCommentService{
+		getCommentContextFunc: func(ctx context.Context, commentID, viewerID uuid.UUID, limit int) (*models.CommentContext, error) {
+			if commentID != replyID {
+				return nil, commentErrors.ErrCommentNotFound
+			}
+			assert.Equal(t, userID, viewerID)
+			gotLimit = limit
+			return &models.CommentContext{
+				Comment:  &models.Comment{ObjectId: replyID, PostId: postID, ParentCommentId: &rootID, Text: "reply"},
+				Parents:  []*models.Comment{{ObjectId: rootID, PostId: postID, Text: "root"}},
+				Post:     models.CommentPostSummary{ObjectId: postID.String(), URLKey: "post-key"},
+				Location: models.ThreadLocation{Limit: limit, RootCursor: "cm9vdA==", RootPosition: 23, ReplyPosition: 4},
+			}, nil
+		},
+	}
+	handler := handlers.NewCommentHandler(mockService, platformconfig.JWTConfig{}, platformconfig.HMACConfig{})
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(types.UserCtxName, types.UserContext{UserID: userID})
+		return c.Next()
+	})
+	app.Get("/comments/:commentId/context", handler.GetCommentContext)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/comments/"+replyID.String()+"/context?limit=20", nil))
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+
+	var body models.CommentContextResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 20, gotLimit)
+	assert.Equal(t, replyID.String(), body.Comment.ObjectId)
+	require.Len(t, body.Parents, 1)
+	assert.Equal(t, rootID.String(), body.Parents[0].ObjectId)
+	assert.Equal(t, "post-key", body.Post.URLKey)
+	assert.Equal(t, int64(23), body.Location.RootPosition)
+	assert.Equal(t, "cm9vdA==", body.Location.RootCursor)
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/comments/"+uuid.Must(uuid.NewV4()).String()+"/context", nil))
+	require.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+}
+
+func TestCommentHandler_AddReaction(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	commentID := uuid.Must(uuid.NewV4())
+
+	mockService := &MockCommentService{
+		addReactionFunc: func(ctx context.Context, gotCommentID, gotUserID uuid.UUID, reactionType string) (*models.ReactionSummary, error) {
+			if reactionType != models.ReactionLaugh {
+				return nil, commentErrors.ErrInvalidReaction
+			}
+			assert.Equal(t, commentID, gotCommentID)
+			assert.Equal(t, userID, gotUserID)
+			return &models.ReactionSummary{Counts: map[string]int64{models.ReactionLaugh: 2}, Mine: []string{models.ReactionLaugh}}, nil
+		},
+	}
+	handler := handlers.NewCommentHandler(mockService, platformconfig.JWTConfig{}, platformconfig.HMACConfig{})
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(types.UserCtxName, types.UserContext{UserID: userID})
+		return c.Next()
+	})
+	app.Post("/comments/:commentId/reactions", handler.AddReaction)
+
+	post := func(body string) *http.Response {
+		req := httptest.NewRequest("POST", "/comments/"+commentID.String()+"/reactions", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	resp := post(`{"type":"laugh"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var summary models.ReactionSummary
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&summary))
+	assert.Equal(t, int64(2), summary.Counts[models.ReactionLaugh])
+	assert.Equal(t, []string{models.ReactionLaugh}, summary.Mine)
+
+	resp = post(`{"type":"shrug"}`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
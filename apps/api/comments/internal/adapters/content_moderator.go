@@ -0,0 +1,41 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/comments/services"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+var _ sharedInterfaces.ContentModerator = (*ContentModerator)(nil)
+
+// ContentModerator lets the moderation module look up and hide reported comments
+type ContentModerator struct {
+	service services.CommentService
+}
+
+// NewContentModerator creates a ContentModerator calling the comment service directly
+func NewContentModerator(svc services.CommentService) *ContentModerator {
+	return &ContentModerator{service: svc}
+}
+
+// ContentOwner returns the comment's real author, or ErrContentNotFound
+func (a *ContentModerator) ContentOwner(ctx context.Context, contentID uuid.UUID) (uuid.UUID, error) {
+	owner, err := a.service.CommentOwner(ctx, contentID)
+	return owner, contentError(err)
+}
+
+// HideContent hides the comment and its replies
+func (a *ContentModerator) HideContent(ctx context.Context, contentID uuid.UUID) error {
+	return contentError(a.service.HideComment(ctx, contentID))
+}
+
+func contentError(err error) error {
+	if errors.Is(err, commentsErrors.ErrCommentNotFound) {
+		return sharedInterfaces.ErrContentNotFound
+	}
+	return err
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 
 	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/platform/grpcconn"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
 	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 	pb "github.com/qolzam/telar/protos/gen/go/commentspb"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Ensure GrpcCounter implements CommentCounter interface
@@ -22,8 +25,13 @@ type GrpcCounter struct {
 }
 
 // NewGrpcCounter creates a new GrpcCounter adapter.
-func NewGrpcCounter(targetAddress string) (*GrpcCounter, error) {
-	conn, err := grpc.Dial(targetAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewGrpcCounter(targetAddress string, cfg platformconfig.GRPCConfig) (*GrpcCounter, error) {
+	opts, err := grpcconn.DialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(targetAddress, append(opts,
+		grpc.WithChainUnaryInterceptor(requestid.UnaryClientInterceptor(), metrics.Default().UnaryClientInterceptor()))...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,19 @@
+-- Migration: 009_add_comment_reply_count.sql
+-- Description: Adds a reply counter to comments for threaded replies
+-- Dependencies: Requires comments table (005_create_comments_table.sql)
+-- Purpose: Replies may nest up to COMMENTS_MAX_DEPTH levels; each comment keeps the number
+-- of its live direct replies, updated in the transaction that creates or deletes a reply
+
+ALTER TABLE comments
+ADD COLUMN IF NOT EXISTS reply_count BIGINT NOT NULL DEFAULT 0;
+
+-- Backfill from the existing replies
+UPDATE comments c
+SET reply_count = r.reply_count
+FROM (
+    SELECT parent_comment_id, COUNT(*) AS reply_count
+    FROM comments
+    WHERE parent_comment_id IS NOT NULL AND is_deleted = FALSE
+    GROUP BY parent_comment_id
+) r
+WHERE c.id = r.parent_comment_id;
@@ -0,0 +1,20 @@
+-- Migration: 010_create_comment_reactions.sql
+-- Description: Creates comment_reactions table for emoji reactions on comments
+-- Dependencies: Requires comments table (005_create_comments_table.sql) and user_auths table (003_create_auth_tables.sql)
+-- Purpose: A user may add each reaction type once per comment. Reactions are counted
+-- per type on comment responses and, unlike likes (comment_votes), leave the score alone.
+
+CREATE TABLE IF NOT EXISTS comment_reactions (
+    comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    owner_user_id UUID NOT NULL REFERENCES user_auths(id) ON DELETE CASCADE,
+    reaction_type VARCHAR(16) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+
+    -- Composite Primary Key enforces one reaction of each type per user per comment
+    PRIMARY KEY (comment_id, owner_user_id, reaction_type)
+);
+
+-- Index for "My Reactions" lookups and account cleanup
+CREATE INDEX IF NOT EXISTS idx_comment_reactions_owner ON comment_reactions(owner_user_id);
+
+-- Note: per-comment counts use the primary key, whose leading column is comment_id.
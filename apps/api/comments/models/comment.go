@@ -14,7 +14,7 @@ type Comment struct {
 	OwnerDisplayName string    `json:"ownerDisplayName" bson:"ownerDisplayName" db:"ownerDisplayName"`
 	OwnerAvatar      string    `json:"ownerAvatar" bson:"ownerAvatar" db:"ownerAvatar"`
 	PostId           uuid.UUID `json:"postId" bson:"postId" db:"postId"`
-	ParentCommentId  *uuid.UUID `json:"parentCommentId,omitempty" bson:"parentCommentId,omitempty" db:"parent_comment_id"` // Always points to root comment (or nil)
+	ParentCommentId  *uuid.UUID `json:"parentCommentId,omitempty" bson:"parentCommentId,omitempty" db:"parent_comment_id"` // Comment this one replies under (or nil for root comments)
 	ReplyToUserId    *uuid.UUID `json:"replyToUserId,omitempty" bson:"replyToUserId,omitempty" db:"reply_to_user_id"` // User being addressed (for UI display)
 	ReplyToDisplayName *string `json:"replyToDisplayName,omitempty" bson:"replyToDisplayName,omitempty" db:"reply_to_display_name"` // Display name of user being replied to (joined from profiles)
 	Text             string    `json:"text" bson:"text" db:"text"`
@@ -22,6 +22,16 @@ type Comment struct {
 	DeletedDate      int64     `json:"deletedDate" bson:"deletedDate" db:"deletedDate"`
 	CreatedDate      int64     `json:"createdDate" bson:"createdDate" db:"createdDate"`
 	LastUpdated      int64     `json:"lastUpdated" bson:"lastUpdated" db:"lastUpdated"`
+	IsAcceptedAnswer bool      `json:"isAcceptedAnswer,omitempty" bson:"-" db:"-"` // Set when the comment is pinned as its question's accepted answer
+}
+
+// PublicOwnerID is the owner ID as shown in responses: empty when the author is hidden
+// behind an anonymous post's alias
+func (c *Comment) PublicOwnerID() string {
+	if c.OwnerUserId == uuid.Nil {
+		return ""
+	}
+	return c.OwnerUserId.String()
 }
 
 // CreateCommentRequest represents the request payload for creating a comment
@@ -71,7 +81,7 @@ type CommentResponse struct {
 	OwnerDisplayName string `json:"ownerDisplayName"`
 	OwnerAvatar      string `json:"ownerAvatar"`
 	PostId           string `json:"postId"`
-	ParentCommentId  *string `json:"parentCommentId,omitempty"` // Always points to root comment (or nil)
+	ParentCommentId  *string `json:"parentCommentId,omitempty"` // Comment this one replies under (or nil for root comments)
 	ReplyToUserId    *string `json:"replyToUserId,omitempty"` // User being addressed (for UI display "Replying to @John")
 	ReplyToDisplayName *string `json:"replyToDisplayName,omitempty"` // Optional: Display name of user being replied to (joined in handler)
 	ReplyCount       int    `json:"replyCount"`
@@ -81,6 +91,9 @@ type CommentResponse struct {
 	CreatedDate      int64  `json:"createdDate"`
 	LastUpdated      int64  `json:"lastUpdated,omitempty"`
 	IsLiked          bool   `json:"isLiked"` // Whether the current user has liked this comment
+	Reactions        map[string]int64 `json:"reactions,omitempty"`   // Count per reaction type
+	MyReactions      []string         `json:"myReactions,omitempty"` // Reaction types the current user added
+	IsAcceptedAnswer bool   `json:"isAcceptedAnswer,omitempty"` // Whether the comment is its question's accepted answer
 }
 
 // CommentsListResponse represents the response for listing comments
@@ -95,4 +108,47 @@ type CommentsListResponse struct {
 	Count    int  `json:"count,omitempty"`
 	Page     int  `json:"page,omitempty"`
 	Limit    int  `json:"limit,omitempty"`
+
+	// Owners of the listed comments, set when the list was requested with ?owners=ids
+	OwnerIds []string `json:"ownerIds,omitempty"`
+}
+
+// CommentPostSummary is the part of the owning post a comment permalink needs to render
+type CommentPostSummary struct {
+	ObjectId         string `json:"objectId"`
+	OwnerUserId      string `json:"ownerUserId"`
+	OwnerDisplayName string `json:"ownerDisplayName"`
+	OwnerAvatar      string `json:"ownerAvatar"`
+	URLKey           string `json:"urlKey"`
+	BodyPreview      string `json:"bodyPreview"`
+	CommentCounter   int64  `json:"commentCounter"`
+	DisableComments  bool   `json:"disableComments"`
+	CreatedDate      int64  `json:"createdDate"`
+}
+
+// ThreadLocation tells a client which pages to load to show a comment in its thread.
+// RootCursor is passed as cursor to GET /comments?postId=; for replies, ReplyCursor is
+// passed to GET /comments/:rootId/replies. Empty cursors mean the first page.
+type ThreadLocation struct {
+	Limit         int    `json:"limit"`                   // Page size the cursors were computed for
+	RootCursor    string `json:"rootCursor"`              // Page of root comments holding the comment or its root
+	RootPosition  int64  `json:"rootPosition"`            // Root comments listed before it (newest first)
+	ReplyCursor   string `json:"replyCursor,omitempty"`   // Page of replies holding the comment
+	ReplyPosition int64  `json:"replyPosition,omitempty"` // Replies listed before it (oldest first)
+}
+
+// CommentContext is a comment with everything needed to open it from a deep link
+type CommentContext struct {
+	Comment  *Comment
+	Parents  []*Comment // Root first
+	Post     CommentPostSummary
+	Location ThreadLocation
+}
+
+// CommentContextResponse is the response of GET /comments/:commentId/context
+type CommentContextResponse struct {
+	Comment  CommentResponse    `json:"comment"`
+	Parents  []CommentResponse  `json:"parents"`
+	Post     CommentPostSummary `json:"post"`
+	Location ThreadLocation     `json:"location"`
 }
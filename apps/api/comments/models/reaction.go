@@ -0,0 +1,36 @@
+package models
+
+// Reaction types a comment can receive. Reactions are counted per type and do not
+// change the comment's score; likes (ToggleLike) do.
+const (
+	ReactionLike  = "like"
+	ReactionLove  = "love"
+	ReactionLaugh = "laugh"
+	ReactionWow   = "wow"
+	ReactionSad   = "sad"
+	ReactionAngry = "angry"
+)
+
+// ReactionTypes lists the accepted reaction types in display order
+var ReactionTypes = []string{ReactionLike, ReactionLove, ReactionLaugh, ReactionWow, ReactionSad, ReactionAngry}
+
+// IsReactionType reports whether t is an accepted reaction type
+func IsReactionType(t string) bool {
+	for _, reaction := range ReactionTypes {
+		if reaction == t {
+			return true
+		}
+	}
+	return false
+}
+
+// AddReactionRequest is the body of POST /comments/:commentId/reactions
+type AddReactionRequest struct {
+	Type string `json:"type"`
+}
+
+// ReactionSummary holds a comment's reaction counts and the viewer's own reactions
+type ReactionSummary struct {
+	Counts map[string]int64 `json:"reactions"`   // Count per reaction type; types without reactions are absent
+	Mine   []string         `json:"myReactions"` // Types the viewer added, in ReactionTypes order
+}
@@ -274,6 +274,59 @@ func TestCommentVotes_Integration(t *testing.T) {
 		require.Equal(t, 1, voteCount, "Only one vote row should exist")
 	})
 
+	// Reactions: idempotent per type, counted in bulk with the viewer's own reactions
+	t.Run("Reactions_BulkSummary", func(t *testing.T) {
+		userIDs := make([]uuid.UUID, 2)
+		for i := range userIDs {
+			userIDs[i] = uuid.Must(uuid.NewV4())
+			passHash, _ := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+			err := authRepo.CreateUser(ctx, &authModels.UserAuth{
+				ObjectId:      userIDs[i],
+				Username:      fmt.Sprintf("user-react-%s@test.com", userIDs[i].String()[:8]),
+				Password:      passHash,
+				Role:          "user",
+				EmailVerified: true,
+			})
+			require.NoError(t, err)
+		}
+
+		postID := uuid.Must(uuid.NewV4())
+		err := postRepo.Create(ctx, &postsModels.Post{ObjectId: postID, OwnerUserId: userIDs[0], PostTypeId: 1, Body: "Test post"})
+		require.NoError(t, err)
+
+		commentIDs := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+		for _, id := range commentIDs {
+			err := commentRepo.Create(ctx, &models.Comment{ObjectId: id, PostId: postID, OwnerUserId: userIDs[0], Text: "Test comment"})
+			require.NoError(t, err)
+		}
+
+		created, err := commentRepo.AddReaction(ctx, commentIDs[0], userIDs[0], models.ReactionLove)
+		require.NoError(t, err)
+		require.True(t, created)
+		created, err = commentRepo.AddReaction(ctx, commentIDs[0], userIDs[0], models.ReactionLove)
+		require.NoError(t, err)
+		require.False(t, created, "Repeated reaction should not be counted twice")
+		_, err = commentRepo.AddReaction(ctx, commentIDs[0], userIDs[0], models.ReactionLike)
+		require.NoError(t, err)
+		_, err = commentRepo.AddReaction(ctx, commentIDs[0], userIDs[1], models.ReactionLove)
+		require.NoError(t, err)
+
+		summaries, err := commentRepo.GetReactionsForComments(ctx, commentIDs, userIDs[0])
+		require.NoError(t, err)
+		require.Len(t, summaries, 1, "Comments without reactions are absent")
+		summary := summaries[commentIDs[0]]
+		require.Equal(t, map[string]int64{models.ReactionLove: 2, models.ReactionLike: 1}, summary.Counts)
+		require.Equal(t, []string{models.ReactionLike, models.ReactionLove}, summary.Mine)
+
+		removed, err := commentRepo.RemoveReaction(ctx, commentIDs[0], userIDs[0], models.ReactionLove)
+		require.NoError(t, err)
+		require.True(t, removed)
+		summaries, err = commentRepo.GetReactionsForComments(ctx, commentIDs, userIDs[1])
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{models.ReactionLove: 1, models.ReactionLike: 1}, summaries[commentIDs[0]].Counts)
+		require.Equal(t, []string{models.ReactionLove}, summaries[commentIDs[0]].Mine)
+	})
+
 	// Test 4: Concurrent Stress Test (Race Condition Safety)
 	t.Run("Concurrent_Stress_Test_NoScoreDrift", func(t *testing.T) {
 		// Create User A
@@ -492,6 +545,14 @@ func applyMigrations(t *testing.T, ctx context.Context, db interface{}, schema s
 			disable_sharing BOOLEAN DEFAULT FALSE,
 			permission VARCHAR(50) DEFAULT 'Public',
 			version VARCHAR(50),
+			media_text TEXT NOT NULL DEFAULT '',
+			content_hash VARCHAR(64) NOT NULL DEFAULT '',
+			is_anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+			accepted_answer_id UUID,
+			search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(body, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(media_text, '')), 'C')
+			) STORED,
 			metadata JSONB DEFAULT '{}'::jsonb
 		);
 	`
@@ -507,6 +568,7 @@ func applyMigrations(t *testing.T, ctx context.Context, db interface{}, schema s
 			reply_to_display_name VARCHAR(255),
 			text TEXT NOT NULL,
 			score BIGINT DEFAULT 0,
+			reply_count BIGINT NOT NULL DEFAULT 0,
 			owner_display_name VARCHAR(255),
 			owner_avatar VARCHAR(512),
 			is_deleted BOOLEAN DEFAULT FALSE,
@@ -537,6 +599,18 @@ func applyMigrations(t *testing.T, ctx context.Context, db interface{}, schema s
 		CREATE INDEX IF NOT EXISTS idx_comment_votes_owner ON comment_votes(owner_user_id);
 	`
 
+	// Apply comment_reactions migration
+	reactionsSQL := `
+		CREATE TABLE IF NOT EXISTS comment_reactions (
+			comment_id UUID NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+			owner_user_id UUID NOT NULL REFERENCES user_auths(id) ON DELETE CASCADE,
+			reaction_type VARCHAR(16) NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (comment_id, owner_user_id, reaction_type)
+		);
+		CREATE INDEX IF NOT EXISTS idx_comment_reactions_owner ON comment_reactions(owner_user_id);
+	`
+
 	// Execute migrations in correct order (auth first, then posts, then comments, then votes)
 	// client.DB() returns *sqlx.DB which implements ExecContext
 	type execContext interface {
@@ -553,6 +627,8 @@ func applyMigrations(t *testing.T, ctx context.Context, db interface{}, schema s
 		require.NoError(t, err, "Failed to apply comments migration")
 		_, err = execer.ExecContext(ctx, votesSQL)
 		require.NoError(t, err, "Failed to apply comment_votes migration")
+		_, err = execer.ExecContext(ctx, reactionsSQL)
+		require.NoError(t, err, "Failed to apply comment_reactions migration")
 	} else {
 		t.Fatalf("db does not implement ExecContext, got %T", db)
 	}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+)
+
+// AddReaction adds a reaction of the given type by the user
+// Returns true if a new row was inserted, false if it already existed
+func (r *postgresCommentRepository) AddReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (bool, error) {
+	query := `
+		INSERT INTO comment_reactions (comment_id, owner_user_id, reaction_type, created_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (comment_id, owner_user_id, reaction_type) DO NOTHING
+	`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, commentID, userID, reactionType)
+	if err != nil {
+		return false, fmt.Errorf("failed to add reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RemoveReaction removes the user's reaction of the given type
+// Returns true if a row was deleted, false if no reaction existed
+func (r *postgresCommentRepository) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (bool, error) {
+	query := `DELETE FROM comment_reactions WHERE comment_id = $1 AND owner_user_id = $2 AND reaction_type = $3`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, commentID, userID, reactionType)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove reaction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// GetReactionsForComments bulk loads reaction counts and the viewer's reactions in a
+// single query grouped by comment and type
+func (r *postgresCommentRepository) GetReactionsForComments(ctx context.Context, commentIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error) {
+	if len(commentIDs) == 0 {
+		return make(map[uuid.UUID]*models.ReactionSummary), nil
+	}
+
+	// Convert []uuid.UUID to pq.Array for PostgreSQL ANY operator
+	commentIDsArray := make([]string, len(commentIDs))
+	for i, id := range commentIDs {
+		commentIDsArray[i] = id.String()
+	}
+
+	query := `
+		SELECT comment_id, reaction_type, COUNT(*) AS reaction_count,
+			BOOL_OR(owner_user_id = $2) AS mine
+		FROM comment_reactions
+		WHERE comment_id = ANY($1::uuid[])
+		GROUP BY comment_id, reaction_type
+	`
+
+	var results []struct {
+		CommentID     uuid.UUID `db:"comment_id"`
+		ReactionType  string    `db:"reaction_type"`
+		ReactionCount int64     `db:"reaction_count"`
+		Mine          bool      `db:"mine"`
+	}
+	err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &results, query, pq.Array(commentIDsArray), viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reactions in bulk: %w", err)
+	}
+
+	summaries := make(map[uuid.UUID]*models.ReactionSummary)
+	for _, result := range results {
+		summary, ok := summaries[result.CommentID]
+		if !ok {
+			summary = &models.ReactionSummary{Counts: make(map[string]int64), Mine: []string{}}
+			summaries[result.CommentID] = summary
+		}
+		summary.Counts[result.ReactionType] = result.ReactionCount
+		if result.Mine {
+			summary.Mine = append(summary.Mine, result.ReactionType)
+		}
+	}
+
+	for _, summary := range summaries {
+		sortReactionTypes(summary.Mine)
+	}
+
+	return summaries, nil
+}
+
+// sortReactionTypes orders reaction types as models.ReactionTypes lists them
+func sortReactionTypes(types []string) {
+	rank := make(map[string]int, len(models.ReactionTypes))
+	for i, t := range models.ReactionTypes {
+		rank[t] = i
+	}
+	sort.Slice(types, func(i, j int) bool { return rank[types[i]] < rank[types[j]] })
+}
@@ -78,16 +78,22 @@ func (r *postgresCommentRepository) Create(ctx context.Context, comment *models.
 		comment.LastUpdated = nowUnix
 	}
 
+	// A reply bumps its parent's reply_count in the same statement
 	query := `
-		INSERT INTO comments (
-			id, post_id, owner_user_id, parent_comment_id, reply_to_user_id, text, score,
-			owner_display_name, owner_avatar, is_deleted, deleted_date,
-			created_at, updated_at, created_date, last_updated
-		) VALUES (
-			:id, :post_id, :owner_user_id, :parent_comment_id, :reply_to_user_id, :text, :score,
-			:owner_display_name, :owner_avatar, :is_deleted, :deleted_date,
-			:created_at, :updated_at, :created_date, :last_updated
-		)`
+		WITH inserted AS (
+			INSERT INTO comments (
+				id, post_id, owner_user_id, parent_comment_id, reply_to_user_id, text, score,
+				owner_display_name, owner_avatar, is_deleted, deleted_date,
+				created_at, updated_at, created_date, last_updated
+			) VALUES (
+				:id, :post_id, :owner_user_id, :parent_comment_id, :reply_to_user_id, :text, :score,
+				:owner_display_name, :owner_avatar, :is_deleted, :deleted_date,
+				:created_at, :updated_at, :created_date, :last_updated
+			)
+			RETURNING parent_comment_id
+		)
+		UPDATE comments SET reply_count = reply_count + 1
+		WHERE id = (SELECT parent_comment_id FROM inserted)`
 
 	insertData := struct {
 		ID               uuid.UUID  `db:"id"`
@@ -193,6 +199,86 @@ func (r *postgresCommentRepository) FindByID(ctx context.Context, commentID uuid
 	}, nil
 }
 
+// FindByIDs retrieves several comments by ID in a single query
+func (r *postgresCommentRepository) FindByIDs(ctx context.Context, commentIDs []uuid.UUID) ([]*models.Comment, error) {
+	if len(commentIDs) == 0 {
+		return []*models.Comment{}, nil
+	}
+
+	idStrings := make([]string, len(commentIDs))
+	for i, id := range commentIDs {
+		idStrings[i] = id.String()
+	}
+
+	query := `
+		SELECT
+			id, post_id, owner_user_id, parent_comment_id, reply_to_user_id, text, score,
+			owner_display_name, owner_avatar, is_deleted, deleted_date,
+			created_date, last_updated
+		FROM comments
+		WHERE id::text = ANY($1::text[])`
+
+	var results []struct {
+		ID               uuid.UUID  `db:"id"`
+		PostID           uuid.UUID  `db:"post_id"`
+		OwnerUserID      uuid.UUID  `db:"owner_user_id"`
+		ParentCommentID  *uuid.UUID `db:"parent_comment_id"`
+		ReplyToUserID    *uuid.UUID `db:"reply_to_user_id"`
+		Text             string     `db:"text"`
+		Score            int64      `db:"score"`
+		OwnerDisplayName string     `db:"owner_display_name"`
+		OwnerAvatar      string     `db:"owner_avatar"`
+		IsDeleted        bool       `db:"is_deleted"`
+		DeletedDate      int64      `db:"deleted_date"`
+		CreatedDate      int64      `db:"created_date"`
+		LastUpdated      int64      `db:"last_updated"`
+	}
+
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &results, query, pq.Array(idStrings)); err != nil {
+		return nil, fmt.Errorf("failed to find comments by IDs: %w", err)
+	}
+
+	comments := make([]*models.Comment, 0, len(results))
+	for _, result := range results {
+		comments = append(comments, &models.Comment{
+			ObjectId:         result.ID,
+			PostId:           result.PostID,
+			OwnerUserId:      result.OwnerUserID,
+			ParentCommentId:  result.ParentCommentID,
+			ReplyToUserId:    result.ReplyToUserID,
+			Text:             result.Text,
+			Score:            result.Score,
+			OwnerDisplayName: result.OwnerDisplayName,
+			OwnerAvatar:      result.OwnerAvatar,
+			Deleted:          result.IsDeleted,
+			DeletedDate:      result.DeletedDate,
+			CreatedDate:      result.CreatedDate,
+			LastUpdated:      result.LastUpdated,
+		})
+	}
+	return comments, nil
+}
+
+// FindAcceptedAnswer retrieves the live accepted answer of a question post, or nil when it has none
+func (r *postgresCommentRepository) FindAcceptedAnswer(ctx context.Context, postID uuid.UUID) (*models.Comment, error) {
+	query := `
+		SELECT c.id
+		FROM posts p
+		JOIN comments c ON c.id = p.accepted_answer_id
+		WHERE p.id = $1 AND c.is_deleted = FALSE`
+
+	var commentID uuid.UUID
+	err := sqlx.GetContext(ctx, r.getExecutor(ctx), &commentID, query, postID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find accepted answer: %w", err)
+	}
+
+	return r.FindByID(ctx, commentID)
+}
+
 // FindByPostID retrieves root comments for a specific post with pagination
 func (r *postgresCommentRepository) FindByPostID(ctx context.Context, postID uuid.UUID, limit, offset int) ([]*models.Comment, error) {
 	query := `
@@ -201,7 +287,7 @@ func (r *postgresCommentRepository) FindByPostID(ctx context.Context, postID uui
 			owner_display_name, owner_avatar, is_deleted, deleted_date,
 			created_at, updated_at, created_date, last_updated
 		FROM comments 
-		WHERE post_id = $1 AND parent_comment_id IS NULL AND is_deleted = FALSE
+		WHERE post_id = $1 AND parent_comment_id IS NULL AND is_deleted = FALSE` + notAcceptedAnswerClause + `
 		ORDER BY created_date DESC
 		LIMIT $2 OFFSET $3`
 
@@ -251,8 +337,8 @@ func (r *postgresCommentRepository) FindByPostID(ctx context.Context, postID uui
 }
 
 // FindByPostIDWithCursor retrieves root comments for a specific post with cursor-based pagination
-// Uses keyset pagination with created_date DESC, id DESC for stable ordering
-func (r *postgresCommentRepository) FindByPostIDWithCursor(ctx context.Context, postID uuid.UUID, cursor string, limit int) ([]*models.Comment, string, error) {
+// Uses keyset pagination with created_date DESC, id DESC for stable ordering (ASC when oldestFirst)
+func (r *postgresCommentRepository) FindByPostIDWithCursor(ctx context.Context, postID uuid.UUID, cursor string, limit int, oldestFirst bool) ([]*models.Comment, string, error) {
 	// Parse cursor if provided
 	var cursorCreatedDate int64
 	var cursorID uuid.UUID
@@ -292,19 +378,24 @@ func (r *postgresCommentRepository) FindByPostIDWithCursor(ctx context.Context,
 			owner_display_name, owner_avatar, is_deleted, deleted_date,
 			created_at, updated_at, created_date, last_updated
 		FROM comments 
-		WHERE post_id = $1 AND parent_comment_id IS NULL AND is_deleted = FALSE`
+		WHERE post_id = $1 AND parent_comment_id IS NULL AND is_deleted = FALSE` + notAcceptedAnswerClause
 
 	args := []interface{}{postID}
 	argIndex := 2
 
+	comparison, direction := "<", "DESC"
+	if oldestFirst {
+		comparison, direction = ">", "ASC"
+	}
+
 	if hasCursor {
 		// Keyset pagination: (created_date < cursorCreatedDate) OR (created_date = cursorCreatedDate AND id < cursorID)
-		query += fmt.Sprintf(` AND ((created_date < $%d) OR (created_date = $%d AND id < $%d))`, argIndex, argIndex, argIndex+1)
+		query += fmt.Sprintf(` AND ((created_date %[1]s $%[2]d) OR (created_date = $%[2]d AND id %[1]s $%[3]d))`, comparison, argIndex, argIndex+1)
 		args = append(args, cursorCreatedDate, cursorID)
 		argIndex += 2
 	}
 
-	query += ` ORDER BY created_date DESC, id DESC LIMIT $` + fmt.Sprintf("%d", argIndex)
+	query += fmt.Sprintf(` ORDER BY created_date %[1]s, id %[1]s LIMIT $%[2]d`, direction, argIndex)
 	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
 
 	var results []struct {
@@ -365,7 +456,17 @@ func (r *postgresCommentRepository) FindByPostIDWithCursor(ctx context.Context,
 	return comments, nextCursor, nil
 }
 
-// FindByUserID retrieves comments created by a specific user with pagination
+// notHiddenAuthorClause drops comments the author of an anonymous post wrote in its thread.
+// Listed by author they would tie the thread's alias to the account.
+const notHiddenAuthorClause = ` AND NOT EXISTS (
+			SELECT 1 FROM posts p WHERE p.id = comments.post_id AND p.is_anonymous AND p.owner_user_id = comments.owner_user_id)`
+
+// notAcceptedAnswerClause drops the accepted answer of the post bound to $1 from paged
+// root listings; the service pins it to the first page instead
+const notAcceptedAnswerClause = ` AND id IS DISTINCT FROM (SELECT accepted_answer_id FROM posts WHERE id = $1)`
+
+// FindByUserID retrieves comments created by a specific user with pagination, except
+// those written as the hidden author of an anonymous post
 func (r *postgresCommentRepository) FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Comment, error) {
 	query := `
 		SELECT 
@@ -373,7 +474,7 @@ func (r *postgresCommentRepository) FindByUserID(ctx context.Context, userID uui
 			owner_display_name, owner_avatar, is_deleted, deleted_date,
 			created_at, updated_at, created_date, last_updated
 		FROM comments 
-		WHERE owner_user_id = $1 AND is_deleted = FALSE
+		WHERE owner_user_id = $1 AND is_deleted = FALSE` + notHiddenAuthorClause + `
 		ORDER BY created_date DESC
 		LIMIT $2 OFFSET $3`
 
@@ -652,13 +753,265 @@ func (r *postgresCommentRepository) CountByPostIDs(ctx context.Context, postIDs
 	return result, nil
 }
 
-// CountReplies counts replies to a specific comment
+// LatestByPostIDs returns the newest root comments of several posts in a single query
+func (r *postgresCommentRepository) LatestByPostIDs(ctx context.Context, postIDs []uuid.UUID, perPost int) (map[uuid.UUID][]*models.Comment, error) {
+	if len(postIDs) == 0 || perPost <= 0 {
+		return map[uuid.UUID][]*models.Comment{}, nil
+	}
+
+	idStrings := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		idStrings[i] = id.String()
+	}
+
+	query := `
+		SELECT
+			id, post_id, owner_user_id, parent_comment_id, reply_to_user_id, text, score,
+			owner_display_name, owner_avatar, is_deleted, deleted_date,
+			created_date, last_updated
+		FROM (
+			SELECT c.*,
+				ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY created_date DESC, id DESC) AS rn
+			FROM comments c
+			WHERE post_id::text = ANY($1::text[])
+			  AND parent_comment_id IS NULL
+			  AND is_deleted = FALSE
+		) latest
+		WHERE rn <= $2
+		ORDER BY post_id, created_date DESC, id DESC
+	`
+
+	var results []struct {
+		ID               uuid.UUID  `db:"id"`
+		PostID           uuid.UUID  `db:"post_id"`
+		OwnerUserID      uuid.UUID  `db:"owner_user_id"`
+		ParentCommentID  *uuid.UUID `db:"parent_comment_id"`
+		ReplyToUserID    *uuid.UUID `db:"reply_to_user_id"`
+		Text             string     `db:"text"`
+		Score            int64      `db:"score"`
+		OwnerDisplayName string     `db:"owner_display_name"`
+		OwnerAvatar      string     `db:"owner_avatar"`
+		IsDeleted        bool       `db:"is_deleted"`
+		DeletedDate      int64      `db:"deleted_date"`
+		CreatedDate      int64      `db:"created_date"`
+		LastUpdated      int64      `db:"last_updated"`
+	}
+
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &results, query, pq.Array(idStrings), perPost); err != nil {
+		return nil, fmt.Errorf("failed to find latest comments by post IDs: %w", err)
+	}
+
+	latest := make(map[uuid.UUID][]*models.Comment)
+	for _, result := range results {
+		latest[result.PostID] = append(latest[result.PostID], &models.Comment{
+			ObjectId:         result.ID,
+			PostId:           result.PostID,
+			OwnerUserId:      result.OwnerUserID,
+			ParentCommentId:  result.ParentCommentID,
+			ReplyToUserId:    result.ReplyToUserID,
+			Text:             result.Text,
+			Score:            result.Score,
+			OwnerDisplayName: result.OwnerDisplayName,
+			OwnerAvatar:      result.OwnerAvatar,
+			Deleted:          result.IsDeleted,
+			DeletedDate:      result.DeletedDate,
+			CreatedDate:      result.CreatedDate,
+			LastUpdated:      result.LastUpdated,
+		})
+	}
+
+	return latest, nil
+}
+
+// FindPublicByUserBefore lists a user's comments on public, live posts, newest first.
+// When beforeDate is set only comments ordered after (beforeDate, beforeID) are returned.
+func (r *postgresCommentRepository) FindPublicByUserBefore(ctx context.Context, userID uuid.UUID, beforeDate int64, beforeID uuid.UUID, limit int) ([]*models.Comment, error) {
+	if limit <= 0 {
+		return []*models.Comment{}, nil
+	}
+
+	query := `
+		SELECT
+			c.id, c.post_id, c.owner_user_id, c.parent_comment_id, c.reply_to_user_id, c.text, c.score,
+			c.owner_display_name, c.owner_avatar, c.is_deleted, c.deleted_date,
+			c.created_date, c.last_updated
+		FROM comments c
+		JOIN posts p ON p.id = c.post_id
+		WHERE c.owner_user_id = $1
+		  AND c.is_deleted = FALSE
+		  AND p.is_deleted = FALSE
+		  AND p.is_archived = FALSE
+		  AND p.permission = 'Public'
+		  AND NOT (p.is_anonymous AND p.owner_user_id = c.owner_user_id)
+		  AND ($2 = 0 OR c.created_date < $2 OR (c.created_date = $2 AND c.id < $3))
+		ORDER BY c.created_date DESC, c.id DESC
+		LIMIT $4
+	`
+
+	var results []struct {
+		ID               uuid.UUID  `db:"id"`
+		PostID           uuid.UUID  `db:"post_id"`
+		OwnerUserID      uuid.UUID  `db:"owner_user_id"`
+		ParentCommentID  *uuid.UUID `db:"parent_comment_id"`
+		ReplyToUserID    *uuid.UUID `db:"reply_to_user_id"`
+		Text             string     `db:"text"`
+		Score            int64      `db:"score"`
+		OwnerDisplayName string     `db:"owner_display_name"`
+		OwnerAvatar      string     `db:"owner_avatar"`
+		IsDeleted        bool       `db:"is_deleted"`
+		DeletedDate      int64      `db:"deleted_date"`
+		CreatedDate      int64      `db:"created_date"`
+		LastUpdated      int64      `db:"last_updated"`
+	}
+
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &results, query, userID, beforeDate, beforeID, limit); err != nil {
+		return nil, fmt.Errorf("failed to find public comments by user: %w", err)
+	}
+
+	comments := make([]*models.Comment, 0, len(results))
+	for _, result := range results {
+		comments = append(comments, &models.Comment{
+			ObjectId:         result.ID,
+			PostId:           result.PostID,
+			OwnerUserId:      result.OwnerUserID,
+			ParentCommentId:  result.ParentCommentID,
+			ReplyToUserId:    result.ReplyToUserID,
+			Text:             result.Text,
+			Score:            result.Score,
+			OwnerDisplayName: result.OwnerDisplayName,
+			OwnerAvatar:      result.OwnerAvatar,
+			Deleted:          result.IsDeleted,
+			DeletedDate:      result.DeletedDate,
+			CreatedDate:      result.CreatedDate,
+			LastUpdated:      result.LastUpdated,
+		})
+	}
+
+	return comments, nil
+}
+
+// FindByPostIDAfter lists a post's live comments and replies oldest first, keyset paged
+// on (created_date, id) so a long export never holds a connection between pages
+func (r *postgresCommentRepository) FindByPostIDAfter(ctx context.Context, postID uuid.UUID, afterDate int64, afterID uuid.UUID, limit int) ([]*models.Comment, error) {
+	if limit <= 0 {
+		return []*models.Comment{}, nil
+	}
+
+	query := `
+		SELECT
+			id, post_id, owner_user_id, parent_comment_id, reply_to_user_id, text, score,
+			owner_display_name, owner_avatar, is_deleted, deleted_date,
+			created_date, last_updated
+		FROM comments
+		WHERE post_id = $1
+		  AND is_deleted = FALSE
+		  AND ($2 = 0 OR created_date > $2 OR (created_date = $2 AND id > $3))
+		ORDER BY created_date ASC, id ASC
+		LIMIT $4
+	`
+
+	var results []struct {
+		ID               uuid.UUID  `db:"id"`
+		PostID           uuid.UUID  `db:"post_id"`
+		OwnerUserID      uuid.UUID  `db:"owner_user_id"`
+		ParentCommentID  *uuid.UUID `db:"parent_comment_id"`
+		ReplyToUserID    *uuid.UUID `db:"reply_to_user_id"`
+		Text             string     `db:"text"`
+		Score            int64      `db:"score"`
+		OwnerDisplayName string     `db:"owner_display_name"`
+		OwnerAvatar      string     `db:"owner_avatar"`
+		IsDeleted        bool       `db:"is_deleted"`
+		DeletedDate      int64      `db:"deleted_date"`
+		CreatedDate      int64      `db:"created_date"`
+		LastUpdated      int64      `db:"last_updated"`
+	}
+
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &results, query, postID, afterDate, afterID, limit); err != nil {
+		return nil, fmt.Errorf("failed to page comments of post: %w", err)
+	}
+
+	comments := make([]*models.Comment, 0, len(results))
+	for _, result := range results {
+		comments = append(comments, &models.Comment{
+			ObjectId:         result.ID,
+			PostId:           result.PostID,
+			OwnerUserId:      result.OwnerUserID,
+			ParentCommentId:  result.ParentCommentID,
+			ReplyToUserId:    result.ReplyToUserID,
+			Text:             result.Text,
+			Score:            result.Score,
+			OwnerDisplayName: result.OwnerDisplayName,
+			OwnerAvatar:      result.OwnerAvatar,
+			Deleted:          result.IsDeleted,
+			DeletedDate:      result.DeletedDate,
+			CreatedDate:      result.CreatedDate,
+			LastUpdated:      result.LastUpdated,
+		})
+	}
+
+	return comments, nil
+}
+
+// ThreadPosition locates a comment in its listing: root comments of the post newest first
+// (oldest first when oldestFirst), or replies of its root oldest first, matching
+// FindByPostIDWithCursor and FindRepliesWithCursor
+func (r *postgresCommentRepository) ThreadPosition(ctx context.Context, comment *models.Comment, pageSize int, oldestFirst bool) (int64, string, error) {
+	if pageSize <= 0 {
+		return 0, "", fmt.Errorf("page size must be positive")
+	}
+
+	// Siblings listed before the comment, and the order the listing uses
+	where := `post_id = $1 AND parent_comment_id IS NULL AND is_deleted = FALSE
+		AND (created_date > $2 OR (created_date = $2 AND id > $3))` + notAcceptedAnswerClause
+	order := `created_date DESC, id DESC`
+	scope := comment.PostId
+	if oldestFirst {
+		where = `post_id = $1 AND parent_comment_id IS NULL AND is_deleted = FALSE
+		AND (created_date < $2 OR (created_date = $2 AND id < $3))` + notAcceptedAnswerClause
+		order = `created_date ASC, id ASC`
+	}
+	if comment.ParentCommentId != nil {
+		where = `parent_comment_id = $1 AND is_deleted = FALSE
+		AND (created_date < $2 OR (created_date = $2 AND id < $3))`
+		order = `created_date ASC, id ASC`
+		scope = *comment.ParentCommentId
+	}
+
+	var position int64
+	countQuery := `SELECT COUNT(*) FROM comments WHERE ` + where
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &position, countQuery, scope, comment.CreatedDate, comment.ObjectId); err != nil {
+		return 0, "", fmt.Errorf("failed to count preceding comments: %w", err)
+	}
+
+	// The first page needs no cursor; later pages start after the last comment of the page before
+	pageStart := position / int64(pageSize) * int64(pageSize)
+	if pageStart == 0 {
+		return position, "", nil
+	}
+
+	var last struct {
+		ID          uuid.UUID `db:"id"`
+		CreatedDate int64     `db:"created_date"`
+	}
+	lastQuery := `SELECT id, created_date FROM comments WHERE ` + where + ` ORDER BY ` + order + ` OFFSET $4 LIMIT 1`
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &last, lastQuery, scope, comment.CreatedDate, comment.ObjectId, pageStart-1); err != nil {
+		return 0, "", fmt.Errorf("failed to find page boundary: %w", err)
+	}
+
+	cursorData := fmt.Sprintf("%d:%s", last.CreatedDate, last.ID.String())
+	return position, base64.URLEncoding.EncodeToString([]byte(cursorData)), nil
+}
+
+// CountReplies returns the number of live direct replies to a comment
 func (r *postgresCommentRepository) CountReplies(ctx context.Context, parentID uuid.UUID) (int64, error) {
-	query := `SELECT COUNT(*) FROM comments WHERE parent_comment_id = $1 AND is_deleted = FALSE`
+	query := `SELECT reply_count FROM comments WHERE id = $1`
 
 	var count int64
 	err := sqlx.GetContext(ctx, r.getExecutor(ctx), &count, query, parentID)
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
 		return 0, fmt.Errorf("failed to count replies: %w", err)
 	}
 
@@ -681,7 +1034,7 @@ func (r *postgresCommentRepository) Find(ctx context.Context, filter CommentFilt
 		argIndex++
 	}
 	if filter.OwnerUserID != nil {
-		query += fmt.Sprintf(` AND owner_user_id = $%d`, argIndex)
+		query += fmt.Sprintf(` AND owner_user_id = $%d`, argIndex) + notHiddenAuthorClause
 		args = append(args, *filter.OwnerUserID)
 		argIndex++
 	}
@@ -692,6 +1045,9 @@ func (r *postgresCommentRepository) Find(ctx context.Context, filter CommentFilt
 	} else if filter.RootOnly {
 		query += ` AND parent_comment_id IS NULL`
 	}
+	if filter.ExcludeAcceptedAnswer && filter.PostID != nil {
+		query += notAcceptedAnswerClause
+	}
 	if !filter.IncludeDeleted {
 		if filter.Deleted != nil {
 			query += fmt.Sprintf(` AND is_deleted = $%d`, argIndex)
@@ -770,7 +1126,7 @@ func (r *postgresCommentRepository) Count(ctx context.Context, filter CommentFil
 		argIndex++
 	}
 	if filter.OwnerUserID != nil {
-		query += fmt.Sprintf(` AND owner_user_id = $%d`, argIndex)
+		query += fmt.Sprintf(` AND owner_user_id = $%d`, argIndex) + notHiddenAuthorClause
 		args = append(args, *filter.OwnerUserID)
 		argIndex++
 	}
@@ -781,6 +1137,9 @@ func (r *postgresCommentRepository) Count(ctx context.Context, filter CommentFil
 	} else if filter.RootOnly {
 		query += ` AND parent_comment_id IS NULL`
 	}
+	if filter.ExcludeAcceptedAnswer && filter.PostID != nil {
+		query += notAcceptedAnswerClause
+	}
 	if !filter.IncludeDeleted {
 		if filter.Deleted != nil {
 			query += fmt.Sprintf(` AND is_deleted = $%d`, argIndex)
@@ -904,6 +1263,35 @@ func (r *postgresCommentRepository) UpdateOwnerProfile(ctx context.Context, user
 	return nil
 }
 
+// RepairOwnerProfiles copies the current name and avatar of profiles updated since into up to
+// limit of their comments still showing older ones. Repairs are not edits, so the comments'
+// timestamps are kept.
+func (r *postgresCommentRepository) RepairOwnerProfiles(ctx context.Context, since time.Time, limit int) (int64, error) {
+	query := `
+		UPDATE comments c SET
+			owner_display_name = stale.full_name,
+			owner_avatar = stale.avatar
+		FROM (
+			SELECT sc.id, p.full_name, p.avatar
+			FROM comments sc
+			JOIN profiles p ON p.user_id = sc.owner_user_id
+			WHERE p.updated_at >= $1
+			  AND (sc.owner_display_name IS DISTINCT FROM p.full_name OR sc.owner_avatar IS DISTINCT FROM p.avatar)
+			LIMIT $2
+		) stale
+		WHERE c.id = stale.id`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, since, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to repair owner profiles: %w", err)
+	}
+	repaired, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return repaired, nil
+}
+
 // IncrementScore atomically increments the score for a comment
 func (r *postgresCommentRepository) IncrementScore(ctx context.Context, commentID uuid.UUID, delta int) error {
 	query := `UPDATE comments SET score = score + $1, updated_at = NOW(), last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT WHERE id = $2`
@@ -925,21 +1313,30 @@ func (r *postgresCommentRepository) IncrementScore(ctx context.Context, commentI
 	return nil
 }
 
-// Delete soft deletes a comment by ID
+// Delete soft deletes a comment by ID. Deleting a live reply drops its parent's
+// reply_count in the same statement.
 func (r *postgresCommentRepository) Delete(ctx context.Context, commentID uuid.UUID) error {
 	nowUnix := time.Now().Unix()
-	query := `UPDATE comments SET is_deleted = TRUE, deleted_date = $1, updated_at = NOW(), last_updated = $1 WHERE id = $2`
-
-	result, err := r.getExecutor(ctx).ExecContext(ctx, query, nowUnix, commentID)
-	if err != nil {
+	query := `
+		WITH target AS (
+			SELECT id, parent_comment_id, is_deleted FROM comments WHERE id = $2 FOR UPDATE
+		), deleted AS (
+			UPDATE comments c SET is_deleted = TRUE, deleted_date = $1, updated_at = NOW(), last_updated = $1
+			FROM target t
+			WHERE c.id = t.id
+			RETURNING t.parent_comment_id, t.is_deleted AS was_deleted
+		), parent AS (
+			UPDATE comments p SET reply_count = GREATEST(p.reply_count - 1, 0)
+			FROM deleted d
+			WHERE p.id = d.parent_comment_id AND NOT d.was_deleted
+		)
+		SELECT COUNT(*) FROM deleted`
+
+	var rowsAffected int64
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &rowsAffected, query, nowUnix, commentID); err != nil {
 		return fmt.Errorf("failed to delete comment: %w", err)
 	}
 
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
-	}
-
 	if rowsAffected == 0 {
 		return fmt.Errorf("comment not found")
 	}
@@ -960,11 +1357,18 @@ func (r *postgresCommentRepository) DeleteByPostID(ctx context.Context, postID u
 	return nil
 }
 
-// DeleteRepliesByParentID soft deletes all replies to a specific parent comment
-// Used for cascade delete when a root comment is deleted
+// DeleteRepliesByParentID soft deletes all replies below a parent comment, at any depth
+// Used for cascade delete when a comment is deleted
 func (r *postgresCommentRepository) DeleteRepliesByParentID(ctx context.Context, parentID uuid.UUID) error {
 	nowUnix := time.Now().Unix()
-	query := `UPDATE comments SET is_deleted = TRUE, deleted_date = $1, updated_at = NOW(), last_updated = $1 WHERE parent_comment_id = $2 AND is_deleted = FALSE`
+	query := `
+		WITH RECURSIVE descendants AS (
+			SELECT id FROM comments WHERE parent_comment_id = $2
+			UNION
+			SELECT c.id FROM comments c JOIN descendants d ON c.parent_comment_id = d.id
+		)
+		UPDATE comments SET is_deleted = TRUE, deleted_date = $1, updated_at = NOW(), last_updated = $1
+		WHERE id IN (SELECT id FROM descendants) AND is_deleted = FALSE`
 
 	result, err := r.getExecutor(ctx).ExecContext(ctx, query, nowUnix, parentID)
 	if err != nil {
@@ -986,7 +1390,45 @@ func (r *postgresCommentRepository) DeleteRepliesByParentID(ctx context.Context,
 	return nil
 }
 
-// CountRepliesBulk counts replies for multiple comments in a single query
+// DeleteByOwner permanently deletes a user's comments; replies below them go with
+// them through the parent_comment_id foreign key
+func (r *postgresCommentRepository) DeleteByOwner(ctx context.Context, ownerID uuid.UUID) (map[uuid.UUID]int, error) {
+	// Parents owned by the user are deleted by the same statement and skipped
+	query := `
+		WITH deleted AS (
+			DELETE FROM comments WHERE owner_user_id = $1
+			RETURNING post_id, parent_comment_id, is_deleted
+		), parent AS (
+			UPDATE comments p SET reply_count = GREATEST(p.reply_count - d.replies, 0)
+			FROM (
+				SELECT parent_comment_id, COUNT(*) AS replies FROM deleted
+				WHERE parent_comment_id IS NOT NULL AND NOT is_deleted
+				GROUP BY parent_comment_id
+			) d
+			WHERE p.id = d.parent_comment_id AND p.owner_user_id <> $1
+		)
+		SELECT d.post_id, COUNT(*) AS root_count
+		FROM deleted d
+		JOIN posts p ON p.id = d.post_id
+		WHERE d.parent_comment_id IS NULL AND NOT d.is_deleted AND p.owner_user_id <> $1
+		GROUP BY d.post_id`
+
+	var rows []struct {
+		PostID    uuid.UUID `db:"post_id"`
+		RootCount int       `db:"root_count"`
+	}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, ownerID); err != nil {
+		return nil, fmt.Errorf("failed to delete comments by owner: %w", err)
+	}
+
+	removed := make(map[uuid.UUID]int, len(rows))
+	for _, row := range rows {
+		removed[row.PostID] = row.RootCount
+	}
+	return removed, nil
+}
+
+// CountRepliesBulk reads the reply counters of multiple comments in a single query
 // Returns a map of parentCommentID -> replyCount
 // This avoids N+1 queries when loading comment lists
 func (r *postgresCommentRepository) CountRepliesBulk(ctx context.Context, parentIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
@@ -995,14 +1437,13 @@ func (r *postgresCommentRepository) CountRepliesBulk(ctx context.Context, parent
 	}
 
 	query := `
-		SELECT parent_comment_id, COUNT(*) as reply_count
+		SELECT id, reply_count
 		FROM comments
-		WHERE parent_comment_id = ANY($1::uuid[]) AND is_deleted = FALSE
-		GROUP BY parent_comment_id
+		WHERE id = ANY($1::uuid[])
 	`
 
 	type replyCountResult struct {
-		ParentCommentID uuid.UUID `db:"parent_comment_id"`
+		ParentCommentID uuid.UUID `db:"id"`
 		ReplyCount      int64     `db:"reply_count"`
 	}
 
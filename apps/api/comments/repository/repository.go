@@ -7,6 +7,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/comments/models"
@@ -15,36 +16,45 @@ import (
 // CommentFilter represents filtering criteria for querying comments
 type CommentFilter struct {
 	PostID          *uuid.UUID
-	OwnerUserID     *uuid.UUID
+	OwnerUserID     *uuid.UUID // Skips comments made as the hidden author of an anonymous post
 	ParentCommentID *uuid.UUID
 	RootOnly        bool // If true, only return root comments (parent_comment_id IS NULL)
 	IncludeDeleted  bool // If false, filter out deleted comments
 	Deleted         *bool
 	CreatedAfter    *int64
 	CreatedBefore   *int64
+	// ExcludeAcceptedAnswer leaves out the accepted answer of the PostID question, for
+	// listings that pin it to their first page
+	ExcludeAcceptedAnswer bool
 }
 
 // CommentRepository defines the interface for comment-specific database operations
 // This is a domain-specific repository that knows exactly what a "Comment" is
 // and how to execute optimized SQL queries for that specific domain.
 type CommentRepository interface {
-	// Create inserts a new comment
+	// Create inserts a new comment; a reply increments its parent's reply count
 	Create(ctx context.Context, comment *models.Comment) error
 
 	// FindByID retrieves a comment by its ID
 	FindByID(ctx context.Context, commentID uuid.UUID) (*models.Comment, error)
 
+	// FindByIDs retrieves the comments with the given IDs, deleted ones included, in a
+	// single query. IDs that match no comment are absent from the result.
+	FindByIDs(ctx context.Context, commentIDs []uuid.UUID) ([]*models.Comment, error)
+
 	// FindByPostID retrieves comments for a specific post with pagination
 	// Returns root comments (parent_comment_id IS NULL) ordered by created_date DESC
 	FindByPostID(ctx context.Context, postID uuid.UUID, limit, offset int) ([]*models.Comment, error)
 
 	// FindByPostIDWithCursor retrieves comments for a specific post with cursor-based pagination
-	// Returns root comments (parent_comment_id IS NULL) ordered by created_date DESC, id DESC
+	// Returns root comments (parent_comment_id IS NULL) ordered by created_date DESC, id DESC,
+	// or ASC when oldestFirst
 	// cursor is a base64-encoded string containing created_date and id
 	// Returns comments, nextCursor (empty if no more), and error
-	FindByPostIDWithCursor(ctx context.Context, postID uuid.UUID, cursor string, limit int) ([]*models.Comment, string, error)
+	FindByPostIDWithCursor(ctx context.Context, postID uuid.UUID, cursor string, limit int, oldestFirst bool) ([]*models.Comment, string, error)
 
-	// FindByUserID retrieves comments created by a specific user with pagination
+	// FindByUserID retrieves comments created by a specific user with pagination.
+	// Comments made as the hidden author of an anonymous post are left out.
 	FindByUserID(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Comment, error)
 
 	// FindReplies retrieves replies to a specific comment with pagination
@@ -62,7 +72,32 @@ type CommentRepository interface {
 	// CountByPostIDs counts root comments for multiple posts in a single query
 	CountByPostIDs(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int64, error)
 
-	// CountReplies counts replies to a specific comment
+	// LatestByPostIDs returns the newest perPost root comments of each post in a single
+	// query, newest first. Posts without comments are absent from the map.
+	LatestByPostIDs(ctx context.Context, postIDs []uuid.UUID, perPost int) (map[uuid.UUID][]*models.Comment, error)
+
+	// FindAcceptedAnswer returns the live accepted answer of a question post, or nil when
+	// it has none. Paged root listings of the post leave it out.
+	FindAcceptedAnswer(ctx context.Context, postID uuid.UUID) (*models.Comment, error)
+
+	// ThreadPosition returns how many comments precede the given one in its listing
+	// (root comments of the post in the order oldestFirst picks, or replies of its root)
+	// and the cursor of the page that holds it when the listing is read pageSize at a
+	// time; empty on the first page
+	ThreadPosition(ctx context.Context, comment *models.Comment, pageSize int, oldestFirst bool) (int64, string, error)
+
+	// FindPublicByUserBefore lists a user's comments on public, live posts newest first,
+	// starting after (beforeDate, beforeID); a zero beforeDate starts from the newest.
+	// Comments made as the hidden author of an anonymous post are left out.
+	FindPublicByUserBefore(ctx context.Context, userID uuid.UUID, beforeDate int64, beforeID uuid.UUID, limit int) ([]*models.Comment, error)
+
+	// FindByPostIDAfter lists all live comments of a post, replies included, oldest first,
+	// starting after (afterDate, afterID); a zero afterDate starts from the oldest. Used
+	// to page through a whole thread, e.g. for exports.
+	FindByPostIDAfter(ctx context.Context, postID uuid.UUID, afterDate int64, afterID uuid.UUID, limit int) ([]*models.Comment, error)
+
+	// CountReplies returns the number of live direct replies to a comment, kept in its
+	// reply_count as replies are created and deleted
 	CountReplies(ctx context.Context, parentID uuid.UUID) (int64, error)
 
 	// Find retrieves comments matching the filter criteria with pagination
@@ -77,19 +112,29 @@ type CommentRepository interface {
 	// UpdateOwnerProfile updates display name and avatar for all comments by an owner
 	UpdateOwnerProfile(ctx context.Context, userID uuid.UUID, displayName, avatar string) error
 
+	// RepairOwnerProfiles copies the current name and avatar of profiles updated since into
+	// up to limit of their comments still showing older ones. Returns the number repaired.
+	RepairOwnerProfiles(ctx context.Context, since time.Time, limit int) (int64, error)
+
 	// IncrementScore atomically increments the score for a comment
 	IncrementScore(ctx context.Context, commentID uuid.UUID, delta int) error
 
-	// Delete deletes a comment by ID (soft delete)
+	// Delete deletes a comment by ID (soft delete); a deleted reply no longer counts
+	// towards its parent's replies
 	Delete(ctx context.Context, commentID uuid.UUID) error
 
 	// DeleteByPostID soft deletes all comments for a post (batch operation)
 	DeleteByPostID(ctx context.Context, postID uuid.UUID) error
 
-	// DeleteRepliesByParentID soft deletes all replies to a specific parent comment
-	// Used for cascade delete when a root comment is deleted
+	// DeleteRepliesByParentID soft deletes all replies below a parent comment, at any depth
+	// Used for cascade delete when a comment is deleted
 	DeleteRepliesByParentID(ctx context.Context, parentID uuid.UUID) error
 
+	// DeleteByOwner permanently deletes a user's comments and the replies below them.
+	// It returns how many live root comments it removed from each post the user does
+	// not own, for the posts' comment counts.
+	DeleteByOwner(ctx context.Context, ownerID uuid.UUID) (map[uuid.UUID]int, error)
+
 	// AddVote attempts to add a vote (like) for a comment
 	// Returns true if a new row was inserted, false if it already existed
 	AddVote(ctx context.Context, commentID, userID uuid.UUID) (bool, error)
@@ -102,6 +147,19 @@ type CommentRepository interface {
 	// Returns a map of CommentID -> bool (true if user liked it)
 	GetUserVotesForComments(ctx context.Context, commentIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID]bool, error)
 
+	// AddReaction adds a reaction of the given type by the user
+	// Returns true if a new row was inserted, false if it already existed
+	AddReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (bool, error)
+
+	// RemoveReaction removes the user's reaction of the given type
+	// Returns true if a row was deleted, false if no reaction existed
+	RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (bool, error)
+
+	// GetReactionsForComments bulk loads reaction counts and the viewer's reactions
+	// Returns a map of CommentID -> summary; comments without reactions are absent.
+	// A nil viewerID loads counts only.
+	GetReactionsForComments(ctx context.Context, commentIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error)
+
 	// CountRepliesBulk counts replies for multiple comments in a single query
 	// Returns a map of parentCommentID -> replyCount
 	// This avoids N+1 queries when loading comment lists
@@ -86,8 +86,22 @@ func TestPostgresCommentRepository_Integration(t *testing.T) {
 			disable_sharing BOOLEAN DEFAULT FALSE,
 			permission VARCHAR(50) DEFAULT 'Public',
 			version VARCHAR(50),
+			media_text TEXT NOT NULL DEFAULT '',
+			content_hash VARCHAR(64) NOT NULL DEFAULT '',
+			is_anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+			accepted_answer_id UUID,
+			search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(body, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(media_text, '')), 'C')
+			) STORED,
 			metadata JSONB DEFAULT '{}'::jsonb
 		);
+		CREATE TABLE IF NOT EXISTS post_tags (
+			tag VARCHAR(50) NOT NULL,
+			post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+			PRIMARY KEY (tag, post_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_post_tags_post ON post_tags(post_id);
 	`
 	_, err = client.DB().ExecContext(ctx, postsMigrationSQL)
 	require.NoError(t, err, "Failed to apply posts migration")
@@ -139,6 +153,7 @@ func TestPostgresCommentRepository_Integration(t *testing.T) {
 			reply_to_user_id UUID REFERENCES user_auths(id) ON DELETE SET NULL,
 			text TEXT NOT NULL,
 			score BIGINT DEFAULT 0,
+			reply_count BIGINT NOT NULL DEFAULT 0,
 			owner_display_name VARCHAR(255),
 			owner_avatar VARCHAR(512),
 			is_deleted BOOLEAN DEFAULT FALSE,
@@ -72,6 +72,7 @@ func ApplyCommentsMigration(ctx context.Context, iso *testutil.IsolatedTest) err
 			reply_to_display_name VARCHAR(255),
 			text TEXT NOT NULL,
 			score BIGINT DEFAULT 0,
+			reply_count BIGINT NOT NULL DEFAULT 0,
 			owner_display_name VARCHAR(255),
 			owner_avatar VARCHAR(512),
 			is_deleted BOOLEAN DEFAULT FALSE,
@@ -53,7 +53,10 @@ func RegisterRoutes(app *fiber.App, handlers *CommentsHandlers, cfg *platformcon
 	group.Delete("/post/:postId", dualAuthMiddleware, handlers.CommentHandler.DeleteCommentsByPost)
 	// Specific routes with path parameters must come before generic :commentId route
 	group.Get("/:commentId/replies", dualAuthMiddleware, handlers.CommentHandler.GetReplies)
+	group.Get("/:commentId/context", dualAuthMiddleware, handlers.CommentHandler.GetCommentContext)
 	group.Post("/:commentId/like", dualAuthMiddleware, handlers.CommentHandler.ToggleLike)
+	group.Post("/:commentId/reactions", dualAuthMiddleware, handlers.CommentHandler.AddReaction)
+	group.Delete("/:commentId/reactions/:type", dualAuthMiddleware, handlers.CommentHandler.RemoveReaction)
 	group.Delete("/id/:commentId/post/:postId", dualAuthMiddleware, handlers.CommentHandler.DeleteComment)
 	// Generic :commentId route must come last
 	group.Get("/:commentId", dualAuthMiddleware, handlers.CommentHandler.GetComment)
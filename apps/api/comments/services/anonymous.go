@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+)
+
+// hideAnonymousAuthors rewrites comments written by the hidden author of an anonymous
+// post, and replies addressed to them, to show the post's alias instead of the account.
+// It fails rather than return comments it could not check.
+func (s *commentService) hideAnonymousAuthors(ctx context.Context, comments ...*models.Comment) error {
+	if s.postRepo == nil || len(comments) == 0 {
+		return nil
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	postIDs := make([]uuid.UUID, 0, 1)
+	for _, comment := range comments {
+		if comment != nil && !seen[comment.PostId] {
+			seen[comment.PostId] = true
+			postIDs = append(postIDs, comment.PostId)
+		}
+	}
+
+	posts, err := s.postRepo.GetByIDs(ctx, postIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load posts of comments: %w", err)
+	}
+
+	for _, post := range posts {
+		if !post.Anonymous {
+			continue
+		}
+		alias := post.AnonymousAlias
+		for _, comment := range comments {
+			if comment == nil || comment.PostId != post.ObjectId {
+				continue
+			}
+			if post.IsHiddenAuthor(comment.OwnerUserId) {
+				comment.OwnerUserId = uuid.Nil
+				comment.OwnerDisplayName = alias
+				comment.OwnerAvatar = ""
+			}
+			if comment.ReplyToUserId != nil && post.IsHiddenAuthor(*comment.ReplyToUserId) {
+				comment.ReplyToUserId = nil
+				comment.ReplyToDisplayName = &alias
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+)
+
+// acceptedAnswer loads the accepted answer of a question post for pinning on the first
+// page of its comments, marked as such; nil when the post has none
+func (s *commentService) acceptedAnswer(ctx context.Context, postID uuid.UUID) (*models.Comment, error) {
+	answer, err := s.commentRepo.FindAcceptedAnswer(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find accepted answer: %w", err)
+	}
+	if answer != nil {
+		answer.IsAcceptedAnswer = true
+	}
+	return answer, nil
+}
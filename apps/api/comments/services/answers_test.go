@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+)
+
+func TestQueryCommentsWithCursor_PinsAcceptedAnswer(t *testing.T) {
+	ctx := context.Background()
+	postID := uuid.Must(uuid.NewV4())
+	answer := &models.Comment{ObjectId: uuid.Must(uuid.NewV4()), PostId: postID}
+	other := &models.Comment{ObjectId: uuid.Must(uuid.NewV4()), PostId: postID}
+
+	service, mockCommentRepo, _ := setupTestService()
+	mockCommentRepo.ExpectedCalls = nil
+	mockCommentRepo.On("FindAcceptedAnswer", ctx, postID).Return(answer, nil)
+	mockCommentRepo.On("FindByPostIDWithCursor", ctx, postID, mock.Anything, defaultCommentLimit, false).Return([]*models.Comment{other}, "", nil)
+
+	first, err := service.QueryCommentsWithCursor(ctx, &models.CommentQueryFilter{PostId: &postID})
+	require.NoError(t, err)
+	require.Len(t, first.Comments, 2)
+	assert.Equal(t, answer.ObjectId.String(), first.Comments[0].ObjectId)
+	assert.True(t, first.Comments[0].IsAcceptedAnswer)
+	assert.False(t, first.Comments[1].IsAcceptedAnswer)
+
+	// Later pages never repeat it
+	next, err := service.QueryCommentsWithCursor(ctx, &models.CommentQueryFilter{PostId: &postID, Cursor: "next"})
+	require.NoError(t, err)
+	require.Len(t, next.Comments, 1)
+	assert.Equal(t, other.ObjectId.String(), next.Comments[0].ObjectId)
+	mockCommentRepo.AssertNumberOfCalls(t, "FindAcceptedAnswer", 1)
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/comments/models"
+	postsCommon "github.com/qolzam/telar/apps/api/posts/common"
+)
+
+// maxParentDepth caps COMMENTS_MAX_DEPTH and stops parent walks on corrupt data
+const maxParentDepth = 10
+
+// maxReplyDepth returns how many levels replies may nest below a root comment
+func (s *commentService) maxReplyDepth() int {
+	depth := 1
+	if s.config != nil && s.config.Comments.MaxDepth > 0 {
+		depth = s.config.Comments.MaxDepth
+	}
+	if depth > maxParentDepth {
+		depth = maxParentDepth
+	}
+	return depth
+}
+
+// replyDepth returns how many levels below its root a comment sits, counting no further
+// than the deepest reply level
+func (s *commentService) replyDepth(ctx context.Context, comment *models.Comment) (int, error) {
+	limit := s.maxReplyDepth()
+	depth := 0
+	for current := comment; current.ParentCommentId != nil; {
+		depth++
+		if depth >= limit {
+			break
+		}
+		parent, err := s.commentRepo.FindByID(ctx, *current.ParentCommentId)
+		if err != nil {
+			return 0, fmt.Errorf("failed to find parent comment: %w", err)
+		}
+		current = parent
+	}
+	return depth, nil
+}
+
+// postSummaryPreviewLength caps the post body shown alongside a deep-linked comment
+const postSummaryPreviewLength = 280
+
+// GetCommentContext resolves a comment permalink: the comment, its parents, the owning
+// post and the page cursors that land a client on the comment inside a long thread.
+// Comments on posts the viewer cannot see are reported as not found.
+func (s *commentService) GetCommentContext(ctx context.Context, commentID, viewerID uuid.UUID, limit int) (*models.CommentContext, error) {
+	if limit <= 0 {
+		limit = defaultCommentLimit
+	} else if limit > maxCommentLimit {
+		limit = maxCommentLimit
+	}
+
+	comment, err := s.GetComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := s.postRepo.FindByID(ctx, comment.PostId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, commentsErrors.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("failed to find post: %w", err)
+	}
+	if post.Permission == "OnlyMe" && post.OwnerUserId != viewerID {
+		return nil, commentsErrors.ErrCommentNotFound
+	}
+
+	// Walk up to the root; a deleted parent hides the whole branch
+	var parents []*models.Comment
+	root := comment
+	for root.ParentCommentId != nil && len(parents) < maxParentDepth {
+		parent, err := s.GetComment(ctx, *root.ParentCommentId)
+		if err != nil {
+			return nil, err
+		}
+		parents = append([]*models.Comment{parent}, parents...)
+		root = parent
+	}
+
+	// The accepted answer is pinned to the top of the first page
+	location := models.ThreadLocation{Limit: limit}
+	if post.AcceptedAnswerId == nil || *post.AcceptedAnswerId != root.ObjectId {
+		location.RootPosition, location.RootCursor, err = s.commentRepo.ThreadPosition(ctx, root, limit, s.rootsOldestFirst(ctx, ""))
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate comment in thread: %w", err)
+		}
+	}
+	if root != comment {
+		location.ReplyPosition, location.ReplyCursor, err = s.commentRepo.ThreadPosition(ctx, comment, limit, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to locate reply in thread: %w", err)
+		}
+	}
+
+	preview, _ := postsCommon.BodyPreview(post.Body, postSummaryPreviewLength)
+	summary := models.CommentPostSummary{
+		ObjectId:         post.ObjectId.String(),
+		OwnerUserId:      post.OwnerUserId.String(),
+		OwnerDisplayName: post.OwnerDisplayName,
+		OwnerAvatar:      post.OwnerAvatar,
+		URLKey:           post.URLKey,
+		BodyPreview:      preview,
+		CommentCounter:   post.CommentCounter,
+		DisableComments:  post.DisableComments,
+		CreatedDate:      post.CreatedDate,
+	}
+	if post.Anonymous {
+		summary.OwnerUserId = ""
+		summary.OwnerDisplayName = post.AnonymousAlias
+		summary.OwnerAvatar = ""
+	}
+
+	return &models.CommentContext{
+		Comment:  comment,
+		Parents:  parents,
+		Post:     summary,
+		Location: location,
+	}, nil
+}
@@ -14,6 +14,7 @@ import (
     "github.com/qolzam/telar/apps/api/comments/models"
     commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
     "github.com/qolzam/telar/apps/api/internal/cache"
+    "github.com/qolzam/telar/apps/api/internal/hooks"
     "github.com/qolzam/telar/apps/api/internal/pkg/log"
     platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
     "github.com/qolzam/telar/apps/api/internal/types"
@@ -35,6 +36,15 @@ type commentService struct {
     cacheService     *cache.GenericCacheService
     config           *platformconfig.Config
     postStatsUpdater sharedInterfaces.PostStatsUpdater
+    rules            sharedInterfaces.RulesChecker      // nil unless community rules are enabled
+    communities      sharedInterfaces.CommunityChecker  // nil until SetCommunityChecker; archived communities are not enforced
+    feedDefaults     sharedInterfaces.FeedDefaultsProvider // nil until SetFeedDefaults; root comments list newest first
+    keywordMuter     sharedInterfaces.KeywordMuter         // nil until SetKeywordMuter; nothing is muted
+    mentionRecorder  sharedInterfaces.MentionRecorder      // nil until SetMentionRecorder; mentions stay plain text
+    outbox           sharedInterfaces.EventOutbox          // nil until SetEventOutbox; post comment counts are written directly
+    screener         sharedInterfaces.ContentScreener      // nil until SetContentScreener; new comments are not screened
+    contentLimits    sharedInterfaces.ContentLimitsProvider // nil until SetContentLimits; the default limits apply
+    profilesRepairedAt time.Time // Comments of profiles updated before this were repaired; zero until the first repair
 }
 
 // Ensure commentService implements sharedInterfaces.CommentCounter interface
@@ -135,7 +145,22 @@ func (s *commentService) CreateComment(ctx context.Context, req *models.CreateCo
     if user == nil {
         return nil, fmt.Errorf("user context is required")
     }
-
+    text, err := sanitizeText(req.Text)
+    if err != nil {
+        return nil, err
+    }
+    sanitized := *req
+    sanitized.Text = text
+    req = &sanitized
+    if err := s.checkContentLimits(ctx, req.Text); err != nil {
+        return nil, err
+    }
+    if err := s.checkLiveThread(ctx, req.PostId, user.UserID); err != nil {
+        return nil, err
+    }
+    if err := s.checkCommunity(ctx, req.PostId, user); err != nil {
+        return nil, err
+    }
 
     commentID, err := uuid.NewV4()
     if err != nil {
@@ -144,7 +169,8 @@ func (s *commentService) CreateComment(ctx context.Context, req *models.CreateCo
 
     now := utils.UTCNowUnix()
     
-    // Two-Tier Architecture: Flatten nested replies to always point to root
+    // Replies nest under the comment they answer down to the configured depth; deeper
+    // replies join the replies of the comment they answer
     var rootParentID *uuid.UUID
     var replyToUserID *uuid.UUID
     var replyToDisplayName *string
@@ -168,20 +194,22 @@ func (s *commentService) CreateComment(ctx context.Context, req *models.CreateCo
             return nil, fmt.Errorf("parent comment does not belong to the specified post")
         }
         
-        if targetComment.ParentCommentId == nil {
-            // Case A: Replying to a Root Comment
-            // The Root becomes the parent. Track who we're replying to.
+        depth, err := s.replyDepth(ctx, targetComment)
+        if err != nil {
+            return nil, err
+        }
+        if depth < s.maxReplyDepth() {
+            // Case A: Above the deepest level
+            // The target becomes the parent. Track who we're replying to.
             rootParentID = &targetComment.ObjectId
-            replyToUserID = &targetComment.OwnerUserId
-            replyToDisplayName = &targetComment.OwnerDisplayName
         } else {
-            // Case B: Replying to a Reply (Nested)
-            // FLATTEN IT: The parent's parent is the Root.
+            // Case B: Replying at the deepest level
+            // FLATTEN IT: The target's parent takes the reply.
             rootParentID = targetComment.ParentCommentId
-            // We explicitly track who we are replying to for the UI.
-            replyToUserID = &targetComment.OwnerUserId
-            replyToDisplayName = &targetComment.OwnerDisplayName
         }
+        // We explicitly track who we are replying to for the UI.
+        replyToUserID = &targetComment.OwnerUserId
+        replyToDisplayName = &targetComment.OwnerDisplayName
     }
     
     comment := &models.Comment{
@@ -191,7 +219,7 @@ func (s *commentService) CreateComment(ctx context.Context, req *models.CreateCo
         OwnerDisplayName: user.DisplayName,
         OwnerAvatar:      user.Avatar,
         PostId:           req.PostId,
-        ParentCommentId:  rootParentID,  // ALWAYS points to Root (or nil)
+        ParentCommentId:  rootParentID,  // Comment this one nests under (or nil for roots)
         ReplyToUserId:    replyToUserID, // Points to specific user being addressed
         ReplyToDisplayName: replyToDisplayName, // Display name of user being replied to
         Text:             req.Text,
@@ -204,8 +232,8 @@ func (s *commentService) CreateComment(ctx context.Context, req *models.CreateCo
     // Determine if this is a root comment (affects comment_count update)
     isRootComment := rootParentID == nil
 
-    // Use transaction for atomic comment creation + count increment
-    if isRootComment {
+    // Use transaction for atomic comment creation + count increment (or outbox event)
+    if isRootComment || s.outbox != nil {
         // Use PostRepository's WithTransaction to ensure atomicity
         err = s.postRepo.WithTransaction(ctx, func(txCtx context.Context) error {
             // Create comment within transaction
@@ -221,11 +249,7 @@ func (s *commentService) CreateComment(ctx context.Context, req *models.CreateCo
             }
 
             // Increment post comment_count within same transaction
-            if err := s.postRepo.IncrementCommentCount(txCtx, req.PostId, 1); err != nil {
-                return fmt.Errorf("failed to increment comment count: %w", err)
-            }
-
-            return nil
+            return s.recordCommentCreated(txCtx, comment)
         })
         if err != nil {
             // Check if the error is already a domain error (ErrUserNotFound, ErrPostNotFound)
@@ -235,7 +259,7 @@ func (s *commentService) CreateComment(ctx context.Context, req *models.CreateCo
             return nil, fmt.Errorf("failed to create comment atomically: %w", err)
         }
     } else {
-        // For replies, no count update needed - just create the comment
+        // For replies, no post count update needed; Create bumps the parent's reply count
         if err := s.commentRepo.Create(ctx, comment); err != nil {
             // Check for foreign key violations (user or post not found)
             if strings.Contains(err.Error(), "user does not exist") {
@@ -252,6 +276,26 @@ func (s *commentService) CreateComment(ctx context.Context, req *models.CreateCo
     s.invalidatePostComments(ctx, comment.PostId)
     s.invalidateAllComments(ctx)
 
+    parentCommentID := ""
+    if comment.ParentCommentId != nil {
+        parentCommentID = comment.ParentCommentId.String()
+    }
+    hooks.RunAfter(ctx, hooks.CommentAfterCreate, user.UserID.String(), map[string]interface{}{
+        "commentId":       comment.ObjectId.String(),
+        "postId":          comment.PostId.String(),
+        "parentCommentId": parentCommentID,
+        "text":            comment.Text,
+    })
+
+    if err := s.hideAnonymousAuthors(ctx, comment); err != nil {
+        return nil, err
+    }
+    // With an outbox the realtime events are published by its consumer
+    if s.outbox == nil {
+        s.publishCommentCreated(ctx, comment, user.UserID, replyToUserID)
+    }
+    s.recordMentions(ctx, comment, user.UserID)
+    s.screenContent(ctx, comment, user.UserID)
     return comment, nil
 }
 
@@ -280,9 +324,34 @@ func (s *commentService) GetComment(ctx context.Context, commentID uuid.UUID) (*
         return nil, commentsErrors.ErrCommentNotFound
     }
 
+    if err := s.hideAnonymousAuthors(ctx, comment); err != nil {
+        return nil, err
+    }
     return comment, nil
 }
 
+// GetCommentsByIDs loads several comments at once, e.g. for sync, skipping deleted ones
+func (s *commentService) GetCommentsByIDs(ctx context.Context, commentIDs []uuid.UUID) ([]*models.Comment, error) {
+    if len(commentIDs) == 0 {
+        return []*models.Comment{}, nil
+    }
+    found, err := s.commentRepo.FindByIDs(ctx, commentIDs)
+    if err != nil {
+        return nil, fmt.Errorf("failed to find comments: %w", err)
+    }
+
+    comments := make([]*models.Comment, 0, len(found))
+    for _, comment := range found {
+        if !comment.Deleted {
+            comments = append(comments, comment)
+        }
+    }
+    if err := s.hideAnonymousAuthors(ctx, comments...); err != nil {
+        return nil, err
+    }
+    return comments, nil
+}
+
 // GetCommentsByPost lists root comments for a specific post.
 func (s *commentService) GetCommentsByPost(ctx context.Context, postID uuid.UUID, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error) {
     if filter == nil {
@@ -336,6 +405,19 @@ func (s *commentService) QueryComments(ctx context.Context, filter *models.Comme
         repoFilter.CreatedBefore = &createdBefore
     }
 
+    // A question's accepted answer heads its plain root listing and is left out of the pages
+    var answer *models.Comment
+    pinAnswer := filter.PostId != nil && filter.RootOnly && filter.ParentCommentId == nil &&
+        filter.OwnerUserId == nil && filter.CreatedAfter == nil && filter.CreatedBefore == nil &&
+        filter.Deleted == nil && !filter.IncludeDeleted
+    if pinAnswer {
+        var err error
+        if answer, err = s.acceptedAnswer(ctx, *filter.PostId); err != nil {
+            return nil, err
+        }
+        repoFilter.ExcludeAcceptedAnswer = answer != nil
+    }
+
     limit := filter.Limit
     offset := (filter.Page - 1) * filter.Limit
 
@@ -350,6 +432,16 @@ func (s *commentService) QueryComments(ctx context.Context, filter *models.Comme
     if err != nil {
         return nil, fmt.Errorf("failed to count comments: %w", err)
     }
+    if answer != nil {
+        totalCount++
+        if filter.Page <= 1 {
+            comments = append([]*models.Comment{answer}, comments...)
+        }
+    }
+
+    if err := s.hideAnonymousAuthors(ctx, comments...); err != nil {
+        return nil, err
+    }
 
     // Convert to response format
     // Note: IsLiked will be set to false by default, caller should bulk-load votes if needed
@@ -392,11 +484,26 @@ func (s *commentService) QueryCommentsWithCursor(ctx context.Context, filter *mo
     // Use cursor pagination for post comments
     cursor := filter.Cursor
 
-    comments, nextCursor, err := s.commentRepo.FindByPostIDWithCursor(ctx, *filter.PostId, cursor, limit)
+    comments, nextCursor, err := s.commentRepo.FindByPostIDWithCursor(ctx, *filter.PostId, cursor, limit, s.rootsOldestFirst(ctx, filter.SortDirection))
     if err != nil {
         return nil, fmt.Errorf("failed to query comments with cursor: %w", err)
     }
 
+    // The accepted answer is never part of the pages; it heads the first one
+    if cursor == "" {
+        answer, err := s.acceptedAnswer(ctx, *filter.PostId)
+        if err != nil {
+            return nil, err
+        }
+        if answer != nil {
+            comments = append([]*models.Comment{answer}, comments...)
+        }
+    }
+
+    if err := s.hideAnonymousAuthors(ctx, comments...); err != nil {
+        return nil, err
+    }
+
     // Convert to response format
     // Note: IsLiked will be set to false by default, caller should bulk-load votes if needed
     responses := make([]models.CommentResponse, len(comments))
@@ -429,6 +536,10 @@ func (s *commentService) QueryRepliesWithCursor(ctx context.Context, parentID uu
         return nil, fmt.Errorf("failed to query replies with cursor: %w", err)
     }
 
+    if err := s.hideAnonymousAuthors(ctx, replies...); err != nil {
+        return nil, err
+    }
+
     // Convert to response format
     // Note: IsLiked will be set to false by default, caller should bulk-load votes if needed
     responses := make([]models.CommentResponse, len(replies))
@@ -455,6 +566,13 @@ func (s *commentService) UpdateComment(ctx context.Context, commentID uuid.UUID,
     if user == nil {
         return nil, fmt.Errorf("user context is required")
     }
+    text, err := sanitizeText(req.Text)
+    if err != nil {
+        return nil, err
+    }
+    if err := s.checkContentLimits(ctx, text); err != nil {
+        return nil, err
+    }
 
     // Fetch comment and verify ownership
     comment, err := s.commentRepo.FindByID(ctx, commentID)
@@ -474,17 +592,21 @@ func (s *commentService) UpdateComment(ctx context.Context, commentID uuid.UUID,
     }
 
     // Update comment
-    comment.Text = req.Text
+    comment.Text = text
     comment.LastUpdated = utils.UTCNowUnix()
 
-    if err := s.commentRepo.Update(ctx, comment); err != nil {
-        return nil, fmt.Errorf("failed to update comment: %w", err)
+    if err := s.updateComment(ctx, comment); err != nil {
+        return nil, err
     }
 
     s.invalidateUserComments(ctx, user.UserID)
     s.invalidatePostComments(ctx, comment.PostId)
     s.invalidateAllComments(ctx)
     
+    if err := s.hideAnonymousAuthors(ctx, comment); err != nil {
+        return nil, err
+    }
+    s.recordMentions(ctx, comment, user.UserID)
     return comment, nil
 }
 
@@ -542,45 +664,69 @@ func (s *commentService) DeleteComment(ctx context.Context, commentID uuid.UUID,
         return fmt.Errorf("comment does not belong to the provided post")
     }
 
+    return s.removeComment(ctx, comment)
+}
+
+// removeComment soft deletes a comment with its replies, keeps the post's comment count
+// in step and invalidates the cached lists holding it
+func (s *commentService) removeComment(ctx context.Context, comment *models.Comment) error {
     // Check if this is a root comment (affects comment_count update)
     isRootComment := comment.ParentCommentId == nil || *comment.ParentCommentId == uuid.Nil
 
     // Use transaction for atomic comment deletion + count decrement
     if isRootComment {
         // Use PostRepository's WithTransaction to ensure atomicity
-        err = s.postRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+        err := s.postRepo.WithTransaction(ctx, func(txCtx context.Context) error {
             // Soft delete comment within transaction
-            if err := s.commentRepo.Delete(txCtx, commentID); err != nil {
+            if err := s.commentRepo.Delete(txCtx, comment.ObjectId); err != nil {
                 return fmt.Errorf("failed to delete comment: %w", err)
             }
 
-            if err := s.commentRepo.DeleteRepliesByParentID(txCtx, commentID); err != nil {
+            if err := s.commentRepo.DeleteRepliesByParentID(txCtx, comment.ObjectId); err != nil {
                 return fmt.Errorf("failed to cascade delete replies: %w", err)
             }
 
-            // Decrement post comment_count within same transaction
-            if err := s.postRepo.IncrementCommentCount(txCtx, comment.PostId, -1); err != nil {
-                return fmt.Errorf("failed to decrement comment count: %w", err)
+            // A deleted comment can no longer be a question's accepted answer
+            if err := s.postRepo.ClearAcceptedAnswer(txCtx, comment.ObjectId); err != nil {
+                return err
             }
 
-            return nil
+            // Decrement post comment_count within same transaction
+            return s.recordRootCommentsDeleted(txCtx, comment.PostId, &comment.ObjectId, 1)
         })
         if err != nil {
             return fmt.Errorf("failed to delete comment atomically: %w", err)
         }
     } else {
-        // For replies, no count update needed - just delete the comment
-        if err := s.commentRepo.Delete(ctx, commentID); err != nil {
-            return fmt.Errorf("failed to delete comment: %w", err)
+        // For replies, no post count update needed - delete the reply and its own replies
+        if err := s.deleteReply(ctx, comment); err != nil {
+            return err
         }
     }
 
-    s.invalidateUserComments(ctx, user.UserID)
+    s.invalidateUserComments(ctx, comment.OwnerUserId)
     s.invalidatePostComments(ctx, comment.PostId)
     s.invalidateAllComments(ctx)
     return nil
 }
 
+// deleteReply soft deletes a reply and the replies nested below it in one transaction
+func (s *commentService) deleteReply(ctx context.Context, reply *models.Comment) error {
+    err := s.commentRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+        if err := s.commentRepo.Delete(txCtx, reply.ObjectId); err != nil {
+            return fmt.Errorf("failed to delete comment: %w", err)
+        }
+        if err := s.commentRepo.DeleteRepliesByParentID(txCtx, reply.ObjectId); err != nil {
+            return fmt.Errorf("failed to cascade delete replies: %w", err)
+        }
+        return s.recordCommentChanged(txCtx, sharedInterfaces.OutboxReplyDeleted, reply)
+    })
+    if err != nil {
+        return fmt.Errorf("failed to delete reply atomically: %w", err)
+    }
+    return nil
+}
+
 // DeleteCommentsByPost removes all comments for a given post (soft delete).
 func (s *commentService) DeleteCommentsByPost(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
     if user == nil {
@@ -602,11 +748,7 @@ func (s *commentService) DeleteCommentsByPost(ctx context.Context, postID uuid.U
             }
 
             // Decrement post comment_count by the number of root comments
-            if err := s.postRepo.IncrementCommentCount(txCtx, postID, -int(rootCount)); err != nil {
-                return fmt.Errorf("failed to decrement comment count: %w", err)
-            }
-
-            return nil
+            return s.recordRootCommentsDeleted(txCtx, postID, nil, int(rootCount))
         })
         if err != nil {
             return fmt.Errorf("failed to delete comments atomically: %w", err)
@@ -652,17 +794,18 @@ func (s *commentService) DeleteByOwner(ctx context.Context, owner uuid.UUID, obj
                 return fmt.Errorf("failed to cascade delete replies: %w", err)
             }
 
-            if err := s.postRepo.IncrementCommentCount(txCtx, comment.PostId, -1); err != nil {
-                return fmt.Errorf("failed to decrement comment count: %w", err)
+            if err := s.postRepo.ClearAcceptedAnswer(txCtx, objectID); err != nil {
+                return err
             }
-            return nil
+
+            return s.recordRootCommentsDeleted(txCtx, comment.PostId, &objectID, 1)
         })
         if err != nil {
             return fmt.Errorf("failed to delete comment atomically: %w", err)
         }
     } else {
-        if err := s.commentRepo.Delete(ctx, objectID); err != nil {
-            return fmt.Errorf("failed to delete comment: %w", err)
+        if err := s.deleteReply(ctx, comment); err != nil {
+            return err
         }
     }
 
@@ -682,7 +825,7 @@ func (s *commentService) convertToCommentResponse(comment *models.Comment, isLik
     return models.CommentResponse{
         ObjectId:         comment.ObjectId.String(),
         Score:            comment.Score,
-        OwnerUserId:      comment.OwnerUserId.String(),
+        OwnerUserId:      comment.PublicOwnerID(),
         OwnerDisplayName: comment.OwnerDisplayName,
         OwnerAvatar:      comment.OwnerAvatar,
         PostId:           comment.PostId.String(),
@@ -706,6 +849,7 @@ func (s *commentService) convertToCommentResponse(comment *models.Comment, isLik
         DeletedDate:      comment.DeletedDate,
         CreatedDate:      comment.CreatedDate,
         LastUpdated:      comment.LastUpdated,
+        IsAcceptedAnswer: comment.IsAcceptedAnswer,
         IsLiked:          isLiked,
     }
 }
@@ -794,6 +938,9 @@ func (s *commentService) ToggleLike(ctx context.Context, commentID, userID uuid.
 
         return nil
     })
+    if err == nil {
+        err = s.hideAnonymousAuthors(ctx, comment)
+    }
     
     return comment, newScore, isLiked, err
 }
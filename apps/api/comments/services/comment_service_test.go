@@ -21,6 +21,7 @@ import (
 	"github.com/qolzam/telar/apps/api/comments/services/mocks"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/internal/types"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
 )
 
 func createTestUserContext() *types.UserContext {
@@ -77,6 +78,11 @@ func setupTestService() (*commentService, *mocks.MockCommentRepository, *mocks.M
 		config:           cfg,
 		postStatsUpdater: nil,
 	}
+	// Returned comments are checked against their posts for anonymous authors
+	mockPostRepo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*postsModels.Post{}, nil).Maybe()
+	// Root listings look up a pinned accepted answer; deleting a root comment un-accepts it
+	mockCommentRepo.On("FindAcceptedAnswer", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	mockPostRepo.On("ClearAcceptedAnswer", mock.Anything, mock.Anything).Return(nil).Maybe()
 	return svc, mockCommentRepo, mockPostRepo
 }
 
@@ -171,6 +177,51 @@ func TestCreateComment_Reply_NoCountIncrement(t *testing.T) {
 	mockCommentRepo.AssertExpectations(t)
 }
 
+// Test CreateComment nests replies down to COMMENTS_MAX_DEPTH and flattens deeper ones
+func TestCreateComment_ReplyDepth(t *testing.T) {
+	rootID := uuid.Must(uuid.NewV4())
+	childID := uuid.Must(uuid.NewV4())
+	grandchildID := uuid.Must(uuid.NewV4())
+
+	tests := []struct {
+		name       string
+		maxDepth   int
+		targetID   uuid.UUID
+		wantParent uuid.UUID
+	}{
+		{name: "default keeps replies under the root", maxDepth: 0, targetID: childID, wantParent: rootID},
+		{name: "nests below the deepest level", maxDepth: 2, targetID: childID, wantParent: childID},
+		{name: "flattens at the deepest level", maxDepth: 2, targetID: grandchildID, wantParent: childID},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockCommentRepo, _ := setupTestService()
+			service.config.Comments.MaxDepth = tt.maxDepth
+			ctx := context.Background()
+			user := createTestUserContext()
+			req := createTestCreateCommentRequest()
+			req.ParentCommentId = &tt.targetID
+
+			thread := map[uuid.UUID]*models.Comment{
+				rootID:       {ObjectId: rootID, PostId: req.PostId},
+				childID:      {ObjectId: childID, PostId: req.PostId, ParentCommentId: &rootID},
+				grandchildID: {ObjectId: grandchildID, PostId: req.PostId, ParentCommentId: &childID},
+			}
+			for id, comment := range thread {
+				mockCommentRepo.On("FindByID", ctx, id).Return(comment, nil).Maybe()
+			}
+			mockCommentRepo.On("Create", ctx, mock.AnythingOfType("*models.Comment")).Return(nil)
+
+			result, err := service.CreateComment(ctx, req, user)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantParent, *result.ParentCommentId)
+			assert.Equal(t, thread[tt.targetID].OwnerUserId, *result.ReplyToUserId)
+		})
+	}
+}
+
 // Test CreateComment with nil request
 func TestCreateComment_NilRequest_ReturnsError(t *testing.T) {
 	service, _, _ := setupTestService()
@@ -267,6 +318,23 @@ func TestGetComment_DeletedComment_ReturnsError(t *testing.T) {
 	mockCommentRepo.AssertExpectations(t)
 }
 
+func TestGetCommentsByIDs_SkipsDeletedComments(t *testing.T) {
+	service, mockCommentRepo, _ := setupTestService()
+	ctx := context.Background()
+	live := createTestComment()
+	deleted := createTestComment()
+	deleted.Deleted = true
+	ids := []uuid.UUID{live.ObjectId, deleted.ObjectId, uuid.Must(uuid.NewV4())}
+
+	mockCommentRepo.On("FindByIDs", ctx, ids).Return([]*models.Comment{&live, &deleted}, nil).Once()
+
+	result, err := service.GetCommentsByIDs(ctx, ids)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Comment{&live}, result)
+	mockCommentRepo.AssertExpectations(t)
+}
+
 // Test UpdateComment with valid request
 func TestUpdateComment_ValidRequest_Success(t *testing.T) {
 	service, mockCommentRepo, _ := setupTestService()
@@ -423,8 +491,10 @@ func TestDeleteComment_Reply_NoCountDecrement(t *testing.T) {
 	// Setup expectations for FindByID (to verify ownership)
 	mockCommentRepo.On("FindByID", ctx, commentID).Return(&testComment, nil)
 
-	// Setup expectations for Delete (no transaction for replies)
+	// Setup expectations for Delete and the cascade to nested replies (comment transaction only)
+	mockCommentRepo.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
 	mockCommentRepo.On("Delete", ctx, commentID).Return(nil)
+	mockCommentRepo.On("DeleteRepliesByParentID", ctx, commentID).Return(nil)
 
 	// Execute
 	err := service.DeleteComment(ctx, commentID, postID, user)
@@ -544,6 +614,30 @@ func TestUpdateCommentProfile_ValidParameters_Success(t *testing.T) {
 	mockCommentRepo.AssertExpectations(t)
 }
 
+// Test RepairOwnerProfiles
+func TestRepairOwnerProfiles_RepairsInBatchesFromLastRun(t *testing.T) {
+	service, mockCommentRepo, _ := setupTestService()
+	ctx := context.Background()
+
+	// The first repair checks every profile, batch after batch until one comes back short
+	mockCommentRepo.On("RepairOwnerProfiles", ctx, time.Time{}, profileRepairBatch).Return(int64(profileRepairBatch), nil).Once()
+	mockCommentRepo.On("RepairOwnerProfiles", ctx, time.Time{}, profileRepairBatch).Return(int64(3), nil).Once()
+
+	repaired, err := service.RepairOwnerProfiles(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(profileRepairBatch+3), repaired)
+
+	// Later repairs only check profiles changed since shortly before the previous one
+	mockCommentRepo.On("RepairOwnerProfiles", ctx, mock.MatchedBy(func(since time.Time) bool {
+		return !since.IsZero() && time.Since(since) >= profileRepairOverlap
+	}), profileRepairBatch).Return(int64(0), nil).Once()
+
+	repaired, err = service.RepairOwnerProfiles(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), repaired)
+	mockCommentRepo.AssertExpectations(t)
+}
+
 // Test GetRootCommentCount
 func TestGetRootCommentCount_Success(t *testing.T) {
 	service, mockCommentRepo, _ := setupTestService()
@@ -605,3 +699,47 @@ func TestValidateCommentOwnership_InvalidOwner_ReturnsError(t *testing.T) {
 
 	mockCommentRepo.AssertExpectations(t)
 }
+
+// Comments by the hidden author of an anonymous post, and replies addressed to them,
+// carry the post's alias instead of the account
+func TestQueryRepliesWithCursor_HidesAnonymousAuthor(t *testing.T) {
+	mockCommentRepo := &mocks.MockCommentRepository{}
+	mockPostRepo := &mocks.MockPostRepository{}
+	service := &commentService{commentRepo: mockCommentRepo, postRepo: mockPostRepo, config: &platformconfig.Config{}}
+	ctx := context.Background()
+
+	authorID := uuid.Must(uuid.NewV4())
+	post := &postsModels.Post{
+		ObjectId:       uuid.Must(uuid.NewV4()),
+		OwnerUserId:    authorID,
+		Anonymous:      true,
+		AnonymousAlias: "Anonymous Calm Otter 42",
+	}
+	rootID := uuid.Must(uuid.NewV4())
+
+	byAuthor := createTestComment()
+	byAuthor.PostId = post.ObjectId
+	byAuthor.OwnerUserId = authorID
+	byAuthor.ParentCommentId = &rootID
+	toAuthor := createTestComment()
+	toAuthor.PostId = post.ObjectId
+	toAuthor.ParentCommentId = &rootID
+	toAuthor.ReplyToUserId = &authorID
+	realName := "Real Name"
+	toAuthor.ReplyToDisplayName = &realName
+
+	mockCommentRepo.On("FindRepliesWithCursor", ctx, rootID, "", 10).Return([]*models.Comment{&byAuthor, &toAuthor}, "", nil)
+	mockPostRepo.On("GetByIDs", ctx, []uuid.UUID{post.ObjectId}).Return([]*postsModels.Post{post}, nil)
+
+	result, err := service.QueryRepliesWithCursor(ctx, rootID, "", 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Comments, 2)
+	assert.Empty(t, result.Comments[0].OwnerUserId)
+	assert.Equal(t, post.AnonymousAlias, result.Comments[0].OwnerDisplayName)
+	assert.Empty(t, result.Comments[0].OwnerAvatar)
+
+	assert.NotEmpty(t, result.Comments[1].OwnerUserId)
+	assert.Nil(t, result.Comments[1].ReplyToUserId)
+	assert.Equal(t, post.AnonymousAlias, *result.Comments[1].ReplyToDisplayName)
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetCommunityChecker keeps posts in archived communities from taking new comments, and
+// posts in communities that approve their members from taking comments from anyone else;
+// without a checker every post may be commented on
+func (s *commentService) SetCommunityChecker(checker sharedInterfaces.CommunityChecker) {
+	s.communities = checker
+}
+
+// checkCommunity refuses comments on posts made in an archived community, from anyone
+// but its members and admins on posts made in a community that requires approval, and
+// from users who have not acknowledged the community's rules. Posts shared into such a
+// community from elsewhere stay open in their own community.
+func (s *commentService) checkCommunity(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	if s.communities == nil || s.postRepo == nil {
+		return nil
+	}
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	if post.CommunityId == nil {
+		return nil
+	}
+	archived, err := s.communities.IsCommunityArchived(ctx, *post.CommunityId)
+	if err != nil {
+		return fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	if archived {
+		return commentsErrors.ErrCommunityArchived
+	}
+	if user.SystemRole == "admin" {
+		return nil
+	}
+	gated, err := s.communities.RequiresApproval(ctx, *post.CommunityId)
+	if err != nil {
+		return fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	if gated {
+		member, err := s.communities.IsCommunityMember(ctx, *post.CommunityId, user.UserID)
+		if err != nil {
+			return fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+		}
+		if !member {
+			return commentsErrors.ErrMembershipRequired
+		}
+	}
+	return s.checkRules(ctx, *post.CommunityId, user)
+}
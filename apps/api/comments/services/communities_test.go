@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+)
+
+type stubCommunities struct {
+	archived map[uuid.UUID]bool
+	gated    map[uuid.UUID]bool
+	members  map[uuid.UUID]bool
+}
+
+func (s *stubCommunities) IsCommunityMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return s.members[userID], nil
+}
+
+func (s *stubCommunities) IsCommunityModerator(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (s *stubCommunities) IsCommunityArchived(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return s.archived[communityID], nil
+}
+
+func (s *stubCommunities) AllowsAnonymousPosts(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (s *stubCommunities) RequiresApproval(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return s.gated[communityID], nil
+}
+
+func TestCheckCommunity(t *testing.T) {
+	ctx := context.Background()
+	service, _, mockPostRepo := setupTestService()
+
+	active := uuid.Must(uuid.NewV4())
+	archived := uuid.Must(uuid.NewV4())
+	feedPost := uuid.Must(uuid.NewV4())
+	activePost := uuid.Must(uuid.NewV4())
+	archivedPost := uuid.Must(uuid.NewV4())
+	mockPostRepo.On("FindByID", ctx, feedPost).Return(&postsModels.Post{ObjectId: feedPost}, nil)
+	mockPostRepo.On("FindByID", ctx, activePost).Return(&postsModels.Post{ObjectId: activePost, CommunityId: &active}, nil)
+	mockPostRepo.On("FindByID", ctx, archivedPost).Return(&postsModels.Post{ObjectId: archivedPost, CommunityId: &archived}, nil)
+
+	user := &types.UserContext{UserID: uuid.Must(uuid.NewV4())}
+	assert.NoError(t, service.checkCommunity(ctx, archivedPost, user), "nothing is enforced without a checker")
+
+	service.SetCommunityChecker(&stubCommunities{archived: map[uuid.UUID]bool{archived: true}})
+	assert.NoError(t, service.checkCommunity(ctx, feedPost, user))
+	assert.NoError(t, service.checkCommunity(ctx, activePost, user))
+	assert.ErrorIs(t, service.checkCommunity(ctx, archivedPost, user), commentsErrors.ErrCommunityArchived)
+}
+
+func TestCheckCommunityRequiringApproval(t *testing.T) {
+	ctx := context.Background()
+	service, _, mockPostRepo := setupTestService()
+
+	gated := uuid.Must(uuid.NewV4())
+	post := uuid.Must(uuid.NewV4())
+	mockPostRepo.On("FindByID", ctx, post).Return(&postsModels.Post{ObjectId: post, CommunityId: &gated}, nil)
+	member := &types.UserContext{UserID: uuid.Must(uuid.NewV4())}
+	outsider := &types.UserContext{UserID: uuid.Must(uuid.NewV4())}
+	admin := &types.UserContext{UserID: uuid.Must(uuid.NewV4()), SystemRole: "admin"}
+
+	service.SetCommunityChecker(&stubCommunities{
+		gated:   map[uuid.UUID]bool{gated: true},
+		members: map[uuid.UUID]bool{member.UserID: true},
+	})
+	assert.NoError(t, service.checkCommunity(ctx, post, member))
+	assert.NoError(t, service.checkCommunity(ctx, post, admin))
+	assert.ErrorIs(t, service.checkCommunity(ctx, post, outsider), commentsErrors.ErrMembershipRequired)
+}
+
+// stubRules has users acknowledge the rules of the communities in acknowledged
+type stubRules struct {
+	acknowledged map[uuid.UUID]bool
+}
+
+func (s *stubRules) HasAcknowledgedRules(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return s.acknowledged[communityID], nil
+}
+
+func TestCheckCommunityRules(t *testing.T) {
+	ctx := context.Background()
+	service, _, mockPostRepo := setupTestService()
+
+	acknowledged := uuid.Must(uuid.NewV4())
+	unacknowledged := uuid.Must(uuid.NewV4())
+	feedPost := uuid.Must(uuid.NewV4())
+	acknowledgedPost := uuid.Must(uuid.NewV4())
+	unacknowledgedPost := uuid.Must(uuid.NewV4())
+	mockPostRepo.On("FindByID", ctx, feedPost).Return(&postsModels.Post{ObjectId: feedPost}, nil)
+	mockPostRepo.On("FindByID", ctx, acknowledgedPost).Return(&postsModels.Post{ObjectId: acknowledgedPost, CommunityId: &acknowledged}, nil)
+	mockPostRepo.On("FindByID", ctx, unacknowledgedPost).Return(&postsModels.Post{ObjectId: unacknowledgedPost, CommunityId: &unacknowledged}, nil)
+	user := &types.UserContext{UserID: uuid.Must(uuid.NewV4())}
+	admin := &types.UserContext{UserID: uuid.Must(uuid.NewV4()), SystemRole: "admin"}
+
+	service.SetCommunityChecker(&stubCommunities{})
+	service.SetRulesChecker(&stubRules{acknowledged: map[uuid.UUID]bool{acknowledged: true}})
+	assert.NoError(t, service.checkCommunity(ctx, feedPost, user), "posts outside communities have no rules")
+	assert.NoError(t, service.checkCommunity(ctx, acknowledgedPost, user))
+	assert.NoError(t, service.checkCommunity(ctx, unacknowledgedPost, admin))
+	assert.ErrorIs(t, service.checkCommunity(ctx, unacknowledgedPost, user), commentsErrors.ErrRulesNotAcknowledged)
+}
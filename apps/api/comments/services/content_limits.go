@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetContentLimits lets admins choose the longest comment; without a provider the
+// default limit applies
+func (s *commentService) SetContentLimits(provider sharedInterfaces.ContentLimitsProvider) {
+	s.contentLimits = provider
+}
+
+// checkContentLimits checks a new or edited comment's text against the content limits
+func (s *commentService) checkContentLimits(ctx context.Context, text string) error {
+	limits := sharedInterfaces.DefaultContentLimits
+	if s.contentLimits != nil {
+		limits = s.contentLimits.ContentLimits(ctx)
+	}
+
+	var v validate.Validator
+	v.MaxLength("text", text, limits.CommentText)
+	if err := v.Err(); err != nil {
+		return fmt.Errorf("%w: %w", commentsErrors.ErrValidationFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	postsCommon "github.com/qolzam/telar/apps/api/posts/common"
+)
+
+// notificationPreviewLength caps the comment text carried by notification events
+const notificationPreviewLength = 140
+
+// publishCommentCreated announces a new comment on the event bus: to the public feed
+// and the post's topic when the post is public, and as a notification to the post owner and the user
+// replied to. comment must already be masked for anonymous posts; authorID and
+// replyToUserID are the real accounts, used only to address notifications.
+func (s *commentService) publishCommentCreated(ctx context.Context, comment *models.Comment, authorID uuid.UUID, replyToUserID *uuid.UUID) {
+	if s.postRepo == nil || !events.HasSubscribers() {
+		return
+	}
+
+	post, err := s.postRepo.FindByID(ctx, comment.PostId)
+	if err != nil {
+		log.Warn("Skipping realtime events for comment %s: %v", comment.ObjectId, err)
+		return
+	}
+
+	now := time.Now().UTC().UnixMilli()
+	if post.Permission == "Public" {
+		events.Publish(ctx, events.Event{
+			Type:        events.TypeCommentCreated,
+			Data:        s.convertToCommentResponse(comment, false),
+			CreatedDate: now,
+			Public:      true,
+			Topic:       events.PostTopic(post.ObjectId),
+		})
+	}
+
+	preview, _ := postsCommon.BodyPreview(comment.Text, notificationPreviewLength)
+	notify := func(recipient uuid.UUID, kind string) {
+		if s.mutedBy(ctx, recipient, comment.Text) {
+			return
+		}
+		events.Publish(ctx, events.Event{
+			Type: events.TypeNotification,
+			Data: events.Notification{
+				Kind:             kind,
+				PostId:           comment.PostId.String(),
+				CommentId:        comment.ObjectId.String(),
+				ActorUserId:      comment.PublicOwnerID(),
+				ActorDisplayName: comment.OwnerDisplayName,
+				ActorAvatar:      comment.OwnerAvatar,
+				Preview:          preview,
+			},
+			CreatedDate: now,
+			Recipients:  []uuid.UUID{recipient},
+		})
+	}
+
+	// A reply to the post owner reaches them once, as a reply
+	if replyToUserID != nil && *replyToUserID != authorID {
+		notify(*replyToUserID, events.NotificationReply)
+	}
+	if post.OwnerUserId != authorID && (replyToUserID == nil || *replyToUserID != post.OwnerUserId) {
+		notify(post.OwnerUserId, events.NotificationComment)
+	}
+}
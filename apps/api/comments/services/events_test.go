@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+)
+
+func TestCreateComment_PublishesEvents(t *testing.T) {
+	service, mockCommentRepo, mockPostRepo := setupTestService()
+	ctx := context.Background()
+	user := createTestUserContext()
+	req := createTestCreateCommentRequest()
+	postOwnerID := uuid.Must(uuid.NewV4())
+	parentOwnerID := uuid.Must(uuid.NewV4())
+	parentID := uuid.Must(uuid.NewV4())
+	req.ParentCommentId = &parentID
+
+	var published []events.Event
+	unsubscribe := events.Subscribe(func(e events.Event) { published = append(published, e) })
+	defer unsubscribe()
+
+	mockCommentRepo.On("FindByID", ctx, parentID).Return(&models.Comment{
+		ObjectId:         parentID,
+		PostId:           req.PostId,
+		OwnerUserId:      parentOwnerID,
+		OwnerDisplayName: "Parent Author",
+	}, nil)
+	mockCommentRepo.On("Create", ctx, mock.AnythingOfType("*models.Comment")).Return(nil)
+	mockPostRepo.On("FindByID", ctx, req.PostId).Return(&postsModels.Post{
+		ObjectId:    req.PostId,
+		OwnerUserId: postOwnerID,
+		Permission:  "Public",
+	}, nil)
+
+	_, err := service.CreateComment(ctx, req, user)
+	require.NoError(t, err)
+
+	require.Len(t, published, 3)
+	assert.Equal(t, events.TypeCommentCreated, published[0].Type)
+	assert.True(t, published[0].Public)
+
+	recipients := map[uuid.UUID]string{}
+	for _, e := range published[1:] {
+		assert.Equal(t, events.TypeNotification, e.Type)
+		assert.False(t, e.Public)
+		require.Len(t, e.Recipients, 1)
+		notification := e.Data.(events.Notification)
+		assert.Equal(t, user.DisplayName, notification.ActorDisplayName)
+		recipients[e.Recipients[0]] = notification.Kind
+	}
+	assert.Equal(t, map[uuid.UUID]string{
+		parentOwnerID: events.NotificationReply,
+		postOwnerID:   events.NotificationComment,
+	}, recipients)
+}
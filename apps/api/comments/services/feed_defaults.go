@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetFeedDefaults lets admins choose the order root comments are listed in when a
+// request names none; without a provider they are listed newest first
+func (s *commentService) SetFeedDefaults(provider sharedInterfaces.FeedDefaultsProvider) {
+	s.feedDefaults = provider
+}
+
+// rootsOldestFirst reports whether root comments are listed oldest first: "asc" or
+// "desc" when the request chose, the deployment default otherwise
+func (s *commentService) rootsOldestFirst(ctx context.Context, direction string) bool {
+	if direction != "" {
+		return direction == "asc"
+	}
+	return s.feedDefaults != nil && s.feedDefaults.FeedDefaults(ctx).CommentSort == sharedInterfaces.CommentSortOldest
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+type staticFeedDefaults sharedInterfaces.FeedDefaults
+
+func (d staticFeedDefaults) FeedDefaults(ctx context.Context) sharedInterfaces.FeedDefaults {
+	return sharedInterfaces.FeedDefaults(d)
+}
+
+func TestQueryCommentsWithCursor_DefaultCommentSort(t *testing.T) {
+	ctx := context.Background()
+	postID := uuid.Must(uuid.NewV4())
+
+	service, mockCommentRepo, _ := setupTestService()
+	mockCommentRepo.ExpectedCalls = nil
+	service.SetFeedDefaults(staticFeedDefaults{CommentSort: sharedInterfaces.CommentSortOldest})
+	mockCommentRepo.On("FindByPostIDWithCursor", ctx, postID, "next", defaultCommentLimit, true).Return([]*models.Comment{}, "", nil).Once()
+	mockCommentRepo.On("FindByPostIDWithCursor", ctx, postID, "next", defaultCommentLimit, false).Return([]*models.Comment{}, "", nil).Once()
+
+	_, err := service.QueryCommentsWithCursor(ctx, &models.CommentQueryFilter{PostId: &postID, Cursor: "next"})
+	require.NoError(t, err)
+
+	// A sort named by the request wins over the default
+	_, err = service.QueryCommentsWithCursor(ctx, &models.CommentQueryFilter{PostId: &postID, Cursor: "next", SortDirection: "desc"})
+	require.NoError(t, err)
+	mockCommentRepo.AssertExpectations(t)
+	mockCommentRepo.AssertNotCalled(t, "FindAcceptedAnswer", mock.Anything, mock.Anything)
+}
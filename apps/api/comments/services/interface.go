@@ -6,6 +6,7 @@ import (
 	uuid "github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/comments/models"
 	"github.com/qolzam/telar/apps/api/internal/types"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // CommentService defines the interface for comment operations
@@ -16,6 +17,9 @@ type CommentService interface {
 
 	// Read operations
 	GetComment(ctx context.Context, commentID uuid.UUID) (*models.Comment, error)
+	// GetCommentsByIDs returns the live comments among commentIDs in one query; deleted and
+	// unknown IDs are left out
+	GetCommentsByIDs(ctx context.Context, commentIDs []uuid.UUID) ([]*models.Comment, error)
 	GetCommentsByPost(ctx context.Context, postID uuid.UUID, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error)
 	GetCommentsByUser(ctx context.Context, userID uuid.UUID, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error)
 	QueryComments(ctx context.Context, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error)
@@ -26,9 +30,15 @@ type CommentService interface {
 	QueryCommentsWithCursor(ctx context.Context, filter *models.CommentQueryFilter) (*models.CommentsListResponse, error)
 	QueryRepliesWithCursor(ctx context.Context, parentID uuid.UUID, cursor string, limit int) (*models.CommentsListResponse, error)
 
+	// Permalinks: the comment with its parents, post summary and page cursors (limit per page)
+	GetCommentContext(ctx context.Context, commentID, viewerID uuid.UUID, limit int) (*models.CommentContext, error)
+
 	// Update operations
 	UpdateComment(ctx context.Context, commentID uuid.UUID, req *models.UpdateCommentRequest, user *types.UserContext) (*models.Comment, error)
 	UpdateCommentProfile(ctx context.Context, userID uuid.UUID, displayName, avatar string) error
+	// RepairOwnerProfiles brings the stored owner names and avatars of comments up to date with
+	// profiles changed since the last repair. Returns the number of comments repaired.
+	RepairOwnerProfiles(ctx context.Context) (int64, error)
 	IncrementScore(ctx context.Context, commentID uuid.UUID, delta int, user *types.UserContext) error
 	ToggleLike(ctx context.Context, commentID uuid.UUID, userID uuid.UUID) (*models.Comment, int64, bool, error) // Returns (comment, newScore, isLiked, error) - comment returned to avoid re-fetch
 
@@ -38,6 +48,11 @@ type CommentService interface {
 	SoftDeleteComment(ctx context.Context, commentID uuid.UUID, user *types.UserContext) error
 	DeleteByOwner(ctx context.Context, owner uuid.UUID, objectId uuid.UUID) error
 
+	// Moderation: the real owner of a live comment (anonymous or not), and hiding a
+	// comment with its replies whoever owns it
+	CommentOwner(ctx context.Context, commentID uuid.UUID) (uuid.UUID, error)
+	HideComment(ctx context.Context, commentID uuid.UUID) error
+
 	// Utility operations
 	ValidateCommentOwnership(ctx context.Context, commentID uuid.UUID, userID uuid.UUID) error
 
@@ -47,5 +62,45 @@ type CommentService interface {
 	// GetUserVotesForComments bulk checks which comments the user has liked
 	// Returns a map of CommentID -> bool (true if user liked it)
 	GetUserVotesForComments(ctx context.Context, commentIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID]bool, error)
+
+	// Reactions: per-type emoji reactions that leave the score alone; both return the
+	// comment's reactions as seen by the user
+	AddReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (*models.ReactionSummary, error)
+	RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (*models.ReactionSummary, error)
+
+	// GetReactionsForComments bulk loads reaction counts and the viewer's reactions
+	// Returns a map of CommentID -> summary; comments without reactions are absent
+	GetReactionsForComments(ctx context.Context, commentIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error)
+
+	// SetRulesChecker requires acknowledging the community rules to comment
+	SetRulesChecker(checker sharedInterfaces.RulesChecker)
+
+	// SetCommunityChecker refuses comments on posts in archived communities
+	SetCommunityChecker(checker sharedInterfaces.CommunityChecker)
+
+	// SetFeedDefaults applies the deployment's default root comment order
+	SetFeedDefaults(provider sharedInterfaces.FeedDefaultsProvider)
+
+	// DropMutedComments removes the comments containing one of the viewer's muted keywords
+	DropMutedComments(ctx context.Context, viewerID uuid.UUID, comments []models.CommentResponse) []models.CommentResponse
+
+	// SetKeywordMuter hides comments with muted keywords from lists and notifications
+	SetKeywordMuter(muter sharedInterfaces.KeywordMuter)
+
+	// SetMentionRecorder records @mentions in comments on public posts and notifies the users mentioned
+	SetMentionRecorder(recorder sharedInterfaces.MentionRecorder)
+
+	// SetContentLimits enforces the deployment's limit on comment length
+	SetContentLimits(provider sharedInterfaces.ContentLimitsProvider)
+
+	// SetContentScreener checks new comments for abuse and flags them for moderators
+	SetContentScreener(screener sharedInterfaces.ContentScreener)
+
+	// SetEventOutbox writes comment events to the outbox in the comment's transaction
+	// instead of updating post comment counts and publishing realtime events directly
+	SetEventOutbox(outbox sharedInterfaces.EventOutbox)
+
+	// PublishCommentCreated publishes the realtime events of a comment announced through the outbox
+	PublishCommentCreated(ctx context.Context, event sharedInterfaces.CommentCreatedEvent) error
 }
 
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
+	"github.com/qolzam/telar/apps/api/internal/utils"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// checkLiveThread applies a post's live thread to a new comment: once the thread has
+// ended the post is frozen, and while it is live everyone but the host waits the
+// thread's slow-mode interval between comments
+func (s *commentService) checkLiveThread(ctx context.Context, postID, userID uuid.UUID) error {
+	if s.config == nil || !s.config.LiveThreads.Enabled || s.postRepo == nil {
+		return nil
+	}
+
+	thread, err := s.postRepo.GetLiveThread(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+
+	now := utils.UTCNowUnix()
+	switch thread.StateAt(now) {
+	case postsModels.LiveStateEnded:
+		return commentsErrors.ErrCommentsClosed
+	case postsModels.LiveStateScheduled:
+		return nil
+	}
+	if thread.SlowModeSeconds <= 0 {
+		return nil
+	}
+
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	if post.OwnerUserId == userID {
+		return nil
+	}
+
+	since := now - int64(thread.SlowModeSeconds)*1000
+	recent, err := s.commentRepo.Find(ctx, commentRepository.CommentFilter{
+		PostID:       &postID,
+		OwnerUserID:  &userID,
+		CreatedAfter: &since,
+	}, 1, 0)
+	if err != nil {
+		return fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	if len(recent) == 0 {
+		return nil
+	}
+	waitMillis := recent[0].CreatedDate - since
+	return &commentsErrors.SlowModeError{RetryAfter: int((waitMillis + 999) / 1000)}
+}
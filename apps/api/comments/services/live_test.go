@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/internal/utils"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+)
+
+func TestCheckLiveThread(t *testing.T) {
+	ctx := context.Background()
+	service, mockCommentRepo, mockPostRepo := setupTestService()
+	service.config.LiveThreads.Enabled = true
+
+	now := utils.UTCNowUnix()
+	hostID := uuid.Must(uuid.NewV4())
+	guestID := uuid.Must(uuid.NewV4())
+	plain := uuid.Must(uuid.NewV4())
+	live := uuid.Must(uuid.NewV4())
+	ended := uuid.Must(uuid.NewV4())
+
+	mockPostRepo.On("GetLiveThread", ctx, plain).Return(nil, sql.ErrNoRows)
+	mockPostRepo.On("GetLiveThread", ctx, live).Return(&postsModels.LiveThread{PostId: live, StartsAt: now - 60000, EndsAt: now + 60000, SlowModeSeconds: 30}, nil)
+	mockPostRepo.On("GetLiveThread", ctx, ended).Return(&postsModels.LiveThread{PostId: ended, StartsAt: now - 60000, EndsAt: now - 1000}, nil)
+	mockPostRepo.On("FindByID", ctx, live).Return(&postsModels.Post{ObjectId: live, OwnerUserId: hostID}, nil)
+	// The guest commented ten seconds ago
+	mockCommentRepo.On("Find", ctx, mock.Anything, 1, 0).Return([]*models.Comment{{CreatedDate: now - 10000}}, nil)
+
+	assert.NoError(t, service.checkLiveThread(ctx, plain, guestID))
+	assert.ErrorIs(t, service.checkLiveThread(ctx, ended, guestID), commentsErrors.ErrCommentsClosed)
+	assert.NoError(t, service.checkLiveThread(ctx, live, hostID), "the host is not slow-moded")
+
+	err := service.checkLiveThread(ctx, live, guestID)
+	var slowMode *commentsErrors.SlowModeError
+	require.ErrorAs(t, err, &slowMode)
+	assert.ErrorIs(t, err, commentsErrors.ErrSlowMode)
+	assert.InDelta(t, 20, slowMode.RetryAfter, 1)
+
+	service.config.LiveThreads.Enabled = false
+	assert.NoError(t, service.checkLiveThread(ctx, ended, guestID), "live thread rules are off with the feature")
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetMentionRecorder records the @mentions in comments on public posts and notifies the
+// users mentioned. Without a recorder mentions stay plain text.
+func (s *commentService) SetMentionRecorder(recorder sharedInterfaces.MentionRecorder) {
+	s.mentionRecorder = recorder
+}
+
+// recordMentions hands a comment on a public post to the mention recorder. comment must
+// already be masked for anonymous posts; authorID is the real account, which is never
+// notified of its own mentions.
+func (s *commentService) recordMentions(ctx context.Context, comment *models.Comment, authorID uuid.UUID) {
+	if s.mentionRecorder == nil || s.postRepo == nil {
+		return
+	}
+
+	post, err := s.postRepo.FindByID(ctx, comment.PostId)
+	if err != nil {
+		log.Warn("Skipping mentions in comment %s: %v", comment.ObjectId, err)
+		return
+	}
+	if post.Permission != "Public" {
+		return
+	}
+
+	commentID := comment.ObjectId
+	source := sharedInterfaces.MentionSource{
+		PostID:           comment.PostId,
+		CommentID:        &commentID,
+		AuthorID:         authorID,
+		ActorDisplayName: comment.OwnerDisplayName,
+		ActorAvatar:      comment.OwnerAvatar,
+		Text:             comment.Text,
+		HidePreview:      post.SupporterOnly,
+	}
+	if comment.OwnerUserId != uuid.Nil {
+		ownerID := comment.OwnerUserId
+		source.ActorUserID = &ownerID
+	}
+	s.mentionRecorder.RecordMentions(ctx, source)
+}
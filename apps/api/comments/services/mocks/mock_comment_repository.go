@@ -7,6 +7,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/comments/models"
@@ -42,8 +43,8 @@ func (m *MockCommentRepository) FindByPostID(ctx context.Context, postID uuid.UU
 	return args.Get(0).([]*models.Comment), args.Error(1)
 }
 
-func (m *MockCommentRepository) FindByPostIDWithCursor(ctx context.Context, postID uuid.UUID, cursor string, limit int) ([]*models.Comment, string, error) {
-	args := m.Called(ctx, postID, cursor, limit)
+func (m *MockCommentRepository) FindByPostIDWithCursor(ctx context.Context, postID uuid.UUID, cursor string, limit int, oldestFirst bool) ([]*models.Comment, string, error) {
+	args := m.Called(ctx, postID, cursor, limit, oldestFirst)
 	if args.Get(0) == nil {
 		return nil, args.String(1), args.Error(2)
 	}
@@ -79,6 +80,14 @@ func (m *MockCommentRepository) CountByPostID(ctx context.Context, postID uuid.U
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockCommentRepository) FindByIDs(ctx context.Context, commentIDs []uuid.UUID) ([]*models.Comment, error) {
+	args := m.Called(ctx, commentIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Comment), args.Error(1)
+}
+
 func (m *MockCommentRepository) CountByPostIDs(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int64, error) {
 	args := m.Called(ctx, postIDs)
 	if args.Get(0) == nil {
@@ -87,6 +96,43 @@ func (m *MockCommentRepository) CountByPostIDs(ctx context.Context, postIDs []uu
 	return args.Get(0).(map[uuid.UUID]int64), args.Error(1)
 }
 
+func (m *MockCommentRepository) LatestByPostIDs(ctx context.Context, postIDs []uuid.UUID, perPost int) (map[uuid.UUID][]*models.Comment, error) {
+	args := m.Called(ctx, postIDs, perPost)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindAcceptedAnswer(ctx context.Context, postID uuid.UUID) (*models.Comment, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) ThreadPosition(ctx context.Context, comment *models.Comment, pageSize int, oldestFirst bool) (int64, string, error) {
+	args := m.Called(ctx, comment, pageSize, oldestFirst)
+	return args.Get(0).(int64), args.String(1), args.Error(2)
+}
+
+func (m *MockCommentRepository) FindPublicByUserBefore(ctx context.Context, userID uuid.UUID, beforeDate int64, beforeID uuid.UUID, limit int) ([]*models.Comment, error) {
+	args := m.Called(ctx, userID, beforeDate, beforeID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Comment), args.Error(1)
+}
+
+func (m *MockCommentRepository) FindByPostIDAfter(ctx context.Context, postID uuid.UUID, afterDate int64, afterID uuid.UUID, limit int) ([]*models.Comment, error) {
+	args := m.Called(ctx, postID, afterDate, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Comment), args.Error(1)
+}
+
 func (m *MockCommentRepository) CountReplies(ctx context.Context, parentID uuid.UUID) (int64, error) {
 	args := m.Called(ctx, parentID)
 	return args.Get(0).(int64), args.Error(1)
@@ -123,6 +169,11 @@ func (m *MockCommentRepository) UpdateOwnerProfile(ctx context.Context, userID u
 	return args.Error(0)
 }
 
+func (m *MockCommentRepository) RepairOwnerProfiles(ctx context.Context, since time.Time, limit int) (int64, error) {
+	args := m.Called(ctx, since, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockCommentRepository) IncrementScore(ctx context.Context, commentID uuid.UUID, delta int) error {
 	args := m.Called(ctx, commentID, delta)
 	return args.Error(0)
@@ -143,6 +194,14 @@ func (m *MockCommentRepository) DeleteRepliesByParentID(ctx context.Context, par
 	return args.Error(0)
 }
 
+func (m *MockCommentRepository) DeleteByOwner(ctx context.Context, ownerID uuid.UUID) (map[uuid.UUID]int, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
+}
+
 func (m *MockCommentRepository) AddVote(ctx context.Context, commentID, userID uuid.UUID) (bool, error) {
 	args := m.Called(ctx, commentID, userID)
 	return args.Bool(0), args.Error(1)
@@ -161,6 +220,24 @@ func (m *MockCommentRepository) GetUserVotesForComments(ctx context.Context, com
 	return args.Get(0).(map[uuid.UUID]bool), args.Error(1)
 }
 
+func (m *MockCommentRepository) AddReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (bool, error) {
+	args := m.Called(ctx, commentID, userID, reactionType)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (bool, error) {
+	args := m.Called(ctx, commentID, userID, reactionType)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockCommentRepository) GetReactionsForComments(ctx context.Context, commentIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error) {
+	args := m.Called(ctx, commentIDs, viewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]*models.ReactionSummary), args.Error(1)
+}
+
 func (m *MockCommentRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
 	args := m.Called(ctx, fn)
 	// Execute the function within the mock
@@ -71,12 +71,33 @@ func (m *MockPostRepository) Count(ctx context.Context, filter postsRepository.P
 	return args.Get(0).(int64), args.Error(1)
 }
 
-func (m *MockPostRepository) Search(ctx context.Context, query string, limit int) ([]*models.Post, error) {
-	args := m.Called(ctx, query, limit)
+func (m *MockPostRepository) FullTextSearch(ctx context.Context, query models.PostSearchQuery) ([]postsRepository.SearchHit, bool, error) {
+	args := m.Called(ctx, query)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, args.Bool(1), args.Error(2)
 	}
-	return args.Get(0).([]*models.Post), args.Error(1)
+	return args.Get(0).([]postsRepository.SearchHit), args.Bool(1), args.Error(2)
+}
+
+func (m *MockPostRepository) FindByTag(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) ([]*models.Post, bool, error) {
+	args := m.Called(ctx, tag, within, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.Post), args.Bool(1), args.Error(2)
+}
+
+func (m *MockPostRepository) RefreshTrendingTags(ctx context.Context, window string, since, computedDate int64, limit int) error {
+	args := m.Called(ctx, window, since, computedDate, limit)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) ListTrendingTags(ctx context.Context, window string) ([]models.TrendingTag, int64, error) {
+	args := m.Called(ctx, window)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]models.TrendingTag), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *MockPostRepository) Update(ctx context.Context, post *models.Post) error {
@@ -89,6 +110,37 @@ func (m *MockPostRepository) UpdateOwnerProfile(ctx context.Context, ownerID uui
 	return args.Error(0)
 }
 
+func (m *MockPostRepository) ReplaceImage(ctx context.Context, ownerID uuid.UUID, key, fullPath, thumbnail string) ([]uuid.UUID, error) {
+	args := m.Called(ctx, ownerID, key, fullPath, thumbnail)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockPostRepository) UpdateMediaText(ctx context.Context, postID uuid.UUID, mediaText string) error {
+	args := m.Called(ctx, postID, mediaText)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) ArchiveExpired(ctx context.Context, now int64, limit int) (int64, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPostRepository) GetReadMarkers(ctx context.Context, userID uuid.UUID, feedKeys []string) (map[string]int64, error) {
+	args := m.Called(ctx, userID, feedKeys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func (m *MockPostRepository) SaveReadMarker(ctx context.Context, userID uuid.UUID, feedKey string, lastReadDate int64) error {
+	args := m.Called(ctx, userID, feedKey, lastReadDate)
+	return args.Error(0)
+}
+
 func (m *MockPostRepository) SetCommentDisabled(ctx context.Context, postID uuid.UUID, disabled bool, ownerID uuid.UUID) error {
 	args := m.Called(ctx, postID, disabled, ownerID)
 	return args.Error(0)
@@ -104,6 +156,11 @@ func (m *MockPostRepository) IncrementViewCount(ctx context.Context, postID uuid
 	return args.Error(0)
 }
 
+func (m *MockPostRepository) IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error {
+	args := m.Called(ctx, postID, delta)
+	return args.Error(0)
+}
+
 func (m *MockPostRepository) IncrementCommentCount(ctx context.Context, postID uuid.UUID, delta int) error {
 	args := m.Called(ctx, postID, delta)
 	return args.Error(0)
@@ -130,6 +187,11 @@ func (m *MockPostRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockPostRepository) DeleteByOwner(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 func (m *MockPostRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error) {
 	args := m.Called(ctx, ids)
 	if args.Get(0) == nil {
@@ -137,3 +199,119 @@ func (m *MockPostRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*
 	}
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
+
+func (m *MockPostRepository) AddCoauthor(ctx context.Context, coauthor *models.PostCoauthor) (bool, error) {
+	args := m.Called(ctx, coauthor)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) GetCoauthor(ctx context.Context, postID, userID uuid.UUID) (*models.PostCoauthor, error) {
+	args := m.Called(ctx, postID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PostCoauthor), args.Error(1)
+}
+
+func (m *MockPostRepository) AcceptCoauthor(ctx context.Context, postID, userID uuid.UUID, displayName, avatar string, respondedDate int64) (bool, error) {
+	args := m.Called(ctx, postID, userID, displayName, avatar, respondedDate)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) RemoveCoauthor(ctx context.Context, postID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, postID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) DeleteReadMarkersByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) DeleteCoauthorsByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) AddCommunityPlacement(ctx context.Context, placement *models.CommunityPlacement) (bool, error) {
+	args := m.Called(ctx, placement)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) RemoveCommunityPlacement(ctx context.Context, postID, communityID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, postID, communityID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) ListCommunityPlacements(ctx context.Context, postID uuid.UUID) ([]models.CommunityPlacement, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityPlacement), args.Error(1)
+}
+
+func (m *MockPostRepository) ListCoauthors(ctx context.Context, postIDs []uuid.UUID, status string) (map[uuid.UUID][]models.PostCoauthor, error) {
+	args := m.Called(ctx, postIDs, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]models.PostCoauthor), args.Error(1)
+}
+
+func (m *MockPostRepository) ListPendingCoauthorInvites(ctx context.Context, userID uuid.UUID) ([]models.PostCoauthor, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PostCoauthor), args.Error(1)
+}
+
+func (m *MockPostRepository) SetAcceptedAnswer(ctx context.Context, postID uuid.UUID, commentID *uuid.UUID, ownerID uuid.UUID) error {
+	args := m.Called(ctx, postID, commentID, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) ClearAcceptedAnswer(ctx context.Context, commentID uuid.UUID) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) SaveLiveThread(ctx context.Context, thread *models.LiveThread) error {
+	args := m.Called(ctx, thread)
+	return args.Error(0)
+}
+
+func (m *MockPostRepository) GetLiveThread(ctx context.Context, postID uuid.UUID) (*models.LiveThread, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LiveThread), args.Error(1)
+}
+
+func (m *MockPostRepository) DeleteLiveThread(ctx context.Context, postID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepository) CloseEndedLiveThreads(ctx context.Context, now int64, limit int) ([]*models.LiveThread, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LiveThread), args.Error(1)
+}
+
+func (m *MockPostRepository) ListUnsummarizedLiveThreads(ctx context.Context, maxAttempts, limit int) ([]*models.LiveThread, error) {
+	args := m.Called(ctx, maxAttempts, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LiveThread), args.Error(1)
+}
+
+func (m *MockPostRepository) SaveLiveThreadSummary(ctx context.Context, postID uuid.UUID, summary string, keyPoints []string) error {
+	args := m.Called(ctx, postID, summary, keyPoints)
+	return args.Error(0)
+}
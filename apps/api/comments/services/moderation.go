@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/comments/models"
+)
+
+// CommentOwner returns the owner of a comment that is not deleted. Unlike GetComment
+// it reads the repository directly, so anonymous comments give their real author.
+func (s *commentService) CommentOwner(ctx context.Context, commentID uuid.UUID) (uuid.UUID, error) {
+	comment, err := s.findLiveComment(ctx, commentID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return comment.OwnerUserId, nil
+}
+
+// HideComment soft-deletes a comment and its replies for a moderator, whoever owns it
+func (s *commentService) HideComment(ctx context.Context, commentID uuid.UUID) error {
+	comment, err := s.findLiveComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+	return s.removeComment(ctx, comment)
+}
+
+func (s *commentService) findLiveComment(ctx context.Context, commentID uuid.UUID) (*models.Comment, error) {
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		if err.Error() == "comment not found" {
+			return nil, commentsErrors.ErrCommentNotFound
+		}
+		return nil, fmt.Errorf("failed to find comment: %w", err)
+	}
+	if comment.Deleted {
+		return nil, commentsErrors.ErrCommentNotFound
+	}
+	return comment, nil
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetKeywordMuter lets readers mute keywords: comments containing one are left out of
+// their comment lists and notifications. Without a muter nothing is hidden.
+func (s *commentService) SetKeywordMuter(muter sharedInterfaces.KeywordMuter) {
+	s.keywordMuter = muter
+}
+
+// DropMutedComments removes the comments the viewer muted; the viewer's own comments
+// always stay. A page may come back shorter than its limit without affecting its cursor.
+func (s *commentService) DropMutedComments(ctx context.Context, viewerID uuid.UUID, comments []models.CommentResponse) []models.CommentResponse {
+	if s.keywordMuter == nil || viewerID == uuid.Nil || len(comments) == 0 {
+		return comments
+	}
+	matcher := s.keywordMuter.MutedMatcher(ctx, viewerID)
+	if matcher == nil {
+		return comments
+	}
+	viewer := viewerID.String()
+	kept := make([]models.CommentResponse, 0, len(comments))
+	for _, comment := range comments {
+		if comment.OwnerUserId == viewer || !matcher.Match(comment.Text) {
+			kept = append(kept, comment)
+		}
+	}
+	return kept
+}
+
+// mutedBy reports whether userID muted a keyword that text contains
+func (s *commentService) mutedBy(ctx context.Context, userID uuid.UUID, text string) bool {
+	if s.keywordMuter == nil {
+		return false
+	}
+	matcher := s.keywordMuter.MutedMatcher(ctx, userID)
+	return matcher != nil && matcher.Match(text)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// substringMuter mutes, for every user in it, text containing its phrase
+type substringMuter map[uuid.UUID]string
+
+func (m substringMuter) MutedMatcher(ctx context.Context, userID uuid.UUID) sharedInterfaces.KeywordMatcher {
+	if phrase, ok := m[userID]; ok {
+		return substringMatcher(phrase)
+	}
+	return nil
+}
+
+type substringMatcher string
+
+func (m substringMatcher) Match(text string) bool { return strings.Contains(text, string(m)) }
+
+func TestDropMutedComments(t *testing.T) {
+	ctx := context.Background()
+	viewer := uuid.Must(uuid.NewV4())
+	author := uuid.Must(uuid.NewV4()).String()
+	comments := []models.CommentResponse{
+		{ObjectId: "1", OwnerUserId: author, Text: "the ending spoilers"},
+		{ObjectId: "2", OwnerUserId: viewer.String(), Text: "my spoilers"},
+		{ObjectId: "3", OwnerUserId: author, Text: "great post"},
+	}
+
+	service, _, _ := setupTestService()
+	assert.Len(t, service.DropMutedComments(ctx, viewer, comments), 3, "nothing is muted without a muter")
+
+	service.SetKeywordMuter(substringMuter{viewer: "spoilers"})
+	kept := service.DropMutedComments(ctx, viewer, comments)
+	if assert.Len(t, kept, 2) {
+		assert.Equal(t, "2", kept[0].ObjectId)
+		assert.Equal(t, "3", kept[1].ObjectId)
+	}
+	assert.Len(t, service.DropMutedComments(ctx, uuid.Nil, comments), 3)
+	assert.True(t, service.mutedBy(ctx, viewer, "spoilers ahead"))
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetEventOutbox makes comments announce their creation and deletion through the
+// transactional outbox instead of writing post comment counts directly. Consumers then
+// update the counts (posts) and publish realtime events (PublishCommentCreated). Edits
+// and deleted replies are announced too, for the sync endpoint.
+func (s *commentService) SetEventOutbox(outbox sharedInterfaces.EventOutbox) {
+	s.outbox = outbox
+}
+
+// recordCommentCreated runs in the transaction creating comment. Without an outbox it
+// counts a root comment on its post; replies do not change the count.
+func (s *commentService) recordCommentCreated(txCtx context.Context, comment *models.Comment) error {
+	if s.outbox != nil {
+		return s.outbox.Append(txCtx, sharedInterfaces.OutboxCommentCreated, comment.PostId.String(), sharedInterfaces.CommentCreatedEvent{
+			CommentId:       comment.ObjectId,
+			PostId:          comment.PostId,
+			AuthorUserId:    comment.OwnerUserId,
+			ParentCommentId: comment.ParentCommentId,
+			ReplyToUserId:   comment.ReplyToUserId,
+		})
+	}
+	if comment.ParentCommentId != nil {
+		return nil
+	}
+	if err := s.postRepo.IncrementCommentCount(txCtx, comment.PostId, 1); err != nil {
+		return fmt.Errorf("failed to increment comment count: %w", err)
+	}
+	return nil
+}
+
+// recordRootCommentsDeleted runs in the transaction deleting rootCount root comments of
+// a post: commentID, or all of them when commentID is nil
+func (s *commentService) recordRootCommentsDeleted(txCtx context.Context, postID uuid.UUID, commentID *uuid.UUID, rootCount int) error {
+	if s.outbox != nil {
+		return s.outbox.Append(txCtx, sharedInterfaces.OutboxCommentDeleted, postID.String(), sharedInterfaces.CommentDeletedEvent{
+			PostId:    postID,
+			CommentId: commentID,
+			RootCount: rootCount,
+		})
+	}
+	if err := s.postRepo.IncrementCommentCount(txCtx, postID, -rootCount); err != nil {
+		return fmt.Errorf("failed to decrement comment count: %w", err)
+	}
+	return nil
+}
+
+// updateComment saves an edited comment, in one transaction with its event when there is
+// an outbox
+func (s *commentService) updateComment(ctx context.Context, comment *models.Comment) error {
+	if s.outbox == nil {
+		if err := s.commentRepo.Update(ctx, comment); err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		return nil
+	}
+	return s.commentRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.commentRepo.Update(txCtx, comment); err != nil {
+			return fmt.Errorf("failed to update comment: %w", err)
+		}
+		return s.recordCommentChanged(txCtx, sharedInterfaces.OutboxCommentUpdated, comment)
+	})
+}
+
+// recordCommentChanged runs in the transaction editing comment or deleting a reply
+func (s *commentService) recordCommentChanged(txCtx context.Context, eventType string, comment *models.Comment) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.Append(txCtx, eventType, comment.PostId.String(), sharedInterfaces.CommentChangedEvent{
+		CommentId: comment.ObjectId,
+		PostId:    comment.PostId,
+	})
+}
+
+// PublishCommentCreated publishes the realtime events of a comment announced through
+// the outbox. Comments deleted since are skipped.
+func (s *commentService) PublishCommentCreated(ctx context.Context, event sharedInterfaces.CommentCreatedEvent) error {
+	comment, err := s.commentRepo.FindByID(ctx, event.CommentId)
+	if err != nil {
+		if err.Error() == "comment not found" {
+			return nil
+		}
+		return fmt.Errorf("failed to find comment: %w", err)
+	}
+	if comment.Deleted {
+		return nil
+	}
+	if err := s.hideAnonymousAuthors(ctx, comment); err != nil {
+		return err
+	}
+	s.publishCommentCreated(ctx, comment, event.AuthorUserId, event.ReplyToUserId)
+	return nil
+}
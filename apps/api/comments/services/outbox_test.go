@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+type appendedEvent struct {
+	eventType string
+	key       string
+	data      interface{}
+}
+
+// recordingOutbox records appended events
+type recordingOutbox struct {
+	events []appendedEvent
+}
+
+func (o *recordingOutbox) Append(ctx context.Context, eventType string, key string, data interface{}) error {
+	o.events = append(o.events, appendedEvent{eventType, key, data})
+	return nil
+}
+
+func TestCreateComment_WithOutbox_AppendsEvent(t *testing.T) {
+	service, mockCommentRepo, mockPostRepo := setupTestService()
+	outbox := &recordingOutbox{}
+	service.SetEventOutbox(outbox)
+	ctx := context.Background()
+	user := createTestUserContext()
+	req := createTestCreateCommentRequest()
+
+	mockPostRepo.On("WithTransaction", ctx, mock.Anything).Return(nil)
+	mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Comment")).Return(nil)
+
+	comment, err := service.CreateComment(ctx, req, user)
+
+	require.NoError(t, err)
+	require.Len(t, outbox.events, 1)
+	assert.Equal(t, sharedInterfaces.OutboxCommentCreated, outbox.events[0].eventType)
+	assert.Equal(t, req.PostId.String(), outbox.events[0].key)
+	assert.Equal(t, sharedInterfaces.CommentCreatedEvent{
+		CommentId:    comment.ObjectId,
+		PostId:       req.PostId,
+		AuthorUserId: user.UserID,
+	}, outbox.events[0].data)
+	mockPostRepo.AssertNotCalled(t, "IncrementCommentCount")
+}
+
+func TestCreateComment_WithOutbox_ReplyAppendsEvent(t *testing.T) {
+	service, mockCommentRepo, mockPostRepo := setupTestService()
+	outbox := &recordingOutbox{}
+	service.SetEventOutbox(outbox)
+	ctx := context.Background()
+	user := createTestUserContext()
+	req := createTestCreateCommentRequest()
+	parentID := uuid.Must(uuid.NewV4())
+	parentOwner := uuid.Must(uuid.NewV4())
+	req.ParentCommentId = &parentID
+
+	mockCommentRepo.On("FindByID", ctx, parentID).Return(&models.Comment{
+		ObjectId:    parentID,
+		PostId:      req.PostId,
+		OwnerUserId: parentOwner,
+	}, nil)
+	mockPostRepo.On("WithTransaction", ctx, mock.Anything).Return(nil)
+	mockCommentRepo.On("Create", mock.Anything, mock.AnythingOfType("*models.Comment")).Return(nil)
+
+	_, err := service.CreateComment(ctx, req, user)
+
+	require.NoError(t, err)
+	require.Len(t, outbox.events, 1)
+	event := outbox.events[0].data.(sharedInterfaces.CommentCreatedEvent)
+	assert.Equal(t, &parentID, event.ParentCommentId)
+	assert.Equal(t, &parentOwner, event.ReplyToUserId)
+	mockPostRepo.AssertNotCalled(t, "IncrementCommentCount")
+}
+
+func TestDeleteComment_WithOutbox_AppendsDeletedEvent(t *testing.T) {
+	service, mockCommentRepo, mockPostRepo := setupTestService()
+	outbox := &recordingOutbox{}
+	service.SetEventOutbox(outbox)
+	ctx := context.Background()
+	user := createTestUserContext()
+	comment := createTestComment()
+	comment.OwnerUserId = user.UserID
+
+	mockCommentRepo.On("FindByID", ctx, comment.ObjectId).Return(&comment, nil)
+	mockPostRepo.On("WithTransaction", ctx, mock.Anything).Return(nil)
+	mockCommentRepo.On("Delete", mock.Anything, comment.ObjectId).Return(nil)
+	mockCommentRepo.On("DeleteRepliesByParentID", mock.Anything, comment.ObjectId).Return(nil)
+
+	err := service.DeleteComment(ctx, comment.ObjectId, comment.PostId, user)
+
+	require.NoError(t, err)
+	require.Len(t, outbox.events, 1)
+	assert.Equal(t, sharedInterfaces.OutboxCommentDeleted, outbox.events[0].eventType)
+	assert.Equal(t, sharedInterfaces.CommentDeletedEvent{
+		PostId:    comment.PostId,
+		CommentId: &comment.ObjectId,
+		RootCount: 1,
+	}, outbox.events[0].data)
+	mockPostRepo.AssertNotCalled(t, "IncrementCommentCount")
+}
+
+func TestUpdateComment_WithOutbox_AppendsUpdatedEvent(t *testing.T) {
+	service, mockCommentRepo, _ := setupTestService()
+	outbox := &recordingOutbox{}
+	service.SetEventOutbox(outbox)
+	ctx := context.Background()
+	user := createTestUserContext()
+	comment := createTestComment()
+	comment.OwnerUserId = user.UserID
+
+	mockCommentRepo.On("FindByID", ctx, comment.ObjectId).Return(&comment, nil)
+	mockCommentRepo.On("WithTransaction", ctx, mock.Anything).Return(nil)
+	mockCommentRepo.On("Update", ctx, mock.AnythingOfType("*models.Comment")).Return(nil)
+
+	_, err := service.UpdateComment(ctx, comment.ObjectId, &models.UpdateCommentRequest{ObjectId: comment.ObjectId, Text: "Edited"}, user)
+
+	require.NoError(t, err)
+	require.Len(t, outbox.events, 1)
+	assert.Equal(t, sharedInterfaces.OutboxCommentUpdated, outbox.events[0].eventType)
+	assert.Equal(t, sharedInterfaces.CommentChangedEvent{CommentId: comment.ObjectId, PostId: comment.PostId}, outbox.events[0].data)
+}
+
+func TestPublishCommentCreated_SkipsDeletedComments(t *testing.T) {
+	service, mockCommentRepo, _ := setupTestService()
+	ctx := context.Background()
+	comment := createTestComment()
+	comment.Deleted = true
+
+	mockCommentRepo.On("FindByID", ctx, comment.ObjectId).Return(&comment, nil)
+
+	err := service.PublishCommentCreated(ctx, sharedInterfaces.CommentCreatedEvent{CommentId: comment.ObjectId, PostId: comment.PostId})
+
+	assert.NoError(t, err)
+	mockCommentRepo.AssertExpectations(t)
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+const (
+	// profileRepairBatch is how many comments one repair statement updates
+	profileRepairBatch = 500
+	// profileRepairOverlap re-checks profiles changed shortly before the last repair, covering
+	// clock skew and updates still in flight when it ran
+	profileRepairOverlap = time.Minute
+)
+
+// RepairOwnerProfiles brings the stored owner names and avatars of comments up to date with
+// profiles changed since the last repair. The first repair checks every profile.
+func (s *commentService) RepairOwnerProfiles(ctx context.Context) (int64, error) {
+	started := time.Now()
+	var total int64
+	for {
+		repaired, err := s.commentRepo.RepairOwnerProfiles(ctx, s.profilesRepairedAt, profileRepairBatch)
+		total += repaired
+		if err != nil {
+			return total, fmt.Errorf("failed to repair comment owner profiles: %w", err)
+		}
+		if repaired < profileRepairBatch {
+			break
+		}
+	}
+	if total > 0 {
+		s.invalidateAllComments(ctx)
+	}
+	s.profilesRepairedAt = started.Add(-profileRepairOverlap)
+	return total, nil
+}
+
+// StartProfileRepairJob repairs the owner names and avatars of comments now and then every
+// interval until ctx is done. A non-positive interval disables the job.
+func StartProfileRepairJob(ctx context.Context, svc CommentService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		run := func() {
+			if repaired, err := svc.RepairOwnerProfiles(ctx); err != nil {
+				log.Error("Comment profile repair failed: %v", err)
+			} else if repaired > 0 {
+				log.Info("Repaired owner profiles of %d comments", repaired)
+			}
+		}
+
+		run()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
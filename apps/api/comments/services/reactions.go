@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/comments/models"
+)
+
+// AddReaction adds the user's reaction of the given type to a comment and returns the
+// comment's reactions. Adding a reaction the user already gave changes nothing.
+func (s *commentService) AddReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (*models.ReactionSummary, error) {
+	if err := s.checkReactable(ctx, commentID, reactionType); err != nil {
+		return nil, err
+	}
+	if _, err := s.commentRepo.AddReaction(ctx, commentID, userID, reactionType); err != nil {
+		return nil, fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	return s.reactionSummary(ctx, commentID, userID)
+}
+
+// RemoveReaction removes the user's reaction of the given type from a comment and
+// returns the comment's reactions. Removing a reaction the user never gave changes nothing.
+func (s *commentService) RemoveReaction(ctx context.Context, commentID, userID uuid.UUID, reactionType string) (*models.ReactionSummary, error) {
+	if err := s.checkReactable(ctx, commentID, reactionType); err != nil {
+		return nil, err
+	}
+	if _, err := s.commentRepo.RemoveReaction(ctx, commentID, userID, reactionType); err != nil {
+		return nil, fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	return s.reactionSummary(ctx, commentID, userID)
+}
+
+// GetReactionsForComments bulk loads reaction counts, and the viewer's own reactions
+// when viewerID is set. Comments without reactions are absent from the map.
+func (s *commentService) GetReactionsForComments(ctx context.Context, commentIDs []uuid.UUID, viewerID uuid.UUID) (map[uuid.UUID]*models.ReactionSummary, error) {
+	return s.commentRepo.GetReactionsForComments(ctx, commentIDs, viewerID)
+}
+
+// checkReactable rejects unknown reaction types and comments that do not exist
+func (s *commentService) checkReactable(ctx context.Context, commentID uuid.UUID, reactionType string) error {
+	if !models.IsReactionType(reactionType) {
+		return commentsErrors.ErrInvalidReaction
+	}
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		if err.Error() == "comment not found" {
+			return commentsErrors.ErrCommentNotFound
+		}
+		return fmt.Errorf("failed to find comment: %w", err)
+	}
+	if comment.Deleted {
+		return commentsErrors.ErrCommentNotFound
+	}
+	return nil
+}
+
+// reactionSummary loads a single comment's reactions as seen by the user
+func (s *commentService) reactionSummary(ctx context.Context, commentID, userID uuid.UUID) (*models.ReactionSummary, error) {
+	summaries, err := s.commentRepo.GetReactionsForComments(ctx, []uuid.UUID{commentID}, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	if summary, ok := summaries[commentID]; ok {
+		return summary, nil
+	}
+	return &models.ReactionSummary{Counts: map[string]int64{}, Mine: []string{}}, nil
+}
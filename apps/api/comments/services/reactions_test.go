@@ -0,0 +1,84 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/comments/models"
+)
+
+func TestAddReaction_ReturnsSummary(t *testing.T) {
+	ctx := context.Background()
+	comment := createTestComment()
+	userID := uuid.Must(uuid.NewV4())
+	summary := &models.ReactionSummary{Counts: map[string]int64{models.ReactionLove: 3}, Mine: []string{models.ReactionLove}}
+
+	service, mockCommentRepo, _ := setupTestService()
+	mockCommentRepo.On("FindByID", ctx, comment.ObjectId).Return(&comment, nil)
+	mockCommentRepo.On("AddReaction", ctx, comment.ObjectId, userID, models.ReactionLove).Return(true, nil)
+	mockCommentRepo.On("GetReactionsForComments", ctx, []uuid.UUID{comment.ObjectId}, userID).
+		Return(map[uuid.UUID]*models.ReactionSummary{comment.ObjectId: summary}, nil)
+
+	result, err := service.AddReaction(ctx, comment.ObjectId, userID, models.ReactionLove)
+	require.NoError(t, err)
+	assert.Equal(t, summary, result)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestAddReaction_RejectsUnknownType(t *testing.T) {
+	service, mockCommentRepo, _ := setupTestService()
+
+	_, err := service.AddReaction(context.Background(), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), "shrug")
+	assert.ErrorIs(t, err, commentsErrors.ErrInvalidReaction)
+	mockCommentRepo.AssertNotCalled(t, "AddReaction")
+}
+
+func TestAddReaction_DeletedComment(t *testing.T) {
+	ctx := context.Background()
+	comment := createTestComment()
+	comment.Deleted = true
+
+	service, mockCommentRepo, _ := setupTestService()
+	mockCommentRepo.On("FindByID", ctx, comment.ObjectId).Return(&comment, nil)
+
+	_, err := service.AddReaction(ctx, comment.ObjectId, uuid.Must(uuid.NewV4()), models.ReactionLike)
+	assert.ErrorIs(t, err, commentsErrors.ErrCommentNotFound)
+	mockCommentRepo.AssertNotCalled(t, "AddReaction")
+}
+
+func TestRemoveReaction_LastReactionLeavesEmptySummary(t *testing.T) {
+	ctx := context.Background()
+	comment := createTestComment()
+	userID := uuid.Must(uuid.NewV4())
+
+	service, mockCommentRepo, _ := setupTestService()
+	mockCommentRepo.On("FindByID", ctx, comment.ObjectId).Return(&comment, nil)
+	mockCommentRepo.On("RemoveReaction", ctx, comment.ObjectId, userID, models.ReactionWow).Return(true, nil)
+	mockCommentRepo.On("GetReactionsForComments", ctx, []uuid.UUID{comment.ObjectId}, userID).
+		Return(map[uuid.UUID]*models.ReactionSummary{}, nil)
+
+	result, err := service.RemoveReaction(ctx, comment.ObjectId, userID, models.ReactionWow)
+	require.NoError(t, err)
+	assert.Empty(t, result.Counts)
+	assert.Empty(t, result.Mine)
+	mockCommentRepo.AssertExpectations(t)
+}
+
+func TestRemoveReaction_RepositoryError(t *testing.T) {
+	ctx := context.Background()
+	comment := createTestComment()
+	userID := uuid.Must(uuid.NewV4())
+
+	service, mockCommentRepo, _ := setupTestService()
+	mockCommentRepo.On("FindByID", ctx, comment.ObjectId).Return(&comment, nil)
+	mockCommentRepo.On("RemoveReaction", ctx, comment.ObjectId, userID, models.ReactionSad).Return(false, errors.New("connection reset"))
+
+	_, err := service.RemoveReaction(ctx, comment.ObjectId, userID, models.ReactionSad)
+	assert.ErrorIs(t, err, commentsErrors.ErrDatabaseOperation)
+}
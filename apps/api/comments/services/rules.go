@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetRulesChecker requires users to acknowledge a community's rules before they
+// comment on posts made in it; without a checker nobody is asked to
+func (s *commentService) SetRulesChecker(checker sharedInterfaces.RulesChecker) {
+	s.rules = checker
+}
+
+// checkRules lets admins, and users who acknowledged the community's rules, comment on
+// posts made in it
+func (s *commentService) checkRules(ctx context.Context, communityID uuid.UUID, user *types.UserContext) error {
+	if s.rules == nil || user.SystemRole == "admin" {
+		return nil
+	}
+	acknowledged, err := s.rules.HasAcknowledgedRules(ctx, communityID, user.UserID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", commentsErrors.ErrDatabaseOperation, err)
+	}
+	if !acknowledged {
+		return commentsErrors.ErrRulesNotAcknowledged
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"fmt"
+
+	commentsErrors "github.com/qolzam/telar/apps/api/comments/errors"
+	"github.com/qolzam/telar/apps/api/internal/pkg/sanitize"
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
+)
+
+// sanitizeText cleans a new or edited comment's text. Text that was nothing but
+// whitespace and invisible characters is rejected; empty text is left to the handler's
+// validation.
+func sanitizeText(text string) (string, error) {
+	clean := sanitize.Text(text)
+	if clean == "" && text != "" {
+		var v validate.Validator
+		v.Add("text", "cannot be empty")
+		return "", fmt.Errorf("%w: %w", commentsErrors.ErrValidationFailed, v.Err())
+	}
+	return clean, nil
+}
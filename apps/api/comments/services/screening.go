@@ -0,0 +1,32 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetContentScreener has new comments checked for abuse in the background; those that
+// look abusive are reported to moderators. Without a screener comments are only
+// reported by users.
+func (s *commentService) SetContentScreener(screener sharedInterfaces.ContentScreener) {
+	s.screener = screener
+}
+
+// screenContent hands a new comment's text to the screener. authorID is the real
+// account, since comments on anonymous posts may already be masked.
+func (s *commentService) screenContent(ctx context.Context, comment *models.Comment, authorID uuid.UUID) {
+	if s.screener == nil || strings.TrimSpace(comment.Text) == "" {
+		return
+	}
+	s.screener.ScreenContent(ctx, sharedInterfaces.ScreenedContent{
+		TargetType: sharedInterfaces.ScreenedComment,
+		TargetID:   comment.ObjectId,
+		AuthorID:   authorID,
+		Text:       comment.Text,
+	})
+}
@@ -3,8 +3,10 @@ package validation
 import (
 	"fmt"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/qolzam/telar/apps/api/comments/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // ValidateCreateCommentRequest validates the create comment request
@@ -21,8 +23,9 @@ func ValidateCreateCommentRequest(req *models.CreateCommentRequest) error {
 		return fmt.Errorf("text is required")
 	}
 
-	if len(req.Text) > 1000 {
-		return fmt.Errorf("text must be less than 1000 characters")
+	// The deployment's own, lower limit is enforced by the service
+	if utf8.RuneCountInString(req.Text) > sharedInterfaces.MaxContentLimits.CommentText {
+		return fmt.Errorf("text cannot exceed %d characters", sharedInterfaces.MaxContentLimits.CommentText)
 	}
 
 	if len(strings.TrimSpace(req.Text)) < 1 {
@@ -50,8 +53,9 @@ func ValidateUpdateCommentRequest(req *models.UpdateCommentRequest) error {
 		return fmt.Errorf("text is required")
 	}
 
-	if len(req.Text) > 1000 {
-		return fmt.Errorf("text must be less than 1000 characters")
+	// The deployment's own, lower limit is enforced by the service
+	if utf8.RuneCountInString(req.Text) > sharedInterfaces.MaxContentLimits.CommentText {
+		return fmt.Errorf("text cannot exceed %d characters", sharedInterfaces.MaxContentLimits.CommentText)
 	}
 
 	if len(strings.TrimSpace(req.Text)) < 1 {
@@ -1,10 +1,12 @@
 package validation
 
 import (
+	"strings"
 	"testing"
 
 	uuid "github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/comments/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 func TestValidateCreateCommentRequest(t *testing.T) {
@@ -48,7 +50,7 @@ func TestValidateCreateCommentRequest(t *testing.T) {
 			name: "text too long",
 			req: &models.CreateCommentRequest{
 				PostId: validPostID,
-				Text:   string(make([]byte, 1001)), // 1001 characters
+				Text:   strings.Repeat("a", sharedInterfaces.MaxContentLimits.CommentText+1),
 			},
 			wantErr: true,
 		},
@@ -105,7 +107,7 @@ func TestValidateUpdateCommentRequest(t *testing.T) {
 			name: "text too long",
 			req: &models.UpdateCommentRequest{
 				ObjectId: validObjectID,
-				Text:     string(make([]byte, 1001)),
+				Text:     strings.Repeat("a", sharedInterfaces.MaxContentLimits.CommentText+1),
 			},
 			wantErr: true,
 		},
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrCommunityNotFound  = errors.New("community not found")
+	ErrSlugTaken          = errors.New("community slug already taken")
+	ErrAlreadyMember      = errors.New("already a member of the community")
+	ErrNotMember          = errors.New("not a member of the community")
+	ErrOwnerCannotLeave   = errors.New("the owner cannot leave the community")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrInvalidRequest     = errors.New("invalid request")
+	ErrMissingUserContext = errors.New("missing user context")
+	ErrDatabaseOperation  = errors.New("database operation failed")
+	ErrStarterNotFound    = errors.New("conversation starter not found")
+	ErrStarterReviewed    = errors.New("conversation starter already reviewed")
+	ErrStartersDisabled   = errors.New("conversation starters are not available")
+	ErrCommunityArchived  = errors.New("community is archived")
+	ErrNotArchived        = errors.New("community is not archived")
+	ErrApprovalRequired   = errors.New("joining this community requires an approved application")
+)
+
+const (
+	CodeCommunityNotFound = "COMMUNITY_NOT_FOUND"
+	CodeSlugTaken         = "SLUG_TAKEN"
+	CodeAlreadyMember     = "ALREADY_MEMBER"
+	CodeNotMember         = "NOT_MEMBER"
+	CodeOwnerCannotLeave  = "OWNER_CANNOT_LEAVE"
+	CodePermissionDenied  = "PERMISSION_DENIED"
+	CodeInvalidRequest    = "INVALID_REQUEST"
+	CodeMissingUserCtx    = "MISSING_USER_CONTEXT"
+	CodeDatabaseError     = "DATABASE_ERROR"
+	CodeStarterNotFound   = "STARTER_NOT_FOUND"
+	CodeStarterReviewed   = "STARTER_ALREADY_REVIEWED"
+	CodeStartersDisabled  = "STARTERS_UNAVAILABLE"
+	CodeCommunityArchived = "COMMUNITY_ARCHIVED"
+	CodeNotArchived       = "COMMUNITY_NOT_ARCHIVED"
+	CodeApprovalRequired  = "APPROVAL_REQUIRED"
+	CodeInternalError     = "INTERNAL_ERROR"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func HandleServiceError(c *fiber.Ctx, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, ErrCommunityNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeCommunityNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrSlugTaken):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeSlugTaken, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrAlreadyMember):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeAlreadyMember, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrNotMember):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeNotMember, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrOwnerCannotLeave):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeOwnerCannotLeave, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrPermissionDenied):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodePermissionDenied, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrMissingUserContext):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrStarterNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeStarterNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrStarterReviewed):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeStarterReviewed, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrStartersDisabled):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeStartersDisabled, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrCommunityArchived):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeCommunityArchived, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrNotArchived):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeNotArchived, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrApprovalRequired):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodeApprovalRequired, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrDatabaseOperation):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeDatabaseError, Message: err.Error(), Details: err.Error()})
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Code: CodeInternalError, Message: "An unexpected error occurred", Details: err.Error()})
+	}
+}
+
+func HandleValidationError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: message, Details: message})
+}
+
+func HandleUserContextError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: message, Details: message})
+}
@@ -0,0 +1,306 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/communities/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type CommunityHandler struct {
+	service services.Service
+}
+
+func NewCommunityHandler(service services.Service) *CommunityHandler {
+	return &CommunityHandler{service: service}
+}
+
+// Create creates a community owned by the current user.
+// Endpoint: POST /communities
+// Body: {"slug": "home-cooks", "name": "Home cooks", "description": "...", "topic": "cooking"}
+func (h *CommunityHandler) Create(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	var req models.CreateCommunityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	community, err := h.service.Create(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(community)
+}
+
+// Discover lists communities, most members first unless sort=new.
+// Endpoint: GET /communities?q=&topic=&sort=popular|new&limit=&offset=
+func (h *CommunityHandler) Discover(c *fiber.Ctx) error {
+	communities, err := h.service.Discover(c.Context(), models.DiscoveryFilter{
+		Query:  c.Query("q"),
+		Topic:  c.Query("topic"),
+		Sort:   c.Query("sort"),
+		Limit:  c.QueryInt("limit", 20),
+		Offset: c.QueryInt("offset", 0),
+	})
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"communities": communities,
+	})
+}
+
+// Recommend suggests communities the current user has not joined, with why each was
+// suggested.
+// Endpoint: GET /communities/discover?limit=
+func (h *CommunityHandler) Recommend(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	recommendations, err := h.service.Recommend(c.Context(), user.UserID, c.QueryInt("limit", 20))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"communities": recommendations,
+	})
+}
+
+// MyCommunities returns the communities the current user belongs to, with their role.
+// Endpoint: GET /communities/me?limit=&offset=
+func (h *CommunityHandler) MyCommunities(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	communities, err := h.service.MyCommunities(c.Context(), user.UserID, c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"communities": communities,
+	})
+}
+
+// Get returns a community by ID or slug, with the current user's role.
+// Endpoint: GET /communities/:communityId
+func (h *CommunityHandler) Get(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	community, err := h.service.Get(c.Context(), c.Params("communityId"), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(community)
+}
+
+// Update changes a community's name, description or topic.
+// Endpoint: PUT /communities/:communityId
+func (h *CommunityHandler) Update(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	var req models.UpdateCommunityRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	community, err := h.service.Update(c.Context(), communityID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(community)
+}
+
+// Join makes the current user a member.
+// Endpoint: POST /communities/:communityId/join
+func (h *CommunityHandler) Join(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	member, err := h.service.Join(c.Context(), communityID, user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(member)
+}
+
+// Leave removes the current user from the community.
+// Endpoint: POST /communities/:communityId/leave
+func (h *CommunityHandler) Leave(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	if err := h.service.Leave(c.Context(), communityID, user.UserID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// ListMembers returns the members, owner and moderators first.
+// Endpoint: GET /communities/:communityId/members?role=owner|moderator|member&limit=&offset=
+func (h *CommunityHandler) ListMembers(c *fiber.Ctx) error {
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	members, err := h.service.ListMembers(c.Context(), communityID, c.Query("role"), c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"members": members,
+	})
+}
+
+// SetRole makes a member a moderator or a plain member.
+// Endpoint: PUT /communities/:communityId/members/:userId
+// Body: {"role": "moderator"}
+func (h *CommunityHandler) SetRole(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	userID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+	var req models.SetRoleRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	member, err := h.service.SetRole(c.Context(), communityID, user.UserID, userID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(member)
+}
+
+// RemoveMember removes a member from the community.
+// Endpoint: DELETE /communities/:communityId/members/:userId
+func (h *CommunityHandler) RemoveMember(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	userID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+
+	if err := h.service.RemoveMember(c.Context(), communityID, user.UserID, userID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// Analytics returns a community's growth, active members, top posts and posting heatmap.
+// Endpoint: GET /communities/:communityId/analytics?days=
+func (h *CommunityHandler) Analytics(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	analytics, err := h.service.Analytics(c.Context(), communityID, user.UserID, c.QueryInt("days", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(analytics)
+}
+
+// Archive makes a community read-only and notifies its members.
+// Endpoint: POST /communities/:communityId/archive
+func (h *CommunityHandler) Archive(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	community, err := h.service.Archive(c.Context(), communityID, user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(community)
+}
+
+// Unarchive makes an archived community active again.
+// Endpoint: POST /communities/:communityId/unarchive
+func (h *CommunityHandler) Unarchive(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	community, err := h.service.Unarchive(c.Context(), communityID, user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(community)
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/communities/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// StarterHandler serves a community's AI conversation starter settings and review queue
+type StarterHandler struct {
+	service services.Service
+}
+
+func NewStarterHandler(service services.Service) *StarterHandler {
+	return &StarterHandler{service: service}
+}
+
+// GetSettings returns the community's conversation starter settings.
+// Endpoint: GET /communities/:communityId/starters/settings
+func (h *StarterHandler) GetSettings(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	settings, err := h.service.GetStarterSettings(c.Context(), communityID, user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(settings)
+}
+
+// UpdateSettings changes the community's conversation starter settings.
+// Endpoint: PUT /communities/:communityId/starters/settings
+// Body: {"enabled": true, "style": "playful", "quietHours": 48, "minPosts": 2, "draftsPerRun": 3, "intervalHours": 24}
+func (h *StarterHandler) UpdateSettings(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	var req models.UpdateStarterSettingsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	settings, err := h.service.UpdateStarterSettings(c.Context(), communityID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(settings)
+}
+
+// List returns the drafted starters, newest first.
+// Endpoint: GET /communities/:communityId/starters?status=pending|approved|rejected&limit=&offset=
+func (h *StarterHandler) List(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	drafts, err := h.service.ListStarterDrafts(c.Context(), communityID, user.UserID, c.Query("status"), c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"starters": drafts,
+	})
+}
+
+// Approve publishes a drafted starter as a post by the current user.
+// Endpoint: POST /communities/:communityId/starters/:draftId/approve
+// Body (optional): {"body": "edited text"}
+func (h *StarterHandler) Approve(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	draftID, err := uuid.FromString(c.Params("draftId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid draftId")
+	}
+	var req models.ApproveStarterRequest
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&req); err != nil {
+			return errors.HandleValidationError(c, "invalid request body")
+		}
+	}
+
+	draft, err := h.service.ApproveStarter(c.Context(), communityID, draftID, sharedInterfaces.CommunityPostAuthor{
+		UserID:      user.UserID,
+		DisplayName: user.DisplayName,
+		SocialName:  user.SocialName,
+		Avatar:      user.Avatar,
+	}, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(draft)
+}
+
+// Reject discards a drafted starter.
+// Endpoint: POST /communities/:communityId/starters/:draftId/reject
+func (h *StarterHandler) Reject(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	draftID, err := uuid.FromString(c.Params("draftId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid draftId")
+	}
+
+	draft, err := h.service.RejectStarter(c.Context(), communityID, draftID, user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(draft)
+}
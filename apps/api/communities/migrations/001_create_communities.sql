@@ -0,0 +1,27 @@
+-- Communities: groups users create, join and post in. The creator owns the community and
+-- may promote members to moderators. member_count is kept with each join and leave so
+-- discovery can sort by popularity without counting members.
+CREATE TABLE IF NOT EXISTS communities (
+    id UUID PRIMARY KEY,
+    slug VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    topic VARCHAR(100) NOT NULL DEFAULT '',
+    owner_user_id UUID NOT NULL,
+    member_count INT NOT NULL DEFAULT 0,
+    created_date BIGINT NOT NULL,
+    last_updated BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_communities_popular ON communities(member_count DESC, id);
+CREATE INDEX IF NOT EXISTS idx_communities_topic ON communities(lower(topic));
+
+CREATE TABLE IF NOT EXISTS community_members (
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'moderator', 'member')),
+    joined_date BIGINT NOT NULL,
+    PRIMARY KEY (community_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_community_members_user ON community_members(user_id, joined_date DESC);
@@ -0,0 +1,30 @@
+-- AI conversation starters: each community opts in with its own cadence, and the
+-- scheduler drafts starters for quiet communities into a queue the owner and moderators
+-- review. last_run_date is set when the scheduler claims a community, so a community is
+-- looked at once per interval however many API instances run the scheduler.
+CREATE TABLE IF NOT EXISTS community_starter_settings (
+    community_id UUID PRIMARY KEY REFERENCES communities(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    style VARCHAR(50) NOT NULL DEFAULT 'friendly',
+    quiet_hours INT NOT NULL,
+    min_posts INT NOT NULL,
+    drafts_per_run INT NOT NULL,
+    interval_hours INT NOT NULL,
+    last_run_date BIGINT NOT NULL DEFAULT 0,
+    last_updated BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_community_starter_settings_due ON community_starter_settings(last_run_date) WHERE enabled;
+
+CREATE TABLE IF NOT EXISTS community_starter_drafts (
+    id UUID PRIMARY KEY,
+    community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE,
+    body TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    created_date BIGINT NOT NULL,
+    reviewed_by UUID,
+    reviewed_date BIGINT NOT NULL DEFAULT 0,
+    post_id UUID
+);
+
+CREATE INDEX IF NOT EXISTS idx_community_starter_drafts_queue ON community_starter_drafts(community_id, status, created_date DESC);
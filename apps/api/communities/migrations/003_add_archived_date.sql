@@ -0,0 +1,9 @@
+-- Archived communities are read-only: their posts and comments stay readable, but nobody
+-- joins, posts or comments until the owner unarchives them, and discovery skips them.
+-- archived_date is 0 for active communities, otherwise when the community was archived
+-- (Unix milliseconds).
+ALTER TABLE communities ADD COLUMN IF NOT EXISTS archived_date BIGINT NOT NULL DEFAULT 0;
+
+-- Discovery lists active communities by popularity
+CREATE INDEX IF NOT EXISTS idx_communities_active_popular ON communities(member_count DESC, id)
+    WHERE archived_date = 0;
@@ -0,0 +1,3 @@
+-- Anonymous posting is a community setting: members of a community with allow_anonymous
+-- may post with their author shown as a per-thread pseudonym. Communities start without it.
+ALTER TABLE communities ADD COLUMN IF NOT EXISTS allow_anonymous BOOLEAN NOT NULL DEFAULT FALSE;
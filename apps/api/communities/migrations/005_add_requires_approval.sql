@@ -0,0 +1,4 @@
+-- Joining a community with requires_approval goes through its approval queue: applicants
+-- answer the community's join questions and join once its owner or a moderator approves
+-- them. Communities start open to anyone.
+ALTER TABLE communities ADD COLUMN IF NOT EXISTS requires_approval BOOLEAN NOT NULL DEFAULT FALSE;
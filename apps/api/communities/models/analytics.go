@@ -0,0 +1,40 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Analytics describes a community's activity over its last Days days, for its owner
+// and moderators. Days and hours are in UTC.
+type Analytics struct {
+	CommunityId   uuid.UUID      `json:"communityId"`
+	Days          int            `json:"days"`
+	Since         int64          `json:"since"` // Start of the window, Unix milliseconds
+	MemberCount   int            `json:"memberCount"`
+	ActiveMembers int            `json:"activeMembers"` // Members who posted or commented in the community
+	Growth        []*DailyJoins  `json:"growth"`        // Days without joins are left out
+	TopPosts      []*TopPost     `json:"topPosts"`
+	Heatmap       []*HeatmapCell `json:"heatmap"` // Cells without posts are left out
+}
+
+// DailyJoins is how many members joined on a day
+type DailyJoins struct {
+	Day   string `json:"day" db:"day"` // YYYY-MM-DD
+	Joins int    `json:"joins" db:"joins"`
+}
+
+// TopPost is one of a community's most engaging posts in the window
+type TopPost struct {
+	PostId       uuid.UUID `json:"postId" db:"post_id"`
+	Score        int64     `json:"score" db:"score"`
+	CommentCount int64     `json:"commentCount" db:"comment_count"`
+	Impressions  int64     `json:"impressions" db:"impressions"`  // Estimated from sampled client events
+	CreatedDate  int64     `json:"createdDate" db:"created_date"` // Unix milliseconds
+}
+
+// HeatmapCell counts the posts made in a community at an hour of a weekday
+type HeatmapCell struct {
+	Weekday int `json:"weekday" db:"weekday"` // 0 is Sunday
+	Hour    int `json:"hour" db:"hour"`
+	Posts   int `json:"posts" db:"posts"`
+}
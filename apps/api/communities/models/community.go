@@ -0,0 +1,100 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Member roles. The creator owns the community; the owner appoints moderators, who may
+// edit the community and remove members.
+const (
+	RoleOwner     = "owner"
+	RoleModerator = "moderator"
+	RoleMember    = "member"
+)
+
+// IsAssignableRole reports whether the owner may give a member role; ownership is never
+// handed out this way
+func IsAssignableRole(role string) bool {
+	return role == RoleModerator || role == RoleMember
+}
+
+// IsValidRole reports whether role is a role a member can have
+func IsValidRole(role string) bool {
+	return role == RoleOwner || IsAssignableRole(role)
+}
+
+// Discovery sort orders
+const (
+	SortPopular = "popular" // Most members first
+	SortNew     = "new"     // Newest first
+)
+
+// Community is a group users join and post in
+type Community struct {
+	ObjectId     uuid.UUID `json:"objectId" db:"id"`
+	Slug         string    `json:"slug" db:"slug"`
+	Name         string    `json:"name" db:"name"`
+	Description  string    `json:"description" db:"description"`
+	Topic        string    `json:"topic" db:"topic"` // What the community is about; seeds AI conversation starters
+	OwnerUserId  uuid.UUID `json:"ownerUserId" db:"owner_user_id"`
+	MemberCount  int       `json:"memberCount" db:"member_count"`
+	CreatedDate  int64     `json:"createdDate" db:"created_date"`
+	LastUpdated  int64     `json:"lastUpdated" db:"last_updated"`
+	ArchivedDate int64     `json:"archivedDate,omitempty" db:"archived_date"` // When the owner made it read-only; 0 while active
+
+	// AllowAnonymous lets members post in it with their author shown as a per-thread pseudonym
+	AllowAnonymous bool `json:"allowAnonymous" db:"allow_anonymous"`
+
+	// RequiresApproval makes joining go through the community's approval queue
+	RequiresApproval bool `json:"requiresApproval" db:"requires_approval"`
+
+	// Role is the viewer's role; empty when the viewer is not a member
+	Role string `json:"role,omitempty" db:"role"`
+}
+
+// Archived reports whether the community is read-only
+func (c *Community) Archived() bool {
+	return c.ArchivedDate != 0
+}
+
+// Member is a user's membership of a community
+type Member struct {
+	CommunityId uuid.UUID `json:"communityId" db:"community_id"`
+	UserId      uuid.UUID `json:"userId" db:"user_id"`
+	Role        string    `json:"role" db:"role"`
+	JoinedDate  int64     `json:"joinedDate" db:"joined_date"`
+}
+
+// CreateCommunityRequest is the POST /communities request body
+type CreateCommunityRequest struct {
+	Slug             string `json:"slug"` // Lowercase letters, digits and hyphens; unique
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	Topic            string `json:"topic"`
+	AllowAnonymous   bool   `json:"allowAnonymous"`   // Let members post anonymously
+	RequiresApproval bool   `json:"requiresApproval"` // Approve applicants before they join
+}
+
+// UpdateCommunityRequest is the PUT /communities/:communityId request body; omitted
+// fields are left unchanged
+type UpdateCommunityRequest struct {
+	Name             *string `json:"name,omitempty"`
+	Description      *string `json:"description,omitempty"`
+	Topic            *string `json:"topic,omitempty"`
+	AllowAnonymous   *bool   `json:"allowAnonymous,omitempty"`
+	RequiresApproval *bool   `json:"requiresApproval,omitempty"`
+}
+
+// SetRoleRequest is the PUT /communities/:communityId/members/:userId request body
+type SetRoleRequest struct {
+	Role string `json:"role"` // "moderator" or "member"
+}
+
+// DiscoveryFilter selects communities for GET /communities
+type DiscoveryFilter struct {
+	Query  string // Matches name, slug or description
+	Topic  string // Exact topic, case-insensitive
+	Sort   string // SortPopular (default) or SortNew
+	Limit  int
+	Offset int
+}
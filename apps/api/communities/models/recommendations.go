@@ -0,0 +1,20 @@
+package models
+
+// Recommendation reasons, strongest signal first
+const (
+	ReasonFollows  = "follows"  // People the viewer follows are members
+	ReasonTopic    = "topic"    // The topic is a tag on the viewer's recent posts
+	ReasonActivity = "activity" // The viewer recently commented on posts shared in it
+	ReasonPopular  = "popular"  // No signal matched; suggested for its size
+)
+
+// Recommendation is a community suggested to a user who has not joined it
+type Recommendation struct {
+	Community
+	FollowedMembers int      `json:"followedMembers" db:"followed_members"` // Members the viewer follows
+	TaggedPosts     int      `json:"-" db:"tagged_posts"`                   // Viewer's recent posts tagged with the topic
+	CommentedPosts  int      `json:"-" db:"commented_posts"`                // Posts in it the viewer recently commented on
+	Score           int      `json:"score" db:"score"`
+	Reasons         []string `json:"reasons"`
+	Joinable        bool     `json:"joinable"` // Whether the viewer can join it now
+}
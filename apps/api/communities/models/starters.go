@@ -0,0 +1,82 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Conversation starter draft statuses. Drafts wait as pending until an owner or
+// moderator approves them, publishing them as a post, or rejects them.
+const (
+	StarterPending  = "pending"
+	StarterApproved = "approved"
+	StarterRejected = "rejected"
+)
+
+// IsValidStarterStatus reports whether status is a draft status
+func IsValidStarterStatus(status string) bool {
+	return status == StarterPending || status == StarterApproved || status == StarterRejected
+}
+
+// StarterSettings is how a community gets AI conversation starters. When enabled, the
+// scheduler looks at the community every IntervalHours and, if it had fewer than
+// MinPosts posts in the last QuietHours, drafts up to DraftsPerRun starters for review.
+type StarterSettings struct {
+	CommunityId   uuid.UUID `json:"communityId" db:"community_id"`
+	Enabled       bool      `json:"enabled" db:"enabled"`
+	Style         string    `json:"style" db:"style"` // Tone of the starters, such as "friendly" or "thought-provoking"
+	QuietHours    int       `json:"quietHours" db:"quiet_hours"`
+	MinPosts      int       `json:"minPosts" db:"min_posts"`
+	DraftsPerRun  int       `json:"draftsPerRun" db:"drafts_per_run"`
+	IntervalHours int       `json:"intervalHours" db:"interval_hours"`
+	LastRunDate   int64     `json:"lastRunDate" db:"last_run_date"` // When the scheduler last looked at the community; 0 if never
+	LastUpdated   int64     `json:"lastUpdated" db:"last_updated"`
+}
+
+// DefaultStarterSettings are a community's settings until its owner or a moderator
+// saves some; starters stay off until they do
+func DefaultStarterSettings(communityID uuid.UUID) *StarterSettings {
+	return &StarterSettings{
+		CommunityId:   communityID,
+		Style:         "friendly",
+		QuietHours:    72,
+		MinPosts:      3,
+		DraftsPerRun:  3,
+		IntervalHours: 24,
+	}
+}
+
+// StarterRun is a community the scheduler claimed, with what starters are generated from
+type StarterRun struct {
+	StarterSettings
+	Name  string `db:"name"`
+	Topic string `db:"topic"`
+}
+
+// StarterDraft is a generated conversation starter awaiting or past review
+type StarterDraft struct {
+	ObjectId     uuid.UUID  `json:"objectId" db:"id"`
+	CommunityId  uuid.UUID  `json:"communityId" db:"community_id"`
+	Body         string     `json:"body" db:"body"`
+	Status       string     `json:"status" db:"status"`
+	CreatedDate  int64      `json:"createdDate" db:"created_date"`
+	ReviewedBy   *uuid.UUID `json:"reviewedBy,omitempty" db:"reviewed_by"`
+	ReviewedDate int64      `json:"reviewedDate,omitempty" db:"reviewed_date"`
+	PostId       *uuid.UUID `json:"postId,omitempty" db:"post_id"` // The published post, once approved
+}
+
+// UpdateStarterSettingsRequest is the PUT /communities/:communityId/starters/settings
+// request body; omitted fields are left unchanged
+type UpdateStarterSettingsRequest struct {
+	Enabled       *bool   `json:"enabled,omitempty"`
+	Style         *string `json:"style,omitempty"`
+	QuietHours    *int    `json:"quietHours,omitempty"`
+	MinPosts      *int    `json:"minPosts,omitempty"`
+	DraftsPerRun  *int    `json:"draftsPerRun,omitempty"`
+	IntervalHours *int    `json:"intervalHours,omitempty"`
+}
+
+// ApproveStarterRequest is the POST /communities/:communityId/starters/:draftId/approve
+// request body; Body, when given, replaces the drafted text before it is published
+type ApproveStarterRequest struct {
+	Body *string `json:"body,omitempty"`
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/communities/models"
+)
+
+// communityPostsFrom selects the live posts placed in community $1 and created since $2.
+// Posts and placements belong to the posts module and are read through the search path.
+const communityPostsFrom = `
+	FROM post_community_placements pl
+	JOIN posts p ON p.id = pl.post_id
+	WHERE pl.community_id = $1 AND p.created_at >= $2 AND NOT COALESCE(p.is_deleted, FALSE)`
+
+// GetAnalytics reads the figures in one transaction so they describe the same moment.
+// Impressions come from the client telemetry in analytics_events, weighted by 1 /
+// sample_rate like the other aggregations over it.
+func (r *postgresRepository) GetAnalytics(ctx context.Context, communityID uuid.UUID, since time.Time, topPosts int) (*models.Analytics, error) {
+	analytics := &models.Analytics{CommunityId: communityID, Since: since.UnixMilli()}
+	err := r.WithTransaction(ctx, func(ctx context.Context) error {
+		exec := r.getExecutor(ctx)
+
+		query := fmt.Sprintf(`SELECT member_count FROM %scommunities WHERE id = $1`, r.schemaPrefix())
+		if err := sqlx.GetContext(ctx, exec, &analytics.MemberCount, query, communityID); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrNotFound
+			}
+			return fmt.Errorf("get community member count: %w", err)
+		}
+
+		query = fmt.Sprintf(`
+			SELECT to_char(to_timestamp(joined_date / 1000.0) AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day, COUNT(*) AS joins
+			FROM %scommunity_members
+			WHERE community_id = $1 AND joined_date >= $2
+			GROUP BY 1
+			ORDER BY 1
+		`, r.schemaPrefix())
+		if err := sqlx.SelectContext(ctx, exec, &analytics.Growth, query, communityID, since.UnixMilli()); err != nil {
+			return fmt.Errorf("get community growth: %w", err)
+		}
+
+		query = fmt.Sprintf(`
+			SELECT COUNT(DISTINCT active.user_id) FROM (
+				SELECT p.owner_user_id AS user_id %[2]s
+				UNION ALL
+				SELECT cm.owner_user_id
+				FROM post_community_placements pl
+				JOIN comments cm ON cm.post_id = pl.post_id
+				WHERE pl.community_id = $1 AND cm.created_at >= $2 AND NOT COALESCE(cm.is_deleted, FALSE)
+			) active
+			JOIN %[1]scommunity_members m ON m.community_id = $1 AND m.user_id = active.user_id
+		`, r.schemaPrefix(), communityPostsFrom)
+		if err := sqlx.GetContext(ctx, exec, &analytics.ActiveMembers, query, communityID, since); err != nil {
+			return fmt.Errorf("count active community members: %w", err)
+		}
+
+		query = `
+			SELECT p.id AS post_id, COALESCE(p.score, 0) AS score, COALESCE(p.comment_count, 0) AS comment_count,
+				(EXTRACT(EPOCH FROM p.created_at) * 1000)::BIGINT AS created_date,
+				COALESCE((
+					SELECT ROUND(SUM(1 / NULLIF(e.sample_rate, 0)))::BIGINT FROM analytics_events e
+					WHERE e.post_id = p.id AND e.event_type = 'impression' AND e.occurred_at >= $2
+				), 0) AS impressions
+			` + communityPostsFrom + `
+			ORDER BY COALESCE(p.score, 0) + 2 * COALESCE(p.comment_count, 0) DESC, p.created_at DESC, p.id
+			LIMIT $3
+		`
+		if err := sqlx.SelectContext(ctx, exec, &analytics.TopPosts, query, communityID, since, topPosts); err != nil {
+			return fmt.Errorf("get top community posts: %w", err)
+		}
+
+		query = `
+			SELECT EXTRACT(DOW FROM p.created_at AT TIME ZONE 'UTC')::INT AS weekday,
+				EXTRACT(HOUR FROM p.created_at AT TIME ZONE 'UTC')::INT AS hour,
+				COUNT(*) AS posts
+			` + communityPostsFrom + `
+			GROUP BY 1, 2
+			ORDER BY 1, 2
+		`
+		if err := sqlx.SelectContext(ctx, exec, &analytics.Heatmap, query, communityID, since); err != nil {
+			return fmt.Errorf("get community posting heatmap: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return analytics, nil
+}
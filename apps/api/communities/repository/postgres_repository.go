@@ -0,0 +1,561 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+const communityColumns = `c.id, c.slug, c.name, c.description, c.topic, c.owner_user_id, c.member_count, c.created_date, c.last_updated, c.archived_date, c.allow_anonymous, c.requires_approval`
+
+const memberColumns = `community_id, user_id, role, joined_date`
+
+const starterSettingsColumns = `community_id, enabled, style, quiet_hours, min_posts, drafts_per_run, interval_hours, last_run_date, last_updated`
+
+const starterDraftColumns = `id, community_id, body, status, created_date, reviewed_by, reviewed_date, post_id`
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) Create(ctx context.Context, community *models.Community) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		exec := r.getExecutor(ctx)
+		query := fmt.Sprintf(`
+			INSERT INTO %scommunities (id, slug, name, description, topic, owner_user_id, member_count, created_date, last_updated, allow_anonymous, requires_approval)
+			VALUES ($1, $2, $3, $4, $5, $6, 1, $7, $8, $9, $10)
+		`, r.schemaPrefix())
+		_, err := exec.ExecContext(ctx, query,
+			community.ObjectId, community.Slug, community.Name, community.Description, community.Topic,
+			community.OwnerUserId, community.CreatedDate, community.LastUpdated, community.AllowAnonymous, community.RequiresApproval)
+		if err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+				return ErrDuplicateSlug
+			}
+			return fmt.Errorf("insert community: %w", err)
+		}
+
+		query = fmt.Sprintf(`
+			INSERT INTO %scommunity_members (community_id, user_id, role, joined_date)
+			VALUES ($1, $2, $3, $4)
+		`, r.schemaPrefix())
+		if _, err := exec.ExecContext(ctx, query, community.ObjectId, community.OwnerUserId, models.RoleOwner, community.CreatedDate); err != nil {
+			return fmt.Errorf("insert community owner: %w", err)
+		}
+		community.MemberCount = 1
+		return nil
+	})
+}
+
+func (r *postgresRepository) Get(ctx context.Context, communityID uuid.UUID) (*models.Community, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %scommunities c WHERE c.id = $1`, communityColumns, r.schemaPrefix())
+	return r.getCommunity(ctx, query, communityID)
+}
+
+func (r *postgresRepository) GetBySlug(ctx context.Context, slug string) (*models.Community, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %scommunities c WHERE c.slug = $1`, communityColumns, r.schemaPrefix())
+	return r.getCommunity(ctx, query, slug)
+}
+
+func (r *postgresRepository) getCommunity(ctx context.Context, query string, args ...interface{}) (*models.Community, error) {
+	var community models.Community
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &community, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get community: %w", err)
+	}
+	return &community, nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, community *models.Community) error {
+	query := fmt.Sprintf(`
+		UPDATE %scommunities
+		SET name = $2, description = $3, topic = $4, last_updated = $5, allow_anonymous = $6, requires_approval = $7
+		WHERE id = $1
+	`, r.schemaPrefix())
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		community.ObjectId, community.Name, community.Description, community.Topic, community.LastUpdated, community.AllowAnonymous, community.RequiresApproval)
+	if err != nil {
+		return fmt.Errorf("update community: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) SetArchivedDate(ctx context.Context, communityID uuid.UUID, archivedDate, lastUpdated int64) error {
+	query := fmt.Sprintf(`
+		UPDATE %scommunities SET archived_date = $2, last_updated = $3 WHERE id = $1
+	`, r.schemaPrefix())
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, communityID, archivedDate, lastUpdated)
+	if err != nil {
+		return fmt.Errorf("set community archived date: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) List(ctx context.Context, filter models.DiscoveryFilter) ([]*models.Community, error) {
+	order := "c.member_count DESC, c.id"
+	if filter.Sort == models.SortNew {
+		order = "c.created_date DESC, c.id"
+	}
+	query := fmt.Sprintf(`
+		SELECT %s FROM %scommunities c
+		WHERE c.archived_date = 0
+		AND ($1 = '' OR c.name ILIKE '%%' || $1 || '%%' OR c.slug ILIKE '%%' || $1 || '%%' OR c.description ILIKE '%%' || $1 || '%%')
+		AND ($2 = '' OR lower(c.topic) = lower($2))
+		ORDER BY %s
+		LIMIT $3 OFFSET $4
+	`, communityColumns, r.schemaPrefix(), order)
+
+	var communities []*models.Community
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &communities, query,
+		escapeLike(filter.Query), filter.Topic, filter.Limit, filter.Offset); err != nil {
+		return nil, fmt.Errorf("list communities: %w", err)
+	}
+	return communities, nil
+}
+
+// Recommend scores every community in one statement. Posts, tags, comments and
+// follows belong to other modules and are read through the search path, like the
+// following feed reads follows. Each signal is capped so one cannot drown the others.
+func (r *postgresRepository) Recommend(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]*models.Recommendation, error) {
+	query := fmt.Sprintf(`
+		WITH signals AS (
+			SELECT m.community_id, COUNT(*) AS followed_members, 0 AS tagged_posts, 0 AS commented_posts
+			FROM follows f
+			JOIN %[1]scommunity_members m ON m.user_id = f.followee_id
+			WHERE f.follower_id = $1
+			GROUP BY m.community_id
+			UNION ALL
+			SELECT c.id, 0, COUNT(DISTINCT p.id), 0
+			FROM posts p
+			JOIN post_tags t ON t.post_id = p.id
+			JOIN %[1]scommunities c ON c.topic <> '' AND lower(c.topic) = t.tag
+			WHERE p.owner_user_id = $1 AND p.created_at >= $2 AND NOT COALESCE(p.is_deleted, FALSE)
+			GROUP BY c.id
+			UNION ALL
+			SELECT pl.community_id, 0, 0, COUNT(DISTINCT cm.post_id)
+			FROM comments cm
+			JOIN post_community_placements pl ON pl.post_id = cm.post_id
+			WHERE cm.owner_user_id = $1 AND cm.created_at >= $2 AND NOT COALESCE(cm.is_deleted, FALSE)
+			GROUP BY pl.community_id
+		), scored AS (
+			SELECT community_id,
+				LEAST(SUM(followed_members), 10)::INT AS followed_members,
+				LEAST(SUM(tagged_posts), 10)::INT AS tagged_posts,
+				LEAST(SUM(commented_posts), 10)::INT AS commented_posts
+			FROM signals
+			GROUP BY community_id
+		)
+		SELECT %[2]s,
+			COALESCE(s.followed_members, 0) AS followed_members,
+			COALESCE(s.tagged_posts, 0) AS tagged_posts,
+			COALESCE(s.commented_posts, 0) AS commented_posts,
+			COALESCE(3 * s.followed_members + 2 * s.tagged_posts + s.commented_posts, 0) AS score
+		FROM %[1]scommunities c
+		LEFT JOIN scored s ON s.community_id = c.id
+		WHERE c.archived_date = 0
+		AND NOT EXISTS (
+			SELECT 1 FROM %[1]scommunity_members m WHERE m.community_id = c.id AND m.user_id = $1
+		)
+		ORDER BY score DESC, c.member_count DESC, c.id
+		LIMIT $3
+	`, r.schemaPrefix(), communityColumns)
+
+	var recommendations []*models.Recommendation
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &recommendations, query, userID, since, limit); err != nil {
+		return nil, fmt.Errorf("recommend communities: %w", err)
+	}
+	return recommendations, nil
+}
+
+func (r *postgresRepository) ListByMember(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Community, error) {
+	query := fmt.Sprintf(`
+		SELECT %s, m.role FROM %scommunities c
+		JOIN %scommunity_members m ON m.community_id = c.id
+		WHERE m.user_id = $1
+		ORDER BY m.joined_date DESC, c.id
+		LIMIT $2 OFFSET $3
+	`, communityColumns, r.schemaPrefix(), r.schemaPrefix())
+
+	var communities []*models.Community
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &communities, query, userID, limit, offset); err != nil {
+		return nil, fmt.Errorf("list member communities: %w", err)
+	}
+	return communities, nil
+}
+
+// AddMember inserts and counts in one transaction, so member_count stays in step with
+// concurrent joins
+func (r *postgresRepository) AddMember(ctx context.Context, member *models.Member) (bool, error) {
+	added := false
+	err := r.WithTransaction(ctx, func(ctx context.Context) error {
+		exec := r.getExecutor(ctx)
+		query := fmt.Sprintf(`
+			INSERT INTO %scommunity_members (community_id, user_id, role, joined_date)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (community_id, user_id) DO NOTHING
+		`, r.schemaPrefix())
+		result, err := exec.ExecContext(ctx, query, member.CommunityId, member.UserId, member.Role, member.JoinedDate)
+		if err != nil {
+			return fmt.Errorf("insert community member: %w", err)
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			return err
+		}
+		if err := r.addToCount(ctx, member.CommunityId, 1); err != nil {
+			return err
+		}
+		added = true
+		return nil
+	})
+	return added, err
+}
+
+func (r *postgresRepository) RemoveMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	removed := false
+	err := r.WithTransaction(ctx, func(ctx context.Context) error {
+		query := fmt.Sprintf(`
+			DELETE FROM %scommunity_members
+			WHERE community_id = $1 AND user_id = $2 AND role <> 'owner'
+		`, r.schemaPrefix())
+		result, err := r.getExecutor(ctx).ExecContext(ctx, query, communityID, userID)
+		if err != nil {
+			return fmt.Errorf("delete community member: %w", err)
+		}
+		if rows, err := result.RowsAffected(); err != nil || rows == 0 {
+			return err
+		}
+		if err := r.addToCount(ctx, communityID, -1); err != nil {
+			return err
+		}
+		removed = true
+		return nil
+	})
+	return removed, err
+}
+
+func (r *postgresRepository) addToCount(ctx context.Context, communityID uuid.UUID, delta int) error {
+	query := fmt.Sprintf(`
+		UPDATE %scommunities SET member_count = GREATEST(member_count + $2, 0) WHERE id = $1
+	`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, communityID, delta); err != nil {
+		return fmt.Errorf("update community member count: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetMember(ctx context.Context, communityID, userID uuid.UUID) (*models.Member, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %scommunity_members WHERE community_id = $1 AND user_id = $2
+	`, memberColumns, r.schemaPrefix())
+	return r.getMember(ctx, query, communityID, userID)
+}
+
+func (r *postgresRepository) getMember(ctx context.Context, query string, args ...interface{}) (*models.Member, error) {
+	var member models.Member
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &member, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get community member: %w", err)
+	}
+	return &member, nil
+}
+
+func (r *postgresRepository) ListMembers(ctx context.Context, communityID uuid.UUID, role string, limit, offset int) ([]*models.Member, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %scommunity_members
+		WHERE community_id = $1 AND ($2 = '' OR role = $2)
+		ORDER BY CASE role WHEN 'owner' THEN 0 WHEN 'moderator' THEN 1 ELSE 2 END, joined_date, user_id
+		LIMIT $3 OFFSET $4
+	`, memberColumns, r.schemaPrefix())
+
+	var members []*models.Member
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &members, query, communityID, role, limit, offset); err != nil {
+		return nil, fmt.Errorf("list community members: %w", err)
+	}
+	return members, nil
+}
+
+func (r *postgresRepository) ListMemberIDs(ctx context.Context, communityID uuid.UUID) ([]uuid.UUID, error) {
+	query := fmt.Sprintf(`
+		SELECT user_id FROM %scommunity_members WHERE community_id = $1 ORDER BY joined_date, user_id
+	`, r.schemaPrefix())
+	var userIDs []uuid.UUID
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &userIDs, query, communityID); err != nil {
+		return nil, fmt.Errorf("list community member ids: %w", err)
+	}
+	return userIDs, nil
+}
+
+func (r *postgresRepository) SetRole(ctx context.Context, communityID, userID uuid.UUID, role string) (*models.Member, error) {
+	query := fmt.Sprintf(`
+		UPDATE %scommunity_members SET role = $3
+		WHERE community_id = $1 AND user_id = $2 AND role <> 'owner'
+		RETURNING %s
+	`, r.schemaPrefix(), memberColumns)
+	return r.getMember(ctx, query, communityID, userID, role)
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID, now int64) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		exec := r.getExecutor(ctx)
+		handOver := fmt.Sprintf(`
+			WITH successors AS (
+				SELECT DISTINCT ON (m.community_id) m.community_id, m.user_id
+				FROM %[1]scommunity_members m
+				JOIN %[1]scommunities c ON c.id = m.community_id
+				WHERE c.owner_user_id = $1 AND m.user_id <> $1
+				ORDER BY m.community_id, CASE m.role WHEN 'moderator' THEN 0 ELSE 1 END, m.joined_date, m.user_id
+			), promoted AS (
+				UPDATE %[1]scommunity_members m SET role = 'owner'
+				FROM successors s
+				WHERE m.community_id = s.community_id AND m.user_id = s.user_id
+			)
+			UPDATE %[1]scommunities c SET owner_user_id = s.user_id, last_updated = $2
+			FROM successors s
+			WHERE c.id = s.community_id
+		`, r.schemaPrefix())
+		if _, err := exec.ExecContext(ctx, handOver, userID, now); err != nil {
+			return fmt.Errorf("hand over communities: %w", err)
+		}
+
+		leave := fmt.Sprintf(`
+			WITH removed AS (
+				DELETE FROM %[1]scommunity_members WHERE user_id = $1
+				RETURNING community_id
+			)
+			UPDATE %[1]scommunities c SET member_count = GREATEST(c.member_count - 1, 0)
+			FROM removed WHERE c.id = removed.community_id
+		`, r.schemaPrefix())
+		if _, err := exec.ExecContext(ctx, leave, userID); err != nil {
+			return fmt.Errorf("delete community memberships: %w", err)
+		}
+
+		// Only communities nobody else belonged to are still owned by the user
+		orphaned := fmt.Sprintf(`DELETE FROM %scommunities WHERE owner_user_id = $1`, r.schemaPrefix())
+		if _, err := exec.ExecContext(ctx, orphaned, userID); err != nil {
+			return fmt.Errorf("delete owned communities: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *postgresRepository) GetStarterSettings(ctx context.Context, communityID uuid.UUID) (*models.StarterSettings, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %scommunity_starter_settings WHERE community_id = $1
+	`, starterSettingsColumns, r.schemaPrefix())
+	var settings models.StarterSettings
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &settings, query, communityID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get starter settings: %w", err)
+	}
+	return &settings, nil
+}
+
+func (r *postgresRepository) SaveStarterSettings(ctx context.Context, settings *models.StarterSettings) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %scommunity_starter_settings (community_id, enabled, style, quiet_hours, min_posts, drafts_per_run, interval_hours, last_updated)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (community_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled, style = EXCLUDED.style, quiet_hours = EXCLUDED.quiet_hours,
+			min_posts = EXCLUDED.min_posts, drafts_per_run = EXCLUDED.drafts_per_run,
+			interval_hours = EXCLUDED.interval_hours, last_updated = EXCLUDED.last_updated
+		RETURNING last_run_date
+	`, r.schemaPrefix())
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &settings.LastRunDate, query,
+		settings.CommunityId, settings.Enabled, settings.Style, settings.QuietHours, settings.MinPosts,
+		settings.DraftsPerRun, settings.IntervalHours, settings.LastUpdated); err != nil {
+		return fmt.Errorf("save starter settings: %w", err)
+	}
+	return nil
+}
+
+// ClaimStarterRuns picks and marks due communities in one statement; SKIP LOCKED keeps
+// a second scheduler from waiting on, or repeating, rows the first is claiming
+func (r *postgresRepository) ClaimStarterRuns(ctx context.Context, now int64, limit, maxPending int) ([]*models.StarterRun, error) {
+	prefix := r.schemaPrefix()
+	query := fmt.Sprintf(`
+		WITH due AS (
+			SELECT s.community_id FROM %scommunity_starter_settings s
+			WHERE s.enabled AND s.last_run_date + s.interval_hours::BIGINT * 3600000 <= $1
+			AND NOT EXISTS (SELECT 1 FROM %scommunities a WHERE a.id = s.community_id AND a.archived_date <> 0)
+			AND (SELECT COUNT(*) FROM %scommunity_starter_drafts d
+				WHERE d.community_id = s.community_id AND d.status = 'pending') < $3
+			ORDER BY s.last_run_date
+			LIMIT $2
+			FOR UPDATE OF s SKIP LOCKED
+		)
+		UPDATE %scommunity_starter_settings s SET last_run_date = $1
+		FROM due, %scommunities c
+		WHERE s.community_id = due.community_id AND c.id = s.community_id
+		RETURNING s.community_id, s.enabled, s.style, s.quiet_hours, s.min_posts, s.drafts_per_run,
+			s.interval_hours, s.last_run_date, s.last_updated, c.name, c.topic
+	`, prefix, prefix, prefix, prefix, prefix)
+
+	var runs []*models.StarterRun
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &runs, query, now, limit, maxPending); err != nil {
+		return nil, fmt.Errorf("claim starter runs: %w", err)
+	}
+	return runs, nil
+}
+
+func (r *postgresRepository) CreateStarterDrafts(ctx context.Context, drafts []*models.StarterDraft) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		query := fmt.Sprintf(`
+			INSERT INTO %scommunity_starter_drafts (id, community_id, body, status, created_date)
+			VALUES ($1, $2, $3, $4, $5)
+		`, r.schemaPrefix())
+		for _, draft := range drafts {
+			if _, err := r.getExecutor(ctx).ExecContext(ctx, query,
+				draft.ObjectId, draft.CommunityId, draft.Body, draft.Status, draft.CreatedDate); err != nil {
+				return fmt.Errorf("insert starter draft: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresRepository) GetStarterDraft(ctx context.Context, communityID, draftID uuid.UUID) (*models.StarterDraft, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %scommunity_starter_drafts WHERE id = $1 AND community_id = $2
+	`, starterDraftColumns, r.schemaPrefix())
+	var draft models.StarterDraft
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &draft, query, draftID, communityID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get starter draft: %w", err)
+	}
+	return &draft, nil
+}
+
+func (r *postgresRepository) ListStarterDrafts(ctx context.Context, communityID uuid.UUID, status string, limit, offset int) ([]*models.StarterDraft, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %scommunity_starter_drafts
+		WHERE community_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_date DESC, id
+		LIMIT $3 OFFSET $4
+	`, starterDraftColumns, r.schemaPrefix())
+
+	var drafts []*models.StarterDraft
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &drafts, query, communityID, status, limit, offset); err != nil {
+		return nil, fmt.Errorf("list starter drafts: %w", err)
+	}
+	return drafts, nil
+}
+
+// ReviewStarterDraft only moves pending drafts, so two reviewers acting at once cannot
+// both publish the same starter
+func (r *postgresRepository) ReviewStarterDraft(ctx context.Context, draft *models.StarterDraft) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE %scommunity_starter_drafts
+		SET status = $3, body = $4, reviewed_by = $5, reviewed_date = $6
+		WHERE id = $1 AND community_id = $2 AND status = 'pending'
+	`, r.schemaPrefix())
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		draft.ObjectId, draft.CommunityId, draft.Status, draft.Body, draft.ReviewedBy, draft.ReviewedDate)
+	if err != nil {
+		return false, fmt.Errorf("review starter draft: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("review starter draft: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) ReopenStarterDraft(ctx context.Context, draftID uuid.UUID) error {
+	query := fmt.Sprintf(`
+		UPDATE %scommunity_starter_drafts
+		SET status = 'pending', reviewed_by = NULL, reviewed_date = 0
+		WHERE id = $1 AND status = 'approved' AND post_id IS NULL
+	`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, draftID); err != nil {
+		return fmt.Errorf("reopen starter draft: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) SetStarterPost(ctx context.Context, draftID, postID uuid.UUID) error {
+	query := fmt.Sprintf(`
+		UPDATE %scommunity_starter_drafts SET post_id = $2 WHERE id = $1
+	`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, draftID, postID); err != nil {
+		return fmt.Errorf("set starter post: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
+
+// escapeLike makes LIKE wildcards in a search term match themselves
+func escapeLike(term string) string {
+	return likeEscaper.Replace(term)
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/communities/models"
+)
+
+var (
+	// ErrNotFound is returned when a community or member does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrDuplicateSlug is returned when another community has the slug
+	ErrDuplicateSlug = errors.New("community slug already exists")
+)
+
+// Repository defines data access for communities and their members.
+type Repository interface {
+	// Create stores a community with its owner as its only member. Returns
+	// ErrDuplicateSlug when the slug is taken.
+	Create(ctx context.Context, community *models.Community) error
+
+	// Get returns a community or ErrNotFound.
+	Get(ctx context.Context, communityID uuid.UUID) (*models.Community, error)
+
+	// GetBySlug returns the community with slug or ErrNotFound.
+	GetBySlug(ctx context.Context, slug string) (*models.Community, error)
+
+	// Update stores the name, description, topic, anonymous posting and approval
+	// settings and last updated date of a community.
+	// Returns ErrNotFound when it does not exist.
+	Update(ctx context.Context, community *models.Community) error
+
+	// SetArchivedDate archives a community at archivedDate, or makes it active again
+	// when archivedDate is 0. Returns ErrNotFound when it does not exist.
+	SetArchivedDate(ctx context.Context, communityID uuid.UUID, archivedDate, lastUpdated int64) error
+
+	// List returns the active communities matching filter, in its sort order.
+	List(ctx context.Context, filter models.DiscoveryFilter) ([]*models.Community, error)
+
+	// Recommend returns up to limit active communities userID is not a member of, scored by
+	// members userID follows, userID's posts since then tagged with the topic, and
+	// posts in the community userID commented on since then. Communities without a
+	// signal follow by member count.
+	Recommend(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]*models.Recommendation, error)
+
+	// ListByMember returns the communities userID belongs to, with their role, most
+	// recently joined first.
+	ListByMember(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Community, error)
+
+	// AddMember adds a member and counts them. Returns false, changing nothing, when
+	// the user is already a member.
+	AddMember(ctx context.Context, member *models.Member) (bool, error)
+
+	// RemoveMember removes a member other than the owner and stops counting them.
+	// Returns false when there is no such member.
+	RemoveMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error)
+
+	// GetMember returns a member or ErrNotFound.
+	GetMember(ctx context.Context, communityID, userID uuid.UUID) (*models.Member, error)
+
+	// ListMembers returns the members in role (all when empty), owner and moderators
+	// first, then by join date.
+	ListMembers(ctx context.Context, communityID uuid.UUID, role string, limit, offset int) ([]*models.Member, error)
+
+	// ListMemberIDs returns the IDs of every member, longest-standing first.
+	ListMemberIDs(ctx context.Context, communityID uuid.UUID) ([]uuid.UUID, error)
+
+	// SetRole changes the role of a member other than the owner and returns them.
+	// Returns ErrNotFound when there is no such member.
+	SetRole(ctx context.Context, communityID, userID uuid.UUID, role string) (*models.Member, error)
+
+	// GetAnalytics returns the community's joins, active members, up to topPosts most
+	// engaging posts and posting heatmap since then. Returns ErrNotFound when the
+	// community does not exist.
+	GetAnalytics(ctx context.Context, communityID uuid.UUID, since time.Time, topPosts int) (*models.Analytics, error)
+
+	// DeleteByUser removes userID from every community. Communities userID owns pass
+	// to their longest-standing moderator, or member when there is none; those left
+	// without members are deleted.
+	DeleteByUser(ctx context.Context, userID uuid.UUID, now int64) error
+
+	// GetStarterSettings returns a community's conversation starter settings, or
+	// ErrNotFound when none were saved.
+	GetStarterSettings(ctx context.Context, communityID uuid.UUID) (*models.StarterSettings, error)
+
+	// SaveStarterSettings creates or replaces a community's conversation starter
+	// settings, keeping when the scheduler last ran for it.
+	SaveStarterSettings(ctx context.Context, settings *models.StarterSettings) error
+
+	// ClaimStarterRuns marks up to limit enabled, active communities whose interval has passed
+	// by now (Unix milliseconds) and that have fewer than maxPending pending drafts as
+	// run, and returns them with their name and topic. Rows locked by another claim
+	// are skipped, so concurrent schedulers never claim the same community.
+	ClaimStarterRuns(ctx context.Context, now int64, limit, maxPending int) ([]*models.StarterRun, error)
+
+	// CreateStarterDrafts stores generated drafts.
+	CreateStarterDrafts(ctx context.Context, drafts []*models.StarterDraft) error
+
+	// GetStarterDraft returns a draft of the community, or ErrNotFound.
+	GetStarterDraft(ctx context.Context, communityID, draftID uuid.UUID) (*models.StarterDraft, error)
+
+	// ListStarterDrafts returns the community's drafts in status (all when empty),
+	// newest first.
+	ListStarterDrafts(ctx context.Context, communityID uuid.UUID, status string, limit, offset int) ([]*models.StarterDraft, error)
+
+	// ReviewStarterDraft moves a pending draft to status, recording the reviewer, and
+	// returns it. Returns false, changing nothing, when the draft is not pending.
+	ReviewStarterDraft(ctx context.Context, draft *models.StarterDraft) (bool, error)
+
+	// ReopenStarterDraft puts an approved draft that could not be published back in
+	// the queue.
+	ReopenStarterDraft(ctx context.Context, draftID uuid.UUID) error
+
+	// SetStarterPost records the post an approved draft was published as.
+	SetStarterPost(ctx context.Context, draftID, postID uuid.UUID) error
+
+	// WithTransaction runs fn in a transaction, joining the caller's when there is one.
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
@@ -0,0 +1,59 @@
+package communities
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/communities/handlers"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+type Handlers struct {
+	CommunityHandler *handlers.CommunityHandler
+	StarterHandler   *handlers.StarterHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires communities, their members and discovery. Roles within a
+// community are checked by the service, not by middleware.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/communities")
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	group.Post("/", dualAuthMiddleware, handlers.CommunityHandler.Create)
+	group.Get("/", dualAuthMiddleware, handlers.CommunityHandler.Discover)
+	group.Get("/me", dualAuthMiddleware, handlers.CommunityHandler.MyCommunities)
+	group.Get("/discover", dualAuthMiddleware, handlers.CommunityHandler.Recommend)
+	group.Get("/:communityId", dualAuthMiddleware, handlers.CommunityHandler.Get)
+	group.Put("/:communityId", dualAuthMiddleware, handlers.CommunityHandler.Update)
+	group.Post("/:communityId/join", dualAuthMiddleware, handlers.CommunityHandler.Join)
+	group.Post("/:communityId/leave", dualAuthMiddleware, handlers.CommunityHandler.Leave)
+	group.Get("/:communityId/members", dualAuthMiddleware, handlers.CommunityHandler.ListMembers)
+
+	// --- Owner and moderator routes ---
+	group.Put("/:communityId/members/:userId", dualAuthMiddleware, handlers.CommunityHandler.SetRole)
+	group.Delete("/:communityId/members/:userId", dualAuthMiddleware, handlers.CommunityHandler.RemoveMember)
+	group.Post("/:communityId/archive", dualAuthMiddleware, handlers.CommunityHandler.Archive)
+	group.Post("/:communityId/unarchive", dualAuthMiddleware, handlers.CommunityHandler.Unarchive)
+	group.Get("/:communityId/analytics", dualAuthMiddleware, handlers.CommunityHandler.Analytics)
+	group.Get("/:communityId/starters/settings", dualAuthMiddleware, handlers.StarterHandler.GetSettings)
+	group.Put("/:communityId/starters/settings", dualAuthMiddleware, handlers.StarterHandler.UpdateSettings)
+	group.Get("/:communityId/starters", dualAuthMiddleware, handlers.StarterHandler.List)
+	group.Post("/:communityId/starters/:draftId/approve", dualAuthMiddleware, handlers.StarterHandler.Approve)
+	group.Post("/:communityId/starters/:draftId/reject", dualAuthMiddleware, handlers.StarterHandler.Reject)
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+)
+
+const (
+	defaultAnalyticsDays = 30
+	maxAnalyticsDays     = 90
+	analyticsTopPosts    = 10
+)
+
+func (s *service) Analytics(ctx context.Context, communityID, userID uuid.UUID, days int) (*models.Analytics, error) {
+	if days == 0 {
+		days = defaultAnalyticsDays
+	}
+	if days < 1 || days > maxAnalyticsDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", communitiesErrors.ErrInvalidRequest, maxAnalyticsDays)
+	}
+	member, err := s.member(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Role == models.RoleMember {
+		return nil, communitiesErrors.ErrPermissionDenied
+	}
+
+	// Windows start at midnight UTC so the first day of growth is a whole day
+	since := s.now().UTC().Truncate(24*time.Hour).AddDate(0, 0, 1-days)
+	analytics, err := s.repo.GetAnalytics(ctx, communityID, since, analyticsTopPosts)
+	if err != nil {
+		return nil, s.notFound(err)
+	}
+	analytics.Days = days
+	if analytics.Growth == nil {
+		analytics.Growth = []*models.DailyJoins{}
+	}
+	if analytics.TopPosts == nil {
+		analytics.TopPosts = []*models.TopPost{}
+	}
+	if analytics.Heatmap == nil {
+		analytics.Heatmap = []*models.HeatmapCell{}
+	}
+	return analytics, nil
+}
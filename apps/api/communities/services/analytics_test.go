@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalytics(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 15, 30, 0, 0, time.UTC)
+	communityID := uuid.Must(uuid.NewV4())
+	modID := uuid.Must(uuid.NewV4())
+	memberID := uuid.Must(uuid.NewV4())
+
+	t.Run("reads whole days for moderators", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetMember", ctx, communityID, modID).Return(&models.Member{Role: models.RoleModerator}, nil).Once()
+		since := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+		repo.On("GetAnalytics", ctx, communityID, since, analyticsTopPosts).
+			Return(&models.Analytics{CommunityId: communityID, MemberCount: 12, ActiveMembers: 4}, nil).Once()
+
+		analytics, err := newTestService(repo, now).Analytics(ctx, communityID, modID, 7)
+		require.NoError(t, err)
+		assert.Equal(t, 7, analytics.Days)
+		assert.Equal(t, 4, analytics.ActiveMembers)
+		assert.NotNil(t, analytics.Growth)
+		assert.NotNil(t, analytics.TopPosts)
+		assert.NotNil(t, analytics.Heatmap)
+	})
+
+	t.Run("keeps plain members and outsiders out", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetMember", ctx, communityID, memberID).Return(&models.Member{Role: models.RoleMember}, nil).Once()
+
+		_, err := newTestService(repo, now).Analytics(ctx, communityID, memberID, 0)
+		assert.ErrorIs(t, err, communitiesErrors.ErrPermissionDenied)
+		repo.AssertNotCalled(t, "GetAnalytics", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects windows out of range", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := newTestService(repo, now)
+
+		for _, days := range []int{-1, maxAnalyticsDays + 1} {
+			_, err := svc.Analytics(ctx, communityID, modID, days)
+			assert.ErrorIs(t, err, communitiesErrors.ErrInvalidRequest)
+		}
+	})
+}
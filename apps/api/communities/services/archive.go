@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/communities/repository"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+func (s *service) Archive(ctx context.Context, communityID, userID uuid.UUID) (*models.Community, error) {
+	community, err := s.ownedCommunity(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if community.Archived() {
+		return nil, communitiesErrors.ErrCommunityArchived
+	}
+	now := s.now().UTC().UnixMilli()
+	if err := s.repo.SetArchivedDate(ctx, communityID, now, now); err != nil {
+		return nil, s.notFound(err)
+	}
+	community.ArchivedDate = now
+	community.LastUpdated = now
+	s.notifyArchived(ctx, community, userID)
+	return community, nil
+}
+
+func (s *service) Unarchive(ctx context.Context, communityID, userID uuid.UUID) (*models.Community, error) {
+	community, err := s.ownedCommunity(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !community.Archived() {
+		return nil, communitiesErrors.ErrNotArchived
+	}
+	now := s.now().UTC().UnixMilli()
+	if err := s.repo.SetArchivedDate(ctx, communityID, 0, now); err != nil {
+		return nil, s.notFound(err)
+	}
+	community.ArchivedDate = 0
+	community.LastUpdated = now
+	return community, nil
+}
+
+func (s *service) IsCommunityArchived(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	community, err := s.repo.Get(ctx, communityID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return community.Archived(), nil
+}
+
+func (s *service) AllowsAnonymousPosts(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	community, err := s.repo.Get(ctx, communityID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return community.AllowAnonymous, nil
+}
+
+func (s *service) RequiresApproval(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	community, err := s.repo.Get(ctx, communityID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return community.RequiresApproval && !community.Archived(), nil
+}
+
+func (s *service) AdmitMember(ctx context.Context, communityID, userID uuid.UUID) error {
+	if _, err := s.activeCommunity(ctx, communityID); err != nil {
+		return err
+	}
+	if _, err := s.addMember(ctx, communityID, userID); err != nil && !errors.Is(err, communitiesErrors.ErrAlreadyMember) {
+		return err
+	}
+	return nil
+}
+
+// ownedCommunity returns the community when userID owns it, with their role
+func (s *service) ownedCommunity(ctx context.Context, communityID, userID uuid.UUID) (*models.Community, error) {
+	community, err := s.repo.Get(ctx, communityID)
+	if err != nil {
+		return nil, s.notFound(err)
+	}
+	member, err := s.member(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Role != models.RoleOwner {
+		return nil, communitiesErrors.ErrPermissionDenied
+	}
+	community.Role = member.Role
+	return community, nil
+}
+
+// activeCommunity returns the community unless it is missing or archived
+func (s *service) activeCommunity(ctx context.Context, communityID uuid.UUID) (*models.Community, error) {
+	community, err := s.repo.Get(ctx, communityID)
+	if err != nil {
+		return nil, s.notFound(err)
+	}
+	if community.Archived() {
+		return nil, communitiesErrors.ErrCommunityArchived
+	}
+	return community, nil
+}
+
+// notifyArchived tells every member but the owner who archived it that the community is
+// now read-only. The archive stands when members cannot be listed.
+func (s *service) notifyArchived(ctx context.Context, community *models.Community, ownerID uuid.UUID) {
+	if !events.HasSubscribers() {
+		return
+	}
+	memberIDs, err := s.repo.ListMemberIDs(ctx, community.ObjectId)
+	if err != nil {
+		log.Warn("Members of archived community %s were not notified: %v", community.ObjectId, err)
+		return
+	}
+	recipients := make([]uuid.UUID, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		if id != ownerID {
+			recipients = append(recipients, id)
+		}
+	}
+	if len(recipients) == 0 {
+		return
+	}
+	events.Publish(ctx, events.Event{
+		Type: events.TypeNotification,
+		Data: events.Notification{
+			Kind:        events.NotificationCommunityArchived,
+			CommunityId: community.ObjectId.String(),
+			ActorUserId: ownerID.String(),
+			Preview:     community.Name,
+		},
+		CreatedDate: community.ArchivedDate,
+		Recipients:  recipients,
+	})
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchive(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	communityID := uuid.Must(uuid.NewV4())
+	ownerID := uuid.Must(uuid.NewV4())
+	modID := uuid.Must(uuid.NewV4())
+	memberID := uuid.Must(uuid.NewV4())
+
+	t.Run("archives and notifies the other members", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Get", ctx, communityID).Return(&models.Community{ObjectId: communityID, Name: "Cooks"}, nil).Once()
+		repo.On("GetMember", ctx, communityID, ownerID).Return(&models.Member{Role: models.RoleOwner}, nil).Once()
+		repo.On("SetArchivedDate", ctx, communityID, now.UnixMilli(), now.UnixMilli()).Return(nil).Once()
+		repo.On("ListMemberIDs", ctx, communityID).Return([]uuid.UUID{ownerID, modID, memberID}, nil).Once()
+
+		var published []events.Event
+		unsubscribe := events.Subscribe(func(event events.Event) { published = append(published, event) })
+		defer unsubscribe()
+
+		community, err := newTestService(repo, now).Archive(ctx, communityID, ownerID)
+		require.NoError(t, err)
+		assert.True(t, community.Archived())
+		require.Len(t, published, 1)
+		assert.ElementsMatch(t, []uuid.UUID{modID, memberID}, published[0].Recipients)
+		notification := published[0].Data.(events.Notification)
+		assert.Equal(t, events.NotificationCommunityArchived, notification.Kind)
+		assert.Equal(t, communityID.String(), notification.CommunityId)
+	})
+
+	t.Run("lets only the owner archive", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Get", ctx, communityID).Return(&models.Community{ObjectId: communityID}, nil).Once()
+		repo.On("GetMember", ctx, communityID, modID).Return(&models.Member{Role: models.RoleModerator}, nil).Once()
+
+		_, err := newTestService(repo, now).Archive(ctx, communityID, modID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrPermissionDenied)
+		repo.AssertNotCalled(t, "SetArchivedDate", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("unarchives only archived communities", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Get", ctx, communityID).Return(&models.Community{ObjectId: communityID}, nil).Once()
+		repo.On("GetMember", ctx, communityID, ownerID).Return(&models.Member{Role: models.RoleOwner}, nil).Once()
+
+		_, err := newTestService(repo, now).Unarchive(ctx, communityID, ownerID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrNotArchived)
+
+		repo.On("Get", ctx, communityID).Return(&models.Community{ObjectId: communityID, ArchivedDate: 1}, nil).Once()
+		repo.On("GetMember", ctx, communityID, ownerID).Return(&models.Member{Role: models.RoleOwner}, nil).Once()
+		repo.On("SetArchivedDate", ctx, communityID, int64(0), now.UnixMilli()).Return(nil).Once()
+		community, err := newTestService(repo, now).Unarchive(ctx, communityID, ownerID)
+		require.NoError(t, err)
+		assert.False(t, community.Archived())
+	})
+
+	t.Run("keeps archived communities read-only", func(t *testing.T) {
+		repo := new(MockRepository)
+		archived := &models.Community{ObjectId: communityID, ArchivedDate: now.UnixMilli()}
+		repo.On("Get", ctx, communityID).Return(archived, nil)
+		repo.On("GetMember", ctx, communityID, modID).Return(&models.Member{Role: models.RoleModerator}, nil)
+		svc := newTestService(repo, now)
+
+		_, err := svc.Join(ctx, communityID, memberID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrCommunityArchived)
+		name := "New name"
+		_, err = svc.Update(ctx, communityID, modID, &models.UpdateCommunityRequest{Name: &name})
+		assert.ErrorIs(t, err, communitiesErrors.ErrCommunityArchived)
+		repo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+
+		isArchived, err := svc.IsCommunityArchived(ctx, communityID)
+		require.NoError(t, err)
+		assert.True(t, isArchived)
+	})
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/communities/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the communities repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) Create(ctx context.Context, community *models.Community) error {
+	args := m.Called(ctx, community)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Get(ctx context.Context, communityID uuid.UUID) (*models.Community, error) {
+	args := m.Called(ctx, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Community), args.Error(1)
+}
+
+func (m *MockRepository) GetBySlug(ctx context.Context, slug string) (*models.Community, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Community), args.Error(1)
+}
+
+func (m *MockRepository) Update(ctx context.Context, community *models.Community) error {
+	args := m.Called(ctx, community)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SetArchivedDate(ctx context.Context, communityID uuid.UUID, archivedDate, lastUpdated int64) error {
+	args := m.Called(ctx, communityID, archivedDate, lastUpdated)
+	return args.Error(0)
+}
+
+func (m *MockRepository) List(ctx context.Context, filter models.DiscoveryFilter) ([]*models.Community, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Community), args.Error(1)
+}
+
+func (m *MockRepository) Recommend(ctx context.Context, userID uuid.UUID, since time.Time, limit int) ([]*models.Recommendation, error) {
+	args := m.Called(ctx, userID, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Recommendation), args.Error(1)
+}
+
+func (m *MockRepository) ListByMember(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Community, error) {
+	args := m.Called(ctx, userID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Community), args.Error(1)
+}
+
+func (m *MockRepository) AddMember(ctx context.Context, member *models.Member) (bool, error) {
+	args := m.Called(ctx, member)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) RemoveMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, communityID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) GetMember(ctx context.Context, communityID, userID uuid.UUID) (*models.Member, error) {
+	args := m.Called(ctx, communityID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Member), args.Error(1)
+}
+
+func (m *MockRepository) ListMembers(ctx context.Context, communityID uuid.UUID, role string, limit, offset int) ([]*models.Member, error) {
+	args := m.Called(ctx, communityID, role, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Member), args.Error(1)
+}
+
+func (m *MockRepository) ListMemberIDs(ctx context.Context, communityID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) SetRole(ctx context.Context, communityID, userID uuid.UUID, role string) (*models.Member, error) {
+	args := m.Called(ctx, communityID, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Member), args.Error(1)
+}
+
+func (m *MockRepository) GetAnalytics(ctx context.Context, communityID uuid.UUID, since time.Time, topPosts int) (*models.Analytics, error) {
+	args := m.Called(ctx, communityID, since, topPosts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Analytics), args.Error(1)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID, now int64) error {
+	args := m.Called(ctx, userID, now)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetStarterSettings(ctx context.Context, communityID uuid.UUID) (*models.StarterSettings, error) {
+	args := m.Called(ctx, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StarterSettings), args.Error(1)
+}
+
+func (m *MockRepository) SaveStarterSettings(ctx context.Context, settings *models.StarterSettings) error {
+	args := m.Called(ctx, settings)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ClaimStarterRuns(ctx context.Context, now int64, limit, maxPending int) ([]*models.StarterRun, error) {
+	args := m.Called(ctx, now, limit, maxPending)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StarterRun), args.Error(1)
+}
+
+func (m *MockRepository) CreateStarterDrafts(ctx context.Context, drafts []*models.StarterDraft) error {
+	args := m.Called(ctx, drafts)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetStarterDraft(ctx context.Context, communityID, draftID uuid.UUID) (*models.StarterDraft, error) {
+	args := m.Called(ctx, communityID, draftID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.StarterDraft), args.Error(1)
+}
+
+func (m *MockRepository) ListStarterDrafts(ctx context.Context, communityID uuid.UUID, status string, limit, offset int) ([]*models.StarterDraft, error) {
+	args := m.Called(ctx, communityID, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.StarterDraft), args.Error(1)
+}
+
+func (m *MockRepository) ReviewStarterDraft(ctx context.Context, draft *models.StarterDraft) (bool, error) {
+	args := m.Called(ctx, draft)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ReopenStarterDraft(ctx context.Context, draftID uuid.UUID) error {
+	args := m.Called(ctx, draftID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SetStarterPost(ctx context.Context, draftID, postID uuid.UUID) error {
+	args := m.Called(ctx, draftID, postID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
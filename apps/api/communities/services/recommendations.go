@@ -0,0 +1,91 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/internal/cache"
+)
+
+const (
+	// maxRecommendations is how many recommendations are scored and cached per user;
+	// smaller limits are cut from the cached list
+	maxRecommendations = 50
+	// recommendationWindow is how far back posts and comments count as activity
+	recommendationWindow = 30 * 24 * time.Hour
+	// recommendationCacheTTL is how often a user's recommendations are scored again
+	recommendationCacheTTL = 15 * time.Minute
+)
+
+func recommendationCacheKey(userID uuid.UUID) string {
+	return "recommendations:" + userID.String()
+}
+
+func (s *service) Recommend(ctx context.Context, userID uuid.UUID, limit int) ([]*models.Recommendation, error) {
+	if limit <= 0 || limit > maxRecommendations {
+		limit = defaultListLimit
+	}
+
+	var recommendations []*models.Recommendation
+	cached := false
+	if s.recommendations != nil {
+		cached = s.recommendations.GetCached(ctx, recommendationCacheKey(userID), &recommendations) == nil
+	}
+	if !cached {
+		since := s.now().UTC().Add(-recommendationWindow)
+		found, err := s.repo.Recommend(ctx, userID, since, maxRecommendations)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+		}
+		for _, recommendation := range found {
+			recommendation.Reasons = reasons(recommendation)
+			recommendation.Joinable = true
+		}
+		recommendations = found
+		if s.recommendations != nil {
+			_ = s.recommendations.CacheData(ctx, recommendationCacheKey(userID), recommendations, recommendationCacheTTL)
+		}
+	}
+
+	if recommendations == nil {
+		recommendations = []*models.Recommendation{}
+	}
+	if len(recommendations) > limit {
+		recommendations = recommendations[:limit]
+	}
+	return recommendations, nil
+}
+
+// reasons lists the signals that recommended a community, strongest first
+func reasons(recommendation *models.Recommendation) []string {
+	var reasons []string
+	if recommendation.FollowedMembers > 0 {
+		reasons = append(reasons, models.ReasonFollows)
+	}
+	if recommendation.TaggedPosts > 0 {
+		reasons = append(reasons, models.ReasonTopic)
+	}
+	if recommendation.CommentedPosts > 0 {
+		reasons = append(reasons, models.ReasonActivity)
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, models.ReasonPopular)
+	}
+	return reasons
+}
+
+// forgetRecommendations drops a user's cached recommendations after they join or leave
+// a community, so the next request is scored against their memberships
+func (s *service) forgetRecommendations(ctx context.Context, userID uuid.UUID) {
+	if s.recommendations != nil {
+		_ = s.recommendations.InvalidateKey(ctx, recommendationCacheKey(userID))
+	}
+}
+
+func (s *service) SetRecommendationCache(recommendations *cache.GenericCacheService) {
+	s.recommendations = recommendations
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecommend(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	userID := uuid.Must(uuid.NewV4())
+	followed := &models.Recommendation{Community: models.Community{ObjectId: uuid.Must(uuid.NewV4())}, FollowedMembers: 2, CommentedPosts: 1}
+	topical := &models.Recommendation{Community: models.Community{ObjectId: uuid.Must(uuid.NewV4())}, TaggedPosts: 3}
+	popular := &models.Recommendation{Community: models.Community{ObjectId: uuid.Must(uuid.NewV4()), MemberCount: 500}}
+
+	t.Run("explains each recommendation", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Recommend", ctx, userID, now.Add(-recommendationWindow), maxRecommendations).
+			Return([]*models.Recommendation{followed, topical, popular}, nil).Once()
+
+		recommendations, err := newTestService(repo, now).Recommend(ctx, userID, 2)
+		require.NoError(t, err)
+		require.Len(t, recommendations, 2)
+		assert.Equal(t, []string{models.ReasonFollows, models.ReasonActivity}, recommendations[0].Reasons)
+		assert.Equal(t, []string{models.ReasonTopic}, recommendations[1].Reasons)
+		assert.True(t, recommendations[0].Joinable)
+		assert.Equal(t, []string{models.ReasonPopular}, reasons(popular))
+	})
+
+	t.Run("serves cached recommendations until the user joins a community", func(t *testing.T) {
+		repo := new(MockRepository)
+		config := cache.DefaultCacheConfig()
+		svc := newTestService(repo, now)
+		svc.SetRecommendationCache(cache.NewGenericCacheService(cache.NewMemoryCache(config), config))
+		repo.On("Recommend", ctx, userID, mock.Anything, maxRecommendations).
+			Return([]*models.Recommendation{followed}, nil).Twice()
+
+		_, err := svc.Recommend(ctx, userID, 10)
+		require.NoError(t, err)
+		recommendations, err := svc.Recommend(ctx, userID, 10)
+		require.NoError(t, err)
+		require.Len(t, recommendations, 1)
+		assert.Equal(t, followed.ObjectId, recommendations[0].ObjectId)
+		repo.AssertNumberOfCalls(t, "Recommend", 1)
+
+		repo.On("Get", ctx, followed.ObjectId).Return(&followed.Community, nil).Once()
+		repo.On("AddMember", ctx, mock.Anything).Return(true, nil).Once()
+		_, err = svc.Join(ctx, followed.ObjectId, userID)
+		require.NoError(t, err)
+		_, err = svc.Recommend(ctx, userID, 10)
+		require.NoError(t, err)
+		repo.AssertNumberOfCalls(t, "Recommend", 2)
+	})
+
+	t.Run("returns an empty list when there is nothing to recommend", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Recommend", ctx, userID, mock.Anything, maxRecommendations).Return(nil, nil).Once()
+
+		recommendations, err := newTestService(repo, now).Recommend(ctx, userID, 0)
+		require.NoError(t, err)
+		assert.NotNil(t, recommendations)
+		assert.Empty(t, recommendations)
+	})
+}
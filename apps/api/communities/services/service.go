@@ -0,0 +1,460 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/communities/repository"
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	maxNameLength        = 100
+	maxDescriptionLength = 2000
+	maxTopicLength       = 100
+	defaultListLimit     = 20
+	maxListLimit         = 100
+)
+
+// slugPattern is 3 to 64 lowercase letters, digits and single hyphens between them
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Service defines community, membership and discovery operations.
+type Service interface {
+	// Create creates a community owned by userID, its first member.
+	Create(ctx context.Context, userID uuid.UUID, req *models.CreateCommunityRequest) (*models.Community, error)
+
+	// Get returns the community with ID or slug ref, with the viewer's role.
+	Get(ctx context.Context, ref string, viewerID uuid.UUID) (*models.Community, error)
+
+	// Update changes the name, description, topic or settings of an active community;
+	// owner and moderators only.
+	Update(ctx context.Context, communityID, userID uuid.UUID, req *models.UpdateCommunityRequest) (*models.Community, error)
+
+	// Archive makes the community read-only: its posts and comments stay readable, but
+	// nobody joins, posts or comments, and discovery skips it. Members are notified;
+	// owner only.
+	Archive(ctx context.Context, communityID, userID uuid.UUID) (*models.Community, error)
+
+	// Unarchive makes an archived community active again; owner only.
+	Unarchive(ctx context.Context, communityID, userID uuid.UUID) (*models.Community, error)
+
+	// Discover lists active communities by popularity or age, optionally matching a search
+	// term or topic.
+	Discover(ctx context.Context, filter models.DiscoveryFilter) ([]*models.Community, error)
+
+	// Recommend suggests up to limit communities userID has not joined, from the people
+	// they follow, the tags on their recent posts and the communities of posts they
+	// recently commented on, then by member count. Recommendations are cached per user
+	// for a few minutes.
+	Recommend(ctx context.Context, userID uuid.UUID, limit int) ([]*models.Recommendation, error)
+
+	// MyCommunities returns the communities userID belongs to, with their role.
+	MyCommunities(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Community, error)
+
+	// Join makes userID a member of an active community. Communities that require
+	// approval are joined by applying through their approval queue instead.
+	Join(ctx context.Context, communityID, userID uuid.UUID) (*models.Member, error)
+
+	// Leave removes userID from the community; the owner cannot leave.
+	Leave(ctx context.Context, communityID, userID uuid.UUID) error
+
+	// ListMembers returns the members in role (all when empty).
+	ListMembers(ctx context.Context, communityID uuid.UUID, role string, limit, offset int) ([]*models.Member, error)
+
+	// SetRole makes a member a moderator or a plain member; owner only.
+	SetRole(ctx context.Context, communityID, actorID, userID uuid.UUID, req *models.SetRoleRequest) (*models.Member, error)
+
+	// RemoveMember removes a member: the owner may remove anyone else, moderators only
+	// plain members.
+	RemoveMember(ctx context.Context, communityID, actorID, userID uuid.UUID) error
+
+	// Analytics returns the community's growth, active members, top posts and posting
+	// heatmap over its last days days (30 when zero, at most 90); owner and moderators
+	// only.
+	Analytics(ctx context.Context, communityID, userID uuid.UUID, days int) (*models.Analytics, error)
+
+	// GetStarterSettings returns the conversation starter settings, the defaults when
+	// none were saved; owner and moderators only.
+	GetStarterSettings(ctx context.Context, communityID, userID uuid.UUID) (*models.StarterSettings, error)
+
+	// UpdateStarterSettings changes the conversation starter settings of an active
+	// community; owner and moderators only.
+	UpdateStarterSettings(ctx context.Context, communityID, userID uuid.UUID, req *models.UpdateStarterSettingsRequest) (*models.StarterSettings, error)
+
+	// ListStarterDrafts returns the drafted starters in status (all when empty); owner
+	// and moderators only.
+	ListStarterDrafts(ctx context.Context, communityID, userID uuid.UUID, status string, limit, offset int) ([]*models.StarterDraft, error)
+
+	// ApproveStarter publishes a pending starter in an active community as a post by
+	// reviewer, optionally with edited text; owner and moderators only.
+	ApproveStarter(ctx context.Context, communityID, draftID uuid.UUID, reviewer sharedInterfaces.CommunityPostAuthor, req *models.ApproveStarterRequest) (*models.StarterDraft, error)
+
+	// RejectStarter discards a pending starter; owner and moderators only.
+	RejectStarter(ctx context.Context, communityID, draftID, userID uuid.UUID) (*models.StarterDraft, error)
+
+	// DraftStarters runs the conversation starter scheduler once and returns how many
+	// starters it drafted.
+	DraftStarters(ctx context.Context, batch, maxPending int) (int, error)
+
+	// SetStarterGenerator lets DraftStarters write starters, through the AI engine
+	SetStarterGenerator(generator StarterGenerator)
+
+	// SetRecommendationCache keeps each user's recommendations between requests
+	SetRecommendationCache(recommendations *cache.GenericCacheService)
+
+	// SetCommunityPoster lets starters measure community activity and publish posts
+	SetCommunityPoster(poster sharedInterfaces.CommunityPoster)
+
+	sharedInterfaces.CommunityAdmitter
+}
+
+type service struct {
+	repo     repository.Repository
+	now      func() time.Time
+	starters StarterGenerator
+	poster   sharedInterfaces.CommunityPoster
+
+	recommendations *cache.GenericCacheService // nil when caching is disabled
+}
+
+// NewService constructs a communities service.
+func NewService(repo repository.Repository) Service {
+	return &service{repo: repo, now: time.Now}
+}
+
+func (s *service) Create(ctx context.Context, userID uuid.UUID, req *models.CreateCommunityRequest) (*models.Community, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", communitiesErrors.ErrInvalidRequest)
+	}
+	slug := strings.ToLower(strings.TrimSpace(req.Slug))
+	if len(slug) < 3 || len(slug) > 64 || !slugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("%w: slugs are 3 to 64 lowercase letters, digits and hyphens", communitiesErrors.ErrInvalidRequest)
+	}
+	community := &models.Community{
+		ObjectId:         uuid.Must(uuid.NewV4()),
+		Slug:             slug,
+		OwnerUserId:      userID,
+		AllowAnonymous:   req.AllowAnonymous,
+		RequiresApproval: req.RequiresApproval,
+		Role:             models.RoleOwner,
+	}
+	if err := applyDetails(community, &req.Name, &req.Description, &req.Topic); err != nil {
+		return nil, err
+	}
+	now := s.now().UTC().UnixMilli()
+	community.CreatedDate = now
+	community.LastUpdated = now
+
+	if err := s.repo.Create(ctx, community); err != nil {
+		if errors.Is(err, repository.ErrDuplicateSlug) {
+			return nil, communitiesErrors.ErrSlugTaken
+		}
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	return community, nil
+}
+
+// applyDetails sets the fields that are given, trimmed and checked; a community needs a name
+func applyDetails(community *models.Community, name, description, topic *string) error {
+	if name != nil {
+		community.Name = strings.TrimSpace(*name)
+	}
+	if description != nil {
+		community.Description = strings.TrimSpace(*description)
+	}
+	if topic != nil {
+		community.Topic = strings.TrimSpace(*topic)
+	}
+	if community.Name == "" {
+		return fmt.Errorf("%w: name is required", communitiesErrors.ErrInvalidRequest)
+	}
+	if utf8.RuneCountInString(community.Name) > maxNameLength {
+		return fmt.Errorf("%w: names are at most %d characters", communitiesErrors.ErrInvalidRequest, maxNameLength)
+	}
+	if utf8.RuneCountInString(community.Description) > maxDescriptionLength {
+		return fmt.Errorf("%w: descriptions are at most %d characters", communitiesErrors.ErrInvalidRequest, maxDescriptionLength)
+	}
+	if utf8.RuneCountInString(community.Topic) > maxTopicLength {
+		return fmt.Errorf("%w: topics are at most %d characters", communitiesErrors.ErrInvalidRequest, maxTopicLength)
+	}
+	return nil
+}
+
+func (s *service) Get(ctx context.Context, ref string, viewerID uuid.UUID) (*models.Community, error) {
+	var community *models.Community
+	var err error
+	if id, parseErr := uuid.FromString(ref); parseErr == nil {
+		community, err = s.repo.Get(ctx, id)
+	} else {
+		community, err = s.repo.GetBySlug(ctx, strings.ToLower(ref))
+	}
+	if err != nil {
+		return nil, s.notFound(err)
+	}
+	if viewerID != uuid.Nil {
+		member, err := s.member(ctx, community.ObjectId, viewerID)
+		if err != nil {
+			return nil, err
+		}
+		if member != nil {
+			community.Role = member.Role
+		}
+	}
+	return community, nil
+}
+
+func (s *service) Update(ctx context.Context, communityID, userID uuid.UUID, req *models.UpdateCommunityRequest) (*models.Community, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", communitiesErrors.ErrInvalidRequest)
+	}
+	community, err := s.repo.Get(ctx, communityID)
+	if err != nil {
+		return nil, s.notFound(err)
+	}
+	member, err := s.member(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Role == models.RoleMember {
+		return nil, communitiesErrors.ErrPermissionDenied
+	}
+	if community.Archived() {
+		return nil, communitiesErrors.ErrCommunityArchived
+	}
+	if err := applyDetails(community, req.Name, req.Description, req.Topic); err != nil {
+		return nil, err
+	}
+	if req.AllowAnonymous != nil {
+		community.AllowAnonymous = *req.AllowAnonymous
+	}
+	if req.RequiresApproval != nil {
+		community.RequiresApproval = *req.RequiresApproval
+	}
+	community.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Update(ctx, community); err != nil {
+		return nil, s.notFound(err)
+	}
+	community.Role = member.Role
+	return community, nil
+}
+
+func (s *service) Discover(ctx context.Context, filter models.DiscoveryFilter) ([]*models.Community, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	filter.Topic = strings.TrimSpace(filter.Topic)
+	switch filter.Sort {
+	case "":
+		filter.Sort = models.SortPopular
+	case models.SortPopular, models.SortNew:
+	default:
+		return nil, fmt.Errorf("%w: sort must be popular or new", communitiesErrors.ErrInvalidRequest)
+	}
+	filter.Limit, filter.Offset = normalizePage(filter.Limit, filter.Offset)
+
+	communities, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	if communities == nil {
+		communities = []*models.Community{}
+	}
+	return communities, nil
+}
+
+func (s *service) MyCommunities(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Community, error) {
+	limit, offset = normalizePage(limit, offset)
+	communities, err := s.repo.ListByMember(ctx, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	if communities == nil {
+		communities = []*models.Community{}
+	}
+	return communities, nil
+}
+
+func (s *service) Join(ctx context.Context, communityID, userID uuid.UUID) (*models.Member, error) {
+	community, err := s.activeCommunity(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if community.RequiresApproval {
+		return nil, communitiesErrors.ErrApprovalRequired
+	}
+	return s.addMember(ctx, communityID, userID)
+}
+
+// addMember makes userID a plain member of the community
+func (s *service) addMember(ctx context.Context, communityID, userID uuid.UUID) (*models.Member, error) {
+	member := &models.Member{
+		CommunityId: communityID,
+		UserId:      userID,
+		Role:        models.RoleMember,
+		JoinedDate:  s.now().UTC().UnixMilli(),
+	}
+	added, err := s.repo.AddMember(ctx, member)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	if !added {
+		return nil, communitiesErrors.ErrAlreadyMember
+	}
+	s.forgetRecommendations(ctx, userID)
+	return member, nil
+}
+
+func (s *service) Leave(ctx context.Context, communityID, userID uuid.UUID) error {
+	member, err := s.member(ctx, communityID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return communitiesErrors.ErrNotMember
+	}
+	if member.Role == models.RoleOwner {
+		return communitiesErrors.ErrOwnerCannotLeave
+	}
+	return s.remove(ctx, communityID, userID)
+}
+
+func (s *service) ListMembers(ctx context.Context, communityID uuid.UUID, role string, limit, offset int) ([]*models.Member, error) {
+	if role != "" && !models.IsValidRole(role) {
+		return nil, fmt.Errorf("%w: unknown role %q", communitiesErrors.ErrInvalidRequest, role)
+	}
+	if _, err := s.repo.Get(ctx, communityID); err != nil {
+		return nil, s.notFound(err)
+	}
+	limit, offset = normalizePage(limit, offset)
+	members, err := s.repo.ListMembers(ctx, communityID, role, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	if members == nil {
+		members = []*models.Member{}
+	}
+	return members, nil
+}
+
+func (s *service) SetRole(ctx context.Context, communityID, actorID, userID uuid.UUID, req *models.SetRoleRequest) (*models.Member, error) {
+	if req == nil || !models.IsAssignableRole(req.Role) {
+		return nil, fmt.Errorf("%w: role must be moderator or member", communitiesErrors.ErrInvalidRequest)
+	}
+	actor, err := s.member(ctx, communityID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if actor == nil || actor.Role != models.RoleOwner {
+		return nil, communitiesErrors.ErrPermissionDenied
+	}
+	if actorID == userID {
+		return nil, fmt.Errorf("%w: the owner keeps their role", communitiesErrors.ErrInvalidRequest)
+	}
+
+	member, err := s.repo.SetRole(ctx, communityID, userID, req.Role)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, communitiesErrors.ErrNotMember
+		}
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	return member, nil
+}
+
+func (s *service) RemoveMember(ctx context.Context, communityID, actorID, userID uuid.UUID) error {
+	if actorID == userID {
+		return s.Leave(ctx, communityID, userID)
+	}
+	actor, err := s.member(ctx, communityID, actorID)
+	if err != nil {
+		return err
+	}
+	if actor == nil || actor.Role == models.RoleMember {
+		return communitiesErrors.ErrPermissionDenied
+	}
+	member, err := s.member(ctx, communityID, userID)
+	if err != nil {
+		return err
+	}
+	if member == nil {
+		return communitiesErrors.ErrNotMember
+	}
+	// Moderators remove members; only the owner removes moderators, and nobody the owner
+	if member.Role == models.RoleOwner || (member.Role == models.RoleModerator && actor.Role != models.RoleOwner) {
+		return communitiesErrors.ErrPermissionDenied
+	}
+	return s.remove(ctx, communityID, userID)
+}
+
+func (s *service) remove(ctx context.Context, communityID, userID uuid.UUID) error {
+	removed, err := s.repo.RemoveMember(ctx, communityID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	if !removed {
+		return communitiesErrors.ErrNotMember
+	}
+	s.forgetRecommendations(ctx, userID)
+	return nil
+}
+
+func (s *service) IsCommunityMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	member, err := s.repo.GetMember(ctx, communityID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return member != nil, nil
+}
+
+func (s *service) IsCommunityModerator(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	member, err := s.repo.GetMember(ctx, communityID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return member.Role == models.RoleOwner || member.Role == models.RoleModerator, nil
+}
+
+// member returns the user's membership, or nil when they are not a member
+func (s *service) member(ctx context.Context, communityID, userID uuid.UUID) (*models.Member, error) {
+	member, err := s.repo.GetMember(ctx, communityID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	return member, nil
+}
+
+// notFound maps a missing community to ErrCommunityNotFound and anything else to a
+// database error
+func (s *service) notFound(err error) error {
+	if errors.Is(err, repository.ErrNotFound) {
+		return communitiesErrors.ErrCommunityNotFound
+	}
+	return fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+}
+
+func normalizePage(limit, offset int) (int, int) {
+	if limit <= 0 || limit > maxListLimit {
+		limit = defaultListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
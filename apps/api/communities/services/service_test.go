@@ -0,0 +1,203 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/communities/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(repo *MockRepository, now time.Time) *service {
+	svc := NewService(repo).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestCreate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	ownerID := uuid.Must(uuid.NewV4())
+
+	t.Run("creates the community with its owner", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Create", ctx, mock.Anything).Return(nil).Once()
+
+		community, err := newTestService(repo, now).Create(ctx, ownerID, &models.CreateCommunityRequest{
+			Slug: " Home-Cooks ", Name: " Home cooks ", Topic: "cooking",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "home-cooks", community.Slug)
+		assert.Equal(t, "Home cooks", community.Name)
+		assert.Equal(t, ownerID, community.OwnerUserId)
+		assert.Equal(t, models.RoleOwner, community.Role)
+		assert.Equal(t, now.UnixMilli(), community.CreatedDate)
+	})
+
+	t.Run("rejects bad slugs and missing names", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := newTestService(repo, now)
+
+		for _, slug := range []string{"ab", "two words", "trailing-", "under_score"} {
+			_, err := svc.Create(ctx, ownerID, &models.CreateCommunityRequest{Slug: slug, Name: "Name"})
+			assert.ErrorIs(t, err, communitiesErrors.ErrInvalidRequest, slug)
+		}
+		_, err := svc.Create(ctx, ownerID, &models.CreateCommunityRequest{Slug: "cooks", Name: " "})
+		assert.ErrorIs(t, err, communitiesErrors.ErrInvalidRequest)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("reports a taken slug", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Create", ctx, mock.Anything).Return(repository.ErrDuplicateSlug).Once()
+
+		_, err := newTestService(repo, now).Create(ctx, ownerID, &models.CreateCommunityRequest{Slug: "cooks", Name: "Cooks"})
+		assert.ErrorIs(t, err, communitiesErrors.ErrSlugTaken)
+	})
+}
+
+func TestJoinAndLeave(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	communityID := uuid.Must(uuid.NewV4())
+	userID := uuid.Must(uuid.NewV4())
+	community := &models.Community{ObjectId: communityID, Slug: "cooks"}
+
+	t.Run("joins as a member", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Get", ctx, communityID).Return(community, nil).Once()
+		repo.On("AddMember", ctx, mock.MatchedBy(func(m *models.Member) bool {
+			return m.UserId == userID && m.Role == models.RoleMember
+		})).Return(true, nil).Once()
+
+		member, err := newTestService(repo, now).Join(ctx, communityID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, now.UnixMilli(), member.JoinedDate)
+	})
+
+	t.Run("reports members joining again", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Get", ctx, communityID).Return(community, nil).Once()
+		repo.On("AddMember", ctx, mock.Anything).Return(false, nil).Once()
+
+		_, err := newTestService(repo, now).Join(ctx, communityID, userID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrAlreadyMember)
+	})
+
+	t.Run("sends applicants to the approval queue", func(t *testing.T) {
+		repo := new(MockRepository)
+		gated := &models.Community{ObjectId: communityID, Slug: "cooks", RequiresApproval: true}
+		repo.On("Get", ctx, communityID).Return(gated, nil)
+		svc := newTestService(repo, now)
+
+		_, err := svc.Join(ctx, communityID, userID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrApprovalRequired)
+		repo.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+
+		repo.On("AddMember", ctx, mock.Anything).Return(false, nil).Once()
+		assert.NoError(t, svc.AdmitMember(ctx, communityID, userID), "approved applicants are admitted once")
+	})
+
+	t.Run("keeps the owner from leaving", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetMember", ctx, communityID, userID).Return(&models.Member{Role: models.RoleOwner}, nil).Once()
+
+		err := newTestService(repo, now).Leave(ctx, communityID, userID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrOwnerCannotLeave)
+		repo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("reports leaving a community the user is not in", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetMember", ctx, communityID, userID).Return(nil, repository.ErrNotFound).Once()
+
+		err := newTestService(repo, now).Leave(ctx, communityID, userID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrNotMember)
+	})
+}
+
+func TestRoles(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	communityID := uuid.Must(uuid.NewV4())
+	ownerID := uuid.Must(uuid.NewV4())
+	moderatorID := uuid.Must(uuid.NewV4())
+	memberID := uuid.Must(uuid.NewV4())
+
+	withMembers := func() *MockRepository {
+		repo := new(MockRepository)
+		repo.On("GetMember", ctx, communityID, ownerID).Return(&models.Member{UserId: ownerID, Role: models.RoleOwner}, nil)
+		repo.On("GetMember", ctx, communityID, moderatorID).Return(&models.Member{UserId: moderatorID, Role: models.RoleModerator}, nil)
+		repo.On("GetMember", ctx, communityID, memberID).Return(&models.Member{UserId: memberID, Role: models.RoleMember}, nil)
+		return repo
+	}
+
+	t.Run("only the owner appoints moderators", func(t *testing.T) {
+		repo := withMembers()
+		repo.On("SetRole", ctx, communityID, memberID, models.RoleModerator).
+			Return(&models.Member{UserId: memberID, Role: models.RoleModerator}, nil).Once()
+		svc := newTestService(repo, now)
+
+		member, err := svc.SetRole(ctx, communityID, ownerID, memberID, &models.SetRoleRequest{Role: models.RoleModerator})
+		require.NoError(t, err)
+		assert.Equal(t, models.RoleModerator, member.Role)
+
+		_, err = svc.SetRole(ctx, communityID, moderatorID, memberID, &models.SetRoleRequest{Role: models.RoleModerator})
+		assert.ErrorIs(t, err, communitiesErrors.ErrPermissionDenied)
+
+		_, err = svc.SetRole(ctx, communityID, ownerID, memberID, &models.SetRoleRequest{Role: models.RoleOwner})
+		assert.ErrorIs(t, err, communitiesErrors.ErrInvalidRequest)
+	})
+
+	t.Run("moderators remove members but not other moderators", func(t *testing.T) {
+		repo := withMembers()
+		repo.On("RemoveMember", ctx, communityID, memberID).Return(true, nil).Once()
+		svc := newTestService(repo, now)
+
+		assert.NoError(t, svc.RemoveMember(ctx, communityID, moderatorID, memberID))
+		assert.ErrorIs(t, svc.RemoveMember(ctx, communityID, moderatorID, ownerID), communitiesErrors.ErrPermissionDenied)
+		assert.ErrorIs(t, svc.RemoveMember(ctx, communityID, memberID, moderatorID), communitiesErrors.ErrPermissionDenied)
+	})
+
+	t.Run("only owners and moderators edit the community", func(t *testing.T) {
+		repo := withMembers()
+		repo.On("Get", ctx, communityID).Return(&models.Community{ObjectId: communityID, Name: "Cooks"}, nil)
+		repo.On("Update", ctx, mock.Anything).Return(nil).Once()
+		svc := newTestService(repo, now)
+		topic := "baking"
+
+		allow := true
+
+		community, err := svc.Update(ctx, communityID, moderatorID, &models.UpdateCommunityRequest{Topic: &topic, AllowAnonymous: &allow})
+		require.NoError(t, err)
+		assert.Equal(t, "baking", community.Topic)
+		assert.Equal(t, "Cooks", community.Name)
+		assert.True(t, community.AllowAnonymous)
+
+		_, err = svc.Update(ctx, communityID, memberID, &models.UpdateCommunityRequest{Topic: &topic})
+		assert.ErrorIs(t, err, communitiesErrors.ErrPermissionDenied)
+	})
+}
+
+func TestDiscover(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	repo.On("List", ctx, models.DiscoveryFilter{Topic: "cooking", Sort: models.SortPopular, Limit: 20}).
+		Return(nil, nil).Once()
+	svc := newTestService(repo, time.Now())
+
+	communities, err := svc.Discover(ctx, models.DiscoveryFilter{Topic: " cooking ", Limit: 500, Offset: -1})
+	require.NoError(t, err)
+	assert.Empty(t, communities)
+	assert.NotNil(t, communities)
+
+	_, err = svc.Discover(ctx, models.DiscoveryFilter{Sort: "hot"})
+	assert.ErrorIs(t, err, communitiesErrors.ErrInvalidRequest)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/communities/repository"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	maxStarterLength    = 2000
+	maxStarterStyle     = 50
+	maxStarterHours     = 720 // 30 days
+	maxStarterMinPosts  = 1000
+	maxStarterDraftsRun = 10
+)
+
+// StarterGenerator writes conversation starters; the AI engine client implements it
+type StarterGenerator interface {
+	GenerateConversationStarters(ctx context.Context, topic, style string) ([]string, error)
+}
+
+func (s *service) SetStarterGenerator(generator StarterGenerator) {
+	s.starters = generator
+}
+
+func (s *service) SetCommunityPoster(poster sharedInterfaces.CommunityPoster) {
+	s.poster = poster
+}
+
+func (s *service) GetStarterSettings(ctx context.Context, communityID, userID uuid.UUID) (*models.StarterSettings, error) {
+	if _, err := s.checkModerator(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+	return s.starterSettings(ctx, communityID)
+}
+
+func (s *service) UpdateStarterSettings(ctx context.Context, communityID, userID uuid.UUID, req *models.UpdateStarterSettingsRequest) (*models.StarterSettings, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", communitiesErrors.ErrInvalidRequest)
+	}
+	community, err := s.checkModerator(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if community.Archived() {
+		return nil, communitiesErrors.ErrCommunityArchived
+	}
+	settings, err := s.starterSettings(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyStarterSettings(settings, req); err != nil {
+		return nil, err
+	}
+	settings.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.SaveStarterSettings(ctx, settings); err != nil {
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	return settings, nil
+}
+
+// applyStarterSettings sets the fields that are given and checks them all
+func applyStarterSettings(settings *models.StarterSettings, req *models.UpdateStarterSettingsRequest) error {
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+	if req.Style != nil {
+		settings.Style = strings.TrimSpace(*req.Style)
+	}
+	if req.QuietHours != nil {
+		settings.QuietHours = *req.QuietHours
+	}
+	if req.MinPosts != nil {
+		settings.MinPosts = *req.MinPosts
+	}
+	if req.DraftsPerRun != nil {
+		settings.DraftsPerRun = *req.DraftsPerRun
+	}
+	if req.IntervalHours != nil {
+		settings.IntervalHours = *req.IntervalHours
+	}
+	if settings.Style == "" || utf8.RuneCountInString(settings.Style) > maxStarterStyle {
+		return fmt.Errorf("%w: style is 1 to %d characters", communitiesErrors.ErrInvalidRequest, maxStarterStyle)
+	}
+	if settings.QuietHours < 1 || settings.QuietHours > maxStarterHours {
+		return fmt.Errorf("%w: quietHours is 1 to %d", communitiesErrors.ErrInvalidRequest, maxStarterHours)
+	}
+	if settings.IntervalHours < 1 || settings.IntervalHours > maxStarterHours {
+		return fmt.Errorf("%w: intervalHours is 1 to %d", communitiesErrors.ErrInvalidRequest, maxStarterHours)
+	}
+	if settings.MinPosts < 1 || settings.MinPosts > maxStarterMinPosts {
+		return fmt.Errorf("%w: minPosts is 1 to %d", communitiesErrors.ErrInvalidRequest, maxStarterMinPosts)
+	}
+	if settings.DraftsPerRun < 1 || settings.DraftsPerRun > maxStarterDraftsRun {
+		return fmt.Errorf("%w: draftsPerRun is 1 to %d", communitiesErrors.ErrInvalidRequest, maxStarterDraftsRun)
+	}
+	return nil
+}
+
+// starterSettings returns the saved settings, or the defaults when there are none
+func (s *service) starterSettings(ctx context.Context, communityID uuid.UUID) (*models.StarterSettings, error) {
+	settings, err := s.repo.GetStarterSettings(ctx, communityID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return models.DefaultStarterSettings(communityID), nil
+		}
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	return settings, nil
+}
+
+func (s *service) ListStarterDrafts(ctx context.Context, communityID, userID uuid.UUID, status string, limit, offset int) ([]*models.StarterDraft, error) {
+	if status != "" && !models.IsValidStarterStatus(status) {
+		return nil, fmt.Errorf("%w: unknown status %q", communitiesErrors.ErrInvalidRequest, status)
+	}
+	if _, err := s.checkModerator(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+	limit, offset = normalizePage(limit, offset)
+	drafts, err := s.repo.ListStarterDrafts(ctx, communityID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	if drafts == nil {
+		drafts = []*models.StarterDraft{}
+	}
+	return drafts, nil
+}
+
+func (s *service) ApproveStarter(ctx context.Context, communityID, draftID uuid.UUID, reviewer sharedInterfaces.CommunityPostAuthor, req *models.ApproveStarterRequest) (*models.StarterDraft, error) {
+	if s.poster == nil {
+		return nil, communitiesErrors.ErrStartersDisabled
+	}
+	community, err := s.checkModerator(ctx, communityID, reviewer.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if community.Archived() {
+		return nil, communitiesErrors.ErrCommunityArchived
+	}
+	draft, err := s.pendingStarter(ctx, communityID, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if req != nil && req.Body != nil {
+		draft.Body = strings.TrimSpace(*req.Body)
+		if draft.Body == "" || utf8.RuneCountInString(draft.Body) > maxStarterLength {
+			return nil, fmt.Errorf("%w: starters are 1 to %d characters", communitiesErrors.ErrInvalidRequest, maxStarterLength)
+		}
+	}
+	if err := s.review(ctx, draft, models.StarterApproved, reviewer.UserID); err != nil {
+		return nil, err
+	}
+
+	// The draft is claimed as approved first so a second reviewer cannot publish it too;
+	// it goes back in the queue if the post cannot be made
+	postID, err := s.poster.PublishCommunityPost(ctx, communityID, draft.Body, reviewer)
+	if err != nil {
+		if reopenErr := s.repo.ReopenStarterDraft(ctx, draft.ObjectId); reopenErr != nil {
+			log.Warn("Conversation starter %s stays approved without a post: %v", draft.ObjectId, reopenErr)
+		}
+		return nil, fmt.Errorf("publish conversation starter: %w", err)
+	}
+	if err := s.repo.SetStarterPost(ctx, draft.ObjectId, postID); err != nil {
+		log.Warn("Conversation starter %s was published as post %s but not linked to it: %v", draft.ObjectId, postID, err)
+	}
+	draft.PostId = &postID
+	return draft, nil
+}
+
+func (s *service) RejectStarter(ctx context.Context, communityID, draftID, userID uuid.UUID) (*models.StarterDraft, error) {
+	if _, err := s.checkModerator(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+	draft, err := s.pendingStarter(ctx, communityID, draftID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.review(ctx, draft, models.StarterRejected, userID); err != nil {
+		return nil, err
+	}
+	return draft, nil
+}
+
+// pendingStarter returns a draft of the community that still awaits review
+func (s *service) pendingStarter(ctx context.Context, communityID, draftID uuid.UUID) (*models.StarterDraft, error) {
+	draft, err := s.repo.GetStarterDraft(ctx, communityID, draftID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, communitiesErrors.ErrStarterNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	if draft.Status != models.StarterPending {
+		return nil, communitiesErrors.ErrStarterReviewed
+	}
+	return draft, nil
+}
+
+func (s *service) review(ctx context.Context, draft *models.StarterDraft, status string, reviewerID uuid.UUID) error {
+	draft.Status = status
+	draft.ReviewedBy = &reviewerID
+	draft.ReviewedDate = s.now().UTC().UnixMilli()
+	reviewed, err := s.repo.ReviewStarterDraft(ctx, draft)
+	if err != nil {
+		return fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+	if !reviewed {
+		return communitiesErrors.ErrStarterReviewed
+	}
+	return nil
+}
+
+// checkModerator lets only the owner and moderators of an existing community through,
+// and returns the community
+func (s *service) checkModerator(ctx context.Context, communityID, userID uuid.UUID) (*models.Community, error) {
+	community, err := s.repo.Get(ctx, communityID)
+	if err != nil {
+		return nil, s.notFound(err)
+	}
+	member, err := s.member(ctx, communityID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || member.Role == models.RoleMember {
+		return nil, communitiesErrors.ErrPermissionDenied
+	}
+	return community, nil
+}
+
+// DraftStarters claims up to batch communities that are due a look and drafts starters
+// for the quiet ones. A community that fails is logged and left for its next interval,
+// so one bad community never stalls the rest.
+func (s *service) DraftStarters(ctx context.Context, batch, maxPending int) (int, error) {
+	if s.starters == nil || s.poster == nil {
+		return 0, communitiesErrors.ErrStartersDisabled
+	}
+	now := s.now().UTC()
+	runs, err := s.repo.ClaimStarterRuns(ctx, now.UnixMilli(), batch, maxPending)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", communitiesErrors.ErrDatabaseOperation, err)
+	}
+
+	drafted := 0
+	for _, run := range runs {
+		count, err := s.draftStarters(ctx, run, now)
+		if err != nil {
+			log.Warn("Conversation starters for community %s failed: %v", run.CommunityId, err)
+			continue
+		}
+		drafted += count
+	}
+	return drafted, nil
+}
+
+// draftStarters queues starters for a community with fewer than MinPosts posts in its
+// quiet window, generated from its topic, or its name when it has none
+func (s *service) draftStarters(ctx context.Context, run *models.StarterRun, now time.Time) (int, error) {
+	posts, err := s.poster.CountCommunityPosts(ctx, run.CommunityId, now.Add(-time.Duration(run.QuietHours)*time.Hour))
+	if err != nil {
+		return 0, fmt.Errorf("count posts: %w", err)
+	}
+	if posts >= int64(run.MinPosts) {
+		return 0, nil
+	}
+
+	topic := run.Topic
+	if topic == "" {
+		topic = run.Name
+	}
+	generated, err := s.starters.GenerateConversationStarters(ctx, topic, run.Style)
+	if err != nil {
+		return 0, fmt.Errorf("generate: %w", err)
+	}
+
+	var drafts []*models.StarterDraft
+	for _, body := range generated {
+		body = strings.TrimSpace(body)
+		if body == "" || utf8.RuneCountInString(body) > maxStarterLength {
+			continue
+		}
+		drafts = append(drafts, &models.StarterDraft{
+			ObjectId:    uuid.Must(uuid.NewV4()),
+			CommunityId: run.CommunityId,
+			Body:        body,
+			Status:      models.StarterPending,
+			CreatedDate: now.UnixMilli(),
+		})
+		if len(drafts) == run.DraftsPerRun {
+			break
+		}
+	}
+	if len(drafts) == 0 {
+		return 0, nil
+	}
+	if err := s.repo.CreateStarterDrafts(ctx, drafts); err != nil {
+		return 0, fmt.Errorf("store drafts: %w", err)
+	}
+	return len(drafts), nil
+}
+
+// StartStarterJob runs DraftStarters every cfg.StartersInterval until ctx is done.
+// A non-positive interval disables the job.
+func StartStarterJob(ctx context.Context, svc Service, cfg platformconfig.CommunitiesConfig) {
+	if cfg.StartersInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(cfg.StartersInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				drafted, err := svc.DraftStarters(ctx, cfg.StartersBatch, cfg.StartersMaxPending)
+				if err != nil {
+					log.Error("Conversation starter job failed: %v", err)
+					continue
+				}
+				if drafted > 0 {
+					log.Info("Drafted %d conversation starters", drafted)
+				}
+			}
+		}
+	}()
+}
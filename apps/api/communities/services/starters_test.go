@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	communitiesErrors "github.com/qolzam/telar/apps/api/communities/errors"
+	"github.com/qolzam/telar/apps/api/communities/models"
+	"github.com/qolzam/telar/apps/api/communities/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeGenerator struct {
+	starters []string
+	err      error
+	topics   []string
+}
+
+func (g *fakeGenerator) GenerateConversationStarters(ctx context.Context, topic, style string) ([]string, error) {
+	g.topics = append(g.topics, topic)
+	return g.starters, g.err
+}
+
+type fakePoster struct {
+	posts      map[uuid.UUID]int64
+	since      time.Time
+	publishErr error
+	published  []string
+}
+
+func (p *fakePoster) CountCommunityPosts(ctx context.Context, communityID uuid.UUID, since time.Time) (int64, error) {
+	p.since = since
+	return p.posts[communityID], nil
+}
+
+func (p *fakePoster) PublishCommunityPost(ctx context.Context, communityID uuid.UUID, body string, author sharedInterfaces.CommunityPostAuthor) (uuid.UUID, error) {
+	if p.publishErr != nil {
+		return uuid.Nil, p.publishErr
+	}
+	p.published = append(p.published, body)
+	return uuid.Must(uuid.NewV4()), nil
+}
+
+func TestDraftStarters(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	quietID := uuid.Must(uuid.NewV4())
+	busyID := uuid.Must(uuid.NewV4())
+
+	run := func(communityID uuid.UUID, topic string) *models.StarterRun {
+		settings := models.DefaultStarterSettings(communityID)
+		settings.Enabled = true
+		settings.DraftsPerRun = 2
+		return &models.StarterRun{StarterSettings: *settings, Name: "Home cooks", Topic: topic}
+	}
+
+	t.Run("drafts starters for quiet communities only", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ClaimStarterRuns", ctx, now.UnixMilli(), 20, 10).Return([]*models.StarterRun{run(quietID, ""), run(busyID, "baking")}, nil).Once()
+		repo.On("CreateStarterDrafts", ctx, mock.MatchedBy(func(drafts []*models.StarterDraft) bool {
+			return len(drafts) == 2 && drafts[0].CommunityId == quietID && drafts[0].Status == models.StarterPending &&
+				drafts[0].Body == "What did you cook this week?" && drafts[1].Body == "Which knife do you reach for?"
+		})).Return(nil).Once()
+		generator := &fakeGenerator{starters: []string{" What did you cook this week? ", "", "Which knife do you reach for?", "A third"}}
+		poster := &fakePoster{posts: map[uuid.UUID]int64{quietID: 2, busyID: 3}}
+
+		svc := newTestService(repo, now)
+		svc.SetStarterGenerator(generator)
+		svc.SetCommunityPoster(poster)
+		drafted, err := svc.DraftStarters(ctx, 20, 10)
+		require.NoError(t, err)
+		assert.Equal(t, 2, drafted)
+		// The community without a topic is described by its name
+		assert.Equal(t, []string{"Home cooks"}, generator.topics)
+		assert.Equal(t, now.Add(-72*time.Hour), poster.since)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("carries on past a community that fails", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ClaimStarterRuns", ctx, now.UnixMilli(), 20, 10).Return([]*models.StarterRun{run(quietID, "cooking")}, nil).Once()
+
+		svc := newTestService(repo, now)
+		svc.SetStarterGenerator(&fakeGenerator{err: errors.New("engine down")})
+		svc.SetCommunityPoster(&fakePoster{})
+		drafted, err := svc.DraftStarters(ctx, 20, 10)
+		require.NoError(t, err)
+		assert.Zero(t, drafted)
+		repo.AssertNotCalled(t, "CreateStarterDrafts", mock.Anything, mock.Anything)
+	})
+
+	t.Run("needs a generator", func(t *testing.T) {
+		_, err := newTestService(new(MockRepository), now).DraftStarters(ctx, 20, 10)
+		assert.ErrorIs(t, err, communitiesErrors.ErrStartersDisabled)
+	})
+}
+
+func TestStarterSettings(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	communityID := uuid.Must(uuid.NewV4())
+	moderatorID := uuid.Must(uuid.NewV4())
+	memberID := uuid.Must(uuid.NewV4())
+
+	setup := func() *MockRepository {
+		repo := new(MockRepository)
+		repo.On("Get", ctx, communityID).Return(&models.Community{ObjectId: communityID}, nil)
+		repo.On("GetMember", ctx, communityID, moderatorID).Return(&models.Member{Role: models.RoleModerator}, nil)
+		repo.On("GetMember", ctx, communityID, memberID).Return(&models.Member{Role: models.RoleMember}, nil)
+		return repo
+	}
+
+	t.Run("returns the defaults until settings are saved", func(t *testing.T) {
+		repo := setup()
+		repo.On("GetStarterSettings", ctx, communityID).Return(nil, repository.ErrNotFound).Once()
+
+		settings, err := newTestService(repo, now).GetStarterSettings(ctx, communityID, moderatorID)
+		require.NoError(t, err)
+		assert.Equal(t, models.DefaultStarterSettings(communityID), settings)
+	})
+
+	t.Run("saves changed settings", func(t *testing.T) {
+		repo := setup()
+		repo.On("GetStarterSettings", ctx, communityID).Return(nil, repository.ErrNotFound).Once()
+		repo.On("SaveStarterSettings", ctx, mock.Anything).Return(nil).Once()
+
+		enabled, style := true, " playful "
+		settings, err := newTestService(repo, now).UpdateStarterSettings(ctx, communityID, moderatorID, &models.UpdateStarterSettingsRequest{
+			Enabled: &enabled, Style: &style,
+		})
+		require.NoError(t, err)
+		assert.True(t, settings.Enabled)
+		assert.Equal(t, "playful", settings.Style)
+		assert.Equal(t, now.UnixMilli(), settings.LastUpdated)
+	})
+
+	t.Run("rejects out of range settings", func(t *testing.T) {
+		repo := setup()
+		repo.On("GetStarterSettings", ctx, communityID).Return(nil, repository.ErrNotFound)
+
+		svc := newTestService(repo, now)
+		zero, many := 0, 11
+		for _, req := range []*models.UpdateStarterSettingsRequest{{QuietHours: &zero}, {DraftsPerRun: &many}} {
+			_, err := svc.UpdateStarterSettings(ctx, communityID, moderatorID, req)
+			assert.ErrorIs(t, err, communitiesErrors.ErrInvalidRequest)
+		}
+		repo.AssertNotCalled(t, "SaveStarterSettings", mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps plain members out", func(t *testing.T) {
+		_, err := newTestService(setup(), now).GetStarterSettings(ctx, communityID, memberID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrPermissionDenied)
+	})
+}
+
+func TestReviewStarters(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	communityID := uuid.Must(uuid.NewV4())
+	draftID := uuid.Must(uuid.NewV4())
+	moderatorID := uuid.Must(uuid.NewV4())
+	reviewer := sharedInterfaces.CommunityPostAuthor{UserID: moderatorID, DisplayName: "Mod"}
+
+	setup := func(status string) *MockRepository {
+		repo := new(MockRepository)
+		repo.On("Get", ctx, communityID).Return(&models.Community{ObjectId: communityID}, nil)
+		repo.On("GetMember", ctx, communityID, moderatorID).Return(&models.Member{Role: models.RoleOwner}, nil)
+		repo.On("GetStarterDraft", ctx, communityID, draftID).Return(&models.StarterDraft{
+			ObjectId: draftID, CommunityId: communityID, Body: "What did you cook?", Status: status,
+		}, nil)
+		return repo
+	}
+
+	t.Run("approving publishes the edited starter", func(t *testing.T) {
+		repo := setup(models.StarterPending)
+		repo.On("ReviewStarterDraft", ctx, mock.Anything).Return(true, nil).Once()
+		repo.On("SetStarterPost", ctx, draftID, mock.Anything).Return(nil).Once()
+		poster := &fakePoster{}
+
+		svc := newTestService(repo, now)
+		svc.SetCommunityPoster(poster)
+		body := "What did you cook this week?"
+		draft, err := svc.ApproveStarter(ctx, communityID, draftID, reviewer, &models.ApproveStarterRequest{Body: &body})
+		require.NoError(t, err)
+		assert.Equal(t, models.StarterApproved, draft.Status)
+		assert.Equal(t, moderatorID, *draft.ReviewedBy)
+		assert.NotNil(t, draft.PostId)
+		assert.Equal(t, []string{body}, poster.published)
+	})
+
+	t.Run("a starter that cannot be published goes back in the queue", func(t *testing.T) {
+		repo := setup(models.StarterPending)
+		repo.On("ReviewStarterDraft", ctx, mock.Anything).Return(true, nil).Once()
+		repo.On("ReopenStarterDraft", ctx, draftID).Return(nil).Once()
+
+		svc := newTestService(repo, now)
+		svc.SetCommunityPoster(&fakePoster{publishErr: errors.New("posts unavailable")})
+		_, err := svc.ApproveStarter(ctx, communityID, draftID, reviewer, nil)
+		require.Error(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("a reviewed starter cannot be reviewed again", func(t *testing.T) {
+		svc := newTestService(setup(models.StarterRejected), now)
+		svc.SetCommunityPoster(&fakePoster{})
+		_, err := svc.ApproveStarter(ctx, communityID, draftID, reviewer, nil)
+		assert.ErrorIs(t, err, communitiesErrors.ErrStarterReviewed)
+	})
+
+	t.Run("a concurrent review wins", func(t *testing.T) {
+		repo := setup(models.StarterPending)
+		repo.On("ReviewStarterDraft", ctx, mock.Anything).Return(false, nil).Once()
+
+		_, err := newTestService(repo, now).RejectStarter(ctx, communityID, draftID, moderatorID)
+		assert.ErrorIs(t, err, communitiesErrors.ErrStarterReviewed)
+	})
+}
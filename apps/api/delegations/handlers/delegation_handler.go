@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/delegations/errors"
+	"github.com/qolzam/telar/apps/api/delegations/models"
+	"github.com/qolzam/telar/apps/api/delegations/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type DelegationHandler struct {
+	service services.Service
+}
+
+func NewDelegationHandler(service services.Service) *DelegationHandler {
+	return &DelegationHandler{service: service}
+}
+
+// Create offers another user posting rights on the current user's account.
+// Endpoint: POST /delegations
+func (h *DelegationHandler) Create(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.CreateGrantRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	grant, err := h.service.CreateGrant(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(grant)
+}
+
+// Granted lists the grants the current user has given.
+// Endpoint: GET /delegations/granted
+func (h *DelegationHandler) Granted(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	grants, err := h.service.ListGranted(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"grants": grants})
+}
+
+// Received lists the grants offered to the current user.
+// Endpoint: GET /delegations/received
+func (h *DelegationHandler) Received(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	grants, err := h.service.ListReceived(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"grants": grants})
+}
+
+// Accept activates a pending grant offered to the current user.
+// Endpoint: POST /delegations/:grantId/accept
+func (h *DelegationHandler) Accept(c *fiber.Ctx) error {
+	return h.respond(c, h.service.AcceptGrant)
+}
+
+// Decline refuses a pending grant offered to the current user.
+// Endpoint: POST /delegations/:grantId/decline
+func (h *DelegationHandler) Decline(c *fiber.Ctx) error {
+	return h.respond(c, h.service.DeclineGrant)
+}
+
+func (h *DelegationHandler) respond(c *fiber.Ctx, answer func(ctx context.Context, delegateID, grantID uuid.UUID) (*models.Grant, error)) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	grantID, err := uuid.FromString(c.Params("grantId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid grantId")
+	}
+
+	grant, err := answer(c.Context(), user.UserID, grantID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(grant)
+}
+
+// Revoke ends a grant; the owner withdraws it or the delegate gives it up.
+// Endpoint: DELETE /delegations/:grantId
+func (h *DelegationHandler) Revoke(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	grantID, err := uuid.FromString(c.Params("grantId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid grantId")
+	}
+
+	if err := h.service.RevokeGrant(c.Context(), user.UserID, grantID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// Audit lists actions delegates took as the current user, attributed to each delegate.
+// Endpoint: GET /delegations/audit?limit=50
+func (h *DelegationHandler) Audit(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return errors.HandleValidationError(c, "limit must be a positive integer")
+		}
+		limit = parsed
+	}
+
+	entries, err := h.service.ListAudit(c.Context(), user.UserID, limit)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"entries": entries})
+}
@@ -0,0 +1,32 @@
+-- Delegation grants let one account act as another within the granted scopes
+-- (e.g. a social media manager posting as a brand account). A grant starts pending
+-- and takes effect once the delegate accepts it.
+CREATE TABLE IF NOT EXISTS delegation_grants (
+    id UUID PRIMARY KEY,
+    owner_user_id UUID NOT NULL,
+    delegate_user_id UUID NOT NULL,
+    scopes TEXT[] NOT NULL DEFAULT '{}',
+    status TEXT NOT NULL DEFAULT 'pending',
+    created_date BIGINT NOT NULL,
+    responded_date BIGINT NOT NULL DEFAULT 0,
+    last_updated BIGINT NOT NULL,
+
+    CHECK (owner_user_id <> delegate_user_id)
+);
+
+-- One grant per pair; granting again after a revoke or decline reuses the row
+CREATE UNIQUE INDEX IF NOT EXISTS idx_delegation_grants_pair ON delegation_grants(owner_user_id, delegate_user_id);
+CREATE INDEX IF NOT EXISTS idx_delegation_grants_delegate ON delegation_grants(delegate_user_id);
+
+-- Actions taken under a grant, attributed to the delegate who actually performed them
+CREATE TABLE IF NOT EXISTS delegation_audit (
+    id UUID PRIMARY KEY,
+    grant_id UUID NOT NULL,
+    owner_user_id UUID NOT NULL,
+    delegate_user_id UUID NOT NULL,
+    scope TEXT NOT NULL,
+    target_id UUID,
+    created_date BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_delegation_audit_owner ON delegation_audit(owner_user_id, created_date DESC);
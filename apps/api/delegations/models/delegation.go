@@ -0,0 +1,70 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Status is the lifecycle state of a delegation grant
+type Status string
+
+const (
+	// StatusPending grants wait for the delegate to accept them and confer nothing yet
+	StatusPending Status = "pending"
+	// StatusActive grants let the delegate act within their scopes
+	StatusActive Status = "active"
+	// StatusDeclined grants were refused by the delegate
+	StatusDeclined Status = "declined"
+	// StatusRevoked grants were withdrawn by the owner or given up by the delegate
+	StatusRevoked Status = "revoked"
+)
+
+// Live reports whether the grant is pending or active, i.e. not yet ended
+func (s Status) Live() bool {
+	return s == StatusPending || s == StatusActive
+}
+
+// ScopePostCreate lets the delegate publish posts as the owner
+const ScopePostCreate = sharedInterfaces.DelegationScopePostCreate
+
+// KnownScopes lists the scopes a grant may carry
+var KnownScopes = []string{ScopePostCreate}
+
+// Grant lets DelegateUserId act as OwnerUserId within Scopes
+type Grant struct {
+	ObjectId       uuid.UUID `json:"objectId"`
+	OwnerUserId    uuid.UUID `json:"ownerUserId"`
+	DelegateUserId uuid.UUID `json:"delegateUserId"`
+	Scopes         []string  `json:"scopes"`
+	Status         Status    `json:"status"`
+	CreatedDate    int64     `json:"createdDate"`
+	RespondedDate  int64     `json:"respondedDate,omitempty"`
+	LastUpdated    int64     `json:"lastUpdated"`
+}
+
+// HasScope reports whether the grant carries scope
+func (g *Grant) HasScope(scope string) bool {
+	for _, s := range g.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// CreateGrantRequest is the body of POST /delegations
+type CreateGrantRequest struct {
+	DelegateUserId uuid.UUID `json:"delegateUserId"`
+	Scopes         []string  `json:"scopes"`
+}
+
+// AuditEntry records an action a delegate took as the owner
+type AuditEntry struct {
+	ObjectId       uuid.UUID  `json:"objectId"`
+	GrantId        uuid.UUID  `json:"grantId"`
+	OwnerUserId    uuid.UUID  `json:"ownerUserId"`
+	DelegateUserId uuid.UUID  `json:"delegateUserId"`
+	Scope          string     `json:"scope"`
+	TargetId       *uuid.UUID `json:"targetId,omitempty"`
+	CreatedDate    int64      `json:"createdDate"`
+}
@@ -0,0 +1,229 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/delegations/models"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// grantRow mirrors the delegation_grants table
+type grantRow struct {
+	ID             uuid.UUID      `db:"id"`
+	OwnerUserID    uuid.UUID      `db:"owner_user_id"`
+	DelegateUserID uuid.UUID      `db:"delegate_user_id"`
+	Scopes         pq.StringArray `db:"scopes"`
+	Status         string         `db:"status"`
+	CreatedDate    int64          `db:"created_date"`
+	RespondedDate  int64          `db:"responded_date"`
+	LastUpdated    int64          `db:"last_updated"`
+}
+
+// auditRow mirrors the delegation_audit table
+type auditRow struct {
+	ID             uuid.UUID     `db:"id"`
+	GrantID        uuid.UUID     `db:"grant_id"`
+	OwnerUserID    uuid.UUID     `db:"owner_user_id"`
+	DelegateUserID uuid.UUID     `db:"delegate_user_id"`
+	Scope          string        `db:"scope"`
+	TargetID       uuid.NullUUID `db:"target_id"`
+	CreatedDate    int64         `db:"created_date"`
+}
+
+const grantColumns = `id, owner_user_id, delegate_user_id, scopes, status, created_date, responded_date, last_updated`
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) SaveGrant(ctx context.Context, grant *models.Grant) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %sdelegation_grants (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (owner_user_id, delegate_user_id) DO UPDATE
+		SET id = EXCLUDED.id, scopes = EXCLUDED.scopes, status = EXCLUDED.status,
+			created_date = EXCLUDED.created_date, responded_date = EXCLUDED.responded_date,
+			last_updated = EXCLUDED.last_updated
+	`, r.schemaPrefix(), grantColumns)
+
+	_, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		grant.ObjectId, grant.OwnerUserId, grant.DelegateUserId, pq.StringArray(grant.Scopes),
+		string(grant.Status), grant.CreatedDate, grant.RespondedDate, grant.LastUpdated)
+	if err != nil {
+		return fmt.Errorf("save delegation grant: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetGrant(ctx context.Context, grantID uuid.UUID) (*models.Grant, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %sdelegation_grants WHERE id = $1`, grantColumns, r.schemaPrefix())
+	return r.getOne(ctx, query, grantID)
+}
+
+func (r *postgresRepository) GetGrantByPair(ctx context.Context, ownerID, delegateID uuid.UUID) (*models.Grant, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %sdelegation_grants WHERE owner_user_id = $1 AND delegate_user_id = $2`, grantColumns, r.schemaPrefix())
+	return r.getOne(ctx, query, ownerID, delegateID)
+}
+
+func (r *postgresRepository) getOne(ctx context.Context, query string, args ...interface{}) (*models.Grant, error) {
+	var row grantRow
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get delegation grant: %w", err)
+	}
+	return row.toModel(), nil
+}
+
+func (r *postgresRepository) UpdateStatus(ctx context.Context, grantID uuid.UUID, status models.Status, respondedDate, lastUpdated int64) error {
+	query := fmt.Sprintf(`
+		UPDATE %sdelegation_grants
+		SET status = $2, responded_date = $3, last_updated = $4
+		WHERE id = $1
+	`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, grantID, string(status), respondedDate, lastUpdated)
+	if err != nil {
+		return fmt.Errorf("update delegation grant: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*models.Grant, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %sdelegation_grants WHERE owner_user_id = $1 ORDER BY created_date DESC`, grantColumns, r.schemaPrefix())
+	return r.list(ctx, query, ownerID)
+}
+
+func (r *postgresRepository) ListByDelegate(ctx context.Context, delegateID uuid.UUID) ([]*models.Grant, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %sdelegation_grants WHERE delegate_user_id = $1 ORDER BY created_date DESC`, grantColumns, r.schemaPrefix())
+	return r.list(ctx, query, delegateID)
+}
+
+func (r *postgresRepository) list(ctx context.Context, query string, args ...interface{}) ([]*models.Grant, error) {
+	var rows []grantRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("list delegation grants: %w", err)
+	}
+	grants := make([]*models.Grant, 0, len(rows))
+	for i := range rows {
+		grants = append(grants, rows[i].toModel())
+	}
+	return grants, nil
+}
+
+func (r *postgresRepository) AddAudit(ctx context.Context, entry *models.AuditEntry) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %sdelegation_audit (id, grant_id, owner_user_id, delegate_user_id, scope, target_id, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, r.schemaPrefix())
+
+	target := uuid.NullUUID{}
+	if entry.TargetId != nil {
+		target = uuid.NullUUID{UUID: *entry.TargetId, Valid: true}
+	}
+	_, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		entry.ObjectId, entry.GrantId, entry.OwnerUserId, entry.DelegateUserId, entry.Scope, target, entry.CreatedDate)
+	if err != nil {
+		return fmt.Errorf("insert delegation audit: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) ListAudit(ctx context.Context, ownerID uuid.UUID, limit int) ([]*models.AuditEntry, error) {
+	query := fmt.Sprintf(`
+		SELECT id, grant_id, owner_user_id, delegate_user_id, scope, target_id, created_date
+		FROM %sdelegation_audit
+		WHERE owner_user_id = $1
+		ORDER BY created_date DESC
+		LIMIT $2
+	`, r.schemaPrefix())
+
+	var rows []auditRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, ownerID, limit); err != nil {
+		return nil, fmt.Errorf("list delegation audit: %w", err)
+	}
+	entries := make([]*models.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := &models.AuditEntry{
+			ObjectId:       row.ID,
+			GrantId:        row.GrantID,
+			OwnerUserId:    row.OwnerUserID,
+			DelegateUserId: row.DelegateUserID,
+			Scope:          row.Scope,
+			CreatedDate:    row.CreatedDate,
+		}
+		if row.TargetID.Valid {
+			target := row.TargetID.UUID
+			entry.TargetId = &target
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	queries := []string{
+		`DELETE FROM %sdelegation_grants WHERE owner_user_id = $1 OR delegate_user_id = $1`,
+		`DELETE FROM %sdelegation_audit WHERE owner_user_id = $1`,
+	}
+	for _, query := range queries {
+		if _, err := r.getExecutor(ctx).ExecContext(ctx, fmt.Sprintf(query, r.schemaPrefix()), userID); err != nil {
+			return fmt.Errorf("delete delegations of user: %w", err)
+		}
+	}
+	return nil
+}
+
+func (row *grantRow) toModel() *models.Grant {
+	return &models.Grant{
+		ObjectId:       row.ID,
+		OwnerUserId:    row.OwnerUserID,
+		DelegateUserId: row.DelegateUserID,
+		Scopes:         []string(row.Scopes),
+		Status:         models.Status(row.Status),
+		CreatedDate:    row.CreatedDate,
+		RespondedDate:  row.RespondedDate,
+		LastUpdated:    row.LastUpdated,
+	}
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
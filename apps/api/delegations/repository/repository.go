@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/delegations/models"
+)
+
+// ErrNotFound is returned when no grant matches
+var ErrNotFound = errors.New("delegation grant not found")
+
+// Repository defines data access for delegation grants and their audit trail.
+type Repository interface {
+	// SaveGrant stores a grant, replacing any earlier grant between the same owner and delegate.
+	SaveGrant(ctx context.Context, grant *models.Grant) error
+
+	// GetGrant returns a grant by ID or ErrNotFound.
+	GetGrant(ctx context.Context, grantID uuid.UUID) (*models.Grant, error)
+
+	// GetGrantByPair returns the grant from owner to delegate or ErrNotFound.
+	GetGrantByPair(ctx context.Context, ownerID, delegateID uuid.UUID) (*models.Grant, error)
+
+	// UpdateStatus changes a grant's status; returns ErrNotFound when the grant does not exist.
+	UpdateStatus(ctx context.Context, grantID uuid.UUID, status models.Status, respondedDate, lastUpdated int64) error
+
+	// ListByOwner returns the grants an owner has given, newest first.
+	ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*models.Grant, error)
+
+	// ListByDelegate returns the grants a delegate has received, newest first.
+	ListByDelegate(ctx context.Context, delegateID uuid.UUID) ([]*models.Grant, error)
+
+	// AddAudit stores an audit entry.
+	AddAudit(ctx context.Context, entry *models.AuditEntry) error
+
+	// ListAudit returns the newest audit entries for actions taken as the owner.
+	ListAudit(ctx context.Context, ownerID uuid.UUID, limit int) ([]*models.AuditEntry, error)
+
+	// DeleteByUser deletes the grants userID gave or received and the audit trail of
+	// actions taken as userID.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
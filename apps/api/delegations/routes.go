@@ -0,0 +1,41 @@
+package delegations
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/delegations/handlers"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+type Handlers struct {
+	DelegationHandler *handlers.DelegationHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires delegation grant, acceptance and audit endpoints.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/delegations", createDualAuthMiddleware(routerCfg))
+	group.Post("/", handlers.DelegationHandler.Create)
+	group.Get("/granted", handlers.DelegationHandler.Granted)
+	group.Get("/received", handlers.DelegationHandler.Received)
+	group.Get("/audit", handlers.DelegationHandler.Audit)
+	group.Post("/:grantId/accept", handlers.DelegationHandler.Accept)
+	group.Post("/:grantId/decline", handlers.DelegationHandler.Decline)
+	group.Delete("/:grantId", handlers.DelegationHandler.Revoke)
+}
@@ -0,0 +1,77 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/delegations/models"
+	"github.com/qolzam/telar/apps/api/delegations/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the delegations repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) SaveGrant(ctx context.Context, grant *models.Grant) error {
+	args := m.Called(ctx, grant)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetGrant(ctx context.Context, grantID uuid.UUID) (*models.Grant, error) {
+	args := m.Called(ctx, grantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Grant), args.Error(1)
+}
+
+func (m *MockRepository) GetGrantByPair(ctx context.Context, ownerID, delegateID uuid.UUID) (*models.Grant, error) {
+	args := m.Called(ctx, ownerID, delegateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Grant), args.Error(1)
+}
+
+func (m *MockRepository) UpdateStatus(ctx context.Context, grantID uuid.UUID, status models.Status, respondedDate, lastUpdated int64) error {
+	args := m.Called(ctx, grantID, status, respondedDate, lastUpdated)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListByOwner(ctx context.Context, ownerID uuid.UUID) ([]*models.Grant, error) {
+	args := m.Called(ctx, ownerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Grant), args.Error(1)
+}
+
+func (m *MockRepository) ListByDelegate(ctx context.Context, delegateID uuid.UUID) ([]*models.Grant, error) {
+	args := m.Called(ctx, delegateID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Grant), args.Error(1)
+}
+
+func (m *MockRepository) AddAudit(ctx context.Context, entry *models.AuditEntry) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListAudit(ctx context.Context, ownerID uuid.UUID, limit int) ([]*models.AuditEntry, error) {
+	args := m.Called(ctx, ownerID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.AuditEntry), args.Error(1)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
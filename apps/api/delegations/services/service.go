@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	delegationErrors "github.com/qolzam/telar/apps/api/delegations/errors"
+	"github.com/qolzam/telar/apps/api/delegations/models"
+	"github.com/qolzam/telar/apps/api/delegations/repository"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	defaultAuditLimit = 50
+	maxAuditLimit     = 200
+)
+
+// ProfileLookup resolves the profile a delegate publishes as; the profile service satisfies it.
+type ProfileLookup interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*profileModels.Profile, error)
+}
+
+// Service defines delegation operations.
+type Service interface {
+	// CreateGrant offers delegateID the given scopes on the owner's account; the grant is
+	// pending until the delegate accepts it.
+	CreateGrant(ctx context.Context, ownerID uuid.UUID, req *models.CreateGrantRequest) (*models.Grant, error)
+
+	// ListGranted returns the grants the owner has given, newest first.
+	ListGranted(ctx context.Context, ownerID uuid.UUID) ([]*models.Grant, error)
+
+	// ListReceived returns the grants the delegate has received, newest first.
+	ListReceived(ctx context.Context, delegateID uuid.UUID) ([]*models.Grant, error)
+
+	// AcceptGrant activates a pending grant addressed to the delegate.
+	AcceptGrant(ctx context.Context, delegateID, grantID uuid.UUID) (*models.Grant, error)
+
+	// DeclineGrant refuses a pending grant addressed to the delegate.
+	DeclineGrant(ctx context.Context, delegateID, grantID uuid.UUID) (*models.Grant, error)
+
+	// RevokeGrant ends a grant; either the owner or the delegate may revoke it.
+	RevokeGrant(ctx context.Context, userID, grantID uuid.UUID) error
+
+	// ListAudit returns the newest actions delegates took as the owner.
+	ListAudit(ctx context.Context, ownerID uuid.UUID, limit int) ([]*models.AuditEntry, error)
+
+	sharedInterfaces.DelegationAuthorizer
+}
+
+type service struct {
+	repo     repository.Repository
+	profiles ProfileLookup
+	now      func() time.Time
+}
+
+// NewService constructs a delegations service. profiles may be nil, in which case
+// delegates are not checked to exist and act without the owner's display name.
+func NewService(repo repository.Repository, profiles ProfileLookup) Service {
+	return &service{repo: repo, profiles: profiles, now: time.Now}
+}
+
+func (s *service) CreateGrant(ctx context.Context, ownerID uuid.UUID, req *models.CreateGrantRequest) (*models.Grant, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", delegationErrors.ErrInvalidRequest)
+	}
+	if req.DelegateUserId == uuid.Nil {
+		return nil, fmt.Errorf("%w: delegateUserId is required", delegationErrors.ErrInvalidRequest)
+	}
+	if req.DelegateUserId == ownerID {
+		return nil, fmt.Errorf("%w: cannot delegate to yourself", delegationErrors.ErrInvalidRequest)
+	}
+	scopes, err := normalizeScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	if s.profiles != nil {
+		if profile, err := s.profiles.GetProfile(ctx, req.DelegateUserId); err != nil || profile == nil {
+			return nil, fmt.Errorf("%w: delegate user not found", delegationErrors.ErrInvalidRequest)
+		}
+	}
+
+	existing, err := s.repo.GetGrantByPair(ctx, ownerID, req.DelegateUserId)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	if existing != nil && existing.Status.Live() {
+		return nil, delegationErrors.ErrGrantExists
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("generate grant id: %w", err)
+	}
+	now := s.now().UTC().UnixMilli()
+	grant := &models.Grant{
+		ObjectId:       id,
+		OwnerUserId:    ownerID,
+		DelegateUserId: req.DelegateUserId,
+		Scopes:         scopes,
+		Status:         models.StatusPending,
+		CreatedDate:    now,
+		LastUpdated:    now,
+	}
+	if err := s.repo.SaveGrant(ctx, grant); err != nil {
+		return nil, fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	return grant, nil
+}
+
+func (s *service) ListGranted(ctx context.Context, ownerID uuid.UUID) ([]*models.Grant, error) {
+	grants, err := s.repo.ListByOwner(ctx, ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	return grants, nil
+}
+
+func (s *service) ListReceived(ctx context.Context, delegateID uuid.UUID) ([]*models.Grant, error) {
+	grants, err := s.repo.ListByDelegate(ctx, delegateID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	return grants, nil
+}
+
+func (s *service) AcceptGrant(ctx context.Context, delegateID, grantID uuid.UUID) (*models.Grant, error) {
+	return s.respond(ctx, delegateID, grantID, models.StatusActive)
+}
+
+func (s *service) DeclineGrant(ctx context.Context, delegateID, grantID uuid.UUID) (*models.Grant, error) {
+	return s.respond(ctx, delegateID, grantID, models.StatusDeclined)
+}
+
+// respond records the delegate's answer to a pending grant
+func (s *service) respond(ctx context.Context, delegateID, grantID uuid.UUID, status models.Status) (*models.Grant, error) {
+	grant, err := s.getGrant(ctx, grantID)
+	if err != nil {
+		return nil, err
+	}
+	if grant.DelegateUserId != delegateID {
+		// Grants addressed to someone else are reported as missing
+		return nil, delegationErrors.ErrGrantNotFound
+	}
+	if grant.Status != models.StatusPending {
+		return nil, fmt.Errorf("%w: grant is %s", delegationErrors.ErrInvalidRequest, grant.Status)
+	}
+
+	now := s.now().UTC().UnixMilli()
+	if err := s.repo.UpdateStatus(ctx, grantID, status, now, now); err != nil {
+		return nil, fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	grant.Status = status
+	grant.RespondedDate = now
+	grant.LastUpdated = now
+	return grant, nil
+}
+
+func (s *service) RevokeGrant(ctx context.Context, userID, grantID uuid.UUID) error {
+	grant, err := s.getGrant(ctx, grantID)
+	if err != nil {
+		return err
+	}
+	if grant.OwnerUserId != userID && grant.DelegateUserId != userID {
+		return delegationErrors.ErrGrantNotFound
+	}
+	if !grant.Status.Live() {
+		return nil
+	}
+
+	now := s.now().UTC().UnixMilli()
+	if err := s.repo.UpdateStatus(ctx, grantID, models.StatusRevoked, grant.RespondedDate, now); err != nil {
+		return fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+func (s *service) ListAudit(ctx context.Context, ownerID uuid.UUID, limit int) ([]*models.AuditEntry, error) {
+	if limit <= 0 {
+		limit = defaultAuditLimit
+	}
+	if limit > maxAuditLimit {
+		limit = maxAuditLimit
+	}
+	entries, err := s.repo.ListAudit(ctx, ownerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	return entries, nil
+}
+
+func (s *service) AuthorizeActingAs(ctx context.Context, principalID, delegateID uuid.UUID, scope string) (*sharedInterfaces.ActingIdentity, error) {
+	grant, err := s.activeGrant(ctx, principalID, delegateID, scope)
+	if err != nil || grant == nil {
+		return nil, err
+	}
+
+	identity := &sharedInterfaces.ActingIdentity{UserID: principalID, GrantID: grant.ObjectId}
+	if s.profiles != nil {
+		profile, err := s.profiles.GetProfile(ctx, principalID)
+		if err != nil {
+			log.Warn("delegations: profile of %s unavailable, acting without display fields: %v", principalID, err)
+		} else if profile != nil {
+			identity.DisplayName = profile.FullName
+			identity.SocialName = profile.SocialName
+			identity.Avatar = profile.Avatar
+		}
+	}
+	return identity, nil
+}
+
+func (s *service) RecordDelegatedAction(ctx context.Context, identity *sharedInterfaces.ActingIdentity, delegateID uuid.UUID, scope string, targetID uuid.UUID) error {
+	if identity == nil || identity.GrantID == uuid.Nil {
+		return fmt.Errorf("%w: acting identity carries no grant", delegationErrors.ErrInvalidRequest)
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Errorf("generate audit id: %w", err)
+	}
+	entry := &models.AuditEntry{
+		ObjectId:       id,
+		GrantId:        identity.GrantID,
+		OwnerUserId:    identity.UserID,
+		DelegateUserId: delegateID,
+		Scope:          scope,
+		CreatedDate:    s.now().UTC().UnixMilli(),
+	}
+	if targetID != uuid.Nil {
+		entry.TargetId = &targetID
+	}
+	if err := s.repo.AddAudit(ctx, entry); err != nil {
+		return fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// activeGrant returns the active grant from principal to delegate carrying scope, or nil
+func (s *service) activeGrant(ctx context.Context, principalID, delegateID uuid.UUID, scope string) (*models.Grant, error) {
+	grant, err := s.repo.GetGrantByPair(ctx, principalID, delegateID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	if grant.Status != models.StatusActive || !grant.HasScope(scope) {
+		return nil, nil
+	}
+	return grant, nil
+}
+
+func (s *service) getGrant(ctx context.Context, grantID uuid.UUID) (*models.Grant, error) {
+	grant, err := s.repo.GetGrant(ctx, grantID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, delegationErrors.ErrGrantNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", delegationErrors.ErrDatabaseOperation, err)
+	}
+	return grant, nil
+}
+
+// normalizeScopes rejects unknown scopes and drops duplicates
+func normalizeScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, fmt.Errorf("%w: at least one scope is required", delegationErrors.ErrInvalidRequest)
+	}
+	normalized := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if !slices.Contains(models.KnownScopes, scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q", delegationErrors.ErrInvalidRequest, scope)
+		}
+		if !slices.Contains(normalized, scope) {
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
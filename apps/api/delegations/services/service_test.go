@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	delegationErrors "github.com/qolzam/telar/apps/api/delegations/errors"
+	"github.com/qolzam/telar/apps/api/delegations/models"
+	"github.com/qolzam/telar/apps/api/delegations/repository"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProfiles map[uuid.UUID]*profileModels.Profile
+
+func (p stubProfiles) GetProfile(ctx context.Context, userID uuid.UUID) (*profileModels.Profile, error) {
+	if profile, ok := p[userID]; ok {
+		return profile, nil
+	}
+	return nil, repository.ErrNotFound
+}
+
+func TestCreateGrant(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	delegate := uuid.Must(uuid.NewV4())
+	profiles := stubProfiles{delegate: {ObjectId: delegate}}
+
+	t.Run("starts pending with deduplicated scopes", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetGrantByPair", ctx, owner, delegate).Return(nil, repository.ErrNotFound)
+		repo.On("SaveGrant", ctx, mock.AnythingOfType("*models.Grant")).Return(nil)
+
+		grant, err := NewService(repo, profiles).CreateGrant(ctx, owner, &models.CreateGrantRequest{
+			DelegateUserId: delegate,
+			Scopes:         []string{models.ScopePostCreate, models.ScopePostCreate},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusPending, grant.Status)
+		assert.Equal(t, []string{models.ScopePostCreate}, grant.Scopes)
+	})
+
+	t.Run("rejects unknown scopes and self delegation", func(t *testing.T) {
+		svc := NewService(new(MockRepository), profiles)
+
+		_, err := svc.CreateGrant(ctx, owner, &models.CreateGrantRequest{DelegateUserId: delegate, Scopes: []string{"post:delete"}})
+		assert.ErrorIs(t, err, delegationErrors.ErrInvalidRequest)
+
+		_, err = svc.CreateGrant(ctx, owner, &models.CreateGrantRequest{DelegateUserId: owner, Scopes: []string{models.ScopePostCreate}})
+		assert.ErrorIs(t, err, delegationErrors.ErrInvalidRequest)
+	})
+
+	t.Run("rejects a second live grant", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetGrantByPair", ctx, owner, delegate).Return(&models.Grant{Status: models.StatusActive}, nil)
+
+		_, err := NewService(repo, profiles).CreateGrant(ctx, owner, &models.CreateGrantRequest{DelegateUserId: delegate, Scopes: []string{models.ScopePostCreate}})
+		assert.ErrorIs(t, err, delegationErrors.ErrGrantExists)
+	})
+}
+
+func TestAcceptGrant(t *testing.T) {
+	ctx := context.Background()
+	grantID := uuid.Must(uuid.NewV4())
+	delegate := uuid.Must(uuid.NewV4())
+
+	t.Run("only the delegate can accept", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetGrant", ctx, grantID).Return(&models.Grant{ObjectId: grantID, DelegateUserId: delegate, Status: models.StatusPending}, nil)
+
+		_, err := NewService(repo, nil).AcceptGrant(ctx, uuid.Must(uuid.NewV4()), grantID)
+		assert.ErrorIs(t, err, delegationErrors.ErrGrantNotFound)
+	})
+
+	t.Run("activates a pending grant", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetGrant", ctx, grantID).Return(&models.Grant{ObjectId: grantID, DelegateUserId: delegate, Status: models.StatusPending}, nil)
+		repo.On("UpdateStatus", ctx, grantID, models.StatusActive, mock.Anything, mock.Anything).Return(nil)
+
+		grant, err := NewService(repo, nil).AcceptGrant(ctx, delegate, grantID)
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusActive, grant.Status)
+		assert.NotZero(t, grant.RespondedDate)
+	})
+}
+
+func TestAuthorizeActingAs(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	delegate := uuid.Must(uuid.NewV4())
+	profiles := stubProfiles{owner: {ObjectId: owner, FullName: "Acme", SocialName: "acme", Avatar: "acme.png"}}
+
+	t.Run("returns the owner's identity for an active grant", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetGrantByPair", ctx, owner, delegate).Return(&models.Grant{Status: models.StatusActive, Scopes: []string{models.ScopePostCreate}}, nil)
+
+		identity, err := NewService(repo, profiles).AuthorizeActingAs(ctx, owner, delegate, models.ScopePostCreate)
+		require.NoError(t, err)
+		require.NotNil(t, identity)
+		assert.Equal(t, owner, identity.UserID)
+		assert.Equal(t, "acme", identity.SocialName)
+	})
+
+	t.Run("pending grants confer nothing", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetGrantByPair", ctx, owner, delegate).Return(&models.Grant{Status: models.StatusPending, Scopes: []string{models.ScopePostCreate}}, nil)
+
+		identity, err := NewService(repo, profiles).AuthorizeActingAs(ctx, owner, delegate, models.ScopePostCreate)
+		require.NoError(t, err)
+		assert.Nil(t, identity)
+	})
+
+	t.Run("records audit entries under the authorizing grant", func(t *testing.T) {
+		grantID := uuid.Must(uuid.NewV4())
+		postID := uuid.Must(uuid.NewV4())
+		repo := new(MockRepository)
+		repo.On("GetGrantByPair", ctx, owner, delegate).Return(&models.Grant{ObjectId: grantID, Status: models.StatusActive, Scopes: []string{models.ScopePostCreate}}, nil).Once()
+		repo.On("AddAudit", ctx, mock.MatchedBy(func(entry *models.AuditEntry) bool {
+			return entry.GrantId == grantID && entry.OwnerUserId == owner && entry.DelegateUserId == delegate &&
+				entry.TargetId != nil && *entry.TargetId == postID
+		})).Return(nil)
+
+		svc := NewService(repo, profiles)
+		identity, err := svc.AuthorizeActingAs(ctx, owner, delegate, models.ScopePostCreate)
+		require.NoError(t, err)
+		// The grant is not read again, so a revocation in between cannot drop the entry
+		require.NoError(t, svc.RecordDelegatedAction(ctx, identity, delegate, models.ScopePostCreate, postID))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reports audit write failures", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("AddAudit", ctx, mock.Anything).Return(errors.New("connection reset"))
+
+		identity := &sharedInterfaces.ActingIdentity{UserID: owner, GrantID: uuid.Must(uuid.NewV4())}
+		err := NewService(repo, profiles).RecordDelegatedAction(ctx, identity, delegate, models.ScopePostCreate, uuid.Must(uuid.NewV4()))
+		assert.ErrorIs(t, err, delegationErrors.ErrDatabaseOperation)
+	})
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/deltasync/errors"
+	"github.com/qolzam/telar/apps/api/deltasync/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type SyncHandler struct {
+	service services.Service
+}
+
+func NewSyncHandler(service services.Service) *SyncHandler {
+	return &SyncHandler{service: service}
+}
+
+// Changes returns what changed for the current user after the since cursor; without
+// one, the cursor to start from.
+// Endpoint: GET /sync?since=...&limit=...
+func (h *SyncHandler) Changes(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var since *int64
+	if sinceParam := c.Query("since"); sinceParam != "" {
+		cursor, err := strconv.ParseInt(sinceParam, 10, 64)
+		if err != nil || cursor < 0 {
+			return errors.HandleValidationError(c, "since must be a cursor returned by this endpoint")
+		}
+		since = &cursor
+	}
+
+	ctxWithUser := context.WithValue(c.Context(), types.UserCtxName, user)
+	resp, err := h.service.Changes(ctxWithUser, user.UserID, since, c.QueryInt("limit", services.DefaultLimit))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
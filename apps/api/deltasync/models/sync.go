@@ -0,0 +1,42 @@
+package models
+
+import (
+	commentsModels "github.com/qolzam/telar/apps/api/comments/models"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// Record types of a change
+const (
+	TypePost     = "post"
+	TypeComment  = "comment"
+	TypeBookmark = "bookmark" // ID is the bookmarked post
+)
+
+// Actions of a change
+const (
+	ActionUpsert = "upsert" // Store the record, replacing any older copy
+	ActionDelete = "delete" // Drop the record; for a post, drop its comments too
+	ActionClear  = "clear"  // Drop every comment of PostID
+)
+
+// Change is the current state of one record that changed since the client's cursor.
+// Several changes to a record are collapsed into one, at the seq of the last.
+type Change struct {
+	Seq     int64                     `json:"seq"`
+	Type    string                    `json:"type"`
+	Action  string                    `json:"action"`
+	ID      string                    `json:"id,omitempty"`     // Empty for ActionClear
+	PostID  string                    `json:"postId,omitempty"` // Post of a comment
+	Post    *postsModels.PostResponse `json:"post,omitempty"`   // Upserted posts and bookmarks
+	Comment *commentsModels.Comment   `json:"comment,omitempty"`
+}
+
+// SyncResponse is a page of changes. Clients apply them in order, store Cursor and ask
+// again with it while HasMore is set. When Reset is set the changes since the client's
+// cursor are no longer kept: it drops its cache, fetches afresh and syncs from Cursor.
+type SyncResponse struct {
+	Changes []Change `json:"changes"`
+	Cursor  int64    `json:"cursor"`
+	HasMore bool     `json:"hasMore"`
+	Reset   bool     `json:"reset"`
+}
@@ -0,0 +1,22 @@
+package deltasync
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/deltasync/handlers"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+type Handlers struct {
+	SyncHandler *handlers.SyncHandler
+}
+
+// RegisterRoutes wires the delta sync endpoint offline clients reconcile their caches with.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	dualAuthMiddleware := dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	})
+
+	app.Get("/sync", dualAuthMiddleware, handlers.SyncHandler.Changes)
+}
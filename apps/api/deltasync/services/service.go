@@ -0,0 +1,321 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	commentsModels "github.com/qolzam/telar/apps/api/comments/models"
+	syncErrors "github.com/qolzam/telar/apps/api/deltasync/errors"
+	"github.com/qolzam/telar/apps/api/deltasync/models"
+	"github.com/qolzam/telar/apps/api/internal/outbox"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Page sizes, in events read from the log
+const (
+	DefaultLimit = 100
+	MaxLimit     = 500
+)
+
+// settleWindow is how old an event must be before it is synced. Events are numbered
+// when appended but become visible when their transaction commits, so a younger event
+// may still be joined by one with a lower seq.
+const settleWindow = 5 * time.Second
+
+// syncedEventTypes are the outbox events that change what a client caches
+var syncedEventTypes = []string{
+	sharedInterfaces.OutboxPostCreated,
+	sharedInterfaces.OutboxPostUpdated,
+	sharedInterfaces.OutboxPostDeleted,
+	sharedInterfaces.OutboxCommentCreated,
+	sharedInterfaces.OutboxCommentUpdated,
+	sharedInterfaces.OutboxCommentDeleted,
+	sharedInterfaces.OutboxReplyDeleted,
+	sharedInterfaces.OutboxBookmarkAdded,
+	sharedInterfaces.OutboxBookmarkRemoved,
+}
+
+// Service brings offline clients up to date from the outbox event log.
+type Service interface {
+	// Changes returns the posts, comments and bookmarks that changed after the cursor
+	// since, as the user may see them now. With no cursor it returns only the cursor to
+	// start syncing from.
+	Changes(ctx context.Context, userID uuid.UUID, since *int64, limit int) (*models.SyncResponse, error)
+}
+
+// EventLog reads the outbox events in append order
+type EventLog interface {
+	Bounds(ctx context.Context, settle time.Duration) (oldest, latest int64, err error)
+	Since(ctx context.Context, after, upTo int64, eventTypes []string, userID uuid.UUID, limit int) ([]outbox.LogEntry, error)
+}
+
+// PostReader reads the current state of changed posts
+type PostReader interface {
+	GetPostsByIDs(ctx context.Context, ids []uuid.UUID) ([]*postsModels.Post, error)
+	ConvertPostToResponse(ctx context.Context, post *postsModels.Post) postsModels.PostResponse
+	ApplySupporterAccess(ctx context.Context, posts []postsModels.PostResponse)
+}
+
+// CommentReader reads the current state of changed comments
+type CommentReader interface {
+	GetCommentsByIDs(ctx context.Context, commentIDs []uuid.UUID) ([]*commentsModels.Comment, error)
+}
+
+type service struct {
+	log      EventLog
+	posts    PostReader
+	comments CommentReader
+	now      func() time.Time
+}
+
+// NewService syncs from log, reading changed records through posts and comments.
+func NewService(log EventLog, posts PostReader, comments CommentReader) Service {
+	return &service{log: log, posts: posts, comments: comments, now: time.Now}
+}
+
+// pendingChange is a change read from the log, before its record is read
+type pendingChange struct {
+	seq    int64
+	kind   string
+	action string
+	id     uuid.UUID
+	postID uuid.UUID
+}
+
+func (s *service) Changes(ctx context.Context, userID uuid.UUID, since *int64, limit int) (*models.SyncResponse, error) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+
+	oldest, latest, err := s.log.Bounds(ctx, settleWindow)
+	if err != nil {
+		return nil, err
+	}
+	resp := &models.SyncResponse{Changes: []models.Change{}, Cursor: latest}
+	if since == nil {
+		return resp, nil
+	}
+	if *since < 0 {
+		return nil, fmt.Errorf("%w: since must not be negative", syncErrors.ErrInvalidRequest)
+	}
+	// Events after the cursor were purged, or the cursor comes from another log
+	if *since+1 < oldest || *since > latest {
+		resp.Reset = true
+		return resp, nil
+	}
+	if *since == latest {
+		return resp, nil
+	}
+
+	entries, err := s.log.Since(ctx, *since, latest, syncedEventTypes, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == limit {
+		resp.HasMore = true
+		resp.Cursor = entries[len(entries)-1].Seq
+	}
+
+	changes, err := s.resolve(ctx, userID, compact(entries))
+	if err != nil {
+		return nil, err
+	}
+	resp.Changes = changes
+	return resp, nil
+}
+
+// compact turns events into changes, keeping only the last change of each record
+func compact(entries []outbox.LogEntry) []pendingChange {
+	last := make(map[string]pendingChange, len(entries))
+	for _, entry := range entries {
+		change, ok := decode(entry)
+		if !ok {
+			continue
+		}
+		key := change.kind + ":" + change.id.String()
+		if change.action == models.ActionClear {
+			key = models.ActionClear + ":" + change.postID.String()
+		}
+		last[key] = change
+	}
+
+	changes := make([]pendingChange, 0, len(last))
+	for _, change := range last {
+		changes = append(changes, change)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].seq < changes[j].seq })
+	return changes
+}
+
+// decode reads the record an event changed
+func decode(entry outbox.LogEntry) (pendingChange, bool) {
+	change := pendingChange{seq: entry.Seq, action: models.ActionUpsert}
+	var err error
+	switch entry.Type {
+	case sharedInterfaces.OutboxPostCreated, sharedInterfaces.OutboxPostUpdated, sharedInterfaces.OutboxPostDeleted:
+		var event sharedInterfaces.PostChangedEvent
+		err = entry.Decode(&event)
+		change.kind, change.id, change.postID = models.TypePost, event.PostId, event.PostId
+		if entry.Type == sharedInterfaces.OutboxPostDeleted {
+			change.action = models.ActionDelete
+		}
+	case sharedInterfaces.OutboxCommentCreated:
+		var event sharedInterfaces.CommentCreatedEvent
+		err = entry.Decode(&event)
+		change.kind, change.id, change.postID = models.TypeComment, event.CommentId, event.PostId
+	case sharedInterfaces.OutboxCommentUpdated, sharedInterfaces.OutboxReplyDeleted:
+		var event sharedInterfaces.CommentChangedEvent
+		err = entry.Decode(&event)
+		change.kind, change.id, change.postID = models.TypeComment, event.CommentId, event.PostId
+		if entry.Type == sharedInterfaces.OutboxReplyDeleted {
+			change.action = models.ActionDelete
+		}
+	case sharedInterfaces.OutboxCommentDeleted:
+		var event sharedInterfaces.CommentDeletedEvent
+		err = entry.Decode(&event)
+		change.kind, change.postID, change.action = models.TypeComment, event.PostId, models.ActionClear
+		if event.CommentId != nil {
+			change.id, change.action = *event.CommentId, models.ActionDelete
+		}
+	case sharedInterfaces.OutboxBookmarkAdded, sharedInterfaces.OutboxBookmarkRemoved:
+		var event sharedInterfaces.BookmarkChangedEvent
+		err = entry.Decode(&event)
+		change.kind, change.id, change.postID = models.TypeBookmark, event.PostId, event.PostId
+		if entry.Type == sharedInterfaces.OutboxBookmarkRemoved {
+			change.action = models.ActionDelete
+		}
+	default:
+		return change, false
+	}
+	if err != nil {
+		log.Warn("Skipping outbox event %d in sync: %v", entry.Seq, err)
+		return change, false
+	}
+	return change, true
+}
+
+// resolve reads the current state of upserted records. Records the user may no longer
+// see become deletions; comments under posts the user may not see are left out.
+func (s *service) resolve(ctx context.Context, userID uuid.UUID, pending []pendingChange) ([]models.Change, error) {
+	posts, err := s.visiblePosts(ctx, userID, pending)
+	if err != nil {
+		return nil, err
+	}
+	comments, err := s.liveComments(ctx, pending, posts)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]models.Change, 0, len(pending))
+	for _, p := range pending {
+		change := models.Change{Seq: p.seq, Type: p.kind, Action: p.action, PostID: p.postID.String()}
+		if p.action != models.ActionClear {
+			change.ID = p.id.String()
+		}
+		if p.kind != models.TypeComment {
+			change.PostID = ""
+		}
+
+		switch {
+		case p.action != models.ActionUpsert:
+		case p.kind == models.TypeComment:
+			if _, ok := posts[p.postID]; !ok {
+				continue
+			}
+			if comment, ok := comments[p.id]; ok {
+				change.Comment = comment
+			} else {
+				change.Action = models.ActionDelete
+			}
+		default:
+			if post, ok := posts[p.id]; ok {
+				change.Post = post
+			} else {
+				change.Action = models.ActionDelete
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
+
+// liveComments reads, in one query, the comments upserted in pending under posts the
+// user may see. Deleted comments are absent from the map.
+func (s *service) liveComments(ctx context.Context, pending []pendingChange, posts map[uuid.UUID]*postsModels.PostResponse) (map[uuid.UUID]*commentsModels.Comment, error) {
+	var ids []uuid.UUID
+	for _, p := range pending {
+		if p.kind != models.TypeComment || p.action != models.ActionUpsert {
+			continue
+		}
+		if _, ok := posts[p.postID]; ok {
+			ids = append(ids, p.id)
+		}
+	}
+	if len(ids) == 0 {
+		return map[uuid.UUID]*commentsModels.Comment{}, nil
+	}
+
+	found, err := s.comments.GetCommentsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get comments: %w", err)
+	}
+	comments := make(map[uuid.UUID]*commentsModels.Comment, len(found))
+	for _, comment := range found {
+		comments[comment.ObjectId] = comment
+	}
+	return comments, nil
+}
+
+// visiblePosts reads the posts upserted or commented on in pending that the user may
+// see, as responses
+func (s *service) visiblePosts(ctx context.Context, userID uuid.UUID, pending []pendingChange) (map[uuid.UUID]*postsModels.PostResponse, error) {
+	seen := make(map[uuid.UUID]bool)
+	var ids []uuid.UUID
+	for _, p := range pending {
+		if p.action == models.ActionUpsert && !seen[p.postID] {
+			seen[p.postID] = true
+			ids = append(ids, p.postID)
+		}
+	}
+	if len(ids) == 0 {
+		return map[uuid.UUID]*postsModels.PostResponse{}, nil
+	}
+
+	found, err := s.posts.GetPostsByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("get posts: %w", err)
+	}
+	now := s.now().UnixMilli()
+	responses := make([]postsModels.PostResponse, 0, len(found))
+	for _, post := range found {
+		if visible(post, userID, now) {
+			responses = append(responses, s.posts.ConvertPostToResponse(ctx, post))
+		}
+	}
+	s.posts.ApplySupporterAccess(ctx, responses)
+
+	visiblePosts := make(map[uuid.UUID]*postsModels.PostResponse, len(responses))
+	for i := range responses {
+		if id, err := uuid.FromString(responses[i].ObjectId); err == nil {
+			visiblePosts[id] = &responses[i]
+		}
+	}
+	return visiblePosts, nil
+}
+
+// visible reports whether the user may see the post at now (Unix milliseconds): it is
+// live and public, or theirs
+func visible(post *postsModels.Post, userID uuid.UUID, now int64) bool {
+	if post.Deleted || post.Archived || (post.VisibleUntil > 0 && post.VisibleUntil <= now) {
+		return false
+	}
+	return post.Permission == "" || post.Permission == "Public" || post.OwnerUserId == userID
+}
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	commentsModels "github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/deltasync/models"
+	"github.com/qolzam/telar/apps/api/internal/outbox"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLog serves entries, filtered like the outbox repository
+type fakeLog struct {
+	oldest, latest int64
+	entries        []outbox.LogEntry
+}
+
+func (f *fakeLog) Bounds(ctx context.Context, settle time.Duration) (int64, int64, error) {
+	return f.oldest, f.latest, nil
+}
+
+func (f *fakeLog) Since(ctx context.Context, after, upTo int64, eventTypes []string, userID uuid.UUID, limit int) ([]outbox.LogEntry, error) {
+	var entries []outbox.LogEntry
+	for _, entry := range f.entries {
+		var addressed struct {
+			UserId *uuid.UUID `json:"userId"`
+		}
+		_ = json.Unmarshal(entry.Data, &addressed)
+		if entry.Seq > after && entry.Seq <= upTo && (addressed.UserId == nil || *addressed.UserId == userID) && len(entries) < limit {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
+
+func (f *fakeLog) append(eventType string, data interface{}) {
+	payload, _ := json.Marshal(data)
+	f.latest++
+	f.entries = append(f.entries, outbox.LogEntry{Seq: f.latest, Message: outbox.Message{Type: eventType, Data: payload}})
+}
+
+type fakePosts struct {
+	posts map[uuid.UUID]*postsModels.Post
+}
+
+func (f *fakePosts) GetPostsByIDs(ctx context.Context, ids []uuid.UUID) ([]*postsModels.Post, error) {
+	var posts []*postsModels.Post
+	for _, id := range ids {
+		if post, ok := f.posts[id]; ok {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
+func (f *fakePosts) ConvertPostToResponse(ctx context.Context, post *postsModels.Post) postsModels.PostResponse {
+	return postsModels.PostResponse{ObjectId: post.ObjectId.String(), Body: post.Body}
+}
+
+func (f *fakePosts) ApplySupporterAccess(ctx context.Context, posts []postsModels.PostResponse) {}
+
+type fakeComments struct {
+	comments map[uuid.UUID]*commentsModels.Comment
+	calls    int
+}
+
+func (f *fakeComments) GetCommentsByIDs(ctx context.Context, commentIDs []uuid.UUID) ([]*commentsModels.Comment, error) {
+	f.calls++
+	var comments []*commentsModels.Comment
+	for _, id := range commentIDs {
+		if comment, ok := f.comments[id]; ok {
+			comments = append(comments, comment)
+		}
+	}
+	return comments, nil
+}
+
+func TestChanges(t *testing.T) {
+	ctx := context.Background()
+	user := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4())
+	public := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: other, Body: "edited", Permission: "Public"}
+	private := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: other, Permission: "OnlyMe"}
+	mine := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: user, Permission: "OnlyMe"}
+	comment := &commentsModels.Comment{ObjectId: uuid.Must(uuid.NewV4()), PostId: public.ObjectId, Text: "hi"}
+	deletedComment := uuid.Must(uuid.NewV4())
+
+	log := &fakeLog{oldest: 1}
+	log.append(sharedInterfaces.OutboxPostCreated, sharedInterfaces.PostChangedEvent{PostId: public.ObjectId})
+	log.append(sharedInterfaces.OutboxPostCreated, sharedInterfaces.PostChangedEvent{PostId: private.ObjectId})
+	log.append(sharedInterfaces.OutboxPostCreated, sharedInterfaces.PostChangedEvent{PostId: mine.ObjectId})
+	log.append(sharedInterfaces.OutboxCommentCreated, sharedInterfaces.CommentCreatedEvent{CommentId: comment.ObjectId, PostId: public.ObjectId})
+	log.append(sharedInterfaces.OutboxCommentCreated, sharedInterfaces.CommentCreatedEvent{CommentId: uuid.Must(uuid.NewV4()), PostId: private.ObjectId})
+	log.append(sharedInterfaces.OutboxCommentCreated, sharedInterfaces.CommentCreatedEvent{CommentId: deletedComment, PostId: public.ObjectId})
+	log.append(sharedInterfaces.OutboxBookmarkAdded, sharedInterfaces.BookmarkChangedEvent{UserId: other, PostId: public.ObjectId})
+	log.append(sharedInterfaces.OutboxBookmarkAdded, sharedInterfaces.BookmarkChangedEvent{UserId: user, PostId: public.ObjectId})
+	log.append(sharedInterfaces.OutboxPostUpdated, sharedInterfaces.PostChangedEvent{PostId: public.ObjectId})
+	log.append(sharedInterfaces.OutboxCommentDeleted, sharedInterfaces.CommentDeletedEvent{PostId: mine.ObjectId, RootCount: 2})
+
+	posts := &fakePosts{posts: map[uuid.UUID]*postsModels.Post{public.ObjectId: public, private.ObjectId: private, mine.ObjectId: mine}}
+	comments := &fakeComments{comments: map[uuid.UUID]*commentsModels.Comment{comment.ObjectId: comment}}
+	svc := NewService(log, posts, comments)
+
+	t.Run("returns the cursor to start from", func(t *testing.T) {
+		resp, err := svc.Changes(ctx, user, nil, 0)
+		require.NoError(t, err)
+		assert.Empty(t, resp.Changes)
+		assert.Equal(t, int64(10), resp.Cursor)
+	})
+
+	t.Run("collapses and filters changes", func(t *testing.T) {
+		since := int64(0)
+		comments.calls = 0
+		resp, err := svc.Changes(ctx, user, &since, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, comments.calls, "comments are read in one batch")
+		assert.False(t, resp.HasMore)
+		assert.Equal(t, int64(10), resp.Cursor)
+
+		assert.Equal(t, []models.Change{
+			{Seq: 2, Type: models.TypePost, Action: models.ActionDelete, ID: private.ObjectId.String()},
+			{Seq: 3, Type: models.TypePost, Action: models.ActionUpsert, ID: mine.ObjectId.String(), Post: &postsModels.PostResponse{ObjectId: mine.ObjectId.String()}},
+			{Seq: 4, Type: models.TypeComment, Action: models.ActionUpsert, ID: comment.ObjectId.String(), PostID: public.ObjectId.String(), Comment: comment},
+			{Seq: 6, Type: models.TypeComment, Action: models.ActionDelete, ID: deletedComment.String(), PostID: public.ObjectId.String()},
+			{Seq: 8, Type: models.TypeBookmark, Action: models.ActionUpsert, ID: public.ObjectId.String(), Post: &postsModels.PostResponse{ObjectId: public.ObjectId.String(), Body: "edited"}},
+			{Seq: 9, Type: models.TypePost, Action: models.ActionUpsert, ID: public.ObjectId.String(), Post: &postsModels.PostResponse{ObjectId: public.ObjectId.String(), Body: "edited"}},
+			{Seq: 10, Type: models.TypeComment, Action: models.ActionClear, PostID: mine.ObjectId.String()},
+		}, resp.Changes)
+	})
+
+	t.Run("pages through the log", func(t *testing.T) {
+		since := int64(0)
+		resp, err := svc.Changes(ctx, user, &since, 4)
+		require.NoError(t, err)
+		assert.True(t, resp.HasMore)
+		assert.Equal(t, int64(4), resp.Cursor)
+		assert.Len(t, resp.Changes, 4)
+
+		resp, err = svc.Changes(ctx, user, &resp.Cursor, 4)
+		require.NoError(t, err)
+		assert.True(t, resp.HasMore)
+		assert.Equal(t, int64(9), resp.Cursor)
+	})
+
+	t.Run("resets clients whose changes were purged", func(t *testing.T) {
+		purged := &fakeLog{oldest: 5, latest: 10}
+		since := int64(2)
+		resp, err := NewService(purged, posts, comments).Changes(ctx, user, &since, 0)
+		require.NoError(t, err)
+		assert.True(t, resp.Reset)
+		assert.Equal(t, int64(10), resp.Cursor)
+
+		since = 4
+		resp, err = NewService(purged, posts, comments).Changes(ctx, user, &since, 0)
+		require.NoError(t, err)
+		assert.False(t, resp.Reset)
+	})
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/experiments/errors"
+	"github.com/qolzam/telar/apps/api/experiments/models"
+	"github.com/qolzam/telar/apps/api/experiments/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type ExperimentHandler struct {
+	service services.Service
+}
+
+func NewExperimentHandler(service services.Service) *ExperimentHandler {
+	return &ExperimentHandler{service: service}
+}
+
+// Assignments returns the current user's variant for each running experiment they are
+// enrolled in. Clients log an exposure event when they first render a variant.
+// Endpoint: GET /experiments/assignments
+func (h *ExperimentHandler) Assignments(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	assignments, err := h.service.GetAssignments(c.Context(), user.UserID, user.SystemRole)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(models.AssignmentsResponse{Assignments: assignments})
+}
+
+// List returns every experiment.
+// Endpoint: GET /experiments
+func (h *ExperimentHandler) List(c *fiber.Ctx) error {
+	experiments, err := h.service.ListExperiments(c.Context())
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(experiments)
+}
+
+// Create defines a new experiment in draft status.
+// Endpoint: POST /experiments
+func (h *ExperimentHandler) Create(c *fiber.Ctx) error {
+	var req models.CreateExperimentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	experiment, err := h.service.CreateExperiment(c.Context(), &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(experiment)
+}
+
+// Update changes an experiment; setting status to "paused" or "completed" stops assignment.
+// Endpoint: PUT /experiments/:key
+func (h *ExperimentHandler) Update(c *fiber.Ctx) error {
+	key := c.Params("key")
+	if key == "" {
+		return errors.HandleValidationError(c, "key is required")
+	}
+
+	var req models.UpdateExperimentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	experiment, err := h.service.UpdateExperiment(c.Context(), key, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(experiment)
+}
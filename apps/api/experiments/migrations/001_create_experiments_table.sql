@@ -0,0 +1,15 @@
+-- A/B test definitions. Assignment is computed from the experiment key and user ID,
+-- so no per-user rows are stored; exposures are logged to analytics_events.
+CREATE TABLE IF NOT EXISTS experiments (
+    id UUID PRIMARY KEY,
+    key TEXT NOT NULL UNIQUE,
+    description TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'draft',
+    variants JSONB NOT NULL DEFAULT '[]'::jsonb,
+    audience JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_date BIGINT NOT NULL,
+    last_updated BIGINT NOT NULL
+);
+
+-- Assignment loads running experiments only
+CREATE INDEX IF NOT EXISTS idx_experiments_status ON experiments(status);
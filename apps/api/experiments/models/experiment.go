@@ -0,0 +1,83 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Status is the lifecycle state of an experiment
+type Status string
+
+const (
+	// StatusDraft experiments are being set up and assign nobody
+	StatusDraft Status = "draft"
+	// StatusRunning experiments assign their audience to variants
+	StatusRunning Status = "running"
+	// StatusPaused experiments assign nobody; clients fall back to default behavior
+	StatusPaused Status = "paused"
+	// StatusCompleted experiments are finished and can no longer be changed
+	StatusCompleted Status = "completed"
+)
+
+// Valid reports whether s is a known status
+func (s Status) Valid() bool {
+	switch s {
+	case StatusDraft, StatusRunning, StatusPaused, StatusCompleted:
+		return true
+	}
+	return false
+}
+
+// Variant is one arm of an experiment. The first variant of an experiment is its control.
+type Variant struct {
+	Key    string `json:"key"`
+	Weight int    `json:"weight"` // Relative share of the audience
+}
+
+// Audience selects the users enrolled in an experiment
+type Audience struct {
+	Percent int      `json:"percent"`           // Share of eligible users enrolled, 0-100
+	Roles   []string `json:"roles,omitempty"`   // System roles eligible; empty means every role
+	UserIds []string `json:"userIds,omitempty"` // Always enrolled regardless of percent and roles (QA, internal testers)
+}
+
+// Experiment is an A/B test definition
+type Experiment struct {
+	ObjectId    uuid.UUID `json:"objectId"`
+	Key         string    `json:"key"`
+	Description string    `json:"description,omitempty"`
+	Status      Status    `json:"status"`
+	Variants    []Variant `json:"variants"`
+	Audience    Audience  `json:"audience"`
+	CreatedDate int64     `json:"createdDate"`
+	LastUpdated int64     `json:"lastUpdated"`
+}
+
+// Control returns the key of the control variant
+func (e *Experiment) Control() string {
+	if len(e.Variants) == 0 {
+		return ""
+	}
+	return e.Variants[0].Key
+}
+
+// CreateExperimentRequest is the POST /experiments request body
+type CreateExperimentRequest struct {
+	Key         string    `json:"key"`
+	Description string    `json:"description"`
+	Variants    []Variant `json:"variants"`
+	Audience    Audience  `json:"audience"`
+}
+
+// UpdateExperimentRequest is the PUT /experiments/:key request body. Variants can only
+// change while the experiment is a draft, so running users never switch arms.
+type UpdateExperimentRequest struct {
+	Description *string    `json:"description,omitempty"`
+	Status      *Status    `json:"status,omitempty"`
+	Variants    *[]Variant `json:"variants,omitempty"`
+	Audience    *Audience  `json:"audience,omitempty"`
+}
+
+// AssignmentsResponse maps experiment keys to the variant assigned to the current user
+type AssignmentsResponse struct {
+	Assignments map[string]string `json:"assignments"`
+}
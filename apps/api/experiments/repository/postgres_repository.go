@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/experiments/models"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// experimentRow mirrors the experiments table; variants and audience are JSONB
+type experimentRow struct {
+	ID          uuid.UUID `db:"id"`
+	Key         string    `db:"key"`
+	Description string    `db:"description"`
+	Status      string    `db:"status"`
+	Variants    []byte    `db:"variants"`
+	Audience    []byte    `db:"audience"`
+	CreatedDate int64     `db:"created_date"`
+	LastUpdated int64     `db:"last_updated"`
+}
+
+const selectColumns = `id, key, description, status, variants, audience, created_date, last_updated`
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) Create(ctx context.Context, experiment *models.Experiment) error {
+	variants, audience, err := marshalJSONColumns(experiment)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %sexperiments (id, key, description, status, variants, audience, created_date, last_updated)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, r.schemaPrefix())
+
+	_, err = r.getExecutor(ctx).ExecContext(ctx, query,
+		experiment.ObjectId, experiment.Key, experiment.Description, string(experiment.Status),
+		variants, audience, experiment.CreatedDate, experiment.LastUpdated)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" { // unique_violation
+			return ErrDuplicateKey
+		}
+		return fmt.Errorf("insert experiment: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) Update(ctx context.Context, experiment *models.Experiment) error {
+	variants, audience, err := marshalJSONColumns(experiment)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`
+		UPDATE %sexperiments
+		SET description = $2, status = $3, variants = $4, audience = $5, last_updated = $6
+		WHERE key = $1
+	`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		experiment.Key, experiment.Description, string(experiment.Status), variants, audience, experiment.LastUpdated)
+	if err != nil {
+		return fmt.Errorf("update experiment: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetByKey(ctx context.Context, key string) (*models.Experiment, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %sexperiments WHERE key = $1`, selectColumns, r.schemaPrefix())
+
+	var row experimentRow
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get experiment: %w", err)
+	}
+	return row.toModel()
+}
+
+func (r *postgresRepository) List(ctx context.Context, status models.Status) ([]*models.Experiment, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %sexperiments`, selectColumns, r.schemaPrefix())
+	args := []interface{}{}
+	if status != "" {
+		query += ` WHERE status = $1`
+		args = append(args, string(status))
+	}
+	query += ` ORDER BY created_date DESC`
+
+	var rows []experimentRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("list experiments: %w", err)
+	}
+
+	experiments := make([]*models.Experiment, 0, len(rows))
+	for i := range rows {
+		experiment, err := rows[i].toModel()
+		if err != nil {
+			return nil, err
+		}
+		experiments = append(experiments, experiment)
+	}
+	return experiments, nil
+}
+
+func (row *experimentRow) toModel() (*models.Experiment, error) {
+	experiment := &models.Experiment{
+		ObjectId:    row.ID,
+		Key:         row.Key,
+		Description: row.Description,
+		Status:      models.Status(row.Status),
+		CreatedDate: row.CreatedDate,
+		LastUpdated: row.LastUpdated,
+	}
+	if err := json.Unmarshal(row.Variants, &experiment.Variants); err != nil {
+		return nil, fmt.Errorf("decode variants of %s: %w", row.Key, err)
+	}
+	if err := json.Unmarshal(row.Audience, &experiment.Audience); err != nil {
+		return nil, fmt.Errorf("decode audience of %s: %w", row.Key, err)
+	}
+	return experiment, nil
+}
+
+func marshalJSONColumns(experiment *models.Experiment) ([]byte, []byte, error) {
+	variants, err := json.Marshal(experiment.Variants)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode variants: %w", err)
+	}
+	audience, err := json.Marshal(experiment.Audience)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode audience: %w", err)
+	}
+	return variants, audience, nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/qolzam/telar/apps/api/experiments/models"
+)
+
+var (
+	// ErrNotFound is returned when no experiment has the requested key
+	ErrNotFound = errors.New("experiment not found")
+	// ErrDuplicateKey is returned when an experiment with the same key exists
+	ErrDuplicateKey = errors.New("experiment key already exists")
+)
+
+// Repository defines data access for experiment definitions.
+type Repository interface {
+	// Create stores a new experiment; returns ErrDuplicateKey when the key is taken.
+	Create(ctx context.Context, experiment *models.Experiment) error
+
+	// Update overwrites the mutable fields of an experiment identified by key.
+	Update(ctx context.Context, experiment *models.Experiment) error
+
+	// GetByKey returns an experiment or ErrNotFound.
+	GetByKey(ctx context.Context, key string) (*models.Experiment, error)
+
+	// List returns experiments ordered by creation time, newest first. An empty
+	// status returns every experiment.
+	List(ctx context.Context, status models.Status) ([]*models.Experiment, error)
+}
@@ -0,0 +1,43 @@
+package experiments
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/experiments/handlers"
+	adminmw "github.com/qolzam/telar/apps/api/internal/middleware/admin"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+type Handlers struct {
+	ExperimentHandler *handlers.ExperimentHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires experiment assignment and admin endpoints.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	group := app.Group("/experiments", dualAuthMiddleware)
+	group.Get("/assignments", handlers.ExperimentHandler.Assignments)
+
+	adminGroup := group.Group("", adminmw.New(adminmw.Config{}))
+	adminGroup.Get("/", handlers.ExperimentHandler.List)
+	adminGroup.Post("/", handlers.ExperimentHandler.Create)
+	adminGroup.Put("/:key", handlers.ExperimentHandler.Update)
+}
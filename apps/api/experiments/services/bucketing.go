@@ -0,0 +1,63 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"strings"
+
+	"github.com/qolzam/telar/apps/api/experiments/models"
+)
+
+// bucketCount is the resolution of bucketing: audiences and weights are split in 0.01% steps
+const bucketCount = 10000
+
+// bucket hashes the parts into [0, bucketCount). It is stable across processes and
+// releases, so a user keeps the same variant for the life of an experiment.
+func bucket(parts ...string) int {
+	sum := sha256.Sum256([]byte(strings.Join(parts, ":")))
+	return int(binary.BigEndian.Uint64(sum[:8]) % bucketCount)
+}
+
+// inAudience reports whether the user is enrolled in the experiment. Enrollment and
+// variant choice use separate salts so that widening the audience percent adds users
+// without reshuffling the variants of users already enrolled.
+func inAudience(e *models.Experiment, userID, role string) bool {
+	for _, id := range e.Audience.UserIds {
+		if id == userID {
+			return true
+		}
+	}
+	if len(e.Audience.Roles) > 0 && !containsString(e.Audience.Roles, role) {
+		return false
+	}
+	return bucket("audience", e.Key, userID) < e.Audience.Percent*bucketCount/100
+}
+
+// pickVariant chooses a variant by weight
+func pickVariant(e *models.Experiment, userID string) string {
+	total := 0
+	for _, v := range e.Variants {
+		total += v.Weight
+	}
+	if total <= 0 {
+		return e.Control()
+	}
+
+	point := bucket("variant", e.Key, userID) * total / bucketCount
+	for _, v := range e.Variants {
+		if point < v.Weight {
+			return v.Key
+		}
+		point -= v.Weight
+	}
+	return e.Control()
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+
+	"github.com/qolzam/telar/apps/api/experiments/models"
+	"github.com/qolzam/telar/apps/api/experiments/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the experiments repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) Create(ctx context.Context, experiment *models.Experiment) error {
+	args := m.Called(ctx, experiment)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Update(ctx context.Context, experiment *models.Experiment) error {
+	args := m.Called(ctx, experiment)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetByKey(ctx context.Context, key string) (*models.Experiment, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Experiment), args.Error(1)
+}
+
+func (m *MockRepository) List(ctx context.Context, status models.Status) ([]*models.Experiment, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Experiment), args.Error(1)
+}
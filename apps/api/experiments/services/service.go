@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	experimentErrors "github.com/qolzam/telar/apps/api/experiments/errors"
+	"github.com/qolzam/telar/apps/api/experiments/models"
+	"github.com/qolzam/telar/apps/api/experiments/repository"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+const (
+	maxVariants       = 10
+	maxDescriptionLen = 500
+)
+
+// keyPattern restricts experiment and variant keys to short slugs that are safe in JWT
+// claims, analytics rows and client code
+var keyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// Service defines experiment operations.
+type Service interface {
+	// CreateExperiment stores a new experiment in draft status.
+	CreateExperiment(ctx context.Context, req *models.CreateExperimentRequest) (*models.Experiment, error)
+
+	// UpdateExperiment changes the description, status, variants or audience of an experiment.
+	UpdateExperiment(ctx context.Context, key string, req *models.UpdateExperimentRequest) (*models.Experiment, error)
+
+	// ListExperiments returns every experiment, newest first.
+	ListExperiments(ctx context.Context) ([]*models.Experiment, error)
+
+	// GetAssignments returns the variant of each running experiment the user is enrolled in.
+	GetAssignments(ctx context.Context, userID uuid.UUID, role string) (map[string]string, error)
+
+	// AssignmentsForUser is GetAssignments for token issuance: failures are logged and
+	// yield no assignments so sign-in never fails because of experiments.
+	AssignmentsForUser(ctx context.Context, userID uuid.UUID, role string) map[string]string
+}
+
+type service struct {
+	repo         repository.Repository
+	config       platformconfig.ExperimentsConfig
+	forceControl map[string]struct{}
+	now          func() time.Time
+
+	mu       sync.Mutex
+	running  []*models.Experiment
+	loadedAt time.Time
+}
+
+// NewService constructs an experiments service.
+func NewService(repo repository.Repository, cfg platformconfig.ExperimentsConfig) Service {
+	forceControl := make(map[string]struct{}, len(cfg.ForceControl))
+	for _, key := range cfg.ForceControl {
+		forceControl[key] = struct{}{}
+	}
+	return &service{repo: repo, config: cfg, forceControl: forceControl, now: time.Now}
+}
+
+func (s *service) CreateExperiment(ctx context.Context, req *models.CreateExperimentRequest) (*models.Experiment, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", experimentErrors.ErrInvalidRequest)
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, fmt.Errorf("generate experiment id: %w", err)
+	}
+	now := s.now().UTC().UnixMilli()
+	experiment := &models.Experiment{
+		ObjectId:    id,
+		Key:         strings.TrimSpace(req.Key),
+		Description: strings.TrimSpace(req.Description),
+		Status:      models.StatusDraft,
+		Variants:    req.Variants,
+		Audience:    req.Audience,
+		CreatedDate: now,
+		LastUpdated: now,
+	}
+	if err := validateExperiment(experiment); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, experiment); err != nil {
+		if errors.Is(err, repository.ErrDuplicateKey) {
+			return nil, experimentErrors.ErrExperimentExists
+		}
+		return nil, fmt.Errorf("%w: %v", experimentErrors.ErrDatabaseOperation, err)
+	}
+	return experiment, nil
+}
+
+func (s *service) UpdateExperiment(ctx context.Context, key string, req *models.UpdateExperimentRequest) (*models.Experiment, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", experimentErrors.ErrInvalidRequest)
+	}
+
+	experiment, err := s.repo.GetByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, experimentErrors.ErrExperimentNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", experimentErrors.ErrDatabaseOperation, err)
+	}
+	if experiment.Status == models.StatusCompleted {
+		return nil, fmt.Errorf("%w: completed experiments cannot be changed", experimentErrors.ErrInvalidRequest)
+	}
+
+	if req.Description != nil {
+		experiment.Description = strings.TrimSpace(*req.Description)
+	}
+	if req.Variants != nil {
+		if experiment.Status != models.StatusDraft {
+			return nil, fmt.Errorf("%w: variants can only change while the experiment is a draft", experimentErrors.ErrInvalidRequest)
+		}
+		experiment.Variants = *req.Variants
+	}
+	if req.Audience != nil {
+		experiment.Audience = *req.Audience
+	}
+	if req.Status != nil {
+		if !req.Status.Valid() {
+			return nil, fmt.Errorf("%w: unknown status %q", experimentErrors.ErrInvalidRequest, *req.Status)
+		}
+		if *req.Status == models.StatusDraft && experiment.Status != models.StatusDraft {
+			return nil, fmt.Errorf("%w: a started experiment cannot return to draft", experimentErrors.ErrInvalidRequest)
+		}
+		experiment.Status = *req.Status
+	}
+	if err := validateExperiment(experiment); err != nil {
+		return nil, err
+	}
+
+	experiment.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Update(ctx, experiment); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, experimentErrors.ErrExperimentNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", experimentErrors.ErrDatabaseOperation, err)
+	}
+	s.invalidate()
+	return experiment, nil
+}
+
+func (s *service) ListExperiments(ctx context.Context) ([]*models.Experiment, error) {
+	experiments, err := s.repo.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", experimentErrors.ErrDatabaseOperation, err)
+	}
+	return experiments, nil
+}
+
+func (s *service) GetAssignments(ctx context.Context, userID uuid.UUID, role string) (map[string]string, error) {
+	assignments := map[string]string{}
+	if !s.config.Enabled || userID == uuid.Nil {
+		return assignments, nil
+	}
+
+	running, err := s.runningExperiments(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", experimentErrors.ErrDatabaseOperation, err)
+	}
+
+	uid := userID.String()
+	for _, experiment := range running {
+		if !inAudience(experiment, uid, role) {
+			continue
+		}
+		// Guardrail: a force-control key keeps the audience enrolled (so exposure data
+		// stays comparable) but sends everyone to control without a deploy
+		if _, forced := s.forceControl[experiment.Key]; forced {
+			assignments[experiment.Key] = experiment.Control()
+			continue
+		}
+		assignments[experiment.Key] = pickVariant(experiment, uid)
+	}
+	return assignments, nil
+}
+
+func (s *service) AssignmentsForUser(ctx context.Context, userID uuid.UUID, role string) map[string]string {
+	assignments, err := s.GetAssignments(ctx, userID, role)
+	if err != nil {
+		log.Warn("Failed to compute experiment assignments for %s: %v", userID, err)
+		return nil
+	}
+	return assignments
+}
+
+// runningExperiments returns running experiments, cached for CacheTTL since every
+// sign-in and assignments request needs them
+func (s *service) runningExperiments(ctx context.Context) ([]*models.Experiment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running != nil && s.now().Sub(s.loadedAt) < s.config.CacheTTL {
+		return s.running, nil
+	}
+
+	running, err := s.repo.List(ctx, models.StatusRunning)
+	if err != nil {
+		return nil, err
+	}
+	s.running = running
+	s.loadedAt = s.now()
+	return running, nil
+}
+
+// invalidate drops cached experiments so a status change applies to the next request
+// served by this instance; other instances pick it up within CacheTTL
+func (s *service) invalidate() {
+	s.mu.Lock()
+	s.running = nil
+	s.mu.Unlock()
+}
+
+// validateExperiment checks an experiment definition before it is stored
+func validateExperiment(e *models.Experiment) error {
+	if !keyPattern.MatchString(e.Key) {
+		return fmt.Errorf("%w: key must be 1-64 lowercase letters, digits, '-' or '_'", experimentErrors.ErrInvalidRequest)
+	}
+	if len(e.Description) > maxDescriptionLen {
+		return fmt.Errorf("%w: description must be at most %d characters", experimentErrors.ErrInvalidRequest, maxDescriptionLen)
+	}
+	if len(e.Variants) < 2 || len(e.Variants) > maxVariants {
+		return fmt.Errorf("%w: an experiment needs between 2 and %d variants", experimentErrors.ErrInvalidRequest, maxVariants)
+	}
+	seen := make(map[string]struct{}, len(e.Variants))
+	for _, v := range e.Variants {
+		if !keyPattern.MatchString(v.Key) {
+			return fmt.Errorf("%w: variant key %q must be 1-64 lowercase letters, digits, '-' or '_'", experimentErrors.ErrInvalidRequest, v.Key)
+		}
+		if _, dup := seen[v.Key]; dup {
+			return fmt.Errorf("%w: duplicate variant %q", experimentErrors.ErrInvalidRequest, v.Key)
+		}
+		seen[v.Key] = struct{}{}
+		if v.Weight < 1 || v.Weight > bucketCount {
+			return fmt.Errorf("%w: variant weights must be between 1 and %d", experimentErrors.ErrInvalidRequest, bucketCount)
+		}
+	}
+	if e.Audience.Percent < 0 || e.Audience.Percent > 100 {
+		return fmt.Errorf("%w: audience percent must be between 0 and 100", experimentErrors.ErrInvalidRequest)
+	}
+	for _, id := range e.Audience.UserIds {
+		if _, err := uuid.FromString(id); err != nil {
+			return fmt.Errorf("%w: audience userIds must be valid UUIDs", experimentErrors.ErrInvalidRequest)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	experimentErrors "github.com/qolzam/telar/apps/api/experiments/errors"
+	"github.com/qolzam/telar/apps/api/experiments/models"
+	"github.com/qolzam/telar/apps/api/experiments/repository"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newRunningExperiment(key string, percent int) *models.Experiment {
+	return &models.Experiment{
+		Key:      key,
+		Status:   models.StatusRunning,
+		Variants: []models.Variant{{Key: "control", Weight: 50}, {Key: "treatment", Weight: 50}},
+		Audience: models.Audience{Percent: percent},
+	}
+}
+
+func defaultConfig() platformconfig.ExperimentsConfig {
+	return platformconfig.ExperimentsConfig{Enabled: true, CacheTTL: time.Minute}
+}
+
+func TestBucketing(t *testing.T) {
+	experiment := newRunningExperiment("feed-ranking", 100)
+
+	t.Run("assigns the same variant on every call", func(t *testing.T) {
+		userID := uuid.Must(uuid.NewV4()).String()
+		first := pickVariant(experiment, userID)
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, first, pickVariant(experiment, userID))
+		}
+	})
+
+	t.Run("splits users by weight", func(t *testing.T) {
+		weighted := newRunningExperiment("checkout", 100)
+		weighted.Variants = []models.Variant{{Key: "control", Weight: 90}, {Key: "treatment", Weight: 10}}
+
+		counts := map[string]int{}
+		for i := 0; i < 10000; i++ {
+			counts[pickVariant(weighted, uuid.Must(uuid.NewV4()).String())]++
+		}
+		assert.InDelta(t, 9000, counts["control"], 300)
+		assert.InDelta(t, 1000, counts["treatment"], 300)
+	})
+
+	t.Run("widening the audience keeps enrolled users", func(t *testing.T) {
+		narrow := newRunningExperiment("feed-ranking", 20)
+		wide := newRunningExperiment("feed-ranking", 60)
+		for i := 0; i < 1000; i++ {
+			userID := uuid.Must(uuid.NewV4()).String()
+			if inAudience(narrow, userID, "user") {
+				assert.True(t, inAudience(wide, userID, "user"))
+			}
+		}
+	})
+}
+
+func TestGetAssignments(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+
+	t.Run("filters by audience and caches running experiments", func(t *testing.T) {
+		everyone := newRunningExperiment("everyone", 100)
+		nobody := newRunningExperiment("nobody", 0)
+		admins := newRunningExperiment("admins", 100)
+		admins.Audience.Roles = []string{"admin"}
+		allowlisted := newRunningExperiment("allowlisted", 0)
+		allowlisted.Audience.UserIds = []string{userID.String()}
+
+		mockRepo := new(MockRepository)
+		mockRepo.On("List", ctx, models.StatusRunning).
+			Return([]*models.Experiment{everyone, nobody, admins, allowlisted}, nil).Once()
+
+		svc := NewService(mockRepo, defaultConfig())
+		assignments, err := svc.GetAssignments(ctx, userID, "user")
+		require.NoError(t, err)
+		assert.Len(t, assignments, 2)
+		assert.Contains(t, assignments, "everyone")
+		assert.Contains(t, assignments, "allowlisted")
+
+		again, err := svc.GetAssignments(ctx, userID, "user")
+		require.NoError(t, err)
+		assert.Equal(t, assignments, again)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("force control sends the audience to control", func(t *testing.T) {
+		experiment := newRunningExperiment("feed-ranking", 100)
+		experiment.Variants = []models.Variant{{Key: "control", Weight: 1}, {Key: "treatment", Weight: bucketCount}}
+
+		mockRepo := new(MockRepository)
+		mockRepo.On("List", ctx, models.StatusRunning).Return([]*models.Experiment{experiment}, nil)
+
+		cfg := defaultConfig()
+		cfg.ForceControl = []string{"feed-ranking"}
+		assignments, err := NewService(mockRepo, cfg).GetAssignments(ctx, userID, "user")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"feed-ranking": "control"}, assignments)
+	})
+
+	t.Run("disabled experiments assign nobody", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		cfg := defaultConfig()
+		cfg.Enabled = false
+
+		assignments, err := NewService(mockRepo, cfg).GetAssignments(ctx, userID, "user")
+		require.NoError(t, err)
+		assert.Empty(t, assignments)
+		mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("token assignments tolerate storage failures", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("List", ctx, models.StatusRunning).Return(nil, errors.New("db down"))
+
+		assert.Nil(t, NewService(mockRepo, defaultConfig()).AssignmentsForUser(ctx, userID, "user"))
+	})
+}
+
+func TestCreateAndUpdateExperiment(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("validates and creates drafts", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Create", ctx, mock.Anything).Return(nil).Once()
+		svc := NewService(mockRepo, defaultConfig())
+
+		experiment, err := svc.CreateExperiment(ctx, &models.CreateExperimentRequest{
+			Key:      "feed-ranking",
+			Variants: []models.Variant{{Key: "control", Weight: 1}, {Key: "treatment", Weight: 1}},
+			Audience: models.Audience{Percent: 10},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusDraft, experiment.Status)
+
+		_, err = svc.CreateExperiment(ctx, &models.CreateExperimentRequest{
+			Key:      "Feed Ranking",
+			Variants: []models.Variant{{Key: "control", Weight: 1}, {Key: "control", Weight: 1}},
+		})
+		assert.True(t, errors.Is(err, experimentErrors.ErrInvalidRequest))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("maps duplicate keys", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Create", ctx, mock.Anything).Return(repository.ErrDuplicateKey).Once()
+
+		_, err := NewService(mockRepo, defaultConfig()).CreateExperiment(ctx, &models.CreateExperimentRequest{
+			Key:      "feed-ranking",
+			Variants: []models.Variant{{Key: "control", Weight: 1}, {Key: "treatment", Weight: 1}},
+		})
+		assert.True(t, errors.Is(err, experimentErrors.ErrExperimentExists))
+	})
+
+	t.Run("locks variants once running", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetByKey", ctx, "feed-ranking").Return(newRunningExperiment("feed-ranking", 50), nil).Once()
+
+		variants := []models.Variant{{Key: "control", Weight: 1}, {Key: "other", Weight: 1}}
+		_, err := NewService(mockRepo, defaultConfig()).UpdateExperiment(ctx, "feed-ranking", &models.UpdateExperimentRequest{Variants: &variants})
+		assert.True(t, errors.Is(err, experimentErrors.ErrInvalidRequest))
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("pausing drops cached assignments", func(t *testing.T) {
+		userID := uuid.Must(uuid.NewV4())
+		experiment := newRunningExperiment("feed-ranking", 100)
+
+		mockRepo := new(MockRepository)
+		mockRepo.On("List", ctx, models.StatusRunning).Return([]*models.Experiment{experiment}, nil).Once()
+		mockRepo.On("GetByKey", ctx, "feed-ranking").Return(newRunningExperiment("feed-ranking", 100), nil).Once()
+		mockRepo.On("Update", ctx, mock.Anything).Return(nil).Once()
+		mockRepo.On("List", ctx, models.StatusRunning).Return([]*models.Experiment{}, nil).Once()
+
+		svc := NewService(mockRepo, defaultConfig())
+		before, err := svc.GetAssignments(ctx, userID, "user")
+		require.NoError(t, err)
+		assert.Len(t, before, 1)
+
+		paused := models.StatusPaused
+		_, err = svc.UpdateExperiment(ctx, "feed-ranking", &models.UpdateExperimentRequest{Status: &paused})
+		require.NoError(t, err)
+
+		after, err := svc.GetAssignments(ctx, userID, "user")
+		require.NoError(t, err)
+		assert.Empty(t, after)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/follows/errors"
+	"github.com/qolzam/telar/apps/api/follows/models"
+	"github.com/qolzam/telar/apps/api/follows/services"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+type FollowHandler struct {
+	service services.Service
+}
+
+func NewFollowHandler(service services.Service) *FollowHandler {
+	return &FollowHandler{service: service}
+}
+
+// Follow makes the current user follow another user.
+// Endpoint: POST /follow/:userId
+func (h *FollowHandler) Follow(c *fiber.Ctx) error {
+	return h.change(c, h.service.Follow, true)
+}
+
+// Unfollow stops the current user following another user.
+// Endpoint: DELETE /follow/:userId
+func (h *FollowHandler) Unfollow(c *fiber.Ctx) error {
+	return h.change(c, h.service.Unfollow, false)
+}
+
+func (h *FollowHandler) change(c *fiber.Ctx, apply func(ctx context.Context, followerID, followeeID uuid.UUID) error, following bool) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	targetID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+
+	if err := apply(c.Context(), user.UserID, targetID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(models.FollowStateResponse{Following: following})
+}
+
+// Status reports whether the current user follows another user.
+// Endpoint: GET /follow/:userId
+func (h *FollowHandler) Status(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	targetID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+
+	following, err := h.service.IsFollowing(c.Context(), user.UserID, targetID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(models.FollowStateResponse{Following: following})
+}
+
+// Followers lists the users following a user with cursor pagination.
+// Endpoint: GET /follow/:userId/followers?cursor=...&limit=...
+func (h *FollowHandler) Followers(c *fiber.Ctx) error {
+	return h.list(c, h.service.ListFollowers)
+}
+
+// Following lists the users a user follows with cursor pagination.
+// Endpoint: GET /follow/:userId/following?cursor=...&limit=...
+func (h *FollowHandler) Following(c *fiber.Ctx) error {
+	return h.list(c, h.service.ListFollowing)
+}
+
+func (h *FollowHandler) list(c *fiber.Ctx, load func(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.FollowListResponse, error)) error {
+	userID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+
+	resp, err := load(c.Context(), userID, c.Query("cursor"), c.QueryInt("limit", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
@@ -0,0 +1,13 @@
+-- One row per follower -> followee edge. Both directions are listed newest first,
+-- so each has an index matching its keyset order.
+CREATE TABLE IF NOT EXISTS follows (
+    follower_id UUID NOT NULL,
+    followee_id UUID NOT NULL,
+    created_date BIGINT NOT NULL,
+
+    PRIMARY KEY (follower_id, followee_id),
+    CHECK (follower_id <> followee_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_follows_follower ON follows(follower_id, created_date DESC, followee_id DESC);
+CREATE INDEX IF NOT EXISTS idx_follows_followee ON follows(followee_id, created_date DESC, follower_id DESC);
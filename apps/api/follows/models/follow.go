@@ -0,0 +1,33 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Follow is a directed edge: FollowerId follows FolloweeId
+type Follow struct {
+	FollowerId  uuid.UUID `json:"followerId"`
+	FolloweeId  uuid.UUID `json:"followeeId"`
+	CreatedDate int64     `json:"createdDate"`
+}
+
+// FollowUser is a profile listed as a follower or followee
+type FollowUser struct {
+	UserId       string `json:"userId"`
+	FullName     string `json:"fullName"`
+	SocialName   string `json:"socialName"`
+	Avatar       string `json:"avatar"`
+	FollowedDate int64  `json:"followedDate"` // When the edge was created
+}
+
+// FollowListResponse is a page of followers or followees
+type FollowListResponse struct {
+	Users      []FollowUser `json:"users"`
+	NextCursor string       `json:"nextCursor,omitempty"`
+	HasNext    bool         `json:"hasNext"`
+}
+
+// FollowStateResponse reports whether the current user follows the target after a change
+type FollowStateResponse struct {
+	Following bool `json:"following"`
+}
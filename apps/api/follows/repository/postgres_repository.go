@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/follows/models"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// followRow mirrors the follows table
+type followRow struct {
+	FollowerID  uuid.UUID `db:"follower_id"`
+	FolloweeID  uuid.UUID `db:"followee_id"`
+	CreatedDate int64     `db:"created_date"`
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) FollowRepository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) FollowRepository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// Follow inserts the edge and updates the denormalized profile counters in one statement,
+// so a repeated follow neither fails nor counts twice.
+func (r *postgresRepository) Follow(ctx context.Context, followerID, followeeID uuid.UUID, createdDate int64) (bool, error) {
+	query := fmt.Sprintf(`
+		WITH inserted AS (
+			INSERT INTO %[1]sfollows (follower_id, followee_id, created_date)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (follower_id, followee_id) DO NOTHING
+			RETURNING follower_id, followee_id
+		), following AS (
+			UPDATE %[1]sprofiles p SET follow_count = p.follow_count + 1
+			FROM inserted WHERE p.user_id = inserted.follower_id
+		), followers AS (
+			UPDATE %[1]sprofiles p SET follower_count = p.follower_count + 1
+			FROM inserted WHERE p.user_id = inserted.followee_id
+		)
+		SELECT COUNT(*) FROM inserted
+	`, r.schemaPrefix())
+
+	var inserted int
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &inserted, query, followerID, followeeID, createdDate); err != nil {
+		return false, fmt.Errorf("insert follow: %w", err)
+	}
+	return inserted > 0, nil
+}
+
+func (r *postgresRepository) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`
+		WITH deleted AS (
+			DELETE FROM %[1]sfollows
+			WHERE follower_id = $1 AND followee_id = $2
+			RETURNING follower_id, followee_id
+		), following AS (
+			UPDATE %[1]sprofiles p SET follow_count = GREATEST(p.follow_count - 1, 0)
+			FROM deleted WHERE p.user_id = deleted.follower_id
+		), followers AS (
+			UPDATE %[1]sprofiles p SET follower_count = GREATEST(p.follower_count - 1, 0)
+			FROM deleted WHERE p.user_id = deleted.followee_id
+		)
+		SELECT COUNT(*) FROM deleted
+	`, r.schemaPrefix())
+
+	var deleted int
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &deleted, query, followerID, followeeID); err != nil {
+		return false, fmt.Errorf("delete follow: %w", err)
+	}
+	return deleted > 0, nil
+}
+
+func (r *postgresRepository) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %sfollows WHERE follower_id = $1 AND followee_id = $2)`, r.schemaPrefix())
+
+	var following bool
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &following, query, followerID, followeeID); err != nil {
+		return false, fmt.Errorf("check follow: %w", err)
+	}
+	return following, nil
+}
+
+func (r *postgresRepository) ListFollowers(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Follow, string, error) {
+	return r.list(ctx, "followee_id", "follower_id", userID, cursor, limit)
+}
+
+func (r *postgresRepository) ListFollowing(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Follow, string, error) {
+	return r.list(ctx, "follower_id", "followee_id", userID, cursor, limit)
+}
+
+// DeleteByUser removes the edges and adjusts the counters in one statement, like Unfollow
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := fmt.Sprintf(`
+		WITH deleted AS (
+			DELETE FROM %[1]sfollows
+			WHERE follower_id = $1 OR followee_id = $1
+			RETURNING follower_id, followee_id
+		), following AS (
+			UPDATE %[1]sprofiles p SET follow_count = GREATEST(p.follow_count - d.edges, 0)
+			FROM (SELECT follower_id, COUNT(*) AS edges FROM deleted GROUP BY follower_id) d
+			WHERE p.user_id = d.follower_id
+		), followers AS (
+			UPDATE %[1]sprofiles p SET follower_count = GREATEST(p.follower_count - d.edges, 0)
+			FROM (SELECT followee_id, COUNT(*) AS edges FROM deleted GROUP BY followee_id) d
+			WHERE p.user_id = d.followee_id
+		)
+		SELECT COUNT(*) FROM deleted
+	`, r.schemaPrefix())
+
+	var deleted int
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &deleted, query, userID); err != nil {
+		return fmt.Errorf("delete follows of user: %w", err)
+	}
+	return nil
+}
+
+// list pages through the edges whose scopeColumn is userID, ordered by created_date and
+// the opposite end (otherColumn), newest first
+func (r *postgresRepository) list(ctx context.Context, scopeColumn, otherColumn string, userID uuid.UUID, cursor string, limit int) ([]models.Follow, string, error) {
+	query := fmt.Sprintf(`SELECT follower_id, followee_id, created_date FROM %sfollows WHERE %s = $1`, r.schemaPrefix(), scopeColumn)
+	args := []interface{}{userID}
+
+	if cursor != "" {
+		createdDate, otherID, err := decodeCursor(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+		query += fmt.Sprintf(` AND (created_date, %s) < ($2, $3)`, otherColumn)
+		args = append(args, createdDate, otherID)
+	}
+
+	query += fmt.Sprintf(` ORDER BY created_date DESC, %s DESC LIMIT $%d`, otherColumn, len(args)+1)
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+
+	var rows []followRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, args...); err != nil {
+		return nil, "", fmt.Errorf("list follows: %w", err)
+	}
+
+	hasNext := len(rows) > limit
+	if hasNext {
+		rows = rows[:limit]
+	}
+
+	follows := make([]models.Follow, 0, len(rows))
+	for _, row := range rows {
+		follows = append(follows, models.Follow{
+			FollowerId:  row.FollowerID,
+			FolloweeId:  row.FolloweeID,
+			CreatedDate: row.CreatedDate,
+		})
+	}
+
+	nextCursor := ""
+	if hasNext && len(rows) > 0 {
+		last := rows[len(rows)-1]
+		otherID := last.FollowerID
+		if otherColumn == "followee_id" {
+			otherID = last.FolloweeID
+		}
+		nextCursor = encodeCursor(last.CreatedDate, otherID)
+	}
+	return follows, nextCursor, nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
+
+// encodeCursor packs the last edge of a page as base64(created_date:uuid)
+func encodeCursor(createdDate int64, id uuid.UUID) string {
+	return base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", createdDate, id.String())))
+}
+
+func decodeCursor(cursor string) (int64, uuid.UUID, error) {
+	decoded, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, uuid.Nil, err
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 2 {
+		return 0, uuid.Nil, fmt.Errorf("expected created_date:uuid")
+	}
+	createdDate, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, uuid.Nil, err
+	}
+	id, err := uuid.FromString(parts[1])
+	if err != nil {
+		return 0, uuid.Nil, err
+	}
+	return createdDate, id, nil
+}
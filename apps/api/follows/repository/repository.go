@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/follows/models"
+)
+
+// ErrInvalidCursor is returned when a list cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// FollowRepository defines data access for the follow graph.
+type FollowRepository interface {
+	// Follow stores the edge and bumps both profiles' counters; returns true when the
+	// edge is new.
+	Follow(ctx context.Context, followerID, followeeID uuid.UUID, createdDate int64) (bool, error)
+
+	// Unfollow deletes the edge and lowers both profiles' counters; returns true when an
+	// edge was deleted.
+	Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+
+	// IsFollowing reports whether followerID follows followeeID.
+	IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+
+	// ListFollowers returns the edges into userID, newest first, with cursor pagination.
+	ListFollowers(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Follow, string, error)
+
+	// ListFollowing returns the edges out of userID, newest first, with cursor pagination.
+	ListFollowing(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Follow, string, error)
+
+	// DeleteByUser deletes every edge into and out of userID, lowering the counters of
+	// the profiles at the other ends.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
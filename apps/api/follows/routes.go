@@ -0,0 +1,39 @@
+package follows
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/follows/handlers"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+type Handlers struct {
+	FollowHandler *handlers.FollowHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires follow, unfollow and follower/following list endpoints.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/follow", createDualAuthMiddleware(routerCfg))
+	group.Get("/:userId/followers", handlers.FollowHandler.Followers)
+	group.Get("/:userId/following", handlers.FollowHandler.Following)
+	group.Get("/:userId", handlers.FollowHandler.Status)
+	group.Post("/:userId", handlers.FollowHandler.Follow)
+	group.Delete("/:userId", handlers.FollowHandler.Unfollow)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/follows/models"
+	"github.com/qolzam/telar/apps/api/follows/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the follows repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.FollowRepository = (*MockRepository)(nil)
+
+func (m *MockRepository) Follow(ctx context.Context, followerID, followeeID uuid.UUID, createdDate int64) (bool, error) {
+	args := m.Called(ctx, followerID, followeeID, createdDate)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, followerID, followeeID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ListFollowers(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Follow, string, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]models.Follow), args.String(1), args.Error(2)
+}
+
+func (m *MockRepository) ListFollowing(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Follow, string, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]models.Follow), args.String(1), args.Error(2)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
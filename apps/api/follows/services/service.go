@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	followErrors "github.com/qolzam/telar/apps/api/follows/errors"
+	"github.com/qolzam/telar/apps/api/follows/models"
+	"github.com/qolzam/telar/apps/api/follows/repository"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// ProfileLookup resolves the profiles shown in follow lists; the profile service satisfies it.
+type ProfileLookup interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*profileModels.Profile, error)
+	GetProfilesByIds(ctx context.Context, userIds []uuid.UUID) ([]*profileModels.Profile, error)
+}
+
+// Service defines follow operations.
+type Service interface {
+	// Follow makes followerID follow followeeID; following twice is not an error.
+	Follow(ctx context.Context, followerID, followeeID uuid.UUID) error
+
+	// Unfollow removes the edge; unfollowing someone not followed is not an error.
+	Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error
+
+	// IsFollowing reports whether followerID follows followeeID.
+	IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error)
+
+	// ListFollowers returns the users following userID, newest first.
+	ListFollowers(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.FollowListResponse, error)
+
+	// ListFollowing returns the users userID follows, newest first.
+	ListFollowing(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.FollowListResponse, error)
+}
+
+type service struct {
+	repo     repository.FollowRepository
+	profiles ProfileLookup
+	now      func() time.Time
+}
+
+// NewService constructs a follows service. profiles may be nil, in which case followees
+// are not checked to exist and lists carry user IDs only.
+func NewService(repo repository.FollowRepository, profiles ProfileLookup) Service {
+	return &service{repo: repo, profiles: profiles, now: time.Now}
+}
+
+func (s *service) Follow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	if err := validatePair(followerID, followeeID); err != nil {
+		return err
+	}
+	if s.profiles != nil {
+		if profile, err := s.profiles.GetProfile(ctx, followeeID); err != nil || profile == nil {
+			return followErrors.ErrUserNotFound
+		}
+	}
+
+	if _, err := s.repo.Follow(ctx, followerID, followeeID, s.now().UTC().UnixMilli()); err != nil {
+		return fmt.Errorf("%w: %v", followErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+func (s *service) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	if err := validatePair(followerID, followeeID); err != nil {
+		return err
+	}
+	if _, err := s.repo.Unfollow(ctx, followerID, followeeID); err != nil {
+		return fmt.Errorf("%w: %v", followErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+func (s *service) IsFollowing(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	following, err := s.repo.IsFollowing(ctx, followerID, followeeID)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", followErrors.ErrDatabaseOperation, err)
+	}
+	return following, nil
+}
+
+func (s *service) ListFollowers(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.FollowListResponse, error) {
+	follows, nextCursor, err := s.repo.ListFollowers(ctx, userID, cursor, clampLimit(limit))
+	if err != nil {
+		return nil, listError(err)
+	}
+	return s.hydrate(ctx, follows, nextCursor, func(f models.Follow) uuid.UUID { return f.FollowerId })
+}
+
+func (s *service) ListFollowing(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.FollowListResponse, error) {
+	follows, nextCursor, err := s.repo.ListFollowing(ctx, userID, cursor, clampLimit(limit))
+	if err != nil {
+		return nil, listError(err)
+	}
+	return s.hydrate(ctx, follows, nextCursor, func(f models.Follow) uuid.UUID { return f.FolloweeId })
+}
+
+// hydrate turns a page of edges into the listed users' profiles, in edge order. Users
+// whose profile is gone are skipped.
+func (s *service) hydrate(ctx context.Context, follows []models.Follow, nextCursor string, listed func(models.Follow) uuid.UUID) (*models.FollowListResponse, error) {
+	resp := &models.FollowListResponse{
+		Users:      make([]models.FollowUser, 0, len(follows)),
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+	}
+	if len(follows) == 0 {
+		return resp, nil
+	}
+
+	var profiles map[uuid.UUID]*profileModels.Profile
+	if s.profiles != nil {
+		ids := make([]uuid.UUID, 0, len(follows))
+		for _, f := range follows {
+			ids = append(ids, listed(f))
+		}
+		found, err := s.profiles.GetProfilesByIds(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("get profiles: %w", err)
+		}
+		profiles = make(map[uuid.UUID]*profileModels.Profile, len(found))
+		for _, p := range found {
+			profiles[p.ObjectId] = p
+		}
+	}
+
+	for _, f := range follows {
+		id := listed(f)
+		user := models.FollowUser{UserId: id.String(), FollowedDate: f.CreatedDate}
+		if profiles != nil {
+			profile, ok := profiles[id]
+			if !ok {
+				continue
+			}
+			user.FullName = profile.FullName
+			user.SocialName = profile.SocialName
+			user.Avatar = profile.Avatar
+		}
+		resp.Users = append(resp.Users, user)
+	}
+	return resp, nil
+}
+
+// listError maps a repository list failure to the service's errors
+func listError(err error) error {
+	if errors.Is(err, repository.ErrInvalidCursor) {
+		return fmt.Errorf("%w: %v", followErrors.ErrInvalidRequest, err)
+	}
+	return fmt.Errorf("%w: %v", followErrors.ErrDatabaseOperation, err)
+}
+
+func validatePair(followerID, followeeID uuid.UUID) error {
+	if followeeID == uuid.Nil {
+		return fmt.Errorf("%w: userId is required", followErrors.ErrInvalidRequest)
+	}
+	if followerID == followeeID {
+		return fmt.Errorf("%w: cannot follow yourself", followErrors.ErrInvalidRequest)
+	}
+	return nil
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+	return limit
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	followErrors "github.com/qolzam/telar/apps/api/follows/errors"
+	"github.com/qolzam/telar/apps/api/follows/models"
+	"github.com/qolzam/telar/apps/api/follows/repository"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProfiles map[uuid.UUID]*profileModels.Profile
+
+func (p stubProfiles) GetProfile(ctx context.Context, userID uuid.UUID) (*profileModels.Profile, error) {
+	if profile, ok := p[userID]; ok {
+		return profile, nil
+	}
+	return nil, fmt.Errorf("profile not found")
+}
+
+func (p stubProfiles) GetProfilesByIds(ctx context.Context, userIds []uuid.UUID) ([]*profileModels.Profile, error) {
+	found := make([]*profileModels.Profile, 0, len(userIds))
+	for _, id := range userIds {
+		if profile, ok := p[id]; ok {
+			found = append(found, profile)
+		}
+	}
+	return found, nil
+}
+
+func TestFollow(t *testing.T) {
+	ctx := context.Background()
+	follower := uuid.Must(uuid.NewV4())
+	followee := uuid.Must(uuid.NewV4())
+	profiles := stubProfiles{followee: {ObjectId: followee}}
+
+	t.Run("stores the edge and tolerates repeats", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Follow", ctx, follower, followee, mock.AnythingOfType("int64")).Return(true, nil).Once()
+		repo.On("Follow", ctx, follower, followee, mock.AnythingOfType("int64")).Return(false, nil).Once()
+
+		svc := NewService(repo, profiles)
+		require.NoError(t, svc.Follow(ctx, follower, followee))
+		require.NoError(t, svc.Follow(ctx, follower, followee))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects self follows and unknown users", func(t *testing.T) {
+		svc := NewService(new(MockRepository), profiles)
+
+		assert.ErrorIs(t, svc.Follow(ctx, follower, follower), followErrors.ErrInvalidRequest)
+		assert.ErrorIs(t, svc.Follow(ctx, follower, uuid.Must(uuid.NewV4())), followErrors.ErrUserNotFound)
+	})
+}
+
+func TestListFollowers(t *testing.T) {
+	ctx := context.Background()
+	user := uuid.Must(uuid.NewV4())
+	newer := uuid.Must(uuid.NewV4())
+	older := uuid.Must(uuid.NewV4())
+	gone := uuid.Must(uuid.NewV4())
+	profiles := stubProfiles{
+		newer: {ObjectId: newer, FullName: "Newer", SocialName: "newer"},
+		older: {ObjectId: older, FullName: "Older", SocialName: "older"},
+	}
+
+	t.Run("hydrates profiles in edge order", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListFollowers", ctx, user, "", defaultListLimit).Return([]models.Follow{
+			{FollowerId: newer, FolloweeId: user, CreatedDate: 3},
+			{FollowerId: gone, FolloweeId: user, CreatedDate: 2},
+			{FollowerId: older, FolloweeId: user, CreatedDate: 1},
+		}, "next", nil)
+
+		resp, err := NewService(repo, profiles).ListFollowers(ctx, user, "", 0)
+		require.NoError(t, err)
+		require.Len(t, resp.Users, 2)
+		assert.Equal(t, "newer", resp.Users[0].SocialName)
+		assert.Equal(t, int64(3), resp.Users[0].FollowedDate)
+		assert.Equal(t, older.String(), resp.Users[1].UserId)
+		assert.True(t, resp.HasNext)
+		assert.Equal(t, "next", resp.NextCursor)
+	})
+
+	t.Run("reports bad cursors as invalid requests", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListFollowers", ctx, user, "garbage", maxListLimit).Return(nil, "", repository.ErrInvalidCursor)
+
+		_, err := NewService(repo, profiles).ListFollowers(ctx, user, "garbage", 1000)
+		assert.ErrorIs(t, err, followErrors.ErrInvalidRequest)
+	})
+}
@@ -0,0 +1,95 @@
+package bootstrap
+
+import (
+	"os"
+
+	bookmarksRepository "github.com/qolzam/telar/apps/api/bookmarks/repository"
+	"github.com/qolzam/telar/apps/api/comments"
+	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
+	commentServices "github.com/qolzam/telar/apps/api/comments/services"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/posts"
+	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
+	postsServices "github.com/qolzam/telar/apps/api/posts/services"
+	"github.com/qolzam/telar/apps/api/profile"
+	profileServices "github.com/qolzam/telar/apps/api/profile/services"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	votesRepository "github.com/qolzam/telar/apps/api/votes/repository"
+)
+
+// Microservices reports whether cross-service calls go over gRPC
+// (DEPLOYMENT_MODE=microservices) rather than direct calls in this process.
+func Microservices() bool {
+	return os.Getenv("DEPLOYMENT_MODE") == "microservices"
+}
+
+// envOr returns the environment variable key, or fallback when it is empty
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// NewProfileClient returns the client other modules use to reach profiles.
+func NewProfileClient(cfg *platformconfig.Config, profiles profileServices.ProfileService) (profileServices.ProfileServiceClient, error) {
+	if !Microservices() {
+		log.Info("Wiring profile service using direct call adapter")
+		return profile.NewDirectCallAdapter(profiles), nil
+	}
+
+	log.Info("Wiring profile service using gRPC adapter")
+	warnUnauthenticatedGRPC(cfg.GRPC)
+	addr := envOr("PROFILE_SERVICE_GRPC_ADDR", "localhost:50051")
+	client, err := profile.NewGrpcAdapter(addr, cfg.GRPC)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Profile gRPC client connected to %s", addr)
+	return client, nil
+}
+
+// NewCommentCounter returns the comment counter the posts module reads.
+func NewCommentCounter(infra *Infra) (sharedInterfaces.CommentCounter, error) {
+	if !Microservices() {
+		// Counting needs no post stats updater, so a bare comments service will do
+		service := commentServices.NewCommentService(
+			commentRepository.NewPostgresCommentRepository(infra.DB),
+			postsRepository.NewPostgresRepository(infra.DB),
+			infra.Config, nil)
+		return comments.NewDirectCallCounter(service), nil
+	}
+
+	warnUnauthenticatedGRPC(infra.Config.GRPC)
+	addr := envOr("COMMENTS_SERVICE_GRPC_ADDR", "localhost:50052")
+	counter, err := comments.NewGrpcCounter(addr, infra.Config.GRPC)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Comments gRPC client connected to %s", addr)
+	return counter, nil
+}
+
+// NewPostStatsUpdater returns the post stats updater the comments module writes to.
+func NewPostStatsUpdater(infra *Infra) (sharedInterfaces.PostStatsUpdater, error) {
+	if !Microservices() {
+		// Updating stats needs no comment counter, so a bare posts service will do
+		service := postsServices.NewPostService(
+			postsRepository.NewPostgresRepository(infra.DB),
+			votesRepository.NewPostgresVoteRepository(infra.DB),
+			bookmarksRepository.NewPostgresRepository(infra.DB),
+			infra.Config, nil,
+			commentRepository.NewPostgresCommentRepository(infra.DB))
+		return posts.NewDirectCallStatsUpdater(service), nil
+	}
+
+	warnUnauthenticatedGRPC(infra.Config.GRPC)
+	addr := envOr("POSTS_SERVICE_GRPC_ADDR", "localhost:50053")
+	updater, err := posts.NewGrpcStatsUpdater(addr, infra.Config.GRPC)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Posts gRPC client connected to %s", addr)
+	return updater, nil
+}
@@ -0,0 +1,278 @@
+// Package authmodule wires the auth use cases for the monolith and the auth service.
+// It is kept apart from package bootstrap so binaries without auth do not link it.
+package authmodule
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	achievementsRepository "github.com/qolzam/telar/apps/api/achievements/repository"
+	analyticsRepository "github.com/qolzam/telar/apps/api/analytics/repository"
+	"github.com/qolzam/telar/apps/api/auth"
+	accountsUC "github.com/qolzam/telar/apps/api/auth/accounts"
+	adminUC "github.com/qolzam/telar/apps/api/auth/admin"
+	adminRepository "github.com/qolzam/telar/apps/api/auth/admin/repository"
+	jwksUC "github.com/qolzam/telar/apps/api/auth/jwks"
+	loginUC "github.com/qolzam/telar/apps/api/auth/login"
+	mfaUC "github.com/qolzam/telar/apps/api/auth/mfa"
+	oauthUC "github.com/qolzam/telar/apps/api/auth/oauth"
+	passwordUC "github.com/qolzam/telar/apps/api/auth/password"
+	authRepository "github.com/qolzam/telar/apps/api/auth/repository"
+	signupUC "github.com/qolzam/telar/apps/api/auth/signup"
+	verifyUC "github.com/qolzam/telar/apps/api/auth/verification"
+	calendarRepository "github.com/qolzam/telar/apps/api/calendar/repository"
+	commentsRepository "github.com/qolzam/telar/apps/api/comments/repository"
+	communitiesRepository "github.com/qolzam/telar/apps/api/communities/repository"
+	delegationsRepository "github.com/qolzam/telar/apps/api/delegations/repository"
+	followsRepository "github.com/qolzam/telar/apps/api/follows/repository"
+	"github.com/qolzam/telar/apps/api/internal/bootstrap"
+	"github.com/qolzam/telar/apps/api/internal/database/jsonbmigrate"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/platform"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	platformemail "github.com/qolzam/telar/apps/api/internal/platform/email"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
+	"github.com/qolzam/telar/apps/api/internal/recaptcha"
+	"github.com/qolzam/telar/apps/api/internal/testutil"
+	leaderboardsRepository "github.com/qolzam/telar/apps/api/leaderboards/repository"
+	membershipRepository "github.com/qolzam/telar/apps/api/membership/repository"
+	mentionsRepository "github.com/qolzam/telar/apps/api/mentions/repository"
+	moderationRepository "github.com/qolzam/telar/apps/api/moderation/repository"
+	signupOrchestrator "github.com/qolzam/telar/apps/api/orchestrator/signup"
+	userdeletionOrchestrator "github.com/qolzam/telar/apps/api/orchestrator/userdeletion"
+	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
+	profileRepository "github.com/qolzam/telar/apps/api/profile/repository"
+	profileServices "github.com/qolzam/telar/apps/api/profile/services"
+	rulesRepository "github.com/qolzam/telar/apps/api/rules/repository"
+	"github.com/qolzam/telar/apps/api/shared/interfaces"
+	streaksRepository "github.com/qolzam/telar/apps/api/streaks/repository"
+	supportersRepository "github.com/qolzam/telar/apps/api/supporters/repository"
+	thanksRepository "github.com/qolzam/telar/apps/api/thanks/repository"
+	tipsRepository "github.com/qolzam/telar/apps/api/tips/repository"
+	votesRepository "github.com/qolzam/telar/apps/api/votes/repository"
+)
+
+// Deps are the auth module's dependencies on other modules.
+type Deps struct {
+	// Profiles creates and reads the profiles of signed-up users
+	Profiles profileServices.ProfileServiceClient
+
+	// Experiments, when set, embeds the user's experiment variants in tokens
+	Experiments interfaces.ExperimentAssigner
+
+	// Outbox, when set, announces completed signups and deleted accounts as outbox events
+	Outbox interfaces.EventOutbox
+}
+
+// Module serves signup, login, verification, password reset, OAuth, JWKS, linked
+// accounts, second factors and user administration.
+type Module struct {
+	handlers *auth.AuthHandlers
+
+	// Suspender lets moderators suspend the authors of reported content
+	Suspender interfaces.UserSuspender
+}
+
+// New wires the auth use cases. It fails closed when reCAPTCHA is neither configured
+// nor explicitly disabled.
+func New(ctx context.Context, infra *bootstrap.Infra, deps Deps) (*Module, error) {
+	cfg := infra.Config
+	jwtConfig := platformconfig.JWTConfig{PublicKey: cfg.JWT.PublicKey, PrivateKey: cfg.JWT.PrivateKey}
+	hmacConfig := platformconfig.HMACConfig{Secret: cfg.HMAC.Secret}
+	privateKey := cfg.JWT.PrivateKey
+	webDomain := cfg.App.WebDomain
+
+	baseService, err := platform.NewBaseService(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create base service: %w", err)
+	}
+	if collections := cfg.DataMigration.DualWrite; len(collections) > 0 {
+		// Mirror the legacy collections into their typed tables ahead of the cutover
+		mappings, err := jsonbmigrate.Lookup(collections)
+		if err != nil {
+			return nil, fmt.Errorf("configure dual writes: %w", err)
+		}
+		baseService.Repository = jsonbmigrate.NewDualWriteRepository(baseService.Repository, infra.DB.DB(), mappings)
+	}
+	emailSender, err := newEmailSender(cfg.Email)
+	if err != nil {
+		log.Warn("Failed to initialize SMTP sender: %v", err)
+	}
+
+	authRepo := authRepository.NewPostgresAuthRepository(infra.DB)
+	verifRepo := authRepository.NewPostgresVerificationRepository(infra.DB)
+	profileRepo := profileRepository.NewPostgresProfileRepository(infra.DB)
+
+	signupService := signupUC.NewService(verifRepo, &signupUC.ServiceConfig{
+		JWTConfig:  jwtConfig,
+		HMACConfig: hmacConfig,
+		AppConfig:  platformconfig.AppConfig{WebDomain: webDomain},
+	})
+	if emailSender != nil {
+		signupService = signupService.WithEmailSender(emailSender)
+	}
+	// Email signups are screened against the domains admins allow and deny
+	signupService = signupService.WithEmailDomains(infra.Settings)
+	recaptchaVerifier, err := newRecaptchaVerifier(cfg.Security)
+	if err != nil {
+		return nil, err
+	}
+
+	adminService := adminUC.NewService(authRepo, profileRepo, adminRepository.NewPostgresAdminRepository(infra.DB), privateKey, cfg)
+	userDeleter := userdeletionOrchestrator.NewService(
+		authRepo,
+		profileRepo,
+		postsRepository.NewPostgresRepository(infra.DB),
+		commentsRepository.NewPostgresCommentRepository(infra.DB),
+		userdeletionOrchestrator.Related{
+			Follows:      followsRepository.NewPostgresRepository(infra.DB),
+			Delegations:  delegationsRepository.NewPostgresRepository(infra.DB),
+			Tips:         tipsRepository.NewPostgresRepository(infra.DB),
+			Thanks:       thanksRepository.NewPostgresRepository(infra.DB),
+			Mentions:     mentionsRepository.NewPostgresRepository(infra.DB),
+			Communities:  communitiesRepository.NewPostgresRepository(infra.DB),
+			Votes:        votesRepository.NewPostgresVoteRepository(infra.DB),
+			ProfileViews: profileRepository.NewPostgresViewRepository(infra.DB),
+			Propagation:  propagation.NewPostgresRepository(infra.DB),
+			Calendar:     calendarRepository.NewPostgresRepository(infra.DB),
+			Streaks:      streaksRepository.NewPostgresRepository(infra.DB),
+			Badges:       achievementsRepository.NewPostgresRepository(infra.DB),
+			Leaderboards: leaderboardsRepository.NewPostgresRepository(infra.DB),
+			Analytics:    analyticsRepository.NewPostgresRepository(infra.DB),
+			Membership:   membershipRepository.NewPostgresRepository(infra.DB),
+			Rules:        rulesRepository.NewPostgresRepository(infra.DB),
+			Supporters:   supportersRepository.NewPostgresRepository(infra.DB),
+			Moderation:   moderationRepository.NewPostgresRepository(infra.DB),
+		},
+	)
+	userDeleter.SetEventOutbox(deps.Outbox)
+	adminService.SetUserDeleter(userDeleter)
+
+	loginService := loginUC.NewServiceWithProfileCreator(authRepo, deps.Profiles, &loginUC.ServiceConfig{
+		JWTConfig:  jwtConfig,
+		HMACConfig: hmacConfig,
+	})
+
+	// Second factor; consulted by user and admin logins and when linking accounts
+	mfaService := mfaUC.NewService(authRepository.NewPostgresMFARepository(infra.DB), authRepo, &mfaUC.ServiceConfig{
+		Issuer:          cfg.MFA.Issuer,
+		ChallengeSecret: cfg.HMAC.Secret,
+		ChallengeTTL:    cfg.MFA.ChallengeTTL,
+	})
+	adminService.SetSecondFactor(mfaService)
+
+	// Linked accounts record login sessions and issue tokens when switching accounts
+	accountsService := accountsUC.NewService(authRepo, authRepository.NewPostgresAccountRepository(infra.DB), deps.Profiles, &accountsUC.ServiceConfig{
+		WebDomain:   webDomain,
+		PrivateKey:  privateKey,
+		Experiments: deps.Experiments,
+		MFA:         mfaService,
+	})
+
+	verifyService := verifyUC.NewServiceWithRepositoriesAndKeys(
+		verifRepo,
+		authRepo,
+		&verifyUC.ServiceConfig{
+			JWTConfig:  jwtConfig,
+			HMACConfig: hmacConfig,
+			AppConfig:  platformconfig.AppConfig{OrgName: "Telar", WebDomain: webDomain},
+		},
+		privateKey,
+		cfg.App.OrgName,
+		cfg.App.WebDomain,
+		deps.Profiles,
+	)
+	signupCompleter := signupOrchestrator.NewService(authRepo, profileRepo, verifRepo)
+	signupCompleter.SetEventOutbox(deps.Outbox)
+	verifyService.SetSignupOrchestrator(signupCompleter)
+
+	passwordService := passwordUC.NewServiceWithRepositories(authRepo, verifRepo, &passwordUC.ServiceConfig{
+		JWTConfig:  jwtConfig,
+		HMACConfig: hmacConfig,
+		EmailConfig: platformconfig.EmailConfig{
+			SMTPEmail:    cfg.Email.SMTPEmail,
+			RefEmail:     cfg.Email.RefEmail,
+			RefEmailPass: cfg.Email.RefEmailPass,
+		},
+		AppConfig: platformconfig.AppConfig{WebDomain: webDomain},
+	})
+	if emailSender != nil {
+		passwordService = passwordService.WithEmailSender(emailSender)
+	}
+	passwordHandler, err := passwordUC.NewPasswordHandler(passwordService, &passwordUC.HandlerConfig{
+		RefEmail:     cfg.Email.RefEmail,
+		RefEmailPass: cfg.Email.RefEmailPass,
+		SMTPEmail:    cfg.Email.SMTPEmail,
+		WebDomain:    webDomain,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create password handler: %w", err)
+	}
+
+	oauthService := oauthUC.NewService(baseService, &oauthUC.ServiceConfig{
+		OAuthConfig: oauthUC.NewOAuthConfig(webDomain, "", "", "", ""),
+		JWTConfig:   jwtConfig,
+		HMACConfig:  hmacConfig,
+		AppConfig:   platformconfig.AppConfig{WebDomain: webDomain},
+	})
+
+	return &Module{Suspender: adminService, handlers: &auth.AuthHandlers{
+		AdminHandler:  adminUC.NewAdminHandler(adminService, jwtConfig, hmacConfig),
+		SignupHandler: signupUC.NewHandler(signupService, recaptchaVerifier, privateKey),
+		LoginHandler: loginUC.NewHandler(loginService, &loginUC.HandlerConfig{
+			WebDomain:           webDomain,
+			PrivateKey:          privateKey,
+			HeaderCookieName:    "telar-header",
+			PayloadCookieName:   "telar-payload",
+			SignatureCookieName: "telar-signature",
+			Experiments:         deps.Experiments,
+			Sessions:            accountsService,
+			MFA:                 mfaService,
+		}),
+		VerifyHandler: verifyUC.NewHandler(verifyService, &verifyUC.HandlerConfig{
+			PublicKey: cfg.JWT.PublicKey,
+			OrgName:   "Telar",
+			WebDomain: webDomain,
+		}),
+		PasswordHandler: passwordHandler,
+		OAuthHandler: oauthUC.NewHandler(oauthService, &oauthUC.HandlerConfig{
+			WebDomain:  webDomain,
+			PrivateKey: privateKey,
+		}, oauthUC.NewMemoryStateStore()),
+		JWKSHandler:     jwksUC.NewHandler(cfg.JWT.PublicKey, "telar-auth-key-1"),
+		AccountsHandler: accountsUC.NewHandler(accountsService),
+		MFAHandler:      mfaUC.NewHandler(mfaService),
+	}}, nil
+}
+
+// Register adds the auth routes.
+func (m *Module) Register(app *fiber.App, cfg *platformconfig.Config) {
+	auth.RegisterRoutes(app, m.handlers, cfg)
+}
+
+// newEmailSender returns nil when no SMTP host is configured
+func newEmailSender(cfg platformconfig.EmailConfig) (*platformemail.SMTPSender, error) {
+	if cfg.SMTPHost == "" {
+		return nil, nil
+	}
+	return platformemail.NewSMTPSender(cfg.SMTPHost, fmt.Sprintf("%d", cfg.SMTPPort), cfg.SMTPUser, cfg.SMTPPass)
+}
+
+// SECURITY: Fail Closed - a missing RECAPTCHA_KEY only starts the server when
+// reCAPTCHA is explicitly disabled (dev/test mode).
+func newRecaptchaVerifier(cfg platformconfig.SecurityConfig) (recaptcha.Verifier, error) {
+	if cfg.RecaptchaKey == "" {
+		if !cfg.RecaptchaDisabled {
+			return nil, fmt.Errorf("SECURITY ERROR: RECAPTCHA_KEY is missing. Configure it or set RECAPTCHA_DISABLED=true in config")
+		}
+		log.Warn("SECURITY WARNING: Recaptcha is explicitly disabled via configuration. Using FakeVerifier.")
+		return &testutil.FakeRecaptchaVerifier{ShouldSucceed: true}, nil
+	}
+
+	verifier, err := recaptcha.NewGoogleVerifier(cfg.RecaptchaKey)
+	if err != nil {
+		return nil, fmt.Errorf("initialize Google Recaptcha: %w", err)
+	}
+	return verifier, nil
+}
@@ -0,0 +1,204 @@
+// Package bootstrap wires the API modules into runnable binaries. The monolith and
+// every service binary build their dependencies through the constructors here, so a
+// module is wired the same way wherever it runs.
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	authRepository "github.com/qolzam/telar/apps/api/auth/repository"
+	"github.com/qolzam/telar/apps/api/auth/revocation"
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	dbi "github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/qolzam/telar/apps/api/internal/database/observability"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/internal/database/schema"
+	"github.com/qolzam/telar/apps/api/internal/hooks"
+	"github.com/qolzam/telar/apps/api/internal/middleware/authjwt"
+	"github.com/qolzam/telar/apps/api/internal/middleware/ratelimit"
+	"github.com/qolzam/telar/apps/api/internal/middleware/readonly"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
+	settingsRepository "github.com/qolzam/telar/apps/api/settings/repository"
+	settingsServices "github.com/qolzam/telar/apps/api/settings/services"
+	webhooksRepository "github.com/qolzam/telar/apps/api/webhooks/repository"
+)
+
+// Infra is what every module shares: configuration, the database pool and the
+// settings service, which also carries the admin read-only switch.
+type Infra struct {
+	Config   *platformconfig.Config
+	DB       *postgres.Client
+	Settings settingsServices.Service
+}
+
+// LoadConfig reads the platform config from the environment and applies the
+// process-wide parts of it (logging and its request trace buffer, extension hooks, read-only mode, the cache
+// backend, database metrics and the rate limit store) before any service can
+// reach them.
+func LoadConfig() (*platformconfig.Config, error) {
+	cfg, err := platformconfig.LoadFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("load platform config: %w", err)
+	}
+	log.Setup(os.Stdout, cfg.Log.Format, cfg.Log.Level)
+	log.Retain(cfg.Log.TraceBuffer)
+	if err := hooks.Configure(cfg.Hooks); err != nil {
+		return nil, fmt.Errorf("configure hooks: %w", err)
+	}
+	readonly.Configure(cfg.ReadOnly)
+	cache.Configure(cfg.Cache)
+	if cfg.Metrics.Enabled {
+		observability.SetQueryObserver(metrics.Default().ObserveQuery)
+	}
+	if err := configureRateLimits(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// configureRateLimits points every rate limiter at Redis when the deployment shares
+// its counts between instances. It runs before any module registers its routes.
+func configureRateLimits(cfg *platformconfig.Config) error {
+	if cfg.RateLimits.Storage != "redis" {
+		return nil
+	}
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Cache.Redis.Address,
+		Password: cfg.Cache.Redis.Password,
+		DB:       cfg.Cache.Redis.Database,
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return fmt.Errorf("connect rate limit store: %w", err)
+	}
+	ratelimit.SetStore(ratelimit.NewRedisStore(client, cfg.Cache.Prefix+"ratelimit:"))
+	return nil
+}
+
+// NewPostgresConfig maps the platform database settings to a client config. With
+// module schemas configured, the search path spans all of them.
+func NewPostgresConfig(cfg *platformconfig.Config) *dbi.PostgreSQLConfig {
+	return &dbi.PostgreSQLConfig{
+		Host:               cfg.Database.Postgres.Host,
+		Port:               cfg.Database.Postgres.Port,
+		Username:           cfg.Database.Postgres.Username,
+		Password:           cfg.Database.Postgres.Password,
+		Database:           cfg.Database.Postgres.Database,
+		Schema:             SearchPath(cfg, ""),
+		SSLMode:            cfg.Database.Postgres.SSLMode,
+		MaxOpenConnections: cfg.Database.Postgres.MaxOpenConns,
+		MaxIdleConnections: cfg.Database.Postgres.MaxIdleConns,
+		MaxLifetime:        int(cfg.Database.Postgres.ConnMaxLifetime.Seconds()),
+		ConnectTimeout:     10,
+	}
+}
+
+// NewModulePostgresConfig is the client config of a service binary that runs one
+// module: it connects as the module's role, when one is configured, and resolves
+// unqualified table names in the module's schema first.
+func NewModulePostgresConfig(cfg *platformconfig.Config, module string) *dbi.PostgreSQLConfig {
+	pgConfig := NewPostgresConfig(cfg)
+	pgConfig.Schema = SearchPath(cfg, module)
+	if moduleConfig := cfg.Database.Postgres.Modules[module]; moduleConfig.Username != "" {
+		pgConfig.Username = moduleConfig.Username
+		pgConfig.Password = moduleConfig.Password
+	}
+	return pgConfig
+}
+
+// SearchPath is the schema search path for connections acting for module, or for
+// every module when module is empty: the module's own schema, the other module
+// schemas and then the shared public schema, where the outbox, settings and the
+// modules without a schema of their own live. It is empty, leaving the server
+// default, when no module has a schema.
+func SearchPath(cfg *platformconfig.Config, module string) string {
+	modules := cfg.Database.Postgres.Modules
+	var schemas []string
+	add := func(schema string) {
+		for _, existing := range schemas {
+			if existing == schema {
+				return
+			}
+		}
+		schemas = append(schemas, schema)
+	}
+
+	if schema := modules[module].Schema; schema != "" {
+		add(schema)
+	}
+	for _, name := range platformconfig.PostgresModules {
+		if schema := modules[name].Schema; schema != "" {
+			add(schema)
+		}
+	}
+	if len(schemas) == 0 {
+		return ""
+	}
+	add("public")
+	return strings.Join(schemas, ",")
+}
+
+// NewPostgresClient opens the shared connection pool.
+func NewPostgresClient(ctx context.Context, cfg *platformconfig.Config) (*postgres.Client, error) {
+	return connectPostgres(ctx, NewPostgresConfig(cfg))
+}
+
+// NewModulePostgresClient opens a connection pool for one module's service binary.
+func NewModulePostgresClient(ctx context.Context, cfg *platformconfig.Config, module string) (*postgres.Client, error) {
+	return connectPostgres(ctx, NewModulePostgresConfig(cfg, module))
+}
+
+func connectPostgres(ctx context.Context, pgConfig *dbi.PostgreSQLConfig) (*postgres.Client, error) {
+	client, err := postgres.NewClient(ctx, pgConfig, pgConfig.Database)
+	if err != nil {
+		return nil, fmt.Errorf("create postgres client: %w", err)
+	}
+	return client, nil
+}
+
+// NewInfra connects to the database, refuses to serve (or goes read-only) when the
+// schema does not match this build, follows the admin read-only switch, records
+// webhook deliveries and checks tokens against account suspensions and revocations.
+func NewInfra(ctx context.Context, cfg *platformconfig.Config) (*Infra, error) {
+	client, err := NewPostgresClient(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return newInfra(ctx, cfg, client)
+}
+
+// NewModuleInfra is NewInfra for a service binary that runs module, connected with
+// the module's schema and credentials.
+func NewModuleInfra(ctx context.Context, cfg *platformconfig.Config, module string) (*Infra, error) {
+	client, err := NewModulePostgresClient(ctx, cfg, module)
+	if err != nil {
+		return nil, err
+	}
+	return newInfra(ctx, cfg, client)
+}
+
+func newInfra(ctx context.Context, cfg *platformconfig.Config, client *postgres.Client) (*Infra, error) {
+	if err := schema.Enforce(ctx, client.DB(), cfg.Schema); err != nil {
+		return nil, fmt.Errorf("schema compatibility check failed: %w", err)
+	}
+
+	// Every binary that runs hooks records its webhook deliveries for the admin tooling
+	hooks.SetDeliveryLog(webhooksRepository.NewPostgresRepository(client))
+
+	// Tokens of suspended users, and tokens issued before a role change, are refused everywhere
+	authjwt.SetAccountChecker(revocation.NewChecker(authRepository.NewPostgresAuthRepository(client), revocation.DefaultTTL))
+
+	settings := settingsServices.NewService(settingsRepository.NewPostgresRepository(client), cfg.App)
+	readonly.Watch(ctx, cfg.ReadOnly.PollInterval, settings.LoadReadOnly)
+
+	return &Infra{Config: cfg, DB: client, Settings: settings}, nil
+}
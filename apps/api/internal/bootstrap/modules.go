@@ -0,0 +1,531 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/achievements"
+	achievementsHandlers "github.com/qolzam/telar/apps/api/achievements/handlers"
+	achievementsModels "github.com/qolzam/telar/apps/api/achievements/models"
+	achievementsRepository "github.com/qolzam/telar/apps/api/achievements/repository"
+	achievementsServices "github.com/qolzam/telar/apps/api/achievements/services"
+	"github.com/qolzam/telar/apps/api/analytics"
+	analyticsHandlers "github.com/qolzam/telar/apps/api/analytics/handlers"
+	analyticsRepository "github.com/qolzam/telar/apps/api/analytics/repository"
+	analyticsServices "github.com/qolzam/telar/apps/api/analytics/services"
+	"github.com/qolzam/telar/apps/api/bookmarks"
+	bookmarksHandlers "github.com/qolzam/telar/apps/api/bookmarks/handlers"
+	bookmarksRepository "github.com/qolzam/telar/apps/api/bookmarks/repository"
+	bookmarksServices "github.com/qolzam/telar/apps/api/bookmarks/services"
+	"github.com/qolzam/telar/apps/api/calendar"
+	calendarHandlers "github.com/qolzam/telar/apps/api/calendar/handlers"
+	calendarRepository "github.com/qolzam/telar/apps/api/calendar/repository"
+	calendarServices "github.com/qolzam/telar/apps/api/calendar/services"
+	"github.com/qolzam/telar/apps/api/comments"
+	"github.com/qolzam/telar/apps/api/communities"
+	communitiesHandlers "github.com/qolzam/telar/apps/api/communities/handlers"
+	communitiesServices "github.com/qolzam/telar/apps/api/communities/services"
+	"github.com/qolzam/telar/apps/api/deltasync"
+	deltasyncHandlers "github.com/qolzam/telar/apps/api/deltasync/handlers"
+	deltasyncServices "github.com/qolzam/telar/apps/api/deltasync/services"
+	"github.com/qolzam/telar/apps/api/experiments"
+	experimentsHandlers "github.com/qolzam/telar/apps/api/experiments/handlers"
+	experimentsRepository "github.com/qolzam/telar/apps/api/experiments/repository"
+	experimentsServices "github.com/qolzam/telar/apps/api/experiments/services"
+	"github.com/qolzam/telar/apps/api/follows"
+	followsHandlers "github.com/qolzam/telar/apps/api/follows/handlers"
+	followsRepository "github.com/qolzam/telar/apps/api/follows/repository"
+	followsServices "github.com/qolzam/telar/apps/api/follows/services"
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/leaderboards"
+	leaderboardsHandlers "github.com/qolzam/telar/apps/api/leaderboards/handlers"
+	leaderboardsRepository "github.com/qolzam/telar/apps/api/leaderboards/repository"
+	leaderboardsServices "github.com/qolzam/telar/apps/api/leaderboards/services"
+	"github.com/qolzam/telar/apps/api/membership"
+	membershipHandlers "github.com/qolzam/telar/apps/api/membership/handlers"
+	membershipRepository "github.com/qolzam/telar/apps/api/membership/repository"
+	membershipServices "github.com/qolzam/telar/apps/api/membership/services"
+	"github.com/qolzam/telar/apps/api/mentions"
+	mentionsHandlers "github.com/qolzam/telar/apps/api/mentions/handlers"
+	mentionsRepository "github.com/qolzam/telar/apps/api/mentions/repository"
+	mentionsServices "github.com/qolzam/telar/apps/api/mentions/services"
+	"github.com/qolzam/telar/apps/api/moderation"
+	moderationHandlers "github.com/qolzam/telar/apps/api/moderation/handlers"
+	moderationRepository "github.com/qolzam/telar/apps/api/moderation/repository"
+	moderationServices "github.com/qolzam/telar/apps/api/moderation/services"
+	"github.com/qolzam/telar/apps/api/posts"
+	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
+	postsServices "github.com/qolzam/telar/apps/api/posts/services"
+	profileServices "github.com/qolzam/telar/apps/api/profile/services"
+	"github.com/qolzam/telar/apps/api/realtime"
+	realtimeHandlers "github.com/qolzam/telar/apps/api/realtime/handlers"
+	realtimeServices "github.com/qolzam/telar/apps/api/realtime/services"
+	"github.com/qolzam/telar/apps/api/rules"
+	rulesHandlers "github.com/qolzam/telar/apps/api/rules/handlers"
+	rulesRepository "github.com/qolzam/telar/apps/api/rules/repository"
+	rulesServices "github.com/qolzam/telar/apps/api/rules/services"
+	"github.com/qolzam/telar/apps/api/settings"
+	settingsHandlers "github.com/qolzam/telar/apps/api/settings/handlers"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/qolzam/telar/apps/api/storage"
+	storageHandlers "github.com/qolzam/telar/apps/api/storage/handlers"
+	storagePipeline "github.com/qolzam/telar/apps/api/storage/pipeline"
+	storageProvider "github.com/qolzam/telar/apps/api/storage/provider"
+	storageRepository "github.com/qolzam/telar/apps/api/storage/repository"
+	storageServices "github.com/qolzam/telar/apps/api/storage/services"
+	"github.com/qolzam/telar/apps/api/streaks"
+	streaksHandlers "github.com/qolzam/telar/apps/api/streaks/handlers"
+	streaksRepository "github.com/qolzam/telar/apps/api/streaks/repository"
+	streaksServices "github.com/qolzam/telar/apps/api/streaks/services"
+	"github.com/qolzam/telar/apps/api/system"
+	systemHandlers "github.com/qolzam/telar/apps/api/system/handlers"
+	systemServices "github.com/qolzam/telar/apps/api/system/services"
+	"github.com/qolzam/telar/apps/api/thanks"
+	thanksHandlers "github.com/qolzam/telar/apps/api/thanks/handlers"
+	thanksRepository "github.com/qolzam/telar/apps/api/thanks/repository"
+	thanksServices "github.com/qolzam/telar/apps/api/thanks/services"
+	"github.com/qolzam/telar/apps/api/votes"
+	votesHandlers "github.com/qolzam/telar/apps/api/votes/handlers"
+	votesRepository "github.com/qolzam/telar/apps/api/votes/repository"
+	votesServices "github.com/qolzam/telar/apps/api/votes/services"
+	"github.com/qolzam/telar/apps/api/webhooks"
+	webhooksHandlers "github.com/qolzam/telar/apps/api/webhooks/handlers"
+	webhooksRepository "github.com/qolzam/telar/apps/api/webhooks/repository"
+	webhooksServices "github.com/qolzam/telar/apps/api/webhooks/services"
+)
+
+// VotesModule serves votes and the moderator view of vote integrity.
+type VotesModule struct {
+	Service   votesServices.VoteService
+	Integrity votesServices.IntegrityService
+}
+
+// NewVotesModule creates the votes services and starts the integrity scans that flag
+// flip-flopping and voting rings. Voters are listed with their profiles. Score changes
+// are written to eventOutbox when it is set.
+func NewVotesModule(ctx context.Context, infra *Infra, profiles profileServices.ProfileService, eventOutbox sharedInterfaces.EventOutbox) *VotesModule {
+	integrity := votesServices.NewIntegrityService(votesRepository.NewPostgresIntegrityRepository(infra.DB), infra.Config.VoteIntegrity)
+	integrity.Start(ctx)
+	service := votesServices.NewVoteServiceWithProfiles(votesRepository.NewPostgresVoteRepository(infra.DB), postsRepository.NewPostgresRepository(infra.DB), profiles)
+	service.SetEventOutbox(eventOutbox)
+	return &VotesModule{
+		Service:   service,
+		Integrity: integrity,
+	}
+}
+
+// Register adds the vote routes.
+func (m *VotesModule) Register(app *fiber.App, cfg *platformconfig.Config) {
+	votes.RegisterRoutes(app, &votes.VotesHandlers{
+		VoteHandler:      votesHandlers.NewVoteHandler(m.Service, cfg.JWT, cfg.HMAC),
+		IntegrityHandler: votesHandlers.NewIntegrityHandler(m.Integrity),
+	}, cfg)
+}
+
+// NewBookmarksModule serves bookmarks; bookmarked posts are read through posts. Bookmark
+// changes are written to eventOutbox when it is set.
+func NewBookmarksModule(infra *Infra, posts postsServices.PostService, eventOutbox sharedInterfaces.EventOutbox) Module {
+	service := bookmarksServices.NewService(bookmarksRepository.NewPostgresRepository(infra.DB), posts)
+	service.SetEventOutbox(eventOutbox)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		bookmarks.RegisterRoutes(app, &bookmarks.Handlers{BookmarkHandler: bookmarksHandlers.NewBookmarkHandler(service)}, cfg)
+	})
+}
+
+// NewSyncModule serves the delta sync endpoint from the outbox event log. It registers
+// nothing unless the outbox is enabled.
+func NewSyncModule(eventOutbox *Outbox, posts postsServices.PostService, comments deltasyncServices.CommentReader) Module {
+	if eventOutbox == nil {
+		return ModuleFunc(func(*fiber.App, *platformconfig.Config) {})
+	}
+	service := deltasyncServices.NewService(eventOutbox.Repo, posts, comments)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		deltasync.RegisterRoutes(app, &deltasync.Handlers{SyncHandler: deltasyncHandlers.NewSyncHandler(service)}, cfg)
+	})
+}
+
+// NewThanksModule serves the thanks button and starts the weekly allowance reset job.
+// It registers nothing unless thanks are enabled.
+func NewThanksModule(ctx context.Context, infra *Infra, posts postsServices.PostService) Module {
+	cfg := infra.Config.Thanks
+	if !cfg.Enabled {
+		return ModuleFunc(func(*fiber.App, *platformconfig.Config) {})
+	}
+	service := thanksServices.NewService(thanksRepository.NewPostgresRepository(infra.DB), posts, cfg)
+	thanksServices.StartJob(ctx, service, cfg.JobInterval)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		thanks.RegisterRoutes(app, &thanks.Handlers{ThanksHandler: thanksHandlers.NewThanksHandler(service)}, cfg)
+	})
+}
+
+// NewCalendarModule serves event posts, the calendar and its iCal feed, and those of
+// each community for its members. It registers nothing unless the calendar is enabled.
+func NewCalendarModule(infra *Infra, posts postsServices.PostService) Module {
+	cfg := infra.Config.Calendar
+	if !cfg.Enabled {
+		return ModuleFunc(func(*fiber.App, *platformconfig.Config) {})
+	}
+	apiURL := strings.TrimRight(infra.Config.Server.Gateway, "/")
+	links := calendarServices.Links{
+		FeedURL: apiURL + "/calendar/feed.ics",
+		APIURL:  apiURL,
+		WebURL:  infra.Config.App.WebDomain,
+	}
+	service := calendarServices.NewService(calendarRepository.NewPostgresRepository(infra.DB), posts, cfg, links)
+	service.SetCommunityChecker(newCommunitiesService(infra))
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		calendar.RegisterRoutes(app, &calendar.Handlers{CalendarHandler: calendarHandlers.NewCalendarHandler(service)}, cfg)
+	})
+}
+
+// NewCommunitiesModule serves communities, their members and community discovery, the
+// join questions and approval queue of communities that approve their members, and the
+// conversation starters drafted for them. Starters are counted and published
+// through postService; the scheduler drafting them runs when enabled and the AI engine
+// is configured. Recommendations are cached when caching is enabled.
+func NewCommunitiesModule(ctx context.Context, infra *Infra, postService postsServices.PostService) Module {
+	cfg := infra.Config
+	service := newCommunitiesService(infra)
+	service.SetCommunityPoster(posts.NewCommunityPoster(postService))
+	if cfg.Cache.Enabled {
+		service.SetRecommendationCache(cache.NewGenericCacheServiceFor("communities"))
+	}
+	if cfg.Communities.StartersEnabled {
+		if cfg.AIEngine.URL == "" {
+			log.Warn("Community conversation starters are enabled but AI_ENGINE_URL is not set; no starters will be drafted")
+		} else if client, err := aiengine.NewClient(cfg.AIEngine.URL, cfg.AIEngine.Timeout); err != nil {
+			log.Warn("AI engine client could not be initialized, no conversation starters will be drafted: %v", err)
+		} else {
+			service.SetStarterGenerator(client)
+			communitiesServices.StartStarterJob(ctx, service, cfg.Communities)
+		}
+	}
+	applications := membershipServices.NewService(membershipRepository.NewPostgresRepository(infra.DB), service, cfg.Membership)
+	communityRules := rulesServices.NewService(rulesRepository.NewPostgresRepository(infra.DB), service, cfg.Rules)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		communities.RegisterRoutes(app, &communities.Handlers{
+			CommunityHandler: communitiesHandlers.NewCommunityHandler(service),
+			StarterHandler:   communitiesHandlers.NewStarterHandler(service),
+		}, cfg)
+		membership.RegisterRoutes(app, &membership.Handlers{MembershipHandler: membershipHandlers.NewMembershipHandler(applications)}, cfg)
+		rules.RegisterRoutes(app, &rules.Handlers{RulesHandler: rulesHandlers.NewRulesHandler(communityRules)}, cfg)
+	})
+}
+
+// ModerationModule serves user reports and the moderators' queue.
+type ModerationModule struct {
+	Service moderationServices.Service
+
+	// Screener flags new posts and comments the AI engine scores as abusive; nil unless
+	// automatic flagging is enabled
+	Screener sharedInterfaces.ContentScreener
+}
+
+// NewModerationModule creates the moderation service. Reported posts and comments are
+// looked up and hidden through their modules, which have new content screened when
+// automatic flagging is enabled, and authors are suspended through suspender.
+func NewModerationModule(infra *Infra, postsModule *PostsModule, commentsModule *CommentsModule, suspender sharedInterfaces.UserSuspender) *ModerationModule {
+	module := newModerationModule(infra,
+		posts.NewContentModerator(postsModule.Service),
+		comments.NewContentModerator(commentsModule.Service),
+		suspender)
+	if module.Screener != nil {
+		postsModule.Service.SetContentScreener(module.Screener)
+		commentsModule.Service.SetContentScreener(module.Screener)
+	}
+	return module
+}
+
+func newModerationModule(infra *Infra, posts, comments sharedInterfaces.ContentModerator, suspender sharedInterfaces.UserSuspender) *ModerationModule {
+	cfg := infra.Config
+	module := &ModerationModule{
+		Service: moderationServices.NewService(moderationRepository.NewPostgresRepository(infra.DB), posts, comments, suspender),
+	}
+	if !cfg.Moderation.AutoFlagEnabled {
+		return module
+	}
+	if cfg.AIEngine.URL == "" {
+		log.Warn("Automatic moderation flags are enabled but AI_ENGINE_URL is not set; new content will not be screened")
+		return module
+	}
+	client, err := aiengine.NewClient(cfg.AIEngine.URL, cfg.AIEngine.Timeout)
+	if err != nil {
+		log.Warn("AI engine client could not be initialized, new content will not be screened: %v", err)
+		return module
+	}
+	module.Screener = moderationServices.NewScreener(module.Service, client, cfg.Moderation)
+	return module
+}
+
+// Register adds the report and moderation routes.
+func (m *ModerationModule) Register(app *fiber.App, cfg *platformconfig.Config) {
+	moderation.RegisterRoutes(app, &moderation.Handlers{ModerationHandler: moderationHandlers.NewModerationHandler(m.Service)}, cfg)
+}
+
+// NewAnalyticsModule serves analytics event ingestion.
+func NewAnalyticsModule(infra *Infra) Module {
+	service := analyticsServices.NewService(analyticsRepository.NewPostgresRepository(infra.DB), infra.Config.Analytics)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		analytics.RegisterRoutes(app, &analytics.Handlers{EventsHandler: analyticsHandlers.NewEventsHandler(service)}, cfg)
+	})
+}
+
+// ExperimentsModule serves experiments; auth embeds assignments in tokens.
+type ExperimentsModule struct {
+	Service experimentsServices.Service
+}
+
+// NewExperimentsModule creates the experiments service.
+func NewExperimentsModule(infra *Infra) *ExperimentsModule {
+	return &ExperimentsModule{
+		Service: experimentsServices.NewService(experimentsRepository.NewPostgresRepository(infra.DB), infra.Config.Experiments),
+	}
+}
+
+// Register adds the experiment routes.
+func (m *ExperimentsModule) Register(app *fiber.App, cfg *platformconfig.Config) {
+	experiments.RegisterRoutes(app, &experiments.Handlers{ExperimentHandler: experimentsHandlers.NewExperimentHandler(m.Service)}, cfg)
+}
+
+// NewFollowsModule serves follows; followed accounts are resolved through profiles.
+func NewFollowsModule(infra *Infra, profiles profileServices.ProfileService) Module {
+	service := followsServices.NewService(followsRepository.NewPostgresRepository(infra.DB), profiles)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		follows.RegisterRoutes(app, &follows.Handlers{FollowHandler: followsHandlers.NewFollowHandler(service)}, cfg)
+	})
+}
+
+// MentionsModule serves the current user's @mentions.
+type MentionsModule struct {
+	Service mentionsServices.Service
+}
+
+// NewMentionsModule creates the mentions service, which posts and comments hand their
+// writes to. Mentioned social names are resolved through profiles, directly or over
+// gRPC, and notifications respect the recipients' muted keywords. With eventOutbox,
+// the names and avatars users change to are copied into their mentions; pass it only
+// in the process serving mentions.
+func NewMentionsModule(ctx context.Context, infra *Infra, profiles profileServices.ProfileServiceClient, eventOutbox *Outbox) (*MentionsModule, error) {
+	service := mentionsServices.NewService(mentionsRepository.NewPostgresRepository(infra.DB), profiles, infra.Settings)
+	if eventOutbox != nil {
+		if err := mentions.SubscribeProfilePropagation(ctx, eventOutbox.Broker, eventOutbox.Repo, eventOutbox.Progress, infra.Config.Outbox.ProfilePropagationAttempts, service); err != nil {
+			return nil, fmt.Errorf("subscribe to profile events: %w", err)
+		}
+	}
+	return &MentionsModule{Service: service}, nil
+}
+
+// Register adds the mention routes.
+func (m *MentionsModule) Register(app *fiber.App, cfg *platformconfig.Config) {
+	mentions.RegisterRoutes(app, &mentions.Handlers{MentionHandler: mentionsHandlers.NewMentionHandler(m.Service)}, cfg)
+}
+
+// NewAchievementsModule serves badges. When enabled, badges are granted in the
+// background as domain events arrive on the bus.
+func NewAchievementsModule(ctx context.Context, infra *Infra) (Module, error) {
+	repo := achievementsRepository.NewPostgresRepository(infra.DB)
+	service, err := achievementsServices.NewService(repo, achievementsModels.DefaultBadges())
+	if err != nil {
+		return nil, fmt.Errorf("load badge catalog: %w", err)
+	}
+	if cfg := infra.Config.Achievements; cfg.Enabled {
+		evaluator := achievementsServices.NewEvaluator(service, repo, cfg.QueueSize)
+		evaluator.Start(ctx)
+		events.Subscribe(evaluator.Deliver)
+	}
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		achievements.RegisterRoutes(app, &achievements.Handlers{BadgeHandler: achievementsHandlers.NewBadgeHandler(service)}, cfg)
+	}), nil
+}
+
+// NewLeaderboardsModule serves leaderboards and starts the job that recomputes them
+// into summary tables.
+func NewLeaderboardsModule(ctx context.Context, infra *Infra) Module {
+	cfg := infra.Config.Leaderboards
+	service := leaderboardsServices.NewService(leaderboardsRepository.NewPostgresRepository(infra.DB), cfg.Size)
+	leaderboardsServices.StartRefreshJob(ctx, service, cfg.RefreshInterval)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		leaderboards.RegisterRoutes(app, &leaderboards.Handlers{LeaderboardHandler: leaderboardsHandlers.NewLeaderboardHandler(service)}, cfg)
+	})
+}
+
+// StreaksModule serves activity streaks, which profiles also show.
+type StreaksModule struct {
+	Service streaksServices.Service
+}
+
+// NewStreaksModule creates the streaks service and starts the job that records them
+// from analytics events.
+func NewStreaksModule(ctx context.Context, infra *Infra) *StreaksModule {
+	cfg := infra.Config.Streaks
+	service := streaksServices.NewService(streaksRepository.NewPostgresRepository(infra.DB), cfg)
+	streaksServices.StartJob(ctx, service, cfg.JobInterval)
+	return &StreaksModule{Service: service}
+}
+
+// Register adds the streak routes.
+func (m *StreaksModule) Register(app *fiber.App, cfg *platformconfig.Config) {
+	streaks.RegisterRoutes(app, &streaks.Handlers{StreakHandler: streaksHandlers.NewStreakHandler(m.Service)}, cfg)
+}
+
+// NewRealtimeModule serves the realtime gateway, which pushes post, comment and
+// notification events from the bus to WebSocket clients. It registers nothing unless
+// the gateway is enabled.
+func NewRealtimeModule(infra *Infra) Module {
+	cfg := infra.Config.Realtime
+	if !cfg.Enabled {
+		return ModuleFunc(func(*fiber.App, *platformconfig.Config) {})
+	}
+	hub := realtimeServices.NewHub(cfg.MaxConnectionsPerUser, cfg.SendBuffer)
+	events.Subscribe(hub.Deliver)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		handler := realtimeHandlers.NewRealtimeHandler(hub, cfg.Realtime.PingInterval, cfg.App.WebDomain)
+		realtime.RegisterRoutes(app, &realtime.Handlers{RealtimeHandler: handler}, cfg)
+	})
+}
+
+// NewSettingsModule serves branding, the read-only switch, feed defaults, content limits
+// and per-user settings.
+func NewSettingsModule(infra *Infra) Module {
+	service := infra.Settings
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		settings.RegisterRoutes(app, &settings.Handlers{
+			BrandingHandler:      settingsHandlers.NewBrandingHandler(service),
+			ReadOnlyHandler:      settingsHandlers.NewReadOnlyHandler(service),
+			PrivacyHandler:       settingsHandlers.NewPrivacyHandler(service),
+			NotificationsHandler: settingsHandlers.NewNotificationsHandler(service),
+			FeedDefaultsHandler:  settingsHandlers.NewFeedDefaultsHandler(service),
+			ContentLimitsHandler: settingsHandlers.NewContentLimitsHandler(service),
+			SocialNamesHandler:   settingsHandlers.NewSocialNamesHandler(service),
+			EmailDomainsHandler:  settingsHandlers.NewEmailDomainsHandler(service),
+			MutedKeywordsHandler: settingsHandlers.NewMutedKeywordsHandler(service),
+		}, cfg)
+	})
+}
+
+// NewWebhooksModule serves the admin tooling for outgoing webhooks and purges their
+// deliveries once they pass the retention.
+func NewWebhooksModule(ctx context.Context, infra *Infra) Module {
+	service := webhooksServices.NewService(webhooksRepository.NewPostgresRepository(infra.DB), infra.Config.Hooks)
+	webhooksServices.StartJob(ctx, service, time.Hour)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		webhooks.RegisterRoutes(app, &webhooks.Handlers{WebhookHandler: webhooksHandlers.NewWebhookHandler(service)}, cfg)
+	})
+}
+
+// NewSystemModule serves the admin dashboard of the running build: its version,
+// enabled features, the versions of Postgres, Redis (when the cache or rate limits use
+// it) and the AI engine with its vector store and LLM providers, and pending migrations.
+func NewSystemModule(infra *Infra) Module {
+	cfg := infra.Config
+	var redisClient redis.UniversalClient
+	if cfg.Cache.Backend == "redis" || cfg.RateLimits.Storage == "redis" {
+		redisClient = redis.NewClient(&redis.Options{
+			Addr:     cfg.Cache.Redis.Address,
+			Password: cfg.Cache.Redis.Password,
+			DB:       cfg.Cache.Redis.Database,
+		})
+	}
+	var aiEngine systemServices.AIEngine
+	if cfg.AIEngine.URL != "" {
+		if client, err := aiengine.NewClient(cfg.AIEngine.URL, cfg.AIEngine.Timeout); err != nil {
+			log.Warn("AI engine client could not be initialized, system info will leave it out: %v", err)
+		} else {
+			aiEngine = client
+		}
+	}
+
+	service := systemServices.NewService(cfg, infra.DB.DB(), redisClient, aiEngine)
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		system.RegisterRoutes(app, &system.Handlers{SystemHandler: systemHandlers.NewSystemHandler(service)}, cfg)
+	})
+}
+
+// NewStorageModule serves uploads when a storage bucket is configured. Storage is
+// optional: without a bucket, or when the provider fails, its routes are left out.
+// Unless STORAGE_IMAGE_PIPELINE_EMBEDDED is off, it also runs the image pipeline, which
+// points posts at resized images through posts (may be nil).
+func NewStorageModule(ctx context.Context, infra *Infra, posts sharedInterfaces.PostImageUpdater) Module {
+	cfg := &infra.Config.Storage
+	disabled := ModuleFunc(func(*fiber.App, *platformconfig.Config) {})
+	if !storageConfigured(cfg) {
+		log.Warn("Storage configuration not found, storage endpoints disabled")
+		return disabled
+	}
+
+	blobProvider, err := storageProvider.NewProvider(cfg)
+	if err != nil {
+		log.Warn("Failed to initialize storage provider, storage endpoints disabled: %v", err)
+		return disabled
+	}
+
+	repo := storageRepository.NewPostgresRepository(infra.DB)
+	service := storageServices.NewStorageService(repo, blobProvider, cfg.BucketName, cfg)
+	log.Info("Storage service initialized")
+
+	// Confirmed uploads wake the embedded pipeline; it also polls for uploads confirmed
+	// by other instances
+	if cfg.ImagePipelineEmbedded {
+		worker := storagePipeline.NewWorker(repo, blobProvider, storagePipeline.ConfigFromStorage(cfg))
+		if posts != nil {
+			worker.SetPostImageUpdater(posts)
+		}
+		events.Subscribe(func(event events.Event) {
+			if event.Type == events.TypeMediaUploaded {
+				worker.Wake()
+			}
+		})
+		worker.Start(ctx)
+	}
+
+	return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		storage.RegisterRoutes(app, &storage.StorageHandlers{StorageHandler: storageHandlers.NewStorageHandler(service)}, cfg)
+	})
+}
+
+// NewImagePipeline creates the image pipeline worker for running it as its own service;
+// posts points posts at resized images and may be nil.
+func NewImagePipeline(infra *Infra, posts sharedInterfaces.PostImageUpdater) (*storagePipeline.Worker, error) {
+	cfg := &infra.Config.Storage
+	if !storageConfigured(cfg) {
+		return nil, fmt.Errorf("storage is not configured")
+	}
+	blobProvider, err := storageProvider.NewProvider(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage provider: %w", err)
+	}
+
+	worker := storagePipeline.NewWorker(storageRepository.NewPostgresRepository(infra.DB), blobProvider, storagePipeline.ConfigFromStorage(cfg))
+	if posts != nil {
+		worker.SetPostImageUpdater(posts)
+	}
+	return worker, nil
+}
+
+// NewImageVariantResolver lets posts show resized images uploaded before the post was
+// written. It returns nil without storage or a public storage URL, since posts would
+// otherwise keep presigned URLs that expire.
+func NewImageVariantResolver(infra *Infra) sharedInterfaces.ImageVariantResolver {
+	cfg := &infra.Config.Storage
+	if !storageConfigured(cfg) || cfg.PublicURL == "" {
+		return nil
+	}
+	blobProvider, err := storageProvider.NewProvider(cfg)
+	if err != nil {
+		log.Warn("Failed to initialize storage provider, posts keep uploaded originals: %v", err)
+		return nil
+	}
+	return storagePipeline.NewVariantResolver(storageRepository.NewPostgresRepository(infra.DB), blobProvider)
+}
+
+func storageConfigured(cfg *platformconfig.StorageConfig) bool {
+	return cfg.BucketName != "" && cfg.AccessKeyID != ""
+}
@@ -0,0 +1,62 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/qolzam/telar/apps/api/internal/outbox"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Outbox carries cross-service events through the transactional outbox
+// (OUTBOX_ENABLED). A nil *Outbox is a disabled outbox.
+type Outbox struct {
+	Repo       outbox.Repository
+	Broker     outbox.Broker
+	Progress   propagation.Repository // Where profile changes stand at each service
+	dispatcher *outbox.Dispatcher
+}
+
+// NewOutbox connects to the configured broker. It returns nil when the outbox is
+// disabled. The in-process broker only reaches consumers of the same process, so it is
+// refused in microservices mode.
+func NewOutbox(ctx context.Context, infra *Infra) (*Outbox, error) {
+	cfg := &infra.Config.Outbox
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if Microservices() && (cfg.Broker == "" || cfg.Broker == "local") {
+		return nil, errors.New("OUTBOX_BROKER=local delivers events within one process; use nats or kafka in microservices mode")
+	}
+
+	broker, err := outbox.NewBroker(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("connect outbox broker: %w", err)
+	}
+	repo := outbox.NewPostgresRepository(infra.DB)
+	return &Outbox{
+		Repo:       repo,
+		Broker:     broker,
+		Progress:   propagation.NewPostgresRepository(infra.DB),
+		dispatcher: outbox.NewDispatcher(repo, broker, outbox.ConfigFromPlatform(cfg)),
+	}, nil
+}
+
+// Events returns the outbox services append to, or nil when it is disabled
+func (o *Outbox) Events() sharedInterfaces.EventOutbox {
+	if o == nil {
+		return nil
+	}
+	return o.Repo
+}
+
+// Start publishes appended events until ctx is done. Call it once this process's
+// consumers are subscribed: the in-process broker drops events nobody subscribed to.
+func (o *Outbox) Start(ctx context.Context) {
+	if o == nil {
+		return
+	}
+	o.dispatcher.Start(ctx)
+}
@@ -0,0 +1,247 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	bookmarksRepository "github.com/qolzam/telar/apps/api/bookmarks/repository"
+	"github.com/qolzam/telar/apps/api/comments"
+	commentHandlers "github.com/qolzam/telar/apps/api/comments/handlers"
+	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
+	commentServices "github.com/qolzam/telar/apps/api/comments/services"
+	communitiesRepository "github.com/qolzam/telar/apps/api/communities/repository"
+	communitiesServices "github.com/qolzam/telar/apps/api/communities/services"
+	"github.com/qolzam/telar/apps/api/delegations"
+	delegationsHandlers "github.com/qolzam/telar/apps/api/delegations/handlers"
+	delegationsRepository "github.com/qolzam/telar/apps/api/delegations/repository"
+	delegationsServices "github.com/qolzam/telar/apps/api/delegations/services"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/posts"
+	"github.com/qolzam/telar/apps/api/posts/handlers"
+	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
+	postsServices "github.com/qolzam/telar/apps/api/posts/services"
+	profileServices "github.com/qolzam/telar/apps/api/profile/services"
+	rulesRepository "github.com/qolzam/telar/apps/api/rules/repository"
+	rulesServices "github.com/qolzam/telar/apps/api/rules/services"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/qolzam/telar/apps/api/supporters"
+	supportersHandlers "github.com/qolzam/telar/apps/api/supporters/handlers"
+	supportersRepository "github.com/qolzam/telar/apps/api/supporters/repository"
+	supportersServices "github.com/qolzam/telar/apps/api/supporters/services"
+	"github.com/qolzam/telar/apps/api/tips"
+	tipsHandlers "github.com/qolzam/telar/apps/api/tips/handlers"
+	tipsRepository "github.com/qolzam/telar/apps/api/tips/repository"
+	tipsServices "github.com/qolzam/telar/apps/api/tips/services"
+	votesRepository "github.com/qolzam/telar/apps/api/votes/repository"
+)
+
+// PostsModule serves posts together with the delegation grants that let a delegate
+// post as another account and, when enabled, the supporter tiers that unlock
+// supporter-only posts and tips between users.
+type PostsModule struct {
+	Service     postsServices.PostService
+	Delegations delegationsServices.Service
+	Supporters  supportersServices.Service // nil unless SUPPORTERS_ENABLED
+	Tips        tipsServices.Service       // nil unless TIPS_ENABLED
+}
+
+// PostsDeps are the posts module's dependencies on other modules.
+type PostsDeps struct {
+	// Profiles resolves the acting account of delegated posts
+	Profiles profileServices.ProfileService
+
+	// Comments, when set, supplies comment counts
+	Comments sharedInterfaces.CommentCounter
+
+	// Mentions, when set, records the users posts @mention
+	Mentions *MentionsModule
+
+	// Outbox, when set, receives post events; comment counts, the AI knowledge base and
+	// changed names and avatars are then applied by its consumers
+	Outbox *Outbox
+}
+
+// NewPostsModule creates the posts service and starts the post expiry, trending tags and
+// live thread jobs.
+func NewPostsModule(ctx context.Context, infra *Infra, deps PostsDeps) (*PostsModule, error) {
+	cfg := infra.Config
+	profiles := deps.Profiles
+	service := newPostService(infra, deps.Comments)
+	postsServices.StartExpiryJob(ctx, service, cfg.Posts.ExpiryInterval)
+	postsServices.StartTrendingTagsJob(ctx, service, cfg.Posts.TrendingTagsInterval)
+	if cfg.LiveThreads.Enabled {
+		postsServices.StartLiveThreadJob(ctx, service, cfg.LiveThreads.JobInterval)
+	}
+	service.SetRulesChecker(newRulesService(infra))
+	service.SetCommunityChecker(newCommunitiesService(infra))
+	service.SetFeedDefaults(infra.Settings)
+	service.SetKeywordMuter(infra.Settings)
+	service.SetContentLimits(infra.Settings)
+	if resolver := NewImageVariantResolver(infra); resolver != nil {
+		service.SetImageVariantResolver(resolver)
+	}
+
+	m := &PostsModule{
+		Service:     service,
+		Delegations: delegationsServices.NewService(delegationsRepository.NewPostgresRepository(infra.DB), profiles),
+	}
+	service.SetDelegationAuditor(m.Delegations)
+
+	// Billing reports payments to /supporters/billing/events
+	if cfg.Supporters.Enabled {
+		m.Supporters = supportersServices.NewService(supportersRepository.NewPostgresRepository(infra.DB), cfg.Supporters)
+		service.SetSupporterChecker(m.Supporters)
+	}
+	// Billing reports tip payments and payouts to /tips/billing/events
+	if cfg.Tips.Enabled {
+		m.Tips = tipsServices.NewService(tipsRepository.NewPostgresRepository(infra.DB), service, profiles, cfg.Tips)
+	}
+
+	if deps.Mentions != nil {
+		service.SetMentionRecorder(deps.Mentions.Service)
+	}
+	if err := m.subscribe(ctx, cfg, deps.Outbox); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// subscribe writes post events to eventOutbox and consumes the comment and profile
+// events posts follow, and with the HTTP cache its own post events to drop cached pages
+func (m *PostsModule) subscribe(ctx context.Context, cfg *platformconfig.Config, eventOutbox *Outbox) error {
+	m.Service.SetEventOutbox(eventOutbox.Events())
+	if eventOutbox == nil {
+		return nil
+	}
+	if err := posts.SubscribeCommentCounts(ctx, eventOutbox.Broker, eventOutbox.Repo, m.Service); err != nil {
+		return fmt.Errorf("subscribe to comment events: %w", err)
+	}
+	if cfg.AIEngine.URL != "" {
+		if err := posts.SubscribeKnowledgeIngestion(ctx, eventOutbox.Broker, eventOutbox.Repo, m.Service); err != nil {
+			return fmt.Errorf("subscribe to post events: %w", err)
+		}
+	}
+	if err := posts.SubscribeProfilePropagation(ctx, eventOutbox.Broker, eventOutbox.Repo, eventOutbox.Progress, cfg.Outbox.ProfilePropagationAttempts, m.Service); err != nil {
+		return fmt.Errorf("subscribe to profile events: %w", err)
+	}
+	if cfg.HTTPCache.Enabled {
+		if err := posts.SubscribePublicPages(ctx, eventOutbox.Broker, m.Service); err != nil {
+			return fmt.Errorf("subscribe to post events: %w", err)
+		}
+	}
+	return nil
+}
+
+// NewPostImageUpdater lets an image pipeline running as its own service point posts at
+// resized images. It starts none of the posts jobs.
+func NewPostImageUpdater(infra *Infra) sharedInterfaces.PostImageUpdater {
+	return newPostService(infra, nil)
+}
+
+func newPostService(infra *Infra, counter sharedInterfaces.CommentCounter) postsServices.PostService {
+	return postsServices.NewPostService(
+		postsRepository.NewPostgresRepository(infra.DB),
+		votesRepository.NewPostgresVoteRepository(infra.DB),
+		bookmarksRepository.NewPostgresRepository(infra.DB),
+		infra.Config, counter,
+		commentRepository.NewPostgresCommentRepository(infra.DB))
+}
+
+// Register adds the posts, delegation, supporter and tip routes.
+func (m *PostsModule) Register(app *fiber.App, cfg *platformconfig.Config) {
+	postHandler := handlers.NewPostHandler(m.Service, cfg.JWT, cfg.HMAC).WithDelegation(m.Delegations)
+	posts.RegisterRoutes(app, &posts.PostsHandlers{PostHandler: postHandler}, cfg)
+
+	delegations.RegisterRoutes(app, &delegations.Handlers{DelegationHandler: delegationsHandlers.NewDelegationHandler(m.Delegations)}, cfg)
+	if m.Supporters != nil {
+		supporters.RegisterRoutes(app, &supporters.Handlers{SupporterHandler: supportersHandlers.NewSupporterHandler(m.Supporters)}, cfg)
+	}
+	if m.Tips != nil {
+		tips.RegisterRoutes(app, &tips.Handlers{TipHandler: tipsHandlers.NewTipHandler(m.Tips)}, cfg)
+	}
+}
+
+// CommentsModule serves comments.
+type CommentsModule struct {
+	Service commentServices.CommentService
+}
+
+// CommentsDeps are the comments module's dependencies on other modules.
+type CommentsDeps struct {
+	// PostStats, when set, receives post comment counts
+	PostStats sharedInterfaces.PostStatsUpdater
+
+	// Mentions, when set, records the users comments @mention
+	Mentions *MentionsModule
+
+	// Outbox, when set, receives comment events; changed names and avatars are then
+	// applied by its consumers
+	Outbox *Outbox
+
+	// Notifications publishes the realtime events of comments announced through the
+	// outbox; set it in the process serving the realtime gateway
+	Notifications bool
+}
+
+// NewCommentsModule creates the comments service and starts the job repairing the
+// owner names and avatars comments store.
+func NewCommentsModule(ctx context.Context, infra *Infra, deps CommentsDeps) (*CommentsModule, error) {
+	service := commentServices.NewCommentService(
+		commentRepository.NewPostgresCommentRepository(infra.DB),
+		postsRepository.NewPostgresRepository(infra.DB),
+		infra.Config, deps.PostStats)
+	service.SetRulesChecker(newRulesService(infra))
+	service.SetCommunityChecker(newCommunitiesService(infra))
+	service.SetFeedDefaults(infra.Settings)
+	service.SetKeywordMuter(infra.Settings)
+	service.SetContentLimits(infra.Settings)
+	if deps.Mentions != nil {
+		service.SetMentionRecorder(deps.Mentions.Service)
+	}
+	commentServices.StartProfileRepairJob(ctx, service, infra.Config.Comments.ProfileRepairInterval)
+
+	m := &CommentsModule{Service: service}
+	if err := m.subscribe(ctx, infra.Config, deps.Outbox, deps.Notifications); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// subscribe writes comment events to eventOutbox and consumes the profile events
+// comments follow, and with notifications the comment events themselves
+func (m *CommentsModule) subscribe(ctx context.Context, cfg *platformconfig.Config, eventOutbox *Outbox, notifications bool) error {
+	m.Service.SetEventOutbox(eventOutbox.Events())
+	if eventOutbox == nil {
+		return nil
+	}
+	if notifications {
+		if err := comments.SubscribeNotifications(ctx, eventOutbox.Broker, eventOutbox.Repo, m.Service); err != nil {
+			return fmt.Errorf("subscribe to comment events: %w", err)
+		}
+	}
+	if err := comments.SubscribeProfilePropagation(ctx, eventOutbox.Broker, eventOutbox.Repo, eventOutbox.Progress, cfg.Outbox.ProfilePropagationAttempts, m.Service); err != nil {
+		return fmt.Errorf("subscribe to profile events: %w", err)
+	}
+	return nil
+}
+
+// newCommunitiesService reads community members; posts use it to let only members post
+// in a community, comments to let only members comment where membership is approved, and
+// posts and comments to keep archived communities read-only
+func newCommunitiesService(infra *Infra) communitiesServices.Service {
+	return communitiesServices.NewService(communitiesRepository.NewPostgresRepository(infra.DB))
+}
+
+// newRulesService reads rules acknowledgments; posts and comments use it to gate writes
+// in communities wherever they run
+func newRulesService(infra *Infra) rulesServices.Service {
+	return rulesServices.NewService(rulesRepository.NewPostgresRepository(infra.DB), newCommunitiesService(infra), infra.Config.Rules)
+}
+
+// Register adds the comment routes.
+func (m *CommentsModule) Register(app *fiber.App, cfg *platformconfig.Config) {
+	comments.RegisterRoutes(app, &comments.CommentsHandlers{
+		CommentHandler: commentHandlers.NewCommentHandler(m.Service, cfg.JWT, cfg.HMAC),
+	}, cfg)
+}
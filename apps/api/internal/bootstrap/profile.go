@@ -0,0 +1,71 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+	achievementsModels "github.com/qolzam/telar/apps/api/achievements/models"
+	achievementsRepository "github.com/qolzam/telar/apps/api/achievements/repository"
+	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
+	"github.com/qolzam/telar/apps/api/profile"
+	profileRepository "github.com/qolzam/telar/apps/api/profile/repository"
+	profileServices "github.com/qolzam/telar/apps/api/profile/services"
+	streaksServices "github.com/qolzam/telar/apps/api/streaks/services"
+)
+
+// ProfileModule serves profiles with view tracking and activity timelines.
+type ProfileModule struct {
+	Service  profileServices.ProfileService
+	Views    profileServices.ViewService
+	Activity *profileServices.ActivityService
+
+	streaks streaksServices.Service
+}
+
+// NewProfileModule creates the profile services and starts view tracking.
+// Activity timelines read posts, comments and badges from the shared database. With
+// eventOutbox, changed names and avatars are announced to the posts, comments and
+// mentions consumers, which record how far each change got.
+func NewProfileModule(ctx context.Context, infra *Infra, eventOutbox *Outbox) *ProfileModule {
+	cfg := infra.Config
+	service := profileServices.NewProfileService(profileRepository.NewPostgresProfileRepository(infra.DB), cfg)
+	service.SetContentLimits(infra.Settings)
+	service.SetSocialNameRules(infra.Settings)
+	if eventOutbox != nil {
+		service.SetProfilePropagation(eventOutbox.Events(), eventOutbox.Progress)
+	}
+
+	// Views are revealed according to the privacy settings of both users
+	views := profileServices.NewViewService(profileRepository.NewPostgresViewRepository(infra.DB), service, infra.Settings, cfg.ProfileViews)
+	views.Start(ctx)
+
+	activity := profileServices.NewActivityService(service,
+		profileServices.NewPostActivitySource(postsRepository.NewPostgresRepository(infra.DB), cfg.Supporters.TeaserLength),
+		profileServices.NewCommentActivitySource(commentRepository.NewPostgresCommentRepository(infra.DB)),
+		profileServices.NewBadgeActivitySource(achievementsRepository.NewPostgresRepository(infra.DB), achievementsModels.DefaultBadges()),
+	)
+
+	return &ProfileModule{Service: service, Views: views, Activity: activity}
+}
+
+// WithStreaks shows activity streaks on profiles.
+func (m *ProfileModule) WithStreaks(streaks streaksServices.Service) *ProfileModule {
+	m.streaks = streaks
+	return m
+}
+
+// Register adds the profile routes.
+func (m *ProfileModule) Register(app *fiber.App, cfg *platformconfig.Config) {
+	handler := profile.NewProfileHandler(m.Service, cfg.JWT, cfg.HMAC).WithViewTracking(m.Views)
+	if m.streaks != nil {
+		handler = handler.WithStreaks(profileServices.NewStreakSource(m.streaks))
+	}
+
+	profile.RegisterRoutes(app, &profile.ProfileHandlers{
+		ProfileHandler:  handler,
+		ActivityHandler: profile.NewActivityHandler(m.Activity),
+		ViewHandler:     profile.NewViewHandler(m.Views),
+	}, cfg)
+}
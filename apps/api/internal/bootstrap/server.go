@@ -0,0 +1,268 @@
+package bootstrap
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/qolzam/telar/apps/api/internal/middleware/ratelimit"
+	"github.com/qolzam/telar/apps/api/internal/middleware/readonly"
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/platform/grpcconn"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Module registers a module's routes on the app.
+type Module interface {
+	Register(app *fiber.App, cfg *platformconfig.Config)
+}
+
+// ModuleFunc adapts a plain function to Module.
+type ModuleFunc func(app *fiber.App, cfg *platformconfig.Config)
+
+// Register calls f.
+func (f ModuleFunc) Register(app *fiber.App, cfg *platformconfig.Config) {
+	f(app, cfg)
+}
+
+// Server builds the HTTP app for a binary from its modules.
+type Server struct {
+	name    string
+	cfg     *platformconfig.Config
+	browser bool
+	modules []Module
+	metrics *metrics.Metrics
+	outbox  *Outbox
+}
+
+// NewServer starts building a server; name is only used in logs.
+func NewServer(name string, cfg *platformconfig.Config) *Server {
+	return &Server{name: name, cfg: cfg}
+}
+
+// WithBrowserAccess serves browsers directly: CORS for the configured web domains
+// and an error handler that keeps responses already set by handlers.
+func (s *Server) WithBrowserAccess() *Server {
+	s.browser = true
+	return s
+}
+
+// WithMetrics records and serves m instead of the process-wide metrics, so tests can
+// read a registry of their own.
+func (s *Server) WithMetrics(m *metrics.Metrics) *Server {
+	s.metrics = m
+	return s
+}
+
+// WithOutbox publishes the events appended to eventOutbox once the server listens, after
+// every module has subscribed its consumers. A nil eventOutbox is ignored.
+func (s *Server) WithOutbox(eventOutbox *Outbox) *Server {
+	s.outbox = eventOutbox
+	return s
+}
+
+// With adds modules; routes are registered in the order modules are added.
+func (s *Server) With(modules ...Module) *Server {
+	s.modules = append(s.modules, modules...)
+	return s
+}
+
+// Build creates the fiber app with the middleware chain and every module's routes.
+func (s *Server) Build() *fiber.App {
+	if !s.browser {
+		app := fiber.New()
+		// Keep the request ID the gateway or calling service sent
+		app.Use(requestid.New())
+		s.useMetrics(app)
+		app.Use(readonly.NewFromConfig(s.cfg.ReadOnly))
+		s.useRateLimitHeaders(app)
+		s.register(app)
+		return app
+	}
+
+	app := fiber.New(fiber.Config{
+		// Disable default error handler that might interfere with custom responses
+		ErrorHandler: errorHandler,
+	})
+
+	// Request ID middleware (must be early in the chain)
+	app.Use(requestid.New())
+	s.useMetrics(app)
+
+	// CORS Configuration for Browser Direct Access
+	// IMPORTANT: When AllowCredentials is true, AllowOrigins cannot be "*"
+	allowedOrigins := parseOrigins(s.cfg.App.WebDomain)
+	app.Use(cors.New(cors.Config{
+		AllowOriginsFunc: func(origin string) bool {
+			// Empty origin means same-origin request (should be allowed)
+			if origin == "" {
+				return true
+			}
+			return allowedOrigins[origin]
+		},
+		AllowCredentials: true,
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Request-ID",
+		AllowMethods:     "GET, POST, PUT, DELETE, PATCH, OPTIONS",
+		// Let browser clients read their rate limit budget and back off
+		ExposeHeaders: "X-Request-ID, Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, X-RateLimit-Policy",
+	}))
+
+	// Reject writes while the API is in read-only mode (after CORS so browsers can read the 503)
+	app.Use(readonly.NewFromConfig(s.cfg.ReadOnly))
+
+	s.useRateLimitHeaders(app)
+	s.register(app)
+	return app
+}
+
+// Listen builds the app and serves it on addr until it fails.
+func (s *Server) Listen(addr string) error {
+	app := s.Build()
+	s.outbox.Start(context.Background())
+	log.Info("Starting %s on %s", s.name, addr)
+	return app.Listen(addr)
+}
+
+// useMetrics times every request and serves the metrics endpoint ahead of the modules,
+// so module middleware such as authentication never applies to scrapes
+func (s *Server) useMetrics(app *fiber.App) {
+	if !s.cfg.Metrics.Enabled {
+		return
+	}
+	m := s.metrics
+	if m == nil {
+		m = metrics.Default()
+	}
+	app.Use(m.Middleware())
+
+	path := s.cfg.Metrics.Path
+	if path == "" {
+		path = "/metrics"
+	}
+	app.Get(path, metricsToken(s.cfg.Metrics.Token), m.Handler())
+}
+
+// useRateLimitHeaders reports each user's soft request budget on authenticated responses
+// and serves the rate limit policies of this binary at /rate-limits
+func (s *Server) useRateLimitHeaders(app *fiber.App) {
+	limit := s.cfg.RateLimits.API
+	app.Use(ratelimit.NewSoft(limit.Enabled, limit.Max, limit.Duration))
+	app.Get("/rate-limits", ratelimit.PolicyHandler())
+}
+
+// metricsToken requires the configured bearer token when one is set
+func metricsToken(token string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if token == "" {
+			return c.Next()
+		}
+		got := strings.TrimPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			return c.SendStatus(fiber.StatusUnauthorized)
+		}
+		return c.Next()
+	}
+}
+
+func (s *Server) register(app *fiber.App) {
+	for _, module := range s.modules {
+		module.Register(app, s.cfg)
+	}
+}
+
+// parseOrigins splits the comma-separated web domains into a lookup set
+func parseOrigins(webDomain string) map[string]bool {
+	origins := make(map[string]bool)
+	for _, origin := range strings.Split(webDomain, ",") {
+		origins[strings.TrimSpace(origin)] = true
+	}
+	return origins
+}
+
+func errorHandler(c *fiber.Ctx, err error) error {
+	code := fiber.StatusInternalServerError
+	if e, ok := err.(*fiber.Error); ok {
+		code = e.Code
+	}
+	log.Logger(c.Context()).Warn("request failed",
+		"path", c.Path(), "error", err.Error(), "status", code, "response_bytes", len(c.Response().Body()))
+
+	// If response already set by handler, don't override it
+	if len(c.Response().Body()) > 0 {
+		return nil
+	}
+
+	return c.Status(code).JSON(fiber.Map{
+		"error": err.Error(),
+	})
+}
+
+var unauthenticatedGRPCWarning sync.Once
+
+// warnUnauthenticatedGRPC logs once per process when the gRPC calls between services
+// are not signed, so anyone who can reach a service's port can call it
+func warnUnauthenticatedGRPC(cfg platformconfig.GRPCConfig) {
+	if cfg.AuthSecret != "" {
+		return
+	}
+	unauthenticatedGRPCWarning.Do(func() {
+		log.Error("GRPC_AUTH_SECRET is not set: internal gRPC calls are NOT authenticated and any client that can reach a service's gRPC port may call it. Set the same GRPC_AUTH_SECRET on every service in production.")
+	})
+}
+
+// ServeGRPC serves a gRPC server on port in the background; register adds the
+// services and cfg secures them. The process exits if the port cannot be served.
+func ServeGRPC(name, port string, cfg platformconfig.GRPCConfig, register func(server *grpc.Server)) {
+	warnUnauthenticatedGRPC(cfg)
+	go func() {
+		grpcServer, err := newGRPCServer(cfg, register)
+		if err != nil {
+			log.Fatal("Failed to create gRPC server: %v", err)
+		}
+		lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+		if err != nil {
+			log.Fatal("Failed to listen on gRPC port %s: %v", port, err)
+		}
+
+		log.Info("%s gRPC server listening on port %s (TLS: %t, call authentication: %t)", name, port, cfg.TLSEnabled(), cfg.AuthSecret != "")
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Fatal("Failed to serve gRPC: %v", err)
+		}
+	}()
+}
+
+// newGRPCServer creates a gRPC server with the services register adds, using TLS,
+// call authentication and keepalive as cfg sets them. It also answers the standard
+// health checking protocol, reporting the server and each service as serving, and
+// server reflection, so probes and grpcurl need no proto files. Health checks need no
+// authentication.
+func newGRPCServer(cfg platformconfig.GRPCConfig, register func(server *grpc.Server)) (*grpc.Server, error) {
+	opts, err := grpcconn.ServerOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	// Callers forward their request ID in metadata; it runs first so rejected calls
+	// are logged with it
+	opts = append([]grpc.ServerOption{grpc.ChainUnaryInterceptor(requestid.UnaryServerInterceptor())}, opts...)
+	server := grpc.NewServer(opts...)
+	register(server)
+
+	healthServer := health.NewServer()
+	for service := range server.GetServiceInfo() {
+		healthServer.SetServingStatus(service, healthpb.HealthCheckResponse_SERVING)
+	}
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+	return server, nil
+}
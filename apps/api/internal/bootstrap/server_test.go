@@ -0,0 +1,266 @@
+package bootstrap
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/platform/grpcconn"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts"
+	postspb "github.com/qolzam/telar/protos/gen/go/postspb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func testConfig() *platformconfig.Config {
+	cfg := &platformconfig.Config{}
+	cfg.App.WebDomain = "https://telar.example, https://admin.telar.example"
+	return cfg
+}
+
+func TestServer_RegistersModulesInOrder(t *testing.T) {
+	var order []string
+	module := func(name string) Module {
+		return ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+			order = append(order, name)
+			app.Get("/"+name, func(c *fiber.Ctx) error { return c.SendString(name) })
+		})
+	}
+
+	app := NewServer("test", testConfig()).With(module("auth"), module("posts")).With(module("comments")).Build()
+	assert.Equal(t, []string{"auth", "posts", "comments"}, order)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/posts", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+}
+
+func TestServer_BrowserAccess(t *testing.T) {
+	ok := ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		app.Get("/ping", func(c *fiber.Ctx) error { return c.SendString("pong") })
+	})
+
+	browser := NewServer("test", testConfig()).WithBrowserAccess().With(ok).Build()
+	req := httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set("Origin", "https://admin.telar.example")
+	resp, err := browser.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, "https://admin.telar.example", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.NotEmpty(t, resp.Header.Get("X-Request-ID"))
+
+	req = httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set("Origin", "https://elsewhere.example")
+	resp, err = browser.Test(req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+	// Service binaries sit behind the gateway and answer no CORS
+	service := NewServer("test", testConfig()).With(ok).Build()
+	req = httptest.NewRequest("GET", "/ping", nil)
+	req.Header.Set("Origin", "https://admin.telar.example")
+	resp, err = service.Test(req)
+	require.NoError(t, err)
+	assert.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+}
+
+func TestServer_Metrics(t *testing.T) {
+	cfg := testConfig()
+	cfg.Metrics = platformconfig.MetricsConfig{Enabled: true, Path: "/metrics", Token: "scrape"}
+	ok := ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		app.Get("/posts/:postId", func(c *fiber.Ctx) error { return c.SendString("post") })
+	})
+	app := NewServer("test", cfg).WithMetrics(metrics.New(nil)).With(ok).Build()
+
+	_, err := app.Test(httptest.NewRequest("GET", "/posts/42", nil))
+	require.NoError(t, err)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusUnauthorized, resp.StatusCode)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Authorization", "Bearer scrape")
+	resp, err = app.Test(req)
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `http_request_duration_seconds_count{method="GET",route="/posts/:postId",status="200"} 1`)
+
+	// Disabled metrics leave the path to the modules
+	cfg.Metrics.Enabled = false
+	resp, err = NewServer("test", cfg).With(ok).Build().Test(httptest.NewRequest("GET", "/metrics", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_RateLimitHeaders(t *testing.T) {
+	cfg := testConfig()
+	cfg.RateLimits.API = platformconfig.RateLimitConfig{Enabled: true, Max: 100, Duration: time.Minute}
+	authenticated := ModuleFunc(func(app *fiber.App, cfg *platformconfig.Config) {
+		app.Get("/me", func(c *fiber.Ctx) error {
+			c.Locals(types.UserCtxName, types.UserContext{UserID: uuid.Must(uuid.NewV4())})
+			return c.SendString("me")
+		})
+	})
+	app := NewServer("test", cfg).WithBrowserAccess().With(authenticated).Build()
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Origin", "https://telar.example")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, "100", resp.Header.Get("X-RateLimit-Limit"))
+	assert.Equal(t, "99", resp.Header.Get("X-RateLimit-Remaining"))
+	assert.Contains(t, resp.Header.Get("Access-Control-Expose-Headers"), "X-RateLimit-Remaining")
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/rate-limits", nil))
+	require.NoError(t, err)
+	require.Equal(t, fiber.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), `"name":"api","limit":100,"windowSeconds":60,"scope":"user","enforced":false`)
+}
+
+func TestNewPostgresConfig(t *testing.T) {
+	cfg := testConfig()
+	cfg.Database.Postgres.Host = "db"
+	cfg.Database.Postgres.Port = 5432
+	cfg.Database.Postgres.Database = "telar"
+	cfg.Database.Postgres.ConnMaxLifetime = 5 * time.Minute
+
+	pgConfig := NewPostgresConfig(cfg)
+	assert.Equal(t, "db", pgConfig.Host)
+	assert.Equal(t, 5432, pgConfig.Port)
+	assert.Equal(t, "telar", pgConfig.Database)
+	assert.Equal(t, 300, pgConfig.MaxLifetime)
+	assert.Equal(t, 10, pgConfig.ConnectTimeout)
+}
+
+func TestNewModulePostgresConfig(t *testing.T) {
+	t.Run("shared schema when no module has one", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Database.Postgres.Username = "telar"
+
+		pgConfig := NewModulePostgresConfig(cfg, "posts")
+		assert.Equal(t, "telar", pgConfig.Username)
+		assert.Empty(t, pgConfig.Schema)
+	})
+
+	t.Run("module schema and role", func(t *testing.T) {
+		cfg := testConfig()
+		cfg.Database.Postgres.Username = "telar"
+		cfg.Database.Postgres.Password = "shared"
+		cfg.Database.Postgres.Modules = map[string]platformconfig.PostgresModuleConfig{
+			"auth":     {Schema: "auth", Username: "telar_auth", Password: "secret"},
+			"posts":    {Schema: "posts"},
+			"comments": {Schema: "comments", Username: "telar_comments"},
+		}
+
+		pgConfig := NewModulePostgresConfig(cfg, "comments")
+		assert.Equal(t, "telar_comments", pgConfig.Username)
+		assert.Equal(t, "comments,auth,posts,public", pgConfig.Schema)
+
+		pgConfig = NewModulePostgresConfig(cfg, "posts")
+		assert.Equal(t, "telar", pgConfig.Username)
+		assert.Equal(t, "shared", pgConfig.Password)
+		assert.Equal(t, "posts,auth,comments,public", pgConfig.Schema)
+
+		// The monolith and modules without a schema see every module schema
+		assert.Equal(t, "auth,posts,comments,public", NewPostgresConfig(cfg).Schema)
+		assert.Equal(t, "auth,posts,comments,public", NewModulePostgresConfig(cfg, "profile").Schema)
+	})
+}
+
+func TestNewGRPCServer_HealthAndReflection(t *testing.T) {
+	server, err := newGRPCServer(platformconfig.GRPCConfig{}, func(server *grpc.Server) {
+		postspb.RegisterPostsServiceServer(server, posts.NewGrpcServer(nil))
+	})
+	require.NoError(t, err)
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+	ctx := context.Background()
+
+	health := healthpb.NewHealthClient(conn)
+	for _, service := range []string{"", "posts.v1.PostsService"} {
+		resp, err := health.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status, service)
+	}
+	_, err = health.Check(ctx, &healthpb.HealthCheckRequest{Service: "unknown.Service"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.Name)
+	}
+	assert.Contains(t, services, "posts.v1.PostsService")
+	assert.Contains(t, services, "grpc.health.v1.Health")
+}
+
+func TestNewGRPCServer_AuthenticatesCalls(t *testing.T) {
+	cfg := platformconfig.GRPCConfig{AuthSecret: "service-secret"}
+	server, err := newGRPCServer(cfg, func(server *grpc.Server) {
+		postspb.RegisterPostsServiceServer(server, posts.NewGrpcServer(nil))
+	})
+	require.NoError(t, err)
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	dial := func(cfg platformconfig.GRPCConfig) *grpc.ClientConn {
+		opts, err := grpcconn.DialOptions(cfg)
+		require.NoError(t, err)
+		conn, err := grpc.NewClient("passthrough:///bufnet", append(opts,
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }))...)
+		require.NoError(t, err)
+		return conn
+	}
+	ctx := context.Background()
+	req := &postspb.IncrementCommentCountRequest{PostId: "not-a-uuid"}
+
+	unsigned := dial(platformconfig.GRPCConfig{})
+	defer unsigned.Close()
+	_, err = postspb.NewPostsServiceClient(unsigned).IncrementCommentCount(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = healthpb.NewHealthClient(unsigned).Check(ctx, &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err, "health checks need no signature")
+
+	wrongSecret := dial(platformconfig.GRPCConfig{AuthSecret: "other"})
+	defer wrongSecret.Close()
+	_, err = postspb.NewPostsServiceClient(wrongSecret).IncrementCommentCount(ctx, req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	signed := dial(cfg)
+	defer signed.Close()
+	_, err = postspb.NewPostsServiceClient(signed).IncrementCommentCount(ctx, req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "signed calls reach the service")
+}
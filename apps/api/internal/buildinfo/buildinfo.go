@@ -0,0 +1,30 @@
+// Package buildinfo identifies the running build of the API. Release builds stamp it
+// with -ldflags:
+//
+//	go build -ldflags "-X github.com/qolzam/telar/apps/api/internal/buildinfo.Version=v1.2.0 -X github.com/qolzam/telar/apps/api/internal/buildinfo.GitSHA=$(git rev-parse HEAD)"
+package buildinfo
+
+import "runtime/debug"
+
+var (
+	// Version is the release of this build; "dev" when it was not stamped
+	Version = "dev"
+	// GitSHA is the commit this build was made from; see Revision
+	GitSHA = ""
+)
+
+// Revision returns GitSHA, or the commit the Go toolchain recorded when the binary
+// was built from a checkout without -ldflags. It is empty when neither is known.
+func Revision() string {
+	if GitSHA != "" {
+		return GitSHA
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, setting := range info.Settings {
+			if setting.Key == "vcs.revision" {
+				return setting.Value
+			}
+		}
+	}
+	return ""
+}
@@ -3,9 +3,40 @@ package cache
 import (
 	"os"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+var (
+	platformMu  sync.RWMutex
+	platformCfg *platformconfig.CacheConfig
+
+	// sharedRedis is the one Redis backend services created from platform config share;
+	// their keys are already apart by prefix. A failed connection is remembered, so
+	// every service falls back to memory after a single attempt.
+	sharedRedisOnce sync.Once
+	sharedRedis     Cache
 )
 
+// Configure makes NewGenericCacheServiceFor follow the platform cache settings
+// (CACHE_BACKEND, CACHE_PREFIX, REDIS_*) instead of its legacy environment lookups.
+// Call it once at startup, before services are created.
+func Configure(cfg platformconfig.CacheConfig) {
+	platformMu.Lock()
+	defer platformMu.Unlock()
+	platformCfg = &cfg
+}
+
+// configured returns the platform cache settings, or nil before Configure
+func configured() *platformconfig.CacheConfig {
+	platformMu.RLock()
+	defer platformMu.RUnlock()
+	return platformCfg
+}
+
 // toCacheConfig creates a default cache config with optional prefix
 func toCacheConfig(prefix string) *CacheConfig {
 	cfg := DefaultCacheConfig()
@@ -14,6 +45,11 @@ func toCacheConfig(prefix string) *CacheConfig {
 	if prefix != "" {
 		cfg.Prefix = prefix
 	}
+
+	if pc := configured(); pc != nil {
+		applyPlatformConfig(cfg, pc, prefix)
+		return cfg
+	}
 	
 	// Use environment variables for configuration
 	// Note: This is a legacy integration function - new code should use platform config
@@ -27,6 +63,60 @@ func toCacheConfig(prefix string) *CacheConfig {
 	return cfg
 }
 
+// applyPlatformConfig copies the platform settings onto cfg. Service keys go under
+// the deployment prefix, so instances sharing a Redis server agree on them.
+func applyPlatformConfig(cfg *CacheConfig, pc *platformconfig.CacheConfig, prefix string) {
+	cfg.Enabled = pc.Enabled
+	cfg.Backend = CacheType(strings.ToLower(pc.Backend))
+	if pc.TTL > 0 {
+		cfg.TTL = pc.TTL
+	}
+	if pc.MaxMemory > 0 {
+		cfg.MaxMemory = pc.MaxMemory
+	}
+	if pc.CleanupInterval > 0 {
+		cfg.CleanupInterval = pc.CleanupInterval
+	}
+	if deployment := strings.TrimSuffix(pc.Prefix, ":"); deployment != "" {
+		cfg.Prefix = deployment
+		if prefix != "" {
+			cfg.Prefix += ":" + prefix
+		}
+	}
+
+	cfg.Redis.Address = pc.Redis.Address
+	cfg.Redis.Password = pc.Redis.Password
+	cfg.Redis.Database = pc.Redis.Database
+	if pc.Redis.PoolSize > 0 {
+		cfg.Redis.PoolSize = pc.Redis.PoolSize
+	}
+	cfg.Redis.MinIdleConns = pc.Redis.MinIdleConns
+	if pc.Redis.MaxConnAge > 0 {
+		cfg.Redis.MaxConnAge = pc.Redis.MaxConnAge
+	}
+	if pc.Redis.Cluster.Enabled {
+		cfg.Redis.Cluster.Enabled = true
+		cfg.Redis.Cluster.Addresses = pc.Redis.Cluster.Addresses
+		if cfg.Redis.Password == "" {
+			cfg.Redis.Password = pc.Redis.Cluster.Password
+		}
+	}
+}
+
+// sharedRedisBackend connects the Redis backend on first use, or returns nil when
+// Redis cannot be reached
+func sharedRedisBackend(cfg *CacheConfig) Cache {
+	sharedRedisOnce.Do(func() {
+		redisCache, err := NewRedisCache(cfg)
+		if err != nil {
+			log.Warn("Redis cache unavailable, caching in memory per instance: %v", err)
+			return
+		}
+		sharedRedis = redisCache
+	})
+	return sharedRedis
+}
+
 // NewGenericCacheServiceFor creates a GenericCacheService using app config, isolated in cache layer
 // prefix sets a service-specific key prefix, e.g. "posts"
 func NewGenericCacheServiceFor(prefix string) *GenericCacheService {
@@ -35,6 +125,9 @@ func NewGenericCacheServiceFor(prefix string) *GenericCacheService {
 	// Optional backend switch via env: memory | redis | hybrid
 	// Note: This is a legacy integration function - new code should use platform config
 	backend := strings.ToLower(os.Getenv("CACHE_BACKEND"))
+	if configured() != nil {
+		backend = string(cfg.Backend)
+	}
 	if backend == "" {
 		backend = "memory"
 	}
@@ -46,6 +139,14 @@ func NewGenericCacheServiceFor(prefix string) *GenericCacheService {
 		cfg.Enabled = false
 		cacheBackend = nil
 
+	case "redis":
+		// Shared across instances; memory keeps the service working without Redis
+		cfg.Backend = CacheTypeRedis
+		if cacheBackend = sharedRedisBackend(cfg); cacheBackend == nil {
+			cfg.Backend = CacheTypeMemory
+			cacheBackend = NewMemoryCache(cfg)
+		}
+
 	case "test":
 		cfg.Enabled = true
 		cfg.Backend = CacheTypeMemory
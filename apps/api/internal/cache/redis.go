@@ -79,9 +79,12 @@ func (r *RedisCache) Get(ctx context.Context, key string) ([]byte, error) {
 	return result, nil
 }
 
-// Set stores a value in Redis cache with TTL
+// Set stores a value in Redis cache with TTL, tagging it for DeletePattern
 func (r *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
-	if err := r.client.Set(ctx, key, value, ttl).Err(); err != nil {
+	err := r.setTagged(ctx, key, ttl, func(pipe redis.Pipeliner) {
+		pipe.Set(ctx, key, value, ttl)
+	})
+	if err != nil {
 		return fmt.Errorf("redis set error: %w", err)
 	}
 	return nil
@@ -95,32 +98,18 @@ func (r *RedisCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
-// DeletePattern removes all keys matching the given pattern using SCAN
+// DeletePattern removes all keys matching the given pattern. Keys are found through
+// the tag sets written with them (see redis_tags.go), never by scanning the keyspace.
 func (r *RedisCache) DeletePattern(ctx context.Context, pattern string) error {
-	// Use SCAN to find matching keys to avoid blocking Redis
-	var cursor uint64
-	var deletedCount int64
-
-	for {
-		keys, nextCursor, err := r.client.Scan(ctx, cursor, pattern, 100).Result()
-		if err != nil {
-			return fmt.Errorf("redis scan error: %w", err)
-		}
-
-		if len(keys) > 0 {
-			// Delete keys in batch
-			if err := r.client.Del(ctx, keys...).Err(); err != nil {
-				return fmt.Errorf("redis batch delete error: %w", err)
-			}
-			deletedCount += int64(len(keys))
-		}
-
-		cursor = nextCursor
-		if cursor == 0 {
-			break
+	namespaces, err := r.namespacesFor(ctx, pattern)
+	if err != nil {
+		return err
+	}
+	for _, namespace := range namespaces {
+		if _, err := r.deleteTagged(ctx, namespace, pattern); err != nil {
+			return err
 		}
 	}
-
 	return nil
 }
 
@@ -143,7 +132,10 @@ func (r *RedisCache) Increment(ctx context.Context, key string, delta int64) (in
 	// Set TTL if this is a new key
 	ttl, err := r.client.TTL(ctx, key).Result()
 	if err == nil && ttl == -1 { // Key exists but has no TTL
-		r.client.Expire(ctx, key, r.config.TTL)
+		pipe := r.client.Pipeline()
+		pipe.Expire(ctx, key, r.config.TTL)
+		tag(ctx, pipe, key, r.config.TTL)
+		pipe.Exec(ctx)
 	}
 
 	return result, nil
@@ -151,7 +143,10 @@ func (r *RedisCache) Increment(ctx context.Context, key string, delta int64) (in
 
 // SetAdd adds a member to a Redis set at the given key.
 func (r *RedisCache) SetAdd(ctx context.Context, key string, member string) error {
-	if err := r.client.SAdd(ctx, key, member).Err(); err != nil {
+	err := r.setTagged(ctx, key, 0, func(pipe redis.Pipeliner) {
+		pipe.SAdd(ctx, key, member)
+	})
+	if err != nil {
 		return fmt.Errorf("redis sadd error: %w", err)
 	}
 	return nil
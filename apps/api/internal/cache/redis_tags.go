@@ -0,0 +1,172 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Pattern invalidation without SCAN: every key written through RedisCache is added to
+// the tag set of its namespace (the key up to its second colon, e.g. "telar:posts:"),
+// and every namespace to a registry set. DeletePattern reads the tag sets the pattern
+// can reach instead of walking the keyspace, which blocks Redis on large databases and
+// misses keys on other cluster nodes.
+const (
+	tagKeyPrefix    = "__tags:"
+	tagRegistryKey  = "__tags"
+	tagNamespaceLen = 2 // colons a namespace ends at
+)
+
+// tagScript adds a key to its namespace's tag set and keeps the set alive at least as
+// long as the key; a key without expiry makes the set persistent
+var tagScript = redis.NewScript(`
+local existed = redis.call('EXISTS', KEYS[1])
+redis.call('SADD', KEYS[1], ARGV[1])
+local ttl = tonumber(ARGV[2])
+if ttl <= 0 then
+	redis.call('PERSIST', KEYS[1])
+	return 0
+end
+local current = redis.call('PTTL', KEYS[1])
+if existed == 0 or (current >= 0 and current < ttl) then
+	redis.call('PEXPIRE', KEYS[1], ttl)
+end
+return 0
+`)
+
+// tagNamespace returns the namespace key belongs to: the key through its second colon,
+// or its last colon when it has fewer, or "" when it has none
+func tagNamespace(key string) string {
+	end := 0
+	for colons := 0; colons < tagNamespaceLen; colons++ {
+		i := strings.IndexByte(key[end:], ':')
+		if i < 0 {
+			break
+		}
+		end += i + 1
+	}
+	return key[:end]
+}
+
+// literalPrefix returns the part of a glob pattern before its first wildcard
+func literalPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, "*?["); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
+
+// tag queues the tagging of key on pipe
+func tag(ctx context.Context, pipe redis.Pipeliner, key string, ttl time.Duration) {
+	namespace := tagNamespace(key)
+	tagScript.Eval(ctx, pipe, []string{tagKeyPrefix + namespace}, key, ttl.Milliseconds())
+	pipe.SAdd(ctx, tagRegistryKey, namespace)
+}
+
+// setTagged writes key and tags it in one round trip
+func (r *RedisCache) setTagged(ctx context.Context, key string, ttl time.Duration, write func(redis.Pipeliner)) error {
+	pipe := r.client.Pipeline()
+	write(pipe)
+	tag(ctx, pipe, key, ttl)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// namespacesFor returns the namespaces whose keys may match pattern. A pattern whose
+// literal prefix reaches past a namespace needs only that namespace; shorter ones
+// consult the registry.
+func (r *RedisCache) namespacesFor(ctx context.Context, pattern string) ([]string, error) {
+	literal := literalPrefix(pattern)
+	if strings.Count(literal, ":") >= tagNamespaceLen || literal == pattern {
+		return []string{tagNamespace(literal)}, nil
+	}
+
+	registered, err := r.client.SMembers(ctx, tagRegistryKey).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis tag registry error: %w", err)
+	}
+	// A matching key starts with both the literal prefix and its namespace, so one of
+	// the two is a prefix of the other
+	namespaces := make([]string, 0, len(registered))
+	for _, namespace := range registered {
+		if strings.HasPrefix(namespace, literal) || strings.HasPrefix(literal, namespace) {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	return namespaces, nil
+}
+
+// deleteTagged deletes the keys of a namespace matching pattern and forgets them,
+// along with members whose keys already expired
+func (r *RedisCache) deleteTagged(ctx context.Context, namespace, pattern string) (int, error) {
+	tagKey := tagKeyPrefix + namespace
+	members, err := r.client.SMembers(ctx, tagKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("redis tag read error: %w", err)
+	}
+
+	var matched []string
+	exists := make(map[string]*redis.IntCmd)
+	pipe := r.client.Pipeline()
+	for _, key := range members {
+		if matchGlob(key, pattern) {
+			matched = append(matched, key)
+			// One DEL per key, so keys in different cluster slots are not mixed
+			pipe.Del(ctx, key)
+		} else {
+			exists[key] = pipe.Exists(ctx, key)
+		}
+	}
+	if pipe.Len() == 0 {
+		return 0, nil
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("redis batch delete error: %w", err)
+	}
+
+	forget := matched
+	for key, cmd := range exists {
+		if cmd.Val() == 0 {
+			forget = append(forget, key)
+		}
+	}
+	if len(forget) > 0 {
+		members := make([]interface{}, len(forget))
+		for i, key := range forget {
+			members[i] = key
+		}
+		if err := r.client.SRem(ctx, tagKey, members...).Err(); err != nil {
+			return len(matched), fmt.Errorf("redis tag cleanup error: %w", err)
+		}
+	}
+	return len(matched), nil
+}
+
+// matchGlob reports whether text matches a Redis-style pattern of '*' (any run of
+// characters, including '/' and ':') and '?' (any one character)
+func matchGlob(text, pattern string) bool {
+	t, p := 0, 0
+	star, mark := -1, 0
+	for t < len(text) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == text[t]):
+			t++
+			p++
+		case p < len(pattern) && pattern[p] == '*':
+			star, mark = p, t
+			p++
+		case star >= 0:
+			mark++
+			t, p = mark, star+1
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
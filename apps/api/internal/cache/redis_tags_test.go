@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"testing"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+func TestTagNamespace(t *testing.T) {
+	cases := map[string]string{
+		"telar:posts:post:123": "telar:posts:",
+		"telar:posts":          "telar:",
+		"plain":                "",
+	}
+	for key, want := range cases {
+		if got := tagNamespace(key); got != want {
+			t.Errorf("tagNamespace(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestLiteralPrefix(t *testing.T) {
+	if got := literalPrefix("telar:posts:post:*"); got != "telar:posts:post:" {
+		t.Errorf("literalPrefix = %q", got)
+	}
+	if got := literalPrefix("telar:posts:a?c"); got != "telar:posts:a" {
+		t.Errorf("literalPrefix = %q", got)
+	}
+	if got := literalPrefix("exact"); got != "exact" {
+		t.Errorf("literalPrefix = %q", got)
+	}
+}
+
+func TestMatchGlob(t *testing.T) {
+	cases := []struct {
+		text, pattern string
+		want          bool
+	}{
+		{"telar:posts:post:1", "telar:posts:post:*", true},
+		{"telar:posts:feed:1", "telar:posts:post:*", false},
+		{"telar:posts:post:1", "telar:posts:post:?", true},
+		{"telar:posts:post:12", "telar:posts:post:?", false},
+		{"telar:posts:post:1:comments", "telar:*:comments", true},
+		{"exact", "exact", true},
+		{"", "*", true},
+	}
+	for _, c := range cases {
+		if got := matchGlob(c.text, c.pattern); got != c.want {
+			t.Errorf("matchGlob(%q, %q) = %v, want %v", c.text, c.pattern, got, c.want)
+		}
+	}
+}
+
+func TestConfigure_RedisUnreachableFallsBackToMemory(t *testing.T) {
+	Configure(platformconfig.CacheConfig{
+		Enabled: true,
+		Backend: "redis",
+		Prefix:  "telar:",
+		Redis:   platformconfig.RedisConfig{Address: "127.0.0.1:1"},
+	})
+	defer func() {
+		platformMu.Lock()
+		platformCfg = nil
+		platformMu.Unlock()
+	}()
+
+	svc := NewGenericCacheServiceFor("posts")
+	if svc.config.Prefix != "telar:posts" {
+		t.Fatalf("prefix = %q, want telar:posts", svc.config.Prefix)
+	}
+	if svc.config.Backend != CacheTypeMemory {
+		t.Fatalf("backend = %q, want memory fallback", svc.config.Backend)
+	}
+	if _, ok := svc.cache.(*MemoryCache); !ok {
+		t.Fatalf("cache = %T, want *MemoryCache", svc.cache)
+	}
+}
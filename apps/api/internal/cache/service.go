@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
 )
 
 // GenericCacheService provides a generic caching service for all microservices
@@ -100,8 +101,10 @@ func (gcs *GenericCacheService) GetCached(ctx context.Context, key string, targe
 	if err != nil {
 		if err == ErrKeyNotFound {
 			gcs.stats.incMisses()
+			gcs.observeLookup(metrics.CacheMiss)
 		} else {
 			gcs.stats.incErrors()
+			gcs.observeLookup(metrics.CacheError)
 			log.Error("Cache get error for key %s: %v", fullKey, err)
 		}
 		return err
@@ -110,14 +113,25 @@ func (gcs *GenericCacheService) GetCached(ctx context.Context, key string, targe
 	// Unmarshal the data
 	if err := json.Unmarshal(data, target); err != nil {
 		gcs.stats.incErrors()
+		gcs.observeLookup(metrics.CacheError)
 		log.Error("Cache data unmarshal error for key %s: %v", fullKey, err)
 		return fmt.Errorf("%w: %v", ErrDeserializationFailed, err)
 	}
 	
 	gcs.stats.incHits()
+	gcs.observeLookup(metrics.CacheHit)
 	return nil
 }
 
+// observeLookup counts a lookup of an enabled cache under its prefix
+func (gcs *GenericCacheService) observeLookup(result string) {
+	name := strings.TrimSuffix(gcs.config.Prefix, ":")
+	if name == "" {
+		name = "default"
+	}
+	metrics.Default().ObserveCacheLookup(name, result)
+}
+
 // CacheData marshals and stores data in cache with TTL
 func (gcs *GenericCacheService) CacheData(ctx context.Context, key string, data interface{}, ttl ...time.Duration) error {
 	if !gcs.config.Enabled || gcs.cache == nil {
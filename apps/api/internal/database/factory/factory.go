@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/qolzam/telar/apps/api/internal/database/mongodb"
 	"github.com/qolzam/telar/apps/api/internal/database/postgresql"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 )
@@ -89,6 +90,23 @@ func NewRepositoryFactoryFromPlatformConfig(dbConfig platformconfig.DatabaseConf
 		if config.PostgresConfig.Schema == "" {
 			config.PostgresConfig.Schema = "public"
 		}
+
+	case interfaces.DatabaseTypeMongoDB:
+		config.ConnectionString = dbConfig.Mongo.URI
+		config.MongoConfig = &interfaces.MongoDBConfig{
+			URI:            dbConfig.Mongo.URI,
+			Database:       dbConfig.Mongo.Database,
+			MaxPoolSize:    dbConfig.Mongo.MaxPoolSize,
+			ConnectTimeout: 10, // Default 10 seconds
+		}
+
+		// A database named in the URI takes precedence, as it does in POSTGRES_DSN
+		if parsed, err := url.Parse(dbConfig.Mongo.URI); err == nil {
+			if dbName := strings.TrimPrefix(parsed.Path, "/"); dbName != "" {
+				config.MongoConfig.Database = dbName
+				config.DatabaseName = dbName
+			}
+		}
 	}
 
 	return &RepositoryFactory{
@@ -101,6 +119,8 @@ func getDatabaseName(dbConfig platformconfig.DatabaseConfig) string {
 	switch dbConfig.Type {
 	case interfaces.DatabaseTypePostgreSQL:
 		return dbConfig.Postgres.Database
+	case interfaces.DatabaseTypeMongoDB:
+		return dbConfig.Mongo.Database
 	default:
 		return "telar_social" // Default database name
 	}
@@ -111,8 +131,10 @@ func (f *RepositoryFactory) CreateRepository(ctx context.Context) (interfaces.Re
 	switch f.config.DatabaseType {
 	case interfaces.DatabaseTypePostgreSQL:
 		return f.createPostgreSQLRepository(ctx)
+	case interfaces.DatabaseTypeMongoDB:
+		return f.createMongoDBRepository(ctx)
 	default:
-		return nil, fmt.Errorf("unsupported database type: %s (only PostgreSQL is supported)", f.config.DatabaseType)
+		return nil, fmt.Errorf("unsupported database type: %s", f.config.DatabaseType)
 	}
 }
 
@@ -130,6 +152,25 @@ func (f *RepositoryFactory) createPostgreSQLRepository(ctx context.Context) (int
 	return pgRepo, nil
 }
 
+// createMongoDBRepository creates a MongoDB repository instance
+func (f *RepositoryFactory) createMongoDBRepository(ctx context.Context) (interfaces.Repository, error) {
+	if f.config.MongoConfig == nil {
+		return nil, fmt.Errorf("MongoDB configuration is missing")
+	}
+
+	databaseName := f.config.DatabaseName
+	if databaseName == "" {
+		databaseName = f.config.MongoConfig.Database
+	}
+
+	mongoRepo, err := mongodb.NewMongoDBRepository(ctx, f.config.MongoConfig, databaseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create MongoDB repository: %w", err)
+	}
+
+	return mongoRepo, nil
+}
+
 // CreateRepositoryFromConnectionString creates a repository from a connection string
 func CreateRepositoryFromConnectionString(ctx context.Context, databaseType, connectionString, databaseName string) (interfaces.Repository, error) {
 	config := &interfaces.RepositoryConfig{
@@ -146,6 +187,11 @@ func CreateRepositoryFromConnectionString(ctx context.Context, databaseType, con
 			Port:    5432,
 			SSLMode: "disable",
 		}
+	case interfaces.DatabaseTypeMongoDB:
+		config.MongoConfig = &interfaces.MongoDBConfig{
+			URI:      connectionString,
+			Database: databaseName,
+		}
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", databaseType)
 	}
@@ -171,6 +217,22 @@ func CreateRepositoryFromConfig(ctx context.Context, databaseType string, config
 		factory := NewRepositoryFactory(factoryConfig)
 		return factory.CreateRepository(ctx)
 
+	case interfaces.DatabaseTypeMongoDB:
+		mongoConfig, ok := config.(*interfaces.MongoDBConfig)
+		if !ok {
+			return nil, fmt.Errorf("invalid MongoDB configuration type")
+		}
+
+		factoryConfig := &interfaces.RepositoryConfig{
+			DatabaseType:     databaseType,
+			ConnectionString: mongoConfig.URI,
+			DatabaseName:     mongoConfig.Database,
+			MongoConfig:      mongoConfig,
+		}
+
+		factory := NewRepositoryFactory(factoryConfig)
+		return factory.CreateRepository(ctx)
+
 	default:
 		return nil, fmt.Errorf("unsupported database type: %s", databaseType)
 	}
@@ -193,6 +255,12 @@ func (f *RepositoryFactory) ValidateConfig() error {
 		}
 		return f.validatePostgreSQLConfig()
 
+	case interfaces.DatabaseTypeMongoDB:
+		if f.config.MongoConfig == nil {
+			return fmt.Errorf("MongoDB configuration is required")
+		}
+		return f.validateMongoDBConfig()
+
 	default:
 		return fmt.Errorf("unsupported database type: %s", f.config.DatabaseType)
 	}
@@ -232,3 +300,29 @@ func (f *RepositoryFactory) validatePostgreSQLConfig() error {
 
 	return nil
 }
+
+// validateMongoDBConfig validates MongoDB configuration
+func (f *RepositoryFactory) validateMongoDBConfig() error {
+	config := f.config.MongoConfig
+
+	if config.URI == "" {
+		return fmt.Errorf("MongoDB URI is required")
+	}
+
+	if config.Database == "" {
+		config.Database = f.config.DatabaseName
+	}
+	if config.Database == "" {
+		return fmt.Errorf("MongoDB database name is required")
+	}
+
+	if config.MaxPoolSize <= 0 {
+		config.MaxPoolSize = 100 // Driver default pool size
+	}
+
+	if config.ConnectTimeout <= 0 {
+		config.ConnectTimeout = 10 // Default 10 seconds
+	}
+
+	return nil
+}
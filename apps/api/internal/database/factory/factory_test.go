@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 )
 
 func TestRepositoryFactory_ValidateConfigErrors(t *testing.T) {
@@ -21,3 +22,25 @@ func TestRepositoryFactory_CreateUnsupported(t *testing.T) {
 }
 
 
+func TestRepositoryFactory_ValidateMongoDBConfig(t *testing.T) {
+    f := NewRepositoryFactory(&interfaces.RepositoryConfig{ DatabaseType: interfaces.DatabaseTypeMongoDB })
+    if err := f.ValidateConfig(); err == nil { t.Fatalf("expected error for missing mongodb config") }
+
+    f = NewRepositoryFactory(&interfaces.RepositoryConfig{ DatabaseType: interfaces.DatabaseTypeMongoDB, MongoConfig: &interfaces.MongoDBConfig{ Database: "telar" } })
+    if err := f.ValidateConfig(); err == nil { t.Fatalf("expected error for missing mongodb uri") }
+
+    cfg := &interfaces.MongoDBConfig{ URI: "mongodb://localhost:27017" }
+    f = NewRepositoryFactory(&interfaces.RepositoryConfig{ DatabaseType: interfaces.DatabaseTypeMongoDB, DatabaseName: "telar", MongoConfig: cfg })
+    if err := f.ValidateConfig(); err != nil { t.Fatalf("unexpected error: %v", err) }
+    if cfg.Database != "telar" || cfg.ConnectTimeout == 0 || cfg.MaxPoolSize == 0 { t.Fatalf("expected defaults to be applied, got %+v", cfg) }
+}
+
+func TestNewRepositoryFactoryFromPlatformConfig_MongoDBURIDatabase(t *testing.T) {
+    f := NewRepositoryFactoryFromPlatformConfig(platformconfig.DatabaseConfig{
+        Type:  interfaces.DatabaseTypeMongoDB,
+        Mongo: platformconfig.MongoDBConfig{ URI: "mongodb://localhost:27017/telar_social?replicaSet=rs0", Database: "telar" },
+    })
+    if f.config.DatabaseName != "telar_social" || f.config.MongoConfig.Database != "telar_social" {
+        t.Fatalf("expected the database named in the URI, got %q", f.config.DatabaseName)
+    }
+}
@@ -13,6 +13,9 @@ type RepositoryConfig struct {
 
 	// PostgreSQL specific
 	PostgresConfig *PostgreSQLConfig
+
+	// MongoDB specific
+	MongoConfig *MongoDBConfig
 }
 
 // PostgreSQLConfig represents PostgreSQL specific configuration
@@ -30,3 +33,11 @@ type PostgreSQLConfig struct {
 	MaxLifetime        int
 	Schema             string
 }
+
+// MongoDBConfig represents MongoDB specific configuration
+type MongoDBConfig struct {
+	URI            string
+	Database       string
+	MaxPoolSize    int
+	ConnectTimeout int
+}
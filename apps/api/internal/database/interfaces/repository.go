@@ -206,6 +206,14 @@ type TransactionMetrics struct {
 	ErrorMessage     string        `json:"errorMessage,omitempty"`
 }
 
+// Aggregator is implemented by repositories that can run aggregation pipelines.
+// A pipeline is a list of single-operator stages such as {"$match": {...}} or
+// {"$group": {...}}; field references use the "$field" form. Services type-assert
+// a Repository to Aggregator before relying on it.
+type Aggregator interface {
+	Aggregate(ctx context.Context, collectionName string, pipeline interface{}) <-chan QueryResult
+}
+
 // Transaction represents a database transaction that can perform repository operations
 // It embeds Repository so it can be used anywhere a Repository is expected
 type Transaction interface {
@@ -264,6 +272,7 @@ type CursorPaginationResult struct {
 // Database configuration constants
 const (
 	DatabaseTypePostgreSQL = "postgresql"
+	DatabaseTypeMongoDB    = "mongodb"
 )
 
 // Common errors
@@ -0,0 +1,209 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package jsonbmigrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// DualWriteRepository writes the legacy collections as before and mirrors every
+// change to a mapped collection into its typed table. After a legacy write succeeds,
+// each document it touched is read back and upserted into the typed table, or removed
+// from it when the document is gone, so the typed row always follows the legacy row
+// however the write was expressed. Collections without a mapping pass straight through.
+type DualWriteRepository struct {
+	interfaces.Repository
+	db       sqlx.ExtContext
+	mappings map[string]Mapping
+}
+
+// NewDualWriteRepository mirrors writes of the mapped collections of legacy into db,
+// the connection that reaches the typed tables
+func NewDualWriteRepository(legacy interfaces.Repository, db sqlx.ExtContext, mapped []Mapping) *DualWriteRepository {
+	r := &DualWriteRepository{Repository: legacy, db: db, mappings: make(map[string]Mapping, len(mapped))}
+	for _, m := range mapped {
+		r.mappings[m.Collection()] = m
+	}
+	return r
+}
+
+// Save writes the document and mirrors it
+func (r *DualWriteRepository) Save(ctx context.Context, collectionName string, objectID uuid.UUID, ownerUserID uuid.UUID, createdDate, lastUpdated int64, data interface{}) <-chan interfaces.RepositoryResult {
+	write := r.Repository.Save(ctx, collectionName, objectID, ownerUserID, createdDate, lastUpdated, data)
+	return r.mirror(ctx, collectionName, write, func() []string { return []string{objectID.String()} })
+}
+
+// SaveMany writes the documents and mirrors them
+func (r *DualWriteRepository) SaveMany(ctx context.Context, collectionName string, items []interfaces.SaveItem) <-chan interfaces.RepositoryResult {
+	write := r.Repository.SaveMany(ctx, collectionName, items)
+	return r.mirror(ctx, collectionName, write, func() []string {
+		ids := make([]string, 0, len(items))
+		for _, item := range items {
+			ids = append(ids, item.ObjectID.String())
+		}
+		return ids
+	})
+}
+
+// Update writes the matching documents and mirrors them
+func (r *DualWriteRepository) Update(ctx context.Context, collectionName string, query *interfaces.Query, data interface{}, opts *interfaces.UpdateOptions) <-chan interfaces.RepositoryResult {
+	return r.mirrorQuery(ctx, collectionName, []*interfaces.Query{query}, func() <-chan interfaces.RepositoryResult {
+		return r.Repository.Update(ctx, collectionName, query, data, opts)
+	})
+}
+
+// UpdateMany writes the matching documents and mirrors them
+func (r *DualWriteRepository) UpdateMany(ctx context.Context, collectionName string, query *interfaces.Query, data interface{}, opts *interfaces.UpdateOptions) <-chan interfaces.RepositoryResult {
+	return r.mirrorQuery(ctx, collectionName, []*interfaces.Query{query}, func() <-chan interfaces.RepositoryResult {
+		return r.Repository.UpdateMany(ctx, collectionName, query, data, opts)
+	})
+}
+
+// Delete removes the matching documents and their typed rows
+func (r *DualWriteRepository) Delete(ctx context.Context, collectionName string, query *interfaces.Query) <-chan interfaces.RepositoryResult {
+	return r.mirrorQuery(ctx, collectionName, []*interfaces.Query{query}, func() <-chan interfaces.RepositoryResult {
+		return r.Repository.Delete(ctx, collectionName, query)
+	})
+}
+
+// DeleteMany removes the matching documents and their typed rows
+func (r *DualWriteRepository) DeleteMany(ctx context.Context, collectionName string, queries []*interfaces.Query) <-chan interfaces.RepositoryResult {
+	return r.mirrorQuery(ctx, collectionName, queries, func() <-chan interfaces.RepositoryResult {
+		return r.Repository.DeleteMany(ctx, collectionName, queries)
+	})
+}
+
+// UpdateFields writes the matching documents and mirrors them
+func (r *DualWriteRepository) UpdateFields(ctx context.Context, collectionName string, query *interfaces.Query, updates map[string]interface{}) <-chan interfaces.RepositoryResult {
+	return r.mirrorQuery(ctx, collectionName, []*interfaces.Query{query}, func() <-chan interfaces.RepositoryResult {
+		return r.Repository.UpdateFields(ctx, collectionName, query, updates)
+	})
+}
+
+// IncrementFields writes the matching documents and mirrors them
+func (r *DualWriteRepository) IncrementFields(ctx context.Context, collectionName string, query *interfaces.Query, increments map[string]interface{}) <-chan interfaces.RepositoryResult {
+	return r.mirrorQuery(ctx, collectionName, []*interfaces.Query{query}, func() <-chan interfaces.RepositoryResult {
+		return r.Repository.IncrementFields(ctx, collectionName, query, increments)
+	})
+}
+
+// UpdateAndIncrement writes the matching documents and mirrors them
+func (r *DualWriteRepository) UpdateAndIncrement(ctx context.Context, collectionName string, query *interfaces.Query, updates map[string]interface{}, increments map[string]interface{}) <-chan interfaces.RepositoryResult {
+	return r.mirrorQuery(ctx, collectionName, []*interfaces.Query{query}, func() <-chan interfaces.RepositoryResult {
+		return r.Repository.UpdateAndIncrement(ctx, collectionName, query, updates, increments)
+	})
+}
+
+// UpdateWithOwnership writes the document and mirrors it
+func (r *DualWriteRepository) UpdateWithOwnership(ctx context.Context, collectionName string, entityID interface{}, ownerID interface{}, updates map[string]interface{}) <-chan interfaces.RepositoryResult {
+	write := r.Repository.UpdateWithOwnership(ctx, collectionName, entityID, ownerID, updates)
+	return r.mirror(ctx, collectionName, write, func() []string { return []string{fmt.Sprint(entityID)} })
+}
+
+// DeleteWithOwnership removes the document and its typed row
+func (r *DualWriteRepository) DeleteWithOwnership(ctx context.Context, collectionName string, entityID interface{}, ownerID interface{}) <-chan interfaces.RepositoryResult {
+	write := r.Repository.DeleteWithOwnership(ctx, collectionName, entityID, ownerID)
+	return r.mirror(ctx, collectionName, write, func() []string { return []string{fmt.Sprint(entityID)} })
+}
+
+// IncrementWithOwnership writes the document and mirrors it
+func (r *DualWriteRepository) IncrementWithOwnership(ctx context.Context, collectionName string, entityID interface{}, ownerID interface{}, increments map[string]interface{}) <-chan interfaces.RepositoryResult {
+	write := r.Repository.IncrementWithOwnership(ctx, collectionName, entityID, ownerID, increments)
+	return r.mirror(ctx, collectionName, write, func() []string { return []string{fmt.Sprint(entityID)} })
+}
+
+// mirrorQuery runs a write by query and mirrors the documents the queries matched
+// before it, which covers updates that move a document out of the query and deletes,
+// and after it, which covers upserts
+func (r *DualWriteRepository) mirrorQuery(ctx context.Context, collectionName string, queries []*interfaces.Query, write func() <-chan interfaces.RepositoryResult) <-chan interfaces.RepositoryResult {
+	if _, ok := r.mappings[collectionName]; !ok {
+		return write()
+	}
+	before := r.matching(ctx, collectionName, queries)
+	return r.mirror(ctx, collectionName, write(), func() []string {
+		return append(before, r.matching(ctx, collectionName, queries)...)
+	})
+}
+
+// mirror forwards the result of a legacy write and, when it succeeded, syncs the
+// typed rows of the documents ids returns
+func (r *DualWriteRepository) mirror(ctx context.Context, collectionName string, write <-chan interfaces.RepositoryResult, ids func() []string) <-chan interfaces.RepositoryResult {
+	m, ok := r.mappings[collectionName]
+	if !ok {
+		return write
+	}
+
+	result := make(chan interfaces.RepositoryResult, 1)
+	go func() {
+		defer close(result)
+		res := <-write
+		if res.Error == nil {
+			seen := make(map[string]bool)
+			for _, id := range ids() {
+				if seen[id] {
+					continue
+				}
+				seen[id] = true
+				if err := r.sync(ctx, m, id); err != nil {
+					log.Warn("Dual write of %s %s to %s failed: %v", collectionName, id, m.Table(), err)
+				}
+			}
+		}
+		result <- res
+	}()
+	return result
+}
+
+// sync makes the typed row of a document match its legacy row
+func (r *DualWriteRepository) sync(ctx context.Context, m Mapping, objectID string) error {
+	query := &interfaces.Query{Conditions: []interfaces.Field{{Name: "object_id", Value: objectID, Operator: "="}}}
+	single := <-r.Repository.FindOne(ctx, m.Collection(), query)
+	if err := single.Error(); err != nil && !errors.Is(err, interfaces.ErrNoDocuments) {
+		return err
+	}
+
+	var data json.RawMessage
+	if err := single.Decode(&data); err != nil {
+		if errors.Is(err, interfaces.ErrNoDocuments) {
+			return m.Remove(ctx, r.db, objectID)
+		}
+		return err
+	}
+	return m.Upsert(ctx, r.db, Document{ObjectID: objectID, Data: data})
+}
+
+// matching lists the objectIds of the documents the queries match. A failed lookup
+// only costs the mirror, so it is logged and the write goes ahead.
+func (r *DualWriteRepository) matching(ctx context.Context, collectionName string, queries []*interfaces.Query) []string {
+	var ids []string
+	for _, query := range queries {
+		cursor := <-r.Repository.Find(ctx, collectionName, query, nil)
+		if err := cursor.Error(); err != nil {
+			log.Warn("Dual write lookup in %s failed: %v", collectionName, err)
+			continue
+		}
+		for cursor.Next() {
+			var data json.RawMessage
+			if err := cursor.Decode(&data); err != nil {
+				log.Warn("Dual write lookup in %s failed: %v", collectionName, err)
+				continue
+			}
+			if id, err := objectIDOf(data); err == nil {
+				ids = append(ids, id)
+			}
+		}
+		cursor.Close()
+	}
+	return ids
+}
@@ -0,0 +1,305 @@
+package jsonbmigrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeLegacy is an in-memory legacy repository keyed by objectId. Queries match on
+// object_id conditions; a query without one matches every document.
+type fakeLegacy struct {
+	interfaces.Repository
+	docs map[string]json.RawMessage
+}
+
+func newFakeLegacy() *fakeLegacy {
+	return &fakeLegacy{docs: map[string]json.RawMessage{}}
+}
+
+func (f *fakeLegacy) matches(query *interfaces.Query) []string {
+	var ids []string
+	for id := range f.docs {
+		ok := true
+		for _, cond := range query.Conditions {
+			if cond.Name == "object_id" && fmt.Sprint(cond.Value) != id {
+				ok = false
+			}
+		}
+		if ok {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func done(err error) <-chan interfaces.RepositoryResult {
+	result := make(chan interfaces.RepositoryResult, 1)
+	result <- interfaces.RepositoryResult{Error: err}
+	close(result)
+	return result
+}
+
+func (f *fakeLegacy) Save(ctx context.Context, collectionName string, objectID uuid.UUID, ownerUserID uuid.UUID, createdDate, lastUpdated int64, data interface{}) <-chan interfaces.RepositoryResult {
+	if _, exists := f.docs[objectID.String()]; exists {
+		return done(interfaces.ErrDuplicateKey)
+	}
+	raw, _ := json.Marshal(data)
+	f.docs[objectID.String()] = raw
+	return done(nil)
+}
+
+func (f *fakeLegacy) UpdateFields(ctx context.Context, collectionName string, query *interfaces.Query, updates map[string]interface{}) <-chan interfaces.RepositoryResult {
+	for _, id := range f.matches(query) {
+		var doc map[string]interface{}
+		json.Unmarshal(f.docs[id], &doc)
+		for field, value := range updates {
+			doc[field] = value
+		}
+		f.docs[id], _ = json.Marshal(doc)
+	}
+	return done(nil)
+}
+
+func (f *fakeLegacy) Delete(ctx context.Context, collectionName string, query *interfaces.Query) <-chan interfaces.RepositoryResult {
+	for _, id := range f.matches(query) {
+		delete(f.docs, id)
+	}
+	return done(nil)
+}
+
+func (f *fakeLegacy) Find(ctx context.Context, collectionName string, query *interfaces.Query, opts *interfaces.FindOptions) <-chan interfaces.QueryResult {
+	cursor := &fakeCursor{}
+	for _, id := range f.matches(query) {
+		cursor.docs = append(cursor.docs, f.docs[id])
+	}
+	result := make(chan interfaces.QueryResult, 1)
+	result <- cursor
+	close(result)
+	return result
+}
+
+func (f *fakeLegacy) FindOne(ctx context.Context, collectionName string, query *interfaces.Query) <-chan interfaces.SingleResult {
+	single := &fakeSingle{}
+	if ids := f.matches(query); len(ids) > 0 {
+		single.doc = f.docs[ids[0]]
+	}
+	result := make(chan interfaces.SingleResult, 1)
+	result <- single
+	close(result)
+	return result
+}
+
+type fakeCursor struct {
+	docs []json.RawMessage
+	next int
+}
+
+func (c *fakeCursor) Next() bool { c.next++; return c.next <= len(c.docs) }
+func (c *fakeCursor) Decode(v interface{}) error {
+	return json.Unmarshal(c.docs[c.next-1], v)
+}
+func (c *fakeCursor) Close()       {}
+func (c *fakeCursor) Error() error { return nil }
+
+type fakeSingle struct {
+	doc json.RawMessage
+}
+
+func (s *fakeSingle) Decode(v interface{}) error {
+	if s.doc == nil {
+		return interfaces.ErrNoDocuments
+	}
+	return json.Unmarshal(s.doc, v)
+}
+func (s *fakeSingle) Error() error   { return nil }
+func (s *fakeSingle) NoResult() bool { return s.doc == nil }
+
+// fakeMapping keeps the typed table in memory
+type fakeMapping struct {
+	collection string
+	rows       map[string]json.RawMessage
+	failOn     string // objectId whose upsert fails
+}
+
+func newFakeMapping(collection string) *fakeMapping {
+	return &fakeMapping{collection: collection, rows: map[string]json.RawMessage{}}
+}
+
+func (m *fakeMapping) Collection() string { return m.collection }
+func (m *fakeMapping) Table() string      { return m.collection + "_typed" }
+
+func (m *fakeMapping) Upsert(ctx context.Context, db sqlx.ExtContext, doc Document) error {
+	if doc.ObjectID == m.failOn {
+		return errors.New("constraint violated")
+	}
+	m.rows[doc.ObjectID] = doc.Data
+	return nil
+}
+
+func (m *fakeMapping) Remove(ctx context.Context, db sqlx.ExtContext, objectID string) error {
+	delete(m.rows, objectID)
+	return nil
+}
+
+func (m *fakeMapping) Compare(ctx context.Context, db sqlx.ExtContext, doc Document) ([]string, error) {
+	row, ok := m.rows[doc.ObjectID]
+	if !ok {
+		return []string{FieldMissing}, nil
+	}
+	if string(row) != string(doc.Data) {
+		return []string{"data"}, nil
+	}
+	return nil, nil
+}
+
+func objectQuery(id uuid.UUID) *interfaces.Query {
+	return &interfaces.Query{Conditions: []interfaces.Field{{Name: "object_id", Value: id, Operator: "="}}}
+}
+
+func TestDualWriteRepository(t *testing.T) {
+	ctx := context.Background()
+	legacy := newFakeLegacy()
+	mapping := newFakeMapping("userAuth")
+	repo := NewDualWriteRepository(legacy, nil, []Mapping{mapping})
+	id := uuid.Must(uuid.NewV4())
+
+	t.Run("mirrors saves", func(t *testing.T) {
+		doc := map[string]interface{}{"objectId": id.String(), "role": "user"}
+		require.NoError(t, (<-repo.Save(ctx, "userAuth", id, id, 1, 1, doc)).Error)
+		assert.JSONEq(t, `{"objectId":"`+id.String()+`","role":"user"}`, string(mapping.rows[id.String()]))
+	})
+
+	t.Run("mirrors updates by query", func(t *testing.T) {
+		require.NoError(t, (<-repo.UpdateFields(ctx, "userAuth", objectQuery(id), map[string]interface{}{"role": "admin"})).Error)
+		assert.JSONEq(t, `{"objectId":"`+id.String()+`","role":"admin"}`, string(mapping.rows[id.String()]))
+	})
+
+	t.Run("does not mirror failed writes", func(t *testing.T) {
+		mapping.rows[id.String()] = json.RawMessage(`"stale"`)
+		err := (<-repo.Save(ctx, "userAuth", id, id, 1, 1, map[string]interface{}{"objectId": id.String()})).Error
+		assert.ErrorIs(t, err, interfaces.ErrDuplicateKey)
+		assert.Equal(t, `"stale"`, string(mapping.rows[id.String()]))
+	})
+
+	t.Run("removes deleted documents", func(t *testing.T) {
+		require.NoError(t, (<-repo.Delete(ctx, "userAuth", objectQuery(id))).Error)
+		assert.Empty(t, mapping.rows)
+	})
+
+	t.Run("passes other collections through", func(t *testing.T) {
+		other := uuid.Must(uuid.NewV4())
+		require.NoError(t, (<-repo.Save(ctx, "posts", other, other, 1, 1, map[string]interface{}{"objectId": other.String()})).Error)
+		assert.Contains(t, legacy.docs, other.String())
+		assert.Empty(t, mapping.rows)
+	})
+}
+
+// fakeSource pages through fixed documents
+type fakeSource struct {
+	docs  []Document
+	reads int
+}
+
+func (s *fakeSource) Batch(ctx context.Context, collection string, afterID int64, limit int) ([]Document, error) {
+	s.reads++
+	var batch []Document
+	for _, doc := range s.docs {
+		if doc.ID > afterID && len(batch) < limit {
+			batch = append(batch, doc)
+		}
+	}
+	return batch, nil
+}
+
+type fakeCheckpoints struct {
+	saved map[string]Checkpoint
+}
+
+func (c *fakeCheckpoints) Load(ctx context.Context, collection string) (Checkpoint, error) {
+	return c.saved[collection], nil
+}
+
+func (c *fakeCheckpoints) Save(ctx context.Context, checkpoint Checkpoint) error {
+	c.saved[checkpoint.Collection] = checkpoint
+	return nil
+}
+
+func legacyDocs(n int) []Document {
+	docs := make([]Document, n)
+	for i := range docs {
+		id := fmt.Sprintf("doc-%d", i+1)
+		docs[i] = Document{ID: int64(i + 1), ObjectID: id, Data: json.RawMessage(`{"objectId":"` + id + `"}`)}
+	}
+	return docs
+}
+
+func TestMigratorBackfill(t *testing.T) {
+	ctx := context.Background()
+	source := &fakeSource{docs: legacyDocs(5)}
+	checkpoints := &fakeCheckpoints{saved: map[string]Checkpoint{}}
+	mapping := newFakeMapping("userAuth")
+	mapping.failOn = "doc-2"
+	migrator := NewMigrator(nil, source, checkpoints)
+
+	checkpoint, err := migrator.Backfill(ctx, mapping, BackfillOptions{BatchSize: 2})
+	require.NoError(t, err)
+	assert.Equal(t, Checkpoint{Collection: "userAuth", LastID: 5, Copied: 4, Failed: 1, Done: true}, checkpoint)
+	assert.Equal(t, checkpoint, checkpoints.saved["userAuth"])
+	assert.Len(t, mapping.rows, 4)
+	assert.Equal(t, 3, source.reads)
+
+	t.Run("resumes after the checkpoint", func(t *testing.T) {
+		source.docs = append(source.docs, legacyDocs(7)[5:]...)
+		source.reads = 0
+		checkpoint, err := migrator.Backfill(ctx, mapping, BackfillOptions{BatchSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), checkpoint.LastID)
+		assert.Equal(t, int64(6), checkpoint.Copied)
+		assert.Equal(t, 2, source.reads)
+	})
+
+	t.Run("restarts from the first row", func(t *testing.T) {
+		mapping.failOn = ""
+		checkpoint, err := migrator.Backfill(ctx, mapping, BackfillOptions{BatchSize: 10, Restart: true})
+		require.NoError(t, err)
+		assert.Equal(t, Checkpoint{Collection: "userAuth", LastID: 7, Copied: 7, Done: true}, checkpoint)
+	})
+}
+
+func TestMigratorVerify(t *testing.T) {
+	docs := legacyDocs(4)
+	mapping := newFakeMapping("userProfile")
+	mapping.rows["doc-1"] = docs[0].Data
+	mapping.rows["doc-2"] = json.RawMessage(`{"objectId":"doc-2","fullName":"old"}`)
+	mapping.rows["doc-4"] = docs[3].Data
+	migrator := NewMigrator(nil, &fakeSource{docs: docs}, &fakeCheckpoints{})
+
+	report, err := migrator.Verify(context.Background(), mapping, VerifyOptions{BatchSize: 3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), report.Checked)
+	assert.Equal(t, int64(1), report.Missing)
+	assert.Equal(t, int64(1), report.Mismatched)
+	assert.False(t, report.Clean())
+	assert.Equal(t, []Mismatch{{ObjectID: "doc-2", Fields: []string{"data"}}, {ObjectID: "doc-3", Fields: []string{FieldMissing}}}, report.Samples)
+}
+
+func TestLookup(t *testing.T) {
+	selected, err := Lookup([]string{"userProfile", "userAuth"})
+	require.NoError(t, err)
+	assert.Equal(t, "profiles", selected[0].Table())
+	assert.Equal(t, "user_auths", selected[1].Table())
+
+	_, err = Lookup([]string{"posts"})
+	assert.Error(t, err)
+}
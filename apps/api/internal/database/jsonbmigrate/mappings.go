@@ -0,0 +1,223 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package jsonbmigrate
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+func init() {
+	register(userAuthMapping{})
+	register(userProfileMapping{})
+}
+
+// legacyUserAuth is a userAuth document as the auth services write it
+type legacyUserAuth struct {
+	ObjectID      string `json:"objectId"`
+	Username      string `json:"username"`
+	Password      []byte `json:"password"`
+	Role          string `json:"role"`
+	EmailVerified bool   `json:"emailVerified"`
+	PhoneVerified bool   `json:"phoneVerified"`
+	Suspended     bool   `json:"suspended"`
+	SuspendedDate int64  `json:"suspendedDate"`
+	CreatedDate   int64  `json:"createdDate"`
+	LastUpdated   int64  `json:"lastUpdated"`
+}
+
+// userAuthMapping moves userAuth documents to user_auths
+type userAuthMapping struct{}
+
+func (userAuthMapping) Collection() string { return "userAuth" }
+func (userAuthMapping) Table() string      { return "user_auths" }
+
+func (m userAuthMapping) decode(doc Document) (legacyUserAuth, error) {
+	var user legacyUserAuth
+	if err := json.Unmarshal(doc.Data, &user); err != nil {
+		return user, fmt.Errorf("failed to decode %s document: %w", m.Collection(), err)
+	}
+	if user.ObjectID == "" {
+		user.ObjectID = doc.ObjectID
+	}
+	if user.Role == "" {
+		user.Role = "user"
+	}
+	if user.Password == nil {
+		user.Password = []byte{}
+	}
+	return user, nil
+}
+
+func (m userAuthMapping) Upsert(ctx context.Context, db sqlx.ExtContext, doc Document) error {
+	user, err := m.decode(doc)
+	if err != nil {
+		return err
+	}
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO user_auths (id, username, password_hash, role, email_verified, phone_verified, suspended, suspended_date, created_date, last_updated)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			username = EXCLUDED.username,
+			password_hash = EXCLUDED.password_hash,
+			role = EXCLUDED.role,
+			email_verified = EXCLUDED.email_verified,
+			phone_verified = EXCLUDED.phone_verified,
+			suspended = EXCLUDED.suspended,
+			suspended_date = EXCLUDED.suspended_date,
+			created_date = EXCLUDED.created_date,
+			last_updated = EXCLUDED.last_updated,
+			updated_at = NOW()
+		WHERE user_auths.last_updated <= EXCLUDED.last_updated`,
+		user.ObjectID, user.Username, user.Password, user.Role, user.EmailVerified, user.PhoneVerified,
+		user.Suspended, user.SuspendedDate, user.CreatedDate, user.LastUpdated,
+	)
+	return err
+}
+
+func (userAuthMapping) Remove(ctx context.Context, db sqlx.ExtContext, objectID string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM user_auths WHERE id = $1`, objectID)
+	return err
+}
+
+func (m userAuthMapping) Compare(ctx context.Context, db sqlx.ExtContext, doc Document) ([]string, error) {
+	legacy, err := m.decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	var typed legacyUserAuth
+	err = db.QueryRowxContext(ctx, `
+		SELECT username, password_hash, COALESCE(role, ''), COALESCE(email_verified, FALSE), COALESCE(phone_verified, FALSE),
+			suspended, suspended_date, created_date, last_updated
+		FROM user_auths WHERE id = $1`, legacy.ObjectID,
+	).Scan(&typed.Username, &typed.Password, &typed.Role, &typed.EmailVerified, &typed.PhoneVerified,
+		&typed.Suspended, &typed.SuspendedDate, &typed.CreatedDate, &typed.LastUpdated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return []string{FieldMissing}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var diff differences
+	diff.check("username", legacy.Username == typed.Username)
+	diff.check("password", bytes.Equal(legacy.Password, typed.Password))
+	diff.check("role", legacy.Role == typed.Role)
+	diff.check("emailVerified", legacy.EmailVerified == typed.EmailVerified)
+	diff.check("phoneVerified", legacy.PhoneVerified == typed.PhoneVerified)
+	diff.check("suspended", legacy.Suspended == typed.Suspended)
+	diff.check("suspendedDate", legacy.SuspendedDate == typed.SuspendedDate)
+	diff.check("createdDate", legacy.CreatedDate == typed.CreatedDate)
+	diff.check("lastUpdated", legacy.LastUpdated == typed.LastUpdated)
+	return diff, nil
+}
+
+// legacyUserProfile is a userProfile document as the auth services write it
+type legacyUserProfile struct {
+	ObjectID    string `json:"objectId"`
+	FullName    string `json:"fullName"`
+	SocialName  string `json:"socialName"`
+	Email       string `json:"email"`
+	Avatar      string `json:"avatar"`
+	Banner      string `json:"banner"`
+	TagLine     string `json:"tagLine"`
+	CreatedDate int64  `json:"createdDate"`
+	LastUpdated int64  `json:"lastUpdated"`
+}
+
+// userProfileMapping moves userProfile documents to profiles
+type userProfileMapping struct{}
+
+func (userProfileMapping) Collection() string { return "userProfile" }
+func (userProfileMapping) Table() string      { return "profiles" }
+
+func (m userProfileMapping) decode(doc Document) (legacyUserProfile, error) {
+	var profile legacyUserProfile
+	if err := json.Unmarshal(doc.Data, &profile); err != nil {
+		return profile, fmt.Errorf("failed to decode %s document: %w", m.Collection(), err)
+	}
+	if profile.ObjectID == "" {
+		profile.ObjectID = doc.ObjectID
+	}
+	return profile, nil
+}
+
+func (m userProfileMapping) Upsert(ctx context.Context, db sqlx.ExtContext, doc Document) error {
+	profile, err := m.decode(doc)
+	if err != nil {
+		return err
+	}
+	// social_name is unique where set, so an empty one is stored as NULL
+	_, err = db.ExecContext(ctx, `
+		INSERT INTO profiles (user_id, full_name, social_name, email, avatar, banner, tagline, created_date, last_updated)
+		VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id) DO UPDATE SET
+			full_name = EXCLUDED.full_name,
+			social_name = EXCLUDED.social_name,
+			email = EXCLUDED.email,
+			avatar = EXCLUDED.avatar,
+			banner = EXCLUDED.banner,
+			tagline = EXCLUDED.tagline,
+			created_date = EXCLUDED.created_date,
+			last_updated = EXCLUDED.last_updated,
+			updated_at = NOW()
+		WHERE profiles.last_updated <= EXCLUDED.last_updated`,
+		profile.ObjectID, profile.FullName, profile.SocialName, profile.Email, profile.Avatar,
+		profile.Banner, profile.TagLine, profile.CreatedDate, profile.LastUpdated,
+	)
+	return err
+}
+
+func (userProfileMapping) Remove(ctx context.Context, db sqlx.ExtContext, objectID string) error {
+	_, err := db.ExecContext(ctx, `DELETE FROM profiles WHERE user_id = $1`, objectID)
+	return err
+}
+
+func (m userProfileMapping) Compare(ctx context.Context, db sqlx.ExtContext, doc Document) ([]string, error) {
+	legacy, err := m.decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	var typed legacyUserProfile
+	err = db.QueryRowxContext(ctx, `
+		SELECT COALESCE(full_name, ''), COALESCE(social_name, ''), COALESCE(email, ''), COALESCE(avatar, ''),
+			COALESCE(banner, ''), COALESCE(tagline, ''), created_date, last_updated
+		FROM profiles WHERE user_id = $1`, legacy.ObjectID,
+	).Scan(&typed.FullName, &typed.SocialName, &typed.Email, &typed.Avatar,
+		&typed.Banner, &typed.TagLine, &typed.CreatedDate, &typed.LastUpdated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return []string{FieldMissing}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var diff differences
+	diff.check("fullName", legacy.FullName == typed.FullName)
+	diff.check("socialName", legacy.SocialName == typed.SocialName)
+	diff.check("email", legacy.Email == typed.Email)
+	diff.check("avatar", legacy.Avatar == typed.Avatar)
+	diff.check("banner", legacy.Banner == typed.Banner)
+	diff.check("tagLine", legacy.TagLine == typed.TagLine)
+	diff.check("createdDate", legacy.CreatedDate == typed.CreatedDate)
+	diff.check("lastUpdated", legacy.LastUpdated == typed.LastUpdated)
+	return diff, nil
+}
+
+// differences collects the names of the fields that do not match
+type differences []string
+
+func (d *differences) check(field string, equal bool) {
+	if !equal {
+		*d = append(*d, field)
+	}
+}
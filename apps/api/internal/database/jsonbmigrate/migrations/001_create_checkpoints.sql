@@ -0,0 +1,11 @@
+-- Progress of backfills copying legacy JSONB collections to their typed tables, one
+-- row per collection, so an interrupted backfill resumes where it stopped
+
+CREATE TABLE IF NOT EXISTS data_migration_checkpoints (
+    collection VARCHAR(100) PRIMARY KEY,
+    last_id BIGINT NOT NULL DEFAULT 0, -- Legacy row id the backfill got through
+    copied BIGINT NOT NULL DEFAULT 0,
+    failed BIGINT NOT NULL DEFAULT 0,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,187 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package jsonbmigrate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+const defaultBatchSize = 500
+
+// Source pages through the rows of a legacy collection
+type Source interface {
+	// Batch returns up to limit rows of collection with an id above afterID, in id order
+	Batch(ctx context.Context, collection string, afterID int64, limit int) ([]Document, error)
+}
+
+// Checkpoint is how far a backfill of a collection got
+type Checkpoint struct {
+	Collection string
+	LastID     int64 // Legacy id of the last row copied; the next run starts after it
+	Copied     int64
+	Failed     int64
+	Done       bool
+}
+
+// Checkpoints stores backfill progress
+type Checkpoints interface {
+	// Load returns the checkpoint of collection, or a zero one when none is stored
+	Load(ctx context.Context, collection string) (Checkpoint, error)
+	Save(ctx context.Context, checkpoint Checkpoint) error
+}
+
+// Migrator runs backfills and verifications of mapped collections
+type Migrator struct {
+	db          sqlx.ExtContext
+	source      Source
+	checkpoints Checkpoints
+}
+
+// NewMigrator copies and compares rows from source to the typed tables reached through db
+func NewMigrator(db sqlx.ExtContext, source Source, checkpoints Checkpoints) *Migrator {
+	return &Migrator{db: db, source: source, checkpoints: checkpoints}
+}
+
+// BackfillOptions tune a backfill run
+type BackfillOptions struct {
+	BatchSize int           // Rows read and checkpointed at a time; defaults to 500
+	Pause     time.Duration // Wait between batches to spare the primary
+	Restart   bool          // Ignore the stored checkpoint and start from the first row
+}
+
+// Backfill copies the rows of a collection to its typed table, resuming after the
+// stored checkpoint. The checkpoint is saved after every batch, so a run stopped at
+// any point continues where it left off. Rows that fail to copy are counted and
+// logged, not retried; a verify run finds them.
+func (mg *Migrator) Backfill(ctx context.Context, m Mapping, opts BackfillOptions) (Checkpoint, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	checkpoint := Checkpoint{Collection: m.Collection()}
+	if !opts.Restart {
+		stored, err := mg.checkpoints.Load(ctx, m.Collection())
+		if err != nil {
+			return checkpoint, fmt.Errorf("failed to load checkpoint of %s: %w", m.Collection(), err)
+		}
+		checkpoint = stored
+		checkpoint.Collection = m.Collection()
+	}
+	// Rows written since the last run are past LastID, so even a finished backfill
+	// picks them up
+	checkpoint.Done = false
+
+	for {
+		docs, err := mg.source.Batch(ctx, m.Collection(), checkpoint.LastID, batchSize)
+		if err != nil {
+			return checkpoint, fmt.Errorf("failed to read %s after id %d: %w", m.Collection(), checkpoint.LastID, err)
+		}
+		for _, doc := range docs {
+			if err := m.Upsert(ctx, mg.db, doc); err != nil {
+				checkpoint.Failed++
+				log.Warn("Backfill of %s %s (id %d) to %s failed: %v", m.Collection(), doc.ObjectID, doc.ID, m.Table(), err)
+			} else {
+				checkpoint.Copied++
+			}
+			checkpoint.LastID = doc.ID
+		}
+		checkpoint.Done = len(docs) < batchSize
+		if err := mg.checkpoints.Save(ctx, checkpoint); err != nil {
+			return checkpoint, fmt.Errorf("failed to save checkpoint of %s: %w", m.Collection(), err)
+		}
+		log.Info("Backfill of %s: through id %d, %d copied, %d failed", m.Collection(), checkpoint.LastID, checkpoint.Copied, checkpoint.Failed)
+		if checkpoint.Done {
+			return checkpoint, nil
+		}
+
+		if opts.Pause > 0 {
+			select {
+			case <-ctx.Done():
+				return checkpoint, ctx.Err()
+			case <-time.After(opts.Pause):
+			}
+		}
+	}
+}
+
+// Mismatch is a legacy row whose typed row differs
+type Mismatch struct {
+	ObjectID string   `json:"objectId"`
+	Fields   []string `json:"fields"` // Differing fields, or FieldMissing
+}
+
+// Report is the outcome of a verify run
+type Report struct {
+	Collection string     `json:"collection"`
+	Checked    int64      `json:"checked"`
+	Missing    int64      `json:"missing"`    // Legacy rows without a typed row
+	Mismatched int64      `json:"mismatched"` // Legacy rows whose typed row differs
+	Errors     int64      `json:"errors"`     // Rows that could not be compared
+	Samples    []Mismatch `json:"samples"`    // The first mismatches found
+}
+
+// Clean reports whether every legacy row has an identical typed row
+func (r Report) Clean() bool {
+	return r.Missing == 0 && r.Mismatched == 0 && r.Errors == 0
+}
+
+// VerifyOptions tune a verify run
+type VerifyOptions struct {
+	BatchSize  int // Rows read at a time; defaults to 500
+	MaxSamples int // Mismatches kept in the report; defaults to 20
+}
+
+// Verify compares every legacy row of a collection with its typed row. It only reads,
+// so it can run against live traffic as often as needed before the cutover.
+func (mg *Migrator) Verify(ctx context.Context, m Mapping, opts VerifyOptions) (Report, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	maxSamples := opts.MaxSamples
+	if maxSamples <= 0 {
+		maxSamples = 20
+	}
+
+	report := Report{Collection: m.Collection(), Samples: []Mismatch{}}
+	var lastID int64
+	for {
+		docs, err := mg.source.Batch(ctx, m.Collection(), lastID, batchSize)
+		if err != nil {
+			return report, fmt.Errorf("failed to read %s after id %d: %w", m.Collection(), lastID, err)
+		}
+		for _, doc := range docs {
+			lastID = doc.ID
+			report.Checked++
+			fields, err := m.Compare(ctx, mg.db, doc)
+			if err != nil {
+				report.Errors++
+				log.Warn("Verify of %s %s (id %d) failed: %v", m.Collection(), doc.ObjectID, doc.ID, err)
+				continue
+			}
+			if len(fields) == 0 {
+				continue
+			}
+			if len(fields) == 1 && fields[0] == FieldMissing {
+				report.Missing++
+			} else {
+				report.Mismatched++
+			}
+			if len(report.Samples) < maxSamples {
+				report.Samples = append(report.Samples, Mismatch{ObjectID: doc.ObjectID, Fields: fields})
+			}
+		}
+		if len(docs) < batchSize {
+			return report, nil
+		}
+	}
+}
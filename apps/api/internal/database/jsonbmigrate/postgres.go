@@ -0,0 +1,95 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package jsonbmigrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// PostgresSource reads the legacy collection tables of the generic JSONB repository
+type PostgresSource struct {
+	db     sqlx.QueryerContext
+	schema string
+}
+
+// NewPostgresSource reads legacy collections from schema, "public" when empty
+func NewPostgresSource(db sqlx.QueryerContext, schema string) *PostgresSource {
+	if schema == "" {
+		schema = "public"
+	}
+	return &PostgresSource{db: db, schema: schema}
+}
+
+// Batch implements Source. A collection that was never written has no table and
+// reads as empty.
+func (s *PostgresSource) Batch(ctx context.Context, collection string, afterID int64, limit int) ([]Document, error) {
+	// Like the repository that creates them, the table name is left unquoted
+	query := fmt.Sprintf(`SELECT id, object_id, data FROM %s.%s WHERE id > $1 ORDER BY id LIMIT $2`, s.schema, collection)
+	rows, err := s.db.QueryxContext(ctx, query, afterID, limit)
+	if err != nil {
+		var pgErr *pq.Error
+		if errors.As(err, &pgErr) && pgErr.Code == "42P01" { // undefined_table
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer rows.Close()
+
+	var docs []Document
+	for rows.Next() {
+		var doc Document
+		if err := rows.Scan(&doc.ID, &doc.ObjectID, &doc.Data); err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, rows.Err()
+}
+
+// PostgresCheckpoints stores backfill progress in data_migration_checkpoints
+type PostgresCheckpoints struct {
+	db sqlx.ExtContext
+}
+
+// NewPostgresCheckpoints stores checkpoints through db
+func NewPostgresCheckpoints(db sqlx.ExtContext) *PostgresCheckpoints {
+	return &PostgresCheckpoints{db: db}
+}
+
+// Load implements Checkpoints
+func (c *PostgresCheckpoints) Load(ctx context.Context, collection string) (Checkpoint, error) {
+	checkpoint := Checkpoint{Collection: collection}
+	err := c.db.QueryRowxContext(ctx, `
+		SELECT last_id, copied, failed, done FROM data_migration_checkpoints WHERE collection = $1`,
+		collection,
+	).Scan(&checkpoint.LastID, &checkpoint.Copied, &checkpoint.Failed, &checkpoint.Done)
+	if errors.Is(err, sql.ErrNoRows) {
+		return checkpoint, nil
+	}
+	return checkpoint, err
+}
+
+// Save implements Checkpoints
+func (c *PostgresCheckpoints) Save(ctx context.Context, checkpoint Checkpoint) error {
+	_, err := c.db.ExecContext(ctx, `
+		INSERT INTO data_migration_checkpoints (collection, last_id, copied, failed, done, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT (collection) DO UPDATE SET
+			last_id = EXCLUDED.last_id,
+			copied = EXCLUDED.copied,
+			failed = EXCLUDED.failed,
+			done = EXCLUDED.done,
+			updated_at = NOW()`,
+		checkpoint.Collection, checkpoint.LastID, checkpoint.Copied, checkpoint.Failed, checkpoint.Done,
+	)
+	return err
+}
@@ -0,0 +1,459 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package mongodb
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Documents mirror the PostgreSQL table layout so the same Query objects work on both
+// backends: the indexed columns are top-level fields and the entity lives under "data".
+const (
+	fieldObjectID    = "object_id"
+	fieldOwnerUserID = "owner_user_id"
+	fieldData        = "data"
+	fieldCreatedDate = "created_date"
+	fieldLastUpdated = "last_updated"
+)
+
+var (
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+	castPattern       = regexp.MustCompile(`(::[A-Za-z][A-Za-z0-9_ ]*)+$`)
+	jsonbArrowPattern = regexp.MustCompile(`->>?`)
+)
+
+// fieldPath translates a column name or JSONB path into a dotted document path,
+// e.g. "data->>'body'" becomes "data.body" and "(data->'votes'->>'up')::int" becomes "data.votes.up"
+func fieldPath(name string) (string, error) {
+	expr := strings.TrimSpace(name)
+	for {
+		stripped := strings.TrimSpace(castPattern.ReplaceAllString(expr, ""))
+		if strings.HasPrefix(stripped, "(") && strings.HasSuffix(stripped, ")") {
+			stripped = strings.TrimSpace(stripped[1 : len(stripped)-1])
+		}
+		if stripped == expr {
+			break
+		}
+		expr = stripped
+	}
+
+	if !strings.Contains(expr, "->") {
+		if !identifierPattern.MatchString(expr) {
+			return "", fmt.Errorf("%w: unsupported field %q", interfaces.ErrInvalidFilter, name)
+		}
+		return expr, nil
+	}
+
+	parts := jsonbArrowPattern.Split(expr, -1)
+	segments := make([]string, 0, len(parts))
+	for i, part := range parts {
+		segment := strings.TrimSpace(part)
+		if i > 0 {
+			segment = strings.Trim(segment, "'")
+		}
+		if segment == "" || strings.ContainsAny(segment, "'$") || (i == 0 && !identifierPattern.MatchString(segment)) {
+			return "", fmt.Errorf("%w: unsupported field %q", interfaces.ErrInvalidFilter, name)
+		}
+		segments = append(segments, segment)
+	}
+	return strings.Join(segments, "."), nil
+}
+
+// dataPath prefixes an entity field, using dot notation for nested fields, with the data document
+func dataPath(key string) string {
+	segments := strings.Split(key, ".")
+	for i := range segments {
+		segments[i] = strings.TrimSpace(segments[i])
+	}
+	return fieldData + "." + strings.Join(segments, ".")
+}
+
+// toBSONDocument converts an entity to the document stored under "data". It goes through
+// JSON so documents match what PostgreSQL stores in JSONB: json tags name the fields,
+// UUIDs become strings and integers keep their width.
+func toBSONDocument(data interface{}) (bson.D, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal data: %w", err)
+	}
+	trimmed := strings.TrimSpace(string(jsonData))
+	if !strings.HasPrefix(trimmed, "{") {
+		return nil, fmt.Errorf("data must marshal to a JSON object, got %s", trimmed)
+	}
+
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON(jsonData, false, &doc); err != nil {
+		return nil, fmt.Errorf("failed to convert data to BSON: %w", err)
+	}
+	return doc, nil
+}
+
+// toBSONValue converts a query or update value the same way toBSONDocument converts
+// entities, so values compare against stored fields like they do against JSONB
+func toBSONValue(value interface{}) (interface{}, error) {
+	jsonData, err := json.Marshal(map[string]interface{}{"v": value})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal value: %w", err)
+	}
+
+	var doc bson.D
+	if err := bson.UnmarshalExtJSON(jsonData, false, &doc); err != nil {
+		return nil, fmt.Errorf("failed to convert value to BSON: %w", err)
+	}
+	if len(doc) != 1 {
+		return nil, fmt.Errorf("failed to convert value to BSON")
+	}
+	return doc[0].Value, nil
+}
+
+// coerceCast applies a JSONB cast to string values, since PostgreSQL would cast them
+// before comparing while MongoDB compares types strictly
+func coerceCast(value interface{}, cast string) interface{} {
+	s, ok := value.(string)
+	if !ok || cast == "" {
+		return value
+	}
+
+	switch strings.ToLower(strings.TrimSpace(strings.TrimPrefix(cast, "::"))) {
+	case "boolean", "bool":
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	case "int", "integer", "bigint", "smallint":
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+	case "numeric", "decimal", "real", "float", "double precision":
+		if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return i
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return value
+}
+
+// buildFilter is the MongoDB counterpart of the PostgreSQL WHERE clause builder:
+// conditions are ANDed together and each OR group becomes an $or clause
+func buildFilter(query *interfaces.Query) (bson.D, error) {
+	if query == nil {
+		return bson.D{}, nil
+	}
+
+	clauses := bson.A{}
+	for _, field := range query.Conditions {
+		clause, err := buildCondition(field)
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, clause)
+	}
+
+	for _, group := range query.OrGroups {
+		alternatives := bson.A{}
+		for _, field := range group {
+			clause, err := buildCondition(field)
+			if err != nil {
+				return nil, err
+			}
+			alternatives = append(alternatives, clause)
+		}
+		if len(alternatives) > 0 {
+			clauses = append(clauses, bson.D{{Key: "$or", Value: alternatives}})
+		}
+	}
+
+	if len(clauses) == 0 {
+		return bson.D{}, nil
+	}
+	return bson.D{{Key: "$and", Value: clauses}}, nil
+}
+
+func buildCondition(field interfaces.Field) (bson.D, error) {
+	path, err := fieldPath(field.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	operator := strings.ToUpper(strings.TrimSpace(field.Operator))
+	switch operator {
+	case "CURSOR_PAGINATION":
+		return buildCursorCondition(path, field.Value)
+	case "IS NULL":
+		return bson.D{{Key: path, Value: nil}}, nil
+	case "IS NOT NULL":
+		return bson.D{{Key: path, Value: bson.D{{Key: "$ne", Value: nil}}}}, nil
+	}
+
+	value, err := toBSONValue(coerceCast(field.Value, field.JSONBCast))
+	if err != nil {
+		return nil, err
+	}
+
+	// Non-empty arrays mean "= ANY" as in the PostgreSQL builder. $in also covers
+	// CONTAINS_ANY, since it matches array fields sharing any element with the list.
+	if values, ok := value.(bson.A); ok && len(values) > 0 {
+		if operator == "!=" || operator == "<>" || operator == "NOT IN" {
+			return bson.D{{Key: path, Value: bson.D{{Key: "$nin", Value: values}}}}, nil
+		}
+		return bson.D{{Key: path, Value: bson.D{{Key: "$in", Value: values}}}}, nil
+	}
+
+	switch operator {
+	case "LIKE", "ILIKE", "NOT LIKE", "NOT ILIKE":
+		pattern, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s requires a string pattern", interfaces.ErrInvalidFilter, field.Operator)
+		}
+		regex := primitive.Regex{Pattern: likeToRegex(pattern)}
+		if strings.HasSuffix(operator, "ILIKE") {
+			regex.Options = "i"
+		}
+		if strings.HasPrefix(operator, "NOT ") {
+			return bson.D{{Key: path, Value: bson.D{{Key: "$not", Value: regex}}}}, nil
+		}
+		return bson.D{{Key: path, Value: regex}}, nil
+	case "~", "~*", "REGEX", "REGEX_I":
+		pattern, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s requires a string pattern", interfaces.ErrInvalidFilter, field.Operator)
+		}
+		regex := primitive.Regex{Pattern: pattern}
+		if operator == "~*" || operator == "REGEX_I" {
+			regex.Options = "i"
+		}
+		return bson.D{{Key: path, Value: regex}}, nil
+	}
+
+	mongoOp, err := comparisonOperator(operator)
+	if err != nil {
+		return nil, err
+	}
+	return bson.D{{Key: path, Value: bson.D{{Key: mongoOp, Value: value}}}}, nil
+}
+
+// buildCursorCondition translates the keyset condition added by WhereCursorCondition:
+// rows past the sort value, or on it with an object_id past the tie breaker
+func buildCursorCondition(path string, raw interface{}) (bson.D, error) {
+	valMap, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid CURSOR_PAGINATION value for %s", interfaces.ErrInvalidFilter, path)
+	}
+
+	primaryOp, _ := valMap["primaryOp"].(string)
+	if primaryOp == "" {
+		primaryOp = "<"
+	}
+	tieOp, _ := valMap["tieOp"].(string)
+	if tieOp == "" {
+		tieOp = primaryOp
+	}
+
+	primary, err := comparisonOperator(primaryOp)
+	if err != nil {
+		return nil, err
+	}
+	tie, err := comparisonOperator(tieOp)
+	if err != nil {
+		return nil, err
+	}
+	sortValue, err := toBSONValue(valMap["sortValue"])
+	if err != nil {
+		return nil, err
+	}
+	tieBreaker, err := toBSONValue(valMap["tieBreaker"])
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.D{{Key: "$or", Value: bson.A{
+		bson.D{{Key: path, Value: bson.D{{Key: primary, Value: sortValue}}}},
+		bson.D{
+			{Key: path, Value: bson.D{{Key: "$eq", Value: sortValue}}},
+			{Key: fieldObjectID, Value: bson.D{{Key: tie, Value: tieBreaker}}},
+		},
+	}}}, nil
+}
+
+func comparisonOperator(operator string) (string, error) {
+	switch strings.TrimSpace(operator) {
+	case "=", "==":
+		return "$eq", nil
+	case "!=", "<>":
+		return "$ne", nil
+	case "<":
+		return "$lt", nil
+	case "<=":
+		return "$lte", nil
+	case ">":
+		return "$gt", nil
+	case ">=":
+		return "$gte", nil
+	default:
+		return "", fmt.Errorf("%w: unsupported operator %q", interfaces.ErrInvalidFilter, operator)
+	}
+}
+
+// likeToRegex converts a SQL LIKE pattern into an anchored regular expression
+func likeToRegex(pattern string) string {
+	var b strings.Builder
+	b.WriteString("^")
+	escaped := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			b.WriteString(".*")
+		case r == '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// buildSort translates sort options keyed by column names or JSONB paths, in the same
+// deterministic order the PostgreSQL ORDER BY builder uses
+func buildSort(sortFields map[string]int) (bson.D, error) {
+	keys := make([]string, 0, len(sortFields))
+	for key := range sortFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	sortDoc := bson.D{}
+	for _, key := range keys {
+		path, err := fieldPath(key)
+		if err != nil {
+			return nil, err
+		}
+		direction := 1
+		if sortFields[key] == -1 {
+			direction = -1
+		}
+		sortDoc = append(sortDoc, bson.E{Key: path, Value: direction})
+	}
+	return sortDoc, nil
+}
+
+// buildDataFields converts entity field updates into data-prefixed update fields
+func buildDataFields(fields map[string]interface{}, convert func(interface{}) (interface{}, error)) (bson.D, error) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	doc := bson.D{}
+	for _, key := range keys {
+		value, err := convert(fields[key])
+		if err != nil {
+			return nil, err
+		}
+		doc = append(doc, bson.E{Key: dataPath(key), Value: value})
+	}
+	return doc, nil
+}
+
+// incrementValue validates an increment the same way the PostgreSQL builder does.
+// Whole numbers stay integers so incremented fields still decode into integer fields.
+func incrementValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32:
+		return toBSONValue(v)
+	case uint64:
+		if v > math.MaxInt64 {
+			return nil, fmt.Errorf("increment %d overflows int64", v)
+		}
+		return int64(v), nil
+	case float32:
+		return incrementValue(float64(v))
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v), nil
+		}
+		return v, nil
+	case string:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return i, nil
+		}
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f, nil
+		}
+		return nil, fmt.Errorf("cannot convert string '%s' to numeric for increment", v)
+	default:
+		return nil, fmt.Errorf("unsupported type for increment: %T", value)
+	}
+}
+
+// buildUpdate sets and increments entity fields and stamps last_updated
+func buildUpdate(updates, increments map[string]interface{}, lastUpdated int64) (bson.D, error) {
+	set, err := buildDataFields(updates, toBSONValue)
+	if err != nil {
+		return nil, err
+	}
+	set = append(set, bson.E{Key: fieldLastUpdated, Value: lastUpdated})
+	update := bson.D{{Key: "$set", Value: set}}
+
+	if len(increments) > 0 {
+		inc, err := buildDataFields(increments, incrementValue)
+		if err != nil {
+			return nil, err
+		}
+		update = append(update, bson.E{Key: "$inc", Value: inc})
+	}
+	return update, nil
+}
+
+// buildUpdateDocument mirrors the PostgreSQL update clause builder: plain maps set fields,
+// $set/$inc maps set and increment them, and anything else replaces the whole entity
+func buildUpdateDocument(data interface{}, lastUpdated int64) (bson.D, error) {
+	if fields, ok := data.(map[string]interface{}); ok {
+		setMap, hasSet := fields["$set"].(map[string]interface{})
+		incMap, hasInc := fields["$inc"].(map[string]interface{})
+		if hasSet || hasInc {
+			return buildUpdate(setMap, incMap, lastUpdated)
+		}
+
+		hasOperators := false
+		for key := range fields {
+			if strings.HasPrefix(key, "$") {
+				hasOperators = true
+				break
+			}
+		}
+		if !hasOperators {
+			return buildUpdate(fields, nil, lastUpdated)
+		}
+		return nil, fmt.Errorf("%w: unsupported update operators", interfaces.ErrUnsupportedOperation)
+	}
+
+	doc, err := toBSONDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	return bson.D{{Key: "$set", Value: bson.D{
+		{Key: fieldData, Value: doc},
+		{Key: fieldLastUpdated, Value: lastUpdated},
+	}}}, nil
+}
@@ -0,0 +1,195 @@
+package mongodb
+
+import (
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestFieldPath(t *testing.T) {
+	cases := map[string]string{
+		"object_id":                         "object_id",
+		"created_date":                      "created_date",
+		"data->>'body'":                     "data.body",
+		"data->'votes'->>'up'":              "data.votes.up",
+		"(data->>'score')::numeric":         "data.score",
+		"(data->>'rank')::double precision": "data.rank",
+		" data ->> 'deleted' ":              "data.deleted",
+	}
+	for name, want := range cases {
+		got, err := fieldPath(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, want, got, name)
+	}
+
+	for _, name := range []string{"", "data->>''", "data #>> '{a,b}'", "data->>'$where'", "lower(data->>'x')"} {
+		_, err := fieldPath(name)
+		assert.ErrorIs(t, err, interfaces.ErrInvalidFilter, name)
+	}
+}
+
+func TestBuildFilter_TranslatesOperators(t *testing.T) {
+	id := uuid.Must(uuid.NewV4())
+	query := &interfaces.Query{
+		Conditions: []interfaces.Field{
+			{Name: "object_id", Value: id, Operator: "="},
+			{Name: "data->>'deleted'", Value: "false", Operator: "=", IsJSONB: true, JSONBCast: "::boolean"},
+			{Name: "(data->>'score')", Value: 10, Operator: ">=", IsJSONB: true, JSONBCast: "::numeric"},
+			{Name: "data->>'role'", Value: []string{"admin", "user"}, Operator: "="},
+			{Name: "data->>'bannedDate'", Operator: "IS NULL"},
+		},
+		OrGroups: [][]interfaces.Field{{
+			{Name: "data->>'fullName'", Value: "%ann_e%", Operator: "ILIKE"},
+			{Name: "data->>'email'", Value: "^ann", Operator: "REGEX_I"},
+		}},
+	}
+
+	filter, err := buildFilter(query)
+	require.NoError(t, err)
+
+	want := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "object_id", Value: bson.D{{Key: "$eq", Value: id.String()}}}},
+		bson.D{{Key: "data.deleted", Value: bson.D{{Key: "$eq", Value: false}}}},
+		bson.D{{Key: "data.score", Value: bson.D{{Key: "$gte", Value: int32(10)}}}},
+		bson.D{{Key: "data.role", Value: bson.D{{Key: "$in", Value: bson.A{"admin", "user"}}}}},
+		bson.D{{Key: "data.bannedDate", Value: nil}},
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "data.fullName", Value: primitive.Regex{Pattern: "^.*ann.e.*$", Options: "i"}}},
+			bson.D{{Key: "data.email", Value: primitive.Regex{Pattern: "^ann", Options: "i"}}},
+		}}},
+	}}}
+	assert.Equal(t, want, filter)
+}
+
+func TestBuildFilter_EmptyQueryMatchesEverything(t *testing.T) {
+	filter, err := buildFilter(nil)
+	require.NoError(t, err)
+	assert.Empty(t, filter)
+
+	filter, err = buildFilter(&interfaces.Query{})
+	require.NoError(t, err)
+	assert.Empty(t, filter)
+}
+
+func TestBuildFilter_RejectsUnknownOperator(t *testing.T) {
+	_, err := buildFilter(&interfaces.Query{Conditions: []interfaces.Field{
+		{Name: "data->>'body'", Value: "x", Operator: "@@"},
+	}})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidFilter)
+}
+
+func TestBuildFilter_CursorPagination(t *testing.T) {
+	filter, err := buildFilter(&interfaces.Query{Conditions: []interfaces.Field{{
+		Name:     "created_date",
+		Operator: "CURSOR_PAGINATION",
+		Value: map[string]interface{}{
+			"sortValue":  int64(1700000000000),
+			"tieBreaker": "b4c1",
+			"primaryOp":  ">",
+		},
+	}}})
+	require.NoError(t, err)
+
+	want := bson.D{{Key: "$and", Value: bson.A{
+		bson.D{{Key: "$or", Value: bson.A{
+			bson.D{{Key: "created_date", Value: bson.D{{Key: "$gt", Value: int64(1700000000000)}}}},
+			bson.D{
+				{Key: "created_date", Value: bson.D{{Key: "$eq", Value: int64(1700000000000)}}},
+				{Key: "object_id", Value: bson.D{{Key: "$gt", Value: "b4c1"}}},
+			},
+		}}},
+	}}}
+	assert.Equal(t, want, filter)
+}
+
+func TestLikeToRegex(t *testing.T) {
+	assert.Equal(t, "^a\\.b.*$", likeToRegex("a.b%"))
+	assert.Equal(t, "^100%$", likeToRegex("100\\%"))
+	assert.Equal(t, "^.\\(x\\)$", likeToRegex("_(x)"))
+}
+
+func TestBuildSort(t *testing.T) {
+	sortDoc, err := buildSort(map[string]int{"object_id": -1, "created_date": -1, "data->>'score'": 1})
+	require.NoError(t, err)
+	assert.Equal(t, bson.D{
+		{Key: "created_date", Value: -1},
+		{Key: "data.score", Value: 1},
+		{Key: "object_id", Value: -1},
+	}, sortDoc)
+}
+
+func TestBuildUpdateDocument(t *testing.T) {
+	update, err := buildUpdateDocument(map[string]interface{}{
+		"$set": map[string]interface{}{"body": "hi", "votes.abc": true},
+		"$inc": map[string]interface{}{"commentCounter": "2", "score": 1.5},
+	}, 99)
+	require.NoError(t, err)
+	assert.Equal(t, bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: "data.body", Value: "hi"},
+			{Key: "data.votes.abc", Value: true},
+			{Key: "last_updated", Value: int64(99)},
+		}},
+		{Key: "$inc", Value: bson.D{
+			{Key: "data.commentCounter", Value: int64(2)},
+			{Key: "data.score", Value: 1.5},
+		}},
+	}, update)
+
+	_, err = buildUpdateDocument(map[string]interface{}{"$inc": map[string]interface{}{"score": "many"}}, 99)
+	assert.Error(t, err)
+
+	type entity struct {
+		Body string `json:"body"`
+	}
+	update, err = buildUpdateDocument(entity{Body: "replaced"}, 99)
+	require.NoError(t, err)
+	assert.Equal(t, bson.D{{Key: "$set", Value: bson.D{
+		{Key: "data", Value: bson.D{{Key: "body", Value: "replaced"}}},
+		{Key: "last_updated", Value: int64(99)},
+	}}}, update)
+}
+
+func TestDocumentRoundTrip(t *testing.T) {
+	type entity struct {
+		ObjectId    uuid.UUID         `json:"objectId"`
+		Score       int64             `json:"score"`
+		Ratio       float64           `json:"ratio"`
+		CreatedDate int64             `json:"created_date"`
+		Tags        []string          `json:"tags"`
+		Votes       map[string]string `json:"votes"`
+	}
+	in := entity{
+		ObjectId:    uuid.Must(uuid.NewV4()),
+		Score:       7,
+		Ratio:       0.25,
+		CreatedDate: 1700000000123,
+		Tags:        []string{"go", "mongo"},
+		Votes:       map[string]string{"u1": "up"},
+	}
+
+	doc, err := newDocument(in.ObjectId, uuid.Nil, in.CreatedDate, in.CreatedDate, in)
+	require.NoError(t, err)
+	raw, err := bson.Marshal(doc)
+	require.NoError(t, err)
+
+	var out entity
+	require.NoError(t, decodeData(raw, &out))
+	assert.Equal(t, in, out)
+
+	_, err = toBSONDocument([]string{"not", "an", "object"})
+	assert.Error(t, err)
+}
+
+func TestSingleResult_NoDocuments(t *testing.T) {
+	result := &MongoDBSingleResult{noResult: true}
+	assert.True(t, result.NoResult())
+	assert.True(t, errors.Is(result.Error(), interfaces.ErrNoDocuments))
+	assert.True(t, errors.Is(result.Decode(&struct{}{}), interfaces.ErrNoDocuments))
+}
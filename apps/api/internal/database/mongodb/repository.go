@@ -0,0 +1,887 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package mongodb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+// indexTimeout bounds index creation, which runs outside any caller transaction
+const indexTimeout = 30 * time.Second
+
+// MongoDBRepository implements the Repository interface for MongoDB
+type MongoDBRepository struct {
+	client  *mongo.Client
+	db      *mongo.Database
+	dbName  string
+	indexed *sync.Map // collections whose indexes have been created
+
+	// tx is set on the repository a MongoDBTransaction runs its operations through
+	tx *MongoDBTransaction
+}
+
+// MongoDBQueryResult implements QueryResult for MongoDB
+type MongoDBQueryResult struct {
+	cursor *mongo.Cursor
+	ctx    context.Context
+	err    error
+	closed bool
+}
+
+// MongoDBSingleResult implements SingleResult for MongoDB
+type MongoDBSingleResult struct {
+	raw      bson.Raw
+	err      error
+	noResult bool
+}
+
+// NewMongoDBRepository creates a new MongoDB repository
+func NewMongoDBRepository(ctx context.Context, config *interfaces.MongoDBConfig, databaseName string) (*MongoDBRepository, error) {
+	clientOpts := options.Client().ApplyURI(config.URI)
+	if config.MaxPoolSize > 0 {
+		clientOpts.SetMaxPoolSize(uint64(config.MaxPoolSize))
+	}
+	if config.ConnectTimeout > 0 {
+		clientOpts.SetConnectTimeout(time.Duration(config.ConnectTimeout) * time.Second)
+		clientOpts.SetServerSelectionTimeout(time.Duration(config.ConnectTimeout) * time.Second)
+	}
+
+	client, err := mongo.Connect(ctx, clientOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MongoDB: %w", err)
+	}
+
+	// Test the connection
+	if err := client.Ping(ctx, readpref.Primary()); err != nil {
+		_ = client.Disconnect(ctx)
+		return nil, fmt.Errorf("failed to ping MongoDB: %w", err)
+	}
+
+	return &MongoDBRepository{
+		client:  client,
+		db:      client.Database(databaseName),
+		dbName:  databaseName,
+		indexed: &sync.Map{},
+	}, nil
+}
+
+// opContext returns the context an operation runs under. Inside a transaction that is the
+// transaction's session context, so the operation joins it whatever context was passed.
+func (r *MongoDBRepository) opContext(ctx context.Context) (context.Context, error) {
+	if r.tx == nil {
+		return ctx, nil
+	}
+	if !r.tx.isValidTransaction() {
+		return nil, interfaces.ErrTransactionInactive
+	}
+	r.tx.incrementOperationCount()
+	return r.tx.ctx, nil
+}
+
+// collection returns the collection, creating the indexes the PostgreSQL tables get
+// on first use
+func (r *MongoDBRepository) collection(collectionName string) *mongo.Collection {
+	coll := r.db.Collection(collectionName)
+	if _, done := r.indexed.Load(collectionName); done {
+		return coll
+	}
+
+	// Index builds cannot join a transaction, so they run on a fresh context
+	ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+	defer cancel()
+
+	indexes := []mongo.IndexModel{
+		{Keys: bson.D{{Key: fieldObjectID, Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: fieldCreatedDate, Value: 1}}},
+		{Keys: bson.D{{Key: fieldLastUpdated, Value: 1}}},
+		{Keys: bson.D{{Key: fieldOwnerUserID, Value: 1}}},
+		// Composite index to optimize cursor pagination and ordering
+		{Keys: bson.D{{Key: fieldCreatedDate, Value: 1}, {Key: fieldObjectID, Value: 1}}},
+	}
+	if _, err := coll.Indexes().CreateMany(ctx, indexes); err != nil {
+		log.Warn("Failed to create indexes for %s: %s", collectionName, err.Error())
+		return coll
+	}
+
+	r.indexed.Store(collectionName, true)
+	return coll
+}
+
+// newDocument builds the stored document for an entity
+func newDocument(objectID, ownerUserID uuid.UUID, createdDate, lastUpdated int64, data interface{}) (bson.D, error) {
+	doc, err := toBSONDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	return bson.D{
+		{Key: fieldObjectID, Value: objectID.String()},
+		{Key: fieldOwnerUserID, Value: ownerUserID.String()},
+		{Key: fieldData, Value: doc},
+		{Key: fieldCreatedDate, Value: createdDate},
+		{Key: fieldLastUpdated, Value: lastUpdated},
+	}, nil
+}
+
+// dataProjection returns only the entity, as the PostgreSQL queries SELECT data
+func dataProjection() bson.D {
+	return bson.D{{Key: fieldData, Value: 1}, {Key: "_id", Value: 0}}
+}
+
+// Save stores a single document with indexed columns explicitly provided
+func (r *MongoDBRepository) Save(ctx context.Context, collectionName string, objectID uuid.UUID, ownerUserID uuid.UUID, createdDate, lastUpdated int64, data interface{}) <-chan interfaces.RepositoryResult {
+	result := make(chan interfaces.RepositoryResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		doc, err := newDocument(objectID, ownerUserID, createdDate, lastUpdated, data)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		res, err := r.collection(collectionName).InsertOne(opCtx, doc)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				result <- interfaces.RepositoryResult{Error: interfaces.ErrDuplicateKey}
+				return
+			}
+			log.Error("MongoDB Save error: %s", err.Error())
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		result <- interfaces.RepositoryResult{Result: res.InsertedID}
+	}()
+
+	return result
+}
+
+// SaveMany stores multiple documents
+func (r *MongoDBRepository) SaveMany(ctx context.Context, collectionName string, items []interfaces.SaveItem) <-chan interfaces.RepositoryResult {
+	result := make(chan interfaces.RepositoryResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		if len(items) == 0 {
+			result <- interfaces.RepositoryResult{Result: []interface{}{}}
+			return
+		}
+
+		docs := make([]interface{}, 0, len(items))
+		for i, item := range items {
+			doc, err := newDocument(item.ObjectID, item.OwnerUserID, item.CreatedDate, item.LastUpdated, item.Data)
+			if err != nil {
+				result <- interfaces.RepositoryResult{Error: fmt.Errorf("failed to convert data at index %d: %w", i, err)}
+				return
+			}
+			docs = append(docs, doc)
+		}
+
+		res, err := r.collection(collectionName).InsertMany(opCtx, docs)
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				result <- interfaces.RepositoryResult{Error: interfaces.ErrDuplicateKey}
+				return
+			}
+			log.Error("MongoDB SaveMany error: %s", err.Error())
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		result <- interfaces.RepositoryResult{Result: res.InsertedIDs}
+	}()
+
+	return result
+}
+
+// Find retrieves multiple documents
+func (r *MongoDBRepository) Find(ctx context.Context, collectionName string, query *interfaces.Query, opts *interfaces.FindOptions) <-chan interfaces.QueryResult {
+	result := make(chan interfaces.QueryResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- &MongoDBQueryResult{err: err}
+			return
+		}
+
+		filter, err := buildFilter(query)
+		if err != nil {
+			result <- &MongoDBQueryResult{err: err}
+			return
+		}
+
+		findOpts := options.Find().SetProjection(dataProjection())
+		if opts != nil {
+			if len(opts.Sort) > 0 {
+				sortDoc, err := buildSort(opts.Sort)
+				if err != nil {
+					result <- &MongoDBQueryResult{err: err}
+					return
+				}
+				findOpts.SetSort(sortDoc)
+			}
+			if opts.Limit != nil {
+				findOpts.SetLimit(*opts.Limit)
+			}
+			if opts.Skip != nil {
+				findOpts.SetSkip(*opts.Skip)
+			}
+		}
+
+		cursor, err := r.collection(collectionName).Find(opCtx, filter, findOpts)
+		if err != nil {
+			log.Error("MongoDB Find error: %s", err.Error())
+			result <- &MongoDBQueryResult{err: err}
+			return
+		}
+
+		result <- &MongoDBQueryResult{cursor: cursor, ctx: opCtx}
+	}()
+
+	return result
+}
+
+// FindOne retrieves a single document
+func (r *MongoDBRepository) FindOne(ctx context.Context, collectionName string, query *interfaces.Query) <-chan interfaces.SingleResult {
+	result := make(chan interfaces.SingleResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- &MongoDBSingleResult{err: err}
+			return
+		}
+
+		filter, err := buildFilter(query)
+		if err != nil {
+			result <- &MongoDBSingleResult{err: err}
+			return
+		}
+
+		raw, err := r.collection(collectionName).FindOne(opCtx, filter, options.FindOne().SetProjection(dataProjection())).Raw()
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				result <- &MongoDBSingleResult{noResult: true}
+				return
+			}
+			log.Error("MongoDB FindOne error: %s", err.Error())
+			result <- &MongoDBSingleResult{err: err}
+			return
+		}
+
+		result <- &MongoDBSingleResult{raw: raw}
+	}()
+
+	return result
+}
+
+// Update updates documents matching the query
+func (r *MongoDBRepository) Update(ctx context.Context, collectionName string, query *interfaces.Query, data interface{}, opts *interfaces.UpdateOptions) <-chan interfaces.RepositoryResult {
+	result := make(chan interfaces.RepositoryResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		coll := r.collection(collectionName)
+
+		if opts != nil && opts.Upsert != nil && *opts.Upsert {
+			filter, update, err := buildUpsert(query, data)
+			if err != nil {
+				result <- interfaces.RepositoryResult{Error: err}
+				return
+			}
+			if _, err := coll.UpdateOne(opCtx, filter, update, options.Update().SetUpsert(true)); err != nil {
+				result <- interfaces.RepositoryResult{Error: fmt.Errorf("upsert failed: %w", err)}
+				return
+			}
+			result <- interfaces.RepositoryResult{Result: "OK"}
+			return
+		}
+
+		update, err := buildUpdateDocument(data, time.Now().Unix())
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		filter, err := buildFilter(query)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		if _, err := coll.UpdateMany(opCtx, filter, update); err != nil {
+			log.Error("MongoDB Update error: %s", err.Error())
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		result <- interfaces.RepositoryResult{Result: "OK"}
+	}()
+
+	return result
+}
+
+// buildUpsert mirrors the PostgreSQL upsert: the object_id comes from the query and the
+// indexed columns from the data map when present
+func buildUpsert(query *interfaces.Query, data interface{}) (bson.D, bson.D, error) {
+	var objectID uuid.UUID
+	if query != nil {
+		for _, field := range query.Conditions {
+			if field.Name == fieldObjectID && !field.IsJSONB {
+				if id, ok := field.Value.(uuid.UUID); ok {
+					objectID = id
+				}
+			}
+		}
+	}
+	if objectID == uuid.Nil {
+		return nil, nil, fmt.Errorf("upsert requires object_id in query conditions")
+	}
+
+	now := time.Now().Unix()
+	ownerUserID := objectID
+	createdDate, lastUpdated := now, now
+	if dataMap, ok := data.(map[string]interface{}); ok {
+		if setMap, ok := dataMap["$set"].(map[string]interface{}); ok {
+			data, dataMap = setMap, setMap
+		}
+		if oid, ok := dataMap["objectId"].(uuid.UUID); ok && oid != uuid.Nil {
+			objectID = oid
+		}
+		if ownerID, ok := dataMap["ownerUserID"].(uuid.UUID); ok {
+			ownerUserID = ownerID
+		}
+		if cd, ok := dataMap["createdDate"].(int64); ok {
+			createdDate = cd
+		}
+		if lu, ok := dataMap["lastUpdated"].(int64); ok {
+			lastUpdated = lu
+		}
+	}
+
+	doc, err := toBSONDocument(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filter := bson.D{{Key: fieldObjectID, Value: objectID.String()}}
+	update := bson.D{
+		{Key: "$set", Value: bson.D{
+			{Key: fieldData, Value: doc},
+			{Key: fieldLastUpdated, Value: lastUpdated},
+		}},
+		{Key: "$setOnInsert", Value: bson.D{
+			{Key: fieldOwnerUserID, Value: ownerUserID.String()},
+			{Key: fieldCreatedDate, Value: createdDate},
+		}},
+	}
+	return filter, update, nil
+}
+
+// UpdateMany updates multiple documents matching the query
+func (r *MongoDBRepository) UpdateMany(ctx context.Context, collectionName string, query *interfaces.Query, data interface{}, opts *interfaces.UpdateOptions) <-chan interfaces.RepositoryResult {
+	// Update already applies to every matching document
+	return r.Update(ctx, collectionName, query, data, opts)
+}
+
+// Delete deletes documents matching the query
+func (r *MongoDBRepository) Delete(ctx context.Context, collectionName string, query *interfaces.Query) <-chan interfaces.RepositoryResult {
+	result := make(chan interfaces.RepositoryResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		filter, err := buildFilter(query)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		res, err := r.collection(collectionName).DeleteMany(opCtx, filter)
+		if err != nil {
+			log.Error("MongoDB Delete error: %s", err.Error())
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		if res.DeletedCount == 0 {
+			result <- interfaces.RepositoryResult{Error: interfaces.ErrNoDocuments}
+			return
+		}
+
+		result <- interfaces.RepositoryResult{Result: "OK"}
+	}()
+
+	return result
+}
+
+// DeleteMany performs bulk delete operations for multiple queries.
+// The deletes are only atomic when run inside a transaction.
+func (r *MongoDBRepository) DeleteMany(ctx context.Context, collectionName string, queries []*interfaces.Query) <-chan interfaces.RepositoryResult {
+	result := make(chan interfaces.RepositoryResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		coll := r.collection(collectionName)
+		var totalDeleted int64
+
+		for _, query := range queries {
+			filter, err := buildFilter(query)
+			if err != nil {
+				result <- interfaces.RepositoryResult{Error: err}
+				return
+			}
+
+			res, err := coll.DeleteMany(opCtx, filter)
+			if err != nil {
+				log.Error("MongoDB DeleteMany error: %s", err.Error())
+				result <- interfaces.RepositoryResult{Error: err}
+				return
+			}
+			totalDeleted += res.DeletedCount
+		}
+
+		result <- interfaces.RepositoryResult{Result: totalDeleted}
+	}()
+
+	return result
+}
+
+// CreateIndex creates an index per key, mapping column names and JSONB paths to document paths
+func (r *MongoDBRepository) CreateIndex(ctx context.Context, collectionName string, indexes map[string]interface{}) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		defer close(result)
+
+		keys := make([]string, 0, len(indexes))
+		for key := range indexes {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		models := make([]mongo.IndexModel, 0, len(keys))
+		for _, key := range keys {
+			path, err := fieldPath(key)
+			if err != nil {
+				result <- err
+				return
+			}
+			direction := 1
+			switch v := indexes[key].(type) {
+			case int:
+				if v < 0 {
+					direction = -1
+				}
+			case int32:
+				if v < 0 {
+					direction = -1
+				}
+			case int64:
+				if v < 0 {
+					direction = -1
+				}
+			case float64:
+				if v < 0 {
+					direction = -1
+				}
+			}
+			models = append(models, mongo.IndexModel{Keys: bson.D{{Key: path, Value: direction}}})
+		}
+
+		if len(models) == 0 {
+			result <- nil
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), indexTimeout)
+		defer cancel()
+		_, err := r.collection(collectionName).Indexes().CreateMany(ctx, models)
+		result <- err
+	}()
+
+	return result
+}
+
+// Count counts documents matching the query
+func (r *MongoDBRepository) Count(ctx context.Context, collectionName string, query *interfaces.Query) <-chan interfaces.CountResult {
+	return r.CountWithFilter(ctx, collectionName, query)
+}
+
+// FindWithCursor retrieves documents with cursor-based pagination
+func (r *MongoDBRepository) FindWithCursor(ctx context.Context, collectionName string, query *interfaces.Query, opts *interfaces.CursorFindOptions) <-chan interfaces.QueryResult {
+	result := make(chan interfaces.QueryResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- &MongoDBQueryResult{err: err}
+			return
+		}
+
+		// NOTE: Cursor conditions are already in the Query object from the service layer
+		// (via WhereCursorCondition), exactly as for PostgreSQL.
+		filter, err := buildFilter(query)
+		if err != nil {
+			result <- &MongoDBQueryResult{err: err}
+			return
+		}
+
+		findOpts := options.Find().SetProjection(dataProjection())
+		if opts != nil {
+			sortField := opts.SortField
+			if sortField == "" {
+				sortField = fieldCreatedDate
+			}
+			direction := -1
+			if opts.SortDirection == "asc" {
+				direction = 1
+			}
+
+			// Indexed columns sort directly, anything else is an entity field.
+			// SortFieldType is not needed since documents keep their value types.
+			primary := sortField
+			if sortField != fieldObjectID && sortField != fieldCreatedDate && sortField != fieldLastUpdated {
+				primary = dataPath(sortField)
+			}
+
+			// Always include object_id as tiebreaker
+			sortDoc := bson.D{{Key: primary, Value: direction}}
+			if primary != fieldObjectID {
+				sortDoc = append(sortDoc, bson.E{Key: fieldObjectID, Value: direction})
+			}
+			findOpts.SetSort(sortDoc)
+
+			if opts.Limit != nil {
+				findOpts.SetLimit(*opts.Limit)
+			}
+		}
+
+		cursor, err := r.collection(collectionName).Find(opCtx, filter, findOpts)
+		if err != nil {
+			log.Error("MongoDB FindWithCursor error: %s", err.Error())
+			result <- &MongoDBQueryResult{err: err}
+			return
+		}
+
+		result <- &MongoDBQueryResult{cursor: cursor, ctx: opCtx}
+	}()
+
+	return result
+}
+
+// CountWithFilter counts documents matching the query
+func (r *MongoDBRepository) CountWithFilter(ctx context.Context, collectionName string, query *interfaces.Query) <-chan interfaces.CountResult {
+	result := make(chan interfaces.CountResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- interfaces.CountResult{Error: err}
+			return
+		}
+
+		filter, err := buildFilter(query)
+		if err != nil {
+			result <- interfaces.CountResult{Error: err}
+			return
+		}
+
+		count, err := r.collection(collectionName).CountDocuments(opCtx, filter)
+		if err != nil {
+			log.Error("MongoDB Count error: %s", err.Error())
+			result <- interfaces.CountResult{Error: err}
+			return
+		}
+
+		result <- interfaces.CountResult{Count: count}
+	}()
+
+	return result
+}
+
+// UpdateFields updates specific fields using clean syntax (no $set, $inc operators)
+func (r *MongoDBRepository) UpdateFields(ctx context.Context, collectionName string, query *interfaces.Query, updates map[string]interface{}) <-chan interfaces.RepositoryResult {
+	return r.updateMatching(ctx, collectionName, query, updates, nil, true, "UpdateFields")
+}
+
+// IncrementFields increments numeric fields using clean syntax (no $inc operators)
+func (r *MongoDBRepository) IncrementFields(ctx context.Context, collectionName string, query *interfaces.Query, increments map[string]interface{}) <-chan interfaces.RepositoryResult {
+	return r.updateMatching(ctx, collectionName, query, nil, increments, true, "IncrementFields")
+}
+
+// UpdateAndIncrement performs both update and increment operations
+func (r *MongoDBRepository) UpdateAndIncrement(ctx context.Context, collectionName string, query *interfaces.Query, updates map[string]interface{}, increments map[string]interface{}) <-chan interfaces.RepositoryResult {
+	return r.updateMatching(ctx, collectionName, query, updates, increments, false, "UpdateAndIncrement")
+}
+
+// updateMatching sets and increments fields on every document matching the query.
+// requireMatch reports ErrNoDocuments when nothing matched, as the PostgreSQL
+// UpdateFields and IncrementFields do.
+func (r *MongoDBRepository) updateMatching(ctx context.Context, collectionName string, query *interfaces.Query, updates, increments map[string]interface{}, requireMatch bool, op string) <-chan interfaces.RepositoryResult {
+	result := make(chan interfaces.RepositoryResult)
+
+	go func() {
+		defer close(result)
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		update, err := buildUpdate(updates, increments, time.Now().Unix())
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		filter, err := buildFilter(query)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		res, err := r.collection(collectionName).UpdateMany(opCtx, filter, update)
+		if err != nil {
+			log.Error("MongoDB %s error: %s", op, err.Error())
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		if requireMatch && res.MatchedCount == 0 {
+			result <- interfaces.RepositoryResult{Error: interfaces.ErrNoDocuments}
+			return
+		}
+
+		result <- interfaces.RepositoryResult{Result: "OK"}
+	}()
+
+	return result
+}
+
+// ownershipFilter matches a live entity owned by ownerID
+func ownershipFilter(entityID interface{}, ownerID interface{}) bson.D {
+	return bson.D{
+		{Key: fieldObjectID, Value: fmt.Sprint(entityID)},
+		{Key: dataPath("ownerUserId"), Value: fmt.Sprint(ownerID)},
+		{Key: dataPath("deleted"), Value: false},
+	}
+}
+
+// UpdateWithOwnership performs atomic update with ownership validation
+func (r *MongoDBRepository) UpdateWithOwnership(ctx context.Context, collectionName string, entityID interface{}, ownerID interface{}, updates map[string]interface{}) <-chan interfaces.RepositoryResult {
+	now := time.Now().Unix()
+	fields := make(map[string]interface{}, len(updates)+1)
+	for key, value := range updates {
+		fields[key] = value
+	}
+	fields["lastUpdated"] = now
+
+	update, err := buildUpdate(fields, nil, now)
+	return r.updateOwned(ctx, collectionName, entityID, ownerID, update, err, "UpdateWithOwnership")
+}
+
+// DeleteWithOwnership performs atomic soft delete with ownership validation
+func (r *MongoDBRepository) DeleteWithOwnership(ctx context.Context, collectionName string, entityID interface{}, ownerID interface{}) <-chan interfaces.RepositoryResult {
+	now := time.Now().Unix()
+	update, err := buildUpdate(map[string]interface{}{"deleted": true, "deletedDate": now}, nil, now)
+	return r.updateOwned(ctx, collectionName, entityID, ownerID, update, err, "DeleteWithOwnership")
+}
+
+// IncrementWithOwnership performs atomic increment with ownership validation
+func (r *MongoDBRepository) IncrementWithOwnership(ctx context.Context, collectionName string, entityID interface{}, ownerID interface{}, increments map[string]interface{}) <-chan interfaces.RepositoryResult {
+	update, err := buildUpdate(nil, increments, time.Now().Unix())
+	return r.updateOwned(ctx, collectionName, entityID, ownerID, update, err, "IncrementWithOwnership")
+}
+
+func (r *MongoDBRepository) updateOwned(ctx context.Context, collectionName string, entityID interface{}, ownerID interface{}, update bson.D, buildErr error, op string) <-chan interfaces.RepositoryResult {
+	result := make(chan interfaces.RepositoryResult)
+
+	go func() {
+		defer close(result)
+
+		if buildErr != nil {
+			result <- interfaces.RepositoryResult{Error: buildErr}
+			return
+		}
+
+		opCtx, err := r.opContext(ctx)
+		if err != nil {
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		res, err := r.collection(collectionName).UpdateOne(opCtx, ownershipFilter(entityID, ownerID), update)
+		if err != nil {
+			log.Error("MongoDB %s error: %s", op, err.Error())
+			result <- interfaces.RepositoryResult{Error: err}
+			return
+		}
+
+		// Check if the entity existed and belonged to owner
+		if res.MatchedCount == 0 {
+			result <- interfaces.RepositoryResult{Error: fmt.Errorf("entity not found or unauthorized")}
+			return
+		}
+
+		result <- interfaces.RepositoryResult{Result: res.MatchedCount}
+	}()
+
+	return result
+}
+
+// Ping tests the database connection
+func (r *MongoDBRepository) Ping(ctx context.Context) <-chan error {
+	result := make(chan error)
+
+	go func() {
+		defer close(result)
+		result <- r.client.Ping(ctx, readpref.Primary())
+	}()
+
+	return result
+}
+
+// Close closes the database connection
+func (r *MongoDBRepository) Close() error {
+	return r.client.Disconnect(context.Background())
+}
+
+// Client returns the underlying MongoDB client for direct access
+func (r *MongoDBRepository) Client() *mongo.Client {
+	return r.client
+}
+
+// decodeData decodes the entity stored under "data" into v. It goes through relaxed
+// extended JSON so json tags apply, the same as decoding a JSONB column.
+func decodeData(raw bson.Raw, v interface{}) error {
+	value, err := raw.LookupErr(fieldData)
+	if err != nil {
+		return fmt.Errorf("document has no data field: %w", err)
+	}
+	doc, ok := value.DocumentOK()
+	if !ok {
+		return fmt.Errorf("document data is not an object")
+	}
+
+	jsonData, err := bson.MarshalExtJSON(doc, false, false)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(jsonData, v)
+}
+
+// MongoDBQueryResult implementation
+func (r *MongoDBQueryResult) Next() bool {
+	if r.cursor == nil || r.closed {
+		return false
+	}
+	return r.cursor.Next(r.ctx)
+}
+
+func (r *MongoDBQueryResult) Decode(v interface{}) error {
+	if r.cursor == nil {
+		return fmt.Errorf("cursor is nil")
+	}
+	return decodeData(r.cursor.Current, v)
+}
+
+func (r *MongoDBQueryResult) Close() {
+	if r.cursor != nil && !r.closed {
+		_ = r.cursor.Close(r.ctx)
+		r.closed = true
+	}
+}
+
+func (r *MongoDBQueryResult) Error() error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.cursor != nil {
+		return r.cursor.Err()
+	}
+	return nil
+}
+
+// MongoDBSingleResult implementation
+func (r *MongoDBSingleResult) Decode(v interface{}) error {
+	if r.noResult {
+		return interfaces.ErrNoDocuments
+	}
+	if r.err != nil {
+		return r.err
+	}
+	return decodeData(r.raw, v)
+}
+
+func (r *MongoDBSingleResult) Error() error {
+	if r.noResult {
+		return interfaces.ErrNoDocuments
+	}
+	return r.err
+}
+
+func (r *MongoDBSingleResult) NoResult() bool {
+	return r.noResult
+}
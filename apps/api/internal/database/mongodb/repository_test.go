@@ -0,0 +1,309 @@
+package mongodb
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+const testCollection = "post"
+
+type testPost struct {
+	ObjectId       uuid.UUID       `json:"objectId"`
+	OwnerUserId    uuid.UUID       `json:"ownerUserId"`
+	Body           string          `json:"body"`
+	Score          int64           `json:"score"`
+	CommentCounter int64           `json:"commentCounter"`
+	Tags           []string        `json:"tags"`
+	Votes          map[string]bool `json:"votes"`
+	Deleted        bool            `json:"deleted"`
+	DeletedDate    int64           `json:"deletedDate"`
+	CreatedDate    int64           `json:"createdDate"`
+}
+
+// newTestRepository connects to MONGO_URI and uses a throwaway database per test
+func newTestRepository(t *testing.T) *MongoDBRepository {
+	t.Helper()
+	if os.Getenv("RUN_DB_TESTS") != "1" {
+		t.Skip("set RUN_DB_TESTS=1 to run database tests")
+	}
+	uri := os.Getenv("MONGO_URI")
+	if uri == "" {
+		t.Skip("set MONGO_URI to run MongoDB tests")
+	}
+
+	ctx := context.Background()
+	dbName := "telar_test_" + strings.ReplaceAll(uuid.Must(uuid.NewV4()).String(), "-", "")[:12]
+	repo, err := NewMongoDBRepository(ctx, &interfaces.MongoDBConfig{URI: uri, ConnectTimeout: 5}, dbName)
+	if err != nil {
+		t.Skipf("MongoDB not available: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = repo.db.Drop(context.Background())
+		_ = repo.Close()
+	})
+	return repo
+}
+
+// requireTransactions skips unless the server is a replica set member or mongos
+func requireTransactions(t *testing.T, repo *MongoDBRepository) {
+	t.Helper()
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err := repo.client.Database("admin").RunCommand(context.Background(), bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	require.NoError(t, err)
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		t.Skip("MongoDB transactions need a replica set")
+	}
+}
+
+func savePost(t *testing.T, repo interfaces.Repository, post testPost) {
+	t.Helper()
+	res := <-repo.Save(context.Background(), testCollection, post.ObjectId, post.OwnerUserId, post.CreatedDate, post.CreatedDate, post)
+	require.NoError(t, res.Error)
+}
+
+func newPost(owner uuid.UUID, body string, createdDate int64) testPost {
+	return testPost{
+		ObjectId:    uuid.Must(uuid.NewV4()),
+		OwnerUserId: owner,
+		Body:        body,
+		Tags:        []string{"go"},
+		Votes:       map[string]bool{},
+		CreatedDate: createdDate,
+	}
+}
+
+func byObjectID(id uuid.UUID) *interfaces.Query {
+	return &interfaces.Query{Conditions: []interfaces.Field{{Name: "object_id", Value: id, Operator: "="}}}
+}
+
+func findPost(t *testing.T, repo interfaces.Repository, id uuid.UUID) (testPost, error) {
+	t.Helper()
+	var post testPost
+	err := (<-repo.FindOne(context.Background(), testCollection, byObjectID(id))).Decode(&post)
+	return post, err
+}
+
+func collectPosts(t *testing.T, result interfaces.QueryResult) []testPost {
+	t.Helper()
+	require.NoError(t, result.Error())
+	defer result.Close()
+	var posts []testPost
+	for result.Next() {
+		var post testPost
+		require.NoError(t, result.Decode(&post))
+		posts = append(posts, post)
+	}
+	require.NoError(t, result.Error())
+	return posts
+}
+
+func TestMongoDBRepository_CRUD(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+
+	first := newPost(owner, "Hello Mongo", 1)
+	second := newPost(owner, "hello postgres", 2)
+	savePost(t, repo, first)
+	savePost(t, repo, second)
+
+	dup := <-repo.Save(ctx, testCollection, first.ObjectId, owner, 3, 3, first)
+	assert.ErrorIs(t, dup.Error, interfaces.ErrDuplicateKey)
+
+	got, err := findPost(t, repo, first.ObjectId)
+	require.NoError(t, err)
+	assert.Equal(t, first, got)
+
+	_, err = findPost(t, repo, uuid.Must(uuid.NewV4()))
+	assert.ErrorIs(t, err, interfaces.ErrNoDocuments)
+
+	// ILIKE is case-insensitive and sorting accepts JSONB paths
+	search := &interfaces.Query{Conditions: []interfaces.Field{
+		{Name: "data->>'body'", Value: "hello%", Operator: "ILIKE", IsJSONB: true},
+	}}
+	posts := collectPosts(t, <-repo.Find(ctx, testCollection, search, &interfaces.FindOptions{Sort: map[string]int{"data->>'createdDate'": -1}}))
+	require.Len(t, posts, 2)
+	assert.Equal(t, second.ObjectId, posts[0].ObjectId)
+
+	count := <-repo.Count(ctx, testCollection, search)
+	require.NoError(t, count.Error)
+	assert.Equal(t, int64(2), count.Count)
+
+	res := <-repo.UpdateFields(ctx, testCollection, byObjectID(first.ObjectId), map[string]interface{}{"body": "edited", "votes." + owner.String(): true})
+	require.NoError(t, res.Error)
+	res = <-repo.IncrementFields(ctx, testCollection, byObjectID(first.ObjectId), map[string]interface{}{"score": 2, "commentCounter": "3"})
+	require.NoError(t, res.Error)
+	res = <-repo.UpdateAndIncrement(ctx, testCollection, byObjectID(first.ObjectId), map[string]interface{}{"tags": []string{"go", "db"}}, map[string]interface{}{"score": -1})
+	require.NoError(t, res.Error)
+
+	got, err = findPost(t, repo, first.ObjectId)
+	require.NoError(t, err)
+	assert.Equal(t, "edited", got.Body)
+	assert.True(t, got.Votes[owner.String()])
+	assert.Equal(t, int64(1), got.Score)
+	assert.Equal(t, int64(3), got.CommentCounter)
+	assert.Equal(t, []string{"go", "db"}, got.Tags)
+
+	res = <-repo.UpdateFields(ctx, testCollection, byObjectID(uuid.Must(uuid.NewV4())), map[string]interface{}{"body": "x"})
+	assert.ErrorIs(t, res.Error, interfaces.ErrNoDocuments)
+
+	res = <-repo.Delete(ctx, testCollection, byObjectID(second.ObjectId))
+	require.NoError(t, res.Error)
+	res = <-repo.Delete(ctx, testCollection, byObjectID(second.ObjectId))
+	assert.ErrorIs(t, res.Error, interfaces.ErrNoDocuments)
+}
+
+func TestMongoDBRepository_Upsert(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	post := newPost(uuid.Must(uuid.NewV4()), "first", 1)
+	upsert := true
+
+	res := <-repo.Update(ctx, testCollection, byObjectID(post.ObjectId), post, &interfaces.UpdateOptions{Upsert: &upsert})
+	require.NoError(t, res.Error)
+	post.Body = "second"
+	res = <-repo.Update(ctx, testCollection, byObjectID(post.ObjectId), post, &interfaces.UpdateOptions{Upsert: &upsert})
+	require.NoError(t, res.Error)
+
+	got, err := findPost(t, repo, post.ObjectId)
+	require.NoError(t, err)
+	assert.Equal(t, "second", got.Body)
+
+	count := <-repo.CountWithFilter(ctx, testCollection, nil)
+	require.NoError(t, count.Error)
+	assert.Equal(t, int64(1), count.Count)
+}
+
+func TestMongoDBRepository_FindWithCursor(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	for i := int64(1); i <= 5; i++ {
+		savePost(t, repo, newPost(owner, "post", i))
+	}
+
+	limit := int64(2)
+	opts := &interfaces.CursorFindOptions{Limit: &limit, SortField: "created_date", SortDirection: "desc"}
+	page := collectPosts(t, <-repo.FindWithCursor(ctx, testCollection, nil, opts))
+	require.Len(t, page, 2)
+	assert.Equal(t, []int64{5, 4}, []int64{page[0].CreatedDate, page[1].CreatedDate})
+
+	last := page[len(page)-1]
+	next := &interfaces.Query{Conditions: []interfaces.Field{{
+		Name:     "created_date",
+		Operator: "CURSOR_PAGINATION",
+		Value: map[string]interface{}{
+			"sortValue":  last.CreatedDate,
+			"tieBreaker": last.ObjectId.String(),
+			"primaryOp":  "<",
+		},
+	}}}
+	page = collectPosts(t, <-repo.FindWithCursor(ctx, testCollection, next, opts))
+	require.Len(t, page, 2)
+	assert.Equal(t, []int64{3, 2}, []int64{page[0].CreatedDate, page[1].CreatedDate})
+}
+
+func TestMongoDBRepository_Ownership(t *testing.T) {
+	repo := newTestRepository(t)
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	post := newPost(owner, "mine", 1)
+	savePost(t, repo, post)
+
+	res := <-repo.UpdateWithOwnership(ctx, testCollection, post.ObjectId, uuid.Must(uuid.NewV4()), map[string]interface{}{"body": "stolen"})
+	assert.Error(t, res.Error)
+
+	res = <-repo.UpdateWithOwnership(ctx, testCollection, post.ObjectId, owner, map[string]interface{}{"body": "still mine"})
+	require.NoError(t, res.Error)
+	res = <-repo.IncrementWithOwnership(ctx, testCollection, post.ObjectId, owner, map[string]interface{}{"score": 4})
+	require.NoError(t, res.Error)
+	res = <-repo.DeleteWithOwnership(ctx, testCollection, post.ObjectId, owner)
+	require.NoError(t, res.Error)
+
+	got, err := findPost(t, repo, post.ObjectId)
+	require.NoError(t, err)
+	assert.Equal(t, "still mine", got.Body)
+	assert.Equal(t, int64(4), got.Score)
+	assert.True(t, got.Deleted)
+	assert.NotZero(t, got.DeletedDate)
+
+	// Soft-deleted entities no longer match
+	res = <-repo.IncrementWithOwnership(ctx, testCollection, post.ObjectId, owner, map[string]interface{}{"score": 1})
+	assert.Error(t, res.Error)
+}
+
+func TestMongoDBRepository_Transactions(t *testing.T) {
+	repo := newTestRepository(t)
+	requireTransactions(t, repo)
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+
+	// Create the collection outside the transactions
+	savePost(t, repo, newPost(owner, "seed", 1))
+
+	committed := newPost(owner, "committed", 2)
+	tx, err := repo.Begin(ctx)
+	require.NoError(t, err)
+	savePost(t, tx, committed)
+	_, err = findPost(t, repo, committed.ObjectId)
+	assert.ErrorIs(t, err, interfaces.ErrNoDocuments, "uncommitted writes must not be visible outside the transaction")
+	_, err = findPost(t, tx, committed.ObjectId)
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+	assert.False(t, tx.IsActive())
+	assert.Equal(t, interfaces.DatabaseTypeMongoDB, tx.GetMetrics().DatabaseType)
+	_, err = findPost(t, repo, committed.ObjectId)
+	require.NoError(t, err)
+
+	res := <-tx.Save(ctx, testCollection, uuid.Must(uuid.NewV4()), owner, 3, 3, committed)
+	assert.ErrorIs(t, res.Error, interfaces.ErrTransactionInactive)
+
+	rolledBack := newPost(owner, "rolled back", 3)
+	tx, err = repo.Begin(ctx)
+	require.NoError(t, err)
+	savePost(t, tx, rolledBack)
+	require.NoError(t, tx.Rollback())
+	_, err = findPost(t, repo, rolledBack.ObjectId)
+	assert.ErrorIs(t, err, interfaces.ErrNoDocuments)
+
+	_, err = tx.Begin(ctx)
+	assert.ErrorIs(t, err, interfaces.ErrNestedTransaction)
+
+	failed := newPost(owner, "failed", 4)
+	boom := errors.New("boom")
+	err = repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		res := <-repo.Save(txCtx, testCollection, failed.ObjectId, owner, 4, 4, failed)
+		require.NoError(t, res.Error)
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+	_, err = findPost(t, repo, failed.ObjectId)
+	assert.ErrorIs(t, err, interfaces.ErrNoDocuments)
+}
+
+func TestMongoDBRepository_TransactionTimeout(t *testing.T) {
+	repo := newTestRepository(t)
+	requireTransactions(t, repo)
+
+	tx, err := repo.BeginWithConfig(context.Background(), &interfaces.TransactionConfig{Timeout: 50 * time.Millisecond})
+	require.NoError(t, err)
+	defer tx.Close()
+
+	time.Sleep(100 * time.Millisecond)
+	post := newPost(uuid.Must(uuid.NewV4()), "late", 1)
+	res := <-tx.Save(context.Background(), testCollection, post.ObjectId, post.OwnerUserId, 1, 1, post)
+	assert.Error(t, res.Error)
+}
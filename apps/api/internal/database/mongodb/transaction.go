@@ -0,0 +1,266 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package mongodb
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/qolzam/telar/apps/api/internal/database/observability"
+	"github.com/qolzam/telar/apps/api/internal/database/utils"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readconcern"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
+)
+
+// MongoDBTransaction implements Transaction interface for MongoDB.
+// It embeds a MongoDBRepository whose operations all run in the transaction's session.
+// MongoDB transactions need a replica set or sharded cluster.
+type MongoDBTransaction struct {
+	*MongoDBRepository
+	session        mongo.Session
+	ctx            context.Context // session context every operation runs under
+	cancel         context.CancelFunc
+	config         *interfaces.TransactionConfig
+	metrics        *interfaces.TransactionMetrics
+	transactionID  string
+	isActive       int32 // atomic flag for thread-safe status checking
+	operationCount int64 // atomic counter for operations
+}
+
+// MongoDBTransactionContext implements TransactionContext for MongoDB (legacy)
+type MongoDBTransactionContext struct {
+	session mongo.Session
+	ctx     context.Context
+}
+
+// transactionOptions returns the options for a transaction. MongoDB transactions always
+// read from a snapshot, so the configured isolation level needs no translation.
+func transactionOptions() *options.TransactionOptions {
+	return options.Transaction().
+		SetReadConcern(readconcern.Snapshot()).
+		SetWriteConcern(writeconcern.Majority())
+}
+
+// Begin starts a new transaction that implements the Transaction interface
+func (r *MongoDBRepository) Begin(ctx context.Context) (interfaces.Transaction, error) {
+	return r.BeginWithConfig(ctx, utils.DefaultTransactionConfig())
+}
+
+// BeginWithConfig starts a new transaction with enterprise configuration
+func (r *MongoDBRepository) BeginWithConfig(ctx context.Context, config *interfaces.TransactionConfig) (interfaces.Transaction, error) {
+	if r.tx != nil {
+		return nil, interfaces.ErrNestedTransaction
+	}
+
+	// Validate and merge configuration
+	if err := utils.ValidateTransactionConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid transaction config: %w", err)
+	}
+
+	finalConfig := utils.MergeTransactionConfig(config)
+
+	// Create timeout context
+	timeoutCtx, cancel := utils.CreateTimeoutContext(ctx, finalConfig)
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	if err := session.StartTransaction(transactionOptions()); err != nil {
+		session.EndSession(context.Background())
+		cancel()
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	// Generate transaction ID and create metrics
+	txID := utils.GenerateTransactionID()
+	metrics := observability.GetGlobalMetrics().StartTransaction(txID, interfaces.DatabaseTypeMongoDB, finalConfig)
+
+	tx := &MongoDBTransaction{
+		session:       session,
+		ctx:           mongo.NewSessionContext(timeoutCtx, session),
+		cancel:        cancel,
+		config:        finalConfig,
+		metrics:       metrics,
+		transactionID: txID,
+		isActive:      1, // Set as active
+	}
+	tx.MongoDBRepository = &MongoDBRepository{
+		client:  r.client,
+		db:      r.db,
+		dbName:  r.dbName,
+		indexed: r.indexed,
+		tx:      tx,
+	}
+
+	return tx, nil
+}
+
+// BeginTransaction starts a new database transaction (legacy method)
+func (r *MongoDBRepository) BeginTransaction(ctx context.Context) (interfaces.TransactionContext, error) {
+	if r.tx != nil {
+		return nil, interfaces.ErrNestedTransaction
+	}
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start session: %w", err)
+	}
+	if err := session.StartTransaction(transactionOptions()); err != nil {
+		session.EndSession(context.Background())
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	return &MongoDBTransactionContext{
+		session: session,
+		ctx:     mongo.NewSessionContext(ctx, session),
+	}, nil
+}
+
+// WithTransaction executes a function within a transaction. Operations join it through
+// the context passed to fn. The driver retries fn on transient transaction errors.
+func (r *MongoDBRepository) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if r.tx != nil {
+		return interfaces.ErrNestedTransaction
+	}
+
+	session, err := r.client.StartSession()
+	if err != nil {
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+	defer session.EndSession(context.Background())
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	}, transactionOptions())
+	return err
+}
+
+// isValidTransaction checks if the transaction is still valid
+func (t *MongoDBTransaction) isValidTransaction() bool {
+	return t.session != nil && t.ctx != nil && atomic.LoadInt32(&t.isActive) == 1
+}
+
+// incrementOperationCount safely increments the operation counter
+func (t *MongoDBTransaction) incrementOperationCount() {
+	atomic.AddInt64(&t.operationCount, 1)
+	if t.metrics != nil {
+		observability.GetGlobalMetrics().IncrementOperations(t.transactionID)
+	}
+}
+
+// Enterprise methods implementation
+func (t *MongoDBTransaction) GetMetrics() *interfaces.TransactionMetrics {
+	if t.metrics != nil {
+		// Return current metrics with updated operation count
+		metrics := *t.metrics
+		metrics.OperationsCount = atomic.LoadInt64(&t.operationCount)
+		if metrics.Status == "active" {
+			metrics.Duration = time.Since(metrics.StartTime)
+		}
+		return &metrics
+	}
+	return nil
+}
+
+func (t *MongoDBTransaction) GetConfig() *interfaces.TransactionConfig {
+	return t.config
+}
+
+func (t *MongoDBTransaction) IsActive() bool {
+	return atomic.LoadInt32(&t.isActive) == 1
+}
+
+func (t *MongoDBTransaction) GetTransactionID() string {
+	return t.transactionID
+}
+
+// Commit commits the transaction
+func (t *MongoDBTransaction) Commit() error {
+	if !atomic.CompareAndSwapInt32(&t.isActive, 1, 0) {
+		return nil
+	}
+	defer t.cancel()
+	defer t.session.EndSession(context.Background())
+
+	err := t.session.CommitTransaction(t.ctx)
+
+	// Record metrics
+	if t.metrics != nil {
+		if err != nil {
+			observability.GetGlobalMetrics().FailTransaction(t.transactionID, err)
+		} else {
+			observability.GetGlobalMetrics().CommitTransaction(t.transactionID)
+		}
+	}
+
+	return err
+}
+
+// Rollback rolls back the transaction
+func (t *MongoDBTransaction) Rollback() error {
+	if !atomic.CompareAndSwapInt32(&t.isActive, 1, 0) {
+		return nil
+	}
+	defer t.cancel()
+	defer t.session.EndSession(context.Background())
+
+	// Abort even when the transaction timed out, so the server releases it promptly
+	err := t.session.AbortTransaction(context.Background())
+
+	// Record metrics
+	if t.metrics != nil {
+		observability.GetGlobalMetrics().RollbackTransaction(t.transactionID, err)
+	}
+
+	return err
+}
+
+// Transaction methods that don't support nesting
+func (t *MongoDBTransaction) Begin(ctx context.Context) (interfaces.Transaction, error) {
+	return nil, interfaces.ErrNestedTransaction
+}
+
+func (t *MongoDBTransaction) BeginWithConfig(ctx context.Context, config *interfaces.TransactionConfig) (interfaces.Transaction, error) {
+	return nil, interfaces.ErrNestedTransaction
+}
+
+func (t *MongoDBTransaction) BeginTransaction(ctx context.Context) (interfaces.TransactionContext, error) {
+	return nil, interfaces.ErrNestedTransaction
+}
+
+func (t *MongoDBTransaction) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return interfaces.ErrNestedTransaction
+}
+
+func (t *MongoDBTransaction) Close() error {
+	// Don't close the underlying client, just rollback if still active
+	if t.IsActive() {
+		return t.Rollback()
+	}
+	return nil
+}
+
+// MongoDBTransactionContext implementation
+func (t *MongoDBTransactionContext) Commit() error {
+	defer t.session.EndSession(context.Background())
+	return t.session.CommitTransaction(t.ctx)
+}
+
+func (t *MongoDBTransactionContext) Rollback() error {
+	defer t.session.EndSession(context.Background())
+	return t.session.AbortTransaction(context.Background())
+}
+
+func (t *MongoDBTransactionContext) Context() context.Context {
+	return t.ctx
+}
@@ -0,0 +1,204 @@
+package observability
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync/atomic"
+	"time"
+)
+
+// QueryObserver receives every statement run through a driver wrapped by WrapDriver:
+// its SQL text, how long the database took and the error it returned, if any
+type QueryObserver func(query string, duration time.Duration, err error)
+
+var queryObserver atomic.Pointer[QueryObserver]
+
+// SetQueryObserver makes observer receive the statements of wrapped drivers; nil stops
+// observing
+func SetQueryObserver(observer QueryObserver) {
+	if observer == nil {
+		queryObserver.Store(nil)
+		return
+	}
+	queryObserver.Store(&observer)
+}
+
+// observeQuery reports a finished statement. driver.ErrSkip only asks database/sql to
+// take another path, so it is not a statement that ran.
+func observeQuery(query string, start time.Time, err error) {
+	if err == driver.ErrSkip {
+		return
+	}
+	if observer := queryObserver.Load(); observer != nil {
+		(*observer)(query, time.Since(start), err)
+	}
+}
+
+// WrapDriver returns a driver that times every statement of d's connections for the
+// query observer, so repositories are measured without changes to their code
+func WrapDriver(d driver.Driver) driver.Driver {
+	return &observedDriver{Driver: d}
+}
+
+type observedDriver struct {
+	driver.Driver
+}
+
+func (d *observedDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.Driver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &observedConn{Conn: conn}, nil
+}
+
+// observedConn forwards to the wrapped connection, timing queries and executions
+type observedConn struct {
+	driver.Conn
+}
+
+func (c *observedConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return &observedStmt{Stmt: stmt, query: query}, nil
+}
+
+func (c *observedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	preparer, ok := c.Conn.(driver.ConnPrepareContext)
+	if !ok {
+		return c.Prepare(query)
+	}
+	stmt, err := preparer.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return &observedStmt{Stmt: stmt, query: query}, nil
+}
+
+func (c *observedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck // fallback for drivers without BeginTx
+}
+
+func (c *observedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	observeQuery(query, start, err)
+	return rows, err
+}
+
+func (c *observedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	observeQuery(query, start, err)
+	return result, err
+}
+
+func (c *observedConn) Ping(ctx context.Context) error {
+	if pinger, ok := c.Conn.(driver.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+func (c *observedConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *observedConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *observedConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// observedStmt times executions of a prepared statement
+type observedStmt struct {
+	driver.Stmt
+	query string
+}
+
+func (s *observedStmt) Exec(args []driver.Value) (driver.Result, error) {
+	start := time.Now()
+	result, err := s.Stmt.Exec(args) //nolint:staticcheck // required by driver.Stmt
+	observeQuery(s.query, start, err)
+	return result, err
+}
+
+func (s *observedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	start := time.Now()
+	rows, err := s.Stmt.Query(args) //nolint:staticcheck // required by driver.Stmt
+	observeQuery(s.query, start, err)
+	return rows, err
+}
+
+func (s *observedStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		return s.Exec(values)
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, args)
+	observeQuery(s.query, start, err)
+	return result, err
+}
+
+func (s *observedStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		values, err := namedValuesToValues(args)
+		if err != nil {
+			return nil, err
+		}
+		return s.Query(values)
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, args)
+	observeQuery(s.query, start, err)
+	return rows, err
+}
+
+func (s *observedStmt) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+// namedValuesToValues supports statements of drivers predating named arguments
+func namedValuesToValues(args []driver.NamedValue) ([]driver.Value, error) {
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		if arg.Name != "" {
+			return nil, driver.ErrSkip
+		}
+		values[i] = arg.Value
+	}
+	return values, nil
+}
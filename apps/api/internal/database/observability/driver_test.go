@@ -0,0 +1,89 @@
+package observability
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver answers every query with no rows and fails statements containing "fail"
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{query: query}, nil }
+func (fakeConn) Close() error                              { return nil }
+func (fakeConn) Begin() (driver.Tx, error)                 { return fakeTx{}, nil }
+
+func (fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if query == "fail" {
+		return nil, errors.New("query failed")
+	}
+	return fakeRows{}, nil
+}
+
+type fakeStmt struct{ query string }
+
+func (fakeStmt) Close() error  { return nil }
+func (fakeStmt) NumInput() int { return -1 }
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) { return fakeRows{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeRows struct{}
+
+func (fakeRows) Columns() []string         { return []string{"id"} }
+func (fakeRows) Close() error              { return nil }
+func (fakeRows) Next([]driver.Value) error { return io.EOF }
+
+func TestWrapDriver_ObservesStatements(t *testing.T) {
+	var mu sync.Mutex
+	var observed []string
+	var failures int
+	SetQueryObserver(func(query string, duration time.Duration, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		observed = append(observed, query)
+		if err != nil {
+			failures++
+		}
+	})
+	defer SetQueryObserver(nil)
+
+	sql.Register("observed-fake", WrapDriver(fakeDriver{}))
+	db, err := sql.Open("observed-fake", "")
+	require.NoError(t, err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT id FROM posts")
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+
+	_, err = db.Query("fail")
+	require.Error(t, err)
+
+	// The fake has no ExecContext, so database/sql falls back to a prepared statement
+	_, err = db.Exec("UPDATE posts SET score = 1")
+	require.NoError(t, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"SELECT id FROM posts", "fail", "UPDATE posts SET score = 1"}, observed)
+	assert.Equal(t, 1, failures)
+}
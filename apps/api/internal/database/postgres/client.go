@@ -13,10 +13,19 @@ import (
 	"time"
 
 	"github.com/jmoiron/sqlx"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	dbi "github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/qolzam/telar/apps/api/internal/database/observability"
 )
 
+// driverName is lib/pq wrapped so every statement is timed for the query observer
+const driverName = "postgres-observed"
+
+func init() {
+	sql.Register(driverName, observability.WrapDriver(&pq.Driver{}))
+	sqlx.BindDriver(driverName, sqlx.DOLLAR)
+}
+
 // Client wraps sqlx.DB and provides connection pooling, health checks, and transaction management
 type Client struct {
 	db *sqlx.DB
@@ -26,7 +35,7 @@ type Client struct {
 func NewClient(ctx context.Context, config *dbi.PostgreSQLConfig, databaseName string) (*Client, error) {
 	connStr := buildConnectionString(config, databaseName)
 
-	db, err := sqlx.ConnectContext(ctx, "postgres", connStr)
+	db, err := sqlx.ConnectContext(ctx, driverName, connStr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
 	}
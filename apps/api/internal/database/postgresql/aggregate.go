@@ -0,0 +1,740 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package postgresql
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+)
+
+// Aggregation pipelines are translated stage by stage into nested SELECTs over the
+// JSONB data column. Every level yields a single "data" column, so aggregation rows
+// decode exactly like Find results. Stages share a level while SQL allows it and wrap
+// the previous level as a subquery once a stage has to see that level's output.
+
+var (
+	aggregatePathPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z0-9_]+)*$`)
+	aggregateNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// aggregateLevel is one SELECT in the generated query
+type aggregateLevel struct {
+	from    string
+	project string // replaces s.data in the select list when set
+	where   []string
+	groupBy []string
+	having  string
+	orderBy []string
+	limit   int64 // negative when unset
+	offset  int64
+}
+
+func newAggregateLevel(from string) *aggregateLevel {
+	return &aggregateLevel{from: from, limit: -1}
+}
+
+// reshaped reports whether the level's output differs from its input rows
+func (l *aggregateLevel) reshaped() bool {
+	return l.project != "" || len(l.groupBy) > 0 || l.having != ""
+}
+
+func (l *aggregateLevel) paged() bool {
+	return l.limit >= 0 || l.offset > 0
+}
+
+func (l *aggregateLevel) sql() string {
+	selectExpr := "s.data"
+	if l.project != "" {
+		selectExpr = l.project
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "SELECT %s AS data FROM %s s", selectExpr, l.from)
+	if len(l.where) > 0 {
+		b.WriteString(" WHERE " + strings.Join(l.where, " AND "))
+	}
+	if len(l.groupBy) > 0 {
+		b.WriteString(" GROUP BY " + strings.Join(l.groupBy, ", "))
+	}
+	if l.having != "" {
+		b.WriteString(" HAVING " + l.having)
+	}
+	if len(l.orderBy) > 0 {
+		b.WriteString(" ORDER BY " + strings.Join(l.orderBy, ", "))
+	}
+	if l.limit >= 0 {
+		fmt.Fprintf(&b, " LIMIT %d", l.limit)
+	}
+	if l.offset > 0 {
+		fmt.Fprintf(&b, " OFFSET %d", l.offset)
+	}
+	return b.String()
+}
+
+// aggregateBuilder accumulates the current level and the positional arguments
+type aggregateBuilder struct {
+	level *aggregateLevel
+	args  []interface{}
+}
+
+// buildAggregateQuery translates a pipeline into a single SQL statement.
+// Supported stages are $match, $group, $sort, $skip, $limit, $project and $count.
+func buildAggregateQuery(tableName string, pipeline interface{}) (string, []interface{}, error) {
+	stages, err := normalizePipeline(pipeline)
+	if err != nil {
+		return "", nil, err
+	}
+
+	b := &aggregateBuilder{level: newAggregateLevel(tableName)}
+	for i, stage := range stages {
+		if len(stage) != 1 {
+			return "", nil, fmt.Errorf("%w: pipeline stage %d must have exactly one operator", interfaces.ErrInvalidFilter, i)
+		}
+		for op, spec := range stage {
+			switch op {
+			case "$match":
+				err = b.match(spec)
+			case "$group":
+				err = b.group(spec)
+			case "$sort":
+				err = b.sort(spec)
+			case "$skip":
+				err = b.skip(spec)
+			case "$limit":
+				err = b.limitTo(spec)
+			case "$project":
+				err = b.projectFields(spec)
+			case "$count":
+				err = b.count(spec)
+			default:
+				err = fmt.Errorf("%w: aggregation stage %s", interfaces.ErrUnsupportedOperation, op)
+			}
+			if err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	return b.level.sql(), b.args, nil
+}
+
+// normalizePipeline accepts []map[string]interface{} directly and anything else that
+// marshals to a JSON array of objects, such as a slice of typed stage structs.
+func normalizePipeline(pipeline interface{}) ([]map[string]interface{}, error) {
+	switch p := pipeline.(type) {
+	case nil:
+		return nil, nil
+	case []map[string]interface{}:
+		return p, nil
+	}
+
+	raw, err := json.Marshal(pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("%w: pipeline: %v", interfaces.ErrInvalidFilter, err)
+	}
+	var stages []map[string]interface{}
+	if err := json.Unmarshal(raw, &stages); err != nil {
+		return nil, fmt.Errorf("%w: pipeline must be a list of stages", interfaces.ErrInvalidFilter)
+	}
+	return stages, nil
+}
+
+// wrap turns the current level into the FROM clause of a fresh level
+func (b *aggregateBuilder) wrap() {
+	b.level = newAggregateLevel("(" + b.level.sql() + ")")
+}
+
+// bind adds a value as a JSONB argument and returns its placeholder
+func (b *aggregateBuilder) bind(value interface{}) (string, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", interfaces.ErrInvalidFilter, err)
+	}
+	b.args = append(b.args, string(raw))
+	return fmt.Sprintf("$%d::jsonb", len(b.args)), nil
+}
+
+// bindText adds a value as a text argument and returns its placeholder
+func (b *aggregateBuilder) bindText(value string) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// fieldPath validates a dotted field path and returns it as a Postgres path literal.
+// Paths are inlined rather than bound so GROUP BY expressions match the select list.
+func fieldPath(field string) (string, error) {
+	if !aggregatePathPattern.MatchString(field) {
+		return "", fmt.Errorf("%w: invalid field path %q", interfaces.ErrInvalidFilter, field)
+	}
+	return "'{" + strings.ReplaceAll(field, ".", ",") + "}'", nil
+}
+
+func jsonField(field string) (string, error) {
+	path, err := fieldPath(field)
+	if err != nil {
+		return "", err
+	}
+	return "s.data #> " + path, nil
+}
+
+func textField(field string) (string, error) {
+	path, err := fieldPath(field)
+	if err != nil {
+		return "", err
+	}
+	return "s.data #>> " + path, nil
+}
+
+// fieldRef strips the $ prefix from a field reference such as "$score"
+func fieldRef(value interface{}) (string, bool) {
+	s, ok := value.(string)
+	if !ok || !strings.HasPrefix(s, "$") {
+		return "", false
+	}
+	return strings.TrimPrefix(s, "$"), true
+}
+
+// outputName validates a key written into a result object
+func outputName(name string) (string, error) {
+	if !aggregateNamePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: invalid output field %q", interfaces.ErrInvalidFilter, name)
+	}
+	return "'" + name + "'", nil
+}
+
+// expression returns a JSONB expression for a field reference or a constant
+func (b *aggregateBuilder) expression(value interface{}) (string, error) {
+	if field, ok := fieldRef(value); ok {
+		return jsonField(field)
+	}
+	return b.bind(value)
+}
+
+// asMap converts a stage spec into a map, accepting typed maps such as map[string]int
+func asMap(value interface{}) (map[string]interface{}, bool) {
+	if m, ok := value.(map[string]interface{}); ok {
+		return m, true
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, false
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil || m == nil {
+		return nil, false
+	}
+	return m, true
+}
+
+func asInt(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case float64:
+		if v == math.Trunc(v) {
+			return int64(v), true
+		}
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// match adds a $match filter: field equality, $eq/$ne/$gt/$gte/$lt/$lte/$in/$nin/
+// $exists/$regex operators, and nested $and/$or lists.
+func (b *aggregateBuilder) match(spec interface{}) error {
+	filter, ok := asMap(spec)
+	if !ok {
+		return fmt.Errorf("%w: $match must be an object", interfaces.ErrInvalidFilter)
+	}
+	if b.level.reshaped() || b.level.paged() {
+		b.wrap()
+	}
+
+	cond, err := b.filter(filter)
+	if err != nil {
+		return err
+	}
+	if cond != "" {
+		b.level.where = append(b.level.where, cond)
+	}
+	return nil
+}
+
+func (b *aggregateBuilder) filter(filter map[string]interface{}) (string, error) {
+	var conds []string
+	for _, key := range sortedKeys(filter) {
+		value := filter[key]
+
+		if key == "$and" || key == "$or" {
+			list, ok := value.([]interface{})
+			if !ok {
+				// Typed slices such as []map[string]interface{} arrive here
+				raw, _ := json.Marshal(value)
+				if err := json.Unmarshal(raw, &list); err != nil {
+					return "", fmt.Errorf("%w: %s must be a list", interfaces.ErrInvalidFilter, key)
+				}
+			}
+			var parts []string
+			for _, item := range list {
+				sub, ok := asMap(item)
+				if !ok {
+					return "", fmt.Errorf("%w: %s entries must be objects", interfaces.ErrInvalidFilter, key)
+				}
+				cond, err := b.filter(sub)
+				if err != nil {
+					return "", err
+				}
+				if cond == "" {
+					cond = "TRUE"
+				}
+				parts = append(parts, cond)
+			}
+			if len(parts) == 0 {
+				return "", fmt.Errorf("%w: %s must not be empty", interfaces.ErrInvalidFilter, key)
+			}
+			conds = append(conds, "("+strings.Join(parts, " "+strings.ToUpper(key[1:])+" ")+")")
+			continue
+		}
+		if strings.HasPrefix(key, "$") {
+			return "", fmt.Errorf("%w: $match operator %s", interfaces.ErrUnsupportedOperation, key)
+		}
+
+		if ops, ok := asMap(value); ok && isOperatorMap(ops) {
+			for _, op := range sortedKeys(ops) {
+				if op == "$options" {
+					continue
+				}
+				cond, err := b.operator(key, op, ops[op], ops)
+				if err != nil {
+					return "", err
+				}
+				conds = append(conds, cond)
+			}
+			continue
+		}
+
+		cond, err := b.equals(key, value)
+		if err != nil {
+			return "", err
+		}
+		conds = append(conds, cond)
+	}
+	return strings.Join(conds, " AND "), nil
+}
+
+func isOperatorMap(m map[string]interface{}) bool {
+	if len(m) == 0 {
+		return false
+	}
+	for k := range m {
+		if !strings.HasPrefix(k, "$") {
+			return false
+		}
+	}
+	return true
+}
+
+// equals matches like MongoDB: a null value matches both missing and null fields
+func (b *aggregateBuilder) equals(field string, value interface{}) (string, error) {
+	expr, err := jsonField(field)
+	if err != nil {
+		return "", err
+	}
+	if value == nil {
+		return fmt.Sprintf("(%s IS NULL OR %s = 'null'::jsonb)", expr, expr), nil
+	}
+	param, err := b.bind(value)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s = %s", expr, param), nil
+}
+
+func (b *aggregateBuilder) operator(field, op string, value interface{}, ops map[string]interface{}) (string, error) {
+	expr, err := jsonField(field)
+	if err != nil {
+		return "", err
+	}
+
+	switch op {
+	case "$eq":
+		return b.equals(field, value)
+	case "$ne":
+		if value == nil {
+			return fmt.Sprintf("(%s IS NOT NULL AND %s <> 'null'::jsonb)", expr, expr), nil
+		}
+		param, err := b.bind(value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s IS DISTINCT FROM %s", expr, param), nil
+	case "$gt", "$gte", "$lt", "$lte":
+		sqlOp := map[string]string{"$gt": ">", "$gte": ">=", "$lt": "<", "$lte": "<="}[op]
+		param, err := b.bind(value)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s %s %s", expr, sqlOp, param), nil
+	case "$in", "$nin":
+		raw, err := json.Marshal(value)
+		if err != nil || len(raw) == 0 || raw[0] != '[' {
+			return "", fmt.Errorf("%w: %s expects a list", interfaces.ErrInvalidFilter, op)
+		}
+		b.args = append(b.args, string(raw))
+		cond := fmt.Sprintf("$%d::jsonb @> jsonb_build_array(%s)", len(b.args), expr)
+		if op == "$nin" {
+			return "NOT " + cond, nil
+		}
+		return cond, nil
+	case "$exists":
+		exists, ok := value.(bool)
+		if !ok {
+			return "", fmt.Errorf("%w: $exists expects a boolean", interfaces.ErrInvalidFilter)
+		}
+		if exists {
+			return expr + " IS NOT NULL", nil
+		}
+		return expr + " IS NULL", nil
+	case "$regex":
+		pattern, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("%w: $regex expects a string", interfaces.ErrInvalidFilter)
+		}
+		text, err := textField(field)
+		if err != nil {
+			return "", err
+		}
+		sqlOp := "~"
+		if options, _ := ops["$options"].(string); strings.Contains(options, "i") {
+			sqlOp = "~*"
+		}
+		return fmt.Sprintf("%s %s %s", text, sqlOp, b.bindText(pattern)), nil
+	}
+	return "", fmt.Errorf("%w: $match operator %s", interfaces.ErrUnsupportedOperation, op)
+}
+
+// group adds a $group stage. _id may be null, a constant, a field reference or an
+// object of field references; accumulators are $sum, $avg, $min, $max, $count,
+// $push and $addToSet. $sum/$avg/$min/$max work on numeric fields.
+func (b *aggregateBuilder) group(spec interface{}) error {
+	fields, ok := asMap(spec)
+	if !ok {
+		return fmt.Errorf("%w: $group must be an object", interfaces.ErrInvalidFilter)
+	}
+	idSpec, ok := fields["_id"]
+	if !ok {
+		return fmt.Errorf("%w: $group requires an _id", interfaces.ErrInvalidFilter)
+	}
+
+	if b.level.reshaped() || b.level.paged() {
+		b.wrap()
+	}
+	// Input order does not survive grouping
+	b.level.orderBy = nil
+
+	idExpr, keys, err := b.groupKey(idSpec)
+	if err != nil {
+		return err
+	}
+
+	parts := []string{"'_id'", idExpr}
+	for _, name := range sortedKeys(fields) {
+		if name == "_id" {
+			continue
+		}
+		key, err := outputName(name)
+		if err != nil {
+			return err
+		}
+		acc, ok := asMap(fields[name])
+		if !ok || len(acc) != 1 {
+			return fmt.Errorf("%w: $group field %s needs one accumulator", interfaces.ErrInvalidFilter, name)
+		}
+		for op, arg := range acc {
+			expr, err := b.accumulator(op, arg)
+			if err != nil {
+				return err
+			}
+			parts = append(parts, key, expr)
+		}
+	}
+
+	b.level.project = "jsonb_build_object(" + strings.Join(parts, ", ") + ")"
+	b.level.groupBy = keys
+	if len(keys) == 0 {
+		// A single group over no rows yields no document, as in MongoDB
+		b.level.having = "COUNT(*) > 0"
+	}
+	return nil
+}
+
+func (b *aggregateBuilder) groupKey(idSpec interface{}) (string, []string, error) {
+	if idSpec == nil {
+		return "NULL", nil, nil
+	}
+	if field, ok := fieldRef(idSpec); ok {
+		expr, err := jsonField(field)
+		if err != nil {
+			return "", nil, err
+		}
+		return expr, []string{expr}, nil
+	}
+	if composite, ok := idSpec.(map[string]interface{}); ok {
+		var parts, keys []string
+		for _, name := range sortedKeys(composite) {
+			key, err := outputName(name)
+			if err != nil {
+				return "", nil, err
+			}
+			expr, err := b.expression(composite[name])
+			if err != nil {
+				return "", nil, err
+			}
+			if _, isField := fieldRef(composite[name]); isField {
+				keys = append(keys, expr)
+			}
+			parts = append(parts, key, expr)
+		}
+		return "jsonb_build_object(" + strings.Join(parts, ", ") + ")", keys, nil
+	}
+
+	expr, err := b.bind(idSpec)
+	return expr, nil, err
+}
+
+func (b *aggregateBuilder) accumulator(op string, arg interface{}) (string, error) {
+	switch op {
+	case "$count":
+		return "COUNT(*)", nil
+	case "$sum":
+		if n, ok := asInt(arg); ok {
+			if n == 1 {
+				return "COUNT(*)", nil
+			}
+			return "COUNT(*) * " + strconv.FormatInt(n, 10), nil
+		}
+		text, err := b.numericField(op, arg)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("COALESCE(SUM((%s)::numeric), 0)", text), nil
+	case "$avg", "$min", "$max":
+		text, err := b.numericField(op, arg)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s((%s)::numeric)", strings.ToUpper(op[1:]), text), nil
+	case "$push", "$addToSet":
+		expr, err := b.expression(arg)
+		if err != nil {
+			return "", err
+		}
+		if op == "$addToSet" {
+			return "jsonb_agg(DISTINCT " + expr + ")", nil
+		}
+		return "jsonb_agg(" + expr + ")", nil
+	}
+	return "", fmt.Errorf("%w: $group accumulator %s", interfaces.ErrUnsupportedOperation, op)
+}
+
+func (b *aggregateBuilder) numericField(op string, arg interface{}) (string, error) {
+	field, ok := fieldRef(arg)
+	if !ok {
+		return "", fmt.Errorf("%w: %s expects a field reference", interfaces.ErrInvalidFilter, op)
+	}
+	return textField(field)
+}
+
+// sort adds a $sort stage. A map is applied in key order; pass a list of single-key
+// maps, e.g. [{"count": -1}, {"_id": 1}], when the order of sort keys matters.
+func (b *aggregateBuilder) sort(spec interface{}) error {
+	var keys []map[string]interface{}
+	if m, ok := asMap(spec); ok {
+		for _, k := range sortedKeys(m) {
+			keys = append(keys, map[string]interface{}{k: m[k]})
+		}
+	} else {
+		raw, _ := json.Marshal(spec)
+		if err := json.Unmarshal(raw, &keys); err != nil {
+			return fmt.Errorf("%w: $sort must be an object or a list of objects", interfaces.ErrInvalidFilter)
+		}
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("%w: $sort must not be empty", interfaces.ErrInvalidFilter)
+	}
+
+	if b.level.reshaped() || b.level.paged() {
+		b.wrap()
+	}
+
+	var orderBy []string
+	for _, key := range keys {
+		for field, dir := range key {
+			expr, err := jsonField(field)
+			if err != nil {
+				return err
+			}
+			switch n, _ := asInt(dir); n {
+			case 1:
+				orderBy = append(orderBy, expr+" ASC")
+			case -1:
+				orderBy = append(orderBy, expr+" DESC")
+			default:
+				return fmt.Errorf("%w: sort direction for %s must be 1 or -1", interfaces.ErrInvalidFilter, field)
+			}
+		}
+	}
+	b.level.orderBy = orderBy
+	return nil
+}
+
+func (b *aggregateBuilder) skip(spec interface{}) error {
+	n, ok := asInt(spec)
+	if !ok || n < 0 {
+		return fmt.Errorf("%w: $skip must be a non-negative integer", interfaces.ErrInvalidFilter)
+	}
+	if b.level.limit >= 0 {
+		b.level.limit -= n
+		if b.level.limit < 0 {
+			b.level.limit = 0
+		}
+	}
+	b.level.offset += n
+	return nil
+}
+
+func (b *aggregateBuilder) limitTo(spec interface{}) error {
+	n, ok := asInt(spec)
+	if !ok || n <= 0 {
+		return fmt.Errorf("%w: $limit must be a positive integer", interfaces.ErrInvalidFilter)
+	}
+	if b.level.limit < 0 || n < b.level.limit {
+		b.level.limit = n
+	}
+	return nil
+}
+
+// projectFields adds a $project stage. Either list fields to keep (1, true or a
+// "$path" to rename) or fields to drop (0 or false); the two cannot be mixed, except
+// that "_id": 0 is always allowed. Kept fields that are missing come back as null.
+func (b *aggregateBuilder) projectFields(spec interface{}) error {
+	fields, ok := asMap(spec)
+	if !ok || len(fields) == 0 {
+		return fmt.Errorf("%w: $project must be a non-empty object", interfaces.ErrInvalidFilter)
+	}
+	if b.level.reshaped() {
+		b.wrap()
+	}
+
+	var include, exclude []string
+	excludeID := false
+	for _, name := range sortedKeys(fields) {
+		value := fields[name]
+		if field, ok := fieldRef(value); ok {
+			key, err := outputName(name)
+			if err != nil {
+				return err
+			}
+			expr, err := jsonField(field)
+			if err != nil {
+				return err
+			}
+			include = append(include, key, expr)
+			continue
+		}
+
+		keep, ok := value.(bool)
+		if !ok {
+			n, isInt := asInt(value)
+			if !isInt || (n != 0 && n != 1) {
+				return fmt.Errorf("%w: $project value for %s must be 0, 1 or a field reference", interfaces.ErrInvalidFilter, name)
+			}
+			keep = n == 1
+		}
+
+		if keep {
+			key, err := outputName(name)
+			if err != nil {
+				return err
+			}
+			expr, err := jsonField(name)
+			if err != nil {
+				return err
+			}
+			include = append(include, key, expr)
+			continue
+		}
+		if name == "_id" {
+			excludeID = true
+			continue
+		}
+		path, err := fieldPath(name)
+		if err != nil {
+			return err
+		}
+		exclude = append(exclude, path)
+	}
+
+	switch {
+	case len(include) > 0 && len(exclude) > 0:
+		return fmt.Errorf("%w: $project cannot mix inclusion and exclusion", interfaces.ErrInvalidFilter)
+	case len(include) > 0:
+		// _id is only part of the output when it is listed explicitly
+		b.level.project = "jsonb_build_object(" + strings.Join(include, ", ") + ")"
+	default:
+		if excludeID {
+			exclude = append(exclude, "'{_id}'")
+		}
+		b.level.project = "s.data #- " + strings.Join(exclude, " #- ")
+	}
+	return nil
+}
+
+// count adds a $count stage producing a single document {name: n}
+func (b *aggregateBuilder) count(spec interface{}) error {
+	name, ok := spec.(string)
+	if !ok {
+		return fmt.Errorf("%w: $count expects a field name", interfaces.ErrInvalidFilter)
+	}
+	key, err := outputName(name)
+	if err != nil {
+		return err
+	}
+	if b.level.reshaped() || b.level.paged() {
+		b.wrap()
+	}
+	b.level.orderBy = nil
+	b.level.project = "jsonb_build_object(" + key + ", COUNT(*))"
+	b.level.having = "COUNT(*) > 0"
+	return nil
+}
+
+var (
+	_ interfaces.Aggregator = (*PostgreSQLRepository)(nil)
+	_ interfaces.Aggregator = (*PostgreSQLTransaction)(nil)
+)
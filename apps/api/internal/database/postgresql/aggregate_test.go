@@ -0,0 +1,153 @@
+package postgresql
+
+import (
+	"testing"
+
+	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildAggregateQuery_EmptyPipelineSelectsData(t *testing.T) {
+	query, args, err := buildAggregateQuery("public.posts", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT s.data AS data FROM public.posts s", query)
+	assert.Empty(t, args)
+}
+
+func TestBuildAggregateQuery_MatchGroupSortLimit(t *testing.T) {
+	pipeline := []map[string]interface{}{
+		{"$match": map[string]interface{}{
+			"deleted": false,
+			"score":   map[string]interface{}{"$gte": 10},
+		}},
+		{"$group": map[string]interface{}{
+			"_id":   "$ownerUserId",
+			"posts": map[string]interface{}{"$sum": 1},
+			"score": map[string]interface{}{"$avg": "$score"},
+		}},
+		{"$sort": []map[string]interface{}{{"posts": -1}, {"_id": 1}}},
+		{"$limit": 5},
+	}
+
+	query, args, err := buildAggregateQuery("public.posts", pipeline)
+	require.NoError(t, err)
+	assert.Equal(t,
+		"SELECT s.data AS data FROM ("+
+			"SELECT jsonb_build_object('_id', s.data #> '{ownerUserId}', 'posts', COUNT(*), 'score', AVG((s.data #>> '{score}')::numeric)) AS data "+
+			"FROM public.posts s WHERE s.data #> '{deleted}' = $1::jsonb AND s.data #> '{score}' >= $2::jsonb "+
+			"GROUP BY s.data #> '{ownerUserId}'"+
+			") s ORDER BY s.data #> '{posts}' DESC, s.data #> '{_id}' ASC LIMIT 5",
+		query)
+	assert.Equal(t, []interface{}{"false", "10"}, args)
+}
+
+func TestBuildAggregateQuery_MatchOperators(t *testing.T) {
+	query, args, err := buildAggregateQuery("public.posts", []map[string]interface{}{
+		{"$match": map[string]interface{}{
+			"$or": []map[string]interface{}{
+				{"tags": map[string]interface{}{"$in": []string{"go", "sql"}}},
+				{"body": map[string]interface{}{"$regex": "^hello", "$options": "i"}},
+			},
+			"album.title": nil,
+			"postTypeId":  map[string]interface{}{"$ne": 2, "$exists": true},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t,
+		"SELECT s.data AS data FROM public.posts s WHERE "+
+			"($1::jsonb @> jsonb_build_array(s.data #> '{tags}') OR s.data #>> '{body}' ~* $2) AND "+
+			"(s.data #> '{album,title}' IS NULL OR s.data #> '{album,title}' = 'null'::jsonb) AND "+
+			"s.data #> '{postTypeId}' IS NOT NULL AND "+
+			"s.data #> '{postTypeId}' IS DISTINCT FROM $3::jsonb",
+		query)
+	assert.Equal(t, []interface{}{`["go","sql"]`, "^hello", "2"}, args)
+}
+
+func TestBuildAggregateQuery_GroupAllAndCount(t *testing.T) {
+	query, _, err := buildAggregateQuery("public.votes", []map[string]interface{}{
+		{"$group": map[string]interface{}{
+			"_id":   nil,
+			"total": map[string]interface{}{"$sum": "$weight"},
+			"top":   map[string]interface{}{"$max": "$weight"},
+			"users": map[string]interface{}{"$addToSet": "$ownerUserId"},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t,
+		"SELECT jsonb_build_object('_id', NULL, 'top', MAX((s.data #>> '{weight}')::numeric), "+
+			"'total', COALESCE(SUM((s.data #>> '{weight}')::numeric), 0), 'users', jsonb_agg(DISTINCT s.data #> '{ownerUserId}')) AS data "+
+			"FROM public.votes s HAVING COUNT(*) > 0",
+		query)
+
+	query, _, err = buildAggregateQuery("public.votes", []map[string]interface{}{
+		{"$sort": map[string]int{"createdDate": -1}},
+		{"$skip": 10},
+		{"$count": "votes"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t,
+		"SELECT jsonb_build_object('votes', COUNT(*)) AS data FROM ("+
+			"SELECT s.data AS data FROM public.votes s ORDER BY s.data #> '{createdDate}' DESC OFFSET 10"+
+			") s HAVING COUNT(*) > 0",
+		query)
+}
+
+func TestBuildAggregateQuery_Project(t *testing.T) {
+	query, _, err := buildAggregateQuery("public.posts", []map[string]interface{}{
+		{"$limit": 20},
+		{"$skip": 5},
+		{"$project": map[string]interface{}{"body": 1, "author": "$ownerDisplayName", "_id": 0}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t,
+		"SELECT jsonb_build_object('author', s.data #> '{ownerDisplayName}', 'body', s.data #> '{body}') AS data "+
+			"FROM public.posts s LIMIT 15 OFFSET 5",
+		query)
+
+	query, _, err = buildAggregateQuery("public.posts", []map[string]interface{}{
+		{"$project": map[string]interface{}{"votes": 0, "album.photos": false}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT s.data #- '{album,photos}' #- '{votes}' AS data FROM public.posts s", query)
+
+	_, _, err = buildAggregateQuery("public.posts", []map[string]interface{}{
+		{"$project": map[string]interface{}{"body": 1, "votes": 0}},
+	})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidFilter)
+}
+
+func TestBuildAggregateQuery_RejectsInvalidPipelines(t *testing.T) {
+	invalid := [][]map[string]interface{}{
+		{{"$match": map[string]interface{}{"body'; DROP TABLE posts; --": "x"}}},
+		{{"$group": map[string]interface{}{"posts": map[string]interface{}{"$sum": 1}}}},
+		{{"$group": map[string]interface{}{"_id": nil, "total": map[string]interface{}{"$sum": "weight"}}}},
+		{{"$sort": map[string]interface{}{"score": 2}}},
+		{{"$limit": 0}},
+		{{"$match": map[string]interface{}{"tags": map[string]interface{}{"$in": "go"}}}},
+		{{"$count": "bad name"}},
+		{{"$match": map[string]interface{}{}, "$limit": 1}},
+	}
+	for _, pipeline := range invalid {
+		_, _, err := buildAggregateQuery("public.posts", pipeline)
+		assert.ErrorIs(t, err, interfaces.ErrInvalidFilter, "%v", pipeline)
+	}
+
+	_, _, err := buildAggregateQuery("public.posts", []map[string]interface{}{{"$lookup": map[string]interface{}{}}})
+	assert.ErrorIs(t, err, interfaces.ErrUnsupportedOperation)
+
+	_, _, err = buildAggregateQuery("public.posts", []map[string]interface{}{
+		{"$group": map[string]interface{}{"_id": nil, "first": map[string]interface{}{"$first": "$body"}}},
+	})
+	assert.ErrorIs(t, err, interfaces.ErrUnsupportedOperation)
+}
+
+func TestNormalizePipeline_AcceptsTypedStages(t *testing.T) {
+	type stage map[string]interface{}
+	stages, err := normalizePipeline([]stage{{"$limit": 3}})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"$limit": float64(3)}}, stages)
+
+	_, err = normalizePipeline("not a pipeline")
+	assert.ErrorIs(t, err, interfaces.ErrInvalidFilter)
+}
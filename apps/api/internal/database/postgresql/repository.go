@@ -854,16 +854,33 @@ func (r *PostgreSQLRepository) DeleteMany(ctx context.Context, collectionName st
 	return result
 }
 
-// Aggregate performs aggregation operations (simplified for PostgreSQL)
+// Aggregate runs an aggregation pipeline over the collection's JSONB data.
+// See buildAggregateQuery for the supported stages.
 func (r *PostgreSQLRepository) Aggregate(ctx context.Context, collectionName string, pipeline interface{}) <-chan interfaces.QueryResult {
 	result := make(chan interfaces.QueryResult)
 
 	go func() {
 		defer close(result)
 
-		// This is a simplified implementation
-		// In a real implementation, you'd need to translate any legacy aggregation pipeline representation to SQL
-		result <- &PostgreSQLQueryResult{err: interfaces.ErrUnsupportedOperation}
+		if err := r.ensureTable(ctx, collectionName); err != nil {
+			result <- &PostgreSQLQueryResult{err: err}
+			return
+		}
+
+		query, args, err := buildAggregateQuery(r.getTableName(collectionName), pipeline)
+		if err != nil {
+			result <- &PostgreSQLQueryResult{err: err}
+			return
+		}
+
+		rows, err := r.db.QueryContext(ctx, query, args...)
+		if err != nil {
+			log.Error("PostgreSQL Aggregate error: %s", err.Error())
+			result <- &PostgreSQLQueryResult{err: err}
+			return
+		}
+
+		result <- &PostgreSQLQueryResult{rows: rows, columns: []string{"data"}}
 	}()
 
 	return result
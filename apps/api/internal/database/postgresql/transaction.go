@@ -603,6 +603,37 @@ func (t *PostgreSQLTransaction) Find(ctx context.Context, collectionName string,
 	return result
 }
 
+// Aggregate runs an aggregation pipeline inside the transaction
+func (t *PostgreSQLTransaction) Aggregate(ctx context.Context, collectionName string, pipeline interface{}) <-chan interfaces.QueryResult {
+	result := make(chan interfaces.QueryResult)
+	
+	go func() {
+		defer close(result)
+		
+		if err := t.ensureTable(t.ctx, collectionName); err != nil {
+			result <- &PostgreSQLQueryResult{err: err}
+			return
+		}
+		
+		query, args, err := buildAggregateQuery(t.getTableName(collectionName), pipeline)
+		if err != nil {
+			result <- &PostgreSQLQueryResult{err: err}
+			return
+		}
+		
+		rows, err := t.tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			log.Error("PostgreSQL Transaction Aggregate error: %s", err.Error())
+			result <- &PostgreSQLQueryResult{err: err}
+			return
+		}
+		
+		result <- &PostgreSQLQueryResult{rows: rows, columns: []string{"data"}}
+	}()
+	
+	return result
+}
+
 func (t *PostgreSQLTransaction) Update(ctx context.Context, collectionName string, query *interfaces.Query, data interface{}, opts *interfaces.UpdateOptions) <-chan interfaces.RepositoryResult {
 	result := make(chan interfaces.RepositoryResult)
 	
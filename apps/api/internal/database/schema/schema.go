@@ -0,0 +1,125 @@
+// Package schema gates startup on database schema compatibility so blue/green and
+// rolling deploys never run a binary against a schema it cannot handle.
+//
+// tools/dev/infra/db-migrate.sh records every applied migration in schema_migrations,
+// numbered by its position in the MIGRATIONS list. Migrations are expand-only by
+// default, so a build keeps working on newer schemas until a migration marked with a
+// "-- schema:breaking" line is applied.
+package schema
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/internal/middleware/readonly"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+// BuiltVersion is the newest migration this build depends on. Bump it whenever a
+// migration is appended to db-migrate.sh.
+const BuiltVersion = 74
+
+// Policies for a schema mismatch (SCHEMA_CHECK_POLICY)
+const (
+	PolicyRefuse   = "refuse"   // Exit at startup
+	PolicyReadOnly = "readonly" // Serve reads, reject writes with 503
+	PolicyWarn     = "warn"     // Log and serve normally
+	PolicyOff      = "off"      // Skip the check
+)
+
+// Range is the span of schema versions a build supports. Max is 0 when no breaking
+// migration newer than Min has been applied, i.e. the range is open-ended.
+type Range struct {
+	Min int
+	Max int
+}
+
+// Result is the outcome of a compatibility check
+type Result struct {
+	Version    int   // Newest applied migration; 0 when the table is missing
+	Supported  Range // What this build supports given the applied breaking migrations
+	Compatible bool
+	Known      bool   // False when schema_migrations does not exist (schema applied by hand)
+	Reason     string // Why the schema is incompatible
+}
+
+// Check compares the applied migrations with the range a build supports
+func Check(ctx context.Context, db sqlx.QueryerContext, built int) (*Result, error) {
+	var version int
+	err := sqlx.GetContext(ctx, db, &version, `SELECT COALESCE(MAX(version), 0) FROM schema_migrations`)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "42P01" { // undefined_table
+			return &Result{Supported: Range{Min: built}, Compatible: true}, nil
+		}
+		return nil, fmt.Errorf("read schema version: %w", err)
+	}
+
+	var breaking []int
+	err = sqlx.SelectContext(ctx, db, &breaking,
+		`SELECT version FROM schema_migrations WHERE breaking AND version > $1 ORDER BY version`, built)
+	if err != nil {
+		return nil, fmt.Errorf("read breaking migrations: %w", err)
+	}
+
+	return evaluate(version, breaking, built), nil
+}
+
+// evaluate decides compatibility from the applied version and breaking migrations newer than built
+func evaluate(version int, breaking []int, built int) *Result {
+	result := &Result{Version: version, Supported: Range{Min: built}, Known: true, Compatible: true}
+	if len(breaking) > 0 {
+		result.Supported.Max = breaking[0] - 1
+	}
+
+	switch {
+	case version < built:
+		result.Compatible = false
+		result.Reason = fmt.Sprintf("schema version %d is older than %d required by this build; apply migrations first", version, built)
+	case len(breaking) > 0:
+		result.Compatible = false
+		result.Reason = fmt.Sprintf("schema version %d includes breaking migration %d; this build supports up to %d", version, breaking[0], result.Supported.Max)
+	}
+	return result
+}
+
+// Enforce runs the check and applies the configured policy. It returns an error only
+// when the binary must not start.
+func Enforce(ctx context.Context, db sqlx.QueryerContext, cfg platformconfig.SchemaConfig) error {
+	if cfg.CheckPolicy == PolicyOff {
+		return nil
+	}
+
+	result, err := Check(ctx, db, BuiltVersion)
+	if err != nil {
+		if cfg.CheckPolicy == PolicyRefuse {
+			return err
+		}
+		log.Warn("Schema compatibility check failed: %v", err)
+		return nil
+	}
+	if !result.Known {
+		log.Warn("schema_migrations table not found; skipping schema compatibility check (build requires version %d)", BuiltVersion)
+		return nil
+	}
+	if result.Compatible {
+		log.Info("Schema version %d is compatible (build supports %d and newer)", result.Version, result.Supported.Min)
+		return nil
+	}
+
+	switch cfg.CheckPolicy {
+	case PolicyReadOnly:
+		log.Error("Incompatible schema, entering read-only mode: %s", result.Reason)
+		readonly.Enable(readonly.SourceSchema, result.Reason)
+		return nil
+	case PolicyWarn:
+		log.Warn("Incompatible schema: %s", result.Reason)
+		return nil
+	default:
+		return fmt.Errorf("incompatible schema: %s", result.Reason)
+	}
+}
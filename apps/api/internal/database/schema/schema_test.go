@@ -0,0 +1,30 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Run("same or newer expand-only schema is compatible", func(t *testing.T) {
+		for _, version := range []int{20, 25} {
+			result := evaluate(version, nil, 20)
+			assert.True(t, result.Compatible, version)
+			assert.Equal(t, Range{Min: 20}, result.Supported)
+		}
+	})
+
+	t.Run("older schema is incompatible", func(t *testing.T) {
+		result := evaluate(18, nil, 20)
+		assert.False(t, result.Compatible)
+		assert.Contains(t, result.Reason, "apply migrations first")
+	})
+
+	t.Run("breaking migration caps the supported range", func(t *testing.T) {
+		result := evaluate(24, []int{23}, 20)
+		assert.False(t, result.Compatible)
+		assert.Equal(t, Range{Min: 20, Max: 22}, result.Supported)
+		assert.Contains(t, result.Reason, "breaking migration 23")
+	})
+}
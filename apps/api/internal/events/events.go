@@ -0,0 +1,201 @@
+// Package events is the in-process bus services publish domain events on, such as a
+// new post or a reply, so that delivery subsystems like the realtime gateway can react
+// without the services depending on them.
+//
+// Publishing never blocks the request: subscribers are called synchronously and must
+// hand events off quickly. Services should skip building an event when HasSubscribers
+// reports nobody is listening.
+package events
+
+import (
+	"context"
+	"sync"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+const (
+	// TypePostCreated is published for new public posts. Data: the post response.
+	TypePostCreated = "post.created"
+	// TypeCommentCreated is published for new comments on public posts. Data: the comment response.
+	TypeCommentCreated = "comment.created"
+	// TypeNotification is published to the users a new activity concerns. Data: Notification.
+	TypeNotification = "notification"
+	// TypeVoteCast is published when a user upvotes a post. Data: Vote. It has no
+	// recipients and is never sent to clients.
+	TypeVoteCast = "vote.cast"
+	// TypeLiveThreadEnded is published to a public post's topic when its live thread is
+	// frozen. Data: the live thread.
+	TypeLiveThreadEnded = "live.ended"
+	// TypeMediaUploaded is published when an upload is confirmed. Data: MediaUpload. It has
+	// no recipients and wakes the image pipeline.
+	TypeMediaUploaded = "media.uploaded"
+)
+
+const (
+	// NotificationComment tells a post owner someone commented on their post
+	NotificationComment = "comment"
+	// NotificationReply tells a commenter someone replied to them
+	NotificationReply = "reply"
+	// NotificationBadge tells a user they earned a badge
+	NotificationBadge = "badge"
+	// NotificationStreak warns a user their activity streak ends unless they are active today
+	NotificationStreak = "streak"
+	// NotificationWelcome welcomes a user whose membership application was approved
+	NotificationWelcome = "welcome"
+	// NotificationMention tells a user a post or comment @mentioned them
+	NotificationMention = "mention"
+	// NotificationWarning tells a user a moderator warned them about a post or comment
+	NotificationWarning = "warning"
+	// NotificationCommunityArchived tells members their community was archived and is now read-only
+	NotificationCommunityArchived = "community_archived"
+)
+
+// Event is a domain event. Recipients, Public and Topic decide who receives it; only
+// Type, Data and CreatedDate are sent to clients.
+type Event struct {
+	Type        string      `json:"type"`
+	Data        interface{} `json:"data"`
+	CreatedDate int64       `json:"createdDate"`
+	// Recipients receive the event on their personal channel
+	Recipients []uuid.UUID `json:"-"`
+	// Public events also go to everyone following the public feed
+	Public bool `json:"-"`
+	// Topic events also go to everyone following that topic, such as PostTopic
+	Topic string `json:"-"`
+}
+
+// PostTopic is the topic carrying a public post's new comments and live thread events
+func PostTopic(postID uuid.UUID) string {
+	return "post:" + postID.String()
+}
+
+// Notification is the data of a TypeNotification event
+type Notification struct {
+	Kind             string `json:"kind"`
+	PostId           string `json:"postId,omitempty"`
+	CommentId        string `json:"commentId,omitempty"`
+	CommunityId      string `json:"communityId,omitempty"`
+	BadgeId          string `json:"badgeId,omitempty"`
+	StreakDays       int    `json:"streakDays,omitempty"`
+	ActorUserId      string `json:"actorUserId,omitempty"`
+	ActorDisplayName string `json:"actorDisplayName"`
+	ActorAvatar      string `json:"actorAvatar,omitempty"`
+	Preview          string `json:"preview,omitempty"`
+}
+
+// Vote is the data of a TypeVoteCast event
+type Vote struct {
+	PostId      string `json:"postId"`
+	VoterUserId string `json:"voterUserId"`
+}
+
+// MediaUpload is the data of a TypeMediaUploaded event
+type MediaUpload struct {
+	FileId      string `json:"fileId"`
+	OwnerUserId string `json:"ownerUserId"`
+	Purpose     string `json:"purpose"`
+	MimeType    string `json:"mimeType"`
+}
+
+// Handler receives published events. It must not block.
+type Handler func(event Event)
+
+// Bus delivers published events to subscribers
+type Bus interface {
+	Publish(ctx context.Context, event Event)
+	Subscribe(handler Handler) (unsubscribe func())
+	HasSubscribers() bool
+}
+
+// LocalBus delivers events to subscribers in the same process
+type LocalBus struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[int]Handler
+}
+
+// NewLocalBus creates a bus without subscribers
+func NewLocalBus() *LocalBus {
+	return &LocalBus{handlers: make(map[int]Handler)}
+}
+
+// Publish calls every subscriber with the event, recovering from subscriber panics
+func (b *LocalBus) Publish(ctx context.Context, event Event) {
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, h := range b.handlers {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		deliver(h, event)
+	}
+}
+
+func deliver(h Handler, event Event) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Error("Event subscriber panicked on %s: %v", event.Type, p)
+		}
+	}()
+	h(event)
+}
+
+// Subscribe adds a handler and returns a function that removes it
+func (b *LocalBus) Subscribe(handler Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = handler
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+// HasSubscribers reports whether anyone would receive a published event
+func (b *LocalBus) HasSubscribers() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.handlers) > 0
+}
+
+var (
+	defaultMu  sync.RWMutex
+	defaultBus Bus = NewLocalBus()
+)
+
+// Default returns the process-wide bus used by services
+func Default() Bus {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultBus
+}
+
+// SetDefault replaces the process-wide bus, e.g. with one that fans out across instances
+func SetDefault(bus Bus) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultBus = bus
+}
+
+// Publish publishes an event on the process-wide bus
+func Publish(ctx context.Context, event Event) {
+	Default().Publish(ctx, event)
+}
+
+// Subscribe adds a handler to the process-wide bus
+func Subscribe(handler Handler) func() {
+	return Default().Subscribe(handler)
+}
+
+// HasSubscribers reports whether the process-wide bus has subscribers
+func HasSubscribers() bool {
+	return Default().HasSubscribers()
+}
@@ -0,0 +1,39 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalBus(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("delivers to every subscriber until unsubscribed", func(t *testing.T) {
+		bus := NewLocalBus()
+		assert.False(t, bus.HasSubscribers())
+
+		var first, second []string
+		unsubscribe := bus.Subscribe(func(e Event) { first = append(first, e.Type) })
+		bus.Subscribe(func(e Event) { second = append(second, e.Type) })
+		assert.True(t, bus.HasSubscribers())
+
+		bus.Publish(ctx, Event{Type: TypePostCreated})
+		unsubscribe()
+		bus.Publish(ctx, Event{Type: TypeCommentCreated})
+
+		assert.Equal(t, []string{TypePostCreated}, first)
+		assert.Equal(t, []string{TypePostCreated, TypeCommentCreated}, second)
+	})
+
+	t.Run("a panicking subscriber does not stop delivery", func(t *testing.T) {
+		bus := NewLocalBus()
+		delivered := 0
+		bus.Subscribe(func(e Event) { panic("boom") })
+		bus.Subscribe(func(e Event) { delivered++ })
+
+		assert.NotPanics(t, func() { bus.Publish(ctx, Event{Type: TypeNotification}) })
+		assert.Equal(t, 1, delivered)
+	})
+}
@@ -0,0 +1,102 @@
+package hooks
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// maxRecordedBody caps the response body kept in a delivery record. Request bodies
+// are kept whole so they can be redelivered.
+const maxRecordedBody = 64 << 10
+
+// Delivery is one request to a configured webhook, kept so integrators can inspect
+// what was sent and answered and have it sent again
+type Delivery struct {
+	ID             string            `json:"id"`
+	WebhookID      string            `json:"webhookId"`
+	Point          Point             `json:"point"`
+	URL            string            `json:"url"`
+	RequestHeaders map[string]string `json:"requestHeaders"`
+	RequestBody    string            `json:"requestBody"`
+	ResponseStatus int               `json:"responseStatus"` // 0 when no response arrived
+	ResponseBody   string            `json:"responseBody"`
+	Error          string            `json:"error,omitempty"`
+	DurationMs     int64             `json:"durationMs"`
+	RedeliveryOf   string            `json:"redeliveryOf,omitempty"` // Delivery this one sent again
+	CreatedDate    int64             `json:"createdDate"`            // Unix milliseconds
+}
+
+// Succeeded reports whether the webhook answered with a 2xx status
+func (d *Delivery) Succeeded() bool {
+	return d.Error == "" && d.ResponseStatus >= 200 && d.ResponseStatus <= 299
+}
+
+// DeliveryLog stores webhook deliveries
+type DeliveryLog interface {
+	Record(ctx context.Context, delivery *Delivery) error
+}
+
+var (
+	webhooksMu  sync.RWMutex
+	webhooks    []*Webhook
+	deliveryLog DeliveryLog
+)
+
+// SetDeliveryLog makes configured webhooks record their deliveries in l. Until it is
+// called (e.g. in binaries without a database) deliveries are not recorded.
+func SetDeliveryLog(l DeliveryLog) {
+	webhooksMu.Lock()
+	defer webhooksMu.Unlock()
+	deliveryLog = l
+}
+
+// Webhooks lists the webhooks configured with HOOKS_WEBHOOKS
+func Webhooks() []*Webhook {
+	webhooksMu.RLock()
+	defer webhooksMu.RUnlock()
+	return append([]*Webhook(nil), webhooks...)
+}
+
+// FindWebhook returns the configured webhook with the ID
+func FindWebhook(id string) (*Webhook, bool) {
+	webhooksMu.RLock()
+	defer webhooksMu.RUnlock()
+	for _, w := range webhooks {
+		if w.ID == id {
+			return w, true
+		}
+	}
+	return nil, false
+}
+
+func addWebhook(w *Webhook) {
+	webhooksMu.Lock()
+	defer webhooksMu.Unlock()
+	webhooks = append(webhooks, w)
+}
+
+// recordDelivery stores a delivery of a configured webhook. A failure to record is
+// logged; it never fails the hook.
+func recordDelivery(ctx context.Context, delivery *Delivery) {
+	webhooksMu.RLock()
+	l := deliveryLog
+	webhooksMu.RUnlock()
+	if l == nil || delivery.WebhookID == "" {
+		return
+	}
+
+	if err := l.Record(context.WithoutCancel(ctx), delivery); err != nil {
+		log.Warn("Failed to record webhook delivery %s: %v", delivery.ID, err)
+	}
+}
+
+// recordedBody truncates a response body to maxRecordedBody and makes it valid UTF-8 for storage
+func recordedBody(data []byte) string {
+	if len(data) > maxRecordedBody {
+		data = data[:maxRecordedBody]
+	}
+	return strings.ToValidUTF8(string(data), "�")
+}
@@ -0,0 +1,210 @@
+// Package hooks lets self-hosters customize server behavior without forking. Hooks
+// are registered for well-defined points, either from Go plugins loaded at startup
+// or as external webhooks, and run synchronously with a per-hook timeout.
+//
+// Before-hooks (e.g. post.beforeCreate) may rewrite the event payload or reject the
+// operation by returning an error wrapping ErrRejected. After-hooks (e.g.
+// comment.afterCreate) are notifications; their failures are logged only.
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// Point names a place in the request flow where hooks run
+type Point string
+
+const (
+	// PostBeforeCreate runs before a post is stored. Payload: body, tags, permission, postTypeId.
+	PostBeforeCreate Point = "post.beforeCreate"
+	// CommentAfterCreate runs after a comment is stored. Payload: commentId, postId, parentCommentId, text.
+	CommentAfterCreate Point = "comment.afterCreate"
+	// UserAfterSignup runs after a new account and its profile are created. Payload: userId, username, fullName, socialName.
+	UserAfterSignup Point = "user.afterSignup"
+)
+
+// Points lists every supported hook point
+var Points = []Point{PostBeforeCreate, CommentAfterCreate, UserAfterSignup}
+
+// IsBefore reports whether hooks at this point run before the operation and can reject it
+func (p Point) IsBefore() bool {
+	return strings.Contains(string(p), ".before")
+}
+
+// ErrRejected is wrapped by hooks that veto an operation; the message is shown to the user
+var ErrRejected = errors.New("rejected by hook")
+
+// Reject returns an error that vetoes the operation with a user-facing reason
+func Reject(reason string) error {
+	if reason == "" {
+		return ErrRejected
+	}
+	return fmt.Errorf("%w: %s", ErrRejected, reason)
+}
+
+// Event is passed to every hook at a point
+type Event struct {
+	Point   Point                  `json:"point"`
+	UserID  string                 `json:"userId,omitempty"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// Hook handles an event. Before-hooks may modify event.Payload in place.
+type Hook func(ctx context.Context, event *Event) error
+
+type registered struct {
+	name string
+	hook Hook
+}
+
+// Registry holds the hooks registered for each point
+type Registry struct {
+	mu       sync.RWMutex
+	hooks    map[Point][]registered
+	timeout  time.Duration
+	failOpen bool
+}
+
+// NewRegistry creates an empty registry. Each hook call is limited to timeout. With
+// failOpen a before-hook that errors or times out (other than rejecting) is skipped;
+// otherwise the operation fails.
+func NewRegistry(timeout time.Duration, failOpen bool) *Registry {
+	return &Registry{hooks: make(map[Point][]registered), timeout: timeout, failOpen: failOpen}
+}
+
+// Register adds a hook at a point. Hooks run in registration order.
+func (r *Registry) Register(point Point, name string, hook Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks[point] = append(r.hooks[point], registered{name: name, hook: hook})
+}
+
+// Has reports whether any hook is registered at the point
+func (r *Registry) Has(point Point) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.hooks[point]) > 0
+}
+
+// RunBefore runs the hooks at a before point in order, each seeing the payload as
+// rewritten by the previous one. A rejection stops the chain and is returned.
+func (r *Registry) RunBefore(ctx context.Context, event *Event) error {
+	r.mu.RLock()
+	failOpen := r.failOpen
+	r.mu.RUnlock()
+
+	for _, h := range r.snapshot(event.Point) {
+		err := r.call(ctx, h, event)
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, ErrRejected) {
+			return err
+		}
+		if !failOpen {
+			return fmt.Errorf("hook %s at %s failed: %w", h.name, event.Point, err)
+		}
+		log.Warn("Hook %s at %s failed, continuing: %v", h.name, event.Point, err)
+	}
+	return nil
+}
+
+// RunAfter runs the hooks at an after point. The operation already succeeded, so
+// failures are logged and cancellation of the request does not cut hooks short.
+func (r *Registry) RunAfter(ctx context.Context, event *Event) {
+	ctx = context.WithoutCancel(ctx)
+	for _, h := range r.snapshot(event.Point) {
+		if err := r.call(ctx, h, event); err != nil {
+			log.Warn("Hook %s at %s failed: %v", h.name, event.Point, err)
+		}
+	}
+}
+
+func (r *Registry) snapshot(point Point) []registered {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]registered(nil), r.hooks[point]...)
+}
+
+// call runs one hook under the timeout, recovering from panics in plugin code
+func (r *Registry) call(ctx context.Context, h registered, event *Event) (err error) {
+	r.mu.RLock()
+	timeout := r.timeout
+	r.mu.RUnlock()
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	done := make(chan error, 1)
+	// The hook gets its own copy of the payload so a timed-out hook cannot race
+	// with the caller; the copy is adopted only when it returns in time.
+	scratch := &Event{Point: event.Point, UserID: event.UserID, Payload: copyPayload(event.Payload)}
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				done <- fmt.Errorf("panic: %v", p)
+			}
+		}()
+		done <- h.hook(ctx, scratch)
+	}()
+
+	select {
+	case err = <-done:
+		if err == nil {
+			event.Payload = scratch.Payload
+		}
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func copyPayload(payload map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(payload))
+	for k, v := range payload {
+		copied[k] = v
+	}
+	return copied
+}
+
+var defaultRegistry = NewRegistry(2*time.Second, true)
+
+// Default returns the process-wide registry used by services and plugins
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a hook to the process-wide registry
+func Register(point Point, name string, hook Hook) {
+	defaultRegistry.Register(point, name, hook)
+}
+
+// RunBefore runs before-hooks from the process-wide registry
+func RunBefore(ctx context.Context, point Point, userID string, payload map[string]interface{}) (map[string]interface{}, error) {
+	event := &Event{Point: point, UserID: userID, Payload: payload}
+	if !defaultRegistry.Has(point) {
+		return payload, nil
+	}
+	if err := defaultRegistry.RunBefore(ctx, event); err != nil {
+		return nil, err
+	}
+	return event.Payload, nil
+}
+
+// RunAfter runs after-hooks from the process-wide registry
+func RunAfter(ctx context.Context, point Point, userID string, payload map[string]interface{}) {
+	if !defaultRegistry.Has(point) {
+		return
+	}
+	defaultRegistry.RunAfter(ctx, &Event{Point: point, UserID: userID, Payload: payload})
+}
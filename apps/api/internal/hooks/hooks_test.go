@@ -0,0 +1,183 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_RunBefore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("chains payload rewrites in order", func(t *testing.T) {
+		r := NewRegistry(time.Second, true)
+		r.Register(PostBeforeCreate, "upper", func(ctx context.Context, e *Event) error {
+			e.Payload["body"] = e.Payload["body"].(string) + " one"
+			return nil
+		})
+		r.Register(PostBeforeCreate, "second", func(ctx context.Context, e *Event) error {
+			e.Payload["body"] = e.Payload["body"].(string) + " two"
+			return nil
+		})
+
+		event := &Event{Point: PostBeforeCreate, Payload: map[string]interface{}{"body": "zero"}}
+		require.NoError(t, r.RunBefore(ctx, event))
+		assert.Equal(t, "zero one two", event.Payload["body"])
+	})
+
+	t.Run("rejection stops the chain", func(t *testing.T) {
+		r := NewRegistry(time.Second, true)
+		called := false
+		r.Register(PostBeforeCreate, "deny", func(ctx context.Context, e *Event) error {
+			return Reject("no links allowed")
+		})
+		r.Register(PostBeforeCreate, "never", func(ctx context.Context, e *Event) error {
+			called = true
+			return nil
+		})
+
+		err := r.RunBefore(ctx, &Event{Point: PostBeforeCreate, Payload: map[string]interface{}{}})
+		assert.True(t, errors.Is(err, ErrRejected))
+		assert.Contains(t, err.Error(), "no links allowed")
+		assert.False(t, called)
+	})
+
+	t.Run("timeouts and panics fail open or closed", func(t *testing.T) {
+		slow := func(ctx context.Context, e *Event) error {
+			e.Payload["body"] = "late"
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		}
+		broken := func(ctx context.Context, e *Event) error { panic("boom") }
+
+		open := NewRegistry(20*time.Millisecond, true)
+		open.Register(PostBeforeCreate, "slow", slow)
+		open.Register(PostBeforeCreate, "broken", broken)
+		event := &Event{Point: PostBeforeCreate, Payload: map[string]interface{}{"body": "original"}}
+		require.NoError(t, open.RunBefore(ctx, event))
+		assert.Equal(t, "original", event.Payload["body"])
+
+		closed := NewRegistry(20*time.Millisecond, false)
+		closed.Register(PostBeforeCreate, "broken", broken)
+		err := closed.RunBefore(ctx, &Event{Point: PostBeforeCreate, Payload: map[string]interface{}{}})
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, ErrRejected))
+	})
+}
+
+func TestWebhook(t *testing.T) {
+	ctx := context.Background()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var event Event
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&event))
+		assert.Equal(t, string(PostBeforeCreate), req.Header.Get(HeaderHookPoint))
+
+		switch event.Payload["body"] {
+		case "spam":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"reject": true, "reason": "looks like spam"})
+		case "error":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"payload": map[string]interface{}{"body": "rewritten"}})
+		}
+	}))
+	defer server.Close()
+
+	hook := NewWebhook(server.URL, server.Client())
+
+	event := &Event{Point: PostBeforeCreate, Payload: map[string]interface{}{"body": "hello"}}
+	require.NoError(t, hook(ctx, event))
+	assert.Equal(t, "rewritten", event.Payload["body"])
+
+	err := hook(ctx, &Event{Point: PostBeforeCreate, Payload: map[string]interface{}{"body": "spam"}})
+	assert.True(t, errors.Is(err, ErrRejected))
+
+	err = hook(ctx, &Event{Point: PostBeforeCreate, Payload: map[string]interface{}{"body": "error"}})
+	require.Error(t, err)
+	assert.False(t, errors.Is(err, ErrRejected))
+}
+
+func TestParseWebhook(t *testing.T) {
+	point, url, err := parseWebhook(" comment.afterCreate = https://hooks.example.com/c ")
+	require.NoError(t, err)
+	assert.Equal(t, CommentAfterCreate, point)
+	assert.Equal(t, "https://hooks.example.com/c", url)
+
+	for _, bad := range []string{"post.beforeCreate", "post.afterDelete=https://x", "user.afterSignup=ftp://x"} {
+		_, _, err := parseWebhook(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"point":"comment.afterCreate","payload":{}}`)
+	now := time.Unix(1_800_000_000, 0)
+	ts := "1800000000"
+	signature := Sign("secret", now.Unix(), body)
+
+	require.NoError(t, VerifySignature("secret", signature, ts, body, DefaultSignatureTolerance, now))
+	require.NoError(t, VerifySignature("secret", "v1=old, "+signature, ts, body, DefaultSignatureTolerance, now))
+
+	assert.ErrorIs(t, VerifySignature("other", signature, ts, body, DefaultSignatureTolerance, now), ErrSignatureMismatch)
+	assert.ErrorIs(t, VerifySignature("secret", signature, ts, []byte(`{}`), DefaultSignatureTolerance, now), ErrSignatureMismatch)
+	assert.ErrorIs(t, VerifySignature("secret", signature, "", body, DefaultSignatureTolerance, now), ErrMissingSignature)
+	assert.ErrorIs(t, VerifySignature("secret", signature, ts, body, DefaultSignatureTolerance, now.Add(time.Hour)), ErrTimestampOutOfRange)
+	require.NoError(t, VerifySignature("secret", signature, ts, body, 0, now.Add(time.Hour)))
+}
+
+type memoryDeliveryLog struct {
+	deliveries []*Delivery
+}
+
+func (l *memoryDeliveryLog) Record(ctx context.Context, delivery *Delivery) error {
+	l.deliveries = append(l.deliveries, delivery)
+	return nil
+}
+
+func TestWebhook_SignsAndRecordsDeliveries(t *testing.T) {
+	ctx := context.Background()
+	status := http.StatusOK
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		require.NoError(t, VerifySignature("secret", req.Header.Get(HeaderSignature), req.Header.Get(HeaderTimestamp), body, time.Minute, time.Now()))
+		assert.NotEmpty(t, req.Header.Get(HeaderDelivery))
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	deliveries := &memoryDeliveryLog{}
+	SetDeliveryLog(deliveries)
+	defer SetDeliveryLog(nil)
+
+	webhook := newWebhook(CommentAfterCreate, server.URL, "secret", server.Client())
+	require.NoError(t, webhook.Hook()(ctx, &Event{Point: CommentAfterCreate, Payload: map[string]interface{}{"text": "hi"}}))
+	require.Len(t, deliveries.deliveries, 1)
+	first := deliveries.deliveries[0]
+	assert.Equal(t, WebhookID(CommentAfterCreate, server.URL), first.WebhookID)
+	assert.Equal(t, http.StatusOK, first.ResponseStatus)
+	assert.Equal(t, "ok", first.ResponseBody)
+	assert.Contains(t, first.RequestBody, `"text":"hi"`)
+	assert.True(t, first.Succeeded())
+
+	status = http.StatusInternalServerError
+	redelivery, err := webhook.Redeliver(ctx, []byte(first.RequestBody), first.ID)
+	require.NoError(t, err)
+	require.Len(t, deliveries.deliveries, 2)
+	assert.NotEqual(t, first.ID, redelivery.ID)
+	assert.Equal(t, first.ID, redelivery.RedeliveryOf)
+	assert.Equal(t, first.RequestBody, redelivery.RequestBody)
+	assert.Equal(t, http.StatusInternalServerError, redelivery.ResponseStatus)
+	assert.False(t, redelivery.Succeeded())
+}
@@ -0,0 +1,98 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"plugin"
+	"sort"
+	"strings"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+// PluginRegisterSymbol is the function every Go plugin exports:
+//
+//	func Register(r *hooks.Registry) error
+//
+// Plugins must be built with -buildmode=plugin against the same telar version and Go
+// toolchain as the server, and loading requires a cgo-enabled build.
+const PluginRegisterSymbol = "Register"
+
+// Configure applies hook settings to the process-wide registry: it registers the
+// configured webhooks, signed with the webhook secret, and loads Go plugins from the
+// plugin directory.
+func Configure(cfg platformconfig.HooksConfig) error {
+	defaultRegistry.mu.Lock()
+	defaultRegistry.timeout = cfg.Timeout
+	defaultRegistry.failOpen = cfg.FailOpen
+	defaultRegistry.mu.Unlock()
+
+	for _, entry := range cfg.Webhooks {
+		point, url, err := parseWebhook(entry)
+		if err != nil {
+			return err
+		}
+		webhook := newWebhook(point, url, cfg.WebhookSecret, nil)
+		addWebhook(webhook)
+		Register(point, "webhook "+url, webhook.Hook())
+		log.Info("Registered %s webhook %s (%s)", point, url, webhook.ID)
+	}
+
+	if cfg.PluginDir != "" {
+		return LoadPlugins(cfg.PluginDir, defaultRegistry)
+	}
+	return nil
+}
+
+// LoadPlugins opens every .so file in dir, in name order, and calls its Register function
+func LoadPlugins(dir string, r *Registry) error {
+	if _, err := os.Stat(dir); err != nil {
+		return fmt.Errorf("plugin directory %s: %w", dir, err)
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.so"))
+	if err != nil {
+		return fmt.Errorf("list plugins in %s: %w", dir, err)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		p, err := plugin.Open(path)
+		if err != nil {
+			return fmt.Errorf("open plugin %s: %w", path, err)
+		}
+		sym, err := p.Lookup(PluginRegisterSymbol)
+		if err != nil {
+			return fmt.Errorf("plugin %s: %w", path, err)
+		}
+		register, ok := sym.(func(*Registry) error)
+		if !ok {
+			return fmt.Errorf("plugin %s: %s must be func(*hooks.Registry) error", path, PluginRegisterSymbol)
+		}
+		if err := register(r); err != nil {
+			return fmt.Errorf("plugin %s: register: %w", path, err)
+		}
+		log.Info("Loaded hook plugin %s", filepath.Base(path))
+	}
+	return nil
+}
+
+// parseWebhook splits a "point=url" entry from HOOKS_WEBHOOKS
+func parseWebhook(entry string) (Point, string, error) {
+	name, url, ok := strings.Cut(entry, "=")
+	point := Point(strings.TrimSpace(name))
+	url = strings.TrimSpace(url)
+	if !ok || url == "" {
+		return "", "", fmt.Errorf("hook webhook %q must be point=url", entry)
+	}
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return "", "", fmt.Errorf("hook webhook %q must use an http(s) URL", entry)
+	}
+	for _, known := range Points {
+		if point == known {
+			return point, url, nil
+		}
+	}
+	return "", "", fmt.Errorf("unknown hook point %q", point)
+}
@@ -0,0 +1,169 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// HeaderHookPoint names the hook point on webhook requests
+const HeaderHookPoint = "X-Telar-Hook"
+
+// maxWebhookResponse caps how much of a webhook response is read
+const maxWebhookResponse = 1 << 20
+
+// webhookResponse is the optional JSON body a webhook returns. Before-hooks may set
+// reject (with a reason shown to the user) or return a rewritten payload.
+type webhookResponse struct {
+	Reject  bool                   `json:"reject"`
+	Reason  string                 `json:"reason"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// Webhook is an external endpoint receiving the events of a hook point. Webhooks
+// configured with HOOKS_WEBHOOKS have an ID and record their deliveries.
+type Webhook struct {
+	ID    string `json:"id"`
+	Point Point  `json:"point"`
+	URL   string `json:"url"`
+
+	client *http.Client
+	secret string
+}
+
+// WebhookID derives the ID of the webhook for url at point. It is stable across
+// restarts, so recorded deliveries still find their webhook.
+func WebhookID(point Point, url string) string {
+	sum := sha256.Sum256([]byte(string(point) + "=" + url))
+	return "wh_" + hex.EncodeToString(sum[:6])
+}
+
+// NewWebhook returns a hook that POSTs the event as JSON to url. Any 2xx response
+// accepts the event; an empty body leaves the payload unchanged. Its requests are
+// not signed and its deliveries not recorded.
+func NewWebhook(url string, client *http.Client) Hook {
+	return (&Webhook{URL: url, client: client}).Hook()
+}
+
+// newWebhook creates a configured webhook; requests are signed when secret is set
+func newWebhook(point Point, url, secret string, client *http.Client) *Webhook {
+	return &Webhook{ID: WebhookID(point, url), Point: point, URL: url, client: client, secret: secret}
+}
+
+// Hook returns the hook that delivers events to the webhook
+func (w *Webhook) Hook() Hook {
+	return func(ctx context.Context, event *Event) error {
+		body, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encode event: %w", err)
+		}
+
+		_, data, err := w.deliver(ctx, event.Point, body, "")
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(data)) == 0 || !event.Point.IsBefore() {
+			return nil
+		}
+
+		var result webhookResponse
+		if err := json.Unmarshal(data, &result); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+		if result.Reject {
+			return Reject(result.Reason)
+		}
+		if result.Payload != nil {
+			event.Payload = result.Payload
+		}
+		return nil
+	}
+}
+
+// Redeliver sends a recorded request body to the webhook again, with a new delivery
+// ID and a fresh signature, and returns the new delivery. A failed request is
+// reported in the delivery; a before-hook's answer no longer changes anything.
+func (w *Webhook) Redeliver(ctx context.Context, body []byte, redeliveryOf string) (*Delivery, error) {
+	delivery, _, err := w.deliver(ctx, w.Point, body, redeliveryOf)
+	if delivery == nil {
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// deliver POSTs body and records the delivery. It returns the response body of a
+// 2xx response and an error for anything else.
+func (w *Webhook) deliver(ctx context.Context, point Point, body []byte, redeliveryOf string) (*Delivery, []byte, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, nil, fmt.Errorf("generate delivery ID: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, fmt.Errorf("build request: %w", err)
+	}
+
+	now := time.Now()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderHookPoint, string(point))
+	req.Header.Set(HeaderDelivery, id.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now.Unix(), 10))
+	if w.secret != "" {
+		req.Header.Set(HeaderSignature, Sign(w.secret, now.Unix(), body))
+	}
+
+	delivery := &Delivery{
+		ID:             id.String(),
+		WebhookID:      w.ID,
+		Point:          point,
+		URL:            w.URL,
+		RequestHeaders: make(map[string]string, len(req.Header)),
+		RequestBody:    string(body),
+		RedeliveryOf:   redeliveryOf,
+		CreatedDate:    now.UnixMilli(),
+	}
+	for name := range req.Header {
+		delivery.RequestHeaders[name] = req.Header.Get(name)
+	}
+
+	data, err := w.send(req, delivery)
+	delivery.DurationMs = time.Since(now).Milliseconds()
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+	recordDelivery(ctx, delivery)
+	return delivery, data, err
+}
+
+// send does the request, noting the response in delivery
+func (w *Webhook) send(req *http.Request, delivery *Delivery) ([]byte, error) {
+	client := w.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	delivery.ResponseStatus = resp.StatusCode
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponse))
+	delivery.ResponseBody = recordedBody(data)
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return data, nil
+}
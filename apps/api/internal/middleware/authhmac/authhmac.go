@@ -6,6 +6,7 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
 	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 	"github.com/qolzam/telar/apps/api/internal/types"
 )
@@ -78,6 +79,7 @@ func New(config Config) fiber.Handler {
 			SystemRole:  c.Get("systemRole"),
 			CreatedDate: createdDate,
 		})
+		requestid.SetUserID(c, userUUID)
 
 		return c.Next()
 	}
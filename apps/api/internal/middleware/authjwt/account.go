@@ -0,0 +1,51 @@
+package authjwt
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/gofrs/uuid"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+// AccountChecker decides whether a validly signed, unexpired token still speaks for
+// its user, so that suspensions and role changes take effect before tokens expire.
+type AccountChecker interface {
+	// CheckToken returns an error when tokens of userID issued at issuedAt (Unix
+	// seconds) are no longer honoured
+	CheckToken(ctx context.Context, userID uuid.UUID, issuedAt int64) error
+}
+
+// accounts is the checker every JWT validation consults; nil honours all tokens
+var accounts atomic.Pointer[AccountChecker]
+
+// SetAccountChecker makes every JWT validation, in this middleware and in dualauth,
+// consult checker. nil honours every validly signed token.
+func SetAccountChecker(checker AccountChecker) {
+	if checker == nil {
+		accounts.Store(nil)
+		return
+	}
+	accounts.Store(&checker)
+}
+
+// checkAccount asks the account checker about the user a token acts as and, for a
+// switched token, the account that signed in
+func checkAccount(ctx context.Context, claims jwt.MapClaims, userCtx types.UserContext) error {
+	checker := accounts.Load()
+	if checker == nil {
+		return nil
+	}
+	var issuedAt int64
+	if iat, ok := claims["iat"].(float64); ok {
+		issuedAt = int64(iat)
+	}
+	if err := (*checker).CheckToken(ctx, userCtx.UserID, issuedAt); err != nil {
+		return err
+	}
+	if userCtx.LoginUserID != uuid.Nil && userCtx.LoginUserID != userCtx.UserID {
+		return (*checker).CheckToken(ctx, userCtx.LoginUserID, issuedAt)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package authjwt_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	tokenutil "github.com/qolzam/telar/apps/api/internal/auth/tokens"
+	"github.com/qolzam/telar/apps/api/internal/middleware/authjwt"
+	"github.com/qolzam/telar/apps/api/internal/testutil"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// revokedUsers refuses the tokens of the users it lists
+type revokedUsers map[uuid.UUID]bool
+
+func (r revokedUsers) CheckToken(_ context.Context, userID uuid.UUID, _ int64) error {
+	if r[userID] {
+		return errors.New("revoked")
+	}
+	return nil
+}
+
+func TestValidateToken_ConsultsAccountChecker(t *testing.T) {
+	publicKey, privateKey := testutil.GenerateECDSAKeyPairPEM(t)
+	active, suspended := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	token := func(userID uuid.UUID) string {
+		claim := map[string]interface{}{types.HeaderUID: userID.String(), "role": "admin"}
+		profile := map[string]string{"id": userID.String(), "login": "a", "name": "a", "audience": "http://localhost"}
+		signed, err := tokenutil.CreateTokenWithKey("telar", profile, "Telar", claim, privateKey)
+		require.NoError(t, err)
+		return signed
+	}
+
+	authjwt.SetAccountChecker(revokedUsers{suspended: true})
+	t.Cleanup(func() { authjwt.SetAccountChecker(nil) })
+
+	user, err := authjwt.ValidateToken(token(active), publicKey, "claim", nil)
+	require.NoError(t, err)
+	assert.Equal(t, active, user.UserID)
+
+	_, err = authjwt.ValidateToken(token(suspended), publicKey, "claim", nil)
+	assert.Error(t, err, "a signed, unexpired token of a suspended user is refused")
+
+	authjwt.SetAccountChecker(nil)
+	_, err = authjwt.ValidateToken(token(suspended), publicKey, "claim", nil)
+	assert.NoError(t, err, "without a checker every signed token stands")
+}
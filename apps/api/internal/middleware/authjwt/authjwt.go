@@ -11,6 +11,7 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/internal/cache"
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
 	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 	"github.com/qolzam/telar/apps/api/internal/types"
 )
@@ -152,7 +153,16 @@ func New(cfg Config) fiber.Handler {
 				})
 			}
 
+			// Suspended users and tokens issued before a revocation lose access at once
+			if err := checkAccount(c.Context(), claims, userCtx); err != nil {
+				return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+					"code":    "UNAUTHORIZED",
+					"message": "Session has been revoked. Please log in again.",
+				})
+			}
+
 			c.Locals(cfg.UserCtxName, userCtx)
+			requestid.SetUserID(c, userCtx.UserID)
 			return c.Next()
 		}
 
@@ -218,6 +228,16 @@ func mapToUserContext(claimData map[string]interface{}) (types.UserContext, erro
 		userCtx.CreatedDate = int64(createdDate)
 	}
 
+	// Extract session ID
+	if sessionID, ok := claimData["jti"].(string); ok {
+		userCtx.SessionID = sessionID
+	}
+
+	// Extract the signed-in account of a switched token
+	if loginUID, ok := claimData["loginUid"].(string); ok {
+		userCtx.LoginUserID = uuid.FromStringOrNil(loginUID)
+	}
+
 	return userCtx, nil
 }
 
@@ -286,6 +306,9 @@ func ValidateToken(tokenString string, publicKey string, claimKey string, sessio
 		if err != nil {
 			return userCtx, fmt.Errorf("invalid user context in token: %w", err)
 		}
+		if err := checkAccount(context.Background(), claims, userCtx); err != nil {
+			return types.UserContext{}, fmt.Errorf("session has been revoked: %w", err)
+		}
 
 		return userCtx, nil
 	}
@@ -19,7 +19,7 @@ func New(config Config) fiber.Handler {
 		}
 
 		// Get current user
-		currentUser, ok := c.Locals("user").(types.UserContext)
+		currentUser, ok := c.Locals(cfg.UserCtxName).(types.UserContext)
 		if !ok {
 			log.Error("Can not retrieve current user context")
 			return cfg.Unauthorized(c)
@@ -42,3 +42,17 @@ func TestAuthRole_UnauthorizedWithMismatchedRole(t *testing.T) {
 }
 
 
+
+func TestAuthRole_AuthorizedWithAnyOfRoles(t *testing.T) {
+    for role, want := range map[string]int{"moderator": http.StatusOK, "admin": http.StatusOK, "user": http.StatusUnauthorized} {
+        app := fiber.New()
+        app.Use(func(c *fiber.Ctx) error {
+            c.Locals(types.UserCtxName, types.UserContext{ SystemRole: role })
+            return c.Next()
+        })
+        app.Get("/", New(Config{ Roles: []string{"admin", "moderator"} }), func(c *fiber.Ctx) error { return c.SendStatus(http.StatusOK) })
+        req := httptest.NewRequest("GET", "/", nil)
+        resp, _ := app.Test(req)
+        if resp.StatusCode != want { t.Fatalf("role %s: expected %d, got %d", role, want, resp.StatusCode) }
+    }
+}
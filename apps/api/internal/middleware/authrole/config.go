@@ -1,6 +1,8 @@
 package authrole
 
 import (
+	"slices"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/qolzam/telar/apps/api/internal/types"
 )
@@ -39,6 +41,11 @@ type Config struct {
 	// Optional. Default: "admin"
 	Role string
 
+	// Roles allows any of several roles instead of Role, such as admins and moderators
+	//
+	// Optional. Default: nil
+	Roles []string
+
 	// UserCtxName is the key to store the user context in Locals
 	//
 	// Optional. Default: "user"
@@ -70,6 +77,9 @@ func configDefault(config ...Config) Config {
 	}
 	if cfg.Authorizer == nil {
 		cfg.Authorizer = func(userRole string) bool {
+			if len(cfg.Roles) > 0 {
+				return slices.Contains(cfg.Roles, userRole)
+			}
 			return cfg.Role == userRole
 		}
 	}
@@ -6,6 +6,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	authjwt "github.com/qolzam/telar/apps/api/internal/middleware/authjwt"
 	authhmac "github.com/qolzam/telar/apps/api/internal/middleware/authhmac"
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
 	"github.com/qolzam/telar/apps/api/internal/types"
 )
 
@@ -57,6 +58,7 @@ func CreateDualAuthMiddleware(cfg Config) fiber.Handler {
 			if err == nil {
 				// Set user context and proceed (call Next ONLY once)
 				c.Locals(types.UserCtxName, userCtx)
+				requestid.SetUserID(c, userCtx.UserID)
 				return c.Next()
 			}
 			// If JWT validation fails, do NOT fall through to HMAC
@@ -70,6 +72,7 @@ func CreateDualAuthMiddleware(cfg Config) fiber.Handler {
 			if err == nil {
 				// Set user context and proceed (call Next ONLY once)
 				c.Locals(types.UserCtxName, userCtx)
+				requestid.SetUserID(c, userCtx.UserID)
 				return c.Next()
 			}
 		}
@@ -0,0 +1,63 @@
+package grpcauth
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testMethod = "/posts.v1.PostsService/IncrementCommentCount"
+
+// incoming turns the metadata a client signed into the context a server sees
+func incoming(ctx context.Context) context.Context {
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestVerify_RejectsReplayedCalls(t *testing.T) {
+	seen := newNonceCache()
+	call := incoming(sign(context.Background(), "secret", testMethod))
+
+	nonce, err := verify(call, "secret", testMethod, time.Now(), seen)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, nonce)
+
+	_, err = verify(call, "secret", testMethod, time.Now(), seen)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err), "a replayed call is rejected")
+
+	_, err = verify(incoming(sign(context.Background(), "secret", testMethod)), "secret", testMethod, time.Now(), seen)
+	assert.NoError(t, err, "a new call has a new nonce")
+}
+
+func TestVerify_SignatureCoversNonce(t *testing.T) {
+	now := time.Now().Unix()
+	call := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		MetadataTimestamp, strconv.FormatInt(now, 10),
+		MetadataNonce, "other-nonce",
+		MetadataSignature, Sign("secret", testMethod, now, "nonce")))
+
+	_, err := verify(call, "secret", testMethod, time.Now(), newNonceCache())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
+
+func TestNonceCache_ReleasesUnavailableCalls(t *testing.T) {
+	seen := newNonceCache()
+	now := time.Now()
+	assert.True(t, seen.claim("n1", now))
+
+	seen.release("n1", status.Error(codes.InvalidArgument, "bad"))
+	assert.False(t, seen.claim("n1", now), "a call that was served stays claimed")
+
+	seen.release("n1", status.Error(codes.Unavailable, "restarting"))
+	assert.True(t, seen.claim("n1", now), "a call the client will retry may be retried")
+
+	assert.True(t, seen.claim("n2", now))
+	assert.True(t, seen.claim("n3", now.Add(2*MaxClockSkew+time.Second)))
+	assert.NotContains(t, seen.seen, "n2", "expired nonces are forgotten")
+}
@@ -0,0 +1,214 @@
+// Package httpcache caches responses of anonymous public GET endpoints. Responses carry
+// Cache-Control: public so browsers and CDNs can reuse them, and a server-side copy lets
+// crawler and viral traffic skip the database. Entries of a route with a Subject are
+// dropped through InvalidateSubjects when that subject changes; other writes drop a whole
+// scope through Invalidate.
+package httpcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+// Scopes group cached responses so a write can drop every page it may affect
+const (
+	ScopePosts        = "posts"
+	ScopeProfiles     = "profiles"
+	ScopeBranding     = "branding"
+	ScopeLeaderboards = "leaderboards"
+	ScopeTags         = "tags"
+)
+
+// HeaderCache reports whether a response was served from the server-side cache (HIT or MISS)
+const HeaderCache = "X-Cache"
+
+// Config holds the configuration for the HTTP cache middleware
+type Config struct {
+	// Scope groups entries for invalidation (ScopePosts, ScopeProfiles, ScopeBranding, ScopeLeaderboards, ScopeTags)
+	Scope string
+
+	// MaxAge is the Cache-Control max-age sent to browsers and CDNs
+	MaxAge time.Duration
+
+	// TTL is the server-side entry lifetime; defaults to MaxAge
+	TTL time.Duration
+
+	// Subject names what a response shows (a post, a tag, a profile) so writes to it drop
+	// only its entries; nil keys the route by scope alone
+	Subject Subject
+
+	// Store overrides the shared cache store (tests)
+	Store *cache.GenericCacheService
+}
+
+// Subject returns the subject of a request, or "" to key it by scope alone
+type Subject func(c *fiber.Ctx) string
+
+// entry is a cached response
+type entry struct {
+	ContentType string `json:"contentType"`
+	Body        []byte `json:"body"`
+}
+
+var (
+	sharedStoreOnce sync.Once
+	sharedStore     *cache.GenericCacheService
+)
+
+// defaultStore returns the process-wide store shared by the middleware and Invalidate,
+// so in-memory backends see the same entries; Redis backends are shared across instances
+func defaultStore() *cache.GenericCacheService {
+	sharedStoreOnce.Do(func() {
+		sharedStore = cache.NewGenericCacheServiceFor("http")
+	})
+	return sharedStore
+}
+
+// NewFromConfig creates the middleware from platform config; subject may be nil. When
+// HTTP caching is disabled the returned handler passes every request through.
+func NewFromConfig(scope string, subject Subject, cfg platformconfig.HTTPCacheConfig) fiber.Handler {
+	if !cfg.Enabled {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+	return New(Config{Scope: scope, MaxAge: cfg.MaxAge, TTL: cfg.TTL, Subject: subject})
+}
+
+// New creates a middleware that serves anonymous GET requests from cache and stores
+// successful responses. Requests with credentials bypass the cache so signed-in users
+// see their own writes immediately.
+func New(cfg Config) fiber.Handler {
+	store := cfg.Store
+	if store == nil {
+		store = defaultStore()
+	}
+	ttl := cfg.TTL
+	if ttl <= 0 {
+		ttl = cfg.MaxAge
+	}
+	maxAge := int(cfg.MaxAge.Seconds())
+	cacheControl := fmt.Sprintf("public, max-age=%d, stale-while-revalidate=%d", maxAge, maxAge)
+
+	return func(c *fiber.Ctx) error {
+		if c.Method() != fiber.MethodGet || hasCredentials(c) {
+			return c.Next()
+		}
+
+		prefix := cfg.Scope
+		if cfg.Subject != nil {
+			if subject := cfg.Subject(c); subject != "" {
+				prefix = subjectPrefix(cfg.Scope, subject)
+			}
+		}
+		key := cacheKey(store, prefix, c)
+		var cached entry
+		if err := store.GetCached(c.Context(), key, &cached); err == nil {
+			c.Set(fiber.HeaderCacheControl, cacheControl)
+			c.Set(HeaderCache, "HIT")
+			c.Set(fiber.HeaderContentType, cached.ContentType)
+			return c.Send(cached.Body)
+		}
+
+		if err := c.Next(); err != nil {
+			return err
+		}
+		if c.Response().StatusCode() != fiber.StatusOK {
+			return nil
+		}
+
+		c.Set(fiber.HeaderCacheControl, cacheControl)
+		c.Set(HeaderCache, "MISS")
+		fresh := entry{
+			ContentType: string(c.Response().Header.ContentType()),
+			Body:        append([]byte(nil), c.Response().Body()...),
+		}
+		if err := store.CacheData(c.Context(), key, fresh, ttl); err != nil && !errors.Is(err, cache.ErrCacheDisabled) {
+			log.Warn("Failed to cache response for %s: %v", c.Path(), err)
+		}
+		return nil
+	}
+}
+
+// Invalidate drops every cached response in the given scopes. It is best-effort:
+// entries that survive a failed invalidation still expire after their TTL.
+func Invalidate(ctx context.Context, scopes ...string) {
+	invalidate(ctx, defaultStore(), scopes...)
+}
+
+func invalidate(ctx context.Context, store *cache.GenericCacheService, scopes ...string) {
+	for _, scope := range scopes {
+		if err := store.InvalidatePattern(ctx, scope+":*"); err != nil && !errors.Is(err, cache.ErrCacheDisabled) {
+			log.Warn("Failed to invalidate HTTP cache scope %s: %v", scope, err)
+		}
+	}
+}
+
+// InvalidateSubjects drops the cached responses of the given subjects in scope, leaving
+// the rest of the scope cached. Like Invalidate it is best-effort.
+func InvalidateSubjects(ctx context.Context, scope string, subjects ...string) {
+	invalidateSubjects(ctx, defaultStore(), scope, subjects...)
+}
+
+func invalidateSubjects(ctx context.Context, store *cache.GenericCacheService, scope string, subjects ...string) {
+	for _, subject := range subjects {
+		if subject == "" {
+			continue
+		}
+		if err := store.InvalidatePattern(ctx, subjectPrefix(scope, subject)+":*"); err != nil && !errors.Is(err, cache.ErrCacheDisabled) {
+			log.Warn("Failed to invalidate HTTP cache subject %s in %s: %v", subject, scope, err)
+		}
+	}
+}
+
+// ConsumerGroup names the outbox consumer group that invalidates cached responses. An
+// in-memory store belongs to one instance, so each instance consumes under a group of
+// its own to drop its own entries; a Redis store is shared and needs a single consumer.
+func ConsumerGroup(base string) string {
+	if cfg := defaultStore().GetConfig(); cfg != nil && cfg.Backend == cache.CacheTypeRedis {
+		return base
+	}
+	host, err := os.Hostname()
+	if err != nil || host == "" {
+		return base
+	}
+	return base + "." + host
+}
+
+// subjectPrefix keys a subject's entries under its scope. Subjects are hashed, as tags
+// and names may hold characters cache keys and patterns do not allow.
+func subjectPrefix(scope, subject string) string {
+	sum := sha256.Sum256([]byte(subject))
+	return scope + ":" + hex.EncodeToString(sum[:8])
+}
+
+// hasCredentials reports whether the request is authenticated (bearer token or session cookie)
+func hasCredentials(c *fiber.Ctx) bool {
+	return c.Get(types.HeaderAuthorization) != "" || c.Cookies("access_token") != ""
+}
+
+// cacheKey identifies a response by path and query, with query parameters in sorted order
+func cacheKey(store *cache.GenericCacheService, prefix string, c *fiber.Ctx) string {
+	query, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	normalized := string(c.Request().URI().QueryString())
+	if err == nil {
+		normalized = query.Encode()
+	}
+	return store.GenerateHashKey(prefix, map[string]interface{}{
+		"path":  c.Path(),
+		"query": normalized,
+	})
+}
@@ -0,0 +1,134 @@
+package httpcache
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+func newTestApp(t *testing.T) (*fiber.App, *cache.GenericCacheService, *int) {
+	memCache := cache.NewMemoryCache(cache.DefaultCacheConfig())
+	t.Cleanup(func() { memCache.Close() })
+	cfg := cache.DefaultCacheConfig()
+	cfg.Prefix = "http_test"
+	store := cache.NewGenericCacheService(memCache, cfg)
+
+	calls := 0
+	app := fiber.New()
+	app.Use(New(Config{Scope: ScopePosts, MaxAge: time.Minute, Store: store}))
+	app.Get("/feed", func(c *fiber.Ctx) error {
+		calls++
+		return c.JSON(fiber.Map{"calls": calls})
+	})
+	app.Get("/missing", func(c *fiber.Ctx) error {
+		calls++
+		return c.SendStatus(fiber.StatusNotFound)
+	})
+	return app, store, &calls
+}
+
+func get(t *testing.T, app *fiber.App, path string, header ...string) *httptestResponse {
+	req := httptest.NewRequest("GET", path, nil)
+	if len(header) == 2 {
+		req.Header.Set(header[0], header[1])
+	}
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return &httptestResponse{status: resp.StatusCode, cache: resp.Header.Get(HeaderCache), cacheControl: resp.Header.Get(fiber.HeaderCacheControl), body: string(body)}
+}
+
+type httptestResponse struct {
+	status       int
+	cache        string
+	cacheControl string
+	body         string
+}
+
+func TestHTTPCache_ServesAnonymousRequestsFromCache(t *testing.T) {
+	app, _, calls := newTestApp(t)
+
+	first := get(t, app, "/feed?b=2&a=1")
+	assert.Equal(t, 200, first.status)
+	assert.Equal(t, "MISS", first.cache)
+	assert.Equal(t, "public, max-age=60, stale-while-revalidate=60", first.cacheControl)
+
+	// Same query in a different order hits the same entry
+	second := get(t, app, "/feed?a=1&b=2")
+	assert.Equal(t, "HIT", second.cache)
+	assert.Equal(t, first.body, second.body)
+	assert.Equal(t, 1, *calls)
+}
+
+func TestHTTPCache_BypassesAuthenticatedRequests(t *testing.T) {
+	app, _, calls := newTestApp(t)
+
+	get(t, app, "/feed")
+	resp := get(t, app, "/feed", types.HeaderAuthorization, "Bearer token")
+	assert.Empty(t, resp.cache)
+	assert.Empty(t, resp.cacheControl)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestHTTPCache_SkipsErrorResponses(t *testing.T) {
+	app, _, calls := newTestApp(t)
+
+	for i := 0; i < 2; i++ {
+		resp := get(t, app, "/missing")
+		assert.Equal(t, 404, resp.status)
+		assert.Empty(t, resp.cacheControl)
+	}
+	assert.Equal(t, 2, *calls)
+}
+
+func TestHTTPCache_InvalidateScope(t *testing.T) {
+	app, store, calls := newTestApp(t)
+
+	get(t, app, "/feed")
+	invalidate(context.Background(), store, ScopePosts)
+	resp := get(t, app, "/feed")
+	assert.Equal(t, "MISS", resp.cache)
+	assert.Equal(t, `{"calls":`+strconv.Itoa(*calls)+`}`, resp.body)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestHTTPCache_InvalidateSubjects(t *testing.T) {
+	memCache := cache.NewMemoryCache(cache.DefaultCacheConfig())
+	t.Cleanup(func() { memCache.Close() })
+	cfg := cache.DefaultCacheConfig()
+	cfg.Prefix = "http_test"
+	store := cache.NewGenericCacheService(memCache, cfg)
+
+	calls := 0
+	app := fiber.New()
+	app.Get("/tags/:tag", New(Config{Scope: ScopePosts, MaxAge: time.Minute, Store: store, Subject: func(c *fiber.Ctx) string {
+		return "tag:" + c.Params("tag")
+	}}), func(c *fiber.Ctx) error {
+		calls++
+		return c.JSON(fiber.Map{"calls": calls})
+	})
+
+	get(t, app, "/tags/go")
+	get(t, app, "/tags/rust")
+	invalidateSubjects(context.Background(), store, ScopePosts, "tag:go")
+
+	assert.Equal(t, "MISS", get(t, app, "/tags/go").cache)
+	assert.Equal(t, "HIT", get(t, app, "/tags/rust").cache)
+
+	// Scope invalidation still reaches entries kept by subject
+	invalidate(context.Background(), store, ScopePosts)
+	assert.Equal(t, "MISS", get(t, app, "/tags/rust").cache)
+	assert.Equal(t, 4, calls)
+}
@@ -0,0 +1,78 @@
+package ratelimit
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// headerRateLimitPolicy names the policy the other X-RateLimit headers describe, so
+// clients can look it up in the policy endpoint
+const headerRateLimitPolicy = "X-RateLimit-Policy"
+
+// Scopes of a policy's counts
+const (
+	// ScopeRoute counts per route and per identity
+	ScopeRoute = "route"
+	// ScopeUser counts every authenticated request of a user together
+	ScopeUser = "user"
+)
+
+// Policy describes one limit the API applies, as served by PolicyHandler
+type Policy struct {
+	Name          string `json:"name"`
+	Limit         int    `json:"limit"`
+	WindowSeconds int    `json:"windowSeconds"`
+	Scope         string `json:"scope"`
+	// Enforced policies reject requests over the limit with 429; soft policies only
+	// report the budget in headers so clients can back off
+	Enforced bool `json:"enforced"`
+}
+
+var (
+	policiesMu sync.RWMutex
+	policies   = make(map[string]Policy)
+)
+
+// registerPolicy records a limiter's policy; limiters sharing a name share a policy
+func registerPolicy(name string, limit int, window time.Duration, scope string, enforced bool) {
+	policiesMu.Lock()
+	defer policiesMu.Unlock()
+	policies[name] = Policy{
+		Name:          name,
+		Limit:         limit,
+		WindowSeconds: int(window.Seconds()),
+		Scope:         scope,
+		Enforced:      enforced,
+	}
+}
+
+// Policies returns the policies of the limiters created in this process, by name
+func Policies() []Policy {
+	policiesMu.RLock()
+	defer policiesMu.RUnlock()
+	out := make([]Policy, 0, len(policies))
+	for _, policy := range policies {
+		out = append(out, policy)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// PolicyHandler serves the rate limit policies as JSON. It reads them per request, so
+// limiters created after the handler are listed too.
+func PolicyHandler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{
+			"policies": Policies(),
+			"headers": fiber.Map{
+				"limit":     headerRateLimitLimit,
+				"remaining": headerRateLimitRemaining,
+				"reset":     headerRateLimitReset,
+				"policy":    headerRateLimitPolicy,
+			},
+		})
+	}
+}
@@ -4,11 +4,22 @@ package ratelimit
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/limiter"
+	"github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+// Headers sent with requests a limiter let through, matching Fiber's limiter
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
 )
 
 // EndpointLimits defines rate limiting configuration for specific endpoints
@@ -187,6 +198,12 @@ func New(config Config) fiber.Handler {
 // NewWithConfig creates a rate limiter with explicit configuration.
 // This is the recommended generic function for configuring rate limits.
 //
+// Requests are counted per route and per identity: the authenticated user when an
+// auth middleware ran before the limiter, otherwise the client IP. Counts live in the
+// store set with SetStore (Redis in multi-instance deployments) or, without one, in
+// memory owned by the limiter. Rejected requests get 429 with a Retry-After header.
+// The limit is listed by PolicyHandler under endpointName.
+//
 // Parameters:
 //   - enabled: Whether rate limiting is enabled
 //   - max: Maximum number of requests allowed
@@ -204,22 +221,44 @@ func NewWithConfig(enabled bool, max int, duration time.Duration, endpointName s
 		}
 	}
 
-	return limiter.New(limiter.Config{
-		Max:        max,
-		Expiration: duration,
-		KeyGenerator: func(c *fiber.Ctx) string {
-			return c.IP() + ":" + c.Path()
-		},
-		LimitReached: func(c *fiber.Ctx) error {
+	registerPolicy(endpointName, max, duration, ScopeRoute, true)
+	store := storeForLimiter()
+	return func(c *fiber.Ctx) error {
+		key := endpointName + ":" + c.Route().Path + ":" + identity(c)
+		hits, resetIn, err := store.Hit(c.UserContext(), key, duration)
+		if err != nil {
+			// Failing open keeps the API up while the shared store is unreachable
+			log.Warn("[RateLimit] Counting %s failed, allowing request: %v", endpointName, err)
+			return c.Next()
+		}
+
+		retryAfter := int(math.Ceil(resetIn.Seconds()))
+		remaining := max - hits
+		if remaining < 0 {
 			log.Warn("[RateLimit] Rate limit exceeded for %s from IP: %s", endpointName, c.IP())
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
 			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
-				"error":     "Rate limit exceeded",
-				"code":      "RATE_LIMIT_EXCEEDED",
-				"message":   fmt.Sprintf("Too many %s attempts. Please try again later.", endpointName),
-				"retryAfter": int(duration.Seconds()),
+				"error":      "Rate limit exceeded",
+				"code":       "RATE_LIMIT_EXCEEDED",
+				"message":    fmt.Sprintf("Too many %s attempts. Please try again later.", endpointName),
+				"retryAfter": retryAfter,
 			})
-		},
-	})
+		}
+
+		c.Set(headerRateLimitLimit, strconv.Itoa(max))
+		c.Set(headerRateLimitRemaining, strconv.Itoa(remaining))
+		c.Set(headerRateLimitReset, strconv.Itoa(retryAfter))
+		c.Set(headerRateLimitPolicy, endpointName)
+		return c.Next()
+	}
+}
+
+// identity names who a request counts against: the authenticated user, else the IP
+func identity(c *fiber.Ctx) string {
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok && user.UserID != uuid.Nil {
+		return "user:" + user.UserID.String()
+	}
+	return "ip:" + c.IP()
 }
 
 // NewConditional creates a rate limiter with conditional enabling and full config control.
@@ -4,12 +4,15 @@ package ratelimit
 
 import (
 	"io"
+	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/qolzam/telar/apps/api/internal/types"
@@ -355,3 +358,68 @@ func TestRateLimit_Performance_MinimalOverhead(t *testing.T) {
 	assert.Greater(t, successCount, 0)
 	assert.Less(t, elapsed, 100*time.Millisecond, "Rate limiting should not add significant overhead")
 }
+
+func TestRateLimit_NewWithConfig_RetryAfterAndIdentity(t *testing.T) {
+	limiter := NewWithConfig(true, 2, time.Minute, "post creation")
+	app := fiber.New()
+	app.Post("/posts", func(c *fiber.Ctx) error {
+		if userID := c.Get("X-Test-User"); userID != "" {
+			c.Locals(types.UserCtxName, types.UserContext{UserID: uuid.FromStringOrNil(userID)})
+		}
+		return c.Next()
+	}, limiter, func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusCreated)
+	})
+
+	post := func(userID string) *http.Response {
+		req := httptest.NewRequest("POST", "/posts", nil)
+		if userID != "" {
+			req.Header.Set("X-Test-User", userID)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	alice := uuid.Must(uuid.NewV4()).String()
+	bob := uuid.Must(uuid.NewV4()).String()
+	first := post(alice)
+	assert.Equal(t, fiber.StatusCreated, first.StatusCode)
+	assert.Equal(t, "2", first.Header.Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, fiber.StatusCreated, post(alice).StatusCode)
+
+	limited := post(alice)
+	assert.Equal(t, fiber.StatusTooManyRequests, limited.StatusCode)
+	retryAfter, err := strconv.Atoi(limited.Header.Get(fiber.HeaderRetryAfter))
+	require.NoError(t, err)
+	assert.InDelta(t, 60, retryAfter, 1)
+
+	// Other users and anonymous callers from the same IP have buckets of their own
+	assert.Equal(t, fiber.StatusCreated, post(bob).StatusCode)
+	assert.Equal(t, fiber.StatusCreated, post("").StatusCode)
+}
+
+func TestRateLimit_NewWithConfig_SharedStore(t *testing.T) {
+	SetStore(NewMemoryStore())
+	defer SetStore(nil)
+
+	// Two instances of the same route count in one bucket once the store is shared
+	newInstance := func() *fiber.App {
+		app := fiber.New()
+		app.Post("/auth/login", NewWithConfig(true, 1, time.Minute, "login"), func(c *fiber.Ctx) error {
+			return c.SendStatus(fiber.StatusOK)
+		})
+		return app
+	}
+	first, second := newInstance(), newInstance()
+
+	resp, err := first.Test(httptest.NewRequest("POST", "/auth/login", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, resp.StatusCode)
+
+	resp, err = second.Test(httptest.NewRequest("POST", "/auth/login", nil))
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusTooManyRequests, resp.StatusCode)
+}
@@ -0,0 +1,47 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// hitScript increments a window and starts its expiry on the first hit, atomically,
+// so instances racing on the same key agree on the count and the reset time
+var hitScript = redis.NewScript(`
+local hits = redis.call('INCR', KEYS[1])
+if hits == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl < 0 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+return {hits, ttl}
+`)
+
+// RedisStore keeps windows in Redis, shared by every instance using the same prefix
+type RedisStore struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+// NewRedisStore creates a store whose keys are prefix followed by the limiter's key
+func NewRedisStore(client redis.UniversalClient, prefix string) *RedisStore {
+	return &RedisStore{client: client, prefix: prefix}
+}
+
+// Hit implements Store
+func (s *RedisStore) Hit(ctx context.Context, key string, window time.Duration) (int, time.Duration, error) {
+	result, err := hitScript.Run(ctx, s.client, []string{s.prefix + key}, window.Milliseconds()).Int64Slice()
+	if err != nil {
+		return 0, 0, fmt.Errorf("rate limit hit: %w", err)
+	}
+	if len(result) != 2 {
+		return 0, 0, fmt.Errorf("rate limit hit: unexpected reply %v", result)
+	}
+	return int(result[0]), time.Duration(result[1]) * time.Millisecond, nil
+}
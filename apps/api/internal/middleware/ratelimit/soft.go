@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/types"
+)
+
+// SoftPolicyName is the policy of the soft limit on authenticated requests
+const SoftPolicyName = "api"
+
+// NewSoft counts every authenticated request against a per-user budget of limit
+// requests per duration and reports it in the X-RateLimit headers, without rejecting
+// requests over the budget. Clients use the headers to back off before an enforced
+// limit rejects them.
+//
+// Add it to the app ahead of the modules: it runs after the route's handlers, once an
+// auth middleware has identified the user. Responses of routes whose own limiter set
+// the headers or rejected the request are left alone, since that limiter is the one
+// that rejects.
+func NewSoft(enabled bool, limit int, duration time.Duration) fiber.Handler {
+	if !enabled {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	registerPolicy(SoftPolicyName, limit, duration, ScopeUser, false)
+	store := storeForLimiter()
+	return func(c *fiber.Ctx) error {
+		err := c.Next()
+
+		user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+		if !ok || user.UserID == uuid.Nil {
+			return err
+		}
+		if len(c.Response().Header.Peek(headerRateLimitLimit)) > 0 || len(c.Response().Header.Peek(fiber.HeaderRetryAfter)) > 0 {
+			return err
+		}
+		hits, resetIn, hitErr := store.Hit(c.UserContext(), SoftPolicyName+":user:"+user.UserID.String(), duration)
+		if hitErr != nil {
+			log.Warn("[RateLimit] Counting the soft limit failed: %v", hitErr)
+			return err
+		}
+
+		c.Set(headerRateLimitLimit, strconv.Itoa(limit))
+		c.Set(headerRateLimitRemaining, strconv.Itoa(max(limit-hits, 0)))
+		c.Set(headerRateLimitReset, strconv.Itoa(int(math.Ceil(resetIn.Seconds()))))
+		c.Set(headerRateLimitPolicy, SoftPolicyName)
+		return err
+	}
+}
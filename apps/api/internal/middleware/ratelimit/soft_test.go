@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSoft(t *testing.T) {
+	app := fiber.New()
+	app.Use(NewSoft(true, 2, time.Minute))
+	authenticate := func(c *fiber.Ctx) error {
+		if userID := c.Get("X-Test-User"); userID != "" {
+			c.Locals(types.UserCtxName, types.UserContext{UserID: uuid.FromStringOrNil(userID)})
+		}
+		return c.Next()
+	}
+	ok := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/feed", authenticate, ok)
+	app.Post("/posts", authenticate, NewWithConfig(true, 5, time.Hour, "soft test posts"), ok)
+
+	request := func(method, path, userID string) *http.Response {
+		req := httptest.NewRequest(method, path, nil)
+		if userID != "" {
+			req.Header.Set("X-Test-User", userID)
+		}
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	alice := uuid.Must(uuid.NewV4()).String()
+	first := request("GET", "/feed", alice)
+	assert.Equal(t, "2", first.Header.Get("X-RateLimit-Limit"))
+	assert.Equal(t, "1", first.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, "60", first.Header.Get("X-RateLimit-Reset"))
+	assert.Equal(t, SoftPolicyName, first.Header.Get("X-RateLimit-Policy"))
+
+	// Over the budget requests still succeed; the headers tell the client to back off
+	request("GET", "/feed", alice)
+	over := request("GET", "/feed", alice)
+	assert.Equal(t, fiber.StatusOK, over.StatusCode)
+	assert.Equal(t, "0", over.Header.Get("X-RateLimit-Remaining"))
+
+	// Anonymous requests are not counted
+	assert.Empty(t, request("GET", "/feed", "").Header.Get("X-RateLimit-Limit"))
+
+	// A route's own limiter reports its budget instead
+	posted := request("POST", "/posts", alice)
+	assert.Equal(t, "5", posted.Header.Get("X-RateLimit-Limit"))
+	assert.Equal(t, "soft test posts", posted.Header.Get("X-RateLimit-Policy"))
+}
+
+func TestPolicyHandler(t *testing.T) {
+	NewSoft(true, 600, time.Minute)
+	NewWithConfig(true, 30, time.Hour, "policy test")
+	NewWithConfig(false, 1, time.Hour, "policy test disabled")
+
+	app := fiber.New()
+	app.Get("/rate-limits", PolicyHandler())
+	resp, err := app.Test(httptest.NewRequest("GET", "/rate-limits", nil))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var body struct {
+		Policies []Policy          `json:"policies"`
+		Headers  map[string]string `json:"headers"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	byName := make(map[string]Policy)
+	for _, policy := range body.Policies {
+		byName[policy.Name] = policy
+	}
+	assert.Equal(t, Policy{Name: "policy test", Limit: 30, WindowSeconds: 3600, Scope: ScopeRoute, Enforced: true}, byName["policy test"])
+	assert.Equal(t, Policy{Name: SoftPolicyName, Limit: 600, WindowSeconds: 60, Scope: ScopeUser}, byName[SoftPolicyName])
+	assert.NotContains(t, byName, "policy test disabled")
+	assert.Equal(t, "X-RateLimit-Remaining", body.Headers["remaining"])
+}
@@ -0,0 +1,87 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Store counts hits in fixed windows. A window starts with the first hit on a key
+// and every hit until it ends shares its count.
+type Store interface {
+	// Hit records one hit on key and returns the hits in the current window and the
+	// time left until it resets
+	Hit(ctx context.Context, key string, window time.Duration) (int, time.Duration, error)
+}
+
+// shared is the store limiters created from now on count in; nil gives each limiter
+// a memory store of its own
+var shared atomic.Pointer[Store]
+
+// SetStore makes limiters created after the call count in store, so instances of a
+// multi-instance deployment share their buckets. nil restores per-limiter memory.
+func SetStore(store Store) {
+	if store == nil {
+		shared.Store(nil)
+		return
+	}
+	shared.Store(&store)
+}
+
+// storeForLimiter returns the shared store, or a fresh memory store without one
+func storeForLimiter() Store {
+	if store := shared.Load(); store != nil {
+		return *store
+	}
+	return NewMemoryStore()
+}
+
+// memoryWindow is the count of one key in its current window
+type memoryWindow struct {
+	hits    int
+	resetAt time.Time
+}
+
+// MemoryStore keeps windows in process memory; counts are per instance
+type MemoryStore struct {
+	mu        sync.Mutex
+	windows   map[string]*memoryWindow
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewMemoryStore creates an empty in-process store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{windows: make(map[string]*memoryWindow), now: time.Now}
+}
+
+// Hit implements Store
+func (s *MemoryStore) Hit(_ context.Context, key string, window time.Duration) (int, time.Duration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now, window)
+	w, ok := s.windows[key]
+	if !ok || !now.Before(w.resetAt) {
+		w = &memoryWindow{resetAt: now.Add(window)}
+		s.windows[key] = w
+	}
+	w.hits++
+	return w.hits, w.resetAt.Sub(now), nil
+}
+
+// sweep drops ended windows at most once per window length, so keys of clients that
+// went away do not pile up
+func (s *MemoryStore) sweep(now time.Time, window time.Duration) {
+	if now.Sub(s.lastSweep) < window {
+		return
+	}
+	s.lastSweep = now
+	for key, w := range s.windows {
+		if !now.Before(w.resetAt) {
+			delete(s.windows, key)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_FixedWindows(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	hits, resetIn, err := store.Hit(ctx, "login:ip:1", time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, 1, hits)
+	assert.Equal(t, time.Minute, resetIn)
+
+	now = now.Add(20 * time.Second)
+	hits, resetIn, _ = store.Hit(ctx, "login:ip:1", time.Minute)
+	assert.Equal(t, 2, hits)
+	assert.Equal(t, 40*time.Second, resetIn)
+
+	hits, _, _ = store.Hit(ctx, "login:ip:2", time.Minute)
+	assert.Equal(t, 1, hits, "keys count separately")
+
+	now = now.Add(40 * time.Second)
+	hits, resetIn, _ = store.Hit(ctx, "login:ip:1", time.Minute)
+	assert.Equal(t, 1, hits, "a new window starts once the old one ends")
+	assert.Equal(t, time.Minute, resetIn)
+}
+
+func TestMemoryStore_SweepsEndedWindows(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	store := NewMemoryStore()
+	store.now = func() time.Time { return now }
+
+	_, _, _ = store.Hit(ctx, "a", time.Minute)
+	_, _, _ = store.Hit(ctx, "b", time.Minute)
+	now = now.Add(2 * time.Minute)
+	_, _, _ = store.Hit(ctx, "c", time.Minute)
+
+	assert.Len(t, store.windows, 1)
+}
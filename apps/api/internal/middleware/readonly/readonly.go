@@ -0,0 +1,179 @@
+// Package readonly puts the API into a degraded read-only mode: mutating requests are
+// answered with 503 while reads keep working. The mode is process-wide and can be
+// switched on by several sources at once (the environment, the startup schema check,
+// the admin setting); it stays on while any of them holds it.
+package readonly
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+// CodeReadOnly is the error code returned for rejected mutating requests
+const CodeReadOnly = "READ_ONLY_MODE"
+
+// Sources that can switch read-only mode on, in the order their reason is reported
+const (
+	SourceConfig   = "config"   // READ_ONLY_MODE in the environment
+	SourceSchema   = "schema"   // Startup schema check with the readonly policy
+	SourceSettings = "settings" // Admin toggle stored in the settings module
+)
+
+var sourceOrder = []string{SourceConfig, SourceSchema, SourceSettings}
+
+// TogglePath is the admin endpoint that switches the settings source. It stays
+// writable in read-only mode, as do the login endpoints admins need to reach it.
+const TogglePath = "/read-only"
+
+// defaultExemptPaths are accepted even while read-only mode is on. Users with MFA
+// finish signing in at /auth/login/mfa, so it is exempt with the login itself.
+var defaultExemptPaths = []string{TogglePath, "/auth/login", "/auth/login/mfa", "/auth/admin/login"}
+
+// defaultReason is reported when a source gives none
+const defaultReason = "Maintenance in progress"
+
+var (
+	mu      sync.RWMutex
+	reasons = map[string]string{}
+)
+
+// Config holds the configuration for the read-only middleware
+type Config struct {
+	// RetryAfter is sent as the Retry-After header of rejected requests; zero omits it
+	RetryAfter time.Duration
+
+	// ExemptPaths accept writes even in read-only mode; defaults to TogglePath and login
+	ExemptPaths []string
+}
+
+// Enable switches read-only mode on for source; reason is reported to clients
+func Enable(source, why string) {
+	if why == "" {
+		why = defaultReason
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	reasons[source] = why
+}
+
+// Disable releases read-only mode for source. The mode stays on while another source holds it.
+func Disable(source string) {
+	mu.Lock()
+	defer mu.Unlock()
+	delete(reasons, source)
+}
+
+// Status reports whether read-only mode is on and the reason of the highest-priority source
+func Status() (bool, string) {
+	mu.RLock()
+	defer mu.RUnlock()
+	for _, source := range sourceOrder {
+		if why, ok := reasons[source]; ok {
+			return true, why
+		}
+	}
+	for _, why := range reasons {
+		return true, why
+	}
+	return false, ""
+}
+
+// Configure applies the environment switch. Call it once at startup.
+func Configure(cfg platformconfig.ReadOnlyConfig) {
+	if cfg.Enabled {
+		log.Warn("READ_ONLY_MODE is set; rejecting writes until it is removed")
+		Enable(SourceConfig, cfg.Reason)
+		return
+	}
+	Disable(SourceConfig)
+}
+
+// Watch keeps the settings source in sync with load, which returns the admin setting.
+// It loads once before returning so the first requests see the stored state, then
+// polls every interval until ctx is done. Failed loads keep the last known state.
+func Watch(ctx context.Context, interval time.Duration, load func(ctx context.Context) (bool, string, error)) {
+	apply := func() {
+		on, why, err := load(ctx)
+		if err != nil {
+			log.Warn("Failed to load read-only setting: %v", err)
+			return
+		}
+		if on {
+			Enable(SourceSettings, why)
+		} else {
+			Disable(SourceSettings)
+		}
+	}
+
+	apply()
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				apply()
+			}
+		}
+	}()
+}
+
+// NewFromConfig creates the middleware from platform config
+func NewFromConfig(cfg platformconfig.ReadOnlyConfig) fiber.Handler {
+	return New(Config{RetryAfter: cfg.RetryAfter})
+}
+
+// New creates a middleware that rejects mutating requests while read-only mode is on
+func New(cfg Config) fiber.Handler {
+	exempt := cfg.ExemptPaths
+	if exempt == nil {
+		exempt = defaultExemptPaths
+	}
+	retryAfter := ""
+	if cfg.RetryAfter > 0 {
+		retryAfter = strconv.Itoa(int(cfg.RetryAfter.Seconds()))
+	}
+
+	return func(c *fiber.Ctx) error {
+		on, why := Status()
+		if !on || isSafeMethod(c.Method()) || isExempt(exempt, c.Path()) {
+			return c.Next()
+		}
+		if retryAfter != "" {
+			c.Set(fiber.HeaderRetryAfter, retryAfter)
+		}
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"code":    CodeReadOnly,
+			"message": "The service is temporarily read-only",
+			"details": why,
+		})
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case fiber.MethodGet, fiber.MethodHead, fiber.MethodOptions:
+		return true
+	}
+	return false
+}
+
+func isExempt(paths []string, path string) bool {
+	for _, p := range paths {
+		if path == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,94 @@
+package readonly
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadOnlyMiddleware(t *testing.T) {
+	app := fiber.New()
+	app.Use(New(Config{RetryAfter: time.Minute}))
+	app.Get("/posts", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Post("/posts", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+	app.Put(TogglePath, func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Post("/auth/login/mfa", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	request := func(method, path string) (int, string) {
+		resp, err := app.Test(httptest.NewRequest(method, path, nil))
+		require.NoError(t, err)
+		return resp.StatusCode, resp.Header.Get(fiber.HeaderRetryAfter)
+	}
+
+	status, _ := request("POST", "/posts")
+	assert.Equal(t, fiber.StatusCreated, status)
+
+	Enable(SourceSchema, "schema mismatch")
+	t.Cleanup(func() { Disable(SourceSchema) })
+
+	status, _ = request("GET", "/posts")
+	assert.Equal(t, fiber.StatusOK, status)
+	status, retryAfter := request("POST", "/posts")
+	assert.Equal(t, fiber.StatusServiceUnavailable, status)
+	assert.Equal(t, "60", retryAfter)
+	status, _ = request("PUT", TogglePath)
+	assert.Equal(t, fiber.StatusOK, status, "the admin toggle stays writable")
+	status, _ = request("POST", "/auth/login/mfa")
+	assert.Equal(t, fiber.StatusOK, status, "users with MFA can still sign in")
+
+	Disable(SourceSchema)
+	status, _ = request("POST", "/posts")
+	assert.Equal(t, fiber.StatusCreated, status)
+}
+
+func TestStatus_StaysOnWhileAnySourceHoldsIt(t *testing.T) {
+	t.Cleanup(func() {
+		Disable(SourceConfig)
+		Disable(SourceSettings)
+	})
+
+	Enable(SourceSettings, "")
+	Enable(SourceConfig, "restore in progress")
+
+	on, why := Status()
+	assert.True(t, on)
+	assert.Equal(t, "restore in progress", why)
+
+	Disable(SourceConfig)
+	on, why = Status()
+	assert.True(t, on)
+	assert.Equal(t, defaultReason, why)
+
+	Disable(SourceSettings)
+	on, _ = Status()
+	assert.False(t, on)
+}
+
+func TestWatch_AppliesSettingAndKeepsStateOnError(t *testing.T) {
+	t.Cleanup(func() { Disable(SourceSettings) })
+
+	Watch(context.Background(), 0, func(context.Context) (bool, string, error) {
+		return true, "failover", nil
+	})
+	on, why := Status()
+	assert.True(t, on)
+	assert.Equal(t, "failover", why)
+
+	Watch(context.Background(), 0, func(context.Context) (bool, string, error) {
+		return false, "", errors.New("database unavailable")
+	})
+	on, _ = Status()
+	assert.True(t, on, "a failed load keeps the last known state")
+
+	Watch(context.Background(), 0, func(context.Context) (bool, string, error) {
+		return false, "", nil
+	})
+	on, _ = Status()
+	assert.False(t, on)
+}
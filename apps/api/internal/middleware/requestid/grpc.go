@@ -0,0 +1,46 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// MetadataRequestID is the gRPC metadata key carrying the request ID between services
+const MetadataRequestID = "x-request-id"
+
+// UnaryClientInterceptor forwards the request ID in ctx to the called service.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := log.RequestID(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, MetadataRequestID, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor puts the caller's request ID, or a new one, in the handler's
+// context and logs failed calls with it.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		requestID := ""
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(MetadataRequestID); len(values) > 0 && valid(values[0]) {
+				requestID = values[0]
+			}
+		}
+		if requestID == "" {
+			requestID = uuid.Must(uuid.NewV4()).String()
+		}
+		ctx = log.WithRequestID(ctx, requestID)
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			log.Logger(ctx).Warn("grpc call failed", "method", info.FullMethod, "error", err.Error())
+		}
+		return resp, err
+	}
+}
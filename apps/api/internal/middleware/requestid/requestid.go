@@ -1,43 +1,106 @@
 package requestid
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+	"unicode"
+
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 )
 
 const (
 	// HeaderRequestID is the HTTP header name for request ID
 	HeaderRequestID = "X-Request-ID"
 	// ContextKeyRequestID is the key used to store request ID in Fiber context
-	ContextKeyRequestID = "request_id"
+	ContextKeyRequestID = log.ContextKeyRequestID
+	// BodyFieldRequestID is the field carrying the request ID in JSON error bodies
+	BodyFieldRequestID = "requestId"
+
+	// MaxRequestIDLength bounds IDs accepted from callers
+	MaxRequestIDLength = 128
 )
 
-// New creates a middleware that generates or uses an existing X-Request-ID header
+// New creates a middleware that generates or uses an existing X-Request-ID header.
+// The ID is stored in the request's Locals and user context, so logs written with
+// c.Context() or c.UserContext() carry it; once the request finishes one access
+// record is logged with its status, latency and authenticated user. Errors are
+// answered here with the app's error handler, and JSON error bodies get the ID as
+// "requestId" so users can quote it to support.
 func New() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		// Check if request ID already exists in header
 		requestID := c.Get(HeaderRequestID)
-		
-		// If not present, generate a new UUID
-		if requestID == "" {
-			id, err := uuid.NewV4()
-			if err != nil {
-				// Fallback: generate another UUID (should never fail)
-				id, _ = uuid.NewV4()
-			}
-			requestID = id.String()
+
+		// Generate a new UUID when there is none, or the caller's cannot be logged safely
+		if !valid(requestID) {
+			requestID = uuid.Must(uuid.NewV4()).String()
 		}
-		
+
 		// Store in context for use by handlers and logger
 		c.Locals(ContextKeyRequestID, requestID)
-		
+		c.SetUserContext(log.WithRequestID(c.UserContext(), requestID))
+
 		// Set response header so client can track the request
 		c.Set(HeaderRequestID, requestID)
-		
-		return c.Next()
+
+		start := time.Now()
+		if err := c.Next(); err != nil {
+			if handlerErr := c.App().ErrorHandler(c, err); handlerErr != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		stampErrorBody(c, requestID)
+
+		log.Logger(c.Context()).Info("request",
+			"method", c.Method(),
+			"path", c.Path(),
+			"status", c.Response().StatusCode(),
+			"duration_ms", time.Since(start).Milliseconds())
+		return nil
 	}
 }
 
+// stampErrorBody adds "requestId" to a JSON object error body that has none
+func stampErrorBody(c *fiber.Ctx, requestID string) {
+	if c.Response().StatusCode() < fiber.StatusBadRequest {
+		return
+	}
+	if !strings.HasPrefix(string(c.Response().Header.ContentType()), fiber.MIMEApplicationJSON) {
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(c.Response().Body(), &fields); err != nil || fields == nil {
+		return
+	}
+	if _, ok := fields[BodyFieldRequestID]; ok {
+		return
+	}
+
+	// Splice the field in before the closing brace so the body keeps its field order
+	body := bytes.TrimRightFunc(c.Response().Body(), unicode.IsSpace)
+	id, _ := json.Marshal(requestID)
+	stamped := make([]byte, 0, len(body)+len(id)+16)
+	stamped = append(stamped, body[:len(body)-1]...)
+	if len(fields) > 0 {
+		stamped = append(stamped, ',')
+	}
+	stamped = append(stamped, `"`+BodyFieldRequestID+`":`...)
+	stamped = append(stamped, id...)
+	stamped = append(stamped, '}')
+	c.Response().SetBodyRaw(stamped)
+}
+
+// SetUserID records the authenticated user on the request so later log records carry it
+func SetUserID(c *fiber.Ctx, userID uuid.UUID) {
+	c.Locals(log.ContextKeyUserID, userID.String())
+	c.SetUserContext(log.WithUserID(c.UserContext(), userID.String()))
+}
+
 // GetRequestID retrieves the request ID from Fiber context
 func GetRequestID(c *fiber.Ctx) string {
 	if id, ok := c.Locals(ContextKeyRequestID).(string); ok {
@@ -46,3 +109,15 @@ func GetRequestID(c *fiber.Ctx) string {
 	return ""
 }
 
+// valid accepts short IDs of printable ASCII without spaces
+func valid(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package requestid
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestNew_PropagatesRequestAndUserIDs(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	app := fiber.New()
+	app.Use(New())
+	app.Get("/", func(c *fiber.Ctx) error {
+		SetUserID(c, userID)
+		// Services receive c.Context(); both contexts carry the IDs
+		assert.Equal(t, "req-123", log.RequestID(c.Context()))
+		assert.Equal(t, "req-123", log.RequestID(c.UserContext()))
+		assert.Equal(t, userID.String(), log.UserID(c.Context()))
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set(HeaderRequestID, "req-123")
+	resp, err := app.Test(req)
+	require.NoError(t, err)
+	assert.Equal(t, fiber.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "req-123", resp.Header.Get(HeaderRequestID))
+}
+
+func TestNew_ReplacesUnsafeIDs(t *testing.T) {
+	app := fiber.New()
+	app.Use(New())
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendString(GetRequestID(c)) })
+
+	for _, id := range []string{"", "has space", strings.Repeat("a", 129)} {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(HeaderRequestID, id)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		generated := resp.Header.Get(HeaderRequestID)
+		assert.NotEqual(t, id, generated)
+		_, err = uuid.FromString(generated)
+		assert.NoError(t, err)
+	}
+}
+
+func TestNew_AddsRequestIDToErrorBodies(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: func(c *fiber.Ctx, err error) error {
+		return c.Status(fiber.StatusTeapot).JSON(fiber.Map{"error": err.Error()})
+	}})
+	app.Use(New())
+	app.Get("/envelope", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"code": "NOT_FOUND", "message": "missing"})
+	})
+	app.Get("/returned", func(c *fiber.Ctx) error { return fiber.NewError(fiber.StatusBadRequest, "bad") })
+	app.Get("/ok", func(c *fiber.Ctx) error { return c.JSON(fiber.Map{"ok": true}) })
+	app.Get("/text", func(c *fiber.Ctx) error { return c.Status(fiber.StatusBadRequest).SendString("bad") })
+
+	get := func(path string) (int, string) {
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set(HeaderRequestID, `req-"7"`)
+		resp, err := app.Test(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, string(body)
+	}
+
+	status, body := get("/envelope")
+	assert.Equal(t, fiber.StatusNotFound, status)
+	assert.JSONEq(t, `{"code":"NOT_FOUND","message":"missing","requestId":"req-\"7\""}`, body)
+
+	status, body = get("/returned")
+	assert.Equal(t, fiber.StatusTeapot, status, "returned errors go through the app's error handler")
+	assert.JSONEq(t, `{"error":"bad","requestId":"req-\"7\""}`, body)
+
+	_, body = get("/ok")
+	assert.JSONEq(t, `{"ok":true}`, body)
+	_, body = get("/text")
+	assert.Equal(t, "bad", body)
+}
+
+func TestGRPCInterceptors_CarryRequestID(t *testing.T) {
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+	ctx := log.WithRequestID(context.Background(), "req-456")
+	require.NoError(t, UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil, invoker))
+	assert.Equal(t, []string{"req-456"}, outgoing.Get(MetadataRequestID))
+
+	var handled string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = log.RequestID(ctx)
+		return nil, nil
+	}
+	incoming := metadata.NewIncomingContext(context.Background(), outgoing)
+	_, err := UnaryServerInterceptor()(incoming, nil, &grpc.UnaryServerInfo{FullMethod: "/svc/Method"}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, "req-456", handled)
+}
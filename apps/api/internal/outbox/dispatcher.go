@@ -0,0 +1,130 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+const (
+	// staleAfter is how long a claim may last before another dispatcher publishes the events
+	staleAfter = time.Minute
+
+	// purgeInterval is how often published events past their retention are deleted
+	purgeInterval = time.Hour
+)
+
+// Config tunes the dispatcher
+type Config struct {
+	BatchSize    int           // Events published per poll
+	PollInterval time.Duration // How often to look for events
+	Retention    time.Duration // How long published events are kept; 0 keeps them
+}
+
+// ConfigFromPlatform derives the dispatcher configuration from the outbox configuration
+func ConfigFromPlatform(cfg *platformconfig.OutboxConfig) Config {
+	return Config{
+		BatchSize:    cfg.BatchSize,
+		PollInterval: cfg.PollInterval,
+		Retention:    cfg.Retention,
+	}
+}
+
+// Dispatcher publishes outbox events to the broker. Dispatchers claim events with SKIP
+// LOCKED, so every process may run one.
+type Dispatcher struct {
+	repo      Repository
+	broker    Broker
+	cfg       Config
+	lastPurge time.Time
+}
+
+// NewDispatcher creates a dispatcher; call Run or Start to begin publishing
+func NewDispatcher(repo Repository, broker Broker, cfg Config) *Dispatcher {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	return &Dispatcher{repo: repo, broker: broker, cfg: cfg}
+}
+
+// Start runs the dispatcher in the background until ctx is done
+func (d *Dispatcher) Start(ctx context.Context) {
+	go d.Run(ctx)
+}
+
+// Run publishes events until ctx is done, polling every PollInterval
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		// Drain the backlog before waiting again
+		for ctx.Err() == nil {
+			claimed, err := d.DispatchPending(ctx)
+			if err != nil {
+				log.Error("Outbox dispatch failed: %v", err)
+				break
+			}
+			if claimed < d.cfg.BatchSize {
+				break
+			}
+		}
+		d.purge(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchPending publishes one batch of events and returns how many it claimed. Events
+// the broker did not accept go back to the queue for the next poll.
+func (d *Dispatcher) DispatchPending(ctx context.Context) (int, error) {
+	msgs, err := d.repo.Claim(ctx, d.cfg.BatchSize, staleAfter)
+	if err != nil {
+		return 0, err
+	}
+	if len(msgs) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]uuid.UUID, len(msgs))
+	for i, msg := range msgs {
+		ids[i] = msg.ID
+	}
+	if err := d.broker.Publish(ctx, msgs); err != nil {
+		if releaseErr := d.repo.Release(ctx, ids, err); releaseErr != nil {
+			log.Error("Failed to release outbox events: %v", releaseErr)
+		}
+		return len(msgs), err
+	}
+	if err := d.repo.MarkPublished(ctx, ids); err != nil {
+		// The claim goes stale and the events are published again; consumers skip them
+		return len(msgs), err
+	}
+	return len(msgs), nil
+}
+
+// purge deletes published events past their retention, at most once per purgeInterval
+func (d *Dispatcher) purge(ctx context.Context) {
+	if d.cfg.Retention <= 0 || time.Since(d.lastPurge) < purgeInterval || ctx.Err() != nil {
+		return
+	}
+	d.lastPurge = time.Now()
+	deleted, err := d.repo.Purge(ctx, time.Now().Add(-d.cfg.Retention))
+	if err != nil {
+		log.Error("Failed to purge outbox events: %v", err)
+		return
+	}
+	if deleted > 0 {
+		log.Info("Purged %d published outbox events", deleted)
+	}
+}
@@ -0,0 +1,166 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository keeps outbox events in memory
+type memoryRepository struct {
+	pending   []Message
+	claimed   map[uuid.UUID]Message
+	published []uuid.UUID
+	released  []uuid.UUID
+	consumed  map[string]bool
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{claimed: make(map[uuid.UUID]Message), consumed: make(map[string]bool)}
+}
+
+func (r *memoryRepository) Append(ctx context.Context, eventType string, key string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	r.pending = append(r.pending, Message{ID: uuid.Must(uuid.NewV4()), Type: eventType, Key: key, Data: payload, CreatedAt: time.Now()})
+	return nil
+}
+
+func (r *memoryRepository) Claim(ctx context.Context, limit int, staleAfter time.Duration) ([]Message, error) {
+	if limit > len(r.pending) {
+		limit = len(r.pending)
+	}
+	msgs := r.pending[:limit]
+	r.pending = r.pending[limit:]
+	for _, msg := range msgs {
+		r.claimed[msg.ID] = msg
+	}
+	return msgs, nil
+}
+
+func (r *memoryRepository) MarkPublished(ctx context.Context, ids []uuid.UUID) error {
+	for _, id := range ids {
+		delete(r.claimed, id)
+	}
+	r.published = append(r.published, ids...)
+	return nil
+}
+
+func (r *memoryRepository) Release(ctx context.Context, ids []uuid.UUID, cause error) error {
+	for _, id := range ids {
+		r.pending = append(r.pending, r.claimed[id])
+		delete(r.claimed, id)
+	}
+	r.released = append(r.released, ids...)
+	return nil
+}
+
+func (r *memoryRepository) Once(ctx context.Context, consumer string, eventID uuid.UUID, fn func(txCtx context.Context) error) error {
+	receipt := consumer + "/" + eventID.String()
+	if r.consumed[receipt] {
+		return nil
+	}
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	r.consumed[receipt] = true
+	return nil
+}
+
+func (r *memoryRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *memoryRepository) Bounds(ctx context.Context, settle time.Duration) (int64, int64, error) {
+	return 0, 0, nil
+}
+
+func (r *memoryRepository) Since(ctx context.Context, after, upTo int64, eventTypes []string, userID uuid.UUID, limit int) ([]LogEntry, error) {
+	return nil, nil
+}
+
+func TestDispatchPending_PublishesAndMarksEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	broker := NewLocalBroker()
+
+	var received []string
+	require.NoError(t, Subscribe(ctx, broker, repo, "test", []string{"thing.created"}, func(ctx context.Context, msg Message) error {
+		var data map[string]string
+		require.NoError(t, msg.Decode(&data))
+		received = append(received, data["name"])
+		return nil
+	}))
+	require.NoError(t, repo.Append(ctx, "thing.created", "k", map[string]string{"name": "a"}))
+	require.NoError(t, repo.Append(ctx, "thing.deleted", "k", map[string]string{"name": "b"}))
+	require.NoError(t, repo.Append(ctx, "thing.created", "k", map[string]string{"name": "c"}))
+
+	claimed, err := NewDispatcher(repo, broker, Config{BatchSize: 10}).DispatchPending(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, claimed)
+	assert.Equal(t, []string{"a", "c"}, received)
+	assert.Len(t, repo.published, 3)
+	assert.Empty(t, repo.pending)
+}
+
+func TestDispatchPending_ReleasesEventsOnFailure(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	broker := NewLocalBroker()
+
+	fail := true
+	calls := 0
+	require.NoError(t, Subscribe(ctx, broker, repo, "test", []string{"thing.created"}, func(ctx context.Context, msg Message) error {
+		calls++
+		if fail {
+			return errors.New("handler failed")
+		}
+		return nil
+	}))
+	require.NoError(t, repo.Append(ctx, "thing.created", "k", nil))
+	dispatcher := NewDispatcher(repo, broker, Config{BatchSize: 10})
+
+	_, err := dispatcher.DispatchPending(ctx)
+	assert.Error(t, err)
+	assert.Len(t, repo.released, 1)
+	assert.Empty(t, repo.published)
+	assert.Len(t, repo.pending, 1)
+
+	fail = false
+	_, err = dispatcher.DispatchPending(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, repo.published, 1)
+	assert.Equal(t, 2, calls)
+}
+
+func TestSubscribe_HandlesRedeliveredEventsOnce(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	broker := NewLocalBroker()
+
+	calls := 0
+	require.NoError(t, Subscribe(ctx, broker, repo, "test", []string{"thing.created"}, func(ctx context.Context, msg Message) error {
+		calls++
+		return nil
+	}))
+	msg := Message{ID: uuid.Must(uuid.NewV4()), Type: "thing.created"}
+
+	require.NoError(t, broker.Publish(ctx, []Message{msg}))
+	require.NoError(t, broker.Publish(ctx, []Message{msg}))
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestTopicName(t *testing.T) {
+	assert.Equal(t, "telar.comment.created", topicName("telar.", "comment.created"))
+	assert.Equal(t, "comment.created", topicName("", "comment.created"))
+}
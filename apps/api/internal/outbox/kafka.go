@@ -0,0 +1,277 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+const (
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	kafkaAccept      = "application/vnd.kafka.v2+json"
+
+	// kafkaPollTimeout is how long the REST Proxy waits for records on a poll
+	kafkaPollTimeout = 5 * time.Second
+
+	// kafkaTimeout bounds the other REST Proxy calls
+	kafkaTimeout = 10 * time.Second
+)
+
+// KafkaBroker publishes events to Kafka topics and consumes them through the Confluent
+// REST Proxy (v2 API), so no Kafka client library is needed. Each event type is a topic;
+// events are keyed by their Key, so events about one post land on one partition.
+//
+// Consumers commit offsets only after the handler succeeded. A failed event makes the
+// consumer seek back to it and retry after a pause, holding back the later events of
+// its partition.
+type KafkaBroker struct {
+	baseURL string
+	prefix  string
+	client  *http.Client
+}
+
+// NewKafkaBroker creates a broker talking to the REST Proxy at restURL. client may be
+// nil for a default client.
+func NewKafkaBroker(restURL, prefix string, client *http.Client) *KafkaBroker {
+	if client == nil {
+		client = &http.Client{Timeout: kafkaPollTimeout + kafkaTimeout}
+	}
+	return &KafkaBroker{baseURL: strings.TrimRight(restURL, "/"), prefix: prefix, client: client}
+}
+
+type kafkaRecord struct {
+	Key   string  `json:"key,omitempty"`
+	Value Message `json:"value"`
+}
+
+// Publish produces msgs, one request per topic, and fails if any record was rejected
+func (b *KafkaBroker) Publish(ctx context.Context, msgs []Message) error {
+	var topics []string
+	byTopic := make(map[string][]kafkaRecord)
+	for _, msg := range msgs {
+		topic := topicName(b.prefix, msg.Type)
+		if _, ok := byTopic[topic]; !ok {
+			topics = append(topics, topic)
+		}
+		byTopic[topic] = append(byTopic[topic], kafkaRecord{Key: msg.Key, Value: msg})
+	}
+
+	for _, topic := range topics {
+		var result struct {
+			Offsets []struct {
+				ErrorCode *int   `json:"error_code"`
+				Error     string `json:"error"`
+			} `json:"offsets"`
+		}
+		body := map[string]interface{}{"records": byTopic[topic]}
+		if err := b.call(ctx, http.MethodPost, b.baseURL+"/topics/"+url.PathEscape(topic), body, &result); err != nil {
+			return fmt.Errorf("kafka produce to %s: %w", topic, err)
+		}
+		for _, offset := range result.Offsets {
+			if offset.ErrorCode != nil {
+				return fmt.Errorf("kafka produce to %s: %s (%d)", topic, offset.Error, *offset.ErrorCode)
+			}
+		}
+	}
+	return nil
+}
+
+// Subscribe creates a consumer instance in the group, subscribes it to the event
+// topics and polls in the background until ctx is done
+func (b *KafkaBroker) Subscribe(ctx context.Context, group string, eventTypes []string, handler Handler) error {
+	topics := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		topics[i] = topicName(b.prefix, eventType)
+	}
+	instance, err := b.createInstance(ctx, group, topics)
+	if err != nil {
+		return err
+	}
+	go b.consume(ctx, group, topics, instance, handler)
+	return nil
+}
+
+// Close is a no-op; consumer instances are deleted when their subscription ends
+func (b *KafkaBroker) Close() error {
+	return nil
+}
+
+// createInstance creates a consumer instance committing offsets manually and
+// subscribes it to topics. It returns the instance's base URI.
+func (b *KafkaBroker) createInstance(ctx context.Context, group string, topics []string) (string, error) {
+	var created struct {
+		BaseURI string `json:"base_uri"`
+	}
+	err := b.call(ctx, http.MethodPost, b.baseURL+"/consumers/"+url.PathEscape(group), map[string]interface{}{
+		"name":               group + "-" + randomToken(),
+		"format":             "json",
+		"auto.offset.reset":  "earliest",
+		"auto.commit.enable": "false",
+	}, &created)
+	if err != nil {
+		return "", fmt.Errorf("kafka create consumer in %s: %w", group, err)
+	}
+	if err := b.call(ctx, http.MethodPost, created.BaseURI+"/subscription", map[string]interface{}{"topics": topics}, nil); err != nil {
+		b.deleteInstance(created.BaseURI)
+		return "", fmt.Errorf("kafka subscribe %s: %w", group, err)
+	}
+	return created.BaseURI, nil
+}
+
+func (b *KafkaBroker) deleteInstance(instance string) {
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaTimeout)
+	defer cancel()
+	if err := b.call(ctx, http.MethodDelete, instance, nil, nil); err != nil {
+		log.Warn("Failed to delete Kafka consumer instance %s: %v", instance, err)
+	}
+}
+
+// consume polls until ctx is done. The REST Proxy drops idle instances, so a failing
+// poll recreates the instance.
+func (b *KafkaBroker) consume(ctx context.Context, group string, topics []string, instance string, handler Handler) {
+	defer func() {
+		if instance != "" {
+			b.deleteInstance(instance)
+		}
+	}()
+	for ctx.Err() == nil {
+		var err error
+		if instance == "" {
+			instance, err = b.createInstance(ctx, group, topics)
+		}
+		if err == nil {
+			err = b.poll(ctx, instance, handler)
+		}
+		if err == nil || ctx.Err() != nil {
+			continue
+		}
+
+		log.Warn("Outbox consumer %s: %v", group, err)
+		if errors.Is(err, errKafkaInstanceGone) {
+			instance = ""
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+var errKafkaInstanceGone = errors.New("kafka consumer instance not found")
+
+type kafkaPosition struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	Offset    int64  `json:"offset"`
+}
+
+// poll fetches one batch of records, handles them and commits what succeeded. On a
+// failure it seeks the partition back to the failed record and skips the partition's
+// later records until the next poll.
+func (b *KafkaBroker) poll(ctx context.Context, instance string, handler Handler) error {
+	var records []struct {
+		Topic     string  `json:"topic"`
+		Partition int     `json:"partition"`
+		Offset    int64   `json:"offset"`
+		Value     Message `json:"value"`
+	}
+	query := fmt.Sprintf("/records?timeout=%d", kafkaPollTimeout.Milliseconds())
+	if err := b.call(ctx, http.MethodGet, instance+query, nil, &records); err != nil {
+		return err
+	}
+
+	type partition struct {
+		topic string
+		id    int
+	}
+	failed := make(map[partition]bool)
+	var commits, seeks []kafkaPosition
+	for _, record := range records {
+		key := partition{record.Topic, record.Partition}
+		if failed[key] {
+			continue
+		}
+		position := kafkaPosition{Topic: record.Topic, Partition: record.Partition, Offset: record.Offset}
+		if err := handler(ctx, record.Value); err != nil {
+			log.Warn("Outbox event %s (%s) failed, will retry: %v", record.Value.ID.String(), record.Value.Type, err)
+			failed[key] = true
+			seeks = append(seeks, position)
+			continue
+		}
+		commits = append(commits, position)
+	}
+
+	// The REST Proxy commits the offset after each given one
+	if len(commits) > 0 {
+		if err := b.call(ctx, http.MethodPost, instance+"/offsets", map[string]interface{}{"offsets": commits}, nil); err != nil {
+			return fmt.Errorf("kafka commit: %w", err)
+		}
+	}
+	if len(seeks) > 0 {
+		if err := b.call(ctx, http.MethodPost, instance+"/positions", map[string]interface{}{"offsets": seeks}, nil); err != nil {
+			return fmt.Errorf("kafka seek: %w", err)
+		}
+		return fmt.Errorf("%d partitions hold back a failed event", len(seeks))
+	}
+	return nil
+}
+
+// call sends a JSON request to the REST Proxy and decodes the response into out
+func (b *KafkaBroker) call(ctx context.Context, method, target string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", kafkaContentType)
+	}
+	if method == http.MethodGet {
+		req.Header.Set("Accept", kafkaContentType)
+	} else {
+		req.Header.Set("Accept", kafkaAccept)
+	}
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound && strings.Contains(target, "/instances/") {
+		return errKafkaInstanceGone
+	}
+	if resp.StatusCode >= 300 {
+		var proxyErr struct {
+			Message string `json:"message"`
+		}
+		json.Unmarshal(data, &proxyErr)
+		if proxyErr.Message == "" {
+			proxyErr.Message = strings.TrimSpace(string(data))
+		}
+		return fmt.Errorf("REST Proxy returned %d: %s", resp.StatusCode, proxyErr.Message)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
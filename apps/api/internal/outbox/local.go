@@ -0,0 +1,78 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// LocalBroker delivers events to consumers in the same process, for the monolith. A
+// handler error fails the publish, so the dispatcher delivers the batch again later.
+type LocalBroker struct {
+	mu     sync.RWMutex
+	groups map[string]*localGroup // by group name
+}
+
+type localGroup struct {
+	eventTypes map[string]bool
+	handler    Handler
+}
+
+// NewLocalBroker creates an in-process broker
+func NewLocalBroker() *LocalBroker {
+	return &LocalBroker{groups: make(map[string]*localGroup)}
+}
+
+// Publish hands each message to every group subscribed to its type, in order
+func (b *LocalBroker) Publish(ctx context.Context, msgs []Message) error {
+	b.mu.RLock()
+	groups := make([]*localGroup, 0, len(b.groups))
+	for _, group := range b.groups {
+		groups = append(groups, group)
+	}
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, msg := range msgs {
+		for _, group := range groups {
+			if !group.eventTypes[msg.Type] {
+				continue
+			}
+			if err := group.handler(ctx, msg); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Subscribe registers handler for the group until ctx is done. A group has one
+// subscriber in process; subscribing again replaces it.
+func (b *LocalBroker) Subscribe(ctx context.Context, group string, eventTypes []string, handler Handler) error {
+	sub := &localGroup{eventTypes: make(map[string]bool, len(eventTypes)), handler: handler}
+	for _, eventType := range eventTypes {
+		sub.eventTypes[eventType] = true
+	}
+
+	b.mu.Lock()
+	b.groups[group] = sub
+	b.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		if b.groups[group] == sub {
+			delete(b.groups, group)
+		}
+		b.mu.Unlock()
+	}()
+	return nil
+}
+
+// Close drops all subscriptions
+func (b *LocalBroker) Close() error {
+	b.mu.Lock()
+	b.groups = make(map[string]*localGroup)
+	b.mu.Unlock()
+	return nil
+}
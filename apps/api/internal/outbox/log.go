@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// LogEntry is an outbox event read back from the log, with its position in it
+type LogEntry struct {
+	Seq int64
+	Message
+}
+
+// Bounds returns the seq of the oldest event kept and of the newest one appended at
+// least settle ago, 0 when there is none. Appends commit out of seq order; reading only
+// up to an event that has settled keeps a reader from passing one still committing.
+func (r *postgresRepository) Bounds(ctx context.Context, settle time.Duration) (int64, int64, error) {
+	query := `
+		SELECT
+			COALESCE((SELECT MIN(seq) FROM %[1]soutbox_events), 0) AS oldest,
+			COALESCE((SELECT MAX(seq) FROM %[1]soutbox_events WHERE created_at < NOW() - make_interval(secs => $1)), 0) AS latest
+	`
+	var bounds struct {
+		Oldest int64 `db:"oldest"`
+		Latest int64 `db:"latest"`
+	}
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &bounds, r.prefixSchema(query), settle.Seconds()); err != nil {
+		return 0, 0, fmt.Errorf("failed to read outbox bounds: %w", err)
+	}
+	return bounds.Oldest, bounds.Latest, nil
+}
+
+// Since returns up to limit events of eventTypes with a seq in (after, upTo], in seq
+// order. Events whose data names a user (a userId field) are returned for that user only.
+func (r *postgresRepository) Since(ctx context.Context, after, upTo int64, eventTypes []string, userID uuid.UUID, limit int) ([]LogEntry, error) {
+	query := `
+		SELECT id, event_type, event_key, payload, created_at, seq
+		FROM %soutbox_events
+		WHERE seq > $1 AND seq <= $2
+			AND event_type = ANY($3::text[])
+			AND (payload->>'userId' IS NULL OR payload->>'userId' = $4)
+		ORDER BY seq
+		LIMIT $5
+	`
+	var rows []eventRow
+	err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, r.prefixSchema(query), after, upTo, pq.Array(eventTypes), userID.String(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox events: %w", err)
+	}
+
+	entries := make([]LogEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = LogEntry{
+			Seq:     row.Seq,
+			Message: Message{ID: row.ID, Type: row.EventType, Key: row.EventKey, Data: row.Payload, CreatedAt: row.CreatedAt},
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,33 @@
+-- Transactional outbox: services append events in the transaction that makes a change,
+-- dispatchers publish them to the message broker and consumers record what they handled
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id UUID PRIMARY KEY,
+    seq BIGSERIAL NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    event_key VARCHAR(255) NOT NULL DEFAULT '',
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    claimed_at TIMESTAMPTZ,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    published_at TIMESTAMPTZ
+);
+
+-- Dispatchers claim unpublished events in append order
+CREATE INDEX IF NOT EXISTS idx_outbox_events_unpublished ON outbox_events(seq)
+    WHERE published_at IS NULL;
+
+-- Retention deletes published events by age
+CREATE INDEX IF NOT EXISTS idx_outbox_events_published ON outbox_events(published_at)
+    WHERE published_at IS NOT NULL;
+
+-- One row per event a consumer group handled; redelivered events are skipped
+CREATE TABLE IF NOT EXISTS outbox_consumed (
+    consumer VARCHAR(100) NOT NULL,
+    event_id UUID NOT NULL,
+    consumed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (consumer, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_consumed_at ON outbox_consumed(consumed_at);
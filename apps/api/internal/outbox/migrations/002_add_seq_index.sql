@@ -0,0 +1,2 @@
+-- The sync endpoint reads the log by seq, published events included
+CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_events_seq ON outbox_events(seq);
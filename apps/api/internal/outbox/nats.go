@@ -0,0 +1,706 @@
+package outbox
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+const (
+	// natsTimeout bounds connecting and each JetStream API call
+	natsTimeout = 10 * time.Second
+
+	// natsPullBatch is how many events a consumer asks for at once
+	natsPullBatch = 20
+
+	// natsPullExpires is how long a pull request waits for events
+	natsPullExpires = 5 * time.Second
+
+	// natsAckWait is how long JetStream waits for an ack before redelivering an event
+	natsAckWait = 30 * time.Second
+)
+
+// NATSBroker publishes events to a NATS JetStream stream and consumes them with one
+// durable pull consumer per group, so events published while a consumer is down are
+// delivered when it is back. It speaks the NATS client protocol directly and needs
+// NATS 2.10 or newer with JetStream enabled.
+//
+// The stream is named after the topic prefix ("telar." makes TELAR) and created on
+// first connect, keeping events for the outbox retention.
+type NATSBroker struct {
+	url    string
+	prefix string
+	stream string
+	maxAge time.Duration
+
+	mu     sync.Mutex
+	conn   *natsConn // nil until connected, replaced when the connection drops
+	closed bool
+}
+
+// NewNATSBroker connects to the NATS server at rawURL (nats:// or tls://, with optional
+// user:password or token credentials) and creates the stream if it does not exist
+func NewNATSBroker(ctx context.Context, rawURL, prefix string, maxAge time.Duration) (*NATSBroker, error) {
+	if prefix == "" {
+		prefix = "outbox."
+	}
+	b := &NATSBroker{url: rawURL, prefix: prefix, stream: streamName(prefix), maxAge: maxAge}
+	if _, err := b.connection(ctx); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Publish stores msgs in the stream and waits for JetStream to acknowledge each. Event
+// IDs are sent as Nats-Msg-Id, so a batch published twice is stored once.
+func (b *NATSBroker) Publish(ctx context.Context, msgs []Message) error {
+	conn, err := b.connection(ctx)
+	if err != nil {
+		return err
+	}
+
+	acks := make([]*natsRequest, 0, len(msgs))
+	defer func() {
+		for _, ack := range acks {
+			ack.cancel()
+		}
+	}()
+	for _, msg := range msgs {
+		data, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("encode %s event: %w", msg.Type, err)
+		}
+		header := "NATS/1.0\r\nNats-Msg-Id: " + msg.ID.String() + "\r\n\r\n"
+		ack, err := conn.startRequest(topicName(b.prefix, msg.Type), header, data)
+		if err != nil {
+			return err
+		}
+		acks = append(acks, ack)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, natsTimeout)
+	defer cancel()
+	for _, ack := range acks {
+		reply, err := ack.wait(ctx)
+		if err != nil {
+			return fmt.Errorf("nats publish: %w", err)
+		}
+		if err := jsResponseError(reply); err != nil {
+			return fmt.Errorf("nats publish: %w", err)
+		}
+	}
+	return nil
+}
+
+// Subscribe creates or updates the group's durable consumer and pulls events for it in
+// the background until ctx is done
+func (b *NATSBroker) Subscribe(ctx context.Context, group string, eventTypes []string, handler Handler) error {
+	subjects := make([]string, len(eventTypes))
+	for i, eventType := range eventTypes {
+		subjects[i] = topicName(b.prefix, eventType)
+	}
+	durable := consumerName(group)
+
+	conn, err := b.connection(ctx)
+	if err != nil {
+		return err
+	}
+	if err := b.ensureConsumer(ctx, conn, durable, subjects); err != nil {
+		return err
+	}
+	go b.consume(ctx, conn, durable, subjects, handler)
+	return nil
+}
+
+// Close closes the connection; consumers stop
+func (b *NATSBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	if b.conn != nil {
+		b.conn.close()
+	}
+	return nil
+}
+
+// connection returns the live connection, reconnecting when it dropped
+func (b *NATSBroker) connection(ctx context.Context) (*natsConn, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return nil, errors.New("nats broker closed")
+	}
+	if b.conn != nil && b.conn.alive() {
+		return b.conn, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, natsTimeout)
+	defer cancel()
+	conn, err := dialNATS(ctx, b.url)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.ensureStream(ctx, conn); err != nil {
+		conn.close()
+		return nil, err
+	}
+	b.conn = conn
+	return conn, nil
+}
+
+// ensureStream creates the stream unless it exists
+func (b *NATSBroker) ensureStream(ctx context.Context, conn *natsConn) error {
+	config := map[string]interface{}{
+		"name":      b.stream,
+		"subjects":  []string{b.prefix + ">"},
+		"retention": "limits",
+		"storage":   "file",
+		"discard":   "old",
+		"max_age":   b.maxAge.Nanoseconds(),
+	}
+	err := jsCall(ctx, conn, "$JS.API.STREAM.CREATE."+b.stream, config)
+	var jsErr *jsError
+	if errors.As(err, &jsErr) && jsErr.ErrCode == jsErrStreamNameInUse {
+		return nil
+	}
+	return err
+}
+
+// ensureConsumer creates the group's durable consumer, or updates its subjects
+func (b *NATSBroker) ensureConsumer(ctx context.Context, conn *natsConn, durable string, subjects []string) error {
+	ctx, cancel := context.WithTimeout(ctx, natsTimeout)
+	defer cancel()
+	return jsCall(ctx, conn, "$JS.API.CONSUMER.CREATE."+b.stream+"."+durable, map[string]interface{}{
+		"stream_name": b.stream,
+		"config": map[string]interface{}{
+			"durable_name":    durable,
+			"deliver_policy":  "all",
+			"ack_policy":      "explicit",
+			"ack_wait":        natsAckWait.Nanoseconds(),
+			"filter_subjects": subjects,
+		},
+	})
+}
+
+// consume pulls events until ctx is done, reconnecting after connection failures
+func (b *NATSBroker) consume(ctx context.Context, ready *natsConn, durable string, subjects []string, handler Handler) {
+	for ctx.Err() == nil {
+		conn, err := b.connection(ctx)
+		if err == nil && conn != ready {
+			if err = b.ensureConsumer(ctx, conn, durable, subjects); err == nil {
+				ready = conn
+			}
+		}
+		if err == nil {
+			err = b.pull(ctx, conn, durable, handler)
+		}
+		if err == nil {
+			continue
+		}
+
+		b.mu.Lock()
+		closed := b.closed
+		b.mu.Unlock()
+		if closed {
+			return
+		}
+		log.Warn("Outbox consumer %s: %v", durable, err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(retryDelay):
+		}
+	}
+}
+
+// pull asks for one batch of events and handles them as they arrive
+func (b *NATSBroker) pull(ctx context.Context, conn *natsConn, durable string, handler Handler) error {
+	inbox := conn.newInbox()
+	sid, deliveries, err := conn.subscribe(inbox, natsPullBatch+1)
+	if err != nil {
+		return err
+	}
+	defer conn.unsubscribe(sid)
+
+	request, _ := json.Marshal(map[string]interface{}{"batch": natsPullBatch, "expires": natsPullExpires.Nanoseconds()})
+	if err := conn.publish("$JS.API.CONSUMER.MSG.NEXT."+b.stream+"."+durable, inbox, "", request); err != nil {
+		return err
+	}
+
+	timeout := time.NewTimer(natsPullExpires + natsTimeout)
+	defer timeout.Stop()
+	for received := 0; received < natsPullBatch; {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-conn.done:
+			return conn.failure()
+		case <-timeout.C:
+			return errors.New("pull request got no answer")
+		case msg := <-deliveries:
+			switch msg.Status {
+			case "":
+			case "100": // Idle heartbeat
+				continue
+			case "404", "408": // No events before the request expired
+				return nil
+			default:
+				return fmt.Errorf("pull request failed with status %s", msg.Status)
+			}
+			received++
+			b.handle(ctx, conn, msg, handler)
+		}
+	}
+	return nil
+}
+
+// handle runs the handler for a delivered event and acknowledges it. Failed events are
+// redelivered after retryDelay; events that cannot be decoded are dropped.
+func (b *NATSBroker) handle(ctx context.Context, conn *natsConn, delivery natsMsg, handler Handler) {
+	var msg Message
+	if err := json.Unmarshal(delivery.Data, &msg); err != nil {
+		log.Error("Dropping undecodable outbox event on %s: %v", delivery.Subject, err)
+		conn.publish(delivery.Reply, "", "", []byte("+TERM"))
+		return
+	}
+	if err := handler(ctx, msg); err != nil {
+		log.Warn("Outbox event %s (%s) failed, will retry: %v", msg.ID.String(), msg.Type, err)
+		conn.publish(delivery.Reply, "", "", []byte(fmt.Sprintf(`-NAK {"delay":%d}`, retryDelay.Nanoseconds())))
+		return
+	}
+	if err := conn.publish(delivery.Reply, "", "", []byte("+ACK")); err != nil {
+		log.Warn("Failed to acknowledge outbox event %s: %v", msg.ID.String(), err)
+	}
+}
+
+// streamName derives the stream name from the topic prefix
+func streamName(prefix string) string {
+	name := strings.ToUpper(consumerName(strings.TrimRight(prefix, ".")))
+	if name == "" {
+		return "OUTBOX"
+	}
+	return name
+}
+
+// consumerName replaces the characters NATS does not allow in names
+func consumerName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '*' || r == '>' || r == ' ' || r == '\t' {
+			return '_'
+		}
+		return r
+	}, name)
+}
+
+// jsErrStreamNameInUse is the JetStream error code for creating a stream that exists
+const jsErrStreamNameInUse = 10058
+
+// jsError is an error returned by the JetStream API
+type jsError struct {
+	Code        int    `json:"code"`
+	ErrCode     int    `json:"err_code"`
+	Description string `json:"description"`
+}
+
+func (e *jsError) Error() string {
+	return fmt.Sprintf("jetstream: %s (%d)", e.Description, e.ErrCode)
+}
+
+// jsCall sends a JetStream API request and returns the error it answered with, if any
+func jsCall(ctx context.Context, conn *natsConn, subject string, request interface{}) error {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	req, err := conn.startRequest(subject, "", data)
+	if err != nil {
+		return err
+	}
+	defer req.cancel()
+	reply, err := req.wait(ctx)
+	if err != nil {
+		return fmt.Errorf("%s: %w", subject, err)
+	}
+	return jsResponseError(reply)
+}
+
+// jsResponseError returns the error of a JetStream API reply or publish ack
+func jsResponseError(reply natsMsg) error {
+	if reply.Status == "503" {
+		return errors.New("no responders: is JetStream enabled?")
+	}
+	var response struct {
+		Error *jsError `json:"error"`
+	}
+	if err := json.Unmarshal(reply.Data, &response); err != nil {
+		return fmt.Errorf("unexpected JetStream reply: %w", err)
+	}
+	if response.Error != nil {
+		return response.Error
+	}
+	return nil
+}
+
+// natsMsg is a message delivered to a subscription
+type natsMsg struct {
+	Subject string
+	Reply   string
+	Status  string // Status of a header-only message, e.g. 408 when a pull request expired
+	Data    []byte
+}
+
+// natsConn is one connection to a NATS server. A reader goroutine routes delivered
+// messages to subscriptions; replies to requests share one wildcard inbox.
+type natsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	wmu    sync.Mutex
+	writer *bufio.Writer
+
+	mu       sync.Mutex
+	nextSID  int
+	subs     map[string]chan natsMsg // by subscription ID
+	replySID string
+	inbox    string
+	pending  map[string]chan natsMsg // by reply token
+	nextReq  int
+	err      error
+
+	done chan struct{}
+}
+
+// dialNATS connects, upgrading to TLS when the URL or server asks for it, and waits for
+// the server to accept the CONNECT
+func dialNATS(ctx context.Context, rawURL string) (*natsConn, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NATS URL: %w", err)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", host)
+	if err != nil {
+		return nil, fmt.Errorf("connect to NATS: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	reader := bufio.NewReader(conn)
+
+	line, err := reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return nil, fmt.Errorf("NATS handshake failed: %q %v", strings.TrimSpace(line), err)
+	}
+	var info struct {
+		TLSRequired bool `json:"tls_required"`
+	}
+	json.Unmarshal([]byte(strings.TrimPrefix(strings.TrimSpace(line), "INFO ")), &info)
+	if u.Scheme == "tls" || info.TLSRequired {
+		tlsConn := tls.Client(conn, &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.HandshakeContext(ctx); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("NATS TLS handshake failed: %w", err)
+		}
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	options := map[string]interface{}{
+		"verbose": false, "pedantic": false, "lang": "go", "version": "telar-outbox",
+		"protocol": 1, "headers": true, "no_responders": true, "name": "telar-outbox",
+	}
+	if u.User != nil {
+		if password, ok := u.User.Password(); ok {
+			options["user"], options["pass"] = u.User.Username(), password
+		} else {
+			options["auth_token"] = u.User.Username()
+		}
+	}
+	connect, _ := json.Marshal(options)
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("NATS handshake failed: %w", err)
+	}
+	// The server answers the PING once it accepted the CONNECT, or sends -ERR
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("NATS handshake failed: %w", err)
+		}
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "-ERR") {
+			conn.Close()
+			return nil, fmt.Errorf("NATS refused the connection: %s", strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+		if line == "PONG" {
+			break
+		}
+	}
+	conn.SetDeadline(time.Time{})
+
+	c := &natsConn{
+		conn:    conn,
+		reader:  reader,
+		writer:  bufio.NewWriter(conn),
+		subs:    make(map[string]chan natsMsg),
+		pending: make(map[string]chan natsMsg),
+		inbox:   "_INBOX." + randomToken(),
+		done:    make(chan struct{}),
+	}
+	replySID, _, err := c.subscribe(c.inbox+".*", 0)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c.replySID = replySID
+	go c.readLoop()
+	return c, nil
+}
+
+func (c *natsConn) alive() bool {
+	select {
+	case <-c.done:
+		return false
+	default:
+		return true
+	}
+}
+
+func (c *natsConn) failure() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		return errors.New("nats connection closed")
+	}
+	return fmt.Errorf("nats connection lost: %w", c.err)
+}
+
+func (c *natsConn) close() {
+	c.conn.Close()
+}
+
+// newInbox returns a subject no one else subscribes to
+func (c *natsConn) newInbox() string {
+	return "_INBOX." + randomToken()
+}
+
+// write sends protocol lines and flushes them
+func (c *natsConn) write(parts ...[]byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(natsTimeout))
+	for _, part := range parts {
+		if _, err := c.writer.Write(part); err != nil {
+			return err
+		}
+	}
+	return c.writer.Flush()
+}
+
+// subscribe delivers messages on subject to the returned channel. Messages that do not
+// fit into buffer are dropped; JetStream redelivers unacknowledged events.
+func (c *natsConn) subscribe(subject string, buffer int) (string, <-chan natsMsg, error) {
+	c.mu.Lock()
+	c.nextSID++
+	sid := strconv.Itoa(c.nextSID)
+	ch := make(chan natsMsg, buffer)
+	c.subs[sid] = ch
+	c.mu.Unlock()
+
+	if err := c.write([]byte("SUB " + subject + " " + sid + "\r\n")); err != nil {
+		return "", nil, err
+	}
+	return sid, ch, nil
+}
+
+func (c *natsConn) unsubscribe(sid string) {
+	c.mu.Lock()
+	delete(c.subs, sid)
+	c.mu.Unlock()
+	c.write([]byte("UNSUB " + sid + "\r\n"))
+}
+
+// publish sends data to subject, with headers when header is set
+func (c *natsConn) publish(subject, reply, header string, data []byte) error {
+	target := subject
+	if reply != "" {
+		target += " " + reply
+	}
+	if header == "" {
+		return c.write([]byte(fmt.Sprintf("PUB %s %d\r\n", target, len(data))), data, []byte("\r\n"))
+	}
+	return c.write([]byte(fmt.Sprintf("HPUB %s %d %d\r\n", target, len(header), len(header)+len(data))), []byte(header), data, []byte("\r\n"))
+}
+
+// natsRequest is a message sent with a reply subject, waiting for its reply
+type natsRequest struct {
+	conn  *natsConn
+	token string
+	reply chan natsMsg
+}
+
+// startRequest publishes data to subject and returns the request to wait on
+func (c *natsConn) startRequest(subject, header string, data []byte) (*natsRequest, error) {
+	c.mu.Lock()
+	c.nextReq++
+	req := &natsRequest{conn: c, token: strconv.Itoa(c.nextReq), reply: make(chan natsMsg, 1)}
+	c.pending[req.token] = req.reply
+	c.mu.Unlock()
+
+	if err := c.publish(subject, c.inbox+"."+req.token, header, data); err != nil {
+		req.cancel()
+		return nil, err
+	}
+	return req, nil
+}
+
+func (r *natsRequest) wait(ctx context.Context) (natsMsg, error) {
+	select {
+	case msg := <-r.reply:
+		return msg, nil
+	case <-r.conn.done:
+		return natsMsg{}, r.conn.failure()
+	case <-ctx.Done():
+		return natsMsg{}, ctx.Err()
+	}
+}
+
+func (r *natsRequest) cancel() {
+	r.conn.mu.Lock()
+	delete(r.conn.pending, r.token)
+	r.conn.mu.Unlock()
+}
+
+// readLoop routes messages until the connection fails
+func (c *natsConn) readLoop() {
+	err := c.read()
+	c.mu.Lock()
+	c.err = err
+	c.mu.Unlock()
+	c.conn.Close()
+	close(c.done)
+}
+
+func (c *natsConn) read() error {
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		op, args, _ := strings.Cut(strings.TrimRight(line, "\r\n"), " ")
+		switch strings.ToUpper(op) {
+		case "MSG":
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			fields := strings.Fields(args)
+			if len(fields) < 3 || len(fields) > 4 {
+				return fmt.Errorf("malformed MSG: %q", args)
+			}
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return fmt.Errorf("malformed MSG: %q", args)
+			}
+			payload, err := c.readPayload(size)
+			if err != nil {
+				return err
+			}
+			msg := natsMsg{Subject: fields[0], Data: payload}
+			if len(fields) == 4 {
+				msg.Reply = fields[2]
+			}
+			c.deliver(fields[1], msg)
+		case "HMSG":
+			// HMSG <subject> <sid> [reply-to] <#header bytes> <#total bytes>
+			fields := strings.Fields(args)
+			if len(fields) < 4 || len(fields) > 5 {
+				return fmt.Errorf("malformed HMSG: %q", args)
+			}
+			headerSize, err1 := strconv.Atoi(fields[len(fields)-2])
+			size, err2 := strconv.Atoi(fields[len(fields)-1])
+			if err1 != nil || err2 != nil || headerSize > size {
+				return fmt.Errorf("malformed HMSG: %q", args)
+			}
+			payload, err := c.readPayload(size)
+			if err != nil {
+				return err
+			}
+			msg := natsMsg{Subject: fields[0], Status: headerStatus(payload[:headerSize]), Data: payload[headerSize:]}
+			if len(fields) == 5 {
+				msg.Reply = fields[2]
+			}
+			c.deliver(fields[1], msg)
+		case "PING":
+			if err := c.write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case "-ERR":
+			return fmt.Errorf("server error: %s", args)
+		}
+	}
+}
+
+// readPayload reads a message body and the CRLF after it
+func (c *natsConn) readPayload(size int) ([]byte, error) {
+	payload := make([]byte, size+2)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return nil, err
+	}
+	return payload[:size], nil
+}
+
+// deliver hands a message to its subscription without blocking the reader
+func (c *natsConn) deliver(sid string, msg natsMsg) {
+	c.mu.Lock()
+	var ch chan natsMsg
+	if sid == c.replySID {
+		token := msg.Subject[strings.LastIndexByte(msg.Subject, '.')+1:]
+		ch = c.pending[token]
+		delete(c.pending, token)
+	} else {
+		ch = c.subs[sid]
+	}
+	c.mu.Unlock()
+
+	if ch == nil {
+		return
+	}
+	select {
+	case ch <- msg:
+	default:
+	}
+}
+
+// headerStatus returns the status code of a "NATS/1.0 408 Request Timeout" header block
+func headerStatus(header []byte) string {
+	line, _, _ := strings.Cut(string(header), "\r\n")
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return ""
+	}
+	return fields[1]
+}
+
+func randomToken() string {
+	buf := make([]byte, 12)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,90 @@
+// Package outbox carries events between services through a transactional outbox.
+//
+// Services append events to the outbox_events table inside the transaction that makes
+// the change they describe, so an event exists if and only if the change committed. A
+// Dispatcher claims unpublished events with SKIP LOCKED and publishes them to a Broker:
+// in process, NATS JetStream or Kafka. Consumers subscribe with Subscribe, which records
+// each event it handles in the same transaction as the handler's writes, so an event
+// delivered twice is applied once per consumer group.
+//
+// Delivery is at least once and eventually consistent: a consumer may see an event
+// seconds after the change, and events are only ordered within one dispatcher batch.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+// retryDelay is the wait before a consumer retries a failed event or reconnects
+const retryDelay = 5 * time.Second
+
+// Message is an outbox event as it travels through the broker
+type Message struct {
+	ID        uuid.UUID       `json:"id"`
+	Type      string          `json:"type"`
+	Key       string          `json:"key"`
+	Data      json.RawMessage `json:"data"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// Decode unmarshals the event data into v
+func (m Message) Decode(v interface{}) error {
+	if err := json.Unmarshal(m.Data, v); err != nil {
+		return fmt.Errorf("decode %s event %s: %w", m.Type, m.ID.String(), err)
+	}
+	return nil
+}
+
+// Handler consumes a message. Returning an error asks the broker to deliver it again.
+type Handler func(ctx context.Context, msg Message) error
+
+// Broker publishes outbox events and delivers them to consumer groups. Each group
+// receives every event of the types it subscribed to; the subscribers of a group share
+// its events.
+type Broker interface {
+	// Publish sends msgs and returns once the broker has accepted all of them
+	Publish(ctx context.Context, msgs []Message) error
+	// Subscribe delivers events of eventTypes to handler until ctx is done. It returns
+	// once the subscription is in place.
+	Subscribe(ctx context.Context, group string, eventTypes []string, handler Handler) error
+	// Close releases the broker's connections
+	Close() error
+}
+
+// NewBroker connects to the broker selected by cfg.Broker
+func NewBroker(ctx context.Context, cfg *platformconfig.OutboxConfig) (Broker, error) {
+	switch cfg.Broker {
+	case "", "local":
+		return NewLocalBroker(), nil
+	case "nats":
+		return NewNATSBroker(ctx, cfg.NATSURL, cfg.TopicPrefix, cfg.Retention)
+	case "kafka":
+		return NewKafkaBroker(cfg.KafkaRESTURL, cfg.TopicPrefix, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox broker %q", cfg.Broker)
+	}
+}
+
+// Subscribe delivers events of eventTypes to handler once per consumer group, even when
+// the broker delivers them again. The handler runs in a transaction, carried by its ctx,
+// that also records the event as consumed; writes it makes through repositories that
+// join the transaction are applied exactly once.
+func Subscribe(ctx context.Context, broker Broker, repo Repository, group string, eventTypes []string, handler Handler) error {
+	return broker.Subscribe(ctx, group, eventTypes, func(ctx context.Context, msg Message) error {
+		return repo.Once(ctx, group, msg.ID, func(txCtx context.Context) error {
+			return handler(txCtx, msg)
+		})
+	})
+}
+
+// topicName names the subject or topic events of eventType are published to
+func topicName(prefix, eventType string) string {
+	return prefix + eventType
+}
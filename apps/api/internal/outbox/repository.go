@@ -0,0 +1,216 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Repository stores outbox events and the events each consumer group has handled
+type Repository interface {
+	sharedInterfaces.EventOutbox
+
+	// Claim marks up to limit unpublished events as being published and returns them,
+	// oldest first. Claims older than staleAfter (a dispatcher died) are claimed again.
+	Claim(ctx context.Context, limit int, staleAfter time.Duration) ([]Message, error)
+	// MarkPublished records that the broker accepted the events
+	MarkPublished(ctx context.Context, ids []uuid.UUID) error
+	// Release returns claimed events to the queue after a failed publish
+	Release(ctx context.Context, ids []uuid.UUID, cause error) error
+	// Once runs fn in a transaction that also records eventID as handled by consumer.
+	// It skips fn when the consumer already handled the event.
+	Once(ctx context.Context, consumer string, eventID uuid.UUID, fn func(txCtx context.Context) error) error
+	// Purge deletes published events and consumer receipts older than before
+	Purge(ctx context.Context, before time.Time) (int64, error)
+
+	// Bounds returns the seq of the oldest event kept and of the newest event appended
+	// at least settle ago; 0 when there is none
+	Bounds(ctx context.Context, settle time.Duration) (oldest, latest int64, err error)
+	// Since reads the log: up to limit events of eventTypes after seq after and up to
+	// upTo, oldest first, leaving out events addressed to users other than userID
+	Since(ctx context.Context, after, upTo int64, eventTypes []string, userID uuid.UUID, limit int) ([]LogEntry, error)
+}
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates the outbox repository
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client}
+}
+
+// NewPostgresRepositoryWithSchema creates the outbox repository for tables in schema
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) prefixSchema(query string) string {
+	if r.schema != "" {
+		return fmt.Sprintf(query, r.schema+".")
+	}
+	return fmt.Sprintf(query, "")
+}
+
+// Append records an event in the caller's transaction
+func (r *postgresRepository) Append(ctx context.Context, eventType string, key string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event: %w", eventType, err)
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return fmt.Errorf("failed to generate event ID: %w", err)
+	}
+
+	query := `INSERT INTO %soutbox_events (id, event_type, event_key, payload) VALUES ($1, $2, $3, $4)`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, r.prefixSchema(query), id, eventType, key, payload); err != nil {
+		return fmt.Errorf("failed to append %s event: %w", eventType, err)
+	}
+	return nil
+}
+
+type eventRow struct {
+	ID        uuid.UUID `db:"id"`
+	EventType string    `db:"event_type"`
+	EventKey  string    `db:"event_key"`
+	Payload   []byte    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+	Seq       int64     `db:"seq"`
+}
+
+// Claim marks a batch of unpublished events as claimed. SKIP LOCKED lets several
+// dispatchers poll at once without claiming the same event.
+func (r *postgresRepository) Claim(ctx context.Context, limit int, staleAfter time.Duration) ([]Message, error) {
+	query := `
+		UPDATE %soutbox_events
+		SET claimed_at = NOW(), attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM %soutbox_events
+			WHERE published_at IS NULL
+				AND (claimed_at IS NULL OR claimed_at < NOW() - make_interval(secs => $2))
+			ORDER BY seq
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_type, event_key, payload, created_at, seq
+	`
+
+	prefix := ""
+	if r.schema != "" {
+		prefix = r.schema + "."
+	}
+	var rows []eventRow
+	err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, fmt.Sprintf(query, prefix, prefix), limit, staleAfter.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim outbox events: %w", err)
+	}
+
+	// RETURNING does not keep the order of the subquery
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Seq < rows[j].Seq })
+	msgs := make([]Message, len(rows))
+	for i, row := range rows {
+		msgs[i] = Message{ID: row.ID, Type: row.EventType, Key: row.EventKey, Data: row.Payload, CreatedAt: row.CreatedAt}
+	}
+	return msgs, nil
+}
+
+// MarkPublished records that the broker accepted the events
+func (r *postgresRepository) MarkPublished(ctx context.Context, ids []uuid.UUID) error {
+	query := `UPDATE %soutbox_events SET published_at = NOW(), last_error = NULL WHERE id = ANY($1::uuid[])`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, r.prefixSchema(query), pq.Array(uuidStrings(ids))); err != nil {
+		return fmt.Errorf("failed to mark outbox events published: %w", err)
+	}
+	return nil
+}
+
+// Release returns claimed events to the queue and records why publishing failed
+func (r *postgresRepository) Release(ctx context.Context, ids []uuid.UUID, cause error) error {
+	message := ""
+	if cause != nil {
+		message = cause.Error()
+	}
+	query := `UPDATE %soutbox_events SET claimed_at = NULL, last_error = $2 WHERE id = ANY($1::uuid[])`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, r.prefixSchema(query), pq.Array(uuidStrings(ids)), message); err != nil {
+		return fmt.Errorf("failed to release outbox events: %w", err)
+	}
+	return nil
+}
+
+// Once inserts the receipt first: a second delivery of the same event waits on the
+// receipt's row lock, then finds it and skips the handler.
+func (r *postgresRepository) Once(ctx context.Context, consumer string, eventID uuid.UUID, fn func(txCtx context.Context) error) error {
+	tx, err := r.client.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if r.schema != "" {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`SET search_path TO %s`, r.schema)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to set search_path in transaction (schema=%s): %w", r.schema, err)
+		}
+	}
+
+	query := `INSERT INTO %soutbox_consumed (consumer, event_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	result, err := tx.ExecContext(ctx, r.prefixSchema(query), consumer, eventID)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to record consumed event: %w", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil || inserted == 0 {
+		tx.Rollback()
+		return err
+	}
+
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("transaction error: %w, rollback error: %v", err, rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// Purge deletes published events and consumer receipts older than before. Receipts are
+// kept as long as events, so a redelivered event is still recognized.
+func (r *postgresRepository) Purge(ctx context.Context, before time.Time) (int64, error) {
+	exec := r.getExecutor(ctx)
+	result, err := exec.ExecContext(ctx, r.prefixSchema(`DELETE FROM %soutbox_events WHERE published_at < $1`), before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge outbox events: %w", err)
+	}
+	if _, err := exec.ExecContext(ctx, r.prefixSchema(`DELETE FROM %soutbox_consumed WHERE consumed_at < $1`), before); err != nil {
+		return 0, fmt.Errorf("failed to purge consumed events: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	strs := make([]string, len(ids))
+	for i, id := range ids {
+		strs[i] = id.String()
+	}
+	return strs
+}
@@ -0,0 +1,137 @@
+// Package fieldset implements sparse fieldsets for list endpoints: clients pass
+// ?fields=objectId,body,ownerDisplayName and responses keep only those JSON fields.
+package fieldset
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Set is a parsed field selection. A nil Set selects every field.
+type Set map[string]struct{}
+
+// Parse parses a comma separated field list and rejects names not in allowed.
+// An empty value returns a nil Set (all fields).
+func Parse(raw string, allowed []string) (Set, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, nil
+	}
+
+	known := make(map[string]struct{}, len(allowed))
+	for _, name := range allowed {
+		known[name] = struct{}{}
+	}
+
+	set := make(Set)
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("unknown field %q", name)
+		}
+		set[name] = struct{}{}
+	}
+	if len(set) == 0 {
+		return nil, nil
+	}
+	return set, nil
+}
+
+// Has reports whether the field is selected
+func (s Set) Has(field string) bool {
+	if s == nil {
+		return true
+	}
+	_, ok := s[field]
+	return ok
+}
+
+// Fields returns the selected field names in sorted order, or nil when all fields are selected
+func (s Set) Fields() []string {
+	if s == nil {
+		return nil
+	}
+	fields := make([]string, 0, len(s))
+	for name := range s {
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// FromFields builds a Set from already validated field names
+func FromFields(fields []string) Set {
+	if len(fields) == 0 {
+		return nil
+	}
+	set := make(Set, len(fields))
+	for _, name := range fields {
+		set[name] = struct{}{}
+	}
+	return set
+}
+
+// JSONFields lists the JSON field names of a struct value, for use as the allowed list in Parse
+func JSONFields(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// Project returns items reduced to the selected fields plus any always-included fields
+// (typically the ID). With a nil Set the items are returned as maps unchanged.
+func Project[T any](items []T, s Set, always ...string) ([]map[string]interface{}, error) {
+	projected := make([]map[string]interface{}, 0, len(items))
+	for i := range items {
+		data, err := json.Marshal(items[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal item: %w", err)
+		}
+		var m map[string]interface{}
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal item: %w", err)
+		}
+		if s != nil {
+			for key := range m {
+				if !s.Has(key) && !contains(always, key) {
+					delete(m, key)
+				}
+			}
+		}
+		projected = append(projected, m)
+	}
+	return projected, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
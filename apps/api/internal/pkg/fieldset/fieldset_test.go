@@ -0,0 +1,47 @@
+package fieldset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type card struct {
+	ID     string            `json:"objectId"`
+	Body   string            `json:"body"`
+	Votes  map[string]string `json:"votes"`
+	Hidden string            `json:"-"`
+	Score  int               `json:"score,omitempty"`
+}
+
+func TestParse(t *testing.T) {
+	allowed := JSONFields(card{})
+	assert.Equal(t, []string{"objectId", "body", "votes", "score"}, allowed)
+
+	set, err := Parse(" body, score ,", allowed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"body", "score"}, set.Fields())
+	assert.True(t, set.Has("body"))
+	assert.False(t, set.Has("votes"))
+
+	set, err = Parse("", allowed)
+	require.NoError(t, err)
+	assert.Nil(t, set)
+	assert.True(t, set.Has("anything"))
+
+	_, err = Parse("body,password", allowed)
+	assert.Error(t, err)
+}
+
+func TestProject(t *testing.T) {
+	items := []card{{ID: "1", Body: "hello", Votes: map[string]string{"u": "1"}, Score: 3}}
+
+	out, err := Project(items, FromFields([]string{"body"}), "objectId")
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{{"objectId": "1", "body": "hello"}}, out)
+
+	out, err = Project(items, nil)
+	require.NoError(t, err)
+	assert.Len(t, out[0], 4)
+}
@@ -1,88 +1,146 @@
+// Package log writes structured records through log/slog. Records logged with a
+// context carry the request and user IDs stored in it, so one request can be
+// followed through every service it reaches.
 package log
 
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
 
 	"github.com/davecgh/go-spew/spew"
-	"github.com/fatih/color"
 )
 
-const contextKeyRequestID = "request_id"
+// Context keys. They are plain strings so values fiber stores with c.Locals are found
+// through c.Context() as well.
+const (
+	ContextKeyRequestID = "request_id"
+	ContextKeyUserID    = "user_id"
+)
+
+// Setup makes the default logger write format ("json" or "text") records at level
+// ("debug", "info", "warn" or "error") and above to w. The standard library logger
+// writes through it too, and records are still kept for Traced if Retain is on.
+func Setup(w io.Writer, format, level string) {
+	opts := &slog.HandlerOptions{Level: parseLevel(level)}
+	var handler slog.Handler
+	if strings.EqualFold(format, "json") {
+		handler = slog.NewJSONHandler(w, opts)
+	} else {
+		handler = slog.NewTextHandler(w, opts)
+	}
+	traces.mu.Lock()
+	defer traces.mu.Unlock()
+	traces.handler = handler
+	setHandler()
+}
+
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
 
 // WithRequestID adds request ID to context for logging
 func WithRequestID(ctx context.Context, requestID string) context.Context {
-	return context.WithValue(ctx, contextKeyRequestID, requestID)
+	return context.WithValue(ctx, ContextKeyRequestID, requestID)
+}
+
+// WithUserID adds the acting user's ID to context for logging
+func WithUserID(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, ContextKeyUserID, userID)
 }
 
-// getRequestID retrieves request ID from context
-func getRequestID(ctx context.Context) string {
-	if id, ok := ctx.Value(contextKeyRequestID).(string); ok {
+// RequestID returns the request ID in ctx, or "" when there is none
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(ContextKeyRequestID).(string); ok {
 		return id
 	}
 	return ""
 }
 
-// formatLog formats log message with optional request ID
-func formatLog(level string, requestID string, format string, a ...interface{}) string {
-	msg := fmt.Sprintf(format, a...)
-	if requestID != "" {
-		return fmt.Sprintf("[%s] [req_id=%s] %s", level, requestID, msg)
+// UserID returns the user ID in ctx, or "" when there is none
+func UserID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if id, ok := ctx.Value(ContextKeyUserID).(string); ok {
+		return id
 	}
-	return fmt.Sprintf("[%s] %s", level, msg)
+	return ""
+}
+
+// Logger returns the default logger with the request and user IDs in ctx attached
+func Logger(ctx context.Context) *slog.Logger {
+	logger := slog.Default()
+	if id := RequestID(ctx); id != "" {
+		logger = logger.With(slog.String(ContextKeyRequestID, id))
+	}
+	if id := UserID(ctx); id != "" {
+		logger = logger.With(slog.String(ContextKeyUserID, id))
+	}
+	return logger
+}
+
+// Debug log debug detail
+func Debug(format string, a ...interface{}) {
+	slog.Default().Debug(fmt.Sprintf(format, a...))
+}
+
+// DebugWithContext logs debug detail with the request and user IDs in ctx
+func DebugWithContext(ctx context.Context, format string, a ...interface{}) {
+	Logger(ctx).DebugContext(ctx, fmt.Sprintf(format, a...))
 }
 
 // Info log information
 func Info(format string, a ...interface{}) {
-	info := color.New(color.FgWhite, color.BgGreen).SprintFunc()
-	fmt.Printf("%s ", info("[INFO] "))
-	fmt.Printf(format, a...)
-	fmt.Println()
+	slog.Default().Info(fmt.Sprintf(format, a...))
 }
 
-// InfoWithContext logs information with context (includes request ID if available)
+// InfoWithContext logs information with the request and user IDs in ctx
 func InfoWithContext(ctx context.Context, format string, a ...interface{}) {
-	requestID := getRequestID(ctx)
-	msg := formatLog("INFO", requestID, format, a...)
-	info := color.New(color.FgWhite, color.BgGreen).SprintFunc()
-	fmt.Printf("%s ", info("[INFO] "))
-	fmt.Println(msg)
+	Logger(ctx).InfoContext(ctx, fmt.Sprintf(format, a...))
 }
 
 // Warn log warning
 func Warn(format string, a ...interface{}) {
-	info := color.New(color.FgWhite, color.BgGreen).SprintFunc()
-	fmt.Printf("%s ", info("[WARN] "))
-	fmt.Printf(format, a...)
-	fmt.Println()
+	slog.Default().Warn(fmt.Sprintf(format, a...))
 }
 
-// WarnWithContext logs warning with context (includes request ID if available)
+// WarnWithContext logs warning with the request and user IDs in ctx
 func WarnWithContext(ctx context.Context, format string, a ...interface{}) {
-	requestID := getRequestID(ctx)
-	msg := formatLog("WARN", requestID, format, a...)
-	warn := color.New(color.FgWhite, color.BgYellow).SprintFunc()
-	fmt.Printf("%s ", warn("[WARN] "))
-	fmt.Println(msg)
+	Logger(ctx).WarnContext(ctx, fmt.Sprintf(format, a...))
 }
 
 // Error log error
 func Error(format string, a ...interface{}) {
-	red := color.New(color.FgRed).SprintFunc()
-	fmt.Printf("%s ", red("[Error]"))
-	fmt.Printf(format, a...)
-	fmt.Println()
+	slog.Default().Error(fmt.Sprintf(format, a...))
 }
 
-// ErrorWithContext logs error with context (includes request ID if available)
+// ErrorWithContext logs error with the request and user IDs in ctx
 func ErrorWithContext(ctx context.Context, format string, a ...interface{}) {
-	requestID := getRequestID(ctx)
-	msg := formatLog("ERROR", requestID, format, a...)
-	red := color.New(color.FgRed).SprintFunc()
-	fmt.Printf("%s ", red("[Error]"))
-	fmt.Println(msg)
+	Logger(ctx).ErrorContext(ctx, fmt.Sprintf(format, a...))
+}
+
+// Fatal logs an error and exits the process
+func Fatal(format string, a ...interface{}) {
+	Error(format, a...)
+	os.Exit(1)
 }
 
 func InfoStruct(a ...interface{}) {
-	spew.Sdump(a...)
+	slog.Default().Info(spew.Sdump(a...))
 }
@@ -1,17 +1,93 @@
 package log
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
-// TestLog_Basic ensures the package compiles and basic functionality works
-func TestLog_Basic(t *testing.T) {
-	// Basic test to ensure the package compiles and can be imported
-	t.Log("internal/pkg/log package test passed")
+func captureJSON(t *testing.T, level string) *bytes.Buffer {
+	t.Helper()
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	var buf bytes.Buffer
+	Setup(&buf, "json", level)
+	return &buf
+}
+
+func TestWithContext_AddsRequestAndUserIDs(t *testing.T) {
+	buf := captureJSON(t, "info")
+
+	ctx := WithUserID(WithRequestID(context.Background(), "req-1"), "user-1")
+	ErrorWithContext(ctx, "failed to save %s", "post")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "ERROR", record["level"])
+	assert.Equal(t, "failed to save post", record["msg"])
+	assert.Equal(t, "req-1", record[ContextKeyRequestID])
+	assert.Equal(t, "user-1", record[ContextKeyUserID])
+}
+
+func TestWithContext_OmitsMissingIDs(t *testing.T) {
+	buf := captureJSON(t, "info")
+
+	InfoWithContext(context.Background(), "started")
+
+	var record map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.NotContains(t, record, ContextKeyRequestID)
+	assert.NotContains(t, record, ContextKeyUserID)
 }
 
-// TestLog_Compilation ensures all functions can be called
-func TestLog_Compilation(t *testing.T) {
-	// This test ensures that the log package compiles correctly
-	t.Log("internal/pkg/log compilation test passed")
+func TestSetup_Level(t *testing.T) {
+	buf := captureJSON(t, "warn")
+
+	Info("hidden")
+	Debug("hidden")
+	assert.Empty(t, buf.String())
+
+	Warn("shown")
+	assert.Contains(t, buf.String(), "shown")
+}
+
+func TestRequestID_NilContext(t *testing.T) {
+	assert.Empty(t, RequestID(nil))
+	assert.Empty(t, UserID(context.Background()))
+}
+
+func TestRetain_KeepsRecordsByRequestID(t *testing.T) {
+	buf := captureJSON(t, "info")
+	Retain(3)
+	t.Cleanup(func() { Retain(0) })
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	InfoWithContext(ctx, "first")
+	Logger(ctx).Warn("second", "status", 500)
+	Info("no request")
+	Debug("below level")
+	slog.Default().InfoContext(WithRequestID(context.Background(), "req-2"), "other")
+
+	records := Traced("req-1")
+	require.Len(t, records, 2)
+	assert.Equal(t, "first", records[0].Message)
+	assert.Equal(t, "WARN", records[1].Level)
+	assert.EqualValues(t, 500, records[1].Attrs["status"])
+	assert.NotContains(t, records[1].Attrs, ContextKeyRequestID)
+	assert.Contains(t, buf.String(), "second", "records are still written")
+
+	// The oldest record is dropped once the buffer is full
+	InfoWithContext(ctx, "third")
+	records = Traced("req-1")
+	require.Len(t, records, 2)
+	assert.Equal(t, "second", records[0].Message)
+	assert.Equal(t, "third", records[1].Message)
+	assert.Len(t, Traced("req-2"), 1)
+	assert.Empty(t, Traced("missing"))
 }
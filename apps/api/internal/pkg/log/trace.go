@@ -0,0 +1,169 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// TraceRecord is a log record kept in memory so support can look up what happened
+// during one request
+type TraceRecord struct {
+	Time    time.Time              `json:"time"`
+	Level   string                 `json:"level"`
+	Message string                 `json:"message"`
+	Attrs   map[string]interface{} `json:"attrs,omitempty"`
+}
+
+// traceBuffer holds the most recent records that carry a request ID
+type traceBuffer struct {
+	mu       sync.Mutex
+	records  []tracedRecord
+	next     int
+	capacity int
+}
+
+type tracedRecord struct {
+	requestID string
+	record    TraceRecord
+}
+
+var traces struct {
+	mu     sync.RWMutex
+	buffer *traceBuffer
+	// handler is the one Setup built. Retaining wraps it rather than slog's built-in
+	// default, which writes through the standard logger and so back into slog.
+	handler slog.Handler
+}
+
+// setHandler makes the default logger write through the Setup handler, keeping
+// records in the trace buffer when there is one. traces.mu must be held.
+func setHandler() {
+	if traces.handler == nil && traces.buffer == nil {
+		return
+	}
+	if traces.handler == nil {
+		traces.handler = slog.NewTextHandler(os.Stderr, nil)
+	}
+	if traces.buffer == nil {
+		slog.SetDefault(slog.New(traces.handler))
+		return
+	}
+	slog.SetDefault(slog.New(&traceHandler{next: traces.handler, buffer: traces.buffer}))
+}
+
+// Retain keeps the last capacity records logged with a request ID in memory, for
+// Traced to return. Records below the configured level are not kept. A capacity of 0
+// or less stops retaining.
+func Retain(capacity int) {
+	traces.mu.Lock()
+	defer traces.mu.Unlock()
+	traces.buffer = nil
+	if capacity > 0 {
+		traces.buffer = &traceBuffer{records: make([]tracedRecord, 0, capacity), capacity: capacity}
+	}
+	setHandler()
+}
+
+// Traced returns the retained records logged with requestID, oldest first. Only
+// records written by this process are known.
+func Traced(requestID string) []TraceRecord {
+	traces.mu.RLock()
+	buffer := traces.buffer
+	traces.mu.RUnlock()
+	if buffer == nil || requestID == "" {
+		return nil
+	}
+	return buffer.find(requestID)
+}
+
+func (b *traceBuffer) add(requestID string, record TraceRecord) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry := tracedRecord{requestID: requestID, record: record}
+	if len(b.records) < b.capacity {
+		b.records = append(b.records, entry)
+		return
+	}
+	b.records[b.next] = entry
+	b.next = (b.next + 1) % b.capacity
+}
+
+func (b *traceBuffer) find(requestID string) []TraceRecord {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var found []TraceRecord
+	// Once full, the oldest record is the one next overwrites
+	for i := range b.records {
+		entry := b.records[(b.next+i)%len(b.records)]
+		if entry.requestID == requestID {
+			found = append(found, entry.record)
+		}
+	}
+	return found
+}
+
+// traceHandler passes records on to next and keeps those carrying a request ID
+type traceHandler struct {
+	next   slog.Handler
+	buffer *traceBuffer
+	attrs  []slog.Attr
+	group  string
+}
+
+func (h *traceHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *traceHandler) Handle(ctx context.Context, record slog.Record) error {
+	attrs := make(map[string]interface{}, len(h.attrs)+record.NumAttrs())
+	for _, attr := range h.attrs {
+		attrs[attr.Key] = attr.Value.Resolve().Any()
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs[h.key(attr.Key)] = attr.Value.Resolve().Any()
+		return true
+	})
+
+	requestID, _ := attrs[ContextKeyRequestID].(string)
+	if requestID == "" {
+		requestID = RequestID(ctx)
+	}
+	if requestID != "" {
+		delete(attrs, ContextKeyRequestID)
+		h.buffer.add(requestID, TraceRecord{
+			Time:    record.Time,
+			Level:   record.Level.String(),
+			Message: record.Message,
+			Attrs:   attrs,
+		})
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *traceHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithAttrs(attrs)
+	clone.attrs = append([]slog.Attr(nil), h.attrs...)
+	for _, attr := range attrs {
+		clone.attrs = append(clone.attrs, slog.Attr{Key: h.key(attr.Key), Value: attr.Value})
+	}
+	return &clone
+}
+
+func (h *traceHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.next = h.next.WithGroup(name)
+	clone.group = h.key(name)
+	return &clone
+}
+
+// key prefixes attribute keys inside a group with the group name
+func (h *traceHandler) key(key string) string {
+	if h.group == "" {
+		return key
+	}
+	return h.group + "." + key
+}
@@ -0,0 +1,124 @@
+// Package sanitize cleans user-written text before it is stored. It replaces invalid
+// UTF-8, applies NFKC normalization so fullwidth and styled letters read as the plain
+// ones they imitate, and drops control characters, bidi overrides and invisible
+// characters that can hide or disguise text. Characters that real scripts and emoji
+// need (ZWJ, ZWNJ, LRM, RLM) are kept, but runs of them collapse to one.
+package sanitize
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Text cleans multi-line text such as post bodies, comments and bios. Line breaks are
+// kept, but trailing spaces are dropped, spaces inside a line collapse to one and at
+// most one blank line separates paragraphs.
+func Text(s string) string {
+	lines := strings.Split(clean(s), "\n")
+	out := make([]string, 0, len(lines))
+	blank := 0
+	for _, line := range lines {
+		line = collapseLine(line)
+		if line == "" {
+			blank++
+			if blank > 1 {
+				continue
+			}
+		} else {
+			blank = 0
+		}
+		out = append(out, line)
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+// Line cleans single-line text such as names, tags and titles; every run of whitespace,
+// line breaks included, collapses to one space.
+func Line(s string) string {
+	return strings.Join(strings.Fields(clean(s)), " ")
+}
+
+// clean removes the characters neither Text nor Line keeps and turns every line break
+// into "\n" and every other space into " "
+func clean(s string) string {
+	s = norm.NFKC.String(strings.ToValidUTF8(s, string(utf8.RuneError)))
+
+	var b strings.Builder
+	b.Grow(len(s))
+	var prev rune
+	flag := false // Inside a subdivision flag emoji, which is spelled with tag characters
+	for i, r := range s {
+		switch {
+		case r == '\r':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				continue
+			}
+			r = '\n'
+		case r == '\u2028' || r == '\u2029':
+			r = '\n'
+		case r == '\n' || r == '\t':
+		case unicode.IsControl(r), isBidiOverride(r), isInvisible(r):
+			continue
+		case isTag(r):
+			if !flag {
+				continue
+			}
+		case isJoinerOrMark(r):
+			// One is meaningful, a run of them only hides text
+			if isJoinerOrMark(prev) {
+				continue
+			}
+		case unicode.IsSpace(r):
+			r = ' '
+		}
+		flag = r == '\U0001F3F4' || (flag && isTag(r))
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
+
+// collapseLine keeps a line's indentation, collapses the other runs of spaces and tabs
+// to one space and drops trailing ones
+func collapseLine(line string) string {
+	body := strings.TrimLeft(line, " \t")
+	if body == "" {
+		return ""
+	}
+	return line[:len(line)-len(body)] + strings.Join(strings.Fields(body), " ")
+}
+
+// isBidiOverride reports the embedding, override and isolate controls, which reorder
+// the text around them (the "Trojan Source" trick)
+func isBidiOverride(r rune) bool {
+	return (r >= '\u202A' && r <= '\u202E') || (r >= '\u2066' && r <= '\u2069')
+}
+
+// isInvisible reports zero-width characters with no use in social text
+func isInvisible(r rune) bool {
+	switch r {
+	case '\u00AD', // Soft hyphen
+		'\u034F',                               // Combining grapheme joiner
+		'\u115F', '\u1160', '\u3164', '\uFFA0', // Hangul fillers
+		'\u180E',                                         // Mongolian vowel separator
+		'\u200B',                                         // Zero-width space
+		'\u2060', '\u2061', '\u2062', '\u2063', '\u2064', // Word joiner and invisible operators
+		'\uFEFF': // Byte order mark
+		return true
+	}
+	return false
+}
+
+// isJoinerOrMark reports ZWNJ and ZWJ, which Persian, Indic scripts and emoji need,
+// and the direction marks LRM, RLM and ALM, which mixed-direction text needs
+func isJoinerOrMark(r rune) bool {
+	return r == '\u200C' || r == '\u200D' || r == '\u200E' || r == '\u200F' || r == '\u061C'
+}
+
+// isTag reports the tag characters, which are invisible outside flag emoji
+func isTag(r rune) bool {
+	return r >= '\U000E0000' && r <= '\U000E007F'
+}
@@ -0,0 +1,62 @@
+package sanitize
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"keeps plain text", "Hello, world!", "Hello, world!"},
+		{"replaces invalid UTF-8", "caf\xe9 au lait", "caf� au lait"},
+		{"strips control characters", "ring\x07 the\x00 bell\x1b[31m", "ring the bell[31m"},
+		{"normalizes line endings", "one\r\ntwo\rthree\u2028four", "one\ntwo\nthree\nfour"},
+		{"collapses blank lines", "one\n\n\n\n\ntwo\n \t\nthree", "one\n\ntwo\n\nthree"},
+		{"collapses spaces inside lines", "a   b\u00A0\u00A0c  \n  indented   line  ", "a b c\n  indented line"},
+		{"trims the text", "\n\n  hello  \n\n", "hello"},
+		// Homoglyphs: fullwidth and styled letters read as the plain ones they imitate
+		{"folds fullwidth letters", "ｐａｙｐａｌ．ｃｏｍ", "paypal.com"},
+		{"folds mathematical letters", "\U0001D429\U0001D41A\U0001D432\U0001D429\U0001D41A\U0001D425", "paypal"},
+		{"folds compatibility forms", "ﬁle ①", "file 1"},
+		{"keeps other scripts", "Привет سلام", "Привет سلام"},
+		// Bidi overrides: the text must read as it is stored
+		{"strips bidi overrides", "invoice_\u202Egpj.exe", "invoice_gpj.exe"},
+		{"strips bidi isolates", "access\u2067 level\u2069 admin", "access level admin"},
+		{"keeps direction marks", "Telar\u200F سلام", "Telar\u200F سلام"},
+		// Zero-width abuse
+		{"strips zero-width spaces", "fr\u200Bee mo\u2060ney\uFEFF", "free money"},
+		{"collapses runs of joiners", "می\u200C\u200C\u200Cخواهم", "می\u200Cخواهم"},
+		{"keeps emoji sequences", "\U0001F468\u200D\U0001F469\u200D\U0001F467", "\U0001F468\u200D\U0001F469\u200D\U0001F467"},
+		{"keeps subdivision flags", "\U0001F3F4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F", "\U0001F3F4\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F"},
+		{"strips smuggled tag characters", "hi\U000E0069\U000E0067\U000E006E\U000E006F\U000E0072\U000E0065", "hi"},
+		{"empties invisible-only text", "\u200B\u2060\u00AD \u3164", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Text(tt.in))
+		})
+	}
+}
+
+func TestLine(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"collapses all whitespace", "  Jane \n\t Doe\u3000 ", "Jane Doe"},
+		{"folds fullwidth letters", "Ａｄｍｉｎ", "Admin"},
+		{"strips bidi overrides", "\u202Enimda", "nimda"},
+		{"strips zero-width spaces", "ad\u200Bmin", "admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Line(tt.in))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Package validate collects field-level validation errors, so a request that breaks
+// several limits is rejected once with every offending field.
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// FieldError is a request field that failed validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+	Limit   int    `json:"limit,omitempty"` // The limit the field exceeded, when there is one
+}
+
+// Error is returned by Validator.Err; handlers send its Fields to clients
+type Error struct {
+	Fields []FieldError
+}
+
+func (e *Error) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Field + ": " + field.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Fields returns the field errors in err's chain, if any
+func Fields(err error) ([]FieldError, bool) {
+	var validationErr *Error
+	if errors.As(err, &validationErr) {
+		return validationErr.Fields, true
+	}
+	return nil, false
+}
+
+// Validator accumulates field errors. The zero value is ready to use.
+type Validator struct {
+	fields []FieldError
+}
+
+// Add records a field error
+func (v *Validator) Add(field, message string) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: message})
+}
+
+// MaxLength checks that value has at most max characters; max <= 0 means no limit
+func (v *Validator) MaxLength(field, value string, max int) {
+	if max > 0 && utf8.RuneCountInString(value) > max {
+		v.fields = append(v.fields, FieldError{
+			Field:   field,
+			Message: fmt.Sprintf("must be at most %d characters", max),
+			Limit:   max,
+		})
+	}
+}
+
+// MaxItems checks that a list field has at most max items; max <= 0 means no limit
+func (v *Validator) MaxItems(field string, count, max int) {
+	if max > 0 && count > max {
+		v.fields = append(v.fields, FieldError{
+			Field:   field,
+			Message: fmt.Sprintf("must have at most %d items", max),
+			Limit:   max,
+		})
+	}
+}
+
+// Err returns an *Error with every recorded field, or nil when all fields passed
+func (v *Validator) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &Error{Fields: v.fields}
+}
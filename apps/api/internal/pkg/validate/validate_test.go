@@ -0,0 +1,45 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator(t *testing.T) {
+	t.Run("passes values within their limits", func(t *testing.T) {
+		var v Validator
+		v.MaxLength("body", "héllo", 5)
+		v.MaxItems("tags", 2, 2)
+		v.MaxLength("bio", strings.Repeat("a", 100), 0)
+		assert.NoError(t, v.Err())
+	})
+
+	t.Run("reports every field over its limit", func(t *testing.T) {
+		var v Validator
+		v.MaxLength("body", "hello!", 5)
+		v.MaxItems("tags", 3, 2)
+		v.Add("tags[1]", "cannot be empty")
+
+		err := v.Err()
+		require.Error(t, err)
+		assert.Equal(t, "body: must be at most 5 characters; tags: must have at most 2 items; tags[1]: cannot be empty", err.Error())
+
+		fields, ok := Fields(fmt.Errorf("%w: %w", errors.New("validation failed"), err))
+		require.True(t, ok)
+		assert.Equal(t, []FieldError{
+			{Field: "body", Message: "must be at most 5 characters", Limit: 5},
+			{Field: "tags", Message: "must have at most 2 items", Limit: 2},
+			{Field: "tags[1]", Message: "cannot be empty"},
+		}, fields)
+	})
+
+	t.Run("finds no fields in other errors", func(t *testing.T) {
+		_, ok := Fields(errors.New("boom"))
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,345 @@
+package aiengine
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+// ErrBudgetExceeded is returned once the AI engine has spent a monthly token budget the
+// request counts against. Callers answering users fall back to results without AI.
+var ErrBudgetExceeded = errors.New("AI engine token budget exceeded")
+
+// communityHeader names the community whose token budget a request counts against
+const communityHeader = "X-Community-ID"
+
+type communityKey struct{}
+
+// WithCommunity makes the AI engine requests made with ctx count against the token
+// budget of communityID
+func WithCommunity(ctx context.Context, communityID string) context.Context {
+	return context.WithValue(ctx, communityKey{}, communityID)
+}
+
+// Client calls the AI engine service over HTTP
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	quota      *userQuota // nil when requests are not metered per user
+}
+
+// NewClient creates an AI engine client. baseURL is required.
+func NewClient(baseURL string, timeout time.Duration) (*Client, error) {
+	if strings.TrimSpace(baseURL) == "" {
+		return nil, fmt.Errorf("AI engine base URL is required")
+	}
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	return &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// NewClientFromConfig creates an AI engine client from platform config. Requests made
+// on behalf of a user (see WithUser) count against cfg.UserQuota.
+func NewClientFromConfig(cfg platformconfig.AIEngineConfig) (*Client, error) {
+	client, err := NewClient(cfg.URL, cfg.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.UserQuota > 0 {
+		client.quota = newUserQuota(cfg.UserQuota, cfg.UserQuotaWindow)
+	}
+	return client, nil
+}
+
+type similarityRequest struct {
+	Text       string   `json:"text"`
+	Candidates []string `json:"candidates"`
+}
+
+type similarityResponse struct {
+	Scores []float64 `json:"scores"`
+}
+
+// Similarity returns the embedding cosine similarity between text and each candidate,
+// in the same order as candidates
+func (c *Client) Similarity(ctx context.Context, text string, candidates []string) ([]float64, error) {
+	if len(candidates) == 0 {
+		return []float64{}, nil
+	}
+
+	var out similarityResponse
+	if err := c.post(ctx, "/api/v1/similarity", similarityRequest{Text: text, Candidates: candidates}, &out); err != nil {
+		return nil, err
+	}
+	if len(out.Scores) != len(candidates) {
+		return nil, fmt.Errorf("AI engine returned %d scores for %d candidates", len(out.Scores), len(candidates))
+	}
+	return out.Scores, nil
+}
+
+type ingestRequest struct {
+	ID       string            `json:"id,omitempty"`
+	Text     string            `json:"text"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+type ingestResponse struct {
+	Status string `json:"status"`
+	ID     string `json:"id"`
+}
+
+// Ingest embeds text into the AI engine knowledge base. When id is set the document
+// is upserted, so re-ingesting the same source does not create duplicates.
+func (c *Client) Ingest(ctx context.Context, id, text string, metadata map[string]string) error {
+	var out ingestResponse
+	return c.post(ctx, "/api/v1/ingest", ingestRequest{ID: id, Text: text, Metadata: metadata}, &out)
+}
+
+// ThreadMessage is a post or comment sent for summarization
+type ThreadMessage struct {
+	Author string `json:"author"`
+	Text   string `json:"text"`
+}
+
+type threadSummaryRequest struct {
+	Post     ThreadMessage   `json:"post"`
+	Comments []ThreadMessage `json:"comments"`
+}
+
+type threadSummaryResponse struct {
+	Summary   string   `json:"summary"`
+	KeyPoints []string `json:"key_points"`
+}
+
+// SummarizeThread returns a short digest of a post and its comments, oldest comment
+// first, together with its key points
+func (c *Client) SummarizeThread(ctx context.Context, post ThreadMessage, comments []ThreadMessage) (string, []string, error) {
+	if comments == nil {
+		comments = []ThreadMessage{}
+	}
+
+	var out threadSummaryResponse
+	if err := c.post(ctx, "/api/v1/generate/thread-summary", threadSummaryRequest{Post: post, Comments: comments}, &out); err != nil {
+		return "", nil, err
+	}
+	return out.Summary, out.KeyPoints, nil
+}
+
+type conversationStartersRequest struct {
+	CommunityTopic string `json:"community_topic"`
+	Style          string `json:"style"`
+}
+
+// GenerateConversationStarters returns discussion prompts for a community about topic,
+// written in the tone style describes
+func (c *Client) GenerateConversationStarters(ctx context.Context, topic, style string) ([]string, error) {
+	var out []string
+	if err := c.post(ctx, "/api/v1/generate/conversation-starters", conversationStartersRequest{CommunityTopic: topic, Style: style}, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+type moderationRequest struct {
+	Content string `json:"content"`
+	Kind    string `json:"kind,omitempty"`
+}
+
+// ModerationScores are the AI engine's toxicity and spam scores for a piece of content,
+// each between 0 and 1
+type ModerationScores struct {
+	Toxicity float64 `json:"toxicity"`
+	Spam     float64 `json:"spam"`
+	Flagged  bool    `json:"flagged"`
+	Reason   string  `json:"reason,omitempty"`
+}
+
+// ScoreModeration scores a post or comment for toxicity and spam. kind is "post" or
+// "comment".
+func (c *Client) ScoreModeration(ctx context.Context, kind, text string) (*ModerationScores, error) {
+	var out ModerationScores
+	if err := c.post(ctx, "/api/v1/analyze/moderation", moderationRequest{Content: text, Kind: kind}, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// PostSearchQuery asks the AI engine for the posts closest in meaning to Query.
+// Empty filters match every post.
+type PostSearchQuery struct {
+	Query       string `json:"query"`
+	Limit       int    `json:"limit,omitempty"`
+	CommunityID string `json:"community_id,omitempty"`
+	AuthorID    string `json:"author_id,omitempty"`
+	From        int64  `json:"from,omitempty"` // Unix milliseconds, inclusive
+	To          int64  `json:"to,omitempty"`   // Unix milliseconds, exclusive
+}
+
+// PostSearchHit is a post the AI engine matched, with an excerpt of its ingested text
+type PostSearchHit struct {
+	PostID  string  `json:"post_id"`
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+type postSearchResponse struct {
+	Results []PostSearchHit `json:"results"`
+}
+
+// SearchPosts runs a semantic search over the posts ingested into the AI engine and
+// returns them best match first. A search within a community counts against its budget.
+func (c *Client) SearchPosts(ctx context.Context, query PostSearchQuery) ([]PostSearchHit, error) {
+	if query.CommunityID != "" {
+		ctx = WithCommunity(ctx, query.CommunityID)
+	}
+	var out postSearchResponse
+	if err := c.post(ctx, "/api/v1/knowledge/search", query, &out); err != nil {
+		return nil, err
+	}
+	return out.Results, nil
+}
+
+// PostDocument is the text of a post as the AI engine knowledge base stores it, with
+// the metadata semantic search filters on
+type PostDocument struct {
+	Text        string `json:"text"`
+	OwnerUserID string `json:"owner_user_id,omitempty"` // Empty for anonymous posts
+	CommunityID string `json:"community_id,omitempty"`
+	CreatedDate int64  `json:"created_date,omitempty"` // Unix milliseconds
+}
+
+type postIngestResponse struct {
+	PostID string `json:"post_id"`
+	Chunks int    `json:"chunks"`
+}
+
+// IngestPost replaces the post's chunks in the AI engine knowledge base with doc
+func (c *Client) IngestPost(ctx context.Context, postID string, doc PostDocument) error {
+	var out postIngestResponse
+	return c.do(ctx, http.MethodPut, "/api/v1/knowledge/posts/"+url.PathEscape(postID), doc, &out)
+}
+
+// DeletePost removes the post from the AI engine knowledge base
+func (c *Client) DeletePost(ctx context.Context, postID string) error {
+	var out map[string]interface{}
+	return c.do(ctx, http.MethodDelete, "/api/v1/knowledge/posts/"+url.PathEscape(postID), nil, &out)
+}
+
+// SystemInfo is what the AI engine reports about its build and connections
+type SystemInfo struct {
+	Service       string       `json:"service"`
+	Version       string       `json:"version"`
+	GitSHA        string       `json:"git_sha,omitempty"`
+	GoVersion     string       `json:"go_version"`
+	Completion    ProviderInfo `json:"completion"`
+	Embedding     ProviderInfo `json:"embedding"`
+	VectorStore   Dependency   `json:"vector_store"`
+	MaxConcurrent int          `json:"max_concurrent"`
+}
+
+// ProviderInfo names an LLM provider and the model used with it
+type ProviderInfo struct {
+	Provider string `json:"provider"`
+	Model    string `json:"model,omitempty"`
+}
+
+// Dependency is the version of a service the AI engine is connected to
+type Dependency struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"` // "up" or "down"
+	Version string `json:"version,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// SystemInfo returns the AI engine's version, LLM providers and vector store version
+func (c *Client) SystemInfo(ctx context.Context) (*SystemInfo, error) {
+	var out SystemInfo
+	if err := c.do(ctx, http.MethodGet, "/api/v1/system/info", nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// post sends a JSON request to the AI engine and decodes a JSON response into out
+func (c *Client) post(ctx context.Context, path string, payload interface{}, out interface{}) error {
+	return c.do(ctx, http.MethodPost, path, payload, out)
+}
+
+// do sends a request to the AI engine, with payload as its JSON body unless it is nil,
+// and decodes a JSON response into out
+func (c *Client) do(ctx context.Context, method, path string, payload interface{}, out interface{}) error {
+	if userID, _ := ctx.Value(userKey{}).(string); userID != "" && c.quota != nil {
+		if err := c.quota.charge(ctx, userID); err != nil {
+			return err
+		}
+	}
+
+	var body io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if id := log.RequestID(ctx); id != "" {
+		req.Header.Set(requestid.HeaderRequestID, id)
+	}
+	if communityID, _ := ctx.Value(communityKey{}).(string); communityID != "" {
+		req.Header.Set(communityHeader, communityID)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("AI engine request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read AI engine response: %w", err)
+	}
+	if resp.StatusCode == http.StatusTooManyRequests && budgetExceeded(respBody) {
+		return fmt.Errorf("%w: %s", ErrBudgetExceeded, string(respBody))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("AI engine returned status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode AI engine response: %w", err)
+	}
+	return nil
+}
+
+// budgetExceeded reports whether a 429 from the AI engine means a token budget is spent,
+// rather than a provider limiting its rate
+func budgetExceeded(body []byte) bool {
+	var out struct {
+		Code string `json:"code"`
+	}
+	return json.Unmarshal(body, &out) == nil && out.Code == "BUDGET_EXCEEDED"
+}
@@ -0,0 +1,227 @@
+package aiengine
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Similarity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/similarity", r.URL.Path)
+		var req similarityRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "new post", req.Text)
+		json.NewEncoder(w).Encode(similarityResponse{Scores: []float64{0.95, 0.1}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL+"/", time.Second)
+	require.NoError(t, err)
+
+	scores, err := client.Similarity(t.Context(), "new post", []string{"old post", "other"})
+	require.NoError(t, err)
+	assert.Equal(t, []float64{0.95, 0.1}, scores)
+}
+
+func TestClient_SimilarityErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	_, err = client.Similarity(t.Context(), "text", []string{"candidate"})
+	assert.Error(t, err)
+}
+
+func TestNewClient_RequiresURL(t *testing.T) {
+	_, err := NewClient(" ", time.Second)
+	assert.Error(t, err)
+}
+
+func TestClient_Ingest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/ingest", r.URL.Path)
+		var req ingestRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "doc-1", req.ID)
+		assert.Equal(t, "post", req.Metadata["source"])
+		json.NewEncoder(w).Encode(ingestResponse{Status: "success", ID: req.ID})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+	require.NoError(t, client.Ingest(t.Context(), "doc-1", "hello", map[string]string{"source": "post"}))
+}
+
+func TestClient_GenerateConversationStarters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/generate/conversation-starters", r.URL.Path)
+		var req conversationStartersRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "sourdough", req.CommunityTopic)
+		json.NewEncoder(w).Encode([]string{"What was your first loaf like?"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	starters, err := client.GenerateConversationStarters(t.Context(), "sourdough", "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"What was your first loaf like?"}, starters)
+}
+
+func TestClient_ScoreModeration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/analyze/moderation", r.URL.Path)
+		var req moderationRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "comment", req.Kind)
+		assert.Equal(t, "buy now", req.Content)
+		json.NewEncoder(w).Encode(ModerationScores{Toxicity: 0.1, Spam: 0.9, Flagged: true, Reason: "advertising"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	scores, err := client.ScoreModeration(t.Context(), "comment", "buy now")
+	require.NoError(t, err)
+	assert.Equal(t, 0.9, scores.Spam)
+	assert.True(t, scores.Flagged)
+	assert.Equal(t, "advertising", scores.Reason)
+}
+
+func TestClient_SearchPosts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/knowledge/search", r.URL.Path)
+		var req PostSearchQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, PostSearchQuery{Query: "cooking", Limit: 5, AuthorID: "author", From: 1000}, req)
+		json.NewEncoder(w).Encode(postSearchResponse{Results: []PostSearchHit{{PostID: "post-1", Snippet: "Cheap meals…", Score: 0.87}}})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	hits, err := client.SearchPosts(t.Context(), PostSearchQuery{Query: "cooking", Limit: 5, AuthorID: "author", From: 1000})
+	require.NoError(t, err)
+	assert.Equal(t, []PostSearchHit{{PostID: "post-1", Snippet: "Cheap meals…", Score: 0.87}}, hits)
+}
+
+func TestClient_SearchPostsBudgetExceeded(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "c1", r.Header.Get(communityHeader))
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"Token budget exceeded","code":"BUDGET_EXCEEDED"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	_, err = client.SearchPosts(t.Context(), PostSearchQuery{Query: "cooking", CommunityID: "c1"})
+	assert.ErrorIs(t, err, ErrBudgetExceeded)
+}
+
+func TestClient_RateLimitedIsNotBudget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get(communityHeader))
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error":"Too many concurrent requests"}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	_, err = client.SearchPosts(t.Context(), PostSearchQuery{Query: "cooking"})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrBudgetExceeded)
+}
+
+func TestClient_IngestAndDeletePost(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPut {
+			var doc PostDocument
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&doc))
+			assert.Equal(t, PostDocument{Text: "Cheap meals", OwnerUserID: "author", CreatedDate: 1000}, doc)
+			json.NewEncoder(w).Encode(postIngestResponse{PostID: "post-1", Chunks: 1})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"status": "deleted"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	require.NoError(t, client.IngestPost(t.Context(), "post-1", PostDocument{Text: "Cheap meals", OwnerUserID: "author", CreatedDate: 1000}))
+	require.NoError(t, client.DeletePost(t.Context(), "post-1"))
+	assert.Equal(t, []string{
+		"PUT /api/v1/knowledge/posts/post-1",
+		"DELETE /api/v1/knowledge/posts/post-1",
+	}, requests)
+}
+
+func TestClient_SystemInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/api/v1/system/info", r.URL.Path)
+		w.Write([]byte(`{"service":"ai-engine","version":"v1.0.0","completion":{"provider":"groq","model":"llama"},` +
+			`"vector_store":{"name":"weaviate","status":"up","version":"1.27.0"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+
+	info, err := client.SystemInfo(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", info.Version)
+	assert.Equal(t, ProviderInfo{Provider: "groq", Model: "llama"}, info.Completion)
+	assert.Equal(t, "1.27.0", info.VectorStore.Version)
+}
+
+func TestClient_UserQuota(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"scores":[0.5]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, time.Second)
+	require.NoError(t, err)
+	client.quota = &userQuota{limit: 1, window: time.Hour, counters: cache.NewGenericCacheServiceFor("aiquota-test"), now: time.Now}
+
+	ctx := WithUser(t.Context(), "user-1")
+	_, err = client.Similarity(ctx, "a", []string{"b"})
+	require.NoError(t, err)
+
+	_, err = client.Similarity(ctx, "a", []string{"b"})
+	assert.ErrorIs(t, err, ErrQuotaExceeded)
+	assert.True(t, OverQuota(err))
+	assert.Equal(t, 1, calls, "a request over quota is not sent")
+
+	_, err = client.Similarity(WithUser(t.Context(), "user-2"), "a", []string{"b"})
+	assert.NoError(t, err, "each user has their own quota")
+
+	_, err = client.Similarity(t.Context(), "a", []string{"b"})
+	assert.NoError(t, err, "requests without a user are not metered")
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,77 @@
+package aiengine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// ErrQuotaExceeded is returned once a user has made as many AI engine requests as their
+// quota allows in the current window. The request is not sent.
+var ErrQuotaExceeded = errors.New("AI engine user quota exceeded")
+
+type userKey struct{}
+
+// WithUser makes the AI engine requests made with ctx count against userID's quota.
+// Requests without a user, such as background jobs, are not metered.
+func WithUser(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userKey{}, userID)
+}
+
+// OverQuota reports whether err means the request was refused because a user quota or
+// a token budget is spent, so the caller should answer without AI
+func OverQuota(err error) bool {
+	return errors.Is(err, ErrQuotaExceeded) || errors.Is(err, ErrBudgetExceeded)
+}
+
+var (
+	sharedCountersOnce sync.Once
+	sharedCounters     *cache.GenericCacheService
+)
+
+// defaultCounters returns the process-wide quota counters, so every client in the
+// process counts against the same quota; Redis backends are shared across instances
+func defaultCounters() *cache.GenericCacheService {
+	sharedCountersOnce.Do(func() {
+		sharedCounters = cache.NewGenericCacheServiceFor("aiquota")
+	})
+	return sharedCounters
+}
+
+// userQuota counts each user's AI engine requests in fixed windows
+type userQuota struct {
+	limit    int64
+	window   time.Duration
+	counters *cache.GenericCacheService
+	now      func() time.Time
+}
+
+func newUserQuota(limit int, window time.Duration) *userQuota {
+	if window <= 0 {
+		window = time.Hour
+	}
+	return &userQuota{limit: int64(limit), window: window, counters: defaultCounters(), now: time.Now}
+}
+
+// charge counts a request against userID and returns ErrQuotaExceeded once the quota
+// for the current window is spent. The quota is soft: when the counters are unavailable
+// the request is allowed.
+func (q *userQuota) charge(ctx context.Context, userID string) error {
+	start := q.now().Truncate(q.window).Unix()
+	count, err := q.counters.Increment(ctx, fmt.Sprintf("user:%s:%d", userID, start), 1)
+	if err != nil {
+		if !errors.Is(err, cache.ErrCacheDisabled) {
+			log.Warn("AI engine quota for user %s not counted: %v", userID, err)
+		}
+		return nil
+	}
+	if count > q.limit {
+		return ErrQuotaExceeded
+	}
+	return nil
+}
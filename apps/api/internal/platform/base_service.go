@@ -69,6 +69,8 @@ func getDatabaseNameFromConfig(cfg *platformconfig.Config) string {
 	switch cfg.Database.Type {
 	case interfaces.DatabaseTypePostgreSQL:
 		return cfg.Database.Postgres.Database
+	case interfaces.DatabaseTypeMongoDB:
+		return cfg.Database.Mongo.Database
 	default:
 		return "telar_social" // Default database name
 	}
@@ -12,17 +12,49 @@ import (
 
 // Config represents the new, clean configuration structure
 type Config struct {
-	Server     ServerConfig     `json:"server"`
-	Database   DatabaseConfig   `json:"database"`
-	JWT        JWTConfig        `json:"jwt"`
-	HMAC       HMACConfig       `json:"hmac"`
-	Email      EmailConfig      `json:"email"`
-	Security   SecurityConfig   `json:"security"`
-	App        AppConfig        `json:"app"`
-	External   ExternalConfig   `json:"external"`
-	Cache      CacheConfig      `json:"cache"`
-	RateLimits RateLimitsConfig `json:"rateLimits"`
-	Storage    StorageConfig    `json:"storage"`
+	Server        ServerConfig        `json:"server"`
+	Database      DatabaseConfig      `json:"database"`
+	JWT           JWTConfig           `json:"jwt"`
+	HMAC          HMACConfig          `json:"hmac"`
+	Email         EmailConfig         `json:"email"`
+	Security      SecurityConfig      `json:"security"`
+	App           AppConfig           `json:"app"`
+	Log           LogConfig           `json:"log"`
+	External      ExternalConfig      `json:"external"`
+	Cache         CacheConfig         `json:"cache"`
+	RateLimits    RateLimitsConfig    `json:"rateLimits"`
+	Storage       StorageConfig       `json:"storage"`
+	OCR           OCRConfig           `json:"ocr"`
+	AIEngine      AIEngineConfig      `json:"aiEngine"`
+	Duplicates    DuplicatesConfig    `json:"duplicates"`
+	Moderation    ModerationConfig    `json:"moderation"`
+	Posts         PostsConfig         `json:"posts"`
+	HTTPCache     HTTPCacheConfig     `json:"httpCache"`
+	Analytics     AnalyticsConfig     `json:"analytics"`
+	Experiments   ExperimentsConfig   `json:"experiments"`
+	Hooks         HooksConfig         `json:"hooks"`
+	Schema        SchemaConfig        `json:"schema"`
+	ReadOnly      ReadOnlyConfig      `json:"readOnly"`
+	VoteIntegrity VoteIntegrityConfig `json:"voteIntegrity"`
+	ProfileViews  ProfileViewsConfig  `json:"profileViews"`
+	Realtime      RealtimeConfig      `json:"realtime"`
+	Achievements  AchievementsConfig  `json:"achievements"`
+	MFA           MFAConfig           `json:"mfa"`
+	Leaderboards  LeaderboardsConfig  `json:"leaderboards"`
+	Streaks       StreaksConfig       `json:"streaks"`
+	Supporters    SupportersConfig    `json:"supporters"`
+	Tips          TipsConfig          `json:"tips"`
+	Thanks        ThanksConfig        `json:"thanks"`
+	Calendar      CalendarConfig      `json:"calendar"`
+	LiveThreads   LiveThreadsConfig   `json:"liveThreads"`
+	Metrics       MetricsConfig       `json:"metrics"`
+	Membership    MembershipConfig    `json:"membership"`
+	Rules         RulesConfig         `json:"rules"`
+	Communities   CommunitiesConfig   `json:"communities"`
+	Comments      CommentsConfig      `json:"comments"`
+	Outbox        OutboxConfig        `json:"outbox"`
+	GRPC          GRPCConfig          `json:"grpc"`
+	DataMigration DataMigrationConfig `json:"dataMigration"`
 }
 
 // ServerConfig holds server-related configuration
@@ -40,6 +72,7 @@ type ServerConfig struct {
 type DatabaseConfig struct {
 	Type                  string           `json:"type"`
 	Postgres              PostgreSQLConfig `json:"postgres"`
+	Mongo                 MongoDBConfig    `json:"mongo"`
 	ForceNonTransactional bool             `json:"forceNonTransactional"`
 }
 
@@ -55,6 +88,47 @@ type PostgreSQLConfig struct {
 	MaxOpenConns    int           `json:"maxOpenConns"`
 	MaxIdleConns    int           `json:"maxIdleConns"`
 	ConnMaxLifetime time.Duration `json:"connMaxLifetime"`
+	// Modules holds the schema and credentials of the modules that keep their tables
+	// apart (POSTGRES_<MODULE>_SCHEMA, _USERNAME, _PASSWORD). A module missing here
+	// lives in the shared schema and connects with the shared credentials.
+	Modules map[string]PostgresModuleConfig `json:"modules"`
+}
+
+// PostgresModules are the modules that can keep their tables in a schema of their own
+var PostgresModules = []string{"auth", "posts", "comments", "profile"}
+
+// PostgresModuleConfig is where one module's tables live and the role its service
+// binary connects as. An empty Schema means the shared schema and an empty Username
+// the shared credentials.
+type PostgresModuleConfig struct {
+	Schema   string `json:"schema"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// loadPostgresModules reads the per-module database settings; modules with none set
+// are left out
+func loadPostgresModules(get func(key, defaultValue string) string) map[string]PostgresModuleConfig {
+	modules := map[string]PostgresModuleConfig{}
+	for _, module := range PostgresModules {
+		prefix := "POSTGRES_" + strings.ToUpper(module) + "_"
+		moduleConfig := PostgresModuleConfig{
+			Schema:   get(prefix+"SCHEMA", ""),
+			Username: get(prefix+"USERNAME", ""),
+			Password: get(prefix+"PASSWORD", ""),
+		}
+		if moduleConfig != (PostgresModuleConfig{}) {
+			modules[module] = moduleConfig
+		}
+	}
+	return modules
+}
+
+// MongoDBConfig holds MongoDB-specific configuration
+type MongoDBConfig struct {
+	URI         string `json:"uri"`
+	Database    string `json:"database"`
+	MaxPoolSize int    `json:"maxPoolSize"`
 }
 
 // JWTConfig holds JWT-related configuration
@@ -96,6 +170,15 @@ type AppConfig struct {
 	QueryPrettyURL bool   `json:"queryPrettyUrl"`
 }
 
+// LogConfig holds structured logging settings
+type LogConfig struct {
+	Format string `json:"format"` // "text" or "json"
+	Level  string `json:"level"`  // "debug", "info", "warn" or "error"
+	// TraceBuffer is how many recent records logged during requests are kept in
+	// memory for admins to look up by request ID; 0 keeps none
+	TraceBuffer int `json:"traceBuffer"`
+}
+
 // ExternalConfig holds external service configuration
 type ExternalConfig struct {
 	GitHubClientID    string `json:"githubClientId"`
@@ -127,10 +210,15 @@ type RateLimitConfig struct {
 
 // RateLimitsConfig holds rate limiting configuration for all endpoints
 type RateLimitsConfig struct {
-	Signup        RateLimitConfig `json:"signup"`
-	Login         RateLimitConfig `json:"login"`
-	PasswordReset RateLimitConfig `json:"passwordReset"`
-	Verification  RateLimitConfig `json:"verification"`
+	Signup         RateLimitConfig `json:"signup"`
+	Login          RateLimitConfig `json:"login"`
+	PasswordReset  RateLimitConfig `json:"passwordReset"`
+	Verification   RateLimitConfig `json:"verification"`
+	CommentPreview RateLimitConfig `json:"commentPreview"` // Post reads with includeComments=preview
+	PostCreate     RateLimitConfig `json:"postCreate"`     // Post creation, per user
+	CommentExport  RateLimitConfig `json:"commentExport"`  // Post owners' comment exports, per user
+	API            RateLimitConfig `json:"api"`            // Soft budget of all authenticated requests, per user; reported in headers, never enforced
+	Storage        string          `json:"storage"`        // "memory" (per instance) or "redis" (shared, uses the cache Redis settings)
 }
 
 // RedisConfig holds Redis-specific configuration
@@ -158,18 +246,302 @@ type ClusterConfig struct {
 
 // StorageConfig holds storage provider configuration (Cloudflare R2, AWS S3, etc.)
 type StorageConfig struct {
-	Provider        string   `json:"provider"`         // "r2", "s3", "gcs"
-	AccountID       string   `json:"accountId"`        // Cloudflare Account ID (for R2)
-	AccessKeyID     string   `json:"accessKeyId"`      // Access Key ID
-	SecretAccessKey string   `json:"secretAccessKey"`  // Secret Access Key
-	BucketName      string   `json:"bucketName"`        // Bucket name
-	PublicURL       string   `json:"publicUrl"`        // Public CDN URL (e.g., https://media.telar.press)
-	Region          string   `json:"region"`           // Region (for S3 compatibility)
-	Endpoint              string   `json:"endpoint"`                // Custom endpoint (for R2: https://<account-id>.r2.cloudflarestorage.com)
-	MaxFileSizeMB         int      `json:"maxFileSizeMB"`           // Maximum file size in MB (hard cap, requires client-side compression)
-	AllowedMimeTypes      []string `json:"allowedMimeTypes"`        // Allowed MIME types (comma-separated in env)
-	GlobalDailyUploadLimit int     `json:"globalDailyUploadLimit"`  // Global daily upload limit (Class A protection)
-	UserDailyUploadLimit   int     `json:"userDailyUploadLimit"`    // Per-user daily upload limit
+	Provider               string   `json:"provider"`               // "r2", "s3" or "minio"
+	AccountID              string   `json:"accountId"`              // Cloudflare Account ID (for R2)
+	AccessKeyID            string   `json:"accessKeyId"`            // Access Key ID
+	SecretAccessKey        string   `json:"secretAccessKey"`        // Secret Access Key
+	BucketName             string   `json:"bucketName"`             // Bucket name
+	PublicURL              string   `json:"publicUrl"`              // Public CDN URL (e.g., https://media.telar.press)
+	Region                 string   `json:"region"`                 // Region (for S3 compatibility)
+	Endpoint               string   `json:"endpoint"`               // Custom endpoint (for R2: https://<account-id>.r2.cloudflarestorage.com)
+	MaxFileSizeMB          int      `json:"maxFileSizeMB"`          // Maximum file size in MB (hard cap, requires client-side compression)
+	AllowedMimeTypes       []string `json:"allowedMimeTypes"`       // Allowed MIME types (comma-separated in env)
+	GlobalDailyUploadLimit int      `json:"globalDailyUploadLimit"` // Global daily upload limit (Class A protection)
+	UserDailyUploadLimit   int      `json:"userDailyUploadLimit"`   // Per-user daily upload limit
+	ForcePathStyle         bool     `json:"forcePathStyle"`         // Path-style bucket addressing (always on for r2 and minio)
+	ThumbnailSize          int      `json:"thumbnailSize"`          // Longest side of a thumbnail in pixels
+	// The image pipeline resizes confirmed uploads into variants and strips EXIF. It runs
+	// inside the API server unless ImagePipelineEmbedded is off and cmd/services/media runs it.
+	ImagePipelineEmbedded bool          `json:"imagePipelineEmbedded"`
+	ImageWorkers          int           `json:"imageWorkers"`      // Images processed concurrently
+	ImageBatchSize        int           `json:"imageBatchSize"`    // Uploads claimed per poll
+	ImagePollInterval     time.Duration `json:"imagePollInterval"` // How often to look for uploads; the embedded pipeline is also woken by each upload
+}
+
+// OCRConfig holds configuration for extracting text from uploaded post images
+type OCRConfig struct {
+	Enabled        bool          `json:"enabled"`
+	Provider       string        `json:"provider"`       // "tesseract" or "vision"
+	TesseractPath  string        `json:"tesseractPath"`  // Path to the tesseract binary
+	Language       string        `json:"language"`       // Tesseract language code (e.g., "eng")
+	VisionEndpoint string        `json:"visionEndpoint"` // OpenAI-compatible chat completions URL
+	VisionAPIKey   string        `json:"visionApiKey"`
+	VisionModel    string        `json:"visionModel"`
+	AllowedHosts   []string      `json:"allowedHosts"`  // Hosts images are read from, besides the storage public URL host
+	Workers        int           `json:"workers"`       // Concurrent OCR jobs
+	QueueSize      int           `json:"queueSize"`     // Pending jobs before new ones are dropped
+	Timeout        time.Duration `json:"timeout"`       // Per-image extraction timeout
+	MaxTextLength  int           `json:"maxTextLength"` // Maximum characters stored per post
+}
+
+// AIEngineConfig holds connection settings for the AI engine service
+type AIEngineConfig struct {
+	URL             string        `json:"url"` // Base URL, e.g. http://localhost:8000; empty disables AI features
+	Timeout         time.Duration `json:"timeout"`
+	UserQuota       int           `json:"userQuota"`       // AI requests a user may make per window; 0 disables the quota
+	UserQuotaWindow time.Duration `json:"userQuotaWindow"` // Window the user quota is counted over
+}
+
+// DuplicatesConfig holds duplicate post detection settings
+type DuplicatesConfig struct {
+	Enabled             bool          `json:"enabled"`
+	Policy              string        `json:"policy"`              // "warn" or "block"
+	SimilarityThreshold float64       `json:"similarityThreshold"` // Cosine similarity at or above which posts count as near-duplicates
+	Window              time.Duration `json:"window"`              // How far back to look for duplicates
+	MaxCandidates       int           `json:"maxCandidates"`       // Recent posts by the same author compared by embedding
+}
+
+// ModerationConfig holds settings for screening new posts and comments with the AI
+// engine and flagging high-scoring ones into the moderation queue
+type ModerationConfig struct {
+	AutoFlagEnabled   bool    `json:"autoFlagEnabled"`   // Score new posts and comments; needs the AI engine
+	AutoFlagThreshold float64 `json:"autoFlagThreshold"` // Toxicity or spam score at or above which content is reported
+	AutoFlagWorkers   int     `json:"autoFlagWorkers"`   // Concurrent scoring requests
+	AutoFlagQueueSize int     `json:"autoFlagQueueSize"` // Content waiting to be scored; more is skipped
+}
+
+// PostsConfig holds post presentation settings
+type PostsConfig struct {
+	PreviewLength        int           `json:"previewLength"`        // Max characters of bodyPreview on feed items
+	TruncateFeedBodies   bool          `json:"truncateFeedBodies"`   // Drop the full body of truncated feed items unless the client asks for it
+	ExpiryInterval       time.Duration `json:"expiryInterval"`       // Time between expiry job runs; 0 disables the job
+	ExpiryBatchSize      int           `json:"expiryBatchSize"`      // Posts archived per statement
+	TrendingTagsInterval time.Duration `json:"trendingTagsInterval"` // Time between trending tag counts; 0 disables the job
+	TrendingTagsSize     int           `json:"trendingTagsSize"`     // Tags kept per trending window
+	AnonymousMaxRange    time.Duration `json:"anonymousMaxRange"`    // Widest since/until range anonymous readers may ask for
+}
+
+// HTTPCacheConfig holds response caching for anonymous public endpoints
+type HTTPCacheConfig struct {
+	Enabled bool          `json:"enabled"`
+	MaxAge  time.Duration `json:"maxAge"` // Cache-Control max-age sent to browsers and CDNs
+	TTL     time.Duration `json:"ttl"`    // Server-side lifetime; writes invalidate entries earlier
+}
+
+// AnalyticsConfig holds client telemetry collection settings for POST /events
+type AnalyticsConfig struct {
+	EventsEnabled        bool    `json:"eventsEnabled"`
+	MaxBatchSize         int     `json:"maxBatchSize"`
+	RateLimitPerMinute   int     `json:"rateLimitPerMinute"`   // Batches per client IP per minute
+	ImpressionSampleRate float64 `json:"impressionSampleRate"` // Fraction of events stored, 0-1
+	DwellSampleRate      float64 `json:"dwellSampleRate"`
+	ClickSampleRate      float64 `json:"clickSampleRate"`
+}
+
+// ExperimentsConfig holds A/B testing settings and guardrails
+type ExperimentsConfig struct {
+	Enabled      bool          `json:"enabled"`      // When false no user is assigned to any experiment
+	ForceControl []string      `json:"forceControl"` // Experiment keys whose audience all receive the control variant (kill switch)
+	CacheTTL     time.Duration `json:"cacheTTL"`     // How long experiment definitions are cached in process
+}
+
+// HooksConfig holds server-side extension hooks (Go plugins and webhooks)
+type HooksConfig struct {
+	PluginDir string        `json:"pluginDir"` // Directory of Go plugins (*.so) loaded at startup
+	Webhooks  []string      `json:"webhooks"`  // "point=url" entries, e.g. post.beforeCreate=https://hooks.example.com/posts
+	Timeout   time.Duration `json:"timeout"`   // Limit for each hook call
+	FailOpen  bool          `json:"failOpen"`  // When true a failing before-hook is skipped instead of failing the request
+
+	WebhookSecret     string        `json:"-"`                 // Signs webhook requests (X-Telar-Signature); unsigned when empty
+	DeliveryRetention time.Duration `json:"deliveryRetention"` // How long webhook deliveries are kept for inspection and redelivery
+}
+
+// SchemaConfig holds the startup schema compatibility gate
+type SchemaConfig struct {
+	CheckPolicy string `json:"checkPolicy"` // On mismatch: "refuse", "readonly", "warn" or "off"
+}
+
+// ReadOnlyConfig holds the degraded read-only mode switch
+type ReadOnlyConfig struct {
+	Enabled      bool          `json:"enabled"`      // Force read-only mode from the environment (overrides the admin setting)
+	Reason       string        `json:"reason"`       // Reason reported to clients while forced on
+	RetryAfter   time.Duration `json:"retryAfter"`   // Retry-After sent with rejected writes
+	PollInterval time.Duration `json:"pollInterval"` // How often each instance re-reads the admin setting
+}
+
+// VoteIntegrityConfig holds the vote manipulation heuristics job
+type VoteIntegrityConfig struct {
+	Enabled            bool          `json:"enabled"`            // Run the scan job in this process
+	Interval           time.Duration `json:"interval"`           // Time between scans
+	Lookback           time.Duration `json:"lookback"`           // Vote history each scan examines
+	FlipFlopMinChanges int           `json:"flipFlopMinChanges"` // Vote events by one user on one post that raise a flag
+	BurstWindow        time.Duration `json:"burstWindow"`        // Votes this close together count as voting together
+	RingMinAccounts    int           `json:"ringMinAccounts"`    // Accounts needed to flag a voting ring
+	RingMinSharedPosts int           `json:"ringMinSharedPosts"` // Posts of one author two accounts must vote on together
+}
+
+// ProfileViewsConfig holds who-viewed-my-profile tracking
+type ProfileViewsConfig struct {
+	Enabled       bool          `json:"enabled"`       // Record profile views
+	Retention     time.Duration `json:"retention"`     // Views older than this are deleted
+	PurgeInterval time.Duration `json:"purgeInterval"` // Time between retention purges; 0 disables the job
+}
+
+// RealtimeConfig holds the WebSocket gateway for feed and notification events
+type RealtimeConfig struct {
+	Enabled               bool          `json:"enabled"`               // Serve GET /realtime/ws
+	MaxConnectionsPerUser int           `json:"maxConnectionsPerUser"` // Open sockets allowed per account in this process
+	SendBuffer            int           `json:"sendBuffer"`            // Events queued per connection; a client that falls further behind is disconnected
+	PingInterval          time.Duration `json:"pingInterval"`          // Keepalive ping period; a client silent for twice this long is dropped
+}
+
+// AchievementsConfig holds the badge evaluator
+type AchievementsConfig struct {
+	Enabled   bool `json:"enabled"`   // Grant badges as domain events arrive; listings work either way
+	QueueSize int  `json:"queueSize"` // Events waiting for evaluation; further events are dropped until it drains
+}
+
+// MFAConfig holds the second factor login step
+type MFAConfig struct {
+	Issuer       string        `json:"issuer"`       // Name shown next to the code in authenticator apps
+	ChallengeTTL time.Duration `json:"challengeTTL"` // Time between the password step and the code step of a login
+}
+
+// LeaderboardsConfig holds the leaderboard refresh job
+type LeaderboardsConfig struct {
+	RefreshInterval time.Duration `json:"refreshInterval"` // Time between refreshes; 0 disables the job
+	Size            int           `json:"size"`            // Users kept on each board
+}
+
+// StreaksConfig holds the activity streak job and its reminders
+type StreaksConfig struct {
+	JobInterval      time.Duration `json:"jobInterval"`      // Time between runs; each completed day is recorded on the first run after midnight UTC. 0 disables the job
+	RemindersEnabled bool          `json:"remindersEnabled"` // Notify opted-in users before their streak ends
+	ReminderWindow   time.Duration `json:"reminderWindow"`   // Reminders go out during this last part of the UTC day
+	ReminderMinDays  int           `json:"reminderMinDays"`  // Shorter streaks get no reminder
+}
+
+// SupportersConfig holds supporter tiers and supporter-only posts
+type SupportersConfig struct {
+	Enabled      bool `json:"enabled"`      // Serve /supporters and allow supporter-only posts
+	TeaserLength int  `json:"teaserLength"` // Characters of a supporter-only post non-supporters see
+	MaxTiers     int  `json:"maxTiers"`     // Tiers a creator may offer at once; 0 means no limit
+}
+
+// TipsConfig holds tipping between users. Amounts are in the minor unit of the tip's currency.
+type TipsConfig struct {
+	Enabled        bool     `json:"enabled"`        // Serve /tips
+	Currencies     []string `json:"currencies"`     // ISO 4217 codes tips may be sent in
+	MinAmountCents int64    `json:"minAmountCents"` // Smallest tip
+	MaxAmountCents int64    `json:"maxAmountCents"` // Largest tip
+	FeeBasisPoints int      `json:"feeBasisPoints"` // Platform fee as a share of the tip; 500 is 5%
+	FeeFixedCents  int64    `json:"feeFixedCents"`  // Platform fee added per tip
+	ShowCounts     bool     `json:"showCounts"`     // Include tip counts in post responses
+}
+
+// ThanksConfig holds the thanks button, a money-free appreciation signal with a weekly allowance
+type ThanksConfig struct {
+	Enabled         bool          `json:"enabled"`         // Serve /thanks
+	WeeklyAllowance int           `json:"weeklyAllowance"` // Thanks each user may give per week; weeks start Monday 00:00 UTC
+	JobInterval     time.Duration `json:"jobInterval"`     // Time between allowance resets; 0 disables the job
+}
+
+// CalendarConfig holds event posts, the calendar listing them and its iCal subscription feed
+type CalendarConfig struct {
+	Enabled   bool          `json:"enabled"`   // Serve /calendar
+	FeedLimit int           `json:"feedLimit"` // Most events in one iCal feed response
+	FeedPast  time.Duration `json:"feedPast"`  // How far back the iCal feed reaches; later events are all included
+}
+
+// LiveThreadsConfig holds live threads: posts whose comments stream in real time during
+// a scheduled window and are summarized once it ends
+type LiveThreadsConfig struct {
+	Enabled         bool          `json:"enabled"`         // Serve /posts/:postId/live and run the close job
+	SlowModeSeconds int           `json:"slowModeSeconds"` // Default seconds between one user's comments while live
+	MaxDuration     time.Duration `json:"maxDuration"`     // Longest window a post owner may schedule
+	JobInterval     time.Duration `json:"jobInterval"`     // Time between runs of the job closing and summarizing ended threads
+	SummaryComments int           `json:"summaryComments"` // Most comments sent to the AI engine per summary
+}
+
+// MetricsConfig holds the Prometheus endpoint every service exposes
+type MetricsConfig struct {
+	Enabled bool   `json:"enabled"` // Record request, database, cache and gRPC metrics and serve them
+	Path    string `json:"path"`    // Route the metrics are served on
+	Token   string `json:"-"`       // Bearer token scrapers must send; empty leaves the endpoint open
+}
+
+// MembershipConfig holds the limits of membership approval: applicants to a community
+// that requires it answer its join questions and join once a moderator approves them
+type MembershipConfig struct {
+	MaxQuestions    int `json:"maxQuestions"`    // Most join questions moderators may set
+	MaxAnswerLength int `json:"maxAnswerLength"` // Longest answer, in characters
+}
+
+// RulesConfig bounds the rules each community's members acknowledge before their first
+// post or comment there
+type RulesConfig struct {
+	MaxRules      int `json:"maxRules"`      // Most rules a community's moderators may set
+	MaxRuleLength int `json:"maxRuleLength"` // Longest rule body, in characters
+}
+
+// CommunitiesConfig holds the conversation starter scheduler. Each community opts in
+// and sets its own cadence; these settings bound the job as a whole.
+type CommunitiesConfig struct {
+	StartersEnabled    bool          `json:"startersEnabled"`    // Run the scheduler in this process; needs the AI engine
+	StartersInterval   time.Duration `json:"startersInterval"`   // Time between scheduler runs
+	StartersBatch      int           `json:"startersBatch"`      // Most communities drafted for in one run
+	StartersMaxPending int           `json:"startersMaxPending"` // Drafts awaiting review that stop a community getting more
+}
+
+// CommentsConfig holds comment threading
+type CommentsConfig struct {
+	MaxDepth              int           `json:"maxDepth"`              // Deepest reply level; replies to comments at this level join their parent's replies
+	ProfileRepairInterval time.Duration `json:"profileRepairInterval"` // Time between repairs of stored owner names and avatars; 0 disables the job
+}
+
+// OutboxConfig holds the transactional outbox. When enabled, cross-service effects such as
+// post comment counts are written as events in the same transaction and applied by
+// consumers, instead of being written directly.
+type OutboxConfig struct {
+	Enabled      bool          `json:"enabled"`
+	Broker       string        `json:"broker"`       // "local" (in process), "nats" or "kafka"
+	NATSURL      string        `json:"natsUrl"`      // e.g. nats://localhost:4222
+	KafkaRESTURL string        `json:"kafkaRestUrl"` // Kafka REST Proxy, e.g. http://localhost:8082
+	TopicPrefix  string        `json:"topicPrefix"`  // Prepended to event types to name subjects and topics
+	BatchSize    int           `json:"batchSize"`    // Events published per poll
+	PollInterval time.Duration `json:"pollInterval"` // How often the dispatcher looks for events when not woken
+	Retention    time.Duration `json:"retention"`    // How long published events and consumer receipts are kept
+
+	ProfilePropagationAttempts int `json:"profilePropagationAttempts"` // Attempts of each service at copying a changed name and avatar
+}
+
+// DataMigrationConfig holds the move of legacy JSONB collections to their typed tables.
+// Writes to the listed collections are mirrored into the typed tables, so a backfill and
+// verification can run while the services stay up.
+type DataMigrationConfig struct {
+	DualWrite []string `json:"dualWrite"` // Legacy collections mirrored on write, e.g. userAuth,userProfile
+}
+
+// GRPCConfig secures the internal gRPC calls between services in microservices mode.
+// The same settings serve a binary's gRPC server and the clients it dials.
+type GRPCConfig struct {
+	TLSCertFile   string `json:"tlsCertFile"`   // This service's certificate, served and presented to peers; enables TLS
+	TLSKeyFile    string `json:"tlsKeyFile"`    // Private key of TLSCertFile
+	TLSCAFile     string `json:"tlsCaFile"`     // CA verifying peers; servers then require client certificates (mTLS)
+	TLSServerName string `json:"tlsServerName"` // Name checked in server certificates instead of the dialed host
+
+	AuthSecret string `json:"-"` // Shared secret signing every call's metadata; calls are not authenticated when empty
+
+	KeepaliveTime     time.Duration `json:"keepaliveTime"`     // Idle time after which a connection is pinged
+	KeepaliveTimeout  time.Duration `json:"keepaliveTimeout"`  // How long a ping may go unanswered before the connection is closed
+	MaxRetries        int           `json:"maxRetries"`        // Retries of calls failing with UNAVAILABLE; 0 disables retries
+	RetryBackoff      time.Duration `json:"retryBackoff"`      // Initial backoff between retries and reconnects, doubled each time
+	ConnectBackoffMax time.Duration `json:"connectBackoffMax"` // Upper bound of the retry and reconnect backoff
+}
+
+// TLSEnabled reports whether gRPC connections use TLS
+func (c GRPCConfig) TLSEnabled() bool {
+	return c.TLSCertFile != "" || c.TLSCAFile != ""
 }
 
 // LoadFromEnv loads configuration from the environment.
@@ -229,6 +601,12 @@ func LoadFromEnv() (*Config, error) {
 				MaxOpenConns:    getEnvAsInt("POSTGRES_MAX_OPEN_CONNS", 25),
 				MaxIdleConns:    getEnvAsInt("POSTGRES_MAX_IDLE_CONNS", 25),
 				ConnMaxLifetime: time.Duration(getEnvAsInt("POSTGRES_CONN_MAX_LIFETIME", 300)) * time.Second,
+				Modules:         loadPostgresModules(getEnvOrDefault),
+			},
+			Mongo: MongoDBConfig{
+				URI:         getEnvOrDefault("MONGO_URI", "mongodb://localhost:27017"),
+				Database:    getEnvOrDefault("MONGO_DATABASE", "telar"),
+				MaxPoolSize: getEnvAsInt("MONGO_MAX_POOL_SIZE", 100),
 			},
 		},
 		JWT: JWTConfig{
@@ -260,6 +638,11 @@ func LoadFromEnv() (*Config, error) {
 			OrgAvatar:      getEnvOrDefault("ORG_AVATAR", ""),
 			QueryPrettyURL: getEnvAsBool("QUERY_PRETTY_URL", false),
 		},
+		Log: LogConfig{
+			Format:      getEnvOrDefault("LOG_FORMAT", "text"),
+			Level:       getEnvOrDefault("LOG_LEVEL", "info"),
+			TraceBuffer: getEnvAsInt("LOG_TRACE_BUFFER", 5000),
+		},
 		External: ExternalConfig{
 			GitHubClientID:    getEnvOrDefault("GITHUB_CLIENT_ID", ""),
 			GitHubSecret:      getEnvOrDefault("GITHUB_SECRET", ""),
@@ -316,20 +699,244 @@ func LoadFromEnv() (*Config, error) {
 				Max:      getEnvAsInt("RATE_LIMIT_VERIFICATION_MAX", 10),
 				Duration: getEnvAsDuration("RATE_LIMIT_VERIFICATION_DURATION", 15*time.Minute),
 			},
+			CommentPreview: RateLimitConfig{
+				Enabled:  getEnvAsBool("RATE_LIMIT_COMMENT_PREVIEW_ENABLED", true),
+				Max:      getEnvAsInt("RATE_LIMIT_COMMENT_PREVIEW_MAX", 120),
+				Duration: getEnvAsDuration("RATE_LIMIT_COMMENT_PREVIEW_DURATION", 1*time.Minute),
+			},
+			PostCreate: RateLimitConfig{
+				Enabled:  getEnvAsBool("RATE_LIMIT_POST_CREATE_ENABLED", true),
+				Max:      getEnvAsInt("RATE_LIMIT_POST_CREATE_MAX", 30),
+				Duration: getEnvAsDuration("RATE_LIMIT_POST_CREATE_DURATION", 1*time.Hour),
+			},
+			CommentExport: RateLimitConfig{
+				Enabled:  getEnvAsBool("RATE_LIMIT_COMMENT_EXPORT_ENABLED", true),
+				Max:      getEnvAsInt("RATE_LIMIT_COMMENT_EXPORT_MAX", 10),
+				Duration: getEnvAsDuration("RATE_LIMIT_COMMENT_EXPORT_DURATION", 1*time.Hour),
+			},
+			API: RateLimitConfig{
+				Enabled:  getEnvAsBool("RATE_LIMIT_API_ENABLED", true),
+				Max:      getEnvAsInt("RATE_LIMIT_API_MAX", 600),
+				Duration: getEnvAsDuration("RATE_LIMIT_API_DURATION", 1*time.Minute),
+			},
+			Storage: getEnvOrDefault("RATE_LIMIT_STORAGE", "memory"),
 		},
 		Storage: StorageConfig{
-			Provider:              getEnvOrDefault("STORAGE_PROVIDER", "r2"),
-			AccountID:             getEnvOrDefault("R2_ACCOUNT_ID", ""),
-			AccessKeyID:           getEnvOrDefault("R2_ACCESS_KEY_ID", ""),
-			SecretAccessKey:       getEnvOrDefault("R2_SECRET_ACCESS_KEY", ""),
-			BucketName:            getEnvOrDefault("R2_BUCKET_NAME", ""),
-			PublicURL:             getEnvOrDefault("R2_PUBLIC_URL", ""),
-			Region:                getEnvOrDefault("R2_REGION", "auto"),
-			Endpoint:              getEnvOrDefault("R2_ENDPOINT", ""),
-			MaxFileSizeMB:         getEnvAsInt("STORAGE_MAX_FILE_SIZE_MB", 2),
-			AllowedMimeTypes:      parseCommaSeparated(getEnvOrDefault("STORAGE_ALLOWED_MIME_TYPES", "image/jpeg,image/png,image/webp")),
+			Provider:               getEnvOrDefault("STORAGE_PROVIDER", "r2"),
+			AccountID:              getEnvOrDefault("R2_ACCOUNT_ID", ""),
+			AccessKeyID:            getEnvOrDefault("STORAGE_ACCESS_KEY_ID", getEnvOrDefault("R2_ACCESS_KEY_ID", "")),
+			SecretAccessKey:        getEnvOrDefault("STORAGE_SECRET_ACCESS_KEY", getEnvOrDefault("R2_SECRET_ACCESS_KEY", "")),
+			BucketName:             getEnvOrDefault("STORAGE_BUCKET_NAME", getEnvOrDefault("R2_BUCKET_NAME", "")),
+			PublicURL:              getEnvOrDefault("STORAGE_PUBLIC_URL", getEnvOrDefault("R2_PUBLIC_URL", "")),
+			Region:                 getEnvOrDefault("STORAGE_REGION", getEnvOrDefault("R2_REGION", "auto")),
+			Endpoint:               getEnvOrDefault("STORAGE_ENDPOINT", getEnvOrDefault("R2_ENDPOINT", "")),
+			MaxFileSizeMB:          getEnvAsInt("STORAGE_MAX_FILE_SIZE_MB", 2),
+			AllowedMimeTypes:       parseCommaSeparated(getEnvOrDefault("STORAGE_ALLOWED_MIME_TYPES", "image/jpeg,image/png,image/webp")),
 			GlobalDailyUploadLimit: getEnvAsInt("STORAGE_GLOBAL_DAILY_UPLOAD_LIMIT", 30000),
 			UserDailyUploadLimit:   getEnvAsInt("STORAGE_USER_DAILY_UPLOAD_LIMIT", 20),
+			ForcePathStyle:         getEnvAsBool("STORAGE_FORCE_PATH_STYLE", false),
+			ThumbnailSize:          getEnvAsInt("STORAGE_THUMBNAIL_SIZE", 320),
+			ImagePipelineEmbedded:  getEnvAsBool("STORAGE_IMAGE_PIPELINE_EMBEDDED", true),
+			ImageWorkers:           getEnvAsInt("STORAGE_IMAGE_WORKERS", 2),
+			ImageBatchSize:         getEnvAsInt("STORAGE_IMAGE_BATCH_SIZE", 20),
+			ImagePollInterval:      getEnvAsDuration("STORAGE_IMAGE_POLL_INTERVAL", 15*time.Second),
+		},
+		OCR: OCRConfig{
+			Enabled:        getEnvAsBool("OCR_ENABLED", false),
+			Provider:       getEnvOrDefault("OCR_PROVIDER", "tesseract"),
+			TesseractPath:  getEnvOrDefault("OCR_TESSERACT_PATH", "tesseract"),
+			Language:       getEnvOrDefault("OCR_LANGUAGE", "eng"),
+			VisionEndpoint: getEnvOrDefault("OCR_VISION_ENDPOINT", ""),
+			VisionAPIKey:   getEnvOrDefault("OCR_VISION_API_KEY", ""),
+			VisionModel:    getEnvOrDefault("OCR_VISION_MODEL", ""),
+			AllowedHosts:   parseCommaSeparated(getEnvOrDefault("OCR_ALLOWED_HOSTS", "")),
+			Workers:        getEnvAsInt("OCR_WORKERS", 2),
+			QueueSize:      getEnvAsInt("OCR_QUEUE_SIZE", 100),
+			Timeout:        getEnvAsDuration("OCR_TIMEOUT", 30*time.Second),
+			MaxTextLength:  getEnvAsInt("OCR_MAX_TEXT_LENGTH", 5000),
+		},
+		AIEngine: AIEngineConfig{
+			URL:             getEnvOrDefault("AI_ENGINE_URL", ""),
+			Timeout:         getEnvAsDuration("AI_ENGINE_TIMEOUT", 10*time.Second),
+			UserQuota:       getEnvAsInt("AI_ENGINE_USER_QUOTA", 100),
+			UserQuotaWindow: getEnvAsDuration("AI_ENGINE_USER_QUOTA_WINDOW", time.Hour),
+		},
+		Duplicates: DuplicatesConfig{
+			Enabled:             getEnvAsBool("DUPLICATE_DETECTION_ENABLED", false),
+			Policy:              getEnvOrDefault("DUPLICATE_DETECTION_POLICY", "warn"),
+			SimilarityThreshold: getEnvAsFloat("DUPLICATE_DETECTION_SIMILARITY_THRESHOLD", 0.92),
+			Window:              getEnvAsDuration("DUPLICATE_DETECTION_WINDOW", 7*24*time.Hour),
+			MaxCandidates:       getEnvAsInt("DUPLICATE_DETECTION_MAX_CANDIDATES", 20),
+		},
+		Moderation: ModerationConfig{
+			AutoFlagEnabled:   getEnvAsBool("MODERATION_AUTO_FLAG_ENABLED", false),
+			AutoFlagThreshold: getEnvAsFloat("MODERATION_AUTO_FLAG_THRESHOLD", 0.8),
+			AutoFlagWorkers:   getEnvAsInt("MODERATION_AUTO_FLAG_WORKERS", 2),
+			AutoFlagQueueSize: getEnvAsInt("MODERATION_AUTO_FLAG_QUEUE_SIZE", 200),
+		},
+		Posts: PostsConfig{
+			PreviewLength:        getEnvAsInt("POST_PREVIEW_LENGTH", 280),
+			TruncateFeedBodies:   getEnvAsBool("POST_TRUNCATE_FEED_BODIES", false),
+			ExpiryInterval:       getEnvAsDuration("POST_EXPIRY_INTERVAL", time.Minute),
+			ExpiryBatchSize:      getEnvAsInt("POST_EXPIRY_BATCH_SIZE", 500),
+			TrendingTagsInterval: getEnvAsDuration("POST_TRENDING_TAGS_INTERVAL", 10*time.Minute),
+			TrendingTagsSize:     getEnvAsInt("POST_TRENDING_TAGS_SIZE", 20),
+			AnonymousMaxRange:    getEnvAsDuration("POST_ANONYMOUS_MAX_RANGE", 31*24*time.Hour),
+		},
+		HTTPCache: HTTPCacheConfig{
+			Enabled: getEnvAsBool("HTTP_CACHE_ENABLED", true),
+			MaxAge:  getEnvAsDuration("HTTP_CACHE_MAX_AGE", time.Minute),
+			TTL:     getEnvAsDuration("HTTP_CACHE_TTL", 5*time.Minute),
+		},
+		Analytics: AnalyticsConfig{
+			EventsEnabled:        getEnvAsBool("ANALYTICS_EVENTS_ENABLED", true),
+			MaxBatchSize:         getEnvAsInt("ANALYTICS_MAX_BATCH_SIZE", 100),
+			RateLimitPerMinute:   getEnvAsInt("ANALYTICS_RATE_LIMIT_PER_MINUTE", 60),
+			ImpressionSampleRate: getEnvAsFloat("ANALYTICS_IMPRESSION_SAMPLE_RATE", 1),
+			DwellSampleRate:      getEnvAsFloat("ANALYTICS_DWELL_SAMPLE_RATE", 1),
+			ClickSampleRate:      getEnvAsFloat("ANALYTICS_CLICK_SAMPLE_RATE", 1),
+		},
+		Experiments: ExperimentsConfig{
+			Enabled:      getEnvAsBool("EXPERIMENTS_ENABLED", true),
+			ForceControl: parseCommaSeparated(getEnvOrDefault("EXPERIMENTS_FORCE_CONTROL", "")),
+			CacheTTL:     getEnvAsDuration("EXPERIMENTS_CACHE_TTL", 30*time.Second),
+		},
+		Hooks: HooksConfig{
+			PluginDir: getEnvOrDefault("HOOKS_PLUGIN_DIR", ""),
+			Webhooks:  parseCommaSeparated(getEnvOrDefault("HOOKS_WEBHOOKS", "")),
+			Timeout:   getEnvAsDuration("HOOKS_TIMEOUT", 2*time.Second),
+			FailOpen:  getEnvAsBool("HOOKS_FAIL_OPEN", true),
+
+			WebhookSecret:     getEnvOrDefault("HOOKS_WEBHOOK_SECRET", ""),
+			DeliveryRetention: getEnvAsDuration("HOOKS_DELIVERY_RETENTION", 7*24*time.Hour),
+		},
+		Schema: SchemaConfig{
+			CheckPolicy: getEnvOrDefault("SCHEMA_CHECK_POLICY", "refuse"),
+		},
+		ReadOnly: ReadOnlyConfig{
+			Enabled:      getEnvAsBool("READ_ONLY_MODE", false),
+			Reason:       getEnvOrDefault("READ_ONLY_REASON", ""),
+			RetryAfter:   getEnvAsDuration("READ_ONLY_RETRY_AFTER", 60*time.Second),
+			PollInterval: getEnvAsDuration("READ_ONLY_POLL_INTERVAL", 15*time.Second),
+		},
+		VoteIntegrity: VoteIntegrityConfig{
+			Enabled:            getEnvAsBool("VOTE_INTEGRITY_ENABLED", true),
+			Interval:           getEnvAsDuration("VOTE_INTEGRITY_INTERVAL", 15*time.Minute),
+			Lookback:           getEnvAsDuration("VOTE_INTEGRITY_LOOKBACK", 24*time.Hour),
+			FlipFlopMinChanges: getEnvAsInt("VOTE_INTEGRITY_FLIP_FLOP_MIN_CHANGES", 6),
+			BurstWindow:        getEnvAsDuration("VOTE_INTEGRITY_BURST_WINDOW", 10*time.Minute),
+			RingMinAccounts:    getEnvAsInt("VOTE_INTEGRITY_RING_MIN_ACCOUNTS", 3),
+			RingMinSharedPosts: getEnvAsInt("VOTE_INTEGRITY_RING_MIN_SHARED_POSTS", 3),
+		},
+		ProfileViews: ProfileViewsConfig{
+			Enabled:       getEnvAsBool("PROFILE_VIEWS_ENABLED", false),
+			Retention:     getEnvAsDuration("PROFILE_VIEWS_RETENTION", 90*24*time.Hour),
+			PurgeInterval: getEnvAsDuration("PROFILE_VIEWS_PURGE_INTERVAL", 6*time.Hour),
+		},
+		Realtime: RealtimeConfig{
+			Enabled:               getEnvAsBool("REALTIME_ENABLED", true),
+			MaxConnectionsPerUser: getEnvAsInt("REALTIME_MAX_CONNECTIONS_PER_USER", 5),
+			SendBuffer:            getEnvAsInt("REALTIME_SEND_BUFFER", 64),
+			PingInterval:          getEnvAsDuration("REALTIME_PING_INTERVAL", 30*time.Second),
+		},
+		Achievements: AchievementsConfig{
+			Enabled:   getEnvAsBool("ACHIEVEMENTS_ENABLED", true),
+			QueueSize: getEnvAsInt("ACHIEVEMENTS_QUEUE_SIZE", 256),
+		},
+		MFA: MFAConfig{
+			Issuer:       getEnvOrDefault("MFA_ISSUER", "Telar"),
+			ChallengeTTL: getEnvAsDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+		},
+		Leaderboards: LeaderboardsConfig{
+			RefreshInterval: getEnvAsDuration("LEADERBOARDS_REFRESH_INTERVAL", 15*time.Minute),
+			Size:            getEnvAsInt("LEADERBOARDS_SIZE", 25),
+		},
+		Streaks: StreaksConfig{
+			JobInterval:      getEnvAsDuration("STREAKS_JOB_INTERVAL", time.Hour),
+			RemindersEnabled: getEnvAsBool("STREAKS_REMINDERS_ENABLED", true),
+			ReminderWindow:   getEnvAsDuration("STREAKS_REMINDER_WINDOW", 6*time.Hour),
+			ReminderMinDays:  getEnvAsInt("STREAKS_REMINDER_MIN_DAYS", 3),
+		},
+		Supporters: SupportersConfig{
+			Enabled:      getEnvAsBool("SUPPORTERS_ENABLED", false),
+			TeaserLength: getEnvAsInt("SUPPORTERS_TEASER_LENGTH", 280),
+			MaxTiers:     getEnvAsInt("SUPPORTERS_MAX_TIERS", 5),
+		},
+		Tips: TipsConfig{
+			Enabled:        getEnvAsBool("TIPS_ENABLED", false),
+			Currencies:     parseCommaSeparated(getEnvOrDefault("TIPS_CURRENCIES", "USD")),
+			MinAmountCents: getEnvAsInt64("TIPS_MIN_AMOUNT_CENTS", 100),
+			MaxAmountCents: getEnvAsInt64("TIPS_MAX_AMOUNT_CENTS", 50000),
+			FeeBasisPoints: getEnvAsInt("TIPS_FEE_BASIS_POINTS", 500),
+			FeeFixedCents:  getEnvAsInt64("TIPS_FEE_FIXED_CENTS", 0),
+			ShowCounts:     getEnvAsBool("TIPS_SHOW_COUNTS", false),
+		},
+		Thanks: ThanksConfig{
+			Enabled:         getEnvAsBool("THANKS_ENABLED", false),
+			WeeklyAllowance: getEnvAsInt("THANKS_WEEKLY_ALLOWANCE", 5),
+			JobInterval:     getEnvAsDuration("THANKS_JOB_INTERVAL", time.Hour),
+		},
+		Calendar: CalendarConfig{
+			Enabled:   getEnvAsBool("CALENDAR_ENABLED", false),
+			FeedLimit: getEnvAsInt("CALENDAR_FEED_LIMIT", 500),
+			FeedPast:  getEnvAsDuration("CALENDAR_FEED_PAST", 30*24*time.Hour),
+		},
+		LiveThreads: LiveThreadsConfig{
+			Enabled:         getEnvAsBool("LIVE_THREADS_ENABLED", false),
+			SlowModeSeconds: getEnvAsInt("LIVE_THREADS_SLOW_MODE_SECONDS", 30),
+			MaxDuration:     getEnvAsDuration("LIVE_THREADS_MAX_DURATION", 6*time.Hour),
+			JobInterval:     getEnvAsDuration("LIVE_THREADS_JOB_INTERVAL", time.Minute),
+			SummaryComments: getEnvAsInt("LIVE_THREADS_SUMMARY_COMMENTS", 200),
+		},
+		Metrics: MetricsConfig{
+			Enabled: getEnvAsBool("METRICS_ENABLED", true),
+			Path:    getEnvOrDefault("METRICS_PATH", "/metrics"),
+			Token:   getEnvOrDefault("METRICS_TOKEN", ""),
+		},
+		Membership: MembershipConfig{
+			MaxQuestions:    getEnvAsInt("MEMBERSHIP_MAX_QUESTIONS", 10),
+			MaxAnswerLength: getEnvAsInt("MEMBERSHIP_MAX_ANSWER_LENGTH", 2000),
+		},
+		Rules: RulesConfig{
+			MaxRules:      getEnvAsInt("RULES_MAX_RULES", 20),
+			MaxRuleLength: getEnvAsInt("RULES_MAX_RULE_LENGTH", 2000),
+		},
+		Communities: CommunitiesConfig{
+			StartersEnabled:    getEnvAsBool("COMMUNITY_STARTERS_ENABLED", false),
+			StartersInterval:   getEnvAsDuration("COMMUNITY_STARTERS_INTERVAL", time.Hour),
+			StartersBatch:      getEnvAsInt("COMMUNITY_STARTERS_BATCH", 20),
+			StartersMaxPending: getEnvAsInt("COMMUNITY_STARTERS_MAX_PENDING", 10),
+		},
+		Comments: CommentsConfig{
+			MaxDepth:              getEnvAsInt("COMMENTS_MAX_DEPTH", 1),
+			ProfileRepairInterval: getEnvAsDuration("COMMENTS_PROFILE_REPAIR_INTERVAL", 10*time.Minute),
+		},
+		Outbox: OutboxConfig{
+			Enabled:      getEnvAsBool("OUTBOX_ENABLED", false),
+			Broker:       getEnvOrDefault("OUTBOX_BROKER", "local"),
+			NATSURL:      getEnvOrDefault("OUTBOX_NATS_URL", "nats://localhost:4222"),
+			KafkaRESTURL: getEnvOrDefault("OUTBOX_KAFKA_REST_URL", "http://localhost:8082"),
+			TopicPrefix:  getEnvOrDefault("OUTBOX_TOPIC_PREFIX", "telar."),
+			BatchSize:    getEnvAsInt("OUTBOX_BATCH_SIZE", 100),
+			PollInterval: getEnvAsDuration("OUTBOX_POLL_INTERVAL", time.Second),
+			Retention:    getEnvAsDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+
+			ProfilePropagationAttempts: getEnvAsInt("OUTBOX_PROFILE_PROPAGATION_ATTEMPTS", 10),
+		},
+		GRPC: GRPCConfig{
+			TLSCertFile:       getEnvOrDefault("GRPC_TLS_CERT_FILE", ""),
+			TLSKeyFile:        getEnvOrDefault("GRPC_TLS_KEY_FILE", ""),
+			TLSCAFile:         getEnvOrDefault("GRPC_TLS_CA_FILE", ""),
+			TLSServerName:     getEnvOrDefault("GRPC_TLS_SERVER_NAME", ""),
+			AuthSecret:        getEnvOrDefault("GRPC_AUTH_SECRET", ""),
+			KeepaliveTime:     getEnvAsDuration("GRPC_KEEPALIVE_TIME", 30*time.Second),
+			KeepaliveTimeout:  getEnvAsDuration("GRPC_KEEPALIVE_TIMEOUT", 10*time.Second),
+			MaxRetries:        getEnvAsInt("GRPC_MAX_RETRIES", 3),
+			RetryBackoff:      getEnvAsDuration("GRPC_RETRY_BACKOFF", 100*time.Millisecond),
+			ConnectBackoffMax: getEnvAsDuration("GRPC_CONNECT_BACKOFF_MAX", 10*time.Second),
+		},
+		DataMigration: DataMigrationConfig{
+			DualWrite: parseCommaSeparated(getEnvOrDefault("DATA_MIGRATION_DUAL_WRITE", "")),
 		},
 	}
 
@@ -382,6 +989,16 @@ func LoadFromMap(envMap map[string]string) (*Config, error) {
 		return defaultValue
 	}
 
+	// Helper to get a float value from the map or a default.
+	getFloat := func(key string, defaultValue float64) float64 {
+		if value, exists := envMap[key]; exists {
+			if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+				return floatValue
+			}
+		}
+		return defaultValue
+	}
+
 	// Helper to get a duration value from the map or a default.
 	getDuration := func(key string, defaultValue time.Duration) time.Duration {
 		if value, exists := envMap[key]; exists {
@@ -432,6 +1049,12 @@ func LoadFromMap(envMap map[string]string) (*Config, error) {
 				MaxOpenConns:    getInt("POSTGRES_MAX_OPEN_CONNS", 25),
 				MaxIdleConns:    getInt("POSTGRES_MAX_IDLE_CONNS", 25),
 				ConnMaxLifetime: time.Duration(getInt("POSTGRES_CONN_MAX_LIFETIME", 300)) * time.Second,
+				Modules:         loadPostgresModules(get),
+			},
+			Mongo: MongoDBConfig{
+				URI:         get("MONGO_URI", "mongodb://localhost:27017"),
+				Database:    get("MONGO_DATABASE", "telar"),
+				MaxPoolSize: getInt("MONGO_MAX_POOL_SIZE", 100),
 			},
 		},
 		JWT: JWTConfig{
@@ -463,6 +1086,11 @@ func LoadFromMap(envMap map[string]string) (*Config, error) {
 			OrgAvatar:      get("ORG_AVATAR", ""),
 			QueryPrettyURL: getBool("QUERY_PRETTY_URL", false),
 		},
+		Log: LogConfig{
+			Format:      get("LOG_FORMAT", "text"),
+			Level:       get("LOG_LEVEL", "info"),
+			TraceBuffer: getInt("LOG_TRACE_BUFFER", 5000),
+		},
 		External: ExternalConfig{
 			GitHubClientID:    get("GITHUB_CLIENT_ID", ""),
 			GitHubSecret:      get("GITHUB_SECRET", ""),
@@ -519,20 +1147,244 @@ func LoadFromMap(envMap map[string]string) (*Config, error) {
 				Max:      getEnvAsInt("RATE_LIMIT_VERIFICATION_MAX", 10),
 				Duration: getEnvAsDuration("RATE_LIMIT_VERIFICATION_DURATION", 15*time.Minute),
 			},
+			CommentPreview: RateLimitConfig{
+				Enabled:  getEnvAsBool("RATE_LIMIT_COMMENT_PREVIEW_ENABLED", true),
+				Max:      getEnvAsInt("RATE_LIMIT_COMMENT_PREVIEW_MAX", 120),
+				Duration: getEnvAsDuration("RATE_LIMIT_COMMENT_PREVIEW_DURATION", 1*time.Minute),
+			},
+			PostCreate: RateLimitConfig{
+				Enabled:  getEnvAsBool("RATE_LIMIT_POST_CREATE_ENABLED", true),
+				Max:      getEnvAsInt("RATE_LIMIT_POST_CREATE_MAX", 30),
+				Duration: getEnvAsDuration("RATE_LIMIT_POST_CREATE_DURATION", 1*time.Hour),
+			},
+			CommentExport: RateLimitConfig{
+				Enabled:  getEnvAsBool("RATE_LIMIT_COMMENT_EXPORT_ENABLED", true),
+				Max:      getEnvAsInt("RATE_LIMIT_COMMENT_EXPORT_MAX", 10),
+				Duration: getEnvAsDuration("RATE_LIMIT_COMMENT_EXPORT_DURATION", 1*time.Hour),
+			},
+			API: RateLimitConfig{
+				Enabled:  getEnvAsBool("RATE_LIMIT_API_ENABLED", true),
+				Max:      getEnvAsInt("RATE_LIMIT_API_MAX", 600),
+				Duration: getEnvAsDuration("RATE_LIMIT_API_DURATION", 1*time.Minute),
+			},
+			Storage: getEnvOrDefault("RATE_LIMIT_STORAGE", "memory"),
 		},
 		Storage: StorageConfig{
-			Provider:              get("STORAGE_PROVIDER", "r2"),
-			AccountID:             get("R2_ACCOUNT_ID", ""),
-			AccessKeyID:           get("R2_ACCESS_KEY_ID", ""),
-			SecretAccessKey:       get("R2_SECRET_ACCESS_KEY", ""),
-			BucketName:            get("R2_BUCKET_NAME", ""),
-			PublicURL:             get("R2_PUBLIC_URL", ""),
-			Region:                get("R2_REGION", "auto"),
-			Endpoint:              get("R2_ENDPOINT", ""),
-			MaxFileSizeMB:         getInt("STORAGE_MAX_FILE_SIZE_MB", 2),
-			AllowedMimeTypes:      parseCommaSeparated(get("STORAGE_ALLOWED_MIME_TYPES", "image/jpeg,image/png,image/webp")),
+			Provider:               get("STORAGE_PROVIDER", "r2"),
+			AccountID:              get("R2_ACCOUNT_ID", ""),
+			AccessKeyID:            get("STORAGE_ACCESS_KEY_ID", get("R2_ACCESS_KEY_ID", "")),
+			SecretAccessKey:        get("STORAGE_SECRET_ACCESS_KEY", get("R2_SECRET_ACCESS_KEY", "")),
+			BucketName:             get("STORAGE_BUCKET_NAME", get("R2_BUCKET_NAME", "")),
+			PublicURL:              get("STORAGE_PUBLIC_URL", get("R2_PUBLIC_URL", "")),
+			Region:                 get("STORAGE_REGION", get("R2_REGION", "auto")),
+			Endpoint:               get("STORAGE_ENDPOINT", get("R2_ENDPOINT", "")),
+			MaxFileSizeMB:          getInt("STORAGE_MAX_FILE_SIZE_MB", 2),
+			AllowedMimeTypes:       parseCommaSeparated(get("STORAGE_ALLOWED_MIME_TYPES", "image/jpeg,image/png,image/webp")),
 			GlobalDailyUploadLimit: getInt("STORAGE_GLOBAL_DAILY_UPLOAD_LIMIT", 30000),
 			UserDailyUploadLimit:   getInt("STORAGE_USER_DAILY_UPLOAD_LIMIT", 20),
+			ForcePathStyle:         getBool("STORAGE_FORCE_PATH_STYLE", false),
+			ThumbnailSize:          getInt("STORAGE_THUMBNAIL_SIZE", 320),
+			ImagePipelineEmbedded:  getBool("STORAGE_IMAGE_PIPELINE_EMBEDDED", true),
+			ImageWorkers:           getInt("STORAGE_IMAGE_WORKERS", 2),
+			ImageBatchSize:         getInt("STORAGE_IMAGE_BATCH_SIZE", 20),
+			ImagePollInterval:      getDuration("STORAGE_IMAGE_POLL_INTERVAL", 15*time.Second),
+		},
+		OCR: OCRConfig{
+			Enabled:        getBool("OCR_ENABLED", false),
+			Provider:       get("OCR_PROVIDER", "tesseract"),
+			TesseractPath:  get("OCR_TESSERACT_PATH", "tesseract"),
+			Language:       get("OCR_LANGUAGE", "eng"),
+			VisionEndpoint: get("OCR_VISION_ENDPOINT", ""),
+			VisionAPIKey:   get("OCR_VISION_API_KEY", ""),
+			VisionModel:    get("OCR_VISION_MODEL", ""),
+			AllowedHosts:   parseCommaSeparated(get("OCR_ALLOWED_HOSTS", "")),
+			Workers:        getInt("OCR_WORKERS", 2),
+			QueueSize:      getInt("OCR_QUEUE_SIZE", 100),
+			Timeout:        getDuration("OCR_TIMEOUT", 30*time.Second),
+			MaxTextLength:  getInt("OCR_MAX_TEXT_LENGTH", 5000),
+		},
+		AIEngine: AIEngineConfig{
+			URL:             get("AI_ENGINE_URL", ""),
+			Timeout:         getDuration("AI_ENGINE_TIMEOUT", 10*time.Second),
+			UserQuota:       getInt("AI_ENGINE_USER_QUOTA", 100),
+			UserQuotaWindow: getDuration("AI_ENGINE_USER_QUOTA_WINDOW", time.Hour),
+		},
+		Duplicates: DuplicatesConfig{
+			Enabled:             getBool("DUPLICATE_DETECTION_ENABLED", false),
+			Policy:              get("DUPLICATE_DETECTION_POLICY", "warn"),
+			SimilarityThreshold: getFloat("DUPLICATE_DETECTION_SIMILARITY_THRESHOLD", 0.92),
+			Window:              getDuration("DUPLICATE_DETECTION_WINDOW", 7*24*time.Hour),
+			MaxCandidates:       getInt("DUPLICATE_DETECTION_MAX_CANDIDATES", 20),
+		},
+		Moderation: ModerationConfig{
+			AutoFlagEnabled:   getBool("MODERATION_AUTO_FLAG_ENABLED", false),
+			AutoFlagThreshold: getFloat("MODERATION_AUTO_FLAG_THRESHOLD", 0.8),
+			AutoFlagWorkers:   getInt("MODERATION_AUTO_FLAG_WORKERS", 2),
+			AutoFlagQueueSize: getInt("MODERATION_AUTO_FLAG_QUEUE_SIZE", 200),
+		},
+		Posts: PostsConfig{
+			PreviewLength:        getInt("POST_PREVIEW_LENGTH", 280),
+			TruncateFeedBodies:   getBool("POST_TRUNCATE_FEED_BODIES", false),
+			ExpiryInterval:       getDuration("POST_EXPIRY_INTERVAL", time.Minute),
+			ExpiryBatchSize:      getInt("POST_EXPIRY_BATCH_SIZE", 500),
+			TrendingTagsInterval: getDuration("POST_TRENDING_TAGS_INTERVAL", 10*time.Minute),
+			TrendingTagsSize:     getInt("POST_TRENDING_TAGS_SIZE", 20),
+			AnonymousMaxRange:    getDuration("POST_ANONYMOUS_MAX_RANGE", 31*24*time.Hour),
+		},
+		HTTPCache: HTTPCacheConfig{
+			Enabled: getBool("HTTP_CACHE_ENABLED", true),
+			MaxAge:  getDuration("HTTP_CACHE_MAX_AGE", time.Minute),
+			TTL:     getDuration("HTTP_CACHE_TTL", 5*time.Minute),
+		},
+		Analytics: AnalyticsConfig{
+			EventsEnabled:        getBool("ANALYTICS_EVENTS_ENABLED", true),
+			MaxBatchSize:         getInt("ANALYTICS_MAX_BATCH_SIZE", 100),
+			RateLimitPerMinute:   getInt("ANALYTICS_RATE_LIMIT_PER_MINUTE", 60),
+			ImpressionSampleRate: getFloat("ANALYTICS_IMPRESSION_SAMPLE_RATE", 1),
+			DwellSampleRate:      getFloat("ANALYTICS_DWELL_SAMPLE_RATE", 1),
+			ClickSampleRate:      getFloat("ANALYTICS_CLICK_SAMPLE_RATE", 1),
+		},
+		Experiments: ExperimentsConfig{
+			Enabled:      getBool("EXPERIMENTS_ENABLED", true),
+			ForceControl: parseCommaSeparated(get("EXPERIMENTS_FORCE_CONTROL", "")),
+			CacheTTL:     getDuration("EXPERIMENTS_CACHE_TTL", 30*time.Second),
+		},
+		Hooks: HooksConfig{
+			PluginDir: get("HOOKS_PLUGIN_DIR", ""),
+			Webhooks:  parseCommaSeparated(get("HOOKS_WEBHOOKS", "")),
+			Timeout:   getDuration("HOOKS_TIMEOUT", 2*time.Second),
+			FailOpen:  getBool("HOOKS_FAIL_OPEN", true),
+
+			WebhookSecret:     get("HOOKS_WEBHOOK_SECRET", ""),
+			DeliveryRetention: getDuration("HOOKS_DELIVERY_RETENTION", 7*24*time.Hour),
+		},
+		Schema: SchemaConfig{
+			CheckPolicy: get("SCHEMA_CHECK_POLICY", "refuse"),
+		},
+		ReadOnly: ReadOnlyConfig{
+			Enabled:      getBool("READ_ONLY_MODE", false),
+			Reason:       get("READ_ONLY_REASON", ""),
+			RetryAfter:   getDuration("READ_ONLY_RETRY_AFTER", 60*time.Second),
+			PollInterval: getDuration("READ_ONLY_POLL_INTERVAL", 15*time.Second),
+		},
+		VoteIntegrity: VoteIntegrityConfig{
+			Enabled:            getBool("VOTE_INTEGRITY_ENABLED", true),
+			Interval:           getDuration("VOTE_INTEGRITY_INTERVAL", 15*time.Minute),
+			Lookback:           getDuration("VOTE_INTEGRITY_LOOKBACK", 24*time.Hour),
+			FlipFlopMinChanges: getInt("VOTE_INTEGRITY_FLIP_FLOP_MIN_CHANGES", 6),
+			BurstWindow:        getDuration("VOTE_INTEGRITY_BURST_WINDOW", 10*time.Minute),
+			RingMinAccounts:    getInt("VOTE_INTEGRITY_RING_MIN_ACCOUNTS", 3),
+			RingMinSharedPosts: getInt("VOTE_INTEGRITY_RING_MIN_SHARED_POSTS", 3),
+		},
+		ProfileViews: ProfileViewsConfig{
+			Enabled:       getBool("PROFILE_VIEWS_ENABLED", false),
+			Retention:     getDuration("PROFILE_VIEWS_RETENTION", 90*24*time.Hour),
+			PurgeInterval: getDuration("PROFILE_VIEWS_PURGE_INTERVAL", 6*time.Hour),
+		},
+		Realtime: RealtimeConfig{
+			Enabled:               getBool("REALTIME_ENABLED", true),
+			MaxConnectionsPerUser: getInt("REALTIME_MAX_CONNECTIONS_PER_USER", 5),
+			SendBuffer:            getInt("REALTIME_SEND_BUFFER", 64),
+			PingInterval:          getDuration("REALTIME_PING_INTERVAL", 30*time.Second),
+		},
+		Achievements: AchievementsConfig{
+			Enabled:   getBool("ACHIEVEMENTS_ENABLED", true),
+			QueueSize: getInt("ACHIEVEMENTS_QUEUE_SIZE", 256),
+		},
+		MFA: MFAConfig{
+			Issuer:       get("MFA_ISSUER", "Telar"),
+			ChallengeTTL: getDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+		},
+		Leaderboards: LeaderboardsConfig{
+			RefreshInterval: getDuration("LEADERBOARDS_REFRESH_INTERVAL", 15*time.Minute),
+			Size:            getInt("LEADERBOARDS_SIZE", 25),
+		},
+		Streaks: StreaksConfig{
+			JobInterval:      getDuration("STREAKS_JOB_INTERVAL", time.Hour),
+			RemindersEnabled: getBool("STREAKS_REMINDERS_ENABLED", true),
+			ReminderWindow:   getDuration("STREAKS_REMINDER_WINDOW", 6*time.Hour),
+			ReminderMinDays:  getInt("STREAKS_REMINDER_MIN_DAYS", 3),
+		},
+		Supporters: SupportersConfig{
+			Enabled:      getBool("SUPPORTERS_ENABLED", false),
+			TeaserLength: getInt("SUPPORTERS_TEASER_LENGTH", 280),
+			MaxTiers:     getInt("SUPPORTERS_MAX_TIERS", 5),
+		},
+		Tips: TipsConfig{
+			Enabled:        getBool("TIPS_ENABLED", false),
+			Currencies:     parseCommaSeparated(get("TIPS_CURRENCIES", "USD")),
+			MinAmountCents: getInt64("TIPS_MIN_AMOUNT_CENTS", 100),
+			MaxAmountCents: getInt64("TIPS_MAX_AMOUNT_CENTS", 50000),
+			FeeBasisPoints: getInt("TIPS_FEE_BASIS_POINTS", 500),
+			FeeFixedCents:  getInt64("TIPS_FEE_FIXED_CENTS", 0),
+			ShowCounts:     getBool("TIPS_SHOW_COUNTS", false),
+		},
+		Thanks: ThanksConfig{
+			Enabled:         getBool("THANKS_ENABLED", false),
+			WeeklyAllowance: getInt("THANKS_WEEKLY_ALLOWANCE", 5),
+			JobInterval:     getDuration("THANKS_JOB_INTERVAL", time.Hour),
+		},
+		Calendar: CalendarConfig{
+			Enabled:   getBool("CALENDAR_ENABLED", false),
+			FeedLimit: getInt("CALENDAR_FEED_LIMIT", 500),
+			FeedPast:  getDuration("CALENDAR_FEED_PAST", 30*24*time.Hour),
+		},
+		LiveThreads: LiveThreadsConfig{
+			Enabled:         getBool("LIVE_THREADS_ENABLED", false),
+			SlowModeSeconds: getInt("LIVE_THREADS_SLOW_MODE_SECONDS", 30),
+			MaxDuration:     getDuration("LIVE_THREADS_MAX_DURATION", 6*time.Hour),
+			JobInterval:     getDuration("LIVE_THREADS_JOB_INTERVAL", time.Minute),
+			SummaryComments: getInt("LIVE_THREADS_SUMMARY_COMMENTS", 200),
+		},
+		Metrics: MetricsConfig{
+			Enabled: getBool("METRICS_ENABLED", true),
+			Path:    get("METRICS_PATH", "/metrics"),
+			Token:   get("METRICS_TOKEN", ""),
+		},
+		Membership: MembershipConfig{
+			MaxQuestions:    getInt("MEMBERSHIP_MAX_QUESTIONS", 10),
+			MaxAnswerLength: getInt("MEMBERSHIP_MAX_ANSWER_LENGTH", 2000),
+		},
+		Rules: RulesConfig{
+			MaxRules:      getInt("RULES_MAX_RULES", 20),
+			MaxRuleLength: getInt("RULES_MAX_RULE_LENGTH", 2000),
+		},
+		Communities: CommunitiesConfig{
+			StartersEnabled:    getBool("COMMUNITY_STARTERS_ENABLED", false),
+			StartersInterval:   getDuration("COMMUNITY_STARTERS_INTERVAL", time.Hour),
+			StartersBatch:      getInt("COMMUNITY_STARTERS_BATCH", 20),
+			StartersMaxPending: getInt("COMMUNITY_STARTERS_MAX_PENDING", 10),
+		},
+		Comments: CommentsConfig{
+			MaxDepth:              getInt("COMMENTS_MAX_DEPTH", 1),
+			ProfileRepairInterval: getDuration("COMMENTS_PROFILE_REPAIR_INTERVAL", 10*time.Minute),
+		},
+		Outbox: OutboxConfig{
+			Enabled:      getBool("OUTBOX_ENABLED", false),
+			Broker:       get("OUTBOX_BROKER", "local"),
+			NATSURL:      get("OUTBOX_NATS_URL", "nats://localhost:4222"),
+			KafkaRESTURL: get("OUTBOX_KAFKA_REST_URL", "http://localhost:8082"),
+			TopicPrefix:  get("OUTBOX_TOPIC_PREFIX", "telar."),
+			BatchSize:    getInt("OUTBOX_BATCH_SIZE", 100),
+			PollInterval: getDuration("OUTBOX_POLL_INTERVAL", time.Second),
+			Retention:    getDuration("OUTBOX_RETENTION", 7*24*time.Hour),
+
+			ProfilePropagationAttempts: getInt("OUTBOX_PROFILE_PROPAGATION_ATTEMPTS", 10),
+		},
+		GRPC: GRPCConfig{
+			TLSCertFile:       get("GRPC_TLS_CERT_FILE", ""),
+			TLSKeyFile:        get("GRPC_TLS_KEY_FILE", ""),
+			TLSCAFile:         get("GRPC_TLS_CA_FILE", ""),
+			TLSServerName:     get("GRPC_TLS_SERVER_NAME", ""),
+			AuthSecret:        get("GRPC_AUTH_SECRET", ""),
+			KeepaliveTime:     getDuration("GRPC_KEEPALIVE_TIME", 30*time.Second),
+			KeepaliveTimeout:  getDuration("GRPC_KEEPALIVE_TIMEOUT", 10*time.Second),
+			MaxRetries:        getInt("GRPC_MAX_RETRIES", 3),
+			RetryBackoff:      getDuration("GRPC_RETRY_BACKOFF", 100*time.Millisecond),
+			ConnectBackoffMax: getDuration("GRPC_CONNECT_BACKOFF_MAX", 10*time.Second),
+		},
+		DataMigration: DataMigrationConfig{
+			DualWrite: parseCommaSeparated(get("DATA_MIGRATION_DUAL_WRITE", "")),
 		},
 	}
 
@@ -561,11 +1413,38 @@ func (c *Config) Validate() error {
 	}
 
 	// Validate database type
-	validDbTypes := []string{"postgresql"}
+	validDbTypes := []string{"postgresql", "mongodb"}
 	if !contains(validDbTypes, c.Database.Type) {
 		errors = append(errors, fmt.Sprintf("DB_TYPE must be one of: %s", strings.Join(validDbTypes, ", ")))
 	}
 
+	// Validate schema check policy
+	validSchemaPolicies := []string{"refuse", "readonly", "warn", "off"}
+	if !contains(validSchemaPolicies, c.Schema.CheckPolicy) {
+		errors = append(errors, fmt.Sprintf("SCHEMA_CHECK_POLICY must be one of: %s", strings.Join(validSchemaPolicies, ", ")))
+	}
+
+	// Validate rate limit storage
+	validRateLimitStorages := []string{"memory", "redis"}
+	if !contains(validRateLimitStorages, c.RateLimits.Storage) {
+		errors = append(errors, fmt.Sprintf("RATE_LIMIT_STORAGE must be one of: %s", strings.Join(validRateLimitStorages, ", ")))
+	}
+
+	// Validate storage provider
+	validStorageProviders := []string{"r2", "s3", "minio"}
+	if !contains(validStorageProviders, c.Storage.Provider) {
+		errors = append(errors, fmt.Sprintf("STORAGE_PROVIDER must be one of: %s", strings.Join(validStorageProviders, ", ")))
+	}
+
+	validOutboxBrokers := []string{"local", "nats", "kafka"}
+	if c.Outbox.Enabled && !contains(validOutboxBrokers, c.Outbox.Broker) {
+		errors = append(errors, fmt.Sprintf("OUTBOX_BROKER must be one of: %s", strings.Join(validOutboxBrokers, ", ")))
+	}
+
+	if (c.GRPC.TLSCertFile == "") != (c.GRPC.TLSKeyFile == "") {
+		errors = append(errors, "GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE must be set together")
+	}
+
 	if len(errors) > 0 {
 		return fmt.Errorf("validation errors: %s", strings.Join(errors, "; "))
 	}
@@ -608,6 +1487,15 @@ func getEnvAsBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
+func getEnvAsFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
 		if s == item {
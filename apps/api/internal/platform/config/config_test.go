@@ -35,6 +35,10 @@ func TestLoadFromMap(t *testing.T) {
 			"POSTGRES_MAX_OPEN_CONNS": "55",
 			"POSTGRES_MAX_IDLE_CONNS": "23",
 			"POSTGRES_CONN_MAX_LIFETIME": "321",
+			"POSTGRES_AUTH_SCHEMA":    "auth",
+			"POSTGRES_AUTH_USERNAME":  "telar_auth",
+			"POSTGRES_AUTH_PASSWORD":  "auth-pass",
+			"POSTGRES_POSTS_SCHEMA":   "posts",
 			"SERVER_PORT":           "9090",
 			"DEBUG":                 "true",
 			"CACHE_TTL":             "30m",
@@ -57,6 +61,10 @@ func TestLoadFromMap(t *testing.T) {
 		require.Equal(t, 55, cfg.Database.Postgres.MaxOpenConns)
 		require.Equal(t, 23, cfg.Database.Postgres.MaxIdleConns)
 		require.Equal(t, 321*time.Second, cfg.Database.Postgres.ConnMaxLifetime)
+		require.Equal(t, map[string]PostgresModuleConfig{
+			"auth":  {Schema: "auth", Username: "telar_auth", Password: "auth-pass"},
+			"posts": {Schema: "posts"},
+		}, cfg.Database.Postgres.Modules)
 		require.Equal(t, 9090, cfg.Server.Port)
 		require.True(t, cfg.Server.Debug)
 		require.Equal(t, 30*time.Minute, cfg.Cache.TTL)
@@ -81,6 +89,29 @@ func TestLoadFromMap(t *testing.T) {
 		require.NotEmpty(t, cfg.Database.Postgres.DSN)
 	})
 
+	t.Run("Loads MongoDB settings", func(t *testing.T) {
+		t.Parallel()
+
+		testEnv := map[string]string{
+			"HMAC_SECRET":         "test-secret",
+			"JWT_PRIVATE_KEY":     "test-private-key",
+			"JWT_PUBLIC_KEY":      "test-public-key",
+			"DB_TYPE":             "mongodb",
+			"MONGO_URI":           "mongodb://mongo-host:27018/?replicaSet=rs0",
+			"MONGO_DATABASE":      "test-db",
+			"MONGO_MAX_POOL_SIZE": "42",
+		}
+
+		cfg, err := LoadFromMap(testEnv)
+		require.NoError(t, err)
+		require.NoError(t, cfg.Validate())
+
+		require.Equal(t, "mongodb", cfg.Database.Type)
+		require.Equal(t, "mongodb://mongo-host:27018/?replicaSet=rs0", cfg.Database.Mongo.URI)
+		require.Equal(t, "test-db", cfg.Database.Mongo.Database)
+		require.Equal(t, 42, cfg.Database.Mongo.MaxPoolSize)
+	})
+
 	t.Run("Returns error for missing JWT_PRIVATE_KEY", func(t *testing.T) {
 		t.Parallel()
 
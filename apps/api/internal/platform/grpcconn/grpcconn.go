@@ -0,0 +1,171 @@
+// Package grpcconn builds the options of the internal gRPC connections between
+// services from platformconfig.GRPCConfig: TLS or mutual TLS, per-call
+// authentication, keepalive, and reconnect and retry backoff. Servers and clients
+// built from the same config can talk to each other.
+package grpcconn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/middleware/grpcauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// maxRetryAttempts is the most attempts gRPC makes for one call, the first included
+const maxRetryAttempts = 5
+
+// DialOptions returns the options for dialing another service's gRPC server.
+func DialOptions(cfg platformconfig.GRPCConfig) ([]grpc.DialOption, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLSEnabled() {
+		tlsConfig, err := clientTLS(cfg)
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tlsConfig)
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  positive(cfg.RetryBackoff, backoff.DefaultConfig.BaseDelay),
+				Multiplier: backoff.DefaultConfig.Multiplier,
+				Jitter:     backoff.DefaultConfig.Jitter,
+				MaxDelay:   positive(cfg.ConnectBackoffMax, backoff.DefaultConfig.MaxDelay),
+			},
+		}),
+	}
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.KeepaliveTime,
+			Timeout:             cfg.KeepaliveTimeout,
+			PermitWithoutStream: true,
+		}))
+	}
+	if serviceConfig := retryServiceConfig(cfg); serviceConfig != "" {
+		opts = append(opts, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+	if cfg.AuthSecret != "" {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(grpcauth.UnaryClientInterceptor(cfg.AuthSecret)),
+			grpc.WithChainStreamInterceptor(grpcauth.StreamClientInterceptor(cfg.AuthSecret)))
+	}
+	return opts, nil
+}
+
+// ServerOptions returns the options for serving gRPC to the other services.
+func ServerOptions(cfg platformconfig.GRPCConfig) ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+	if cfg.TLSEnabled() {
+		tlsConfig, err := serverTLS(cfg)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts,
+			grpc.KeepaliveParams(keepalive.ServerParameters{
+				Time:    cfg.KeepaliveTime,
+				Timeout: cfg.KeepaliveTimeout,
+			}),
+			// Clients ping as often as KeepaliveTime; allow some slack before the
+			// server treats pings as abuse and closes the connection
+			grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+				MinTime:             cfg.KeepaliveTime / 2,
+				PermitWithoutStream: true,
+			}))
+	}
+	if cfg.AuthSecret != "" {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(grpcauth.UnaryServerInterceptor(cfg.AuthSecret)),
+			grpc.ChainStreamInterceptor(grpcauth.StreamServerInterceptor(cfg.AuthSecret)))
+	}
+	return opts, nil
+}
+
+// retryServiceConfig retries every method on UNAVAILABLE, which gRPC returns when
+// the call did not reach a server (e.g. while it restarts)
+func retryServiceConfig(cfg platformconfig.GRPCConfig) string {
+	if cfg.MaxRetries <= 0 {
+		return ""
+	}
+	attempts := min(cfg.MaxRetries+1, maxRetryAttempts)
+	return fmt.Sprintf(`{"methodConfig":[{"name":[{}],"retryPolicy":{"maxAttempts":%d,"initialBackoff":"%s","maxBackoff":"%s","backoffMultiplier":2,"retryableStatusCodes":["UNAVAILABLE"]}}]}`,
+		attempts,
+		seconds(positive(cfg.RetryBackoff, 100*time.Millisecond)),
+		seconds(positive(cfg.ConnectBackoffMax, 10*time.Second)))
+}
+
+func clientTLS(cfg platformconfig.GRPCConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: cfg.TLSServerName}
+	if cfg.TLSCAFile != "" {
+		pool, err := loadCA(cfg.TLSCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if cfg.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load gRPC client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+func serverTLS(cfg platformconfig.GRPCConfig) (*tls.Config, error) {
+	if cfg.TLSCertFile == "" {
+		return nil, fmt.Errorf("serving gRPC over TLS needs GRPC_TLS_CERT_FILE and GRPC_TLS_KEY_FILE")
+	}
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("load gRPC server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12, Certificates: []tls.Certificate{cert}}
+	if cfg.TLSCAFile != "" {
+		pool, err := loadCA(cfg.TLSCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+func loadCA(path string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read gRPC CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no certificates in gRPC CA %s", path)
+	}
+	return pool, nil
+}
+
+func positive(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// seconds formats d as a service config duration, e.g. "0.1s"
+func seconds(d time.Duration) string {
+	return fmt.Sprintf("%gs", d.Seconds())
+}
@@ -0,0 +1,123 @@
+package grpcconn
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// writeCA creates a CA and a certificate it signs for "telar-internal", usable by
+// servers and clients, and returns the paths of the CA, certificate and key
+func writeCA(t *testing.T, dir, name string) (caFile, certFile, keyFile string) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name + " CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "telar-internal"},
+		DNSNames:     []string{"telar-internal"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	write := func(file, blockType string, data []byte) string {
+		path := filepath.Join(dir, file)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: data}), 0o600))
+		return path
+	}
+	return write(name+"-ca.pem", "CERTIFICATE", caDER),
+		write(name+"-cert.pem", "CERTIFICATE", der),
+		write(name+"-key.pem", "EC PRIVATE KEY", keyDER)
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	caFile, certFile, keyFile := writeCA(t, dir, "trusted")
+	_, otherCert, otherKey := writeCA(t, dir, "untrusted")
+	serverCfg := platformconfig.GRPCConfig{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSCAFile: caFile}
+
+	opts, err := ServerOptions(serverCfg)
+	require.NoError(t, err)
+	server := grpc.NewServer(opts...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	defer server.Stop()
+
+	check := func(cfg platformconfig.GRPCConfig) error {
+		opts, err := DialOptions(cfg)
+		require.NoError(t, err)
+		conn, err := grpc.NewClient("passthrough:///telar-internal", append(opts,
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }))...)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+		return err
+	}
+
+	assert.NoError(t, check(platformconfig.GRPCConfig{TLSCertFile: certFile, TLSKeyFile: keyFile, TLSCAFile: caFile}))
+	assert.Error(t, check(platformconfig.GRPCConfig{TLSCAFile: caFile}), "clients must present a certificate")
+	assert.Error(t, check(platformconfig.GRPCConfig{TLSCertFile: otherCert, TLSKeyFile: otherKey, TLSCAFile: caFile}), "the certificate must be signed by the CA")
+	assert.Error(t, check(platformconfig.GRPCConfig{}), "plaintext is refused")
+}
+
+func TestRetryServiceConfig(t *testing.T) {
+	assert.Empty(t, retryServiceConfig(platformconfig.GRPCConfig{}))
+	assert.Equal(t,
+		`{"methodConfig":[{"name":[{}],"retryPolicy":{"maxAttempts":5,"initialBackoff":"0.1s","maxBackoff":"10s","backoffMultiplier":2,"retryableStatusCodes":["UNAVAILABLE"]}}]}`,
+		retryServiceConfig(platformconfig.GRPCConfig{MaxRetries: 9, RetryBackoff: 100 * time.Millisecond, ConnectBackoffMax: 10 * time.Second}))
+
+	// gRPC refuses to create a client with an invalid service config
+	opts, err := DialOptions(platformconfig.GRPCConfig{MaxRetries: 3, RetryBackoff: 250 * time.Millisecond, KeepaliveTime: 30 * time.Second})
+	require.NoError(t, err)
+	conn, err := grpc.NewClient("localhost:50051", opts...)
+	require.NoError(t, err)
+	conn.Close()
+}
+
+func TestServerOptions_RequiresCertificate(t *testing.T) {
+	_, err := ServerOptions(platformconfig.GRPCConfig{TLSCAFile: "ca.pem"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,236 @@
+// Package metrics exposes Prometheus metrics for the API services: request latency per
+// route, database statement timing, cache lookups, gRPC client calls and signup email
+// domain checks.
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// Cache lookup results
+const (
+	CacheHit   = "hit"
+	CacheMiss  = "miss"
+	CacheError = "error"
+)
+
+// Signup email domain check results
+const (
+	EmailDomainAllowed  = "allowed"
+	EmailDomainRejected = "rejected"
+)
+
+// unmatchedRoute labels requests no endpoint handled, so they are not counted under
+// the prefix of whichever middleware ran last
+const unmatchedRoute = "unmatched"
+
+// Metrics holds the collectors of one registry
+type Metrics struct {
+	registry     *prometheus.Registry
+	httpDuration *prometheus.HistogramVec
+	dbDuration   *prometheus.HistogramVec
+	cacheLookups *prometheus.CounterVec
+	grpcDuration *prometheus.HistogramVec
+	emailDomains *prometheus.CounterVec
+}
+
+// New registers the API collectors on registry; a nil registry gets a fresh one, so
+// tests can read exactly what they recorded
+func New(registry *prometheus.Registry) *Metrics {
+	if registry == nil {
+		registry = prometheus.NewRegistry()
+	}
+	m := &Metrics{
+		registry: registry,
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Latency of HTTP requests by method, route pattern and status.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		dbDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Latency of database statements by operation, table and outcome.",
+			Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+		}, []string{"operation", "table", "outcome"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_lookups_total",
+			Help: "Cache lookups by cache and result (hit, miss or error).",
+		}, []string{"cache", "result"}),
+		grpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "grpc_client_call_duration_seconds",
+			Help:    "Latency of outgoing gRPC calls by method and status code.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"method", "code"}),
+		emailDomains: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "signup_email_domain_checks_total",
+			Help: "Signup email domain checks by list (allow or deny) and result (allowed or rejected).",
+		}, []string{"list", "result"}),
+	}
+	registry.MustRegister(m.httpDuration, m.dbDuration, m.cacheLookups, m.grpcDuration, m.emailDomains)
+	return m
+}
+
+var (
+	defaultMu      sync.RWMutex
+	defaultMetrics *Metrics
+)
+
+// Default returns the process-wide metrics, created on first use with the Go runtime
+// and process collectors
+func Default() *Metrics {
+	defaultMu.RLock()
+	m := defaultMetrics
+	defaultMu.RUnlock()
+	if m != nil {
+		return m
+	}
+
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	if defaultMetrics == nil {
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(
+			collectors.NewGoCollector(),
+			collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		)
+		defaultMetrics = New(registry)
+	}
+	return defaultMetrics
+}
+
+// SetDefault replaces the process-wide metrics, e.g. with ones on a test registry
+func SetDefault(m *Metrics) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultMetrics = m
+}
+
+// Registry returns the registry the collectors are registered on
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Middleware records the latency of every request under its route pattern
+// (/posts/:postId), never the raw path. The endpoints are read from the app on the
+// first request, once every module has registered its routes.
+func (m *Metrics) Middleware() fiber.Handler {
+	var (
+		once      sync.Once
+		endpoints map[string]bool
+	)
+	return func(c *fiber.Ctx) error {
+		once.Do(func() {
+			endpoints = make(map[string]bool)
+			for _, r := range c.App().GetRoutes(true) {
+				endpoints[r.Method+" "+r.Path] = true
+			}
+		})
+
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if fiberErr, ok := err.(*fiber.Error); ok {
+			status = fiberErr.Code
+		}
+		route := unmatchedRoute
+		if r := c.Route(); endpoints[r.Method+" "+r.Path] {
+			route = r.Path
+		}
+		m.httpDuration.WithLabelValues(c.Method(), route, strconv.Itoa(status)).
+			Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() fiber.Handler {
+	return adaptor.HTTPHandler(promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry}))
+}
+
+// ObserveQuery records a database statement; it matches observability.QueryObserver
+func (m *Metrics) ObserveQuery(query string, duration time.Duration, err error) {
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	operation, table := queryLabels(query)
+	m.dbDuration.WithLabelValues(operation, table, outcome).Observe(duration.Seconds())
+}
+
+// ObserveCacheLookup counts a lookup in the named cache
+func (m *Metrics) ObserveCacheLookup(cache, result string) {
+	m.cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+// ObserveEmailDomainCheck counts a signup checked against the named email domain list
+func (m *Metrics) ObserveEmailDomainCheck(list, result string) {
+	m.emailDomains.WithLabelValues(list, result).Inc()
+}
+
+// UnaryClientInterceptor records the latency and status code of outgoing calls
+func (m *Metrics) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		m.grpcDuration.WithLabelValues(method, status.Code(err).String()).Observe(time.Since(start).Seconds())
+		return err
+	}
+}
+
+// queryLabels reduces a statement to its verb and the table it works on, keeping the
+// label set small whatever the query text. Statements it cannot read get "other".
+func queryLabels(query string) (operation, table string) {
+	fields := strings.Fields(strings.ToLower(query))
+	if len(fields) == 0 {
+		return "other", "other"
+	}
+	operation = fields[0]
+	var after string
+	switch operation {
+	case "select", "delete":
+		after = "from"
+	case "insert":
+		after = "into"
+	case "update":
+		if len(fields) > 1 {
+			return operation, tableName(fields[1])
+		}
+		return operation, "other"
+	case "with":
+		return operation, "other"
+	default:
+		return "other", "other"
+	}
+	for i := 1; i < len(fields)-1; i++ {
+		if fields[i] == after {
+			return operation, tableName(fields[i+1])
+		}
+	}
+	return operation, "other"
+}
+
+// tableName strips the schema, quotes and trailing punctuation from a table reference
+func tableName(ref string) string {
+	ref = strings.TrimRight(ref, ",;(")
+	if i := strings.LastIndexByte(ref, '.'); i >= 0 {
+		ref = ref[i+1:]
+	}
+	ref = strings.Trim(ref, `"`)
+	if ref == "" || strings.HasPrefix(ref, "(") {
+		return "other"
+	}
+	return ref
+}
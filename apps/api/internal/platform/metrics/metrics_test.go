@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestQueryLabels(t *testing.T) {
+	tests := []struct {
+		query     string
+		operation string
+		table     string
+	}{
+		{"SELECT id FROM posts WHERE id = $1", "select", "posts"},
+		{"select count(*) from public.comments c", "select", "comments"},
+		{"INSERT INTO \"votes\" (id) VALUES ($1)", "insert", "votes"},
+		{"UPDATE posts SET score = score + 1", "update", "posts"},
+		{"DELETE FROM bookmarks WHERE owner = $1", "delete", "bookmarks"},
+		{"WITH closed AS (UPDATE post_live_threads SET x = 1) SELECT 1", "with", "other"},
+		{"SELECT 1", "select", "other"},
+		{"BEGIN", "other", "other"},
+		{"   ", "other", "other"},
+	}
+	for _, tt := range tests {
+		operation, table := queryLabels(tt.query)
+		assert.Equal(t, tt.operation, operation, tt.query)
+		assert.Equal(t, tt.table, table, tt.query)
+	}
+}
+
+func TestMiddleware_LabelsRoutePattern(t *testing.T) {
+	m := New(nil)
+	app := fiber.New()
+	app.Use(m.Middleware())
+	app.Get("/posts/:postId", func(c *fiber.Ctx) error { return c.SendString("ok") })
+
+	for _, path := range []string{"/posts/1", "/posts/2", "/missing"} {
+		_, err := app.Test(httptest.NewRequest("GET", path, nil))
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 2.0, sample(t, m, "http_request_duration_seconds", map[string]string{"route": "/posts/:postId", "status": "200"}))
+	assert.Equal(t, 1.0, sample(t, m, "http_request_duration_seconds", map[string]string{"route": unmatchedRoute, "status": "404"}))
+}
+
+func TestObserveCacheLookupAndQuery(t *testing.T) {
+	m := New(nil)
+	m.ObserveCacheLookup("posts", CacheHit)
+	m.ObserveCacheLookup("posts", CacheHit)
+	m.ObserveCacheLookup("posts", CacheMiss)
+	assert.Equal(t, 2.0, sample(t, m, "cache_lookups_total", map[string]string{"cache": "posts", "result": CacheHit}))
+	assert.Equal(t, 1.0, sample(t, m, "cache_lookups_total", map[string]string{"cache": "posts", "result": CacheMiss}))
+
+	m.ObserveQuery("SELECT * FROM posts", time.Millisecond, nil)
+	m.ObserveQuery("SELECT * FROM posts", time.Millisecond, errors.New("boom"))
+	assert.Equal(t, 1.0, sample(t, m, "db_query_duration_seconds", map[string]string{"operation": "select", "table": "posts", "outcome": "ok"}))
+	assert.Equal(t, 1.0, sample(t, m, "db_query_duration_seconds", map[string]string{"operation": "select", "table": "posts", "outcome": "error"}))
+}
+
+func TestObserveEmailDomainCheck(t *testing.T) {
+	m := New(nil)
+	m.ObserveEmailDomainCheck("deny", EmailDomainRejected)
+	m.ObserveEmailDomainCheck("allow", EmailDomainAllowed)
+	m.ObserveEmailDomainCheck("allow", EmailDomainAllowed)
+	assert.Equal(t, 1.0, sample(t, m, "signup_email_domain_checks_total", map[string]string{"list": "deny", "result": EmailDomainRejected}))
+	assert.Equal(t, 2.0, sample(t, m, "signup_email_domain_checks_total", map[string]string{"list": "allow", "result": EmailDomainAllowed}))
+}
+
+func TestUnaryClientInterceptor_RecordsCode(t *testing.T) {
+	m := New(nil)
+	interceptor := m.UnaryClientInterceptor()
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return status.Error(codes.NotFound, "missing")
+	}
+
+	err := interceptor(context.Background(), "/posts.PostsService/GetPost", nil, nil, nil, invoker)
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, 1.0, sample(t, m, "grpc_client_call_duration_seconds", map[string]string{"method": "/posts.PostsService/GetPost", "code": "NotFound"}))
+}
+
+// sample returns the value of a counter, or the sample count of a histogram, whose
+// labels include want
+func sample(t *testing.T, m *Metrics, name string, want map[string]string) float64 {
+	t.Helper()
+	families, err := m.Registry().Gather()
+	require.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.GetMetric() {
+			labels := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			for key, value := range want {
+				if labels[key] != value {
+					continue metrics
+				}
+			}
+			if metric.GetHistogram() != nil {
+				return float64(metric.GetHistogram().GetSampleCount())
+			}
+			return metric.GetCounter().GetValue()
+		}
+	}
+	return 0
+}
@@ -0,0 +1,176 @@
+package ocr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+// Extractor abstracts text extraction from images for DI and testing.
+type Extractor interface {
+	ExtractText(ctx context.Context, imageURL string) (string, error)
+}
+
+// maxImageBytes caps how much of a remote image is downloaded for OCR.
+const maxImageBytes = 10 << 20
+
+// maxImageRedirects caps the redirects followed when downloading an image.
+const maxImageRedirects = 5
+
+// ErrImageSourceNotAllowed is returned for image URLs outside the allowed hosts, and for
+// hosts that resolve to loopback, private or link-local addresses.
+var ErrImageSourceNotAllowed = errors.New("image source not allowed")
+
+// NewExtractor creates the extractor selected by cfg.Provider. Images are only read
+// from cfg.AllowedHosts and the host of storage.PublicURL, where uploads are served.
+// Returns (nil, nil) when OCR is disabled.
+func NewExtractor(cfg platformconfig.OCRConfig, storage platformconfig.StorageConfig) (Extractor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	hosts := append([]string{}, cfg.AllowedHosts...)
+	if publicURL, err := url.Parse(storage.PublicURL); err == nil && publicURL.Hostname() != "" {
+		hosts = append(hosts, publicURL.Hostname())
+	}
+	sources := NewImageSources(hosts)
+	if len(sources.hosts) == 0 {
+		return nil, fmt.Errorf("OCR needs STORAGE_PUBLIC_URL or OCR_ALLOWED_HOSTS to know where images may be read from")
+	}
+
+	switch strings.ToLower(cfg.Provider) {
+	case "", "tesseract":
+		return NewTesseractExtractor(cfg.TesseractPath, cfg.Language, sources, cfg.Timeout), nil
+	case "vision":
+		return NewVisionExtractor(cfg.VisionEndpoint, cfg.VisionAPIKey, cfg.VisionModel, sources, cfg.Timeout)
+	default:
+		return nil, fmt.Errorf("unsupported OCR provider: %s", cfg.Provider)
+	}
+}
+
+// ImageSources are the hosts images may be read from. Post images are user input, so
+// reading them is limited to the deployment's storage and CDN hosts, and a download
+// never connects to a loopback, private or link-local address, whatever a host resolves
+// to or redirects to.
+type ImageSources struct {
+	hosts map[string]bool
+}
+
+// NewImageSources allows images from hosts, matched case-insensitively without port.
+func NewImageSources(hosts []string) ImageSources {
+	sources := ImageSources{hosts: make(map[string]bool)}
+	for _, host := range hosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			sources.hosts[host] = true
+		}
+	}
+	return sources
+}
+
+// Check returns ErrImageSourceNotAllowed unless imageURL is an http or https URL on an
+// allowed host.
+func (s ImageSources) Check(imageURL string) error {
+	u, err := url.Parse(imageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("%w: %q is not an http URL", ErrImageSourceNotAllowed, imageURL)
+	}
+	if !s.hosts[strings.ToLower(u.Hostname())] {
+		return fmt.Errorf("%w: host %q", ErrImageSourceNotAllowed, u.Hostname())
+	}
+	return nil
+}
+
+// client returns an HTTP client for image downloads. Addresses are checked as they are
+// dialed, after DNS resolution, so neither DNS rebinding nor a redirect reaches an
+// internal address; redirects must also stay on the allowed hosts.
+func (s ImageSources) client(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	dialer := &net.Dialer{Timeout: timeout, Control: checkDialAddress}
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy:               nil, // A proxy would dial on our behalf, past the address check
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxImageRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxImageRedirects)
+			}
+			return s.Check(req.URL.String())
+		},
+	}
+}
+
+// checkDialAddress refuses connections to addresses inside the deployment's network
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: %q is not an IP address", ErrImageSourceNotAllowed, host)
+	}
+	if !isPublicAddr(addr) {
+		return fmt.Errorf("%w: %s is not a public address", ErrImageSourceNotAllowed, addr)
+	}
+	return nil
+}
+
+// sharedAddressSpace is carrier-grade NAT space (RFC 6598), private in practice
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// isPublicAddr reports whether addr is routable on the internet
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// fetchImage downloads an image from an allowed source, refusing bodies larger than
+// maxImageBytes.
+func fetchImage(ctx context.Context, client *http.Client, sources ImageSources, imageURL string) ([]byte, error) {
+	if err := sources.Check(imageURL); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build image request: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download image: status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	if len(data) > maxImageBytes {
+		return nil, fmt.Errorf("image exceeds %d bytes", maxImageBytes)
+	}
+	return data, nil
+}
+
+func newHTTPClient(timeout time.Duration) *http.Client {
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	return &http.Client{Timeout: timeout}
+}
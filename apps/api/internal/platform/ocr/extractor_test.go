@@ -0,0 +1,67 @@
+package ocr
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageSources_Check(t *testing.T) {
+	sources := NewImageSources([]string{"media.example.com", " CDN.example.com "})
+
+	assert.NoError(t, sources.Check("https://media.example.com/a.png"))
+	assert.NoError(t, sources.Check("http://cdn.example.com:8080/b.png"))
+	for _, imageURL := range []string{
+		"https://evil.example.com/a.png",
+		"https://media.example.com.evil.com/a.png",
+		"file:///etc/passwd",
+		"http://169.254.169.254/latest/meta-data/",
+		"not a url",
+	} {
+		assert.ErrorIs(t, sources.Check(imageURL), ErrImageSourceNotAllowed, imageURL)
+	}
+}
+
+func TestIsPublicAddr(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fe80::1", "fd00::1", "::ffff:127.0.0.1"} {
+		assert.False(t, isPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+	for _, addr := range []string{"8.8.8.8", "2606:4700::1111"} {
+		assert.True(t, isPublicAddr(netip.MustParseAddr(addr)), addr)
+	}
+}
+
+func TestFetchImage_RefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("image"))
+	}))
+	defer server.Close()
+
+	// Even an allowed host is refused when it resolves to a loopback address
+	host := mustHostname(t, server.URL)
+	sources := NewImageSources([]string{host})
+	_, err := fetchImage(t.Context(), sources.client(time.Second), sources, server.URL+"/a.png")
+	assert.ErrorIs(t, err, ErrImageSourceNotAllowed)
+}
+
+func TestFetchImage_RefusesRedirectsOffAllowedHosts(t *testing.T) {
+	sources := NewImageSources([]string{"media.example.com"})
+	client := sources.client(time.Second)
+
+	req, err := http.NewRequest(http.MethodGet, "https://evil.example.com/a.png", nil)
+	require.NoError(t, err)
+	via := []*http.Request{{URL: &url.URL{Scheme: "https", Host: "media.example.com"}}}
+	assert.ErrorIs(t, client.CheckRedirect(req, via), ErrImageSourceNotAllowed)
+}
+
+func mustHostname(t *testing.T, rawURL string) string {
+	u, err := url.Parse(rawURL)
+	require.NoError(t, err)
+	return u.Hostname()
+}
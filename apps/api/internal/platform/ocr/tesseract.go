@@ -0,0 +1,50 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// TesseractExtractor runs the tesseract CLI against downloaded images.
+type TesseractExtractor struct {
+	binary   string
+	language string
+	sources  ImageSources
+	client   *http.Client
+}
+
+// NewTesseractExtractor creates a tesseract-backed extractor reading images from sources.
+// binary defaults to "tesseract" on PATH and language defaults to "eng".
+func NewTesseractExtractor(binary, language string, sources ImageSources, timeout time.Duration) *TesseractExtractor {
+	if binary == "" {
+		binary = "tesseract"
+	}
+	if language == "" {
+		language = "eng"
+	}
+	return &TesseractExtractor{binary: binary, language: language, sources: sources, client: sources.client(timeout)}
+}
+
+func (e *TesseractExtractor) ExtractText(ctx context.Context, imageURL string) (string, error) {
+	image, err := fetchImage(ctx, e.client, e.sources, imageURL)
+	if err != nil {
+		return "", err
+	}
+
+	// "stdin stdout" makes tesseract read the image from stdin and print text to stdout
+	cmd := exec.CommandContext(ctx, e.binary, "stdin", "stdout", "-l", e.language)
+	cmd.Stdin = bytes.NewReader(image)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("tesseract failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,109 @@
+package ocr
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const visionPrompt = "Transcribe all legible text in this image. Reply with the text only, or an empty reply if there is none."
+
+// VisionExtractor asks an OpenAI-compatible chat completions endpoint with vision
+// support to transcribe the text in an image.
+type VisionExtractor struct {
+	endpoint string
+	apiKey   string
+	model    string
+	sources  ImageSources
+	client   *http.Client
+}
+
+// NewVisionExtractor creates a vision-model-backed extractor. endpoint is the full
+// chat completions URL (e.g. https://api.openai.com/v1/chat/completions); only image
+// URLs from sources are passed to it.
+func NewVisionExtractor(endpoint, apiKey, model string, sources ImageSources, timeout time.Duration) (*VisionExtractor, error) {
+	if endpoint == "" || model == "" {
+		return nil, fmt.Errorf("vision OCR endpoint and model are required")
+	}
+	return &VisionExtractor{endpoint: endpoint, apiKey: apiKey, model: model, sources: sources, client: newHTTPClient(timeout)}, nil
+}
+
+type visionContentPart struct {
+	Type     string          `json:"type"`
+	Text     string          `json:"text,omitempty"`
+	ImageURL *visionImageURL `json:"image_url,omitempty"`
+}
+
+type visionImageURL struct {
+	URL string `json:"url"`
+}
+
+type visionMessage struct {
+	Role    string              `json:"role"`
+	Content []visionContentPart `json:"content"`
+}
+
+type visionRequest struct {
+	Model       string          `json:"model"`
+	Messages    []visionMessage `json:"messages"`
+	Temperature float64         `json:"temperature"`
+}
+
+type visionResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+}
+
+func (e *VisionExtractor) ExtractText(ctx context.Context, imageURL string) (string, error) {
+	if err := e.sources.Check(imageURL); err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(visionRequest{
+		Model: e.model,
+		Messages: []visionMessage{{
+			Role: "user",
+			Content: []visionContentPart{
+				{Type: "text", Text: visionPrompt},
+				{Type: "image_url", ImageURL: &visionImageURL{URL: imageURL}},
+			},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode vision request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf("failed to build vision request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.apiKey)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vision request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vision request failed: status %d", resp.StatusCode)
+	}
+
+	var out visionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", fmt.Errorf("failed to decode vision response: %w", err)
+	}
+	if len(out.Choices) == 0 {
+		return "", nil
+	}
+	return strings.TrimSpace(out.Choices[0].Message.Content), nil
+}
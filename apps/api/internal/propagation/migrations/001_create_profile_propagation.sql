@@ -0,0 +1,12 @@
+-- Progress of copying a user's changed name and avatar into the services that store them:
+-- one row per user and target service, holding the latest change
+CREATE TABLE IF NOT EXISTS profile_propagation (
+    user_id UUID NOT NULL,
+    target VARCHAR(50) NOT NULL,
+    version BIGINT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, target)
+);
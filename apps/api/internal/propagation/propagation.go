@@ -0,0 +1,102 @@
+// Package propagation copies a user's changed name and avatar into the services that
+// store them next to the user's content: posts and co-authorships, comments and the
+// mentions users are notified of.
+//
+// The profile service writes a profile.updated event to the outbox in the transaction
+// changing the profile and records the change as pending for every target. Each target
+// subscribes with a consumer group of its own and applies the change in the transaction
+// that records it as done. A failed attempt is recorded and retried by the broker until
+// the attempts run out; a change that arrives after a newer one was applied is skipped.
+package propagation
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/outbox"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Services a changed name and avatar are copied into
+const (
+	TargetPosts    = "posts"
+	TargetComments = "comments"
+	TargetMentions = "mentions"
+)
+
+// Targets lists every target; a change is pending for each until it applied it
+var Targets = []string{TargetPosts, TargetComments, TargetMentions}
+
+// Status of a change at one target
+const (
+	StatusPending = "pending"
+	StatusDone    = "done"
+	StatusFailed  = "failed" // The attempts ran out
+)
+
+// DefaultMaxAttempts bounds the attempts of one target at one change when none is configured
+const DefaultMaxAttempts = 10
+
+// consumerPrefix names the outbox consumer group of each target
+const consumerPrefix = "profile-propagation."
+
+// Progress is where the latest change of a user's name and avatar stands at one target
+type Progress struct {
+	UserId    uuid.UUID `json:"-" db:"user_id"`
+	Target    string    `json:"target" db:"target"`
+	Version   int64     `json:"version" db:"version"`
+	Status    string    `json:"status" db:"status"`
+	Attempts  int       `json:"attempts" db:"attempts"`
+	LastError string    `json:"lastError,omitempty" db:"last_error"`
+	UpdatedAt time.Time `json:"updatedAt" db:"updated_at"`
+}
+
+// Apply copies a changed name and avatar into a target's records. It joins the
+// transaction carried by ctx, so its writes commit together with the progress.
+type Apply func(ctx context.Context, event sharedInterfaces.ProfileUpdatedEvent) error
+
+// Subscribe applies the profile changes announced through the outbox to target. An
+// attempt that fails is retried by the broker until maxAttempts were made.
+func Subscribe(ctx context.Context, broker outbox.Broker, outboxRepo outbox.Repository, progress Repository, target string, maxAttempts int, apply Apply) error {
+	eventTypes := []string{sharedInterfaces.OutboxProfileUpdated}
+	return outbox.Subscribe(ctx, broker, outboxRepo, consumerPrefix+target, eventTypes, handler(progress, target, maxAttempts, apply))
+}
+
+// handler applies one profile.updated event to target in the consumer's transaction
+func handler(progress Repository, target string, maxAttempts int, apply Apply) outbox.Handler {
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	return func(txCtx context.Context, msg outbox.Message) error {
+		var event sharedInterfaces.ProfileUpdatedEvent
+		if err := msg.Decode(&event); err != nil {
+			log.Error("Skipping outbox event: %v", err)
+			return nil
+		}
+
+		current, err := progress.Current(txCtx, event.UserId, target, event.Version)
+		if err != nil {
+			return err
+		}
+		if !current {
+			return nil
+		}
+
+		if err := apply(txCtx, event); err != nil {
+			gaveUp, recordErr := progress.Fail(txCtx, event.UserId, target, event.Version, err, maxAttempts)
+			if recordErr != nil {
+				log.Error("Failed to record profile propagation attempt of %s to %s: %v", event.UserId, target, recordErr)
+				return err
+			}
+			if gaveUp {
+				log.Error("Giving up propagating the profile of %s to %s after %d attempts: %v", event.UserId, target, maxAttempts, err)
+				return nil
+			}
+			return err
+		}
+		return progress.Complete(txCtx, event.UserId, target, event.Version)
+	}
+}
@@ -0,0 +1,115 @@
+package propagation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/outbox"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// memoryRepository keeps progress in memory, keyed by target
+type memoryRepository struct {
+	rows map[string]*Progress
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{rows: make(map[string]*Progress)}
+}
+
+func (r *memoryRepository) Start(ctx context.Context, userID uuid.UUID, version int64, targets []string) error {
+	for _, target := range targets {
+		if row, ok := r.rows[target]; !ok || row.Version < version {
+			r.rows[target] = &Progress{UserId: userID, Target: target, Version: version, Status: StatusPending}
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) Current(ctx context.Context, userID uuid.UUID, target string, version int64) (bool, error) {
+	row, ok := r.rows[target]
+	return !ok || row.Version <= version, nil
+}
+
+func (r *memoryRepository) Complete(ctx context.Context, userID uuid.UUID, target string, version int64) error {
+	row := r.rows[target]
+	row.Status = StatusDone
+	row.Attempts++
+	return nil
+}
+
+func (r *memoryRepository) Fail(ctx context.Context, userID uuid.UUID, target string, version int64, cause error, maxAttempts int) (bool, error) {
+	row := r.rows[target]
+	row.Attempts++
+	row.LastError = cause.Error()
+	if row.Attempts >= maxAttempts {
+		row.Status = StatusFailed
+	}
+	return row.Status == StatusFailed, nil
+}
+
+func (r *memoryRepository) List(ctx context.Context, userID uuid.UUID) ([]Progress, error) {
+	return nil, nil
+}
+
+func (r *memoryRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	r.rows = make(map[string]*Progress)
+	return nil
+}
+
+func profileUpdated(t *testing.T, event sharedInterfaces.ProfileUpdatedEvent) outbox.Message {
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	return outbox.Message{ID: uuid.Must(uuid.NewV4()), Type: sharedInterfaces.OutboxProfileUpdated, Data: data}
+}
+
+func TestHandler_AppliesChangeAndRecordsProgress(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	userID := uuid.Must(uuid.NewV4())
+	require.NoError(t, repo.Start(ctx, userID, 100, Targets))
+
+	var applied []string
+	handle := handler(repo, TargetComments, 3, func(ctx context.Context, event sharedInterfaces.ProfileUpdatedEvent) error {
+		applied = append(applied, event.FullName)
+		return nil
+	})
+
+	require.NoError(t, handle(ctx, profileUpdated(t, sharedInterfaces.ProfileUpdatedEvent{UserId: userID, FullName: "New Name", Version: 100})))
+	assert.Equal(t, []string{"New Name"}, applied)
+	assert.Equal(t, StatusDone, repo.rows[TargetComments].Status)
+	assert.Equal(t, StatusPending, repo.rows[TargetPosts].Status)
+
+	// A change older than the one applied arrives late and is skipped
+	require.NoError(t, handle(ctx, profileUpdated(t, sharedInterfaces.ProfileUpdatedEvent{UserId: userID, FullName: "Old Name", Version: 90})))
+	assert.Equal(t, []string{"New Name"}, applied)
+}
+
+func TestHandler_RetriesUntilAttemptsRunOut(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemoryRepository()
+	userID := uuid.Must(uuid.NewV4())
+	require.NoError(t, repo.Start(ctx, userID, 100, Targets))
+
+	failure := errors.New("database unavailable")
+	handle := handler(repo, TargetPosts, 2, func(ctx context.Context, event sharedInterfaces.ProfileUpdatedEvent) error {
+		return failure
+	})
+	msg := profileUpdated(t, sharedInterfaces.ProfileUpdatedEvent{UserId: userID, FullName: "New Name", Version: 100})
+
+	// The first failure asks the broker for another delivery
+	assert.ErrorIs(t, handle(ctx, msg), failure)
+	assert.Equal(t, StatusPending, repo.rows[TargetPosts].Status)
+	assert.Equal(t, "database unavailable", repo.rows[TargetPosts].LastError)
+
+	// The last one is recorded and the event let go
+	assert.NoError(t, handle(ctx, msg))
+	assert.Equal(t, StatusFailed, repo.rows[TargetPosts].Status)
+	assert.Equal(t, 2, repo.rows[TargetPosts].Attempts)
+}
@@ -0,0 +1,137 @@
+package propagation
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+// maxErrorLength caps the error text kept with a failed attempt
+const maxErrorLength = 1000
+
+// Repository stores the progress of profile changes at each target
+type Repository interface {
+	// Start records the change of userID at version as pending for targets. It joins the
+	// transaction carried by ctx, if any.
+	Start(ctx context.Context, userID uuid.UUID, version int64, targets []string) error
+	// Current reports whether target should apply the change at version: false once it
+	// applied a newer change or a newer one is pending
+	Current(ctx context.Context, userID uuid.UUID, target string, version int64) (bool, error)
+	// Complete records that target applied the change at version, in the caller's transaction
+	Complete(ctx context.Context, userID uuid.UUID, target string, version int64) error
+	// Fail records a failed attempt of target at the change. It writes outside the
+	// caller's transaction, which is rolled back. Reports whether the attempts ran out.
+	Fail(ctx context.Context, userID uuid.UUID, target string, version int64, cause error, maxAttempts int) (bool, error)
+	// List returns the progress of the latest change of userID at each target
+	List(ctx context.Context, userID uuid.UUID) ([]Progress, error)
+	// DeleteByUser forgets the changes of userID, in the caller's transaction
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
+
+type postgresRepository struct {
+	client *postgres.Client
+}
+
+// NewPostgresRepository creates the progress repository
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// Start resets the rows of older changes; a newer change recorded first is kept
+func (r *postgresRepository) Start(ctx context.Context, userID uuid.UUID, version int64, targets []string) error {
+	query := `
+		INSERT INTO profile_propagation (user_id, target, version, status)
+		SELECT $1, t, $2, 'pending' FROM unnest($3::text[]) AS t
+		ON CONFLICT (user_id, target) DO UPDATE SET
+			version = EXCLUDED.version,
+			status = 'pending',
+			attempts = 0,
+			last_error = NULL,
+			updated_at = NOW()
+		WHERE profile_propagation.version < EXCLUDED.version`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID, version, pq.Array(targets)); err != nil {
+		return fmt.Errorf("failed to start profile propagation: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) Current(ctx context.Context, userID uuid.UUID, target string, version int64) (bool, error) {
+	var latest []int64
+	query := `SELECT version FROM profile_propagation WHERE user_id = $1 AND target = $2`
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &latest, query, userID, target); err != nil {
+		return false, fmt.Errorf("failed to read profile propagation: %w", err)
+	}
+	return len(latest) == 0 || latest[0] <= version, nil
+}
+
+func (r *postgresRepository) Complete(ctx context.Context, userID uuid.UUID, target string, version int64) error {
+	query := `
+		INSERT INTO profile_propagation (user_id, target, version, status, attempts)
+		VALUES ($1, $2, $3, 'done', 1)
+		ON CONFLICT (user_id, target) DO UPDATE SET
+			status = 'done',
+			attempts = profile_propagation.attempts + 1,
+			last_error = NULL,
+			updated_at = NOW()
+		WHERE profile_propagation.version = EXCLUDED.version`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID, target, version); err != nil {
+		return fmt.Errorf("failed to complete profile propagation: %w", err)
+	}
+	return nil
+}
+
+// Fail writes on the connection pool, never in the transaction carried by ctx
+func (r *postgresRepository) Fail(ctx context.Context, userID uuid.UUID, target string, version int64, cause error, maxAttempts int) (bool, error) {
+	message := cause.Error()
+	if len(message) > maxErrorLength {
+		message = message[:maxErrorLength]
+	}
+	query := `
+		INSERT INTO profile_propagation (user_id, target, version, status, attempts, last_error)
+		VALUES ($1, $2, $3, CASE WHEN $5 <= 1 THEN 'failed' ELSE 'pending' END, 1, $4)
+		ON CONFLICT (user_id, target) DO UPDATE SET
+			attempts = profile_propagation.attempts + 1,
+			status = CASE WHEN profile_propagation.attempts + 1 >= $5 THEN 'failed' ELSE 'pending' END,
+			last_error = EXCLUDED.last_error,
+			updated_at = NOW()
+		WHERE profile_propagation.version = EXCLUDED.version
+		RETURNING status`
+	var status []string
+	if err := sqlx.SelectContext(ctx, r.client.DB(), &status, query, userID, target, version, message, maxAttempts); err != nil {
+		return false, fmt.Errorf("failed to record profile propagation attempt: %w", err)
+	}
+	return len(status) > 0 && status[0] == StatusFailed, nil
+}
+
+func (r *postgresRepository) List(ctx context.Context, userID uuid.UUID) ([]Progress, error) {
+	query := `
+		SELECT user_id, target, version, status, attempts, COALESCE(last_error, '') AS last_error, updated_at
+		FROM profile_propagation WHERE user_id = $1 ORDER BY target`
+	progress := []Progress{}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &progress, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list profile propagation: %w", err)
+	}
+	return progress, nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM profile_propagation WHERE user_id = $1`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete profile propagation: %w", err)
+	}
+	return nil
+}
@@ -17,6 +17,7 @@ const (
 
 // Common Values
 const (
-	UserRole  = "user"
-	AdminRole = "admin"
+	UserRole      = "user"
+	AdminRole     = "admin"
+	ModeratorRole = "moderator"
 )
@@ -14,4 +14,10 @@ type UserContext struct {
 	TagLine     string    `json:"tagLine"`
 	SystemRole  string    `json:"role"`
 	CreatedDate int64     `json:"createdDate"`
+
+	// SessionID is the "jti" claim of the token, empty for HMAC calls
+	SessionID string `json:"jti,omitempty"`
+	// LoginUserID is the account that signed in when the token acts as a linked
+	// account ("loginUid" claim); uuid.Nil when the token is the signed-in account's own
+	LoginUserID uuid.UUID `json:"loginUid,omitempty"`
 }
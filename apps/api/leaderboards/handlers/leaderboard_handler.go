@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/leaderboards/errors"
+	"github.com/qolzam/telar/apps/api/leaderboards/services"
+)
+
+type LeaderboardHandler struct {
+	service services.Service
+}
+
+func NewLeaderboardHandler(service services.Service) *LeaderboardHandler {
+	return &LeaderboardHandler{service: service}
+}
+
+// Get returns the top posters and most helpful commenters of a scope and period.
+// Endpoint: GET /leaderboards/:scope/:period
+func (h *LeaderboardHandler) Get(c *fiber.Ctx) error {
+	resp, err := h.service.Leaderboard(c.Context(), c.Params("scope"), c.Params("period"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
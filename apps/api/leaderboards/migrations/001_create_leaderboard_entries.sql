@@ -0,0 +1,14 @@
+-- Leaderboard summaries. A refresh job recomputes each (scope, period) from posts and
+-- comments and replaces its rows in one transaction, so reads never aggregate.
+CREATE TABLE IF NOT EXISTS leaderboard_entries (
+    scope VARCHAR(64) NOT NULL,
+    period VARCHAR(16) NOT NULL,
+    board VARCHAR(32) NOT NULL,
+    rank INT NOT NULL,
+    user_id UUID NOT NULL,
+    score BIGINT NOT NULL,
+    computed_date BIGINT NOT NULL,
+
+    PRIMARY KEY (scope, period, board, rank)
+);
+
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ScopeSite ranks every member. Per-group scopes need a groups subsystem; the scope is
+// stored with each entry so they can be added without a schema change.
+const ScopeSite = "site"
+
+// Boards computed for every scope and period
+const (
+	BoardPosters    = "posters"    // Live, non-anonymous posts written in the period
+	BoardCommenters = "commenters" // Score received on comments written in the period
+)
+
+// Period is a rolling window leaderboards are computed over
+type Period struct {
+	Name   string
+	Window time.Duration
+}
+
+// Periods lists the supported periods
+var Periods = []Period{
+	{Name: "daily", Window: 24 * time.Hour},
+	{Name: "weekly", Window: 7 * 24 * time.Hour},
+}
+
+// FindPeriod returns the period with the given name
+func FindPeriod(name string) (Period, bool) {
+	for _, period := range Periods {
+		if period.Name == name {
+			return period, true
+		}
+	}
+	return Period{}, false
+}
+
+// Entry is a ranked user on a board
+type Entry struct {
+	Rank       int       `json:"rank" db:"rank"`
+	UserId     uuid.UUID `json:"userId" db:"user_id"`
+	Score      int64     `json:"score" db:"score"`
+	FullName   string    `json:"fullName" db:"full_name"`
+	SocialName string    `json:"socialName" db:"social_name"`
+	Avatar     string    `json:"avatar" db:"avatar"`
+}
+
+// EntryRow is a stored entry with the board it belongs to
+type EntryRow struct {
+	Entry
+	Board        string `db:"board"`
+	ComputedDate int64  `db:"computed_date"`
+}
+
+// LeaderboardResponse holds every board of a scope and period. ComputedDate is when
+// the boards were last refreshed; 0 when they have not been computed yet.
+type LeaderboardResponse struct {
+	Scope             string  `json:"scope"`
+	Period            string  `json:"period"`
+	ComputedDate      int64   `json:"computedDate"`
+	TopPosters        []Entry `json:"topPosters"`
+	HelpfulCommenters []Entry `json:"helpfulCommenters"`
+}
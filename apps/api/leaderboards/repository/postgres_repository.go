@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/leaderboards/models"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) LeaderboardRepository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) LeaderboardRepository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// Refresh counts live, non-anonymous posts for the posters board, and the score of live
+// comments, other than those made as the hidden author of an anonymous post, for the
+// commenters board. Readers see either the old or the new entries, never a mix.
+func (r *postgresRepository) Refresh(ctx context.Context, scope, period string, since time.Time, computedDate int64, limit int) error {
+	deleteQuery := fmt.Sprintf(`DELETE FROM %sleaderboard_entries WHERE scope = $1 AND period = $2`, r.schemaPrefix())
+	postersQuery := fmt.Sprintf(`
+		INSERT INTO %[1]sleaderboard_entries (scope, period, board, rank, user_id, score, computed_date)
+		SELECT $1, $2, '%[2]s', ROW_NUMBER() OVER (ORDER BY COUNT(*) DESC, owner_user_id), owner_user_id, COUNT(*), $4
+		FROM %[1]sposts
+		WHERE created_at >= $3 AND is_deleted = FALSE AND is_anonymous = FALSE
+		GROUP BY owner_user_id
+		ORDER BY COUNT(*) DESC, owner_user_id
+		LIMIT $5
+	`, r.schemaPrefix(), models.BoardPosters)
+	commentersQuery := fmt.Sprintf(`
+		INSERT INTO %[1]sleaderboard_entries (scope, period, board, rank, user_id, score, computed_date)
+		SELECT $1, $2, '%[2]s', ROW_NUMBER() OVER (ORDER BY SUM(c.score) DESC, COUNT(*) DESC, c.owner_user_id),
+			c.owner_user_id, SUM(c.score), $4
+		FROM %[1]scomments c JOIN %[1]sposts p ON p.id = c.post_id
+		WHERE c.created_at >= $3 AND c.score > 0 AND c.is_deleted = FALSE AND p.is_deleted = FALSE
+			AND NOT (p.is_anonymous AND p.owner_user_id = c.owner_user_id)
+		GROUP BY c.owner_user_id
+		ORDER BY SUM(c.score) DESC, COUNT(*) DESC, c.owner_user_id
+		LIMIT $5
+	`, r.schemaPrefix(), models.BoardCommenters)
+
+	return r.withTransaction(ctx, func(txCtx context.Context) error {
+		exec := r.getExecutor(txCtx)
+		if _, err := exec.ExecContext(txCtx, deleteQuery, scope, period); err != nil {
+			return fmt.Errorf("clear leaderboard entries: %w", err)
+		}
+		if _, err := exec.ExecContext(txCtx, postersQuery, scope, period, since, computedDate, limit); err != nil {
+			return fmt.Errorf("compute posters leaderboard: %w", err)
+		}
+		if _, err := exec.ExecContext(txCtx, commentersQuery, scope, period, since, computedDate, limit); err != nil {
+			return fmt.Errorf("compute commenters leaderboard: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *postgresRepository) List(ctx context.Context, scope, period string) ([]models.EntryRow, error) {
+	query := fmt.Sprintf(`
+		SELECT e.board, e.rank, e.user_id, e.score, e.computed_date,
+			COALESCE(p.full_name, '') AS full_name,
+			COALESCE(p.social_name, '') AS social_name,
+			COALESCE(p.avatar, '') AS avatar
+		FROM %[1]sleaderboard_entries e
+		LEFT JOIN %[1]sprofiles p ON p.user_id = e.user_id
+		WHERE e.scope = $1 AND e.period = $2
+		ORDER BY e.board, e.rank
+	`, r.schemaPrefix())
+
+	var rows []models.EntryRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, scope, period); err != nil {
+		return nil, fmt.Errorf("list leaderboard entries: %w", err)
+	}
+	return rows, nil
+}
+
+// withTransaction runs fn in a transaction, joining the caller's when there is one
+func (r *postgresRepository) withTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM %sleaderboard_entries WHERE user_id = $1`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("delete leaderboard entries of user: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/leaderboards/models"
+)
+
+// LeaderboardRepository defines data access for leaderboard summaries.
+type LeaderboardRepository interface {
+	// Refresh recomputes every board of a scope and period from activity since the given
+	// time, keeping the top limit users of each, and replaces the stored entries.
+	Refresh(ctx context.Context, scope, period string, since time.Time, computedDate int64, limit int) error
+
+	// List returns the stored entries of a scope and period, by board and rank, with the
+	// users' current profile names.
+	List(ctx context.Context, scope, period string) ([]models.EntryRow, error)
+
+	// DeleteByUser removes userID from the stored boards; the next refresh ranks the rest.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,19 @@
+package leaderboards
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/leaderboards/handlers"
+)
+
+type Handlers struct {
+	LeaderboardHandler *handlers.LeaderboardHandler
+}
+
+// RegisterRoutes wires the public leaderboards. Anonymous responses are cached until the
+// next refresh, which invalidates them.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	group := app.Group("/leaderboards")
+	group.Get("/:scope/:period", httpcache.NewFromConfig(httpcache.ScopeLeaderboards, nil, cfg.HTTPCache), handlers.LeaderboardHandler.Get)
+}
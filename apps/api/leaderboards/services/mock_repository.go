@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/leaderboards/models"
+	"github.com/qolzam/telar/apps/api/leaderboards/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the leaderboards repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.LeaderboardRepository = (*MockRepository)(nil)
+
+func (m *MockRepository) Refresh(ctx context.Context, scope, period string, since time.Time, computedDate int64, limit int) error {
+	args := m.Called(ctx, scope, period, since, computedDate, limit)
+	return args.Error(0)
+}
+
+func (m *MockRepository) List(ctx context.Context, scope, period string) ([]models.EntryRow, error) {
+	args := m.Called(ctx, scope, period)
+	rows, _ := args.Get(0).([]models.EntryRow)
+	return rows, args.Error(1)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// StartRefreshJob refreshes the leaderboards now and then every interval until ctx is
+// done. A non-positive interval disables the job.
+func StartRefreshJob(ctx context.Context, svc Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		refresh := func() {
+			if err := svc.Refresh(ctx); err != nil {
+				log.Error("Leaderboard refresh failed: %v", err)
+			}
+		}
+
+		refresh()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refresh()
+			}
+		}
+	}()
+}
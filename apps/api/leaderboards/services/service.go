@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
+	leaderboardErrors "github.com/qolzam/telar/apps/api/leaderboards/errors"
+	"github.com/qolzam/telar/apps/api/leaderboards/models"
+	"github.com/qolzam/telar/apps/api/leaderboards/repository"
+)
+
+// defaultSize is used when the leaderboards config does not set one
+const defaultSize = 25
+
+// Service defines leaderboard operations.
+type Service interface {
+	// Leaderboard returns the boards of a scope and period as last refreshed.
+	Leaderboard(ctx context.Context, scope, period string) (*models.LeaderboardResponse, error)
+
+	// Refresh recomputes every board of every scope and period.
+	Refresh(ctx context.Context) error
+}
+
+type service struct {
+	repo repository.LeaderboardRepository
+	size int
+	now  func() time.Time
+}
+
+// NewService constructs a leaderboards service keeping the top size users of each board.
+func NewService(repo repository.LeaderboardRepository, size int) Service {
+	if size <= 0 {
+		size = defaultSize
+	}
+	return &service{repo: repo, size: size, now: time.Now}
+}
+
+func (s *service) Leaderboard(ctx context.Context, scope, period string) (*models.LeaderboardResponse, error) {
+	if scope != models.ScopeSite {
+		return nil, fmt.Errorf("%w: unknown scope %q", leaderboardErrors.ErrNotFound, scope)
+	}
+	if _, ok := models.FindPeriod(period); !ok {
+		return nil, fmt.Errorf("%w: unknown period %q", leaderboardErrors.ErrNotFound, period)
+	}
+
+	rows, err := s.repo.List(ctx, scope, period)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", leaderboardErrors.ErrDatabaseOperation, err)
+	}
+	response := &models.LeaderboardResponse{
+		Scope:             scope,
+		Period:            period,
+		TopPosters:        []models.Entry{},
+		HelpfulCommenters: []models.Entry{},
+	}
+	for _, row := range rows {
+		response.ComputedDate = row.ComputedDate
+		switch row.Board {
+		case models.BoardPosters:
+			response.TopPosters = append(response.TopPosters, row.Entry)
+		case models.BoardCommenters:
+			response.HelpfulCommenters = append(response.HelpfulCommenters, row.Entry)
+		}
+	}
+	return response, nil
+}
+
+func (s *service) Refresh(ctx context.Context) error {
+	now := s.now().UTC()
+	for _, period := range models.Periods {
+		if err := s.repo.Refresh(ctx, models.ScopeSite, period.Name, now.Add(-period.Window), now.UnixMilli(), s.size); err != nil {
+			return fmt.Errorf("%w: %v", leaderboardErrors.ErrDatabaseOperation, err)
+		}
+	}
+	httpcache.Invalidate(ctx, httpcache.ScopeLeaderboards)
+	return nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	leaderboardErrors "github.com/qolzam/telar/apps/api/leaderboards/errors"
+	"github.com/qolzam/telar/apps/api/leaderboards/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLeaderboard_RejectsUnknownScopeAndPeriod(t *testing.T) {
+	svc := NewService(new(MockRepository), 0)
+
+	_, err := svc.Leaderboard(context.Background(), "group-123", "daily")
+	assert.ErrorIs(t, err, leaderboardErrors.ErrNotFound)
+	_, err = svc.Leaderboard(context.Background(), models.ScopeSite, "monthly")
+	assert.ErrorIs(t, err, leaderboardErrors.ErrNotFound)
+}
+
+func TestLeaderboard_SplitsBoards(t *testing.T) {
+	ctx := context.Background()
+	alice := uuid.Must(uuid.NewV4())
+	bob := uuid.Must(uuid.NewV4())
+
+	repo := new(MockRepository)
+	repo.On("List", ctx, models.ScopeSite, "weekly").Return([]models.EntryRow{
+		{Entry: models.Entry{Rank: 1, UserId: bob, Score: 12}, Board: models.BoardCommenters, ComputedDate: 1000},
+		{Entry: models.Entry{Rank: 1, UserId: alice, Score: 5}, Board: models.BoardPosters, ComputedDate: 1000},
+		{Entry: models.Entry{Rank: 2, UserId: bob, Score: 3}, Board: models.BoardPosters, ComputedDate: 1000},
+	}, nil)
+
+	resp, err := NewService(repo, 0).Leaderboard(ctx, models.ScopeSite, "weekly")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), resp.ComputedDate)
+	require.Len(t, resp.TopPosters, 2)
+	assert.Equal(t, alice, resp.TopPosters[0].UserId)
+	assert.Equal(t, bob, resp.TopPosters[1].UserId)
+	require.Len(t, resp.HelpfulCommenters, 1)
+	assert.Equal(t, int64(12), resp.HelpfulCommenters[0].Score)
+}
+
+func TestLeaderboard_EmptyBeforeFirstRefresh(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	repo.On("List", ctx, models.ScopeSite, "daily").Return(nil, nil)
+
+	resp, err := NewService(repo, 0).Leaderboard(ctx, models.ScopeSite, "daily")
+	require.NoError(t, err)
+	assert.Zero(t, resp.ComputedDate)
+	assert.NotNil(t, resp.TopPosters, "boards serialize as empty lists, not null")
+	assert.NotNil(t, resp.HelpfulCommenters)
+}
+
+func TestRefresh_ComputesEveryPeriod(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	repo := new(MockRepository)
+	repo.On("Refresh", ctx, models.ScopeSite, "daily", now.Add(-24*time.Hour), now.UnixMilli(), 10).Return(nil).Once()
+	repo.On("Refresh", ctx, models.ScopeSite, "weekly", now.Add(-7*24*time.Hour), now.UnixMilli(), 10).Return(nil).Once()
+
+	svc := NewService(repo, 10)
+	svc.(*service).now = func() time.Time { return now }
+	require.NoError(t, svc.Refresh(ctx))
+	repo.AssertExpectations(t)
+}
+
+func TestRefresh_WrapsRepositoryErrors(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	repo.On("Refresh", ctx, models.ScopeSite, "daily", mock.AnythingOfType("time.Time"), mock.Anything, defaultSize).Return(errors.New("boom"))
+
+	err := NewService(repo, 0).Refresh(ctx)
+	assert.ErrorIs(t, err, leaderboardErrors.ErrDatabaseOperation)
+}
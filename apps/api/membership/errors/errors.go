@@ -0,0 +1,76 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrApplicationNotFound = errors.New("membership application not found")
+	ErrAlreadyMember       = errors.New("already a member")
+	ErrApplicationPending  = errors.New("membership application already pending")
+	ErrAlreadyReviewed     = errors.New("membership application already reviewed")
+	ErrNotTakingApplicants = errors.New("community does not take membership applications")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrInvalidRequest      = errors.New("invalid request")
+	ErrMissingUserContext  = errors.New("missing user context")
+	ErrDatabaseOperation   = errors.New("database operation failed")
+)
+
+const (
+	CodeApplicationNotFound = "APPLICATION_NOT_FOUND"
+	CodeAlreadyMember       = "ALREADY_MEMBER"
+	CodeApplicationPending  = "APPLICATION_PENDING"
+	CodeAlreadyReviewed     = "ALREADY_REVIEWED"
+	CodeNotTakingApplicants = "NOT_TAKING_APPLICATIONS"
+	CodePermissionDenied    = "PERMISSION_DENIED"
+	CodeInvalidRequest      = "INVALID_REQUEST"
+	CodeMissingUserCtx      = "MISSING_USER_CONTEXT"
+	CodeDatabaseError       = "DATABASE_ERROR"
+	CodeInternalError       = "INTERNAL_ERROR"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func HandleServiceError(c *fiber.Ctx, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, ErrApplicationNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeApplicationNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrAlreadyMember):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeAlreadyMember, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrApplicationPending):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeApplicationPending, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrAlreadyReviewed):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeAlreadyReviewed, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrNotTakingApplicants):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeNotTakingApplicants, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrPermissionDenied):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodePermissionDenied, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrMissingUserContext):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrDatabaseOperation):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeDatabaseError, Message: err.Error(), Details: err.Error()})
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Code: CodeInternalError, Message: "An unexpected error occurred", Details: err.Error()})
+	}
+}
+
+func HandleValidationError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: message, Details: message})
+}
+
+func HandleUserContextError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: message, Details: message})
+}
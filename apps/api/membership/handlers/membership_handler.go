@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/membership/errors"
+	"github.com/qolzam/telar/apps/api/membership/models"
+	"github.com/qolzam/telar/apps/api/membership/services"
+)
+
+type MembershipHandler struct {
+	service services.Service
+}
+
+func NewMembershipHandler(service services.Service) *MembershipHandler {
+	return &MembershipHandler{service: service}
+}
+
+// ListQuestions returns the join questions applicants to the community answer.
+// Endpoint: GET /communities/:communityId/questions
+func (h *MembershipHandler) ListQuestions(c *fiber.Ctx) error {
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	questions, err := h.service.ListQuestions(c.Context(), communityID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"questions": questions,
+	})
+}
+
+// SetQuestions replaces the community's join questions.
+// Endpoint: PUT /communities/:communityId/questions
+func (h *MembershipHandler) SetQuestions(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	var req models.SetQuestionsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	questions, err := h.service.SetQuestions(c.Context(), communityID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"questions": questions,
+	})
+}
+
+// Apply submits the current user's answers to the community for review.
+// Endpoint: POST /communities/:communityId/applications
+func (h *MembershipHandler) Apply(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	var req models.ApplyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	application, err := h.service.Apply(c.Context(), communityID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(application)
+}
+
+// MyApplication returns the current user's application to the community and its review.
+// Endpoint: GET /communities/:communityId/applications/me
+func (h *MembershipHandler) MyApplication(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	application, err := h.service.MyApplication(c.Context(), communityID, user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(application)
+}
+
+// ListApplications is the community's approval queue, oldest first.
+// Endpoint: GET /communities/:communityId/applications?status=pending|approved|rejected|all&limit=&offset=
+func (h *MembershipHandler) ListApplications(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	status := c.Query("status", models.StatusPending)
+	if status == "all" {
+		status = ""
+	}
+
+	applications, err := h.service.ListApplications(c.Context(), communityID, user.UserID, status, c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"applications": applications,
+	})
+}
+
+// ReviewApplication approves or rejects a pending application to the community.
+// Endpoint: PUT /communities/:communityId/applications/:applicationId
+// Body: {"status": "approved", "note": "Welcome aboard!"}
+func (h *MembershipHandler) ReviewApplication(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	applicationID, err := uuid.FromString(c.Params("applicationId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid applicationId")
+	}
+	var req models.ReviewApplicationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	application, err := h.service.ReviewApplication(c.Context(), communityID, applicationID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(application)
+}
@@ -0,0 +1,25 @@
+-- Membership approval: on deployments that require it, users answer the join questions
+-- and a moderator approves their application before they can post or comment.
+CREATE TABLE IF NOT EXISTS membership_questions (
+    id UUID PRIMARY KEY,
+    position INT NOT NULL,
+    prompt TEXT NOT NULL,
+    required BOOLEAN NOT NULL DEFAULT TRUE,
+    created_date BIGINT NOT NULL
+);
+
+-- One application per user; a rejected applicant applies again by replacing it.
+-- answers keeps each question's prompt as the applicant saw it, so editing the
+-- questions later does not change what moderators review.
+CREATE TABLE IF NOT EXISTS membership_applications (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL UNIQUE,
+    answers JSONB NOT NULL DEFAULT '[]',
+    status TEXT NOT NULL CHECK (status IN ('pending', 'approved', 'rejected')),
+    reviewer_id UUID,
+    review_note TEXT NOT NULL DEFAULT '',
+    created_date BIGINT NOT NULL,
+    reviewed_date BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_membership_applications_queue ON membership_applications(status, created_date);
@@ -0,0 +1,20 @@
+-- Join questions and applications belong to a community: each community that requires
+-- approval sets its own questions and reviews its own queue. The deployment-wide
+-- questions and applications have no community to move to and are dropped.
+DELETE FROM membership_questions;
+DELETE FROM membership_applications;
+
+ALTER TABLE membership_questions
+    ADD COLUMN IF NOT EXISTS community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_membership_questions_community ON membership_questions(community_id, position);
+
+-- One application per user and community; users who were rejected, or who left after
+-- being approved, apply again by replacing it.
+ALTER TABLE membership_applications
+    ADD COLUMN IF NOT EXISTS community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE;
+ALTER TABLE membership_applications DROP CONSTRAINT IF EXISTS membership_applications_user_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_membership_applications_community_user ON membership_applications(community_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_membership_applications_user ON membership_applications(user_id);
+
+DROP INDEX IF EXISTS idx_membership_applications_queue;
+CREATE INDEX IF NOT EXISTS idx_membership_applications_queue ON membership_applications(community_id, status, created_date);
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// Application statuses
+const (
+	StatusPending  = "pending"
+	StatusApproved = "approved"
+	StatusRejected = "rejected"
+)
+
+// IsValidReview reports whether status is a decision a moderator can take
+func IsValidReview(status string) bool {
+	return status == StatusApproved || status == StatusRejected
+}
+
+// IsValidStatus reports whether status is a state an application can be in
+func IsValidStatus(status string) bool {
+	return status == StatusPending || IsValidReview(status)
+}
+
+// Question is one of the join questions applicants to a community answer
+type Question struct {
+	ObjectId    uuid.UUID `json:"objectId" db:"id"`
+	CommunityId uuid.UUID `json:"communityId" db:"community_id"`
+	Position    int       `json:"position" db:"position"`
+	Prompt      string    `json:"prompt" db:"prompt"`
+	Required    bool      `json:"required" db:"required"`
+	CreatedDate int64     `json:"createdDate" db:"created_date"`
+}
+
+// QuestionInput is a question in the PUT /communities/:communityId/questions request
+type QuestionInput struct {
+	Prompt   string `json:"prompt"`
+	Required bool   `json:"required"`
+}
+
+// SetQuestionsRequest is the PUT /communities/:communityId/questions request body; it replaces every
+// question, in order
+type SetQuestionsRequest struct {
+	Questions []QuestionInput `json:"questions"`
+}
+
+// Answer is an applicant's answer together with the prompt it answers
+type Answer struct {
+	QuestionId uuid.UUID `json:"questionId"`
+	Prompt     string    `json:"prompt"`
+	Answer     string    `json:"answer"`
+}
+
+// Answers is stored as a JSONB array
+type Answers []Answer
+
+// Value implements driver.Valuer
+func (a Answers) Value() (driver.Value, error) {
+	if a == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(a)
+}
+
+// Scan implements sql.Scanner
+func (a *Answers) Scan(value interface{}) error {
+	if value == nil {
+		*a = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, a)
+}
+
+// Application is a user's request to become a member of a community
+type Application struct {
+	ObjectId     uuid.UUID     `json:"objectId" db:"id"`
+	CommunityId  uuid.UUID     `json:"communityId" db:"community_id"`
+	UserId       uuid.UUID     `json:"userId" db:"user_id"`
+	Answers      Answers       `json:"answers" db:"answers"`
+	Status       string        `json:"status" db:"status"`
+	ReviewerId   uuid.NullUUID `json:"reviewerId" db:"reviewer_id"`
+	ReviewNote   string        `json:"reviewNote,omitempty" db:"review_note"`
+	CreatedDate  int64         `json:"createdDate" db:"created_date"`
+	ReviewedDate int64         `json:"reviewedDate,omitempty" db:"reviewed_date"`
+}
+
+// AnswerInput is an answer in the POST /communities/:communityId/applications request
+type AnswerInput struct {
+	QuestionId uuid.UUID `json:"questionId"`
+	Answer     string    `json:"answer"`
+}
+
+// ApplyRequest is the POST /communities/:communityId/applications request body
+type ApplyRequest struct {
+	Answers []AnswerInput `json:"answers"`
+}
+
+// ReviewApplicationRequest is the PUT /communities/:communityId/applications/:applicationId request body
+type ReviewApplicationRequest struct {
+	Status string `json:"status"` // "approved" or "rejected"
+	Note   string `json:"note"`   // Shown to the applicant; the welcome message on approval
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/membership/models"
+)
+
+const applicationColumns = `id, community_id, user_id, answers, status, reviewer_id, review_note, created_date, reviewed_date`
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) ListQuestions(ctx context.Context, communityID uuid.UUID) ([]*models.Question, error) {
+	query := fmt.Sprintf(`
+		SELECT id, community_id, position, prompt, required, created_date
+		FROM %smembership_questions
+		WHERE community_id = $1
+		ORDER BY position
+	`, r.schemaPrefix())
+
+	var questions []*models.Question
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &questions, query, communityID); err != nil {
+		return nil, fmt.Errorf("list join questions: %w", err)
+	}
+	return questions, nil
+}
+
+func (r *postgresRepository) ReplaceQuestions(ctx context.Context, communityID uuid.UUID, questions []*models.Question) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		exec := r.getExecutor(ctx)
+		deleteQuery := fmt.Sprintf(`DELETE FROM %smembership_questions WHERE community_id = $1`, r.schemaPrefix())
+		if _, err := exec.ExecContext(ctx, deleteQuery, communityID); err != nil {
+			return fmt.Errorf("delete join questions: %w", err)
+		}
+		query := fmt.Sprintf(`
+			INSERT INTO %smembership_questions (id, community_id, position, prompt, required, created_date)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, r.schemaPrefix())
+		for _, q := range questions {
+			if _, err := exec.ExecContext(ctx, query, q.ObjectId, communityID, q.Position, q.Prompt, q.Required, q.CreatedDate); err != nil {
+				return fmt.Errorf("insert join question: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// SubmitApplication upserts in one statement, so two concurrent applications from the
+// same user to the same community cannot both be stored
+func (r *postgresRepository) SubmitApplication(ctx context.Context, application *models.Application) (bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %smembership_applications AS a (id, community_id, user_id, answers, status, review_note, created_date)
+		VALUES ($1, $2, $3, $4, 'pending', '', $5)
+		ON CONFLICT (community_id, user_id) DO UPDATE
+		SET answers = EXCLUDED.answers,
+			status = 'pending',
+			reviewer_id = NULL,
+			review_note = '',
+			created_date = EXCLUDED.created_date,
+			reviewed_date = 0
+		WHERE a.status <> 'pending'
+		RETURNING id
+	`, r.schemaPrefix())
+
+	var id uuid.UUID
+	err := sqlx.GetContext(ctx, r.getExecutor(ctx), &id, query,
+		application.ObjectId, application.CommunityId, application.UserId, application.Answers, application.CreatedDate)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, fmt.Errorf("submit membership application: %w", err)
+	}
+	application.ObjectId = id
+	return true, nil
+}
+
+func (r *postgresRepository) GetApplication(ctx context.Context, communityID, applicationID uuid.UUID) (*models.Application, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %smembership_applications WHERE community_id = $1 AND id = $2`, applicationColumns, r.schemaPrefix())
+	return r.getApplication(ctx, query, communityID, applicationID)
+}
+
+func (r *postgresRepository) GetApplicationByUser(ctx context.Context, communityID, userID uuid.UUID) (*models.Application, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %smembership_applications WHERE community_id = $1 AND user_id = $2`, applicationColumns, r.schemaPrefix())
+	return r.getApplication(ctx, query, communityID, userID)
+}
+
+func (r *postgresRepository) getApplication(ctx context.Context, query string, args ...interface{}) (*models.Application, error) {
+	var application models.Application
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &application, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get membership application: %w", err)
+	}
+	return &application, nil
+}
+
+func (r *postgresRepository) ListApplications(ctx context.Context, communityID uuid.UUID, status string, limit, offset int) ([]*models.Application, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %smembership_applications
+		WHERE community_id = $1 AND ($2 = '' OR status = $2)
+		ORDER BY created_date, id
+		LIMIT $3 OFFSET $4
+	`, applicationColumns, r.schemaPrefix())
+
+	var applications []*models.Application
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &applications, query, communityID, status, limit, offset); err != nil {
+		return nil, fmt.Errorf("list membership applications: %w", err)
+	}
+	return applications, nil
+}
+
+func (r *postgresRepository) ReviewApplication(ctx context.Context, communityID, applicationID uuid.UUID, status string, reviewerID uuid.UUID, note string, now int64) (*models.Application, error) {
+	query := fmt.Sprintf(`
+		UPDATE %smembership_applications
+		SET status = $3, reviewer_id = $4, review_note = $5, reviewed_date = $6
+		WHERE community_id = $1 AND id = $2 AND status = 'pending'
+		RETURNING %s
+	`, r.schemaPrefix(), applicationColumns)
+	return r.getApplication(ctx, query, communityID, applicationID, status, reviewerID, note, now)
+}
+
+func (r *postgresRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	queries := []string{
+		`DELETE FROM %smembership_applications WHERE user_id = $1`,
+		`UPDATE %smembership_applications SET reviewer_id = NULL WHERE reviewer_id = $1`,
+	}
+	for _, query := range queries {
+		if _, err := r.getExecutor(ctx).ExecContext(ctx, fmt.Sprintf(query, r.schemaPrefix()), userID); err != nil {
+			return fmt.Errorf("delete membership of user: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
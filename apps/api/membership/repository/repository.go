@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/membership/models"
+)
+
+// ErrNotFound is returned when an application does not exist
+var ErrNotFound = errors.New("not found")
+
+// Repository defines data access for the join questions and membership applications of
+// communities.
+type Repository interface {
+	// ListQuestions returns the community's join questions in order.
+	ListQuestions(ctx context.Context, communityID uuid.UUID) ([]*models.Question, error)
+
+	// ReplaceQuestions replaces every join question of the community with questions.
+	ReplaceQuestions(ctx context.Context, communityID uuid.UUID, questions []*models.Question) error
+
+	// SubmitApplication stores a pending application, replacing the user's reviewed one
+	// to the same community. Returns false, changing nothing, while the user has a
+	// pending one there.
+	SubmitApplication(ctx context.Context, application *models.Application) (bool, error)
+
+	// GetApplication returns an application to the community or ErrNotFound.
+	GetApplication(ctx context.Context, communityID, applicationID uuid.UUID) (*models.Application, error)
+
+	// GetApplicationByUser returns the user's application to the community or ErrNotFound.
+	GetApplicationByUser(ctx context.Context, communityID, userID uuid.UUID) (*models.Application, error)
+
+	// ListApplications returns the community's applications in status (all when empty),
+	// oldest first.
+	ListApplications(ctx context.Context, communityID uuid.UUID, status string, limit, offset int) ([]*models.Application, error)
+
+	// ReviewApplication records the decision on a pending application to the community
+	// and returns it. Returns ErrNotFound when it has no pending application with that ID.
+	ReviewApplication(ctx context.Context, communityID, applicationID uuid.UUID, status string, reviewerID uuid.UUID, note string, now int64) (*models.Application, error)
+
+	// WithTransaction runs fn in a transaction, joining the caller's when there is one.
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+
+	// DeleteByUser deletes userID's applications and forgets them as the reviewer of others.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,46 @@
+package membership
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/membership/handlers"
+)
+
+type Handlers struct {
+	MembershipHandler *handlers.MembershipHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires the join questions, applications and approval queue of each
+// community. Community owners and moderators are checked by the service, not by
+// middleware.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/communities/:communityId")
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	group.Get("/questions", dualAuthMiddleware, handlers.MembershipHandler.ListQuestions)
+	group.Post("/applications", dualAuthMiddleware, handlers.MembershipHandler.Apply)
+	group.Get("/applications/me", dualAuthMiddleware, handlers.MembershipHandler.MyApplication)
+
+	// --- Owner and moderator routes ---
+	group.Put("/questions", dualAuthMiddleware, handlers.MembershipHandler.SetQuestions)
+	group.Get("/applications", dualAuthMiddleware, handlers.MembershipHandler.ListApplications)
+	group.Put("/applications/:applicationId", dualAuthMiddleware, handlers.MembershipHandler.ReviewApplication)
+}
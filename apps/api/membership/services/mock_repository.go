@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/membership/models"
+	"github.com/qolzam/telar/apps/api/membership/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the membership repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) ListQuestions(ctx context.Context, communityID uuid.UUID) ([]*models.Question, error) {
+	args := m.Called(ctx, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Question), args.Error(1)
+}
+
+func (m *MockRepository) ReplaceQuestions(ctx context.Context, communityID uuid.UUID, questions []*models.Question) error {
+	args := m.Called(ctx, communityID, questions)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SubmitApplication(ctx context.Context, application *models.Application) (bool, error) {
+	args := m.Called(ctx, application)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) GetApplication(ctx context.Context, communityID, applicationID uuid.UUID) (*models.Application, error) {
+	args := m.Called(ctx, communityID, applicationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Application), args.Error(1)
+}
+
+func (m *MockRepository) GetApplicationByUser(ctx context.Context, communityID, userID uuid.UUID) (*models.Application, error) {
+	args := m.Called(ctx, communityID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Application), args.Error(1)
+}
+
+func (m *MockRepository) ListApplications(ctx context.Context, communityID uuid.UUID, status string, limit, offset int) ([]*models.Application, error) {
+	args := m.Called(ctx, communityID, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Application), args.Error(1)
+}
+
+func (m *MockRepository) ReviewApplication(ctx context.Context, communityID, applicationID uuid.UUID, status string, reviewerID uuid.UUID, note string, now int64) (*models.Application, error) {
+	args := m.Called(ctx, communityID, applicationID, status, reviewerID, note, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Application), args.Error(1)
+}
+
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
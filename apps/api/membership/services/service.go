@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	membershipErrors "github.com/qolzam/telar/apps/api/membership/errors"
+	"github.com/qolzam/telar/apps/api/membership/models"
+	"github.com/qolzam/telar/apps/api/membership/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	maxPromptLength     = 500
+	maxReviewNoteLength = 1000
+	defaultListLimit    = 20
+	maxListLimit        = 100
+)
+
+// Service defines the join question, application and review operations of communities
+// that require approval.
+type Service interface {
+	// ListQuestions returns the community's join questions in order.
+	ListQuestions(ctx context.Context, communityID uuid.UUID) ([]*models.Question, error)
+
+	// SetQuestions replaces the community's join questions; owner and moderators only.
+	// Pending applications keep the prompts their applicants answered.
+	SetQuestions(ctx context.Context, communityID, userID uuid.UUID, req *models.SetQuestionsRequest) ([]*models.Question, error)
+
+	// Apply submits the answers of a user who is not a member to a community that
+	// requires approval, replacing their reviewed application.
+	Apply(ctx context.Context, communityID, userID uuid.UUID, req *models.ApplyRequest) (*models.Application, error)
+
+	// MyApplication returns the user's application to the community and its review.
+	MyApplication(ctx context.Context, communityID, userID uuid.UUID) (*models.Application, error)
+
+	// ListApplications returns the community's applications in status (all when empty),
+	// oldest first; owner and moderators only.
+	ListApplications(ctx context.Context, communityID, userID uuid.UUID, status string, limit, offset int) ([]*models.Application, error)
+
+	// ReviewApplication approves or rejects a pending application to the community;
+	// owner and moderators only. Approved applicants join the community and are sent a
+	// welcome notification.
+	ReviewApplication(ctx context.Context, communityID, applicationID, reviewerID uuid.UUID, req *models.ReviewApplicationRequest) (*models.Application, error)
+}
+
+type service struct {
+	repo        repository.Repository
+	communities sharedInterfaces.CommunityAdmitter
+	cfg         platformconfig.MembershipConfig
+	now         func() time.Time
+}
+
+// NewService constructs a membership service admitting approved applicants through
+// communities.
+func NewService(repo repository.Repository, communities sharedInterfaces.CommunityAdmitter, cfg platformconfig.MembershipConfig) Service {
+	return &service{repo: repo, communities: communities, cfg: cfg, now: time.Now}
+}
+
+func (s *service) ListQuestions(ctx context.Context, communityID uuid.UUID) ([]*models.Question, error) {
+	questions, err := s.repo.ListQuestions(ctx, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	if questions == nil {
+		questions = []*models.Question{}
+	}
+	return questions, nil
+}
+
+func (s *service) SetQuestions(ctx context.Context, communityID, userID uuid.UUID, req *models.SetQuestionsRequest) ([]*models.Question, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", membershipErrors.ErrInvalidRequest)
+	}
+	if err := s.checkModerator(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+	if s.cfg.MaxQuestions > 0 && len(req.Questions) > s.cfg.MaxQuestions {
+		return nil, fmt.Errorf("%w: at most %d questions", membershipErrors.ErrInvalidRequest, s.cfg.MaxQuestions)
+	}
+
+	now := s.now().UTC().UnixMilli()
+	questions := make([]*models.Question, 0, len(req.Questions))
+	for i, input := range req.Questions {
+		prompt := strings.TrimSpace(input.Prompt)
+		if prompt == "" {
+			return nil, fmt.Errorf("%w: question %d has no prompt", membershipErrors.ErrInvalidRequest, i+1)
+		}
+		if utf8.RuneCountInString(prompt) > maxPromptLength {
+			return nil, fmt.Errorf("%w: prompts are at most %d characters", membershipErrors.ErrInvalidRequest, maxPromptLength)
+		}
+		questions = append(questions, &models.Question{
+			ObjectId:    uuid.Must(uuid.NewV4()),
+			CommunityId: communityID,
+			Position:    i,
+			Prompt:      prompt,
+			Required:    input.Required,
+			CreatedDate: now,
+		})
+	}
+	if err := s.repo.ReplaceQuestions(ctx, communityID, questions); err != nil {
+		return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	return questions, nil
+}
+
+func (s *service) Apply(ctx context.Context, communityID, userID uuid.UUID, req *models.ApplyRequest) (*models.Application, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", membershipErrors.ErrInvalidRequest)
+	}
+	gated, err := s.communities.RequiresApproval(ctx, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	if !gated {
+		return nil, membershipErrors.ErrNotTakingApplicants
+	}
+	member, err := s.communities.IsCommunityMember(ctx, communityID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	if member {
+		return nil, membershipErrors.ErrAlreadyMember
+	}
+	questions, err := s.repo.ListQuestions(ctx, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	answers, err := s.matchAnswers(questions, req.Answers)
+	if err != nil {
+		return nil, err
+	}
+
+	application := &models.Application{
+		ObjectId:    uuid.Must(uuid.NewV4()),
+		CommunityId: communityID,
+		UserId:      userID,
+		Answers:     answers,
+		Status:      models.StatusPending,
+		CreatedDate: s.now().UTC().UnixMilli(),
+	}
+	stored, err := s.repo.SubmitApplication(ctx, application)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	if !stored {
+		return nil, membershipErrors.ErrApplicationPending
+	}
+	return application, nil
+}
+
+// matchAnswers pairs the answers with the current questions, in question order, and
+// checks every required question is answered
+func (s *service) matchAnswers(questions []*models.Question, inputs []models.AnswerInput) (models.Answers, error) {
+	given := make(map[uuid.UUID]string, len(inputs))
+	for _, input := range inputs {
+		given[input.QuestionId] = strings.TrimSpace(input.Answer)
+	}
+
+	answers := make(models.Answers, 0, len(questions))
+	for _, question := range questions {
+		answer, ok := given[question.ObjectId]
+		delete(given, question.ObjectId)
+		if answer == "" {
+			if question.Required {
+				return nil, fmt.Errorf("%w: %q needs an answer", membershipErrors.ErrInvalidRequest, question.Prompt)
+			}
+			if !ok {
+				continue
+			}
+		}
+		if s.cfg.MaxAnswerLength > 0 && utf8.RuneCountInString(answer) > s.cfg.MaxAnswerLength {
+			return nil, fmt.Errorf("%w: answers are at most %d characters", membershipErrors.ErrInvalidRequest, s.cfg.MaxAnswerLength)
+		}
+		answers = append(answers, models.Answer{QuestionId: question.ObjectId, Prompt: question.Prompt, Answer: answer})
+	}
+	if len(given) > 0 {
+		return nil, fmt.Errorf("%w: answers must be to the current questions", membershipErrors.ErrInvalidRequest)
+	}
+	return answers, nil
+}
+
+func (s *service) MyApplication(ctx context.Context, communityID, userID uuid.UUID) (*models.Application, error) {
+	application, err := s.repo.GetApplicationByUser(ctx, communityID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, membershipErrors.ErrApplicationNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	return application, nil
+}
+
+func (s *service) ListApplications(ctx context.Context, communityID, userID uuid.UUID, status string, limit, offset int) ([]*models.Application, error) {
+	if status != "" && !models.IsValidStatus(status) {
+		return nil, fmt.Errorf("%w: unknown status %q", membershipErrors.ErrInvalidRequest, status)
+	}
+	if err := s.checkModerator(ctx, communityID, userID); err != nil {
+		return nil, err
+	}
+	if limit <= 0 || limit > maxListLimit {
+		limit = defaultListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	applications, err := s.repo.ListApplications(ctx, communityID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	if applications == nil {
+		applications = []*models.Application{}
+	}
+	return applications, nil
+}
+
+func (s *service) ReviewApplication(ctx context.Context, communityID, applicationID, reviewerID uuid.UUID, req *models.ReviewApplicationRequest) (*models.Application, error) {
+	if req == nil || !models.IsValidReview(req.Status) {
+		return nil, fmt.Errorf("%w: status must be approved or rejected", membershipErrors.ErrInvalidRequest)
+	}
+	note := strings.TrimSpace(req.Note)
+	if utf8.RuneCountInString(note) > maxReviewNoteLength {
+		return nil, fmt.Errorf("%w: notes are at most %d characters", membershipErrors.ErrInvalidRequest, maxReviewNoteLength)
+	}
+	if err := s.checkModerator(ctx, communityID, reviewerID); err != nil {
+		return nil, err
+	}
+
+	// The decision and the new membership are stored together, so an approved applicant
+	// is never left outside the community
+	now := s.now().UTC().UnixMilli()
+	var application *models.Application
+	err := s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		application, err = s.repo.ReviewApplication(txCtx, communityID, applicationID, req.Status, reviewerID, note, now)
+		if err != nil || application.Status != models.StatusApproved {
+			return err
+		}
+		return s.communities.AdmitMember(txCtx, communityID, application.UserId)
+	})
+	if err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+		}
+		// Either there is no such application or another moderator got there first
+		if _, err := s.repo.GetApplication(ctx, communityID, applicationID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, membershipErrors.ErrApplicationNotFound
+			}
+			return nil, fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+		}
+		return nil, membershipErrors.ErrAlreadyReviewed
+	}
+
+	if application.Status == models.StatusApproved {
+		s.welcome(ctx, application, now)
+	}
+	return application, nil
+}
+
+// checkModerator lets only the community's owner and moderators manage its questions
+// and applications
+func (s *service) checkModerator(ctx context.Context, communityID, userID uuid.UUID) error {
+	moderator, err := s.communities.IsCommunityModerator(ctx, communityID, userID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", membershipErrors.ErrDatabaseOperation, err)
+	}
+	if !moderator {
+		return membershipErrors.ErrPermissionDenied
+	}
+	return nil
+}
+
+// welcome notifies a newly approved member; the review note is the welcome message
+func (s *service) welcome(ctx context.Context, application *models.Application, now int64) {
+	if !events.HasSubscribers() {
+		return
+	}
+	events.Publish(ctx, events.Event{
+		Type: events.TypeNotification,
+		Data: events.Notification{
+			Kind:        events.NotificationWelcome,
+			CommunityId: application.CommunityId.String(),
+			Preview:     application.ReviewNote,
+		},
+		CreatedDate: now,
+		Recipients:  []uuid.UUID{application.UserId},
+	})
+}
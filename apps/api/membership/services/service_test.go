@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	membershipErrors "github.com/qolzam/telar/apps/api/membership/errors"
+	"github.com/qolzam/telar/apps/api/membership/models"
+	"github.com/qolzam/telar/apps/api/membership/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = platformconfig.MembershipConfig{MaxQuestions: 3, MaxAnswerLength: 20}
+
+// stubCommunities is a community requiring approval, moderated by moderator
+type stubCommunities struct {
+	moderator uuid.UUID
+	open      bool
+	members   map[uuid.UUID]bool
+	admitErr  error
+	admitted  []uuid.UUID
+}
+
+func (s *stubCommunities) IsCommunityMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return s.members[userID], nil
+}
+
+func (s *stubCommunities) IsCommunityModerator(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return userID == s.moderator, nil
+}
+
+func (s *stubCommunities) IsCommunityArchived(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (s *stubCommunities) AllowsAnonymousPosts(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (s *stubCommunities) RequiresApproval(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return !s.open, nil
+}
+
+func (s *stubCommunities) AdmitMember(ctx context.Context, communityID, userID uuid.UUID) error {
+	if s.admitErr != nil {
+		return s.admitErr
+	}
+	s.admitted = append(s.admitted, userID)
+	return nil
+}
+
+var (
+	communityID = uuid.Must(uuid.NewV4())
+	moderatorID = uuid.Must(uuid.NewV4())
+)
+
+func newTestService(repo *MockRepository, now time.Time) *service {
+	return newTestServiceWith(repo, &stubCommunities{moderator: moderatorID}, now)
+}
+
+func newTestServiceWith(repo *MockRepository, communities *stubCommunities, now time.Time) *service {
+	svc := NewService(repo, communities, testConfig).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestSetQuestions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("replaces the questions in order", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ReplaceQuestions", ctx, communityID, mock.Anything).Return(nil).Once()
+
+		questions, err := newTestService(repo, now).SetQuestions(ctx, communityID, moderatorID, &models.SetQuestionsRequest{Questions: []models.QuestionInput{
+			{Prompt: "  Why do you want to join? ", Required: true},
+			{Prompt: "Anything else?"},
+		}})
+		require.NoError(t, err)
+		require.Len(t, questions, 2)
+		assert.Equal(t, "Why do you want to join?", questions[0].Prompt)
+		assert.Equal(t, 1, questions[1].Position)
+		assert.False(t, questions[1].Required)
+		assert.Equal(t, communityID, questions[1].CommunityId)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects empty prompts and too many questions", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := newTestService(repo, now)
+
+		_, err := svc.SetQuestions(ctx, communityID, moderatorID, &models.SetQuestionsRequest{Questions: []models.QuestionInput{{Prompt: " "}}})
+		assert.ErrorIs(t, err, membershipErrors.ErrInvalidRequest)
+
+		_, err = svc.SetQuestions(ctx, communityID, moderatorID, &models.SetQuestionsRequest{Questions: make([]models.QuestionInput, 4)})
+		assert.ErrorIs(t, err, membershipErrors.ErrInvalidRequest)
+		repo.AssertNotCalled(t, "ReplaceQuestions", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("is for the community's owner and moderators", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		_, err := newTestService(repo, now).SetQuestions(ctx, communityID, uuid.Must(uuid.NewV4()), &models.SetQuestionsRequest{})
+		assert.ErrorIs(t, err, membershipErrors.ErrPermissionDenied)
+		repo.AssertNotCalled(t, "ReplaceQuestions", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	userID := uuid.Must(uuid.NewV4())
+	why := &models.Question{ObjectId: uuid.Must(uuid.NewV4()), Prompt: "Why join?", Required: true}
+	extra := &models.Question{ObjectId: uuid.Must(uuid.NewV4()), Prompt: "Anything else?", Position: 1}
+	questions := []*models.Question{why, extra}
+
+	t.Run("stores the answers with their prompts", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListQuestions", ctx, communityID).Return(questions, nil).Once()
+		repo.On("SubmitApplication", ctx, mock.Anything).Return(true, nil).Once()
+
+		application, err := newTestService(repo, now).Apply(ctx, communityID, userID, &models.ApplyRequest{Answers: []models.AnswerInput{
+			{QuestionId: why.ObjectId, Answer: " I like it "},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusPending, application.Status)
+		assert.Equal(t, communityID, application.CommunityId)
+		assert.Equal(t, models.Answers{{QuestionId: why.ObjectId, Prompt: "Why join?", Answer: "I like it"}}, application.Answers)
+		assert.Equal(t, now.UnixMilli(), application.CreatedDate)
+	})
+
+	t.Run("requires answers to required questions only", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListQuestions", ctx, communityID).Return(questions, nil)
+		svc := newTestService(repo, now)
+
+		_, err := svc.Apply(ctx, communityID, userID, &models.ApplyRequest{Answers: []models.AnswerInput{{QuestionId: extra.ObjectId, Answer: "hi"}}})
+		assert.ErrorIs(t, err, membershipErrors.ErrInvalidRequest)
+
+		_, err = svc.Apply(ctx, communityID, userID, &models.ApplyRequest{Answers: []models.AnswerInput{
+			{QuestionId: why.ObjectId, Answer: "ok"},
+			{QuestionId: uuid.Must(uuid.NewV4()), Answer: "stale question"},
+		}})
+		assert.ErrorIs(t, err, membershipErrors.ErrInvalidRequest)
+
+		_, err = svc.Apply(ctx, communityID, userID, &models.ApplyRequest{Answers: []models.AnswerInput{{QuestionId: why.ObjectId, Answer: "far longer than twenty characters"}}})
+		assert.ErrorIs(t, err, membershipErrors.ErrInvalidRequest)
+		repo.AssertNotCalled(t, "SubmitApplication", mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps one pending application per community", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListQuestions", ctx, communityID).Return([]*models.Question{}, nil).Once()
+		repo.On("SubmitApplication", ctx, mock.Anything).Return(false, nil).Once()
+
+		_, err := newTestService(repo, now).Apply(ctx, communityID, userID, &models.ApplyRequest{})
+		assert.ErrorIs(t, err, membershipErrors.ErrApplicationPending)
+	})
+
+	t.Run("only to communities requiring approval, by users outside them", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		_, err := newTestServiceWith(repo, &stubCommunities{open: true}, now).Apply(ctx, communityID, userID, &models.ApplyRequest{})
+		assert.ErrorIs(t, err, membershipErrors.ErrNotTakingApplicants)
+
+		_, err = newTestServiceWith(repo, &stubCommunities{members: map[uuid.UUID]bool{userID: true}}, now).Apply(ctx, communityID, userID, &models.ApplyRequest{})
+		assert.ErrorIs(t, err, membershipErrors.ErrAlreadyMember)
+		repo.AssertNotCalled(t, "SubmitApplication", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReviewApplication(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	applicationID := uuid.Must(uuid.NewV4())
+	applicantID := uuid.Must(uuid.NewV4())
+
+	t.Run("approval admits and welcomes the new member", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ReviewApplication", ctx, communityID, applicationID, models.StatusApproved, moderatorID, "Welcome aboard!", now.UnixMilli()).
+			Return(&models.Application{ObjectId: applicationID, CommunityId: communityID, UserId: applicantID, Status: models.StatusApproved, ReviewNote: "Welcome aboard!"}, nil).Once()
+		communities := &stubCommunities{moderator: moderatorID}
+
+		var notified []events.Notification
+		unsubscribe := events.Subscribe(func(event events.Event) {
+			if event.Type == events.TypeNotification {
+				require.Equal(t, []uuid.UUID{applicantID}, event.Recipients)
+				notified = append(notified, event.Data.(events.Notification))
+			}
+		})
+		defer unsubscribe()
+
+		_, err := newTestServiceWith(repo, communities, now).ReviewApplication(ctx, communityID, applicationID, moderatorID,
+			&models.ReviewApplicationRequest{Status: models.StatusApproved, Note: " Welcome aboard! "})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{applicantID}, communities.admitted)
+		require.Len(t, notified, 1)
+		assert.Equal(t, communityID.String(), notified[0].CommunityId)
+		assert.Equal(t, events.NotificationWelcome, notified[0].Kind)
+		assert.Equal(t, "Welcome aboard!", notified[0].Preview)
+	})
+
+	t.Run("fails the review when the applicant cannot be admitted", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ReviewApplication", ctx, communityID, applicationID, models.StatusApproved, moderatorID, "", now.UnixMilli()).
+			Return(&models.Application{ObjectId: applicationID, CommunityId: communityID, UserId: applicantID, Status: models.StatusApproved}, nil).Once()
+
+		_, err := newTestServiceWith(repo, &stubCommunities{moderator: moderatorID, admitErr: errors.New("down")}, now).
+			ReviewApplication(ctx, communityID, applicationID, moderatorID, &models.ReviewApplicationRequest{Status: models.StatusApproved})
+		assert.ErrorIs(t, err, membershipErrors.ErrDatabaseOperation)
+	})
+
+	t.Run("rejects unknown decisions and other reviewers", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), now)
+
+		_, err := svc.ReviewApplication(ctx, communityID, applicationID, moderatorID,
+			&models.ReviewApplicationRequest{Status: models.StatusPending})
+		assert.ErrorIs(t, err, membershipErrors.ErrInvalidRequest)
+
+		_, err = svc.ReviewApplication(ctx, communityID, applicationID, applicantID,
+			&models.ReviewApplicationRequest{Status: models.StatusApproved})
+		assert.ErrorIs(t, err, membershipErrors.ErrPermissionDenied)
+	})
+
+	t.Run("tells reviewed applications from missing ones", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ReviewApplication", ctx, communityID, applicationID, models.StatusRejected, moderatorID, "", now.UnixMilli()).Return(nil, repository.ErrNotFound)
+		repo.On("GetApplication", ctx, communityID, applicationID).Return(&models.Application{Status: models.StatusApproved}, nil).Once()
+		svc := newTestService(repo, now)
+		req := &models.ReviewApplicationRequest{Status: models.StatusRejected}
+
+		_, err := svc.ReviewApplication(ctx, communityID, applicationID, moderatorID, req)
+		assert.ErrorIs(t, err, membershipErrors.ErrAlreadyReviewed)
+
+		repo.On("GetApplication", ctx, communityID, applicationID).Return(nil, repository.ErrNotFound).Once()
+		_, err = svc.ReviewApplication(ctx, communityID, applicationID, moderatorID, req)
+		assert.ErrorIs(t, err, membershipErrors.ErrApplicationNotFound)
+	})
+}
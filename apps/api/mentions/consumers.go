@@ -0,0 +1,18 @@
+package mentions
+
+import (
+	"context"
+
+	"github.com/qolzam/telar/apps/api/internal/outbox"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
+	"github.com/qolzam/telar/apps/api/mentions/services"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SubscribeProfilePropagation shows the names and avatars users change to on the
+// mentions they made, as announced through the outbox
+func SubscribeProfilePropagation(ctx context.Context, broker outbox.Broker, repo outbox.Repository, progress propagation.Repository, maxAttempts int, service services.Service) error {
+	return propagation.Subscribe(ctx, broker, repo, progress, propagation.TargetMentions, maxAttempts, func(txCtx context.Context, event sharedInterfaces.ProfileUpdatedEvent) error {
+		return service.UpdateActorProfile(txCtx, event.UserId, event.FullName, event.Avatar)
+	})
+}
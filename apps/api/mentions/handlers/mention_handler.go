@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/mentions/errors"
+	"github.com/qolzam/telar/apps/api/mentions/services"
+)
+
+type MentionHandler struct {
+	service services.Service
+}
+
+func NewMentionHandler(service services.Service) *MentionHandler {
+	return &MentionHandler{service: service}
+}
+
+// List returns the posts and comments that mentioned the current user, newest first.
+// Endpoint: GET /mentions?cursor=...&limit=...
+func (h *MentionHandler) List(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	resp, err := h.service.ListMentions(c.Context(), user.UserID, c.Query("cursor"), c.QueryInt("limit", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(resp)
+}
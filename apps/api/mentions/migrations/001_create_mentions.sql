@@ -0,0 +1,19 @@
+-- Mentions: one row per user @mentioned by a post body or comment. source_id is the
+-- comment for comment mentions and the post otherwise, so editing content never records
+-- (or notifies) the same mention twice. The actor columns are what the mentioned user is
+-- shown; actor_user_id is NULL on anonymous posts.
+CREATE TABLE IF NOT EXISTS mentions (
+    mentioned_user_id UUID NOT NULL,
+    source_id UUID NOT NULL,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id UUID REFERENCES comments(id) ON DELETE CASCADE,
+    actor_user_id UUID,
+    actor_display_name VARCHAR(255) NOT NULL DEFAULT '',
+    actor_avatar VARCHAR(512) NOT NULL DEFAULT '',
+    preview TEXT NOT NULL DEFAULT '',
+    created_date BIGINT NOT NULL,
+    PRIMARY KEY (mentioned_user_id, source_id)
+);
+
+-- Serves GET /mentions: a user's mentions, newest first
+CREATE INDEX IF NOT EXISTS idx_mentions_user_created ON mentions(mentioned_user_id, created_date DESC, source_id DESC);
@@ -0,0 +1,2 @@
+-- Profile propagation copies a changed name and avatar into the mentions a user made
+CREATE INDEX IF NOT EXISTS idx_mentions_actor ON mentions(actor_user_id) WHERE actor_user_id IS NOT NULL;
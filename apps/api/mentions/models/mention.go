@@ -0,0 +1,25 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Mention records that a post body or comment @mentioned a user
+type Mention struct {
+	MentionedUserId  uuid.UUID  `json:"-" db:"mentioned_user_id"`
+	SourceId         uuid.UUID  `json:"-" db:"source_id"` // The comment, or the post for post mentions
+	PostId           uuid.UUID  `json:"postId" db:"post_id"`
+	CommentId        *uuid.UUID `json:"commentId,omitempty" db:"comment_id"`
+	ActorUserId      *uuid.UUID `json:"actorUserId,omitempty" db:"actor_user_id"` // Unset on anonymous posts
+	ActorDisplayName string     `json:"actorDisplayName" db:"actor_display_name"`
+	ActorAvatar      string     `json:"actorAvatar,omitempty" db:"actor_avatar"`
+	Preview          string     `json:"preview,omitempty" db:"preview"`
+	CreatedDate      int64      `json:"createdDate" db:"created_date"`
+}
+
+// MentionListResponse is a page of the current user's mentions, newest first
+type MentionListResponse struct {
+	Mentions   []Mention `json:"mentions"`
+	NextCursor string    `json:"nextCursor,omitempty"`
+	HasNext    bool      `json:"hasNext"`
+}
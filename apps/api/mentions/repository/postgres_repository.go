@@ -0,0 +1,164 @@
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/mentions/models"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) MentionRepository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) MentionRepository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// Insert writes all rows in one statement; the primary key drops the ones already there
+func (r *postgresRepository) Insert(ctx context.Context, mention models.Mention, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	ids := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		ids[i] = id.String()
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %smentions (mentioned_user_id, source_id, post_id, comment_id, actor_user_id,
+			actor_display_name, actor_avatar, preview, created_date)
+		SELECT u, $2, $3, $4, $5, $6, $7, $8, $9 FROM unnest($1::uuid[]) AS u
+		ON CONFLICT (mentioned_user_id, source_id) DO NOTHING
+		RETURNING mentioned_user_id
+	`, r.schemaPrefix())
+
+	var inserted []uuid.UUID
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &inserted, query, pq.Array(ids),
+		mention.SourceId, mention.PostId, mention.CommentId, mention.ActorUserId,
+		mention.ActorDisplayName, mention.ActorAvatar, mention.Preview, mention.CreatedDate); err != nil {
+		return nil, fmt.Errorf("insert mentions: %w", err)
+	}
+	return inserted, nil
+}
+
+func (r *postgresRepository) ListForUser(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Mention, string, error) {
+	query, args, err := r.buildListQuery(userID, cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var mentions []models.Mention
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &mentions, query, args...); err != nil {
+		return nil, "", fmt.Errorf("list mentions: %w", err)
+	}
+
+	nextCursor := ""
+	if len(mentions) > limit {
+		mentions = mentions[:limit]
+		last := mentions[len(mentions)-1]
+		nextCursor = encodeCursor(last.CreatedDate, last.SourceId)
+	}
+	return mentions, nextCursor, nil
+}
+
+// buildListQuery pages through a user's mentions by (created_date, source_id), leaving out
+// deleted posts and comments
+func (r *postgresRepository) buildListQuery(userID uuid.UUID, cursor string, limit int) (string, []interface{}, error) {
+	query := fmt.Sprintf(`
+		SELECT m.mentioned_user_id, m.source_id, m.post_id, m.comment_id, m.actor_user_id,
+			m.actor_display_name, m.actor_avatar, m.preview, m.created_date
+		FROM %[1]smentions m
+		JOIN %[1]sposts p ON p.id = m.post_id AND p.is_deleted = FALSE
+		LEFT JOIN %[1]scomments c ON c.id = m.comment_id
+		WHERE m.mentioned_user_id = $1 AND (m.comment_id IS NULL OR c.is_deleted = FALSE)`, r.schemaPrefix())
+	args := []interface{}{userID}
+
+	if cursor != "" {
+		createdDate, sourceID, err := decodeCursor(cursor)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+		query += ` AND (m.created_date, m.source_id) < ($2, $3)`
+		args = append(args, createdDate, sourceID)
+	}
+
+	query += fmt.Sprintf(` ORDER BY m.created_date DESC, m.source_id DESC LIMIT $%d`, len(args)+1)
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+	return query, args, nil
+}
+
+func (r *postgresRepository) UpdateActorProfile(ctx context.Context, actorID uuid.UUID, displayName, avatar string) error {
+	query := fmt.Sprintf(`
+		UPDATE %smentions SET actor_display_name = $1, actor_avatar = $2
+		WHERE actor_user_id = $3`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, displayName, avatar, actorID); err != nil {
+		return fmt.Errorf("update mention actor profile: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := fmt.Sprintf(`
+		DELETE FROM %smentions
+		WHERE mentioned_user_id = $1 OR actor_user_id = $1`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("delete mentions of user: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
+
+// encodeCursor packs the last mention of a page as base64(created_date:source_id)
+func encodeCursor(createdDate int64, id uuid.UUID) string {
+	return base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", createdDate, id.String())))
+}
+
+func decodeCursor(cursor string) (int64, uuid.UUID, error) {
+	decoded, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, uuid.Nil, err
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 2 {
+		return 0, uuid.Nil, fmt.Errorf("expected created_date:uuid")
+	}
+	createdDate, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, uuid.Nil, err
+	}
+	id, err := uuid.FromString(parts[1])
+	if err != nil {
+		return 0, uuid.Nil, err
+	}
+	return createdDate, id, nil
+}
@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/mentions/models"
+)
+
+// ErrInvalidCursor is returned when a list cursor cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// MentionRepository defines data access for @mentions.
+type MentionRepository interface {
+	// Insert records mention, as a template, for each of userIDs. Users the source
+	// already mentioned are skipped; returns the users newly mentioned.
+	Insert(ctx context.Context, mention models.Mention, userIDs []uuid.UUID) ([]uuid.UUID, error)
+
+	// ListForUser returns the mentions of userID in live posts and comments, newest
+	// first, with cursor pagination.
+	ListForUser(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Mention, string, error)
+
+	// UpdateActorProfile sets the name and avatar shown on the mentions actorID made
+	UpdateActorProfile(ctx context.Context, actorID uuid.UUID, displayName, avatar string) error
+
+	// DeleteByUser deletes the mentions of userID and the mentions userID made
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,37 @@
+package services
+
+import "regexp"
+
+// MaxMentions caps how many users one post or comment can mention; later names are ignored
+const MaxMentions = 20
+
+// maxSocialNameLength matches the longest social name a profile can have
+const maxSocialNameLength = 50
+
+// mentionPattern matches @socialName at the start of the text or after a character that
+// cannot be part of a word, an email address or another mention
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@.])@([a-zA-Z0-9](?:[a-zA-Z0-9_.-]*[a-zA-Z0-9])?)`)
+
+// ExtractMentions returns the social names @mentioned in text, once each and in order
+// of first appearance. Names are kept as written: social names match exactly.
+func ExtractMentions(text string) []string {
+	matches := mentionPattern.FindAllStringSubmatch(text, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		name := m[1]
+		if len(name) > maxSocialNameLength || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+		if len(names) == MaxMentions {
+			break
+		}
+	}
+	return names
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/mentions/models"
+	"github.com/qolzam/telar/apps/api/mentions/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the mentions repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.MentionRepository = (*MockRepository)(nil)
+
+func (m *MockRepository) Insert(ctx context.Context, mention models.Mention, userIDs []uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, mention, userIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) ListForUser(ctx context.Context, userID uuid.UUID, cursor string, limit int) ([]models.Mention, string, error) {
+	args := m.Called(ctx, userID, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]models.Mention), args.String(1), args.Error(2)
+}
+
+func (m *MockRepository) UpdateActorProfile(ctx context.Context, actorID uuid.UUID, displayName, avatar string) error {
+	args := m.Called(ctx, actorID, displayName, avatar)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
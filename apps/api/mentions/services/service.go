@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	mentionErrors "github.com/qolzam/telar/apps/api/mentions/errors"
+	"github.com/qolzam/telar/apps/api/mentions/models"
+	"github.com/qolzam/telar/apps/api/mentions/repository"
+	postsCommon "github.com/qolzam/telar/apps/api/posts/common"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+
+	// previewLength caps the text stored with a mention and carried by its notification
+	previewLength = 140
+)
+
+// ProfileResolver resolves mentioned social names; the profile service satisfies it,
+// directly or over gRPC.
+type ProfileResolver interface {
+	GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*profileModels.Profile, error)
+}
+
+// Service defines mention operations.
+type Service interface {
+	sharedInterfaces.MentionRecorder
+
+	// ListMentions returns the mentions of userID, newest first.
+	ListMentions(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.MentionListResponse, error)
+
+	// UpdateActorProfile shows a changed name and avatar on the mentions actorID made.
+	UpdateActorProfile(ctx context.Context, actorID uuid.UUID, displayName, avatar string) error
+}
+
+type service struct {
+	repo     repository.MentionRepository
+	profiles ProfileResolver
+	muter    sharedInterfaces.KeywordMuter
+	now      func() time.Time
+}
+
+// NewService constructs a mentions service. muter may be nil, in which case no
+// notification is held back for muted keywords.
+func NewService(repo repository.MentionRepository, profiles ProfileResolver, muter sharedInterfaces.KeywordMuter) Service {
+	return &service{repo: repo, profiles: profiles, muter: muter, now: time.Now}
+}
+
+func (s *service) RecordMentions(ctx context.Context, source sharedInterfaces.MentionSource) {
+	names := ExtractMentions(source.Text)
+	if len(names) == 0 {
+		return
+	}
+
+	profiles, err := s.profiles.GetProfilesBySocialNames(ctx, names)
+	if err != nil {
+		log.Warn("Skipping mentions in post %s: resolve social names: %v", source.PostID, err)
+		return
+	}
+	userIDs := make([]uuid.UUID, 0, len(profiles))
+	for _, p := range profiles {
+		if p.ObjectId != source.AuthorID {
+			userIDs = append(userIDs, p.ObjectId)
+		}
+	}
+	if len(userIDs) == 0 {
+		return
+	}
+
+	mention := models.Mention{
+		SourceId:         source.PostID,
+		PostId:           source.PostID,
+		CommentId:        source.CommentID,
+		ActorUserId:      source.ActorUserID,
+		ActorDisplayName: source.ActorDisplayName,
+		ActorAvatar:      source.ActorAvatar,
+		CreatedDate:      s.now().UTC().UnixMilli(),
+	}
+	if source.CommentID != nil {
+		mention.SourceId = *source.CommentID
+	}
+	if !source.HidePreview {
+		mention.Preview, _ = postsCommon.BodyPreview(source.Text, previewLength)
+	}
+
+	mentioned, err := s.repo.Insert(ctx, mention, userIDs)
+	if err != nil {
+		log.Warn("Skipping mentions in post %s: %v", source.PostID, err)
+		return
+	}
+	s.notify(ctx, mention, mentioned, source.Text)
+}
+
+func (s *service) UpdateActorProfile(ctx context.Context, actorID uuid.UUID, displayName, avatar string) error {
+	if err := s.repo.UpdateActorProfile(ctx, actorID, displayName, avatar); err != nil {
+		return fmt.Errorf("%w: %v", mentionErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// notify tells the newly mentioned users, except those who muted a keyword in the text
+func (s *service) notify(ctx context.Context, mention models.Mention, userIDs []uuid.UUID, text string) {
+	if len(userIDs) == 0 || !events.HasSubscribers() {
+		return
+	}
+
+	notification := events.Notification{
+		Kind:             events.NotificationMention,
+		PostId:           mention.PostId.String(),
+		ActorDisplayName: mention.ActorDisplayName,
+		ActorAvatar:      mention.ActorAvatar,
+		Preview:          mention.Preview,
+	}
+	if mention.CommentId != nil {
+		notification.CommentId = mention.CommentId.String()
+	}
+	if mention.ActorUserId != nil {
+		notification.ActorUserId = mention.ActorUserId.String()
+	}
+
+	for _, userID := range userIDs {
+		if s.mutedBy(ctx, userID, text) {
+			continue
+		}
+		events.Publish(ctx, events.Event{
+			Type:        events.TypeNotification,
+			Data:        notification,
+			CreatedDate: mention.CreatedDate,
+			Recipients:  []uuid.UUID{userID},
+		})
+	}
+}
+
+func (s *service) mutedBy(ctx context.Context, userID uuid.UUID, text string) bool {
+	if s.muter == nil {
+		return false
+	}
+	matcher := s.muter.MutedMatcher(ctx, userID)
+	return matcher != nil && matcher.Match(text)
+}
+
+func (s *service) ListMentions(ctx context.Context, userID uuid.UUID, cursor string, limit int) (*models.MentionListResponse, error) {
+	mentions, nextCursor, err := s.repo.ListForUser(ctx, userID, cursor, clampLimit(limit))
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, fmt.Errorf("%w: %v", mentionErrors.ErrInvalidRequest, err)
+		}
+		return nil, fmt.Errorf("%w: %v", mentionErrors.ErrDatabaseOperation, err)
+	}
+	if mentions == nil {
+		mentions = []models.Mention{}
+	}
+	return &models.MentionListResponse{Mentions: mentions, NextCursor: nextCursor, HasNext: nextCursor != ""}, nil
+}
+
+func clampLimit(limit int) int {
+	if limit <= 0 {
+		return defaultListLimit
+	}
+	if limit > maxListLimit {
+		return maxListLimit
+	}
+	return limit
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	mentionErrors "github.com/qolzam/telar/apps/api/mentions/errors"
+	"github.com/qolzam/telar/apps/api/mentions/models"
+	"github.com/qolzam/telar/apps/api/mentions/repository"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type stubProfiles []*profileModels.Profile
+
+func (p stubProfiles) GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*profileModels.Profile, error) {
+	found := make([]*profileModels.Profile, 0, len(socialNames))
+	for _, name := range socialNames {
+		for _, profile := range p {
+			if profile.SocialName == name {
+				found = append(found, profile)
+			}
+		}
+	}
+	return found, nil
+}
+
+type stubMuter map[uuid.UUID]string
+
+type substringMatcher string
+
+func (m substringMatcher) Match(text string) bool { return strings.Contains(text, string(m)) }
+
+func (m stubMuter) MutedMatcher(ctx context.Context, userID uuid.UUID) sharedInterfaces.KeywordMatcher {
+	if keyword, ok := m[userID]; ok {
+		return substringMatcher(keyword)
+	}
+	return nil
+}
+
+func TestExtractMentions(t *testing.T) {
+	assert.Equal(t, []string{"alice", "bob.smith", "carol_1"},
+		ExtractMentions("@alice meet @bob.smith, and (@carol_1). Thanks @alice!"))
+	assert.Empty(t, ExtractMentions("mail me at dave@example.com or @@eve or x@frank"))
+	assert.Empty(t, ExtractMentions("@"+strings.Repeat("a", maxSocialNameLength+1)))
+
+	var many strings.Builder
+	for i := 0; i < MaxMentions+5; i++ {
+		many.WriteString(" @user" + strings.Repeat("x", i+1))
+	}
+	assert.Len(t, ExtractMentions(many.String()), MaxMentions)
+}
+
+func TestRecordMentions(t *testing.T) {
+	ctx := context.Background()
+	author := uuid.Must(uuid.NewV4())
+	alice := uuid.Must(uuid.NewV4())
+	bob := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+	commentID := uuid.Must(uuid.NewV4())
+	profiles := stubProfiles{
+		{ObjectId: author, SocialName: "me"},
+		{ObjectId: alice, SocialName: "alice"},
+		{ObjectId: bob, SocialName: "bob"},
+	}
+	now := time.UnixMilli(1700000000000)
+
+	var published []events.Event
+	unsubscribe := events.Subscribe(func(e events.Event) { published = append(published, e) })
+	defer unsubscribe()
+
+	t.Run("stores and notifies newly mentioned users, not the author", func(t *testing.T) {
+		published = nil
+		repo := new(MockRepository)
+		repo.On("Insert", ctx, mock.MatchedBy(func(m models.Mention) bool {
+			return m.SourceId == commentID && m.PostId == postID && m.Preview == "hi @alice @bob @me @nobody" && m.CreatedDate == now.UnixMilli()
+		}), []uuid.UUID{alice, bob}).Return([]uuid.UUID{alice}, nil).Once()
+
+		svc := &service{repo: repo, profiles: profiles, now: func() time.Time { return now }}
+		svc.RecordMentions(ctx, sharedInterfaces.MentionSource{
+			PostID:           postID,
+			CommentID:        &commentID,
+			AuthorID:         author,
+			ActorUserID:      &author,
+			ActorDisplayName: "Me",
+			Text:             "hi @alice @bob @me @nobody",
+		})
+
+		repo.AssertExpectations(t)
+		require.Len(t, published, 1, "bob was already mentioned by this comment")
+		assert.Equal(t, []uuid.UUID{alice}, published[0].Recipients)
+		notification := published[0].Data.(events.Notification)
+		assert.Equal(t, events.NotificationMention, notification.Kind)
+		assert.Equal(t, commentID.String(), notification.CommentId)
+		assert.Equal(t, author.String(), notification.ActorUserId)
+	})
+
+	t.Run("hides the preview and skips muted recipients", func(t *testing.T) {
+		published = nil
+		repo := new(MockRepository)
+		repo.On("Insert", ctx, mock.MatchedBy(func(m models.Mention) bool {
+			return m.SourceId == postID && m.CommentId == nil && m.ActorUserId == nil && m.Preview == ""
+		}), []uuid.UUID{alice, bob}).Return([]uuid.UUID{alice, bob}, nil).Once()
+
+		svc := NewService(repo, profiles, stubMuter{bob: "spoiler"})
+		svc.RecordMentions(ctx, sharedInterfaces.MentionSource{
+			PostID:           postID,
+			AuthorID:         author,
+			ActorDisplayName: "Anonymous",
+			Text:             "spoiler for @alice and @bob",
+			HidePreview:      true,
+		})
+
+		repo.AssertExpectations(t)
+		require.Len(t, published, 1)
+		assert.Equal(t, []uuid.UUID{alice}, published[0].Recipients)
+	})
+
+	t.Run("does nothing without mentions of others", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo, profiles, nil)
+		svc.RecordMentions(ctx, sharedInterfaces.MentionSource{PostID: postID, AuthorID: author, Text: "note to @me"})
+		repo.AssertNotCalled(t, "Insert", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestListMentions(t *testing.T) {
+	ctx := context.Background()
+	user := uuid.Must(uuid.NewV4())
+	repo := new(MockRepository)
+	svc := NewService(repo, stubProfiles{}, nil)
+
+	repo.On("ListForUser", ctx, user, "", defaultListLimit).Return(nil, "", nil).Once()
+	resp, err := svc.ListMentions(ctx, user, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, &models.MentionListResponse{Mentions: []models.Mention{}}, resp)
+
+	repo.On("ListForUser", ctx, user, "bad", maxListLimit).Return(nil, "", repository.ErrInvalidCursor).Once()
+	_, err = svc.ListMentions(ctx, user, "bad", 500)
+	assert.ErrorIs(t, err, mentionErrors.ErrInvalidRequest)
+}
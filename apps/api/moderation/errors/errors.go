@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrReportNotFound     = errors.New("report not found")
+	ErrContentNotFound    = errors.New("reported content not found")
+	ErrAlreadyReported    = errors.New("you already reported this content")
+	ErrOwnContent         = errors.New("you cannot report your own content")
+	ErrInvalidTransition  = errors.New("report cannot move to that status")
+	ErrReportResolved     = errors.New("report is already resolved")
+	ErrProtectedAccount   = errors.New("admins and moderators cannot be suspended through moderation")
+	ErrInvalidRequest     = errors.New("invalid request")
+	ErrMissingUserContext = errors.New("missing user context")
+	ErrDatabaseOperation  = errors.New("database operation failed")
+)
+
+const (
+	CodeReportNotFound    = "REPORT_NOT_FOUND"
+	CodeContentNotFound   = "CONTENT_NOT_FOUND"
+	CodeAlreadyReported   = "ALREADY_REPORTED"
+	CodeOwnContent        = "OWN_CONTENT"
+	CodeInvalidTransition = "INVALID_TRANSITION"
+	CodeReportResolved    = "REPORT_RESOLVED"
+	CodeProtectedAccount  = "PROTECTED_ACCOUNT"
+	CodeInvalidRequest    = "INVALID_REQUEST"
+	CodeMissingUserCtx    = "MISSING_USER_CONTEXT"
+	CodeDatabaseError     = "DATABASE_ERROR"
+	CodeInternalError     = "INTERNAL_ERROR"
+	CodeForbidden         = "FORBIDDEN"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func HandleServiceError(c *fiber.Ctx, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, ErrReportNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeReportNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrContentNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeContentNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrAlreadyReported):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeAlreadyReported, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrOwnContent):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeOwnContent, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidTransition):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeInvalidTransition, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrReportResolved):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeReportResolved, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrProtectedAccount):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodeProtectedAccount, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrMissingUserContext):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrDatabaseOperation):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeDatabaseError, Message: err.Error(), Details: err.Error()})
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Code: CodeInternalError, Message: "An unexpected error occurred", Details: err.Error()})
+	}
+}
+
+func HandleValidationError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: message, Details: message})
+}
+
+func HandleUserContextError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: message, Details: message})
+}
+
+// HandleForbidden answers callers who are signed in but are not moderators
+func HandleForbidden(c *fiber.Ctx) error {
+	message := "moderator role required"
+	return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodeForbidden, Message: message, Details: message})
+}
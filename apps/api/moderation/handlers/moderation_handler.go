@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/moderation/errors"
+	"github.com/qolzam/telar/apps/api/moderation/models"
+	"github.com/qolzam/telar/apps/api/moderation/services"
+)
+
+type ModerationHandler struct {
+	service services.Service
+}
+
+func NewModerationHandler(service services.Service) *ModerationHandler {
+	return &ModerationHandler{service: service}
+}
+
+// CreateReport flags a post or comment for moderators.
+// Endpoint: POST /reports
+// Body: {"targetType": "post", "targetId": "...", "reason": "spam", "details": "..."}
+func (h *ModerationHandler) CreateReport(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	var req models.CreateReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	report, err := h.service.Report(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(report)
+}
+
+// ListReports is the moderators' queue, oldest first.
+// Endpoint: GET /moderation/reports?status=open|reviewing|resolved|all&targetType=post|comment&limit=&offset=
+func (h *ModerationHandler) ListReports(c *fiber.Ctx) error {
+	status := c.Query("status", models.StatusOpen)
+	if status == "all" {
+		status = ""
+	}
+
+	reports, err := h.service.ListReports(c.Context(), status, c.Query("targetType"), c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"reports": reports,
+	})
+}
+
+// GetReport returns a report with its audit trail.
+// Endpoint: GET /moderation/reports/:reportId
+func (h *ModerationHandler) GetReport(c *fiber.Ctx) error {
+	reportID, err := uuid.FromString(c.Params("reportId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid reportId")
+	}
+
+	report, err := h.service.GetReport(c.Context(), reportID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(report)
+}
+
+// UpdateStatus moves a report between open, reviewing and resolved.
+// Endpoint: PUT /moderation/reports/:reportId/status
+// Body: {"status": "reviewing", "note": "..."}
+func (h *ModerationHandler) UpdateStatus(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	reportID, err := uuid.FromString(c.Params("reportId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid reportId")
+	}
+	var req models.UpdateStatusRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	report, err := h.service.UpdateStatus(c.Context(), reportID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(report)
+}
+
+// TakeAction hides the reported content, warns its author or suspends them.
+// Endpoint: POST /moderation/reports/:reportId/actions
+// Body: {"action": "hide_content", "note": "...", "resolve": true}
+func (h *ModerationHandler) TakeAction(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	reportID, err := uuid.FromString(c.Params("reportId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid reportId")
+	}
+	var req models.TakeActionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	report, err := h.service.TakeAction(c.Context(), reportID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(report)
+}
+
+// ListActions is the moderation audit log, newest first.
+// Endpoint: GET /moderation/actions?moderatorId=&limit=&offset=
+func (h *ModerationHandler) ListActions(c *fiber.Ctx) error {
+	moderatorID := uuid.Nil
+	if raw := c.Query("moderatorId"); raw != "" {
+		id, err := uuid.FromString(raw)
+		if err != nil {
+			return errors.HandleValidationError(c, "invalid moderatorId")
+		}
+		moderatorID = id
+	}
+
+	actions, err := h.service.ListActions(c.Context(), moderatorID, c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"actions": actions,
+	})
+}
@@ -0,0 +1,34 @@
+-- Moderation: users report posts and comments, moderators work through the open
+-- reports and every status change or action they take is kept as an audit record.
+CREATE TABLE IF NOT EXISTS moderation_reports (
+    id UUID PRIMARY KEY,
+    reporter_id UUID NOT NULL,
+    target_type TEXT NOT NULL CHECK (target_type IN ('post', 'comment')),
+    target_id UUID NOT NULL,
+    -- The content's real author, recorded when reported so anonymous posts can be acted on
+    target_owner_id UUID NOT NULL,
+    reason TEXT NOT NULL,
+    details TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL CHECK (status IN ('open', 'reviewing', 'resolved')),
+    reviewer_id UUID,
+    created_date BIGINT NOT NULL,
+    updated_date BIGINT NOT NULL
+);
+
+-- A user has at most one unresolved report on a piece of content
+CREATE UNIQUE INDEX IF NOT EXISTS idx_moderation_reports_unresolved
+    ON moderation_reports(reporter_id, target_type, target_id) WHERE status <> 'resolved';
+CREATE INDEX IF NOT EXISTS idx_moderation_reports_queue ON moderation_reports(status, created_date);
+
+CREATE TABLE IF NOT EXISTS moderation_actions (
+    id UUID PRIMARY KEY,
+    report_id UUID NOT NULL REFERENCES moderation_reports(id) ON DELETE CASCADE,
+    moderator_id UUID NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('hide_content', 'warn_user', 'suspend_user', 'status_change')),
+    note TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    created_date BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_report ON moderation_actions(report_id, created_date);
+CREATE INDEX IF NOT EXISTS idx_moderation_actions_moderator ON moderation_actions(moderator_id, created_date DESC);
@@ -0,0 +1,131 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Reportable content
+const (
+	TargetPost    = "post"
+	TargetComment = "comment"
+)
+
+// IsValidTarget reports whether users can report content of targetType
+func IsValidTarget(targetType string) bool {
+	return targetType == TargetPost || targetType == TargetComment
+}
+
+// Report statuses. A report starts open; a moderator may take it into review and hand
+// it back, and closes it by resolving it.
+const (
+	StatusOpen      = "open"
+	StatusReviewing = "reviewing"
+	StatusResolved  = "resolved"
+)
+
+// IsValidStatus reports whether status is a state a report can be in
+func IsValidStatus(status string) bool {
+	return status == StatusOpen || status == StatusReviewing || status == StatusResolved
+}
+
+// CanTransition reports whether a report may move from one status to another
+func CanTransition(from, to string) bool {
+	switch from {
+	case StatusOpen:
+		return to == StatusReviewing || to == StatusResolved
+	case StatusReviewing:
+		return to == StatusOpen || to == StatusResolved
+	default:
+		return false
+	}
+}
+
+// Report reasons
+const (
+	ReasonSpam           = "spam"
+	ReasonHarassment     = "harassment"
+	ReasonHate           = "hate"
+	ReasonViolence       = "violence"
+	ReasonMisinformation = "misinformation"
+	ReasonOther          = "other"
+)
+
+// IsValidReason reports whether reason is one users can pick
+func IsValidReason(reason string) bool {
+	switch reason {
+	case ReasonSpam, ReasonHarassment, ReasonHate, ReasonViolence, ReasonMisinformation, ReasonOther:
+		return true
+	}
+	return false
+}
+
+// Moderator actions. Every status change is recorded as an action too, so the actions
+// are the report's audit trail.
+const (
+	ActionHideContent  = "hide_content"
+	ActionWarnUser     = "warn_user"
+	ActionSuspendUser  = "suspend_user"
+	ActionStatusChange = "status_change"
+)
+
+// IsValidAction reports whether action is one moderators can take on a report
+func IsValidAction(action string) bool {
+	return action == ActionHideContent || action == ActionWarnUser || action == ActionSuspendUser
+}
+
+// Report is a user's flag on a post or comment
+type Report struct {
+	ObjectId      uuid.UUID     `json:"objectId" db:"id"`
+	ReporterId    uuid.UUID     `json:"reporterId" db:"reporter_id"`
+	TargetType    string        `json:"targetType" db:"target_type"`
+	TargetId      uuid.UUID     `json:"targetId" db:"target_id"`
+	TargetOwnerId uuid.UUID     `json:"targetOwnerId" db:"target_owner_id"`
+	Reason        string        `json:"reason" db:"reason"`
+	Details       string        `json:"details,omitempty" db:"details"`
+	Status        string        `json:"status" db:"status"`
+	ReviewerId    uuid.NullUUID `json:"reviewerId" db:"reviewer_id"`
+	CreatedDate   int64         `json:"createdDate" db:"created_date"`
+	UpdatedDate   int64         `json:"updatedDate" db:"updated_date"`
+}
+
+// Action is an audit record of something a moderator did about a report
+type Action struct {
+	ObjectId    uuid.UUID `json:"objectId" db:"id"`
+	ReportId    uuid.UUID `json:"reportId" db:"report_id"`
+	ModeratorId uuid.UUID `json:"moderatorId" db:"moderator_id"`
+	Action      string    `json:"action" db:"action"`
+	Note        string    `json:"note,omitempty" db:"note"`
+	// Status is the report's status after the action
+	Status      string `json:"status" db:"status"`
+	CreatedDate int64  `json:"createdDate" db:"created_date"`
+}
+
+// ReportDetail is a report with the actions taken on it, oldest first
+type ReportDetail struct {
+	Report
+	Actions []*Action `json:"actions"`
+}
+
+// CreateReportRequest is the POST /reports request body
+type CreateReportRequest struct {
+	TargetType string    `json:"targetType"` // "post" or "comment"
+	TargetId   uuid.UUID `json:"targetId"`
+	Reason     string    `json:"reason"`
+	Details    string    `json:"details"`
+}
+
+// UpdateStatusRequest is the PUT /moderation/reports/:reportId/status request body
+type UpdateStatusRequest struct {
+	Status string `json:"status"` // "open", "reviewing" or "resolved"
+	Note   string `json:"note"`
+}
+
+// TakeActionRequest is the POST /moderation/reports/:reportId/actions request body
+type TakeActionRequest struct {
+	Action string `json:"action"` // "hide_content", "warn_user" or "suspend_user"
+	// Note is recorded with the action; warnings show it to the user and suspensions
+	// keep it as the reason
+	Note string `json:"note"`
+	// Resolve closes the report once the action is taken
+	Resolve bool `json:"resolve"`
+}
@@ -0,0 +1,184 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/moderation/models"
+)
+
+const (
+	reportColumns = `id, reporter_id, target_type, target_id, target_owner_id, reason, details, status, reviewer_id, created_date, updated_date`
+	actionColumns = `id, report_id, moderator_id, action, note, status, created_date`
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// CreateReport relies on the partial unique index over unresolved reports, so the
+// same user reporting the same content twice at once stores one report
+func (r *postgresRepository) CreateReport(ctx context.Context, report *models.Report) (bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %smoderation_reports (id, reporter_id, target_type, target_id, target_owner_id, reason, details, status, created_date, updated_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)
+		ON CONFLICT (reporter_id, target_type, target_id) WHERE status <> 'resolved' DO NOTHING
+	`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		report.ObjectId, report.ReporterId, report.TargetType, report.TargetId, report.TargetOwnerId,
+		report.Reason, report.Details, report.Status, report.CreatedDate)
+	if err != nil {
+		return false, fmt.Errorf("create report: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("create report: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) GetReport(ctx context.Context, reportID uuid.UUID) (*models.Report, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %smoderation_reports WHERE id = $1`, reportColumns, r.schemaPrefix())
+	return r.getReport(ctx, query, reportID)
+}
+
+func (r *postgresRepository) getReport(ctx context.Context, query string, args ...interface{}) (*models.Report, error) {
+	var report models.Report
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &report, query, args...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get report: %w", err)
+	}
+	return &report, nil
+}
+
+func (r *postgresRepository) ListReports(ctx context.Context, status, targetType string, limit, offset int) ([]*models.Report, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %smoderation_reports
+		WHERE ($1 = '' OR status = $1) AND ($2 = '' OR target_type = $2)
+		ORDER BY created_date, id
+		LIMIT $3 OFFSET $4
+	`, reportColumns, r.schemaPrefix())
+
+	var reports []*models.Report
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &reports, query, status, targetType, limit, offset); err != nil {
+		return nil, fmt.Errorf("list reports: %w", err)
+	}
+	return reports, nil
+}
+
+func (r *postgresRepository) UpdateStatus(ctx context.Context, reportID uuid.UUID, from, to string, reviewerID uuid.UUID, now int64) (*models.Report, error) {
+	query := fmt.Sprintf(`
+		UPDATE %smoderation_reports
+		SET status = $3, reviewer_id = $4, updated_date = $5
+		WHERE id = $1 AND status = $2
+		RETURNING %s
+	`, r.schemaPrefix(), reportColumns)
+	return r.getReport(ctx, query, reportID, from, to, reviewerID, now)
+}
+
+func (r *postgresRepository) RecordAction(ctx context.Context, action *models.Action) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %smoderation_actions (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`, r.schemaPrefix(), actionColumns)
+
+	_, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		action.ObjectId, action.ReportId, action.ModeratorId, action.Action, action.Note, action.Status, action.CreatedDate)
+	if err != nil {
+		return fmt.Errorf("record moderation action: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) ListActionsByReport(ctx context.Context, reportID uuid.UUID) ([]*models.Action, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %smoderation_actions
+		WHERE report_id = $1
+		ORDER BY created_date, id
+	`, actionColumns, r.schemaPrefix())
+
+	var actions []*models.Action
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &actions, query, reportID); err != nil {
+		return nil, fmt.Errorf("list report actions: %w", err)
+	}
+	return actions, nil
+}
+
+func (r *postgresRepository) ListActions(ctx context.Context, moderatorID uuid.UUID, limit, offset int) ([]*models.Action, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %smoderation_actions
+		WHERE ($1::uuid IS NULL OR moderator_id = $1)
+		ORDER BY created_date DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, actionColumns, r.schemaPrefix())
+
+	moderator := uuid.NullUUID{UUID: moderatorID, Valid: moderatorID != uuid.Nil}
+	var actions []*models.Action
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &actions, query, moderator, limit, offset); err != nil {
+		return nil, fmt.Errorf("list moderation actions: %w", err)
+	}
+	return actions, nil
+}
+
+func (r *postgresRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM %smoderation_reports WHERE reporter_id = $1 OR target_owner_id = $1`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("delete reports of user: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/moderation/models"
+)
+
+// ErrNotFound is returned when a report does not exist
+var ErrNotFound = errors.New("not found")
+
+// Repository defines data access for reports and the moderators' audit records.
+type Repository interface {
+	// CreateReport stores an open report. Returns false, changing nothing, while the
+	// reporter has an unresolved report on the same content.
+	CreateReport(ctx context.Context, report *models.Report) (bool, error)
+
+	// GetReport returns a report or ErrNotFound.
+	GetReport(ctx context.Context, reportID uuid.UUID) (*models.Report, error)
+
+	// ListReports returns reports in status and of targetType (all when empty), oldest first.
+	ListReports(ctx context.Context, status, targetType string, limit, offset int) ([]*models.Report, error)
+
+	// UpdateStatus moves a report from one status to another and returns it. Returns
+	// ErrNotFound when no report with that ID is in the from status.
+	UpdateStatus(ctx context.Context, reportID uuid.UUID, from, to string, reviewerID uuid.UUID, now int64) (*models.Report, error)
+
+	// RecordAction stores an audit record.
+	RecordAction(ctx context.Context, action *models.Action) error
+
+	// ListActionsByReport returns the actions taken on a report, oldest first.
+	ListActionsByReport(ctx context.Context, reportID uuid.UUID) ([]*models.Action, error)
+
+	// ListActions returns the actions moderatorID took (everyone's when uuid.Nil), newest first.
+	ListActions(ctx context.Context, moderatorID uuid.UUID, limit, offset int) ([]*models.Action, error)
+
+	// WithTransaction runs fn in a transaction, joining the caller's when there is one.
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+
+	// DeleteByUser deletes the reports userID filed or that target userID's content, with
+	// their actions. What userID decided as a moderator is kept.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
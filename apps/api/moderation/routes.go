@@ -0,0 +1,49 @@
+package moderation
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/middleware/authrole"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	moderationErrors "github.com/qolzam/telar/apps/api/moderation/errors"
+	"github.com/qolzam/telar/apps/api/moderation/handlers"
+)
+
+type Handlers struct {
+	ModerationHandler *handlers.ModerationHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires user reports and the moderators' queue, actions and audit log.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	app.Post("/reports", dualAuthMiddleware, handlers.ModerationHandler.CreateReport)
+
+	// --- Moderator Routes (admins and moderators) ---
+	group := app.Group("/moderation", dualAuthMiddleware, authrole.New(authrole.Config{
+		Roles:        []string{types.AdminRole, types.ModeratorRole},
+		Unauthorized: moderationErrors.HandleForbidden,
+	}))
+	group.Get("/reports", handlers.ModerationHandler.ListReports)
+	group.Get("/reports/:reportId", handlers.ModerationHandler.GetReport)
+	group.Put("/reports/:reportId/status", handlers.ModerationHandler.UpdateStatus)
+	group.Post("/reports/:reportId/actions", handlers.ModerationHandler.TakeAction)
+	group.Get("/actions", handlers.ModerationHandler.ListActions)
+}
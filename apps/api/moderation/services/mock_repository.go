@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/moderation/models"
+	"github.com/qolzam/telar/apps/api/moderation/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the moderation repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) CreateReport(ctx context.Context, report *models.Report) (bool, error) {
+	args := m.Called(ctx, report)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) GetReport(ctx context.Context, reportID uuid.UUID) (*models.Report, error) {
+	args := m.Called(ctx, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Report), args.Error(1)
+}
+
+func (m *MockRepository) ListReports(ctx context.Context, status, targetType string, limit, offset int) ([]*models.Report, error) {
+	args := m.Called(ctx, status, targetType, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Report), args.Error(1)
+}
+
+func (m *MockRepository) UpdateStatus(ctx context.Context, reportID uuid.UUID, from, to string, reviewerID uuid.UUID, now int64) (*models.Report, error) {
+	args := m.Called(ctx, reportID, from, to, reviewerID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Report), args.Error(1)
+}
+
+func (m *MockRepository) RecordAction(ctx context.Context, action *models.Action) error {
+	args := m.Called(ctx, action)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListActionsByReport(ctx context.Context, reportID uuid.UUID) ([]*models.Action, error) {
+	args := m.Called(ctx, reportID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Action), args.Error(1)
+}
+
+func (m *MockRepository) ListActions(ctx context.Context, moderatorID uuid.UUID, limit, offset int) ([]*models.Action, error) {
+	args := m.Called(ctx, moderatorID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Action), args.Error(1)
+}
+
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	moderationErrors "github.com/qolzam/telar/apps/api/moderation/errors"
+	"github.com/qolzam/telar/apps/api/moderation/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// ModerationScorer scores text for toxicity and spam; the AI engine client implements it
+type ModerationScorer interface {
+	ScoreModeration(ctx context.Context, kind, text string) (*aiengine.ModerationScores, error)
+}
+
+// screener scores new posts and comments in the background and files a report for
+// those scoring at or above the threshold. Workers start lazily on the first
+// ScreenContent; content is skipped when the queue is full or scoring fails, so
+// creating content never depends on the AI engine.
+type screener struct {
+	service   Service
+	scorer    ModerationScorer
+	threshold float64
+	jobs      chan sharedInterfaces.ScreenedContent
+	workers   int
+	startOnce sync.Once
+}
+
+// NewScreener creates a ContentScreener flagging content into service's queue.
+func NewScreener(service Service, scorer ModerationScorer, cfg platformconfig.ModerationConfig) sharedInterfaces.ContentScreener {
+	threshold := cfg.AutoFlagThreshold
+	if threshold <= 0 || threshold > 1 {
+		threshold = 0.8
+	}
+	workers := cfg.AutoFlagWorkers
+	if workers <= 0 {
+		workers = 1
+	}
+	queueSize := cfg.AutoFlagQueueSize
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+	return &screener{
+		service:   service,
+		scorer:    scorer,
+		threshold: threshold,
+		jobs:      make(chan sharedInterfaces.ScreenedContent, queueSize),
+		workers:   workers,
+	}
+}
+
+// ScreenContent queues the content; it never blocks the caller. The request context
+// is not used because scoring outlives the request.
+func (s *screener) ScreenContent(ctx context.Context, content sharedInterfaces.ScreenedContent) {
+	s.startOnce.Do(func() {
+		for w := 0; w < s.workers; w++ {
+			go s.run()
+		}
+	})
+
+	select {
+	case s.jobs <- content:
+	default:
+		log.Warn("Moderation screening queue full, skipping %s %s", content.TargetType, content.TargetID.String())
+	}
+}
+
+func (s *screener) run() {
+	for content := range s.jobs {
+		s.process(context.Background(), content)
+	}
+}
+
+// process scores the content and reports it as spam or harassment, whichever scored
+// higher, when that score reaches the threshold
+func (s *screener) process(ctx context.Context, content sharedInterfaces.ScreenedContent) {
+	scores, err := s.scorer.ScoreModeration(ctx, content.TargetType, content.Text)
+	if err != nil {
+		log.Warn("Moderation scoring failed for %s %s: %v", content.TargetType, content.TargetID.String(), err)
+		return
+	}
+	if max(scores.Toxicity, scores.Spam) < s.threshold {
+		return
+	}
+
+	reason := models.ReasonHarassment
+	if scores.Spam > scores.Toxicity {
+		reason = models.ReasonSpam
+	}
+	details := fmt.Sprintf("Flagged automatically: toxicity %.2f, spam %.2f.", scores.Toxicity, scores.Spam)
+	if scores.Reason != "" {
+		details += " " + scores.Reason
+	}
+
+	_, err = s.service.FlagContent(ctx, content, reason, details)
+	if err != nil && !errors.Is(err, moderationErrors.ErrAlreadyReported) {
+		log.Error("Failed to flag %s %s for moderation: %v", content.TargetType, content.TargetID.String(), err)
+	}
+}
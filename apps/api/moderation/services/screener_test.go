@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	moderationErrors "github.com/qolzam/telar/apps/api/moderation/errors"
+	"github.com/qolzam/telar/apps/api/moderation/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeScorer struct {
+	scores *aiengine.ModerationScores
+	err    error
+}
+
+func (f *fakeScorer) ScoreModeration(ctx context.Context, kind, text string) (*aiengine.ModerationScores, error) {
+	return f.scores, f.err
+}
+
+func newTestScreener(repo *MockRepository, scorer ModerationScorer) *screener {
+	svc, _ := newTestService(repo, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	return NewScreener(svc, scorer, platformconfig.ModerationConfig{AutoFlagThreshold: 0.8}).(*screener)
+}
+
+func TestFlagContent(t *testing.T) {
+	ctx := context.Background()
+	content := sharedInterfaces.ScreenedContent{
+		TargetType: models.TargetComment,
+		TargetID:   uuid.Must(uuid.NewV4()),
+		AuthorID:   uuid.Must(uuid.NewV4()),
+		Text:       "text",
+	}
+
+	t.Run("files an open report without a reporter", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("CreateReport", ctx, mock.Anything).Return(true, nil).Once()
+		svc, _ := newTestService(repo, time.Now())
+
+		report, err := svc.FlagContent(ctx, content, models.ReasonSpam, "  scored high  ")
+		require.NoError(t, err)
+		assert.Equal(t, uuid.Nil, report.ReporterId)
+		assert.Equal(t, content.AuthorID, report.TargetOwnerId)
+		assert.Equal(t, models.StatusOpen, report.Status)
+		assert.Equal(t, "scored high", report.Details)
+	})
+
+	t.Run("reports a flag that is still unresolved", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("CreateReport", ctx, mock.Anything).Return(false, nil).Once()
+		svc, _ := newTestService(repo, time.Now())
+
+		_, err := svc.FlagContent(ctx, content, models.ReasonSpam, "")
+		assert.ErrorIs(t, err, moderationErrors.ErrAlreadyReported)
+	})
+
+	t.Run("rejects unknown reasons", func(t *testing.T) {
+		svc, _ := newTestService(new(MockRepository), time.Now())
+		_, err := svc.FlagContent(ctx, content, "rude", "")
+		assert.ErrorIs(t, err, moderationErrors.ErrInvalidRequest)
+	})
+}
+
+func TestScreener(t *testing.T) {
+	ctx := context.Background()
+	content := sharedInterfaces.ScreenedContent{
+		TargetType: models.TargetPost,
+		TargetID:   uuid.Must(uuid.NewV4()),
+		AuthorID:   uuid.Must(uuid.NewV4()),
+		Text:       "buy followers now",
+	}
+
+	t.Run("flags spam above the threshold", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("CreateReport", ctx, mock.MatchedBy(func(r *models.Report) bool {
+			return r.Reason == models.ReasonSpam && r.TargetId == content.TargetID &&
+				r.Details == "Flagged automatically: toxicity 0.10, spam 0.92. Advertising."
+		})).Return(true, nil).Once()
+		s := newTestScreener(repo, &fakeScorer{scores: &aiengine.ModerationScores{Toxicity: 0.1, Spam: 0.92, Reason: "Advertising."}})
+
+		s.process(ctx, content)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("flags toxic content as harassment", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("CreateReport", ctx, mock.MatchedBy(func(r *models.Report) bool {
+			return r.Reason == models.ReasonHarassment
+		})).Return(true, nil).Once()
+		s := newTestScreener(repo, &fakeScorer{scores: &aiengine.ModerationScores{Toxicity: 0.8, Spam: 0.2}})
+
+		s.process(ctx, content)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("leaves content below the threshold alone", func(t *testing.T) {
+		repo := new(MockRepository)
+		s := newTestScreener(repo, &fakeScorer{scores: &aiengine.ModerationScores{Toxicity: 0.79, Spam: 0.5, Flagged: true}})
+
+		s.process(ctx, content)
+		repo.AssertNotCalled(t, "CreateReport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("skips content the AI engine could not score", func(t *testing.T) {
+		repo := new(MockRepository)
+		s := newTestScreener(repo, &fakeScorer{err: errors.New("unavailable")})
+
+		s.process(ctx, content)
+		repo.AssertNotCalled(t, "CreateReport", mock.Anything, mock.Anything)
+	})
+}
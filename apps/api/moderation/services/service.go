@@ -0,0 +1,376 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	moderationErrors "github.com/qolzam/telar/apps/api/moderation/errors"
+	"github.com/qolzam/telar/apps/api/moderation/models"
+	"github.com/qolzam/telar/apps/api/moderation/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	maxDetailsLength = 1000
+	maxNoteLength    = 1000
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// Service defines reporting, the moderators' queue and the actions they take.
+type Service interface {
+	// Report flags a post or comment for moderators. A user has one unresolved report
+	// per piece of content and cannot report their own.
+	Report(ctx context.Context, reporterID uuid.UUID, req *models.CreateReportRequest) (*models.Report, error)
+
+	// FlagContent files a report on behalf of automatic screening, with uuid.Nil as the
+	// reporter. Returns ErrAlreadyReported while an earlier flag is unresolved.
+	FlagContent(ctx context.Context, content sharedInterfaces.ScreenedContent, reason, details string) (*models.Report, error)
+
+	// ListReports returns reports in status and of targetType (all when empty), oldest first.
+	ListReports(ctx context.Context, status, targetType string, limit, offset int) ([]*models.Report, error)
+
+	// GetReport returns a report with the actions taken on it.
+	GetReport(ctx context.Context, reportID uuid.UUID) (*models.ReportDetail, error)
+
+	// UpdateStatus moves a report through open, reviewing and resolved, recording the
+	// change in its audit trail.
+	UpdateStatus(ctx context.Context, reportID, moderatorID uuid.UUID, req *models.UpdateStatusRequest) (*models.ReportDetail, error)
+
+	// TakeAction hides the reported content, warns its author or suspends them, and
+	// records the action. Resolved reports take no more actions.
+	TakeAction(ctx context.Context, reportID, moderatorID uuid.UUID, req *models.TakeActionRequest) (*models.ReportDetail, error)
+
+	// ListActions returns the actions moderatorID took (everyone's when uuid.Nil), newest first.
+	ListActions(ctx context.Context, moderatorID uuid.UUID, limit, offset int) ([]*models.Action, error)
+}
+
+type service struct {
+	repo      repository.Repository
+	content   map[string]sharedInterfaces.ContentModerator
+	suspender sharedInterfaces.UserSuspender
+	now       func() time.Time
+}
+
+// NewService constructs a moderation service acting on posts and comments through
+// their moderators and suspending users through suspender.
+func NewService(repo repository.Repository, posts, comments sharedInterfaces.ContentModerator, suspender sharedInterfaces.UserSuspender) Service {
+	return &service{
+		repo: repo,
+		content: map[string]sharedInterfaces.ContentModerator{
+			models.TargetPost:    posts,
+			models.TargetComment: comments,
+		},
+		suspender: suspender,
+		now:       time.Now,
+	}
+}
+
+func (s *service) Report(ctx context.Context, reporterID uuid.UUID, req *models.CreateReportRequest) (*models.Report, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", moderationErrors.ErrInvalidRequest)
+	}
+	if !models.IsValidTarget(req.TargetType) {
+		return nil, fmt.Errorf("%w: targetType must be post or comment", moderationErrors.ErrInvalidRequest)
+	}
+	if req.TargetId == uuid.Nil {
+		return nil, fmt.Errorf("%w: targetId is required", moderationErrors.ErrInvalidRequest)
+	}
+	if !models.IsValidReason(req.Reason) {
+		return nil, fmt.Errorf("%w: unknown reason %q", moderationErrors.ErrInvalidRequest, req.Reason)
+	}
+	details := strings.TrimSpace(req.Details)
+	if utf8.RuneCountInString(details) > maxDetailsLength {
+		return nil, fmt.Errorf("%w: details are at most %d characters", moderationErrors.ErrInvalidRequest, maxDetailsLength)
+	}
+
+	owner, err := s.contentModerator(req.TargetType).ContentOwner(ctx, req.TargetId)
+	if err != nil {
+		return nil, contentError(err)
+	}
+	if owner == reporterID {
+		return nil, moderationErrors.ErrOwnContent
+	}
+
+	return s.file(ctx, &models.Report{
+		ReporterId:    reporterID,
+		TargetType:    req.TargetType,
+		TargetId:      req.TargetId,
+		TargetOwnerId: owner,
+		Reason:        req.Reason,
+		Details:       details,
+	})
+}
+
+// file stores a new open report
+func (s *service) file(ctx context.Context, report *models.Report) (*models.Report, error) {
+	now := s.now().UTC().UnixMilli()
+	report.ObjectId = uuid.Must(uuid.NewV4())
+	report.Status = models.StatusOpen
+	report.CreatedDate = now
+	report.UpdatedDate = now
+	stored, err := s.repo.CreateReport(ctx, report)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", moderationErrors.ErrDatabaseOperation, err)
+	}
+	if !stored {
+		return nil, moderationErrors.ErrAlreadyReported
+	}
+	return report, nil
+}
+
+func (s *service) FlagContent(ctx context.Context, content sharedInterfaces.ScreenedContent, reason, details string) (*models.Report, error) {
+	if !models.IsValidTarget(content.TargetType) || content.TargetID == uuid.Nil {
+		return nil, fmt.Errorf("%w: flagged content needs a post or comment id", moderationErrors.ErrInvalidRequest)
+	}
+	if !models.IsValidReason(reason) {
+		return nil, fmt.Errorf("%w: unknown reason %q", moderationErrors.ErrInvalidRequest, reason)
+	}
+	details = strings.TrimSpace(details)
+	if runes := []rune(details); len(runes) > maxDetailsLength {
+		details = string(runes[:maxDetailsLength])
+	}
+
+	// The author is known at creation, so unlike user reports there is no owner lookup
+	return s.file(ctx, &models.Report{
+		ReporterId:    uuid.Nil,
+		TargetType:    content.TargetType,
+		TargetId:      content.TargetID,
+		TargetOwnerId: content.AuthorID,
+		Reason:        reason,
+		Details:       details,
+	})
+}
+
+func (s *service) ListReports(ctx context.Context, status, targetType string, limit, offset int) ([]*models.Report, error) {
+	if status != "" && !models.IsValidStatus(status) {
+		return nil, fmt.Errorf("%w: unknown status %q", moderationErrors.ErrInvalidRequest, status)
+	}
+	if targetType != "" && !models.IsValidTarget(targetType) {
+		return nil, fmt.Errorf("%w: unknown targetType %q", moderationErrors.ErrInvalidRequest, targetType)
+	}
+	limit, offset = page(limit, offset)
+	reports, err := s.repo.ListReports(ctx, status, targetType, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", moderationErrors.ErrDatabaseOperation, err)
+	}
+	if reports == nil {
+		reports = []*models.Report{}
+	}
+	return reports, nil
+}
+
+func (s *service) GetReport(ctx context.Context, reportID uuid.UUID) (*models.ReportDetail, error) {
+	report, err := s.getReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, report)
+}
+
+func (s *service) UpdateStatus(ctx context.Context, reportID, moderatorID uuid.UUID, req *models.UpdateStatusRequest) (*models.ReportDetail, error) {
+	if req == nil || !models.IsValidStatus(req.Status) {
+		return nil, fmt.Errorf("%w: status must be open, reviewing or resolved", moderationErrors.ErrInvalidRequest)
+	}
+	note, err := checkNote(req.Note)
+	if err != nil {
+		return nil, err
+	}
+	report, err := s.getReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if !models.CanTransition(report.Status, req.Status) {
+		return nil, fmt.Errorf("%w: %s to %s", moderationErrors.ErrInvalidTransition, report.Status, req.Status)
+	}
+
+	now := s.now().UTC().UnixMilli()
+	err = s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		updated, err := s.transition(txCtx, report, req.Status, moderatorID, now)
+		if err != nil {
+			return err
+		}
+		report = updated
+		return s.record(txCtx, report, moderatorID, models.ActionStatusChange, note, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, report)
+}
+
+func (s *service) TakeAction(ctx context.Context, reportID, moderatorID uuid.UUID, req *models.TakeActionRequest) (*models.ReportDetail, error) {
+	if req == nil || !models.IsValidAction(req.Action) {
+		return nil, fmt.Errorf("%w: action must be hide_content, warn_user or suspend_user", moderationErrors.ErrInvalidRequest)
+	}
+	note, err := checkNote(req.Note)
+	if err != nil {
+		return nil, err
+	}
+	if req.Action == models.ActionWarnUser && note == "" {
+		return nil, fmt.Errorf("%w: a warning needs a note for the user", moderationErrors.ErrInvalidRequest)
+	}
+	report, err := s.getReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report.Status == models.StatusResolved {
+		return nil, moderationErrors.ErrReportResolved
+	}
+
+	now := s.now().UTC().UnixMilli()
+	switch req.Action {
+	case models.ActionHideContent:
+		if err := s.contentModerator(report.TargetType).HideContent(ctx, report.TargetId); err != nil {
+			return nil, contentError(err)
+		}
+	case models.ActionWarnUser:
+		s.warn(ctx, report, note, now)
+	case models.ActionSuspendUser:
+		reason := note
+		if reason == "" {
+			reason = fmt.Sprintf("reported for %s", report.Reason)
+		}
+		if err := s.suspender.SuspendUser(ctx, moderatorID, report.TargetOwnerId, reason); err != nil {
+			if errors.Is(err, sharedInterfaces.ErrProtectedAccount) {
+				return nil, moderationErrors.ErrProtectedAccount
+			}
+			return nil, fmt.Errorf("suspend user: %w", err)
+		}
+	}
+
+	err = s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if req.Resolve {
+			updated, err := s.transition(txCtx, report, models.StatusResolved, moderatorID, now)
+			if err != nil {
+				return err
+			}
+			report = updated
+		}
+		return s.record(txCtx, report, moderatorID, req.Action, note, now)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.detail(ctx, report)
+}
+
+func (s *service) ListActions(ctx context.Context, moderatorID uuid.UUID, limit, offset int) ([]*models.Action, error) {
+	limit, offset = page(limit, offset)
+	actions, err := s.repo.ListActions(ctx, moderatorID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", moderationErrors.ErrDatabaseOperation, err)
+	}
+	if actions == nil {
+		actions = []*models.Action{}
+	}
+	return actions, nil
+}
+
+func (s *service) getReport(ctx context.Context, reportID uuid.UUID) (*models.Report, error) {
+	report, err := s.repo.GetReport(ctx, reportID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, moderationErrors.ErrReportNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", moderationErrors.ErrDatabaseOperation, err)
+	}
+	return report, nil
+}
+
+func (s *service) detail(ctx context.Context, report *models.Report) (*models.ReportDetail, error) {
+	actions, err := s.repo.ListActionsByReport(ctx, report.ObjectId)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", moderationErrors.ErrDatabaseOperation, err)
+	}
+	if actions == nil {
+		actions = []*models.Action{}
+	}
+	return &models.ReportDetail{Report: *report, Actions: actions}, nil
+}
+
+// transition moves the report on from the status it was read in, so a moderator who
+// acted on a stale view gets ErrInvalidTransition rather than overwriting a colleague
+func (s *service) transition(ctx context.Context, report *models.Report, to string, moderatorID uuid.UUID, now int64) (*models.Report, error) {
+	updated, err := s.repo.UpdateStatus(ctx, report.ObjectId, report.Status, to, moderatorID, now)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, fmt.Errorf("%w: the report changed, reload it", moderationErrors.ErrInvalidTransition)
+		}
+		return nil, fmt.Errorf("%w: %v", moderationErrors.ErrDatabaseOperation, err)
+	}
+	return updated, nil
+}
+
+func (s *service) record(ctx context.Context, report *models.Report, moderatorID uuid.UUID, action, note string, now int64) error {
+	err := s.repo.RecordAction(ctx, &models.Action{
+		ObjectId:    uuid.Must(uuid.NewV4()),
+		ReportId:    report.ObjectId,
+		ModeratorId: moderatorID,
+		Action:      action,
+		Note:        note,
+		Status:      report.Status,
+		CreatedDate: now,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", moderationErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+// warn notifies the author of reported content; the note is the warning they read
+func (s *service) warn(ctx context.Context, report *models.Report, note string, now int64) {
+	if !events.HasSubscribers() {
+		return
+	}
+	notification := events.Notification{
+		Kind:    events.NotificationWarning,
+		Preview: note,
+	}
+	if report.TargetType == models.TargetPost {
+		notification.PostId = report.TargetId.String()
+	} else {
+		notification.CommentId = report.TargetId.String()
+	}
+	events.Publish(ctx, events.Event{
+		Type:        events.TypeNotification,
+		Data:        notification,
+		CreatedDate: now,
+		Recipients:  []uuid.UUID{report.TargetOwnerId},
+	})
+}
+
+func (s *service) contentModerator(targetType string) sharedInterfaces.ContentModerator {
+	return s.content[targetType]
+}
+
+func contentError(err error) error {
+	if errors.Is(err, sharedInterfaces.ErrContentNotFound) {
+		return moderationErrors.ErrContentNotFound
+	}
+	return fmt.Errorf("reported content: %w", err)
+}
+
+func checkNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxNoteLength {
+		return "", fmt.Errorf("%w: notes are at most %d characters", moderationErrors.ErrInvalidRequest, maxNoteLength)
+	}
+	return note, nil
+}
+
+func page(limit, offset int) (int, int) {
+	if limit <= 0 || limit > maxListLimit {
+		limit = defaultListLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	moderationErrors "github.com/qolzam/telar/apps/api/moderation/errors"
+	"github.com/qolzam/telar/apps/api/moderation/models"
+	"github.com/qolzam/telar/apps/api/moderation/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeContent struct {
+	owners map[uuid.UUID]uuid.UUID
+	hidden []uuid.UUID
+}
+
+func (f *fakeContent) ContentOwner(ctx context.Context, contentID uuid.UUID) (uuid.UUID, error) {
+	owner, ok := f.owners[contentID]
+	if !ok {
+		return uuid.Nil, sharedInterfaces.ErrContentNotFound
+	}
+	return owner, nil
+}
+
+func (f *fakeContent) HideContent(ctx context.Context, contentID uuid.UUID) error {
+	if _, ok := f.owners[contentID]; !ok {
+		return sharedInterfaces.ErrContentNotFound
+	}
+	f.hidden = append(f.hidden, contentID)
+	return nil
+}
+
+type fakeSuspender struct {
+	protected map[uuid.UUID]bool
+	suspended map[uuid.UUID]string
+}
+
+func (f *fakeSuspender) SuspendUser(ctx context.Context, moderatorID, userID uuid.UUID, reason string) error {
+	if f.protected[userID] {
+		return sharedInterfaces.ErrProtectedAccount
+	}
+	f.suspended[userID] = reason
+	return nil
+}
+
+type testDeps struct {
+	posts     *fakeContent
+	comments  *fakeContent
+	suspender *fakeSuspender
+}
+
+func newTestService(repo *MockRepository, now time.Time) (*service, *testDeps) {
+	deps := &testDeps{
+		posts:     &fakeContent{owners: map[uuid.UUID]uuid.UUID{}},
+		comments:  &fakeContent{owners: map[uuid.UUID]uuid.UUID{}},
+		suspender: &fakeSuspender{protected: map[uuid.UUID]bool{}, suspended: map[uuid.UUID]string{}},
+	}
+	svc := NewService(repo, deps.posts, deps.comments, deps.suspender).(*service)
+	svc.now = func() time.Time { return now }
+	return svc, deps
+}
+
+func TestReport(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	reporterID := uuid.Must(uuid.NewV4())
+	authorID := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+
+	t.Run("stores an open report with the content's author", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("CreateReport", ctx, mock.Anything).Return(true, nil).Once()
+		svc, deps := newTestService(repo, now)
+		deps.posts.owners[postID] = authorID
+
+		report, err := svc.Report(ctx, reporterID, &models.CreateReportRequest{
+			TargetType: models.TargetPost, TargetId: postID, Reason: models.ReasonSpam, Details: " buy now ",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusOpen, report.Status)
+		assert.Equal(t, authorID, report.TargetOwnerId)
+		assert.Equal(t, "buy now", report.Details)
+		assert.Equal(t, now.UnixMilli(), report.CreatedDate)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a second unresolved report", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("CreateReport", ctx, mock.Anything).Return(false, nil).Once()
+		svc, deps := newTestService(repo, now)
+		deps.posts.owners[postID] = authorID
+
+		_, err := svc.Report(ctx, reporterID, &models.CreateReportRequest{TargetType: models.TargetPost, TargetId: postID, Reason: models.ReasonSpam})
+		assert.ErrorIs(t, err, moderationErrors.ErrAlreadyReported)
+	})
+
+	t.Run("rejects own, missing and malformed reports", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc, deps := newTestService(repo, now)
+		deps.posts.owners[postID] = reporterID
+
+		_, err := svc.Report(ctx, reporterID, &models.CreateReportRequest{TargetType: models.TargetPost, TargetId: postID, Reason: models.ReasonSpam})
+		assert.ErrorIs(t, err, moderationErrors.ErrOwnContent)
+
+		_, err = svc.Report(ctx, reporterID, &models.CreateReportRequest{TargetType: models.TargetComment, TargetId: postID, Reason: models.ReasonSpam})
+		assert.ErrorIs(t, err, moderationErrors.ErrContentNotFound)
+
+		_, err = svc.Report(ctx, reporterID, &models.CreateReportRequest{TargetType: "profile", TargetId: postID, Reason: models.ReasonSpam})
+		assert.ErrorIs(t, err, moderationErrors.ErrInvalidRequest)
+
+		_, err = svc.Report(ctx, reporterID, &models.CreateReportRequest{TargetType: models.TargetPost, TargetId: postID, Reason: "boring"})
+		assert.ErrorIs(t, err, moderationErrors.ErrInvalidRequest)
+		repo.AssertNotCalled(t, "CreateReport", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	reportID := uuid.Must(uuid.NewV4())
+	moderatorID := uuid.Must(uuid.NewV4())
+	open := &models.Report{ObjectId: reportID, TargetType: models.TargetPost, Status: models.StatusOpen}
+
+	t.Run("moves the report and records the change", func(t *testing.T) {
+		repo := new(MockRepository)
+		reviewing := &models.Report{ObjectId: reportID, TargetType: models.TargetPost, Status: models.StatusReviewing}
+		repo.On("GetReport", ctx, reportID).Return(open, nil).Once()
+		repo.On("UpdateStatus", ctx, reportID, models.StatusOpen, models.StatusReviewing, moderatorID, now.UnixMilli()).Return(reviewing, nil).Once()
+		repo.On("RecordAction", ctx, mock.MatchedBy(func(action *models.Action) bool {
+			return action.Action == models.ActionStatusChange && action.Status == models.StatusReviewing && action.Note == "looking"
+		})).Return(nil).Once()
+		repo.On("ListActionsByReport", ctx, reportID).Return(nil, nil).Once()
+		svc, _ := newTestService(repo, now)
+
+		detail, err := svc.UpdateStatus(ctx, reportID, moderatorID, &models.UpdateStatusRequest{Status: models.StatusReviewing, Note: " looking "})
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusReviewing, detail.Status)
+		assert.Empty(t, detail.Actions)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects transitions out of resolved", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetReport", ctx, reportID).Return(&models.Report{ObjectId: reportID, Status: models.StatusResolved}, nil).Once()
+		svc, _ := newTestService(repo, now)
+
+		_, err := svc.UpdateStatus(ctx, reportID, moderatorID, &models.UpdateStatusRequest{Status: models.StatusOpen})
+		assert.ErrorIs(t, err, moderationErrors.ErrInvalidTransition)
+	})
+
+	t.Run("reports a concurrent change", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetReport", ctx, reportID).Return(open, nil).Once()
+		repo.On("UpdateStatus", ctx, reportID, models.StatusOpen, models.StatusResolved, moderatorID, now.UnixMilli()).Return(nil, repository.ErrNotFound).Once()
+		svc, _ := newTestService(repo, now)
+
+		_, err := svc.UpdateStatus(ctx, reportID, moderatorID, &models.UpdateStatusRequest{Status: models.StatusResolved})
+		assert.ErrorIs(t, err, moderationErrors.ErrInvalidTransition)
+		repo.AssertNotCalled(t, "RecordAction", mock.Anything, mock.Anything)
+	})
+
+	t.Run("unknown reports are not found", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetReport", ctx, reportID).Return(nil, repository.ErrNotFound).Once()
+		svc, _ := newTestService(repo, now)
+
+		_, err := svc.UpdateStatus(ctx, reportID, moderatorID, &models.UpdateStatusRequest{Status: models.StatusReviewing})
+		assert.ErrorIs(t, err, moderationErrors.ErrReportNotFound)
+	})
+}
+
+func TestTakeAction(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	reportID := uuid.Must(uuid.NewV4())
+	moderatorID := uuid.Must(uuid.NewV4())
+	authorID := uuid.Must(uuid.NewV4())
+	commentID := uuid.Must(uuid.NewV4())
+	report := func(status string) *models.Report {
+		return &models.Report{ObjectId: reportID, TargetType: models.TargetComment, TargetId: commentID,
+			TargetOwnerId: authorID, Reason: models.ReasonHarassment, Status: status}
+	}
+
+	t.Run("hides the content and resolves the report", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetReport", ctx, reportID).Return(report(models.StatusReviewing), nil).Once()
+		repo.On("UpdateStatus", ctx, reportID, models.StatusReviewing, models.StatusResolved, moderatorID, now.UnixMilli()).
+			Return(report(models.StatusResolved), nil).Once()
+		repo.On("RecordAction", ctx, mock.MatchedBy(func(action *models.Action) bool {
+			return action.Action == models.ActionHideContent && action.Status == models.StatusResolved && action.ModeratorId == moderatorID
+		})).Return(nil).Once()
+		repo.On("ListActionsByReport", ctx, reportID).Return([]*models.Action{{Action: models.ActionHideContent}}, nil).Once()
+		svc, deps := newTestService(repo, now)
+		deps.comments.owners[commentID] = authorID
+
+		detail, err := svc.TakeAction(ctx, reportID, moderatorID, &models.TakeActionRequest{Action: models.ActionHideContent, Resolve: true})
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{commentID}, deps.comments.hidden)
+		assert.Equal(t, models.StatusResolved, detail.Status)
+		assert.Len(t, detail.Actions, 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("warns the author", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetReport", ctx, reportID).Return(report(models.StatusOpen), nil).Once()
+		repo.On("RecordAction", ctx, mock.Anything).Return(nil).Once()
+		repo.On("ListActionsByReport", ctx, reportID).Return(nil, nil).Once()
+		svc, _ := newTestService(repo, now)
+
+		var notified []events.Notification
+		unsubscribe := events.Subscribe(func(event events.Event) {
+			if event.Type == events.TypeNotification {
+				require.Equal(t, []uuid.UUID{authorID}, event.Recipients)
+				notified = append(notified, event.Data.(events.Notification))
+			}
+		})
+		defer unsubscribe()
+
+		_, err := svc.TakeAction(ctx, reportID, moderatorID, &models.TakeActionRequest{Action: models.ActionWarnUser, Note: "Keep it civil"})
+		require.NoError(t, err)
+		require.Len(t, notified, 1)
+		assert.Equal(t, events.NotificationWarning, notified[0].Kind)
+		assert.Equal(t, commentID.String(), notified[0].CommentId)
+		assert.Equal(t, "Keep it civil", notified[0].Preview)
+
+		_, err = svc.TakeAction(ctx, reportID, moderatorID, &models.TakeActionRequest{Action: models.ActionWarnUser})
+		assert.ErrorIs(t, err, moderationErrors.ErrInvalidRequest)
+	})
+
+	t.Run("suspends the author unless protected", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetReport", ctx, reportID).Return(report(models.StatusOpen), nil).Twice()
+		repo.On("RecordAction", ctx, mock.Anything).Return(nil).Once()
+		repo.On("ListActionsByReport", ctx, reportID).Return(nil, nil).Once()
+		svc, deps := newTestService(repo, now)
+
+		_, err := svc.TakeAction(ctx, reportID, moderatorID, &models.TakeActionRequest{Action: models.ActionSuspendUser})
+		require.NoError(t, err)
+		assert.Equal(t, "reported for harassment", deps.suspender.suspended[authorID])
+
+		deps.suspender.protected[authorID] = true
+		_, err = svc.TakeAction(ctx, reportID, moderatorID, &models.TakeActionRequest{Action: models.ActionSuspendUser})
+		assert.ErrorIs(t, err, moderationErrors.ErrProtectedAccount)
+		repo.AssertNumberOfCalls(t, "RecordAction", 1)
+	})
+
+	t.Run("resolved reports take no more actions", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetReport", ctx, reportID).Return(report(models.StatusResolved), nil).Once()
+		svc, deps := newTestService(repo, now)
+		deps.comments.owners[commentID] = authorID
+
+		_, err := svc.TakeAction(ctx, reportID, moderatorID, &models.TakeActionRequest{Action: models.ActionHideContent})
+		assert.ErrorIs(t, err, moderationErrors.ErrReportResolved)
+		assert.Empty(t, deps.comments.hidden)
+	})
+}
+
+func TestListReports(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	repo.On("ListReports", ctx, models.StatusOpen, "", defaultListLimit, 0).Return(nil, nil).Once()
+	svc, _ := newTestService(repo, time.Now())
+
+	reports, err := svc.ListReports(ctx, models.StatusOpen, "", 1000, -1)
+	require.NoError(t, err)
+	assert.NotNil(t, reports)
+
+	_, err = svc.ListReports(ctx, "closed", "", 10, 0)
+	assert.ErrorIs(t, err, moderationErrors.ErrInvalidRequest)
+	repo.AssertExpectations(t)
+}
@@ -7,28 +7,41 @@ package signup
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
+	authErrors "github.com/qolzam/telar/apps/api/auth/errors"
 	authModels "github.com/qolzam/telar/apps/api/auth/models"
 	authRepo "github.com/qolzam/telar/apps/api/auth/repository"
+	"github.com/qolzam/telar/apps/api/internal/hooks"
 	profileModels "github.com/qolzam/telar/apps/api/profile/models"
 	profileRepo "github.com/qolzam/telar/apps/api/profile/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
+// ErrEmailAlreadyRegistered is returned when another signup already created an account
+// for the verified email; it wraps the auth ErrUserAlreadyExists so handlers answer 409
+var ErrEmailAlreadyRegistered = fmt.Errorf("%w: email is already registered", authErrors.ErrUserAlreadyExists)
+
 // Service defines the orchestration logic for signup completion
 // This orchestrator coordinates atomic creation of User Auth and Profile
 // across service boundaries using transactions
 type Service interface {
 	CompleteSignup(ctx context.Context, verification *authModels.UserVerification) error
+
+	// SetEventOutbox announces completed signups as user.signed_up outbox events,
+	// written in the signup transaction
+	SetEventOutbox(outbox sharedInterfaces.EventOutbox)
 }
 
 type service struct {
 	authRepo    authRepo.AuthRepository
 	profileRepo profileRepo.ProfileRepository
 	verifRepo   authRepo.VerificationRepository
+	outbox      sharedInterfaces.EventOutbox // nil until SetEventOutbox
 }
 
 // NewService creates a new signup orchestrator service
@@ -44,8 +57,16 @@ func NewService(
 	}
 }
 
+// SetEventOutbox announces completed signups through the outbox
+func (s *service) SetEventOutbox(outbox sharedInterfaces.EventOutbox) {
+	s.outbox = outbox
+}
+
 // CompleteSignup orchestrates the atomic creation of User Auth and Profile
-// This method ensures both entities are created atomically within a single transaction
+// This method ensures both entities are created atomically within a single transaction.
+// It is idempotent per verification: when a concurrent verify of the same code already
+// created the account, the unique username constraint rejects the second insert and the
+// call resumes that account instead of failing, without announcing it twice.
 func (s *service) CompleteSignup(ctx context.Context, verification *authModels.UserVerification) error {
 	if verification == nil {
 		return fmt.Errorf("verification record is required")
@@ -69,7 +90,8 @@ func (s *service) CompleteSignup(ctx context.Context, verification *authModels.U
 	// Use authRepo.WithTransaction to start the transaction scope
 	// Crucially, we pass the transaction context `txCtx` to ALL repositories
 	// Note: Verification is already marked as used by verifyUserByCode before this is called
-		return s.authRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+	var fullName, socialName string
+	err := s.authRepo.WithTransaction(ctx, func(txCtx context.Context) error {
 		// A. Create Auth User (within transaction)
 		userAuth := &authModels.UserAuth{
 			ObjectId:      verification.UserId,
@@ -83,6 +105,9 @@ func (s *service) CompleteSignup(ctx context.Context, verification *authModels.U
 		}
 
 		if err := s.authRepo.CreateUser(txCtx, userAuth); err != nil {
+			if errors.Is(err, authRepo.ErrUsernameExists) {
+				return err
+			}
 			return fmt.Errorf("failed to create user auth: %w", err)
 		}
 
@@ -90,13 +115,13 @@ func (s *service) CompleteSignup(ctx context.Context, verification *authModels.U
 		// This works because `profileRepo` methods accept a context.
 		// If `txCtx` contains the *sqlx.Tx, and `profileRepo` knows how to extract it
 		// (which it should, via shared transaction key "tx"), this will be atomic.
-		fullName := verification.FullName
+		fullName = verification.FullName
 		if fullName == "" {
 			// Fallback for legacy verification records without stored full name
 			fullName = extractFullNameFromTarget(verification.Target)
 		}
 
-		socialName := generateSocialName(fullName, verification.UserId.String())
+		socialName = generateSocialName(fullName, verification.UserId.String())
 		createdDate := time.Now().Unix()
 
 		profile := &profileModels.Profile{
@@ -141,8 +166,47 @@ func (s *service) CompleteSignup(ctx context.Context, verification *authModels.U
 			// The verification record can be updated later if needed
 		}
 
+		// D. Announce the account to other services; published only if this commits
+		if s.outbox != nil {
+			return s.outbox.Append(txCtx, sharedInterfaces.OutboxUserSignedUp, verification.UserId.String(), sharedInterfaces.UserSignedUpEvent{
+				UserId:     verification.UserId,
+				Username:   verification.Target,
+				FullName:   fullName,
+				SocialName: socialName,
+			})
+		}
 		return nil
 	})
+	if errors.Is(err, authRepo.ErrUsernameExists) {
+		// The failed insert aborted the transaction, so the winner is looked up outside it
+		return s.resolveSignupConflict(ctx, verification)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Hooks run only once the account is committed
+	hooks.RunAfter(ctx, hooks.UserAfterSignup, verification.UserId.String(), map[string]interface{}{
+		"userId":     verification.UserId.String(),
+		"username":   verification.Target,
+		"fullName":   fullName,
+		"socialName": socialName,
+	})
+	return nil
+}
+
+// resolveSignupConflict decides a signup that lost the race for its username. The account
+// belongs to this verification when a concurrent verify of the same code created it;
+// any other account means the email was registered by a different signup.
+func (s *service) resolveSignupConflict(ctx context.Context, verification *authModels.UserVerification) error {
+	existing, err := s.authRepo.FindByUsername(ctx, verification.Target)
+	if err != nil {
+		return fmt.Errorf("failed to resolve signup conflict: %w", err)
+	}
+	if existing.ObjectId != verification.UserId {
+		return ErrEmailAlreadyRegistered
+	}
+	return nil
 }
 
 // extractFullNameFromTarget extracts a full name from an email target
@@ -0,0 +1,211 @@
+package signup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	authErrors "github.com/qolzam/telar/apps/api/auth/errors"
+	authModels "github.com/qolzam/telar/apps/api/auth/models"
+	authRepo "github.com/qolzam/telar/apps/api/auth/repository"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	profileRepo "github.com/qolzam/telar/apps/api/profile/repository"
+)
+
+// fakeDB stands in for Postgres: transactions are serialized, their writes only become
+// visible on commit and user_auths.username is unique, which is how concurrent inserts
+// of the same username resolve in the real database
+type fakeDB struct {
+	txMu     sync.Mutex
+	mu       sync.Mutex
+	users    map[string]*authModels.UserAuth // By username
+	profiles map[uuid.UUID]*profileModels.Profile
+	events   []string
+}
+
+type fakeTx struct {
+	users    []*authModels.UserAuth
+	profiles []*profileModels.Profile
+	events   []string
+}
+
+type txKey struct{}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{users: map[string]*authModels.UserAuth{}, profiles: map[uuid.UUID]*profileModels.Profile{}}
+}
+
+func txFrom(ctx context.Context) *fakeTx {
+	tx, _ := ctx.Value(txKey{}).(*fakeTx)
+	return tx
+}
+
+type fakeAuthRepo struct {
+	authRepo.AuthRepository
+	db *fakeDB
+}
+
+func (r *fakeAuthRepo) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	r.db.txMu.Lock()
+	defer r.db.txMu.Unlock()
+
+	tx := &fakeTx{}
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	for _, user := range tx.users {
+		r.db.users[user.Username] = user
+	}
+	for _, profile := range tx.profiles {
+		r.db.profiles[profile.ObjectId] = profile
+	}
+	r.db.events = append(r.db.events, tx.events...)
+	return nil
+}
+
+func (r *fakeAuthRepo) CreateUser(ctx context.Context, userAuth *authModels.UserAuth) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	if _, ok := r.db.users[userAuth.Username]; ok {
+		return authRepo.ErrUsernameExists
+	}
+	txFrom(ctx).users = append(txFrom(ctx).users, userAuth)
+	return nil
+}
+
+func (r *fakeAuthRepo) FindByUsername(ctx context.Context, username string) (*authModels.UserAuth, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+	if user, ok := r.db.users[username]; ok {
+		return user, nil
+	}
+	return nil, errors.New("user not found")
+}
+
+type fakeProfileRepo struct {
+	profileRepo.ProfileRepository
+	db *fakeDB
+}
+
+func (r *fakeProfileRepo) Create(ctx context.Context, profile *profileModels.Profile) error {
+	txFrom(ctx).profiles = append(txFrom(ctx).profiles, profile)
+	return nil
+}
+
+type fakeVerificationRepo struct {
+	authRepo.VerificationRepository
+}
+
+func (r *fakeVerificationRepo) UpdateUserID(ctx context.Context, verificationID uuid.UUID, userID uuid.UUID) error {
+	return nil
+}
+
+type fakeOutbox struct{}
+
+func (fakeOutbox) Append(ctx context.Context, eventType string, key string, data interface{}) error {
+	txFrom(ctx).events = append(txFrom(ctx).events, eventType+":"+key)
+	return nil
+}
+
+func newTestService(db *fakeDB) Service {
+	svc := NewService(&fakeAuthRepo{db: db}, &fakeProfileRepo{db: db}, &fakeVerificationRepo{})
+	svc.SetEventOutbox(fakeOutbox{})
+	return svc
+}
+
+func newVerification(email string) *authModels.UserVerification {
+	return &authModels.UserVerification{
+		ObjectId:       uuid.Must(uuid.NewV4()),
+		UserId:         uuid.Must(uuid.NewV4()),
+		Target:         email,
+		TargetType:     "email",
+		HashedPassword: []byte("hash"),
+		FullName:       "Jane Doe",
+		ExpiresAt:      time.Now().Add(15 * time.Minute).Unix(),
+	}
+}
+
+// completeConcurrently runs CompleteSignup for every verification at once and returns
+// the errors in the same order
+func completeConcurrently(svc Service, verifications []*authModels.UserVerification) []error {
+	errs := make([]error, len(verifications))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, verification := range verifications {
+		wg.Add(1)
+		go func(i int, verification *authModels.UserVerification) {
+			defer wg.Done()
+			<-start
+			errs[i] = svc.CompleteSignup(context.Background(), verification)
+		}(i, verification)
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
+
+func TestCompleteSignup_ConcurrentVerifiesOfSameCode(t *testing.T) {
+	db := newFakeDB()
+	verification := newVerification("jane@example.com")
+
+	verifications := make([]*authModels.UserVerification, 8)
+	for i := range verifications {
+		copied := *verification
+		verifications[i] = &copied
+	}
+
+	for _, err := range completeConcurrently(newTestService(db), verifications) {
+		assert.NoError(t, err)
+	}
+
+	require.Len(t, db.users, 1)
+	assert.Equal(t, verification.UserId, db.users["jane@example.com"].ObjectId)
+	assert.Len(t, db.profiles, 1)
+	assert.Equal(t, []string{"user.signed_up:" + verification.UserId.String()}, db.events)
+}
+
+func TestCompleteSignup_ConcurrentSignupsForSameEmail(t *testing.T) {
+	db := newFakeDB()
+	verifications := make([]*authModels.UserVerification, 8)
+	for i := range verifications {
+		verifications[i] = newVerification("jane@example.com")
+	}
+
+	var winner uuid.UUID
+	for i, err := range completeConcurrently(newTestService(db), verifications) {
+		if err == nil {
+			assert.Equal(t, uuid.Nil, winner, "only one signup may complete")
+			winner = verifications[i].UserId
+			continue
+		}
+		assert.ErrorIs(t, err, ErrEmailAlreadyRegistered)
+		assert.ErrorIs(t, err, authErrors.ErrUserAlreadyExists)
+	}
+
+	require.NotEqual(t, uuid.Nil, winner)
+	require.Len(t, db.users, 1)
+	assert.Equal(t, winner, db.users["jane@example.com"].ObjectId)
+	assert.Len(t, db.profiles, 1)
+	assert.Len(t, db.events, 1)
+}
+
+func TestCompleteSignup_RetryAfterCompletion(t *testing.T) {
+	db := newFakeDB()
+	svc := newTestService(db)
+	verification := newVerification("jane@example.com")
+
+	require.NoError(t, svc.CompleteSignup(context.Background(), verification))
+	require.NoError(t, svc.CompleteSignup(context.Background(), verification))
+
+	assert.Len(t, db.users, 1)
+	assert.Len(t, db.events, 1)
+}
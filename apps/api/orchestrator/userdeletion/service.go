@@ -0,0 +1,250 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package userdeletion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	badgeRepo "github.com/qolzam/telar/apps/api/achievements/repository"
+	analyticsRepo "github.com/qolzam/telar/apps/api/analytics/repository"
+	authRepo "github.com/qolzam/telar/apps/api/auth/repository"
+	calendarRepo "github.com/qolzam/telar/apps/api/calendar/repository"
+	commentRepo "github.com/qolzam/telar/apps/api/comments/repository"
+	communityRepo "github.com/qolzam/telar/apps/api/communities/repository"
+	delegationRepo "github.com/qolzam/telar/apps/api/delegations/repository"
+	followRepo "github.com/qolzam/telar/apps/api/follows/repository"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
+	leaderboardRepo "github.com/qolzam/telar/apps/api/leaderboards/repository"
+	membershipRepo "github.com/qolzam/telar/apps/api/membership/repository"
+	mentionRepo "github.com/qolzam/telar/apps/api/mentions/repository"
+	moderationRepo "github.com/qolzam/telar/apps/api/moderation/repository"
+	postRepo "github.com/qolzam/telar/apps/api/posts/repository"
+	profileRepo "github.com/qolzam/telar/apps/api/profile/repository"
+	rulesRepo "github.com/qolzam/telar/apps/api/rules/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	streakRepo "github.com/qolzam/telar/apps/api/streaks/repository"
+	supporterRepo "github.com/qolzam/telar/apps/api/supporters/repository"
+	thanksRepo "github.com/qolzam/telar/apps/api/thanks/repository"
+	tipRepo "github.com/qolzam/telar/apps/api/tips/repository"
+	voteRepo "github.com/qolzam/telar/apps/api/votes/repository"
+)
+
+// ErrUserNotFound is returned when the account to delete does not exist
+var ErrUserNotFound = errors.New("user not found")
+
+// Service defines the orchestration logic for deleting an account
+// This orchestrator removes a user's comments, posts, profile, auth record and the
+// rows other modules keep about them across service boundaries in one transaction
+type Service interface {
+	DeleteUser(ctx context.Context, userID, deletedBy uuid.UUID) (*Result, error)
+
+	// SetEventOutbox announces deleted accounts as user.deleted outbox events,
+	// written in the deletion transaction
+	SetEventOutbox(outbox sharedInterfaces.EventOutbox)
+}
+
+// Result summarizes what was deleted with an account
+type Result struct {
+	Username     string `json:"username"`
+	PostsDeleted int64  `json:"postsDeleted"`
+}
+
+// Related are the modules that keep rows about an account with no foreign key to it
+type Related struct {
+	Follows      followRepo.FollowRepository
+	Delegations  delegationRepo.Repository
+	Tips         tipRepo.Repository
+	Thanks       thanksRepo.Repository
+	Mentions     mentionRepo.MentionRepository
+	Communities  communityRepo.Repository
+	Votes        voteRepo.VoteRepository
+	ProfileViews profileRepo.ViewRepository
+	Propagation  propagation.Repository
+	Calendar     calendarRepo.Repository
+	Streaks      streakRepo.StreakRepository
+	Badges       badgeRepo.BadgeRepository
+	Leaderboards leaderboardRepo.LeaderboardRepository
+	Analytics    analyticsRepo.Repository
+	Membership   membershipRepo.Repository
+	Rules        rulesRepo.Repository
+	Supporters   supporterRepo.Repository
+	Moderation   moderationRepo.Repository
+}
+
+type service struct {
+	authRepo    authRepo.AuthRepository
+	profileRepo profileRepo.ProfileRepository
+	postRepo    postRepo.PostRepository
+	commentRepo commentRepo.CommentRepository
+	related     Related
+	outbox      sharedInterfaces.EventOutbox // nil until SetEventOutbox
+	now         func() time.Time
+}
+
+// NewService creates a new user deletion orchestrator service
+func NewService(
+	authRepo authRepo.AuthRepository,
+	profileRepo profileRepo.ProfileRepository,
+	postRepo postRepo.PostRepository,
+	commentRepo commentRepo.CommentRepository,
+	related Related,
+) Service {
+	return &service{
+		authRepo:    authRepo,
+		profileRepo: profileRepo,
+		postRepo:    postRepo,
+		commentRepo: commentRepo,
+		related:     related,
+		now:         time.Now,
+	}
+}
+
+// SetEventOutbox announces deleted accounts through the outbox
+func (s *service) SetEventOutbox(outbox sharedInterfaces.EventOutbox) {
+	s.outbox = outbox
+}
+
+// DeleteUser permanently deletes an account with everything it wrote. Rows that
+// reference the account (second factors, bookmarks, uploads, votes on comments) go
+// with it through their foreign keys; sessions and the related modules' rows are
+// deleted explicitly.
+func (s *service) DeleteUser(ctx context.Context, userID, deletedBy uuid.UUID) (*Result, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("user ID is required")
+	}
+
+	result := &Result{}
+	err := s.authRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+		user, err := s.authRepo.FindByID(txCtx, userID)
+		if err != nil {
+			if err.Error() == "user not found" {
+				return ErrUserNotFound
+			}
+			return fmt.Errorf("failed to find user: %w", err)
+		}
+		result.Username = user.Username
+
+		// A. Comments first, so the counts of other users' posts can be corrected
+		removed, err := s.commentRepo.DeleteByOwner(txCtx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete comments: %w", err)
+		}
+		for postID, rootCount := range removed {
+			if err := s.postRepo.IncrementCommentCount(txCtx, postID, -rootCount); err != nil {
+				return fmt.Errorf("failed to decrement comment count: %w", err)
+			}
+		}
+
+		// B. Posts, with the comments other users wrote on them
+		result.PostsDeleted, err = s.postRepo.DeleteByOwner(txCtx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete posts: %w", err)
+		}
+
+		// C. Rows of other modules that no foreign key removes, while the profile
+		// counters they adjust still exist
+		if err := s.deleteRelated(txCtx, userID); err != nil {
+			return err
+		}
+
+		// D. Profile; accounts whose signup never created one have nothing to delete
+		if err := s.profileRepo.Delete(txCtx, userID); err != nil && err.Error() != "profile not found" {
+			return fmt.Errorf("failed to delete profile: %w", err)
+		}
+
+		// E. Auth record last; the foreign keys clean up the rest
+		if err := s.authRepo.Delete(txCtx, userID); err != nil {
+			return fmt.Errorf("failed to delete user auth: %w", err)
+		}
+
+		// F. Announce the deletion to other services; published only if this commits
+		if s.outbox != nil {
+			return s.outbox.Append(txCtx, sharedInterfaces.OutboxUserDeleted, userID.String(), sharedInterfaces.UserDeletedEvent{
+				UserId:       userID,
+				Username:     user.Username,
+				DeletedBy:    deletedBy,
+				PostsDeleted: result.PostsDeleted,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// deleteRelated removes the account from the follow graph, delegations, tips, thanks,
+// mentions, co-authorships, communities, votes, feeds read, profile views, profile
+// propagation and the engagement, membership, supporter and moderation records.
+// Analytics events stay counted, detached from the account.
+func (s *service) deleteRelated(ctx context.Context, userID uuid.UUID) error {
+	if err := s.postRepo.DeleteCoauthorsByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete co-authorships: %w", err)
+	}
+	if err := s.postRepo.DeleteReadMarkersByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete read markers: %w", err)
+	}
+	if err := s.related.Follows.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete follows: %w", err)
+	}
+	if err := s.related.Delegations.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete delegations: %w", err)
+	}
+	if err := s.related.Tips.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete tips: %w", err)
+	}
+	if err := s.related.Thanks.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete thanks: %w", err)
+	}
+	if err := s.related.Mentions.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete mentions: %w", err)
+	}
+	if err := s.related.Communities.DeleteByUser(ctx, userID, s.now().UTC().UnixMilli()); err != nil {
+		return fmt.Errorf("failed to delete community memberships: %w", err)
+	}
+	if err := s.related.Votes.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete votes: %w", err)
+	}
+	if err := s.related.ProfileViews.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete profile views: %w", err)
+	}
+	if err := s.related.Propagation.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete profile propagation: %w", err)
+	}
+	if err := s.related.Calendar.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete calendar token: %w", err)
+	}
+	if err := s.related.Streaks.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete streak: %w", err)
+	}
+	if err := s.related.Badges.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete badges: %w", err)
+	}
+	if err := s.related.Leaderboards.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete leaderboard entries: %w", err)
+	}
+	if err := s.related.Analytics.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to detach analytics events: %w", err)
+	}
+	if err := s.related.Membership.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete membership application: %w", err)
+	}
+	if err := s.related.Rules.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete rules acknowledgment: %w", err)
+	}
+	if err := s.related.Supporters.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete supporter subscriptions: %w", err)
+	}
+	if err := s.related.Moderation.DeleteByUser(ctx, userID); err != nil {
+		return fmt.Errorf("failed to delete moderation reports: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,400 @@
+package userdeletion
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	badgeRepo "github.com/qolzam/telar/apps/api/achievements/repository"
+	analyticsRepo "github.com/qolzam/telar/apps/api/analytics/repository"
+	authModels "github.com/qolzam/telar/apps/api/auth/models"
+	authRepo "github.com/qolzam/telar/apps/api/auth/repository"
+	calendarRepo "github.com/qolzam/telar/apps/api/calendar/repository"
+	commentRepo "github.com/qolzam/telar/apps/api/comments/repository"
+	communityRepo "github.com/qolzam/telar/apps/api/communities/repository"
+	delegationRepo "github.com/qolzam/telar/apps/api/delegations/repository"
+	followRepo "github.com/qolzam/telar/apps/api/follows/repository"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
+	leaderboardRepo "github.com/qolzam/telar/apps/api/leaderboards/repository"
+	membershipRepo "github.com/qolzam/telar/apps/api/membership/repository"
+	mentionRepo "github.com/qolzam/telar/apps/api/mentions/repository"
+	moderationRepo "github.com/qolzam/telar/apps/api/moderation/repository"
+	postRepo "github.com/qolzam/telar/apps/api/posts/repository"
+	profileRepo "github.com/qolzam/telar/apps/api/profile/repository"
+	rulesRepo "github.com/qolzam/telar/apps/api/rules/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	streakRepo "github.com/qolzam/telar/apps/api/streaks/repository"
+	supporterRepo "github.com/qolzam/telar/apps/api/supporters/repository"
+	thanksRepo "github.com/qolzam/telar/apps/api/thanks/repository"
+	tipRepo "github.com/qolzam/telar/apps/api/tips/repository"
+	voteRepo "github.com/qolzam/telar/apps/api/votes/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// steps records the repository calls in order
+type steps []string
+
+type fakeAuthRepo struct {
+	authRepo.AuthRepository
+	steps    *steps
+	users    map[uuid.UUID]*authModels.UserAuth
+	sessions userRows
+}
+
+func (r *fakeAuthRepo) FindByID(_ context.Context, userID uuid.UUID) (*authModels.UserAuth, error) {
+	if user, ok := r.users[userID]; ok {
+		return user, nil
+	}
+	return nil, fmt.Errorf("user not found")
+}
+
+func (r *fakeAuthRepo) Delete(_ context.Context, userID uuid.UUID) error {
+	*r.steps = append(*r.steps, "auth")
+	delete(r.users, userID)
+	delete(r.sessions, userID)
+	return nil
+}
+
+func (r *fakeAuthRepo) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeProfileRepo struct {
+	profileRepo.ProfileRepository
+	steps *steps
+}
+
+func (r *fakeProfileRepo) Delete(_ context.Context, _ uuid.UUID) error {
+	*r.steps = append(*r.steps, "profile")
+	return fmt.Errorf("profile not found")
+}
+
+type fakePostRepo struct {
+	postRepo.PostRepository
+	steps       *steps
+	counts      map[uuid.UUID]int
+	coauthors   userRows
+	readMarkers userRows
+}
+
+func (r *fakePostRepo) DeleteCoauthorsByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.coauthors, userID)
+	return nil
+}
+
+func (r *fakePostRepo) DeleteReadMarkersByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.readMarkers, userID)
+	return nil
+}
+
+func (r *fakePostRepo) IncrementCommentCount(_ context.Context, postID uuid.UUID, delta int) error {
+	r.counts[postID] += delta
+	return nil
+}
+
+func (r *fakePostRepo) DeleteByOwner(_ context.Context, _ uuid.UUID) (int64, error) {
+	*r.steps = append(*r.steps, "posts")
+	return 2, nil
+}
+
+type fakeCommentRepo struct {
+	commentRepo.CommentRepository
+	steps   *steps
+	removed map[uuid.UUID]int
+}
+
+func (r *fakeCommentRepo) DeleteByOwner(_ context.Context, _ uuid.UUID) (map[uuid.UUID]int, error) {
+	*r.steps = append(*r.steps, "comments")
+	return r.removed, nil
+}
+
+// userRows stands in for a module's tables: the users it keeps rows about
+type userRows map[uuid.UUID]bool
+
+type fakeFollows struct {
+	followRepo.FollowRepository
+	rows userRows
+}
+
+func (r *fakeFollows) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeDelegations struct {
+	delegationRepo.Repository
+	rows userRows
+}
+
+func (r *fakeDelegations) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeTips struct {
+	tipRepo.Repository
+	rows userRows
+}
+
+func (r *fakeTips) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeThanks struct {
+	thanksRepo.Repository
+	rows userRows
+}
+
+func (r *fakeThanks) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeMentions struct {
+	mentionRepo.MentionRepository
+	rows userRows
+}
+
+func (r *fakeMentions) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeCommunities struct {
+	communityRepo.Repository
+	rows userRows
+	err  error
+}
+
+func (r *fakeCommunities) DeleteByUser(_ context.Context, userID uuid.UUID, _ int64) error {
+	if r.err != nil {
+		return r.err
+	}
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeVotes struct {
+	voteRepo.VoteRepository
+	rows userRows
+}
+
+func (r *fakeVotes) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeProfileViews struct {
+	profileRepo.ViewRepository
+	rows userRows
+}
+
+func (r *fakeProfileViews) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakePropagation struct {
+	propagation.Repository
+	rows userRows
+}
+
+func (r *fakePropagation) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeCalendar struct {
+	calendarRepo.Repository
+	rows userRows
+}
+
+func (r *fakeCalendar) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeStreaks struct {
+	streakRepo.StreakRepository
+	rows userRows
+}
+
+func (r *fakeStreaks) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeBadges struct {
+	badgeRepo.BadgeRepository
+	rows userRows
+}
+
+func (r *fakeBadges) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeLeaderboards struct {
+	leaderboardRepo.LeaderboardRepository
+	rows userRows
+}
+
+func (r *fakeLeaderboards) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeAnalytics struct {
+	analyticsRepo.Repository
+	rows userRows
+}
+
+func (r *fakeAnalytics) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeMembership struct {
+	membershipRepo.Repository
+	rows userRows
+}
+
+func (r *fakeMembership) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeRules struct {
+	rulesRepo.Repository
+	rows userRows
+}
+
+func (r *fakeRules) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeSupporters struct {
+	supporterRepo.Repository
+	rows userRows
+}
+
+func (r *fakeSupporters) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+type fakeModeration struct {
+	moderationRepo.Repository
+	rows userRows
+}
+
+func (r *fakeModeration) DeleteByUser(_ context.Context, userID uuid.UUID) error {
+	delete(r.rows, userID)
+	return nil
+}
+
+// relatedRows gives every related module rows about each of users
+func relatedRows(users ...uuid.UUID) (Related, []userRows) {
+	tables := make([]userRows, 18)
+	for i := range tables {
+		tables[i] = userRows{}
+		for _, user := range users {
+			tables[i][user] = true
+		}
+	}
+	return Related{
+		Follows:      &fakeFollows{rows: tables[0]},
+		Delegations:  &fakeDelegations{rows: tables[1]},
+		Tips:         &fakeTips{rows: tables[2]},
+		Thanks:       &fakeThanks{rows: tables[3]},
+		Mentions:     &fakeMentions{rows: tables[4]},
+		Communities:  &fakeCommunities{rows: tables[5]},
+		Votes:        &fakeVotes{rows: tables[6]},
+		ProfileViews: &fakeProfileViews{rows: tables[7]},
+		Propagation:  &fakePropagation{rows: tables[8]},
+		Calendar:     &fakeCalendar{rows: tables[9]},
+		Streaks:      &fakeStreaks{rows: tables[10]},
+		Badges:       &fakeBadges{rows: tables[11]},
+		Leaderboards: &fakeLeaderboards{rows: tables[12]},
+		Analytics:    &fakeAnalytics{rows: tables[13]},
+		Membership:   &fakeMembership{rows: tables[14]},
+		Rules:        &fakeRules{rows: tables[15]},
+		Supporters:   &fakeSupporters{rows: tables[16]},
+		Moderation:   &fakeModeration{rows: tables[17]},
+	}, tables
+}
+
+type recordingOutbox struct {
+	eventType string
+	data      interface{}
+}
+
+func (o *recordingOutbox) Append(_ context.Context, eventType string, _ string, data interface{}) error {
+	o.eventType, o.data = eventType, data
+	return nil
+}
+
+func TestDeleteUser(t *testing.T) {
+	userID, adminID, otherPost := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	var order steps
+	auth := &fakeAuthRepo{steps: &order, users: map[uuid.UUID]*authModels.UserAuth{
+		userID: {ObjectId: userID, Username: "user@example.com"},
+	}}
+	posts := &fakePostRepo{steps: &order, counts: map[uuid.UUID]int{}, coauthors: userRows{}}
+	outbox := &recordingOutbox{}
+	related, _ := relatedRows()
+	s := NewService(auth, &fakeProfileRepo{steps: &order}, posts, &fakeCommentRepo{steps: &order, removed: map[uuid.UUID]int{otherPost: 2}}, related)
+	s.SetEventOutbox(outbox)
+
+	result, err := s.DeleteUser(context.Background(), userID, adminID)
+	require.NoError(t, err)
+	assert.Equal(t, "user@example.com", result.Username)
+	assert.EqualValues(t, 2, result.PostsDeleted)
+	assert.Equal(t, steps{"comments", "posts", "profile", "auth"}, order)
+	assert.Equal(t, -2, posts.counts[otherPost], "other users' posts lose the deleted root comments")
+	assert.Equal(t, sharedInterfaces.OutboxUserDeleted, outbox.eventType)
+	assert.Equal(t, adminID, outbox.data.(sharedInterfaces.UserDeletedEvent).DeletedBy)
+
+	_, err = s.DeleteUser(context.Background(), userID, adminID)
+	assert.ErrorIs(t, err, ErrUserNotFound)
+}
+
+func TestDeleteUser_DeletesRelatedRows(t *testing.T) {
+	userID, otherID := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	var order steps
+	auth := &fakeAuthRepo{steps: &order, users: map[uuid.UUID]*authModels.UserAuth{
+		userID: {ObjectId: userID, Username: "user@example.com"},
+	}, sessions: userRows{userID: true, otherID: true}}
+	posts := &fakePostRepo{steps: &order, counts: map[uuid.UUID]int{},
+		coauthors: userRows{userID: true, otherID: true}, readMarkers: userRows{userID: true, otherID: true}}
+	related, tables := relatedRows(userID, otherID)
+	s := NewService(auth, &fakeProfileRepo{steps: &order}, posts, &fakeCommentRepo{steps: &order}, related)
+
+	_, err := s.DeleteUser(context.Background(), userID, otherID)
+	require.NoError(t, err)
+
+	tables = append(tables, posts.coauthors, posts.readMarkers, auth.sessions)
+	for i, rows := range tables {
+		assert.False(t, rows[userID], "table %d still has rows of the deleted user", i)
+		assert.True(t, rows[otherID], "table %d lost rows of another user", i)
+	}
+}
+
+func TestDeleteUser_RelatedFailureKeepsAccount(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	var order steps
+	auth := &fakeAuthRepo{steps: &order, users: map[uuid.UUID]*authModels.UserAuth{
+		userID: {ObjectId: userID, Username: "user@example.com"},
+	}}
+	related, _ := relatedRows(userID)
+	related.Communities.(*fakeCommunities).err = fmt.Errorf("connection reset")
+	s := NewService(auth, &fakeProfileRepo{steps: &order}, &fakePostRepo{steps: &order, counts: map[uuid.UUID]int{}, coauthors: userRows{}}, &fakeCommentRepo{steps: &order}, related)
+
+	_, err := s.DeleteUser(context.Background(), userID, userID)
+	assert.ErrorContains(t, err, "community memberships")
+	assert.NotContains(t, order, "auth", "the auth record outlives a failed cleanup")
+}
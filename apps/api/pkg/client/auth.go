@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// AuthService signs users in
+type AuthService struct {
+	client *Client
+}
+
+// Session is the outcome of a sign-in. When MFARequired is set no token was issued
+// yet: pass ChallengeToken and a TOTP or recovery code to VerifyMFA.
+type Session struct {
+	User           profileModels.Profile `json:"user"`
+	AccessToken    string                `json:"accessToken"`
+	TokenType      string                `json:"tokenType"`
+	MFARequired    bool                  `json:"mfaRequired"`
+	ChallengeToken string                `json:"challengeToken"`
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type mfaRequest struct {
+	ChallengeToken string `json:"challengeToken"`
+	Code           string `json:"code"`
+}
+
+// Login signs in with a username (email) and password. The client sends the issued
+// token with later requests. Accounts with a second factor return a session with
+// MFARequired set and ErrMFARequired.
+// Endpoint: POST /auth/login
+func (s *AuthService) Login(ctx context.Context, username, password string) (*Session, error) {
+	var session Session
+	err := s.client.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/auth/login",
+		body:   loginRequest{Username: username, Password: password},
+	}, &session)
+	if err != nil {
+		return nil, err
+	}
+	if session.MFARequired {
+		return &session, ErrMFARequired
+	}
+	s.client.setToken(session.AccessToken)
+	return &session, nil
+}
+
+// VerifyMFA completes a sign-in held back for a second factor.
+// Endpoint: POST /auth/login/mfa
+func (s *AuthService) VerifyMFA(ctx context.Context, challengeToken, code string) (*Session, error) {
+	var session Session
+	err := s.client.do(ctx, request{
+		method: http.MethodPost,
+		path:   "/auth/login/mfa",
+		body:   mfaRequest{ChallengeToken: challengeToken, Code: code},
+	}, &session)
+	if err != nil {
+		return nil, err
+	}
+	s.client.setToken(session.AccessToken)
+	return &session, nil
+}
+
+// Logout forgets the access token. Credentials given with WithCredentials are kept,
+// so the next authenticated request signs in again.
+func (s *AuthService) Logout() {
+	s.client.setToken("")
+}
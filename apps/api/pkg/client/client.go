@@ -0,0 +1,414 @@
+// Package client is a Go client for the Telar API. It covers sign-in, posts, comments,
+// profiles and votes, retries rate-limited and failed requests, signs in again when
+// the access token expires and reports failed requests as *APIError.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTimeout      = 30 * time.Second
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = 500 * time.Millisecond
+	maxRetryWait        = 30 * time.Second
+	defaultUserAgent    = "telar-go-client"
+
+	// tokenRefreshLeeway signs in again this long before the access token expires
+	tokenRefreshLeeway = time.Minute
+)
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sends requests with hc instead of a client with a 30 second timeout
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		if hc != nil {
+			c.httpClient = hc
+		}
+	}
+}
+
+// WithToken authenticates requests with an access token issued elsewhere
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.setToken(token)
+	}
+}
+
+// WithCredentials signs in with a username and password on the first authenticated
+// request, and again whenever the access token expires or is rejected
+func WithCredentials(username, password string) Option {
+	return func(c *Client) {
+		c.username = username
+		c.password = password
+	}
+}
+
+// WithRetries sets how often a failed request is retried and the backoff before the
+// first retry, which doubles with every attempt. Zero retries disables retrying.
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		if maxRetries >= 0 {
+			c.maxRetries = maxRetries
+		}
+		if backoff > 0 {
+			c.backoff = backoff
+		}
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// RateLimit is the request budget reported by the last response
+type RateLimit struct {
+	Policy    string    // Name of the limit, e.g. "api" or "post creation"
+	Limit     int       // Requests allowed per window
+	Remaining int       // Requests left in the current window
+	Reset     time.Time // When the window starts over
+}
+
+// Client calls the Telar API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	userAgent  string
+	maxRetries int
+	backoff    time.Duration
+	username   string
+	password   string
+
+	// refreshMu keeps concurrent requests from signing in at the same time
+	refreshMu sync.Mutex
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+	rateLimit *RateLimit
+
+	// sleep waits between retries; replaced in tests
+	sleep func(ctx context.Context, d time.Duration) error
+
+	Auth     *AuthService
+	Posts    *PostsService
+	Comments *CommentsService
+	Profiles *ProfilesService
+	Votes    *VotesService
+}
+
+// New creates a client for the API served at baseURL, e.g. "https://api.example.com".
+func New(baseURL string, opts ...Option) (*Client, error) {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		return nil, fmt.Errorf("base URL is required")
+	}
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	c := &Client{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: defaultTimeout},
+		userAgent:  defaultUserAgent,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultRetryBackoff,
+		sleep:      sleepContext,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Auth = &AuthService{client: c}
+	c.Posts = &PostsService{client: c}
+	c.Comments = &CommentsService{client: c}
+	c.Profiles = &ProfilesService{client: c}
+	c.Votes = &VotesService{client: c}
+	return c, nil
+}
+
+// Token returns the access token requests are sent with, empty before sign-in
+func (c *Client) Token() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.token
+}
+
+// RateLimit returns the request budget reported by the last response that carried one
+func (c *Client) RateLimit() (RateLimit, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.rateLimit == nil {
+		return RateLimit{}, false
+	}
+	return *c.rateLimit, true
+}
+
+// setToken stores token and the expiry read from its claims
+func (c *Client) setToken(token string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.token = token
+	c.expiresAt = tokenExpiry(token)
+}
+
+// request describes one API call
+type request struct {
+	method string
+	path   string
+	query  url.Values
+	body   interface{}
+	auth   bool // Send the access token, signing in first when needed
+}
+
+// do sends req, retrying and refreshing the token as needed, and decodes a JSON
+// response into out when out is not nil
+func (c *Client) do(ctx context.Context, req request, out interface{}) error {
+	var payload []byte
+	if req.body != nil {
+		var err error
+		if payload, err = json.Marshal(req.body); err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+	}
+
+	refreshed := false
+	for attempt := 0; ; attempt++ {
+		token := ""
+		if req.auth {
+			var err error
+			if token, err = c.validToken(ctx); err != nil {
+				return err
+			}
+		}
+
+		resp, err := c.send(ctx, req, payload, token)
+		if err != nil {
+			if ctx.Err() != nil || !idempotent(req.method) || attempt >= c.maxRetries {
+				return err
+			}
+			if err := c.sleep(ctx, c.retryDelay(attempt, nil)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+		c.recordRateLimit(resp.Header)
+
+		if resp.StatusCode < 300 {
+			if out == nil || len(respBody) == 0 {
+				return nil
+			}
+			if err := json.Unmarshal(respBody, out); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+			return nil
+		}
+
+		apiErr := newAPIError(resp, respBody)
+
+		// A rejected token is renewed once, when the client holds credentials
+		if resp.StatusCode == http.StatusUnauthorized && req.auth && !refreshed && c.hasCredentials() {
+			refreshed = true
+			if err := c.refresh(ctx, token); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if attempt >= c.maxRetries || !retryable(req.method, resp.StatusCode) {
+			return apiErr
+		}
+		delay := c.retryDelay(attempt, resp.Header)
+		if delay > maxRetryWait {
+			return apiErr
+		}
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// send performs a single HTTP request
+func (c *Client) send(ctx context.Context, req request, payload []byte, token string) (*http.Response, error) {
+	target := c.baseURL + req.path
+	if len(req.query) > 0 {
+		target += "?" + req.query.Encode()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, req.method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if payload != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if c.userAgent != "" {
+		httpReq.Header.Set("User-Agent", c.userAgent)
+	}
+	if token != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", req.method, req.path, err)
+	}
+	return resp, nil
+}
+
+func (c *Client) hasCredentials() bool {
+	return c.username != "" && c.password != ""
+}
+
+// validToken returns a token that is not about to expire, signing in when the client
+// holds credentials and the current token is missing or expiring
+func (c *Client) validToken(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	token, expiresAt := c.token, c.expiresAt
+	c.mu.Unlock()
+
+	expiring := !expiresAt.IsZero() && time.Until(expiresAt) < tokenRefreshLeeway
+	if (token != "" && !expiring) || !c.hasCredentials() {
+		if token == "" {
+			return "", ErrNotAuthenticated
+		}
+		return token, nil
+	}
+	if err := c.refresh(ctx, token); err != nil {
+		return "", err
+	}
+	return c.Token(), nil
+}
+
+// refresh signs in again unless another request already replaced stale
+func (c *Client) refresh(ctx context.Context, stale string) error {
+	c.refreshMu.Lock()
+	defer c.refreshMu.Unlock()
+	if current := c.Token(); current != "" && current != stale {
+		return nil
+	}
+	_, err := c.Auth.Login(ctx, c.username, c.password)
+	return err
+}
+
+// retryDelay is the wait before retry attempt+1. The server's Retry-After, or the
+// reset of an exhausted rate limit, wins over exponential backoff.
+func (c *Client) retryDelay(attempt int, header http.Header) time.Duration {
+	if header != nil {
+		if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second
+		}
+		if header.Get("X-RateLimit-Remaining") == "0" {
+			if seconds, err := strconv.Atoi(header.Get("X-RateLimit-Reset")); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	delay := c.backoff << uint(attempt)
+	// Up to 20% jitter keeps clients that failed together from retrying together
+	return delay + time.Duration(rand.Int63n(int64(delay)/5+1))
+}
+
+// recordRateLimit keeps the budget reported by the rate-limit headers
+func (c *Client) recordRateLimit(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.Atoi(header.Get("X-RateLimit-Reset"))
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rateLimit = &RateLimit{
+		Policy:    header.Get("X-RateLimit-Policy"),
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Now().Add(time.Duration(reset) * time.Second),
+	}
+}
+
+// idempotent reports whether repeating a request cannot apply it twice
+func idempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryable reports whether a response with status is worth retrying. Rate-limited and
+// unavailable responses were not processed, so any request may be repeated.
+func retryable(method string, status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusInternalServerError:
+		return idempotent(method)
+	}
+	return false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// tokenExpiry reads the exp claim of a JWT without verifying it; the server does that
+func tokenExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
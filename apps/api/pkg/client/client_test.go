@@ -0,0 +1,379 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commentModels "github.com/qolzam/telar/apps/api/comments/models"
+	postModels "github.com/qolzam/telar/apps/api/posts/models"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// testToken builds an unsigned JWT expiring at exp; the client never verifies signatures
+func testToken(exp time.Time, subject string) string {
+	encode := func(v interface{}) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	return encode(map[string]string{"alg": "ES256"}) + "." + encode(map[string]interface{}{"exp": exp.Unix(), "sub": subject}) + ".sig"
+}
+
+// fakeAPI serves the sign-in route and routes registered by the test, counting calls
+type fakeAPI struct {
+	mu     sync.Mutex
+	logins int
+	calls  map[string]int
+	routes map[string]http.HandlerFunc
+	token  func() string // Issued by the next sign-in
+}
+
+func newFakeAPI(t *testing.T) (*fakeAPI, *httptest.Server) {
+	api := &fakeAPI{calls: map[string]int{}, routes: map[string]http.HandlerFunc{}}
+	api.token = func() string { return testToken(time.Now().Add(48*time.Hour), fmt.Sprint(api.logins)) }
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	return api, server
+}
+
+func (a *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := r.Method + " " + r.URL.Path
+	a.mu.Lock()
+	a.calls[key]++
+	handler := a.routes[key]
+	if key == "POST /auth/login" && handler == nil {
+		a.logins++
+		token := a.token()
+		a.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"accessToken": token, "tokenType": "Bearer", "user": map[string]string{"socialName": "bot"}})
+		return
+	}
+	a.mu.Unlock()
+	if handler == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+	handler(w, r)
+}
+
+func (a *fakeAPI) count(key string) int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.calls[key]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// newTestClient returns a client that records retry waits instead of sleeping
+func newTestClient(t *testing.T, baseURL string, opts ...Option) (*Client, *[]time.Duration) {
+	c, err := New(baseURL, opts...)
+	require.NoError(t, err)
+	var waits []time.Duration
+	c.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return c, &waits
+}
+
+func TestNew(t *testing.T) {
+	_, err := New("")
+	assert.Error(t, err)
+	_, err = New("not a url")
+	assert.Error(t, err)
+
+	c, err := New("https://api.example.com/")
+	require.NoError(t, err)
+	assert.Equal(t, "https://api.example.com", c.baseURL)
+}
+
+func TestLoginStoresToken(t *testing.T) {
+	api, server := newFakeAPI(t)
+	postID := uuid.Must(uuid.NewV4())
+	api.routes["GET /posts/"+postID.String()] = func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"code": "UNAUTHORIZED", "message": "Authentication required"})
+			return
+		}
+		writeJSON(w, http.StatusOK, postModels.PostResponse{ObjectId: postID.String(), Body: "hello"})
+	}
+	c, _ := newTestClient(t, server.URL)
+
+	_, err := c.Posts.Get(context.Background(), postID)
+	assert.ErrorIs(t, err, ErrNotAuthenticated)
+
+	session, err := c.Auth.Login(context.Background(), "bot@example.com", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "bot", session.User.SocialName)
+	assert.Equal(t, session.AccessToken, c.Token())
+
+	post, err := c.Posts.Get(context.Background(), postID)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", post.Body)
+}
+
+func TestLoginMFA(t *testing.T) {
+	api, server := newFakeAPI(t)
+	api.routes["POST /auth/login"] = func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"mfaRequired": true, "challengeToken": "challenge", "expires_in": "300"})
+	}
+	api.routes["POST /auth/login/mfa"] = func(w http.ResponseWriter, r *http.Request) {
+		var body mfaRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, mfaRequest{ChallengeToken: "challenge", Code: "123456"}, body)
+		writeJSON(w, http.StatusOK, map[string]string{"accessToken": "verified"})
+	}
+	c, _ := newTestClient(t, server.URL)
+
+	session, err := c.Auth.Login(context.Background(), "bot@example.com", "secret")
+	assert.ErrorIs(t, err, ErrMFARequired)
+	require.NotNil(t, session)
+	assert.Empty(t, c.Token())
+
+	_, err = c.Auth.VerifyMFA(context.Background(), session.ChallengeToken, "123456")
+	require.NoError(t, err)
+	assert.Equal(t, "verified", c.Token())
+}
+
+func TestTokenRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("credentials sign in on first use", func(t *testing.T) {
+		api, server := newFakeAPI(t)
+		api.routes["GET /profile/my"] = func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]string{"fullName": "Bot"})
+		}
+		c, _ := newTestClient(t, server.URL, WithCredentials("bot@example.com", "secret"))
+
+		profile, err := c.Profiles.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Bot", profile.FullName)
+		_, err = c.Profiles.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, api.logins)
+	})
+
+	t.Run("expiring tokens are renewed before the request", func(t *testing.T) {
+		api, server := newFakeAPI(t)
+		api.routes["GET /profile/my"] = func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusOK, map[string]string{})
+		}
+		expiring := testToken(time.Now().Add(10*time.Second), "old")
+		c, _ := newTestClient(t, server.URL, WithToken(expiring), WithCredentials("bot@example.com", "secret"))
+
+		_, err := c.Profiles.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, api.logins)
+		assert.NotEqual(t, expiring, c.Token())
+	})
+
+	t.Run("a rejected token is renewed once", func(t *testing.T) {
+		api, server := newFakeAPI(t)
+		rejected := testToken(time.Now().Add(time.Hour), "revoked")
+		api.routes["GET /profile/my"] = func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "Bearer "+rejected {
+				writeJSON(w, http.StatusUnauthorized, map[string]string{"code": "UNAUTHORIZED", "message": "Session revoked"})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]string{})
+		}
+		c, _ := newTestClient(t, server.URL, WithToken(rejected), WithCredentials("bot@example.com", "secret"))
+
+		_, err := c.Profiles.Me(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, api.logins)
+		assert.Equal(t, 2, api.count("GET /profile/my"))
+
+		// A token that keeps being rejected is not renewed in a loop
+		api.token = func() string { return rejected }
+		c.setToken(rejected)
+		_, err = c.Profiles.Me(ctx)
+		assert.True(t, IsUnauthorized(err))
+		assert.Equal(t, 2, api.logins)
+	})
+
+	t.Run("without credentials a rejected token is returned as is", func(t *testing.T) {
+		api, server := newFakeAPI(t)
+		api.routes["GET /profile/my"] = func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"code": "UNAUTHORIZED", "message": "Token expired"})
+		}
+		c, _ := newTestClient(t, server.URL, WithToken("opaque"))
+
+		_, err := c.Profiles.Me(ctx)
+		assert.True(t, IsUnauthorized(err))
+		assert.Zero(t, api.logins)
+	})
+}
+
+func TestRetries(t *testing.T) {
+	ctx := context.Background()
+	postID := uuid.Must(uuid.NewV4())
+	token := WithToken(testToken(time.Now().Add(time.Hour), "bot"))
+
+	t.Run("rate-limited requests wait for Retry-After", func(t *testing.T) {
+		api, server := newFakeAPI(t)
+		api.routes["POST /posts/"] = func(w http.ResponseWriter, r *http.Request) {
+			if api.count("POST /posts/") < 3 {
+				w.Header().Set("Retry-After", "2")
+				writeJSON(w, http.StatusTooManyRequests, map[string]string{"code": "RATE_LIMIT_EXCEEDED", "message": "Too many requests"})
+				return
+			}
+			writeJSON(w, http.StatusCreated, map[string]string{"objectId": postID.String()})
+		}
+		c, waits := newTestClient(t, server.URL, token)
+
+		created, err := c.Posts.Create(ctx, &postModels.CreatePostRequest{PostTypeId: 1, Body: "hello"})
+		require.NoError(t, err)
+		assert.Equal(t, postID.String(), created.ObjectId)
+		assert.Equal(t, []time.Duration{2 * time.Second, 2 * time.Second}, *waits)
+	})
+
+	t.Run("exhausted rate limits wait for the reset", func(t *testing.T) {
+		api, server := newFakeAPI(t)
+		api.routes["GET /posts/"] = func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-RateLimit-Limit", "600")
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", "7")
+			w.Header().Set("X-RateLimit-Policy", "api")
+			if api.count("GET /posts/") == 1 {
+				writeJSON(w, http.StatusTooManyRequests, map[string]string{"code": "RATE_LIMIT_EXCEEDED", "message": "Too many requests"})
+				return
+			}
+			writeJSON(w, http.StatusOK, postModels.PostsListResponse{Posts: []postModels.PostResponse{}})
+		}
+		c, waits := newTestClient(t, server.URL, token)
+
+		_, err := c.Posts.List(ctx, nil)
+		require.NoError(t, err)
+		assert.Equal(t, []time.Duration{7 * time.Second}, *waits)
+
+		limit, ok := c.RateLimit()
+		require.True(t, ok)
+		assert.Equal(t, "api", limit.Policy)
+		assert.Equal(t, 600, limit.Limit)
+		assert.Zero(t, limit.Remaining)
+	})
+
+	t.Run("server errors back off exponentially", func(t *testing.T) {
+		api, server := newFakeAPI(t)
+		api.routes["GET /posts/"+postID.String()] = func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"code": "SERVICE_UNAVAILABLE", "message": "Try again later"})
+		}
+		c, waits := newTestClient(t, server.URL, token, WithRetries(2, 100*time.Millisecond))
+
+		_, err := c.Posts.Get(ctx, postID)
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		assert.Equal(t, "SERVICE_UNAVAILABLE", apiErr.Code)
+		assert.Equal(t, 3, api.count("GET /posts/"+postID.String()))
+		require.Len(t, *waits, 2)
+		assert.GreaterOrEqual(t, (*waits)[0], 100*time.Millisecond)
+		assert.GreaterOrEqual(t, (*waits)[1], 200*time.Millisecond)
+	})
+
+	t.Run("failed writes that may have been applied are not repeated", func(t *testing.T) {
+		api, server := newFakeAPI(t)
+		api.routes["POST /comments/"] = func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, http.StatusInternalServerError, map[string]string{"code": "SYSTEM_ERROR", "message": "boom"})
+		}
+		c, waits := newTestClient(t, server.URL, token)
+
+		_, err := c.Comments.Create(ctx, &commentModels.CreateCommentRequest{PostId: postID, Text: "hi"})
+		assert.Equal(t, http.StatusInternalServerError, StatusCode(err))
+		assert.Equal(t, 1, api.count("POST /comments/"))
+		assert.Empty(t, *waits)
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		_, server := newFakeAPI(t)
+		c, waits := newTestClient(t, server.URL, token)
+
+		_, err := c.Posts.Get(ctx, postID)
+		assert.True(t, IsNotFound(err))
+		assert.Equal(t, "Not Found", err.(*APIError).Message)
+		assert.Empty(t, *waits)
+	})
+}
+
+func TestNewAPIError(t *testing.T) {
+	resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"30"}}}
+	err := newAPIError(resp, []byte(`{"code":"RATE_LIMIT_EXCEEDED","message":"Too many requests","details":"post creation"}`))
+	assert.Equal(t, "RATE_LIMIT_EXCEEDED", err.Code)
+	assert.Equal(t, "post creation", err.Details)
+	assert.Equal(t, 30*time.Second, err.RetryAfter)
+	assert.Equal(t, "telar API 429 RATE_LIMIT_EXCEEDED: Too many requests", err.Error())
+	assert.True(t, IsRateLimited(err))
+
+	resp = &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+	assert.Equal(t, "<html>bad gateway</html>", newAPIError(resp, []byte("<html>bad gateway</html>")).Message)
+	assert.Equal(t, "Bad Gateway", newAPIError(resp, nil).Message)
+}
+
+func TestRequests(t *testing.T) {
+	ctx := context.Background()
+	postID := uuid.Must(uuid.NewV4())
+	commentID := uuid.Must(uuid.NewV4())
+	api, server := newFakeAPI(t)
+	c, _ := newTestClient(t, server.URL, WithToken("token"))
+
+	api.routes["GET /posts/"] = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "cursor=abc&limit=5&sortBy=score&tags=go%2Capi", r.URL.RawQuery)
+		writeJSON(w, http.StatusOK, postModels.PostsListResponse{NextCursor: "def", HasNext: true})
+	}
+	list, err := c.Posts.List(ctx, &ListPostsOptions{Cursor: "abc", Limit: 5, Tags: []string{"go", "api"}, SortBy: "score"})
+	require.NoError(t, err)
+	assert.Equal(t, "def", list.NextCursor)
+
+	api.routes["GET /comments/"] = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, postID.String(), r.URL.Query().Get("postId"))
+		assert.Equal(t, "oldest", r.URL.Query().Get("sort"))
+		writeJSON(w, http.StatusOK, commentModels.CommentsListResponse{Comments: []commentModels.CommentResponse{{ObjectId: commentID.String()}}})
+	}
+	comments, err := c.Comments.ListByPost(ctx, postID, &ListCommentsOptions{Sort: "oldest"})
+	require.NoError(t, err)
+	require.Len(t, comments.Comments, 1)
+
+	api.routes["DELETE /comments/id/"+commentID.String()+"/post/"+postID.String()] = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+	require.NoError(t, c.Comments.Delete(ctx, commentID, postID))
+
+	api.routes["POST /posts/"+postID.String()+"/vote"] = func(w http.ResponseWriter, r *http.Request) {
+		var body setVoteRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, VoteUp, body.Vote)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"postId": postID, "previousTypeId": 0, "typeId": 1, "score": 4})
+	}
+	change, err := c.Votes.Set(ctx, postID, VoteUp)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), change.Score)
+
+	api.routes["GET /votes/mine"] = func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, postID.String(), r.URL.Query().Get("postIds"))
+		writeJSON(w, http.StatusOK, map[string]interface{}{"votes": map[string]int{postID.String(): 1}})
+	}
+	votes, err := c.Votes.Mine(ctx, []uuid.UUID{postID})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]int{postID: 1}, votes)
+
+	api.routes["PUT /profile/"] = func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}
+	tagLine := "Posting the weather"
+	require.NoError(t, c.Profiles.Update(ctx, &profileModels.UpdateProfileRequest{TagLine: &tagLine}))
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/comments/models"
+)
+
+// CommentsService writes, reads and likes comments
+type CommentsService struct {
+	client *Client
+}
+
+// ListCommentsOptions pages a comment listing. Zero values are left to the server's
+// defaults: 10 comments in the deployment's order.
+type ListCommentsOptions struct {
+	Cursor string // nextCursor of the previous page
+	Limit  int    // 1 to 100
+	Sort   string // Root comments only: newest or oldest
+}
+
+func (o *ListCommentsOptions) values() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Cursor != "" {
+		query.Set("cursor", o.Cursor)
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Sort != "" {
+		query.Set("sort", o.Sort)
+	}
+	return query
+}
+
+// Create comments on a post, or replies to a comment when req.ParentCommentId is set.
+// Endpoint: POST /comments/
+func (s *CommentsService) Create(ctx context.Context, req *models.CreateCommentRequest) (*models.CommentResponse, error) {
+	var comment models.CommentResponse
+	if err := s.client.do(ctx, request{method: http.MethodPost, path: "/comments/", body: req, auth: true}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Get reads a comment.
+// Endpoint: GET /comments/:commentId
+func (s *CommentsService) Get(ctx context.Context, commentID uuid.UUID) (*models.CommentResponse, error) {
+	var comment models.CommentResponse
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/comments/" + commentID.String(), auth: true}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ListByPost pages through the root comments of a post.
+// Endpoint: GET /comments/?postId=
+func (s *CommentsService) ListByPost(ctx context.Context, postID uuid.UUID, opts *ListCommentsOptions) (*models.CommentsListResponse, error) {
+	query := opts.values()
+	query.Set("postId", postID.String())
+
+	var list models.CommentsListResponse
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/comments/", query: query, auth: true}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// ListReplies pages through the replies to a comment, oldest first.
+// Endpoint: GET /comments/:commentId/replies
+func (s *CommentsService) ListReplies(ctx context.Context, commentID uuid.UUID, opts *ListCommentsOptions) (*models.CommentsListResponse, error) {
+	query := opts.values()
+	query.Del("sort")
+
+	var list models.CommentsListResponse
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/comments/" + commentID.String() + "/replies", query: query, auth: true}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Update replaces the text of a comment of the signed-in user.
+// Endpoint: PUT /comments/
+func (s *CommentsService) Update(ctx context.Context, req *models.UpdateCommentRequest) (*models.CommentResponse, error) {
+	var comment models.CommentResponse
+	if err := s.client.do(ctx, request{method: http.MethodPut, path: "/comments/", body: req, auth: true}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// Delete removes a comment of the signed-in user from a post.
+// Endpoint: DELETE /comments/id/:commentId/post/:postId
+func (s *CommentsService) Delete(ctx context.Context, commentID, postID uuid.UUID) error {
+	path := "/comments/id/" + commentID.String() + "/post/" + postID.String()
+	return s.client.do(ctx, request{method: http.MethodDelete, path: path, auth: true}, nil)
+}
+
+// ToggleLike likes a comment, or takes the like back when the user already liked it.
+// The returned comment carries the new score and IsLiked.
+// Endpoint: POST /comments/:commentId/like
+func (s *CommentsService) ToggleLike(ctx context.Context, commentID uuid.UUID) (*models.CommentResponse, error) {
+	var comment models.CommentResponse
+	if err := s.client.do(ctx, request{method: http.MethodPost, path: "/comments/" + commentID.String() + "/like", auth: true}, &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
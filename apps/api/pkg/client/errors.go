@@ -0,0 +1,118 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrNotAuthenticated is returned for requests that need a token when the client
+	// has neither a token nor credentials
+	ErrNotAuthenticated = errors.New("client: not authenticated; use WithToken, WithCredentials or Auth.Login")
+
+	// ErrMFARequired is returned when credentials alone cannot sign in because the
+	// account has a second factor; complete the sign-in with Auth.VerifyMFA
+	ErrMFARequired = errors.New("client: sign-in needs a second factor")
+)
+
+// APIError is a response the API answered with a 4xx or 5xx status. Code is the
+// machine-readable code of the API's error body, e.g. "POST_NOT_FOUND", when it has one.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+	Details    interface{}
+	RetryAfter time.Duration // Set from the Retry-After header of rate-limited responses
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "telar API %d", e.StatusCode)
+	if e.Code != "" {
+		b.WriteString(" " + e.Code)
+	}
+	if e.Message != "" {
+		b.WriteString(": " + e.Message)
+	}
+	return b.String()
+}
+
+// errorBody covers both error shapes the API answers with: the standard
+// {code, message, details} body and the short {error} body
+type errorBody struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details"`
+	Error   string      `json:"error"`
+}
+
+// maxErrorText bounds how much of a non-JSON error body becomes the message
+const maxErrorText = 200
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+		apiErr.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	var parsed errorBody
+	if err := json.Unmarshal(body, &parsed); err == nil {
+		apiErr.Code = parsed.Code
+		apiErr.Message = parsed.Message
+		apiErr.Details = parsed.Details
+		if apiErr.Message == "" {
+			apiErr.Message = parsed.Error
+		}
+	}
+	if apiErr.Message == "" {
+		text := strings.TrimSpace(string(body))
+		if len(text) > maxErrorText {
+			text = text[:maxErrorText] + "..."
+		}
+		if text == "" {
+			text = http.StatusText(resp.StatusCode)
+		}
+		apiErr.Message = text
+	}
+	return apiErr
+}
+
+// StatusCode returns the HTTP status of an *APIError in err's chain, or 0
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// IsNotFound reports whether err is a 404 response
+func IsNotFound(err error) bool {
+	return StatusCode(err) == http.StatusNotFound
+}
+
+// IsUnauthorized reports whether err is a 401 response
+func IsUnauthorized(err error) bool {
+	return StatusCode(err) == http.StatusUnauthorized
+}
+
+// IsForbidden reports whether err is a 403 response
+func IsForbidden(err error) bool {
+	return StatusCode(err) == http.StatusForbidden
+}
+
+// IsConflict reports whether err is a 409 response
+func IsConflict(err error) bool {
+	return StatusCode(err) == http.StatusConflict
+}
+
+// IsRateLimited reports whether err is a 429 response that outlasted the retries
+func IsRateLimited(err error) bool {
+	return StatusCode(err) == http.StatusTooManyRequests
+}
@@ -0,0 +1,115 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+
+	commentModels "github.com/qolzam/telar/apps/api/comments/models"
+	"github.com/qolzam/telar/apps/api/pkg/client"
+	postModels "github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// A bot signs in with its own account and posts. The client signs in on the first
+// request and again whenever the token expires.
+func Example() {
+	ctx := context.Background()
+	c, err := client.New("https://api.example.com",
+		client.WithCredentials("weather-bot@example.com", "bot-password"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	created, err := c.Posts.Create(ctx, &postModels.CreatePostRequest{
+		PostTypeId: 1,
+		Body:       "Sunny with a light breeze today #weather",
+		Tags:       []string{"weather"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("posted", created.ObjectId)
+}
+
+// Pages through the newest posts with a tag, keeping the snapshot so posts published
+// while paging do not shift later pages.
+func ExamplePostsService_List() {
+	ctx := context.Background()
+	c, err := client.New("https://api.example.com", client.WithToken("<access token>"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	opts := &client.ListPostsOptions{Tags: []string{"golang"}, Limit: 50}
+	for {
+		page, err := c.Posts.List(ctx, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, post := range page.Posts {
+			fmt.Println(post.ObjectId, post.OwnerDisplayName)
+		}
+		if !page.HasNext {
+			break
+		}
+		opts.Cursor, opts.Snapshot = page.NextCursor, page.Snapshot
+	}
+}
+
+// Replies to a comment and up-votes its post, telling failures apart by status.
+func ExampleCommentsService_Create() {
+	ctx := context.Background()
+	c, err := client.New("https://api.example.com",
+		client.WithCredentials("helper-bot@example.com", "bot-password"),
+		client.WithRetries(5, time.Second),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	postID := uuid.FromStringOrNil("6f1c2a52-2d1e-4b8a-9a53-0c9f8f7d3e21")
+	parentID := uuid.FromStringOrNil("0b8e4f3c-91a7-4c2e-8d6b-5a1f2e3d4c5b")
+
+	_, err = c.Comments.Create(ctx, &commentModels.CreateCommentRequest{
+		PostId:          postID,
+		ParentCommentId: &parentID,
+		Text:            "Thanks, that fixed it for me too!",
+	})
+	var apiErr *client.APIError
+	switch {
+	case client.IsNotFound(err):
+		fmt.Println("the post is gone")
+		return
+	case errors.As(err, &apiErr):
+		log.Fatalf("comment rejected: %s (%s)", apiErr.Message, apiErr.Code)
+	case err != nil:
+		log.Fatal(err)
+	}
+
+	if _, err := c.Votes.Set(ctx, postID, client.VoteUp); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// Completes a sign-in for an account protected by a second factor.
+func ExampleAuthService_Login() {
+	ctx := context.Background()
+	c, err := client.New("https://api.example.com")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	session, err := c.Auth.Login(ctx, "alice@example.com", "password")
+	if errors.Is(err, client.ErrMFARequired) {
+		session, err = c.Auth.VerifyMFA(ctx, session.ChallengeToken, "123456")
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("signed in as", session.User.SocialName)
+}
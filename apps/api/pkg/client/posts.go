@@ -0,0 +1,127 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// PostsService creates, reads and lists posts
+type PostsService struct {
+	client *Client
+}
+
+// CreatedPost identifies a new post. DuplicateWarning is set when the deployment lets
+// posts resembling an existing one through with a warning.
+type CreatedPost struct {
+	ObjectId         string                 `json:"objectId"`
+	DuplicateWarning *models.DuplicateMatch `json:"duplicateWarning,omitempty"`
+}
+
+// ListPostsOptions filters and pages a post listing. Zero values are left to the
+// server's defaults: 20 posts, newest first or the deployment's feed order.
+type ListPostsOptions struct {
+	Cursor    string    // nextCursor of the previous page
+	Snapshot  string    // snapshot of the first page, keeps later pages stable
+	Limit     int       // 1 to 100
+	Owner     uuid.UUID // Only posts of this user
+	PostType  int       // Only posts of this type
+	Tags      []string  // Only posts with these tags
+	Search    string    // Full-text search term
+	SortBy    string    // createdDate, score, viewCount or lastUpdated
+	SortOrder string    // asc or desc
+}
+
+func (o *ListPostsOptions) values() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if o.Cursor != "" {
+		query.Set("cursor", o.Cursor)
+	}
+	if o.Snapshot != "" {
+		query.Set("snapshot", o.Snapshot)
+	}
+	if o.Limit > 0 {
+		query.Set("limit", strconv.Itoa(o.Limit))
+	}
+	if o.Owner != uuid.Nil {
+		query.Set("owner", o.Owner.String())
+	}
+	if o.PostType != 0 {
+		query.Set("postType", strconv.Itoa(o.PostType))
+	}
+	if len(o.Tags) > 0 {
+		query.Set("tags", strings.Join(o.Tags, ","))
+	}
+	if o.Search != "" {
+		query.Set("search", o.Search)
+	}
+	if o.SortBy != "" {
+		query.Set("sortBy", o.SortBy)
+	}
+	if o.SortOrder != "" {
+		query.Set("sortOrder", o.SortOrder)
+	}
+	return query
+}
+
+// Create publishes a post as the signed-in user.
+// Endpoint: POST /posts/
+func (s *PostsService) Create(ctx context.Context, req *models.CreatePostRequest) (*CreatedPost, error) {
+	var created CreatedPost
+	if err := s.client.do(ctx, request{method: http.MethodPost, path: "/posts/", body: req, auth: true}, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Get reads a post.
+// Endpoint: GET /posts/:postId
+func (s *PostsService) Get(ctx context.Context, postID uuid.UUID) (*models.PostResponse, error) {
+	var post models.PostResponse
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/posts/" + postID.String(), auth: true}, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// GetByURLKey reads the post published under a URL key.
+// Endpoint: GET /posts/urlkey/:urlkey
+func (s *PostsService) GetByURLKey(ctx context.Context, urlKey string) (*models.PostResponse, error) {
+	var post models.PostResponse
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/posts/urlkey/" + url.PathEscape(urlKey), auth: true}, &post); err != nil {
+		return nil, err
+	}
+	return &post, nil
+}
+
+// List pages through posts. Pass the returned NextCursor and Snapshot back in opts
+// for the next page while HasNext is set.
+// Endpoint: GET /posts/
+func (s *PostsService) List(ctx context.Context, opts *ListPostsOptions) (*models.PostsListResponse, error) {
+	var list models.PostsListResponse
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/posts/", query: opts.values(), auth: true}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// Update changes the fields set in req of the post req.ObjectId.
+// Endpoint: PUT /posts/
+func (s *PostsService) Update(ctx context.Context, req *models.UpdatePostRequest) error {
+	return s.client.do(ctx, request{method: http.MethodPut, path: "/posts/", body: req, auth: true}, nil)
+}
+
+// Delete removes a post of the signed-in user.
+// Endpoint: DELETE /posts/:postId
+func (s *PostsService) Delete(ctx context.Context, postID uuid.UUID) error {
+	return s.client.do(ctx, request{method: http.MethodDelete, path: "/posts/" + postID.String(), auth: true}, nil)
+}
@@ -0,0 +1,83 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// ProfilesService reads and updates user profiles
+type ProfilesService struct {
+	client *Client
+}
+
+// Me reads the signed-in user's profile.
+// Endpoint: GET /profile/my
+func (s *ProfilesService) Me(ctx context.Context) (*models.Profile, error) {
+	var profile models.Profile
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/profile/my", auth: true}, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// Get reads the profile of a user.
+// Endpoint: GET /profile/id/:userId
+func (s *ProfilesService) Get(ctx context.Context, userID uuid.UUID) (*models.Profile, error) {
+	var profile models.Profile
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/profile/id/" + userID.String(), auth: true}, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetBySocialName reads the profile with a social name (the @handle).
+// Endpoint: GET /profile/social/:name
+func (s *ProfilesService) GetBySocialName(ctx context.Context, socialName string) (*models.Profile, error) {
+	var profile models.Profile
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/profile/social/" + url.PathEscape(socialName), auth: true}, &profile); err != nil {
+		return nil, err
+	}
+	return &profile, nil
+}
+
+// GetMany reads the profiles of several users; unknown users are left out.
+// Endpoint: POST /profile/ids
+func (s *ProfilesService) GetMany(ctx context.Context, userIDs []uuid.UUID) ([]models.Profile, error) {
+	ids := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		ids = append(ids, id.String())
+	}
+
+	var profiles []models.Profile
+	if err := s.client.do(ctx, request{method: http.MethodPost, path: "/profile/ids", body: ids, auth: true}, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Search finds profiles by name; limit is capped at 20 by the server.
+// Endpoint: GET /profile/search?q=
+func (s *ProfilesService) Search(ctx context.Context, query string, limit int) ([]models.Profile, error) {
+	values := url.Values{"q": {query}}
+	if limit > 0 {
+		values.Set("limit", strconv.Itoa(limit))
+	}
+
+	var profiles []models.Profile
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/profile/search", query: values, auth: true}, &profiles); err != nil {
+		return nil, err
+	}
+	return profiles, nil
+}
+
+// Update changes the fields set in req on the signed-in user's profile.
+// Endpoint: PUT /profile/
+func (s *ProfilesService) Update(ctx context.Context, req *models.UpdateProfileRequest) error {
+	return s.client.do(ctx, request{method: http.MethodPut, path: "/profile/", body: req, auth: true}, nil)
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/votes/models"
+)
+
+// Vote values accepted by VotesService.Set
+const (
+	VoteUp     = "up"
+	VoteDown   = "down"
+	VoteRemove = "remove"
+)
+
+// Voter types accepted by VotesService.ListVoters; down and all are for the post author
+const (
+	VotersUp   = "up"
+	VotersDown = "down"
+	VotersAll  = "all"
+)
+
+// VotesService votes on posts
+type VotesService struct {
+	client *Client
+}
+
+type setVoteRequest struct {
+	Vote string `json:"vote"`
+}
+
+type myVotesResponse struct {
+	Votes map[string]int `json:"votes"`
+}
+
+// Set sets the signed-in user's vote on a post to VoteUp, VoteDown or VoteRemove.
+// Setting the vote the user already has changes nothing, so Set is safe to repeat.
+// Endpoint: POST /posts/:postId/vote
+func (s *VotesService) Set(ctx context.Context, postID uuid.UUID, vote string) (*models.VoteChange, error) {
+	var change models.VoteChange
+	path := "/posts/" + postID.String() + "/vote"
+	if err := s.client.do(ctx, request{method: http.MethodPost, path: path, body: setVoteRequest{Vote: vote}, auth: true}, &change); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+// Mine returns the signed-in user's vote on each post: models.VoteTypeNone, VoteTypeUp
+// or VoteTypeDown.
+// Endpoint: GET /votes/mine?postIds=
+func (s *VotesService) Mine(ctx context.Context, postIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	ids := make([]string, 0, len(postIDs))
+	for _, id := range postIDs {
+		ids = append(ids, id.String())
+	}
+
+	var out myVotesResponse
+	query := url.Values{"postIds": {strings.Join(ids, ",")}}
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: "/votes/mine", query: query, auth: true}, &out); err != nil {
+		return nil, err
+	}
+
+	votes := make(map[uuid.UUID]int, len(out.Votes))
+	for key, voteType := range out.Votes {
+		if id, err := uuid.FromString(key); err == nil {
+			votes[id] = voteType
+		}
+	}
+	return votes, nil
+}
+
+// ListVoters pages through the voters of a post. An empty voterType lists up votes.
+// Endpoint: GET /posts/:postId/voters
+func (s *VotesService) ListVoters(ctx context.Context, postID uuid.UUID, voterType, cursor string, limit int) (*models.VoterListResponse, error) {
+	query := url.Values{}
+	if voterType != "" {
+		query.Set("type", voterType)
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+	if limit > 0 {
+		query.Set("limit", strconv.Itoa(limit))
+	}
+
+	var voters models.VoterListResponse
+	path := "/posts/" + postID.String() + "/voters"
+	if err := s.client.do(ctx, request{method: http.MethodGet, path: path, query: query, auth: true}, &voters); err != nil {
+		return nil, err
+	}
+	return &voters, nil
+}
@@ -1,6 +1,7 @@
 package posts
 
 import (
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/posts/internal/adapters"
 	"github.com/qolzam/telar/apps/api/posts/services"
 	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
@@ -11,8 +12,19 @@ func NewDirectCallStatsUpdater(service services.PostService) sharedInterfaces.Po
 	return adapters.NewDirectCallStatsUpdater(service)
 }
 
-// NewGrpcStatsUpdater creates a gRPC client adapter for microservices deployment mode
-func NewGrpcStatsUpdater(targetAddress string) (sharedInterfaces.PostStatsUpdater, error) {
-	return adapters.NewGrpcStatsUpdater(targetAddress)
+// NewGrpcStatsUpdater creates a gRPC client adapter for microservices deployment mode;
+// cfg sets up TLS, call authentication, keepalive and retries
+func NewGrpcStatsUpdater(targetAddress string, cfg platformconfig.GRPCConfig) (sharedInterfaces.PostStatsUpdater, error) {
+	return adapters.NewGrpcStatsUpdater(targetAddress, cfg)
 }
 
+// NewContentModerator lets the moderation module look up and hide reported posts
+func NewContentModerator(service services.PostService) sharedInterfaces.ContentModerator {
+	return adapters.NewContentModerator(service)
+}
+
+// NewCommunityPoster lets the communities module count community posts and publish
+// approved conversation starters
+func NewCommunityPoster(service services.PostService) sharedInterfaces.CommunityPoster {
+	return adapters.NewCommunityPoster(service)
+}
@@ -2,6 +2,8 @@ package common
 
 import (
     "crypto/rand"
+    "crypto/sha256"
+    "encoding/hex"
     "fmt"
     "strings"
     "time"
@@ -63,3 +65,15 @@ func SplitAndTrim(s string, sep string) []string {
     return out
 }
 
+
+// NormalizeContent lowercases text and collapses all whitespace runs to a single space
+// so trivially reformatted copies of a post compare equal
+func NormalizeContent(body string) string {
+    return strings.Join(strings.Fields(strings.ToLower(body)), " ")
+}
+
+// ContentHash returns the hex SHA-256 of the normalized body, used for exact duplicate detection
+func ContentHash(body string) string {
+    sum := sha256.Sum256([]byte(NormalizeContent(body)))
+    return hex.EncodeToString(sum[:])
+}
@@ -9,3 +9,30 @@ func TestGeneratePostURLKey_Basic(t *testing.T) {
 }
 
 
+
+func TestContentHash_IgnoresCaseAndWhitespace(t *testing.T) {
+    a := ContentHash("Buy   cheap\nwatches NOW")
+    b := ContentHash("  buy cheap watches now ")
+    if a != b { t.Fatalf("expected equal hashes, got %s and %s", a, b) }
+    if a == ContentHash("buy cheap watches later") { t.Fatalf("different content produced same hash") }
+    if len(a) != 64 { t.Fatalf("unexpected hash length: %d", len(a)) }
+}
+
+func TestBodyPreview(t *testing.T) {
+    if p, cut := BodyPreview("short post", 280); p != "short post" || cut { t.Fatalf("unexpected preview %q %v", p, cut) }
+
+    p, cut := BodyPreview("First paragraph.\n\nSecond paragraph.", 280)
+    if p != "First paragraph." || !cut { t.Fatalf("expected first paragraph, got %q %v", p, cut) }
+
+    p, cut = BodyPreview("one two three four", 9)
+    if p != "one two" || !cut { t.Fatalf("expected word boundary cut, got %q", p) }
+
+    p, _ = BodyPreview("see [the docs](https://example.com/docs) now", 20)
+    if p != "see" { t.Fatalf("expected link to be dropped, got %q", p) }
+
+    p, _ = BodyPreview("```\ncode\n\nmore code\n```\n\nafter", 280)
+    if p != "```\ncode\n\nmore code\n```" { t.Fatalf("expected fenced block kept whole, got %q", p) }
+
+    p, _ = BodyPreview("```\nline one\nline two\n```", 10)
+    if p != "```\nline\n```" { t.Fatalf("expected fence to be closed, got %q", p) }
+}
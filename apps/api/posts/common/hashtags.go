@@ -0,0 +1,73 @@
+package common
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTagLength is the longest tag kept, in characters
+	MaxTagLength = 50
+
+	// MaxPostTags caps a post's tags once hashtags from the body are added
+	MaxPostTags = 20
+)
+
+// hashtagPattern matches #word where the word has at least one letter, so "#1" and
+// "#2024" are not tags. The leading group keeps URL fragments (example.com/#top) and
+// HTML entities (&#39;) out.
+var hashtagPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_&/#])#([\p{L}\p{N}_]*\p{L}[\p{L}\p{N}_]*)`)
+
+// NormalizeTag lowercases a tag and drops leading '#' and surrounding whitespace.
+// It returns "" for tags that are empty or longer than MaxTagLength.
+func NormalizeTag(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(tag), "#")))
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return ""
+	}
+	return tag
+}
+
+// ExtractHashtags returns the normalized hashtags in body, in order of first use
+func ExtractHashtags(body string) []string {
+	var tags []string
+	seen := map[string]bool{}
+	for _, match := range hashtagPattern.FindAllStringSubmatch(body, -1) {
+		if tag := NormalizeTag(match[1]); tag != "" && !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// MergeTags normalizes tags, appends the hashtags of body and drops duplicates, keeping
+// at most MaxPostTags. Explicit tags come first so they survive the cap.
+func MergeTags(tags []string, body string) []string {
+	merged := []string{}
+	seen := map[string]bool{}
+	for _, tag := range append(append([]string{}, tags...), ExtractHashtags(body)...) {
+		if tag = NormalizeTag(tag); tag != "" && !seen[tag] && len(merged) < MaxPostTags {
+			seen[tag] = true
+			merged = append(merged, tag)
+		}
+	}
+	return merged
+}
+
+// RetagBody recomputes a post's tags after an edit. Hashtags that only came from the
+// old body are dropped unless they are still in the new body.
+func RetagBody(tags []string, oldBody, newBody string) []string {
+	fromBody := map[string]bool{}
+	for _, tag := range ExtractHashtags(oldBody) {
+		fromBody[tag] = true
+	}
+	kept := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !fromBody[NormalizeTag(tag)] {
+			kept = append(kept, tag)
+		}
+	}
+	return MergeTags(kept, newBody)
+}
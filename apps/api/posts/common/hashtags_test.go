@@ -0,0 +1,42 @@
+package common
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExtractHashtags(t *testing.T) {
+	got := ExtractHashtags("Shipping #GoLang generics (#golang, #Café_2024) at #1! See example.com/#top &#39; #")
+	want := []string{"golang", "café_2024"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if tags := ExtractHashtags("#" + strings.Repeat("a", MaxTagLength+1)); tags != nil {
+		t.Fatalf("expected over-long tag to be dropped, got %v", tags)
+	}
+}
+
+func TestMergeTags(t *testing.T) {
+	got := MergeTags([]string{" News ", "#go", ""}, "more #news and #release")
+	want := []string{"news", "go", "release"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	body := ""
+	for i := 0; i < MaxPostTags+5; i++ {
+		body += " #tag" + strings.Repeat("x", i)
+	}
+	if got := MergeTags([]string{"first"}, body); len(got) != MaxPostTags || got[0] != "first" {
+		t.Fatalf("expected %d tags led by the explicit one, got %v", MaxPostTags, got)
+	}
+}
+
+func TestRetagBody(t *testing.T) {
+	got := RetagBody([]string{"news", "draft", "launch"}, "#draft notes for the #launch", "final #launch post")
+	want := []string{"news", "launch"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
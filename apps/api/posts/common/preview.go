@@ -0,0 +1,88 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+)
+
+// DefaultPreviewLength is the bodyPreview length used when none is configured
+const DefaultPreviewLength = 280
+
+// BodyPreview returns a Markdown-aware preview of a post body: the first paragraph,
+// cut at a word boundary to at most maxRunes characters. A cut never splits a link,
+// an inline code span or leaves a code fence open. The bool reports whether anything
+// of the body was left out.
+func BodyPreview(body string, maxRunes int) (string, bool) {
+	body = strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	if body == "" {
+		return "", false
+	}
+	if maxRunes <= 0 {
+		maxRunes = DefaultPreviewLength
+	}
+
+	preview := firstParagraph(body)
+	truncated := len(preview) < len(body)
+
+	runes := []rune(preview)
+	if len(runes) > maxRunes {
+		preview = cutAtWord(runes, maxRunes)
+		preview = avoidOpenSpans(preview)
+		truncated = true
+	}
+
+	if strings.Count(preview, "```")%2 == 1 {
+		preview += "\n```"
+	}
+	return preview, truncated
+}
+
+// firstParagraph returns the text before the first blank line outside a code fence
+func firstParagraph(body string) string {
+	inFence := false
+	offset := 0
+	for _, line := range strings.SplitAfter(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if trimmed == "" && !inFence && offset > 0 {
+			return strings.TrimRightFunc(body[:offset], unicode.IsSpace)
+		}
+		offset += len(line)
+	}
+	return body
+}
+
+// cutAtWord cuts runes to at most max, backing up to the last whitespace when there is one
+func cutAtWord(runes []rune, max int) string {
+	cut := max
+	for i := max; i > 0; i-- {
+		if unicode.IsSpace(runes[i]) {
+			cut = i
+			break
+		}
+	}
+	return strings.TrimRightFunc(string(runes[:cut]), unicode.IsSpace)
+}
+
+// avoidOpenSpans drops a trailing link or inline code span that the cut left unfinished
+func avoidOpenSpans(s string) string {
+	if open := strings.LastIndex(s, "["); open >= 0 {
+		rest := s[open:]
+		closing := strings.Index(rest, "]")
+		unfinishedURL := closing >= 0 && strings.HasPrefix(rest[closing:], "](") && !strings.Contains(rest[closing:], ")")
+		if closing < 0 || unfinishedURL {
+			if open > 0 && s[open-1] == '!' {
+				open--
+			}
+			s = strings.TrimRightFunc(s[:open], unicode.IsSpace)
+		}
+	}
+
+	inline := strings.Count(s, "`") - 3*strings.Count(s, "```")
+	if inline%2 == 1 {
+		s = strings.TrimRightFunc(s[:strings.LastIndex(s, "`")], unicode.IsSpace)
+	}
+	return s
+}
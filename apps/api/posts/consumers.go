@@ -0,0 +1,108 @@
+package posts
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
+	"github.com/qolzam/telar/apps/api/internal/outbox"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/internal/adapters"
+	"github.com/qolzam/telar/apps/api/posts/services"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Outbox consumer groups of the posts service
+const (
+	// CommentCountsConsumer keeps post comment counts
+	CommentCountsConsumer = "posts.comment-counts"
+	// KnowledgeIngestionConsumer keeps the AI engine knowledge base in step with posts
+	KnowledgeIngestionConsumer = "posts.knowledge-ingestion"
+	// PublicPagesConsumer drops the cached anonymous pages of changed posts
+	PublicPagesConsumer = "posts.public-pages"
+)
+
+// SubscribeCommentCounts keeps post comment counts in step with the comment events the
+// comments service writes to the outbox. Each event is applied once, in the transaction
+// recording it as consumed.
+func SubscribeCommentCounts(ctx context.Context, broker outbox.Broker, repo outbox.Repository, service services.PostService) error {
+	stats := adapters.NewDirectCallStatsUpdater(service)
+	eventTypes := []string{sharedInterfaces.OutboxCommentCreated, sharedInterfaces.OutboxCommentDeleted}
+	return outbox.Subscribe(ctx, broker, repo, CommentCountsConsumer, eventTypes, func(txCtx context.Context, msg outbox.Message) error {
+		postID, delta := commentCountDelta(msg)
+		if delta == 0 {
+			return nil
+		}
+		err := stats.IncrementCommentCountForService(txCtx, postID, delta)
+		if errors.Is(err, postsErrors.ErrPostNotFound) {
+			// Comments of a post deleted since have nothing to count
+			return nil
+		}
+		return err
+	})
+}
+
+// commentCountDelta returns the post a comment event counts on and by how much.
+// Replies are not counted; undecodable events are logged and skipped.
+func commentCountDelta(msg outbox.Message) (uuid.UUID, int) {
+	var err error
+	switch msg.Type {
+	case sharedInterfaces.OutboxCommentCreated:
+		var event sharedInterfaces.CommentCreatedEvent
+		if err = msg.Decode(&event); err == nil && event.ParentCommentId == nil {
+			return event.PostId, 1
+		}
+	case sharedInterfaces.OutboxCommentDeleted:
+		var event sharedInterfaces.CommentDeletedEvent
+		if err = msg.Decode(&event); err == nil {
+			return event.PostId, -event.RootCount
+		}
+	}
+	if err != nil {
+		log.Error("Skipping outbox event: %v", err)
+	}
+	return uuid.Nil, 0
+}
+
+// SubscribeKnowledgeIngestion keeps the AI engine knowledge base in step with posts: each
+// post event re-ingests the post as it is now, or removes it once it is deleted, hidden
+// or no longer public. Failed calls to the AI engine are retried by the broker.
+func SubscribeKnowledgeIngestion(ctx context.Context, broker outbox.Broker, repo outbox.Repository, service services.PostService) error {
+	eventTypes := []string{sharedInterfaces.OutboxPostCreated, sharedInterfaces.OutboxPostUpdated, sharedInterfaces.OutboxPostDeleted}
+	return outbox.Subscribe(ctx, broker, repo, KnowledgeIngestionConsumer, eventTypes, func(txCtx context.Context, msg outbox.Message) error {
+		var event sharedInterfaces.PostChangedEvent
+		if err := msg.Decode(&event); err != nil {
+			log.Error("Skipping outbox event: %v", err)
+			return nil
+		}
+		return service.SyncKnowledge(txCtx, event.PostId)
+	})
+}
+
+// SubscribePublicPages drops the cached anonymous pages (the post page and its tag feeds)
+// of posts that were written, voted on or commented on. Invalidation is idempotent, so
+// events are handled without recording them as consumed; with an in-memory HTTP cache
+// every instance consumes them to drop its own copies.
+func SubscribePublicPages(ctx context.Context, broker outbox.Broker, service services.PostService) error {
+	eventTypes := []string{sharedInterfaces.OutboxPostCreated, sharedInterfaces.OutboxPostUpdated, sharedInterfaces.OutboxPostDeleted, sharedInterfaces.OutboxPostStatsChanged}
+	return broker.Subscribe(ctx, httpcache.ConsumerGroup(PublicPagesConsumer), eventTypes, func(ctx context.Context, msg outbox.Message) error {
+		var event sharedInterfaces.PostChangedEvent
+		if err := msg.Decode(&event); err != nil {
+			log.Error("Skipping outbox event: %v", err)
+			return nil
+		}
+		return service.InvalidatePublicPages(ctx, event)
+	})
+}
+
+// SubscribeProfilePropagation copies the names and avatars users change to into the posts
+// they own or co-author, as announced through the outbox
+func SubscribeProfilePropagation(ctx context.Context, broker outbox.Broker, repo outbox.Repository, progress propagation.Repository, maxAttempts int, service services.PostService) error {
+	return propagation.Subscribe(ctx, broker, repo, progress, propagation.TargetPosts, maxAttempts, func(txCtx context.Context, event sharedInterfaces.ProfileUpdatedEvent) error {
+		return service.UpdatePostProfile(txCtx, event.UserId, event.FullName, event.Avatar)
+	})
+}
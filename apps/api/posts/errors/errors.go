@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
 )
 
 // Post service specific errors
@@ -15,7 +16,28 @@ var (
 	ErrInvalidPostData       = errors.New("invalid post data")
 	ErrPostAlreadyExists     = errors.New("post already exists")
 	ErrPostOwnershipRequired = errors.New("post ownership required")
+	ErrDuplicateContent      = errors.New("duplicate content")
+	ErrContentRejected       = errors.New("content rejected")
 	ErrInvalidUserContext    = errors.New("invalid user context")
+	ErrCoauthorExists        = errors.New("user is already a co-author or invited")
+	ErrCoauthorNotFound      = errors.New("co-author invitation not found")
+	ErrTooManyCoauthors      = errors.New("post has reached the co-author limit")
+	ErrAnonymousDisabled     = errors.New("anonymous posting is disabled")
+	ErrAnonymousPost         = errors.New("not available on anonymous posts")
+	ErrNotQuestion           = errors.New("post is not a question")
+	ErrInvalidAnswer         = errors.New("comment is not an answer on this question")
+	ErrSupportersUnavailable = errors.New("supporter-only posts need a supporter tier")
+	ErrLiveThreadsDisabled   = errors.New("live threads are disabled")
+	ErrLiveThreadNotFound    = errors.New("live thread not found")
+	ErrLiveThreadEnded       = errors.New("live thread has ended")
+	ErrRulesNotAcknowledged  = errors.New("community rules not acknowledged")
+	ErrCommunitiesUnavailable = errors.New("communities are not available")
+	ErrCommunityMembershipRequired = errors.New("community membership required")
+	ErrCommunityArchived     = errors.New("community is archived")
+	ErrSharingDisabled       = errors.New("sharing is disabled for this post")
+	ErrAlreadyInCommunity    = errors.New("post is already in the community")
+	ErrNotInCommunity        = errors.New("post is not in the community")
+	ErrSemanticSearchUnavailable = errors.New("semantic search needs the AI engine")
 	
 	// Request and validation errors
 	ErrInvalidRequest        = errors.New("invalid request")
@@ -53,6 +75,24 @@ func (e *PostError) Unwrap() error {
 	return e.Cause
 }
 
+// DuplicateContentError reports that a new post duplicates an existing one
+type DuplicateContentError struct {
+	DuplicateOf string
+	Similarity  float64
+	Reason      string // "exact" or "similar"
+}
+
+func (e *DuplicateContentError) Error() string {
+	if e.DuplicateOf == "" {
+		return fmt.Sprintf("%s: looks like a duplicate (%s, similarity %.2f)", ErrDuplicateContent, e.Reason, e.Similarity)
+	}
+	return fmt.Sprintf("%s: looks like a duplicate of %s (%s, similarity %.2f)", ErrDuplicateContent, e.DuplicateOf, e.Reason, e.Similarity)
+}
+
+func (e *DuplicateContentError) Unwrap() error {
+	return ErrDuplicateContent
+}
+
 // NewPostError creates a new PostError
 func NewPostError(code, message string, cause error) *PostError {
 	return &PostError{
@@ -70,6 +110,27 @@ const (
 	CodeValidationFailed    = "VALIDATION_FAILED"
 	CodeDatabaseError       = "DATABASE_ERROR"
 	CodeInternalError       = "INTERNAL_ERROR"
+	CodeDuplicateContent    = "DUPLICATE_CONTENT"
+	CodeContentRejected     = "CONTENT_REJECTED"
+	CodeCoauthorExists      = "COAUTHOR_EXISTS"
+	CodeCoauthorNotFound    = "COAUTHOR_NOT_FOUND"
+	CodeTooManyCoauthors    = "TOO_MANY_COAUTHORS"
+	CodeAnonymousDisabled   = "ANONYMOUS_POSTING_DISABLED"
+	CodeAnonymousPost       = "ANONYMOUS_POST"
+	CodeNotQuestion         = "NOT_A_QUESTION"
+	CodeInvalidAnswer       = "INVALID_ANSWER"
+	CodeSupportersUnavailable = "SUPPORTERS_UNAVAILABLE"
+	CodeLiveThreadsDisabled = "LIVE_THREADS_DISABLED"
+	CodeLiveThreadNotFound  = "LIVE_THREAD_NOT_FOUND"
+	CodeLiveThreadEnded     = "LIVE_THREAD_ENDED"
+	CodeRulesNotAcknowledged = "RULES_NOT_ACKNOWLEDGED"
+	CodeCommunitiesUnavailable = "COMMUNITIES_UNAVAILABLE"
+	CodeCommunityMembershipRequired = "COMMUNITY_MEMBERSHIP_REQUIRED"
+	CodeCommunityArchived   = "COMMUNITY_ARCHIVED"
+	CodeSharingDisabled     = "SHARING_DISABLED"
+	CodeAlreadyInCommunity  = "ALREADY_IN_COMMUNITY"
+	CodeNotInCommunity      = "NOT_IN_COMMUNITY"
+	CodeSemanticSearchUnavailable = "SEMANTIC_SEARCH_UNAVAILABLE"
 	
 	// Request and validation codes
 	CodeInvalidRequest      = "INVALID_REQUEST"
@@ -121,6 +182,143 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 			Message: "Post already exists",
 			Details: err.Error(),
 		})
+	case errors.Is(err, ErrDuplicateContent):
+		details := fiber.Map{}
+		var dupErr *DuplicateContentError
+		if errors.As(err, &dupErr) {
+			details = fiber.Map{
+				"similarity": dupErr.Similarity,
+				"reason":     dupErr.Reason,
+			}
+			if dupErr.DuplicateOf != "" {
+				details["duplicateOf"] = dupErr.DuplicateOf
+			}
+		}
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeDuplicateContent,
+			Message: "This post looks like a duplicate of a recent post",
+			Details: details,
+		})
+	case errors.Is(err, ErrContentRejected):
+		return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponse{
+			Code:    CodeContentRejected,
+			Message: "This post was rejected by a community policy",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrCoauthorExists):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeCoauthorExists,
+			Message: "User is already a co-author or has a pending invitation",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrCoauthorNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{
+			Code:    CodeCoauthorNotFound,
+			Message: "Co-author invitation not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrTooManyCoauthors):
+		return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponse{
+			Code:    CodeTooManyCoauthors,
+			Message: "This post already has the maximum number of co-authors",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrAnonymousDisabled):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
+			Code:    CodeAnonymousDisabled,
+			Message: "Anonymous posting is not enabled on this community",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrAnonymousPost):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeAnonymousPost,
+			Message: "This action would reveal the author of an anonymous post",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrSupportersUnavailable):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeSupportersUnavailable,
+			Message: "Offer a supporter tier before publishing supporter-only posts",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrSemanticSearchUnavailable):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{
+			Code:    CodeSemanticSearchUnavailable,
+			Message: "Semantic search is not available on this community",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrLiveThreadsDisabled):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{
+			Code:    CodeLiveThreadsDisabled,
+			Message: "Live threads are not enabled on this community",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrLiveThreadNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{
+			Code:    CodeLiveThreadNotFound,
+			Message: "This post has no live thread",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrLiveThreadEnded):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeLiveThreadEnded,
+			Message: "The live thread has already ended",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrRulesNotAcknowledged):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
+			Code:    CodeRulesNotAcknowledged,
+			Message: "Acknowledge the community rules before you post",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrCommunitiesUnavailable):
+		return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponse{
+			Code:    CodeCommunitiesUnavailable,
+			Message: "Posts cannot be made in communities on this server",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrCommunityMembershipRequired):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
+			Code:    CodeCommunityMembershipRequired,
+			Message: "Join the community before you post in it",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrCommunityArchived):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeCommunityArchived,
+			Message: "The community is archived; nothing new can be posted in it",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrSharingDisabled):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
+			Code:    CodeSharingDisabled,
+			Message: "The author turned sharing off for this post",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrAlreadyInCommunity):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeAlreadyInCommunity,
+			Message: "The post is already in this community",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrNotInCommunity):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{
+			Code:    CodeNotInCommunity,
+			Message: "The post is not in this community",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrNotQuestion):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{
+			Code:    CodeNotQuestion,
+			Message: "Only question posts can have an accepted answer",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrInvalidAnswer):
+		return c.Status(http.StatusUnprocessableEntity).JSON(ErrorResponse{
+			Code:    CodeInvalidAnswer,
+			Message: "The accepted answer must be a top-level comment on the question",
+			Details: err.Error(),
+		})
 	case errors.Is(err, ErrPostOwnershipRequired):
 		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
 			Code:    CodePermissionDenied,
@@ -151,6 +349,15 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 			Message: "Service temporarily unavailable",
 			Details: err.Error(),
 		})
+	case errors.Is(err, ErrValidationFailed) || hasCode(err, CodeValidationFailed):
+		if fields, ok := validate.Fields(err); ok {
+			return c.Status(http.StatusBadRequest).JSON(ErrorResponse{
+				Code:    CodeValidationFailed,
+				Message: "Validation failed",
+				Details: fiber.Map{"fields": fields},
+			})
+		}
+		return HandleValidationError(c, "Validation failed", err.Error())
 	default:
 		// Generic internal server error
 		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{
@@ -161,6 +368,12 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 	}
 }
 
+// hasCode reports whether err wraps a PostError with the given code
+func hasCode(err error, code string) bool {
+	var postErr *PostError
+	return errors.As(err, &postErr) && postErr.Code == code
+}
+
 // HandleValidationError handles validation errors with 400 Bad Request
 func HandleValidationError(c *fiber.Ctx, message string, details ...string) error {
 	response := ErrorResponse{
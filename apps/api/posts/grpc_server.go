@@ -2,12 +2,16 @@ package posts
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/gofrs/uuid"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
 	"github.com/qolzam/telar/apps/api/posts/services"
 	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 	pb "github.com/qolzam/telar/protos/gen/go/postspb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // grpcServer implements the PostsServiceServer interface generated from proto.
@@ -25,12 +29,15 @@ func NewGrpcServer(svc services.PostService) pb.PostsServiceServer {
 func (s *grpcServer) IncrementCommentCount(ctx context.Context, req *pb.IncrementCommentCountRequest) (*pb.IncrementCommentCountResponse, error) {
 	postID, err := uuid.FromString(req.PostId)
 	if err != nil {
-		return nil, err
+		return nil, status.Errorf(codes.InvalidArgument, "invalid post ID: %v", err)
 	}
 
 	// The service's IncrementCommentCountForService method implements PostStatsUpdater interface
 	if updater, ok := s.service.(sharedInterfaces.PostStatsUpdater); ok {
 		err := updater.IncrementCommentCountForService(ctx, postID, int(req.Delta))
+		if errors.Is(err, postsErrors.ErrPostNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
 		if err != nil {
 			return &pb.IncrementCommentCountResponse{Success: false}, err
 		}
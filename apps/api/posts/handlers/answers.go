@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// AcceptAnswer handles PUT /posts/:postId/accepted-answer; only the question's author may accept
+func (h *PostHandler) AcceptAnswer(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	var req models.AcceptAnswerRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+	if req.CommentId == uuid.Nil {
+		return errors.HandleMissingFieldError(c, "commentId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	if err := h.postService.AcceptAnswer(c.Context(), postID, req.CommentId, &user); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// ClearAcceptedAnswer handles DELETE /posts/:postId/accepted-answer
+func (h *PostHandler) ClearAcceptedAnswer(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	if err := h.postService.ClearAcceptedAnswer(c.Context(), postID, &user); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// InviteCoauthor handles POST /posts/:postId/coauthors; only the owner may invite
+func (h *PostHandler) InviteCoauthor(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	var req models.InviteCoauthorRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+	if req.UserId == uuid.Nil {
+		return errors.HandleMissingFieldError(c, "userId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	invite, err := h.postService.InviteCoauthor(c.Context(), postID, &req, &user)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(invite)
+}
+
+// ListCoauthors handles GET /posts/:postId/coauthors
+func (h *PostHandler) ListCoauthors(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	coauthors, err := h.postService.ListCoauthors(c.Context(), postID, &user)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"coauthors": coauthors})
+}
+
+// AcceptCoauthorInvite handles POST /posts/:postId/coauthors/accept
+func (h *PostHandler) AcceptCoauthorInvite(c *fiber.Ctx) error {
+	return h.respondToCoauthorInvite(c, h.postService.AcceptCoauthorInvite)
+}
+
+// DeclineCoauthorInvite handles POST /posts/:postId/coauthors/decline
+func (h *PostHandler) DeclineCoauthorInvite(c *fiber.Ctx) error {
+	return h.respondToCoauthorInvite(c, h.postService.DeclineCoauthorInvite)
+}
+
+func (h *PostHandler) respondToCoauthorInvite(c *fiber.Ctx, respond func(context.Context, uuid.UUID, *types.UserContext) error) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	if err := respond(c.Context(), postID, &user); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// RemoveCoauthor handles DELETE /posts/:postId/coauthors/:userId. The owner removes a
+// co-author or withdraws an invitation; a co-author passes their own id to step down.
+func (h *PostHandler) RemoveCoauthor(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+	coauthorID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "userId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	if err := h.postService.RemoveCoauthor(c.Context(), postID, coauthorID, &user); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// ListCoauthorInvites handles GET /posts/coauthors/invitations, the caller's pending invitations
+func (h *PostHandler) ListCoauthorInvites(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	invitations, err := h.postService.ListCoauthorInvites(c.Context(), &user)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"invitations": invitations})
+}
+
+// withCoauthors fills the author block of a single post
+func (h *PostHandler) withCoauthors(ctx context.Context, response models.PostResponse) models.PostResponse {
+	posts := []models.PostResponse{response}
+	h.postService.AttachCoauthors(ctx, posts)
+	return posts[0]
+}
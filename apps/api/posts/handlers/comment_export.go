@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/services"
+)
+
+// commentExportTimeout bounds how long one export may read and stream comments
+const commentExportTimeout = 10 * time.Minute
+
+// commentExportAudit is the audit record logged for every comments export
+type commentExportAudit struct {
+	Timestamp time.Time `json:"timestamp"`
+	PostID    string    `json:"postId"`
+	UserID    string    `json:"userId"`
+	IPAddress string    `json:"ipAddress"`
+	Format    string    `json:"format"`
+	Comments  int       `json:"comments"`
+	Success   bool      `json:"success"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// ExportComments handles GET /posts/:postId/comments/export?format=csv|json (default csv):
+// every live comment and reply on the caller's post, oldest first, as a download. Rows
+// are streamed as they are read, so large threads are never held in memory.
+func (h *PostHandler) ExportComments(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	format := c.Query("format", models.CommentExportCSV)
+	if format != models.CommentExportCSV && format != models.CommentExportJSON {
+		return errors.HandleInvalidFieldError(c, "format", "must be csv or json")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	// The stream writer runs after this handler returns, when c may be reused
+	audit := commentExportAudit{
+		PostID:    postID.String(),
+		UserID:    user.UserID.String(),
+		IPAddress: c.IP(),
+		Format:    format,
+	}
+
+	// Refused exports are audited too, e.g. someone probing posts they do not own
+	export, err := h.postService.ExportComments(c.Context(), postID, &user)
+	if err != nil {
+		audit.Error = err.Error()
+		logCommentExport(audit)
+		return errors.HandleServiceError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="comments-%s.%s"`, postID, format))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	if format == models.CommentExportCSV {
+		c.Set(fiber.HeaderContentType, "text/csv; charset=utf-8")
+	} else {
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSONCharsetUTF8)
+	}
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), commentExportTimeout)
+		defer cancel()
+
+		var err error
+		if format == models.CommentExportCSV {
+			audit.Comments, err = writeCommentsCSV(ctx, w, export)
+		} else {
+			audit.Comments, err = writeCommentsJSON(ctx, w, export)
+		}
+		audit.Success = err == nil
+		if err != nil {
+			// The status is already sent; a truncated file is all the client can get
+			audit.Error = err.Error()
+		}
+		logCommentExport(audit)
+	})
+	return nil
+}
+
+// writeCommentsCSV streams the export as CSV with a header row and returns how many comments it wrote
+func writeCommentsCSV(ctx context.Context, w *bufio.Writer, export services.CommentExport) (int, error) {
+	out := csv.NewWriter(w)
+	if err := out.Write(models.CommentExportColumns); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	err := export(ctx, func(rows []models.CommentExportRow) error {
+		for _, row := range rows {
+			if err := out.Write(row.CSVRecord()); err != nil {
+				return err
+			}
+		}
+		written += len(rows)
+		out.Flush()
+		if err := out.Error(); err != nil {
+			return err
+		}
+		return w.Flush()
+	})
+	if err != nil {
+		return written, err
+	}
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return written, err
+	}
+	return written, w.Flush()
+}
+
+// writeCommentsJSON streams the export as a JSON array and returns how many comments it wrote
+func writeCommentsJSON(ctx context.Context, w *bufio.Writer, export services.CommentExport) (int, error) {
+	if err := w.WriteByte('['); err != nil {
+		return 0, err
+	}
+
+	written := 0
+	err := export(ctx, func(rows []models.CommentExportRow) error {
+		for _, row := range rows {
+			if written > 0 {
+				if err := w.WriteByte(','); err != nil {
+					return err
+				}
+			}
+			data, err := json.Marshal(row)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(data); err != nil {
+				return err
+			}
+			written++
+		}
+		return w.Flush()
+	})
+	if err != nil {
+		return written, err
+	}
+	if err := w.WriteByte(']'); err != nil {
+		return written, err
+	}
+	return written, w.Flush()
+}
+
+// logCommentExport writes the export's audit record as structured JSON
+func logCommentExport(audit commentExportAudit) {
+	audit.Timestamp = time.Now().UTC()
+	data, err := json.Marshal(audit)
+	if err != nil {
+		log.Error("Failed to serialize comments export audit: %v", err)
+		return
+	}
+	log.Info("[AUDIT] comments_export: %s", string(data))
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// ScheduleLiveThread handles PUT /posts/:postId/live; only the post's owner may schedule
+func (h *PostHandler) ScheduleLiveThread(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	var req models.ScheduleLiveThreadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	thread, err := h.postService.ScheduleLiveThread(c.Context(), postID, &req, &user)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(thread)
+}
+
+// GetLiveThread handles GET /posts/:postId/live
+func (h *PostHandler) GetLiveThread(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	thread, err := h.postService.GetLiveThread(c.Context(), postID, &user)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(thread)
+}
+
+// CancelLiveThread handles DELETE /posts/:postId/live; threads that have ended stay
+func (h *PostHandler) CancelLiveThread(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	if err := h.postService.CancelLiveThread(c.Context(), postID, &user); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
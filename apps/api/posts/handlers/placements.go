@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/errors"
+)
+
+// ListCommunityPlacements handles GET /posts/:postId/communities, the communities the post shows in
+func (h *PostHandler) ListCommunityPlacements(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	placements, err := h.postService.ListCommunityPlacements(c.Context(), postID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"placements": placements})
+}
+
+// ShareToCommunity handles POST /posts/:postId/communities/:communityId; the owner shares
+// their post into another community they belong to
+func (h *PostHandler) ShareToCommunity(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "communityId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	placement, err := h.postService.ShareToCommunity(c.Context(), postID, communityID, &user)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(placement)
+}
+
+// RemoveFromCommunity handles DELETE /posts/:postId/communities/:communityId. The owner
+// removes their post from any community, the community's moderators from theirs only.
+func (h *PostHandler) RemoveFromCommunity(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "communityId")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	if err := h.postService.RemoveFromCommunity(c.Context(), postID, communityID, &user); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
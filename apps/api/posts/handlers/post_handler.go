@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -12,12 +13,14 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/fieldset"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	"github.com/qolzam/telar/apps/api/posts/errors"
 	"github.com/qolzam/telar/apps/api/posts/models"
 	"github.com/qolzam/telar/apps/api/posts/services"
 	"github.com/qolzam/telar/apps/api/posts/validation"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // PostHandler handles all post-related HTTP requests
@@ -26,6 +29,8 @@ type PostHandler struct {
 	jwtConfig   platformconfig.JWTConfig
 	hmacConfig  platformconfig.HMACConfig
 	TestWg      *sync.WaitGroup
+
+	delegations sharedInterfaces.DelegationAuthorizer // nil disables posting as another account
 }
 
 // NewPostHandler creates a new PostHandler with injected dependencies
@@ -37,6 +42,12 @@ func NewPostHandler(postService services.PostService, jwtConfig platformconfig.J
 	}
 }
 
+// WithDelegation enables actingAs on post creation, checked against delegation grants
+func (h *PostHandler) WithDelegation(delegations sharedInterfaces.DelegationAuthorizer) *PostHandler {
+	h.delegations = delegations
+	return h
+}
+
 // CreatePost handles post creation
 func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 	var req models.CreatePostRequest
@@ -55,16 +66,39 @@ func (h *PostHandler) CreatePost(c *fiber.Ctx) error {
 		return errors.HandleUserContextError(c, "Invalid user context")
 	}
 
+	// Posting as another account requires an active post:create grant from it
+	var ctx context.Context = c.Context()
+	if req.ActingAs != nil && *req.ActingAs != user.UserID {
+		if h.delegations == nil {
+			return errors.HandlePermissionError(c, "Delegated posting is not available")
+		}
+		identity, err := h.delegations.AuthorizeActingAs(ctx, *req.ActingAs, user.UserID, sharedInterfaces.DelegationScopePostCreate)
+		if err != nil {
+			return errors.HandleServiceError(c, fmt.Errorf("%w: %v", errors.ErrServiceUnavailable, err))
+		}
+		if identity == nil {
+			return errors.HandlePermissionError(c, "No active delegation grant to post as this account")
+		}
+		// The post belongs to the account acted as; the service audits the true author
+		ctx = services.WithActingAs(ctx, identity)
+	}
+
 	// Create post
-	result, err := h.postService.CreatePost(c.Context(), &req, &user)
+	result, err := h.postService.CreatePost(ctx, &req, &user)
 	if err != nil {
 		return errors.HandleServiceError(c, err)
 	}
 
-	// Return 201 Created for successful resource creation (REST API best practice)
-	return c.Status(http.StatusCreated).JSON(fiber.Map{
+	response := fiber.Map{
 		"objectId": result.ObjectId.String(),
-	})
+	}
+	// Duplicate policy "warn" lets the post through but tells the client what it resembles
+	if result.DuplicateWarning != nil {
+		response["duplicateWarning"] = result.DuplicateWarning
+	}
+
+	// Return 201 Created for successful resource creation (REST API best practice)
+	return c.Status(http.StatusCreated).JSON(response)
 }
 
 // GetPost handles retrieving a single post
@@ -80,6 +114,11 @@ func (h *PostHandler) GetPost(c *fiber.Ctx) error {
 	}
 	// Constraint middleware already validated UUID format, but we validate again defensively
 
+	includeComments, ok := parseIncludeComments(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "includeComments", "must be preview")
+	}
+
 	post, err := h.postService.GetPost(c.Context(), postID)
 	if err != nil {
 		return errors.HandleServiceError(c, err)
@@ -108,7 +147,7 @@ func (h *PostHandler) GetPost(c *fiber.Ctx) error {
 		reqCtx = context.WithValue(reqCtx, types.UserCtxName, user)
 	}
 	response := h.postService.ConvertPostToResponse(reqCtx, post)
-	return c.JSON(response)
+	return c.JSON(h.withCommentPreview(reqCtx, includeComments, h.withSupporterAccess(reqCtx, h.withCoauthors(reqCtx, response))))
 }
 
 // GetPostByURLKey handles retrieving a post by URL key
@@ -117,6 +156,10 @@ func (h *PostHandler) GetPostByURLKey(c *fiber.Ctx) error {
 	if urlKey == "" {
 		return errors.HandleInvalidRequestError(c, "URL key is required")
 	}
+	includeComments, ok := parseIncludeComments(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "includeComments", "must be preview")
+	}
 
 	post, err := h.postService.GetPostByURLKey(c.Context(), urlKey)
 	if err != nil {
@@ -146,27 +189,86 @@ func (h *PostHandler) GetPostByURLKey(c *fiber.Ctx) error {
 		reqCtx = context.WithValue(reqCtx, types.UserCtxName, user)
 	}
 	response := h.postService.ConvertPostToResponse(reqCtx, post)
-	return c.JSON(response)
+	return c.JSON(h.withCommentPreview(reqCtx, includeComments, h.withSupporterAccess(reqCtx, h.withCoauthors(reqCtx, response))))
 }
 
-// SearchPosts handles lightweight post search for autocomplete
+// SearchPosts handles full-text post search. q takes web search syntax ("quoted phrases",
+// OR, -exclusions); tags (comma-separated) keeps posts carrying any of them; cursor pages
+// through results ranked best match first.
 func (h *PostHandler) SearchPosts(c *fiber.Ctx) error {
-	query := c.Query("q")
-	if strings.TrimSpace(query) == "" {
-		return c.JSON([]models.PostResponse{})
+	query := models.PostSearchQuery{Query: strings.TrimSpace(c.Query("q"))}
+	if query.Query == "" {
+		return c.JSON(models.PostSearchResponse{Results: []models.PostSearchResult{}})
 	}
 
-	limit := 5
 	if limitStr := c.Query("limit"); limitStr != "" {
 		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
-			if parsed > 20 {
-				limit = 20
-			} else {
-				limit = parsed
+			query.Limit = parsed
+		}
+	}
+
+	if tagsStr := c.Query("tags"); tagsStr != "" {
+		for _, tag := range strings.Split(tagsStr, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				query.Tags = append(query.Tags, tag)
 			}
 		}
 	}
 
+	if cursor := c.Query("cursor"); cursor != "" {
+		after, err := models.DecodeSearchCursor(cursor)
+		if err != nil {
+			return errors.HandleInvalidFieldError(c, "cursor", err.Error())
+		}
+		query.After = after
+	}
+
+	reqCtx := c.UserContext()
+	if reqCtx == nil {
+		reqCtx = context.Background()
+	}
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok {
+		reqCtx = context.WithValue(reqCtx, types.UserCtxName, user)
+	}
+
+	results, err := h.postService.FullTextSearch(reqCtx, query)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.JSON(results)
+}
+
+// SemanticSearch finds posts by meaning through the AI engine, optionally narrowed to a
+// community, an author and a since/until range
+func (h *PostHandler) SemanticSearch(c *fiber.Ctx) error {
+	query := models.SemanticSearchQuery{
+		Query:       strings.TrimSpace(c.Query("q")),
+		CommunityID: strings.TrimSpace(c.Query("communityId")),
+	}
+	if query.Query == "" {
+		return errors.HandleInvalidRequestError(c, "Search query 'q' is required")
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			query.Limit = parsed
+		}
+	}
+
+	if authorStr := c.Query("authorId"); authorStr != "" {
+		authorID, err := uuid.FromString(authorStr)
+		if err != nil {
+			return errors.HandleInvalidFieldError(c, "authorId", "must be a UUID")
+		}
+		query.AuthorID = &authorID
+	}
+
+	var err error
+	if query.Range, err = parseTimeRange(c); err != nil {
+		return errors.HandleInvalidFieldError(c, "range", err.Error())
+	}
+
 	reqCtx := c.UserContext()
 	if reqCtx == nil {
 		reqCtx = context.Background()
@@ -175,7 +277,7 @@ func (h *PostHandler) SearchPosts(c *fiber.Ctx) error {
 		reqCtx = context.WithValue(reqCtx, types.UserCtxName, user)
 	}
 
-	results, err := h.postService.SearchPostsLite(reqCtx, query, limit)
+	results, err := h.postService.SemanticSearch(reqCtx, query)
 	if err != nil {
 		return errors.HandleServiceError(c, err)
 	}
@@ -186,16 +288,19 @@ func (h *PostHandler) SearchPosts(c *fiber.Ctx) error {
 // QueryPosts handles post querying with filters (now using cursor-based pagination)
 func (h *PostHandler) QueryPosts(c *fiber.Ctx) error {
 	// Parse query parameters for cursor-based pagination
+	// Without a sort field the service applies the deployment's default feed algorithm
 	filter := &models.PostQueryFilter{
-		SortField:     "createdDate", // Default sort field
-		SortDirection: "desc",        // Default sort direction
-		Limit:         20,            // Default limit (matches spec default)
+		SortDirection: "desc", // Default sort direction
+		Limit:         20,     // Default limit (matches spec default)
 	}
 
 	// Parse cursor parameters
 	if cursor := c.Query("cursor"); cursor != "" {
 		filter.Cursor = cursor
 	}
+	if snapshot := c.Query("snapshot"); snapshot != "" {
+		filter.Snapshot = snapshot
+	}
 
 	// Parse limit
 	if limitStr := c.Query("limit"); limitStr != "" {
@@ -226,6 +331,12 @@ func (h *PostHandler) QueryPosts(c *fiber.Ctx) error {
 		}
 	}
 
+	if communityStr := c.Query("communityId"); communityStr != "" {
+		if communityID, err := uuid.FromString(communityStr); err == nil {
+			filter.CommunityId = &communityID
+		}
+	}
+
 	// Parse search term
 	if search := c.Query("search"); search != "" {
 		filter.Search = search
@@ -267,6 +378,40 @@ func (h *PostHandler) QueryPosts(c *fiber.Ctx) error {
 		filter.Tags = tags
 	}
 
+	fields, err := parsePostFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+	filter.Fields = fields.Fields()
+
+	truncate, ok := parseTruncateBodies(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "truncate", "must be true or false")
+	}
+	filter.TruncateBodies = truncate
+
+	answered, ok := parseAnswered(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "answered", "must be true or false")
+	}
+	filter.Answered = answered
+
+	if filter.Range, err = parseTimeRange(c); err != nil {
+		return errors.HandleInvalidFieldError(c, "range", err.Error())
+	}
+
+	followedBy, ok := parseFollowedBy(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "feed", "must be following")
+	}
+	filter.FollowedBy = followedBy
+
+	notInteractedBy, ok := parseHideInteracted(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "hideInteracted", "must be true or false")
+	}
+	filter.NotInteractedBy = notInteractedBy
+
 	// Validate filter
 	if err := validation.ValidatePostQueryFilter(filter); err != nil {
 		return errors.HandleValidationError(c, err.Error())
@@ -283,16 +428,16 @@ func (h *PostHandler) QueryPosts(c *fiber.Ctx) error {
 		return errors.HandleServiceError(c, err)
 	}
 
-	return c.JSON(result)
+	return respondPostsList(c, result, fields)
 }
 
 // QueryPostsWithCursor handles post querying with cursor-based pagination
 func (h *PostHandler) QueryPostsWithCursor(c *fiber.Ctx) error {
 	// Parse query parameters
+	// Without a sort field the service applies the deployment's default feed algorithm
 	filter := &models.PostQueryFilter{
-		SortField:     "createdDate", // Default sort field
-		SortDirection: "desc",        // Default sort direction
-		Limit:         20,            // Default limit
+		SortDirection: "desc", // Default sort direction
+		Limit:         20,     // Default limit
 	}
 
 	// Parse cursor parameters
@@ -305,6 +450,9 @@ func (h *PostHandler) QueryPostsWithCursor(c *fiber.Ctx) error {
 	if beforeCursor := c.Query("before"); beforeCursor != "" {
 		filter.BeforeCursor = beforeCursor
 	}
+	if snapshot := c.Query("snapshot"); snapshot != "" {
+		filter.Snapshot = snapshot
+	}
 
 	// Parse limit
 	if limitStr := c.Query("limit"); limitStr != "" {
@@ -334,6 +482,12 @@ func (h *PostHandler) QueryPostsWithCursor(c *fiber.Ctx) error {
 		}
 	}
 
+	if communityStr := c.Query("communityId"); communityStr != "" {
+		if communityID, err := uuid.FromString(communityStr); err == nil {
+			filter.CommunityId = &communityID
+		}
+	}
+
 	// Parse tags
 	if tagsStr := c.Query("tags"); tagsStr != "" {
 		filter.Tags = []string{tagsStr}
@@ -347,6 +501,40 @@ func (h *PostHandler) QueryPostsWithCursor(c *fiber.Ctx) error {
 		}
 	}
 
+	fields, err := parsePostFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+	filter.Fields = fields.Fields()
+
+	truncate, ok := parseTruncateBodies(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "truncate", "must be true or false")
+	}
+	filter.TruncateBodies = truncate
+
+	answered, ok := parseAnswered(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "answered", "must be true or false")
+	}
+	filter.Answered = answered
+
+	if filter.Range, err = parseTimeRange(c); err != nil {
+		return errors.HandleInvalidFieldError(c, "range", err.Error())
+	}
+
+	followedBy, ok := parseFollowedBy(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "feed", "must be following")
+	}
+	filter.FollowedBy = followedBy
+
+	notInteractedBy, ok := parseHideInteracted(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "hideInteracted", "must be true or false")
+	}
+	filter.NotInteractedBy = notInteractedBy
+
 	// Validate filter
 	if err := validation.ValidatePostQueryFilter(filter); err != nil {
 		return errors.HandleValidationError(c, err.Error())
@@ -364,7 +552,7 @@ func (h *PostHandler) QueryPostsWithCursor(c *fiber.Ctx) error {
 		return errors.HandleServiceError(c, err)
 	}
 
-	return c.JSON(result)
+	return respondPostsList(c, result, fields)
 }
 
 // SearchPostsWithCursor handles post searching with cursor-based pagination
@@ -421,6 +609,12 @@ func (h *PostHandler) SearchPostsWithCursor(c *fiber.Ctx) error {
 		}
 	}
 
+	if communityStr := c.Query("communityId"); communityStr != "" {
+		if communityID, err := uuid.FromString(communityStr); err == nil {
+			filter.CommunityId = &communityID
+		}
+	}
+
 	// Parse tags
 	if tagsStr := c.Query("tags"); tagsStr != "" {
 		filter.Tags = []string{tagsStr}
@@ -434,6 +628,33 @@ func (h *PostHandler) SearchPostsWithCursor(c *fiber.Ctx) error {
 		}
 	}
 
+	fields, err := parsePostFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+	filter.Fields = fields.Fields()
+
+	truncate, ok := parseTruncateBodies(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "truncate", "must be true or false")
+	}
+	filter.TruncateBodies = truncate
+
+	answered, ok := parseAnswered(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "answered", "must be true or false")
+	}
+	filter.Answered = answered
+
+	// reusable=true keeps only posts whose license allows republishing
+	if raw := c.Query("reusable"); raw != "" {
+		reusable, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.HandleInvalidFieldError(c, "reusable", "must be true or false")
+		}
+		filter.ReusableOnly = reusable
+	}
+
 	// Validate filter
 	if err := validation.ValidatePostQueryFilter(filter); err != nil {
 		return errors.HandleValidationError(c, err.Error())
@@ -450,7 +671,7 @@ func (h *PostHandler) SearchPostsWithCursor(c *fiber.Ctx) error {
 		return errors.HandleServiceError(c, err)
 	}
 
-	return c.JSON(result)
+	return respondPostsList(c, result, fields)
 }
 
 // GetCursorInfo handles getting cursor information for a specific post
@@ -769,8 +990,273 @@ func (h *PostHandler) IncrementViewCountByURLKey(c *fiber.Ctx) error {
 	return c.Status(http.StatusOK).JSON(fiber.Map{"message": "view count incremented"})
 }
 
+// maxUnreadFeeds caps how many feeds one unread-count request may ask about
+const maxUnreadFeeds = 20
+
+// GetUnreadCounts returns the caller's unread post counts for the feeds in the comma separated "feeds" query
+func (h *PostHandler) GetUnreadCounts(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	var feeds []string
+	for _, feed := range strings.Split(c.Query("feeds", models.FeedKeyHome), ",") {
+		feed = strings.TrimSpace(feed)
+		if feed == "" {
+			continue
+		}
+		if _, err := models.ParseFeedKey(feed); err != nil {
+			return errors.HandleInvalidFieldError(c, "feeds", err.Error())
+		}
+		feeds = append(feeds, feed)
+	}
+	if len(feeds) == 0 {
+		return errors.HandleMissingFieldError(c, "feeds")
+	}
+	if len(feeds) > maxUnreadFeeds {
+		return errors.HandleInvalidFieldError(c, "feeds", "too many feeds requested")
+	}
+
+	counts, err := h.postService.GetUnreadCounts(c.Context(), user.UserID, feeds)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.JSON(fiber.Map{"feeds": counts})
+}
+
+// MarkFeedRead moves the caller's read marker for a feed
+func (h *PostHandler) MarkFeedRead(c *fiber.Ctx) error {
+	var req models.MarkFeedReadRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+
+	if _, err := models.ParseFeedKey(req.Feed); err != nil {
+		return errors.HandleInvalidFieldError(c, "feed", err.Error())
+	}
+	if req.LastReadDate < 0 {
+		return errors.HandleInvalidFieldError(c, "lastReadDate", "must not be negative")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	if err := h.postService.MarkFeedRead(c.Context(), user.UserID, &req); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// GetPublicTagFeed serves the anonymous tag feed: public, non-deleted posts with the tag,
+// newest first, optionally within since/until. Responses are identical for every caller
+// so they can be HTTP cached.
+func (h *PostHandler) GetPublicTagFeed(c *fiber.Ctx) error {
+	tag := strings.TrimSpace(c.Params("tag"))
+	if tag == "" {
+		return errors.HandleInvalidRequestError(c, "Tag is required")
+	}
+
+	notDeleted := false
+	filter := &models.PostQueryFilter{
+		Tags:          []string{tag},
+		Deleted:       &notDeleted,
+		PublicOnly:    true,
+		Cursor:        c.Query("cursor"),
+		Snapshot:      c.Query("snapshot"),
+		SortField:     "createdDate",
+		SortDirection: "desc",
+		Limit:         20,
+	}
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil && limit > 0 && limit <= 100 {
+			filter.Limit = limit
+		}
+	}
+
+	fields, err := parsePostFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+	filter.Fields = fields.Fields()
+
+	truncate, ok := parseTruncateBodies(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "truncate", "must be true or false")
+	}
+	filter.TruncateBodies = truncate
+
+	if filter.Range, err = parseTimeRange(c); err != nil {
+		return errors.HandleInvalidFieldError(c, "range", err.Error())
+	}
+
+	if err := validation.ValidatePostQueryFilter(filter); err != nil {
+		return errors.HandleValidationError(c, err.Error())
+	}
+
+	result, err := h.postService.QueryPostsWithCursor(c.Context(), filter)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return respondPostsList(c, result, fields)
+}
+
+// GetPublicPostByURLKey serves a public post to anonymous readers, e.g. for SEO pages.
+// Posts that are deleted or not public are reported as not found.
+func (h *PostHandler) GetPublicPostByURLKey(c *fiber.Ctx) error {
+	urlKey := c.Params("urlkey")
+	if urlKey == "" {
+		return errors.HandleInvalidRequestError(c, "URL key is required")
+	}
+	includeComments, ok := parseIncludeComments(c)
+	if !ok {
+		return errors.HandleInvalidFieldError(c, "includeComments", "must be preview")
+	}
+
+	post, err := h.postService.GetPostByURLKey(c.Context(), urlKey)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	if post.Deleted || post.Permission != "Public" {
+		return errors.HandleServiceError(c, errors.ErrPostNotFound)
+	}
+
+	response := h.postService.ConvertPostToResponse(c.Context(), post)
+	return c.JSON(h.withCommentPreview(c.Context(), includeComments, h.withSupporterAccess(c.Context(), h.withCoauthors(c.Context(), response))))
+}
+
 // Helper methods
 
+// postResponseFields are the field names accepted by ?fields= on post list endpoints
+var postResponseFields = fieldset.JSONFields(models.PostResponse{})
+
+// parsePostFields reads the sparse fieldset from the "fields" query parameter
+func parsePostFields(c *fiber.Ctx) (fieldset.Set, error) {
+	return fieldset.Parse(c.Query("fields"), postResponseFields)
+}
+
+// parseTruncateBodies reads the optional "truncate" query parameter. true drops the full
+// body of truncated feed items, false keeps it; absent leaves the server default.
+func parseTruncateBodies(c *fiber.Ctx) (*bool, bool) {
+	raw := c.Query("truncate")
+	if raw == "" {
+		return nil, true
+	}
+	truncate, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, false
+	}
+	return &truncate, true
+}
+
+// parseAnswered reads the optional "answered" query parameter, which narrows results to
+// question posts with (true) or without (false) an accepted answer
+func parseAnswered(c *fiber.Ctx) (*bool, bool) {
+	raw := c.Query("answered")
+	if raw == "" {
+		return nil, true
+	}
+	answered, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, false
+	}
+	return &answered, true
+}
+
+// parseTimeRange reads the optional since/until query parameters (Unix milliseconds or
+// RFC 3339) or a named period (today, week, month) in place of since
+func parseTimeRange(c *fiber.Ctx) (models.TimeRange, error) {
+	return models.ParseTimeRange(c.Query("period"), c.Query("since"), c.Query("until"), time.Now())
+}
+
+// parseFollowedBy reads the optional "feed" query parameter. "following" narrows the
+// listing to posts by users the caller follows; the follower is the caller.
+func parseFollowedBy(c *fiber.Ctx) (*uuid.UUID, bool) {
+	switch c.Query("feed") {
+	case "":
+		return nil, true
+	case models.FeedKeyFollowing:
+		user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+		if !ok {
+			return nil, false
+		}
+		return &user.UserID, true
+	default:
+		return nil, false
+	}
+}
+
+// parseHideInteracted reads the optional "hideInteracted" query parameter. true drops
+// posts the caller has voted on, commented on or bookmarked.
+func parseHideInteracted(c *fiber.Ctx) (*uuid.UUID, bool) {
+	raw := c.Query("hideInteracted")
+	if raw == "" {
+		return nil, true
+	}
+	hide, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, false
+	}
+	if !hide {
+		return nil, true
+	}
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return nil, false
+	}
+	return &user.UserID, true
+}
+
+// parseIncludeComments reads the optional "includeComments" query parameter; the only
+// accepted value is "preview", which attaches the latest comments to the post.
+func parseIncludeComments(c *fiber.Ctx) (string, bool) {
+	raw := c.Query("includeComments")
+	if raw == "" || raw == models.IncludeCommentsPreview {
+		return raw, true
+	}
+	return "", false
+}
+
+// withCommentPreview attaches latestComments to a single post when they were requested
+func (h *PostHandler) withCommentPreview(ctx context.Context, includeComments string, response models.PostResponse) models.PostResponse {
+	if includeComments != models.IncludeCommentsPreview {
+		return response
+	}
+	posts := []models.PostResponse{response}
+	h.postService.AttachLatestComments(ctx, posts)
+	return posts[0]
+}
+
+// withSupporterAccess locks a single supporter-only post the viewer may not read
+func (h *PostHandler) withSupporterAccess(ctx context.Context, response models.PostResponse) models.PostResponse {
+	posts := []models.PostResponse{response}
+	h.postService.ApplySupporterAccess(ctx, posts)
+	return posts[0]
+}
+
+// projectedPostsListResponse is a PostsListResponse whose posts carry only the requested fields
+type projectedPostsListResponse struct {
+	*models.PostsListResponse
+	Posts []map[string]interface{} `json:"posts"`
+}
+
+// respondPostsList writes a post list, reduced to the sparse fieldset when one was requested.
+// objectId is always kept so clients can key and re-fetch items.
+func respondPostsList(c *fiber.Ctx, result *models.PostsListResponse, fields fieldset.Set) error {
+	if fields == nil {
+		return c.JSON(result)
+	}
+	posts, err := fieldset.Project(result.Posts, fields, "objectId")
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(projectedPostsListResponse{PostsListResponse: result, Posts: posts})
+}
+
 func (h *PostHandler) convertPostToResponse(post *models.Post) models.PostResponse {
 	return models.PostResponse{
 		ObjectId:         post.ObjectId.String(),
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
@@ -15,6 +16,8 @@ import (
 	"github.com/qolzam/telar/apps/api/internal/types"
 	"github.com/qolzam/telar/apps/api/posts/handlers"
 	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/services"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // createTestConfig creates a test configuration for handler tests
@@ -48,6 +51,15 @@ type MockPostService struct {
 	setFieldFunc                     func(ctx context.Context, postID uuid.UUID, field string, value interface{}) error
 	updateByOwnerFunc                func(ctx context.Context, postID uuid.UUID, userID uuid.UUID, updates map[string]interface{}) error
 	updateProfileForOwnerFunc        func(ctx context.Context, userID uuid.UUID, displayName, avatar string) error
+	getUnreadCountsFunc              func(ctx context.Context, userID uuid.UUID, feedKeys []string) ([]models.FeedUnreadCount, error)
+	markFeedReadFunc                 func(ctx context.Context, userID uuid.UUID, req *models.MarkFeedReadRequest) error
+	queryPostsWithCursorFunc         func(ctx context.Context, filter *models.PostQueryFilter) (*models.PostsListResponse, error)
+	attachLatestCommentsFunc         func(ctx context.Context, posts []models.PostResponse)
+	fullTextSearchFunc               func(ctx context.Context, query models.PostSearchQuery) (*models.PostSearchResponse, error)
+	semanticSearchFunc               func(ctx context.Context, query models.SemanticSearchQuery) (*models.SemanticSearchResponse, error)
+	getTagPostsFunc                  func(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) (*models.PostsListResponse, error)
+	trendingTagsFunc                 func(ctx context.Context, window string) (*models.TrendingTagsResponse, error)
+	exportCommentsFunc               func(ctx context.Context, postID uuid.UUID, user *types.UserContext) (services.CommentExport, error)
 
 	// Mock state for testing
 	posts        map[string]*models.Post
@@ -91,8 +103,32 @@ func (m *MockPostService) SearchPosts(ctx context.Context, query string, filter
 	return nil, nil
 }
 
-func (m *MockPostService) SearchPostsLite(ctx context.Context, query string, limit int) ([]models.PostResponse, error) {
-	return nil, nil
+func (m *MockPostService) FullTextSearch(ctx context.Context, query models.PostSearchQuery) (*models.PostSearchResponse, error) {
+	if m.fullTextSearchFunc != nil {
+		return m.fullTextSearchFunc(ctx, query)
+	}
+	return &models.PostSearchResponse{Results: []models.PostSearchResult{}}, nil
+}
+
+func (m *MockPostService) SemanticSearch(ctx context.Context, query models.SemanticSearchQuery) (*models.SemanticSearchResponse, error) {
+	if m.semanticSearchFunc != nil {
+		return m.semanticSearchFunc(ctx, query)
+	}
+	return &models.SemanticSearchResponse{Results: []models.SemanticSearchResult{}}, nil
+}
+
+func (m *MockPostService) GetTagPosts(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) (*models.PostsListResponse, error) {
+	if m.getTagPostsFunc != nil {
+		return m.getTagPostsFunc(ctx, tag, within, after, limit)
+	}
+	return &models.PostsListResponse{Posts: []models.PostResponse{}}, nil
+}
+
+func (m *MockPostService) TrendingTags(ctx context.Context, window string) (*models.TrendingTagsResponse, error) {
+	if m.trendingTagsFunc != nil {
+		return m.trendingTagsFunc(ctx, window)
+	}
+	return &models.TrendingTagsResponse{Window: window, Tags: []models.TrendingTag{}}, nil
 }
 
 func (m *MockPostService) GetPostsByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error) {
@@ -141,6 +177,10 @@ func (m *MockPostService) IncrementViewCount(ctx context.Context, postID uuid.UU
 	return nil
 }
 
+func (m *MockPostService) IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error {
+	return nil
+}
+
 func (m *MockPostService) DeletePost(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
 	if m.deletePostFunc != nil {
 		return m.deletePostFunc(ctx, postID, user)
@@ -416,6 +456,25 @@ func (m *MockPostService) DeleteByOwner(ctx context.Context, owner uuid.UUID, ob
 	return nil
 }
 
+func (m *MockPostService) PostOwner(ctx context.Context, postID uuid.UUID) (uuid.UUID, error) {
+	if m.posts != nil {
+		if post, exists := m.posts[postID.String()]; exists {
+			return post.OwnerUserId, nil
+		}
+	}
+	return uuid.Nil, errors.New("post not found")
+}
+
+func (m *MockPostService) HidePost(ctx context.Context, postID uuid.UUID) error {
+	if m.shouldFail {
+		return m.failureError
+	}
+	if m.posts != nil {
+		delete(m.posts, postID.String())
+	}
+	return nil
+}
+
 func (m *MockPostService) IncrementField(ctx context.Context, postID uuid.UUID, field string, delta int) error {
 	// Check for configured failure
 	if m.shouldFail {
@@ -592,12 +651,29 @@ func (m *MockPostService) UpdateProfileForOwner(ctx context.Context, userID uuid
 	return nil
 }
 
+func (m *MockPostService) GetUnreadCounts(ctx context.Context, userID uuid.UUID, feedKeys []string) ([]models.FeedUnreadCount, error) {
+	if m.getUnreadCountsFunc != nil {
+		return m.getUnreadCountsFunc(ctx, userID, feedKeys)
+	}
+	return []models.FeedUnreadCount{}, nil
+}
+
+func (m *MockPostService) MarkFeedRead(ctx context.Context, userID uuid.UUID, req *models.MarkFeedReadRequest) error {
+	if m.markFeedReadFunc != nil {
+		return m.markFeedReadFunc(ctx, userID, req)
+	}
+	return nil
+}
+
 // New cursor-based pagination methods
 func (m *MockPostService) QueryPostsWithCursor(ctx context.Context, filter *models.PostQueryFilter) (*models.PostsListResponse, error) {
 	// Check for configured failure
 	if m.shouldFail {
 		return nil, m.failureError
 	}
+	if m.queryPostsWithCursorFunc != nil {
+		return m.queryPostsWithCursorFunc(ctx, filter)
+	}
 
 	// Simple mock implementation - return empty results for testing
 	return &models.PostsListResponse{
@@ -639,6 +715,125 @@ func (m *MockPostService) GetCursorInfo(ctx context.Context, postID uuid.UUID, s
 	}, nil
 }
 
+func (m *MockPostService) AttachLatestComments(ctx context.Context, posts []models.PostResponse) {
+	if m.attachLatestCommentsFunc != nil {
+		m.attachLatestCommentsFunc(ctx, posts)
+	}
+}
+
+func (m *MockPostService) ArchiveExpiredPosts(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+func (m *MockPostService) RefreshTrendingTags(ctx context.Context) error {
+	return nil
+}
+
+func (m *MockPostService) CloseEndedLiveThreads(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (m *MockPostService) AttachCoauthors(ctx context.Context, posts []models.PostResponse) {}
+
+func (m *MockPostService) ApplySupporterAccess(ctx context.Context, posts []models.PostResponse) {}
+
+func (m *MockPostService) SetSupporterChecker(checker sharedInterfaces.SupporterChecker) {}
+
+func (m *MockPostService) SetRulesChecker(checker sharedInterfaces.RulesChecker) {}
+
+func (m *MockPostService) SetCommunityChecker(checker sharedInterfaces.CommunityChecker) {}
+
+func (m *MockPostService) SetDelegationAuditor(auditor sharedInterfaces.DelegationAuditor) {}
+
+func (m *MockPostService) SetFeedDefaults(provider sharedInterfaces.FeedDefaultsProvider) {}
+
+func (m *MockPostService) SetKeywordMuter(muter sharedInterfaces.KeywordMuter) {}
+
+func (m *MockPostService) SetMentionRecorder(recorder sharedInterfaces.MentionRecorder) {}
+
+func (m *MockPostService) SetImageVariantResolver(resolver sharedInterfaces.ImageVariantResolver) {}
+
+func (m *MockPostService) SetContentScreener(screener sharedInterfaces.ContentScreener) {}
+
+func (m *MockPostService) SetEventOutbox(outbox sharedInterfaces.EventOutbox) {}
+
+func (m *MockPostService) SyncKnowledge(ctx context.Context, postID uuid.UUID) error {
+	return nil
+}
+
+func (m *MockPostService) InvalidatePublicPages(ctx context.Context, event sharedInterfaces.PostChangedEvent) error {
+	return nil
+}
+
+func (m *MockPostService) SetContentLimits(provider sharedInterfaces.ContentLimitsProvider) {}
+
+func (m *MockPostService) ApplyImageVariants(ctx context.Context, ownerID uuid.UUID, key string, variants sharedInterfaces.PostImageVariants) (int, error) {
+	return 0, nil
+}
+
+func (m *MockPostService) InviteCoauthor(ctx context.Context, postID uuid.UUID, req *models.InviteCoauthorRequest, user *types.UserContext) (*models.PostCoauthor, error) {
+	return &models.PostCoauthor{PostId: postID, UserId: req.UserId, InvitedBy: user.UserID, Status: models.CoauthorStatusPending}, nil
+}
+
+func (m *MockPostService) AcceptCoauthorInvite(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	return nil
+}
+
+func (m *MockPostService) DeclineCoauthorInvite(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	return nil
+}
+
+func (m *MockPostService) RemoveCoauthor(ctx context.Context, postID, coauthorID uuid.UUID, user *types.UserContext) error {
+	return nil
+}
+
+func (m *MockPostService) ListCoauthors(ctx context.Context, postID uuid.UUID, user *types.UserContext) ([]models.PostCoauthor, error) {
+	return []models.PostCoauthor{}, nil
+}
+
+func (m *MockPostService) ListCoauthorInvites(ctx context.Context, user *types.UserContext) ([]models.CoauthorInvitation, error) {
+	return []models.CoauthorInvitation{}, nil
+}
+
+func (m *MockPostService) ShareToCommunity(ctx context.Context, postID, communityID uuid.UUID, user *types.UserContext) (*models.CommunityPlacement, error) {
+	return &models.CommunityPlacement{PostId: postID, CommunityId: communityID, PlacedBy: user.UserID}, nil
+}
+
+func (m *MockPostService) RemoveFromCommunity(ctx context.Context, postID, communityID uuid.UUID, user *types.UserContext) error {
+	return nil
+}
+
+func (m *MockPostService) ListCommunityPlacements(ctx context.Context, postID uuid.UUID) ([]models.CommunityPlacement, error) {
+	return []models.CommunityPlacement{}, nil
+}
+
+func (m *MockPostService) AcceptAnswer(ctx context.Context, postID, commentID uuid.UUID, user *types.UserContext) error {
+	return nil
+}
+
+func (m *MockPostService) ClearAcceptedAnswer(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	return nil
+}
+
+func (m *MockPostService) ExportComments(ctx context.Context, postID uuid.UUID, user *types.UserContext) (services.CommentExport, error) {
+	if m.exportCommentsFunc != nil {
+		return m.exportCommentsFunc(ctx, postID, user)
+	}
+	return nil, nil
+}
+
+func (m *MockPostService) ScheduleLiveThread(ctx context.Context, postID uuid.UUID, req *models.ScheduleLiveThreadRequest, user *types.UserContext) (*models.LiveThread, error) {
+	return &models.LiveThread{PostId: postID, StartsAt: req.StartsAt, EndsAt: req.EndsAt}, nil
+}
+
+func (m *MockPostService) GetLiveThread(ctx context.Context, postID uuid.UUID, user *types.UserContext) (*models.LiveThread, error) {
+	return &models.LiveThread{PostId: postID}, nil
+}
+
+func (m *MockPostService) CancelLiveThread(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	return nil
+}
+
 func (m *MockPostService) ConvertPostToResponse(ctx context.Context, post *models.Post) models.PostResponse {
 	if post == nil {
 		return models.PostResponse{}
@@ -721,6 +916,89 @@ func TestPostHandler_CreatePost_Success(t *testing.T) {
 	}
 }
 
+// stubDelegations grants post:create to the delegates it lists
+type stubDelegations struct {
+	grants map[uuid.UUID]uuid.UUID // delegate -> principal
+}
+
+func (s *stubDelegations) AuthorizeActingAs(ctx context.Context, principalID, delegateID uuid.UUID, scope string) (*sharedInterfaces.ActingIdentity, error) {
+	if s.grants[delegateID] != principalID || scope != sharedInterfaces.DelegationScopePostCreate {
+		return nil, nil
+	}
+	return &sharedInterfaces.ActingIdentity{UserID: principalID, DisplayName: "Brand"}, nil
+}
+
+func (s *stubDelegations) RecordDelegatedAction(ctx context.Context, identity *sharedInterfaces.ActingIdentity, delegateID uuid.UUID, scope string, targetID uuid.UUID) error {
+	return nil
+}
+
+func TestPostHandler_CreatePost_ActingAs(t *testing.T) {
+	postID, _ := uuid.NewV4()
+	managerID, _ := uuid.NewV4()
+	brandID, _ := uuid.NewV4()
+
+	newApp := func(delegations *stubDelegations, created *bool) *fiber.App {
+		mockService := &MockPostService{
+			createPostFunc: func(ctx context.Context, req *models.CreatePostRequest, user *types.UserContext) (*models.Post, error) {
+				*created = true
+				return &models.Post{ObjectId: postID, OwnerUserId: brandID}, nil
+			},
+		}
+		jwtConfig, hmacConfig := createTestConfig()
+		handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+		if delegations != nil {
+			handler.WithDelegation(delegations)
+		}
+		app := fiber.New()
+		app.Use(func(c *fiber.Ctx) error {
+			c.Locals(types.UserCtxName, types.UserContext{UserID: managerID, DisplayName: "Manager"})
+			return c.Next()
+		})
+		app.Post("/posts", handler.CreatePost)
+		return app
+	}
+	newRequest := func() *http.Request {
+		reqJSON, _ := json.Marshal(models.CreatePostRequest{PostTypeId: 1, Body: "Launch day", ActingAs: &brandID})
+		req := httptest.NewRequest("POST", "/posts", bytes.NewReader(reqJSON))
+		req.Header.Set(types.HeaderContentType, "application/json")
+		return req
+	}
+
+	t.Run("forbidden without an active grant", func(t *testing.T) {
+		created := false
+		resp, err := newApp(&stubDelegations{}, &created).Test(newRequest())
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusForbidden || created {
+			t.Errorf("Expected 403 without creating a post, got %d (created=%v)", resp.StatusCode, created)
+		}
+	})
+
+	t.Run("forbidden when delegation is not wired", func(t *testing.T) {
+		created := false
+		resp, err := newApp(nil, &created).Test(newRequest())
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusForbidden || created {
+			t.Errorf("Expected 403 without creating a post, got %d (created=%v)", resp.StatusCode, created)
+		}
+	})
+
+	t.Run("creates the post as the account acted as", func(t *testing.T) {
+		created := false
+		delegations := &stubDelegations{grants: map[uuid.UUID]uuid.UUID{managerID: brandID}}
+		resp, err := newApp(delegations, &created).Test(newRequest())
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusCreated || !created {
+			t.Fatalf("Expected 201, got %d", resp.StatusCode)
+		}
+	})
+}
+
 func TestPostHandler_CreatePost_ValidationError(t *testing.T) {
 	mockService := &MockPostService{}
 	jwtConfig, hmacConfig := createTestConfig()
@@ -807,6 +1085,53 @@ func TestPostHandler_GetPost_Success(t *testing.T) {
 	}
 }
 
+func TestPostHandler_GetPost_IncludeCommentsPreview(t *testing.T) {
+	postID, _ := uuid.NewV4()
+	attached := 0
+
+	mockService := &MockPostService{
+		getPostFunc: func(ctx context.Context, id uuid.UUID) (*models.Post, error) {
+			return &models.Post{ObjectId: postID, Body: "Test post content"}, nil
+		},
+		attachLatestCommentsFunc: func(ctx context.Context, posts []models.PostResponse) {
+			attached++
+			posts[0].LatestComments = []models.CommentPreview{{ObjectId: "c1", Text: "First!"}}
+		},
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Get("/posts/:postId", handler.GetPost)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/posts/"+postID.String()+"?includeComments=preview", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var response models.PostResponse
+	json.NewDecoder(resp.Body).Decode(&response)
+	if len(response.LatestComments) != 1 || response.LatestComments[0].Text != "First!" {
+		t.Errorf("Expected one comment preview, got %+v", response.LatestComments)
+	}
+
+	// Without the flag no previews are loaded
+	resp, err = app.Test(httptest.NewRequest("GET", "/posts/"+postID.String(), nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if attached != 1 {
+		t.Errorf("Expected previews to be attached once, got %d", attached)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/posts/"+postID.String()+"?includeComments=all", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400 for an unknown includeComments value, got %d", resp.StatusCode)
+	}
+}
+
 func TestPostHandler_GetPost_NotFound(t *testing.T) {
 	postID, _ := uuid.NewV4()
 
@@ -921,6 +1246,393 @@ func BenchmarkPostHandler_CreatePost(b *testing.B) {
 }
 
 // Example test demonstrating advanced MockPostService capabilities
+func TestPostHandler_QueryPostsWithCursor_SparseFields(t *testing.T) {
+	var gotFields []string
+	mockService := &MockPostService{}
+	mockService.queryPostsWithCursorFunc = func(ctx context.Context, filter *models.PostQueryFilter) (*models.PostsListResponse, error) {
+		gotFields = filter.Fields
+		return &models.PostsListResponse{
+			Posts:   []models.PostResponse{{ObjectId: "p1", Body: "long body", OwnerDisplayName: "Ann", Votes: map[string]string{"u": "1"}}},
+			HasNext: true,
+		}, nil
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Get("/posts/queries/cursor", handler.QueryPostsWithCursor)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/posts/queries/cursor?fields=ownerDisplayName,score", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if len(gotFields) != 2 || gotFields[0] != "ownerDisplayName" || gotFields[1] != "score" {
+		t.Errorf("Expected fields to reach the service, got %v", gotFields)
+	}
+
+	var body map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if body["hasNext"] != true {
+		t.Errorf("Expected pagination fields to be kept, got %v", body)
+	}
+	posts, _ := body["posts"].([]interface{})
+	if len(posts) != 1 {
+		t.Fatalf("Expected one post, got %v", body["posts"])
+	}
+	post := posts[0].(map[string]interface{})
+	if len(post) != 3 || post["objectId"] != "p1" || post["ownerDisplayName"] != "Ann" {
+		t.Errorf("Unexpected projected post: %v", post)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/posts/queries/cursor?fields=secret", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400 for unknown field, got %d", resp.StatusCode)
+	}
+}
+
+func TestPostHandler_GetPublicTagFeed(t *testing.T) {
+	var gotFilter *models.PostQueryFilter
+	mockService := &MockPostService{}
+	mockService.queryPostsWithCursorFunc = func(ctx context.Context, filter *models.PostQueryFilter) (*models.PostsListResponse, error) {
+		gotFilter = filter
+		return &models.PostsListResponse{Posts: []models.PostResponse{}}, nil
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Get("/posts/public/tags/:tag", handler.GetPublicTagFeed)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/posts/public/tags/golang?limit=5", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if gotFilter == nil || !gotFilter.PublicOnly || gotFilter.Limit != 5 || len(gotFilter.Tags) != 1 || gotFilter.Tags[0] != "golang" {
+		t.Errorf("Unexpected filter: %+v", gotFilter)
+	}
+	if gotFilter.Deleted == nil || *gotFilter.Deleted {
+		t.Errorf("Expected deleted posts to be excluded")
+	}
+}
+
+func TestPostHandler_SearchPosts(t *testing.T) {
+	var gotQuery models.PostSearchQuery
+	mockService := &MockPostService{}
+	mockService.fullTextSearchFunc = func(ctx context.Context, query models.PostSearchQuery) (*models.PostSearchResponse, error) {
+		gotQuery = query
+		return &models.PostSearchResponse{Results: []models.PostSearchResult{}}, nil
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Get("/posts/search", handler.SearchPosts)
+
+	cursorID := uuid.Must(uuid.NewV4()).String()
+	cursor := models.EncodeSearchCursor(models.SearchCursor{Rank: 0.5, ID: cursorID})
+	target := "/posts/search?q=%22error+handling%22+-panic&tags=go,+errors&limit=10&cursor=" + cursor
+	resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if gotQuery.Query != `"error handling" -panic` || gotQuery.Limit != 10 {
+		t.Errorf("Unexpected query: %+v", gotQuery)
+	}
+	if len(gotQuery.Tags) != 2 || gotQuery.Tags[0] != "go" || gotQuery.Tags[1] != "errors" {
+		t.Errorf("Unexpected tags: %v", gotQuery.Tags)
+	}
+	if gotQuery.After == nil || gotQuery.After.ID != cursorID {
+		t.Errorf("Expected cursor to be decoded, got %+v", gotQuery.After)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/posts/search?q=go&cursor=garbage!", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400 for a malformed cursor, got %d", resp.StatusCode)
+	}
+}
+
+func TestPostHandler_SemanticSearch(t *testing.T) {
+	authorID := uuid.Must(uuid.NewV4())
+	var gotQuery models.SemanticSearchQuery
+	mockService := &MockPostService{}
+	mockService.semanticSearchFunc = func(ctx context.Context, query models.SemanticSearchQuery) (*models.SemanticSearchResponse, error) {
+		gotQuery = query
+		return &models.SemanticSearchResponse{Results: []models.SemanticSearchResult{}}, nil
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Get("/posts/semantic-search", handler.SemanticSearch)
+
+	target := "/posts/semantic-search?q=cooking+on+a+budget&limit=5&communityId=kitchen&authorId=" + authorID.String() + "&since=1000&until=2000"
+	resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if gotQuery.Query != "cooking on a budget" || gotQuery.Limit != 5 || gotQuery.CommunityID != "kitchen" {
+		t.Errorf("Unexpected query: %+v", gotQuery)
+	}
+	if gotQuery.AuthorID == nil || *gotQuery.AuthorID != authorID {
+		t.Errorf("Expected author %s, got %v", authorID, gotQuery.AuthorID)
+	}
+	if gotQuery.Range.Since != 1000 || gotQuery.Range.Until != 2000 {
+		t.Errorf("Unexpected range: %+v", gotQuery.Range)
+	}
+
+	for _, target := range []string{
+		"/posts/semantic-search",
+		"/posts/semantic-search?q=go&authorId=nobody",
+		"/posts/semantic-search?q=go&since=2000&until=1000",
+	} {
+		resp, err = app.Test(httptest.NewRequest("GET", target, nil))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode != 400 {
+			t.Errorf("Expected status 400 for %s, got %d", target, resp.StatusCode)
+		}
+	}
+}
+
+func TestPostHandler_QueryPostsWithCursor_HideInteracted(t *testing.T) {
+	readerID := uuid.Must(uuid.NewV4())
+	var got *uuid.UUID
+	mockService := &MockPostService{}
+	mockService.queryPostsWithCursorFunc = func(ctx context.Context, filter *models.PostQueryFilter) (*models.PostsListResponse, error) {
+		got = filter.NotInteractedBy
+		return &models.PostsListResponse{Posts: []models.PostResponse{}}, nil
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(types.UserCtxName, types.UserContext{UserID: readerID})
+		return c.Next()
+	})
+	app.Get("/posts/queries/cursor", handler.QueryPostsWithCursor)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/posts/queries/cursor?hideInteracted=true", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if got == nil || *got != readerID {
+		t.Errorf("Expected the caller to reach the service, got %v", got)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/posts/queries/cursor?hideInteracted=maybe", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400, got %d", resp.StatusCode)
+	}
+}
+
+func TestPostHandler_Tags(t *testing.T) {
+	var gotTag, gotWindow string
+	var gotAfter *models.TagCursor
+	var gotRange models.TimeRange
+	mockService := &MockPostService{
+		getTagPostsFunc: func(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) (*models.PostsListResponse, error) {
+			if after != nil {
+				gotTag, gotAfter = tag, after
+			}
+			if !within.IsZero() {
+				gotRange = within
+			}
+			return &models.PostsListResponse{Posts: []models.PostResponse{}}, nil
+		},
+		trendingTagsFunc: func(ctx context.Context, window string) (*models.TrendingTagsResponse, error) {
+			gotWindow = window
+			return &models.TrendingTagsResponse{Window: window, Tags: []models.TrendingTag{}}, nil
+		},
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Get("/tags/trending", handler.GetTrendingTags)
+	app.Get("/tags/:tag/posts", handler.GetTagPosts)
+
+	cursorID := uuid.Must(uuid.NewV4()).String()
+	cursor := models.EncodeTagCursor(models.TagCursor{CreatedDate: 1700000000000, ID: cursorID})
+	for target, want := range map[string]int{
+		"/tags/caf%C3%A9/posts?cursor=" + cursor: 200,
+		"/tags/go/posts?cursor=garbage!":          400,
+		"/tags/go/posts?since=1700000000000":      200,
+		"/tags/go/posts?period=fortnight":         400,
+		"/tags/trending":                          200,
+		"/tags/trending?window=30d":               400,
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("%s: expected status %d, got %d", target, want, resp.StatusCode)
+		}
+	}
+	if gotTag != "café" || gotAfter == nil || gotAfter.ID != cursorID {
+		t.Errorf("Unexpected tag page request: %q %+v", gotTag, gotAfter)
+	}
+	if gotRange != (models.TimeRange{Since: 1700000000000}) {
+		t.Errorf("Expected since to reach the service, got %+v", gotRange)
+	}
+	if gotWindow != models.DefaultTrendingWindow {
+		t.Errorf("Expected the default window, got %q", gotWindow)
+	}
+}
+
+func TestPostHandler_GetPublicPostByURLKey_HidesPrivatePosts(t *testing.T) {
+	ownerID, _ := uuid.NewV4()
+	post := CreateTestPost(ownerID, "hello")
+	mockService := &MockPostService{
+		getPostByURLKeyFunc: func(ctx context.Context, urlKey string) (*models.Post, error) {
+			return post, nil
+		},
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Get("/posts/public/urlkey/:urlkey", handler.GetPublicPostByURLKey)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/posts/public/urlkey/hello", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200 for a public post, got %d", resp.StatusCode)
+	}
+
+	post.Permission = "OnlyMe"
+	resp, err = app.Test(httptest.NewRequest("GET", "/posts/public/urlkey/hello", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 404 {
+		t.Errorf("Expected status 404 for a private post, got %d", resp.StatusCode)
+	}
+}
+
+func TestPostHandler_GetUnreadCounts(t *testing.T) {
+	userID, _ := uuid.NewV4()
+	var gotFeeds []string
+
+	mockService := &MockPostService{
+		getUnreadCountsFunc: func(ctx context.Context, uid uuid.UUID, feedKeys []string) ([]models.FeedUnreadCount, error) {
+			if uid != userID {
+				t.Errorf("Expected user %s, got %s", userID, uid)
+			}
+			gotFeeds = feedKeys
+			return []models.FeedUnreadCount{{Feed: "home", UnreadCount: 3, LastReadDate: 100}}, nil
+		},
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(types.UserCtxName, types.UserContext{UserID: userID})
+		return c.Next()
+	})
+	app.Get("/posts/read-state", handler.GetUnreadCounts)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/posts/read-state?feeds=home,tag:go", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 200 {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if len(gotFeeds) != 2 || gotFeeds[0] != "home" || gotFeeds[1] != "tag:go" {
+		t.Errorf("Unexpected feeds passed to service: %v", gotFeeds)
+	}
+
+	var body struct {
+		Feeds []models.FeedUnreadCount `json:"feeds"`
+	}
+	json.NewDecoder(resp.Body).Decode(&body)
+	if len(body.Feeds) != 1 || body.Feeds[0].UnreadCount != 3 {
+		t.Errorf("Unexpected response: %+v", body)
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", "/posts/read-state?feeds=group:1", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400 for unknown feed, got %d", resp.StatusCode)
+	}
+}
+
+func TestPostHandler_MarkFeedRead(t *testing.T) {
+	userID, _ := uuid.NewV4()
+	var got *models.MarkFeedReadRequest
+
+	mockService := &MockPostService{
+		markFeedReadFunc: func(ctx context.Context, uid uuid.UUID, req *models.MarkFeedReadRequest) error {
+			got = req
+			return nil
+		},
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(types.UserCtxName, types.UserContext{UserID: userID})
+		return c.Next()
+	})
+	app.Put("/posts/read-state", handler.MarkFeedRead)
+
+	req := httptest.NewRequest("PUT", "/posts/read-state", bytes.NewReader([]byte(`{"feed":"tag:go","lastReadDate":1700000000000}`)))
+	req.Header.Set(types.HeaderContentType, "application/json")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 204 {
+		t.Fatalf("Expected status 204, got %d", resp.StatusCode)
+	}
+	if got == nil || got.Feed != "tag:go" || got.LastReadDate != 1700000000000 {
+		t.Errorf("Unexpected request passed to service: %+v", got)
+	}
+
+	req = httptest.NewRequest("PUT", "/posts/read-state", bytes.NewReader([]byte(`{"feed":"user:not-a-uuid"}`)))
+	req.Header.Set(types.HeaderContentType, "application/json")
+	resp, err = app.Test(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 400 {
+		t.Errorf("Expected status 400 for invalid feed, got %d", resp.StatusCode)
+	}
+}
+
 func TestMockPostService_AdvancedFeatures(t *testing.T) {
 	userID, _ := uuid.NewV4()
 	otherUserID, _ := uuid.NewV4()
@@ -1150,3 +1862,70 @@ func CreateTestPost(ownerID uuid.UUID, body string) *models.Post {
 		Version:          "1.0",
 	}
 }
+
+func TestPostHandler_ExportComments(t *testing.T) {
+	ownerID, _ := uuid.NewV4()
+	postID, _ := uuid.NewV4()
+	rows := []models.CommentExportRow{
+		{CommentId: "c1", AuthorUserId: "u1", AuthorDisplayName: "Ann", Text: "Pick me, please", Score: 2, CreatedDate: 1700000000000},
+		{CommentId: "c2", ParentCommentId: "c1", AuthorUserId: "u2", AuthorDisplayName: "Bob", Text: "=HYPERLINK(\"x\")", CreatedDate: 1700000060000},
+	}
+	mockService := &MockPostService{
+		exportCommentsFunc: func(ctx context.Context, id uuid.UUID, user *types.UserContext) (services.CommentExport, error) {
+			if id != postID || user.UserID != ownerID {
+				return nil, errors.New("post ownership required")
+			}
+			return func(ctx context.Context, emit func([]models.CommentExportRow) error) error {
+				return emit(rows)
+			}, nil
+		},
+	}
+
+	jwtConfig, hmacConfig := createTestConfig()
+	handler := handlers.NewPostHandler(mockService, jwtConfig, hmacConfig)
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(types.UserCtxName, types.UserContext{UserID: ownerID})
+		return c.Next()
+	})
+	app.Get("/posts/:postId/comments/export", handler.ExportComments)
+
+	target := "/posts/" + postID.String() + "/comments/export"
+	resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if resp.StatusCode != 200 || resp.Header.Get("Content-Type") != "text/csv; charset=utf-8" {
+		t.Fatalf("Expected a CSV download, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	var body bytes.Buffer
+	body.ReadFrom(resp.Body)
+	want := "commentId,parentCommentId,authorUserId,authorDisplayName,text,score,createdAt,updatedAt\n" +
+		"c1,,u1,Ann,\"Pick me, please\",2,2023-11-14T22:13:20Z,\n" +
+		"c2,c1,u2,Bob,\"'=HYPERLINK(\"\"x\"\")\",0,2023-11-14T22:14:20Z,\n"
+	if body.String() != want {
+		t.Errorf("Unexpected CSV:\n%s", body.String())
+	}
+
+	resp, err = app.Test(httptest.NewRequest("GET", target+"?format=json", nil))
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	var exported []models.CommentExportRow
+	if err := json.NewDecoder(resp.Body).Decode(&exported); err != nil || len(exported) != 2 || exported[1].ParentCommentId != "c1" {
+		t.Errorf("Unexpected JSON export: %+v (%v)", exported, err)
+	}
+
+	for target, want := range map[string]int{
+		target + "?format=xlsx": 400,
+		"/posts/" + uuid.Must(uuid.NewV4()).String() + "/comments/export": 500,
+	} {
+		resp, err := app.Test(httptest.NewRequest("GET", target, nil))
+		if err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if resp.StatusCode != want {
+			t.Errorf("%s: expected status %d, got %d", target, want, resp.StatusCode)
+		}
+	}
+}
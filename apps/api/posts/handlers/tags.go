@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// GetTrendingTags handles GET /tags/trending?window=1h|24h|7d (default 24h): the most used
+// tags on public posts in the window, as last counted by the trending job
+func (h *PostHandler) GetTrendingTags(c *fiber.Ctx) error {
+	window := c.Query("window", models.DefaultTrendingWindow)
+	if _, ok := models.FindTrendingWindow(window); !ok {
+		return errors.HandleInvalidFieldError(c, "window", "must be 1h, 24h or 7d")
+	}
+
+	trending, err := h.postService.TrendingTags(c.Context(), window)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(trending)
+}
+
+// GetTagPosts handles GET /tags/:tag/posts: public posts carrying the tag, newest first.
+// cursor pages through them and since/until or period narrow them to a time range; the
+// tag is matched case-insensitively, with or without '#'.
+func (h *PostHandler) GetTagPosts(c *fiber.Ctx) error {
+	tag, err := url.PathUnescape(c.Params("tag"))
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "tag", "must be URL encoded")
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil && parsed > 0 {
+			limit = parsed
+		}
+	}
+
+	var after *models.TagCursor
+	if cursor := c.Query("cursor"); cursor != "" {
+		if after, err = models.DecodeTagCursor(cursor); err != nil {
+			return errors.HandleInvalidFieldError(c, "cursor", err.Error())
+		}
+	}
+
+	within, err := parseTimeRange(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "range", err.Error())
+	}
+
+	reqCtx := c.UserContext()
+	if reqCtx == nil {
+		reqCtx = context.Background()
+	}
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); ok {
+		reqCtx = context.WithValue(reqCtx, types.UserCtxName, user)
+	}
+
+	result, err := h.postService.GetTagPosts(reqCtx, tag, within, after, limit)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(result)
+}
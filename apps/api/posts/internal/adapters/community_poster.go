@@ -0,0 +1,61 @@
+package adapters
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/services"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+var _ sharedInterfaces.CommunityPoster = (*CommunityPoster)(nil)
+
+// textPostType is the plain text post type community starters are published as
+const textPostType = 1
+
+// CommunityPoster lets the communities module measure activity and publish starters
+type CommunityPoster struct {
+	service services.PostService
+}
+
+// NewCommunityPoster creates a CommunityPoster calling the post service directly
+func NewCommunityPoster(svc services.PostService) *CommunityPoster {
+	return &CommunityPoster{service: svc}
+}
+
+// CountCommunityPosts counts the community's posts created after since, deleted ones aside
+func (a *CommunityPoster) CountCommunityPosts(ctx context.Context, communityID uuid.UUID, since time.Time) (int64, error) {
+	deleted := false
+	result, err := a.service.QueryPosts(ctx, &models.PostQueryFilter{
+		CommunityId:  &communityID,
+		CreatedAfter: &since,
+		Deleted:      &deleted,
+		Limit:        1,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return result.TotalCount, nil
+}
+
+// PublishCommunityPost creates a text post in the community, subject to the same checks
+// as any other post by author
+func (a *CommunityPoster) PublishCommunityPost(ctx context.Context, communityID uuid.UUID, body string, author sharedInterfaces.CommunityPostAuthor) (uuid.UUID, error) {
+	post, err := a.service.CreatePost(ctx, &models.CreatePostRequest{
+		PostTypeId:  textPostType,
+		Body:        body,
+		CommunityId: &communityID,
+	}, &types.UserContext{
+		UserID:      author.UserID,
+		DisplayName: author.DisplayName,
+		SocialName:  author.SocialName,
+		Avatar:      author.Avatar,
+	})
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return post.ObjectId, nil
+}
@@ -0,0 +1,41 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/services"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+var _ sharedInterfaces.ContentModerator = (*ContentModerator)(nil)
+
+// ContentModerator lets the moderation module look up and hide reported posts
+type ContentModerator struct {
+	service services.PostService
+}
+
+// NewContentModerator creates a ContentModerator calling the post service directly
+func NewContentModerator(svc services.PostService) *ContentModerator {
+	return &ContentModerator{service: svc}
+}
+
+// ContentOwner returns the post's owner, or ErrContentNotFound
+func (a *ContentModerator) ContentOwner(ctx context.Context, contentID uuid.UUID) (uuid.UUID, error) {
+	owner, err := a.service.PostOwner(ctx, contentID)
+	return owner, contentError(err)
+}
+
+// HideContent hides the post and its comments
+func (a *ContentModerator) HideContent(ctx context.Context, contentID uuid.UUID) error {
+	return contentError(a.service.HidePost(ctx, contentID))
+}
+
+func contentError(err error) error {
+	if errors.Is(err, postsErrors.ErrPostNotFound) {
+		return sharedInterfaces.ErrContentNotFound
+	}
+	return err
+}
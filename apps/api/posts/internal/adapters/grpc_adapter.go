@@ -5,10 +5,13 @@ import (
 	"fmt"
 
 	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/platform/grpcconn"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
 	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 	pb "github.com/qolzam/telar/protos/gen/go/postspb"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 // Ensure GrpcStatsUpdater implements PostStatsUpdater interface
@@ -23,8 +26,13 @@ type GrpcStatsUpdater struct {
 }
 
 // NewGrpcStatsUpdater creates a new GrpcStatsUpdater adapter.
-func NewGrpcStatsUpdater(targetAddress string) (*GrpcStatsUpdater, error) {
-	conn, err := grpc.Dial(targetAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewGrpcStatsUpdater(targetAddress string, cfg platformconfig.GRPCConfig) (*GrpcStatsUpdater, error) {
+	opts, err := grpcconn.DialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(targetAddress, append(opts,
+		grpc.WithChainUnaryInterceptor(requestid.UnaryClientInterceptor(), metrics.Default().UnaryClientInterceptor()))...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,17 @@
+-- Migration: Add OCR media text to posts
+-- media_text holds text extracted from attached images by the async OCR job.
+-- search_vector combines body (weight A) and media_text (weight C) so that
+-- image text is searchable but ranks below matches in the post body.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS media_text TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(body, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(media_text, '')), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_posts_search_vector ON posts USING GIN (search_vector);
+
+-- Superseded by idx_posts_search_vector
+DROP INDEX IF EXISTS idx_posts_body_fts;
@@ -0,0 +1,9 @@
+-- Migration: Add content hash to posts for duplicate detection
+-- content_hash is a SHA-256 of the normalized body (lowercased, whitespace collapsed)
+-- and lets post creation find exact reposts and copy-paste spam with an index lookup.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_posts_content_hash
+ON posts(content_hash, created_date DESC)
+WHERE content_hash <> '';
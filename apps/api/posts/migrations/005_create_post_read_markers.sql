@@ -0,0 +1,12 @@
+-- Migration: Per-user read markers for post feeds
+-- Each row remembers the created_date of the newest post a user has seen in a feed
+-- (feed_key is "home", "tag:<tag>" or "user:<uuid>") so the API can report unread
+-- counts and flag new feed items.
+
+CREATE TABLE IF NOT EXISTS post_read_markers (
+    user_id UUID NOT NULL,
+    feed_key VARCHAR(300) NOT NULL,
+    last_read_date BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, feed_key)
+);
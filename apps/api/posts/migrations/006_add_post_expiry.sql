@@ -0,0 +1,13 @@
+-- Migration: Time-limited posts
+-- visible_until is the Unix time in milliseconds (like created_date) after which a post stops showing in feeds;
+-- 0 means the post never expires. An expiry job flips is_archived once the time has
+-- passed. List queries filter on both columns so expired posts drop out of feeds even
+-- before the job catches up.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS visible_until BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS is_archived BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Backs the expiry job's scan and the feed filter on expiring posts
+CREATE INDEX IF NOT EXISTS idx_posts_visible_until
+ON posts(visible_until)
+WHERE visible_until > 0 AND is_archived = FALSE;
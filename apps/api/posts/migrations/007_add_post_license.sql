@@ -0,0 +1,12 @@
+-- Migration: Content license and attribution
+-- license holds an SPDX identifier (e.g. CC-BY-4.0) or 'all-rights-reserved'; empty means
+-- the author made no statement. canonical_url points at the original source when a post
+-- republishes content from elsewhere; empty when the post is the original.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS license VARCHAR(32) NOT NULL DEFAULT '';
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS canonical_url TEXT NOT NULL DEFAULT '';
+
+-- Backs the "reusable content" search filter, which only ever looks at licensed posts
+CREATE INDEX IF NOT EXISTS idx_posts_license
+ON posts(license, created_date DESC)
+WHERE license <> '';
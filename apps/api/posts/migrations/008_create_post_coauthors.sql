@@ -0,0 +1,22 @@
+-- Migration: Post co-authors
+-- The post owner invites users to co-author a post. Accepted co-authors can edit the
+-- post, appear in its author block and see it on their profile feed; deleting the post
+-- stays with the owner. display_name and avatar are copied from the co-author's profile
+-- on acceptance, like owner_display_name/owner_avatar on posts.
+
+CREATE TABLE IF NOT EXISTS post_coauthors (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    invited_by UUID NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    display_name VARCHAR(255) NOT NULL DEFAULT '',
+    avatar VARCHAR(512) NOT NULL DEFAULT '',
+    created_date BIGINT NOT NULL,
+    responded_date BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (post_id, user_id),
+    CHECK (status IN ('pending', 'accepted'))
+);
+
+-- Backs profile feeds (accepted) and the invitee's pending list
+CREATE INDEX IF NOT EXISTS idx_post_coauthors_user
+ON post_coauthors(user_id, status);
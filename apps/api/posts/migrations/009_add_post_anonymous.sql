@@ -0,0 +1,6 @@
+-- Migration: Anonymous posting
+-- owner_user_id keeps the real author so moderators and ownership checks still work;
+-- responses show anonymous_alias instead, a pseudonym fixed for the post's thread.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS is_anonymous BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS anonymous_alias VARCHAR(64) NOT NULL DEFAULT '';
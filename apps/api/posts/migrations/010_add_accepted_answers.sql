@@ -0,0 +1,10 @@
+-- Migration: Q&A accepted answers
+-- A question post (post_type_id = 5) is answered once its author accepts one of its
+-- root comments. Soft-deleting the comment clears the answer in the service; the
+-- foreign key covers hard deletes.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS accepted_answer_id UUID REFERENCES comments(id) ON DELETE SET NULL;
+
+-- "Unanswered questions" feed
+CREATE INDEX IF NOT EXISTS idx_posts_unanswered_questions ON posts(created_date DESC)
+    WHERE post_type_id = 5 AND accepted_answer_id IS NULL AND is_deleted = FALSE;
@@ -0,0 +1,5 @@
+-- Migration: supporter-only posts
+-- Readers who do not support the author get a teaser of the body instead of the post;
+-- see the supporters module for tiers and subscriptions.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS is_supporter_only BOOLEAN NOT NULL DEFAULT FALSE;
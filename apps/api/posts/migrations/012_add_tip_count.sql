@@ -0,0 +1,5 @@
+-- Migration: tip counts
+-- Paid tips on the post, kept by the tips module from billing events; refunds take
+-- them back.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS tip_count BIGINT NOT NULL DEFAULT 0;
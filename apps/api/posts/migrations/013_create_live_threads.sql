@@ -0,0 +1,27 @@
+-- Migration: Live threads
+-- A post can host a live session (a meeting or an AMA) between starts_at and ends_at,
+-- Unix milliseconds. Comments stream over the realtime gateway and are slow-moded while
+-- it runs; afterwards a job closes the thread (disable_comments on the post, closed_date
+-- here) and stores the AI engine's summary. summary_attempts bounds retries when the
+-- engine is unavailable.
+
+CREATE TABLE IF NOT EXISTS post_live_threads (
+    post_id UUID PRIMARY KEY REFERENCES posts(id) ON DELETE CASCADE,
+    starts_at BIGINT NOT NULL,
+    ends_at BIGINT NOT NULL,
+    slow_mode_seconds INT NOT NULL DEFAULT 0,
+    closed_date BIGINT NOT NULL DEFAULT 0,
+    summary TEXT NOT NULL DEFAULT '',
+    key_points TEXT[] NOT NULL DEFAULT '{}',
+    summary_attempts INT NOT NULL DEFAULT 0,
+    created_date BIGINT NOT NULL,
+    CHECK (ends_at > starts_at)
+);
+
+-- Threads waiting to be closed
+CREATE INDEX IF NOT EXISTS idx_post_live_threads_due ON post_live_threads(ends_at)
+WHERE closed_date = 0;
+
+-- Closed threads still waiting for a summary
+CREATE INDEX IF NOT EXISTS idx_post_live_threads_unsummarized ON post_live_threads(closed_date)
+WHERE closed_date > 0 AND summary = '';
@@ -0,0 +1,32 @@
+-- Migration: Tag store and trending tags
+-- post_tags holds one row per tag on a post, normalized (lowercase, no '#'). Hashtags in
+-- post bodies are merged into posts.tags on create and update, and the repository keeps
+-- this table in step with posts.tags so tag pages and trending counts use plain indexes.
+-- trending_tags holds the top tags per window (1h, 24h, 7d); a job recounts them and
+-- replaces each window's rows in one transaction, so reads never aggregate.
+
+CREATE TABLE IF NOT EXISTS post_tags (
+    tag VARCHAR(50) NOT NULL,
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    PRIMARY KEY (tag, post_id)
+);
+
+-- Replacing a post's tags looks rows up by post
+CREATE INDEX IF NOT EXISTS idx_post_tags_post ON post_tags(post_id);
+
+CREATE TABLE IF NOT EXISTS trending_tags (
+    window_name VARCHAR(8) NOT NULL,
+    rank INT NOT NULL,
+    tag VARCHAR(50) NOT NULL,
+    post_count BIGINT NOT NULL,
+    computed_date BIGINT NOT NULL,
+
+    PRIMARY KEY (window_name, rank)
+);
+
+-- Backfill tags of existing posts
+INSERT INTO post_tags (tag, post_id)
+SELECT DISTINCT lower(btrim(ltrim(btrim(t), '#'))), p.id
+FROM posts p, unnest(p.tags) t
+WHERE length(btrim(ltrim(btrim(t), '#'))) BETWEEN 1 AND 50
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,8 @@
+-- Migration: Public time range index
+-- Public feeds and tag pages filter live public posts by created_date (since/until) and
+-- page by (created_date, id). This partial index serves both without touching private or
+-- deleted rows.
+
+CREATE INDEX IF NOT EXISTS idx_posts_public_created
+    ON posts(created_date DESC, id DESC)
+    WHERE is_deleted = FALSE AND permission = 'Public';
@@ -0,0 +1,9 @@
+-- Migration: community posts
+-- Posts made in a community carry its ID; the main feed is everything else. Community
+-- feeds page live posts by (created_date, id) within one community.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS community_id UUID;
+
+CREATE INDEX IF NOT EXISTS idx_posts_community_created
+    ON posts(community_id, created_date DESC, id DESC)
+    WHERE community_id IS NOT NULL AND is_deleted = FALSE;
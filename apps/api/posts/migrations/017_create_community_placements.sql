@@ -0,0 +1,23 @@
+-- Migration: community placements
+-- A post appears in a community's feed when it is placed there: in the community it was
+-- made in and in each community it is shared into afterwards, without copying the post.
+-- Removing a placement takes the post out of that community only; posts.community_id
+-- keeps the community the post was made in.
+
+CREATE TABLE IF NOT EXISTS post_community_placements (
+    post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    community_id UUID NOT NULL,
+    placed_by UUID NOT NULL,
+    placed_date BIGINT NOT NULL,
+    PRIMARY KEY (post_id, community_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_community_placements_community
+    ON post_community_placements(community_id, post_id);
+
+-- Place existing community posts in the community they were made in
+INSERT INTO post_community_placements (post_id, community_id, placed_by, placed_date)
+SELECT id, community_id, owner_user_id, created_date
+FROM posts
+WHERE community_id IS NOT NULL
+ON CONFLICT DO NOTHING;
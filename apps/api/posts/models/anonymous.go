@@ -0,0 +1,51 @@
+package models
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+var aliasAdjectives = []string{
+	"Amber", "Brave", "Calm", "Clever", "Curious", "Gentle", "Golden", "Hidden",
+	"Quiet", "Rapid", "Silver", "Sleepy", "Steady", "Swift", "Violet", "Wandering",
+}
+
+var aliasAnimals = []string{
+	"Badger", "Crane", "Falcon", "Fox", "Heron", "Lynx", "Marten", "Moth",
+	"Otter", "Owl", "Panda", "Raven", "Seal", "Sparrow", "Tortoise", "Wolf",
+}
+
+// AnonymousAlias derives the pseudonym userID carries in postID's thread. The same
+// user gets a different alias on every post, and without the secret the alias
+// cannot be traced back to the account.
+func AnonymousAlias(secret string, postID, userID uuid.UUID) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(postID.Bytes())
+	mac.Write(userID.Bytes())
+	sum := mac.Sum(nil)
+
+	adjective := aliasAdjectives[int(sum[0])%len(aliasAdjectives)]
+	animal := aliasAnimals[int(sum[1])%len(aliasAnimals)]
+	return fmt.Sprintf("Anonymous %s %s %d", adjective, animal, binary.BigEndian.Uint16(sum[2:4])%1000)
+}
+
+// IsHiddenAuthor reports whether userID is the concealed author of an anonymous post,
+// whose comments in the thread must carry the post's alias too
+func (p *Post) IsHiddenAuthor(userID uuid.UUID) bool {
+	return p.Anonymous && p.OwnerUserId == userID
+}
+
+// HideAuthor replaces everything on an anonymous post's response that could identify
+// the author: owner fields take the alias and per-user vote entries are dropped.
+func (r *PostResponse) HideAuthor(alias string) {
+	r.Anonymous = true
+	r.OwnerUserId = ""
+	r.OwnerDisplayName = alias
+	r.OwnerAvatar = ""
+	r.Votes = nil
+	r.Coauthors = nil
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymousAlias(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+	otherPostID := uuid.Must(uuid.NewV4())
+	userID := uuid.Must(uuid.NewV4())
+
+	alias := AnonymousAlias("secret", postID, userID)
+	assert.Equal(t, alias, AnonymousAlias("secret", postID, userID), "stable within a thread")
+	assert.NotContains(t, alias, userID.String())
+	assert.Regexp(t, `^Anonymous \w+ \w+ \d+$`, alias)
+
+	assert.NotEqual(t, alias, AnonymousAlias("secret", otherPostID, userID), "differs across threads")
+	assert.NotEqual(t, alias, AnonymousAlias("other-secret", postID, userID), "keyed by the secret")
+}
+
+func TestPostResponse_HideAuthor(t *testing.T) {
+	response := PostResponse{
+		OwnerUserId:      uuid.Must(uuid.NewV4()).String(),
+		OwnerDisplayName: "Real Name",
+		OwnerAvatar:      "https://example.com/avatar.png",
+		Votes:            map[string]string{"user": "1"},
+		Coauthors:        []PostAuthor{{UserId: "co", DisplayName: "Co Author"}},
+	}
+
+	response.HideAuthor("Anonymous Calm Otter 42")
+
+	assert.True(t, response.Anonymous)
+	assert.Empty(t, response.OwnerUserId)
+	assert.Equal(t, "Anonymous Calm Otter 42", response.OwnerDisplayName)
+	assert.Empty(t, response.OwnerAvatar)
+	assert.Nil(t, response.Votes)
+	assert.Nil(t, response.Coauthors)
+}
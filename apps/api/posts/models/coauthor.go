@@ -0,0 +1,47 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Co-author invitation states. Declined invitations are deleted, so the owner can invite
+// the same user again later.
+const (
+	CoauthorStatusPending  = "pending"
+	CoauthorStatusAccepted = "accepted"
+
+	// MaxCoauthors caps accepted and pending co-authors per post
+	MaxCoauthors = 5
+)
+
+// PostCoauthor links a post to a user invited to write it with the owner.
+// DisplayName and Avatar are copied from the co-author's profile when they accept,
+// like the owner fields on Post, so the author block needs no profile lookup.
+type PostCoauthor struct {
+	PostId        uuid.UUID `json:"postId" db:"post_id"`
+	UserId        uuid.UUID `json:"userId" db:"user_id"`
+	InvitedBy     uuid.UUID `json:"invitedBy" db:"invited_by"`
+	Status        string    `json:"status" db:"status"`
+	DisplayName   string    `json:"displayName,omitempty" db:"display_name"`
+	Avatar        string    `json:"avatar,omitempty" db:"avatar"`
+	CreatedDate   int64     `json:"createdDate" db:"created_date"`
+	RespondedDate int64     `json:"respondedDate,omitempty" db:"responded_date"`
+}
+
+// InviteCoauthorRequest asks a user to co-author a post
+type InviteCoauthorRequest struct {
+	UserId uuid.UUID `json:"userId"`
+}
+
+// PostAuthor is an accepted co-author as shown in a post's author block
+type PostAuthor struct {
+	UserId      string `json:"userId"`
+	DisplayName string `json:"displayName"`
+	Avatar      string `json:"avatar,omitempty"`
+}
+
+// CoauthorInvitation is a pending invitation together with the post it is for
+type CoauthorInvitation struct {
+	Invitation PostCoauthor `json:"invitation"`
+	Post       PostResponse `json:"post"`
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of GET /posts/:postId/comments/export
+const (
+	CommentExportCSV  = "csv"
+	CommentExportJSON = "json"
+)
+
+// CommentExportColumns is the header row of CSV exports, in CSVRecord order
+var CommentExportColumns = []string{
+	"commentId", "parentCommentId", "authorUserId", "authorDisplayName",
+	"text", "score", "createdAt", "updatedAt",
+}
+
+// CommentExportRow is one comment or reply in a post owner's comments export
+type CommentExportRow struct {
+	CommentId         string `json:"commentId"`
+	ParentCommentId   string `json:"parentCommentId,omitempty"` // Set on replies
+	AuthorUserId      string `json:"authorUserId"`
+	AuthorDisplayName string `json:"authorDisplayName"`
+	Text              string `json:"text"`
+	Score             int64  `json:"score"`
+	CreatedDate       int64  `json:"createdDate"`
+	LastUpdated       int64  `json:"lastUpdated"`
+}
+
+// CSVRecord returns the row's CSV fields, with dates as RFC 3339 UTC so spreadsheets read
+// them. User-written fields that a spreadsheet would run as a formula are quoted with '.
+func (r CommentExportRow) CSVRecord() []string {
+	return []string{
+		r.CommentId, r.ParentCommentId, r.AuthorUserId, csvText(r.AuthorDisplayName),
+		csvText(r.Text), strconv.FormatInt(r.Score, 10), exportTime(r.CreatedDate), exportTime(r.LastUpdated),
+	}
+}
+
+// csvText defuses text starting with a character spreadsheets treat as a formula
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// exportTime formats a comment timestamp in Unix milliseconds; unset times stay empty
+func exportTime(ms int64) string {
+	if ms <= 0 {
+		return ""
+	}
+	return time.UnixMilli(ms).UTC().Format(time.RFC3339)
+}
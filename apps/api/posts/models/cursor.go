@@ -70,6 +70,13 @@ func DecodeCursor(cursor string) (*CursorData, error) {
 
 // CreateCursorFromPost creates a cursor from a post based on the sort field
 func CreateCursorFromPost(post *Post, sortField, direction string) (string, error) {
+	return CreateSnapshotCursorFromPost(post, sortField, direction, nil)
+}
+
+// CreateSnapshotCursorFromPost creates a cursor that also carries the paging snapshot,
+// so following the cursor keeps newly created posts out of later pages until the
+// snapshot expires. snapshot may be nil.
+func CreateSnapshotCursorFromPost(post *Post, sortField, direction string, snapshot *SnapshotData) (string, error) {
 	if post == nil {
 		return "", errors.New("post cannot be nil")
 	}
@@ -88,6 +95,10 @@ func CreateCursorFromPost(post *Post, sortField, direction string) (string, erro
 		SortField: sortField,
 		Direction: direction,
 	}
+	if snapshot != nil {
+		cursorData.Snapshot = snapshot.CreatedBefore
+		cursorData.SnapshotIssuedAt = snapshot.IssuedAt
+	}
 
 	// Set the value based on the sort field
 	switch sortField {
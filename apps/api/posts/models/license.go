@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Content licenses a post can carry, as SPDX identifiers. An empty license means the
+// author made no statement.
+const (
+	LicenseCC0          = "CC0-1.0"
+	LicenseCCBY         = "CC-BY-4.0"
+	LicenseCCBYSA       = "CC-BY-SA-4.0"
+	LicenseCCBYNC       = "CC-BY-NC-4.0"
+	LicenseCCBYND       = "CC-BY-ND-4.0"
+	LicenseCCBYNCSA     = "CC-BY-NC-SA-4.0"
+	LicenseCCBYNCND     = "CC-BY-NC-ND-4.0"
+	LicenseAllReserved  = "all-rights-reserved"
+	maxCanonicalURLSize = 2048
+)
+
+// licenseURLs maps each accepted license to its deed, used for rel="license" links
+var licenseURLs = map[string]string{
+	LicenseCC0:         "https://creativecommons.org/publicdomain/zero/1.0/",
+	LicenseCCBY:        "https://creativecommons.org/licenses/by/4.0/",
+	LicenseCCBYSA:      "https://creativecommons.org/licenses/by-sa/4.0/",
+	LicenseCCBYNC:      "https://creativecommons.org/licenses/by-nc/4.0/",
+	LicenseCCBYND:      "https://creativecommons.org/licenses/by-nd/4.0/",
+	LicenseCCBYNCSA:    "https://creativecommons.org/licenses/by-nc-sa/4.0/",
+	LicenseCCBYNCND:    "https://creativecommons.org/licenses/by-nc-nd/4.0/",
+	LicenseAllReserved: "",
+}
+
+// ReusableLicenses are the licenses that let others republish the post with attribution;
+// the "reusable content" search filter keeps posts carrying one of these
+var ReusableLicenses = []string{
+	LicenseCC0,
+	LicenseCCBY,
+	LicenseCCBYSA,
+	LicenseCCBYNC,
+	LicenseCCBYND,
+	LicenseCCBYNCSA,
+	LicenseCCBYNCND,
+}
+
+// ValidLicense reports whether license is an accepted license identifier
+func ValidLicense(license string) bool {
+	_, ok := licenseURLs[license]
+	return ok
+}
+
+// LicenseURL returns the deed URL for license, or "" when it has none
+func LicenseURL(license string) string {
+	return licenseURLs[license]
+}
+
+// ValidateCanonicalURL checks that a canonical-source URL is an absolute http(s) URL
+func ValidateCanonicalURL(raw string) error {
+	if len(raw) > maxCanonicalURLSize {
+		return fmt.Errorf("canonicalUrl must be at most %d characters", maxCanonicalURLSize)
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("canonicalUrl must be an absolute URL")
+	}
+	if scheme := strings.ToLower(u.Scheme); scheme != "http" && scheme != "https" {
+		return fmt.Errorf("canonicalUrl must use http or https")
+	}
+	return nil
+}
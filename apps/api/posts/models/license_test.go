@@ -0,0 +1,28 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidLicense(t *testing.T) {
+	assert.True(t, ValidLicense(LicenseCCBY))
+	assert.True(t, ValidLicense(LicenseAllReserved))
+	assert.False(t, ValidLicense(""))
+	assert.False(t, ValidLicense("cc-by"))
+	assert.False(t, ValidLicense("GPL-3.0"))
+
+	assert.Equal(t, "https://creativecommons.org/licenses/by/4.0/", LicenseURL(LicenseCCBY))
+	assert.Empty(t, LicenseURL(LicenseAllReserved))
+	assert.NotContains(t, ReusableLicenses, LicenseAllReserved)
+}
+
+func TestValidateCanonicalURL(t *testing.T) {
+	assert.NoError(t, ValidateCanonicalURL("https://example.com/blog/original"))
+	assert.NoError(t, ValidateCanonicalURL("http://example.com"))
+
+	for _, bad := range []string{"example.com/post", "/relative", "javascript:alert(1)", "ftp://example.com/file"} {
+		assert.Error(t, ValidateCanonicalURL(bad), bad)
+	}
+}
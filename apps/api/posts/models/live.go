@@ -0,0 +1,51 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+	"github.com/lib/pq"
+)
+
+// Live thread states, derived from the window and whether the close job has run
+const (
+	LiveStateScheduled = "scheduled"
+	LiveStateLive      = "live"
+	LiveStateEnded     = "ended"
+)
+
+// LiveThread turns a post into a live session, such as a meeting or an AMA. Between
+// StartsAt and EndsAt (Unix milliseconds) its comments stream over the realtime gateway
+// and each user may comment once every SlowModeSeconds. Afterwards the post is frozen
+// like an archived thread and Summary and KeyPoints are filled in by the AI engine.
+type LiveThread struct {
+	PostId          uuid.UUID      `json:"postId" db:"post_id"`
+	StartsAt        int64          `json:"startsAt" db:"starts_at"`
+	EndsAt          int64          `json:"endsAt" db:"ends_at"`
+	SlowModeSeconds int            `json:"slowModeSeconds" db:"slow_mode_seconds"`
+	ClosedDate      int64          `json:"closedDate,omitempty" db:"closed_date"`
+	Summary         string         `json:"summary,omitempty" db:"summary"`
+	KeyPoints       pq.StringArray `json:"keyPoints,omitempty" db:"key_points"`
+	SummaryAttempts int            `json:"-" db:"summary_attempts"`
+	CreatedDate     int64          `json:"createdDate" db:"created_date"`
+	State           string         `json:"state" db:"-"`
+}
+
+// StateAt returns the thread's state at now (Unix milliseconds). A thread is ended as
+// soon as its window passes, even before the close job freezes it.
+func (t *LiveThread) StateAt(now int64) string {
+	switch {
+	case t.ClosedDate > 0 || now >= t.EndsAt:
+		return LiveStateEnded
+	case now >= t.StartsAt:
+		return LiveStateLive
+	default:
+		return LiveStateScheduled
+	}
+}
+
+// ScheduleLiveThreadRequest is the body of PUT /posts/:postId/live. SlowModeSeconds
+// defaults to the deployment's setting; 0 turns slow mode off.
+type ScheduleLiveThreadRequest struct {
+	StartsAt        int64 `json:"startsAt"`
+	EndsAt          int64 `json:"endsAt"`
+	SlowModeSeconds *int  `json:"slowModeSeconds,omitempty"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// CommunityPlacement puts a post in a community's feed. A post is placed in the
+// community it was made in and in each community it is shared into, so sharing never
+// copies the post. Moderators of a community can remove the post from theirs only.
+type CommunityPlacement struct {
+	PostId      uuid.UUID `json:"postId" db:"post_id"`
+	CommunityId uuid.UUID `json:"communityId" db:"community_id"`
+	PlacedBy    uuid.UUID `json:"placedBy" db:"placed_by"`
+	PlacedDate  int64     `json:"placedDate" db:"placed_date"`
+}
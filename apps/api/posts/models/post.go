@@ -25,7 +25,8 @@ type Post struct {
 	Score            int64          `json:"score" bson:"score" db:"score"`
 	ViewCount        int64          `json:"viewCount" bson:"viewCount" db:"view_count"`
 	CommentCounter   int64          `json:"commentCounter" bson:"commentCounter" db:"comment_count"`
-	Tags             pq.StringArray `json:"tags" bson:"tags" db:"tags"` // Use pq.StringArray for PostgreSQL arrays
+	TipCount         int64          `json:"tipCount" bson:"tipCount" db:"tip_count"` // Paid tips, kept by the tips module
+	Tags             pq.StringArray `json:"tags" bson:"tags" db:"tags"`              // Use pq.StringArray for PostgreSQL arrays
 	URLKey           string         `json:"urlKey" bson:"urlKey" db:"url_key"`
 	OwnerDisplayName string         `json:"ownerDisplayName" bson:"ownerDisplayName" db:"owner_display_name"`
 	OwnerAvatar      string         `json:"ownerAvatar" bson:"ownerAvatar" db:"owner_avatar"`
@@ -39,6 +40,17 @@ type Post struct {
 	DeletedDate      int64          `json:"deletedDate" bson:"deletedDate" db:"deleted_date"`
 	Permission       string         `json:"permission" bson:"permission" db:"permission"`
 	Version          string         `json:"version" bson:"version" db:"version"`
+	MediaText        string         `json:"mediaText,omitempty" bson:"mediaText,omitempty" db:"media_text"`                       // Text extracted from attached images (OCR)
+	ContentHash      string         `json:"-" bson:"-" db:"content_hash"`                                                         // Hash of normalized body for duplicate detection
+	VisibleUntil     int64          `json:"visibleUntil,omitempty" bson:"visibleUntil,omitempty" db:"visible_until"`              // Unix milliseconds after which the post leaves feeds; 0 never expires
+	Archived         bool           `json:"archived" bson:"archived" db:"is_archived"`                                            // Set by the expiry job once visibleUntil has passed
+	License          string         `json:"license,omitempty" bson:"license,omitempty" db:"license"`                              // SPDX identifier, e.g. CC-BY-4.0; empty when unstated
+	CanonicalURL     string         `json:"canonicalUrl,omitempty" bson:"canonicalUrl,omitempty" db:"canonical_url"`              // Original source when the post republishes content
+	Anonymous        bool           `json:"anonymous" bson:"anonymous" db:"is_anonymous"`                                         // Author hidden behind AnonymousAlias in every response
+	AnonymousAlias   string         `json:"-" bson:"-" db:"anonymous_alias"`                                                      // Per-thread pseudonym shown in place of the owner
+	AcceptedAnswerId *uuid.UUID     `json:"acceptedAnswerId,omitempty" bson:"acceptedAnswerId,omitempty" db:"accepted_answer_id"` // Question posts: the comment the author accepted
+	SupporterOnly    bool           `json:"supporterOnly" bson:"supporterOnly" db:"is_supporter_only"`                            // Only the owner's supporters see more than a teaser
+	CommunityId      *uuid.UUID     `json:"communityId,omitempty" bson:"communityId,omitempty" db:"community_id"`                 // Community the post was made in; nil for the main feed
 
 	// Timestamps - both Unix timestamps and TIMESTAMPTZ for compatibility
 	CreatedDate int64     `json:"createdDate" bson:"createdDate" db:"created_date"`
@@ -51,6 +63,16 @@ type Post struct {
 	Album          *Album            `json:"album" bson:"album" db:"-"`                                  // Stored in metadata JSONB
 	AccessUserList []string          `json:"accessUserList" bson:"accessUserList" db:"-"`                // Stored in metadata JSONB
 	Metadata       JSONB             `json:"metadata,omitempty" bson:"metadata,omitempty" db:"metadata"` // Custom JSONB type
+
+	// Set on creation when the post looks like a duplicate but policy allowed it
+	DuplicateWarning *DuplicateMatch `json:"-" bson:"-" db:"-"`
+}
+
+// DuplicateMatch describes an existing post that a new post appears to duplicate
+type DuplicateMatch struct {
+	PostID     string  `json:"postId,omitempty"` // Empty when the matching post belongs to someone else and is not public
+	Similarity float64 `json:"similarity"`       // 1 for exact content matches
+	Reason     string  `json:"reason"`           // "exact" or "similar"
 }
 
 // JSONB is a custom type for PostgreSQL JSONB that implements sql.Scanner and driver.Valuer
@@ -156,6 +178,14 @@ type CreatePostRequest struct {
 	AccessUserList  []string   `json:"accessUserList,omitempty"`
 	Permission      string     `json:"permission,omitempty"`
 	Version         string     `json:"version,omitempty"`
+	AllowDuplicate  bool       `json:"allowDuplicate,omitempty"` // Admins only: post despite the "block" duplicate policy
+	VisibleUntil    int64      `json:"visibleUntil,omitempty"`   // Unix milliseconds; the post is archived and leaves feeds after this time
+	License         string     `json:"license,omitempty"`        // One of the accepted license identifiers
+	CanonicalURL    string     `json:"canonicalUrl,omitempty"`   // Absolute http(s) URL of the original source
+	ActingAs        *uuid.UUID `json:"actingAs,omitempty"`       // Publish as this account under its post:create delegation grant
+	Anonymous       bool       `json:"anonymous,omitempty"`      // Hide the author behind a per-thread pseudonym (needs a community that allows it)
+	SupporterOnly   bool       `json:"supporterOnly,omitempty"`  // Show non-supporters a teaser only (needs SUPPORTERS_ENABLED and a supporter tier)
+	CommunityId     *uuid.UUID `json:"communityId,omitempty"`    // Post in this community; the author must be a member
 	// Legacy compatibility fields
 	Score          int64 `json:"score,omitempty"`
 	ViewCount      int64 `json:"viewCount,omitempty"`
@@ -180,12 +210,17 @@ type UpdatePostRequest struct {
 	AccessUserList  *[]string  `json:"accessUserList,omitempty"`
 	Permission      *string    `json:"permission,omitempty"`
 	Version         *string    `json:"version,omitempty"`
+	VisibleUntil    *int64     `json:"visibleUntil,omitempty"` // Unix milliseconds; 0 removes the expiry
+	License         *string    `json:"license,omitempty"`      // Empty string clears the license
+	CanonicalURL    *string    `json:"canonicalUrl,omitempty"` // Empty string clears the canonical source
+	SupporterOnly   *bool      `json:"supporterOnly,omitempty"`
 }
 
 // PostQueryFilter represents query filters for posts
 type PostQueryFilter struct {
 	OwnerUserId *uuid.UUID `json:"ownerUserId,omitempty"`
 	PostTypeId  *int       `json:"postTypeId,omitempty"`
+	CommunityId *uuid.UUID `json:"communityId,omitempty"`
 	Tags        []string   `json:"tags,omitempty"`
 	Search      string     `json:"search,omitempty"`
 	Deleted     *bool      `json:"deleted,omitempty"`
@@ -197,6 +232,36 @@ type PostQueryFilter struct {
 	Limit         int    `json:"limit" validate:"min=1,max=100"`
 	SortField     string `json:"sortField,omitempty"`     // "createdDate", "score", "lastUpdated"
 	SortDirection string `json:"sortDirection,omitempty"` // "asc", "desc"
+	Snapshot      string `json:"snapshot,omitempty"`      // Pins the result set boundary while paging
+
+	// Sparse fieldset: PostResponse JSON fields to return; empty returns all fields
+	Fields []string `json:"fields,omitempty"`
+
+	// TruncateBodies drops the full body of truncated feed items so clients render
+	// bodyPreview with "read more"; nil uses the server default
+	TruncateBodies *bool `json:"truncateBodies,omitempty"`
+
+	// PublicOnly restricts results to posts with "Public" permission, for anonymous readers
+	PublicOnly bool `json:"publicOnly,omitempty"`
+
+	// ReusableOnly restricts results to posts under a license in ReusableLicenses
+	ReusableOnly bool `json:"reusableOnly,omitempty"`
+
+	// Answered restricts results to question posts with (true) or without (false) an accepted answer
+	Answered *bool `json:"answered,omitempty"`
+
+	// FollowedBy restricts results to posts by users this user follows (the "following" feed)
+	FollowedBy *uuid.UUID `json:"followedBy,omitempty"`
+
+	// NotInteractedBy drops posts this user has voted on, commented on or bookmarked, for catching up
+	NotInteractedBy *uuid.UUID `json:"notInteractedBy,omitempty"`
+
+	// IncludeComments set to IncludeCommentsPreview attaches the latest comments to each post
+	IncludeComments string `json:"includeComments,omitempty"`
+
+	// Range limits results to posts created within it (since/until). Anonymous readers may
+	// only ask for a bounded range; see PostsConfig.AnonymousMaxRange.
+	Range TimeRange `json:"range"`
 
 	// Legacy pagination (deprecated but maintained for backward compatibility)
 	Page         int        `json:"page,omitempty" validate:"min=1"`
@@ -214,7 +279,11 @@ type PostResponse struct {
 	Votes            map[string]string `json:"votes"`
 	ViewCount        int64             `json:"viewCount"`
 	IsBookmarked     bool              `json:"isBookmarked"`
+	Collections      []string          `json:"bookmarkCollections,omitempty"` // Viewer's bookmark collections holding the post
+	IsNew            bool              `json:"isNew"`                         // Created after the viewer's read marker for this feed
 	Body             string            `json:"body"`
+	BodyPreview      string            `json:"bodyPreview,omitempty"` // Feed items only: first paragraph, cut at a word boundary
+	IsTruncated      bool              `json:"isTruncated"`           // bodyPreview leaves part of the body out
 	OwnerUserId      string            `json:"ownerUserId"`
 	OwnerDisplayName string            `json:"ownerDisplayName"`
 	OwnerAvatar      string            `json:"ownerAvatar"`
@@ -234,9 +303,28 @@ type PostResponse struct {
 	LastUpdated      int64             `json:"lastUpdated,omitempty"`
 	Permission       string            `json:"permission"`
 	Version          string            `json:"version,omitempty"`
+	MediaText        string            `json:"mediaText,omitempty"`
+	VisibleUntil     int64             `json:"visibleUntil,omitempty"`
+	Archived         bool              `json:"archived"`
+	License          string            `json:"license,omitempty"`
+	CanonicalURL     string            `json:"canonicalUrl,omitempty"`
+	Anonymous        bool              `json:"anonymous,omitempty"` // Owner fields carry the thread pseudonym, never the real author
+	SupporterOnly    bool              `json:"supporterOnly,omitempty"`
+	CommunityId      string            `json:"communityId,omitempty"`
+	Locked           bool              `json:"locked,omitempty"`   // Supporter-only post the viewer does not support: body is a teaser, media removed
+	TipCount         int64             `json:"tipCount,omitempty"` // Only when TIPS_SHOW_COUNTS is set
+	AcceptedAnswerId string            `json:"acceptedAnswerId,omitempty"`
+	Answered         bool              `json:"answered,omitempty"` // Question posts whose author accepted an answer
+	Coauthors        []PostAuthor      `json:"coauthors,omitempty"`
 	LatestComments   []CommentPreview  `json:"latestComments,omitempty"`
 }
 
+// IncludeCommentsPreview is the includeComments value that attaches latestComments
+const IncludeCommentsPreview = "preview"
+
+// CommentPreviewCount is how many of the latest comments a preview carries per post
+const CommentPreviewCount = 2
+
 // CommentPreview is a lightweight view of a comment for feed previews
 type CommentPreview struct {
 	ObjectId         string `json:"objectId"`
@@ -256,6 +344,7 @@ type PostsListResponse struct {
 	PrevCursor string `json:"prevCursor,omitempty"`
 	HasNext    bool   `json:"hasNext"`
 	HasPrev    bool   `json:"hasPrev"`
+	Snapshot   string `json:"snapshot,omitempty"` // Pass back with later pages (or when restoring a position) to keep results stable
 
 	// Legacy pagination (deprecated but maintained for backward compatibility)
 	TotalCount int64 `json:"totalCount,omitempty"`
@@ -282,6 +371,9 @@ type CursorData struct {
 	Timestamp int64       `json:"timestamp"`
 	SortField string      `json:"sortField"`
 	Direction string      `json:"direction"`
+	Snapshot  int64       `json:"snapshot,omitempty"` // created_date boundary of the paging snapshot, 0 when unpinned
+
+	SnapshotIssuedAt int64 `json:"snapshotIssuedAt,omitempty"` // Unix seconds the paging snapshot was taken at
 }
 
 // Validate validates cursor data
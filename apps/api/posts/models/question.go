@@ -0,0 +1,17 @@
+package models
+
+import uuid "github.com/gofrs/uuid"
+
+// PostTypeQuestion marks a Q&A post: its author can accept one root comment as the
+// answer, which is pinned above the other comments and makes the post answered
+const PostTypeQuestion = 5
+
+// IsQuestion reports whether the post is a Q&A question
+func (p *Post) IsQuestion() bool {
+	return p.PostTypeId == PostTypeQuestion
+}
+
+// AcceptAnswerRequest selects the comment accepted as a question's answer
+type AcceptAnswerRequest struct {
+	CommentId uuid.UUID `json:"commentId"`
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// Feed keys identify a feed for read-state tracking: "home" for the main feed,
+// "following" for posts by followed users, "tag:<tag>" for a tag feed and "user:<uuid>"
+// for a profile feed
+const (
+	FeedKeyHome       = "home"
+	FeedKeyFollowing  = "following"
+	feedKeyTagPrefix  = "tag:"
+	feedKeyUserPrefix = "user:"
+	maxFeedKeyLength  = 300
+)
+
+// FeedRef is a parsed feed key
+type FeedRef struct {
+	Key         string
+	Tag         string
+	OwnerUserId *uuid.UUID
+	Following   bool // Posts by the users the reader follows
+}
+
+// ParseFeedKey validates a feed key and extracts the tag or owner it refers to
+func ParseFeedKey(key string) (FeedRef, error) {
+	key = strings.TrimSpace(key)
+	if key == "" || len(key) > maxFeedKeyLength {
+		return FeedRef{}, fmt.Errorf("invalid feed key %q", key)
+	}
+
+	switch {
+	case key == FeedKeyHome:
+		return FeedRef{Key: key}, nil
+	case key == FeedKeyFollowing:
+		return FeedRef{Key: key, Following: true}, nil
+	case strings.HasPrefix(key, feedKeyTagPrefix):
+		tag := strings.TrimPrefix(key, feedKeyTagPrefix)
+		if strings.TrimSpace(tag) == "" {
+			return FeedRef{}, fmt.Errorf("feed key %q is missing a tag", key)
+		}
+		return FeedRef{Key: key, Tag: tag}, nil
+	case strings.HasPrefix(key, feedKeyUserPrefix):
+		ownerID, err := uuid.FromString(strings.TrimPrefix(key, feedKeyUserPrefix))
+		if err != nil {
+			return FeedRef{}, fmt.Errorf("feed key %q has an invalid user ID", key)
+		}
+		return FeedRef{Key: feedKeyUserPrefix + ownerID.String(), OwnerUserId: &ownerID}, nil
+	default:
+		return FeedRef{}, fmt.Errorf("unknown feed key %q", key)
+	}
+}
+
+// FeedKeyForFilter returns the feed key a cursor query reads, or "" when the query
+// is not a plain feed (search, several tags, type, date or interaction filters)
+func FeedKeyForFilter(filter *PostQueryFilter) string {
+	if filter == nil || filter.Search != "" || filter.PostTypeId != nil || filter.CreatedAfter != nil || !filter.Range.IsZero() || filter.NotInteractedBy != nil || len(filter.Tags) > 1 {
+		return ""
+	}
+	if filter.FollowedBy != nil {
+		if filter.OwnerUserId != nil || len(filter.Tags) > 0 {
+			return ""
+		}
+		return FeedKeyFollowing
+	}
+	switch {
+	case filter.OwnerUserId != nil && len(filter.Tags) == 0:
+		return feedKeyUserPrefix + filter.OwnerUserId.String()
+	case filter.OwnerUserId == nil && len(filter.Tags) == 1:
+		return feedKeyTagPrefix + filter.Tags[0]
+	case filter.OwnerUserId == nil:
+		return FeedKeyHome
+	}
+	return ""
+}
+
+// MarkFeedReadRequest moves the caller's read marker for a feed
+type MarkFeedReadRequest struct {
+	Feed string `json:"feed"`
+	// LastReadDate is the createdDate of the newest post the client has shown; defaults to now
+	LastReadDate int64 `json:"lastReadDate,omitempty"`
+}
+
+// FeedUnreadCount reports how many posts in a feed arrived after the caller's read marker
+type FeedUnreadCount struct {
+	Feed         string `json:"feed"`
+	UnreadCount  int64  `json:"unreadCount"`
+	LastReadDate int64  `json:"lastReadDate"` // 0 when the feed has never been marked read
+}
@@ -0,0 +1,47 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFeedKey(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+
+	ref, err := ParseFeedKey("home")
+	assert.NoError(t, err)
+	assert.Equal(t, FeedRef{Key: "home"}, ref)
+
+	ref, err = ParseFeedKey("tag:golang")
+	assert.NoError(t, err)
+	assert.Equal(t, "golang", ref.Tag)
+
+	ref, err = ParseFeedKey("following")
+	assert.NoError(t, err)
+	assert.True(t, ref.Following)
+
+	ref, err = ParseFeedKey("user:" + userID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, userID, *ref.OwnerUserId)
+
+	for _, bad := range []string{"", "tag:", "user:nope", "group:1"} {
+		_, err := ParseFeedKey(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestFeedKeyForFilter(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	postType := 1
+
+	assert.Equal(t, "home", FeedKeyForFilter(&PostQueryFilter{}))
+	assert.Equal(t, "tag:go", FeedKeyForFilter(&PostQueryFilter{Tags: []string{"go"}}))
+	assert.Equal(t, "user:"+userID.String(), FeedKeyForFilter(&PostQueryFilter{OwnerUserId: &userID}))
+	assert.Empty(t, FeedKeyForFilter(&PostQueryFilter{Search: "go"}))
+	assert.Empty(t, FeedKeyForFilter(&PostQueryFilter{PostTypeId: &postType}))
+	assert.Empty(t, FeedKeyForFilter(&PostQueryFilter{OwnerUserId: &userID, Tags: []string{"go"}}))
+	assert.Equal(t, "following", FeedKeyForFilter(&PostQueryFilter{FollowedBy: &userID}))
+	assert.Empty(t, FeedKeyForFilter(&PostQueryFilter{FollowedBy: &userID, Tags: []string{"go"}}))
+}
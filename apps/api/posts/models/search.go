@@ -0,0 +1,102 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// HighlightStart and HighlightStop delimit matched terms in the fragments Postgres
+// returns from ts_headline. They are private-use characters so they survive HTML
+// escaping and cannot be confused with markup in the post body.
+const (
+	HighlightStart = "\ue000"
+	HighlightStop  = "\ue001"
+)
+
+// PostSearchQuery describes one page of a full-text post search. Query uses web search
+// syntax: "quoted phrases" match in order, OR joins alternatives and -word excludes.
+type PostSearchQuery struct {
+	Query string
+	Tags  []string // Posts must carry at least one of these tags
+	After *SearchCursor
+	Limit int
+}
+
+// SearchCursor marks where a search page ended; results are ordered by rank, then id
+type SearchCursor struct {
+	Rank float32 `json:"rank"`
+	ID   string  `json:"id"`
+}
+
+// EncodeSearchCursor encodes a search cursor into a base64 string
+func EncodeSearchCursor(cursor SearchCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// DecodeSearchCursor decodes a search cursor produced by EncodeSearchCursor
+func DecodeSearchCursor(token string) (*SearchCursor, error) {
+	decoded, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode search cursor: %w", err)
+	}
+
+	var cursor SearchCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal search cursor: %w", err)
+	}
+	if _, err := uuid.FromString(cursor.ID); err != nil {
+		return nil, fmt.Errorf("invalid search cursor id: %w", err)
+	}
+
+	return &cursor, nil
+}
+
+// SemanticSearchQuery describes a search for posts by meaning rather than keywords.
+// Empty filters match every post.
+type SemanticSearchQuery struct {
+	Query       string
+	Limit       int
+	CommunityID string
+	AuthorID    *uuid.UUID
+	Range       TimeRange
+}
+
+// SemanticSearchResult is a post matched by semantic search
+type SemanticSearchResult struct {
+	PostResponse
+	Score   float64 `json:"score"`   // Similarity to the query, 0 to 1
+	Snippet string  `json:"snippet"` // Plain-text excerpt; empty on locked supporter-only posts
+}
+
+// SemanticSearchResponse lists the matched posts, best match first
+type SemanticSearchResponse struct {
+	Results       []SemanticSearchResult `json:"results"`
+	QuotaExceeded bool                   `json:"quotaExceeded"` // The caller's AI quota or the AI budget is spent; Results is empty until it resets
+}
+
+// HighlightHTML escapes a ts_headline fragment and wraps the matched terms in <mark>
+// so clients can render it as HTML
+func HighlightHTML(fragment string) string {
+	escaped := html.EscapeString(fragment)
+	return strings.NewReplacer(HighlightStart, "<mark>", HighlightStop, "</mark>").Replace(escaped)
+}
+
+// PostSearchResult is a post matched by full-text search
+type PostSearchResult struct {
+	PostResponse
+	Rank      float32 `json:"rank"`
+	Highlight string  `json:"highlight"` // Escaped HTML excerpt with matches wrapped in <mark>
+}
+
+// PostSearchResponse is a page of full-text search results, best match first
+type PostSearchResponse struct {
+	Results    []PostSearchResult `json:"results"`
+	NextCursor string             `json:"nextCursor,omitempty"`
+	HasNext    bool               `json:"hasNext"`
+}
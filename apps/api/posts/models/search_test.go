@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCursorRoundTrip(t *testing.T) {
+	cursor := SearchCursor{Rank: 0.0607927, ID: uuid.Must(uuid.NewV4()).String()}
+
+	decoded, err := DecodeSearchCursor(EncodeSearchCursor(cursor))
+	require.NoError(t, err)
+	assert.Equal(t, cursor, *decoded)
+
+	for _, bad := range []string{"not-base64!", EncodeSearchCursor(SearchCursor{Rank: 1, ID: "nope"})} {
+		_, err := DecodeSearchCursor(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestHighlightHTML(t *testing.T) {
+	fragment := "use " + HighlightStart + "<b>generics</b>" + HighlightStop + " & interfaces"
+	assert.Equal(t, "use <mark>&lt;b&gt;generics&lt;/b&gt;</mark> &amp; interfaces", HighlightHTML(fragment))
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SnapshotMaxAge bounds how long a feed snapshot may pin its result set. Older
+// snapshots are rejected so a client restarts from the top instead of paging a stale feed.
+const SnapshotMaxAge = 24 * time.Hour
+
+// SnapshotData pins the boundary of a paged result set. Posts created after
+// CreatedBefore are hidden while paging so new posts cannot shift pages and cause duplicates.
+type SnapshotData struct {
+	CreatedBefore int64 `json:"createdBefore"` // Inclusive upper bound on created_date
+	IssuedAt      int64 `json:"issuedAt"`      // Unix seconds
+}
+
+// NewSnapshot creates a snapshot whose boundary is the given created_date value
+func NewSnapshot(createdBefore int64, now time.Time) *SnapshotData {
+	return &SnapshotData{CreatedBefore: createdBefore, IssuedAt: now.Unix()}
+}
+
+// Validate checks the snapshot boundary and age
+func (sd *SnapshotData) Validate(now time.Time) error {
+	if sd.CreatedBefore <= 0 {
+		return errors.New("snapshot boundary must be positive")
+	}
+	if now.Sub(time.Unix(sd.IssuedAt, 0)) > SnapshotMaxAge {
+		return errors.New("snapshot expired")
+	}
+	return nil
+}
+
+// EncodeSnapshot encodes snapshot data into an opaque token
+func EncodeSnapshot(data *SnapshotData) (string, error) {
+	if data == nil {
+		return "", nil
+	}
+
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(jsonData), nil
+}
+
+// DecodeSnapshot decodes and validates a snapshot token
+func DecodeSnapshot(token string, now time.Time) (*SnapshotData, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+
+	var data SnapshotData
+	if err := json.Unmarshal(decoded, &data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+	}
+
+	if err := data.Validate(now); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	return &data, nil
+}
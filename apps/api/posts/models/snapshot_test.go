@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	now := time.Now()
+	token, err := EncodeSnapshot(NewSnapshot(1700000000000, now))
+	require.NoError(t, err)
+
+	decoded, err := DecodeSnapshot(token, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1700000000000), decoded.CreatedBefore)
+
+	_, err = DecodeSnapshot(token, now.Add(SnapshotMaxAge+time.Minute))
+	assert.Error(t, err, "expired snapshots are rejected")
+
+	empty, err := DecodeSnapshot("", now)
+	assert.NoError(t, err)
+	assert.Nil(t, empty)
+}
+
+func TestCreateSnapshotCursorFromPost(t *testing.T) {
+	post := &Post{CreatedDate: 42}
+	cursor, err := CreateSnapshotCursorFromPost(post, "createdDate", "desc", &SnapshotData{CreatedBefore: 99, IssuedAt: 7})
+	require.NoError(t, err)
+
+	data, err := DecodeCursor(cursor)
+	require.NoError(t, err)
+	assert.Equal(t, int64(99), data.Snapshot)
+	assert.Equal(t, int64(7), data.SnapshotIssuedAt)
+
+	cursor, err = CreateCursorFromPost(post, "createdDate", "desc")
+	require.NoError(t, err)
+	data, err = DecodeCursor(cursor)
+	require.NoError(t, err)
+	assert.Zero(t, data.Snapshot)
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// TrendingWindow is a rolling window trending tags are counted over
+type TrendingWindow struct {
+	Name   string
+	Window time.Duration
+}
+
+// TrendingWindows lists the supported windows
+var TrendingWindows = []TrendingWindow{
+	{Name: "1h", Window: time.Hour},
+	{Name: "24h", Window: 24 * time.Hour},
+	{Name: "7d", Window: 7 * 24 * time.Hour},
+}
+
+// DefaultTrendingWindow is used when a request does not name one
+const DefaultTrendingWindow = "24h"
+
+// FindTrendingWindow returns the window with the given name
+func FindTrendingWindow(name string) (TrendingWindow, bool) {
+	for _, window := range TrendingWindows {
+		if window.Name == name {
+			return window, true
+		}
+	}
+	return TrendingWindow{}, false
+}
+
+// TrendingTag is a tag with the number of public posts that used it in a window
+type TrendingTag struct {
+	Rank      int    `json:"rank" db:"rank"`
+	Tag       string `json:"tag" db:"tag"`
+	PostCount int64  `json:"postCount" db:"post_count"`
+}
+
+// TrendingTagsResponse holds the top tags of a window, most used first. ComputedDate is
+// when they were last counted; 0 when they have not been computed yet.
+type TrendingTagsResponse struct {
+	Window       string        `json:"window"`
+	ComputedDate int64         `json:"computedDate"`
+	Tags         []TrendingTag `json:"tags"`
+}
+
+// TagCursor marks where a tag page ended; posts are ordered newest first, then by id
+type TagCursor struct {
+	CreatedDate int64  `json:"createdDate"`
+	ID          string `json:"id"`
+}
+
+// EncodeTagCursor encodes a tag cursor into a base64 string
+func EncodeTagCursor(cursor TagCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.URLEncoding.EncodeToString(data)
+}
+
+// DecodeTagCursor decodes a tag cursor produced by EncodeTagCursor
+func DecodeTagCursor(token string) (*TagCursor, error) {
+	decoded, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tag cursor: %w", err)
+	}
+
+	var cursor TagCursor
+	if err := json.Unmarshal(decoded, &cursor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tag cursor: %w", err)
+	}
+	if _, err := uuid.FromString(cursor.ID); err != nil {
+		return nil, fmt.Errorf("invalid tag cursor id: %w", err)
+	}
+
+	return &cursor, nil
+}
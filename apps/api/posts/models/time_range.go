@@ -0,0 +1,98 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Periods accepted by ParseTimeRange, counted from the start of the current UTC day,
+// ISO week (Monday) or month
+const (
+	PeriodToday = "today"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// TimeRange limits posts to a created_date in [Since, Until), Unix milliseconds. A zero
+// bound leaves that side open.
+type TimeRange struct {
+	Since int64 `json:"since,omitempty"`
+	Until int64 `json:"until,omitempty"`
+}
+
+// IsZero reports whether the range leaves both sides open
+func (r TimeRange) IsZero() bool {
+	return r.Since == 0 && r.Until == 0
+}
+
+// Span returns how much time the range covers, with an open end taken as now. It
+// returns false when the range has no start and so reaches back indefinitely.
+func (r TimeRange) Span(now time.Time) (time.Duration, bool) {
+	if r.Since == 0 {
+		return 0, false
+	}
+	until := r.Until
+	if until == 0 {
+		until = now.UnixMilli()
+	}
+	return time.Duration(until-r.Since) * time.Millisecond, true
+}
+
+// ParseTimeRange reads the since/until query parameters, each Unix milliseconds or
+// RFC 3339, or a named period (today, week or month) in place of since
+func ParseTimeRange(period, since, until string, now time.Time) (TimeRange, error) {
+	var r TimeRange
+	var err error
+	switch {
+	case period != "" && since != "":
+		return r, fmt.Errorf("period and since cannot be combined")
+	case period != "":
+		if r.Since, err = periodStart(period, now); err != nil {
+			return r, err
+		}
+	case since != "":
+		if r.Since, err = parseTimestamp(since); err != nil {
+			return r, fmt.Errorf("since %w", err)
+		}
+	}
+	if until != "" {
+		if r.Until, err = parseTimestamp(until); err != nil {
+			return r, fmt.Errorf("until %w", err)
+		}
+	}
+	if r.Since > 0 && r.Until > 0 && r.Since >= r.Until {
+		return r, fmt.Errorf("since must be before until")
+	}
+	return r, nil
+}
+
+// periodStart returns the start of the named period containing now, Unix milliseconds
+func periodStart(period string, now time.Time) (int64, error) {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch period {
+	case PeriodToday:
+		return day.UnixMilli(), nil
+	case PeriodWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7).UnixMilli(), nil
+	case PeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC).UnixMilli(), nil
+	}
+	return 0, fmt.Errorf("period must be %s, %s or %s", PeriodToday, PeriodWeek, PeriodMonth)
+}
+
+// parseTimestamp reads Unix milliseconds or an RFC 3339 time
+func parseTimestamp(value string) (int64, error) {
+	if ms, err := strconv.ParseInt(value, 10, 64); err == nil {
+		if ms <= 0 {
+			return 0, fmt.Errorf("must be a positive Unix time in milliseconds")
+		}
+		return ms, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return 0, fmt.Errorf("must be Unix milliseconds or an RFC 3339 time")
+	}
+	return t.UnixMilli(), nil
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeRange(t *testing.T) {
+	// A Thursday afternoon
+	now := time.Date(2026, time.October, 15, 14, 30, 0, 0, time.UTC)
+
+	for period, want := range map[string]time.Time{
+		PeriodToday: time.Date(2026, time.October, 15, 0, 0, 0, 0, time.UTC),
+		PeriodWeek:  time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC),
+		PeriodMonth: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		r, err := ParseTimeRange(period, "", "", now)
+		require.NoError(t, err, period)
+		assert.Equal(t, TimeRange{Since: want.UnixMilli()}, r, period)
+	}
+
+	r, err := ParseTimeRange("", "1700000000000", "2026-10-01T00:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, TimeRange{Since: 1700000000000, Until: time.Date(2026, time.October, 1, 0, 0, 0, 0, time.UTC).UnixMilli()}, r)
+
+	for _, bad := range [][3]string{
+		{"today", "1700000000000", ""},
+		{"yesterday", "", ""},
+		{"", "last tuesday", ""},
+		{"", "-5", ""},
+		{"", "1700000000000", "1600000000000"},
+	} {
+		_, err := ParseTimeRange(bad[0], bad[1], bad[2], now)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestTimeRangeSpan(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+
+	span, ok := TimeRange{Since: now.Add(-48 * time.Hour).UnixMilli()}.Span(now)
+	assert.True(t, ok)
+	assert.Equal(t, 48*time.Hour, span)
+
+	_, ok = TimeRange{Until: now.UnixMilli()}.Span(now)
+	assert.False(t, ok, "a range without a start is unbounded")
+}
@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// answeredClause keeps question posts that have (or lack) an accepted answer
+func answeredClause(answered bool) string {
+	state := "NULL"
+	if answered {
+		state = "NOT NULL"
+	}
+	return fmt.Sprintf(" AND post_type_id = %d AND accepted_answer_id IS %s", models.PostTypeQuestion, state)
+}
+
+// SetAcceptedAnswer records or clears the accepted answer of a question the owner wrote.
+// Ownership validation is embedded in the WHERE clause for atomicity and security.
+func (r *postgresRepository) SetAcceptedAnswer(ctx context.Context, postID uuid.UUID, commentID *uuid.UUID, ownerID uuid.UUID) error {
+	query := fmt.Sprintf(`
+		UPDATE posts
+		SET accepted_answer_id = $2, updated_at = NOW()
+		WHERE id = $1 AND owner_user_id = $3 AND post_type_id = %d AND is_deleted = FALSE`, models.PostTypeQuestion)
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, postID, commentID, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to set accepted answer: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("question not found, deleted, or not owned by user: %s", postID.String())
+	}
+	return nil
+}
+
+// ClearAcceptedAnswer unmarks a comment that is some question's accepted answer
+func (r *postgresRepository) ClearAcceptedAnswer(ctx context.Context, commentID uuid.UUID) error {
+	query := `UPDATE posts SET accepted_answer_id = NULL, updated_at = NOW() WHERE accepted_answer_id = $1`
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, commentID); err != nil {
+		return fmt.Errorf("failed to clear accepted answer: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+const coauthorColumns = `post_id, user_id, invited_by, status, display_name, avatar, created_date, responded_date`
+
+// editorClause limits a posts UPDATE to rows the user in $3 owns or co-authors
+const editorClause = ` AND (owner_user_id = $3 OR EXISTS (
+			SELECT 1 FROM post_coauthors pc WHERE pc.post_id = posts.id AND pc.user_id = $3 AND pc.status = 'accepted'))`
+
+// ownerClause matches posts owned by the user in $argIndex and, with IncludeCoauthored,
+// posts that user co-authors. Anonymous posts are left out unless IncludeAnonymous is set.
+func ownerClause(argIndex int, filter PostFilter) string {
+	clause := fmt.Sprintf(" AND owner_user_id = $%d", argIndex)
+	if filter.IncludeCoauthored {
+		clause = fmt.Sprintf(" AND (owner_user_id = $%[1]d OR id IN (SELECT post_id FROM post_coauthors WHERE user_id = $%[1]d AND status = 'accepted'))", argIndex)
+	}
+	if !filter.IncludeAnonymous {
+		clause += notAnonymousClause
+	}
+	return clause
+}
+
+// AddCoauthor stores a pending invitation unless the user already has one for the post
+func (r *postgresRepository) AddCoauthor(ctx context.Context, coauthor *models.PostCoauthor) (bool, error) {
+	query := `
+		INSERT INTO post_coauthors (post_id, user_id, invited_by, status, created_date)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (post_id, user_id) DO NOTHING`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		coauthor.PostId, coauthor.UserId, coauthor.InvitedBy, coauthor.Status, coauthor.CreatedDate)
+	if err != nil {
+		return false, fmt.Errorf("failed to add co-author: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// GetCoauthor returns the user's invitation for a post
+func (r *postgresRepository) GetCoauthor(ctx context.Context, postID, userID uuid.UUID) (*models.PostCoauthor, error) {
+	query := `SELECT ` + coauthorColumns + ` FROM post_coauthors WHERE post_id = $1 AND user_id = $2`
+
+	var coauthor models.PostCoauthor
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &coauthor, query, postID, userID); err != nil {
+		return nil, err
+	}
+	return &coauthor, nil
+}
+
+// AcceptCoauthor turns a pending invitation into a co-authorship
+func (r *postgresRepository) AcceptCoauthor(ctx context.Context, postID, userID uuid.UUID, displayName, avatar string, respondedDate int64) (bool, error) {
+	query := `
+		UPDATE post_coauthors
+		SET status = 'accepted', display_name = $3, avatar = $4, responded_date = $5
+		WHERE post_id = $1 AND user_id = $2 AND status = 'pending'`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, postID, userID, displayName, avatar, respondedDate)
+	if err != nil {
+		return false, fmt.Errorf("failed to accept co-author invitation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// RemoveCoauthor deletes the user's invitation or co-authorship
+func (r *postgresRepository) RemoveCoauthor(ctx context.Context, postID, userID uuid.UUID) (bool, error) {
+	query := `DELETE FROM post_coauthors WHERE post_id = $1 AND user_id = $2`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, postID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove co-author: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// DeleteCoauthorsByUser deletes the user's invitations and co-authorships on all posts
+func (r *postgresRepository) DeleteCoauthorsByUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM post_coauthors WHERE user_id = $1`
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete co-authorships: %w", err)
+	}
+	return nil
+}
+
+// ListCoauthors loads the co-authors of several posts in one query
+func (r *postgresRepository) ListCoauthors(ctx context.Context, postIDs []uuid.UUID, status string) (map[uuid.UUID][]models.PostCoauthor, error) {
+	byPost := make(map[uuid.UUID][]models.PostCoauthor, len(postIDs))
+	if len(postIDs) == 0 {
+		return byPost, nil
+	}
+
+	query := `SELECT ` + coauthorColumns + ` FROM post_coauthors
+		WHERE post_id = ANY($1::uuid[]) AND ($2 = '' OR status = $2)
+		ORDER BY created_date ASC, user_id ASC`
+
+	ids := make([]string, len(postIDs))
+	for i, id := range postIDs {
+		ids[i] = id.String()
+	}
+
+	var rows []models.PostCoauthor
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, pq.Array(ids), status); err != nil {
+		return nil, fmt.Errorf("failed to list co-authors: %w", err)
+	}
+
+	for _, row := range rows {
+		byPost[row.PostId] = append(byPost[row.PostId], row)
+	}
+	return byPost, nil
+}
+
+// ListPendingCoauthorInvites returns the invitations waiting on the user
+func (r *postgresRepository) ListPendingCoauthorInvites(ctx context.Context, userID uuid.UUID) ([]models.PostCoauthor, error) {
+	query := `
+		SELECT pc.post_id, pc.user_id, pc.invited_by, pc.status, pc.display_name, pc.avatar, pc.created_date, pc.responded_date
+		FROM post_coauthors pc
+		JOIN posts p ON p.id = pc.post_id
+		WHERE pc.user_id = $1 AND pc.status = 'pending' AND p.is_deleted = FALSE
+		ORDER BY pc.created_date DESC`
+
+	var invites []models.PostCoauthor
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &invites, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list co-author invitations: %w", err)
+	}
+	return invites, nil
+}
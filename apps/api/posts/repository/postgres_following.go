@@ -0,0 +1,9 @@
+package repository
+
+import "fmt"
+
+// followedByClause keeps posts by the users the follower bound to argIndex follows. The
+// follows primary key (follower_id, followee_id) serves the subquery.
+func followedByClause(argIndex int) string {
+	return fmt.Sprintf(" AND owner_user_id IN (SELECT followee_id FROM follows WHERE follower_id = $%d)", argIndex) + notAnonymousClause
+}
@@ -0,0 +1,12 @@
+package repository
+
+import "fmt"
+
+// notInteractedClause drops posts the user bound to argIndex has voted on, commented on
+// or bookmarked. Each subquery is an index probe: votes (post_id, owner_user_id), comments
+// (post_id) and bookmarks (owner_user_id, post_id).
+func notInteractedClause(argIndex int) string {
+	return fmt.Sprintf(" AND NOT EXISTS (SELECT 1 FROM votes v WHERE v.post_id = posts.id AND v.owner_user_id = $%[1]d)"+
+		" AND NOT EXISTS (SELECT 1 FROM comments c WHERE c.post_id = posts.id AND c.owner_user_id = $%[1]d)"+
+		" AND NOT EXISTS (SELECT 1 FROM bookmarks b WHERE b.owner_user_id = $%[1]d AND b.post_id = posts.id)", argIndex)
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+const liveThreadColumns = `post_id, starts_at, ends_at, slow_mode_seconds, closed_date, summary, key_points, summary_attempts, created_date`
+
+// SaveLiveThread schedules a live thread or moves the window of one that has not closed
+func (r *postgresRepository) SaveLiveThread(ctx context.Context, thread *models.LiveThread) error {
+	query := `
+		INSERT INTO post_live_threads (post_id, starts_at, ends_at, slow_mode_seconds, created_date)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (post_id) DO UPDATE SET
+			starts_at = EXCLUDED.starts_at,
+			ends_at = EXCLUDED.ends_at,
+			slow_mode_seconds = EXCLUDED.slow_mode_seconds
+		WHERE post_live_threads.closed_date = 0`
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		thread.PostId, thread.StartsAt, thread.EndsAt, thread.SlowModeSeconds, thread.CreatedDate); err != nil {
+		return fmt.Errorf("failed to save live thread: %w", err)
+	}
+	return nil
+}
+
+// GetLiveThread returns the post's live thread
+func (r *postgresRepository) GetLiveThread(ctx context.Context, postID uuid.UUID) (*models.LiveThread, error) {
+	query := `SELECT ` + liveThreadColumns + ` FROM post_live_threads WHERE post_id = $1`
+
+	var thread models.LiveThread
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &thread, query, postID); err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+// DeleteLiveThread removes a live thread that has not closed
+func (r *postgresRepository) DeleteLiveThread(ctx context.Context, postID uuid.UUID) (bool, error) {
+	query := `DELETE FROM post_live_threads WHERE post_id = $1 AND closed_date = 0`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, postID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete live thread: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// CloseEndedLiveThreads closes up to limit threads whose window ended at or before now
+// and disables comments on their posts in the same statement
+func (r *postgresRepository) CloseEndedLiveThreads(ctx context.Context, now int64, limit int) ([]*models.LiveThread, error) {
+	query := `
+		WITH closed AS (
+			UPDATE post_live_threads SET closed_date = $1
+			WHERE post_id IN (
+				SELECT post_id FROM post_live_threads
+				WHERE closed_date = 0 AND ends_at <= $1
+				ORDER BY ends_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING ` + liveThreadColumns + `
+		), frozen AS (
+			UPDATE posts SET disable_comments = TRUE, updated_at = NOW()
+			WHERE id IN (SELECT post_id FROM closed)
+		)
+		SELECT ` + liveThreadColumns + ` FROM closed`
+
+	threads := []*models.LiveThread{}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &threads, query, now, limit); err != nil {
+		return nil, fmt.Errorf("failed to close live threads: %w", err)
+	}
+	return threads, nil
+}
+
+// ListUnsummarizedLiveThreads returns closed threads without a summary that have been
+// tried fewer than maxAttempts times, oldest first
+func (r *postgresRepository) ListUnsummarizedLiveThreads(ctx context.Context, maxAttempts, limit int) ([]*models.LiveThread, error) {
+	query := `
+		SELECT ` + liveThreadColumns + ` FROM post_live_threads
+		WHERE closed_date > 0 AND summary = '' AND summary_attempts < $1
+		ORDER BY closed_date
+		LIMIT $2`
+
+	threads := []*models.LiveThread{}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &threads, query, maxAttempts, limit); err != nil {
+		return nil, fmt.Errorf("failed to list unsummarized live threads: %w", err)
+	}
+	return threads, nil
+}
+
+// SaveLiveThreadSummary records a summary attempt; an empty summary only counts the attempt
+func (r *postgresRepository) SaveLiveThreadSummary(ctx context.Context, postID uuid.UUID, summary string, keyPoints []string) error {
+	if keyPoints == nil {
+		keyPoints = []string{}
+	}
+	query := `
+		UPDATE post_live_threads
+		SET summary = $2, key_points = $3, summary_attempts = summary_attempts + 1
+		WHERE post_id = $1`
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, postID, summary, pq.StringArray(keyPoints)); err != nil {
+		return fmt.Errorf("failed to save live thread summary: %w", err)
+	}
+	return nil
+}
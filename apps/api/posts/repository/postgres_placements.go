@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// communityClause matches posts placed in the community in $argIndex, whether they were
+// made there or shared into it
+func communityClause(argIndex int) string {
+	return fmt.Sprintf(" AND EXISTS (SELECT 1 FROM post_community_placements pl WHERE pl.post_id = posts.id AND pl.community_id = $%d)", argIndex)
+}
+
+// AddCommunityPlacement places a post in a community unless it is there already
+func (r *postgresRepository) AddCommunityPlacement(ctx context.Context, placement *models.CommunityPlacement) (bool, error) {
+	query := `
+		INSERT INTO post_community_placements (post_id, community_id, placed_by, placed_date)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (post_id, community_id) DO NOTHING`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		placement.PostId, placement.CommunityId, placement.PlacedBy, placement.PlacedDate)
+	if err != nil {
+		return false, fmt.Errorf("failed to place post in community: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// RemoveCommunityPlacement takes a post out of one community's feed
+func (r *postgresRepository) RemoveCommunityPlacement(ctx context.Context, postID, communityID uuid.UUID) (bool, error) {
+	query := `DELETE FROM post_community_placements WHERE post_id = $1 AND community_id = $2`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, postID, communityID)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove post from community: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected > 0, nil
+}
+
+// ListCommunityPlacements returns the communities a post is placed in, oldest placement first
+func (r *postgresRepository) ListCommunityPlacements(ctx context.Context, postID uuid.UUID) ([]models.CommunityPlacement, error) {
+	query := `
+		SELECT post_id, community_id, placed_by, placed_date
+		FROM post_community_placements
+		WHERE post_id = $1
+		ORDER BY placed_date ASC, community_id ASC`
+
+	var placements []models.CommunityPlacement
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &placements, query, postID); err != nil {
+		return nil, fmt.Errorf("failed to list community placements: %w", err)
+	}
+	return placements, nil
+}
@@ -10,7 +10,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
-	"strings"
+	"slices"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -64,13 +64,13 @@ func (r *postgresRepository) Create(ctx context.Context, post *models.Post) erro
 		comment_count, is_deleted, deleted_date, created_at, updated_at,
 		created_date, last_updated, tags, url_key, owner_display_name,
 		owner_avatar, image, image_full_path, video, thumbnail,
-		disable_comments, disable_sharing, permission, version, metadata
+		disable_comments, disable_sharing, permission, version, content_hash, visible_until, is_archived, license, canonical_url, is_anonymous, anonymous_alias, is_supporter_only, community_id, metadata
 	) VALUES (
 		:id, :owner_user_id, :post_type_id, :body, :score, :view_count,
 		:comment_count, :is_deleted, :deleted_date, :created_at, :updated_at,
 		:created_date, :last_updated, :tags, :url_key, :owner_display_name,
 		:owner_avatar, :image, :image_full_path, :video, :thumbnail,
-		:disable_comments, :disable_sharing, :permission, :version, :metadata
+		:disable_comments, :disable_sharing, :permission, :version, :content_hash, :visible_until, :is_archived, :license, :canonical_url, :is_anonymous, :anonymous_alias, :is_supporter_only, :community_id, :metadata
 	)`
 
 	// Set timestamps if not set
@@ -114,6 +114,15 @@ func (r *postgresRepository) Create(ctx context.Context, post *models.Post) erro
 		DisableSharing   bool            `db:"disable_sharing"`
 		Permission       string          `db:"permission"`
 		Version          string          `db:"version"`
+		ContentHash      string          `db:"content_hash"`
+		VisibleUntil     int64           `db:"visible_until"`
+		IsArchived       bool            `db:"is_archived"`
+		License          string          `db:"license"`
+		CanonicalURL     string          `db:"canonical_url"`
+		IsAnonymous      bool            `db:"is_anonymous"`
+		AnonymousAlias   string          `db:"anonymous_alias"`
+		IsSupporterOnly  bool            `db:"is_supporter_only"`
+		CommunityID      *uuid.UUID      `db:"community_id"`
 		Metadata         json.RawMessage `db:"metadata"`
 	}{
 		ID:               post.ObjectId,
@@ -141,6 +150,15 @@ func (r *postgresRepository) Create(ctx context.Context, post *models.Post) erro
 		DisableSharing:   post.DisableSharing,
 		Permission:       post.Permission,
 		Version:          post.Version,
+		ContentHash:      post.ContentHash,
+		VisibleUntil:     post.VisibleUntil,
+		IsArchived:       post.Archived,
+		License:          post.License,
+		CanonicalURL:     post.CanonicalURL,
+		IsAnonymous:      post.Anonymous,
+		AnonymousAlias:   post.AnonymousAlias,
+		IsSupporterOnly:  post.SupporterOnly,
+		CommunityID:      post.CommunityId,
 		Metadata:         metadata,
 	}
 
@@ -149,7 +167,18 @@ func (r *postgresRepository) Create(ctx context.Context, post *models.Post) erro
 	if err != nil {
 		return err
 	}
-	return nil
+	// A community post starts out placed in the community it was made in
+	if post.CommunityId != nil {
+		if _, err := r.AddCommunityPlacement(ctx, &models.CommunityPlacement{
+			PostId:      post.ObjectId,
+			CommunityId: *post.CommunityId,
+			PlacedBy:    post.OwnerUserId,
+			PlacedDate:  post.CreatedDate,
+		}); err != nil {
+			return err
+		}
+	}
+	return r.syncPostTags(ctx, post.ObjectId, post.Tags)
 }
 
 // FindByID retrieves a post by its ID
@@ -160,7 +189,7 @@ func (r *postgresRepository) FindByID(ctx context.Context, id uuid.UUID) (*model
 			comment_count, is_deleted, deleted_date, created_at, updated_at,
 			created_date, last_updated, tags, url_key, owner_display_name,
 			owner_avatar, image, image_full_path, video, thumbnail,
-			disable_comments, disable_sharing, permission, version, metadata
+			disable_comments, disable_sharing, permission, version, media_text, content_hash, visible_until, is_archived, license, canonical_url, is_anonymous, anonymous_alias, is_supporter_only, tip_count, accepted_answer_id, community_id, metadata
 		FROM posts
 		WHERE id = $1 AND is_deleted = FALSE
 	`
@@ -192,9 +221,9 @@ func (r *postgresRepository) FindByUser(ctx context.Context, userID uuid.UUID, l
 			comment_count, is_deleted, deleted_date, created_at, updated_at,
 			created_date, last_updated, tags, url_key, owner_display_name,
 			owner_avatar, image, image_full_path, video, thumbnail,
-			disable_comments, disable_sharing, permission, version, metadata
+			disable_comments, disable_sharing, permission, version, media_text, content_hash, visible_until, is_archived, license, canonical_url, is_anonymous, anonymous_alias, is_supporter_only, tip_count, accepted_answer_id, community_id, metadata
 		FROM posts
-		WHERE owner_user_id = $1 AND is_deleted = FALSE
+		WHERE owner_user_id = $1 AND is_deleted = FALSE` + visibleInFeedsClause + notAnonymousClause + `
 		ORDER BY created_at DESC, id DESC
 		LIMIT $2 OFFSET $3
 	`
@@ -252,6 +281,12 @@ func (r *postgresRepository) Update(ctx context.Context, post *models.Post) erro
 			disable_sharing = :disable_sharing,
 			permission = :permission,
 			version = :version,
+			content_hash = :content_hash,
+			visible_until = :visible_until,
+			is_archived = :is_archived,
+			license = :license,
+			canonical_url = :canonical_url,
+			is_supporter_only = :is_supporter_only,
 			metadata = :metadata
 		WHERE id = :id
 	`
@@ -280,6 +315,12 @@ func (r *postgresRepository) Update(ctx context.Context, post *models.Post) erro
 		DisableSharing   bool            `db:"disable_sharing"`
 		Permission       string          `db:"permission"`
 		Version          string          `db:"version"`
+		ContentHash      string          `db:"content_hash"`
+		VisibleUntil     int64           `db:"visible_until"`
+		IsArchived       bool            `db:"is_archived"`
+		License          string          `db:"license"`
+		CanonicalURL     string          `db:"canonical_url"`
+		IsSupporterOnly  bool            `db:"is_supporter_only"`
 		Metadata         json.RawMessage `db:"metadata"`
 	}{
 		ID:               post.ObjectId,
@@ -305,6 +346,12 @@ func (r *postgresRepository) Update(ctx context.Context, post *models.Post) erro
 		DisableSharing:   post.DisableSharing,
 		Permission:       post.Permission,
 		Version:          post.Version,
+		ContentHash:      post.ContentHash,
+		VisibleUntil:     post.VisibleUntil,
+		IsArchived:       post.Archived,
+		License:          post.License,
+		CanonicalURL:     post.CanonicalURL,
+		IsSupporterOnly:  post.SupporterOnly,
 		Metadata:         metadata,
 	}
 
@@ -322,7 +369,7 @@ func (r *postgresRepository) Update(ctx context.Context, post *models.Post) erro
 		return fmt.Errorf("post not found")
 	}
 
-	return nil
+	return r.syncPostTags(ctx, post.ObjectId, post.Tags)
 }
 
 // IncrementViewCount atomically increments the view count for a post
@@ -346,6 +393,27 @@ func (r *postgresRepository) IncrementViewCount(ctx context.Context, postID uuid
 	return nil
 }
 
+// IncrementTipCount atomically adds delta to the tip count of a post, deleted or not
+func (r *postgresRepository) IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error {
+	query := `UPDATE posts SET tip_count = GREATEST(tip_count + $1, 0) WHERE id = $2`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, delta, postID)
+	if err != nil {
+		return fmt.Errorf("failed to increment tip count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("post not found")
+	}
+
+	return nil
+}
+
 // IncrementCommentCount atomically increments the comment count for a post
 func (r *postgresRepository) IncrementCommentCount(ctx context.Context, postID uuid.UUID, delta int) error {
 	query := `UPDATE posts SET comment_count = comment_count + $1, updated_at = NOW(), last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT WHERE id = $2 AND is_deleted = FALSE`
@@ -422,6 +490,20 @@ func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// DeleteByOwner permanently deletes all posts of a user
+func (r *postgresRepository) DeleteByOwner(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	result, err := r.getExecutor(ctx).ExecContext(ctx, `DELETE FROM posts WHERE owner_user_id = $1`, ownerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete posts by owner: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected, nil
+}
+
 func (r *postgresRepository) schemaPrefix() string {
 	if r.schema == "" {
 		return ""
@@ -446,7 +528,7 @@ func (r *postgresRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*
 			comment_count, is_deleted, deleted_date, created_at, updated_at,
 			created_date, last_updated, tags, url_key, owner_display_name,
 			owner_avatar, image, image_full_path, video, thumbnail,
-			disable_comments, disable_sharing, permission, version, metadata
+			disable_comments, disable_sharing, permission, version, media_text, content_hash, visible_until, is_archived, license, canonical_url, is_anonymous, anonymous_alias, is_supporter_only, tip_count, accepted_answer_id, community_id, metadata
 		FROM %sposts
 		WHERE id = ANY($1::uuid[]) AND is_deleted = FALSE
 	`
@@ -541,7 +623,7 @@ func (r *postgresRepository) FindByURLKey(ctx context.Context, urlKey string) (*
 			comment_count, is_deleted, deleted_date, created_at, updated_at,
 			created_date, last_updated, tags, url_key, owner_display_name,
 			owner_avatar, image, image_full_path, video, thumbnail,
-			disable_comments, disable_sharing, permission, version, metadata
+			disable_comments, disable_sharing, permission, version, media_text, content_hash, visible_until, is_archived, license, canonical_url, is_anonymous, anonymous_alias, is_supporter_only, tip_count, accepted_answer_id, community_id, metadata
 		FROM posts
 		WHERE url_key = $1 AND is_deleted = FALSE
 		LIMIT 1`
@@ -634,14 +716,58 @@ func (r *postgresRepository) FindWithCursor(ctx context.Context, filter PostFilt
 	return result, hasMore, nil
 }
 
-// buildCursorQuery constructs a SQL query with cursor-based pagination
-func (r *postgresRepository) buildCursorQuery(filter PostFilter, cursor *models.CursorData, sortField, sortDirection string, limit int) (string, []interface{}) {
-	query := `
-		SELECT id, owner_user_id, post_type_id, body, score, view_count,
+// visibleInFeedsClause drops archived posts and posts past their visible_until. The
+// expiry check covers the gap until the expiry job archives them.
+const visibleInFeedsClause = " AND is_archived = FALSE AND (visible_until = 0 OR visible_until > (EXTRACT(EPOCH FROM NOW()) * 1000)::BIGINT)"
+
+// notAnonymousClause keeps anonymous posts out of lookups by author, which would
+// otherwise tie them to the real owner
+const notAnonymousClause = " AND is_anonymous = FALSE"
+
+// heavyPostColumns lists the large columns that list queries can skip when a sparse
+// fieldset does not ask for any of the response fields they back
+var heavyPostColumns = []struct {
+	column string
+	empty  string
+	fields []string
+}{
+	{column: "body", empty: "''", fields: []string{"body", "bodyPreview", "isTruncated"}},
+	{column: "media_text", empty: "''", fields: []string{"mediaText"}},
+	{column: "metadata", empty: "'{}'::jsonb", fields: []string{"votes", "album"}},
+}
+
+// postSelectColumns returns the SELECT column list for list queries. Heavy columns that
+// back none of the requested fields are replaced by empty literals so rows still scan into models.Post.
+func postSelectColumns(fields []string) string {
+	heavy := make(map[string]string, len(heavyPostColumns))
+	for _, hc := range heavyPostColumns {
+		heavy[hc.column] = hc.column
+		if len(fields) == 0 {
+			continue
+		}
+		needed := false
+		for _, f := range hc.fields {
+			if slices.Contains(fields, f) {
+				needed = true
+				break
+			}
+		}
+		if !needed {
+			heavy[hc.column] = hc.empty + " AS " + hc.column
+		}
+	}
+
+	return `id, owner_user_id, post_type_id, ` + heavy["body"] + `, score, view_count,
 			comment_count, is_deleted, deleted_date, created_at, updated_at,
 			created_date, last_updated, tags, url_key, owner_display_name,
 			owner_avatar, image, image_full_path, video, thumbnail,
-			disable_comments, disable_sharing, permission, version, metadata
+			disable_comments, disable_sharing, permission, version, ` + heavy["media_text"] + `, content_hash, visible_until, is_archived, license, canonical_url, is_anonymous, anonymous_alias, is_supporter_only, tip_count, accepted_answer_id, community_id, ` + heavy["metadata"]
+}
+
+// buildCursorQuery constructs a SQL query with cursor-based pagination
+func (r *postgresRepository) buildCursorQuery(filter PostFilter, cursor *models.CursorData, sortField, sortDirection string, limit int) (string, []interface{}) {
+	query := `
+		SELECT ` + postSelectColumns(filter.Fields) + `
 		FROM posts
 		WHERE 1=1`
 
@@ -650,17 +776,57 @@ func (r *postgresRepository) buildCursorQuery(filter PostFilter, cursor *models.
 
 	// Apply base filters
 	if filter.OwnerUserID != nil {
-		query += fmt.Sprintf(" AND owner_user_id = $%d", argIndex)
+		query += ownerClause(argIndex, filter)
 		args = append(args, *filter.OwnerUserID)
 		argIndex++
 	}
 
+	if filter.ExcludeOwnerUserID != nil {
+		query += fmt.Sprintf(" AND owner_user_id <> $%d", argIndex)
+		args = append(args, *filter.ExcludeOwnerUserID)
+		argIndex++
+	}
+
+	if filter.FollowedBy != nil {
+		query += followedByClause(argIndex)
+		args = append(args, *filter.FollowedBy)
+		argIndex++
+	}
+
+	if filter.NotInteractedBy != nil {
+		query += notInteractedClause(argIndex)
+		args = append(args, *filter.NotInteractedBy)
+		argIndex++
+	}
+
+	if filter.Permission != nil {
+		query += fmt.Sprintf(" AND permission = $%d", argIndex)
+		args = append(args, *filter.Permission)
+		argIndex++
+	}
+
+	if filter.ReusableOnly {
+		query += fmt.Sprintf(" AND license = ANY($%d)", argIndex)
+		args = append(args, pq.Array(models.ReusableLicenses))
+		argIndex++
+	}
+
+	if filter.Answered != nil {
+		query += answeredClause(*filter.Answered)
+	}
+
 	if filter.PostTypeID != nil {
 		query += fmt.Sprintf(" AND post_type_id = $%d", argIndex)
 		args = append(args, *filter.PostTypeID)
 		argIndex++
 	}
 
+	if filter.CommunityID != nil {
+		query += communityClause(argIndex)
+		args = append(args, *filter.CommunityID)
+		argIndex++
+	}
+
 	if len(filter.Tags) > 0 {
 		query += fmt.Sprintf(" AND tags && $%d", argIndex)
 		args = append(args, pq.Array(filter.Tags))
@@ -675,18 +841,34 @@ func (r *postgresRepository) buildCursorQuery(filter PostFilter, cursor *models.
 		query += " AND is_deleted = FALSE"
 	}
 
+	if !filter.IncludeArchived {
+		query += visibleInFeedsClause
+	}
+
 	if filter.CreatedAfter != nil {
 		query += fmt.Sprintf(" AND created_date >= $%d", argIndex)
 		args = append(args, *filter.CreatedAfter)
 		argIndex++
 	}
 
+	if filter.CreatedBefore != nil {
+		query += fmt.Sprintf(" AND created_date <= $%d", argIndex)
+		args = append(args, *filter.CreatedBefore)
+		argIndex++
+	}
+
 	if filter.URLKey != nil {
 		query += fmt.Sprintf(" AND url_key = $%d", argIndex)
 		args = append(args, *filter.URLKey)
 		argIndex++
 	}
 
+	if filter.ContentHash != nil {
+		query += fmt.Sprintf(" AND content_hash = $%d", argIndex)
+		args = append(args, *filter.ContentHash)
+		argIndex++
+	}
+
 	if filter.SearchText != nil && *filter.SearchText != "" {
 		searchPattern := "%" + *filter.SearchText + "%"
 		query += fmt.Sprintf(" AND (body ILIKE $%d OR owner_display_name ILIKE $%d)", argIndex, argIndex)
@@ -797,59 +979,63 @@ func (r *postgresRepository) Count(ctx context.Context, filter PostFilter) (int6
 	return count, nil
 }
 
-// Search retrieves posts using full-text search on body
-func (r *postgresRepository) Search(ctx context.Context, query string, limit int) ([]*models.Post, error) {
-	searchTerm := strings.TrimSpace(query)
-	if searchTerm == "" {
-		return []*models.Post{}, nil
-	}
-	if limit <= 0 {
-		limit = 5
+// UpdateOwnerProfile updates display name and avatar for all posts by an owner
+func (r *postgresRepository) UpdateOwnerProfile(ctx context.Context, ownerID uuid.UUID, displayName, avatar string) error {
+	// Keep the author block of posts this user co-authors in step with the profile
+	coauthorQuery := `UPDATE post_coauthors SET display_name = $1, avatar = $2 WHERE user_id = $3 AND status = 'accepted'`
+	if _, err := r.client.DB().ExecContext(ctx, coauthorQuery, displayName, avatar, ownerID); err != nil {
+		return fmt.Errorf("failed to update co-author profile: %w", err)
 	}
 
-	sqlQuery := `
-		SELECT 
-			id, owner_user_id, post_type_id, body, score, view_count,
-			comment_count, is_deleted, deleted_date, created_at, updated_at,
-			created_date, last_updated, tags, url_key, owner_display_name,
-			owner_avatar, image, image_full_path, video, thumbnail,
-			disable_comments, disable_sharing, permission, version, metadata
-		FROM posts
-		WHERE 
-			is_deleted = FALSE 
-			AND to_tsvector('english', body) @@ plainto_tsquery('english', $1)
-		ORDER BY created_date DESC
-		LIMIT $2
-	`
+	query := `
+		UPDATE posts
+		SET owner_display_name = $1, owner_avatar = $2, updated_at = NOW(), last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT
+		WHERE owner_user_id = $3 AND is_deleted = FALSE`
 
-	var posts []models.Post
-	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &posts, sqlQuery, searchTerm, limit); err != nil {
-		return nil, fmt.Errorf("failed to search posts: %w", err)
+	result, err := r.client.DB().ExecContext(ctx, query, displayName, avatar, ownerID)
+	if err != nil {
+		return fmt.Errorf("failed to update owner profile: %w", err)
 	}
 
-	results := make([]*models.Post, len(posts))
-	for i := range posts {
-		post := &posts[i]
-		if post.Metadata != nil {
-			metadataJSON, _ := json.Marshal(post.Metadata)
-			r.populateMetadata(post, metadataJSON)
-		}
-		results[i] = post
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
-	return results, nil
+	if rowsAffected == 0 {
+		return fmt.Errorf("no posts found for owner: %s", ownerID.String())
+	}
+
+	return nil
 }
 
-// UpdateOwnerProfile updates display name and avatar for all posts by an owner
-func (r *postgresRepository) UpdateOwnerProfile(ctx context.Context, ownerID uuid.UUID, displayName, avatar string) error {
+// ReplaceImage swaps an uploaded original for its resized copy without touching
+// last_updated, since the image pipeline runs in the background and is not a user edit
+func (r *postgresRepository) ReplaceImage(ctx context.Context, ownerID uuid.UUID, key, fullPath, thumbnail string) ([]uuid.UUID, error) {
 	query := `
 		UPDATE posts
-		SET owner_display_name = $1, owner_avatar = $2, updated_at = NOW(), last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT
-		WHERE owner_user_id = $3 AND is_deleted = FALSE`
+		SET image_full_path = $3,
+			thumbnail = CASE WHEN COALESCE(thumbnail, '') = '' THEN $4 ELSE thumbnail END,
+			updated_at = NOW()
+		WHERE owner_user_id = $1 AND is_deleted = FALSE
+			AND (strpos(image_full_path, $2) > 0 OR strpos(image, $2) > 0)
+		RETURNING id`
 
-	result, err := r.client.DB().ExecContext(ctx, query, displayName, avatar, ownerID)
+	var ids []uuid.UUID
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &ids, query, ownerID, key, fullPath, thumbnail); err != nil {
+		return nil, fmt.Errorf("failed to replace post image: %w", err)
+	}
+	return ids, nil
+}
+
+// UpdateMediaText stores OCR text for a post without touching last_updated,
+// since extraction runs in the background and is not a user edit
+func (r *postgresRepository) UpdateMediaText(ctx context.Context, postID uuid.UUID, mediaText string) error {
+	query := `UPDATE posts SET media_text = $1 WHERE id = $2 AND is_deleted = FALSE`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, mediaText, postID)
 	if err != nil {
-		return fmt.Errorf("failed to update owner profile: %w", err)
+		return fmt.Errorf("failed to update media text: %w", err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -858,21 +1044,96 @@ func (r *postgresRepository) UpdateOwnerProfile(ctx context.Context, ownerID uui
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("no posts found for owner: %s", ownerID.String())
+		return fmt.Errorf("post not found")
+	}
+
+	return nil
+}
+
+// ArchiveExpired archives up to limit posts whose visible_until has passed. Like
+// UpdateMediaText it leaves last_updated alone, since expiry is not a user edit.
+func (r *postgresRepository) ArchiveExpired(ctx context.Context, now int64, limit int) (int64, error) {
+	query := `
+		UPDATE posts SET is_archived = TRUE, updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM posts
+			WHERE visible_until > 0 AND visible_until <= $1 AND is_archived = FALSE AND is_deleted = FALSE
+			ORDER BY visible_until
+			LIMIT $2
+		)`
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, now, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to archive expired posts: %w", err)
+	}
+
+	archived, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
+	return archived, nil
+}
+
+// GetReadMarkers returns the user's last-read markers for the given feeds.
+// Feeds the user has never marked as read are absent from the result.
+func (r *postgresRepository) GetReadMarkers(ctx context.Context, userID uuid.UUID, feedKeys []string) (map[string]int64, error) {
+	markers := make(map[string]int64, len(feedKeys))
+	if len(feedKeys) == 0 {
+		return markers, nil
+	}
+
+	query := `SELECT feed_key, last_read_date FROM post_read_markers WHERE user_id = $1 AND feed_key = ANY($2)`
+
+	var rows []struct {
+		FeedKey      string `db:"feed_key"`
+		LastReadDate int64  `db:"last_read_date"`
+	}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, userID, pq.Array(feedKeys)); err != nil {
+		return nil, fmt.Errorf("failed to get read markers: %w", err)
+	}
+
+	for _, row := range rows {
+		markers[row.FeedKey] = row.LastReadDate
+	}
+	return markers, nil
+}
+
+// SaveReadMarker upserts the user's last-read marker for a feed.
+// The marker only moves forward so a stale client cannot resurrect read posts as new.
+func (r *postgresRepository) SaveReadMarker(ctx context.Context, userID uuid.UUID, feedKey string, lastReadDate int64) error {
+	query := `
+		INSERT INTO post_read_markers (user_id, feed_key, last_read_date, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (user_id, feed_key) DO UPDATE SET
+			last_read_date = GREATEST(post_read_markers.last_read_date, EXCLUDED.last_read_date),
+			updated_at = NOW()
+	`
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID, feedKey, lastReadDate); err != nil {
+		return fmt.Errorf("failed to save read marker: %w", err)
+	}
 	return nil
 }
 
-// SetCommentDisabled sets the comment disabled flag for a post with ownership validation
+// DeleteReadMarkersByUser deletes the user's last-read markers of every feed
+func (r *postgresRepository) DeleteReadMarkersByUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM post_read_markers WHERE user_id = $1`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete read markers: %w", err)
+	}
+	return nil
+}
+
+// SetCommentDisabled sets the comment disabled flag for a post the editor owns or co-authors
 // Ownership validation is embedded in the WHERE clause for atomicity and security
-func (r *postgresRepository) SetCommentDisabled(ctx context.Context, postID uuid.UUID, disabled bool, ownerID uuid.UUID) error {
+func (r *postgresRepository) SetCommentDisabled(ctx context.Context, postID uuid.UUID, disabled bool, editorID uuid.UUID) error {
 	query := `
 		UPDATE posts
 		SET disable_comments = $1, updated_at = NOW(), last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT
-		WHERE id = $2 AND owner_user_id = $3 AND is_deleted = FALSE`
+		WHERE id = $2 AND is_deleted = FALSE` + editorClause
 
-	result, err := r.client.DB().ExecContext(ctx, query, disabled, postID, ownerID)
+	result, err := r.client.DB().ExecContext(ctx, query, disabled, postID, editorID)
 	if err != nil {
 		return fmt.Errorf("failed to set comment disabled: %w", err)
 	}
@@ -883,21 +1144,21 @@ func (r *postgresRepository) SetCommentDisabled(ctx context.Context, postID uuid
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("post not found, already deleted, or user does not own the post: %s", postID.String())
+		return fmt.Errorf("post not found, already deleted, or user cannot edit the post: %s", postID.String())
 	}
 
 	return nil
 }
 
-// SetSharingDisabled sets the sharing disabled flag for a post with ownership validation
+// SetSharingDisabled sets the sharing disabled flag for a post the editor owns or co-authors
 // Ownership validation is embedded in the WHERE clause for atomicity and security
-func (r *postgresRepository) SetSharingDisabled(ctx context.Context, postID uuid.UUID, disabled bool, ownerID uuid.UUID) error {
+func (r *postgresRepository) SetSharingDisabled(ctx context.Context, postID uuid.UUID, disabled bool, editorID uuid.UUID) error {
 	query := `
 		UPDATE posts
 		SET disable_sharing = $1, updated_at = NOW(), last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT
-		WHERE id = $2 AND owner_user_id = $3 AND is_deleted = FALSE`
+		WHERE id = $2 AND is_deleted = FALSE` + editorClause
 
-	result, err := r.client.DB().ExecContext(ctx, query, disabled, postID, ownerID)
+	result, err := r.client.DB().ExecContext(ctx, query, disabled, postID, editorID)
 	if err != nil {
 		return fmt.Errorf("failed to set sharing disabled: %w", err)
 	}
@@ -908,7 +1169,7 @@ func (r *postgresRepository) SetSharingDisabled(ctx context.Context, postID uuid
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("post not found, already deleted, or user does not own the post: %s", postID.String())
+		return fmt.Errorf("post not found, already deleted, or user cannot edit the post: %s", postID.String())
 	}
 
 	return nil
@@ -917,11 +1178,7 @@ func (r *postgresRepository) SetSharingDisabled(ctx context.Context, postID uuid
 // buildFindQuery constructs a SQL query with WHERE clause based on filter criteria
 func (r *postgresRepository) buildFindQuery(filter PostFilter, limit, offset int) (string, []interface{}) {
 	query := `
-		SELECT id, owner_user_id, post_type_id, body, score, view_count,
-			comment_count, is_deleted, deleted_date, created_at, updated_at,
-			created_date, last_updated, tags, url_key, owner_display_name,
-			owner_avatar, image, image_full_path, video, thumbnail,
-			disable_comments, disable_sharing, permission, version, metadata
+		SELECT ` + postSelectColumns(filter.Fields) + `
 		FROM posts
 		WHERE 1=1`
 
@@ -929,17 +1186,57 @@ func (r *postgresRepository) buildFindQuery(filter PostFilter, limit, offset int
 	argIndex := 1
 
 	if filter.OwnerUserID != nil {
-		query += fmt.Sprintf(" AND owner_user_id = $%d", argIndex)
+		query += ownerClause(argIndex, filter)
 		args = append(args, *filter.OwnerUserID)
 		argIndex++
 	}
 
+	if filter.ExcludeOwnerUserID != nil {
+		query += fmt.Sprintf(" AND owner_user_id <> $%d", argIndex)
+		args = append(args, *filter.ExcludeOwnerUserID)
+		argIndex++
+	}
+
+	if filter.FollowedBy != nil {
+		query += followedByClause(argIndex)
+		args = append(args, *filter.FollowedBy)
+		argIndex++
+	}
+
+	if filter.NotInteractedBy != nil {
+		query += notInteractedClause(argIndex)
+		args = append(args, *filter.NotInteractedBy)
+		argIndex++
+	}
+
+	if filter.Permission != nil {
+		query += fmt.Sprintf(" AND permission = $%d", argIndex)
+		args = append(args, *filter.Permission)
+		argIndex++
+	}
+
+	if filter.ReusableOnly {
+		query += fmt.Sprintf(" AND license = ANY($%d)", argIndex)
+		args = append(args, pq.Array(models.ReusableLicenses))
+		argIndex++
+	}
+
+	if filter.Answered != nil {
+		query += answeredClause(*filter.Answered)
+	}
+
 	if filter.PostTypeID != nil {
 		query += fmt.Sprintf(" AND post_type_id = $%d", argIndex)
 		args = append(args, *filter.PostTypeID)
 		argIndex++
 	}
 
+	if filter.CommunityID != nil {
+		query += communityClause(argIndex)
+		args = append(args, *filter.CommunityID)
+		argIndex++
+	}
+
 	if len(filter.Tags) > 0 {
 		query += fmt.Sprintf(" AND tags && $%d", argIndex)
 		args = append(args, pq.Array(filter.Tags))
@@ -954,18 +1251,34 @@ func (r *postgresRepository) buildFindQuery(filter PostFilter, limit, offset int
 		query += " AND is_deleted = FALSE"
 	}
 
+	if !filter.IncludeArchived {
+		query += visibleInFeedsClause
+	}
+
 	if filter.CreatedAfter != nil {
 		query += fmt.Sprintf(" AND created_date >= $%d", argIndex)
 		args = append(args, *filter.CreatedAfter)
 		argIndex++
 	}
 
+	if filter.CreatedBefore != nil {
+		query += fmt.Sprintf(" AND created_date <= $%d", argIndex)
+		args = append(args, *filter.CreatedBefore)
+		argIndex++
+	}
+
 	if filter.URLKey != nil {
 		query += fmt.Sprintf(" AND url_key = $%d", argIndex)
 		args = append(args, *filter.URLKey)
 		argIndex++
 	}
 
+	if filter.ContentHash != nil {
+		query += fmt.Sprintf(" AND content_hash = $%d", argIndex)
+		args = append(args, *filter.ContentHash)
+		argIndex++
+	}
+
 	if filter.SearchText != nil && *filter.SearchText != "" {
 		searchPattern := "%" + *filter.SearchText + "%"
 		query += fmt.Sprintf(" AND (body ILIKE $%d OR owner_display_name ILIKE $%d)", argIndex, argIndex)
@@ -988,17 +1301,57 @@ func (r *postgresRepository) buildCountQuery(filter PostFilter) (string, []inter
 	argIndex := 1
 
 	if filter.OwnerUserID != nil {
-		query += fmt.Sprintf(" AND owner_user_id = $%d", argIndex)
+		query += ownerClause(argIndex, filter)
 		args = append(args, *filter.OwnerUserID)
 		argIndex++
 	}
 
+	if filter.ExcludeOwnerUserID != nil {
+		query += fmt.Sprintf(" AND owner_user_id <> $%d", argIndex)
+		args = append(args, *filter.ExcludeOwnerUserID)
+		argIndex++
+	}
+
+	if filter.FollowedBy != nil {
+		query += followedByClause(argIndex)
+		args = append(args, *filter.FollowedBy)
+		argIndex++
+	}
+
+	if filter.NotInteractedBy != nil {
+		query += notInteractedClause(argIndex)
+		args = append(args, *filter.NotInteractedBy)
+		argIndex++
+	}
+
+	if filter.Permission != nil {
+		query += fmt.Sprintf(" AND permission = $%d", argIndex)
+		args = append(args, *filter.Permission)
+		argIndex++
+	}
+
+	if filter.ReusableOnly {
+		query += fmt.Sprintf(" AND license = ANY($%d)", argIndex)
+		args = append(args, pq.Array(models.ReusableLicenses))
+		argIndex++
+	}
+
+	if filter.Answered != nil {
+		query += answeredClause(*filter.Answered)
+	}
+
 	if filter.PostTypeID != nil {
 		query += fmt.Sprintf(" AND post_type_id = $%d", argIndex)
 		args = append(args, *filter.PostTypeID)
 		argIndex++
 	}
 
+	if filter.CommunityID != nil {
+		query += communityClause(argIndex)
+		args = append(args, *filter.CommunityID)
+		argIndex++
+	}
+
 	if len(filter.Tags) > 0 {
 		query += fmt.Sprintf(" AND tags && $%d", argIndex)
 		args = append(args, pq.Array(filter.Tags))
@@ -1013,18 +1366,34 @@ func (r *postgresRepository) buildCountQuery(filter PostFilter) (string, []inter
 		query += " AND is_deleted = FALSE"
 	}
 
+	if !filter.IncludeArchived {
+		query += visibleInFeedsClause
+	}
+
 	if filter.CreatedAfter != nil {
 		query += fmt.Sprintf(" AND created_date >= $%d", argIndex)
 		args = append(args, *filter.CreatedAfter)
 		argIndex++
 	}
 
+	if filter.CreatedBefore != nil {
+		query += fmt.Sprintf(" AND created_date <= $%d", argIndex)
+		args = append(args, *filter.CreatedBefore)
+		argIndex++
+	}
+
 	if filter.URLKey != nil {
 		query += fmt.Sprintf(" AND url_key = $%d", argIndex)
 		args = append(args, *filter.URLKey)
 		argIndex++
 	}
 
+	if filter.ContentHash != nil {
+		query += fmt.Sprintf(" AND content_hash = $%d", argIndex)
+		args = append(args, *filter.ContentHash)
+		argIndex++
+	}
+
 	if filter.SearchText != nil && *filter.SearchText != "" {
 		searchPattern := "%" + *filter.SearchText + "%"
 		query += fmt.Sprintf(" AND (body ILIKE $%d OR owner_display_name ILIKE $%d)", argIndex, argIndex)
@@ -9,6 +9,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -85,8 +86,20 @@ func TestPostgresRepository_Integration(t *testing.T) {
 			disable_sharing BOOLEAN DEFAULT FALSE,
 			permission VARCHAR(50) DEFAULT 'Public',
 			version VARCHAR(50),
+			media_text TEXT NOT NULL DEFAULT '',
+			content_hash VARCHAR(64) NOT NULL DEFAULT '',
+			search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(body, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(media_text, '')), 'C')
+			) STORED,
 			metadata JSONB DEFAULT '{}'::jsonb
 		);
+		CREATE TABLE IF NOT EXISTS post_tags (
+			tag VARCHAR(50) NOT NULL,
+			post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+			PRIMARY KEY (tag, post_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_post_tags_post ON post_tags(post_id);
 
 		CREATE INDEX IF NOT EXISTS idx_posts_owner ON posts(owner_user_id);
 		CREATE INDEX IF NOT EXISTS idx_posts_created_at ON posts(created_at DESC);
@@ -95,6 +108,13 @@ func TestPostgresRepository_Integration(t *testing.T) {
 		CREATE INDEX IF NOT EXISTS idx_posts_post_type ON posts(post_type_id);
 		CREATE INDEX IF NOT EXISTS idx_posts_deleted ON posts(is_deleted) WHERE is_deleted = FALSE;
 		CREATE INDEX IF NOT EXISTS idx_posts_url_key ON posts(url_key) WHERE url_key IS NOT NULL;
+		CREATE TABLE IF NOT EXISTS post_read_markers (
+			user_id UUID NOT NULL,
+			feed_key VARCHAR(300) NOT NULL,
+			last_read_date BIGINT NOT NULL DEFAULT 0,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (user_id, feed_key)
+		);
 	`
 
 	_, err = client.DB().ExecContext(ctx, migrationSQL)
@@ -363,5 +383,93 @@ func TestPostgresRepository_Integration(t *testing.T) {
 		require.Error(t, err, "Should return error for non-existent post")
 		require.Contains(t, err.Error(), "not found", "Error should indicate post not found")
 	})
+
+	// 14. Test read markers only move forward
+	t.Run("ReadMarkers", func(t *testing.T) {
+		userID := uuid.Must(uuid.NewV4())
+
+		markers, err := repo.GetReadMarkers(ctx, userID, []string{"home"})
+		require.NoError(t, err)
+		require.Empty(t, markers, "Unread feeds should have no marker")
+
+		require.NoError(t, repo.SaveReadMarker(ctx, userID, "home", 200))
+		require.NoError(t, repo.SaveReadMarker(ctx, userID, "home", 100))
+		require.NoError(t, repo.SaveReadMarker(ctx, userID, "tag:go", 50))
+
+		markers, err = repo.GetReadMarkers(ctx, userID, []string{"home", "tag:go", "tag:db"})
+		require.NoError(t, err)
+		require.Equal(t, map[string]int64{"home": 200, "tag:go": 50}, markers)
+	})
 }
 
+func TestPostSelectColumns(t *testing.T) {
+	all := postSelectColumns(nil)
+	require.Contains(t, all, "body,")
+	require.Contains(t, all, "media_text,")
+	require.True(t, strings.HasSuffix(all, " metadata"))
+
+	cards := postSelectColumns([]string{"objectId", "ownerDisplayName", "votes"})
+	require.Contains(t, cards, "'' AS body")
+	require.Contains(t, cards, "'' AS media_text")
+	require.True(t, strings.HasSuffix(cards, " metadata"), "votes are stored in metadata")
+
+	bodyOnly := postSelectColumns([]string{"body"})
+	require.NotContains(t, bodyOnly, "'' AS body")
+	require.Contains(t, bodyOnly, "'{}'::jsonb AS metadata")
+}
+
+func TestBuildCursorQuery_FollowedBy(t *testing.T) {
+	r := &postgresRepository{}
+	readerID := uuid.Must(uuid.NewV4())
+	cursor := &models.CursorData{ID: uuid.Must(uuid.NewV4()).String(), Value: int64(100), Timestamp: 100}
+
+	query, args := r.buildCursorQuery(PostFilter{FollowedBy: &readerID}, cursor, "createdDate", "desc", 10)
+	require.Contains(t, query, "owner_user_id IN (SELECT followee_id FROM follows WHERE follower_id = $1)")
+	require.Contains(t, query, "is_anonymous = FALSE")
+	require.Equal(t, readerID, args[0], "cursor bounds must follow the reader argument")
+}
+
+func TestBuildCursorQuery_NotInteractedBy(t *testing.T) {
+	r := &postgresRepository{}
+	readerID := uuid.Must(uuid.NewV4())
+
+	query, args := r.buildCursorQuery(PostFilter{NotInteractedBy: &readerID}, nil, "createdDate", "desc", 10)
+	require.Contains(t, query, "NOT EXISTS (SELECT 1 FROM votes v WHERE v.post_id = posts.id AND v.owner_user_id = $1)")
+	require.Contains(t, query, "NOT EXISTS (SELECT 1 FROM comments c WHERE c.post_id = posts.id AND c.owner_user_id = $1)")
+	require.Contains(t, query, "NOT EXISTS (SELECT 1 FROM bookmarks b WHERE b.owner_user_id = $1 AND b.post_id = posts.id)")
+	require.Equal(t, readerID, args[0])
+}
+
+func TestBuildSearchQuery(t *testing.T) {
+	after := &models.SearchCursor{Rank: 0.25, ID: uuid.Must(uuid.NewV4()).String()}
+
+	query, args := buildSearchQuery(`"error handling" -panic`, []string{"go"}, after, 10)
+	require.Contains(t, query, "websearch_to_tsquery('english', $1)")
+	require.Contains(t, query, "tags && $3")
+	require.Contains(t, query, "(search_rank, id) < ($4::real, $5::uuid)")
+	require.Contains(t, query, "LIMIT $6")
+	require.Equal(t, []interface{}{`"error handling" -panic`, searchHeadlineOptions}, args[:2])
+	require.Equal(t, 11, args[5], "one extra row tells whether another page follows")
+
+	query, args = buildSearchQuery("generics", nil, nil, 5)
+	require.NotContains(t, query, "tags &&")
+	require.NotContains(t, query, "search_rank, id) <")
+	require.Len(t, args, 3)
+}
+
+func TestBuildTagPostsQuery(t *testing.T) {
+	after := &models.TagCursor{CreatedDate: 1700000000000, ID: uuid.Must(uuid.NewV4()).String()}
+
+	query, args := buildTagPostsQuery("golang", models.TimeRange{Since: 1600000000000, Until: 1800000000000}, after, 20)
+	require.Contains(t, query, "pt.tag = $1")
+	require.Contains(t, query, "p.permission = 'Public'")
+	require.Contains(t, query, "p.created_date >= $2 AND p.created_date < $3")
+	require.Contains(t, query, "(p.created_date, p.id) < ($4, $5::uuid)")
+	require.Contains(t, query, "LIMIT $6")
+	require.Equal(t, []interface{}{"golang", int64(1600000000000), int64(1800000000000), after.CreatedDate, after.ID, 21}, args)
+
+	query, args = buildTagPostsQuery("golang", models.TimeRange{}, nil, 5)
+	require.NotContains(t, query, "p.created_date, p.id) <")
+	require.NotContains(t, query, "p.created_date >=")
+	require.Len(t, args, 2)
+}
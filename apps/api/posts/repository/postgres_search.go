@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// searchHeadlineOptions asks ts_headline for up to two short fragments with matches
+// wrapped in the highlight markers
+var searchHeadlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxWords=30, MinWords=12, MaxFragments=2, FragmentDelimiter=" … "`,
+	models.HighlightStart, models.HighlightStop)
+
+// FullTextSearch matches posts against a web search query (websearch_to_tsquery), ranks them
+// with ts_rank_cd and pages through them by (rank, id). search_vector weights body as 'A' and
+// OCR media text as 'C', so body matches rank ahead of matches found only in images.
+func (r *postgresRepository) FullTextSearch(ctx context.Context, query models.PostSearchQuery) ([]SearchHit, bool, error) {
+	searchTerm := strings.TrimSpace(query.Query)
+	if searchTerm == "" {
+		return []SearchHit{}, false, nil
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	sqlQuery, args := buildSearchQuery(searchTerm, query.Tags, query.After, limit)
+
+	var rows []struct {
+		models.Post
+		SearchRank float32 `db:"search_rank"`
+		Headline   string  `db:"headline"`
+	}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, sqlQuery, args...); err != nil {
+		return nil, false, fmt.Errorf("failed to search posts: %w", err)
+	}
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	hits := make([]SearchHit, len(rows))
+	for i := range rows {
+		post := &rows[i].Post
+		if post.Metadata != nil {
+			metadataJSON, _ := json.Marshal(post.Metadata)
+			r.populateMetadata(post, metadataJSON)
+		}
+		hits[i] = SearchHit{Post: post, Rank: rows[i].SearchRank, Highlight: rows[i].Headline}
+	}
+
+	return hits, hasMore, nil
+}
+
+// buildSearchQuery constructs the ranked, keyset-paged full-text search query. The inner
+// query ranks every match; the outer one pages and only builds headlines for the rows it returns.
+func buildSearchQuery(searchTerm string, tags []string, after *models.SearchCursor, limit int) (string, []interface{}) {
+	inner := `
+		SELECT posts.*, ts_rank_cd(search_vector, tsq) AS search_rank, tsq
+		FROM posts, websearch_to_tsquery('english', $1) tsq
+		WHERE is_deleted = FALSE` + visibleInFeedsClause + `
+			AND search_vector @@ tsq`
+	args := []interface{}{searchTerm, searchHeadlineOptions}
+	if len(tags) > 0 {
+		args = append(args, pq.Array(tags))
+		inner += fmt.Sprintf(" AND tags && $%d", len(args))
+	}
+
+	sqlQuery := `
+		SELECT ` + postSelectColumns(nil) + `, search_rank,
+			ts_headline('english', COALESCE(NULLIF(body, ''), media_text), tsq, $2) AS headline
+		FROM (` + inner + `
+		) ranked`
+	if after != nil {
+		args = append(args, after.Rank, after.ID)
+		sqlQuery += fmt.Sprintf(" WHERE (search_rank, id) < ($%d::real, $%d::uuid)", len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+	sqlQuery += fmt.Sprintf(" ORDER BY search_rank DESC, id DESC LIMIT $%d", len(args))
+
+	return sqlQuery, args
+}
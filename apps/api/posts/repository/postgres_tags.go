@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// publicTagClause keeps tag pages and trending counts to live public posts
+const publicTagClause = ` AND p.is_deleted = FALSE AND p.permission = 'Public'`
+
+// syncPostTags makes post_tags hold exactly the post's tags. Create and Update call it so
+// the tag store never drifts from posts.tags.
+func (r *postgresRepository) syncPostTags(ctx context.Context, postID uuid.UUID, tags []string) error {
+	if tags == nil {
+		tags = []string{}
+	}
+	query := `
+		WITH removed AS (
+			DELETE FROM post_tags WHERE post_id = $1 AND tag <> ALL($2::text[])
+		)
+		INSERT INTO post_tags (tag, post_id)
+		SELECT DISTINCT unnest($2::text[]), $1
+		ON CONFLICT DO NOTHING`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, postID, pq.Array(tags)); err != nil {
+		return fmt.Errorf("failed to store post tags: %w", err)
+	}
+	return nil
+}
+
+// FindByTag pages through live public posts carrying the tag and created within the range
+// by (created_date, id), newest first
+func (r *postgresRepository) FindByTag(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) ([]*models.Post, bool, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	sqlQuery, args := buildTagPostsQuery(tag, within, after, limit)
+
+	var rows []models.Post
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, sqlQuery, args...); err != nil {
+		return nil, false, fmt.Errorf("failed to find posts by tag: %w", err)
+	}
+
+	hasMore := len(rows) > limit
+	if hasMore {
+		rows = rows[:limit]
+	}
+
+	posts := make([]*models.Post, len(rows))
+	for i := range rows {
+		post := &rows[i]
+		if post.Metadata != nil {
+			metadataJSON, _ := json.Marshal(post.Metadata)
+			r.populateMetadata(post, metadataJSON)
+		}
+		posts[i] = post
+	}
+	return posts, hasMore, nil
+}
+
+// buildTagPostsQuery constructs the keyset-paged tag page query
+func buildTagPostsQuery(tag string, within models.TimeRange, after *models.TagCursor, limit int) (string, []interface{}) {
+	sqlQuery := `
+		SELECT ` + postSelectColumns(nil) + `
+		FROM posts p JOIN post_tags pt ON pt.post_id = p.id
+		WHERE pt.tag = $1` + publicTagClause + visibleInFeedsClause
+	args := []interface{}{tag}
+	if within.Since > 0 {
+		args = append(args, within.Since)
+		sqlQuery += fmt.Sprintf(" AND p.created_date >= $%d", len(args))
+	}
+	if within.Until > 0 {
+		args = append(args, within.Until)
+		sqlQuery += fmt.Sprintf(" AND p.created_date < $%d", len(args))
+	}
+	if after != nil {
+		args = append(args, after.CreatedDate, after.ID)
+		sqlQuery += fmt.Sprintf(" AND (p.created_date, p.id) < ($%d, $%d::uuid)", len(args)-1, len(args))
+	}
+	args = append(args, limit+1)
+	sqlQuery += fmt.Sprintf(" ORDER BY p.created_date DESC, p.id DESC LIMIT $%d", len(args))
+
+	return sqlQuery, args
+}
+
+// RefreshTrendingTags counts live public posts per tag created since the given time
+// (Unix milliseconds) and replaces the window's stored ranking. Readers see either the
+// old or the new ranking, never a mix.
+func (r *postgresRepository) RefreshTrendingTags(ctx context.Context, window string, since, computedDate int64, limit int) error {
+	insertQuery := `
+		INSERT INTO trending_tags (window_name, rank, tag, post_count, computed_date)
+		SELECT $1, ROW_NUMBER() OVER (ORDER BY COUNT(*) DESC, pt.tag), pt.tag, COUNT(*), $3
+		FROM post_tags pt JOIN posts p ON p.id = pt.post_id
+		WHERE p.created_date >= $2` + publicTagClause + `
+		GROUP BY pt.tag
+		ORDER BY COUNT(*) DESC, pt.tag
+		LIMIT $4`
+
+	return r.WithTransaction(ctx, func(txCtx context.Context) error {
+		exec := r.getExecutor(txCtx)
+		if _, err := exec.ExecContext(txCtx, `DELETE FROM trending_tags WHERE window_name = $1`, window); err != nil {
+			return fmt.Errorf("clear trending tags: %w", err)
+		}
+		if _, err := exec.ExecContext(txCtx, insertQuery, window, since, computedDate, limit); err != nil {
+			return fmt.Errorf("count trending tags: %w", err)
+		}
+		return nil
+	})
+}
+
+// ListTrendingTags returns the window's stored ranking and when it was computed
+func (r *postgresRepository) ListTrendingTags(ctx context.Context, window string) ([]models.TrendingTag, int64, error) {
+	var rows []struct {
+		models.TrendingTag
+		ComputedDate int64 `db:"computed_date"`
+	}
+	query := `SELECT rank, tag, post_count, computed_date FROM trending_tags WHERE window_name = $1 ORDER BY rank`
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, window); err != nil {
+		return nil, 0, fmt.Errorf("failed to list trending tags: %w", err)
+	}
+
+	tags := make([]models.TrendingTag, len(rows))
+	var computedDate int64
+	for i, row := range rows {
+		tags[i] = row.TrendingTag
+		computedDate = row.ComputedDate
+	}
+	return tags, computedDate, nil
+}
@@ -14,20 +14,65 @@ import (
 
 // PostFilter represents filtering criteria for querying posts
 type PostFilter struct {
-	OwnerUserID  *uuid.UUID
-	PostTypeID   *int
-	Tags         []string
-	Deleted      *bool
-	CreatedAfter *int64
-	URLKey       *string
-	SearchText   *string
+	OwnerUserID   *uuid.UUID
+	PostTypeID    *int
+	Tags          []string
+	Deleted       *bool
+	CreatedAfter  *int64
+	CreatedBefore *int64 // Inclusive upper bound, used to pin paging snapshots
+	URLKey        *string
+	SearchText    *string
+	ContentHash   *string
+
+	// IncludeCoauthored widens OwnerUserID to posts that user co-authors (accepted invitations),
+	// so the posts show on both authors' profile feeds
+	IncludeCoauthored bool
+
+	// IncludeAnonymous keeps the owner's anonymous posts when filtering by OwnerUserID.
+	// Only internal checks (e.g. duplicate detection) may set it; responses would expose the author.
+	IncludeAnonymous bool
+
+	// ExcludeOwnerUserID drops posts written by this user (e.g. the viewer's own posts from unread counts)
+	ExcludeOwnerUserID *uuid.UUID
+
+	// Permission keeps only posts with this visibility (e.g. "Public" for anonymous feeds)
+	Permission *string
+
+	// IncludeArchived keeps archived and expired posts, which list queries drop by default
+	IncludeArchived bool
+
+	// ReusableOnly keeps posts under a license that allows republishing (models.ReusableLicenses)
+	ReusableOnly bool
+
+	// Answered keeps question posts with (true) or without (false) an accepted answer
+	Answered *bool
+
+	// FollowedBy keeps posts whose owner this user follows. Anonymous posts are left out,
+	// since listing them here would reveal that a followed user wrote them.
+	FollowedBy *uuid.UUID
+
+	// NotInteractedBy drops posts this user has voted on, commented on or bookmarked
+	NotInteractedBy *uuid.UUID
+
+	// CommunityID keeps posts placed in this community, made there or shared into it
+	CommunityID *uuid.UUID
+
+	// Fields limits list queries to the columns needed for these PostResponse JSON fields; empty loads everything
+	Fields []string
+}
+
+// SearchHit is a post matched by full-text search
+type SearchHit struct {
+	Post      *models.Post
+	Rank      float32
+	Highlight string // ts_headline excerpt with matches between models.HighlightStart and models.HighlightStop
 }
 
 // PostRepository defines the interface for post-specific database operations
 // This is a domain-specific repository that knows exactly what a "Post" is
 // and how to execute optimized SQL queries for that specific domain.
 type PostRepository interface {
-	// Create inserts a new post
+	// Create inserts a new post. Create and Update also store the post's tags for tag pages.
 	Create(ctx context.Context, post *models.Post) error
 
 	// FindByID retrieves a post by its ID
@@ -36,7 +81,7 @@ type PostRepository interface {
 	// FindByURLKey retrieves a post by its URL key
 	FindByURLKey(ctx context.Context, urlKey string) (*models.Post, error)
 
-	// FindByUser retrieves posts by owner user ID with pagination
+	// FindByUser retrieves posts by owner user ID with pagination, excluding anonymous posts
 	FindByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]*models.Post, error)
 
 	// Find retrieves posts matching the filter criteria with pagination
@@ -48,24 +93,123 @@ type PostRepository interface {
 	// Count returns the number of posts matching the filter criteria
 	Count(ctx context.Context, filter PostFilter) (int64, error)
 
-	// Search retrieves posts using full-text search on body
-	Search(ctx context.Context, query string, limit int) ([]*models.Post, error)
+	// FullTextSearch ranks posts matching a web search query over body and OCR media text,
+	// best match first, and reports whether more results follow the page
+	// Matches in body rank higher than matches in media text
+	FullTextSearch(ctx context.Context, query models.PostSearchQuery) ([]SearchHit, bool, error)
+
+	// FindByTag pages through live public posts carrying the tag and created within the
+	// range, newest first, and reports whether more posts follow the page
+	FindByTag(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) ([]*models.Post, bool, error)
+
+	// RefreshTrendingTags recounts the tags of public posts created since the given time
+	// (Unix milliseconds), keeping the top limit, and replaces the window's stored ranking
+	RefreshTrendingTags(ctx context.Context, window string, since, computedDate int64, limit int) error
+
+	// ListTrendingTags returns the window's stored ranking, most used first, and when it was computed
+	ListTrendingTags(ctx context.Context, window string) ([]models.TrendingTag, int64, error)
 
 	// Update updates an existing post
 	Update(ctx context.Context, post *models.Post) error
 
-	// UpdateOwnerProfile updates display name and avatar for all posts by an owner
+	// UpdateOwnerProfile updates display name and avatar for all posts by an owner and on the posts they co-author
 	UpdateOwnerProfile(ctx context.Context, ownerID uuid.UUID, displayName, avatar string) error
 
-	// SetCommentDisabled sets the comment disabled flag for a post with ownership validation
-	SetCommentDisabled(ctx context.Context, postID uuid.UUID, disabled bool, ownerID uuid.UUID) error
+	// ReplaceImage points the owner's posts showing the upload stored at key at a resized
+	// copy, filling in the thumbnail where the post has none, and returns their IDs
+	ReplaceImage(ctx context.Context, ownerID uuid.UUID, key, fullPath, thumbnail string) ([]uuid.UUID, error)
+
+	// UpdateMediaText stores text extracted from the post's images for search indexing
+	UpdateMediaText(ctx context.Context, postID uuid.UUID, mediaText string) error
+
+	// ArchiveExpired archives up to limit posts whose visible_until is at or before now (Unix milliseconds)
+	// and returns how many it archived
+	ArchiveExpired(ctx context.Context, now int64, limit int) (int64, error)
+
+	// GetReadMarkers returns the user's last-read created_date per feed key; feeds never read are omitted
+	GetReadMarkers(ctx context.Context, userID uuid.UUID, feedKeys []string) (map[string]int64, error)
+
+	// SaveReadMarker moves the user's last-read marker for a feed forward; older values are ignored
+	SaveReadMarker(ctx context.Context, userID uuid.UUID, feedKey string, lastReadDate int64) error
+
+	// DeleteReadMarkersByUser deletes the user's last-read markers of every feed
+	DeleteReadMarkersByUser(ctx context.Context, userID uuid.UUID) error
 
-	// SetSharingDisabled sets the sharing disabled flag for a post with ownership validation
-	SetSharingDisabled(ctx context.Context, postID uuid.UUID, disabled bool, ownerID uuid.UUID) error
+	// SetCommentDisabled sets the comment disabled flag for a post; editorID must be the owner or an accepted co-author
+	SetCommentDisabled(ctx context.Context, postID uuid.UUID, disabled bool, editorID uuid.UUID) error
+
+	// SetSharingDisabled sets the sharing disabled flag for a post; editorID must be the owner or an accepted co-author
+	SetSharingDisabled(ctx context.Context, postID uuid.UUID, disabled bool, editorID uuid.UUID) error
+
+	// AddCoauthor stores a pending co-author invitation; it returns false when the user is
+	// already invited to or co-authoring the post
+	AddCoauthor(ctx context.Context, coauthor *models.PostCoauthor) (bool, error)
+
+	// GetCoauthor returns the user's invitation for a post, or sql.ErrNoRows
+	GetCoauthor(ctx context.Context, postID, userID uuid.UUID) (*models.PostCoauthor, error)
+
+	// AcceptCoauthor marks a pending invitation accepted and records the co-author's profile;
+	// it returns false when there was no pending invitation
+	AcceptCoauthor(ctx context.Context, postID, userID uuid.UUID, displayName, avatar string, respondedDate int64) (bool, error)
+
+	// RemoveCoauthor deletes an invitation or co-authorship; it returns false when there was none
+	RemoveCoauthor(ctx context.Context, postID, userID uuid.UUID) (bool, error)
+
+	// DeleteCoauthorsByUser deletes the user's invitations and co-authorships on all posts
+	DeleteCoauthorsByUser(ctx context.Context, userID uuid.UUID) error
+
+	// AddCommunityPlacement places a post in a community's feed; it returns false when the
+	// post is already there
+	AddCommunityPlacement(ctx context.Context, placement *models.CommunityPlacement) (bool, error)
+
+	// RemoveCommunityPlacement takes a post out of one community's feed; it returns false
+	// when the post was not there
+	RemoveCommunityPlacement(ctx context.Context, postID, communityID uuid.UUID) (bool, error)
+
+	// ListCommunityPlacements returns the communities a post is placed in, oldest first
+	ListCommunityPlacements(ctx context.Context, postID uuid.UUID) ([]models.CommunityPlacement, error)
+
+	// ListCoauthors returns co-authors per post for the given posts, oldest first; an empty
+	// status returns every invitation
+	ListCoauthors(ctx context.Context, postIDs []uuid.UUID, status string) (map[uuid.UUID][]models.PostCoauthor, error)
+
+	// ListPendingCoauthorInvites returns the user's pending invitations on posts that still exist, newest first
+	ListPendingCoauthorInvites(ctx context.Context, userID uuid.UUID) ([]models.PostCoauthor, error)
+
+	// SetAcceptedAnswer records commentID as the accepted answer of a question ownerID wrote,
+	// or clears it when nil
+	SetAcceptedAnswer(ctx context.Context, postID uuid.UUID, commentID *uuid.UUID, ownerID uuid.UUID) error
+
+	// ClearAcceptedAnswer unmarks the comment wherever it is the accepted answer, e.g. when it is deleted
+	ClearAcceptedAnswer(ctx context.Context, commentID uuid.UUID) error
+
+	// SaveLiveThread schedules a live thread on a post or reschedules one that has not closed
+	SaveLiveThread(ctx context.Context, thread *models.LiveThread) error
+
+	// GetLiveThread returns the post's live thread, or sql.ErrNoRows
+	GetLiveThread(ctx context.Context, postID uuid.UUID) (*models.LiveThread, error)
+
+	// DeleteLiveThread cancels a live thread that has not closed; it returns false when there was none
+	DeleteLiveThread(ctx context.Context, postID uuid.UUID) (bool, error)
+
+	// CloseEndedLiveThreads closes up to limit threads whose window ended at or before now
+	// (Unix milliseconds), disables comments on their posts and returns the closed threads
+	CloseEndedLiveThreads(ctx context.Context, now int64, limit int) ([]*models.LiveThread, error)
+
+	// ListUnsummarizedLiveThreads returns closed threads still without a summary after
+	// fewer than maxAttempts tries, oldest first
+	ListUnsummarizedLiveThreads(ctx context.Context, maxAttempts, limit int) ([]*models.LiveThread, error)
+
+	// SaveLiveThreadSummary stores a thread's summary and counts the attempt; an empty
+	// summary records a failed attempt
+	SaveLiveThreadSummary(ctx context.Context, postID uuid.UUID, summary string, keyPoints []string) error
 
 	// IncrementViewCount atomically increments the view count for a post
 	IncrementViewCount(ctx context.Context, postID uuid.UUID) error
 
+	// IncrementTipCount atomically adds delta to the tip count of a post
+	IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error
+
 	// IncrementCommentCount atomically increments the comment count for a post
 	// This is used for denormalized count updates when comments are created/deleted
 	IncrementCommentCount(ctx context.Context, postID uuid.UUID, delta int) error
@@ -81,6 +225,10 @@ type PostRepository interface {
 	// Delete deletes a post by ID (soft delete)
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// DeleteByOwner permanently deletes all posts of a user, with the comments and
+	// other rows that reference them, and returns how many posts it deleted
+	DeleteByOwner(ctx context.Context, ownerID uuid.UUID) (int64, error)
+
 	// GetByIDs returns posts matching given IDs using ANY for bulk fetch.
 	GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error)
 }
@@ -54,6 +54,22 @@ func ApplyPostsMigration(ctx context.Context, client *postgres.Client, schema st
 			disable_sharing BOOLEAN DEFAULT FALSE,
 			permission VARCHAR(50) DEFAULT 'Public',
 			version VARCHAR(50),
+			media_text TEXT NOT NULL DEFAULT '',
+			content_hash VARCHAR(64) NOT NULL DEFAULT '',
+			visible_until BIGINT NOT NULL DEFAULT 0,
+			is_archived BOOLEAN NOT NULL DEFAULT FALSE,
+			license VARCHAR(32) NOT NULL DEFAULT '',
+			canonical_url TEXT NOT NULL DEFAULT '',
+			is_anonymous BOOLEAN NOT NULL DEFAULT FALSE,
+			anonymous_alias VARCHAR(64) NOT NULL DEFAULT '',
+			is_supporter_only BOOLEAN NOT NULL DEFAULT FALSE,
+			tip_count BIGINT NOT NULL DEFAULT 0,
+			accepted_answer_id UUID,
+			community_id UUID,
+			search_vector tsvector GENERATED ALWAYS AS (
+				setweight(to_tsvector('english', coalesce(body, '')), 'A') ||
+				setweight(to_tsvector('english', coalesce(media_text, '')), 'C')
+			) STORED,
 			metadata JSONB DEFAULT '{}'::jsonb
 		);
 		CREATE INDEX IF NOT EXISTS idx_posts_owner ON posts(owner_user_id);
@@ -63,6 +79,42 @@ func ApplyPostsMigration(ctx context.Context, client *postgres.Client, schema st
 		CREATE INDEX IF NOT EXISTS idx_posts_post_type ON posts(post_type_id);
 		CREATE INDEX IF NOT EXISTS idx_posts_deleted ON posts(is_deleted) WHERE is_deleted = FALSE;
 		CREATE INDEX IF NOT EXISTS idx_posts_url_key ON posts(url_key) WHERE url_key IS NOT NULL;
+		CREATE INDEX IF NOT EXISTS idx_posts_search_vector ON posts USING GIN (search_vector);
+		CREATE INDEX IF NOT EXISTS idx_posts_content_hash ON posts(content_hash, created_date DESC) WHERE content_hash <> '';
+		CREATE INDEX IF NOT EXISTS idx_posts_visible_until ON posts(visible_until) WHERE visible_until > 0 AND is_archived = FALSE;
+		CREATE INDEX IF NOT EXISTS idx_posts_license ON posts(license, created_date DESC) WHERE license <> '';
+		CREATE TABLE IF NOT EXISTS post_tags (
+			tag VARCHAR(50) NOT NULL,
+			post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+			PRIMARY KEY (tag, post_id)
+		);
+		CREATE INDEX IF NOT EXISTS idx_post_tags_post ON post_tags(post_id);
+		CREATE TABLE IF NOT EXISTS post_read_markers (
+			user_id UUID NOT NULL,
+			feed_key VARCHAR(300) NOT NULL,
+			last_read_date BIGINT NOT NULL DEFAULT 0,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (user_id, feed_key)
+		);
+		CREATE TABLE IF NOT EXISTS post_coauthors (
+			post_id UUID NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+			user_id UUID NOT NULL,
+			invited_by UUID NOT NULL,
+			status VARCHAR(16) NOT NULL DEFAULT 'pending',
+			display_name VARCHAR(255) NOT NULL DEFAULT '',
+			avatar VARCHAR(512) NOT NULL DEFAULT '',
+			created_date BIGINT NOT NULL,
+			responded_date BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (post_id, user_id),
+			CHECK (status IN ('pending', 'accepted'))
+		);
+		CREATE INDEX IF NOT EXISTS idx_post_coauthors_user ON post_coauthors(user_id, status);
+		CREATE TABLE IF NOT EXISTS follows (
+			follower_id UUID NOT NULL,
+			followee_id UUID NOT NULL,
+			created_date BIGINT NOT NULL,
+			PRIMARY KEY (follower_id, followee_id)
+		);
 	`
 
 	_, err := client.DB().ExecContext(ctx, migrationSQL)
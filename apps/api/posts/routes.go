@@ -1,12 +1,17 @@
 package posts
 
 import (
+	"net/url"
+
 	"github.com/gofiber/fiber/v2"
 	authhmac "github.com/qolzam/telar/apps/api/internal/middleware/authhmac"
 	constraints "github.com/qolzam/telar/apps/api/internal/middleware/constraints"
 	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
+	"github.com/qolzam/telar/apps/api/internal/middleware/ratelimit"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/posts/handlers"
+	"github.com/qolzam/telar/apps/api/posts/services"
 )
 
 // createDualAuthMiddleware creates dual authentication middleware using the shared helper
@@ -46,6 +51,12 @@ func RegisterRoutes(app *fiber.App, handlers *PostsHandlers, cfg *platformconfig
 	// Create dual auth middleware for user-facing routes during migration
 	dualAuthMiddleware := createDualAuthMiddleware(routerConfig)
 
+	previewLimiter := commentPreviewLimiter(cfg)
+	createLimit := cfg.RateLimits.PostCreate
+	createLimiter := ratelimit.NewWithConfig(createLimit.Enabled, createLimit.Max, createLimit.Duration, "post creation")
+	exportLimit := cfg.RateLimits.CommentExport
+	exportLimiter := ratelimit.NewWithConfig(exportLimit.Enabled, exportLimit.Max, exportLimit.Duration, "comment export")
+
 	group := app.Group("/posts")
 
 	// --- Service-to-Service Routes (HMAC-Only) ---
@@ -54,14 +65,27 @@ func RegisterRoutes(app *fiber.App, handlers *PostsHandlers, cfg *platformconfig
 	s2sActions.Put("/score", handlers.PostHandler.IncrementScore)
 	s2sActions.Put("/comment/count", handlers.PostHandler.IncrementCommentCount)
 
-	// Public search endpoint for autocomplete
+	// Public full-text search, also used for autocomplete
 	group.Get("/search", handlers.PostHandler.SearchPosts)
 
+	// --- Anonymous Public Routes (HTTP cached) ---
+	// Registered before the dual auth group so these paths never require credentials.
+	// Entries are keyed by tag and post so writes drop only the pages showing them.
+	public := group.Group("/public")
+	public.Get("/tags/:tag", httpcache.NewFromConfig(httpcache.ScopePosts, tagPageSubject, cfg.HTTPCache), handlers.PostHandler.GetPublicTagFeed)
+	public.Get("/urlkey/:urlkey", previewLimiter, httpcache.NewFromConfig(httpcache.ScopePosts, postPageSubject, cfg.HTTPCache), handlers.PostHandler.GetPublicPostByURLKey)
+
+	// --- Hashtags (public, HTTP cached) ---
+	// Tag pages change with posts; trending tags change when the job recounts them
+	tags := app.Group("/tags")
+	tags.Get("/trending", httpcache.NewFromConfig(httpcache.ScopeTags, nil, cfg.HTTPCache), handlers.PostHandler.GetTrendingTags)
+	tags.Get("/:tag/posts", httpcache.NewFromConfig(httpcache.ScopePosts, tagPageSubject, cfg.HTTPCache), handlers.PostHandler.GetTagPosts)
+
 	// --- User-Facing Routes (Dual Auth) ---
 	userGroup := group.Group("", dualAuthMiddleware)
 
 	// Base resource routes
-	userGroup.Post("/", handlers.PostHandler.CreatePost)
+	userGroup.Post("/", createLimiter, handlers.PostHandler.CreatePost)
 	userGroup.Put("/", handlers.PostHandler.UpdatePost)
 	userGroup.Put("/profile", handlers.PostHandler.UpdatePostProfile)
 
@@ -70,6 +94,10 @@ func RegisterRoutes(app *fiber.App, handlers *PostsHandlers, cfg *platformconfig
 	userGroup.Put("/share/disable", handlers.PostHandler.DisableSharing)
 	userGroup.Put("/urlkey/:postId", handlers.PostHandler.GeneratePostURLKey)
 
+	// Read-state ("new since last visit") markers
+	userGroup.Get("/read-state", handlers.PostHandler.GetUnreadCounts)
+	userGroup.Put("/read-state", handlers.PostHandler.MarkFeedRead)
+
 	// Base query route (backward compatibility)
 	userGroup.Get("/", handlers.PostHandler.QueryPosts) // GET /posts/
 
@@ -80,12 +108,71 @@ func RegisterRoutes(app *fiber.App, handlers *PostsHandlers, cfg *platformconfig
 	queryGroup.Get("/cursor", handlers.PostHandler.QueryPostsWithCursor)
 	queryGroup.Get("/search/cursor", handlers.PostHandler.SearchPostsWithCursor)
 
+	// Search by meaning through the AI engine; 503 when it is not configured
+	userGroup.Get("/semantic-search", handlers.PostHandler.SemanticSearch)
+
+	// Co-author invitations waiting on the caller (static path, before /:postId)
+	userGroup.Get("/coauthors/invitations", handlers.PostHandler.ListCoauthorInvites)
+
 	// --- Parameterized Routes for Specific Resources (MUST BE LAST) ---
 	// These routes operate on a single post, identified by a parameter.
-	userGroup.Get("/urlkey/:urlkey", handlers.PostHandler.GetPostByURLKey)
+	userGroup.Get("/urlkey/:urlkey", previewLimiter, handlers.PostHandler.GetPostByURLKey)
 
 	// The constraint is still a good practice for type safety and explicit validation.
 	userGroup.Get("/cursor/info/:postId", constraints.RequireUUID("postId"), handlers.PostHandler.GetCursorInfo)
-	userGroup.Get("/:postId", constraints.RequireUUID("postId"), handlers.PostHandler.GetPost)
+	userGroup.Get("/:postId", constraints.RequireUUID("postId"), previewLimiter, handlers.PostHandler.GetPost)
 	userGroup.Delete("/:postId", constraints.RequireUUID("postId"), handlers.PostHandler.DeletePost)
+
+	// Co-authors: the owner invites, invitees accept or decline, and co-authors may edit the post
+	userGroup.Get("/:postId/coauthors", constraints.RequireUUID("postId"), handlers.PostHandler.ListCoauthors)
+	userGroup.Post("/:postId/coauthors", constraints.RequireUUID("postId"), handlers.PostHandler.InviteCoauthor)
+	userGroup.Post("/:postId/coauthors/accept", constraints.RequireUUID("postId"), handlers.PostHandler.AcceptCoauthorInvite)
+	userGroup.Post("/:postId/coauthors/decline", constraints.RequireUUID("postId"), handlers.PostHandler.DeclineCoauthorInvite)
+	userGroup.Delete("/:postId/coauthors/:userId", constraints.RequireUUID("postId"), constraints.RequireUUID("userId"), handlers.PostHandler.RemoveCoauthor)
+
+	// Communities: the owner shares the post into communities; their moderators can take it out again
+	userGroup.Get("/:postId/communities", constraints.RequireUUID("postId"), handlers.PostHandler.ListCommunityPlacements)
+	userGroup.Post("/:postId/communities/:communityId", constraints.RequireUUID("postId"), constraints.RequireUUID("communityId"), handlers.PostHandler.ShareToCommunity)
+	userGroup.Delete("/:postId/communities/:communityId", constraints.RequireUUID("postId"), constraints.RequireUUID("communityId"), handlers.PostHandler.RemoveFromCommunity)
+
+	// Q&A: the question's author accepts one top-level comment as the answer
+	userGroup.Put("/:postId/accepted-answer", constraints.RequireUUID("postId"), handlers.PostHandler.AcceptAnswer)
+	userGroup.Delete("/:postId/accepted-answer", constraints.RequireUUID("postId"), handlers.PostHandler.ClearAcceptedAnswer)
+
+	// Comments export: the owner downloads every comment as CSV or JSON
+	userGroup.Get("/:postId/comments/export", constraints.RequireUUID("postId"), exportLimiter, handlers.PostHandler.ExportComments)
+
+	// Live threads: the owner schedules a window in which comments stream and are slow-moded
+	if cfg.LiveThreads.Enabled {
+		userGroup.Get("/:postId/live", constraints.RequireUUID("postId"), handlers.PostHandler.GetLiveThread)
+		userGroup.Put("/:postId/live", constraints.RequireUUID("postId"), handlers.PostHandler.ScheduleLiveThread)
+		userGroup.Delete("/:postId/live", constraints.RequireUUID("postId"), handlers.PostHandler.CancelLiveThread)
+	}
+}
+
+// commentPreviewLimiter rate limits post reads that ask for inline comment previews
+// (includeComments=preview), which add a comments query; plain reads pass through.
+func commentPreviewLimiter(cfg *platformconfig.Config) fiber.Handler {
+	limit := cfg.RateLimits.CommentPreview
+	limiter := ratelimit.NewWithConfig(limit.Enabled, limit.Max, limit.Duration, "comment preview")
+	return func(c *fiber.Ctx) error {
+		if c.Query("includeComments") == "" {
+			return c.Next()
+		}
+		return limiter(c)
+	}
+}
+
+// tagPageSubject keys cached tag feeds by normalized tag
+func tagPageSubject(c *fiber.Ctx) string {
+	tag, err := url.PathUnescape(c.Params("tag"))
+	if err != nil {
+		return ""
+	}
+	return services.TagPageSubject(tag)
+}
+
+// postPageSubject keys a cached public post page by its URL key
+func postPageSubject(c *fiber.Ctx) string {
+	return services.PostPageSubject(c.Params("urlkey"))
 }
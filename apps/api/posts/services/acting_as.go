@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// actingAsKey is the context key of the identity a delegate publishes as
+type actingAsKey struct{}
+
+// WithActingAs returns a context in which CreatePost publishes as identity instead of the
+// calling user. Callers must have checked the delegation grant first; the calling user
+// stays the author for hooks and rate limits and is recorded in the delegation audit.
+func WithActingAs(ctx context.Context, identity *sharedInterfaces.ActingIdentity) context.Context {
+	return context.WithValue(ctx, actingAsKey{}, identity)
+}
+
+// SetDelegationAuditor records delegated posts in the delegation audit trail. Without an
+// auditor, posts made while acting as another account are refused.
+func (s *postService) SetDelegationAuditor(auditor sharedInterfaces.DelegationAuditor) {
+	s.delegationAudit = auditor
+}
+
+// actingIdentity returns the identity set in ctx when it differs from the calling user
+func actingIdentity(ctx context.Context, user *types.UserContext) *sharedInterfaces.ActingIdentity {
+	identity, ok := ctx.Value(actingAsKey{}).(*sharedInterfaces.ActingIdentity)
+	if !ok || identity == nil || identity.UserID == user.UserID {
+		return nil
+	}
+	return identity
+}
+
+// auditDelegatedPost records that user published postID as the acting identity in ctx.
+// It runs in the post's transaction, so a delegated post never commits without its entry.
+func (s *postService) auditDelegatedPost(txCtx context.Context, user *types.UserContext, postID uuid.UUID) error {
+	identity := actingIdentity(txCtx, user)
+	if identity == nil {
+		return nil
+	}
+	if s.delegationAudit == nil {
+		return fmt.Errorf("%w: delegated posts cannot be audited", postsErrors.ErrServiceUnavailable)
+	}
+	return s.delegationAudit.RecordDelegatedAction(txCtx, identity, user.UserID, sharedInterfaces.DelegationScopePostCreate, postID)
+}
+
+// postOwner returns the user a new post belongs to: the acting identity when one is set
+// in ctx, otherwise the calling user.
+func postOwner(ctx context.Context, user *types.UserContext) *types.UserContext {
+	identity := actingIdentity(ctx, user)
+	if identity == nil {
+		return user
+	}
+	return &types.UserContext{
+		UserID:      identity.UserID,
+		DisplayName: identity.DisplayName,
+		SocialName:  identity.SocialName,
+		Avatar:      identity.Avatar,
+	}
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// anonymousURLKeyName replaces the owner's social name in anonymous post URL keys
+const anonymousURLKeyName = "anonymous"
+
+// checkAnonymous rejects anonymous posts unless they go to a community that allows
+// them; the main feed has no such setting
+func (s *postService) checkAnonymous(ctx context.Context, req *models.CreatePostRequest) error {
+	if !req.Anonymous {
+		return nil
+	}
+	if req.CommunityId == nil || s.communities == nil {
+		return postsErrors.ErrAnonymousDisabled
+	}
+	allowed, err := s.communities.AllowsAnonymousPosts(ctx, *req.CommunityId)
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if !allowed {
+		return postsErrors.ErrAnonymousDisabled
+	}
+	return nil
+}
+
+// anonymousAlias derives the thread pseudonym for an author on a post. The HMAC secret
+// keys it so aliases cannot be recomputed from public post and user IDs.
+func (s *postService) anonymousAlias(postID, userID uuid.UUID) string {
+	secret := ""
+	if s.config != nil {
+		secret = s.config.HMAC.Secret
+	}
+	return models.AnonymousAlias(secret, postID, userID)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// AcceptAnswer marks a top-level comment on the user's question as its accepted answer,
+// replacing any earlier choice
+func (s *postService) AcceptAnswer(ctx context.Context, postID, commentID uuid.UUID, user *types.UserContext) error {
+	if commentID == uuid.Nil {
+		return postsErrors.WrapValidationError(postsErrors.ErrMissingRequiredField, "commentId")
+	}
+	if _, err := s.findOwnQuestion(ctx, postID, user); err != nil {
+		return err
+	}
+	if s.commentRepo == nil {
+		return fmt.Errorf("comment repository is not configured")
+	}
+
+	comment, err := s.commentRepo.FindByID(ctx, commentID)
+	if err != nil {
+		if err.Error() == "comment not found" {
+			return postsErrors.ErrInvalidAnswer
+		}
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if comment.Deleted || comment.PostId != postID || comment.ParentCommentId != nil {
+		return postsErrors.ErrInvalidAnswer
+	}
+
+	if err := s.repo.SetAcceptedAnswer(ctx, postID, &commentID, user.UserID); err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	s.invalidateAnsweredPost(ctx, postID)
+	return nil
+}
+
+// ClearAcceptedAnswer returns the user's question to the unanswered state
+func (s *postService) ClearAcceptedAnswer(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	if _, err := s.findOwnQuestion(ctx, postID, user); err != nil {
+		return err
+	}
+	if err := s.repo.SetAcceptedAnswer(ctx, postID, nil, user.UserID); err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	s.invalidateAnsweredPost(ctx, postID)
+	return nil
+}
+
+// findOwnQuestion loads a live question post written by the user. Co-authors do not
+// pass: only the asker decides which answer solved their question.
+func (s *postService) findOwnQuestion(ctx context.Context, postID uuid.UUID, user *types.UserContext) (*models.Post, error) {
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.OwnerUserId != user.UserID {
+		return nil, postsErrors.ErrPostOwnershipRequired
+	}
+	if !post.IsQuestion() {
+		return nil, postsErrors.ErrNotQuestion
+	}
+	return post, nil
+}
+
+// invalidateAnsweredPost drops the cached question and the cached feeds that filter on
+// answered state
+func (s *postService) invalidateAnsweredPost(ctx context.Context, postID uuid.UUID) {
+	if s.cacheService != nil {
+		s.invalidatePost(ctx, postID)
+		s.invalidatePostLists(ctx)
+	}
+}
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commentModels "github.com/qolzam/telar/apps/api/comments/models"
+	commentMocks "github.com/qolzam/telar/apps/api/comments/services/mocks"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+func TestAcceptAnswer(t *testing.T) {
+	service, repo := setupTestService()
+	commentRepo := &commentMocks.MockCommentRepository{}
+	service.commentRepo = commentRepo
+	ctx := context.Background()
+
+	question := createTestPost()
+	question.PostTypeId = models.PostTypeQuestion
+	statement := createTestPost()
+	owner := &types.UserContext{UserID: question.OwnerUserId}
+	repo.On("FindByID", ctx, question.ObjectId).Return(question, nil)
+	repo.On("FindByID", ctx, statement.ObjectId).Return(statement, nil)
+
+	answerID := uuid.Must(uuid.NewV4())
+	replyID := uuid.Must(uuid.NewV4())
+	otherID := uuid.Must(uuid.NewV4())
+	commentRepo.On("FindByID", ctx, answerID).Return(&commentModels.Comment{ObjectId: answerID, PostId: question.ObjectId}, nil)
+	commentRepo.On("FindByID", ctx, replyID).Return(&commentModels.Comment{ObjectId: replyID, PostId: question.ObjectId, ParentCommentId: &answerID}, nil)
+	commentRepo.On("FindByID", ctx, otherID).Return(&commentModels.Comment{ObjectId: otherID, PostId: statement.ObjectId}, nil)
+
+	err := service.AcceptAnswer(ctx, statement.ObjectId, answerID, &types.UserContext{UserID: statement.OwnerUserId})
+	assert.ErrorIs(t, err, postsErrors.ErrNotQuestion)
+
+	err = service.AcceptAnswer(ctx, question.ObjectId, answerID, createTestUserContext())
+	assert.ErrorIs(t, err, postsErrors.ErrPostOwnershipRequired)
+
+	for _, invalid := range []uuid.UUID{replyID, otherID} {
+		err = service.AcceptAnswer(ctx, question.ObjectId, invalid, owner)
+		assert.ErrorIs(t, err, postsErrors.ErrInvalidAnswer)
+	}
+
+	repo.On("SetAcceptedAnswer", ctx, question.ObjectId, &answerID, owner.UserID).Return(nil).Once()
+	require.NoError(t, service.AcceptAnswer(ctx, question.ObjectId, answerID, owner))
+
+	repo.On("SetAcceptedAnswer", ctx, question.ObjectId, (*uuid.UUID)(nil), owner.UserID).Return(nil).Once()
+	require.NoError(t, service.ClearAcceptedAnswer(ctx, question.ObjectId, owner))
+	repo.AssertExpectations(t)
+}
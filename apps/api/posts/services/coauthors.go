@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// InviteCoauthor lets the post owner invite a user to co-author the post. The invitee
+// gains nothing until they accept.
+func (s *postService) InviteCoauthor(ctx context.Context, postID uuid.UUID, req *models.InviteCoauthorRequest, user *types.UserContext) (*models.PostCoauthor, error) {
+	if req == nil || req.UserId == uuid.Nil {
+		return nil, postsErrors.WrapValidationError(postsErrors.ErrMissingRequiredField, "userId")
+	}
+
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.OwnerUserId != user.UserID {
+		return nil, postsErrors.ErrPostOwnershipRequired
+	}
+	// The invitation and the author block would both name the owner
+	if post.Anonymous {
+		return nil, postsErrors.ErrAnonymousPost
+	}
+	if req.UserId == post.OwnerUserId {
+		return nil, postsErrors.WrapValidationError(postsErrors.ErrInvalidFieldValue, "the owner cannot co-author their own post")
+	}
+
+	existing, err := s.repo.ListCoauthors(ctx, []uuid.UUID{postID}, "")
+	if err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	if len(existing[postID]) >= models.MaxCoauthors {
+		return nil, postsErrors.ErrTooManyCoauthors
+	}
+
+	coauthor := &models.PostCoauthor{
+		PostId:      postID,
+		UserId:      req.UserId,
+		InvitedBy:   user.UserID,
+		Status:      models.CoauthorStatusPending,
+		CreatedDate: time.Now().UTC().UnixMilli(),
+	}
+	added, err := s.repo.AddCoauthor(ctx, coauthor)
+	if err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	if !added {
+		return nil, postsErrors.ErrCoauthorExists
+	}
+	return coauthor, nil
+}
+
+// AcceptCoauthorInvite accepts the current user's pending invitation for a post
+func (s *postService) AcceptCoauthorInvite(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	if _, err := s.findLivePost(ctx, postID); err != nil {
+		return err
+	}
+
+	accepted, err := s.repo.AcceptCoauthor(ctx, postID, user.UserID, user.DisplayName, user.Avatar, time.Now().UTC().UnixMilli())
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if !accepted {
+		return postsErrors.ErrCoauthorNotFound
+	}
+
+	s.invalidateCoauthoredPosts(ctx, postID)
+	return nil
+}
+
+// DeclineCoauthorInvite drops the current user's pending invitation for a post
+func (s *postService) DeclineCoauthorInvite(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	invite, err := s.repo.GetCoauthor(ctx, postID, user.UserID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postsErrors.ErrCoauthorNotFound
+		}
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if invite.Status != models.CoauthorStatusPending {
+		return postsErrors.ErrCoauthorNotFound
+	}
+
+	if _, err := s.repo.RemoveCoauthor(ctx, postID, user.UserID); err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	return nil
+}
+
+// RemoveCoauthor removes a co-author or pending invitation. The owner can remove anyone;
+// a co-author can only remove themselves.
+func (s *postService) RemoveCoauthor(ctx context.Context, postID, coauthorID uuid.UUID, user *types.UserContext) error {
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.OwnerUserId != user.UserID && coauthorID != user.UserID {
+		return postsErrors.ErrPostOwnershipRequired
+	}
+
+	removed, err := s.repo.RemoveCoauthor(ctx, postID, coauthorID)
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if !removed {
+		return postsErrors.ErrCoauthorNotFound
+	}
+
+	s.invalidateCoauthoredPosts(ctx, postID)
+	return nil
+}
+
+// ListCoauthors returns a post's co-authors. The owner also sees pending invitations.
+func (s *postService) ListCoauthors(ctx context.Context, postID uuid.UUID, user *types.UserContext) ([]models.PostCoauthor, error) {
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := models.CoauthorStatusAccepted
+	if user != nil && post.OwnerUserId == user.UserID {
+		status = ""
+	}
+	coauthors, err := s.repo.ListCoauthors(ctx, []uuid.UUID{postID}, status)
+	if err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	if coauthors[postID] == nil {
+		return []models.PostCoauthor{}, nil
+	}
+	return coauthors[postID], nil
+}
+
+// ListCoauthorInvites returns the current user's pending invitations with the posts they are for
+func (s *postService) ListCoauthorInvites(ctx context.Context, user *types.UserContext) ([]models.CoauthorInvitation, error) {
+	invites, err := s.repo.ListPendingCoauthorInvites(ctx, user.UserID)
+	if err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	if len(invites) == 0 {
+		return []models.CoauthorInvitation{}, nil
+	}
+
+	postIDs := make([]uuid.UUID, len(invites))
+	for i, invite := range invites {
+		postIDs[i] = invite.PostId
+	}
+	posts, err := s.repo.GetByIDs(ctx, postIDs)
+	if err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	byID := make(map[uuid.UUID]*models.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ObjectId] = post
+	}
+
+	result := make([]models.CoauthorInvitation, 0, len(invites))
+	for _, invite := range invites {
+		post, ok := byID[invite.PostId]
+		if !ok {
+			continue
+		}
+		result = append(result, models.CoauthorInvitation{
+			Invitation: invite,
+			Post:       s.ConvertPostToResponse(withoutViewer(ctx), post),
+		})
+	}
+	return result, nil
+}
+
+// AttachCoauthors fills the author block (coauthors) of each post with its accepted
+// co-authors in one query. Like comment previews it is best-effort: on failure the posts
+// are returned with the owner alone.
+func (s *postService) AttachCoauthors(ctx context.Context, posts []models.PostResponse) {
+	if len(posts) == 0 {
+		return
+	}
+
+	postIDs := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		if id, err := uuid.FromString(post.ObjectId); err == nil {
+			postIDs = append(postIDs, id)
+		}
+	}
+
+	coauthors, err := s.repo.ListCoauthors(ctx, postIDs, models.CoauthorStatusAccepted)
+	if err != nil {
+		log.Warn("Failed to load co-authors for %d posts: %v", len(postIDs), err)
+		return
+	}
+
+	for i := range posts {
+		id, err := uuid.FromString(posts[i].ObjectId)
+		if err != nil {
+			continue
+		}
+		for _, coauthor := range coauthors[id] {
+			posts[i].Coauthors = append(posts[i].Coauthors, models.PostAuthor{
+				UserId:      coauthor.UserId.String(),
+				DisplayName: coauthor.DisplayName,
+				Avatar:      coauthor.Avatar,
+			})
+		}
+	}
+}
+
+// requireEditor checks that the user may edit the post: its owner or an accepted co-author
+func (s *postService) requireEditor(ctx context.Context, post *models.Post, userID uuid.UUID) error {
+	if post.OwnerUserId == userID {
+		return nil
+	}
+
+	coauthor, err := s.repo.GetCoauthor(ctx, post.ObjectId, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postsErrors.ErrPostOwnershipRequired
+		}
+		return fmt.Errorf("failed to check co-author: %w", err)
+	}
+	if coauthor.Status != models.CoauthorStatusAccepted {
+		return postsErrors.ErrPostOwnershipRequired
+	}
+	return nil
+}
+
+// findLivePost loads a post that has not been deleted
+func (s *postService) findLivePost(ctx context.Context, postID uuid.UUID) (*models.Post, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postsErrors.ErrPostNotFound
+		}
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	if post.Deleted {
+		return nil, postsErrors.ErrPostNotFound
+	}
+	return post, nil
+}
+
+// invalidateCoauthoredPosts drops cached entries after a change to a post's authors,
+// which affects its author block and the co-author's profile feed
+func (s *postService) invalidateCoauthoredPosts(ctx context.Context, postID uuid.UUID) {
+	if s.cacheService != nil {
+		s.invalidatePost(ctx, postID)
+		s.invalidatePostLists(ctx)
+	}
+}
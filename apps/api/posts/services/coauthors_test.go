@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+func TestInviteCoauthor(t *testing.T) {
+	service, repo := setupTestService()
+	ctx := context.Background()
+	post := createTestPost()
+	owner := &types.UserContext{UserID: post.OwnerUserId}
+	invitee := uuid.Must(uuid.NewV4())
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+
+	_, err := service.InviteCoauthor(ctx, post.ObjectId, &models.InviteCoauthorRequest{UserId: invitee}, createTestUserContext())
+	assert.ErrorIs(t, err, postsErrors.ErrPostOwnershipRequired)
+
+	_, err = service.InviteCoauthor(ctx, post.ObjectId, &models.InviteCoauthorRequest{UserId: owner.UserID}, owner)
+	assert.Error(t, err)
+
+	full := make([]models.PostCoauthor, models.MaxCoauthors)
+	repo.On("ListCoauthors", ctx, []uuid.UUID{post.ObjectId}, "").Return(map[uuid.UUID][]models.PostCoauthor{post.ObjectId: full}, nil).Once()
+	_, err = service.InviteCoauthor(ctx, post.ObjectId, &models.InviteCoauthorRequest{UserId: invitee}, owner)
+	assert.ErrorIs(t, err, postsErrors.ErrTooManyCoauthors)
+
+	repo.On("ListCoauthors", ctx, []uuid.UUID{post.ObjectId}, "").Return(map[uuid.UUID][]models.PostCoauthor{}, nil)
+	repo.On("AddCoauthor", ctx, mock.MatchedBy(func(c *models.PostCoauthor) bool { return c.UserId == invitee })).Return(true, nil).Once()
+	invite, err := service.InviteCoauthor(ctx, post.ObjectId, &models.InviteCoauthorRequest{UserId: invitee}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, models.CoauthorStatusPending, invite.Status)
+	assert.Equal(t, owner.UserID, invite.InvitedBy)
+
+	repo.On("AddCoauthor", ctx, mock.Anything).Return(false, nil).Once()
+	_, err = service.InviteCoauthor(ctx, post.ObjectId, &models.InviteCoauthorRequest{UserId: invitee}, owner)
+	assert.ErrorIs(t, err, postsErrors.ErrCoauthorExists)
+}
+
+func TestUpdatePost_CoauthorMayEdit(t *testing.T) {
+	service, repo := setupTestService()
+	ctx := context.Background()
+	post := createTestPost()
+	coauthor := createTestUserContext()
+	pending := createTestUserContext()
+	body := "Edited together"
+	req := &models.UpdatePostRequest{Body: &body}
+
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+	repo.On("GetCoauthor", ctx, post.ObjectId, coauthor.UserID).Return(&models.PostCoauthor{Status: models.CoauthorStatusAccepted}, nil)
+	repo.On("GetCoauthor", ctx, post.ObjectId, pending.UserID).Return(&models.PostCoauthor{Status: models.CoauthorStatusPending}, nil)
+	repo.On("Update", ctx, mock.MatchedBy(func(p *models.Post) bool { return p.Body == body })).Return(nil)
+
+	assert.NoError(t, service.UpdatePost(ctx, post.ObjectId, req, coauthor))
+	assert.ErrorIs(t, service.UpdatePost(ctx, post.ObjectId, req, pending), postsErrors.ErrPostOwnershipRequired)
+	repo.AssertNumberOfCalls(t, "Update", 1)
+}
+
+func TestSoftDeletePost_CoauthorCannotDelete(t *testing.T) {
+	service, repo := setupTestService()
+	ctx := context.Background()
+	post := createTestPost()
+	coauthor := createTestUserContext()
+
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+
+	err := service.SoftDeletePost(ctx, post.ObjectId, coauthor)
+	assert.ErrorIs(t, err, postsErrors.ErrPostOwnershipRequired)
+	repo.AssertNotCalled(t, "GetCoauthor", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRemoveCoauthor_OwnerOrSelf(t *testing.T) {
+	service, repo := setupTestService()
+	ctx := context.Background()
+	post := createTestPost()
+	coauthorID := uuid.Must(uuid.NewV4())
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+	repo.On("RemoveCoauthor", ctx, post.ObjectId, coauthorID).Return(true, nil)
+
+	err := service.RemoveCoauthor(ctx, post.ObjectId, coauthorID, createTestUserContext())
+	assert.ErrorIs(t, err, postsErrors.ErrPostOwnershipRequired)
+
+	assert.NoError(t, service.RemoveCoauthor(ctx, post.ObjectId, coauthorID, &types.UserContext{UserID: coauthorID}))
+	assert.NoError(t, service.RemoveCoauthor(ctx, post.ObjectId, coauthorID, &types.UserContext{UserID: post.OwnerUserId}))
+}
+
+func TestDeclineCoauthorInvite_OnlyPending(t *testing.T) {
+	service, repo := setupTestService()
+	ctx := context.Background()
+	postID := uuid.Must(uuid.NewV4())
+	user := createTestUserContext()
+
+	repo.On("GetCoauthor", ctx, postID, user.UserID).Return(nil, sql.ErrNoRows).Once()
+	assert.ErrorIs(t, service.DeclineCoauthorInvite(ctx, postID, user), postsErrors.ErrCoauthorNotFound)
+
+	repo.On("GetCoauthor", ctx, postID, user.UserID).Return(&models.PostCoauthor{Status: models.CoauthorStatusAccepted}, nil).Once()
+	assert.ErrorIs(t, service.DeclineCoauthorInvite(ctx, postID, user), postsErrors.ErrCoauthorNotFound)
+	repo.AssertNotCalled(t, "RemoveCoauthor", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAttachCoauthors(t *testing.T) {
+	service, repo := setupTestService()
+	ctx := context.Background()
+	first, second := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	coauthorID := uuid.Must(uuid.NewV4())
+	posts := []models.PostResponse{{ObjectId: first.String()}, {ObjectId: second.String()}}
+
+	repo.On("ListCoauthors", ctx, []uuid.UUID{first, second}, models.CoauthorStatusAccepted).Return(map[uuid.UUID][]models.PostCoauthor{
+		second: {{PostId: second, UserId: coauthorID, DisplayName: "Co Author", Avatar: "co.png"}},
+	}, nil)
+
+	service.AttachCoauthors(ctx, posts)
+
+	assert.Empty(t, posts[0].Coauthors)
+	assert.Equal(t, []models.PostAuthor{{UserId: coauthorID.String(), DisplayName: "Co Author", Avatar: "co.png"}}, posts[1].Coauthors)
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// commentExportBatch is how many comments an export reads per query
+const commentExportBatch = 500
+
+// CommentExport pages through a post's live comments and replies, oldest first, handing
+// each batch to emit. It stops at the first error emit returns.
+type CommentExport func(ctx context.Context, emit func([]models.CommentExportRow) error) error
+
+// ExportComments lets the owner of a post download its comments. Co-authors do not
+// pass: the commenters' list belongs to whoever published the post. The ownership check
+// runs now; the returned export reads the comments when called, so a handler can stream
+// them after it has committed to a response.
+func (s *postService) ExportComments(ctx context.Context, postID uuid.UUID, user *types.UserContext) (CommentExport, error) {
+	if user == nil {
+		return nil, postsErrors.ErrMissingUserContext
+	}
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.OwnerUserId != user.UserID {
+		return nil, postsErrors.ErrPostOwnershipRequired
+	}
+	if s.commentRepo == nil {
+		return nil, fmt.Errorf("comment repository is not configured")
+	}
+
+	return func(ctx context.Context, emit func([]models.CommentExportRow) error) error {
+		var afterDate int64
+		var afterID uuid.UUID
+		for {
+			comments, err := s.commentRepo.FindByPostIDAfter(ctx, postID, afterDate, afterID, commentExportBatch)
+			if err != nil {
+				return postsErrors.WrapDatabaseError(err)
+			}
+			if len(comments) == 0 {
+				return nil
+			}
+
+			rows := make([]models.CommentExportRow, 0, len(comments))
+			for _, c := range comments {
+				row := models.CommentExportRow{
+					CommentId:         c.ObjectId.String(),
+					AuthorUserId:      c.OwnerUserId.String(),
+					AuthorDisplayName: c.OwnerDisplayName,
+					Text:              c.Text,
+					Score:             c.Score,
+					CreatedDate:       c.CreatedDate,
+					LastUpdated:       c.LastUpdated,
+				}
+				if c.ParentCommentId != nil {
+					row.ParentCommentId = c.ParentCommentId.String()
+				}
+				rows = append(rows, row)
+			}
+			if err := emit(rows); err != nil {
+				return err
+			}
+
+			if len(comments) < commentExportBatch {
+				return nil
+			}
+			last := comments[len(comments)-1]
+			afterDate, afterID = last.CreatedDate, last.ObjectId
+		}
+	}, nil
+}
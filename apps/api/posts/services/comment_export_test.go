@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commentModels "github.com/qolzam/telar/apps/api/comments/models"
+	commentMocks "github.com/qolzam/telar/apps/api/comments/services/mocks"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+func TestExportComments(t *testing.T) {
+	service, repo := setupTestService()
+	commentRepo := &commentMocks.MockCommentRepository{}
+	service.commentRepo = commentRepo
+	ctx := context.Background()
+
+	post := createTestPost()
+	owner := &types.UserContext{UserID: post.OwnerUserId}
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+
+	_, err := service.ExportComments(ctx, post.ObjectId, createTestUserContext())
+	assert.ErrorIs(t, err, postsErrors.ErrPostOwnershipRequired)
+
+	// A full first batch makes the export read on from its last comment
+	first := make([]*commentModels.Comment, commentExportBatch)
+	for i := range first {
+		first[i] = &commentModels.Comment{ObjectId: uuid.Must(uuid.NewV4()), PostId: post.ObjectId, CreatedDate: int64(1000 + i)}
+	}
+	rootID := first[0].ObjectId
+	reply := &commentModels.Comment{ObjectId: uuid.Must(uuid.NewV4()), PostId: post.ObjectId, ParentCommentId: &rootID, Text: "me!", CreatedDate: 5000}
+	last := first[len(first)-1]
+	commentRepo.On("FindByPostIDAfter", ctx, post.ObjectId, int64(0), uuid.Nil, commentExportBatch).Return(first, nil).Once()
+	commentRepo.On("FindByPostIDAfter", ctx, post.ObjectId, last.CreatedDate, last.ObjectId, commentExportBatch).Return([]*commentModels.Comment{reply}, nil).Once()
+
+	export, err := service.ExportComments(ctx, post.ObjectId, owner)
+	require.NoError(t, err)
+
+	var rows []models.CommentExportRow
+	require.NoError(t, export(ctx, func(batch []models.CommentExportRow) error {
+		rows = append(rows, batch...)
+		return nil
+	}))
+	require.Len(t, rows, commentExportBatch+1)
+	assert.Equal(t, models.CommentExportRow{
+		CommentId:       reply.ObjectId.String(),
+		ParentCommentId: rootID.String(),
+		AuthorUserId:    uuid.Nil.String(),
+		Text:            "me!",
+		CreatedDate:     5000,
+	}, rows[len(rows)-1])
+	commentRepo.AssertExpectations(t)
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	commentsCommon "github.com/qolzam/telar/apps/api/comments/common"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// commentPreviewTextLength caps the text of each previewed comment
+const commentPreviewTextLength = 280
+
+// AttachLatestComments sets latestComments on each post to its newest root comments,
+// fetched for all posts in one query. Previews are best-effort: when the comments
+// store is unavailable the posts are returned without them.
+func (s *postService) AttachLatestComments(ctx context.Context, posts []models.PostResponse) {
+	if s.commentRepo == nil || len(posts) == 0 {
+		return
+	}
+
+	postIDs := make([]uuid.UUID, 0, len(posts))
+	for _, post := range posts {
+		if post.DisableComments || post.CommentCounter == 0 || post.Locked {
+			continue
+		}
+		if id, err := uuid.FromString(post.ObjectId); err == nil {
+			postIDs = append(postIDs, id)
+		}
+	}
+	if len(postIDs) == 0 {
+		return
+	}
+
+	latest, err := s.commentRepo.LatestByPostIDs(ctx, postIDs, models.CommentPreviewCount)
+	if err != nil {
+		log.Warn("Failed to load comment previews for %d posts: %v", len(postIDs), err)
+		return
+	}
+
+	anonymous := s.anonymousPostsIn(ctx, posts)
+	muted := s.mutedFor(ctx)
+	for i := range posts {
+		id, err := uuid.FromString(posts[i].ObjectId)
+		if err != nil {
+			continue
+		}
+		for _, comment := range latest[id] {
+			if muted != nil && muted(comment.OwnerUserId.String(), comment.Text) {
+				continue
+			}
+			preview := models.CommentPreview{
+				ObjectId:         comment.ObjectId.String(),
+				OwnerUserId:      comment.OwnerUserId.String(),
+				OwnerDisplayName: comment.OwnerDisplayName,
+				OwnerAvatar:      comment.OwnerAvatar,
+				Text:             commentsCommon.GenerateCommentPreview(comment.Text, commentPreviewTextLength),
+				CreatedDate:      comment.CreatedDate,
+			}
+			if post, ok := anonymous[id]; ok && post.IsHiddenAuthor(comment.OwnerUserId) {
+				preview.OwnerUserId = ""
+				preview.OwnerDisplayName = post.AnonymousAlias
+				preview.OwnerAvatar = ""
+			}
+			posts[i].LatestComments = append(posts[i].LatestComments, preview)
+		}
+	}
+}
+
+// anonymousPostsIn loads the stored anonymous posts among a response page; responses no
+// longer carry the real owner, which previews need to recognise the author's comments
+func (s *postService) anonymousPostsIn(ctx context.Context, posts []models.PostResponse) map[uuid.UUID]*models.Post {
+	var ids []uuid.UUID
+	for _, post := range posts {
+		if !post.Anonymous {
+			continue
+		}
+		if id, err := uuid.FromString(post.ObjectId); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	stored, err := s.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		log.Warn("Failed to load anonymous posts for comment previews: %v", err)
+		return nil
+	}
+	byID := make(map[uuid.UUID]*models.Post, len(stored))
+	for _, post := range stored {
+		byID[post.ObjectId] = post
+	}
+	return byID
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	commentModels "github.com/qolzam/telar/apps/api/comments/models"
+	commentMocks "github.com/qolzam/telar/apps/api/comments/services/mocks"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachLatestComments(t *testing.T) {
+	ctx := context.Background()
+	withComments := uuid.Must(uuid.NewV4())
+	noComments := uuid.Must(uuid.NewV4())
+	disabled := uuid.Must(uuid.NewV4())
+	commenter := uuid.Must(uuid.NewV4())
+
+	newPosts := func() []models.PostResponse {
+		return []models.PostResponse{
+			{ObjectId: withComments.String(), CommentCounter: 3},
+			{ObjectId: noComments.String()},
+			{ObjectId: disabled.String(), CommentCounter: 1, DisableComments: true},
+		}
+	}
+
+	t.Run("batch loads previews for posts that have comments", func(t *testing.T) {
+		commentRepo := &commentMocks.MockCommentRepository{}
+		commentRepo.On("LatestByPostIDs", ctx, []uuid.UUID{withComments}, models.CommentPreviewCount).
+			Return(map[uuid.UUID][]*commentModels.Comment{
+				withComments: {
+					{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: commenter, OwnerDisplayName: "Ada", Text: "  newest  ", CreatedDate: 2},
+					{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: commenter, OwnerDisplayName: "Ada", Text: "older", CreatedDate: 1},
+				},
+			}, nil).Once()
+
+		posts := newPosts()
+		(&postService{commentRepo: commentRepo}).AttachLatestComments(ctx, posts)
+
+		assert.Len(t, posts[0].LatestComments, 2)
+		assert.Equal(t, "newest", posts[0].LatestComments[0].Text)
+		assert.Equal(t, commenter.String(), posts[0].LatestComments[0].OwnerUserId)
+		assert.Empty(t, posts[1].LatestComments)
+		assert.Empty(t, posts[2].LatestComments)
+		commentRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns posts unchanged when the comments store fails", func(t *testing.T) {
+		commentRepo := &commentMocks.MockCommentRepository{}
+		commentRepo.On("LatestByPostIDs", ctx, []uuid.UUID{withComments}, models.CommentPreviewCount).
+			Return(nil, errors.New("connection refused")).Once()
+
+		posts := newPosts()
+		(&postService{commentRepo: commentRepo}).AttachLatestComments(ctx, posts)
+		assert.Empty(t, posts[0].LatestComments)
+	})
+}
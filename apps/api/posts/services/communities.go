@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetCommunityChecker enables posting in communities; without a checker posts can only
+// go to the main feed
+func (s *postService) SetCommunityChecker(checker sharedInterfaces.CommunityChecker) {
+	s.communities = checker
+}
+
+// checkCommunity lets members of an active community post in it, in any role
+func (s *postService) checkCommunity(ctx context.Context, communityID, userID uuid.UUID) error {
+	if s.communities == nil {
+		return postsErrors.ErrCommunitiesUnavailable
+	}
+	archived, err := s.communities.IsCommunityArchived(ctx, communityID)
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if archived {
+		return postsErrors.ErrCommunityArchived
+	}
+	member, err := s.communities.IsCommunityMember(ctx, communityID, userID)
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if !member {
+		return postsErrors.ErrCommunityMembershipRequired
+	}
+	return nil
+}
+
+// ShareToCommunity places the owner's post in another community they belong to. The
+// post is not copied: it shows in that community's feed as well.
+func (s *postService) ShareToCommunity(ctx context.Context, postID, communityID uuid.UUID, user *types.UserContext) (*models.CommunityPlacement, error) {
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.OwnerUserId != user.UserID {
+		return nil, postsErrors.ErrPostOwnershipRequired
+	}
+	if post.DisableSharing {
+		return nil, postsErrors.ErrSharingDisabled
+	}
+	if err := s.checkCommunity(ctx, communityID, user.UserID); err != nil {
+		return nil, err
+	}
+	if err := s.checkRules(ctx, communityID, user); err != nil {
+		return nil, err
+	}
+
+	placement := &models.CommunityPlacement{
+		PostId:      postID,
+		CommunityId: communityID,
+		PlacedBy:    user.UserID,
+		PlacedDate:  time.Now().UTC().UnixMilli(),
+	}
+	added, err := s.repo.AddCommunityPlacement(ctx, placement)
+	if err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	if !added {
+		return nil, postsErrors.ErrAlreadyInCommunity
+	}
+
+	if s.cacheService != nil {
+		s.invalidatePostLists(ctx)
+	}
+	return placement, nil
+}
+
+// RemoveFromCommunity takes a post out of one community's feed. The owner can remove
+// their post from any community; the community's owner and moderators from theirs only.
+func (s *postService) RemoveFromCommunity(ctx context.Context, postID, communityID uuid.UUID, user *types.UserContext) error {
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.OwnerUserId != user.UserID {
+		if s.communities == nil {
+			return postsErrors.ErrCommunitiesUnavailable
+		}
+		moderator, err := s.communities.IsCommunityModerator(ctx, communityID, user.UserID)
+		if err != nil {
+			return postsErrors.WrapDatabaseError(err)
+		}
+		if !moderator {
+			return postsErrors.ErrPermissionDenied
+		}
+	}
+
+	removed, err := s.repo.RemoveCommunityPlacement(ctx, postID, communityID)
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if !removed {
+		return postsErrors.ErrNotInCommunity
+	}
+
+	if s.cacheService != nil {
+		s.invalidatePostLists(ctx)
+	}
+	return nil
+}
+
+// ListCommunityPlacements returns the communities a post shows in
+func (s *postService) ListCommunityPlacements(ctx context.Context, postID uuid.UUID) ([]models.CommunityPlacement, error) {
+	if _, err := s.findLivePost(ctx, postID); err != nil {
+		return nil, err
+	}
+	placements, err := s.repo.ListCommunityPlacements(ctx, postID)
+	if err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	if placements == nil {
+		placements = []models.CommunityPlacement{}
+	}
+	return placements, nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+type stubCommunities struct {
+	member    bool
+	moderator bool
+	archived  bool
+	anonymous bool
+	err       error
+}
+
+func (s *stubCommunities) IsCommunityMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return s.member, s.err
+}
+
+func (s *stubCommunities) IsCommunityModerator(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return s.moderator, s.err
+}
+
+func (s *stubCommunities) IsCommunityArchived(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return s.archived, s.err
+}
+
+func (s *stubCommunities) AllowsAnonymousPosts(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return s.anonymous, s.err
+}
+
+func (s *stubCommunities) RequiresApproval(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, s.err
+}
+
+func TestCheckCommunity(t *testing.T) {
+	ctx := context.Background()
+	community := uuid.Must(uuid.NewV4())
+	author := uuid.Must(uuid.NewV4())
+
+	assert.ErrorIs(t, (&postService{}).checkCommunity(ctx, community, author), postsErrors.ErrCommunitiesUnavailable)
+	assert.ErrorIs(t, (&postService{communities: &stubCommunities{}}).checkCommunity(ctx, community, author), postsErrors.ErrCommunityMembershipRequired)
+	assert.ErrorContains(t, (&postService{communities: &stubCommunities{err: errors.New("down")}}).checkCommunity(ctx, community, author), "down")
+	assert.NoError(t, (&postService{communities: &stubCommunities{member: true}}).checkCommunity(ctx, community, author))
+	assert.ErrorIs(t, (&postService{communities: &stubCommunities{member: true, archived: true}}).checkCommunity(ctx, community, author), postsErrors.ErrCommunityArchived)
+}
+
+// stubRules reports the rules of every community as acknowledged, or of none
+type stubRules struct {
+	acknowledged bool
+}
+
+func (s *stubRules) HasAcknowledgedRules(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return s.acknowledged, nil
+}
+
+func TestCheckRules(t *testing.T) {
+	ctx := context.Background()
+	community := uuid.Must(uuid.NewV4())
+	user := &types.UserContext{UserID: uuid.Must(uuid.NewV4())}
+	admin := &types.UserContext{UserID: uuid.Must(uuid.NewV4()), SystemRole: "admin"}
+
+	assert.NoError(t, (&postService{}).checkRules(ctx, community, user), "nothing is enforced without a checker")
+	assert.NoError(t, (&postService{rules: &stubRules{acknowledged: true}}).checkRules(ctx, community, user))
+	assert.NoError(t, (&postService{rules: &stubRules{}}).checkRules(ctx, community, admin))
+	assert.ErrorIs(t, (&postService{rules: &stubRules{}}).checkRules(ctx, community, user), postsErrors.ErrRulesNotAcknowledged)
+}
+
+func TestShareToCommunity(t *testing.T) {
+	service, repo := setupTestService()
+	ctx := context.Background()
+	post := createTestPost()
+	owner := &types.UserContext{UserID: post.OwnerUserId}
+	community := uuid.Must(uuid.NewV4())
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+
+	_, err := service.ShareToCommunity(ctx, post.ObjectId, community, createTestUserContext())
+	assert.ErrorIs(t, err, postsErrors.ErrPostOwnershipRequired)
+
+	service.communities = &stubCommunities{}
+	_, err = service.ShareToCommunity(ctx, post.ObjectId, community, owner)
+	assert.ErrorIs(t, err, postsErrors.ErrCommunityMembershipRequired, "only into communities the owner belongs to")
+
+	service.communities = &stubCommunities{member: true}
+	repo.On("AddCommunityPlacement", ctx, mock.MatchedBy(func(p *models.CommunityPlacement) bool {
+		return p.PostId == post.ObjectId && p.CommunityId == community && p.PlacedBy == owner.UserID
+	})).Return(true, nil).Once()
+	placement, err := service.ShareToCommunity(ctx, post.ObjectId, community, owner)
+	require.NoError(t, err)
+	assert.Equal(t, community, placement.CommunityId)
+
+	repo.On("AddCommunityPlacement", ctx, mock.Anything).Return(false, nil).Once()
+	_, err = service.ShareToCommunity(ctx, post.ObjectId, community, owner)
+	assert.ErrorIs(t, err, postsErrors.ErrAlreadyInCommunity)
+
+	post.DisableSharing = true
+	_, err = service.ShareToCommunity(ctx, post.ObjectId, community, owner)
+	assert.ErrorIs(t, err, postsErrors.ErrSharingDisabled)
+}
+
+func TestRemoveFromCommunity(t *testing.T) {
+	service, repo := setupTestService()
+	ctx := context.Background()
+	post := createTestPost()
+	community := uuid.Must(uuid.NewV4())
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+
+	// A plain member of the community cannot take someone else's post out of it
+	service.communities = &stubCommunities{member: true}
+	err := service.RemoveFromCommunity(ctx, post.ObjectId, community, createTestUserContext())
+	assert.ErrorIs(t, err, postsErrors.ErrPermissionDenied)
+
+	// Its moderators can, for their community only; the post stays everywhere else
+	service.communities = &stubCommunities{member: true, moderator: true}
+	repo.On("RemoveCommunityPlacement", ctx, post.ObjectId, community).Return(true, nil).Once()
+	require.NoError(t, service.RemoveFromCommunity(ctx, post.ObjectId, community, createTestUserContext()))
+
+	repo.On("RemoveCommunityPlacement", ctx, post.ObjectId, community).Return(false, nil).Once()
+	err = service.RemoveFromCommunity(ctx, post.ObjectId, community, &types.UserContext{UserID: post.OwnerUserId})
+	assert.ErrorIs(t, err, postsErrors.ErrNotInCommunity)
+	repo.AssertExpectations(t)
+}
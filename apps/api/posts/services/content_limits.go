@@ -0,0 +1,44 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetContentLimits lets admins choose the largest post bodies, tag lists and albums;
+// without a provider the default limits apply
+func (s *postService) SetContentLimits(provider sharedInterfaces.ContentLimitsProvider) {
+	s.contentLimits = provider
+}
+
+// checkContentLimits checks a post's body, tags and album against the content limits,
+// reporting every field over its limit. Nil arguments are left unchanged by an edit.
+func (s *postService) checkContentLimits(ctx context.Context, body *string, tags *[]string, album *models.Album) error {
+	limits := sharedInterfaces.DefaultContentLimits
+	if s.contentLimits != nil {
+		limits = s.contentLimits.ContentLimits(ctx)
+	}
+
+	var v validate.Validator
+	if body != nil {
+		v.MaxLength("body", *body, limits.PostBody)
+	}
+	if tags != nil {
+		v.MaxItems("tags", len(*tags), limits.PostTags)
+		for i, tag := range *tags {
+			v.MaxLength(fmt.Sprintf("tags[%d]", i), tag, limits.TagLength)
+		}
+	}
+	if album != nil {
+		v.MaxItems("album.photos", len(album.Photos), limits.AlbumPhotos)
+	}
+	if err := v.Err(); err != nil {
+		return fmt.Errorf("%w: %w", postsErrors.ErrValidationFailed, err)
+	}
+	return nil
+}
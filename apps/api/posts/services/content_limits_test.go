@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+type staticContentLimits sharedInterfaces.ContentLimits
+
+func (l staticContentLimits) ContentLimits(ctx context.Context) sharedInterfaces.ContentLimits {
+	return sharedInterfaces.ContentLimits(l)
+}
+
+func TestCheckContentLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("applies the defaults without a provider", func(t *testing.T) {
+		body := strings.Repeat("é", sharedInterfaces.DefaultContentLimits.PostBody)
+		assert.NoError(t, (&postService{}).checkContentLimits(ctx, &body, nil, nil))
+
+		body += "!"
+		assert.ErrorIs(t, (&postService{}).checkContentLimits(ctx, &body, nil, nil), postsErrors.ErrValidationFailed)
+	})
+
+	t.Run("reports every field over the deployment limits", func(t *testing.T) {
+		svc := &postService{contentLimits: staticContentLimits{PostBody: 5, PostTags: 2, TagLength: 3, AlbumPhotos: 1}}
+		body := "too long"
+		tags := []string{"go", "golang", "api"}
+		album := &models.Album{Photos: []string{"a.jpg", "b.jpg"}}
+
+		err := svc.checkContentLimits(ctx, &body, &tags, album)
+		require.ErrorIs(t, err, postsErrors.ErrValidationFailed)
+		fields, ok := validate.Fields(err)
+		require.True(t, ok)
+		var names []string
+		for _, field := range fields {
+			names = append(names, field.Field)
+		}
+		assert.Equal(t, []string{"body", "tags", "tags[1]", "album.photos"}, names)
+	})
+
+	t.Run("skips fields an edit leaves unchanged", func(t *testing.T) {
+		svc := &postService{contentLimits: staticContentLimits{PostBody: 1}}
+		assert.NoError(t, svc.checkContentLimits(ctx, nil, nil, nil))
+	})
+}
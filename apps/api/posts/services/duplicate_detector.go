@@ -0,0 +1,125 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/posts/common"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+// minDuplicateCheckLength skips detection for very short bodies ("lol", "+1"),
+// which legitimately repeat all the time
+const minDuplicateCheckLength = 20
+
+// SimilarityScorer scores semantic similarity between a text and candidate texts.
+// Implemented by the AI engine client.
+type SimilarityScorer interface {
+	Similarity(ctx context.Context, text string, candidates []string) ([]float64, error)
+}
+
+// duplicateDetector finds near-duplicate posts: exact content-hash matches from any
+// author (copy-paste spam) and embedding-similar posts from the same author (reposts)
+type duplicateDetector struct {
+	repo   repository.PostRepository
+	scorer SimilarityScorer // nil disables similarity matching
+	cfg    platformconfig.DuplicatesConfig
+}
+
+func newDuplicateDetector(repo repository.PostRepository, scorer SimilarityScorer, cfg platformconfig.DuplicatesConfig) *duplicateDetector {
+	return &duplicateDetector{repo: repo, scorer: scorer, cfg: cfg}
+}
+
+// blocks reports whether duplicates are rejected rather than only flagged
+func (d *duplicateDetector) blocks() bool {
+	return strings.EqualFold(d.cfg.Policy, "block")
+}
+
+// visibleDuplicate reports whether the author of a new post may be told which post it
+// duplicates: their own, or a public one that does not hide its author. Any other match
+// still counts, without its ID, so copy-paste spam is caught without revealing private,
+// followers-only or anonymous posts.
+func visibleDuplicate(post *models.Post, ownerID uuid.UUID) bool {
+	if post.OwnerUserId == ownerID {
+		return true
+	}
+	return post.Permission == "Public" && !post.Anonymous
+}
+
+// Check returns the best duplicate match for a new post, or nil if none was found.
+// Detection is best-effort: lookup and AI engine failures are logged and treated as no match.
+// Similarity scoring counts against the author's AI quota; once it is spent only exact
+// matches are found.
+func (d *duplicateDetector) Check(ctx context.Context, ownerID uuid.UUID, body, contentHash string) *models.DuplicateMatch {
+	if utf8.RuneCountInString(common.NormalizeContent(body)) < minDuplicateCheckLength {
+		return nil
+	}
+
+	var since *int64
+	if d.cfg.Window > 0 {
+		cutoff := time.Now().Add(-d.cfg.Window).Unix()
+		since = &cutoff
+	}
+
+	exact, err := d.repo.Find(ctx, repository.PostFilter{ContentHash: &contentHash, CreatedAfter: since}, 1, 0)
+	if err != nil {
+		log.Warn("Duplicate detection: content hash lookup failed: %v", err)
+	} else if len(exact) > 0 {
+		match := &models.DuplicateMatch{Similarity: 1, Reason: "exact"}
+		if visibleDuplicate(exact[0], ownerID) {
+			match.PostID = exact[0].ObjectId.String()
+		}
+		return match
+	}
+
+	if d.scorer == nil || d.cfg.MaxCandidates <= 0 {
+		return nil
+	}
+
+	recent, err := d.repo.Find(ctx, repository.PostFilter{OwnerUserID: &ownerID, CreatedAfter: since, IncludeAnonymous: true}, d.cfg.MaxCandidates, 0)
+	if err != nil {
+		log.Warn("Duplicate detection: recent posts lookup failed for user %s: %v", ownerID.String(), err)
+		return nil
+	}
+
+	candidates := make([]*models.Post, 0, len(recent))
+	texts := make([]string, 0, len(recent))
+	for _, p := range recent {
+		if strings.TrimSpace(p.Body) == "" {
+			continue
+		}
+		candidates = append(candidates, p)
+		texts = append(texts, p.Body)
+	}
+	if len(texts) == 0 {
+		return nil
+	}
+
+	scores, err := d.scorer.Similarity(aiengine.WithUser(ctx, ownerID.String()), body, texts)
+	if aiengine.OverQuota(err) {
+		log.Debug("Duplicate detection: AI quota spent for user %s, exact matches only", ownerID.String())
+		return nil
+	}
+	if err != nil {
+		log.Warn("Duplicate detection: similarity scoring failed: %v", err)
+		return nil
+	}
+
+	bestIdx := -1
+	for i, score := range scores {
+		if score >= d.cfg.SimilarityThreshold && (bestIdx < 0 || score > scores[bestIdx]) {
+			bestIdx = i
+		}
+	}
+	if bestIdx < 0 {
+		return nil
+	}
+	return &models.DuplicateMatch{PostID: candidates[bestIdx].ObjectId.String(), Similarity: scores[bestIdx], Reason: "similar"}
+}
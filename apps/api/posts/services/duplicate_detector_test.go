@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+// stubScorer returns fixed similarity scores
+type stubScorer struct {
+	scores []float64
+	err    error
+}
+
+func (s *stubScorer) Similarity(ctx context.Context, text string, candidates []string) ([]float64, error) {
+	return s.scores, s.err
+}
+
+func testDuplicatesConfig(policy string) platformconfig.DuplicatesConfig {
+	return platformconfig.DuplicatesConfig{Enabled: true, Policy: policy, SimilarityThreshold: 0.9, MaxCandidates: 10}
+}
+
+func hashFilter(f repository.PostFilter) bool  { return f.ContentHash != nil }
+func ownerFilter(f repository.PostFilter) bool { return f.OwnerUserID != nil }
+
+func TestDuplicateDetector_ExactMatch(t *testing.T) {
+	repo := new(MockPostRepository)
+	existing := createTestPost()
+	repo.On("Find", mock.Anything, mock.MatchedBy(hashFilter), 1, 0).Return([]*models.Post{existing}, nil)
+
+	d := newDuplicateDetector(repo, nil, testDuplicatesConfig("warn"))
+	match := d.Check(context.Background(), uuid.Must(uuid.NewV4()), "Buy cheap watches at my store today", "hash")
+
+	assert.NotNil(t, match)
+	assert.Equal(t, existing.ObjectId.String(), match.PostID)
+	assert.Equal(t, "exact", match.Reason)
+}
+
+func TestDuplicateDetector_ExactMatchHidesOthersPrivatePosts(t *testing.T) {
+	repo := new(MockPostRepository)
+	existing := createTestPost()
+	existing.Permission = "OnlyMe"
+	repo.On("Find", mock.Anything, mock.MatchedBy(hashFilter), 1, 0).Return([]*models.Post{existing}, nil)
+
+	d := newDuplicateDetector(repo, nil, testDuplicatesConfig("warn"))
+	match := d.Check(context.Background(), uuid.Must(uuid.NewV4()), "Buy cheap watches at my store today", "hash")
+	assert.NotNil(t, match)
+	assert.Empty(t, match.PostID, "another user's private post must not be named")
+
+	// The author is always told about their own posts
+	match = d.Check(context.Background(), existing.OwnerUserId, "Buy cheap watches at my store today", "hash")
+	assert.Equal(t, existing.ObjectId.String(), match.PostID)
+}
+
+func TestDuplicateDetector_SimilarMatchPicksBestAboveThreshold(t *testing.T) {
+	repo := new(MockPostRepository)
+	first, second := createTestPost(), createTestPost()
+	second.ObjectId = uuid.Must(uuid.NewV4())
+	repo.On("Find", mock.Anything, mock.MatchedBy(hashFilter), 1, 0).Return([]*models.Post{}, nil)
+	repo.On("Find", mock.Anything, mock.MatchedBy(ownerFilter), 10, 0).Return([]*models.Post{first, second}, nil)
+
+	d := newDuplicateDetector(repo, &stubScorer{scores: []float64{0.91, 0.97}}, testDuplicatesConfig("warn"))
+	match := d.Check(context.Background(), uuid.Must(uuid.NewV4()), "Buy cheap watches at my store today", "hash")
+
+	assert.NotNil(t, match)
+	assert.Equal(t, second.ObjectId.String(), match.PostID)
+	assert.Equal(t, "similar", match.Reason)
+	assert.InDelta(t, 0.97, match.Similarity, 1e-9)
+}
+
+func TestDuplicateDetector_ScorerFailureIsNoMatch(t *testing.T) {
+	repo := new(MockPostRepository)
+	repo.On("Find", mock.Anything, mock.MatchedBy(hashFilter), 1, 0).Return([]*models.Post{}, nil)
+	repo.On("Find", mock.Anything, mock.MatchedBy(ownerFilter), 10, 0).Return([]*models.Post{createTestPost()}, nil)
+
+	d := newDuplicateDetector(repo, &stubScorer{err: errors.New("ai engine down")}, testDuplicatesConfig("warn"))
+	assert.Nil(t, d.Check(context.Background(), uuid.Must(uuid.NewV4()), "Buy cheap watches at my store today", "hash"))
+}
+
+func TestDuplicateDetector_ShortBodySkipped(t *testing.T) {
+	repo := new(MockPostRepository)
+	d := newDuplicateDetector(repo, nil, testDuplicatesConfig("warn"))
+	assert.Nil(t, d.Check(context.Background(), uuid.Must(uuid.NewV4()), "+1", "hash"))
+	repo.AssertNotCalled(t, "Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestCreatePost_DuplicateBlockedUnlessOverridden(t *testing.T) {
+	service, mockRepo := setupTestService()
+	service.duplicates = newDuplicateDetector(mockRepo, nil, testDuplicatesConfig("block"))
+	ctx := context.Background()
+	user := createTestUserContext()
+	req := createTestCreatePostRequest()
+
+	mockRepo.On("Find", ctx, mock.MatchedBy(hashFilter), 1, 0).Return([]*models.Post{createTestPost()}, nil)
+
+	_, err := service.CreatePost(ctx, req, user)
+	assert.ErrorIs(t, err, postsErrors.ErrDuplicateContent)
+
+	// The override is for admins; anyone else setting it is still blocked
+	req.AllowDuplicate = true
+	_, err = service.CreatePost(ctx, req, user)
+	assert.ErrorIs(t, err, postsErrors.ErrDuplicateContent)
+
+	user.SystemRole = "admin"
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+	result, err := service.CreatePost(ctx, req, user)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.DuplicateWarning)
+}
+
+func TestDuplicateDetector_QuotaSpentFindsExactMatchesOnly(t *testing.T) {
+	repo := new(MockPostRepository)
+	repo.On("Find", mock.Anything, mock.MatchedBy(hashFilter), 1, 0).Return([]*models.Post{}, nil)
+	repo.On("Find", mock.Anything, mock.MatchedBy(ownerFilter), 10, 0).Return([]*models.Post{createTestPost()}, nil)
+
+	d := newDuplicateDetector(repo, &stubScorer{err: aiengine.ErrQuotaExceeded}, testDuplicatesConfig("warn"))
+	assert.Nil(t, d.Check(context.Background(), uuid.Must(uuid.NewV4()), "Buy cheap watches at my store today", "hash"))
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// publishPostCreated announces a new public post on the event bus. The payload is a
+// feed card built from the stored post, with the author hidden for anonymous posts and
+// supporter-only posts locked; clients fetch the full post when they open it.
+func (s *postService) publishPostCreated(ctx context.Context, post *models.Post) {
+	if post.Permission != "Public" || !events.HasSubscribers() {
+		return
+	}
+
+	response := models.PostResponse{
+		ObjectId:         post.ObjectId.String(),
+		PostTypeId:       post.PostTypeId,
+		Body:             post.Body,
+		OwnerUserId:      post.OwnerUserId.String(),
+		OwnerDisplayName: post.OwnerDisplayName,
+		OwnerAvatar:      post.OwnerAvatar,
+		Tags:             post.Tags,
+		Image:            post.Image,
+		ImageFullPath:    post.ImageFullPath,
+		Video:            post.Video,
+		Thumbnail:        post.Thumbnail,
+		URLKey:           post.URLKey,
+		Album:            post.Album,
+		DisableComments:  post.DisableComments,
+		DisableSharing:   post.DisableSharing,
+		CreatedDate:      post.CreatedDate,
+		LastUpdated:      post.LastUpdated,
+		Permission:       post.Permission,
+		VisibleUntil:     post.VisibleUntil,
+		License:          post.License,
+		CanonicalURL:     post.CanonicalURL,
+		SupporterOnly:    post.SupporterOnly,
+	}
+	if post.CommunityId != nil {
+		response.CommunityId = post.CommunityId.String()
+	}
+	if post.Anonymous {
+		response.HideAuthor(post.AnonymousAlias)
+	}
+	// Public events reach every subscriber, supporters or not
+	if post.SupporterOnly {
+		s.lockPost(&response)
+	}
+	responses := []models.PostResponse{response}
+	s.applyFeedPreviews(nil, responses)
+
+	events.Publish(ctx, events.Event{
+		Type:        events.TypePostCreated,
+		Data:        responses[0],
+		CreatedDate: time.Now().UTC().UnixMilli(),
+		Public:      true,
+	})
+}
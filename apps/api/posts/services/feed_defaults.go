@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetFeedDefaults lets admins choose how feeds are sorted and who sees posts created
+// without a permission; without a provider feeds are newest first and such posts keep
+// an empty permission
+func (s *postService) SetFeedDefaults(provider sharedInterfaces.FeedDefaultsProvider) {
+	s.feedDefaults = provider
+}
+
+// feedSortField returns the sort of a feed request that names none. A page cursor keeps
+// the order of the pages before it, so a changed default never reorders a feed mid-scroll.
+func (s *postService) feedSortField(ctx context.Context, filter *models.PostQueryFilter) string {
+	for _, cursor := range []string{filter.Cursor, filter.AfterCursor, filter.BeforeCursor} {
+		if cursor == "" {
+			continue
+		}
+		if decoded, err := models.DecodeCursor(cursor); err == nil && decoded.SortField != "" {
+			return models.ParseSortField(decoded.SortField)
+		}
+	}
+	if s.feedDefaults != nil && s.feedDefaults.FeedDefaults(ctx).FeedAlgorithm == sharedInterfaces.FeedRanked {
+		return "score"
+	}
+	return "createdDate"
+}
+
+// postPermission returns the permission of a new post, the deployment default when the
+// request names none
+func (s *postService) postPermission(ctx context.Context, permission string) string {
+	if permission != "" || s.feedDefaults == nil {
+		return permission
+	}
+	return s.feedDefaults.FeedDefaults(ctx).PostVisibility
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+type staticFeedDefaults sharedInterfaces.FeedDefaults
+
+func (d staticFeedDefaults) FeedDefaults(ctx context.Context) sharedInterfaces.FeedDefaults {
+	return sharedInterfaces.FeedDefaults(d)
+}
+
+func TestFeedSortField(t *testing.T) {
+	ctx := context.Background()
+	ranked := staticFeedDefaults{FeedAlgorithm: sharedInterfaces.FeedRanked}
+
+	assert.Equal(t, "createdDate", (&postService{}).feedSortField(ctx, &models.PostQueryFilter{}))
+	assert.Equal(t, "score", (&postService{feedDefaults: ranked}).feedSortField(ctx, &models.PostQueryFilter{}))
+
+	// Pages listed before the default changed keep their order
+	cursor, err := models.CreateCursorFromPost(&models.Post{ObjectId: uuid.Must(uuid.NewV4()), CreatedDate: 1700000000}, "createdDate", "desc")
+	require.NoError(t, err)
+	assert.Equal(t, "createdDate", (&postService{feedDefaults: ranked}).feedSortField(ctx, &models.PostQueryFilter{Cursor: cursor}))
+}
+
+func TestPostPermission(t *testing.T) {
+	ctx := context.Background()
+	svc := &postService{feedDefaults: staticFeedDefaults{PostVisibility: "Circles"}}
+
+	assert.Equal(t, "Circles", svc.postPermission(ctx, ""))
+	assert.Equal(t, "Public", svc.postPermission(ctx, "Public"))
+	assert.Equal(t, "", (&postService{}).postPermission(ctx, ""))
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/qolzam/telar/apps/api/internal/hooks"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// runBeforeCreateHooks lets post.beforeCreate hooks rewrite or reject a new post.
+// Hooks may change body, tags and permission; other payload fields are informational.
+func runBeforeCreateHooks(ctx context.Context, req *models.CreatePostRequest, user *types.UserContext) error {
+	payload, err := hooks.RunBefore(ctx, hooks.PostBeforeCreate, user.UserID.String(), map[string]interface{}{
+		"body":       req.Body,
+		"tags":       req.Tags,
+		"permission": req.Permission,
+		"postTypeId": req.PostTypeId,
+	})
+	if err != nil {
+		if errors.Is(err, hooks.ErrRejected) {
+			return fmt.Errorf("%w: %v", postsErrors.ErrContentRejected, err)
+		}
+		return fmt.Errorf("%w: %v", postsErrors.ErrServiceUnavailable, err)
+	}
+
+	if body, ok := payload["body"].(string); ok {
+		req.Body = body
+	}
+	if permission, ok := payload["permission"].(string); ok {
+		req.Permission = permission
+	}
+	switch tags := payload["tags"].(type) {
+	case []string:
+		req.Tags = tags
+	case []interface{}:
+		// Webhook payloads come back as decoded JSON
+		req.Tags = make([]string, 0, len(tags))
+		for _, tag := range tags {
+			if s, ok := tag.(string); ok {
+				req.Tags = append(req.Tags, s)
+			}
+		}
+	case nil:
+		req.Tags = nil
+	}
+	return nil
+}
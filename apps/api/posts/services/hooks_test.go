@@ -0,0 +1,42 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/hooks"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunBeforeCreateHooks(t *testing.T) {
+	// The registry is process-wide, so the hook only acts on bodies this test uses
+	hooks.Register(hooks.PostBeforeCreate, "test", func(ctx context.Context, e *hooks.Event) error {
+		body, _ := e.Payload["body"].(string)
+		switch {
+		case strings.HasPrefix(body, "hooktest:reject"):
+			return hooks.Reject("off-topic")
+		case strings.HasPrefix(body, "hooktest:rewrite"):
+			e.Payload["body"] = "rewritten"
+			e.Payload["tags"] = []interface{}{"moderated"}
+		}
+		return nil
+	})
+
+	user := &types.UserContext{UserID: uuid.Must(uuid.NewV4())}
+
+	req := &models.CreatePostRequest{Body: "hooktest:rewrite", Tags: []string{"go"}}
+	require.NoError(t, runBeforeCreateHooks(context.Background(), req, user))
+	assert.Equal(t, "rewritten", req.Body)
+	assert.Equal(t, []string{"moderated"}, req.Tags)
+
+	err := runBeforeCreateHooks(context.Background(), &models.CreatePostRequest{Body: "hooktest:reject"}, user)
+	assert.True(t, errors.Is(err, postsErrors.ErrContentRejected))
+	assert.Contains(t, err.Error(), "off-topic")
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Ensure postService implements sharedInterfaces.PostImageUpdater interface
+var _ sharedInterfaces.PostImageUpdater = (*postService)(nil)
+
+// SetImageVariantResolver shows the resized copies of uploaded post images instead of the
+// originals when a post is written after the image pipeline processed its image.
+// Without a resolver posts keep the originals until the pipeline updates them.
+func (s *postService) SetImageVariantResolver(resolver sharedInterfaces.ImageVariantResolver) {
+	s.imageVariants = resolver
+}
+
+// applyImageVariants swaps the post's image for its resized copies when the pipeline
+// already processed it. Images still waiting are swapped by ApplyImageVariants later.
+func (s *postService) applyImageVariants(ctx context.Context, post *models.Post) {
+	if s.imageVariants == nil {
+		return
+	}
+	imageURL := post.ImageFullPath
+	if imageURL == "" {
+		imageURL = post.Image
+	}
+	if imageURL == "" {
+		return
+	}
+
+	variants, ok := s.imageVariants.PostImageVariants(ctx, post.OwnerUserId, imageURL)
+	if !ok {
+		return
+	}
+	post.ImageFullPath = variants.FullPath
+	if post.Thumbnail == "" {
+		post.Thumbnail = variants.Thumbnail
+	}
+}
+
+// ApplyImageVariants points the owner's posts showing the upload stored at key at its
+// resized copies. It is called by the image pipeline once the upload is processed.
+func (s *postService) ApplyImageVariants(ctx context.Context, ownerID uuid.UUID, key string, variants sharedInterfaces.PostImageVariants) (int, error) {
+	if key == "" || variants.FullPath == "" {
+		return 0, nil
+	}
+
+	ids, err := s.repo.ReplaceImage(ctx, ownerID, key, variants.FullPath, variants.Thumbnail)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply image variants: %w", err)
+	}
+
+	if s.cacheService != nil && len(ids) > 0 {
+		for _, id := range ids {
+			s.invalidatePost(ctx, id)
+		}
+		s.invalidatePostLists(ctx)
+	}
+	return len(ids), nil
+}
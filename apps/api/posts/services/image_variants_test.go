@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// processedImages resolves the image URLs in it to their variants
+type processedImages map[string]sharedInterfaces.PostImageVariants
+
+func (p processedImages) PostImageVariants(ctx context.Context, ownerID uuid.UUID, imageURL string) (sharedInterfaces.PostImageVariants, bool) {
+	variants, ok := p[imageURL]
+	return variants, ok
+}
+
+func TestApplyImageVariants(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	variants := sharedInterfaces.PostImageVariants{FullPath: "https://cdn.test/f_post_1080.jpg", Thumbnail: "https://cdn.test/f_post_720.jpg"}
+	svc := &postService{}
+	svc.SetImageVariantResolver(processedImages{"https://cdn.test/f.png": variants})
+
+	post := &models.Post{OwnerUserId: owner, ImageFullPath: "https://cdn.test/f.png"}
+	svc.applyImageVariants(ctx, post)
+	assert.Equal(t, variants.FullPath, post.ImageFullPath)
+	assert.Equal(t, variants.Thumbnail, post.Thumbnail)
+
+	// A thumbnail chosen by the author is kept
+	post = &models.Post{OwnerUserId: owner, Image: "https://cdn.test/f.png", Thumbnail: "https://cdn.test/mine.jpg"}
+	svc.applyImageVariants(ctx, post)
+	assert.Equal(t, variants.FullPath, post.ImageFullPath)
+	assert.Equal(t, "https://cdn.test/mine.jpg", post.Thumbnail)
+
+	post = &models.Post{OwnerUserId: owner, ImageFullPath: "https://example.com/cat.png"}
+	svc.applyImageVariants(ctx, post)
+	assert.Equal(t, "https://example.com/cat.png", post.ImageFullPath)
+	assert.Empty(t, post.Thumbnail)
+}
+
+func TestApplyImageVariantsUpdatesPosts(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	variants := sharedInterfaces.PostImageVariants{FullPath: "https://cdn.test/f_post_1080.jpg", Thumbnail: "https://cdn.test/f_post_720.jpg"}
+	repo := new(MockPostRepository)
+	ids := []uuid.UUID{uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())}
+	repo.On("ReplaceImage", ctx, owner, "users/u/f.png", variants.FullPath, variants.Thumbnail).Return(ids, nil)
+	svc := &postService{repo: repo}
+
+	updated, err := svc.ApplyImageVariants(ctx, owner, "users/u/f.png", variants)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+	repo.AssertExpectations(t)
+
+	// Nothing to apply without variants
+	updated, err = svc.ApplyImageVariants(ctx, owner, "users/u/f.png", sharedInterfaces.PostImageVariants{})
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
@@ -6,6 +6,7 @@ import (
 	uuid "github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // PostService defines the interface for post operations
@@ -19,7 +20,12 @@ type PostService interface {
 	GetPostsByUser(ctx context.Context, userID uuid.UUID, filter *models.PostQueryFilter) (*models.PostsListResponse, error)
 	QueryPosts(ctx context.Context, filter *models.PostQueryFilter) (*models.PostsListResponse, error)
 	SearchPosts(ctx context.Context, query string, filter *models.PostQueryFilter) (*models.PostsListResponse, error)
-	SearchPostsLite(ctx context.Context, query string, limit int) ([]models.PostResponse, error)
+	FullTextSearch(ctx context.Context, query models.PostSearchQuery) (*models.PostSearchResponse, error)
+	SemanticSearch(ctx context.Context, query models.SemanticSearchQuery) (*models.SemanticSearchResponse, error)
+
+	// Hashtags: tag pages and the most used tags per window
+	GetTagPosts(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) (*models.PostsListResponse, error)
+	TrendingTags(ctx context.Context, window string) (*models.TrendingTagsResponse, error)
 
 	// Cursor-based pagination operations (new optimized methods)
 	QueryPostsWithCursor(ctx context.Context, filter *models.PostQueryFilter) (*models.PostsListResponse, error)
@@ -29,6 +35,10 @@ type PostService interface {
 	// Bulk fetch by IDs (unordered from DB; caller may re-order)
 	GetPostsByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error)
 
+	// Read-state operations
+	GetUnreadCounts(ctx context.Context, userID uuid.UUID, feedKeys []string) ([]models.FeedUnreadCount, error)
+	MarkFeedRead(ctx context.Context, userID uuid.UUID, req *models.MarkFeedReadRequest) error
+
 	// Update operations
 	UpdatePost(ctx context.Context, postID uuid.UUID, req *models.UpdatePostRequest, user *types.UserContext) error
 	UpdatePostProfile(ctx context.Context, userID uuid.UUID, displayName, avatar string) error
@@ -37,12 +47,43 @@ type PostService interface {
 	SetCommentDisabled(ctx context.Context, postID uuid.UUID, disabled bool, user *types.UserContext) error
 	SetSharingDisabled(ctx context.Context, postID uuid.UUID, disabled bool, user *types.UserContext) error
 	IncrementViewCount(ctx context.Context, postID uuid.UUID, user *types.UserContext) error
+	IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error
+
+	// Co-author operations
+	InviteCoauthor(ctx context.Context, postID uuid.UUID, req *models.InviteCoauthorRequest, user *types.UserContext) (*models.PostCoauthor, error)
+	AcceptCoauthorInvite(ctx context.Context, postID uuid.UUID, user *types.UserContext) error
+	DeclineCoauthorInvite(ctx context.Context, postID uuid.UUID, user *types.UserContext) error
+	RemoveCoauthor(ctx context.Context, postID, coauthorID uuid.UUID, user *types.UserContext) error
+	ListCoauthors(ctx context.Context, postID uuid.UUID, user *types.UserContext) ([]models.PostCoauthor, error)
+	ListCoauthorInvites(ctx context.Context, user *types.UserContext) ([]models.CoauthorInvitation, error)
+
+	// Community placements: a post made in one community can be shared into others
+	ShareToCommunity(ctx context.Context, postID, communityID uuid.UUID, user *types.UserContext) (*models.CommunityPlacement, error)
+	RemoveFromCommunity(ctx context.Context, postID, communityID uuid.UUID, user *types.UserContext) error
+	ListCommunityPlacements(ctx context.Context, postID uuid.UUID) ([]models.CommunityPlacement, error)
+
+	// Q&A operations
+	AcceptAnswer(ctx context.Context, postID, commentID uuid.UUID, user *types.UserContext) error
+	ClearAcceptedAnswer(ctx context.Context, postID uuid.UUID, user *types.UserContext) error
+
+	// ExportComments checks the user owns the post and returns an export of all its comments
+	ExportComments(ctx context.Context, postID uuid.UUID, user *types.UserContext) (CommentExport, error)
+
+	// Live thread operations
+	ScheduleLiveThread(ctx context.Context, postID uuid.UUID, req *models.ScheduleLiveThreadRequest, user *types.UserContext) (*models.LiveThread, error)
+	GetLiveThread(ctx context.Context, postID uuid.UUID, user *types.UserContext) (*models.LiveThread, error)
+	CancelLiveThread(ctx context.Context, postID uuid.UUID, user *types.UserContext) error
 
 	// Delete operations
 	DeletePost(ctx context.Context, postID uuid.UUID, user *types.UserContext) error
 	SoftDeletePost(ctx context.Context, postID uuid.UUID, user *types.UserContext) error
 	DeleteByOwner(ctx context.Context, owner uuid.UUID, objectId uuid.UUID) error
 
+	// Moderation: the real owner of a live post (anonymous or not), and hiding a post
+	// with its comments whoever owns it
+	PostOwner(ctx context.Context, postID uuid.UUID) (uuid.UUID, error)
+	HidePost(ctx context.Context, postID uuid.UUID) error
+
 	// Utility operations
 	GenerateURLKey(ctx context.Context, postID uuid.UUID, user *types.UserContext) (string, error)
 	ValidatePostOwnership(ctx context.Context, postID uuid.UUID, userID uuid.UUID) error
@@ -60,4 +101,69 @@ type PostService interface {
 
 	// Response conversion
 	ConvertPostToResponse(ctx context.Context, post *models.Post) models.PostResponse
+
+	// AttachLatestComments fills latestComments (includeComments=preview) in one batch query
+	AttachLatestComments(ctx context.Context, posts []models.PostResponse)
+
+	// AttachCoauthors fills each post's author block with its accepted co-authors in one batch query
+	AttachCoauthors(ctx context.Context, posts []models.PostResponse)
+
+	// ApplySupporterAccess replaces supporter-only posts the viewer may not read with teasers
+	ApplySupporterAccess(ctx context.Context, posts []models.PostResponse)
+
+	// SetSupporterChecker enables supporter-only posts
+	SetSupporterChecker(checker sharedInterfaces.SupporterChecker)
+
+	// SetRulesChecker requires acknowledging the community rules to post
+	SetRulesChecker(checker sharedInterfaces.RulesChecker)
+
+	// SetCommunityChecker lets community members post in their communities
+	SetCommunityChecker(checker sharedInterfaces.CommunityChecker)
+
+	// SetDelegationAuditor records posts delegates publish as another account
+	SetDelegationAuditor(auditor sharedInterfaces.DelegationAuditor)
+
+	// SetFeedDefaults applies the deployment's default feed algorithm and post visibility
+	SetFeedDefaults(provider sharedInterfaces.FeedDefaultsProvider)
+
+	// SetKeywordMuter hides posts and comment previews with muted keywords from readers' feeds
+	SetKeywordMuter(muter sharedInterfaces.KeywordMuter)
+
+	// SetMentionRecorder records @mentions in public posts and notifies the users mentioned
+	SetMentionRecorder(recorder sharedInterfaces.MentionRecorder)
+
+	// SetImageVariantResolver shows the resized copies of uploaded post images the image
+	// pipeline has already processed instead of the originals
+	SetImageVariantResolver(resolver sharedInterfaces.ImageVariantResolver)
+
+	// SetContentLimits enforces the deployment's limits on post bodies, tags and albums
+	SetContentLimits(provider sharedInterfaces.ContentLimitsProvider)
+
+	// SetContentScreener checks new posts for abuse and flags them for moderators
+	SetContentScreener(screener sharedInterfaces.ContentScreener)
+
+	// SetEventOutbox writes post created, updated, deleted and stats events to the outbox
+	// in the transaction that changes the post
+	SetEventOutbox(outbox sharedInterfaces.EventOutbox)
+
+	// SyncKnowledge re-ingests the post into the AI engine knowledge base, or removes it
+	// once it is no longer public and live
+	SyncKnowledge(ctx context.Context, postID uuid.UUID) error
+
+	// InvalidatePublicPages drops the cached anonymous pages showing the post the event names
+	InvalidatePublicPages(ctx context.Context, event sharedInterfaces.PostChangedEvent) error
+
+	// ApplyImageVariants points the owner's posts showing the upload stored at key at its
+	// resized copies and returns how many posts changed
+	ApplyImageVariants(ctx context.Context, ownerID uuid.UUID, key string, variants sharedInterfaces.PostImageVariants) (int, error)
+
+	// ArchiveExpiredPosts archives posts past their visibleUntil and returns how many it archived
+	ArchiveExpiredPosts(ctx context.Context) (int64, error)
+
+	// RefreshTrendingTags recounts the most used tags of every trending window
+	RefreshTrendingTags(ctx context.Context) error
+
+	// CloseEndedLiveThreads freezes live threads whose window has passed, summarizes
+	// closed threads and returns how many it closed
+	CloseEndedLiveThreads(ctx context.Context) (int, error)
 }
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// KnowledgeIngester keeps posts in the AI engine knowledge base; the AI engine client
+// implements it
+type KnowledgeIngester interface {
+	IngestPost(ctx context.Context, postID string, doc aiengine.PostDocument) error
+	DeletePost(ctx context.Context, postID string) error
+}
+
+// SetEventOutbox makes posts announce their creation, edits and deletion through the
+// transactional outbox, in the transaction that writes them
+func (s *postService) SetEventOutbox(outbox sharedInterfaces.EventOutbox) {
+	s.outbox = outbox
+}
+
+// writePost runs write and, with an outbox, appends an eventType event in the same
+// transaction. Delegated writes always get a transaction so their audit entry commits
+// with them.
+func (s *postService) writePost(ctx context.Context, eventType string, event sharedInterfaces.PostChangedEvent, write func(ctx context.Context) error) error {
+	if s.outbox == nil && ctx.Value(actingAsKey{}) == nil {
+		if err := write(ctx); err != nil {
+			return err
+		}
+	} else {
+		err := s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+			if err := write(txCtx); err != nil {
+				return err
+			}
+			return s.recordPostChanged(txCtx, eventType, event)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	s.dropPublicPages(ctx, event)
+	return nil
+}
+
+// postChanged describes post as it is before a write
+func postChanged(post *models.Post) sharedInterfaces.PostChangedEvent {
+	return sharedInterfaces.PostChangedEvent{PostId: post.ObjectId, URLKey: post.URLKey, Tags: slices.Clone(post.Tags)}
+}
+
+// recordPostChanged appends a post event when the outbox is enabled
+func (s *postService) recordPostChanged(txCtx context.Context, eventType string, event sharedInterfaces.PostChangedEvent) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.Append(txCtx, eventType, event.PostId.String(), event)
+}
+
+// SyncKnowledge brings the AI engine knowledge base in line with the post's current
+// state: public, live posts are re-ingested and any other post is removed. It does
+// nothing when the AI engine is not configured.
+func (s *postService) SyncKnowledge(ctx context.Context, postID uuid.UUID) error {
+	if s.knowledge == nil {
+		return nil
+	}
+
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if post != nil {
+		if doc, ok := KnowledgeDocument(post, time.Now().UnixMilli()); ok {
+			if err := s.knowledge.IngestPost(ctx, postID.String(), doc); err != nil {
+				return fmt.Errorf("failed to ingest post: %w", err)
+			}
+			return nil
+		}
+	}
+	if err := s.knowledge.DeletePost(ctx, postID.String()); err != nil {
+		return fmt.Errorf("failed to remove post from the knowledge base: %w", err)
+	}
+	return nil
+}
+
+// KnowledgeDocument is what the AI engine knowledge base stores for a post: its body
+// and the text found in its images, tagged with its community. Only public posts that
+// are live at now are stored, and anonymous posts are stored without their author.
+func KnowledgeDocument(post *models.Post, now int64) (aiengine.PostDocument, bool) {
+	if post.Deleted || post.Archived || (post.VisibleUntil > 0 && post.VisibleUntil <= now) {
+		return aiengine.PostDocument{}, false
+	}
+	if post.Permission != "" && post.Permission != "Public" {
+		return aiengine.PostDocument{}, false
+	}
+
+	text := strings.TrimSpace(post.Body)
+	if post.MediaText != "" {
+		text = strings.TrimSpace(text + "\n\n" + post.MediaText)
+	}
+	if text == "" {
+		return aiengine.PostDocument{}, false
+	}
+
+	doc := aiengine.PostDocument{Text: text, CreatedDate: post.CreatedDate}
+	// Search results built from the metadata must not name an anonymous author
+	if !post.Anonymous {
+		doc.OwnerUserID = post.OwnerUserId.String()
+	}
+	if post.CommunityId != nil {
+		doc.CommunityID = post.CommunityId.String()
+	}
+	return doc, true
+}
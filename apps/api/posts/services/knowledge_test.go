@@ -0,0 +1,177 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+type stubIngester struct {
+	ingested map[string]aiengine.PostDocument
+	deleted  []string
+	err      error
+}
+
+func (s *stubIngester) IngestPost(ctx context.Context, postID string, doc aiengine.PostDocument) error {
+	if s.ingested == nil {
+		s.ingested = map[string]aiengine.PostDocument{}
+	}
+	s.ingested[postID] = doc
+	return s.err
+}
+
+func (s *stubIngester) DeletePost(ctx context.Context, postID string) error {
+	s.deleted = append(s.deleted, postID)
+	return s.err
+}
+
+type recordingOutbox struct {
+	events []string
+	data   []interface{}
+}
+
+func (o *recordingOutbox) Append(ctx context.Context, eventType string, key string, data interface{}) error {
+	o.events = append(o.events, eventType+" "+key)
+	o.data = append(o.data, data)
+	return nil
+}
+
+func TestKnowledgeDocument(t *testing.T) {
+	owner := uuid.Must(uuid.NewV4())
+	post := func() *models.Post {
+		return &models.Post{
+			OwnerUserId: owner,
+			Body:        " Cooking on a budget ",
+			MediaText:   "Menu: rice and beans",
+			Permission:  "Public",
+			CreatedDate: 1000,
+		}
+	}
+
+	t.Run("stores body, media text and author", func(t *testing.T) {
+		doc, ok := KnowledgeDocument(post(), 5000)
+		require.True(t, ok)
+		assert.Equal(t, aiengine.PostDocument{
+			Text:        "Cooking on a budget\n\nMenu: rice and beans",
+			OwnerUserID: owner.String(),
+			CreatedDate: 1000,
+		}, doc)
+	})
+
+	t.Run("stores anonymous posts without their author", func(t *testing.T) {
+		anonymous := post()
+		anonymous.Anonymous = true
+		doc, ok := KnowledgeDocument(anonymous, 5000)
+		require.True(t, ok)
+		assert.Empty(t, doc.OwnerUserID)
+	})
+
+	t.Run("tags community posts with their community", func(t *testing.T) {
+		communityID := uuid.Must(uuid.NewV4())
+		inCommunity := post()
+		inCommunity.CommunityId = &communityID
+		doc, ok := KnowledgeDocument(inCommunity, 5000)
+		require.True(t, ok)
+		assert.Equal(t, communityID.String(), doc.CommunityID)
+	})
+
+	for name, change := range map[string]func(*models.Post){
+		"deleted":  func(p *models.Post) { p.Deleted = true },
+		"archived": func(p *models.Post) { p.Archived = true },
+		"expired":  func(p *models.Post) { p.VisibleUntil = 4000 },
+		"private":  func(p *models.Post) { p.Permission = "OnlyMe" },
+		"empty":    func(p *models.Post) { p.Body, p.MediaText = " ", "" },
+	} {
+		t.Run("skips "+name+" posts", func(t *testing.T) {
+			skipped := post()
+			change(skipped)
+			_, ok := KnowledgeDocument(skipped, 5000)
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestSyncKnowledge(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+
+	t.Run("does nothing without the AI engine", func(t *testing.T) {
+		service, _ := setupTestService()
+		assert.NoError(t, service.SyncKnowledge(context.Background(), postID))
+	})
+
+	t.Run("ingests public posts", func(t *testing.T) {
+		service, repo := setupTestService()
+		ingester := &stubIngester{}
+		service.knowledge = ingester
+		repo.On("FindByID", mock.Anything, postID).Return(&models.Post{ObjectId: postID, Body: "Hello", Permission: "Public"}, nil)
+
+		require.NoError(t, service.SyncKnowledge(context.Background(), postID))
+		assert.Equal(t, "Hello", ingester.ingested[postID.String()].Text)
+		assert.Empty(t, ingester.deleted)
+	})
+
+	t.Run("removes private posts", func(t *testing.T) {
+		service, repo := setupTestService()
+		ingester := &stubIngester{}
+		service.knowledge = ingester
+		repo.On("FindByID", mock.Anything, postID).Return(&models.Post{ObjectId: postID, Body: "Hello", Permission: "OnlyMe"}, nil)
+
+		require.NoError(t, service.SyncKnowledge(context.Background(), postID))
+		assert.Empty(t, ingester.ingested)
+		assert.Equal(t, []string{postID.String()}, ingester.deleted)
+	})
+
+	t.Run("removes deleted posts", func(t *testing.T) {
+		service, repo := setupTestService()
+		ingester := &stubIngester{}
+		service.knowledge = ingester
+		repo.On("FindByID", mock.Anything, postID).Return(nil, fmt.Errorf("post not found: %w", sql.ErrNoRows))
+
+		require.NoError(t, service.SyncKnowledge(context.Background(), postID))
+		assert.Equal(t, []string{postID.String()}, ingester.deleted)
+	})
+
+	t.Run("returns AI engine failures for redelivery", func(t *testing.T) {
+		service, repo := setupTestService()
+		service.knowledge = &stubIngester{err: errors.New("unavailable")}
+		repo.On("FindByID", mock.Anything, postID).Return(&models.Post{ObjectId: postID, Body: "Hello", Permission: "Public"}, nil)
+
+		assert.Error(t, service.SyncKnowledge(context.Background(), postID))
+	})
+}
+
+func TestWritePost_AnnouncesChanges(t *testing.T) {
+	service, repo := setupTestService()
+	outbox := &recordingOutbox{}
+	service.SetEventOutbox(outbox)
+	repo.On("WithTransaction", mock.Anything, mock.Anything).Return(nil)
+	post := &models.Post{ObjectId: uuid.Must(uuid.NewV4()), URLKey: "budget-cooking", Tags: []string{"food"}}
+
+	written := false
+	err := service.writePost(context.Background(), sharedInterfaces.OutboxPostUpdated, postChanged(post), func(txCtx context.Context) error {
+		written = true
+		post.Tags = []string{"travel"}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, written)
+	assert.Equal(t, []string{"post.updated " + post.ObjectId.String()}, outbox.events)
+	// The event keeps the tags the post had, whose cached feeds still list it
+	assert.Equal(t, sharedInterfaces.PostChangedEvent{PostId: post.ObjectId, URLKey: "budget-cooking", Tags: []string{"food"}}, outbox.data[0])
+}
+
+func TestPublicPageSubjects(t *testing.T) {
+	assert.Equal(t, []string{"post:budget-cooking", "tag:food", ""}, publicPageSubjects("budget-cooking", []string{"#Food", "  "}))
+	assert.Equal(t, []string{""}, publicPageSubjects("", nil))
+}
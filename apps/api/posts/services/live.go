@@ -0,0 +1,284 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/internal/utils"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+const (
+	// liveCloseBatchSize bounds the threads closed by one statement of the job
+	liveCloseBatchSize = 100
+	// liveSummaryBatchSize bounds the summaries requested per job run
+	liveSummaryBatchSize = 20
+	// maxLiveSummaryAttempts stops retrying a thread the AI engine keeps failing on
+	maxLiveSummaryAttempts = 5
+	// maxSlowModeSeconds caps the slow mode a post owner may choose
+	maxSlowModeSeconds = 3600
+	// defaultSummaryComments is used when the live threads config does not set a limit
+	defaultSummaryComments = 200
+)
+
+// ThreadSummarizer digests a post and its comments into a summary and key points.
+// The AI engine client implements it.
+type ThreadSummarizer interface {
+	SummarizeThread(ctx context.Context, post aiengine.ThreadMessage, comments []aiengine.ThreadMessage) (string, []string, error)
+}
+
+// SetThreadSummarizer sets what summarizes live threads once they end; without one
+// closed threads keep an empty summary
+func (s *postService) SetThreadSummarizer(summarizer ThreadSummarizer) {
+	s.summarizer = summarizer
+}
+
+// liveThreadsEnabled reports whether post owners may schedule live threads
+func (s *postService) liveThreadsEnabled() bool {
+	return s.config != nil && s.config.LiveThreads.Enabled
+}
+
+// ScheduleLiveThread makes the user's post a live thread for the requested window, or
+// moves the window of a thread that has not ended
+func (s *postService) ScheduleLiveThread(ctx context.Context, postID uuid.UUID, req *models.ScheduleLiveThreadRequest, user *types.UserContext) (*models.LiveThread, error) {
+	if !s.liveThreadsEnabled() {
+		return nil, postsErrors.ErrLiveThreadsDisabled
+	}
+	if req == nil {
+		return nil, postsErrors.ErrInvalidRequestBody
+	}
+
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.OwnerUserId != user.UserID {
+		return nil, postsErrors.ErrPostOwnershipRequired
+	}
+
+	now := utils.UTCNowUnix()
+	if req.StartsAt <= 0 {
+		return nil, postsErrors.WrapValidationError(postsErrors.ErrMissingRequiredField, "startsAt")
+	}
+	if req.EndsAt <= req.StartsAt || req.EndsAt <= now {
+		return nil, postsErrors.WrapValidationError(postsErrors.ErrInvalidFieldValue, "endsAt must be after startsAt and in the future")
+	}
+	if maxDuration := s.config.LiveThreads.MaxDuration; maxDuration > 0 && req.EndsAt-req.StartsAt > maxDuration.Milliseconds() {
+		return nil, postsErrors.WrapValidationError(postsErrors.ErrInvalidFieldValue, "live threads may last at most "+maxDuration.String())
+	}
+	slowMode := s.config.LiveThreads.SlowModeSeconds
+	if req.SlowModeSeconds != nil {
+		slowMode = *req.SlowModeSeconds
+	}
+	if slowMode < 0 || slowMode > maxSlowModeSeconds {
+		return nil, postsErrors.WrapValidationError(postsErrors.ErrInvalidFieldValue, "slowModeSeconds must be between 0 and 3600")
+	}
+
+	existing, err := s.repo.GetLiveThread(ctx, postID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	if existing != nil && existing.ClosedDate > 0 {
+		return nil, postsErrors.ErrLiveThreadEnded
+	}
+
+	thread := &models.LiveThread{
+		PostId:          postID,
+		StartsAt:        req.StartsAt,
+		EndsAt:          req.EndsAt,
+		SlowModeSeconds: slowMode,
+		CreatedDate:     now,
+	}
+	if existing != nil {
+		thread.CreatedDate = existing.CreatedDate
+	}
+	if err := s.repo.SaveLiveThread(ctx, thread); err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	thread.State = thread.StateAt(now)
+	return thread, nil
+}
+
+// GetLiveThread returns the post's live thread with its current state
+func (s *postService) GetLiveThread(ctx context.Context, postID uuid.UUID, user *types.UserContext) (*models.LiveThread, error) {
+	if _, err := s.findLivePost(ctx, postID); err != nil {
+		return nil, err
+	}
+	thread, err := s.repo.GetLiveThread(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, postsErrors.ErrLiveThreadNotFound
+		}
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+	thread.State = thread.StateAt(utils.UTCNowUnix())
+	return thread, nil
+}
+
+// CancelLiveThread turns the user's post back into a normal post. Ended threads stay,
+// since their post has already been frozen.
+func (s *postService) CancelLiveThread(ctx context.Context, postID uuid.UUID, user *types.UserContext) error {
+	post, err := s.findLivePost(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.OwnerUserId != user.UserID {
+		return postsErrors.ErrPostOwnershipRequired
+	}
+
+	thread, err := s.repo.GetLiveThread(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return postsErrors.ErrLiveThreadNotFound
+		}
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if thread.StateAt(utils.UTCNowUnix()) == models.LiveStateEnded {
+		return postsErrors.ErrLiveThreadEnded
+	}
+	deleted, err := s.repo.DeleteLiveThread(ctx, postID)
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if !deleted {
+		return postsErrors.ErrLiveThreadEnded
+	}
+	return nil
+}
+
+// CloseEndedLiveThreads freezes every live thread whose window has passed, announces
+// the end to clients following the post, then summarizes closed threads that still
+// lack a summary. Summaries that fail are retried on later runs.
+func (s *postService) CloseEndedLiveThreads(ctx context.Context) (int, error) {
+	now := utils.UTCNowUnix()
+	total := 0
+	for {
+		closed, err := s.repo.CloseEndedLiveThreads(ctx, now, liveCloseBatchSize)
+		if err != nil {
+			return total, err
+		}
+		total += len(closed)
+		for _, thread := range closed {
+			s.publishLiveThreadEnded(ctx, thread)
+		}
+		if len(closed) < liveCloseBatchSize {
+			break
+		}
+	}
+	if total > 0 && s.cacheService != nil {
+		s.invalidatePostLists(ctx)
+	}
+
+	if s.summarizer == nil {
+		return total, nil
+	}
+	pending, err := s.repo.ListUnsummarizedLiveThreads(ctx, maxLiveSummaryAttempts, liveSummaryBatchSize)
+	if err != nil {
+		return total, err
+	}
+	for _, thread := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		summary, keyPoints, err := s.summarizeLiveThread(ctx, thread)
+		if err != nil {
+			log.Warn("Summarizing live thread %s failed (attempt %d): %v", thread.PostId, thread.SummaryAttempts+1, err)
+		}
+		if err := s.repo.SaveLiveThreadSummary(ctx, thread.PostId, summary, keyPoints); err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+// summarizeLiveThread sends the post and its comments, oldest first, to the summarizer.
+// The hidden author of an anonymous post is named by the thread pseudonym.
+func (s *postService) summarizeLiveThread(ctx context.Context, thread *models.LiveThread) (string, []string, error) {
+	post, err := s.repo.FindByID(ctx, thread.PostId)
+	if err != nil {
+		return "", nil, err
+	}
+	if s.commentRepo == nil {
+		return "", nil, errors.New("comment repository is not configured")
+	}
+
+	limit := s.config.LiveThreads.SummaryComments
+	if limit <= 0 {
+		limit = defaultSummaryComments
+	}
+	comments, err := s.commentRepo.Find(ctx, commentRepository.CommentFilter{PostID: &post.ObjectId}, limit, 0)
+	if err != nil {
+		return "", nil, err
+	}
+
+	author := func(userID uuid.UUID, displayName string) string {
+		if post.Anonymous && userID == post.OwnerUserId {
+			return post.AnonymousAlias
+		}
+		return displayName
+	}
+	messages := make([]aiengine.ThreadMessage, 0, len(comments))
+	for i := len(comments) - 1; i >= 0; i-- {
+		messages = append(messages, aiengine.ThreadMessage{
+			Author: author(comments[i].OwnerUserId, comments[i].OwnerDisplayName),
+			Text:   comments[i].Text,
+		})
+	}
+	return s.summarizer.SummarizeThread(ctx,
+		aiengine.ThreadMessage{Author: author(post.OwnerUserId, post.OwnerDisplayName), Text: post.Body},
+		messages)
+}
+
+// publishLiveThreadEnded tells clients following a public post that its live thread ended
+func (s *postService) publishLiveThreadEnded(ctx context.Context, thread *models.LiveThread) {
+	if !events.HasSubscribers() {
+		return
+	}
+	post, err := s.repo.FindByID(ctx, thread.PostId)
+	if err != nil || post.Permission != "Public" {
+		return
+	}
+	thread.State = models.LiveStateEnded
+	events.Publish(ctx, events.Event{
+		Type:        events.TypeLiveThreadEnded,
+		Data:        thread,
+		CreatedDate: time.Now().UTC().UnixMilli(),
+		Topic:       events.PostTopic(thread.PostId),
+	})
+}
+
+// StartLiveThreadJob runs CloseEndedLiveThreads every interval until ctx is done.
+// A non-positive interval disables the job.
+func StartLiveThreadJob(ctx context.Context, svc PostService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				closed, err := svc.CloseEndedLiveThreads(ctx)
+				if err != nil {
+					log.Error("Live thread job failed: %v", err)
+					continue
+				}
+				if closed > 0 {
+					log.Info("Closed %d live threads", closed)
+				}
+			}
+		}
+	}()
+}
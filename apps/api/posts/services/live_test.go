@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	commentModels "github.com/qolzam/telar/apps/api/comments/models"
+	commentMocks "github.com/qolzam/telar/apps/api/comments/services/mocks"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/internal/utils"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+type stubSummarizer struct {
+	post     aiengine.ThreadMessage
+	comments []aiengine.ThreadMessage
+	err      error
+}
+
+func (s *stubSummarizer) SummarizeThread(ctx context.Context, post aiengine.ThreadMessage, comments []aiengine.ThreadMessage) (string, []string, error) {
+	s.post, s.comments = post, comments
+	if s.err != nil {
+		return "", nil, s.err
+	}
+	return "A short digest", []string{"First point"}, nil
+}
+
+func setupLiveThreadService() (*postService, *MockPostRepository) {
+	service, repo := setupTestService()
+	service.config.LiveThreads = platformconfig.LiveThreadsConfig{
+		Enabled:         true,
+		SlowModeSeconds: 30,
+		MaxDuration:     2 * time.Hour,
+	}
+	return service, repo
+}
+
+func TestScheduleLiveThread(t *testing.T) {
+	service, repo := setupLiveThreadService()
+	ctx := context.Background()
+	post := createTestPost()
+	owner := &types.UserContext{UserID: post.OwnerUserId}
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+	repo.On("GetLiveThread", ctx, post.ObjectId).Return(nil, sql.ErrNoRows)
+
+	start := utils.UTCNowUnix() + time.Minute.Milliseconds()
+	end := start + time.Hour.Milliseconds()
+
+	_, err := service.ScheduleLiveThread(ctx, post.ObjectId, &models.ScheduleLiveThreadRequest{StartsAt: start, EndsAt: end}, createTestUserContext())
+	assert.ErrorIs(t, err, postsErrors.ErrPostOwnershipRequired)
+
+	negative := -1
+	invalid := []*models.ScheduleLiveThreadRequest{
+		{StartsAt: start, EndsAt: start},
+		{StartsAt: start, EndsAt: start + 3*time.Hour.Milliseconds()},
+		{StartsAt: start, EndsAt: end, SlowModeSeconds: &negative},
+	}
+	for _, req := range invalid {
+		_, err = service.ScheduleLiveThread(ctx, post.ObjectId, req, owner)
+		assert.ErrorIs(t, err, postsErrors.ErrInvalidFieldValue)
+	}
+
+	repo.On("SaveLiveThread", ctx, mock.AnythingOfType("*models.LiveThread")).Return(nil).Once()
+	thread, err := service.ScheduleLiveThread(ctx, post.ObjectId, &models.ScheduleLiveThreadRequest{StartsAt: start, EndsAt: end}, owner)
+	require.NoError(t, err)
+	assert.Equal(t, 30, thread.SlowModeSeconds, "slow mode defaults to the config")
+	assert.Equal(t, models.LiveStateScheduled, thread.State)
+
+	service.config.LiveThreads.Enabled = false
+	_, err = service.ScheduleLiveThread(ctx, post.ObjectId, &models.ScheduleLiveThreadRequest{StartsAt: start, EndsAt: end}, owner)
+	assert.ErrorIs(t, err, postsErrors.ErrLiveThreadsDisabled)
+}
+
+func TestScheduleLiveThread_EndedThreadIsFrozen(t *testing.T) {
+	service, repo := setupLiveThreadService()
+	ctx := context.Background()
+	post := createTestPost()
+	now := utils.UTCNowUnix()
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+	repo.On("GetLiveThread", ctx, post.ObjectId).Return(&models.LiveThread{
+		PostId: post.ObjectId, StartsAt: now - 2000, EndsAt: now - 1000, ClosedDate: now,
+	}, nil)
+
+	req := &models.ScheduleLiveThreadRequest{StartsAt: now + 1000, EndsAt: now + 60000}
+	_, err := service.ScheduleLiveThread(ctx, post.ObjectId, req, &types.UserContext{UserID: post.OwnerUserId})
+	assert.ErrorIs(t, err, postsErrors.ErrLiveThreadEnded)
+
+	err = service.CancelLiveThread(ctx, post.ObjectId, &types.UserContext{UserID: post.OwnerUserId})
+	assert.ErrorIs(t, err, postsErrors.ErrLiveThreadEnded)
+	repo.AssertNotCalled(t, "SaveLiveThread", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "DeleteLiveThread", mock.Anything, mock.Anything)
+}
+
+func TestLiveThreadStateAt(t *testing.T) {
+	thread := &models.LiveThread{StartsAt: 100, EndsAt: 200}
+	assert.Equal(t, models.LiveStateScheduled, thread.StateAt(99))
+	assert.Equal(t, models.LiveStateLive, thread.StateAt(100))
+	assert.Equal(t, models.LiveStateEnded, thread.StateAt(200), "the window end is exclusive")
+
+	thread.ClosedDate = 150
+	assert.Equal(t, models.LiveStateEnded, thread.StateAt(150))
+}
+
+func TestCloseEndedLiveThreads_Summarizes(t *testing.T) {
+	service, repo := setupLiveThreadService()
+	commentRepo := &commentMocks.MockCommentRepository{}
+	summarizer := &stubSummarizer{}
+	service.commentRepo = commentRepo
+	service.summarizer = summarizer
+	ctx := context.Background()
+
+	post := createTestPost()
+	post.Anonymous = true
+	post.AnonymousAlias = "Quiet Heron"
+	guest := uuid.Must(uuid.NewV4())
+	thread := &models.LiveThread{PostId: post.ObjectId}
+
+	repo.On("CloseEndedLiveThreads", ctx, mock.AnythingOfType("int64"), liveCloseBatchSize).Return([]*models.LiveThread{thread}, nil).Once()
+	repo.On("ListUnsummarizedLiveThreads", ctx, maxLiveSummaryAttempts, liveSummaryBatchSize).Return([]*models.LiveThread{thread}, nil).Once()
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+	// Comments come back newest first
+	commentRepo.On("Find", ctx, mock.Anything, defaultSummaryComments, 0).Return([]*commentModels.Comment{
+		{OwnerUserId: post.OwnerUserId, OwnerDisplayName: "Real Name", Text: "Thanks everyone"},
+		{OwnerUserId: guest, OwnerDisplayName: "Guest", Text: "What is next?"},
+	}, nil)
+	repo.On("SaveLiveThreadSummary", ctx, post.ObjectId, "A short digest", []string{"First point"}).Return(nil).Once()
+
+	closed, err := service.CloseEndedLiveThreads(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, closed)
+	assert.Equal(t, aiengine.ThreadMessage{Author: "Quiet Heron", Text: post.Body}, summarizer.post)
+	assert.Equal(t, []aiengine.ThreadMessage{
+		{Author: "Guest", Text: "What is next?"},
+		{Author: "Quiet Heron", Text: "Thanks everyone"},
+	}, summarizer.comments, "comments are sent oldest first and the hidden author keeps the pseudonym")
+	repo.AssertExpectations(t)
+}
+
+func TestCloseEndedLiveThreads_CountsFailedSummaries(t *testing.T) {
+	service, repo := setupLiveThreadService()
+	commentRepo := &commentMocks.MockCommentRepository{}
+	service.commentRepo = commentRepo
+	service.summarizer = &stubSummarizer{err: errors.New("engine unavailable")}
+	ctx := context.Background()
+
+	post := createTestPost()
+	thread := &models.LiveThread{PostId: post.ObjectId, SummaryAttempts: 1}
+	repo.On("CloseEndedLiveThreads", ctx, mock.AnythingOfType("int64"), liveCloseBatchSize).Return([]*models.LiveThread{}, nil).Once()
+	repo.On("ListUnsummarizedLiveThreads", ctx, maxLiveSummaryAttempts, liveSummaryBatchSize).Return([]*models.LiveThread{thread}, nil).Once()
+	repo.On("FindByID", ctx, post.ObjectId).Return(post, nil)
+	commentRepo.On("Find", ctx, mock.Anything, defaultSummaryComments, 0).Return([]*commentModels.Comment{}, nil)
+	repo.On("SaveLiveThreadSummary", ctx, post.ObjectId, "", []string(nil)).Return(nil).Once()
+
+	closed, err := service.CloseEndedLiveThreads(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, closed)
+	repo.AssertExpectations(t)
+}
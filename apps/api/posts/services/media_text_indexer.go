@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/platform/ocr"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+// mediaTextJob is a request to OCR the images attached to a post
+type mediaTextJob struct {
+	postID    uuid.UUID
+	imageURLs []string
+}
+
+// mediaTextIndexer extracts text from post images in the background and stores it
+// in the post's media_text column so it becomes part of the full-text search index.
+// Workers start lazily on the first enqueue; jobs are dropped when the queue is full.
+// Each worker has its own queue and a post's jobs always go to the same one, so a slow
+// job for an old image can never overwrite the text of a newer one.
+type mediaTextIndexer struct {
+	repo      repository.PostRepository
+	extractor ocr.Extractor
+	queues    []chan mediaTextJob
+	timeout   time.Duration
+	maxLength int
+	onIndexed func(ctx context.Context, postID uuid.UUID)
+	startOnce sync.Once
+}
+
+// newMediaTextIndexer creates an indexer. onIndexed is called after media text is
+// stored (e.g. to invalidate search caches) and may be nil.
+func newMediaTextIndexer(repo repository.PostRepository, extractor ocr.Extractor, cfg platformconfig.OCRConfig, onIndexed func(ctx context.Context, postID uuid.UUID)) *mediaTextIndexer {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = 100
+	}
+	// The queue size is shared out between the workers
+	queues := make([]chan mediaTextJob, workers)
+	for w := range queues {
+		queues[w] = make(chan mediaTextJob, max(queueSize/workers, 1))
+	}
+	return &mediaTextIndexer{
+		repo:      repo,
+		extractor: extractor,
+		queues:    queues,
+		timeout:   cfg.Timeout,
+		maxLength: cfg.MaxTextLength,
+		onIndexed: onIndexed,
+	}
+}
+
+// postImageURLs returns the distinct image URLs attached to a post
+func postImageURLs(post *models.Post) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	add := func(u string) {
+		u = strings.TrimSpace(u)
+		if u == "" || seen[u] {
+			return
+		}
+		seen[u] = true
+		urls = append(urls, u)
+	}
+
+	add(post.Image)
+	if post.Album != nil {
+		for _, photo := range post.Album.Photos {
+			add(photo)
+		}
+	}
+	return urls
+}
+
+// Enqueue schedules OCR for the given post. It never blocks the caller.
+// Returns false if the job was dropped because the queue is full.
+func (i *mediaTextIndexer) Enqueue(postID uuid.UUID, imageURLs []string) bool {
+	i.startOnce.Do(func() {
+		for _, queue := range i.queues {
+			go i.run(queue)
+		}
+	})
+
+	select {
+	case i.queue(postID) <- mediaTextJob{postID: postID, imageURLs: imageURLs}:
+		return true
+	default:
+		log.Warn("OCR queue full, skipping media text extraction for post %s", postID.String())
+		return false
+	}
+}
+
+// queue returns the queue of the worker that handles every job of the post
+func (i *mediaTextIndexer) queue(postID uuid.UUID) chan mediaTextJob {
+	h := fnv.New32a()
+	h.Write(postID.Bytes())
+	return i.queues[h.Sum32()%uint32(len(i.queues))]
+}
+
+func (i *mediaTextIndexer) run(queue chan mediaTextJob) {
+	for job := range queue {
+		i.process(context.Background(), job)
+	}
+}
+
+// process extracts text from every image of the job and stores the combined result.
+// Images that fail extraction are skipped so one bad URL does not discard the rest.
+func (i *mediaTextIndexer) process(ctx context.Context, job mediaTextJob) {
+	var parts []string
+	for _, imageURL := range job.imageURLs {
+		text, err := i.extract(ctx, imageURL)
+		if err != nil {
+			log.Warn("OCR failed for post %s image %s: %v", job.postID.String(), imageURL, err)
+			continue
+		}
+		if text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	mediaText := truncateRunes(strings.Join(parts, "\n"), i.maxLength)
+	if err := i.repo.UpdateMediaText(ctx, job.postID, mediaText); err != nil {
+		log.Error("Failed to store media text for post %s: %v", job.postID.String(), err)
+		return
+	}
+
+	if i.onIndexed != nil {
+		i.onIndexed(ctx, job.postID)
+	}
+}
+
+func (i *mediaTextIndexer) extract(ctx context.Context, imageURL string) (string, error) {
+	if i.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, i.timeout)
+		defer cancel()
+	}
+	return i.extractor.ExtractText(ctx, imageURL)
+}
+
+// truncateRunes limits s to max runes; max <= 0 means no limit
+func truncateRunes(s string, max int) string {
+	if max <= 0 {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max])
+}
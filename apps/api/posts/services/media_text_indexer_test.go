@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// stubExtractor returns canned OCR results keyed by image URL
+type stubExtractor struct {
+	texts map[string]string
+	errs  map[string]error
+}
+
+func (e *stubExtractor) ExtractText(ctx context.Context, imageURL string) (string, error) {
+	if err := e.errs[imageURL]; err != nil {
+		return "", err
+	}
+	return e.texts[imageURL], nil
+}
+
+func TestMediaTextIndexer_Process(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+	extractor := &stubExtractor{
+		texts: map[string]string{
+			"https://cdn/a.png": "hello world",
+			"https://cdn/c.png": "second image",
+		},
+		errs: map[string]error{"https://cdn/b.png": errors.New("unreadable")},
+	}
+
+	repo := new(MockPostRepository)
+	repo.On("UpdateMediaText", mock.Anything, postID, "hello world\nsecond image").Return(nil)
+
+	indexed := false
+	indexer := newMediaTextIndexer(repo, extractor, platformconfig.OCRConfig{}, func(ctx context.Context, postID uuid.UUID) { indexed = true })
+	indexer.process(context.Background(), mediaTextJob{
+		postID:    postID,
+		imageURLs: []string{"https://cdn/a.png", "https://cdn/b.png", "https://cdn/c.png"},
+	})
+
+	repo.AssertExpectations(t)
+	assert.True(t, indexed, "onIndexed should run after media text is stored")
+}
+
+func TestMediaTextIndexer_ProcessTruncates(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+	extractor := &stubExtractor{texts: map[string]string{"https://cdn/a.png": "héllo wörld"}}
+
+	repo := new(MockPostRepository)
+	repo.On("UpdateMediaText", mock.Anything, postID, "héllo").Return(nil)
+
+	indexer := newMediaTextIndexer(repo, extractor, platformconfig.OCRConfig{MaxTextLength: 5}, nil)
+	indexer.process(context.Background(), mediaTextJob{postID: postID, imageURLs: []string{"https://cdn/a.png"}})
+
+	repo.AssertExpectations(t)
+}
+
+// slowExtractor holds back extraction of old.png until release is closed
+type slowExtractor struct {
+	release chan struct{}
+}
+
+func (e *slowExtractor) ExtractText(ctx context.Context, imageURL string) (string, error) {
+	if imageURL == "https://cdn/old.png" {
+		<-e.release
+	}
+	return imageURL, nil
+}
+
+func TestMediaTextIndexer_KeepsPostJobsInOrder(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+	extractor := &slowExtractor{release: make(chan struct{})}
+
+	var mu sync.Mutex
+	var stored []string
+	done := make(chan struct{}, 2)
+	repo := new(MockPostRepository)
+	repo.On("UpdateMediaText", mock.Anything, postID, mock.Anything).Run(func(args mock.Arguments) {
+		mu.Lock()
+		stored = append(stored, args.String(2))
+		mu.Unlock()
+		done <- struct{}{}
+	}).Return(nil)
+
+	indexer := newMediaTextIndexer(repo, extractor, platformconfig.OCRConfig{Workers: 4}, nil)
+	assert.True(t, indexer.Enqueue(postID, []string{"https://cdn/old.png"}))
+	assert.True(t, indexer.Enqueue(postID, []string{"https://cdn/new.png"}))
+	close(extractor.release)
+	<-done
+	<-done
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"https://cdn/old.png", "https://cdn/new.png"}, stored, "the newer image's text must be stored last")
+}
+
+func TestPostImageURLs(t *testing.T) {
+	post := &models.Post{
+		Image: "https://cdn/cover.png",
+		Album: &models.Album{Photos: []string{"https://cdn/cover.png", " ", "https://cdn/2.png"}},
+	}
+	assert.Equal(t, []string{"https://cdn/cover.png", "https://cdn/2.png"}, postImageURLs(post))
+	assert.Empty(t, postImageURLs(&models.Post{}))
+}
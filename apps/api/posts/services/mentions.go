@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetMentionRecorder records the @mentions in public post bodies and notifies the users
+// mentioned. Without a recorder mentions stay plain text.
+func (s *postService) SetMentionRecorder(recorder sharedInterfaces.MentionRecorder) {
+	s.mentionRecorder = recorder
+}
+
+// recordMentions hands a public post's body to the mention recorder. Users already
+// mentioned by the post are not notified again when it is edited.
+func (s *postService) recordMentions(ctx context.Context, post *models.Post) {
+	if s.mentionRecorder == nil || post.Permission != "Public" {
+		return
+	}
+
+	source := sharedInterfaces.MentionSource{
+		PostID:           post.ObjectId,
+		AuthorID:         post.OwnerUserId,
+		ActorDisplayName: post.OwnerDisplayName,
+		ActorAvatar:      post.OwnerAvatar,
+		Text:             post.Body,
+		HidePreview:      post.SupporterOnly,
+	}
+	if post.Anonymous {
+		source.ActorDisplayName = post.AnonymousAlias
+		source.ActorAvatar = ""
+	} else {
+		ownerID := post.OwnerUserId
+		source.ActorUserID = &ownerID
+	}
+	s.mentionRecorder.RecordMentions(ctx, source)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+type recordedMentions []sharedInterfaces.MentionSource
+
+func (r *recordedMentions) RecordMentions(ctx context.Context, source sharedInterfaces.MentionSource) {
+	*r = append(*r, source)
+}
+
+func TestRecordMentions(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	recorded := &recordedMentions{}
+	svc := &postService{}
+	svc.SetMentionRecorder(recorded)
+
+	svc.recordMentions(ctx, &models.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: owner, Permission: "Followers", Body: "hi @alice"})
+	assert.Empty(t, *recorded, "only public posts notify")
+
+	anonymous := &models.Post{
+		ObjectId:         uuid.Must(uuid.NewV4()),
+		OwnerUserId:      owner,
+		OwnerDisplayName: "Real Name",
+		OwnerAvatar:      "https://example.com/me.png",
+		Permission:       "Public",
+		Anonymous:        true,
+		AnonymousAlias:   "Anonymous Quiet Otter 42",
+		SupporterOnly:    true,
+		Body:             "hi @alice",
+	}
+	svc.recordMentions(ctx, anonymous)
+	require.Len(t, *recorded, 1)
+	source := (*recorded)[0]
+	assert.Equal(t, owner, source.AuthorID)
+	assert.Nil(t, source.ActorUserID, "anonymous authors stay hidden")
+	assert.Equal(t, "Anonymous Quiet Otter 42", source.ActorDisplayName)
+	assert.Empty(t, source.ActorAvatar)
+	assert.True(t, source.HidePreview)
+}
@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+)
+
+// PostOwner returns the owner of a post that is not deleted, even an anonymous one's
+func (s *postService) PostOwner(ctx context.Context, postID uuid.UUID) (uuid.UUID, error) {
+	post, err := s.GetPost(ctx, postID)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if post.Deleted {
+		return uuid.Nil, postsErrors.ErrPostNotFound
+	}
+	return post.OwnerUserId, nil
+}
+
+// HidePost soft-deletes a post and its comments for a moderator, whoever owns it.
+// Hiding a post that is already deleted does nothing.
+func (s *postService) HidePost(ctx context.Context, postID uuid.UUID) error {
+	post, err := s.GetPost(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.Deleted {
+		return nil
+	}
+	return s.softDeleteWithComments(ctx, post)
+}
@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetKeywordMuter lets readers mute keywords: posts and comment previews containing one
+// are left out of their feeds and search results. Without a muter nothing is hidden.
+func (s *postService) SetKeywordMuter(muter sharedInterfaces.KeywordMuter) {
+	s.keywordMuter = muter
+}
+
+// mutedFor returns a test for content the request's viewer muted, or nil when there is no
+// viewer or they muted nothing. The viewer's own content is never muted.
+func (s *postService) mutedFor(ctx context.Context) func(ownerUserID, text string) bool {
+	if s.keywordMuter == nil {
+		return nil
+	}
+	userCtx, ok := ctx.Value(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return nil
+	}
+	matcher := s.keywordMuter.MutedMatcher(ctx, userCtx.UserID)
+	if matcher == nil {
+		return nil
+	}
+	viewerID := userCtx.UserID.String()
+	return func(ownerUserID, text string) bool {
+		return ownerUserID != viewerID && matcher.Match(text)
+	}
+}
+
+// dropMutedPosts removes the posts the viewer muted. A page may come back shorter than
+// its limit; its cursor still points past the last post read, so paging is unaffected.
+func (s *postService) dropMutedPosts(ctx context.Context, posts []models.PostResponse) []models.PostResponse {
+	muted := s.mutedFor(ctx)
+	if muted == nil || len(posts) == 0 {
+		return posts
+	}
+	kept := make([]models.PostResponse, 0, len(posts))
+	for _, post := range posts {
+		if !muted(post.OwnerUserId, postKeywordText(post.Body, post.Tags)) {
+			kept = append(kept, post)
+		}
+	}
+	return kept
+}
+
+// postKeywordText is the text of a post muted keywords are matched against: its body and tags
+func postKeywordText(body string, tags []string) string {
+	if len(tags) == 0 {
+		return body
+	}
+	return body + "\n" + strings.Join(tags, " ")
+}
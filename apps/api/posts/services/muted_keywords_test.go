@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// substringMuter mutes, for every user in it, text containing its phrase
+type substringMuter map[uuid.UUID]string
+
+func (m substringMuter) MutedMatcher(ctx context.Context, userID uuid.UUID) sharedInterfaces.KeywordMatcher {
+	if phrase, ok := m[userID]; ok {
+		return substringMatcher(phrase)
+	}
+	return nil
+}
+
+type substringMatcher string
+
+func (m substringMatcher) Match(text string) bool { return strings.Contains(text, string(m)) }
+
+func TestDropMutedPosts(t *testing.T) {
+	viewer := uuid.Must(uuid.NewV4())
+	author := uuid.Must(uuid.NewV4()).String()
+	posts := []models.PostResponse{
+		{ObjectId: "1", OwnerUserId: author, Body: "finale spoilers inside"},
+		{ObjectId: "2", OwnerUserId: author, Body: "nothing to see", Tags: []string{"spoilers"}},
+		{ObjectId: "3", OwnerUserId: viewer.String(), Body: "my own spoilers"},
+		{ObjectId: "4", OwnerUserId: author, Body: "cat pictures"},
+	}
+	svc := &postService{keywordMuter: substringMuter{viewer: "spoilers"}}
+
+	viewerCtx := context.WithValue(context.Background(), types.UserCtxName, types.UserContext{UserID: viewer})
+	kept := svc.dropMutedPosts(viewerCtx, posts)
+	if assert.Len(t, kept, 2) {
+		assert.Equal(t, "3", kept[0].ObjectId, "the viewer's own posts are never muted")
+		assert.Equal(t, "4", kept[1].ObjectId)
+	}
+
+	otherCtx := context.WithValue(context.Background(), types.UserCtxName, types.UserContext{UserID: uuid.Must(uuid.NewV4())})
+	assert.Len(t, svc.dropMutedPosts(otherCtx, posts), 4)
+	assert.Len(t, svc.dropMutedPosts(context.Background(), posts), 4, "anonymous readers see everything")
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// Cached pages hold post IDs under keys stamped with the list version; each post is cached
+// once under its own key. A change to one post (a vote, a comment, a view) drops that
+// post's entry and every page that shows it picks up the change on its next read. Changes
+// that move posts in or out of lists bump the version, which retires all pages at once;
+// the old keys expire on their own after feedCacheTTL.
+const (
+	listVersionKey = "lists:version"
+	postKeyPrefix  = "post:"
+)
+
+// cachedPostList is a cached page without its posts, which are cached by ID. Pages built
+// from a field projection keep their posts inline, as the partial posts cannot stand in
+// for the full ones.
+type cachedPostList struct {
+	Page    models.PostsListResponse `json:"page"`
+	PostIDs []string                 `json:"postIds,omitempty"`
+}
+
+// listVersion returns the version that list keys are stamped with. The counter lives
+// longer than any page, so a counter that expires and restarts cannot revive one.
+func (s *postService) listVersion(ctx context.Context) int64 {
+	var version int64
+	if err := s.cacheService.GetCached(ctx, listVersionKey, &version); err != nil {
+		return 0
+	}
+	return version
+}
+
+// postCacheKey returns the key of a post's shared response
+func postCacheKey(postID string) string {
+	return postKeyPrefix + postID
+}
+
+// getCachedPosts retrieves a cached page and fills in its posts. Posts missing from the
+// cache are loaded in one query and cached again.
+func (s *postService) getCachedPosts(ctx context.Context, cacheKey string, filter *models.PostQueryFilter) (*models.PostsListResponse, error) {
+	var cached cachedPostList
+	if err := s.cacheService.GetCached(ctx, cacheKey, &cached); err != nil {
+		return nil, err
+	}
+	result := cached.Page
+	if len(filter.Fields) > 0 {
+		return &result, nil
+	}
+
+	posts, err := s.cachedPostResponses(ctx, cached.PostIDs)
+	if err != nil {
+		return nil, err
+	}
+	result.Posts = posts
+	return &result, nil
+}
+
+// cachedPostResponses returns the shared responses of the given posts in order, skipping
+// posts that no longer exist
+func (s *postService) cachedPostResponses(ctx context.Context, postIDs []string) ([]models.PostResponse, error) {
+	found := make(map[string]models.PostResponse, len(postIDs))
+	var missing []uuid.UUID
+	for _, id := range postIDs {
+		var post models.PostResponse
+		if err := s.cacheService.GetCached(ctx, postCacheKey(id), &post); err == nil {
+			found[id] = post
+			continue
+		}
+		if postID, err := uuid.FromString(id); err == nil {
+			missing = append(missing, postID)
+		}
+	}
+
+	if len(missing) > 0 {
+		loaded, err := s.repo.GetByIDs(ctx, missing)
+		if err != nil {
+			return nil, err
+		}
+		sharedCtx := withoutViewer(ctx)
+		responses := make([]models.PostResponse, len(loaded))
+		for i, post := range loaded {
+			responses[i] = s.ConvertPostToResponse(sharedCtx, post)
+		}
+		s.AttachCoauthors(ctx, responses)
+		for _, post := range responses {
+			found[post.ObjectId] = post
+			s.cachePost(ctx, post)
+		}
+	}
+
+	posts := make([]models.PostResponse, 0, len(postIDs))
+	for _, id := range postIDs {
+		if post, ok := found[id]; ok {
+			posts = append(posts, post)
+		}
+	}
+	return posts, nil
+}
+
+// cachePosts stores a page and its posts. Pages are built without a viewer, so the
+// cached posts carry no per-viewer fields.
+// Votes update scores without going through this service, so entries use a short TTL
+func (s *postService) cachePosts(ctx context.Context, cacheKey string, result *models.PostsListResponse, filter *models.PostQueryFilter) error {
+	cached := cachedPostList{Page: *result}
+	if len(filter.Fields) == 0 {
+		cached.Page.Posts = nil
+		cached.PostIDs = make([]string, len(result.Posts))
+		for i, post := range result.Posts {
+			cached.PostIDs[i] = post.ObjectId
+			s.cachePost(ctx, post)
+		}
+	}
+	return s.cacheService.CacheData(ctx, cacheKey, cached, feedCacheTTL)
+}
+
+// cachePost stores a post's shared response
+func (s *postService) cachePost(ctx context.Context, post models.PostResponse) {
+	if err := s.cacheService.CacheData(ctx, postCacheKey(post.ObjectId), post, feedCacheTTL); err != nil {
+		log.Warn("Failed to cache post %s: %v", post.ObjectId, err)
+	}
+}
+
+// invalidatePost drops the cached response of a post whose content or counters changed.
+// Pages keep their place for the post, so its order follows the change only once they
+// expire. Anonymous public pages are dropped per post and tag by InvalidatePublicPages.
+func (s *postService) invalidatePost(ctx context.Context, postID uuid.UUID) {
+	s.cacheService.InvalidateKey(ctx, postCacheKey(postID.String()))
+}
+
+// invalidatePostLists retires every cached page after posts were added, removed or
+// changed in a way that decides which lists they belong to
+func (s *postService) invalidatePostLists(ctx context.Context) {
+	if _, err := s.cacheService.Increment(ctx, listVersionKey, 1); err != nil {
+		log.Warn("Failed to bump the posts list version: %v", err)
+	}
+}
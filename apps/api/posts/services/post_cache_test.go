@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+// feedPostRepository serves a fixed feed and counts the queries and rows that reach it
+type feedPostRepository struct {
+	MockPostRepository
+	posts   []*models.Post
+	queries int
+	rows    int
+}
+
+func (r *feedPostRepository) query(rows int) {
+	r.queries++
+	r.rows += rows
+}
+
+func (r *feedPostRepository) FindWithCursor(ctx context.Context, filter repository.PostFilter, cursor *models.CursorData, sortField, sortDirection string, limit int) ([]*models.Post, bool, error) {
+	page := r.posts[:min(limit, len(r.posts))]
+	r.query(len(page))
+	return page, len(page) < len(r.posts), nil
+}
+
+func (r *feedPostRepository) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error) {
+	found := make([]*models.Post, 0, len(ids))
+	for _, post := range r.posts {
+		for _, id := range ids {
+			if post.ObjectId == id {
+				found = append(found, post)
+			}
+		}
+	}
+	r.query(len(found))
+	return found, nil
+}
+
+func (r *feedPostRepository) ListCoauthors(ctx context.Context, postIDs []uuid.UUID, status string) (map[uuid.UUID][]models.PostCoauthor, error) {
+	return map[uuid.UUID][]models.PostCoauthor{}, nil
+}
+
+func (r *feedPostRepository) IncrementViewCount(ctx context.Context, postID uuid.UUID) error {
+	return nil
+}
+
+func (r *feedPostRepository) IncrementScore(ctx context.Context, postID uuid.UUID, delta int) error {
+	for _, post := range r.posts {
+		if post.ObjectId == postID {
+			post.Score += int64(delta)
+		}
+	}
+	return nil
+}
+
+func (r *feedPostRepository) Create(ctx context.Context, post *models.Post) error {
+	r.posts = append([]*models.Post{post}, r.posts...)
+	return nil
+}
+
+// newCachedFeedService returns a service over a feed of n posts with its own memory cache
+func newCachedFeedService(n int) (*postService, *feedPostRepository) {
+	repo := &feedPostRepository{}
+	for i := 0; i < n; i++ {
+		repo.posts = append(repo.posts, createTestPost())
+	}
+	cacheConfig := cache.DefaultCacheConfig()
+	cacheConfig.Enabled = true
+	cacheConfig.Prefix = "posts_test_" + generateTestID()
+	svc := &postService{
+		repo:         repo,
+		config:       &platformconfig.Config{},
+		cacheService: cache.NewGenericCacheService(cache.NewMemoryCache(cacheConfig), cacheConfig),
+	}
+	return svc, repo
+}
+
+func TestPostChangeKeepsCachedPages(t *testing.T) {
+	svc, repo := newCachedFeedService(5)
+	ctx := context.Background()
+	filter := &models.PostQueryFilter{Limit: 5}
+
+	_, err := svc.QueryPostsWithCursor(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, 1, repo.queries)
+
+	voted := repo.posts[2]
+	require.NoError(t, svc.IncrementScore(ctx, voted.ObjectId, 1, nil))
+
+	result, err := svc.QueryPostsWithCursor(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.queries, "only the voted post is loaded again")
+	assert.Equal(t, 1, repo.rows-5)
+	require.Len(t, result.Posts, 5)
+	assert.Equal(t, voted.ObjectId.String(), result.Posts[2].ObjectId, "the page keeps its order")
+	assert.Equal(t, int64(1), result.Posts[2].Score)
+}
+
+func TestListChangeRetiresCachedPages(t *testing.T) {
+	svc, repo := newCachedFeedService(3)
+	ctx := context.Background()
+	filter := &models.PostQueryFilter{Limit: 10}
+
+	_, err := svc.QueryPostsWithCursor(ctx, filter)
+	require.NoError(t, err)
+	_, err = svc.QueryPostsWithCursor(ctx, filter)
+	require.NoError(t, err)
+	require.Equal(t, 1, repo.queries)
+
+	require.NoError(t, repo.Create(ctx, createTestPost()))
+	svc.invalidatePostLists(ctx)
+
+	result, err := svc.QueryPostsWithCursor(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 2, repo.queries)
+	assert.Len(t, result.Posts, 4)
+}
+
+func TestProjectedPagesCacheTheirPosts(t *testing.T) {
+	svc, repo := newCachedFeedService(3)
+	ctx := context.Background()
+	filter := &models.PostQueryFilter{Limit: 10, Fields: []string{"objectId", "body"}}
+
+	first, err := svc.QueryPostsWithCursor(ctx, filter)
+	require.NoError(t, err)
+	second, err := svc.QueryPostsWithCursor(ctx, filter)
+	require.NoError(t, err)
+	assert.Equal(t, 1, repo.queries)
+	assert.Equal(t, len(first.Posts), len(second.Posts))
+}
+
+// BenchmarkFeedReadsUnderViews reads the first feed page while posts across the feed are
+// viewed, the kind of write that used to flush every cached list
+func BenchmarkFeedReadsUnderViews(b *testing.B) {
+	svc, repo := newCachedFeedService(200)
+	ctx := context.Background()
+	filter := &models.PostQueryFilter{Limit: 20}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		post := repo.posts[i%len(repo.posts)]
+		if err := svc.IncrementViewCount(ctx, post.ObjectId, nil); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := svc.QueryPostsWithCursor(ctx, filter); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(repo.queries)/float64(b.N), "queries/op")
+	b.ReportMetric(float64(repo.rows)/float64(b.N), "rows/op")
+}
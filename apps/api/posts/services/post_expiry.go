@@ -0,0 +1,67 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/utils"
+)
+
+// defaultExpiryBatchSize is used when the posts config does not set one
+const defaultExpiryBatchSize = 500
+
+// ArchiveExpiredPosts archives every post whose visibleUntil has passed, one batch at a
+// time so a large backlog never holds a long lock, and drops cached feeds if any changed.
+// Feeds already hide expired posts, so the job only makes the archived state explicit.
+func (s *postService) ArchiveExpiredPosts(ctx context.Context) (int64, error) {
+	batchSize := defaultExpiryBatchSize
+	if s.config != nil && s.config.Posts.ExpiryBatchSize > 0 {
+		batchSize = s.config.Posts.ExpiryBatchSize
+	}
+
+	now := utils.UTCNowUnix()
+	var total int64
+	for {
+		archived, err := s.repo.ArchiveExpired(ctx, now, batchSize)
+		if err != nil {
+			return total, err
+		}
+		total += archived
+		if archived < int64(batchSize) {
+			break
+		}
+	}
+
+	if total > 0 && s.cacheService != nil {
+		s.invalidatePostLists(ctx)
+	}
+	return total, nil
+}
+
+// StartExpiryJob runs ArchiveExpiredPosts every interval until ctx is done.
+// A non-positive interval disables the job.
+func StartExpiryJob(ctx context.Context, svc PostService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				archived, err := svc.ArchiveExpiredPosts(ctx)
+				if err != nil {
+					log.Error("Post expiry job failed: %v", err)
+					continue
+				}
+				if archived > 0 {
+					log.Info("Archived %d expired posts", archived)
+				}
+			}
+		}
+	}()
+}
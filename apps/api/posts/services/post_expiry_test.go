@@ -0,0 +1,38 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+func TestArchiveExpiredPosts_DrainsBacklogInBatches(t *testing.T) {
+	repo := new(MockPostRepository)
+	svc := &postService{repo: repo, config: &platformconfig.Config{Posts: platformconfig.PostsConfig{ExpiryBatchSize: 2}}}
+
+	repo.On("ArchiveExpired", mock.Anything, mock.AnythingOfType("int64"), 2).Return(int64(2), nil).Twice()
+	repo.On("ArchiveExpired", mock.Anything, mock.AnythingOfType("int64"), 2).Return(int64(1), nil).Once()
+
+	archived, err := svc.ArchiveExpiredPosts(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), archived)
+	repo.AssertNumberOfCalls(t, "ArchiveExpired", 3)
+}
+
+func TestArchiveExpiredPosts_ReportsPartialProgressOnError(t *testing.T) {
+	repo := new(MockPostRepository)
+	svc := &postService{repo: repo}
+
+	repo.On("ArchiveExpired", mock.Anything, mock.AnythingOfType("int64"), defaultExpiryBatchSize).Return(int64(defaultExpiryBatchSize), nil).Once()
+	repo.On("ArchiveExpired", mock.Anything, mock.AnythingOfType("int64"), defaultExpiryBatchSize).Return(int64(0), errors.New("connection reset")).Once()
+
+	archived, err := svc.ArchiveExpiredPosts(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, int64(defaultExpiryBatchSize), archived)
+}
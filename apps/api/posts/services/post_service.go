@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -13,7 +14,9 @@ import (
 	commentRepository "github.com/qolzam/telar/apps/api/comments/repository"
 	"github.com/qolzam/telar/apps/api/internal/cache"
 	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/platform/ocr"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	"github.com/qolzam/telar/apps/api/internal/utils"
 	"github.com/qolzam/telar/apps/api/posts/common"
@@ -28,6 +31,9 @@ import (
 // All query building is now handled by the PostRepository interface methods
 // (e.g., Find, Count) which accept PostFilter structs for domain-specific filtering.
 
+// feedCacheTTL bounds how long cached feed and search pages may lag behind score changes
+const feedCacheTTL = 5 * time.Minute
+
 // postService implements the PostService interface
 type postService struct {
 	repo           repository.PostRepository 
@@ -37,6 +43,22 @@ type postService struct {
 	config         *platformconfig.Config
 	commentCounter sharedInterfaces.CommentCounter
 	commentRepo    commentRepository.CommentRepository 
+	mediaIndexer   *mediaTextIndexer  // nil when OCR is disabled
+	duplicates     *duplicateDetector // nil when duplicate detection is disabled
+	supporters     sharedInterfaces.SupporterChecker // nil until SetSupporterChecker; supporter-only posts stay locked
+	summarizer     ThreadSummarizer                  // nil when live threads or the AI engine are off; summaries stay empty
+	searcher       PostSearcher                      // nil when the AI engine is off; semantic search is unavailable
+	knowledge      KnowledgeIngester                 // nil when the AI engine is off; SyncKnowledge does nothing
+	outbox         sharedInterfaces.EventOutbox      // nil until SetEventOutbox; post changes are not announced
+	rules          sharedInterfaces.RulesChecker      // nil unless community rules are enabled
+	communities    sharedInterfaces.CommunityChecker  // nil until SetCommunityChecker; community posts are refused
+	feedDefaults   sharedInterfaces.FeedDefaultsProvider // nil until SetFeedDefaults; feeds stay chronological
+	keywordMuter   sharedInterfaces.KeywordMuter         // nil until SetKeywordMuter; nothing is muted
+	mentionRecorder sharedInterfaces.MentionRecorder     // nil until SetMentionRecorder; mentions stay plain text
+	imageVariants   sharedInterfaces.ImageVariantResolver // nil until SetImageVariantResolver; posts keep uploaded originals
+	screener        sharedInterfaces.ContentScreener      // nil until SetContentScreener; new posts are not screened
+	contentLimits   sharedInterfaces.ContentLimitsProvider // nil until SetContentLimits; the default limits apply
+	delegationAudit sharedInterfaces.DelegationAuditor     // nil until SetDelegationAuditor; delegated posts are refused
 }
 
 // Ensure postService implements sharedInterfaces.PostStatsUpdater interface
@@ -70,10 +92,16 @@ func (s *postService) incrementCommentCountInternal(ctx context.Context, postID
 	}
 
 	if s.cacheService != nil {
-		log.Info("Invalidating posts cache after commentCounter update for post %s", postID.String())
-		s.invalidateAllPosts(ctx)
+		s.invalidatePost(ctx, postID)
 	}
 
+	// Public pages show the count; comment counts are applied in the transaction of the
+	// consumed comment event, which the stats event joins
+	event := sharedInterfaces.PostChangedEvent{PostId: postID}
+	if err := s.recordPostChanged(ctx, sharedInterfaces.OutboxPostStatsChanged, event); err != nil {
+		return err
+	}
+	s.dropPublicPages(ctx, event)
 	return nil
 }
 
@@ -111,7 +139,7 @@ func NewPostService(repo repository.PostRepository, voteRepo votesRepository.Vot
 		cacheService = cache.NewGenericCacheServiceFor("posts")
 	}
 
-	return &postService{
+	svc := &postService{
 		repo:           repo,
 		voteRepo:       voteRepo,
 		bookmarkRepo:   bookmarkRepo,
@@ -120,10 +148,70 @@ func NewPostService(repo repository.PostRepository, voteRepo votesRepository.Vot
 		commentCounter: commentCounter,
 		commentRepo:    commentRepo,
 	}
+
+	if cfg != nil && cfg.OCR.Enabled {
+		extractor, err := ocr.NewExtractor(cfg.OCR, cfg.Storage)
+		if err != nil {
+			log.Warn("OCR is enabled but could not be initialized, media text indexing disabled: %v", err)
+		} else {
+			svc.mediaIndexer = newMediaTextIndexer(repo, extractor, cfg.OCR, func(ctx context.Context, postID uuid.UUID) {
+				// Media text is searched, so search pages may gain or lose the post
+				if svc.cacheService != nil {
+					svc.invalidatePostLists(ctx)
+				}
+				// The knowledge base embeds media text too
+				if err := svc.recordPostChanged(ctx, sharedInterfaces.OutboxPostUpdated, sharedInterfaces.PostChangedEvent{PostId: postID}); err != nil {
+					log.Error("Failed to announce media text for post %s: %v", postID.String(), err)
+				}
+			})
+		}
+	}
+
+	if cfg != nil && cfg.Duplicates.Enabled {
+		var scorer SimilarityScorer
+		if cfg.AIEngine.URL != "" {
+			client, err := aiengine.NewClientFromConfig(cfg.AIEngine)
+			if err != nil {
+				log.Warn("AI engine client could not be initialized, duplicate detection limited to exact matches: %v", err)
+			} else {
+				scorer = client
+			}
+		}
+		svc.duplicates = newDuplicateDetector(repo, scorer, cfg.Duplicates)
+	}
+
+	if cfg != nil && cfg.LiveThreads.Enabled && cfg.AIEngine.URL != "" {
+		client, err := aiengine.NewClientFromConfig(cfg.AIEngine)
+		if err != nil {
+			log.Warn("AI engine client could not be initialized, live threads will not be summarized: %v", err)
+		} else {
+			svc.summarizer = client
+		}
+	}
+
+	if cfg != nil && cfg.AIEngine.URL != "" {
+		client, err := aiengine.NewClientFromConfig(cfg.AIEngine)
+		if err != nil {
+			log.Warn("AI engine client could not be initialized, semantic search is unavailable: %v", err)
+		} else {
+			svc.searcher = client
+			svc.knowledge = client
+		}
+	}
+
+	return svc
+}
+
+// scheduleMediaText queues OCR for the post's images when media text indexing is enabled
+func (s *postService) scheduleMediaText(post *models.Post, imageURLs []string) {
+	if s.mediaIndexer == nil {
+		return
+	}
+	s.mediaIndexer.Enqueue(post.ObjectId, imageURLs)
 }
 
 // generateCursorCacheKey generates a cache key for cursor-based pagination
-func (s *postService) generateCursorCacheKey(filter *models.PostQueryFilter) string {
+func (s *postService) generateCursorCacheKey(ctx context.Context, filter *models.PostQueryFilter) string {
 	params := map[string]interface{}{
 		"operation": "cursor_query",
 		"limit":     filter.Limit,
@@ -137,6 +225,9 @@ func (s *postService) generateCursorCacheKey(filter *models.PostQueryFilter) str
 	if filter.PostTypeId != nil {
 		params["postTypeId"] = *filter.PostTypeId
 	}
+	if filter.CommunityId != nil {
+		params["communityId"] = filter.CommunityId.String()
+	}
 	if filter.Deleted != nil {
 		params["deleted"] = *filter.Deleted
 	}
@@ -146,6 +237,12 @@ func (s *postService) generateCursorCacheKey(filter *models.PostQueryFilter) str
 	if filter.CreatedAfter != nil {
 		params["createdAfter"] = filter.CreatedAfter.Unix()
 	}
+	if filter.Range.Since > 0 {
+		params["since"] = filter.Range.Since
+	}
+	if filter.Range.Until > 0 {
+		params["until"] = filter.Range.Until
+	}
 	if filter.Cursor != "" {
 		params["cursor"] = filter.Cursor
 	}
@@ -155,12 +252,33 @@ func (s *postService) generateCursorCacheKey(filter *models.PostQueryFilter) str
 	if filter.BeforeCursor != "" {
 		params["beforeCursor"] = filter.BeforeCursor
 	}
+	if filter.Search != "" {
+		params["search"] = filter.Search
+	}
+	if filter.Snapshot != "" {
+		params["snapshot"] = filter.Snapshot
+	}
+	if len(filter.Fields) > 0 {
+		params["fields"] = strings.Join(filter.Fields, ",")
+	}
+	if filter.PublicOnly {
+		params["publicOnly"] = true
+	}
+	if filter.Answered != nil {
+		params["answered"] = *filter.Answered
+	}
+	// The following feed differs per reader; follow changes show once the entry expires
+	if filter.FollowedBy != nil {
+		params["followedBy"] = filter.FollowedBy.String()
+	}
+
+	params["listVersion"] = s.listVersion(ctx)
 
 	return s.cacheService.GenerateHashKey("cursor", params)
 }
 
 // generateQueryCacheKey generates a cache key for offset-based pagination queries
-func (s *postService) generateQueryCacheKey(filter *models.PostQueryFilter) string {
+func (s *postService) generateQueryCacheKey(ctx context.Context, filter *models.PostQueryFilter) string {
 	params := map[string]interface{}{
 		"operation": "query",
 		"limit":     filter.Limit,
@@ -173,6 +291,9 @@ func (s *postService) generateQueryCacheKey(filter *models.PostQueryFilter) stri
 	if filter.PostTypeId != nil {
 		params["postTypeId"] = *filter.PostTypeId
 	}
+	if filter.CommunityId != nil {
+		params["communityId"] = filter.CommunityId.String()
+	}
 	if filter.Deleted != nil {
 		params["deleted"] = *filter.Deleted
 	}
@@ -182,12 +303,29 @@ func (s *postService) generateQueryCacheKey(filter *models.PostQueryFilter) stri
 	if filter.CreatedAfter != nil {
 		params["createdAfter"] = filter.CreatedAfter.Unix()
 	}
+	if len(filter.Fields) > 0 {
+		params["fields"] = strings.Join(filter.Fields, ",")
+	}
+	if filter.PublicOnly {
+		params["publicOnly"] = true
+	}
+	if filter.Answered != nil {
+		params["answered"] = *filter.Answered
+	}
+	if filter.FollowedBy != nil {
+		params["followedBy"] = filter.FollowedBy.String()
+	}
+	if filter.NotInteractedBy != nil {
+		params["notInteractedBy"] = filter.NotInteractedBy.String()
+	}
+
+	params["listVersion"] = s.listVersion(ctx)
 
 	return s.cacheService.GenerateHashKey("query", params)
 }
 
 // generateSearchCacheKey generates a cache key for search operations
-func (s *postService) generateSearchCacheKey(searchTerm string, filter *models.PostQueryFilter) string {
+func (s *postService) generateSearchCacheKey(ctx context.Context, searchTerm string, filter *models.PostQueryFilter) string {
 	params := map[string]interface{}{
 		"operation":  "search",
 		"searchTerm": searchTerm,
@@ -201,44 +339,34 @@ func (s *postService) generateSearchCacheKey(searchTerm string, filter *models.P
 	if filter.PostTypeId != nil {
 		params["postTypeId"] = *filter.PostTypeId
 	}
-
-	return s.cacheService.GenerateHashKey("search", params)
-}
-
-// getCachedPosts retrieves cached posts result
-func (s *postService) getCachedPosts(ctx context.Context, cacheKey string) (*models.PostsListResponse, error) {
-	var result models.PostsListResponse
-	if err := s.cacheService.GetCached(ctx, cacheKey, &result); err != nil {
-		return nil, err
+	if filter.CommunityId != nil {
+		params["communityId"] = filter.CommunityId.String()
+	}
+	if filter.Deleted != nil {
+		params["deleted"] = *filter.Deleted
+	}
+	if len(filter.Tags) > 0 {
+		params["tags"] = strings.Join(filter.Tags, ",")
+	}
+	if len(filter.Fields) > 0 {
+		params["fields"] = strings.Join(filter.Fields, ",")
+	}
+	if filter.ReusableOnly {
+		params["reusableOnly"] = true
+	}
+	if filter.Answered != nil {
+		params["answered"] = *filter.Answered
 	}
-	return &result, nil
-}
-
-// cachePosts stores posts result in cache
-func (s *postService) cachePosts(ctx context.Context, cacheKey string, result *models.PostsListResponse) error {
-	return s.cacheService.CacheData(ctx, cacheKey, result, time.Hour)
-}
-
-// invalidateUserPosts invalidates all cache entries for a specific user
-func (s *postService) invalidateUserPosts(ctx context.Context, userID string) {
-	// Use pattern to invalidate all user-specific cache entries
-	pattern := "cursor:*userId:" + userID + "*"
-	s.cacheService.InvalidatePattern(ctx, pattern)
 
-	pattern = "search:*userId:" + userID + "*"
-	s.cacheService.InvalidatePattern(ctx, pattern)
+	params["listVersion"] = s.listVersion(ctx)
 
-	// Best-effort invalidation for offset-based queries
-	pattern = "query:*"
-	s.cacheService.InvalidatePattern(ctx, pattern)
+	return s.cacheService.GenerateHashKey("search", params)
 }
 
-// invalidateAllPosts invalidates all posts-related cache entries
-func (s *postService) invalidateAllPosts(ctx context.Context) {
-	// Invalidate all cursor and search cache entries
-	s.cacheService.InvalidatePattern(ctx, "cursor:*")
-	s.cacheService.InvalidatePattern(ctx, "search:*")
-	s.cacheService.InvalidatePattern(ctx, "query:*")
+// withoutViewer returns a context without the user so responses built from it carry
+// no per-viewer fields (voteType, isBookmarked) and can be shared through the cache
+func withoutViewer(ctx context.Context) context.Context {
+	return context.WithValue(ctx, types.UserCtxName, nil)
 }
 
 // CreatePost creates a new post
@@ -250,6 +378,40 @@ func (s *postService) CreatePost(ctx context.Context, req *models.CreatePostRequ
 		return nil, fmt.Errorf("user context is required")
 	}
 
+	// Hooks may rewrite the request, so work on a copy of the caller's
+	hooked := *req
+	req = &hooked
+	if err := sanitizeCreateRequest(req); err != nil {
+		return nil, err
+	}
+	if err := runBeforeCreateHooks(ctx, req, user); err != nil {
+		return nil, err
+	}
+	if err := s.checkContentLimits(ctx, &req.Body, &req.Tags, &req.Album); err != nil {
+		return nil, err
+	}
+
+	// Delegated posts belong to the account the delegate acts as
+	owner := postOwner(ctx, user)
+	if req.SupporterOnly {
+		if err := s.checkSupporterOnly(ctx, owner.UserID); err != nil {
+			return nil, err
+		}
+	}
+	if req.CommunityId != nil && *req.CommunityId != uuid.Nil {
+		if err := s.checkCommunity(ctx, *req.CommunityId, owner.UserID); err != nil {
+			return nil, err
+		}
+		if err := s.checkRules(ctx, *req.CommunityId, owner); err != nil {
+			return nil, err
+		}
+	} else {
+		req.CommunityId = nil
+	}
+	if err := s.checkAnonymous(ctx, req); err != nil {
+		return nil, err
+	}
+
 	// Generate UUID for the post, or use provided one for backward compatibility
 	var objectId uuid.UUID
 	if req.ObjectId != nil && *req.ObjectId != uuid.Nil {
@@ -262,6 +424,12 @@ func (s *postService) CreatePost(ctx context.Context, req *models.CreatePostRequ
 		}
 	}
 
+	// Anonymous URL keys must not carry the author's social name
+	urlKeyName := owner.SocialName
+	if req.Anonymous {
+		urlKeyName = anonymousURLKeyName
+	}
+
 	// Create the post entity
 	post := &models.Post{
 		ObjectId:         objectId,
@@ -270,11 +438,11 @@ func (s *postService) CreatePost(ctx context.Context, req *models.CreatePostRequ
 		Votes:            make(map[string]string),
 		ViewCount:        0,
 		Body:             req.Body,
-		OwnerUserId:      user.UserID,
-		OwnerDisplayName: user.DisplayName,
-		OwnerAvatar:      user.Avatar,
-		URLKey:           common.GeneratePostURLKey(user.SocialName, req.Body, objectId.String()),
-		Tags:             req.Tags,
+		OwnerUserId:      owner.UserID,
+		OwnerDisplayName: owner.DisplayName,
+		OwnerAvatar:      owner.Avatar,
+		URLKey:           common.GeneratePostURLKey(urlKeyName, req.Body, objectId.String()),
+		Tags:             common.MergeTags(req.Tags, req.Body),
 		CommentCounter:   0,
 		Image:            req.Image,
 		ImageFullPath:    req.ImageFullPath,
@@ -287,8 +455,17 @@ func (s *postService) CreatePost(ctx context.Context, req *models.CreatePostRequ
 		CreatedDate:      utils.UTCNowUnix(),
 		LastUpdated:      0,
 		AccessUserList:   req.AccessUserList,
-		Permission:       req.Permission,
+		Permission:       s.postPermission(ctx, req.Permission),
 		Version:          req.Version,
+		VisibleUntil:     req.VisibleUntil,
+		License:          req.License,
+		CanonicalURL:     req.CanonicalURL,
+		Anonymous:        req.Anonymous,
+		SupporterOnly:    req.SupporterOnly,
+		CommunityId:      req.CommunityId,
+	}
+	if post.Anonymous {
+		post.AnonymousAlias = s.anonymousAlias(objectId, owner.UserID)
 	}
 
 	// Handle album if provided
@@ -296,6 +473,17 @@ func (s *postService) CreatePost(ctx context.Context, req *models.CreatePostRequ
 		post.Album = &req.Album
 	}
 
+	post.ContentHash = common.ContentHash(post.Body)
+	if s.duplicates != nil {
+		if match := s.duplicates.Check(ctx, owner.UserID, post.Body, post.ContentHash); match != nil {
+			// Only admins may override the block policy; anyone could set the flag otherwise
+			if s.duplicates.blocks() && !(req.AllowDuplicate && user.SystemRole == "admin") {
+				return nil, &postsErrors.DuplicateContentError{DuplicateOf: match.PostID, Similarity: match.Similarity, Reason: match.Reason}
+			}
+			post.DuplicateWarning = match
+		}
+	}
+
 	// Set timestamps
 	now := time.Now()
 	post.CreatedAt = now
@@ -307,17 +495,32 @@ func (s *postService) CreatePost(ctx context.Context, req *models.CreatePostRequ
 		post.LastUpdated = now.Unix()
 	}
 
-	// Save to database using new repository
-	if err := s.repo.Create(ctx, post); err != nil {
+	s.applyImageVariants(ctx, post)
+
+	// Save to database using new repository; a delegated post commits with its audit entry
+	err := s.writePost(ctx, sharedInterfaces.OutboxPostCreated, sharedInterfaces.PostChangedEvent{PostId: post.ObjectId}, func(txCtx context.Context) error {
+		if err := s.repo.Create(txCtx, post); err != nil {
+			return err
+		}
+		return s.auditDelegatedPost(txCtx, user, post.ObjectId)
+	})
+	if err != nil {
 		return nil, fmt.Errorf("failed to create post: %w", err)
 	}
 
 	// Invalidate relevant caches after successful creation
 	if s.cacheService != nil {
-		s.invalidateUserPosts(ctx, user.UserID.String())
-		s.invalidateAllPosts(ctx)
+		s.invalidatePostLists(ctx)
 	}
 
+	if imageURLs := postImageURLs(post); len(imageURLs) > 0 {
+		s.scheduleMediaText(post, imageURLs)
+	}
+
+	s.publishPostCreated(ctx, post)
+	s.recordMentions(ctx, post)
+	s.screenContent(ctx, post)
+
 	return post, nil
 }
 
@@ -362,8 +565,13 @@ func (s *postService) GetPostsByUser(ctx context.Context, userID uuid.UUID, filt
 	}
 	offset := (page - 1) * limit
 
-	// Use new repository method
-	posts, err := s.repo.FindByUser(ctx, userID, limit, offset)
+	// The user's own posts plus the ones they co-author
+	repoFilter := repository.PostFilter{
+		OwnerUserID:       &userID,
+		IncludeCoauthored: true,
+		Deleted:           ptr(false), // Only non-deleted posts
+	}
+	posts, err := s.repo.Find(ctx, repoFilter, limit, offset)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find posts by user: %w", err)
 	}
@@ -373,14 +581,16 @@ func (s *postService) GetPostsByUser(ctx context.Context, userID uuid.UUID, filt
 	for i, post := range posts {
 		postResponses[i] = s.ConvertPostToResponse(ctx, post)
 	}
+	postResponses = s.dropMutedPosts(ctx, postResponses)
+	s.AttachCoauthors(ctx, postResponses)
+	s.ApplySupporterAccess(ctx, postResponses)
+	s.applyFeedPreviews(filter, postResponses)
 
-	// Note: Attaching latest comment preview requires comments service integration - deferred for now
+	if filter != nil && filter.IncludeComments == models.IncludeCommentsPreview {
+		s.AttachLatestComments(ctx, postResponses)
+	}
 
 	// Get total count using repository Count method
-	repoFilter := repository.PostFilter{
-		OwnerUserID: &userID,
-		Deleted:     ptr(false), // Only count non-deleted posts
-	}
 	totalCount, err := s.repo.Count(ctx, repoFilter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get post count: %w", err)
@@ -412,14 +622,21 @@ func (s *postService) SearchPosts(ctx context.Context, query string, filter *mod
 
 	// Build repository filter with search term
 	repoFilter := repository.PostFilter{
-		SearchText: &query,
+		SearchText:   &query,
+		Fields:       filter.Fields,
+		ReusableOnly: filter.ReusableOnly,
+		Answered:     filter.Answered,
 	}
 	if filter.OwnerUserId != nil {
 		repoFilter.OwnerUserID = filter.OwnerUserId
+		repoFilter.IncludeCoauthored = true
 	}
 	if filter.PostTypeId != nil {
 		repoFilter.PostTypeID = filter.PostTypeId
 	}
+	if filter.CommunityId != nil {
+		repoFilter.CommunityID = filter.CommunityId
+	}
 	if len(filter.Tags) > 0 {
 		repoFilter.Tags = filter.Tags
 	}
@@ -440,6 +657,18 @@ func (s *postService) SearchPosts(ctx context.Context, query string, filter *mod
 	}
 	offset := (page - 1) * limit
 
+	var cacheKey string
+	if s.cacheService != nil {
+		cacheKey = s.generateSearchCacheKey(ctx, query, filter)
+		if cached, err := s.getCachedPosts(ctx, cacheKey, filter); err == nil {
+			cached.Posts = s.dropMutedPosts(ctx, cached.Posts)
+			s.enrichPostsForViewer(ctx, cached.Posts)
+			s.ApplySupporterAccess(ctx, cached.Posts)
+			s.applyFeedPreviews(filter, cached.Posts)
+			return cached, nil
+		}
+	}
+
 	// Use repository Find method with search term
 	posts, err := s.repo.Find(ctx, repoFilter, limit, offset)
 	if err != nil {
@@ -453,81 +682,51 @@ func (s *postService) SearchPosts(ctx context.Context, query string, filter *mod
 	}
 
 	// Convert to response format
+	sharedCtx := withoutViewer(ctx)
 	postResponses := make([]models.PostResponse, len(posts))
 	for i, post := range posts {
-		postResponses[i] = s.ConvertPostToResponse(ctx, post)
+		postResponses[i] = s.ConvertPostToResponse(sharedCtx, post)
 	}
+	s.AttachCoauthors(ctx, postResponses)
 
-	return &models.PostsListResponse{
+	result := &models.PostsListResponse{
 		Posts:      postResponses,
 		TotalCount: totalCount,
 		Page:       page,
 		Limit:      limit,
-	}, nil
-}
-
-// SearchPostsLite returns a small set of posts for autocomplete
-func (s *postService) SearchPostsLite(ctx context.Context, query string, limit int) ([]models.PostResponse, error) {
-	trimmed := strings.TrimSpace(query)
-	if len(trimmed) < 3 {
-		return []models.PostResponse{}, nil
-	}
-	if limit <= 0 {
-		limit = 5
-	}
-	if limit > 20 {
-		limit = 20
-	}
-
-	posts, err := s.repo.Search(ctx, trimmed, limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search posts: %w", err)
-	}
-	if len(posts) == 0 {
-		return []models.PostResponse{}, nil
 	}
 
-	// Preload comment counts in bulk to match feed data
-	commentCounts := map[uuid.UUID]int64{}
-	postIDs := make([]uuid.UUID, len(posts))
-	for i, post := range posts {
-		postIDs[i] = post.ObjectId
-	}
-	if s.commentRepo != nil {
-		if counts, err := s.commentRepo.CountByPostIDs(ctx, postIDs); err == nil {
-			commentCounts = counts
+	if cacheKey != "" {
+		if err := s.cachePosts(ctx, cacheKey, result, filter); err != nil {
+			log.Warn("Failed to cache search results: %v", err)
 		}
 	}
 
-	// Preload vote state in bulk when user is authenticated
-	voteMap := map[uuid.UUID]int{}
-	if s.voteRepo != nil {
-		if userCtx, ok := ctx.Value(types.UserCtxName).(types.UserContext); ok {
-			if votes, err := s.voteRepo.GetVotesForPosts(ctx, postIDs, userCtx.UserID); err == nil {
-				voteMap = votes
-			}
-		}
-	}
-
-	responses := make([]models.PostResponse, len(posts))
-	ctxWithoutUser := context.WithValue(ctx, types.UserCtxName, (*types.UserContext)(nil))
+	result.Posts = s.dropMutedPosts(ctx, result.Posts)
+	s.enrichPostsForViewer(ctx, result.Posts)
+	s.ApplySupporterAccess(ctx, result.Posts)
+	s.applyFeedPreviews(filter, result.Posts)
+	return result, nil
+}
 
-	for i, post := range posts {
-		if count, ok := commentCounts[post.ObjectId]; ok {
-			post.CommentCounter = count
-		}
-		response := s.ConvertPostToResponse(ctxWithoutUser, post)
-		if v, ok := voteMap[post.ObjectId]; ok {
-			response.VoteType = v
-		}
-		responses[i] = response
+// enrichPostsForViewer fills per-viewer fields when the request carries a user context
+func (s *postService) enrichPostsForViewer(ctx context.Context, posts []models.PostResponse) {
+	if userCtx, ok := ctx.Value(types.UserCtxName).(types.UserContext); ok {
+		s.enrichPostsWithVoteType(ctx, posts, userCtx.UserID)
+		s.enrichPostsWithBookmarks(ctx, posts, userCtx.UserID)
 	}
-
-	return responses, nil
 }
 
 // UpdatePost updates an existing post
 func (s *postService) UpdatePost(ctx context.Context, postID uuid.UUID, req *models.UpdatePostRequest, user *types.UserContext) error {
+	req, err := sanitizeUpdateRequest(req)
+	if err != nil {
+		return err
+	}
+	if err := s.checkContentLimits(ctx, req.Body, req.Tags, req.Album); err != nil {
+		return err
+	}
+
 	// Load existing post
 	post, err := s.repo.FindByID(ctx, postID)
 	if err != nil {
@@ -537,14 +736,19 @@ func (s *postService) UpdatePost(ctx context.Context, postID uuid.UUID, req *mod
 		return fmt.Errorf("failed to get post: %w", err)
 	}
 
-	// Verify ownership
-	if post.OwnerUserId != user.UserID {
-		return postsErrors.ErrPostOwnershipRequired
+	// The owner and accepted co-authors may edit
+	if err := s.requireEditor(ctx, post, user.UserID); err != nil {
+		return err
 	}
 
+	previousImageURLs := postImageURLs(post)
+	previousBody := post.Body
+	previous := postChanged(post)
+
 	// Update fields on the struct
 	if req.Body != nil {
 		post.Body = *req.Body
+		post.ContentHash = common.ContentHash(post.Body)
 	}
 	if req.Image != nil {
 		post.Image = *req.Image
@@ -558,8 +762,14 @@ func (s *postService) UpdatePost(ctx context.Context, postID uuid.UUID, req *mod
 	if req.Thumbnail != nil {
 		post.Thumbnail = *req.Thumbnail
 	}
+	if req.Image != nil || req.ImageFullPath != nil {
+		s.applyImageVariants(ctx, post)
+	}
+	// Hashtags in the body are tags too; edits drop the ones the new body no longer has
 	if req.Tags != nil {
-		post.Tags = *req.Tags
+		post.Tags = common.MergeTags(*req.Tags, post.Body)
+	} else if req.Body != nil {
+		post.Tags = common.RetagBody(post.Tags, previousBody, post.Body)
 	}
 	if req.Album != nil {
 		post.Album = req.Album
@@ -579,20 +789,60 @@ func (s *postService) UpdatePost(ctx context.Context, postID uuid.UUID, req *mod
 	if req.Version != nil {
 		post.Version = *req.Version
 	}
+	if req.VisibleUntil != nil {
+		// A new or removed expiry brings an archived post back into feeds
+		post.VisibleUntil = *req.VisibleUntil
+		post.Archived = false
+	}
+	if req.License != nil {
+		post.License = *req.License
+	}
+	if req.CanonicalURL != nil {
+		post.CanonicalURL = *req.CanonicalURL
+	}
+	if req.SupporterOnly != nil && *req.SupporterOnly && !post.SupporterOnly {
+		// A teaser offering the author's tiers would reveal who wrote an anonymous post
+		if post.Anonymous {
+			return postsErrors.ErrAnonymousPost
+		}
+		if err := s.checkSupporterOnly(ctx, post.OwnerUserId); err != nil {
+			return err
+		}
+	}
+	if req.SupporterOnly != nil {
+		post.SupporterOnly = *req.SupporterOnly
+	}
+	if post.SupporterOnly && post.Permission != "" && post.Permission != "Public" {
+		return fmt.Errorf("%w: supporter-only posts must be public", postsErrors.ErrValidationFailed)
+	}
 
 	// Update timestamp
 	post.UpdatedAt = time.Now()
 	post.LastUpdated = time.Now().Unix()
 
 	// Save using repository
-	if err := s.repo.Update(ctx, post); err != nil {
+	err = s.writePost(ctx, sharedInterfaces.OutboxPostUpdated, previous, func(txCtx context.Context) error {
+		return s.repo.Update(txCtx, post)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to update post: %w", err)
 	}
 
-	// Invalidate relevant caches after successful update
+	// Invalidate relevant caches after successful update; edits to tags or visibility
+	// change which lists the post belongs to
 	if s.cacheService != nil {
-		s.invalidateUserPosts(ctx, user.UserID.String())
-		s.invalidateAllPosts(ctx)
+		s.invalidatePost(ctx, post.ObjectId)
+		s.invalidatePostLists(ctx)
+	}
+
+	// Re-run OCR when images change; an empty list clears stale media text
+	if imageURLs := postImageURLs(post); !slices.Equal(imageURLs, previousImageURLs) {
+		s.scheduleMediaText(post, imageURLs)
+	}
+
+	// Edits can add mentions, or make a post with mentions public
+	if req.Body != nil || req.Permission != nil {
+		s.recordMentions(ctx, post)
 	}
 
 	return nil
@@ -664,7 +914,7 @@ func (s *postService) IncrementScore(ctx context.Context, postID uuid.UUID, delt
 
 	// Invalidate cache after score increment
 	if s.cacheService != nil {
-		s.invalidateAllPosts(ctx)
+		s.invalidatePost(ctx, postID)
 	}
 
 	return nil
@@ -693,7 +943,25 @@ func (s *postService) IncrementViewCount(ctx context.Context, postID uuid.UUID,
 
 	// Invalidate cache after view count increment
 	if s.cacheService != nil {
-		s.invalidateAllPosts(ctx)
+		s.invalidatePost(ctx, postID)
+	}
+
+	return nil
+}
+
+// IncrementTipCount adds delta to the tip count of a post as the tips module records paid
+// and refunded tips; like IncrementCommentCountForService it takes no user context
+func (s *postService) IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error {
+	if err := s.repo.IncrementTipCount(ctx, postID, delta); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return postsErrors.ErrPostNotFound
+		}
+		return fmt.Errorf("failed to increment tip count: %w", err)
+	}
+
+	// Cached pages only carry tip counts when they are shown
+	if s.cacheService != nil && s.config != nil && s.config.Tips.ShowCounts {
+		s.invalidatePost(ctx, postID)
 	}
 
 	return nil
@@ -702,6 +970,7 @@ func (s *postService) IncrementViewCount(ctx context.Context, postID uuid.UUID,
 // findPostForOwnershipCheck finds a post for ownership validation, regardless of deleted status.
 // This is useful for operations like idempotent deletes or permanent data purges.
 // It does NOT filter by deleted status, allowing it to find already-deleted posts.
+// Co-authors do not pass: deleting stays with the owner.
 func (s *postService) findPostForOwnershipCheck(ctx context.Context, postID uuid.UUID, userID uuid.UUID) (*models.Post, error) {
 	post, err := s.repo.FindByID(ctx, postID)
 	if err != nil {
@@ -766,7 +1035,9 @@ func (s *postService) GenerateURLKey(ctx context.Context, postID uuid.UUID, user
 	// In the original implementation, it calls getUserProfileByID to get the owner's profile
 	// Since we don't have that function, we'll use the owner's display name as social name
 	socialName := post.OwnerDisplayName
-	if socialName == "" {
+	if post.Anonymous {
+		socialName = anonymousURLKeyName
+	} else if socialName == "" {
 		socialName = "user" // fallback
 	}
 
@@ -884,6 +1155,25 @@ func (s *postService) ConvertPostToResponse(ctx context.Context, post *models.Po
 		LastUpdated:      post.LastUpdated,
 		Permission:       post.Permission,
 		Version:          post.Version,
+		MediaText:        post.MediaText,
+		VisibleUntil:     post.VisibleUntil,
+		Archived:         post.Archived,
+		License:          post.License,
+		CanonicalURL:     post.CanonicalURL,
+		SupporterOnly:    post.SupporterOnly,
+	}
+	if post.CommunityId != nil {
+		response.CommunityId = post.CommunityId.String()
+	}
+	if post.AcceptedAnswerId != nil {
+		response.AcceptedAnswerId = post.AcceptedAnswerId.String()
+		response.Answered = true
+	}
+	if post.Anonymous {
+		response.HideAuthor(post.AnonymousAlias)
+	}
+	if s.config != nil && s.config.Tips.ShowCounts {
+		response.TipCount = post.TipCount
 	}
 
 	// Enrich with vote type if user context is available
@@ -909,10 +1199,14 @@ func (s *postService) QueryPosts(ctx context.Context, filter *models.PostQueryFi
 	repoFilter := repository.PostFilter{}
 	if filter.OwnerUserId != nil {
 		repoFilter.OwnerUserID = filter.OwnerUserId
+		repoFilter.IncludeCoauthored = true
 	}
 	if filter.PostTypeId != nil {
 		repoFilter.PostTypeID = filter.PostTypeId
 	}
+	if filter.CommunityId != nil {
+		repoFilter.CommunityID = filter.CommunityId
+	}
 	if len(filter.Tags) > 0 {
 		repoFilter.Tags = filter.Tags
 	}
@@ -923,6 +1217,14 @@ func (s *postService) QueryPosts(ctx context.Context, filter *models.PostQueryFi
 		timestamp := filter.CreatedAfter.Unix()
 		repoFilter.CreatedAfter = &timestamp
 	}
+	repoFilter.Fields = filter.Fields
+	if filter.PublicOnly {
+		public := "Public"
+		repoFilter.Permission = &public
+	}
+	repoFilter.Answered = filter.Answered
+	repoFilter.FollowedBy = filter.FollowedBy
+	repoFilter.NotInteractedBy = filter.NotInteractedBy
 
 	// Normalize pagination
 	limit := filter.Limit
@@ -952,6 +1254,7 @@ func (s *postService) QueryPosts(ctx context.Context, filter *models.PostQueryFi
 	for i, post := range posts {
 		postResponses[i] = s.ConvertPostToResponse(ctx, post)
 	}
+	s.AttachCoauthors(ctx, postResponses)
 
 	hasMore := int64(page*limit) < totalCount
 	result := &models.PostsListResponse{
@@ -979,10 +1282,13 @@ func (s *postService) QueryPosts(ctx context.Context, filter *models.PostQueryFi
 	}
 
 	// Enrich with vote types if user context is available and voteRepo is set
+	result.Posts = s.dropMutedPosts(ctx, result.Posts)
 	if userCtx, ok := ctx.Value(types.UserCtxName).(types.UserContext); ok {
 		s.enrichPostsWithVoteType(ctx, result.Posts, userCtx.UserID)
 		s.enrichPostsWithBookmarks(ctx, result.Posts, userCtx.UserID)
 	}
+	s.ApplySupporterAccess(ctx, result.Posts)
+	s.applyFeedPreviews(filter, result.Posts)
 
 	return result, nil
 }
@@ -1018,6 +1324,14 @@ func (s *postService) enrichSinglePostWithBookmark(ctx context.Context, response
 	if err == nil {
 		response.IsBookmarked = bookmarkMap[postID]
 	}
+	if !response.IsBookmarked {
+		return
+	}
+
+	collectionNames, err := s.bookmarkRepo.GetCollectionNames(ctx, userID, []uuid.UUID{postID})
+	if err == nil {
+		response.Collections = collectionNames[postID]
+	}
 }
 
 // enrichPostsWithVoteType bulk-enriches posts with current user's vote type
@@ -1079,10 +1393,29 @@ func (s *postService) enrichPostsWithBookmarks(ctx context.Context, posts []mode
 		return
 	}
 
+	bookmarked := make([]uuid.UUID, 0, len(postIDs))
 	for i := range posts {
 		id, err := uuid.FromString(posts[i].ObjectId)
 		if err == nil {
 			posts[i].IsBookmarked = bookmarkMap[id]
+			if bookmarkMap[id] {
+				bookmarked = append(bookmarked, id)
+			}
+		}
+	}
+	if len(bookmarked) == 0 {
+		return
+	}
+
+	// Collection names only exist for bookmarked posts
+	collectionNames, err := s.bookmarkRepo.GetCollectionNames(ctx, userID, bookmarked)
+	if err != nil {
+		return
+	}
+	for i := range posts {
+		id, err := uuid.FromString(posts[i].ObjectId)
+		if err == nil {
+			posts[i].Collections = collectionNames[id]
 		}
 	}
 }
@@ -1095,15 +1428,22 @@ func (s *postService) QueryPostsWithCursor(ctx context.Context, filter *models.P
 			Limit: 10,
 		}
 	}
+	if filter.SortField == "" {
+		filter.SortField = s.feedSortField(ctx, filter)
+	}
 
 	// Build repository filter
 	repoFilter := repository.PostFilter{}
 	if filter.OwnerUserId != nil {
 		repoFilter.OwnerUserID = filter.OwnerUserId
+		repoFilter.IncludeCoauthored = true
 	}
 	if filter.PostTypeId != nil {
 		repoFilter.PostTypeID = filter.PostTypeId
 	}
+	if filter.CommunityId != nil {
+		repoFilter.CommunityID = filter.CommunityId
+	}
 	if len(filter.Tags) > 0 {
 		repoFilter.Tags = filter.Tags
 	}
@@ -1120,6 +1460,39 @@ func (s *postService) QueryPostsWithCursor(ctx context.Context, filter *models.P
 	if filter.Search != "" {
 		repoFilter.SearchText = &filter.Search
 	}
+	repoFilter.Fields = filter.Fields
+	if filter.PublicOnly {
+		public := "Public"
+		repoFilter.Permission = &public
+	}
+	repoFilter.Answered = filter.Answered
+	repoFilter.FollowedBy = filter.FollowedBy
+	repoFilter.NotInteractedBy = filter.NotInteractedBy
+
+	snapshot, err := resolveSnapshot(filter)
+	if err != nil {
+		return nil, err
+	}
+	repoFilter.CreatedBefore = &snapshot.CreatedBefore
+	if err := s.checkTimeRange(ctx, filter.Range); err != nil {
+		return nil, err
+	}
+	applyTimeRange(&repoFilter, filter.Range)
+
+	// A page that hides interacted posts goes stale as soon as the reader votes, comments
+	// or bookmarks, so it is never cached
+	var cacheKey string
+	if s.cacheService != nil && filter.NotInteractedBy == nil {
+		cacheKey = s.generateCursorCacheKey(ctx, filter)
+		if cached, err := s.getCachedPosts(ctx, cacheKey, filter); err == nil {
+			cached.Posts = s.dropMutedPosts(ctx, cached.Posts)
+			s.enrichPostsForViewer(ctx, cached.Posts)
+			s.markNewPosts(ctx, filter, cached.Posts)
+			s.ApplySupporterAccess(ctx, cached.Posts)
+			s.applyFeedPreviews(filter, cached.Posts)
+			return cached, nil
+		}
+	}
 
 	// Normalize limit
 	limit := filter.Limit
@@ -1163,20 +1536,27 @@ func (s *postService) QueryPostsWithCursor(ctx context.Context, filter *models.P
 	}
 
 	// Convert to response format
+	sharedCtx := withoutViewer(ctx)
 	postResponses := make([]models.PostResponse, len(posts))
 	for i, post := range posts {
-		postResponses[i] = s.ConvertPostToResponse(ctx, post)
+		postResponses[i] = s.ConvertPostToResponse(sharedCtx, post)
 	}
+	s.AttachCoauthors(ctx, postResponses)
 
 	// Generate nextCursor from the last post if there are more posts
 	var nextCursor string
 	if hasMore && len(posts) > 0 {
 		lastPost := posts[len(posts)-1]
-		if cursor, err := models.CreateCursorFromPost(lastPost, sortField, sortDirection); err == nil {
+		if cursor, err := models.CreateSnapshotCursorFromPost(lastPost, sortField, sortDirection, snapshot); err == nil {
 			nextCursor = cursor
 		}
 	}
 
+	snapshotToken, err := models.EncodeSnapshot(snapshot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
 	result := &models.PostsListResponse{
 		Posts:      postResponses,
 		TotalCount: 0,
@@ -1184,17 +1564,60 @@ func (s *postService) QueryPostsWithCursor(ctx context.Context, filter *models.P
 		Limit:      limit,
 		HasNext:    hasMore, // Set hasNext based on Limit + 1 strategy
 		NextCursor: nextCursor,
+		Snapshot:   snapshotToken,
 	}
 
-	// Enrich with vote types if user context is available and voteRepo is set
-	if userCtx, ok := ctx.Value(types.UserCtxName).(types.UserContext); ok {
-		s.enrichPostsWithVoteType(ctx, result.Posts, userCtx.UserID)
-		s.enrichPostsWithBookmarks(ctx, result.Posts, userCtx.UserID)
+	if cacheKey != "" {
+		if err := s.cachePosts(ctx, cacheKey, result, filter); err != nil {
+			log.Warn("Failed to cache cursor query results: %v", err)
+		}
 	}
 
+	// Enrich with vote types if user context is available and voteRepo is set
+	result.Posts = s.dropMutedPosts(ctx, result.Posts)
+	s.enrichPostsForViewer(ctx, result.Posts)
+	s.markNewPosts(ctx, filter, result.Posts)
+	s.ApplySupporterAccess(ctx, result.Posts)
+	s.applyFeedPreviews(filter, result.Posts)
+
 	return result, nil
 }
 
+// resolveSnapshot returns the snapshot that bounds this page: an explicit snapshot token
+// wins, then the snapshot carried by the cursor, and a first page starts a new snapshot.
+// Either way a snapshot older than SnapshotMaxAge is rejected.
+func resolveSnapshot(filter *models.PostQueryFilter) (*models.SnapshotData, error) {
+	now := time.Now()
+	if filter.Snapshot != "" {
+		snapshot, err := models.DecodeSnapshot(filter.Snapshot, now)
+		if err != nil {
+			return nil, postsErrors.WrapValidationError(err, "snapshot")
+		}
+		return snapshot, nil
+	}
+
+	for _, cursor := range []string{filter.Cursor, filter.AfterCursor, filter.BeforeCursor} {
+		if cursor == "" {
+			continue
+		}
+		if cursorData, err := models.DecodeCursor(cursor); err == nil && cursorData.Snapshot > 0 {
+			snapshot := &models.SnapshotData{CreatedBefore: cursorData.Snapshot, IssuedAt: cursorData.SnapshotIssuedAt}
+			if snapshot.IssuedAt == 0 {
+				// Cursors issued before they carried the snapshot time: a new snapshot's
+				// boundary is the time it was taken, in milliseconds
+				snapshot.IssuedAt = cursorData.Snapshot / 1000
+			}
+			if err := snapshot.Validate(now); err != nil {
+				return nil, postsErrors.WrapValidationError(fmt.Errorf("invalid snapshot: %w", err), "cursor")
+			}
+			return snapshot, nil
+		}
+		break
+	}
+
+	return models.NewSnapshot(utils.UTCNowUnix(), now), nil
+}
+
 // SearchPostsWithCursor retrieves posts matching search criteria with cursor-based pagination
 // Note: Currently falls back to basic SearchPosts. For true cursor pagination, additional repository methods would be needed.
 func (s *postService) SearchPostsWithCursor(ctx context.Context, searchTerm string, filter *models.PostQueryFilter) (*models.PostsListResponse, error) {
@@ -1337,20 +1760,23 @@ func (s *postService) DeletePost(ctx context.Context, postID uuid.UUID, user *ty
 		return fmt.Errorf("failed to get post: %w", err)
 	}
 
-	// Verify ownership
+	// Verify ownership; co-authors can edit but only the owner deletes
 	if post.OwnerUserId != user.UserID {
 		return postsErrors.ErrPostOwnershipRequired
 	}
 
 	// Delete the post (soft delete - sets is_deleted = TRUE)
-	if err := s.repo.Delete(ctx, postID); err != nil {
+	err = s.writePost(ctx, sharedInterfaces.OutboxPostDeleted, postChanged(post), func(txCtx context.Context) error {
+		return s.repo.Delete(txCtx, postID)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to delete post: %w", err)
 	}
 
 	// Invalidate relevant caches after successful deletion
 	if s.cacheService != nil {
-		s.invalidateUserPosts(ctx, user.UserID.String())
-		s.invalidateAllPosts(ctx)
+		s.invalidatePost(ctx, postID)
+		s.invalidatePostLists(ctx)
 	}
 
 	return nil
@@ -1380,9 +1806,17 @@ func (s *postService) SoftDeletePost(ctx context.Context, postID uuid.UUID, user
 		return nil
 	}
 
-	// 3. Perform cascade soft-delete in a transaction (post + comments)
-	err = s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
-		// 3a. Soft-delete the post
+	// 3. Perform cascade soft-delete (post + comments)
+	return s.softDeleteWithComments(ctx, post)
+}
+
+// softDeleteWithComments soft-deletes a post and its comments in one transaction, then
+// invalidates the caches holding it
+func (s *postService) softDeleteWithComments(ctx context.Context, post *models.Post) error {
+	postID := post.ObjectId
+	event := postChanged(post)
+	err := s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		// Soft-delete the post
 		updates := map[string]interface{}{
 			"deleted":     true,
 			"deletedDate": time.Now().Unix(),
@@ -1391,23 +1825,24 @@ func (s *postService) SoftDeletePost(ctx context.Context, postID uuid.UUID, user
 			return fmt.Errorf("failed to soft-delete post: %w", err)
 		}
 
-		// 3b. Cascade soft-delete all comments for this post (write-time propagation)
+		// Cascade soft-delete all comments for this post (write-time propagation)
 		if err := s.commentRepo.DeleteByPostID(txCtx, postID); err != nil {
 			return fmt.Errorf("failed to cascade soft-delete comments: %w", err)
 		}
 
-		return nil
+		return s.recordPostChanged(txCtx, sharedInterfaces.OutboxPostDeleted, event)
 	})
 
 	if err != nil {
 		return err
 	}
 
-	// 4. Invalidate caches.
+	// Invalidate caches.
 	if s.cacheService != nil {
-		s.invalidateUserPosts(ctx, user.UserID.String())
-		s.invalidateAllPosts(ctx)
+		s.invalidatePost(ctx, postID)
+		s.invalidatePostLists(ctx)
 	}
+	s.dropPublicPages(ctx, event)
 
 	return nil
 }
@@ -1427,7 +1862,9 @@ func (s *postService) DeleteByOwner(ctx context.Context, owner uuid.UUID, object
 		return postsErrors.ErrPostNotFound
 	}
 
-	return s.repo.Delete(ctx, objectId)
+	return s.writePost(ctx, sharedInterfaces.OutboxPostDeleted, postChanged(post), func(txCtx context.Context) error {
+		return s.repo.Delete(txCtx, objectId)
+	})
 }
 
 // DELETED: UpdateFieldsWithOwnership, DeleteWithOwnership, IncrementFieldsWithOwnership
@@ -10,13 +10,17 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/qolzam/telar/apps/api/internal/database/interfaces"
 	dbi "github.com/qolzam/telar/apps/api/internal/database/interfaces"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	commentMocks "github.com/qolzam/telar/apps/api/comments/services/mocks"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
 	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // MockRepository implements a mock repository for testing
@@ -476,6 +480,165 @@ func TestCreatePost_ValidRequest_Success(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// Test CreatePost publishes as the acting identity set by a delegation
+func TestCreatePost_ActingAs_UsesActingIdentity(t *testing.T) {
+	service, mockRepo := setupTestService()
+	user := createTestUserContext()
+	req := createTestCreatePostRequest()
+	brand := &sharedInterfaces.ActingIdentity{UserID: uuid.Must(uuid.NewV4()), DisplayName: "Brand", SocialName: "brand", Avatar: "brand.png"}
+	ctx := WithActingAs(context.Background(), brand)
+	service.SetDelegationAuditor(&stubAuditor{})
+
+	mockRepo.On("WithTransaction", ctx, mock.Anything).Return(nil)
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+
+	result, err := service.CreatePost(ctx, req, user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, brand.UserID, result.OwnerUserId)
+	assert.Equal(t, "Brand", result.OwnerDisplayName)
+	assert.Equal(t, "brand.png", result.OwnerAvatar)
+	assert.True(t, strings.HasPrefix(result.URLKey, "brand"), "URL key %q should use the brand's social name", result.URLKey)
+	mockRepo.AssertExpectations(t)
+}
+
+// stubAuditor records delegated actions, or fails with err
+type stubAuditor struct {
+	err     error
+	audited []uuid.UUID
+}
+
+func (a *stubAuditor) RecordDelegatedAction(ctx context.Context, identity *sharedInterfaces.ActingIdentity, delegateID uuid.UUID, scope string, targetID uuid.UUID) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.audited = append(a.audited, targetID)
+	return nil
+}
+
+// Test delegated posts are audited in the transaction that creates them
+func TestCreatePost_ActingAs_AuditsInTransaction(t *testing.T) {
+	brand := &sharedInterfaces.ActingIdentity{UserID: uuid.Must(uuid.NewV4()), GrantID: uuid.Must(uuid.NewV4()), DisplayName: "Brand"}
+
+	t.Run("records the audit entry", func(t *testing.T) {
+		service, mockRepo := setupTestService()
+		auditor := &stubAuditor{}
+		service.SetDelegationAuditor(auditor)
+		ctx := WithActingAs(context.Background(), brand)
+		mockRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+
+		result, err := service.CreatePost(ctx, createTestCreatePostRequest(), createTestUserContext())
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{result.ObjectId}, auditor.audited)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("fails the post when the audit cannot be written", func(t *testing.T) {
+		service, mockRepo := setupTestService()
+		service.SetDelegationAuditor(&stubAuditor{err: errors.New("connection reset")})
+		ctx := WithActingAs(context.Background(), brand)
+		mockRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+
+		result, err := service.CreatePost(ctx, createTestCreatePostRequest(), createTestUserContext())
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("refuses delegated posts without an auditor", func(t *testing.T) {
+		service, mockRepo := setupTestService()
+		ctx := WithActingAs(context.Background(), brand)
+		mockRepo.On("WithTransaction", ctx, mock.Anything).Return(nil).Once()
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+
+		_, err := service.CreatePost(ctx, createTestCreatePostRequest(), createTestUserContext())
+
+		assert.ErrorIs(t, err, postsErrors.ErrServiceUnavailable)
+	})
+}
+
+// Test anonymous posts are refused outside communities that allow them
+func TestCreatePost_Anonymous_RequiresCommunitySetting(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+	community := uuid.Must(uuid.NewV4())
+
+	t.Run("main feed", func(t *testing.T) {
+		service.communities = &stubCommunities{member: true, anonymous: true}
+		req := createTestCreatePostRequest()
+		req.Anonymous = true
+
+		result, err := service.CreatePost(ctx, req, createTestUserContext())
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, postsErrors.ErrAnonymousDisabled)
+	})
+
+	t.Run("community without the setting", func(t *testing.T) {
+		service.communities = &stubCommunities{member: true}
+		req := createTestCreatePostRequest()
+		req.Anonymous = true
+		req.CommunityId = &community
+
+		result, err := service.CreatePost(ctx, req, createTestUserContext())
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, postsErrors.ErrAnonymousDisabled)
+	})
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+// Test anonymous posts keep the real owner but never show it
+func TestCreatePost_Anonymous_HidesAuthor(t *testing.T) {
+	service, mockRepo := setupTestService()
+	service.communities = &stubCommunities{member: true, anonymous: true}
+	ctx := context.Background()
+	user := createTestUserContext()
+	community := uuid.Must(uuid.NewV4())
+	req := createTestCreatePostRequest()
+	req.Anonymous = true
+	req.CommunityId = &community
+
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*models.Post")).Return(nil)
+
+	post, err := service.CreatePost(ctx, req, user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, user.UserID, post.OwnerUserId, "real owner kept for moderation")
+	assert.Equal(t, models.AnonymousAlias("test-secret", post.ObjectId, user.UserID), post.AnonymousAlias)
+	assert.True(t, strings.HasPrefix(post.URLKey, anonymousURLKeyName+"_"), "URL key %q must not carry the social name", post.URLKey)
+
+	post.CommentCounter = 1
+	response := service.ConvertPostToResponse(ctx, post)
+	assert.True(t, response.Anonymous)
+	assert.Empty(t, response.OwnerUserId)
+	assert.Equal(t, post.AnonymousAlias, response.OwnerDisplayName)
+	assert.Empty(t, response.OwnerAvatar)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestSearchPosts_ReusableOnlyFiltersByLicense(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+	reusable := mock.MatchedBy(func(f repository.PostFilter) bool { return f.ReusableOnly })
+	post := &models.Post{ObjectId: uuid.Must(uuid.NewV4()), Body: "photo", License: models.LicenseCCBY, CanonicalURL: "https://example.com/original"}
+
+	mockRepo.On("Find", ctx, reusable, 10, 0).Return([]*models.Post{post}, nil)
+	mockRepo.On("Count", ctx, reusable).Return(int64(1), nil)
+	mockRepo.On("ListCoauthors", ctx, []uuid.UUID{post.ObjectId}, models.CoauthorStatusAccepted).Return(map[uuid.UUID][]models.PostCoauthor{}, nil)
+
+	result, err := service.SearchPosts(ctx, "photo", &models.PostQueryFilter{Limit: 10, Page: 1, ReusableOnly: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Posts, 1)
+	assert.Equal(t, models.LicenseCCBY, result.Posts[0].License)
+	assert.Equal(t, "https://example.com/original", result.Posts[0].CanonicalURL)
+	mockRepo.AssertExpectations(t)
+}
+
 // Test CreatePost with nil request
 func TestCreatePost_NilRequest_ReturnsError(t *testing.T) {
 	service, _ := setupTestService()
@@ -968,6 +1131,7 @@ func TestQueryPosts_WithFilter_ReturnsResults(t *testing.T) {
 	// Note: Service now uses snake_case for sort fields (created_date)
 	mockRepo.On("Find", ctx, mock.AnythingOfType("repository.PostFilter"), 10, 0).Return(testPosts, nil)
 	mockRepo.On("Count", ctx, mock.AnythingOfType("repository.PostFilter")).Return(int64(2), nil)
+	mockRepo.On("ListCoauthors", ctx, mock.Anything, models.CoauthorStatusAccepted).Return(map[uuid.UUID][]models.PostCoauthor{}, nil)
 
 	// Execute
 	result, err := service.QueryPosts(ctx, filter)
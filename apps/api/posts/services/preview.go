@@ -0,0 +1,34 @@
+package services
+
+import (
+	"github.com/qolzam/telar/apps/api/posts/common"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// applyFeedPreviews sets bodyPreview and isTruncated on feed items and, when requested
+// or enabled by default, drops the full body of truncated items. It runs after the
+// cache so cached pages keep full bodies and every client choice is served from them.
+func (s *postService) applyFeedPreviews(filter *models.PostQueryFilter, posts []models.PostResponse) {
+	maxRunes := common.DefaultPreviewLength
+	truncate := false
+	if s.config != nil {
+		if s.config.Posts.PreviewLength > 0 {
+			maxRunes = s.config.Posts.PreviewLength
+		}
+		truncate = s.config.Posts.TruncateFeedBodies
+	}
+	if filter != nil && filter.TruncateBodies != nil {
+		truncate = *filter.TruncateBodies
+	}
+
+	for i := range posts {
+		// Locked posts already carry their teaser
+		if posts[i].Locked {
+			continue
+		}
+		posts[i].BodyPreview, posts[i].IsTruncated = common.BodyPreview(posts[i].Body, maxRunes)
+		if truncate && posts[i].IsTruncated {
+			posts[i].Body = ""
+		}
+	}
+}
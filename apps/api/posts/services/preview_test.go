@@ -0,0 +1,38 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+func TestApplyFeedPreviews(t *testing.T) {
+	long := "First paragraph of a long post.\n\nSecond paragraph."
+	newPosts := func() []models.PostResponse {
+		return []models.PostResponse{{Body: long}, {Body: "short"}}
+	}
+
+	svc := &postService{config: &platformconfig.Config{Posts: platformconfig.PostsConfig{PreviewLength: 280}}}
+	posts := newPosts()
+	svc.applyFeedPreviews(nil, posts)
+	assert.Equal(t, "First paragraph of a long post.", posts[0].BodyPreview)
+	assert.True(t, posts[0].IsTruncated)
+	assert.Equal(t, long, posts[0].Body)
+	assert.Equal(t, "short", posts[1].BodyPreview)
+	assert.False(t, posts[1].IsTruncated)
+
+	truncate := true
+	posts = newPosts()
+	svc.applyFeedPreviews(&models.PostQueryFilter{TruncateBodies: &truncate}, posts)
+	assert.Empty(t, posts[0].Body)
+	assert.Equal(t, "short", posts[1].Body)
+
+	svc.config.Posts.TruncateFeedBodies = true
+	keep := false
+	posts = newPosts()
+	svc.applyFeedPreviews(&models.PostQueryFilter{TruncateBodies: &keep}, posts)
+	assert.Equal(t, long, posts[0].Body)
+}
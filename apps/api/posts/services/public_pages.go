@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/posts/common"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// PostPageSubject keys the cached public page of the post with urlKey
+func PostPageSubject(urlKey string) string {
+	if urlKey == "" {
+		return ""
+	}
+	return "post:" + urlKey
+}
+
+// TagPageSubject keys the cached public feeds of a tag
+func TagPageSubject(tag string) string {
+	tag = common.NormalizeTag(tag)
+	if tag == "" {
+		return ""
+	}
+	return "tag:" + tag
+}
+
+// publicPageSubjects names the cached pages showing a post with urlKey and tags
+func publicPageSubjects(urlKey string, tags []string) []string {
+	subjects := []string{PostPageSubject(urlKey)}
+	for _, tag := range tags {
+		subjects = append(subjects, TagPageSubject(tag))
+	}
+	return subjects
+}
+
+// InvalidatePublicPages drops the cached anonymous pages showing the post: its page and
+// the feeds of its tags, as the event recorded them before the write and as they are now
+func (s *postService) InvalidatePublicPages(ctx context.Context, event sharedInterfaces.PostChangedEvent) error {
+	subjects := publicPageSubjects(event.URLKey, event.Tags)
+	post, err := s.repo.FindByID(ctx, event.PostId)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("failed to get post: %w", err)
+	}
+	if post != nil {
+		subjects = append(subjects, publicPageSubjects(post.URLKey, post.Tags)...)
+	}
+	httpcache.InvalidateSubjects(ctx, httpcache.ScopePosts, subjects...)
+	return nil
+}
+
+// dropPublicPages invalidates the post's cached pages on this instance when there is no
+// outbox whose consumers would do it on every instance
+func (s *postService) dropPublicPages(ctx context.Context, event sharedInterfaces.PostChangedEvent) {
+	if s.outbox != nil || s.config == nil || !s.config.HTTPCache.Enabled {
+		return
+	}
+	if err := s.InvalidatePublicPages(ctx, event); err != nil {
+		log.Warn("Failed to invalidate public pages of post %s: %v", event.PostId.String(), err)
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/internal/utils"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+// GetUnreadCounts returns, per feed, how many posts by other users were created after the
+// user's read marker. Feeds that were never marked read report zero so a first visit does
+// not show every post as new.
+func (s *postService) GetUnreadCounts(ctx context.Context, userID uuid.UUID, feedKeys []string) ([]models.FeedUnreadCount, error) {
+	refs := make([]models.FeedRef, 0, len(feedKeys))
+	keys := make([]string, 0, len(feedKeys))
+	for _, key := range feedKeys {
+		ref, err := models.ParseFeedKey(key)
+		if err != nil {
+			return nil, postsErrors.WrapValidationError(err, key)
+		}
+		refs = append(refs, ref)
+		keys = append(keys, ref.Key)
+	}
+
+	markers, err := s.repo.GetReadMarkers(ctx, userID, keys)
+	if err != nil {
+		return nil, postsErrors.WrapDatabaseError(err)
+	}
+
+	counts := make([]models.FeedUnreadCount, 0, len(refs))
+	for _, ref := range refs {
+		lastRead, ok := markers[ref.Key]
+		count := models.FeedUnreadCount{Feed: ref.Key, LastReadDate: lastRead}
+		if ok {
+			filter := feedRepoFilter(ref, userID)
+			after := lastRead + 1
+			filter.CreatedAfter = &after
+			filter.ExcludeOwnerUserID = &userID
+			count.UnreadCount, err = s.repo.Count(ctx, filter)
+			if err != nil {
+				return nil, postsErrors.WrapDatabaseError(err)
+			}
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
+// MarkFeedRead moves the user's read marker for a feed forward
+func (s *postService) MarkFeedRead(ctx context.Context, userID uuid.UUID, req *models.MarkFeedReadRequest) error {
+	if req == nil {
+		return postsErrors.ErrInvalidRequest
+	}
+	ref, err := models.ParseFeedKey(req.Feed)
+	if err != nil {
+		return postsErrors.WrapValidationError(err, req.Feed)
+	}
+
+	lastRead := req.LastReadDate
+	if lastRead <= 0 {
+		lastRead = utils.UTCNowUnix()
+	}
+
+	if err := s.repo.SaveReadMarker(ctx, userID, ref.Key, lastRead); err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	return nil
+}
+
+// markNewPosts sets isNew on feed items created after the viewer's read marker.
+// Failures leave every item unflagged; the feed itself must still load.
+func (s *postService) markNewPosts(ctx context.Context, filter *models.PostQueryFilter, posts []models.PostResponse) {
+	userCtx, ok := ctx.Value(types.UserCtxName).(types.UserContext)
+	if !ok || len(posts) == 0 {
+		return
+	}
+	feedKey := models.FeedKeyForFilter(filter)
+	if feedKey == "" {
+		return
+	}
+
+	markers, err := s.repo.GetReadMarkers(ctx, userCtx.UserID, []string{feedKey})
+	if err != nil {
+		return
+	}
+	lastRead, ok := markers[feedKey]
+	if !ok {
+		return
+	}
+
+	viewerID := userCtx.UserID.String()
+	for i := range posts {
+		posts[i].IsNew = posts[i].CreatedDate > lastRead && posts[i].OwnerUserId != viewerID
+	}
+}
+
+// feedRepoFilter builds the repository filter that selects a feed's posts as the reader sees them.
+// Unread counts leave the reader's own posts out, so only public posts are counted: other users'
+// OnlyMe and Circles posts must not show up as a number the reader can watch change.
+func feedRepoFilter(ref models.FeedRef, readerID uuid.UUID) repository.PostFilter {
+	notDeleted := false
+	public := "Public"
+	filter := repository.PostFilter{Deleted: &notDeleted, Permission: &public}
+	if ref.Tag != "" {
+		filter.Tags = []string{ref.Tag}
+	}
+	if ref.OwnerUserId != nil {
+		filter.OwnerUserID = ref.OwnerUserId
+		filter.IncludeCoauthored = true
+	}
+	if ref.Following {
+		filter.FollowedBy = &readerID
+	}
+	return filter
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+func TestGetUnreadCounts(t *testing.T) {
+	repo := new(MockPostRepository)
+	userID := uuid.Must(uuid.NewV4())
+	svc := &postService{repo: repo}
+
+	repo.On("GetReadMarkers", mock.Anything, userID, []string{"home", "tag:go"}).
+		Return(map[string]int64{"home": 100}, nil)
+	repo.On("Count", mock.Anything, mock.MatchedBy(func(f repository.PostFilter) bool {
+		return f.CreatedAfter != nil && *f.CreatedAfter == 101 &&
+			f.ExcludeOwnerUserID != nil && *f.ExcludeOwnerUserID == userID && len(f.Tags) == 0
+	})).Return(int64(4), nil)
+
+	counts, err := svc.GetUnreadCounts(context.Background(), userID, []string{"home", "tag:go"})
+	require.NoError(t, err)
+	assert.Equal(t, []models.FeedUnreadCount{
+		{Feed: "home", UnreadCount: 4, LastReadDate: 100},
+		{Feed: "tag:go", UnreadCount: 0, LastReadDate: 0},
+	}, counts)
+	repo.AssertExpectations(t)
+}
+
+func TestGetUnreadCounts_SkipsNonPublicPosts(t *testing.T) {
+	repo := new(MockPostRepository)
+	userID := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4())
+	svc := &postService{repo: repo}
+
+	// The feed holds a public and a private post by another user, both after the marker
+	stored := []models.Post{
+		{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: other, CreatedDate: 150, Permission: "Public"},
+		{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: other, CreatedDate: 150, Permission: "OnlyMe"},
+	}
+	repo.On("GetReadMarkers", mock.Anything, userID, []string{"home"}).Return(map[string]int64{"home": 100}, nil)
+	var counted int64
+	repo.On("Count", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		f := args.Get(1).(repository.PostFilter)
+		for _, p := range stored {
+			if f.Permission == nil || p.Permission == *f.Permission {
+				counted++
+			}
+		}
+	}).Return(int64(0), nil)
+
+	counts, err := svc.GetUnreadCounts(context.Background(), userID, []string{"home"})
+	require.NoError(t, err)
+	require.Len(t, counts, 1)
+	assert.Equal(t, int64(1), counted, "the private post must not be counted")
+}
+
+func TestMarkFeedRead_DefaultsToNow(t *testing.T) {
+	repo := new(MockPostRepository)
+	userID := uuid.Must(uuid.NewV4())
+	svc := &postService{repo: repo}
+
+	repo.On("SaveReadMarker", mock.Anything, userID, "tag:go", mock.MatchedBy(func(v int64) bool { return v > 0 })).Return(nil)
+
+	require.NoError(t, svc.MarkFeedRead(context.Background(), userID, &models.MarkFeedReadRequest{Feed: "tag:go"}))
+	assert.Error(t, svc.MarkFeedRead(context.Background(), userID, &models.MarkFeedReadRequest{Feed: "bogus"}))
+	repo.AssertExpectations(t)
+}
+
+func TestMarkNewPosts(t *testing.T) {
+	repo := new(MockPostRepository)
+	viewer := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4()).String()
+	svc := &postService{repo: repo}
+
+	repo.On("GetReadMarkers", mock.Anything, viewer, []string{"home"}).Return(map[string]int64{"home": 100}, nil)
+
+	posts := []models.PostResponse{
+		{OwnerUserId: other, CreatedDate: 150},
+		{OwnerUserId: viewer.String(), CreatedDate: 150},
+		{OwnerUserId: other, CreatedDate: 100},
+	}
+	ctx := context.WithValue(context.Background(), types.UserCtxName, types.UserContext{UserID: viewer})
+	svc.markNewPosts(ctx, &models.PostQueryFilter{}, posts)
+
+	assert.True(t, posts[0].IsNew)
+	assert.False(t, posts[1].IsNew, "own posts are never new")
+	assert.False(t, posts[2].IsNew)
+
+	// Searches are not feeds and never look up markers
+	svc.markNewPosts(ctx, &models.PostQueryFilter{Search: "x"}, posts)
+	repo.AssertNumberOfCalls(t, "GetReadMarkers", 1)
+}
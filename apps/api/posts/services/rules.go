@@ -0,0 +1,31 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetRulesChecker requires users to acknowledge a community's rules before they post
+// in it; without a checker nobody is asked to
+func (s *postService) SetRulesChecker(checker sharedInterfaces.RulesChecker) {
+	s.rules = checker
+}
+
+// checkRules lets admins, and users who acknowledged the community's rules, post in it
+func (s *postService) checkRules(ctx context.Context, communityID uuid.UUID, user *types.UserContext) error {
+	if s.rules == nil || user.SystemRole == "admin" {
+		return nil
+	}
+	acknowledged, err := s.rules.HasAcknowledgedRules(ctx, communityID, user.UserID)
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if !acknowledged {
+		return postsErrors.ErrRulesNotAcknowledged
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/sanitize"
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// sanitizeCreateRequest cleans the body, tags and album title of a new post. req must
+// be the service's own copy of the caller's request.
+func sanitizeCreateRequest(req *models.CreatePostRequest) error {
+	body, err := sanitizeBody(req.Body)
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	req.Tags = sanitizeTags(req.Tags)
+	req.Album.Title = sanitize.Line(req.Album.Title)
+	return nil
+}
+
+// sanitizeUpdateRequest returns a copy of req with the body, tags and album title it
+// changes cleaned
+func sanitizeUpdateRequest(req *models.UpdatePostRequest) (*models.UpdatePostRequest, error) {
+	sanitized := *req
+	if req.Body != nil {
+		body, err := sanitizeBody(*req.Body)
+		if err != nil {
+			return nil, err
+		}
+		sanitized.Body = &body
+	}
+	if req.Tags != nil {
+		tags := sanitizeTags(*req.Tags)
+		sanitized.Tags = &tags
+	}
+	if req.Album != nil {
+		album := *req.Album
+		album.Title = sanitize.Line(album.Title)
+		sanitized.Album = &album
+	}
+	return &sanitized, nil
+}
+
+// sanitizeBody rejects a body that was nothing but whitespace and invisible characters;
+// an empty body is left to the handler's validation
+func sanitizeBody(body string) (string, error) {
+	clean := sanitize.Text(body)
+	if clean == "" && body != "" {
+		var v validate.Validator
+		v.Add("body", "cannot be empty")
+		return "", fmt.Errorf("%w: %w", postsErrors.ErrValidationFailed, v.Err())
+	}
+	return clean, nil
+}
+
+// sanitizeTags cleans each tag into a new slice, dropping tags left empty
+func sanitizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+	clean := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = sanitize.Line(tag); tag != "" {
+			clean = append(clean, tag)
+		}
+	}
+	return clean
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+func TestSanitizeCreateRequest(t *testing.T) {
+	t.Run("cleans the body, tags and album title", func(t *testing.T) {
+		tags := []string{" go\u200B ", "\u202E", "\uFF47\uFF4F\uFF4C\uFF41\uFF4E\uFF47"}
+		req := &models.CreatePostRequest{
+			Body:  "hello\u202Eworld\n\n\n\nbye ",
+			Tags:  tags,
+			Album: models.Album{Title: " Summer\n2026 "},
+		}
+
+		require.NoError(t, sanitizeCreateRequest(req))
+		assert.Equal(t, "helloworld\n\nbye", req.Body)
+		assert.Equal(t, []string{"go", "golang"}, req.Tags)
+		assert.Equal(t, "Summer 2026", req.Album.Title)
+		assert.Equal(t, " go\u200B ", tags[0], "the caller's tags are not changed")
+	})
+
+	t.Run("rejects a body of invisible characters", func(t *testing.T) {
+		err := sanitizeCreateRequest(&models.CreatePostRequest{Body: "\u200B\u2060 \n"})
+		require.ErrorIs(t, err, postsErrors.ErrValidationFailed)
+		fields, ok := validate.Fields(err)
+		require.True(t, ok)
+		assert.Equal(t, "body", fields[0].Field)
+	})
+}
+
+func TestSanitizeUpdateRequest(t *testing.T) {
+	body := "edited\u200B text"
+	album := &models.Album{Title: "\u2066Trip\u2069"}
+	req := &models.UpdatePostRequest{Body: &body, Album: album}
+
+	sanitized, err := sanitizeUpdateRequest(req)
+	require.NoError(t, err)
+	assert.Equal(t, "edited text", *sanitized.Body)
+	assert.Equal(t, "Trip", sanitized.Album.Title)
+	assert.Nil(t, sanitized.Tags, "fields an edit leaves unchanged stay nil")
+	assert.Equal(t, "edited\u200B text", body, "the caller's request is not changed")
+	assert.Equal(t, "\u2066Trip\u2069", album.Title)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetContentScreener has new posts checked for abuse in the background; those that look
+// abusive are reported to moderators. Without a screener posts are only reported by users.
+func (s *postService) SetContentScreener(screener sharedInterfaces.ContentScreener) {
+	s.screener = screener
+}
+
+// screenContent hands a new post's text to the screener. Anonymous posts are screened
+// under their real author so moderators can act on them.
+func (s *postService) screenContent(ctx context.Context, post *models.Post) {
+	if s.screener == nil || strings.TrimSpace(post.Body) == "" {
+		return
+	}
+	s.screener.ScreenContent(ctx, sharedInterfaces.ScreenedContent{
+		TargetType: sharedInterfaces.ScreenedPost,
+		TargetID:   post.ObjectId,
+		AuthorID:   post.OwnerUserId,
+		Text:       post.Body,
+	})
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+)
+
+// FullTextSearch returns a page of posts matching the query, best match first, each with
+// a highlighted excerpt. Pass the response's NextCursor back as query.After for the next page.
+func (s *postService) FullTextSearch(ctx context.Context, query models.PostSearchQuery) (*models.PostSearchResponse, error) {
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return &models.PostSearchResponse{Results: []models.PostSearchResult{}}, nil
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+
+	hits, hasNext, err := s.repo.FullTextSearch(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search posts: %w", err)
+	}
+	if len(hits) == 0 {
+		return &models.PostSearchResponse{Results: []models.PostSearchResult{}}, nil
+	}
+
+	// Preload comment counts in bulk to match feed data
+	commentCounts := map[uuid.UUID]int64{}
+	postIDs := make([]uuid.UUID, len(hits))
+	for i, hit := range hits {
+		postIDs[i] = hit.Post.ObjectId
+	}
+	if s.commentRepo != nil {
+		if counts, err := s.commentRepo.CountByPostIDs(ctx, postIDs); err == nil {
+			commentCounts = counts
+		}
+	}
+
+	// Preload vote state in bulk when user is authenticated
+	voteMap := map[uuid.UUID]int{}
+	if s.voteRepo != nil {
+		if userCtx, ok := ctx.Value(types.UserCtxName).(types.UserContext); ok {
+			if votes, err := s.voteRepo.GetVotesForPosts(ctx, postIDs, userCtx.UserID); err == nil {
+				voteMap = votes
+			}
+		}
+	}
+
+	responses := make([]models.PostResponse, len(hits))
+	ctxWithoutUser := context.WithValue(ctx, types.UserCtxName, (*types.UserContext)(nil))
+	for i, hit := range hits {
+		if count, ok := commentCounts[hit.Post.ObjectId]; ok {
+			hit.Post.CommentCounter = count
+		}
+		responses[i] = s.ConvertPostToResponse(ctxWithoutUser, hit.Post)
+		if v, ok := voteMap[hit.Post.ObjectId]; ok {
+			responses[i].VoteType = v
+		}
+	}
+	s.ApplySupporterAccess(ctx, responses)
+
+	muted := s.mutedFor(ctx)
+	results := make([]models.PostSearchResult, 0, len(hits))
+	for i, hit := range hits {
+		if muted != nil && muted(hit.Post.OwnerUserId.String(), postKeywordText(hit.Post.Body, hit.Post.Tags)) {
+			continue
+		}
+		result := models.PostSearchResult{PostResponse: responses[i], Rank: hit.Rank}
+		// Locked supporter-only posts must not leak their body through the excerpt
+		if !responses[i].Locked {
+			result.Highlight = models.HighlightHTML(hit.Highlight)
+		}
+		results = append(results, result)
+	}
+
+	response := &models.PostSearchResponse{Results: results, HasNext: hasNext}
+	if hasNext {
+		last := hits[len(hits)-1]
+		response.NextCursor = models.EncodeSearchCursor(models.SearchCursor{Rank: last.Rank, ID: last.Post.ObjectId.String()})
+	}
+	return response, nil
+}
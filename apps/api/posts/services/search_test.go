@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+func TestFullTextSearch(t *testing.T) {
+	ctx := context.Background()
+	first := &models.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: uuid.Must(uuid.NewV4()), Body: "Error handling in <Go>"}
+	second := &models.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: uuid.Must(uuid.NewV4()), Body: "More on error handling"}
+
+	repo := new(MockPostRepository)
+	svc := &postService{repo: repo, config: &platformconfig.Config{}}
+
+	t.Run("clamps the limit and returns a cursor after the last hit", func(t *testing.T) {
+		query := models.PostSearchQuery{Query: `"error handling"`, Tags: []string{"go"}, Limit: maxSearchLimit}
+		repo.On("FullTextSearch", ctx, query).Return([]repository.SearchHit{
+			{Post: first, Rank: 0.9, Highlight: models.HighlightStart + "Error" + models.HighlightStop + " handling in <Go>"},
+			{Post: second, Rank: 0.4, Highlight: "More on " + models.HighlightStart + "error" + models.HighlightStop},
+		}, true, nil).Once()
+
+		resp, err := svc.FullTextSearch(ctx, models.PostSearchQuery{Query: `  "error handling" `, Tags: []string{"go"}, Limit: 500})
+		require.NoError(t, err)
+		require.Len(t, resp.Results, 2)
+		assert.Equal(t, first.ObjectId.String(), resp.Results[0].ObjectId)
+		assert.Equal(t, "<mark>Error</mark> handling in &lt;Go&gt;", resp.Results[0].Highlight)
+		assert.True(t, resp.HasNext)
+
+		cursor, err := models.DecodeSearchCursor(resp.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, models.SearchCursor{Rank: 0.4, ID: second.ObjectId.String()}, *cursor)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("blank query skips the database", func(t *testing.T) {
+		resp, err := svc.FullTextSearch(ctx, models.PostSearchQuery{Query: "   "})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Results)
+		assert.False(t, resp.HasNext)
+		repo.AssertNumberOfCalls(t, "FullTextSearch", 1)
+	})
+}
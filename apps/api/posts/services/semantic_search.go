@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+// PostSearcher searches the posts ingested into the AI engine by meaning; the AI
+// engine client implements it
+type PostSearcher interface {
+	SearchPosts(ctx context.Context, query aiengine.PostSearchQuery) ([]aiengine.PostSearchHit, error)
+}
+
+// SemanticSearch returns the posts closest in meaning to the query, best match first.
+// The AI engine finds the post IDs; posts deleted, archived, expired or not public since
+// they were ingested are dropped, as are posts the caller muted. Searches count against
+// the caller's AI quota; once it or the AI engine's token budget is spent the search
+// falls back to no results, flagged QuotaExceeded.
+func (s *postService) SemanticSearch(ctx context.Context, query models.SemanticSearchQuery) (*models.SemanticSearchResponse, error) {
+	if s.searcher == nil {
+		return nil, postsErrors.ErrSemanticSearchUnavailable
+	}
+	query.Query = strings.TrimSpace(query.Query)
+	if query.Query == "" {
+		return &models.SemanticSearchResponse{Results: []models.SemanticSearchResult{}}, nil
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSearchLimit
+	}
+	if query.Limit > maxSearchLimit {
+		query.Limit = maxSearchLimit
+	}
+
+	search := aiengine.PostSearchQuery{
+		Query:       query.Query,
+		Limit:       query.Limit,
+		CommunityID: query.CommunityID,
+		From:        query.Range.Since,
+		To:          query.Range.Until,
+	}
+	if query.AuthorID != nil {
+		search.AuthorID = query.AuthorID.String()
+	}
+	var viewerID uuid.UUID
+	if user, ok := ctx.Value(types.UserCtxName).(types.UserContext); ok {
+		viewerID = user.UserID
+	}
+	searchCtx := ctx
+	if viewerID != uuid.Nil {
+		searchCtx = aiengine.WithUser(ctx, viewerID.String())
+	}
+	hits, err := s.searcher.SearchPosts(searchCtx, search)
+	if aiengine.OverQuota(err) {
+		return &models.SemanticSearchResponse{Results: []models.SemanticSearchResult{}, QuotaExceeded: true}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: semantic search failed: %v", postsErrors.ErrServiceUnavailable, err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(hits))
+	for _, hit := range hits {
+		if id, err := uuid.FromString(hit.PostID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	posts, err := s.GetPostsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*models.Post, len(posts))
+	for _, post := range posts {
+		byID[post.ObjectId.String()] = post
+	}
+
+	now := time.Now().UnixMilli()
+	muted := s.mutedFor(ctx)
+
+	matched := make([]aiengine.PostSearchHit, 0, len(hits))
+	responses := make([]models.PostResponse, 0, len(hits))
+	sharedCtx := withoutViewer(ctx)
+	for _, hit := range hits {
+		post, ok := byID[hit.PostID]
+		if !ok || !searchable(post, viewerID, now) {
+			continue
+		}
+		// Matching an author must not reveal which anonymous posts are theirs
+		if query.AuthorID != nil && post.Anonymous {
+			continue
+		}
+		if muted != nil && muted(post.OwnerUserId.String(), postKeywordText(post.Body, post.Tags)) {
+			continue
+		}
+		matched = append(matched, hit)
+		responses = append(responses, s.ConvertPostToResponse(sharedCtx, post))
+	}
+	s.enrichPostsForViewer(ctx, responses)
+	s.ApplySupporterAccess(ctx, responses)
+
+	results := make([]models.SemanticSearchResult, len(responses))
+	for i, response := range responses {
+		results[i] = models.SemanticSearchResult{PostResponse: response, Score: matched[i].Score}
+		// Locked supporter-only posts must not leak their body through the snippet
+		if !response.Locked {
+			results[i].Snippet = matched[i].Snippet
+		}
+	}
+	return &models.SemanticSearchResponse{Results: results}, nil
+}
+
+// searchable reports whether a post found in the AI engine may still be shown: it must
+// be live and public, or the viewer's own
+func searchable(post *models.Post, viewerID uuid.UUID, now int64) bool {
+	if post.Deleted || post.Archived || (post.VisibleUntil > 0 && post.VisibleUntil <= now) {
+		return false
+	}
+	if post.Permission != "" && post.Permission != "Public" {
+		return viewerID != uuid.Nil && post.OwnerUserId == viewerID
+	}
+	return true
+}
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+type stubSearcher struct {
+	query aiengine.PostSearchQuery
+	hits  []aiengine.PostSearchHit
+	err   error
+}
+
+func (s *stubSearcher) SearchPosts(ctx context.Context, query aiengine.PostSearchQuery) ([]aiengine.PostSearchHit, error) {
+	s.query = query
+	return s.hits, s.err
+}
+
+func TestSemanticSearch(t *testing.T) {
+	t.Run("is unavailable without the AI engine", func(t *testing.T) {
+		service, _ := setupTestService()
+		_, err := service.SemanticSearch(context.Background(), models.SemanticSearchQuery{Query: "go"})
+		assert.ErrorIs(t, err, postsErrors.ErrSemanticSearchUnavailable)
+	})
+
+	t.Run("passes the filters to the AI engine", func(t *testing.T) {
+		service, repo := setupTestService()
+		searcher := &stubSearcher{}
+		service.searcher = searcher
+		repo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.Post{}, nil).Maybe()
+		authorID := uuid.Must(uuid.NewV4())
+
+		_, err := service.SemanticSearch(context.Background(), models.SemanticSearchQuery{
+			Query:       " cooking ",
+			Limit:       500,
+			CommunityID: "kitchen",
+			AuthorID:    &authorID,
+			Range:       models.TimeRange{Since: 1000, Until: 2000},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, aiengine.PostSearchQuery{
+			Query:       "cooking",
+			Limit:       maxSearchLimit,
+			CommunityID: "kitchen",
+			AuthorID:    authorID.String(),
+			From:        1000,
+			To:          2000,
+		}, searcher.query)
+	})
+
+	t.Run("keeps the AI engine's order and drops posts that may not be shown", func(t *testing.T) {
+		service, repo := setupTestService()
+		ctx := context.Background()
+		viewer := createTestPost()
+
+		best, second := createTestPost(), createTestPost()
+		private := createTestPost()
+		private.Permission = "OnlyMe"
+		own := createTestPost()
+		own.Permission = "OnlyMe"
+		own.OwnerUserId = viewer.OwnerUserId
+		expired := createTestPost()
+		expired.VisibleUntil = time.Now().Add(-time.Hour).UnixMilli()
+		archived := createTestPost()
+		archived.Archived = true
+		gone := uuid.Must(uuid.NewV4())
+
+		hit := func(id uuid.UUID, score float64) aiengine.PostSearchHit {
+			return aiengine.PostSearchHit{PostID: id.String(), Snippet: "snippet " + id.String(), Score: score}
+		}
+		service.searcher = &stubSearcher{hits: []aiengine.PostSearchHit{
+			hit(best.ObjectId, 0.9), hit(private.ObjectId, 0.85), hit(gone, 0.8), hit(own.ObjectId, 0.75),
+			hit(expired.ObjectId, 0.7), hit(archived.ObjectId, 0.65), hit(second.ObjectId, 0.6),
+			{PostID: "not-a-uuid", Score: 0.5},
+		}}
+		repo.On("GetByIDs", mock.Anything, mock.Anything).
+			Return([]*models.Post{second, archived, own, private, expired, best}, nil)
+
+		viewerCtx := context.WithValue(ctx, types.UserCtxName, types.UserContext{UserID: viewer.OwnerUserId})
+		result, err := service.SemanticSearch(viewerCtx, models.SemanticSearchQuery{Query: "go"})
+		require.NoError(t, err)
+
+		var ids []string
+		for _, r := range result.Results {
+			ids = append(ids, r.ObjectId)
+		}
+		assert.Equal(t, []string{best.ObjectId.String(), own.ObjectId.String(), second.ObjectId.String()}, ids)
+		assert.Equal(t, 0.9, result.Results[0].Score)
+		assert.Equal(t, "snippet "+best.ObjectId.String(), result.Results[0].Snippet)
+	})
+
+	t.Run("hides anonymous posts when searching by author", func(t *testing.T) {
+		service, repo := setupTestService()
+		post := createTestPost()
+		post.Anonymous = true
+		service.searcher = &stubSearcher{hits: []aiengine.PostSearchHit{{PostID: post.ObjectId.String()}}}
+		repo.On("GetByIDs", mock.Anything, mock.Anything).Return([]*models.Post{post}, nil)
+
+		result, err := service.SemanticSearch(context.Background(), models.SemanticSearchQuery{Query: "go", AuthorID: &post.OwnerUserId})
+		require.NoError(t, err)
+		assert.Empty(t, result.Results)
+	})
+
+	t.Run("reports the AI engine being down as unavailable", func(t *testing.T) {
+		service, _ := setupTestService()
+		service.searcher = &stubSearcher{err: errors.New("connection refused")}
+		_, err := service.SemanticSearch(context.Background(), models.SemanticSearchQuery{Query: "go"})
+		assert.ErrorIs(t, err, postsErrors.ErrServiceUnavailable)
+	})
+
+	t.Run("answers with no results once the AI budget is spent", func(t *testing.T) {
+		service, _ := setupTestService()
+		service.searcher = &stubSearcher{err: fmt.Errorf("%w: 100 of 100 tokens used", aiengine.ErrBudgetExceeded)}
+		response, err := service.SemanticSearch(context.Background(), models.SemanticSearchQuery{Query: "go"})
+		require.NoError(t, err)
+		assert.True(t, response.QuotaExceeded)
+		assert.NotNil(t, response.Results)
+		assert.Empty(t, response.Results)
+	})
+
+	t.Run("answers with no results once the caller's AI quota is spent", func(t *testing.T) {
+		service, _ := setupTestService()
+		service.searcher = &stubSearcher{err: aiengine.ErrQuotaExceeded}
+		response, err := service.SemanticSearch(context.Background(), models.SemanticSearchQuery{Query: "go"})
+		require.NoError(t, err)
+		assert.True(t, response.QuotaExceeded)
+		assert.Empty(t, response.Results)
+	})
+}
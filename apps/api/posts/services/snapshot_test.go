@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+func TestQueryPostsWithCursor_SnapshotCarriedAcrossPages(t *testing.T) {
+	repo := new(MockPostRepository)
+	svc := &postService{repo: repo}
+
+	first, second := createTestPost(), createTestPost()
+	first.CommentCounter, second.CommentCounter = 1, 1
+
+	var boundaries []int64
+	repo.On("FindWithCursor", mock.Anything, mock.MatchedBy(func(f repository.PostFilter) bool {
+		require.NotNil(t, f.CreatedBefore)
+		boundaries = append(boundaries, *f.CreatedBefore)
+		return true
+	}), mock.Anything, "createdDate", "desc", 1).Return([]*models.Post{first}, true, nil).Once()
+	repo.On("FindWithCursor", mock.Anything, mock.Anything, mock.Anything, "createdDate", "desc", 1).Return([]*models.Post{second}, false, nil).Once()
+	repo.On("ListCoauthors", mock.Anything, mock.Anything, models.CoauthorStatusAccepted).Return(map[uuid.UUID][]models.PostCoauthor{}, nil)
+
+	page1, err := svc.QueryPostsWithCursor(context.Background(), &models.PostQueryFilter{Limit: 1})
+	require.NoError(t, err)
+	require.NotEmpty(t, page1.Snapshot)
+	require.NotEmpty(t, page1.NextCursor)
+
+	cursor, err := models.DecodeCursor(page1.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, boundaries[0], cursor.Snapshot, "next cursor should carry the snapshot boundary")
+
+	page2, err := svc.QueryPostsWithCursor(context.Background(), &models.PostQueryFilter{Limit: 1, Cursor: page1.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, boundaries, 2)
+	assert.Equal(t, boundaries[0], boundaries[1], "later pages must reuse the first page boundary")
+	snap1, err := models.DecodeSnapshot(page1.Snapshot, time.Now())
+	require.NoError(t, err)
+	snap2, err := models.DecodeSnapshot(page2.Snapshot, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, snap1.CreatedBefore, snap2.CreatedBefore)
+}
+
+func TestQueryPostsWithCursor_InvalidSnapshot(t *testing.T) {
+	svc := &postService{repo: new(MockPostRepository)}
+
+	expired, err := models.EncodeSnapshot(models.NewSnapshot(1, time.Now().Add(-2*models.SnapshotMaxAge)))
+	require.NoError(t, err)
+
+	for _, token := range []string{"not-base64!", expired} {
+		_, err := svc.QueryPostsWithCursor(context.Background(), &models.PostQueryFilter{Limit: 1, Snapshot: token})
+		assert.Error(t, err, token)
+	}
+}
+
+func TestQueryPostsWithCursor_ExpiredCursorSnapshot(t *testing.T) {
+	svc := &postService{repo: new(MockPostRepository)}
+
+	stale := models.NewSnapshot(1, time.Now().Add(-2*models.SnapshotMaxAge))
+	cursor, err := models.CreateSnapshotCursorFromPost(createTestPost(), "createdDate", "desc", stale)
+	require.NoError(t, err)
+
+	_, err = svc.QueryPostsWithCursor(context.Background(), &models.PostQueryFilter{Limit: 1, Cursor: cursor})
+	assert.Error(t, err, "a cursor cannot keep a snapshot alive past its max age")
+}
+
+func TestQueryPostsWithCursor_FollowingFeedStable(t *testing.T) {
+	repo := new(MockPostRepository)
+	svc := &postService{repo: repo}
+	readerID := uuid.Must(uuid.NewV4())
+
+	first, second := createTestPost(), createTestPost()
+	var boundaries []int64
+	following := mock.MatchedBy(func(f repository.PostFilter) bool {
+		return f.FollowedBy != nil && *f.FollowedBy == readerID && f.CreatedBefore != nil
+	})
+	record := func(args mock.Arguments) {
+		boundaries = append(boundaries, *args.Get(1).(repository.PostFilter).CreatedBefore)
+	}
+	repo.On("FindWithCursor", mock.Anything, following, mock.Anything, "createdDate", "desc", 1).Run(record).Return([]*models.Post{first}, true, nil).Once()
+	repo.On("FindWithCursor", mock.Anything, following, mock.Anything, "createdDate", "desc", 1).Run(record).Return([]*models.Post{second}, false, nil).Once()
+	repo.On("ListCoauthors", mock.Anything, mock.Anything, models.CoauthorStatusAccepted).Return(map[uuid.UUID][]models.PostCoauthor{}, nil)
+
+	page1, err := svc.QueryPostsWithCursor(context.Background(), &models.PostQueryFilter{Limit: 1, FollowedBy: &readerID})
+	require.NoError(t, err)
+	require.NotEmpty(t, page1.NextCursor)
+
+	page2, err := svc.QueryPostsWithCursor(context.Background(), &models.PostQueryFilter{Limit: 1, FollowedBy: &readerID, Cursor: page1.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, page2.Posts, 1)
+	assert.Equal(t, second.ObjectId.String(), page2.Posts[0].ObjectId)
+	require.Len(t, boundaries, 2)
+	assert.Equal(t, boundaries[0], boundaries[1], "the following feed keeps its first page boundary")
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/posts/common"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetSupporterChecker enables supporter-only posts; without a checker they cannot be
+// published and existing ones are shown to everyone but their authors as teasers.
+func (s *postService) SetSupporterChecker(checker sharedInterfaces.SupporterChecker) {
+	s.supporters = checker
+}
+
+// checkSupporterOnly lets a post become supporter-only once its owner offers a tier
+// supporters can join
+func (s *postService) checkSupporterOnly(ctx context.Context, ownerID uuid.UUID) error {
+	if s.supporters == nil {
+		return postsErrors.ErrSupportersUnavailable
+	}
+	offers, err := s.supporters.OffersTiers(ctx, ownerID)
+	if err != nil {
+		return postsErrors.WrapDatabaseError(err)
+	}
+	if !offers {
+		return postsErrors.ErrSupportersUnavailable
+	}
+	return nil
+}
+
+// ApplySupporterAccess locks the supporter-only posts the viewer may not read: their
+// owner, accepted co-authors and the owner's supporters see them in full. Access is
+// checked in one batch per page and fails closed. Cached pages keep full bodies, so it
+// runs per request after the cache.
+func (s *postService) ApplySupporterAccess(ctx context.Context, posts []models.PostResponse) {
+	var viewerID uuid.UUID
+	if userCtx, ok := ctx.Value(types.UserCtxName).(types.UserContext); ok {
+		viewerID = userCtx.UserID
+	}
+
+	var gated []int
+	var postIDs []uuid.UUID
+	for i := range posts {
+		if !posts[i].SupporterOnly || posts[i].Locked {
+			continue
+		}
+		if viewerID != uuid.Nil && posts[i].OwnerUserId == viewerID.String() {
+			continue
+		}
+		gated = append(gated, i)
+		if id, err := uuid.FromString(posts[i].ObjectId); err == nil {
+			postIDs = append(postIDs, id)
+		}
+	}
+	if len(gated) == 0 {
+		return
+	}
+
+	var supported, coauthored map[uuid.UUID]bool
+	if viewerID != uuid.Nil {
+		supported = s.supportedOwners(ctx, viewerID, posts, gated)
+		coauthored = s.coauthoredBy(ctx, viewerID, postIDs)
+	}
+
+	for _, i := range gated {
+		ownerID, _ := uuid.FromString(posts[i].OwnerUserId)
+		postID, _ := uuid.FromString(posts[i].ObjectId)
+		if supported[ownerID] || coauthored[postID] {
+			continue
+		}
+		s.lockPost(&posts[i])
+	}
+}
+
+// supportedOwners returns the owners of the gated posts the viewer supports
+func (s *postService) supportedOwners(ctx context.Context, viewerID uuid.UUID, posts []models.PostResponse, gated []int) map[uuid.UUID]bool {
+	if s.supporters == nil {
+		return map[uuid.UUID]bool{}
+	}
+	seen := make(map[uuid.UUID]bool)
+	var ownerIDs []uuid.UUID
+	for _, i := range gated {
+		if id, err := uuid.FromString(posts[i].OwnerUserId); err == nil && !seen[id] {
+			seen[id] = true
+			ownerIDs = append(ownerIDs, id)
+		}
+	}
+	supported, err := s.supporters.SupportedCreators(ctx, viewerID, ownerIDs)
+	if err != nil {
+		log.Warn("Failed to check supporter access for %d creators: %v", len(ownerIDs), err)
+		return map[uuid.UUID]bool{}
+	}
+	return supported
+}
+
+// coauthoredBy returns the posts among postIDs the viewer is an accepted co-author of
+func (s *postService) coauthoredBy(ctx context.Context, viewerID uuid.UUID, postIDs []uuid.UUID) map[uuid.UUID]bool {
+	result := make(map[uuid.UUID]bool)
+	if len(postIDs) == 0 {
+		return result
+	}
+	coauthors, err := s.repo.ListCoauthors(ctx, postIDs, models.CoauthorStatusAccepted)
+	if err != nil {
+		log.Warn("Failed to load co-authors for supporter access: %v", err)
+		return result
+	}
+	for postID, list := range coauthors {
+		for _, coauthor := range list {
+			if coauthor.UserId == viewerID {
+				result[postID] = true
+			}
+		}
+	}
+	return result
+}
+
+// lockPost replaces a post's content with its teaser
+func (s *postService) lockPost(post *models.PostResponse) {
+	teaserLength := common.DefaultPreviewLength
+	if s.config != nil && s.config.Supporters.TeaserLength > 0 {
+		teaserLength = s.config.Supporters.TeaserLength
+	}
+	teaser, truncated := common.BodyPreview(post.Body, teaserLength)
+
+	post.Locked = true
+	post.Body = teaser
+	post.BodyPreview = teaser
+	post.IsTruncated = truncated
+	post.Image = ""
+	post.ImageFullPath = ""
+	post.Video = ""
+	post.Album = nil
+	post.MediaText = ""
+	post.LatestComments = nil
+}
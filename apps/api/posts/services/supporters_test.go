@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+type stubSupporters struct {
+	supported map[uuid.UUID]bool
+	offers    bool
+	err       error
+	calls     int
+}
+
+func (s *stubSupporters) SupportedCreators(ctx context.Context, supporterID uuid.UUID, creatorIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	s.calls++
+	return s.supported, s.err
+}
+
+func (s *stubSupporters) OffersTiers(ctx context.Context, creatorID uuid.UUID) (bool, error) {
+	return s.offers, s.err
+}
+
+func TestApplySupporterAccess(t *testing.T) {
+	viewer := uuid.Must(uuid.NewV4())
+	supportedCreator := uuid.Must(uuid.NewV4())
+	otherCreator := uuid.Must(uuid.NewV4())
+	coauthoredID := uuid.Must(uuid.NewV4())
+	body := "The teaser paragraph.\n\nThe part only supporters read."
+
+	newPosts := func() []models.PostResponse {
+		return []models.PostResponse{
+			{ObjectId: uuid.Must(uuid.NewV4()).String(), OwnerUserId: supportedCreator.String(), SupporterOnly: true, Body: body},
+			{ObjectId: uuid.Must(uuid.NewV4()).String(), OwnerUserId: otherCreator.String(), SupporterOnly: true, Body: body, Image: "a.jpg", Thumbnail: "t.jpg",
+				LatestComments: []models.CommentPreview{{Text: "spoiler"}}},
+			{ObjectId: uuid.Must(uuid.NewV4()).String(), OwnerUserId: viewer.String(), SupporterOnly: true, Body: body},
+			{ObjectId: coauthoredID.String(), OwnerUserId: otherCreator.String(), SupporterOnly: true, Body: body},
+			{ObjectId: uuid.Must(uuid.NewV4()).String(), OwnerUserId: otherCreator.String(), Body: body},
+		}
+	}
+	ctx := context.WithValue(context.Background(), types.UserCtxName, types.UserContext{UserID: viewer})
+
+	t.Run("unlocks owners, co-authors and supporters", func(t *testing.T) {
+		repo := new(MockPostRepository)
+		repo.On("ListCoauthors", ctx, mock.Anything, models.CoauthorStatusAccepted).
+			Return(map[uuid.UUID][]models.PostCoauthor{coauthoredID: {{PostId: coauthoredID, UserId: viewer}}}, nil).Once()
+		checker := &stubSupporters{supported: map[uuid.UUID]bool{supportedCreator: true}}
+		svc := &postService{repo: repo, supporters: checker, config: &platformconfig.Config{}}
+
+		posts := newPosts()
+		svc.ApplySupporterAccess(ctx, posts)
+		assert.Equal(t, 1, checker.calls, "access is checked once per page")
+
+		for i, post := range posts {
+			assert.Equal(t, i == 1, post.Locked, "post %d", i)
+		}
+		locked := posts[1]
+		assert.Equal(t, "The teaser paragraph.", locked.Body)
+		assert.Equal(t, locked.Body, locked.BodyPreview)
+		assert.True(t, locked.IsTruncated)
+		assert.Empty(t, locked.Image)
+		assert.Empty(t, locked.LatestComments)
+		assert.Equal(t, "t.jpg", locked.Thumbnail, "the thumbnail stays as part of the teaser")
+	})
+
+	t.Run("fails closed", func(t *testing.T) {
+		repo := new(MockPostRepository)
+		repo.On("ListCoauthors", ctx, mock.Anything, models.CoauthorStatusAccepted).Return(nil, errors.New("timeout")).Once()
+		svc := &postService{repo: repo, supporters: &stubSupporters{err: errors.New("timeout")}}
+
+		posts := newPosts()
+		svc.ApplySupporterAccess(ctx, posts)
+		for i, post := range posts {
+			assert.Equal(t, i != 2 && i != 4, post.Locked, "post %d", i)
+		}
+	})
+
+	t.Run("anonymous readers only see teasers", func(t *testing.T) {
+		repo := new(MockPostRepository)
+		svc := &postService{repo: repo, supporters: &stubSupporters{}}
+
+		posts := newPosts()
+		svc.ApplySupporterAccess(context.Background(), posts)
+		for i, post := range posts {
+			assert.Equal(t, i != 4, post.Locked, "post %d", i)
+		}
+		repo.AssertNotCalled(t, "ListCoauthors", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("feed previews keep the teaser", func(t *testing.T) {
+		svc := &postService{repo: new(MockPostRepository), config: &platformconfig.Config{Posts: platformconfig.PostsConfig{TruncateFeedBodies: true}}}
+		posts := newPosts()[1:2]
+		svc.ApplySupporterAccess(context.Background(), posts)
+		svc.applyFeedPreviews(nil, posts)
+		assert.Equal(t, "The teaser paragraph.", posts[0].Body)
+		assert.True(t, posts[0].IsTruncated)
+	})
+}
+
+func TestCheckSupporterOnly(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+
+	assert.ErrorIs(t, (&postService{}).checkSupporterOnly(ctx, owner), postsErrors.ErrSupportersUnavailable)
+	assert.ErrorIs(t, (&postService{supporters: &stubSupporters{}}).checkSupporterOnly(ctx, owner), postsErrors.ErrSupportersUnavailable)
+	assert.NoError(t, (&postService{supporters: &stubSupporters{offers: true}}).checkSupporterOnly(ctx, owner))
+}
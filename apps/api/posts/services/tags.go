@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/posts/common"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+const (
+	defaultTrendingTagsSize = 20
+	defaultTagPageLimit     = 20
+	maxTagPageLimit         = 50
+)
+
+// TrendingTags returns the window's most used tags as last counted by the trending job
+func (s *postService) TrendingTags(ctx context.Context, window string) (*models.TrendingTagsResponse, error) {
+	if _, ok := models.FindTrendingWindow(window); !ok {
+		return nil, fmt.Errorf("%w: unknown window %q", postsErrors.ErrValidationFailed, window)
+	}
+
+	tags, computedDate, err := s.repo.ListTrendingTags(ctx, window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trending tags: %w", err)
+	}
+	return &models.TrendingTagsResponse{Window: window, ComputedDate: computedDate, Tags: tags}, nil
+}
+
+// RefreshTrendingTags recounts the tags of every window and drops cached trending responses
+func (s *postService) RefreshTrendingTags(ctx context.Context) error {
+	size := defaultTrendingTagsSize
+	if s.config != nil && s.config.Posts.TrendingTagsSize > 0 {
+		size = s.config.Posts.TrendingTagsSize
+	}
+
+	now := time.Now().UTC()
+	for _, window := range models.TrendingWindows {
+		if err := s.repo.RefreshTrendingTags(ctx, window.Name, now.Add(-window.Window).UnixMilli(), now.UnixMilli(), size); err != nil {
+			return fmt.Errorf("failed to refresh trending tags for %s: %w", window.Name, err)
+		}
+	}
+	httpcache.Invalidate(ctx, httpcache.ScopeTags)
+	return nil
+}
+
+// GetTagPosts returns a page of live public posts carrying the tag and created within the
+// range, newest first. Pass the response's NextCursor back as after for the next page.
+func (s *postService) GetTagPosts(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) (*models.PostsListResponse, error) {
+	tag = common.NormalizeTag(tag)
+	if tag == "" {
+		return nil, fmt.Errorf("%w: tag must be 1-%d characters", postsErrors.ErrValidationFailed, common.MaxTagLength)
+	}
+	if err := s.checkTimeRange(ctx, within); err != nil {
+		return nil, err
+	}
+	if limit <= 0 {
+		limit = defaultTagPageLimit
+	}
+	if limit > maxTagPageLimit {
+		limit = maxTagPageLimit
+	}
+
+	posts, hasMore, err := s.repo.FindByTag(ctx, tag, within, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find posts by tag: %w", err)
+	}
+
+	sharedCtx := withoutViewer(ctx)
+	responses := make([]models.PostResponse, len(posts))
+	for i, post := range posts {
+		responses[i] = s.ConvertPostToResponse(sharedCtx, post)
+	}
+	s.AttachCoauthors(ctx, responses)
+
+	result := &models.PostsListResponse{Posts: responses, Limit: limit, HasNext: hasMore}
+	if hasMore && len(posts) > 0 {
+		last := posts[len(posts)-1]
+		result.NextCursor = models.EncodeTagCursor(models.TagCursor{CreatedDate: last.CreatedDate, ID: last.ObjectId.String()})
+	}
+
+	result.Posts = s.dropMutedPosts(ctx, result.Posts)
+	s.enrichPostsForViewer(ctx, result.Posts)
+	s.ApplySupporterAccess(ctx, result.Posts)
+	return result, nil
+}
+
+// StartTrendingTagsJob counts trending tags now and then every interval until ctx is
+// done. A non-positive interval disables the job.
+func StartTrendingTagsJob(ctx context.Context, svc PostService, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		refresh := func() {
+			if err := svc.RefreshTrendingTags(ctx); err != nil {
+				log.Error("Trending tags refresh failed: %v", err)
+			}
+		}
+
+		refresh()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				refresh()
+			}
+		}
+	}()
+}
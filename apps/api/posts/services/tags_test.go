@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+)
+
+func TestGetTagPosts(t *testing.T) {
+	ctx := context.Background()
+	first := &models.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: uuid.Must(uuid.NewV4()), Body: "Launch day #GoLang", CreatedDate: 2000}
+	second := &models.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: uuid.Must(uuid.NewV4()), Body: "Notes #golang", CreatedDate: 1000}
+
+	repo := new(MockPostRepository)
+	svc := &postService{repo: repo, config: &platformconfig.Config{}}
+
+	t.Run("normalizes the tag and returns a cursor after the last post", func(t *testing.T) {
+		after := &models.TagCursor{CreatedDate: 3000, ID: uuid.Must(uuid.NewV4()).String()}
+		repo.On("FindByTag", ctx, "golang", models.TimeRange{}, after, maxTagPageLimit).Return([]*models.Post{first, second}, true, nil).Once()
+		repo.On("ListCoauthors", ctx, mock.Anything, models.CoauthorStatusAccepted).Return(nil, nil).Once()
+
+		resp, err := svc.GetTagPosts(ctx, " #GoLang ", models.TimeRange{}, after, 500)
+		require.NoError(t, err)
+		require.Len(t, resp.Posts, 2)
+		assert.True(t, resp.HasNext)
+
+		cursor, err := models.DecodeTagCursor(resp.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, models.TagCursor{CreatedDate: 1000, ID: second.ObjectId.String()}, *cursor)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an empty tag", func(t *testing.T) {
+		_, err := svc.GetTagPosts(ctx, "#", models.TimeRange{}, nil, 0)
+		assert.ErrorIs(t, err, postsErrors.ErrValidationFailed)
+		repo.AssertNumberOfCalls(t, "FindByTag", 1)
+	})
+
+	t.Run("rejects an unbounded range from anonymous readers", func(t *testing.T) {
+		_, err := svc.GetTagPosts(ctx, "golang", models.TimeRange{Until: 2000}, nil, 0)
+		assert.ErrorIs(t, err, postsErrors.ErrValidationFailed)
+		repo.AssertNumberOfCalls(t, "FindByTag", 1)
+	})
+}
+
+func TestTrendingTags(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockPostRepository)
+	svc := &postService{repo: repo, config: &platformconfig.Config{Posts: platformconfig.PostsConfig{TrendingTagsSize: 5}}}
+
+	t.Run("recounts every window", func(t *testing.T) {
+		for _, window := range models.TrendingWindows {
+			repo.On("RefreshTrendingTags", ctx, window.Name, mock.Anything, mock.Anything, 5).Return(nil).Once()
+		}
+		require.NoError(t, svc.RefreshTrendingTags(ctx))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("lists a known window", func(t *testing.T) {
+		tags := []models.TrendingTag{{Rank: 1, Tag: "golang", PostCount: 12}}
+		repo.On("ListTrendingTags", ctx, "7d").Return(tags, int64(1700000000000), nil).Once()
+
+		resp, err := svc.TrendingTags(ctx, "7d")
+		require.NoError(t, err)
+		assert.Equal(t, &models.TrendingTagsResponse{Window: "7d", ComputedDate: 1700000000000, Tags: tags}, resp)
+
+		_, err = svc.TrendingTags(ctx, "30d")
+		assert.ErrorIs(t, err, postsErrors.ErrValidationFailed)
+	})
+}
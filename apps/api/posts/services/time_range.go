@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+// defaultAnonymousMaxRange is used when the posts config does not set one
+const defaultAnonymousMaxRange = 31 * 24 * time.Hour
+
+// checkTimeRange keeps anonymous readers from scanning the whole history: a range they
+// ask for needs a start and may span at most PostsConfig.AnonymousMaxRange
+func (s *postService) checkTimeRange(ctx context.Context, r models.TimeRange) error {
+	if r.IsZero() {
+		return nil
+	}
+	if _, ok := ctx.Value(types.UserCtxName).(types.UserContext); ok {
+		return nil
+	}
+
+	maxRange := defaultAnonymousMaxRange
+	if s.config != nil && s.config.Posts.AnonymousMaxRange > 0 {
+		maxRange = s.config.Posts.AnonymousMaxRange
+	}
+	if span, bounded := r.Span(time.Now()); !bounded || span > maxRange {
+		return fmt.Errorf("%w: anonymous time ranges need since and may span at most %s", postsErrors.ErrValidationFailed, maxRange)
+	}
+	return nil
+}
+
+// applyTimeRange narrows a repository filter to the range. Until also caps the paging
+// snapshot, so later pages never reach past it.
+func applyTimeRange(filter *repository.PostFilter, r models.TimeRange) {
+	if r.Since > 0 && (filter.CreatedAfter == nil || *filter.CreatedAfter < r.Since) {
+		since := r.Since
+		filter.CreatedAfter = &since
+	}
+	if r.Until > 0 {
+		// CreatedBefore is inclusive and Until is not
+		last := r.Until - 1
+		if filter.CreatedBefore == nil || *filter.CreatedBefore > last {
+			filter.CreatedBefore = &last
+		}
+	}
+}
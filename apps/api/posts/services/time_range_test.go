@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	"github.com/qolzam/telar/apps/api/posts/models"
+	"github.com/qolzam/telar/apps/api/posts/repository"
+)
+
+func TestCheckTimeRange(t *testing.T) {
+	svc := &postService{config: &config.Config{Posts: config.PostsConfig{AnonymousMaxRange: 7 * 24 * time.Hour}}}
+	anonymous := context.Background()
+	signedIn := context.WithValue(anonymous, types.UserCtxName, types.UserContext{UserID: uuid.Must(uuid.NewV4())})
+	now := time.Now()
+	lastWeek := models.TimeRange{Since: now.Add(-6 * 24 * time.Hour).UnixMilli()}
+	lastYear := models.TimeRange{Since: now.AddDate(-1, 0, 0).UnixMilli()}
+	untilOnly := models.TimeRange{Until: now.UnixMilli()}
+
+	assert.NoError(t, svc.checkTimeRange(anonymous, models.TimeRange{}))
+	assert.NoError(t, svc.checkTimeRange(anonymous, lastWeek))
+	assert.ErrorIs(t, svc.checkTimeRange(anonymous, lastYear), postsErrors.ErrValidationFailed)
+	assert.ErrorIs(t, svc.checkTimeRange(anonymous, untilOnly), postsErrors.ErrValidationFailed)
+	assert.NoError(t, svc.checkTimeRange(signedIn, lastYear))
+	assert.NoError(t, svc.checkTimeRange(signedIn, untilOnly))
+}
+
+func TestApplyTimeRange(t *testing.T) {
+	snapshot := int64(3000)
+	filter := repository.PostFilter{CreatedBefore: &snapshot}
+	applyTimeRange(&filter, models.TimeRange{Since: 1000, Until: 2000})
+	assert.Equal(t, int64(1000), *filter.CreatedAfter)
+	assert.Equal(t, int64(1999), *filter.CreatedBefore, "until is exclusive")
+
+	// A later page's snapshot already sits inside the range
+	snapshot = 1500
+	filter = repository.PostFilter{CreatedBefore: &snapshot}
+	applyTimeRange(&filter, models.TimeRange{Since: 1000, Until: 2000})
+	assert.Equal(t, int64(1500), *filter.CreatedBefore)
+}
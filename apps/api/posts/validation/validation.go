@@ -3,9 +3,12 @@ package validation
 import (
 	"fmt"
 	"strings"
+	"time"
+	"unicode/utf8"
 
 	uuid "github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/posts/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // ValidateCreatePostRequest validates the create post request
@@ -22,8 +25,9 @@ func ValidateCreatePostRequest(req *models.CreatePostRequest) error {
 		return fmt.Errorf("body is required")
 	}
 
-	if len(req.Body) > 10000 {
-		return fmt.Errorf("body must be less than 10000 characters")
+	// The deployment's own, lower limits are enforced by the service
+	if utf8.RuneCountInString(req.Body) > sharedInterfaces.MaxContentLimits.PostBody {
+		return fmt.Errorf("body cannot exceed %d characters", sharedInterfaces.MaxContentLimits.PostBody)
 	}
 
 	if req.Permission != "" {
@@ -40,6 +44,26 @@ func ValidateCreatePostRequest(req *models.CreatePostRequest) error {
 		}
 	}
 
+	if req.VisibleUntil != 0 {
+		if err := validateVisibleUntil(req.VisibleUntil); err != nil {
+			return err
+		}
+	}
+
+	if err := validateAttribution(req.License, req.CanonicalURL); err != nil {
+		return err
+	}
+
+	// Teasers name the author and are meant to be found, so the post must be public
+	if req.SupporterOnly {
+		if req.Anonymous {
+			return fmt.Errorf("supporter-only posts cannot be anonymous")
+		}
+		if req.Permission != "" && req.Permission != "Public" {
+			return fmt.Errorf("supporter-only posts must be public")
+		}
+	}
+
 	return nil
 }
 
@@ -61,8 +85,8 @@ func ValidateUpdatePostRequest(req *models.UpdatePostRequest) error {
 		if strings.TrimSpace(*req.Body) == "" {
 			return fmt.Errorf("body cannot be empty or whitespace only")
 		}
-		if len(*req.Body) > 10000 {
-			return fmt.Errorf("body cannot exceed 10000 characters")
+		if utf8.RuneCountInString(*req.Body) > sharedInterfaces.MaxContentLimits.PostBody {
+			return fmt.Errorf("body cannot exceed %d characters", sharedInterfaces.MaxContentLimits.PostBody)
 		}
 		if len(*req.Body) < 1 {
 			return fmt.Errorf("body must be at least 1 character")
@@ -71,15 +95,15 @@ func ValidateUpdatePostRequest(req *models.UpdatePostRequest) error {
 
 	// Validate tags if provided
 	if req.Tags != nil {
-		if len(*req.Tags) > 10 {
-			return fmt.Errorf("maximum 10 tags allowed")
+		if len(*req.Tags) > sharedInterfaces.MaxContentLimits.PostTags {
+			return fmt.Errorf("maximum %d tags allowed", sharedInterfaces.MaxContentLimits.PostTags)
 		}
 		for i, tag := range *req.Tags {
 			if strings.TrimSpace(tag) == "" {
 				return fmt.Errorf("tag at index %d cannot be empty", i)
 			}
-			if len(tag) > 50 {
-				return fmt.Errorf("tag at index %d cannot exceed 50 characters", i)
+			if utf8.RuneCountInString(tag) > sharedInterfaces.MaxContentLimits.TagLength {
+				return fmt.Errorf("tag at index %d cannot exceed %d characters", i, sharedInterfaces.MaxContentLimits.TagLength)
 			}
 		}
 	}
@@ -101,6 +125,49 @@ func ValidateUpdatePostRequest(req *models.UpdatePostRequest) error {
 		}
 	}
 
+	// Zero removes the expiry
+	if req.VisibleUntil != nil && *req.VisibleUntil != 0 {
+		if err := validateVisibleUntil(*req.VisibleUntil); err != nil {
+			return err
+		}
+	}
+
+	// Empty strings clear the license and canonical source
+	var license, canonicalURL string
+	if req.License != nil {
+		license = *req.License
+	}
+	if req.CanonicalURL != nil {
+		canonicalURL = *req.CanonicalURL
+	}
+	if err := validateAttribution(license, canonicalURL); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// validateAttribution checks an optional license identifier and canonical-source URL
+func validateAttribution(license, canonicalURL string) error {
+	if license != "" && !models.ValidLicense(license) {
+		return fmt.Errorf("license %q is not a supported license identifier", license)
+	}
+	if canonicalURL != "" {
+		if err := models.ValidateCanonicalURL(canonicalURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateVisibleUntil checks that an expiry is a Unix time in milliseconds that has not passed
+func validateVisibleUntil(visibleUntil int64) error {
+	if visibleUntil < 0 {
+		return fmt.Errorf("visibleUntil must be a Unix timestamp in milliseconds")
+	}
+	if visibleUntil <= time.Now().UnixMilli() {
+		return fmt.Errorf("visibleUntil must be in the future")
+	}
 	return nil
 }
 
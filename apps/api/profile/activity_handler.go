@@ -0,0 +1,68 @@
+package profile
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/profile/errors"
+	"github.com/qolzam/telar/apps/api/profile/models"
+	"github.com/qolzam/telar/apps/api/profile/services"
+	"github.com/qolzam/telar/apps/api/profile/validation"
+)
+
+// ActivityHandler serves profile activity timelines
+type ActivityHandler struct {
+	activityService *services.ActivityService
+}
+
+// NewActivityHandler creates an ActivityHandler
+func NewActivityHandler(activityService *services.ActivityService) *ActivityHandler {
+	return &ActivityHandler{activityService: activityService}
+}
+
+// GetActivity handles GET /profile/:socialName/activity?cursor=&limit=&types=post,comment
+func (h *ActivityHandler) GetActivity(c *fiber.Ctx) error {
+	name := c.Params("socialName")
+	if err := validation.ValidateSocialName(name); err != nil {
+		return errors.HandleValidationError(c, err.Error())
+	}
+
+	query := models.ActivityQuery{Cursor: c.Query("cursor")}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return errors.HandleInvalidFieldError(c, "limit", "must be a positive integer")
+		}
+		query.Limit = limit
+	}
+
+	if typesStr := c.Query("types"); typesStr != "" {
+		known := h.activityService.Types()
+		for _, t := range strings.Split(typesStr, ",") {
+			t = strings.TrimSpace(t)
+			if t == "" {
+				continue
+			}
+			if !slices.Contains(known, t) {
+				return errors.HandleInvalidFieldError(c, "types", "supported types are "+strings.Join(known, ", "))
+			}
+			query.Types = append(query.Types, t)
+		}
+	}
+
+	viewerID := uuid.Nil
+	if uc, ok := c.Locals(types.UserCtxName).(types.UserContext); ok {
+		viewerID = uc.UserID
+	}
+
+	page, err := h.activityService.GetActivity(c.Context(), name, viewerID, query)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(page)
+}
@@ -1,6 +1,7 @@
 package profile
 
 import (
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/profile/internal/adapters"
 	"github.com/qolzam/telar/apps/api/profile/services"
 )
@@ -10,8 +11,8 @@ func NewDirectCallAdapter(service services.ProfileService) services.ProfileServi
 	return adapters.NewDirectCallCreator(service)
 }
 
-// NewGrpcAdapter creates a gRPC client adapter for microservices deployment mode
-func NewGrpcAdapter(targetAddress string) (services.ProfileServiceClient, error) {
-	return adapters.NewGrpcCreator(targetAddress)
+// NewGrpcAdapter creates a gRPC client adapter for microservices deployment mode;
+// cfg sets up TLS, call authentication, keepalive and retries
+func NewGrpcAdapter(targetAddress string, cfg platformconfig.GRPCConfig) (services.ProfileServiceClient, error) {
+	return adapters.NewGrpcCreator(targetAddress, cfg)
 }
-
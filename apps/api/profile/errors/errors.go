@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
 )
 
 var (
@@ -125,6 +126,22 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 			Message: "Access forbidden",
 			Details: err.Error(),
 		})
+	case errors.Is(err, ErrValidationFailed):
+		var details interface{} = err.Error()
+		if fields, ok := validate.Fields(err); ok {
+			details = fiber.Map{"fields": fields}
+		}
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{
+			Code:    CodeValidationFailed,
+			Message: "Validation failed",
+			Details: details,
+		})
+	case errors.Is(err, ErrInvalidFieldValue):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{
+			Code:    CodeInvalidFieldValue,
+			Message: "Invalid field value",
+			Details: err.Error(),
+		})
 	case errors.Is(err, ErrDatabaseOperation):
 		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{
 			Code:    CodeDatabaseOperation,
@@ -115,6 +115,19 @@ func (s *grpcServer) GetProfilesByIds(ctx context.Context, req *pb.GetProfilesBy
 		return nil, err
 	}
 
+	return &pb.GetProfilesByIdsResponse{Profiles: profilesToPb(profiles)}, nil
+}
+
+func (s *grpcServer) GetProfilesBySocialNames(ctx context.Context, req *pb.GetProfilesBySocialNamesRequest) (*pb.GetProfilesBySocialNamesResponse, error) {
+	profiles, err := s.service.GetProfilesBySocialNames(ctx, req.SocialNames)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetProfilesBySocialNamesResponse{Profiles: profilesToPb(profiles)}, nil
+}
+
+// profilesToPb converts profiles to their wire form
+func profilesToPb(profiles []*models.Profile) []*pb.Profile {
 	pbProfiles := make([]*pb.Profile, 0, len(profiles))
 	for _, profile := range profiles {
 		pbProfile := &pb.Profile{
@@ -132,6 +145,5 @@ func (s *grpcServer) GetProfilesByIds(ctx context.Context, req *pb.GetProfilesBy
 		pbProfiles = append(pbProfiles, pbProfile)
 	}
 
-	return &pb.GetProfilesByIdsResponse{Profiles: pbProfiles}, nil
+	return pbProfiles
 }
-
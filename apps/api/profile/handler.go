@@ -8,6 +8,8 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/fieldset"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	"github.com/qolzam/telar/apps/api/profile/errors"
@@ -18,6 +20,8 @@ import (
 
 type ProfileHandler struct {
 	profileService services.ProfileService
+	viewService    services.ViewService
+	streakSource   services.StreakSource
 	jwtConfig      platformconfig.JWTConfig
 	hmacConfig     platformconfig.HMACConfig
 }
@@ -30,6 +34,49 @@ func NewProfileHandler(profileService services.ProfileService, jwtConfig platfor
 	}
 }
 
+// WithViewTracking records profile views of signed-in users on profile reads
+func (h *ProfileHandler) WithViewTracking(viewService services.ViewService) *ProfileHandler {
+	h.viewService = viewService
+	return h
+}
+
+// recordView stores a view of the profile by the caller. Tracking failures never fail the read.
+func (h *ProfileHandler) recordView(c *fiber.Ctx, profileID uuid.UUID) {
+	if h.viewService == nil {
+		return
+	}
+	uc, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return
+	}
+	if err := h.viewService.RecordView(c.Context(), profileID, uc.UserID); err != nil {
+		log.Warn("Failed to record view of profile %s: %v", profileID, err)
+	}
+}
+
+// WithStreaks attaches activity streaks to profile reads
+func (h *ProfileHandler) WithStreaks(streakSource services.StreakSource) *ProfileHandler {
+	h.streakSource = streakSource
+	return h
+}
+
+// attachStreak sets the profile's activity streak. Whether a streak is at risk is only
+// shown to its owner. Streak failures never fail the read.
+func (h *ProfileHandler) attachStreak(c *fiber.Ctx, doc *models.Profile) {
+	if h.streakSource == nil || doc == nil {
+		return
+	}
+	streak, err := h.streakSource.GetStreak(c.Context(), doc.ObjectId)
+	if err != nil {
+		log.Warn("Failed to read streak of profile %s: %v", doc.ObjectId, err)
+		return
+	}
+	if uc, ok := c.Locals(types.UserCtxName).(types.UserContext); !ok || uc.UserID != doc.ObjectId {
+		streak.AtRisk = false
+	}
+	doc.Streak = streak
+}
+
 func (h *ProfileHandler) ReadMyProfile(c *fiber.Ctx) error {
 	if uc, ok := c.Locals(types.UserCtxName).(types.UserContext); ok && uc.UserID != uuid.Nil {
 		doc, err := h.profileService.GetProfile(c.Context(), uc.UserID)
@@ -39,11 +86,26 @@ func (h *ProfileHandler) ReadMyProfile(c *fiber.Ctx) error {
 		if doc == nil {
 			return errors.HandleNotFoundError(c, "Profile not found")
 		}
+		h.attachStreak(c, doc)
 		return c.JSON(doc)
 	}
 	return errors.HandleUnauthorizedError(c, "Authentication required")
 }
 
+// GetMyPropagation returns how far the current user's latest name and avatar change has
+// been copied into posts, comments and mentions
+func (h *ProfileHandler) GetMyPropagation(c *fiber.Ctx) error {
+	uc, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok || uc.UserID == uuid.Nil {
+		return errors.HandleUnauthorizedError(c, "Authentication required")
+	}
+	progress, err := h.profileService.GetPropagationProgress(c.Context(), uc.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(fiber.Map{"targets": progress})
+}
+
 func (h *ProfileHandler) QueryUserProfile(c *fiber.Ctx) error {
 	search := c.Query("search", "")
 
@@ -59,6 +121,11 @@ func (h *ProfileHandler) QueryUserProfile(c *fiber.Ctx) error {
 		limit = 10
 	}
 
+	fields, err := parseProfileFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+
 	filter := &models.ProfileQueryFilter{
 		Search: search,
 		Page:   page,
@@ -84,7 +151,7 @@ func (h *ProfileHandler) QueryUserProfile(c *fiber.Ctx) error {
 	for i := range result.Profiles {
 		profiles[i] = &result.Profiles[i]
 	}
-	return c.JSON(profiles)
+	return respondProfiles(c, profiles, fields)
 }
 
 func (h *ProfileHandler) SearchProfiles(c *fiber.Ctx) error {
@@ -104,6 +171,11 @@ func (h *ProfileHandler) SearchProfiles(c *fiber.Ctx) error {
 		}
 	}
 
+	fields, err := parseProfileFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+
 	results, err := h.profileService.SearchProfiles(c.Context(), query, limit)
 	if err != nil {
 		return errors.HandleServiceError(c, err)
@@ -112,7 +184,7 @@ func (h *ProfileHandler) SearchProfiles(c *fiber.Ctx) error {
 		results = []*models.Profile{}
 	}
 
-	return c.JSON(results)
+	return respondProfiles(c, results, fields)
 }
 
 func (h *ProfileHandler) ReadProfile(c *fiber.Ctx) error {
@@ -125,6 +197,8 @@ func (h *ProfileHandler) ReadProfile(c *fiber.Ctx) error {
 	if err != nil {
 		return errors.HandleServiceError(c, err)
 	}
+	h.recordView(c, doc.ObjectId)
+	h.attachStreak(c, doc)
 	return c.JSON(doc)
 }
 
@@ -139,9 +213,30 @@ func (h *ProfileHandler) GetBySocialName(c *fiber.Ctx) error {
 	if err != nil {
 		return errors.HandleServiceError(c, err)
 	}
+	h.recordView(c, doc.ObjectId)
+	h.attachStreak(c, doc)
 	return c.JSON(doc)
 }
 
+// GetPublicProfile serves the public fields of a profile to anonymous readers.
+// Profiles that are not public are reported as not found.
+func (h *ProfileHandler) GetPublicProfile(c *fiber.Ctx) error {
+	name := c.Params("name")
+
+	if err := validation.ValidateSocialName(name); err != nil {
+		return errors.HandleValidationError(c, err.Error())
+	}
+
+	doc, err := h.profileService.GetProfileBySocialName(c.Context(), name)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	if doc.Permission != "" && doc.Permission != "Public" {
+		return errors.HandleServiceError(c, errors.ErrProfileNotFound)
+	}
+	return c.JSON(models.NewPublicProfile(doc))
+}
+
 func (h *ProfileHandler) GetProfileByIds(c *fiber.Ctx) error {
 	var idsStr []string
 	if err := json.Unmarshal(c.Body(), &idsStr); err != nil {
@@ -152,6 +247,11 @@ func (h *ProfileHandler) GetProfileByIds(c *fiber.Ctx) error {
 		return errors.HandleValidationError(c, err.Error())
 	}
 
+	fields, err := parseProfileFields(c)
+	if err != nil {
+		return errors.HandleInvalidFieldError(c, "fields", err.Error())
+	}
+
 	ids := make([]uuid.UUID, 0, len(idsStr))
 	for _, s := range idsStr {
 		if id, err := uuid.FromString(s); err == nil {
@@ -172,7 +272,56 @@ func (h *ProfileHandler) GetProfileByIds(c *fiber.Ctx) error {
 	if docs == nil {
 		docs = []*models.Profile{}
 	}
-	return c.JSON(docs)
+	return respondProfiles(c, docs, fields)
+}
+
+// GetProfileSummaries returns the display data of the users in a JSON array of IDs, for
+// hydrating comment lists requested with ?owners=ids
+func (h *ProfileHandler) GetProfileSummaries(c *fiber.Ctx) error {
+	var idsStr []string
+	if err := json.Unmarshal(c.Body(), &idsStr); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid JSON body - expected array of UUID strings")
+	}
+
+	if err := validation.ValidateUserIdList(idsStr); err != nil {
+		return errors.HandleValidationError(c, err.Error())
+	}
+
+	ids := make([]uuid.UUID, 0, len(idsStr))
+	for _, s := range idsStr {
+		if id, err := uuid.FromString(s); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return c.JSON([]*models.ProfileSummary{})
+	}
+
+	summaries, err := h.profileService.GetProfileSummaries(c.Context(), ids)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(summaries)
+}
+
+// profileResponseFields are the field names accepted by ?fields= on profile list endpoints
+var profileResponseFields = fieldset.JSONFields(models.Profile{})
+
+// parseProfileFields reads the sparse fieldset from the "fields" query parameter
+func parseProfileFields(c *fiber.Ctx) (fieldset.Set, error) {
+	return fieldset.Parse(c.Query("fields"), profileResponseFields)
+}
+
+// respondProfiles writes a profile list, reduced to the sparse fieldset when one was requested
+func respondProfiles(c *fiber.Ctx, profiles []*models.Profile, fields fieldset.Set) error {
+	if fields == nil {
+		return c.JSON(profiles)
+	}
+	projected, err := fieldset.Project(profiles, fields, "objectId")
+	if err != nil {
+		return errors.HandleInternalServerError(c, "Failed to project profile fields")
+	}
+	return c.JSON(projected)
 }
 
 func (h *ProfileHandler) InitProfileIndex(c *fiber.Ctx) error {
@@ -17,6 +17,7 @@ type profileServiceClient interface {
 	CreateProfileOnSignup(ctx context.Context, req *models.CreateProfileRequest) error
 	UpdateProfileClient(ctx context.Context, userID uuid.UUID, req *models.UpdateProfileRequest) error
 	GetProfilesByIds(ctx context.Context, userIds []uuid.UUID) ([]*models.Profile, error)
+	GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*models.Profile, error)
 }
 
 type DirectCallCreator struct {
@@ -49,3 +50,6 @@ func (a *DirectCallCreator) GetProfilesByIds(ctx context.Context, userIds []uuid
 	return a.service.GetProfilesByIds(ctx, userIds)
 }
 
+func (a *DirectCallCreator) GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*models.Profile, error) {
+	return a.service.GetProfilesBySocialNames(ctx, socialNames)
+}
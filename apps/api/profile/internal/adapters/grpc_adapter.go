@@ -4,11 +4,14 @@ import (
 	"context"
 
 	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/middleware/requestid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/platform/grpcconn"
+	"github.com/qolzam/telar/apps/api/internal/platform/metrics"
 	"github.com/qolzam/telar/apps/api/profile/models"
 	"github.com/qolzam/telar/apps/api/profile/services"
 	pb "github.com/qolzam/telar/protos/gen/go/profilepb"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 var _ services.ProfileServiceClient = (*GrpcCreator)(nil)
@@ -18,8 +21,13 @@ type GrpcCreator struct {
 	conn   *grpc.ClientConn
 }
 
-func NewGrpcCreator(targetAddress string) (*GrpcCreator, error) {
-	conn, err := grpc.Dial(targetAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+func NewGrpcCreator(targetAddress string, cfg platformconfig.GRPCConfig) (*GrpcCreator, error) {
+	opts, err := grpcconn.DialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := grpc.Dial(targetAddress, append(opts,
+		grpc.WithChainUnaryInterceptor(requestid.UnaryClientInterceptor(), metrics.Default().UnaryClientInterceptor()))...)
 	if err != nil {
 		return nil, err
 	}
@@ -112,8 +120,21 @@ func (a *GrpcCreator) GetProfilesByIds(ctx context.Context, userIds []uuid.UUID)
 		return nil, err
 	}
 
-	profiles := make([]*models.Profile, 0, len(resp.Profiles))
-	for _, pbProfile := range resp.Profiles {
+	return profilesFromPb(resp.Profiles), nil
+}
+
+func (a *GrpcCreator) GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*models.Profile, error) {
+	resp, err := a.client.GetProfilesBySocialNames(ctx, &pb.GetProfilesBySocialNamesRequest{SocialNames: socialNames})
+	if err != nil {
+		return nil, err
+	}
+	return profilesFromPb(resp.Profiles), nil
+}
+
+// profilesFromPb converts wire profiles, skipping any with a malformed ID
+func profilesFromPb(pbProfiles []*pb.Profile) []*models.Profile {
+	profiles := make([]*models.Profile, 0, len(pbProfiles))
+	for _, pbProfile := range pbProfiles {
 		objectId, err := uuid.FromString(pbProfile.ObjectId)
 		if err != nil {
 			continue
@@ -134,6 +155,5 @@ func (a *GrpcCreator) GetProfilesByIds(ctx context.Context, userIds []uuid.UUID)
 		profiles = append(profiles, profile)
 	}
 
-	return profiles, nil
+	return profiles
 }
-
@@ -0,0 +1,16 @@
+-- Who viewed a profile. One row per viewer per profile per UTC day, so repeated
+-- visits on the same day only move viewed_at forward.
+CREATE TABLE IF NOT EXISTS profile_views (
+    profile_user_id UUID NOT NULL,
+    viewer_user_id UUID NOT NULL,
+    view_day INT NOT NULL,      -- Days since the Unix epoch (UTC)
+    viewed_at BIGINT NOT NULL,  -- Last view that day, Unix milliseconds
+
+    PRIMARY KEY (profile_user_id, viewer_user_id, view_day)
+);
+
+-- Owner lookups over a recent window
+CREATE INDEX IF NOT EXISTS idx_profile_views_profile_viewed_at ON profile_views (profile_user_id, viewed_at DESC);
+
+-- Retention purge
+CREATE INDEX IF NOT EXISTS idx_profile_views_viewed_at ON profile_views (viewed_at);
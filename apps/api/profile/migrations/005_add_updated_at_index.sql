@@ -0,0 +1,2 @@
+-- The comment profile repair job looks up profiles changed since its last run
+CREATE INDEX IF NOT EXISTS idx_profiles_updated_at ON profiles(updated_at);
@@ -0,0 +1,41 @@
+package models
+
+// Activity item types returned by GET /profile/:socialName/activity
+const (
+	ActivityTypePost    = "post"
+	ActivityTypeComment = "comment"
+	ActivityTypeBadge   = "badge"
+)
+
+// ActivityCursor marks the last item of a timeline page; the next page starts after it
+type ActivityCursor struct {
+	CreatedDate int64
+	ObjectId    string
+}
+
+// ActivityQuery selects a page of a user's activity timeline
+type ActivityQuery struct {
+	Cursor string
+	Limit  int
+	Types  []string // Empty includes every type
+}
+
+// ActivityItem is one entry of a profile activity timeline
+type ActivityItem struct {
+	Type           string `json:"type"`
+	ObjectId       string `json:"objectId"`
+	CreatedDate    int64  `json:"createdDate"`
+	PostId         string `json:"postId,omitempty"`  // Owning post of a comment
+	URLKey         string `json:"urlKey,omitempty"`  // Post URL key
+	Preview        string `json:"preview,omitempty"` // Shortened post body or comment text, or badge name
+	Locked         bool   `json:"locked,omitempty"`  // Supporter-only post; Preview is its teaser
+	Score          int64  `json:"score"`
+	CommentCounter int64  `json:"commentCounter,omitempty"`
+}
+
+// ActivityPage is a page of a profile activity timeline, newest first
+type ActivityPage struct {
+	Items      []ActivityItem `json:"items"`
+	NextCursor string         `json:"nextCursor,omitempty"`
+	HasNext    bool           `json:"hasNext"`
+}
@@ -52,6 +52,16 @@ type Profile struct {
 	// Access control
 	AccessUserList pq.StringArray `json:"accessUserList" bson:"accessUserList" db:"access_user_list"` // Use pq.StringArray for PostgreSQL arrays
 	Permission     string         `json:"permission" bson:"permission" db:"permission"`
+
+	// Streak is attached on profile reads when streaks are enabled; it is not stored with the profile
+	Streak *ProfileStreak `json:"streak,omitempty" bson:"-" db:"-"`
+}
+
+// ProfileStreak is the activity streak shown on a profile
+type ProfileStreak struct {
+	Current int  `json:"current"`
+	Longest int  `json:"longest"`
+	AtRisk  bool `json:"atRisk,omitempty"` // Only set on the caller's own profile
 }
 
 type UpdateLastSeenRequest struct {
@@ -112,5 +122,46 @@ type ProfilesResponse struct {
 	Total    int64     `json:"total"`
 }
 
+// PublicProfile is the subset of a profile shown to anonymous readers. Contact and
+// personal details (email, phone, address, birthday) are never included.
+type PublicProfile struct {
+	ObjectId      uuid.UUID `json:"objectId"`
+	FullName      string    `json:"fullName"`
+	SocialName    string    `json:"socialName"`
+	Avatar        string    `json:"avatar"`
+	Banner        string    `json:"banner"`
+	Tagline       string    `json:"tagLine"`
+	WebUrl        string    `json:"webUrl"`
+	CompanyName   string    `json:"companyName"`
+	CreatedDate   int64     `json:"createdDate"`
+	FollowCount   int64     `json:"followCount"`
+	FollowerCount int64     `json:"followerCount"`
+	PostCount     int64     `json:"postCount"`
+}
 
+// NewPublicProfile copies the public fields of a profile
+func NewPublicProfile(p *Profile) PublicProfile {
+	return PublicProfile{
+		ObjectId:      p.ObjectId,
+		FullName:      p.FullName,
+		SocialName:    p.SocialName,
+		Avatar:        p.Avatar,
+		Banner:        p.Banner,
+		Tagline:       p.Tagline,
+		WebUrl:        p.WebUrl,
+		CompanyName:   p.CompanyName,
+		CreatedDate:   p.CreatedDate,
+		FollowCount:   p.FollowCount,
+		FollowerCount: p.FollowerCount,
+		PostCount:     p.PostCount,
+	}
+}
 
+// ProfileSummary is the display data shown next to content a user owns, like comments
+// listed with their owner IDs only
+type ProfileSummary struct {
+	ObjectId   uuid.UUID `json:"objectId"`
+	FullName   string    `json:"fullName"`
+	SocialName string    `json:"socialName"`
+	Avatar     string    `json:"avatar"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import uuid "github.com/gofrs/uuid"
+
+// ProfileVisit is the latest day a viewer visited a profile
+type ProfileVisit struct {
+	ViewerID   uuid.UUID `db:"viewer_user_id"`
+	LastViewed int64     `db:"last_viewed"`
+}
+
+// ProfileViewer is a revealed viewer of the caller's profile
+type ProfileViewer struct {
+	UserId     string `json:"userId"`
+	FullName   string `json:"fullName"`
+	SocialName string `json:"socialName"`
+	Avatar     string `json:"avatar"`
+	LastViewed int64  `json:"lastViewed"`
+}
+
+// ProfileViewsResponse is the response of GET /profile/my/views. Counts are always
+// returned; viewers are only listed when the owner and the viewer both share profile views.
+type ProfileViewsResponse struct {
+	Days              int             `json:"days"`
+	Views             int64           `json:"views"`   // Viewer-days in the window
+	Viewers           int64           `json:"viewers"` // Distinct viewers in the window
+	ShareProfileViews bool            `json:"shareProfileViews"`
+	RecentViewers     []ProfileViewer `json:"recentViewers"`
+	HiddenViewers     int64           `json:"hiddenViewers"` // Viewers in the window that are not listed
+}
@@ -209,6 +209,38 @@ func (r *postgresProfileRepository) FindBySocialName(ctx context.Context, social
 	return &profile, nil
 }
 
+// FindBySocialNames retrieves the profiles with these exact social names; unknown names are skipped
+func (r *postgresProfileRepository) FindBySocialNames(ctx context.Context, socialNames []string) ([]*models.Profile, error) {
+	if len(socialNames) == 0 {
+		return []*models.Profile{}, nil
+	}
+
+	// The unique social_name index serves ANY($1)
+	query := `
+		SELECT 
+			user_id, full_name, social_name, email, avatar, banner, tagline,
+			created_at, updated_at, created_date, last_updated, last_seen,
+			birthday, web_url, company_name, country, address, phone,
+			vote_count, share_count, follow_count, follower_count, post_count,
+			facebook_id, instagram_id, twitter_id, linkedin_id,
+			access_user_list, permission
+		FROM profiles
+		WHERE social_name = ANY($1::text[])
+	`
+
+	var profiles []models.Profile
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &profiles, query, pq.Array(socialNames)); err != nil {
+		return nil, fmt.Errorf("failed to find profiles by social names: %w", err)
+	}
+
+	result := make([]*models.Profile, len(profiles))
+	for i := range profiles {
+		result[i] = &profiles[i]
+	}
+
+	return result, nil
+}
+
 // FindByIDs retrieves multiple profiles by user IDs
 func (r *postgresProfileRepository) FindByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*models.Profile, error) {
 	if len(userIDs) == 0 {
@@ -608,3 +640,28 @@ func (r *postgresProfileRepository) buildCountQuery(filter ProfileFilter) (strin
 
 	return query, args
 }
+
+// WithTransaction executes a function within a database transaction
+func (r *postgresProfileRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) (err error) {
+	tx, err := r.client.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	// Create a new context with the transaction
+	txCtx := context.WithValue(ctx, "tx", tx)
+	err = fn(txCtx)
+
+	return err
+}
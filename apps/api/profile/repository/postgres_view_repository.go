@@ -0,0 +1,98 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// millisPerDay buckets views into UTC days for deduplication
+const millisPerDay = 24 * 60 * 60 * 1000
+
+// postgresViewRepository implements ViewRepository using raw SQL queries
+type postgresViewRepository struct {
+	client *postgres.Client
+}
+
+// NewPostgresViewRepository creates a new PostgreSQL repository for profile views
+func NewPostgresViewRepository(client *postgres.Client) ViewRepository {
+	return &postgresViewRepository{client: client}
+}
+
+// getExecutor returns the transaction carried by ctx, or the database
+func (r *postgresViewRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if tx, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return tx
+	}
+	return r.client.DB()
+}
+
+func (r *postgresViewRepository) RecordView(ctx context.Context, profileID, viewerID uuid.UUID, viewedAt int64) error {
+	query := `
+		INSERT INTO profile_views (profile_user_id, viewer_user_id, view_day, viewed_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (profile_user_id, viewer_user_id, view_day)
+		DO UPDATE SET viewed_at = GREATEST(profile_views.viewed_at, EXCLUDED.viewed_at)
+	`
+	if _, err := r.client.DB().ExecContext(ctx, query, profileID, viewerID, viewedAt/millisPerDay, viewedAt); err != nil {
+		return fmt.Errorf("failed to record profile view: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresViewRepository) CountViews(ctx context.Context, profileID uuid.UUID, since int64) (int64, int64, error) {
+	query := `
+		SELECT COUNT(*) AS views, COUNT(DISTINCT viewer_user_id) AS viewers
+		FROM profile_views
+		WHERE profile_user_id = $1 AND viewed_at >= $2
+	`
+	var counts struct {
+		Views   int64 `db:"views"`
+		Viewers int64 `db:"viewers"`
+	}
+	if err := sqlx.GetContext(ctx, r.client.DB(), &counts, query, profileID, since); err != nil {
+		return 0, 0, fmt.Errorf("failed to count profile views: %w", err)
+	}
+	return counts.Views, counts.Viewers, nil
+}
+
+func (r *postgresViewRepository) RecentVisits(ctx context.Context, profileID uuid.UUID, since int64, limit int) ([]models.ProfileVisit, error) {
+	query := `
+		SELECT viewer_user_id, MAX(viewed_at) AS last_viewed
+		FROM profile_views
+		WHERE profile_user_id = $1 AND viewed_at >= $2
+		GROUP BY viewer_user_id
+		ORDER BY last_viewed DESC
+		LIMIT $3
+	`
+	var visits []models.ProfileVisit
+	if err := sqlx.SelectContext(ctx, r.client.DB(), &visits, query, profileID, since, limit); err != nil {
+		return nil, fmt.Errorf("failed to list profile visits: %w", err)
+	}
+	return visits, nil
+}
+
+func (r *postgresViewRepository) PurgeBefore(ctx context.Context, before int64) (int64, error) {
+	result, err := r.client.DB().ExecContext(ctx, `DELETE FROM profile_views WHERE viewed_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge profile views: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *postgresViewRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := `DELETE FROM profile_views WHERE profile_user_id = $1 OR viewer_user_id = $1`
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("failed to delete profile views of user: %w", err)
+	}
+	return nil
+}
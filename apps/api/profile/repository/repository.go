@@ -36,6 +36,9 @@ type ProfileRepository interface {
 	// FindByIDs retrieves multiple profiles by user IDs
 	FindByIDs(ctx context.Context, userIDs []uuid.UUID) ([]*models.Profile, error)
 
+	// FindBySocialNames retrieves the profiles with these exact social names; unknown names are skipped
+	FindBySocialNames(ctx context.Context, socialNames []string) ([]*models.Profile, error)
+
 	// Find retrieves profiles matching the filter criteria with pagination
 	Find(ctx context.Context, filter ProfileFilter, limit, offset int) ([]*models.Profile, error)
 
@@ -57,4 +60,8 @@ type ProfileRepository interface {
 
 	// Delete deletes a profile by user ID (soft delete)
 	Delete(ctx context.Context, userID uuid.UUID) error
+
+	// WithTransaction executes fn within a transaction carried by its ctx
+	// This lets a profile change commit together with the events announcing it
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
 }
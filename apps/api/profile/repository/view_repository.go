@@ -0,0 +1,32 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package repository
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// ViewRepository defines data access for profile view tracking
+type ViewRepository interface {
+	// RecordView stores a view, keeping one row per viewer per profile per UTC day
+	RecordView(ctx context.Context, profileID, viewerID uuid.UUID, viewedAt int64) error
+
+	// CountViews returns viewer-days and distinct viewers of a profile since the given time (Unix milliseconds)
+	CountViews(ctx context.Context, profileID uuid.UUID, since int64) (int64, int64, error)
+
+	// RecentVisits returns the latest visit of each viewer since the given time, newest first
+	RecentVisits(ctx context.Context, profileID uuid.UUID, since int64, limit int) ([]models.ProfileVisit, error)
+
+	// PurgeBefore deletes views older than the given time and returns how many it deleted
+	PurgeBefore(ctx context.Context, before int64) (int64, error)
+
+	// DeleteByUser deletes the views of userID's profile and the views userID made.
+	// It joins the transaction carried by ctx, if any.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	authhmac "github.com/qolzam/telar/apps/api/internal/middleware/authhmac"
 	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 )
 
@@ -15,7 +16,9 @@ func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
 }
 
 type ProfileHandlers struct {
-	ProfileHandler *ProfileHandler
+	ProfileHandler  *ProfileHandler
+	ActivityHandler *ActivityHandler
+	ViewHandler     *ViewHandler
 }
 
 type RouterConfig struct {
@@ -40,12 +43,23 @@ func RegisterRoutes(app *fiber.App, handlers *ProfileHandlers, cfg *platformconf
 	// Public search endpoint for autocomplete
 	group.Get("/search", handlers.ProfileHandler.SearchProfiles)
 
+	// Anonymous public profile pages, HTTP cached per social name
+	group.Get("/public/social/:name", httpcache.NewFromConfig(httpcache.ScopeProfiles, publicProfileSubject, cfg.HTTPCache), handlers.ProfileHandler.GetPublicProfile)
+
 	// User-facing routes with JWT/Cookie auth
 	group.Get("/my", dualAuthMiddleware, handlers.ProfileHandler.ReadMyProfile)
+	group.Get("/my/propagation", dualAuthMiddleware, handlers.ProfileHandler.GetMyPropagation)
+	if handlers.ViewHandler != nil {
+		group.Get("/my/views", dualAuthMiddleware, handlers.ViewHandler.GetMyViews)
+	}
 	group.Get("/", dualAuthMiddleware, handlers.ProfileHandler.QueryUserProfile)
 	group.Get("/id/:userId", dualAuthMiddleware, handlers.ProfileHandler.ReadProfile)
 	group.Get("/social/:name", dualAuthMiddleware, handlers.ProfileHandler.GetBySocialName)
 	group.Post("/ids", dualAuthMiddleware, handlers.ProfileHandler.GetProfileByIds)
+	group.Post("/batch", dualAuthMiddleware, handlers.ProfileHandler.GetProfileSummaries)
+	if handlers.ActivityHandler != nil {
+		group.Get("/:socialName/activity", dualAuthMiddleware, handlers.ActivityHandler.GetActivity)
+	}
 	group.Put("/", dualAuthMiddleware, handlers.ProfileHandler.UpdateProfile)
 
 	// Service-to-service routes with HMAC auth
@@ -58,3 +72,9 @@ func RegisterRoutes(app *fiber.App, handlers *ProfileHandlers, cfg *platformconf
 	group.Put("/follow/inc/:inc/:userId", hmacMiddleware, handlers.ProfileHandler.IncreaseFollowCount)
 	group.Put("/follower/inc/:inc/:userId", hmacMiddleware, handlers.ProfileHandler.IncreaseFollowerCount)
 }
+
+// publicProfileSubject keys cached public profiles by social name, so a profile write
+// drops only its own page
+func publicProfileSubject(c *fiber.Ctx) string {
+	return c.Params("name")
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	uuid "github.com/gofrs/uuid"
+	profileErrors "github.com/qolzam/telar/apps/api/profile/errors"
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+const (
+	defaultActivityLimit = 20
+	maxActivityLimit     = 50
+)
+
+// ActivitySource lists one type of a user's public activity, newest first, ordered by
+// createdDate then objectId. Sources are merged into a single timeline by ActivityService.
+type ActivitySource interface {
+	Type() string
+	ListBefore(ctx context.Context, userID uuid.UUID, before *models.ActivityCursor, limit int) ([]models.ActivityItem, error)
+}
+
+// ActivityService builds profile activity timelines by merging its sources
+type ActivityService struct {
+	profiles ProfileService
+	sources  map[string]ActivitySource
+}
+
+// NewActivityService creates an ActivityService over the given sources
+func NewActivityService(profiles ProfileService, sources ...ActivitySource) *ActivityService {
+	bySource := make(map[string]ActivitySource, len(sources))
+	for _, source := range sources {
+		bySource[source.Type()] = source
+	}
+	return &ActivityService{profiles: profiles, sources: bySource}
+}
+
+// Types returns the activity types the service can list
+func (s *ActivityService) Types() []string {
+	types := make([]string, 0, len(s.sources))
+	for t := range s.sources {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// GetActivity returns a page of the public activity of the user with the given social name.
+// Profiles that are not public are only visible to their owner.
+func (s *ActivityService) GetActivity(ctx context.Context, socialName string, viewerID uuid.UUID, query models.ActivityQuery) (*models.ActivityPage, error) {
+	profile, err := s.profiles.GetProfileBySocialName(ctx, socialName)
+	if err != nil {
+		return nil, err
+	}
+	if profile.Permission != "" && profile.Permission != "Public" && profile.ObjectId != viewerID {
+		return nil, profileErrors.ErrProfileNotFound
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultActivityLimit
+	}
+	if limit > maxActivityLimit {
+		limit = maxActivityLimit
+	}
+
+	before, err := DecodeActivityCursor(query.Cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	types := query.Types
+	if len(types) == 0 {
+		types = s.Types()
+	}
+
+	// Each source returns up to limit+1 items after the cursor, so the merged
+	// list tells whether another page exists
+	var items []models.ActivityItem
+	for _, t := range types {
+		source, ok := s.sources[t]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown activity type %q", profileErrors.ErrInvalidFieldValue, t)
+		}
+		sourceItems, err := source.ListBefore(ctx, profile.ObjectId, before, limit+1)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list %s activity: %w", t, err)
+		}
+		items = append(items, sourceItems...)
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].CreatedDate != items[j].CreatedDate {
+			return items[i].CreatedDate > items[j].CreatedDate
+		}
+		return items[i].ObjectId > items[j].ObjectId
+	})
+
+	page := &models.ActivityPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.HasNext = true
+		last := page.Items[limit-1]
+		page.NextCursor = EncodeActivityCursor(models.ActivityCursor{CreatedDate: last.CreatedDate, ObjectId: last.ObjectId})
+	}
+	if page.Items == nil {
+		page.Items = []models.ActivityItem{}
+	}
+	return page, nil
+}
+
+// EncodeActivityCursor encodes a timeline position as base64url "createdDate:objectId"
+func EncodeActivityCursor(cursor models.ActivityCursor) string {
+	return base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", cursor.CreatedDate, cursor.ObjectId)))
+}
+
+// DecodeActivityCursor parses a cursor from EncodeActivityCursor; an empty cursor means the first page
+func DecodeActivityCursor(cursor string) (*models.ActivityCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", profileErrors.ErrInvalidFieldValue)
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: invalid cursor", profileErrors.ErrInvalidFieldValue)
+	}
+	createdDate, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", profileErrors.ErrInvalidFieldValue)
+	}
+	if _, err := uuid.FromString(parts[1]); err != nil {
+		return nil, fmt.Errorf("%w: invalid cursor", profileErrors.ErrInvalidFieldValue)
+	}
+	return &models.ActivityCursor{CreatedDate: createdDate, ObjectId: parts[1]}, nil
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	postsServices "github.com/qolzam/telar/apps/api/posts/services"
+	profileErrors "github.com/qolzam/telar/apps/api/profile/errors"
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// fakeActivitySource serves fixed items, newest first, honouring the cursor
+type fakeActivitySource struct {
+	kind  string
+	items []models.ActivityItem
+}
+
+func (s *fakeActivitySource) Type() string { return s.kind }
+
+func (s *fakeActivitySource) ListBefore(ctx context.Context, userID uuid.UUID, before *models.ActivityCursor, limit int) ([]models.ActivityItem, error) {
+	var out []models.ActivityItem
+	for _, item := range s.items {
+		if before != nil && (item.CreatedDate > before.CreatedDate ||
+			(item.CreatedDate == before.CreatedDate && item.ObjectId >= before.ObjectId)) {
+			continue
+		}
+		out = append(out, item)
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func activityItem(kind string, createdDate int64) models.ActivityItem {
+	return models.ActivityItem{Type: kind, ObjectId: uuid.Must(uuid.NewV4()).String(), CreatedDate: createdDate}
+}
+
+func newTestActivityService(profile *models.Profile) *ActivityService {
+	repo := new(MockProfileRepository)
+	repo.On("FindBySocialName", mock.Anything, profile.SocialName).Return(profile, nil)
+
+	posts := &fakeActivitySource{kind: models.ActivityTypePost, items: []models.ActivityItem{
+		activityItem(models.ActivityTypePost, 500),
+		activityItem(models.ActivityTypePost, 300),
+		activityItem(models.ActivityTypePost, 100),
+	}}
+	comments := &fakeActivitySource{kind: models.ActivityTypeComment, items: []models.ActivityItem{
+		activityItem(models.ActivityTypeComment, 400),
+		activityItem(models.ActivityTypeComment, 200),
+	}}
+
+	profiles := NewProfileService(repo, &platformconfig.Config{})
+	return NewActivityService(profiles, posts, comments)
+}
+
+func TestActivityService_GetActivity_MergesAndPaginates(t *testing.T) {
+	profile := createTestProfile()
+	svc := newTestActivityService(profile)
+	ctx := context.Background()
+
+	first, err := svc.GetActivity(ctx, profile.SocialName, uuid.Nil, models.ActivityQuery{Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, first.Items, 2)
+	assert.Equal(t, []int64{500, 400}, []int64{first.Items[0].CreatedDate, first.Items[1].CreatedDate})
+	assert.Equal(t, models.ActivityTypeComment, first.Items[1].Type)
+	assert.True(t, first.HasNext)
+	require.NotEmpty(t, first.NextCursor)
+
+	second, err := svc.GetActivity(ctx, profile.SocialName, uuid.Nil, models.ActivityQuery{Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{300, 200}, []int64{second.Items[0].CreatedDate, second.Items[1].CreatedDate})
+	assert.True(t, second.HasNext)
+
+	last, err := svc.GetActivity(ctx, profile.SocialName, uuid.Nil, models.ActivityQuery{Limit: 2, Cursor: second.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, last.Items, 1)
+	assert.Equal(t, int64(100), last.Items[0].CreatedDate)
+	assert.False(t, last.HasNext)
+	assert.Empty(t, last.NextCursor)
+}
+
+func TestActivityService_GetActivity_TypeFilter(t *testing.T) {
+	profile := createTestProfile()
+	svc := newTestActivityService(profile)
+
+	page, err := svc.GetActivity(context.Background(), profile.SocialName, uuid.Nil, models.ActivityQuery{Types: []string{models.ActivityTypeComment}})
+	require.NoError(t, err)
+	require.Len(t, page.Items, 2)
+	for _, item := range page.Items {
+		assert.Equal(t, models.ActivityTypeComment, item.Type)
+	}
+
+	_, err = svc.GetActivity(context.Background(), profile.SocialName, uuid.Nil, models.ActivityQuery{Types: []string{"badge"}})
+	assert.ErrorIs(t, err, profileErrors.ErrInvalidFieldValue)
+}
+
+func TestActivityService_GetActivity_PrivateProfile(t *testing.T) {
+	profile := createTestProfile()
+	profile.Permission = "OnlyMe"
+	svc := newTestActivityService(profile)
+
+	_, err := svc.GetActivity(context.Background(), profile.SocialName, uuid.Must(uuid.NewV4()), models.ActivityQuery{})
+	assert.ErrorIs(t, err, profileErrors.ErrProfileNotFound)
+
+	page, err := svc.GetActivity(context.Background(), profile.SocialName, profile.ObjectId, models.ActivityQuery{})
+	require.NoError(t, err)
+	assert.Len(t, page.Items, 5)
+}
+
+func TestDecodeActivityCursor(t *testing.T) {
+	id := uuid.Must(uuid.NewV4()).String()
+	cursor, err := DecodeActivityCursor(EncodeActivityCursor(models.ActivityCursor{CreatedDate: 42, ObjectId: id}))
+	require.NoError(t, err)
+	assert.Equal(t, &models.ActivityCursor{CreatedDate: 42, ObjectId: id}, cursor)
+
+	_, err = DecodeActivityCursor("not-a-cursor")
+	assert.ErrorIs(t, err, profileErrors.ErrInvalidFieldValue)
+}
+
+func TestPostActivitySource_TeasesSupporterOnlyPosts(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	body := strings.Repeat("premium recipe ", 40)
+	repo := &postsServices.MockPostRepository{}
+	repo.On("FindWithCursor", mock.Anything, mock.Anything, (*postsModels.CursorData)(nil), "createdDate", "desc", 10).Return([]*postsModels.Post{
+		{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: userID, Body: body, SupporterOnly: true},
+		{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: userID, Body: body},
+	}, false, nil)
+
+	items, err := NewPostActivitySource(repo, 20).ListBefore(context.Background(), userID, nil, 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	assert.True(t, items[0].Locked)
+	assert.LessOrEqual(t, len([]rune(items[0].Preview)), 21, "the teaser and an ellipsis at most")
+	assert.False(t, items[1].Locked)
+	assert.Greater(t, len([]rune(items[1].Preview)), 200)
+}
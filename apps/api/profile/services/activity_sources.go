@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	achievementsModels "github.com/qolzam/telar/apps/api/achievements/models"
+	achievementsRepository "github.com/qolzam/telar/apps/api/achievements/repository"
+	commentsCommon "github.com/qolzam/telar/apps/api/comments/common"
+	commentsRepository "github.com/qolzam/telar/apps/api/comments/repository"
+	postsCommon "github.com/qolzam/telar/apps/api/posts/common"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	postsRepository "github.com/qolzam/telar/apps/api/posts/repository"
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// activityPreviewLength caps the preview text of timeline items
+const activityPreviewLength = 280
+
+// postActivitySource lists a user's public posts
+type postActivitySource struct {
+	repo         postsRepository.PostRepository
+	teaserLength int
+}
+
+// NewPostActivitySource creates an ActivitySource over the user's public posts. Previews
+// of supporter-only posts are cut to teaserLength, the teaser non-supporters see.
+func NewPostActivitySource(repo postsRepository.PostRepository, teaserLength int) ActivitySource {
+	if teaserLength <= 0 {
+		teaserLength = postsCommon.DefaultPreviewLength
+	}
+	return &postActivitySource{repo: repo, teaserLength: min(teaserLength, activityPreviewLength)}
+}
+
+func (s *postActivitySource) Type() string {
+	return models.ActivityTypePost
+}
+
+func (s *postActivitySource) ListBefore(ctx context.Context, userID uuid.UUID, before *models.ActivityCursor, limit int) ([]models.ActivityItem, error) {
+	public := "Public"
+	filter := postsRepository.PostFilter{
+		OwnerUserID: &userID,
+		Permission:  &public,
+	}
+	var cursor *postsModels.CursorData
+	if before != nil {
+		cursor = &postsModels.CursorData{ID: before.ObjectId, Value: before.CreatedDate}
+	}
+
+	posts, _, err := s.repo.FindWithCursor(ctx, filter, cursor, "createdDate", "desc", limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.ActivityItem, 0, len(posts))
+	for _, post := range posts {
+		// Timelines are public, so supporter-only posts show no more than their teaser
+		previewLength := activityPreviewLength
+		if post.SupporterOnly {
+			previewLength = s.teaserLength
+		}
+		preview, _ := postsCommon.BodyPreview(post.Body, previewLength)
+		items = append(items, models.ActivityItem{
+			Type:           models.ActivityTypePost,
+			ObjectId:       post.ObjectId.String(),
+			CreatedDate:    post.CreatedDate,
+			URLKey:         post.URLKey,
+			Preview:        preview,
+			Locked:         post.SupporterOnly,
+			Score:          post.Score,
+			CommentCounter: post.CommentCounter,
+		})
+	}
+	return items, nil
+}
+
+// commentActivitySource lists a user's comments on public posts
+type commentActivitySource struct {
+	repo commentsRepository.CommentRepository
+}
+
+// NewCommentActivitySource creates an ActivitySource over the user's comments on public posts
+func NewCommentActivitySource(repo commentsRepository.CommentRepository) ActivitySource {
+	return &commentActivitySource{repo: repo}
+}
+
+func (s *commentActivitySource) Type() string {
+	return models.ActivityTypeComment
+}
+
+func (s *commentActivitySource) ListBefore(ctx context.Context, userID uuid.UUID, before *models.ActivityCursor, limit int) ([]models.ActivityItem, error) {
+	var beforeDate int64
+	beforeID := uuid.Nil
+	if before != nil {
+		beforeDate = before.CreatedDate
+		beforeID = uuid.FromStringOrNil(before.ObjectId)
+	}
+
+	comments, err := s.repo.FindPublicByUserBefore(ctx, userID, beforeDate, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.ActivityItem, 0, len(comments))
+	for _, comment := range comments {
+		items = append(items, models.ActivityItem{
+			Type:        models.ActivityTypeComment,
+			ObjectId:    comment.ObjectId.String(),
+			CreatedDate: comment.CreatedDate,
+			PostId:      comment.PostId.String(),
+			Preview:     commentsCommon.GenerateCommentPreview(comment.Text, activityPreviewLength),
+			Score:       comment.Score,
+		})
+	}
+	return items, nil
+}
+
+// badgeActivitySource lists the badges a user earned
+type badgeActivitySource struct {
+	repo   achievementsRepository.BadgeRepository
+	badges map[string]achievementsModels.Badge
+}
+
+// NewBadgeActivitySource creates an ActivitySource over the user's badges; grants of
+// badges missing from the catalog are skipped
+func NewBadgeActivitySource(repo achievementsRepository.BadgeRepository, catalog []achievementsModels.Badge) ActivitySource {
+	badges := make(map[string]achievementsModels.Badge, len(catalog))
+	for _, badge := range catalog {
+		badges[badge.Id] = badge
+	}
+	return &badgeActivitySource{repo: repo, badges: badges}
+}
+
+func (s *badgeActivitySource) Type() string {
+	return models.ActivityTypeBadge
+}
+
+func (s *badgeActivitySource) ListBefore(ctx context.Context, userID uuid.UUID, before *models.ActivityCursor, limit int) ([]models.ActivityItem, error) {
+	var beforeDate int64
+	beforeID := ""
+	if before != nil {
+		beforeDate = before.CreatedDate
+		beforeID = before.ObjectId
+	}
+
+	grants, err := s.repo.ListGrantsBefore(ctx, userID, beforeDate, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]models.ActivityItem, 0, len(grants))
+	for _, grant := range grants {
+		badge, ok := s.badges[grant.BadgeId]
+		if !ok {
+			continue
+		}
+		items = append(items, models.ActivityItem{
+			Type:        models.ActivityTypeBadge,
+			ObjectId:    grant.BadgeId,
+			CreatedDate: grant.GrantedDate,
+			Preview:     badge.Name,
+		})
+	}
+	return items, nil
+}
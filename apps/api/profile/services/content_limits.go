@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
+	profileErrors "github.com/qolzam/telar/apps/api/profile/errors"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetContentLimits lets admins choose the longest bio; without a provider the default
+// limit applies
+func (s *profileService) SetContentLimits(provider sharedInterfaces.ContentLimitsProvider) {
+	s.contentLimits = provider
+}
+
+// checkContentLimits checks a new bio (the profile tagline) against the content limits;
+// nil means the bio is not being changed
+func (s *profileService) checkContentLimits(ctx context.Context, bio *string) error {
+	if bio == nil {
+		return nil
+	}
+	limits := sharedInterfaces.DefaultContentLimits
+	if s.contentLimits != nil {
+		limits = s.contentLimits.ContentLimits(ctx)
+	}
+
+	var v validate.Validator
+	v.MaxLength("tagLine", *bio, limits.Bio)
+	if err := v.Err(); err != nil {
+		return fmt.Errorf("%w: %w", profileErrors.ErrValidationFailed, err)
+	}
+	return nil
+}
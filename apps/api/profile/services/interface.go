@@ -4,8 +4,10 @@ import (
 	"context"
 
 	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	"github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 type ProfileService interface {
@@ -16,6 +18,8 @@ type ProfileService interface {
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.Profile, error)
 	GetProfileBySocialName(ctx context.Context, socialName string) (*models.Profile, error)
 	GetProfilesByIds(ctx context.Context, userIds []uuid.UUID) ([]*models.Profile, error)
+	// GetProfileSummaries returns cached display data of many users, for hydrating lists that carry owner IDs only
+	GetProfileSummaries(ctx context.Context, userIds []uuid.UUID) ([]*models.ProfileSummary, error)
 	GetProfilesBySearch(ctx context.Context, query string, filter *models.ProfileQueryFilter) (*models.ProfilesResponse, error)
 	QueryProfiles(ctx context.Context, filter *models.ProfileQueryFilter) (*models.ProfilesResponse, error)
 	SearchProfiles(ctx context.Context, query string, limit int) ([]*models.Profile, error)
@@ -39,6 +43,19 @@ type ProfileService interface {
 	UpdateFieldsWithOwnership(ctx context.Context, userID uuid.UUID, ownerID uuid.UUID, updates map[string]interface{}) error
 	DeleteWithOwnership(ctx context.Context, userID uuid.UUID, ownerID uuid.UUID) error
 	IncrementFieldsWithOwnership(ctx context.Context, userID uuid.UUID, ownerID uuid.UUID, increments map[string]interface{}) error
+
+	// SetContentLimits enforces the deployment's limit on bio length
+	SetContentLimits(provider sharedInterfaces.ContentLimitsProvider)
+
+	// SetSocialNameRules checks social names at signup and rename against the
+	// deployment's reserved and blocked names
+	SetSocialNameRules(provider sharedInterfaces.SocialNameRulesProvider)
+
+	// SetProfilePropagation announces name and avatar changes through outbox and tracks how
+	// far they were copied into other services in progress, which may be nil
+	SetProfilePropagation(outbox sharedInterfaces.EventOutbox, progress propagation.Repository)
+	// GetPropagationProgress returns how far the latest name and avatar change of userID was copied
+	GetPropagationProgress(ctx context.Context, userID uuid.UUID) ([]propagation.Progress, error)
 }
 
 // ProfileServiceClient is the public interface for profile operations from other services.
@@ -54,4 +71,6 @@ type ProfileServiceClient interface {
 	UpdateProfile(ctx context.Context, userID uuid.UUID, req *models.UpdateProfileRequest) error
 	GetProfile(ctx context.Context, userID uuid.UUID) (*models.Profile, error)
 	GetProfilesByIds(ctx context.Context, userIds []uuid.UUID) ([]*models.Profile, error)
+	// GetProfilesBySocialNames resolves exact social names, such as @mentions; unknown names are skipped
+	GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*models.Profile, error)
 }
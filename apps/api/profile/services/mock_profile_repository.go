@@ -48,6 +48,14 @@ func (m *MockProfileRepository) FindByIDs(ctx context.Context, userIDs []uuid.UU
 	return args.Get(0).([]*models.Profile), args.Error(1)
 }
 
+func (m *MockProfileRepository) FindBySocialNames(ctx context.Context, socialNames []string) ([]*models.Profile, error) {
+	args := m.Called(ctx, socialNames)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Profile), args.Error(1)
+}
+
 func (m *MockProfileRepository) Find(ctx context.Context, filter repository.ProfileFilter, limit, offset int) ([]*models.Profile, error) {
 	args := m.Called(ctx, filter, limit, offset)
 	if args.Get(0) == nil {
@@ -88,3 +96,14 @@ func (m *MockProfileRepository) Delete(ctx context.Context, userID uuid.UUID) er
 	args := m.Called(ctx, userID)
 	return args.Error(0)
 }
+
+func (m *MockProfileRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	args := m.Called(ctx, fn)
+	// Execute the function within the mock
+	if fn != nil {
+		if err := fn(ctx); err != nil {
+			return err
+		}
+	}
+	return args.Error(0)
+}
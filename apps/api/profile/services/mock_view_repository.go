@@ -0,0 +1,50 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/profile/models"
+	"github.com/qolzam/telar/apps/api/profile/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockViewRepository is a mock implementation of the ViewRepository interface
+type MockViewRepository struct {
+	mock.Mock
+}
+
+var _ repository.ViewRepository = (*MockViewRepository)(nil)
+
+func (m *MockViewRepository) RecordView(ctx context.Context, profileID, viewerID uuid.UUID, viewedAt int64) error {
+	args := m.Called(ctx, profileID, viewerID, viewedAt)
+	return args.Error(0)
+}
+
+func (m *MockViewRepository) CountViews(ctx context.Context, profileID uuid.UUID, since int64) (int64, int64, error) {
+	args := m.Called(ctx, profileID, since)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockViewRepository) RecentVisits(ctx context.Context, profileID uuid.UUID, since int64, limit int) ([]models.ProfileVisit, error) {
+	args := m.Called(ctx, profileID, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.ProfileVisit), args.Error(1)
+}
+
+func (m *MockViewRepository) PurgeBefore(ctx context.Context, before int64) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockViewRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
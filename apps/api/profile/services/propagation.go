@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
+	"github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// SetProfilePropagation makes name and avatar changes announce themselves through the
+// transactional outbox, so posts, comments and mentions copy them, and tracks their
+// progress in progress. Without it those services keep the names they stored.
+func (s *profileService) SetProfilePropagation(outbox sharedInterfaces.EventOutbox, progress propagation.Repository) {
+	s.outbox = outbox
+	s.progress = progress
+}
+
+// GetPropagationProgress returns how far the latest name and avatar change of userID has
+// been copied into each service; empty when it is not tracked
+func (s *profileService) GetPropagationProgress(ctx context.Context, userID uuid.UUID) ([]propagation.Progress, error) {
+	if s.progress == nil {
+		return []propagation.Progress{}, nil
+	}
+	return s.progress.List(ctx, userID)
+}
+
+// saveProfile updates profile, which showed fullName and avatar before. A changed name or
+// avatar is announced in the same transaction when propagation is set up.
+func (s *profileService) saveProfile(ctx context.Context, profile *models.Profile, fullName, avatar string) error {
+	if s.outbox == nil || (profile.FullName == fullName && profile.Avatar == avatar) {
+		return s.repo.Update(ctx, profile)
+	}
+	return s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, profile); err != nil {
+			return err
+		}
+		event := sharedInterfaces.ProfileUpdatedEvent{
+			UserId:   profile.ObjectId,
+			FullName: profile.FullName,
+			Avatar:   profile.Avatar,
+			Version:  profile.UpdatedAt.UnixMilli(),
+		}
+		if err := s.outbox.Append(txCtx, sharedInterfaces.OutboxProfileUpdated, profile.ObjectId.String(), event); err != nil {
+			return fmt.Errorf("failed to announce profile change: %w", err)
+		}
+		if s.progress != nil {
+			return s.progress.Start(txCtx, profile.ObjectId, event.Version, propagation.Targets)
+		}
+		return nil
+	})
+}
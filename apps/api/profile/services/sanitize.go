@@ -0,0 +1,34 @@
+package services
+
+import (
+	"github.com/qolzam/telar/apps/api/internal/pkg/sanitize"
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// sanitizeCreateRequest returns a copy of req with its name, tagline and company cleaned
+func sanitizeCreateRequest(req *models.CreateProfileRequest) *models.CreateProfileRequest {
+	sanitized := *req
+	sanitized.FullName = cleaned(req.FullName, sanitize.Line)
+	sanitized.TagLine = cleaned(req.TagLine, sanitize.Text)
+	sanitized.CompanyName = cleaned(req.CompanyName, sanitize.Line)
+	return &sanitized
+}
+
+// sanitizeUpdateRequest returns a copy of req with the name, tagline and company it
+// changes cleaned
+func sanitizeUpdateRequest(req *models.UpdateProfileRequest) *models.UpdateProfileRequest {
+	sanitized := *req
+	sanitized.FullName = cleaned(req.FullName, sanitize.Line)
+	sanitized.TagLine = cleaned(req.TagLine, sanitize.Text)
+	sanitized.CompanyName = cleaned(req.CompanyName, sanitize.Line)
+	return &sanitized
+}
+
+// cleaned applies clean to an optional field; nil stays nil
+func cleaned(value *string, clean func(string) string) *string {
+	if value == nil {
+		return nil
+	}
+	v := clean(*value)
+	return &v
+}
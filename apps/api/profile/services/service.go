@@ -13,18 +13,27 @@ import (
 
 	uuid "github.com/gofrs/uuid"
 	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/internal/cache"
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/internal/propagation"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	"github.com/qolzam/telar/apps/api/internal/utils"
 	profileErrors "github.com/qolzam/telar/apps/api/profile/errors"
 	"github.com/qolzam/telar/apps/api/profile/models"
 	"github.com/qolzam/telar/apps/api/profile/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // profileService implements the ProfileService interface
 type profileService struct {
-	repo   repository.ProfileRepository
-	config *platformconfig.Config
+	repo          repository.ProfileRepository
+	config        *platformconfig.Config
+	contentLimits sharedInterfaces.ContentLimitsProvider   // nil until SetContentLimits; the default limits apply
+	socialNames   sharedInterfaces.SocialNameRulesProvider // nil until SetSocialNameRules; the default rules apply
+	summaryCache  *cache.GenericCacheService               // nil when caching is disabled
+	outbox        sharedInterfaces.EventOutbox             // nil until SetProfilePropagation; name changes stay local
+	progress      propagation.Repository                   // nil unless propagation progress is tracked
 }
 
 // Ensure profileService implements ProfileService interface
@@ -32,10 +41,14 @@ var _ ProfileService = (*profileService)(nil)
 
 // NewProfileService creates a new ProfileService with the given repository
 func NewProfileService(repo repository.ProfileRepository, cfg *platformconfig.Config) ProfileService {
-	return &profileService{
+	svc := &profileService{
 		repo:   repo,
 		config: cfg,
 	}
+	if cfg != nil && cfg.Cache.Enabled {
+		svc.summaryCache = cache.NewGenericCacheServiceFor("profiles")
+	}
+	return svc
 }
 
 // CreateProfile creates a new profile
@@ -46,6 +59,13 @@ func (s *profileService) CreateProfile(ctx context.Context, req *models.CreatePr
 	if user == nil {
 		return nil, fmt.Errorf("user context is required")
 	}
+	req = sanitizeCreateRequest(req)
+	if err := s.checkContentLimits(ctx, req.TagLine); err != nil {
+		return nil, err
+	}
+	if err := s.checkSocialName(ctx, getStringValue(req.SocialName)); err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
 	createdDate := utils.UTCNowUnix()
@@ -254,6 +274,11 @@ func (s *profileService) UpdateProfile(ctx context.Context, userID uuid.UUID, re
 
 // updateProfileInternal is the internal implementation for updating a profile
 func (s *profileService) updateProfileInternal(ctx context.Context, userID uuid.UUID, req *models.UpdateProfileRequest) error {
+	req = sanitizeUpdateRequest(req)
+	if err := s.checkContentLimits(ctx, req.TagLine); err != nil {
+		return err
+	}
+
 	// Load existing profile
 	profile, err := s.repo.FindByID(ctx, userID)
 	if err != nil {
@@ -262,6 +287,7 @@ func (s *profileService) updateProfileInternal(ctx context.Context, userID uuid.
 		}
 		return fmt.Errorf("failed to get profile: %w", err)
 	}
+	fullName, avatar, socialName := profile.FullName, profile.Avatar, profile.SocialName
 
 	// Apply updates
 	if req.FullName != nil {
@@ -276,7 +302,11 @@ func (s *profileService) updateProfileInternal(ctx context.Context, userID uuid.
 	if req.TagLine != nil {
 		profile.Tagline = *req.TagLine
 	}
-	if req.SocialName != nil {
+	if req.SocialName != nil && *req.SocialName != profile.SocialName {
+		// Only a rename is checked, so names taken before a rule was added keep working
+		if err := s.checkSocialName(ctx, *req.SocialName); err != nil {
+			return err
+		}
 		profile.SocialName = *req.SocialName
 	}
 	if req.WebUrl != nil {
@@ -296,7 +326,7 @@ func (s *profileService) updateProfileInternal(ctx context.Context, userID uuid.
 	}
 
 	// Save updated profile
-	err = s.repo.Update(ctx, profile)
+	err = s.saveProfile(ctx, profile, fullName, avatar)
 	if err != nil {
 		// Handle unique constraint violation on social_name
 		if strings.Contains(err.Error(), "social name already exists") {
@@ -305,6 +335,8 @@ func (s *profileService) updateProfileInternal(ctx context.Context, userID uuid.
 		return fmt.Errorf("failed to update profile: %w", err)
 	}
 
+	s.invalidateSummary(ctx, userID)
+	httpcache.InvalidateSubjects(ctx, httpcache.ScopeProfiles, socialName, profile.SocialName)
 	return nil
 }
 
@@ -323,6 +355,7 @@ func (s *profileService) UpdateProfileFields(ctx context.Context, userID uuid.UU
 		}
 		return fmt.Errorf("failed to get profile: %w", err)
 	}
+	fullName, avatar, socialName := profile.FullName, profile.Avatar, profile.SocialName
 
 	// Apply updates
 	for key, value := range updates {
@@ -409,7 +442,7 @@ func (s *profileService) UpdateProfileFields(ctx context.Context, userID uuid.UU
 	}
 
 	// Save updated profile
-	err = s.repo.Update(ctx, profile)
+	err = s.saveProfile(ctx, profile, fullName, avatar)
 	if err != nil {
 		if strings.Contains(err.Error(), "social name already exists") {
 			return profileErrors.ErrProfileAlreadyExists
@@ -417,6 +450,8 @@ func (s *profileService) UpdateProfileFields(ctx context.Context, userID uuid.UU
 		return fmt.Errorf("failed to update profile: %w", err)
 	}
 
+	s.invalidateSummary(ctx, userID)
+	httpcache.InvalidateSubjects(ctx, httpcache.ScopeProfiles, socialName, profile.SocialName)
 	return nil
 }
 
@@ -430,8 +465,20 @@ func (s *profileService) DeleteProfile(ctx context.Context, userID uuid.UUID, us
 	if err := s.ValidateProfileOwnership(ctx, userID, user); err != nil {
 		return err
 	}
+	profile, err := s.repo.FindByID(ctx, userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "profile not found") {
+			return profileErrors.ErrProfileNotFound
+		}
+		return fmt.Errorf("failed to get profile: %w", err)
+	}
 
-	return s.repo.Delete(ctx, userID)
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	s.invalidateSummary(ctx, userID)
+	httpcache.InvalidateSubjects(ctx, httpcache.ScopeProfiles, profile.SocialName)
+	return nil
 }
 
 // SoftDeleteProfile is not applicable for profiles (no soft delete field)
@@ -670,7 +717,12 @@ func (s *profileService) DeleteWithOwnership(ctx context.Context, userID uuid.UU
 		return profileErrors.ErrProfileOwnershipRequired
 	}
 
-	return s.repo.Delete(ctx, userID)
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	s.invalidateSummary(ctx, userID)
+	httpcache.InvalidateSubjects(ctx, httpcache.ScopeProfiles, profile.SocialName)
+	return nil
 }
 
 // IncrementFieldsWithOwnership increments fields with ownership validation
@@ -698,6 +750,7 @@ func (s *profileService) CreateProfileOnSignup(ctx context.Context, req *models.
 	if req == nil {
 		return fmt.Errorf("create profile request is required")
 	}
+	req.SocialName = s.signupSocialName(ctx, req.SocialName, req.ObjectId.String())
 
 	// Use CreateOrUpdateDTO logic: try to create, if exists then update
 	profile, err := s.repo.FindByID(ctx, req.ObjectId)
@@ -811,6 +864,11 @@ func (s *profileService) GetProfilesByIds(ctx context.Context, userIds []uuid.UU
 	return s.repo.FindByIDs(ctx, userIds)
 }
 
+// GetProfilesBySocialNames retrieves the profiles with these exact social names (for ProfileServiceClient interface)
+func (s *profileService) GetProfilesBySocialNames(ctx context.Context, socialNames []string) ([]*models.Profile, error) {
+	return s.repo.FindBySocialNames(ctx, socialNames)
+}
+
 // GetProfilesByIdsClient retrieves multiple profiles by user IDs (for ProfileServiceClient interface)
 func (s *profileService) GetProfilesByIdsClient(ctx context.Context, userIds []uuid.UUID) ([]*models.Profile, error) {
 	return s.GetProfilesByIds(ctx, userIds)
@@ -16,10 +16,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/qolzam/telar/apps/api/internal/cache"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	profileErrors "github.com/qolzam/telar/apps/api/profile/errors"
 	"github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // Test helper functions
@@ -333,8 +335,9 @@ func TestDeleteProfile_ValidOwnership_Success(t *testing.T) {
 	user := createTestUserContext()
 	userID := user.UserID
 
-	// DeleteProfile validates ownership first, then calls Delete
-	// Ownership validation doesn't call FindByID, it just checks user.UserID == userID
+	// DeleteProfile validates ownership first, then reads the social name whose public
+	// page it drops and calls Delete
+	mockRepo.On("FindByID", ctx, userID).Return(&models.Profile{ObjectId: userID, SocialName: "jane"}, nil)
 	mockRepo.On("Delete", ctx, userID).Return(nil)
 
 	err := service.DeleteProfile(ctx, userID, user)
@@ -452,6 +455,56 @@ func TestGetProfilesByIds_ValidIDs_ReturnsProfiles(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+// Test GetProfileSummaries
+func TestGetProfileSummaries_KeepsRequestOrderAndSkipsUnknown(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+
+	first := createTestProfile()
+	second := createTestProfile()
+	unknown := uuid.Must(uuid.NewV4())
+
+	mockRepo.On("FindByIDs", ctx, []uuid.UUID{second.ObjectId, unknown, first.ObjectId}).Return([]*models.Profile{first, second}, nil)
+
+	result, err := service.GetProfileSummaries(ctx, []uuid.UUID{second.ObjectId, unknown, first.ObjectId, second.ObjectId})
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 2)
+	assert.Equal(t, second.ObjectId, result[0].ObjectId)
+	assert.Equal(t, first.ObjectId, result[1].ObjectId)
+	assert.Equal(t, first.FullName, result[1].FullName)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetProfileSummaries_CachedUntilProfileChanges(t *testing.T) {
+	service, mockRepo := setupTestService()
+	service.summaryCache = cache.NewGenericCacheServiceFor("profiles-test")
+	ctx := context.Background()
+
+	profile := createTestProfile()
+	ids := []uuid.UUID{profile.ObjectId}
+	mockRepo.On("FindByIDs", ctx, ids).Return([]*models.Profile{profile}, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		result, err := service.GetProfileSummaries(ctx, ids)
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	}
+	mockRepo.AssertNumberOfCalls(t, "FindByIDs", 1)
+
+	renamed := *profile
+	renamed.FullName = "Renamed User"
+	mockRepo.On("FindByID", ctx, profile.ObjectId).Return(profile, nil).Once()
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Profile")).Return(nil).Once()
+	assert.NoError(t, service.UpdateProfileFields(ctx, profile.ObjectId, map[string]interface{}{"fullName": "Renamed User"}))
+
+	mockRepo.On("FindByIDs", ctx, ids).Return([]*models.Profile{&renamed}, nil).Once()
+	result, err := service.GetProfileSummaries(ctx, ids)
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed User", result[0].FullName)
+	mockRepo.AssertExpectations(t)
+}
+
 // Test IncrementFields
 func TestIncrementFields_ValidFields_Success(t *testing.T) {
 	service, mockRepo := setupTestService()
@@ -743,3 +796,67 @@ func TestUpdateByOwner_InvalidOwnership_ReturnsError(t *testing.T) {
 	mockRepo.AssertNotCalled(t, "Update")
 }
 
+
+// recordingOutbox records appended events
+type recordingOutbox struct {
+	types []string
+	data  []interface{}
+}
+
+func (o *recordingOutbox) Append(ctx context.Context, eventType string, key string, data interface{}) error {
+	o.types = append(o.types, eventType)
+	o.data = append(o.data, data)
+	return nil
+}
+
+func TestUpdateProfile_NameChange_AnnouncedThroughOutbox(t *testing.T) {
+	service, mockRepo := setupTestService()
+	outbox := &recordingOutbox{}
+	service.SetProfilePropagation(outbox, nil)
+	ctx := context.Background()
+	user := createTestUserContext()
+
+	fullName := "Updated Name"
+	existingProfile := createTestProfile()
+	existingProfile.ObjectId = user.UserID
+	updatedAt := time.Now()
+
+	mockRepo.On("FindByID", ctx, user.UserID).Return(existingProfile, nil)
+	mockRepo.On("WithTransaction", ctx, mock.Anything).Return(nil)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Profile")).Run(func(args mock.Arguments) {
+		args.Get(1).(*models.Profile).UpdatedAt = updatedAt
+	}).Return(nil)
+
+	err := service.UpdateProfile(ctx, user.UserID, &models.UpdateProfileRequest{FullName: &fullName}, user)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{sharedInterfaces.OutboxProfileUpdated}, outbox.types)
+	assert.Equal(t, sharedInterfaces.ProfileUpdatedEvent{
+		UserId:   user.UserID,
+		FullName: fullName,
+		Avatar:   existingProfile.Avatar,
+		Version:  updatedAt.UnixMilli(),
+	}, outbox.data[0])
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateProfile_NameUnchanged_NotAnnounced(t *testing.T) {
+	service, mockRepo := setupTestService()
+	outbox := &recordingOutbox{}
+	service.SetProfilePropagation(outbox, nil)
+	ctx := context.Background()
+	user := createTestUserContext()
+
+	tagLine := "New tagline"
+	existingProfile := createTestProfile()
+	existingProfile.ObjectId = user.UserID
+
+	mockRepo.On("FindByID", ctx, user.UserID).Return(existingProfile, nil)
+	mockRepo.On("Update", ctx, mock.AnythingOfType("*models.Profile")).Return(nil)
+
+	err := service.UpdateProfile(ctx, user.UserID, &models.UpdateProfileRequest{TagLine: &tagLine}, user)
+
+	assert.NoError(t, err)
+	assert.Empty(t, outbox.types)
+	mockRepo.AssertNotCalled(t, "WithTransaction", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/validate"
+	profileErrors "github.com/qolzam/telar/apps/api/profile/errors"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// lookalikes maps characters that read as a Latin letter to that letter: digits and
+// symbols used in place of letters, and Cyrillic and Greek homoglyphs
+var lookalikes = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '8': 'b',
+	'@': 'a', '$': 's', '!': 'i', '|': 'i', 'l': 'i',
+	'а': 'a', 'в': 'b', 'е': 'e', 'ё': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o',
+	'р': 'p', 'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ї': 'i', 'ј': 'j',
+	'ѕ': 's', 'ԁ': 'd', 'ԛ': 'q', 'ԝ': 'w',
+	'α': 'a', 'β': 'b', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v', 'ο': 'o',
+	'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x',
+}
+
+// SetSocialNameRules lets admins choose the reserved and blocked social names; without
+// a provider the default rules apply
+func (s *profileService) SetSocialNameRules(provider sharedInterfaces.SocialNameRulesProvider) {
+	s.socialNames = provider
+}
+
+// checkSocialName rejects a social name that folds to a reserved name or contains a
+// blocked word
+func (s *profileService) checkSocialName(ctx context.Context, name string) error {
+	var v validate.Validator
+	switch s.socialNameRule(ctx, name) {
+	case "reserved":
+		v.Add("socialName", "is reserved")
+	case "blocked":
+		v.Add("socialName", "contains a word that is not allowed")
+	}
+	if err := v.Err(); err != nil {
+		return fmt.Errorf("%w: %w", profileErrors.ErrValidationFailed, err)
+	}
+	return nil
+}
+
+// socialNameRule returns the rule name breaks, or "" when it is allowed
+func (s *profileService) socialNameRule(ctx context.Context, name string) string {
+	folded := foldSocialName(name)
+	if folded == "" {
+		return ""
+	}
+	rules := sharedInterfaces.DefaultSocialNameRules
+	if s.socialNames != nil {
+		rules = s.socialNames.SocialNameRules(ctx)
+	}
+
+	for _, reserved := range rules.Reserved {
+		if folded == foldSocialName(reserved) {
+			return "reserved"
+		}
+	}
+	for _, blocked := range rules.Blocked {
+		if word := foldSocialName(blocked); word != "" && strings.Contains(folded, word) {
+			return "blocked"
+		}
+	}
+	return ""
+}
+
+// signupSocialName returns the generated social name of a new account, or a neutral
+// one when the generated name breaks the rules; signup never fails on it
+func (s *profileService) signupSocialName(ctx context.Context, name *string, userID string) *string {
+	if name == nil || s.socialNameRule(ctx, *name) == "" {
+		return name
+	}
+	neutral := "user"
+	if len(userID) >= 8 {
+		neutral += "_" + userID[:8]
+	}
+	return &neutral
+}
+
+// foldSocialName reduces name to the plain lowercase letters and digits it reads as:
+// accents and styled forms are dropped, look-alikes replaced and separators removed,
+// so "Ad-m1n" and "аdmin" with a Cyrillic а both fold to "admin"
+func foldSocialName(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(strings.ToLower(name)) {
+		if mapped, ok := lookalikes[r]; ok {
+			r = mapped
+		}
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
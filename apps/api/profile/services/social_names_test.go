@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	profileErrors "github.com/qolzam/telar/apps/api/profile/errors"
+	"github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// staticSocialNames serves fixed social name rules
+type staticSocialNames sharedInterfaces.SocialNameRules
+
+func (r staticSocialNames) SocialNameRules(ctx context.Context) sharedInterfaces.SocialNameRules {
+	return sharedInterfaces.SocialNameRules(r)
+}
+
+func TestFoldSocialName(t *testing.T) {
+	tests := map[string]string{
+		"Ad-m1n":    "admin",
+		"аdmin":     "admin", // Cyrillic а
+		"ＡＤＭＩＮ":     "admin", // fullwidth
+		"sÿstem_":   "system",
+		"r00t.user": "rootuser",
+		"__":        "",
+	}
+	for input, want := range tests {
+		assert.Equal(t, want, foldSocialName(input), input)
+	}
+}
+
+func TestCheckSocialName(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := setupTestService()
+	svc.SetSocialNameRules(staticSocialNames{Reserved: []string{"admin", "help"}, Blocked: []string{"badword"}})
+
+	t.Run("rejects reserved names and their look-alikes", func(t *testing.T) {
+		for _, name := range []string{"admin", "Adm1n", "аdmin", "h-e-l-p"} {
+			assert.ErrorIs(t, svc.checkSocialName(ctx, name), profileErrors.ErrValidationFailed, name)
+		}
+	})
+
+	t.Run("rejects names containing a blocked word", func(t *testing.T) {
+		assert.ErrorIs(t, svc.checkSocialName(ctx, "the_b4dw0rd_guy"), profileErrors.ErrValidationFailed)
+	})
+
+	t.Run("allows names that only contain a reserved name", func(t *testing.T) {
+		assert.NoError(t, svc.checkSocialName(ctx, "admin_fan"))
+		assert.NoError(t, svc.checkSocialName(ctx, "jane-doe"))
+	})
+}
+
+func TestUpdateProfile_RenameToReservedName_ReturnsError(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+	user := createTestUserContext()
+
+	existingProfile := createTestProfile()
+	existingProfile.ObjectId = user.UserID
+	mockRepo.On("FindByID", ctx, user.UserID).Return(existingProfile, nil)
+
+	socialName := "supp0rt"
+	err := service.UpdateProfile(ctx, user.UserID, &models.UpdateProfileRequest{SocialName: &socialName}, user)
+
+	assert.True(t, errors.Is(err, profileErrors.ErrValidationFailed))
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestCreateProfileOnSignup_DisallowedName_UsesNeutralName(t *testing.T) {
+	service, mockRepo := setupTestService()
+	ctx := context.Background()
+	userID := uuid.Must(uuid.FromString("0a1b2c3d-0000-4000-8000-000000000000"))
+
+	mockRepo.On("FindByID", ctx, userID).Return(nil, errors.New("profile not found"))
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(profile *models.Profile) bool {
+		return profile.SocialName == "user_0a1b2c3d"
+	})).Return(nil)
+
+	socialName := "admin"
+	err := service.CreateProfileOnSignup(ctx, &models.CreateProfileRequest{ObjectId: userID, SocialName: &socialName})
+
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
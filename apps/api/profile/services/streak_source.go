@@ -0,0 +1,35 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/profile/models"
+	streaksServices "github.com/qolzam/telar/apps/api/streaks/services"
+)
+
+// StreakSource provides the activity streaks shown on profiles
+type StreakSource interface {
+	GetStreak(ctx context.Context, userID uuid.UUID) (*models.ProfileStreak, error)
+}
+
+type streakSource struct {
+	streaks streaksServices.Service
+}
+
+// NewStreakSource creates a StreakSource over the streaks service
+func NewStreakSource(streaks streaksServices.Service) StreakSource {
+	return &streakSource{streaks: streaks}
+}
+
+func (s *streakSource) GetStreak(ctx context.Context, userID uuid.UUID) (*models.ProfileStreak, error) {
+	streak, err := s.streaks.GetStreak(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ProfileStreak{
+		Current: streak.Current,
+		Longest: streak.Longest,
+		AtRisk:  streak.AtRisk,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// summaryCacheTTL bounds how long a summary can outlive a profile change made on another instance
+const summaryCacheTTL = 5 * time.Minute
+
+func summaryCacheKey(userID uuid.UUID) string {
+	return "summary:" + userID.String()
+}
+
+// GetProfileSummaries returns the display data of the users with these IDs, in the order of the
+// first occurrence of each ID. Unknown IDs are left out. Summaries are cached until the profile changes.
+func (s *profileService) GetProfileSummaries(ctx context.Context, userIds []uuid.UUID) ([]*models.ProfileSummary, error) {
+	found := make(map[uuid.UUID]*models.ProfileSummary, len(userIds))
+	var missing []uuid.UUID
+	for _, id := range userIds {
+		if _, ok := found[id]; ok {
+			continue
+		}
+		found[id] = nil
+		if s.summaryCache != nil {
+			var cached models.ProfileSummary
+			if err := s.summaryCache.GetCached(ctx, summaryCacheKey(id), &cached); err == nil {
+				found[id] = &cached
+				continue
+			}
+		}
+		missing = append(missing, id)
+	}
+
+	if len(missing) > 0 {
+		profiles, err := s.repo.FindByIDs(ctx, missing)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get profiles: %w", err)
+		}
+		for _, profile := range profiles {
+			summary := &models.ProfileSummary{
+				ObjectId:   profile.ObjectId,
+				FullName:   profile.FullName,
+				SocialName: profile.SocialName,
+				Avatar:     profile.Avatar,
+			}
+			found[profile.ObjectId] = summary
+			if s.summaryCache != nil {
+				_ = s.summaryCache.CacheData(ctx, summaryCacheKey(profile.ObjectId), summary, summaryCacheTTL)
+			}
+		}
+	}
+
+	summaries := make([]*models.ProfileSummary, 0, len(found))
+	for _, id := range userIds {
+		if summary := found[id]; summary != nil {
+			summaries = append(summaries, summary)
+			found[id] = nil
+		}
+	}
+	return summaries, nil
+}
+
+// invalidateSummary drops the cached summary of a profile after it changed
+func (s *profileService) invalidateSummary(ctx context.Context, userID uuid.UUID) {
+	if s.summaryCache != nil {
+		_ = s.summaryCache.InvalidateKey(ctx, summaryCacheKey(userID))
+	}
+}
@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	profileErrors "github.com/qolzam/telar/apps/api/profile/errors"
+	"github.com/qolzam/telar/apps/api/profile/models"
+	"github.com/qolzam/telar/apps/api/profile/repository"
+)
+
+const (
+	defaultViewDays    = 30
+	maxRevealedViewers = 50
+)
+
+// ViewPrivacy reports which users opted in to sharing profile views; implemented by the settings service
+type ViewPrivacy interface {
+	ProfileViewSharing(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+}
+
+// ViewService tracks who viewed a profile. Owners always get counts; a viewer is only
+// revealed when both the owner and the viewer share profile views.
+type ViewService interface {
+	// RecordView stores a view of profileID by viewerID; a no-op when tracking is off or users view themselves
+	RecordView(ctx context.Context, profileID, viewerID uuid.UUID) error
+
+	// GetViews returns the views of the owner's profile over the last days
+	GetViews(ctx context.Context, ownerID uuid.UUID, days int) (*models.ProfileViewsResponse, error)
+
+	// Purge deletes views older than the retention period
+	Purge(ctx context.Context) (int64, error)
+
+	// Start runs Purge every configured interval until ctx is done; a no-op when disabled
+	Start(ctx context.Context)
+}
+
+type viewService struct {
+	repo     repository.ViewRepository
+	profiles ProfileService
+	privacy  ViewPrivacy
+	cfg      platformconfig.ProfileViewsConfig
+	now      func() time.Time
+}
+
+// NewViewService creates the profile view tracking service
+func NewViewService(repo repository.ViewRepository, profiles ProfileService, privacy ViewPrivacy, cfg platformconfig.ProfileViewsConfig) ViewService {
+	return &viewService{repo: repo, profiles: profiles, privacy: privacy, cfg: cfg, now: time.Now}
+}
+
+func (s *viewService) RecordView(ctx context.Context, profileID, viewerID uuid.UUID) error {
+	if !s.cfg.Enabled || viewerID == uuid.Nil || profileID == viewerID {
+		return nil
+	}
+	return s.repo.RecordView(ctx, profileID, viewerID, s.now().UTC().UnixMilli())
+}
+
+func (s *viewService) GetViews(ctx context.Context, ownerID uuid.UUID, days int) (*models.ProfileViewsResponse, error) {
+	if days <= 0 {
+		days = defaultViewDays
+	}
+	if maxDays := int(s.cfg.Retention / (24 * time.Hour)); maxDays > 0 && days > maxDays {
+		days = maxDays
+	}
+	since := s.now().Add(-time.Duration(days) * 24 * time.Hour).UTC().UnixMilli()
+
+	views, viewers, err := s.repo.CountViews(ctx, ownerID, since)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", profileErrors.ErrDatabaseOperation, err)
+	}
+
+	result := &models.ProfileViewsResponse{
+		Days:          days,
+		Views:         views,
+		Viewers:       viewers,
+		RecentViewers: []models.ProfileViewer{},
+		HiddenViewers: viewers,
+	}
+
+	sharing, err := s.privacy.ProfileViewSharing(ctx, []uuid.UUID{ownerID})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", profileErrors.ErrServiceUnavailable, err)
+	}
+	result.ShareProfileViews = sharing[ownerID]
+	if !result.ShareProfileViews || viewers == 0 {
+		return result, nil
+	}
+
+	visits, err := s.repo.RecentVisits(ctx, ownerID, since, maxRevealedViewers)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", profileErrors.ErrDatabaseOperation, err)
+	}
+	viewerIDs := make([]uuid.UUID, len(visits))
+	for i, visit := range visits {
+		viewerIDs[i] = visit.ViewerID
+	}
+	if sharing, err = s.privacy.ProfileViewSharing(ctx, viewerIDs); err != nil {
+		return nil, fmt.Errorf("%w: %v", profileErrors.ErrServiceUnavailable, err)
+	}
+
+	var revealed []uuid.UUID
+	for _, id := range viewerIDs {
+		if sharing[id] {
+			revealed = append(revealed, id)
+		}
+	}
+	if len(revealed) == 0 {
+		return result, nil
+	}
+
+	profiles, err := s.profiles.GetProfilesByIds(ctx, revealed)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*models.Profile, len(profiles))
+	for _, p := range profiles {
+		byID[p.ObjectId] = p
+	}
+	for _, visit := range visits {
+		p, ok := byID[visit.ViewerID]
+		if !ok {
+			continue
+		}
+		result.RecentViewers = append(result.RecentViewers, models.ProfileViewer{
+			UserId:     p.ObjectId.String(),
+			FullName:   p.FullName,
+			SocialName: p.SocialName,
+			Avatar:     p.Avatar,
+			LastViewed: visit.LastViewed,
+		})
+	}
+	result.HiddenViewers = viewers - int64(len(result.RecentViewers))
+	return result, nil
+}
+
+func (s *viewService) Purge(ctx context.Context) (int64, error) {
+	if s.cfg.Retention <= 0 {
+		return 0, nil
+	}
+	return s.repo.PurgeBefore(ctx, s.now().Add(-s.cfg.Retention).UTC().UnixMilli())
+}
+
+func (s *viewService) Start(ctx context.Context) {
+	if !s.cfg.Enabled || s.cfg.PurgeInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(s.cfg.PurgeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if purged, err := s.Purge(ctx); err != nil {
+					log.Error("Profile view purge failed: %v", err)
+				} else if purged > 0 {
+					log.Info("Purged %d expired profile views", purged)
+				}
+			}
+		}
+	}()
+}
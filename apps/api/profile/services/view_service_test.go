@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/profile/models"
+)
+
+// staticViewPrivacy reports a fixed set of users as sharing profile views
+type staticViewPrivacy map[uuid.UUID]bool
+
+func (p staticViewPrivacy) ProfileViewSharing(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	sharing := make(map[uuid.UUID]bool)
+	for _, id := range userIDs {
+		if p[id] {
+			sharing[id] = true
+		}
+	}
+	return sharing, nil
+}
+
+var testViewNow = time.UnixMilli(1700000000000)
+
+func newTestViewService(repo *MockViewRepository, profileRepo *MockProfileRepository, privacy staticViewPrivacy) *viewService {
+	cfg := platformconfig.ProfileViewsConfig{Enabled: true, Retention: 90 * 24 * time.Hour}
+	svc := NewViewService(repo, NewProfileService(profileRepo, &platformconfig.Config{}), privacy, cfg).(*viewService)
+	svc.now = func() time.Time { return testViewNow }
+	return svc
+}
+
+func TestViewService_RecordView(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	viewer := uuid.Must(uuid.NewV4())
+
+	repo := new(MockViewRepository)
+	repo.On("RecordView", ctx, owner, viewer, testViewNow.UnixMilli()).Return(nil).Once()
+	svc := newTestViewService(repo, new(MockProfileRepository), nil)
+
+	require.NoError(t, svc.RecordView(ctx, owner, viewer))
+	// Owners viewing themselves and anonymous reads are not recorded
+	require.NoError(t, svc.RecordView(ctx, owner, owner))
+	require.NoError(t, svc.RecordView(ctx, owner, uuid.Nil))
+
+	svc.cfg.Enabled = false
+	require.NoError(t, svc.RecordView(ctx, owner, viewer))
+
+	repo.AssertExpectations(t)
+}
+
+func TestViewService_GetViews(t *testing.T) {
+	ctx := context.Background()
+	owner := uuid.Must(uuid.NewV4())
+	sharer := uuid.Must(uuid.NewV4())
+	private := uuid.Must(uuid.NewV4())
+	since := testViewNow.Add(-30 * 24 * time.Hour).UnixMilli()
+	visits := []models.ProfileVisit{
+		{ViewerID: private, LastViewed: 300},
+		{ViewerID: sharer, LastViewed: 200},
+	}
+
+	t.Run("owner not sharing only gets counts", func(t *testing.T) {
+		repo := new(MockViewRepository)
+		repo.On("CountViews", ctx, owner, since).Return(int64(5), int64(2), nil).Once()
+		svc := newTestViewService(repo, new(MockProfileRepository), staticViewPrivacy{sharer: true})
+
+		views, err := svc.GetViews(ctx, owner, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 30, views.Days)
+		assert.Equal(t, int64(5), views.Views)
+		assert.Equal(t, int64(2), views.Viewers)
+		assert.False(t, views.ShareProfileViews)
+		assert.Empty(t, views.RecentViewers)
+		assert.Equal(t, int64(2), views.HiddenViewers)
+		repo.AssertNotCalled(t, "RecentVisits", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("only viewers who share are revealed", func(t *testing.T) {
+		repo := new(MockViewRepository)
+		repo.On("CountViews", ctx, owner, since).Return(int64(5), int64(2), nil).Once()
+		repo.On("RecentVisits", ctx, owner, since, maxRevealedViewers).Return(visits, nil).Once()
+		profileRepo := new(MockProfileRepository)
+		profileRepo.On("FindByIDs", ctx, []uuid.UUID{sharer}).
+			Return([]*models.Profile{{ObjectId: sharer, FullName: "Sharer", SocialName: "sharer"}}, nil).Once()
+		svc := newTestViewService(repo, profileRepo, staticViewPrivacy{owner: true, sharer: true})
+
+		views, err := svc.GetViews(ctx, owner, 30)
+		require.NoError(t, err)
+		assert.True(t, views.ShareProfileViews)
+		require.Len(t, views.RecentViewers, 1)
+		assert.Equal(t, sharer.String(), views.RecentViewers[0].UserId)
+		assert.Equal(t, int64(200), views.RecentViewers[0].LastViewed)
+		assert.Equal(t, int64(1), views.HiddenViewers)
+	})
+
+	t.Run("window is capped by retention", func(t *testing.T) {
+		repo := new(MockViewRepository)
+		repo.On("CountViews", ctx, owner, testViewNow.Add(-90*24*time.Hour).UnixMilli()).Return(int64(0), int64(0), nil).Once()
+		svc := newTestViewService(repo, new(MockProfileRepository), nil)
+
+		views, err := svc.GetViews(ctx, owner, 365)
+		require.NoError(t, err)
+		assert.Equal(t, 90, views.Days)
+	})
+}
+
+func TestViewService_Purge(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockViewRepository)
+	repo.On("PurgeBefore", ctx, testViewNow.Add(-90*24*time.Hour).UnixMilli()).Return(int64(7), nil).Once()
+
+	purged, err := newTestViewService(repo, new(MockProfileRepository), nil).Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), purged)
+}
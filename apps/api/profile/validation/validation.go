@@ -5,8 +5,10 @@ import (
 	"net/url"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 )
 
 // Validation constants for social names
@@ -84,8 +86,9 @@ func ValidateCreateProfileRequest(req *models.CreateProfileRequest) error {
 
 	if req.TagLine != nil {
 		if *req.TagLine != "" {
-			if len(*req.TagLine) > 500 {
-				return fmt.Errorf("tagLine cannot exceed 500 characters")
+			// The deployment's own, lower limit is enforced by the service
+			if utf8.RuneCountInString(*req.TagLine) > sharedInterfaces.MaxContentLimits.Bio {
+				return fmt.Errorf("tagLine cannot exceed %d characters", sharedInterfaces.MaxContentLimits.Bio)
 			}
 		}
 	}
@@ -231,8 +234,9 @@ func ValidateUpdateProfileRequest(req *models.UpdateProfileRequest) error {
 
 	if req.TagLine != nil {
 		if *req.TagLine != "" {
-			if len(*req.TagLine) > 500 {
-				return fmt.Errorf("tagLine cannot exceed 500 characters")
+			// The deployment's own, lower limit is enforced by the service
+			if utf8.RuneCountInString(*req.TagLine) > sharedInterfaces.MaxContentLimits.Bio {
+				return fmt.Errorf("tagLine cannot exceed %d characters", sharedInterfaces.MaxContentLimits.Bio)
 			}
 		}
 	}
@@ -0,0 +1,44 @@
+package profile
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/profile/errors"
+	"github.com/qolzam/telar/apps/api/profile/services"
+)
+
+// ViewHandler serves profile view statistics to profile owners
+type ViewHandler struct {
+	viewService services.ViewService
+}
+
+// NewViewHandler creates a ViewHandler
+func NewViewHandler(viewService services.ViewService) *ViewHandler {
+	return &ViewHandler{viewService: viewService}
+}
+
+// GetMyViews handles GET /profile/my/views?days=30
+func (h *ViewHandler) GetMyViews(c *fiber.Ctx) error {
+	uc, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok || uc.UserID == uuid.Nil {
+		return errors.HandleUnauthorizedError(c, "Authentication required")
+	}
+
+	days := 0
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed <= 0 {
+			return errors.HandleInvalidFieldError(c, "days", "must be a positive integer")
+		}
+		days = parsed
+	}
+
+	views, err := h.viewService.GetViews(c.Context(), uc.UserID, days)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.JSON(views)
+}
@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrUpgradeRequired    = errors.New("websocket upgrade required")
+	ErrInvalidChannel     = errors.New("invalid channel")
+	ErrTooManyConnections = errors.New("too many realtime connections")
+	ErrMissingUserContext = errors.New("missing user context")
+)
+
+const (
+	CodeUpgradeRequired    = "UPGRADE_REQUIRED"
+	CodeInvalidChannel     = "INVALID_CHANNEL"
+	CodeTooManyConnections = "TOO_MANY_CONNECTIONS"
+	CodeMissingUserCtx     = "MISSING_USER_CONTEXT"
+	CodeInternalError      = "INTERNAL_ERROR"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func HandleServiceError(c *fiber.Ctx, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, ErrUpgradeRequired):
+		return c.Status(http.StatusUpgradeRequired).JSON(ErrorResponse{Code: CodeUpgradeRequired, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidChannel):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidChannel, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrTooManyConnections):
+		return c.Status(http.StatusTooManyRequests).JSON(ErrorResponse{Code: CodeTooManyConnections, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrMissingUserContext):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: err.Error(), Details: err.Error()})
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Code: CodeInternalError, Message: "An unexpected error occurred", Details: err.Error()})
+	}
+}
+
+func HandleUserContextError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: message, Details: message})
+}
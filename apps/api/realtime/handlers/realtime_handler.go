@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofiber/contrib/websocket"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	realtimeErrors "github.com/qolzam/telar/apps/api/realtime/errors"
+	"github.com/qolzam/telar/apps/api/realtime/services"
+)
+
+const (
+	channelsLocal = "realtimeChannels"
+	writeTimeout  = 10 * time.Second
+	maxReadSize   = 512
+)
+
+type RealtimeHandler struct {
+	hub            *services.Hub
+	pingInterval   time.Duration
+	allowedOrigins map[string]bool
+}
+
+// NewRealtimeHandler creates the gateway handler. Browsers do not apply CORS to
+// WebSocket upgrades, so the web domains allowed by CORS are checked here as well;
+// requests without an Origin header (non-browser clients) are allowed.
+func NewRealtimeHandler(hub *services.Hub, pingInterval time.Duration, webDomains string) *RealtimeHandler {
+	if pingInterval <= 0 {
+		pingInterval = 30 * time.Second
+	}
+	allowedOrigins := make(map[string]bool)
+	for _, origin := range strings.Split(webDomains, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			allowedOrigins[origin] = true
+		}
+	}
+	return &RealtimeHandler{hub: hub, pingInterval: pingInterval, allowedOrigins: allowedOrigins}
+}
+
+// Upgrade validates the handshake before the connection is upgraded, so refusals are
+// ordinary HTTP errors the client can read.
+// Endpoint: GET /realtime/ws?channels=feed,user,post:<postId>
+func (h *RealtimeHandler) Upgrade(c *fiber.Ctx) error {
+	if !websocket.IsWebSocketUpgrade(c) {
+		return realtimeErrors.HandleServiceError(c, realtimeErrors.ErrUpgradeRequired)
+	}
+	if origin := c.Get(fiber.HeaderOrigin); origin != "" && len(h.allowedOrigins) > 0 && !h.allowedOrigins[origin] {
+		return c.Status(http.StatusForbidden).JSON(realtimeErrors.ErrorResponse{Code: "ORIGIN_NOT_ALLOWED", Message: "origin not allowed"})
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return realtimeErrors.HandleUserContextError(c, "invalid user context")
+	}
+
+	channels, err := services.ParseChannels(c.Query("channels"))
+	if err != nil {
+		return realtimeErrors.HandleServiceError(c, err)
+	}
+	if !h.hub.CanConnect(user.UserID) {
+		return realtimeErrors.HandleServiceError(c, realtimeErrors.ErrTooManyConnections)
+	}
+
+	c.Locals(channelsLocal, channels)
+	return c.Next()
+}
+
+// Serve returns the WebSocket handler that streams events to an upgraded connection
+func (h *RealtimeHandler) Serve() fiber.Handler {
+	return websocket.New(h.serve)
+}
+
+func (h *RealtimeHandler) serve(conn *websocket.Conn) {
+	user, _ := conn.Locals(types.UserCtxName).(types.UserContext)
+	channels, _ := conn.Locals(channelsLocal).(map[string]bool)
+
+	client, err := h.hub.Register(user.UserID, channels)
+	if err != nil {
+		_ = conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.ClosePolicyViolation, err.Error()),
+			time.Now().Add(writeTimeout))
+		_ = conn.Close()
+		return
+	}
+	defer h.hub.Unregister(client)
+
+	// The reader only handles control frames; it ends when the client goes away
+	// or stays silent for two ping intervals.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn.SetReadLimit(maxReadSize)
+		_ = conn.SetReadDeadline(time.Now().Add(2 * h.pingInterval))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(2 * h.pingInterval))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+					log.Warn("Realtime connection of user %s closed: %v", user.UserID, err)
+				}
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(h.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case payload, ok := <-client.Send():
+			_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if !ok {
+				// Dropped by the hub for falling behind
+				_ = conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too slow"))
+				_ = conn.Close()
+				<-done
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, payload); err != nil {
+				_ = conn.Close()
+				<-done
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeTimeout)); err != nil {
+				_ = conn.Close()
+				<-done
+				return
+			}
+		case <-done:
+			_ = conn.Close()
+			return
+		}
+	}
+}
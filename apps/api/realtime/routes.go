@@ -0,0 +1,36 @@
+package realtime
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/realtime/handlers"
+)
+
+type Handlers struct {
+	RealtimeHandler *handlers.RealtimeHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires the WebSocket gateway. Browsers cannot set headers on a
+// WebSocket handshake, so they authenticate with the access_token cookie.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/realtime", createDualAuthMiddleware(routerCfg))
+	group.Get("/ws", handlers.RealtimeHandler.Upgrade, handlers.RealtimeHandler.Serve())
+}
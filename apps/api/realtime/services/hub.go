@@ -0,0 +1,199 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	uuid "github.com/gofrs/uuid"
+
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	realtimeErrors "github.com/qolzam/telar/apps/api/realtime/errors"
+)
+
+const (
+	// ChannelFeed carries public events: new public posts and comments
+	ChannelFeed = "feed"
+	// ChannelUser carries events addressed to the connected user, such as notifications
+	ChannelUser = "user"
+	// channelPostPrefix starts a post channel, "post:<postId>", which carries the new
+	// comments and live thread events of one public post
+	channelPostPrefix = "post:"
+
+	// maxPostChannels bounds the post channels one connection may follow
+	maxPostChannels = 20
+)
+
+// ParseChannels reads a comma-separated channel list; empty means the feed and user
+// channels
+func ParseChannels(raw string) (map[string]bool, error) {
+	channels := make(map[string]bool)
+	posts := 0
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		switch {
+		case name == "":
+			continue
+		case name == ChannelFeed, name == ChannelUser:
+			channels[name] = true
+		case strings.HasPrefix(name, channelPostPrefix):
+			postID, err := uuid.FromString(strings.TrimPrefix(name, channelPostPrefix))
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", realtimeErrors.ErrInvalidChannel, name)
+			}
+			topic := events.PostTopic(postID)
+			if !channels[topic] {
+				if posts++; posts > maxPostChannels {
+					return nil, fmt.Errorf("%w: at most %d post channels", realtimeErrors.ErrInvalidChannel, maxPostChannels)
+				}
+				channels[topic] = true
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s", realtimeErrors.ErrInvalidChannel, name)
+		}
+	}
+	if len(channels) == 0 {
+		channels[ChannelFeed] = true
+		channels[ChannelUser] = true
+	}
+	return channels, nil
+}
+
+// Client is one open connection. Its writer drains Send; the channel is closed when the
+// hub drops the client, either on Unregister or because it fell too far behind.
+type Client struct {
+	UserID   uuid.UUID
+	channels map[string]bool
+	send     chan []byte
+}
+
+// Send returns the queue of encoded events for the connection
+func (c *Client) Send() <-chan []byte {
+	return c.send
+}
+
+// Hub fans events from the bus out to connected clients
+type Hub struct {
+	mu                    sync.RWMutex
+	clients               map[uuid.UUID]map[*Client]struct{}
+	maxConnectionsPerUser int
+	sendBuffer            int
+}
+
+// NewHub creates a hub. maxConnectionsPerUser <= 0 means unlimited.
+func NewHub(maxConnectionsPerUser, sendBuffer int) *Hub {
+	if sendBuffer <= 0 {
+		sendBuffer = 64
+	}
+	return &Hub{
+		clients:               make(map[uuid.UUID]map[*Client]struct{}),
+		maxConnectionsPerUser: maxConnectionsPerUser,
+		sendBuffer:            sendBuffer,
+	}
+}
+
+// CanConnect reports whether userID is below the connection limit, so the handler can
+// refuse before upgrading. Register enforces the limit again.
+func (h *Hub) CanConnect(userID uuid.UUID) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.maxConnectionsPerUser <= 0 || len(h.clients[userID]) < h.maxConnectionsPerUser
+}
+
+// Register adds a connection for userID subscribed to channels
+func (h *Hub) Register(userID uuid.UUID, channels map[string]bool) (*Client, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.maxConnectionsPerUser > 0 && len(h.clients[userID]) >= h.maxConnectionsPerUser {
+		return nil, realtimeErrors.ErrTooManyConnections
+	}
+	client := &Client{UserID: userID, channels: channels, send: make(chan []byte, h.sendBuffer)}
+	if h.clients[userID] == nil {
+		h.clients[userID] = make(map[*Client]struct{})
+	}
+	h.clients[userID][client] = struct{}{}
+	return client, nil
+}
+
+// Unregister removes a connection and closes its queue. Calling it twice is safe.
+func (h *Hub) Unregister(client *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.remove(client)
+}
+
+func (h *Hub) remove(client *Client) {
+	userClients, ok := h.clients[client.UserID]
+	if !ok {
+		return
+	}
+	if _, ok := userClients[client]; !ok {
+		return
+	}
+	delete(userClients, client)
+	if len(userClients) == 0 {
+		delete(h.clients, client.UserID)
+	}
+	close(client.send)
+}
+
+// Connections returns the number of open connections
+func (h *Hub) Connections() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	total := 0
+	for _, userClients := range h.clients {
+		total += len(userClients)
+	}
+	return total
+}
+
+// Deliver queues an event for every client that should receive it. It never blocks:
+// a client whose queue is full is disconnected and can reconnect to catch up.
+func (h *Hub) Deliver(event events.Event) {
+	if !event.Public && len(event.Recipients) == 0 && event.Topic == "" {
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Error("Failed to encode realtime event %s: %v", event.Type, err)
+		return
+	}
+
+	recipients := make(map[uuid.UUID]bool, len(event.Recipients))
+	for _, id := range event.Recipients {
+		recipients[id] = true
+	}
+
+	var slow []*Client
+	h.mu.RLock()
+	for userID, userClients := range h.clients {
+		for client := range userClients {
+			wanted := (event.Public && client.channels[ChannelFeed]) ||
+				(recipients[userID] && client.channels[ChannelUser]) ||
+				(event.Topic != "" && client.channels[event.Topic])
+			if !wanted {
+				continue
+			}
+			select {
+			case client.send <- payload:
+			default:
+				slow = append(slow, client)
+			}
+		}
+	}
+	h.mu.RUnlock()
+
+	if len(slow) == 0 {
+		return
+	}
+	h.mu.Lock()
+	for _, client := range slow {
+		log.Warn("Dropping slow realtime client of user %s", client.UserID)
+		h.remove(client)
+	}
+	h.mu.Unlock()
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/qolzam/telar/apps/api/internal/events"
+	realtimeErrors "github.com/qolzam/telar/apps/api/realtime/errors"
+)
+
+func received(client *Client) []string {
+	var types []string
+	for {
+		select {
+		case payload, ok := <-client.Send():
+			if !ok {
+				return types
+			}
+			var event struct {
+				Type string `json:"type"`
+			}
+			_ = json.Unmarshal(payload, &event)
+			types = append(types, event.Type)
+		default:
+			return types
+		}
+	}
+}
+
+func TestParseChannels(t *testing.T) {
+	channels, err := ParseChannels("")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{ChannelFeed: true, ChannelUser: true}, channels)
+
+	channels, err = ParseChannels(" user ")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{ChannelUser: true}, channels)
+
+	_, err = ParseChannels("feed,admin")
+	assert.ErrorIs(t, err, realtimeErrors.ErrInvalidChannel)
+}
+
+func TestParseChannels_PostChannels(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+
+	channels, err := ParseChannels("post:" + postID.String())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{events.PostTopic(postID): true}, channels, "post channels alone do not add the defaults")
+
+	_, err = ParseChannels("post:not-a-uuid")
+	assert.ErrorIs(t, err, realtimeErrors.ErrInvalidChannel)
+
+	names := make([]string, 0, maxPostChannels+1)
+	for i := 0; i <= maxPostChannels; i++ {
+		names = append(names, "post:"+uuid.Must(uuid.NewV4()).String())
+	}
+	_, err = ParseChannels(strings.Join(names, ","))
+	assert.ErrorIs(t, err, realtimeErrors.ErrInvalidChannel)
+}
+
+func TestHub_DeliverTopic(t *testing.T) {
+	postID := uuid.Must(uuid.NewV4())
+	hub := NewHub(0, 8)
+	follower, err := hub.Register(uuid.Must(uuid.NewV4()), map[string]bool{events.PostTopic(postID): true, ChannelFeed: true})
+	require.NoError(t, err)
+	other, err := hub.Register(uuid.Must(uuid.NewV4()), map[string]bool{events.PostTopic(uuid.Must(uuid.NewV4())): true})
+	require.NoError(t, err)
+
+	hub.Deliver(events.Event{Type: events.TypeCommentCreated, Public: true, Topic: events.PostTopic(postID)})
+	hub.Deliver(events.Event{Type: events.TypeLiveThreadEnded, Topic: events.PostTopic(postID)})
+
+	assert.Equal(t, []string{events.TypeCommentCreated, events.TypeLiveThreadEnded}, received(follower), "feed and topic subscribers get each event once")
+	assert.Empty(t, received(other))
+}
+
+func TestHub_Deliver(t *testing.T) {
+	alice := uuid.Must(uuid.NewV4())
+	bob := uuid.Must(uuid.NewV4())
+
+	hub := NewHub(0, 8)
+	aliceBoth, err := hub.Register(alice, map[string]bool{ChannelFeed: true, ChannelUser: true})
+	require.NoError(t, err)
+	aliceUser, err := hub.Register(alice, map[string]bool{ChannelUser: true})
+	require.NoError(t, err)
+	bobFeed, err := hub.Register(bob, map[string]bool{ChannelFeed: true})
+	require.NoError(t, err)
+
+	hub.Deliver(events.Event{Type: events.TypePostCreated, Public: true})
+	hub.Deliver(events.Event{Type: events.TypeNotification, Recipients: []uuid.UUID{alice}})
+	hub.Deliver(events.Event{Type: events.TypeNotification, Recipients: []uuid.UUID{bob}})
+
+	assert.Equal(t, []string{events.TypePostCreated, events.TypeNotification}, received(aliceBoth))
+	assert.Equal(t, []string{events.TypeNotification}, received(aliceUser))
+	assert.Equal(t, []string{events.TypePostCreated}, received(bobFeed), "bob did not subscribe to his user channel")
+}
+
+func TestHub_ConnectionLimit(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	hub := NewHub(1, 8)
+
+	client, err := hub.Register(userID, map[string]bool{ChannelFeed: true})
+	require.NoError(t, err)
+	assert.False(t, hub.CanConnect(userID))
+
+	_, err = hub.Register(userID, map[string]bool{ChannelFeed: true})
+	assert.ErrorIs(t, err, realtimeErrors.ErrTooManyConnections)
+
+	hub.Unregister(client)
+	hub.Unregister(client)
+	assert.True(t, hub.CanConnect(userID))
+	assert.Equal(t, 0, hub.Connections())
+}
+
+func TestHub_DropsSlowClient(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	hub := NewHub(0, 1)
+	client, err := hub.Register(userID, map[string]bool{ChannelFeed: true})
+	require.NoError(t, err)
+
+	hub.Deliver(events.Event{Type: events.TypePostCreated, Public: true})
+	hub.Deliver(events.Event{Type: events.TypeCommentCreated, Public: true})
+
+	assert.Equal(t, 0, hub.Connections())
+	assert.Equal(t, []string{events.TypePostCreated}, received(client), "queued events drain before the close")
+}
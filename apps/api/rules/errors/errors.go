@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrNoRules            = errors.New("there are no rules to acknowledge")
+	ErrRulesChanged       = errors.New("the rules changed since they were read")
+	ErrInvalidRequest     = errors.New("invalid request")
+	ErrMissingUserContext = errors.New("missing user context")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrDatabaseOperation  = errors.New("database operation failed")
+)
+
+const (
+	CodeNoRules          = "NO_RULES"
+	CodeRulesChanged     = "RULES_CHANGED"
+	CodeInvalidRequest   = "INVALID_REQUEST"
+	CodeMissingUserCtx   = "MISSING_USER_CONTEXT"
+	CodePermissionDenied = "PERMISSION_DENIED"
+	CodeDatabaseError    = "DATABASE_ERROR"
+	CodeInternalError    = "INTERNAL_ERROR"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func HandleServiceError(c *fiber.Ctx, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, ErrNoRules):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeNoRules, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrRulesChanged):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeRulesChanged, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrMissingUserContext):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrPermissionDenied):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodePermissionDenied, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrDatabaseOperation):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeDatabaseError, Message: err.Error(), Details: err.Error()})
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Code: CodeInternalError, Message: "An unexpected error occurred", Details: err.Error()})
+	}
+}
+
+func HandleValidationError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: message, Details: message})
+}
+
+func HandleUserContextError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: message, Details: message})
+}
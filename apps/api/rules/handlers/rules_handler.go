@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/rules/errors"
+	"github.com/qolzam/telar/apps/api/rules/models"
+	"github.com/qolzam/telar/apps/api/rules/services"
+)
+
+type RulesHandler struct {
+	service services.Service
+}
+
+func NewRulesHandler(service services.Service) *RulesHandler {
+	return &RulesHandler{service: service}
+}
+
+// GetRules returns the community's rules and their version.
+// Endpoint: GET /communities/:communityId/rules
+func (h *RulesHandler) GetRules(c *fiber.Ctx) error {
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	rules, err := h.service.GetRules(c.Context(), communityID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(rules)
+}
+
+// SetRules replaces the community's rules. Owner and moderators only.
+// Endpoint: PUT /communities/:communityId/rules
+func (h *RulesHandler) SetRules(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	var req models.SetRulesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	rules, err := h.service.SetRules(c.Context(), communityID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(rules)
+}
+
+// GetAcknowledgment tells the current user whether they acknowledged the community's rules.
+// Endpoint: GET /communities/:communityId/rules/acknowledgment
+func (h *RulesHandler) GetAcknowledgment(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+
+	status, err := h.service.Status(c.Context(), communityID, user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(status)
+}
+
+// Acknowledge records that the current user agreed to the community's rules they read.
+// Endpoint: POST /communities/:communityId/rules/acknowledgment
+// Body: {"version": 1760788800000}
+func (h *RulesHandler) Acknowledge(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	communityID, err := uuid.FromString(c.Params("communityId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid communityId")
+	}
+	var req models.AcknowledgeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	status, err := h.service.Acknowledge(c.Context(), communityID, user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(status)
+}
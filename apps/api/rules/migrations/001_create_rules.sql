@@ -0,0 +1,18 @@
+-- Community rules: on deployments that enable them, users acknowledge the rules before
+-- their first post or comment.
+-- Rules are replaced as a set; version is when the set was published (Unix ms), the
+-- same on every row, so acknowledgments can tell which set a user agreed to.
+CREATE TABLE IF NOT EXISTS community_rules (
+    id UUID PRIMARY KEY,
+    position INT NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    version BIGINT NOT NULL
+);
+
+-- One acknowledgment per user, moved forward when they acknowledge a newer set.
+CREATE TABLE IF NOT EXISTS rules_acknowledgments (
+    user_id UUID PRIMARY KEY,
+    rules_version BIGINT NOT NULL,
+    acknowledged_date BIGINT NOT NULL
+);
@@ -0,0 +1,17 @@
+-- Rules belong to a community: its owner and moderators publish them, and members
+-- acknowledge them before their first post or comment there. The deployment-wide rules
+-- and acknowledgments have no community to move to and are dropped.
+DELETE FROM community_rules;
+DELETE FROM rules_acknowledgments;
+
+ALTER TABLE community_rules
+    ADD COLUMN IF NOT EXISTS community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_community_rules_community ON community_rules(community_id, position);
+
+-- One acknowledgment per user and community, moved forward when they acknowledge a
+-- newer set.
+ALTER TABLE rules_acknowledgments
+    ADD COLUMN IF NOT EXISTS community_id UUID NOT NULL REFERENCES communities(id) ON DELETE CASCADE;
+ALTER TABLE rules_acknowledgments DROP CONSTRAINT IF EXISTS rules_acknowledgments_pkey;
+ALTER TABLE rules_acknowledgments ADD PRIMARY KEY (community_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_rules_acknowledgments_user ON rules_acknowledgments(user_id);
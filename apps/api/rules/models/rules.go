@@ -0,0 +1,53 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Rule is one of a community's rules, shown in position order
+type Rule struct {
+	ObjectId    uuid.UUID `json:"objectId" db:"id"`
+	CommunityId uuid.UUID `json:"communityId" db:"community_id"`
+	Position    int       `json:"position" db:"position"`
+	Title       string    `json:"title" db:"title"`
+	Body        string    `json:"body" db:"body"`
+	Version     int64     `json:"-" db:"version"`
+}
+
+// RuleInput is a rule as written by a moderator
+type RuleInput struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// SetRulesRequest replaces a community's rules
+type SetRulesRequest struct {
+	Rules []RuleInput `json:"rules"`
+}
+
+// RuleSet is a community's current rules and the version its members acknowledge
+type RuleSet struct {
+	Rules   []*Rule `json:"rules"`
+	Version int64   `json:"version"` // Unix ms the rules were published; 0 while there are none
+}
+
+// Acknowledgment records the version of a community's rules a user agreed to
+type Acknowledgment struct {
+	CommunityId      uuid.UUID `json:"communityId" db:"community_id"`
+	UserId           uuid.UUID `json:"userId" db:"user_id"`
+	RulesVersion     int64     `json:"rulesVersion" db:"rules_version"`
+	AcknowledgedDate int64     `json:"acknowledgedDate" db:"acknowledged_date"`
+}
+
+// AcknowledgeRequest names the version of the rules the user read
+type AcknowledgeRequest struct {
+	Version int64 `json:"version"`
+}
+
+// AcknowledgmentStatus tells a user whether they may post and comment in the community
+type AcknowledgmentStatus struct {
+	Acknowledged        bool  `json:"acknowledged"`        // Whether the user may post and comment there
+	AcknowledgedVersion int64 `json:"acknowledgedVersion"` // 0 when the user never acknowledged
+	CurrentVersion      int64 `json:"currentVersion"`      // Differs from acknowledgedVersion once the rules change
+	AcknowledgedDate    int64 `json:"acknowledgedDate,omitempty"`
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/rules/models"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) ListRules(ctx context.Context, communityID uuid.UUID) ([]*models.Rule, error) {
+	query := fmt.Sprintf(`
+		SELECT id, community_id, position, title, body, version
+		FROM %scommunity_rules
+		WHERE community_id = $1
+		ORDER BY position
+	`, r.schemaPrefix())
+
+	var rules []*models.Rule
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rules, query, communityID); err != nil {
+		return nil, fmt.Errorf("list rules: %w", err)
+	}
+	return rules, nil
+}
+
+func (r *postgresRepository) ReplaceRules(ctx context.Context, communityID uuid.UUID, rules []*models.Rule) error {
+	return r.WithTransaction(ctx, func(ctx context.Context) error {
+		exec := r.getExecutor(ctx)
+		deleteQuery := fmt.Sprintf(`DELETE FROM %scommunity_rules WHERE community_id = $1`, r.schemaPrefix())
+		if _, err := exec.ExecContext(ctx, deleteQuery, communityID); err != nil {
+			return fmt.Errorf("delete rules: %w", err)
+		}
+		query := fmt.Sprintf(`
+			INSERT INTO %scommunity_rules (id, community_id, position, title, body, version)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, r.schemaPrefix())
+		for _, rule := range rules {
+			if _, err := exec.ExecContext(ctx, query, rule.ObjectId, communityID, rule.Position, rule.Title, rule.Body, rule.Version); err != nil {
+				return fmt.Errorf("insert rule: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+func (r *postgresRepository) Acknowledge(ctx context.Context, ack *models.Acknowledgment) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %srules_acknowledgments (community_id, user_id, rules_version, acknowledged_date)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (community_id, user_id) DO UPDATE
+		SET rules_version = EXCLUDED.rules_version, acknowledged_date = EXCLUDED.acknowledged_date
+	`, r.schemaPrefix())
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, ack.CommunityId, ack.UserId, ack.RulesVersion, ack.AcknowledgedDate); err != nil {
+		return fmt.Errorf("acknowledge rules: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetAcknowledgment(ctx context.Context, communityID, userID uuid.UUID) (*models.Acknowledgment, error) {
+	query := fmt.Sprintf(`
+		SELECT community_id, user_id, rules_version, acknowledged_date
+		FROM %srules_acknowledgments
+		WHERE community_id = $1 AND user_id = $2
+	`, r.schemaPrefix())
+
+	var ack models.Acknowledgment
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &ack, query, communityID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get rules acknowledgment: %w", err)
+	}
+	return &ack, nil
+}
+
+func (r *postgresRepository) MayWrite(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`
+		SELECT NOT EXISTS (SELECT 1 FROM %[1]scommunity_rules WHERE community_id = $1)
+			OR EXISTS (SELECT 1 FROM %[1]srules_acknowledgments WHERE community_id = $1 AND user_id = $2)
+	`, r.schemaPrefix())
+
+	var allowed bool
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &allowed, query, communityID, userID); err != nil {
+		return false, fmt.Errorf("check rules acknowledgment: %w", err)
+	}
+	return allowed, nil
+}
+
+func (r *postgresRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM %srules_acknowledgments WHERE user_id = $1`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("delete rules acknowledgments of user: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/rules/models"
+)
+
+// ErrNotFound is returned when a user has not acknowledged any rules of a community
+var ErrNotFound = errors.New("not found")
+
+// Repository defines data access for the rules of communities and their acknowledgments.
+type Repository interface {
+	// ListRules returns the community's rules in order.
+	ListRules(ctx context.Context, communityID uuid.UUID) ([]*models.Rule, error)
+
+	// ReplaceRules replaces every rule of the community with rules.
+	ReplaceRules(ctx context.Context, communityID uuid.UUID, rules []*models.Rule) error
+
+	// Acknowledge records that the user agreed to the community's rules of version.
+	Acknowledge(ctx context.Context, ack *models.Acknowledgment) error
+
+	// GetAcknowledgment returns the user's acknowledgment of the community's rules or
+	// ErrNotFound.
+	GetAcknowledgment(ctx context.Context, communityID, userID uuid.UUID) (*models.Acknowledgment, error)
+
+	// MayWrite reports whether the community has no rules or the user acknowledged some.
+	MayWrite(ctx context.Context, communityID, userID uuid.UUID) (bool, error)
+
+	// WithTransaction runs fn in a transaction, joining the caller's when there is one.
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+
+	// DeleteByUser deletes userID's rules acknowledgments.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
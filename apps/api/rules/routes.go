@@ -0,0 +1,43 @@
+package rules
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/rules/handlers"
+)
+
+type Handlers struct {
+	RulesHandler *handlers.RulesHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires the rules of each community and their acknowledgment. Community
+// owners and moderators are checked by the service, not by middleware.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/communities/:communityId/rules")
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	group.Get("/", dualAuthMiddleware, handlers.RulesHandler.GetRules)
+	group.Get("/acknowledgment", dualAuthMiddleware, handlers.RulesHandler.GetAcknowledgment)
+	group.Post("/acknowledgment", dualAuthMiddleware, handlers.RulesHandler.Acknowledge)
+
+	// --- Owner and moderator routes ---
+	group.Put("/", dualAuthMiddleware, handlers.RulesHandler.SetRules)
+}
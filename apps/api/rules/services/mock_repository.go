@@ -0,0 +1,57 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/rules/models"
+	"github.com/qolzam/telar/apps/api/rules/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the rules repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) ListRules(ctx context.Context, communityID uuid.UUID) ([]*models.Rule, error) {
+	args := m.Called(ctx, communityID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Rule), args.Error(1)
+}
+
+func (m *MockRepository) ReplaceRules(ctx context.Context, communityID uuid.UUID, rules []*models.Rule) error {
+	args := m.Called(ctx, communityID, rules)
+	return args.Error(0)
+}
+
+func (m *MockRepository) Acknowledge(ctx context.Context, ack *models.Acknowledgment) error {
+	args := m.Called(ctx, ack)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetAcknowledgment(ctx context.Context, communityID, userID uuid.UUID) (*models.Acknowledgment, error) {
+	args := m.Called(ctx, communityID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Acknowledgment), args.Error(1)
+}
+
+func (m *MockRepository) MayWrite(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, communityID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
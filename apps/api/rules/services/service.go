@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	rulesErrors "github.com/qolzam/telar/apps/api/rules/errors"
+	"github.com/qolzam/telar/apps/api/rules/models"
+	"github.com/qolzam/telar/apps/api/rules/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const maxTitleLength = 200
+
+// Service defines the rules of communities and their acknowledgment.
+type Service interface {
+	// GetRules returns the community's rules in order with their version.
+	GetRules(ctx context.Context, communityID uuid.UUID) (*models.RuleSet, error)
+
+	// SetRules replaces the community's rules, publishing a new version; only its owner
+	// and moderators may. Members who acknowledged an earlier version keep posting;
+	// clients may ask them to read the new one.
+	SetRules(ctx context.Context, communityID, userID uuid.UUID, req *models.SetRulesRequest) (*models.RuleSet, error)
+
+	// Status tells the user whether they acknowledged the community's rules, and which
+	// version.
+	Status(ctx context.Context, communityID, userID uuid.UUID) (*models.AcknowledgmentStatus, error)
+
+	// Acknowledge records that the user agreed to the version of the community's rules
+	// they read.
+	Acknowledge(ctx context.Context, communityID, userID uuid.UUID, req *models.AcknowledgeRequest) (*models.AcknowledgmentStatus, error)
+
+	sharedInterfaces.RulesChecker
+}
+
+type service struct {
+	repo        repository.Repository
+	communities sharedInterfaces.CommunityChecker
+	cfg         platformconfig.RulesConfig
+	now         func() time.Time
+}
+
+// NewService constructs a rules service.
+func NewService(repo repository.Repository, communities sharedInterfaces.CommunityChecker, cfg platformconfig.RulesConfig) Service {
+	return &service{repo: repo, communities: communities, cfg: cfg, now: time.Now}
+}
+
+func (s *service) GetRules(ctx context.Context, communityID uuid.UUID) (*models.RuleSet, error) {
+	rules, err := s.repo.ListRules(ctx, communityID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", rulesErrors.ErrDatabaseOperation, err)
+	}
+	return ruleSet(rules), nil
+}
+
+func (s *service) SetRules(ctx context.Context, communityID, userID uuid.UUID, req *models.SetRulesRequest) (*models.RuleSet, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", rulesErrors.ErrInvalidRequest)
+	}
+	moderator, err := s.communities.IsCommunityModerator(ctx, communityID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", rulesErrors.ErrDatabaseOperation, err)
+	}
+	if !moderator {
+		return nil, rulesErrors.ErrPermissionDenied
+	}
+	if s.cfg.MaxRules > 0 && len(req.Rules) > s.cfg.MaxRules {
+		return nil, fmt.Errorf("%w: at most %d rules", rulesErrors.ErrInvalidRequest, s.cfg.MaxRules)
+	}
+
+	version := s.now().UTC().UnixMilli()
+	rules := make([]*models.Rule, 0, len(req.Rules))
+	for i, input := range req.Rules {
+		title := strings.TrimSpace(input.Title)
+		body := strings.TrimSpace(input.Body)
+		if title == "" {
+			return nil, fmt.Errorf("%w: rule %d has no title", rulesErrors.ErrInvalidRequest, i+1)
+		}
+		if utf8.RuneCountInString(title) > maxTitleLength {
+			return nil, fmt.Errorf("%w: titles are at most %d characters", rulesErrors.ErrInvalidRequest, maxTitleLength)
+		}
+		if s.cfg.MaxRuleLength > 0 && utf8.RuneCountInString(body) > s.cfg.MaxRuleLength {
+			return nil, fmt.Errorf("%w: rules are at most %d characters", rulesErrors.ErrInvalidRequest, s.cfg.MaxRuleLength)
+		}
+		rules = append(rules, &models.Rule{
+			ObjectId:    uuid.Must(uuid.NewV4()),
+			CommunityId: communityID,
+			Position:    i,
+			Title:       title,
+			Body:        body,
+			Version:     version,
+		})
+	}
+	if err := s.repo.ReplaceRules(ctx, communityID, rules); err != nil {
+		return nil, fmt.Errorf("%w: %v", rulesErrors.ErrDatabaseOperation, err)
+	}
+	return ruleSet(rules), nil
+}
+
+func (s *service) Status(ctx context.Context, communityID, userID uuid.UUID) (*models.AcknowledgmentStatus, error) {
+	rules, err := s.GetRules(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	status := &models.AcknowledgmentStatus{Acknowledged: rules.Version == 0, CurrentVersion: rules.Version}
+
+	ack, err := s.repo.GetAcknowledgment(ctx, communityID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return status, nil
+		}
+		return nil, fmt.Errorf("%w: %v", rulesErrors.ErrDatabaseOperation, err)
+	}
+	status.Acknowledged = true
+	status.AcknowledgedVersion = ack.RulesVersion
+	status.AcknowledgedDate = ack.AcknowledgedDate
+	return status, nil
+}
+
+func (s *service) Acknowledge(ctx context.Context, communityID, userID uuid.UUID, req *models.AcknowledgeRequest) (*models.AcknowledgmentStatus, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", rulesErrors.ErrInvalidRequest)
+	}
+	rules, err := s.GetRules(ctx, communityID)
+	if err != nil {
+		return nil, err
+	}
+	if rules.Version == 0 {
+		return nil, rulesErrors.ErrNoRules
+	}
+	// Agreeing to rules the user has not seen would not mean much
+	if req.Version != rules.Version {
+		return nil, rulesErrors.ErrRulesChanged
+	}
+
+	ack := &models.Acknowledgment{
+		CommunityId:      communityID,
+		UserId:           userID,
+		RulesVersion:     rules.Version,
+		AcknowledgedDate: s.now().UTC().UnixMilli(),
+	}
+	if err := s.repo.Acknowledge(ctx, ack); err != nil {
+		return nil, fmt.Errorf("%w: %v", rulesErrors.ErrDatabaseOperation, err)
+	}
+	return &models.AcknowledgmentStatus{
+		Acknowledged:        true,
+		AcknowledgedVersion: ack.RulesVersion,
+		CurrentVersion:      rules.Version,
+		AcknowledgedDate:    ack.AcknowledgedDate,
+	}, nil
+}
+
+// HasAcknowledgedRules lets users write in a community once they acknowledged any
+// version of its rules, and everyone while it has none
+func (s *service) HasAcknowledgedRules(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return s.repo.MayWrite(ctx, communityID, userID)
+}
+
+// ruleSet wraps rules with their version
+func ruleSet(rules []*models.Rule) *models.RuleSet {
+	set := &models.RuleSet{Rules: rules}
+	if set.Rules == nil {
+		set.Rules = []*models.Rule{}
+	}
+	if len(rules) > 0 {
+		set.Version = rules[0].Version
+	}
+	return set
+}
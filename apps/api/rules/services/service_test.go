@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	rulesErrors "github.com/qolzam/telar/apps/api/rules/errors"
+	"github.com/qolzam/telar/apps/api/rules/models"
+	"github.com/qolzam/telar/apps/api/rules/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = platformconfig.RulesConfig{MaxRules: 2, MaxRuleLength: 20}
+
+// stubCommunities is a community moderated by moderator
+type stubCommunities struct {
+	moderator uuid.UUID
+}
+
+func (s *stubCommunities) IsCommunityMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return userID == s.moderator, nil
+}
+
+func (s *stubCommunities) IsCommunityModerator(ctx context.Context, communityID, userID uuid.UUID) (bool, error) {
+	return userID == s.moderator, nil
+}
+
+func (s *stubCommunities) IsCommunityArchived(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (s *stubCommunities) AllowsAnonymousPosts(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+func (s *stubCommunities) RequiresApproval(ctx context.Context, communityID uuid.UUID) (bool, error) {
+	return false, nil
+}
+
+var (
+	communityID = uuid.Must(uuid.NewV4())
+	moderatorID = uuid.Must(uuid.NewV4())
+)
+
+func newTestService(repo *MockRepository, now time.Time) *service {
+	svc := NewService(repo, &stubCommunities{moderator: moderatorID}, testConfig).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestSetRules(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	t.Run("publishes a new version", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ReplaceRules", ctx, communityID, mock.Anything).Return(nil).Once()
+
+		set, err := newTestService(repo, now).SetRules(ctx, communityID, moderatorID, &models.SetRulesRequest{Rules: []models.RuleInput{
+			{Title: " Be kind ", Body: "No insults."},
+			{Title: "Stay on topic"},
+		}})
+		require.NoError(t, err)
+		assert.Equal(t, now.UnixMilli(), set.Version)
+		require.Len(t, set.Rules, 2)
+		assert.Equal(t, "Be kind", set.Rules[0].Title)
+		assert.Equal(t, 1, set.Rules[1].Position)
+		assert.Equal(t, communityID, set.Rules[1].CommunityId)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("only the owner and moderators set rules", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		_, err := newTestService(repo, now).SetRules(ctx, communityID, uuid.Must(uuid.NewV4()), &models.SetRulesRequest{Rules: []models.RuleInput{{Title: "Be kind"}}})
+		assert.ErrorIs(t, err, rulesErrors.ErrPermissionDenied)
+		repo.AssertNotCalled(t, "ReplaceRules", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects untitled, long and too many rules", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := newTestService(repo, now)
+
+		for _, rules := range [][]models.RuleInput{
+			{{Title: " "}},
+			{{Title: "Be kind", Body: "far longer than twenty characters"}},
+			{{Title: "a"}, {Title: "b"}, {Title: "c"}},
+		} {
+			_, err := svc.SetRules(ctx, communityID, moderatorID, &models.SetRulesRequest{Rules: rules})
+			assert.ErrorIs(t, err, rulesErrors.ErrInvalidRequest)
+		}
+		repo.AssertNotCalled(t, "ReplaceRules", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAcknowledge(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	userID := uuid.Must(uuid.NewV4())
+	rules := []*models.Rule{{ObjectId: uuid.Must(uuid.NewV4()), Title: "Be kind", Version: 1000}}
+
+	t.Run("records the version the user read", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListRules", ctx, communityID).Return(rules, nil).Once()
+		repo.On("Acknowledge", ctx, &models.Acknowledgment{CommunityId: communityID, UserId: userID, RulesVersion: 1000, AcknowledgedDate: now.UnixMilli()}).Return(nil).Once()
+
+		status, err := newTestService(repo, now).Acknowledge(ctx, communityID, userID, &models.AcknowledgeRequest{Version: 1000})
+		require.NoError(t, err)
+		assert.True(t, status.Acknowledged)
+		assert.Equal(t, int64(1000), status.AcknowledgedVersion)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("refuses stale versions and missing rules", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListRules", ctx, communityID).Return(rules, nil).Once()
+		_, err := newTestService(repo, now).Acknowledge(ctx, communityID, userID, &models.AcknowledgeRequest{Version: 999})
+		assert.ErrorIs(t, err, rulesErrors.ErrRulesChanged)
+
+		repo.On("ListRules", ctx, communityID).Return(nil, nil).Once()
+		_, err = newTestService(repo, now).Acknowledge(ctx, communityID, userID, &models.AcknowledgeRequest{Version: 0})
+		assert.ErrorIs(t, err, rulesErrors.ErrNoRules)
+		repo.AssertNotCalled(t, "Acknowledge", mock.Anything, mock.Anything)
+	})
+}
+
+func TestStatus(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	userID := uuid.Must(uuid.NewV4())
+
+	t.Run("nothing to acknowledge without rules", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListRules", ctx, communityID).Return(nil, nil).Once()
+		repo.On("GetAcknowledgment", ctx, communityID, userID).Return(nil, repository.ErrNotFound).Once()
+
+		status, err := newTestService(repo, now).Status(ctx, communityID, userID)
+		require.NoError(t, err)
+		assert.True(t, status.Acknowledged)
+		assert.Zero(t, status.CurrentVersion)
+	})
+
+	t.Run("an older acknowledgment still counts", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListRules", ctx, communityID).Return([]*models.Rule{{Title: "Be kind", Version: 2000}}, nil).Once()
+		repo.On("GetAcknowledgment", ctx, communityID, userID).Return(&models.Acknowledgment{UserId: userID, RulesVersion: 1000, AcknowledgedDate: 1500}, nil).Once()
+
+		status, err := newTestService(repo, now).Status(ctx, communityID, userID)
+		require.NoError(t, err)
+		assert.True(t, status.Acknowledged)
+		assert.Equal(t, int64(1000), status.AcknowledgedVersion)
+		assert.Equal(t, int64(2000), status.CurrentVersion)
+	})
+
+	t.Run("new users must acknowledge", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListRules", ctx, communityID).Return([]*models.Rule{{Title: "Be kind", Version: 2000}}, nil).Once()
+		repo.On("GetAcknowledgment", ctx, communityID, userID).Return(nil, repository.ErrNotFound).Once()
+
+		status, err := newTestService(repo, now).Status(ctx, communityID, userID)
+		require.NoError(t, err)
+		assert.False(t, status.Acknowledged)
+	})
+}
@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/services"
+)
+
+type BrandingHandler struct {
+	service services.Service
+}
+
+func NewBrandingHandler(service services.Service) *BrandingHandler {
+	return &BrandingHandler{service: service}
+}
+
+// Get returns the community branding. Public: the web client reads it at boot,
+// before anyone signs in.
+// Endpoint: GET /branding
+func (h *BrandingHandler) Get(c *fiber.Ctx) error {
+	branding, err := h.service.GetBranding(c.Context())
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(branding)
+}
+
+// Update replaces the community branding.
+// Endpoint: PUT /branding
+func (h *BrandingHandler) Update(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.UpdateBrandingRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	branding, err := h.service.UpdateBranding(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(branding)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/services"
+)
+
+type ContentLimitsHandler struct {
+	service services.Service
+}
+
+func NewContentLimitsHandler(service services.Service) *ContentLimitsHandler {
+	return &ContentLimitsHandler{service: service}
+}
+
+// Get returns the deployment's maximum post, comment and bio sizes.
+// Endpoint: GET /content-limits
+func (h *ContentLimitsHandler) Get(c *fiber.Ctx) error {
+	limits, err := h.service.GetContentLimits(c.Context())
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(limits)
+}
+
+// Update changes the content limits present in the body.
+// Endpoint: PUT /content-limits
+func (h *ContentLimitsHandler) Update(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.UpdateContentLimitsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	limits, err := h.service.UpdateContentLimits(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(limits)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/services"
+)
+
+type EmailDomainsHandler struct {
+	service services.Service
+}
+
+func NewEmailDomainsHandler(service services.Service) *EmailDomainsHandler {
+	return &EmailDomainsHandler{service: service}
+}
+
+// Get returns the email domains allowed and denied at signup.
+// Endpoint: GET /email-domains
+func (h *EmailDomainsHandler) Get(c *fiber.Ctx) error {
+	domains, err := h.service.GetEmailDomains(c.Context())
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(domains)
+}
+
+// Update replaces the lists present in the body.
+// Endpoint: PUT /email-domains
+func (h *EmailDomainsHandler) Update(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.UpdateEmailDomainsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	domains, err := h.service.UpdateEmailDomains(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(domains)
+}
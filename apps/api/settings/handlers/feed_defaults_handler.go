@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/services"
+)
+
+type FeedDefaultsHandler struct {
+	service services.Service
+}
+
+func NewFeedDefaultsHandler(service services.Service) *FeedDefaultsHandler {
+	return &FeedDefaultsHandler{service: service}
+}
+
+// Get returns the deployment's default feed algorithm, comment order and post visibility.
+// Endpoint: GET /feed-defaults
+func (h *FeedDefaultsHandler) Get(c *fiber.Ctx) error {
+	feed, err := h.service.GetFeedDefaults(c.Context())
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(feed)
+}
+
+// Update changes the feed defaults present in the body.
+// Endpoint: PUT /feed-defaults
+func (h *FeedDefaultsHandler) Update(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.UpdateFeedDefaultsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	feed, err := h.service.UpdateFeedDefaults(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(feed)
+}
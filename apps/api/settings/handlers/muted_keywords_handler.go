@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/services"
+)
+
+type MutedKeywordsHandler struct {
+	service services.Service
+}
+
+func NewMutedKeywordsHandler(service services.Service) *MutedKeywordsHandler {
+	return &MutedKeywordsHandler{service: service}
+}
+
+// List returns the caller's muted keywords.
+// Endpoint: GET /settings/muted-keywords
+func (h *MutedKeywordsHandler) List(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	muted, err := h.service.ListMutedKeywords(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(muted)
+}
+
+// Add mutes a word or phrase for the caller.
+// Endpoint: POST /settings/muted-keywords
+func (h *MutedKeywordsHandler) Add(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.MutedKeywordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	keyword, err := h.service.AddMutedKeyword(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(keyword)
+}
+
+// Update changes the phrase of one of the caller's muted keywords.
+// Endpoint: PUT /settings/muted-keywords/:keywordId
+func (h *MutedKeywordsHandler) Update(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.MutedKeywordRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	keyword, err := h.service.UpdateMutedKeyword(c.Context(), user.UserID, c.Params("keywordId"), &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(keyword)
+}
+
+// Delete unmutes one of the caller's muted keywords.
+// Endpoint: DELETE /settings/muted-keywords/:keywordId
+func (h *MutedKeywordsHandler) Delete(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	if err := h.service.DeleteMutedKeyword(c.Context(), user.UserID, c.Params("keywordId")); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
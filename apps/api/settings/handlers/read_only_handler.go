@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/services"
+)
+
+type ReadOnlyHandler struct {
+	service services.Service
+}
+
+func NewReadOnlyHandler(service services.Service) *ReadOnlyHandler {
+	return &ReadOnlyHandler{service: service}
+}
+
+// Get returns the admin read-only switch and whether this instance is read-only.
+// Endpoint: GET /read-only
+func (h *ReadOnlyHandler) Get(c *fiber.Ctx) error {
+	status, err := h.service.GetReadOnly(c.Context())
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(status)
+}
+
+// Update switches read-only mode on or off for every instance.
+// Endpoint: PUT /read-only
+func (h *ReadOnlyHandler) Update(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.UpdateReadOnlyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	status, err := h.service.UpdateReadOnly(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(status)
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/services"
+)
+
+type SocialNamesHandler struct {
+	service services.Service
+}
+
+func NewSocialNamesHandler(service services.Service) *SocialNamesHandler {
+	return &SocialNamesHandler{service: service}
+}
+
+// Get returns the reserved and blocked social names.
+// Endpoint: GET /social-names
+func (h *SocialNamesHandler) Get(c *fiber.Ctx) error {
+	names, err := h.service.GetSocialNames(c.Context())
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(names)
+}
+
+// Update replaces the lists present in the body.
+// Endpoint: PUT /social-names
+func (h *SocialNamesHandler) Update(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.UpdateSocialNamesRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	names, err := h.service.UpdateSocialNames(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(names)
+}
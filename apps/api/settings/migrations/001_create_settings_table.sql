@@ -0,0 +1,11 @@
+-- Community settings stored as JSON documents. scope is "deployment" for site-wide
+-- settings; per-group settings will use "group:<groupId>".
+CREATE TABLE IF NOT EXISTS settings (
+    scope TEXT NOT NULL,
+    key TEXT NOT NULL,
+    value JSONB NOT NULL DEFAULT '{}'::jsonb,
+    updated_by UUID,
+    last_updated BIGINT NOT NULL,
+
+    PRIMARY KEY (scope, key)
+);
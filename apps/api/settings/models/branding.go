@@ -0,0 +1,36 @@
+package models
+
+// ColorPalette is the set of brand colors the web client maps onto its theme.
+// Colors are CSS hex values; empty ones keep the client default.
+type ColorPalette struct {
+	Primary    string `json:"primary,omitempty"`
+	Secondary  string `json:"secondary,omitempty"`
+	Background string `json:"background,omitempty"`
+	Text       string `json:"text,omitempty"`
+}
+
+// FooterLink is a custom link shown in the site footer
+type FooterLink struct {
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// Branding is the community's look and name, read by the web client at boot
+type Branding struct {
+	SiteName    string       `json:"siteName"`
+	LogoURL     string       `json:"logoUrl,omitempty"`
+	FaviconURL  string       `json:"faviconUrl,omitempty"`
+	Colors      ColorPalette `json:"colors"`
+	FooterLinks []FooterLink `json:"footerLinks"`
+	LastUpdated int64        `json:"lastUpdated,omitempty"`
+}
+
+// UpdateBrandingRequest is the PUT /branding request body; it replaces the stored
+// branding. Empty fields fall back to deployment defaults.
+type UpdateBrandingRequest struct {
+	SiteName    string       `json:"siteName"`
+	LogoURL     string       `json:"logoUrl"`
+	FaviconURL  string       `json:"faviconUrl"`
+	Colors      ColorPalette `json:"colors"`
+	FooterLinks []FooterLink `json:"footerLinks"`
+}
@@ -0,0 +1,23 @@
+package models
+
+// ContentLimits are the deployment's maximum post, comment and bio sizes, stored in the
+// deployment scope. Lengths count characters.
+type ContentLimits struct {
+	PostBody    int   `json:"postBody"`
+	PostTags    int   `json:"postTags"`
+	TagLength   int   `json:"tagLength"`
+	AlbumPhotos int   `json:"albumPhotos"`
+	CommentText int   `json:"commentText"`
+	Bio         int   `json:"bio"`
+	LastUpdated int64 `json:"lastUpdated,omitempty"`
+}
+
+// UpdateContentLimitsRequest is the PUT /content-limits request body; omitted fields keep their value
+type UpdateContentLimitsRequest struct {
+	PostBody    *int `json:"postBody"`
+	PostTags    *int `json:"postTags"`
+	TagLength   *int `json:"tagLength"`
+	AlbumPhotos *int `json:"albumPhotos"`
+	CommentText *int `json:"commentText"`
+	Bio         *int `json:"bio"`
+}
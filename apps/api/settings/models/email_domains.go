@@ -0,0 +1,16 @@
+package models
+
+// EmailDomains are the deployment's signup email domain lists, stored in the deployment
+// scope. Entries are lowercase domains without the @.
+type EmailDomains struct {
+	Allow       []string `json:"allow"`
+	Deny        []string `json:"deny"`
+	LastUpdated int64    `json:"lastUpdated,omitempty"`
+}
+
+// UpdateEmailDomainsRequest is the PUT /email-domains request body; omitted lists keep
+// their value and an empty list clears one
+type UpdateEmailDomainsRequest struct {
+	Allow *[]string `json:"allow"`
+	Deny  *[]string `json:"deny"`
+}
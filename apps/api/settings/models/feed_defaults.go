@@ -0,0 +1,17 @@
+package models
+
+// FeedDefaults are the deployment's default feed algorithm, root comment order and
+// visibility of new posts, stored in the deployment scope
+type FeedDefaults struct {
+	FeedAlgorithm  string `json:"feedAlgorithm"`  // "chronological" or "ranked"
+	CommentSort    string `json:"commentSort"`    // "newest" or "oldest"
+	PostVisibility string `json:"postVisibility"` // "Public", "OnlyMe" or "Circles"
+	LastUpdated    int64  `json:"lastUpdated,omitempty"`
+}
+
+// UpdateFeedDefaultsRequest is the PUT /feed-defaults request body; omitted fields keep their value
+type UpdateFeedDefaultsRequest struct {
+	FeedAlgorithm  *string `json:"feedAlgorithm"`
+	CommentSort    *string `json:"commentSort"`
+	PostVisibility *string `json:"postVisibility"`
+}
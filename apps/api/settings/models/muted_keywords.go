@@ -0,0 +1,21 @@
+package models
+
+// MutedKeyword is a word or phrase a user muted. Posts and comments containing it are
+// left out of the user's feeds and notifications.
+type MutedKeyword struct {
+	ID          string `json:"id"`
+	Phrase      string `json:"phrase"`
+	CreatedDate int64  `json:"createdDate"`
+}
+
+// MutedKeywords are a user's muted keywords, stored in the user's settings scope
+type MutedKeywords struct {
+	Keywords    []MutedKeyword `json:"keywords"`
+	LastUpdated int64          `json:"lastUpdated,omitempty"`
+}
+
+// MutedKeywordRequest is the POST /settings/muted-keywords and
+// PUT /settings/muted-keywords/:keywordId request body
+type MutedKeywordRequest struct {
+	Phrase string `json:"phrase"`
+}
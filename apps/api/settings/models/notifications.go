@@ -0,0 +1,78 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	// Quiet hours use IANA zones; embed the database so minimal images can load them
+	_ "time/tzdata"
+)
+
+// clockLayout is the wall-clock format of quiet hours bounds
+const clockLayout = "15:04"
+
+// NotificationSettings are a user's notification choices, stored in the user's settings scope
+type NotificationSettings struct {
+	// StreakReminders sends a notification when the user's activity streak is about to end
+	StreakReminders bool        `json:"streakReminders"`
+	QuietHours      *QuietHours `json:"quietHours,omitempty"` // No reminders are sent during these hours
+	LastUpdated     int64       `json:"lastUpdated,omitempty"`
+}
+
+// QuietHours is a daily window, in the user's time zone, during which reminders are held
+// back. End may be earlier than Start for a window that spans midnight.
+type QuietHours struct {
+	Start    string `json:"start"`              // "22:00"
+	End      string `json:"end"`                // "07:00"
+	TimeZone string `json:"timeZone,omitempty"` // IANA name such as "Europe/Berlin"; UTC when empty
+}
+
+// UpdateNotificationsRequest is the PUT /settings/notifications request body; omitted
+// fields keep their value. Send quietHours with empty start and end to clear them.
+type UpdateNotificationsRequest struct {
+	StreakReminders *bool       `json:"streakReminders"`
+	QuietHours      *QuietHours `json:"quietHours"`
+}
+
+// Validate checks the bounds and the time zone
+func (q *QuietHours) Validate() error {
+	start, err := time.Parse(clockLayout, q.Start)
+	if err != nil {
+		return fmt.Errorf("quietHours.start must be a time like 22:00")
+	}
+	end, err := time.Parse(clockLayout, q.End)
+	if err != nil {
+		return fmt.Errorf("quietHours.end must be a time like 07:00")
+	}
+	if start.Equal(end) {
+		return fmt.Errorf("quietHours.start and quietHours.end must differ")
+	}
+	if _, err := time.LoadLocation(q.TimeZone); err != nil {
+		return fmt.Errorf("quietHours.timeZone %q is not a known time zone", q.TimeZone)
+	}
+	return nil
+}
+
+// Contains reports whether t falls inside the quiet hours. Invalid settings never do.
+func (q *QuietHours) Contains(t time.Time) bool {
+	start, err := time.Parse(clockLayout, q.Start)
+	if err != nil {
+		return false
+	}
+	end, err := time.Parse(clockLayout, q.End)
+	if err != nil {
+		return false
+	}
+	loc, err := time.LoadLocation(q.TimeZone)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	if from < to {
+		return minute >= from && minute < to
+	}
+	return minute >= from || minute < to
+}
@@ -0,0 +1,14 @@
+package models
+
+// PrivacySettings are a user's privacy choices, stored in the user's settings scope
+type PrivacySettings struct {
+	// ShareProfileViews lists this user among the viewers of profiles they visit, and lets
+	// them see who viewed their own profile. Viewers are only revealed when both sides opt in.
+	ShareProfileViews bool  `json:"shareProfileViews"`
+	LastUpdated       int64 `json:"lastUpdated,omitempty"`
+}
+
+// UpdatePrivacyRequest is the PUT /settings/privacy request body; omitted fields keep their value
+type UpdatePrivacyRequest struct {
+	ShareProfileViews *bool `json:"shareProfileViews"`
+}
@@ -0,0 +1,23 @@
+package models
+
+// ReadOnlySetting is the admin read-only switch as stored in settings
+type ReadOnlySetting struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// ReadOnlyStatus is returned by GET and PUT /read-only. Setting is the admin switch;
+// Active reports the mode this instance enforces, which may also be forced on by
+// READ_ONLY_MODE or the startup schema check.
+type ReadOnlyStatus struct {
+	Setting      ReadOnlySetting `json:"setting"`
+	Active       bool            `json:"active"`
+	ActiveReason string          `json:"activeReason,omitempty"`
+	LastUpdated  int64           `json:"lastUpdated,omitempty"`
+}
+
+// UpdateReadOnlyRequest is the PUT /read-only request body
+type UpdateReadOnlyRequest struct {
+	Enabled bool   `json:"enabled"`
+	Reason  string `json:"reason"`
+}
@@ -0,0 +1,16 @@
+package models
+
+// SocialNames are the deployment's social name rules, stored in the deployment scope.
+// Entries are lowercase; profiles compare them after folding look-alike characters.
+type SocialNames struct {
+	Reserved    []string `json:"reserved"`
+	Blocked     []string `json:"blocked"`
+	LastUpdated int64    `json:"lastUpdated,omitempty"`
+}
+
+// UpdateSocialNamesRequest is the PUT /social-names request body; omitted lists keep
+// their value and an empty list clears one
+type UpdateSocialNamesRequest struct {
+	Reserved *[]string `json:"reserved"`
+	Blocked  *[]string `json:"blocked"`
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) Get(ctx context.Context, scope, key string, dest interface{}) (int64, error) {
+	query := fmt.Sprintf(`SELECT value, last_updated FROM %ssettings WHERE scope = $1 AND key = $2`, r.schemaPrefix())
+
+	var row struct {
+		Value       []byte `db:"value"`
+		LastUpdated int64  `db:"last_updated"`
+	}
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, scope, key); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("get setting %s/%s: %w", scope, key, err)
+	}
+	if err := json.Unmarshal(row.Value, dest); err != nil {
+		return 0, fmt.Errorf("decode setting %s/%s: %w", scope, key, err)
+	}
+	return row.LastUpdated, nil
+}
+
+func (r *postgresRepository) GetMany(ctx context.Context, scopes []string, key string) (map[string]json.RawMessage, error) {
+	values := make(map[string]json.RawMessage, len(scopes))
+	if len(scopes) == 0 {
+		return values, nil
+	}
+
+	query := fmt.Sprintf(`SELECT scope, value FROM %ssettings WHERE scope = ANY($1) AND key = $2`, r.schemaPrefix())
+
+	var rows []struct {
+		Scope string `db:"scope"`
+		Value []byte `db:"value"`
+	}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, pq.Array(scopes), key); err != nil {
+		return nil, fmt.Errorf("get settings %s for %d scopes: %w", key, len(scopes), err)
+	}
+	for _, row := range rows {
+		values[row.Scope] = json.RawMessage(row.Value)
+	}
+	return values, nil
+}
+
+func (r *postgresRepository) Put(ctx context.Context, scope, key string, value interface{}, updatedBy uuid.UUID, lastUpdated int64) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encode setting %s/%s: %w", scope, key, err)
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %ssettings (scope, key, value, updated_by, last_updated)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (scope, key) DO UPDATE
+		SET value = EXCLUDED.value, updated_by = EXCLUDED.updated_by, last_updated = EXCLUDED.last_updated
+	`, r.schemaPrefix())
+
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, scope, key, data, updatedBy, lastUpdated); err != nil {
+		return fmt.Errorf("put setting %s/%s: %w", scope, key, err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
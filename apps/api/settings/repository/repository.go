@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// ScopeDeployment holds settings that apply to the whole site
+const ScopeDeployment = "deployment"
+
+// ScopeUser returns the scope holding one user's settings
+func ScopeUser(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
+
+// KeyNotifications is the per-user key of the notification settings. Other modules read
+// it in SQL to find users who opted in to reminders.
+const KeyNotifications = "notifications"
+
+// ErrNotFound is returned when a setting has never been saved
+var ErrNotFound = errors.New("setting not found")
+
+// Repository defines data access for settings documents.
+type Repository interface {
+	// Get decodes the setting stored under scope and key into dest and returns its
+	// last update time; returns ErrNotFound when it has never been saved.
+	Get(ctx context.Context, scope, key string, dest interface{}) (int64, error)
+
+	// GetMany returns the raw JSON stored under key for each of scopes; scopes that
+	// never saved the setting are absent from the map.
+	GetMany(ctx context.Context, scopes []string, key string) (map[string]json.RawMessage, error)
+
+	// Put stores value under scope and key, replacing any previous value.
+	Put(ctx context.Context, scope, key string, value interface{}, updatedBy uuid.UUID, lastUpdated int64) error
+}
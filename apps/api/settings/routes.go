@@ -0,0 +1,95 @@
+package settings
+
+import (
+	"github.com/gofiber/fiber/v2"
+	adminmw "github.com/qolzam/telar/apps/api/internal/middleware/admin"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
+	"github.com/qolzam/telar/apps/api/internal/middleware/readonly"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/settings/handlers"
+)
+
+type Handlers struct {
+	BrandingHandler      *handlers.BrandingHandler
+	ReadOnlyHandler      *handlers.ReadOnlyHandler
+	PrivacyHandler       *handlers.PrivacyHandler
+	NotificationsHandler *handlers.NotificationsHandler
+	FeedDefaultsHandler  *handlers.FeedDefaultsHandler
+	ContentLimitsHandler *handlers.ContentLimitsHandler
+	SocialNamesHandler   *handlers.SocialNamesHandler
+	EmailDomainsHandler  *handlers.EmailDomainsHandler
+	MutedKeywordsHandler *handlers.MutedKeywordsHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires community settings endpoints.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	// Every page load reads branding, so anonymous reads are served from the HTTP cache
+	app.Get("/branding", httpcache.NewFromConfig(httpcache.ScopeBranding, nil, cfg.HTTPCache), handlers.BrandingHandler.Get)
+	app.Put("/branding", dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.BrandingHandler.Update)
+
+	// The read-only middleware exempts this path so admins can always switch the mode off
+	app.Get(readonly.TogglePath, dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.ReadOnlyHandler.Get)
+	app.Put(readonly.TogglePath, dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.ReadOnlyHandler.Update)
+
+	// Clients read the defaults to preselect the sort they show; posts and comments
+	// apply them to requests that name none
+	if handlers.FeedDefaultsHandler != nil {
+		app.Get("/feed-defaults", dualAuthMiddleware, handlers.FeedDefaultsHandler.Get)
+		app.Put("/feed-defaults", dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.FeedDefaultsHandler.Update)
+	}
+
+	// Clients read the limits to show character counters; posts, comments and profiles
+	// enforce them
+	if handlers.ContentLimitsHandler != nil {
+		app.Get("/content-limits", dualAuthMiddleware, handlers.ContentLimitsHandler.Get)
+		app.Put("/content-limits", dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.ContentLimitsHandler.Update)
+	}
+
+	// Profiles check social names against these lists at signup and rename
+	if handlers.SocialNamesHandler != nil {
+		app.Get("/social-names", dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.SocialNamesHandler.Get)
+		app.Put("/social-names", dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.SocialNamesHandler.Update)
+	}
+
+	// Signup screens new accounts against these lists
+	if handlers.EmailDomainsHandler != nil {
+		app.Get("/email-domains", dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.EmailDomainsHandler.Get)
+		app.Put("/email-domains", dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.EmailDomainsHandler.Update)
+	}
+
+	// Per-user settings
+	if handlers.PrivacyHandler != nil {
+		app.Get("/settings/privacy", dualAuthMiddleware, handlers.PrivacyHandler.Get)
+		app.Put("/settings/privacy", dualAuthMiddleware, handlers.PrivacyHandler.Update)
+	}
+	if handlers.NotificationsHandler != nil {
+		app.Get("/settings/notifications", dualAuthMiddleware, handlers.NotificationsHandler.Get)
+		app.Put("/settings/notifications", dualAuthMiddleware, handlers.NotificationsHandler.Update)
+	}
+	if handlers.MutedKeywordsHandler != nil {
+		app.Get("/settings/muted-keywords", dualAuthMiddleware, handlers.MutedKeywordsHandler.List)
+		app.Post("/settings/muted-keywords", dualAuthMiddleware, handlers.MutedKeywordsHandler.Add)
+		app.Put("/settings/muted-keywords/:keywordId", dualAuthMiddleware, handlers.MutedKeywordsHandler.Update)
+		app.Delete("/settings/muted-keywords/:keywordId", dualAuthMiddleware, handlers.MutedKeywordsHandler.Delete)
+	}
+}
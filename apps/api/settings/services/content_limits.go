@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const keyContentLimits = "content_limits"
+
+// contentLimitsTTL is how long an instance enforces the content limits it read last;
+// other instances pick up a change within it
+const contentLimitsTTL = 30 * time.Second
+
+// contentLimitField is one limit of a stored document with its default and the highest
+// value admins can set
+type contentLimitField struct {
+	name       string
+	value      *int
+	def, upper int
+}
+
+func contentLimitFields(limits *models.ContentLimits) []contentLimitField {
+	def, upper := sharedInterfaces.DefaultContentLimits, sharedInterfaces.MaxContentLimits
+	return []contentLimitField{
+		{"postBody", &limits.PostBody, def.PostBody, upper.PostBody},
+		{"postTags", &limits.PostTags, def.PostTags, upper.PostTags},
+		{"tagLength", &limits.TagLength, def.TagLength, upper.TagLength},
+		{"albumPhotos", &limits.AlbumPhotos, def.AlbumPhotos, upper.AlbumPhotos},
+		{"commentText", &limits.CommentText, def.CommentText, upper.CommentText},
+		{"bio", &limits.Bio, def.Bio, upper.Bio},
+	}
+}
+
+func (s *service) GetContentLimits(ctx context.Context) (*models.ContentLimits, error) {
+	var limits models.ContentLimits
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeDeployment, keyContentLimits, &limits)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	// Unset or out of range limits fall back to the defaults
+	for _, field := range contentLimitFields(&limits) {
+		if *field.value <= 0 || *field.value > field.upper {
+			*field.value = field.def
+		}
+	}
+	limits.LastUpdated = lastUpdated
+	return &limits, nil
+}
+
+func (s *service) UpdateContentLimits(ctx context.Context, userID uuid.UUID, req *models.UpdateContentLimitsRequest) (*models.ContentLimits, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+
+	limits, err := s.GetContentLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+	requested := []*int{req.PostBody, req.PostTags, req.TagLength, req.AlbumPhotos, req.CommentText, req.Bio}
+	for i, field := range contentLimitFields(limits) {
+		value := requested[i]
+		if value == nil {
+			continue
+		}
+		if *value < 1 || *value > field.upper {
+			return nil, fmt.Errorf("%w: %s must be between 1 and %d", settingsErrors.ErrInvalidRequest, field.name, field.upper)
+		}
+		*field.value = *value
+	}
+
+	limits.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeDeployment, keyContentLimits, limits, userID, limits.LastUpdated); err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	s.cacheContentLimits(*limits)
+	return limits, nil
+}
+
+func (s *service) ContentLimits(ctx context.Context) sharedInterfaces.ContentLimits {
+	s.limitsMu.Lock()
+	cached, fresh := s.limits, s.now().Before(s.limitsExpires)
+	s.limitsMu.Unlock()
+	if fresh {
+		return cached
+	}
+
+	limits, err := s.GetContentLimits(ctx)
+	if err != nil {
+		log.Warn("Failed to load content limits: %v", err)
+		if cached.PostBody != 0 {
+			// Keep enforcing the last copy rather than the defaults
+			return cached
+		}
+		return sharedInterfaces.DefaultContentLimits
+	}
+	return s.cacheContentLimits(*limits)
+}
+
+// cacheContentLimits keeps limits as this instance's copy for contentLimitsTTL
+func (s *service) cacheContentLimits(limits models.ContentLimits) sharedInterfaces.ContentLimits {
+	s.limitsMu.Lock()
+	defer s.limitsMu.Unlock()
+	s.limits = sharedInterfaces.ContentLimits{
+		PostBody:    limits.PostBody,
+		PostTags:    limits.PostTags,
+		TagLength:   limits.TagLength,
+		AlbumPhotos: limits.AlbumPhotos,
+		CommentText: limits.CommentText,
+		Bio:         limits.Bio,
+	}
+	s.limitsExpires = s.now().Add(contentLimitsTTL)
+	return s.limits
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateContentLimits(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.Must(uuid.NewV4())
+
+	t.Run("changes the limits present and keeps the rest", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "content_limits").
+			Return(`{"bio":300}`, int64(5), nil).Once()
+		mockRepo.On("Put", ctx, repository.ScopeDeployment, "content_limits", mock.MatchedBy(func(l *models.ContentLimits) bool {
+			return l.CommentText == 5000 && l.Bio == 300 && l.PostBody == 10000
+		}), adminID, int64(1700000000000)).Return(nil).Once()
+
+		commentText := 5000
+		svc := newTestService(mockRepo)
+		limits, err := svc.UpdateContentLimits(ctx, adminID, &models.UpdateContentLimitsRequest{CommentText: &commentText})
+		require.NoError(t, err)
+		assert.Equal(t, 5000, limits.CommentText)
+		mockRepo.AssertExpectations(t)
+
+		// This instance enforces the change at once, without reading it back
+		assert.Equal(t, 5000, svc.ContentLimits(ctx).CommentText)
+		mockRepo.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("rejects limits above the maximum", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "content_limits").Return("", int64(0), repository.ErrNotFound).Once()
+
+		postBody := sharedInterfaces.MaxContentLimits.PostBody + 1
+		_, err := newTestService(mockRepo).UpdateContentLimits(ctx, adminID, &models.UpdateContentLimitsRequest{PostBody: &postBody})
+		assert.ErrorIs(t, err, settingsErrors.ErrInvalidRequest)
+		mockRepo.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestContentLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serves the defaults until saved", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "content_limits").Return("", int64(0), repository.ErrNotFound).Once()
+
+		assert.Equal(t, sharedInterfaces.DefaultContentLimits, newTestService(mockRepo).ContentLimits(ctx))
+	})
+
+	t.Run("replaces stored limits that are out of range", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "content_limits").Return(`{"postTags":0,"tagLength":999,"albumPhotos":5}`, int64(5), nil).Once()
+
+		limits := newTestService(mockRepo).ContentLimits(ctx)
+		assert.Equal(t, sharedInterfaces.DefaultContentLimits.PostTags, limits.PostTags)
+		assert.Equal(t, sharedInterfaces.DefaultContentLimits.TagLength, limits.TagLength)
+		assert.Equal(t, 5, limits.AlbumPhotos)
+	})
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const keyEmailDomains = "email_domains"
+
+const maxEmailDomains = 5000
+
+// emailDomainsTTL is how long an instance screens signups against the lists it read
+// last; other instances pick up a change within it
+const emailDomainsTTL = 30 * time.Second
+
+var emailDomain = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]{2,63}$`)
+
+func (s *service) GetEmailDomains(ctx context.Context) (*models.EmailDomains, error) {
+	var domains models.EmailDomains
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeDeployment, keyEmailDomains, &domains)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	if domains.Allow == nil {
+		domains.Allow = []string{}
+	}
+	if domains.Deny == nil {
+		domains.Deny = []string{}
+	}
+	domains.LastUpdated = lastUpdated
+	return &domains, nil
+}
+
+func (s *service) UpdateEmailDomains(ctx context.Context, userID uuid.UUID, req *models.UpdateEmailDomainsRequest) (*models.EmailDomains, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+
+	domains, err := s.GetEmailDomains(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Allow != nil {
+		if domains.Allow, err = emailDomainEntries("allow", *req.Allow); err != nil {
+			return nil, err
+		}
+	}
+	if req.Deny != nil {
+		if domains.Deny, err = emailDomainEntries("deny", *req.Deny); err != nil {
+			return nil, err
+		}
+	}
+
+	domains.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeDeployment, keyEmailDomains, domains, userID, domains.LastUpdated); err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	s.cacheEmailDomains(*domains)
+	return domains, nil
+}
+
+// emailDomainEntries lowercases domains and drops a leading @, blanks and duplicates
+func emailDomainEntries(list string, entries []string) ([]string, error) {
+	if len(entries) > maxEmailDomains {
+		return nil, fmt.Errorf("%w: %s can hold at most %d domains", settingsErrors.ErrInvalidRequest, list, maxEmailDomains)
+	}
+	seen := make(map[string]bool, len(entries))
+	cleaned := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimPrefix(strings.ToLower(strings.TrimSpace(entry)), "@")
+		if entry == "" || seen[entry] {
+			continue
+		}
+		if len(entry) > 253 || !emailDomain.MatchString(entry) {
+			return nil, fmt.Errorf("%w: %q in %s is not a domain", settingsErrors.ErrInvalidRequest, entry, list)
+		}
+		seen[entry] = true
+		cleaned = append(cleaned, entry)
+	}
+	return cleaned, nil
+}
+
+func (s *service) EmailDomainRules(ctx context.Context) sharedInterfaces.EmailDomainRules {
+	s.domainsMu.Lock()
+	cached, fresh := s.domains, s.now().Before(s.domainsExpires)
+	s.domainsMu.Unlock()
+	if fresh {
+		return cached
+	}
+
+	domains, err := s.GetEmailDomains(ctx)
+	if err != nil {
+		log.Warn("Failed to load email domain lists: %v", err)
+		// Keep screening against the last copy, if any
+		return cached
+	}
+	return s.cacheEmailDomains(*domains)
+}
+
+// cacheEmailDomains keeps domains as this instance's copy for emailDomainsTTL
+func (s *service) cacheEmailDomains(domains models.EmailDomains) sharedInterfaces.EmailDomainRules {
+	s.domainsMu.Lock()
+	defer s.domainsMu.Unlock()
+	s.domains = sharedInterfaces.EmailDomainRules{Allow: domains.Allow, Deny: domains.Deny}
+	s.domainsExpires = s.now().Add(emailDomainsTTL)
+	return s.domains
+}
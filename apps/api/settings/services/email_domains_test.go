@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateEmailDomains(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.Must(uuid.NewV4())
+
+	t.Run("normalizes the lists present and keeps the rest", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "email_domains").
+			Return(`{"deny":["mailinator.com"]}`, int64(5), nil).Once()
+		mockRepo.On("Put", ctx, repository.ScopeDeployment, "email_domains", mock.MatchedBy(func(d *models.EmailDomains) bool {
+			return assert.ObjectsAreEqual([]string{"example.com"}, d.Allow) && assert.ObjectsAreEqual([]string{"mailinator.com"}, d.Deny)
+		}), adminID, int64(1700000000000)).Return(nil).Once()
+
+		allow := []string{"@Example.com", "example.com "}
+		svc := newTestService(mockRepo)
+		domains, err := svc.UpdateEmailDomains(ctx, adminID, &models.UpdateEmailDomainsRequest{Allow: &allow})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"example.com"}, domains.Allow)
+		mockRepo.AssertExpectations(t)
+
+		// This instance screens against the change at once, without reading it back
+		assert.Equal(t, []string{"example.com"}, svc.EmailDomainRules(ctx).Allow)
+		mockRepo.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("rejects entries that are not domains", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "email_domains").Return("", int64(0), repository.ErrNotFound).Once()
+
+		deny := []string{"jane@example.com"}
+		_, err := newTestService(mockRepo).UpdateEmailDomains(ctx, adminID, &models.UpdateEmailDomainsRequest{Deny: &deny})
+		assert.ErrorIs(t, err, settingsErrors.ErrInvalidRequest)
+		mockRepo.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestEmailDomainRules(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("admits every domain until saved", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "email_domains").Return("", int64(0), repository.ErrNotFound).Once()
+
+		rules := newTestService(mockRepo).EmailDomainRules(ctx)
+		assert.Empty(t, rules.Allow)
+		assert.Empty(t, rules.Deny)
+	})
+
+	t.Run("admits every domain when the lists cannot be read", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "email_domains").Return("", int64(0), errors.New("down")).Once()
+
+		assert.Empty(t, newTestService(mockRepo).EmailDomainRules(ctx).Allow)
+	})
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the settings repository. Get decodes the
+// first return value, a JSON string, into dest.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) Get(ctx context.Context, scope, key string, dest interface{}) (int64, error) {
+	args := m.Called(ctx, scope, key)
+	if raw, ok := args.Get(0).(string); ok && raw != "" {
+		if err := json.Unmarshal([]byte(raw), dest); err != nil {
+			return 0, err
+		}
+	}
+	return args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockRepository) GetMany(ctx context.Context, scopes []string, key string) (map[string]json.RawMessage, error) {
+	args := m.Called(ctx, scopes, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]json.RawMessage), args.Error(1)
+}
+
+func (m *MockRepository) Put(ctx context.Context, scope, key string, value interface{}, updatedBy uuid.UUID, lastUpdated int64) error {
+	args := m.Called(ctx, scope, key, value, updatedBy, lastUpdated)
+	return args.Error(0)
+}
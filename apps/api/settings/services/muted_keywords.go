@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const (
+	maxMutedKeywords     = 100
+	minMutedPhraseLen    = 2
+	maxMutedPhraseLen    = 100
+	maxCachedMatchers    = 10000
+	mutedMatcherTTL      = time.Minute
+	mutedWordBoundary    = `[^\p{L}\p{N}_]`
+	mutedPhraseSeparator = `\s+`
+)
+
+// cachedMatcher is a user's compiled matcher; a nil matcher caches that the user muted nothing
+type cachedMatcher struct {
+	matcher *keywordMatcher
+	expires time.Time
+}
+
+// keywordMatcher matches all of a user's muted keywords with a single regular expression
+type keywordMatcher struct {
+	re *regexp.Regexp
+}
+
+func (m *keywordMatcher) Match(text string) bool {
+	return text != "" && m.re.MatchString(text)
+}
+
+func (s *service) ListMutedKeywords(ctx context.Context, userID uuid.UUID) (*models.MutedKeywords, error) {
+	var muted models.MutedKeywords
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeUser(userID), keyMutedKeywords, &muted)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	if muted.Keywords == nil {
+		muted.Keywords = []models.MutedKeyword{}
+	}
+	muted.LastUpdated = lastUpdated
+	return &muted, nil
+}
+
+func (s *service) AddMutedKeyword(ctx context.Context, userID uuid.UUID, req *models.MutedKeywordRequest) (*models.MutedKeyword, error) {
+	phrase, err := mutedPhrase(req)
+	if err != nil {
+		return nil, err
+	}
+
+	muted, err := s.ListMutedKeywords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(muted.Keywords) >= maxMutedKeywords {
+		return nil, fmt.Errorf("%w: at most %d muted keywords", settingsErrors.ErrInvalidRequest, maxMutedKeywords)
+	}
+	if mutedIndex(muted.Keywords, phrase) >= 0 {
+		return nil, fmt.Errorf("%w: %q is already muted", settingsErrors.ErrConflict, phrase)
+	}
+
+	keyword := models.MutedKeyword{
+		ID:          uuid.Must(uuid.NewV4()).String(),
+		Phrase:      phrase,
+		CreatedDate: s.now().UTC().UnixMilli(),
+	}
+	muted.Keywords = append(muted.Keywords, keyword)
+	if err := s.saveMutedKeywords(ctx, userID, muted); err != nil {
+		return nil, err
+	}
+	return &keyword, nil
+}
+
+func (s *service) UpdateMutedKeyword(ctx context.Context, userID uuid.UUID, keywordID string, req *models.MutedKeywordRequest) (*models.MutedKeyword, error) {
+	phrase, err := mutedPhrase(req)
+	if err != nil {
+		return nil, err
+	}
+
+	muted, err := s.ListMutedKeywords(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	i := mutedKeywordByID(muted.Keywords, keywordID)
+	if i < 0 {
+		return nil, fmt.Errorf("%w: muted keyword %s", settingsErrors.ErrNotFound, keywordID)
+	}
+	if j := mutedIndex(muted.Keywords, phrase); j >= 0 && j != i {
+		return nil, fmt.Errorf("%w: %q is already muted", settingsErrors.ErrConflict, phrase)
+	}
+
+	muted.Keywords[i].Phrase = phrase
+	if err := s.saveMutedKeywords(ctx, userID, muted); err != nil {
+		return nil, err
+	}
+	return &muted.Keywords[i], nil
+}
+
+func (s *service) DeleteMutedKeyword(ctx context.Context, userID uuid.UUID, keywordID string) error {
+	muted, err := s.ListMutedKeywords(ctx, userID)
+	if err != nil {
+		return err
+	}
+	i := mutedKeywordByID(muted.Keywords, keywordID)
+	if i < 0 {
+		return fmt.Errorf("%w: muted keyword %s", settingsErrors.ErrNotFound, keywordID)
+	}
+
+	muted.Keywords = append(muted.Keywords[:i], muted.Keywords[i+1:]...)
+	return s.saveMutedKeywords(ctx, userID, muted)
+}
+
+func (s *service) MutedMatcher(ctx context.Context, userID uuid.UUID) sharedInterfaces.KeywordMatcher {
+	now := s.now()
+	s.mutedMu.Lock()
+	cached, ok := s.mutedMatchers[userID]
+	s.mutedMu.Unlock()
+	if !ok || !now.Before(cached.expires) {
+		muted, err := s.ListMutedKeywords(ctx, userID)
+		if err != nil {
+			log.Warn("Failed to load muted keywords for %s: %v", userID, err)
+			return nil
+		}
+		cached = s.cacheMatcher(userID, muted.Keywords)
+	}
+
+	if cached.matcher == nil {
+		return nil
+	}
+	return cached.matcher
+}
+
+// saveMutedKeywords stores the user's muted keywords and recompiles this instance's
+// matcher, so the change applies at once here and within mutedMatcherTTL elsewhere
+func (s *service) saveMutedKeywords(ctx context.Context, userID uuid.UUID, muted *models.MutedKeywords) error {
+	muted.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeUser(userID), keyMutedKeywords, muted, userID, muted.LastUpdated); err != nil {
+		return fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	s.cacheMatcher(userID, muted.Keywords)
+	return nil
+}
+
+// cacheMatcher compiles the keywords and keeps the matcher for mutedMatcherTTL. A full
+// cache first drops expired matchers, then starts over.
+func (s *service) cacheMatcher(userID uuid.UUID, keywords []models.MutedKeyword) cachedMatcher {
+	now := s.now()
+	cached := cachedMatcher{matcher: compileMutedKeywords(keywords), expires: now.Add(mutedMatcherTTL)}
+
+	s.mutedMu.Lock()
+	defer s.mutedMu.Unlock()
+	if _, ok := s.mutedMatchers[userID]; !ok && len(s.mutedMatchers) >= maxCachedMatchers {
+		for id, entry := range s.mutedMatchers {
+			if !now.Before(entry.expires) {
+				delete(s.mutedMatchers, id)
+			}
+		}
+		if len(s.mutedMatchers) >= maxCachedMatchers {
+			s.mutedMatchers = make(map[uuid.UUID]cachedMatcher)
+		}
+	}
+	s.mutedMatchers[userID] = cached
+	return cached
+}
+
+// compileMutedKeywords builds one case-insensitive expression matching any keyword as
+// whole words, with any run of whitespace between the words of a phrase. It returns nil
+// when there are no keywords.
+func compileMutedKeywords(keywords []models.MutedKeyword) *keywordMatcher {
+	if len(keywords) == 0 {
+		return nil
+	}
+	alternatives := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		words := strings.Fields(keyword.Phrase)
+		for j, word := range words {
+			words[j] = regexp.QuoteMeta(word)
+		}
+		alternatives[i] = strings.Join(words, mutedPhraseSeparator)
+	}
+	pattern := `(?i)(?:^|` + mutedWordBoundary + `)(?:` + strings.Join(alternatives, "|") + `)(?:$|` + mutedWordBoundary + `)`
+	return &keywordMatcher{re: regexp.MustCompile(pattern)}
+}
+
+// mutedPhrase validates a requested phrase and collapses its whitespace
+func mutedPhrase(req *models.MutedKeywordRequest) (string, error) {
+	if req == nil {
+		return "", fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+	phrase := strings.Join(strings.Fields(req.Phrase), " ")
+	if n := len([]rune(phrase)); n < minMutedPhraseLen || n > maxMutedPhraseLen {
+		return "", fmt.Errorf("%w: phrase must be %d-%d characters", settingsErrors.ErrInvalidRequest, minMutedPhraseLen, maxMutedPhraseLen)
+	}
+	return phrase, nil
+}
+
+// mutedIndex returns the position of the keyword with this phrase, ignoring case, or -1
+func mutedIndex(keywords []models.MutedKeyword, phrase string) int {
+	for i, keyword := range keywords {
+		if strings.EqualFold(keyword.Phrase, phrase) {
+			return i
+		}
+	}
+	return -1
+}
+
+// mutedKeywordByID returns the position of the keyword with this ID, or -1
+func mutedKeywordByID(keywords []models.MutedKeyword, id string) int {
+	for i, keyword := range keywords {
+		if keyword.ID == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+)
+
+func TestMutedKeywords(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	scope := repository.ScopeUser(userID)
+	stored := `{"keywords":[{"id":"k1","phrase":"spoilers","createdDate":1}]}`
+
+	t.Run("adds a phrase with collapsed whitespace", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, scope, "muted_keywords").Return(stored, int64(1), nil).Once()
+		mockRepo.On("Put", ctx, scope, "muted_keywords", mock.MatchedBy(func(m *models.MutedKeywords) bool {
+			return len(m.Keywords) == 2 && m.Keywords[1].Phrase == "season finale"
+		}), userID, int64(1700000000000)).Return(nil).Once()
+
+		keyword, err := newTestService(mockRepo).AddMutedKeyword(ctx, userID, &models.MutedKeywordRequest{Phrase: "  season \t finale "})
+		require.NoError(t, err)
+		assert.Equal(t, "season finale", keyword.Phrase)
+		assert.NotEmpty(t, keyword.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects duplicates and bad phrases", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, scope, "muted_keywords").Return(stored, int64(1), nil)
+		svc := newTestService(mockRepo)
+
+		_, err := svc.AddMutedKeyword(ctx, userID, &models.MutedKeywordRequest{Phrase: "SPOILERS"})
+		assert.ErrorIs(t, err, settingsErrors.ErrConflict)
+		_, err = svc.AddMutedKeyword(ctx, userID, &models.MutedKeywordRequest{Phrase: " x "})
+		assert.ErrorIs(t, err, settingsErrors.ErrInvalidRequest)
+		mockRepo.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("updates and deletes by id", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, scope, "muted_keywords").Return(stored, int64(1), nil)
+		mockRepo.On("Put", ctx, scope, "muted_keywords", mock.Anything, userID, int64(1700000000000)).Return(nil)
+		svc := newTestService(mockRepo)
+
+		keyword, err := svc.UpdateMutedKeyword(ctx, userID, "k1", &models.MutedKeywordRequest{Phrase: "leaks"})
+		require.NoError(t, err)
+		assert.Equal(t, models.MutedKeyword{ID: "k1", Phrase: "leaks", CreatedDate: 1}, *keyword)
+
+		require.NoError(t, svc.DeleteMutedKeyword(ctx, userID, "k1"))
+		assert.ErrorIs(t, svc.DeleteMutedKeyword(ctx, userID, "missing"), settingsErrors.ErrNotFound)
+	})
+}
+
+func TestMutedMatcher(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	scope := repository.ScopeUser(userID)
+
+	t.Run("matches whole words and phrases ignoring case", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, scope, "muted_keywords").
+			Return(`{"keywords":[{"id":"a","phrase":"season finale"},{"id":"b","phrase":"c++"},{"id":"c","phrase":"crypto"}]}`, int64(1), nil).Once()
+		svc := newTestService(mockRepo)
+
+		matcher := svc.MutedMatcher(ctx, userID)
+		require.NotNil(t, matcher)
+		for text, want := range map[string]bool{
+			"No SEASON\nFinale talk please": true,
+			"Learning C++ this week":        true,
+			"#crypto is back":               true,
+			"cryptography homework":         false,
+			"the season's finale":           false,
+			"":                              false,
+		} {
+			assert.Equal(t, want, matcher.Match(text), text)
+		}
+
+		// Served from the cache until it expires
+		svc.MutedMatcher(ctx, userID)
+		mockRepo.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("is nil without keywords or when they cannot be read", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, scope, "muted_keywords").Return("", int64(0), repository.ErrNotFound).Once()
+		other := uuid.Must(uuid.NewV4())
+		mockRepo.On("Get", ctx, repository.ScopeUser(other), "muted_keywords").Return("", int64(0), errors.New("connection refused")).Once()
+		svc := newTestService(mockRepo)
+
+		assert.Nil(t, svc.MutedMatcher(ctx, userID))
+		assert.Nil(t, svc.MutedMatcher(ctx, other))
+	})
+
+	t.Run("applies a change on this instance at once", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, scope, "muted_keywords").Return("", int64(0), repository.ErrNotFound)
+		mockRepo.On("Put", ctx, scope, "muted_keywords", mock.Anything, userID, int64(1700000000000)).Return(nil).Once()
+		svc := newTestService(mockRepo)
+
+		assert.Nil(t, svc.MutedMatcher(ctx, userID))
+		_, err := svc.AddMutedKeyword(ctx, userID, &models.MutedKeywordRequest{Phrase: "spoilers"})
+		require.NoError(t, err)
+		matcher := svc.MutedMatcher(ctx, userID)
+		require.NotNil(t, matcher)
+		assert.True(t, matcher.Match("no spoilers!"))
+	})
+}
@@ -0,0 +1,558 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/middleware/httpcache"
+	"github.com/qolzam/telar/apps/api/internal/middleware/readonly"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+// Settings keys of the deployment documents
+const (
+	keyBranding     = "branding"
+	keyReadOnly     = "read_only"
+	keyFeedDefaults = "feed_defaults"
+)
+
+// Settings keys of the per-user documents
+const (
+	keyPrivacy       = "privacy"
+	keyMutedKeywords = "muted_keywords"
+)
+
+const (
+	maxSiteNameLen    = 64
+	maxURLLen         = 2048
+	maxFooterLinks    = 10
+	maxFooterLabelLen = 40
+	maxReadOnlyReason = 200
+)
+
+// feedDefaultsTTL is how long an instance serves the feed defaults it read last; other
+// instances pick up a change within it
+const feedDefaultsTTL = 30 * time.Second
+
+// builtinFeedDefaults apply until an admin saves feed defaults
+var builtinFeedDefaults = models.FeedDefaults{
+	FeedAlgorithm:  sharedInterfaces.FeedChronological,
+	CommentSort:    sharedInterfaces.CommentSortNewest,
+	PostVisibility: "Public",
+}
+
+var (
+	feedAlgorithms   = map[string]bool{sharedInterfaces.FeedChronological: true, sharedInterfaces.FeedRanked: true}
+	commentSorts     = map[string]bool{sharedInterfaces.CommentSortNewest: true, sharedInterfaces.CommentSortOldest: true}
+	postVisibilities = map[string]bool{"Public": true, "OnlyMe": true, "Circles": true}
+)
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// Service defines community settings operations.
+type Service interface {
+	// GetBranding returns the deployment branding, filled in with defaults from the
+	// app config for anything an admin has not set.
+	GetBranding(ctx context.Context) (*models.Branding, error)
+
+	// UpdateBranding replaces the deployment branding.
+	UpdateBranding(ctx context.Context, userID uuid.UUID, req *models.UpdateBrandingRequest) (*models.Branding, error)
+
+	// GetReadOnly returns the admin read-only switch and the mode this instance enforces.
+	GetReadOnly(ctx context.Context) (*models.ReadOnlyStatus, error)
+
+	// UpdateReadOnly stores the admin read-only switch and applies it to this instance
+	// at once; other instances pick it up on their next poll.
+	UpdateReadOnly(ctx context.Context, userID uuid.UUID, req *models.UpdateReadOnlyRequest) (*models.ReadOnlyStatus, error)
+
+	// LoadReadOnly reads the stored switch for readonly.Watch.
+	LoadReadOnly(ctx context.Context) (bool, string, error)
+
+	// GetPrivacy returns a user's privacy settings; everything is off until the user opts in.
+	GetPrivacy(ctx context.Context, userID uuid.UUID) (*models.PrivacySettings, error)
+
+	// UpdatePrivacy changes the privacy settings present in req.
+	UpdatePrivacy(ctx context.Context, userID uuid.UUID, req *models.UpdatePrivacyRequest) (*models.PrivacySettings, error)
+
+	// ProfileViewSharing reports which of the users opted in to sharing profile views.
+	ProfileViewSharing(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+
+	// GetNotifications returns a user's notification settings; reminders are off until
+	// the user opts in.
+	GetNotifications(ctx context.Context, userID uuid.UUID) (*models.NotificationSettings, error)
+
+	// UpdateNotifications changes the notification settings present in req.
+	UpdateNotifications(ctx context.Context, userID uuid.UUID, req *models.UpdateNotificationsRequest) (*models.NotificationSettings, error)
+
+	// GetFeedDefaults returns the deployment feed defaults, built-in ones for anything
+	// an admin has not set.
+	GetFeedDefaults(ctx context.Context) (*models.FeedDefaults, error)
+
+	// UpdateFeedDefaults changes the feed defaults present in req.
+	UpdateFeedDefaults(ctx context.Context, userID uuid.UUID, req *models.UpdateFeedDefaultsRequest) (*models.FeedDefaults, error)
+
+	// FeedDefaults serves the feed defaults to posts and comments from a copy that is
+	// read again after feedDefaultsTTL.
+	FeedDefaults(ctx context.Context) sharedInterfaces.FeedDefaults
+
+	// GetContentLimits returns the deployment content limits, defaults for anything an
+	// admin has not set.
+	GetContentLimits(ctx context.Context) (*models.ContentLimits, error)
+
+	// UpdateContentLimits changes the content limits present in req.
+	UpdateContentLimits(ctx context.Context, userID uuid.UUID, req *models.UpdateContentLimitsRequest) (*models.ContentLimits, error)
+
+	// ContentLimits serves the content limits to posts, comments and profiles from a
+	// copy that is read again after contentLimitsTTL.
+	ContentLimits(ctx context.Context) sharedInterfaces.ContentLimits
+
+	// GetSocialNames returns the reserved and blocked social names, defaults for a list
+	// an admin has not saved.
+	GetSocialNames(ctx context.Context) (*models.SocialNames, error)
+
+	// UpdateSocialNames replaces the social name lists present in req.
+	UpdateSocialNames(ctx context.Context, userID uuid.UUID, req *models.UpdateSocialNamesRequest) (*models.SocialNames, error)
+
+	// SocialNameRules serves the social name rules to profiles from a copy that is read
+	// again after socialNamesTTL.
+	SocialNameRules(ctx context.Context) sharedInterfaces.SocialNameRules
+
+	// GetEmailDomains returns the email domains allowed and denied at signup.
+	GetEmailDomains(ctx context.Context) (*models.EmailDomains, error)
+
+	// UpdateEmailDomains replaces the email domain lists present in req.
+	UpdateEmailDomains(ctx context.Context, userID uuid.UUID, req *models.UpdateEmailDomainsRequest) (*models.EmailDomains, error)
+
+	// EmailDomainRules serves the email domain lists to signup from a copy that is read
+	// again after emailDomainsTTL.
+	EmailDomainRules(ctx context.Context) sharedInterfaces.EmailDomainRules
+
+	// ListMutedKeywords returns a user's muted keywords, oldest first.
+	ListMutedKeywords(ctx context.Context, userID uuid.UUID) (*models.MutedKeywords, error)
+
+	// AddMutedKeyword mutes a word or phrase for the user.
+	AddMutedKeyword(ctx context.Context, userID uuid.UUID, req *models.MutedKeywordRequest) (*models.MutedKeyword, error)
+
+	// UpdateMutedKeyword replaces the phrase of one of the user's muted keywords.
+	UpdateMutedKeyword(ctx context.Context, userID uuid.UUID, keywordID string, req *models.MutedKeywordRequest) (*models.MutedKeyword, error)
+
+	// DeleteMutedKeyword unmutes one of the user's muted keywords.
+	DeleteMutedKeyword(ctx context.Context, userID uuid.UUID, keywordID string) error
+
+	// MutedMatcher serves posts and comments a compiled matcher of the user's muted
+	// keywords from a per-instance cache that is read again after mutedMatcherTTL.
+	MutedMatcher(ctx context.Context, userID uuid.UUID) sharedInterfaces.KeywordMatcher
+}
+
+type service struct {
+	repo     repository.Repository
+	defaults models.Branding
+	now      func() time.Time
+
+	feedMu      sync.Mutex
+	feed        sharedInterfaces.FeedDefaults
+	feedExpires time.Time
+
+	limitsMu      sync.Mutex
+	limits        sharedInterfaces.ContentLimits
+	limitsExpires time.Time
+
+	namesMu      sync.Mutex
+	names        sharedInterfaces.SocialNameRules
+	namesExpires time.Time
+
+	domainsMu      sync.Mutex
+	domains        sharedInterfaces.EmailDomainRules
+	domainsExpires time.Time
+
+	mutedMu       sync.Mutex
+	mutedMatchers map[uuid.UUID]cachedMatcher
+}
+
+// NewService constructs a settings service. Branding defaults come from the app
+// config (APP_NAME, ORG_AVATAR), so deployments without stored branding look as before.
+func NewService(repo repository.Repository, cfg platformconfig.AppConfig) Service {
+	return &service{
+		repo: repo,
+		defaults: models.Branding{
+			SiteName:    cfg.Name,
+			LogoURL:     cfg.OrgAvatar,
+			FooterLinks: []models.FooterLink{},
+		},
+		now:           time.Now,
+		mutedMatchers: make(map[uuid.UUID]cachedMatcher),
+	}
+}
+
+func (s *service) GetBranding(ctx context.Context) (*models.Branding, error) {
+	var stored models.Branding
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeDeployment, keyBranding, &stored)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			branding := s.defaults
+			return &branding, nil
+		}
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	stored.LastUpdated = lastUpdated
+	return s.withDefaults(stored), nil
+}
+
+func (s *service) UpdateBranding(ctx context.Context, userID uuid.UUID, req *models.UpdateBrandingRequest) (*models.Branding, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+
+	branding := models.Branding{
+		SiteName:    strings.TrimSpace(req.SiteName),
+		LogoURL:     strings.TrimSpace(req.LogoURL),
+		FaviconURL:  strings.TrimSpace(req.FaviconURL),
+		Colors:      req.Colors,
+		FooterLinks: req.FooterLinks,
+	}
+	if branding.FooterLinks == nil {
+		branding.FooterLinks = []models.FooterLink{}
+	}
+	if err := validateBranding(&branding); err != nil {
+		return nil, err
+	}
+
+	lastUpdated := s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeDeployment, keyBranding, branding, userID, lastUpdated); err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	httpcache.Invalidate(ctx, httpcache.ScopeBranding)
+
+	branding.LastUpdated = lastUpdated
+	return s.withDefaults(branding), nil
+}
+
+func (s *service) GetReadOnly(ctx context.Context) (*models.ReadOnlyStatus, error) {
+	var setting models.ReadOnlySetting
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeDeployment, keyReadOnly, &setting)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	return readOnlyStatus(setting, lastUpdated), nil
+}
+
+func (s *service) UpdateReadOnly(ctx context.Context, userID uuid.UUID, req *models.UpdateReadOnlyRequest) (*models.ReadOnlyStatus, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+	setting := models.ReadOnlySetting{Enabled: req.Enabled, Reason: strings.TrimSpace(req.Reason)}
+	if len([]rune(setting.Reason)) > maxReadOnlyReason {
+		return nil, fmt.Errorf("%w: reason must be at most %d characters", settingsErrors.ErrInvalidRequest, maxReadOnlyReason)
+	}
+	if !setting.Enabled {
+		setting.Reason = ""
+	}
+
+	lastUpdated := s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeDeployment, keyReadOnly, setting, userID, lastUpdated); err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	applyReadOnly(setting)
+
+	return readOnlyStatus(setting, lastUpdated), nil
+}
+
+func (s *service) LoadReadOnly(ctx context.Context) (bool, string, error) {
+	var setting models.ReadOnlySetting
+	if _, err := s.repo.Get(ctx, repository.ScopeDeployment, keyReadOnly, &setting); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return false, "", nil
+		}
+		return false, "", err
+	}
+	return setting.Enabled, setting.Reason, nil
+}
+
+func (s *service) GetPrivacy(ctx context.Context, userID uuid.UUID) (*models.PrivacySettings, error) {
+	var privacy models.PrivacySettings
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeUser(userID), keyPrivacy, &privacy)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	privacy.LastUpdated = lastUpdated
+	return &privacy, nil
+}
+
+func (s *service) UpdatePrivacy(ctx context.Context, userID uuid.UUID, req *models.UpdatePrivacyRequest) (*models.PrivacySettings, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+
+	privacy, err := s.GetPrivacy(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.ShareProfileViews != nil {
+		privacy.ShareProfileViews = *req.ShareProfileViews
+	}
+
+	privacy.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeUser(userID), keyPrivacy, privacy, userID, privacy.LastUpdated); err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	return privacy, nil
+}
+
+func (s *service) ProfileViewSharing(ctx context.Context, userIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	scopes := make([]string, len(userIDs))
+	for i, id := range userIDs {
+		scopes[i] = repository.ScopeUser(id)
+	}
+	stored, err := s.repo.GetMany(ctx, scopes, keyPrivacy)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+
+	sharing := make(map[uuid.UUID]bool, len(userIDs))
+	for _, id := range userIDs {
+		var privacy models.PrivacySettings
+		if raw, ok := stored[repository.ScopeUser(id)]; ok && json.Unmarshal(raw, &privacy) == nil {
+			sharing[id] = privacy.ShareProfileViews
+		}
+	}
+	return sharing, nil
+}
+
+func (s *service) GetNotifications(ctx context.Context, userID uuid.UUID) (*models.NotificationSettings, error) {
+	var notifications models.NotificationSettings
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeUser(userID), repository.KeyNotifications, &notifications)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	notifications.LastUpdated = lastUpdated
+	return &notifications, nil
+}
+
+func (s *service) UpdateNotifications(ctx context.Context, userID uuid.UUID, req *models.UpdateNotificationsRequest) (*models.NotificationSettings, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+
+	notifications, err := s.GetNotifications(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.StreakReminders != nil {
+		notifications.StreakReminders = *req.StreakReminders
+	}
+	if q := req.QuietHours; q != nil {
+		if q.Start == "" && q.End == "" {
+			notifications.QuietHours = nil
+		} else {
+			quiet := models.QuietHours{
+				Start:    strings.TrimSpace(q.Start),
+				End:      strings.TrimSpace(q.End),
+				TimeZone: strings.TrimSpace(q.TimeZone),
+			}
+			if err := quiet.Validate(); err != nil {
+				return nil, fmt.Errorf("%w: %v", settingsErrors.ErrInvalidRequest, err)
+			}
+			notifications.QuietHours = &quiet
+		}
+	}
+
+	notifications.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeUser(userID), repository.KeyNotifications, notifications, userID, notifications.LastUpdated); err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	return notifications, nil
+}
+
+func (s *service) GetFeedDefaults(ctx context.Context) (*models.FeedDefaults, error) {
+	var stored models.FeedDefaults
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeDeployment, keyFeedDefaults, &stored)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	feed := feedDefaultsWithBuiltins(stored)
+	feed.LastUpdated = lastUpdated
+	return feed, nil
+}
+
+func (s *service) UpdateFeedDefaults(ctx context.Context, userID uuid.UUID, req *models.UpdateFeedDefaultsRequest) (*models.FeedDefaults, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+
+	feed, err := s.GetFeedDefaults(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range []struct {
+		name  string
+		value *string
+		dest  *string
+		valid map[string]bool
+	}{
+		{"feedAlgorithm", req.FeedAlgorithm, &feed.FeedAlgorithm, feedAlgorithms},
+		{"commentSort", req.CommentSort, &feed.CommentSort, commentSorts},
+		{"postVisibility", req.PostVisibility, &feed.PostVisibility, postVisibilities},
+	} {
+		if field.value == nil {
+			continue
+		}
+		value := strings.TrimSpace(*field.value)
+		if !field.valid[value] {
+			return nil, fmt.Errorf("%w: unknown %s %q", settingsErrors.ErrInvalidRequest, field.name, value)
+		}
+		*field.dest = value
+	}
+
+	feed.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeDeployment, keyFeedDefaults, feed, userID, feed.LastUpdated); err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	s.cacheFeedDefaults(*feed)
+	return feed, nil
+}
+
+func (s *service) FeedDefaults(ctx context.Context) sharedInterfaces.FeedDefaults {
+	s.feedMu.Lock()
+	cached, fresh := s.feed, s.now().Before(s.feedExpires)
+	s.feedMu.Unlock()
+	if fresh {
+		return cached
+	}
+
+	feed, err := s.GetFeedDefaults(ctx)
+	if err != nil {
+		log.Warn("Failed to load feed defaults: %v", err)
+		if cached.FeedAlgorithm != "" {
+			// Keep serving the last copy rather than the built-ins
+			return cached
+		}
+		return sharedFeedDefaults(builtinFeedDefaults)
+	}
+	return s.cacheFeedDefaults(*feed)
+}
+
+// cacheFeedDefaults keeps feed as this instance's copy for feedDefaultsTTL
+func (s *service) cacheFeedDefaults(feed models.FeedDefaults) sharedInterfaces.FeedDefaults {
+	s.feedMu.Lock()
+	defer s.feedMu.Unlock()
+	s.feed = sharedFeedDefaults(feed)
+	s.feedExpires = s.now().Add(feedDefaultsTTL)
+	return s.feed
+}
+
+// feedDefaultsWithBuiltins fills unset or no longer valid fields with the built-in defaults
+func feedDefaultsWithBuiltins(feed models.FeedDefaults) *models.FeedDefaults {
+	if !feedAlgorithms[feed.FeedAlgorithm] {
+		feed.FeedAlgorithm = builtinFeedDefaults.FeedAlgorithm
+	}
+	if !commentSorts[feed.CommentSort] {
+		feed.CommentSort = builtinFeedDefaults.CommentSort
+	}
+	if !postVisibilities[feed.PostVisibility] {
+		feed.PostVisibility = builtinFeedDefaults.PostVisibility
+	}
+	return &feed
+}
+
+func sharedFeedDefaults(feed models.FeedDefaults) sharedInterfaces.FeedDefaults {
+	return sharedInterfaces.FeedDefaults{
+		FeedAlgorithm:  feed.FeedAlgorithm,
+		CommentSort:    feed.CommentSort,
+		PostVisibility: feed.PostVisibility,
+	}
+}
+
+// applyReadOnly switches the settings source of this instance's read-only mode
+func applyReadOnly(setting models.ReadOnlySetting) {
+	if setting.Enabled {
+		readonly.Enable(readonly.SourceSettings, setting.Reason)
+	} else {
+		readonly.Disable(readonly.SourceSettings)
+	}
+}
+
+func readOnlyStatus(setting models.ReadOnlySetting, lastUpdated int64) *models.ReadOnlyStatus {
+	active, reason := readonly.Status()
+	return &models.ReadOnlyStatus{
+		Setting:      setting,
+		Active:       active,
+		ActiveReason: reason,
+		LastUpdated:  lastUpdated,
+	}
+}
+
+// withDefaults fills unset fields from the deployment defaults
+func (s *service) withDefaults(b models.Branding) *models.Branding {
+	if b.SiteName == "" {
+		b.SiteName = s.defaults.SiteName
+	}
+	if b.LogoURL == "" {
+		b.LogoURL = s.defaults.LogoURL
+	}
+	if b.FooterLinks == nil {
+		b.FooterLinks = []models.FooterLink{}
+	}
+	return &b
+}
+
+// validateBranding checks lengths, colors and URLs. URLs must be http(s) or
+// site-relative so a stored value can never become a javascript: link.
+func validateBranding(b *models.Branding) error {
+	if len([]rune(b.SiteName)) > maxSiteNameLen {
+		return fmt.Errorf("%w: siteName must be at most %d characters", settingsErrors.ErrInvalidRequest, maxSiteNameLen)
+	}
+	for field, value := range map[string]string{"logoUrl": b.LogoURL, "faviconUrl": b.FaviconURL} {
+		if value != "" && !validURL(value) {
+			return fmt.Errorf("%w: %s must be an http(s) or site-relative URL", settingsErrors.ErrInvalidRequest, field)
+		}
+	}
+	for field, value := range map[string]string{
+		"colors.primary":    b.Colors.Primary,
+		"colors.secondary":  b.Colors.Secondary,
+		"colors.background": b.Colors.Background,
+		"colors.text":       b.Colors.Text,
+	} {
+		if value != "" && !hexColor.MatchString(value) {
+			return fmt.Errorf("%w: %s must be a hex color like #1a2b3c", settingsErrors.ErrInvalidRequest, field)
+		}
+	}
+	if len(b.FooterLinks) > maxFooterLinks {
+		return fmt.Errorf("%w: at most %d footer links", settingsErrors.ErrInvalidRequest, maxFooterLinks)
+	}
+	for i := range b.FooterLinks {
+		link := &b.FooterLinks[i]
+		link.Label = strings.TrimSpace(link.Label)
+		link.URL = strings.TrimSpace(link.URL)
+		if link.Label == "" || len([]rune(link.Label)) > maxFooterLabelLen {
+			return fmt.Errorf("%w: footer link labels must be 1-%d characters", settingsErrors.ErrInvalidRequest, maxFooterLabelLen)
+		}
+		if !validURL(link.URL) {
+			return fmt.Errorf("%w: footer link %q must have an http(s) or site-relative URL", settingsErrors.ErrInvalidRequest, link.Label)
+		}
+	}
+	return nil
+}
+
+func validURL(raw string) bool {
+	if len(raw) > maxURLLen {
+		return false
+	}
+	if strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//") {
+		return true
+	}
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/middleware/readonly"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestService(repo repository.Repository) *service {
+	svc := NewService(repo, platformconfig.AppConfig{Name: "Telar", OrgAvatar: "https://cdn.example.com/logo.png"}).(*service)
+	svc.now = func() time.Time { return time.UnixMilli(1700000000000) }
+	return svc
+}
+
+func TestGetBranding(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("falls back to app defaults", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "branding").Return("", int64(0), repository.ErrNotFound).Once()
+
+		branding, err := newTestService(mockRepo).GetBranding(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Telar", branding.SiteName)
+		assert.Equal(t, "https://cdn.example.com/logo.png", branding.LogoURL)
+		assert.NotNil(t, branding.FooterLinks)
+	})
+
+	t.Run("merges stored branding over defaults", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "branding").
+			Return(`{"siteName":"Makers","colors":{"primary":"#ff6600"}}`, int64(42), nil).Once()
+
+		branding, err := newTestService(mockRepo).GetBranding(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "Makers", branding.SiteName)
+		assert.Equal(t, "https://cdn.example.com/logo.png", branding.LogoURL)
+		assert.Equal(t, "#ff6600", branding.Colors.Primary)
+		assert.Equal(t, int64(42), branding.LastUpdated)
+	})
+}
+
+func TestUpdateBranding(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.Must(uuid.NewV4())
+
+	t.Run("stores valid branding", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Put", ctx, repository.ScopeDeployment, "branding", mock.Anything, adminID, int64(1700000000000)).Return(nil).Once()
+
+		branding, err := newTestService(mockRepo).UpdateBranding(ctx, adminID, &models.UpdateBrandingRequest{
+			SiteName:    " Makers ",
+			Colors:      models.ColorPalette{Primary: "#f60"},
+			FooterLinks: []models.FooterLink{{Label: "Code of conduct", URL: "/conduct"}, {Label: "Blog", URL: "https://blog.example.com"}},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Makers", branding.SiteName)
+		assert.Len(t, branding.FooterLinks, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects unsafe urls and bad colors", func(t *testing.T) {
+		svc := newTestService(new(MockRepository))
+		for _, req := range []*models.UpdateBrandingRequest{
+			{LogoURL: "javascript:alert(1)"},
+			{FooterLinks: []models.FooterLink{{Label: "Home", URL: "//evil.example.com"}}},
+			{FooterLinks: []models.FooterLink{{Label: "", URL: "/about"}}},
+			{Colors: models.ColorPalette{Background: "red"}},
+		} {
+			_, err := svc.UpdateBranding(ctx, adminID, req)
+			assert.True(t, errors.Is(err, settingsErrors.ErrInvalidRequest), "%+v", req)
+		}
+	})
+}
+
+func TestUpdateReadOnly(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.Must(uuid.NewV4())
+	t.Cleanup(func() { readonly.Disable(readonly.SourceSettings) })
+
+	t.Run("stores the switch and applies it locally", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Put", ctx, repository.ScopeDeployment, "read_only",
+			models.ReadOnlySetting{Enabled: true, Reason: "DB failover"}, adminID, int64(1700000000000)).Return(nil).Once()
+
+		status, err := newTestService(mockRepo).UpdateReadOnly(ctx, adminID, &models.UpdateReadOnlyRequest{Enabled: true, Reason: " DB failover "})
+		require.NoError(t, err)
+		assert.True(t, status.Active)
+		assert.Equal(t, "DB failover", status.ActiveReason)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("switching off clears the reason", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Put", ctx, repository.ScopeDeployment, "read_only",
+			models.ReadOnlySetting{Enabled: false}, adminID, int64(1700000000000)).Return(nil).Once()
+
+		status, err := newTestService(mockRepo).UpdateReadOnly(ctx, adminID, &models.UpdateReadOnlyRequest{Reason: "stale"})
+		require.NoError(t, err)
+		assert.False(t, status.Active)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestLoadReadOnly(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("Get", ctx, repository.ScopeDeployment, "read_only").Return("", int64(0), repository.ErrNotFound).Once()
+	on, _, err := newTestService(mockRepo).LoadReadOnly(ctx)
+	require.NoError(t, err)
+	assert.False(t, on)
+
+	mockRepo = new(MockRepository)
+	mockRepo.On("Get", ctx, repository.ScopeDeployment, "read_only").Return(`{"enabled":true,"reason":"restore"}`, int64(7), nil).Once()
+	on, why, err := newTestService(mockRepo).LoadReadOnly(ctx)
+	require.NoError(t, err)
+	assert.True(t, on)
+	assert.Equal(t, "restore", why)
+}
+
+func TestUpdatePrivacy(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	scope := repository.ScopeUser(userID)
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("Get", ctx, scope, "privacy").Return("", int64(0), repository.ErrNotFound).Once()
+	mockRepo.On("Put", ctx, scope, "privacy",
+		&models.PrivacySettings{ShareProfileViews: true, LastUpdated: 1700000000000}, userID, int64(1700000000000)).Return(nil).Once()
+
+	share := true
+	privacy, err := newTestService(mockRepo).UpdatePrivacy(ctx, userID, &models.UpdatePrivacyRequest{ShareProfileViews: &share})
+	require.NoError(t, err)
+	assert.True(t, privacy.ShareProfileViews)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestProfileViewSharing(t *testing.T) {
+	ctx := context.Background()
+	sharer := uuid.Must(uuid.NewV4())
+	optedOut := uuid.Must(uuid.NewV4())
+	unset := uuid.Must(uuid.NewV4())
+	scopes := []string{repository.ScopeUser(sharer), repository.ScopeUser(optedOut), repository.ScopeUser(unset)}
+
+	mockRepo := new(MockRepository)
+	mockRepo.On("GetMany", ctx, scopes, "privacy").Return(map[string]json.RawMessage{
+		repository.ScopeUser(sharer):   json.RawMessage(`{"shareProfileViews":true}`),
+		repository.ScopeUser(optedOut): json.RawMessage(`{"shareProfileViews":false}`),
+	}, nil).Once()
+
+	sharing, err := newTestService(mockRepo).ProfileViewSharing(ctx, []uuid.UUID{sharer, optedOut, unset})
+	require.NoError(t, err)
+	assert.True(t, sharing[sharer])
+	assert.False(t, sharing[optedOut])
+	assert.False(t, sharing[unset])
+}
+
+func TestUpdateNotifications(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	scope := repository.ScopeUser(userID)
+
+	t.Run("enables reminders with quiet hours", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, scope, "notifications").Return("", int64(0), repository.ErrNotFound).Once()
+		mockRepo.On("Put", ctx, scope, "notifications", &models.NotificationSettings{
+			StreakReminders: true,
+			QuietHours:      &models.QuietHours{Start: "22:00", End: "07:30", TimeZone: "Europe/Berlin"},
+			LastUpdated:     1700000000000,
+		}, userID, int64(1700000000000)).Return(nil).Once()
+
+		on := true
+		settings, err := newTestService(mockRepo).UpdateNotifications(ctx, userID, &models.UpdateNotificationsRequest{
+			StreakReminders: &on,
+			QuietHours:      &models.QuietHours{Start: " 22:00", End: "07:30", TimeZone: "Europe/Berlin"},
+		})
+		require.NoError(t, err)
+		assert.True(t, settings.StreakReminders)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("clears quiet hours and keeps the rest", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, scope, "notifications").
+			Return(`{"streakReminders":true,"quietHours":{"start":"22:00","end":"07:00"}}`, int64(5), nil).Once()
+		mockRepo.On("Put", ctx, scope, "notifications",
+			&models.NotificationSettings{StreakReminders: true, LastUpdated: 1700000000000}, userID, int64(1700000000000)).Return(nil).Once()
+
+		settings, err := newTestService(mockRepo).UpdateNotifications(ctx, userID, &models.UpdateNotificationsRequest{
+			QuietHours: &models.QuietHours{},
+		})
+		require.NoError(t, err)
+		assert.Nil(t, settings.QuietHours)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid quiet hours", func(t *testing.T) {
+		for _, quiet := range []models.QuietHours{
+			{Start: "25:00", End: "07:00"},
+			{Start: "22:00", End: "22:00"},
+			{Start: "22:00", End: "07:00", TimeZone: "Mars/Olympus"},
+		} {
+			mockRepo := new(MockRepository)
+			mockRepo.On("Get", ctx, scope, "notifications").Return("", int64(0), repository.ErrNotFound).Once()
+
+			q := quiet
+			_, err := newTestService(mockRepo).UpdateNotifications(ctx, userID, &models.UpdateNotificationsRequest{QuietHours: &q})
+			assert.ErrorIs(t, err, settingsErrors.ErrInvalidRequest)
+			mockRepo.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+}
+
+func TestUpdateFeedDefaults(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.Must(uuid.NewV4())
+
+	t.Run("changes the fields present and keeps the rest", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "feed_defaults").
+			Return(`{"commentSort":"oldest"}`, int64(5), nil).Once()
+		mockRepo.On("Put", ctx, repository.ScopeDeployment, "feed_defaults", &models.FeedDefaults{
+			FeedAlgorithm:  "ranked",
+			CommentSort:    "oldest",
+			PostVisibility: "Public",
+			LastUpdated:    1700000000000,
+		}, adminID, int64(1700000000000)).Return(nil).Once()
+
+		ranked := " ranked"
+		svc := newTestService(mockRepo)
+		feed, err := svc.UpdateFeedDefaults(ctx, adminID, &models.UpdateFeedDefaultsRequest{FeedAlgorithm: &ranked})
+		require.NoError(t, err)
+		assert.Equal(t, "ranked", feed.FeedAlgorithm)
+		mockRepo.AssertExpectations(t)
+
+		// This instance serves the change at once, without reading it back
+		assert.Equal(t, "ranked", svc.FeedDefaults(ctx).FeedAlgorithm)
+		mockRepo.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("rejects unknown values", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "feed_defaults").Return("", int64(0), repository.ErrNotFound).Once()
+
+		visibility := "Friends"
+		_, err := newTestService(mockRepo).UpdateFeedDefaults(ctx, adminID, &models.UpdateFeedDefaultsRequest{PostVisibility: &visibility})
+		assert.ErrorIs(t, err, settingsErrors.ErrInvalidRequest)
+		mockRepo.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestFeedDefaults(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serves built-in defaults until saved", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "feed_defaults").Return("", int64(0), repository.ErrNotFound).Once()
+
+		feed := newTestService(mockRepo).FeedDefaults(ctx)
+		assert.Equal(t, "chronological", feed.FeedAlgorithm)
+		assert.Equal(t, "newest", feed.CommentSort)
+		assert.Equal(t, "Public", feed.PostVisibility)
+	})
+
+	t.Run("reads again once the copy expires", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "feed_defaults").Return(`{"feedAlgorithm":"ranked"}`, int64(5), nil).Once()
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "feed_defaults").Return("", int64(0), errors.New("connection refused")).Once()
+
+		svc := newTestService(mockRepo)
+		now := svc.now()
+		assert.Equal(t, "ranked", svc.FeedDefaults(ctx).FeedAlgorithm)
+		assert.Equal(t, "ranked", svc.FeedDefaults(ctx).FeedAlgorithm)
+		mockRepo.AssertNumberOfCalls(t, "Get", 1)
+
+		// A failed read keeps the last copy
+		svc.now = func() time.Time { return now.Add(feedDefaultsTTL) }
+		assert.Equal(t, "ranked", svc.FeedDefaults(ctx).FeedAlgorithm)
+		mockRepo.AssertNumberOfCalls(t, "Get", 2)
+	})
+}
+
+func TestQuietHoursContains(t *testing.T) {
+	overnight := &models.QuietHours{Start: "22:00", End: "07:00", TimeZone: "America/New_York"}
+	// 03:00 UTC is 23:00 the previous evening in New York (EDT)
+	assert.True(t, overnight.Contains(time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)))
+	assert.True(t, overnight.Contains(time.Date(2026, 6, 1, 10, 59, 0, 0, time.UTC)))
+	assert.False(t, overnight.Contains(time.Date(2026, 6, 1, 11, 0, 0, 0, time.UTC)))
+
+	daytime := &models.QuietHours{Start: "09:00", End: "17:00"}
+	assert.True(t, daytime.Contains(time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)))
+	assert.False(t, daytime.Contains(time.Date(2026, 6, 1, 17, 0, 0, 0, time.UTC)))
+	assert.False(t, daytime.Contains(time.Date(2026, 6, 1, 8, 59, 0, 0, time.UTC)))
+}
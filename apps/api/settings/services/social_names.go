@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+)
+
+const keySocialNames = "social_names"
+
+const (
+	maxSocialNameEntries  = 1000
+	maxSocialNameEntryLen = 50
+)
+
+// socialNamesTTL is how long an instance checks names against the rules it read last;
+// other instances pick up a change within it
+const socialNamesTTL = 30 * time.Second
+
+func (s *service) GetSocialNames(ctx context.Context) (*models.SocialNames, error) {
+	var names models.SocialNames
+	lastUpdated, err := s.repo.Get(ctx, repository.ScopeDeployment, keySocialNames, &names)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	// A list never saved falls back to the defaults; a saved empty list stays empty
+	if names.Reserved == nil {
+		names.Reserved = append([]string{}, sharedInterfaces.DefaultSocialNameRules.Reserved...)
+	}
+	if names.Blocked == nil {
+		names.Blocked = append([]string{}, sharedInterfaces.DefaultSocialNameRules.Blocked...)
+	}
+	names.LastUpdated = lastUpdated
+	return &names, nil
+}
+
+func (s *service) UpdateSocialNames(ctx context.Context, userID uuid.UUID, req *models.UpdateSocialNamesRequest) (*models.SocialNames, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", settingsErrors.ErrInvalidRequest)
+	}
+
+	names, err := s.GetSocialNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Reserved != nil {
+		if names.Reserved, err = socialNameEntries("reserved", *req.Reserved); err != nil {
+			return nil, err
+		}
+	}
+	if req.Blocked != nil {
+		if names.Blocked, err = socialNameEntries("blocked", *req.Blocked); err != nil {
+			return nil, err
+		}
+	}
+
+	names.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.Put(ctx, repository.ScopeDeployment, keySocialNames, names, userID, names.LastUpdated); err != nil {
+		return nil, fmt.Errorf("%w: %v", settingsErrors.ErrDatabaseOperation, err)
+	}
+	s.cacheSocialNames(*names)
+	return names, nil
+}
+
+// socialNameEntries lowercases and trims entries, dropping blanks and duplicates
+func socialNameEntries(list string, entries []string) ([]string, error) {
+	if len(entries) > maxSocialNameEntries {
+		return nil, fmt.Errorf("%w: %s can hold at most %d names", settingsErrors.ErrInvalidRequest, list, maxSocialNameEntries)
+	}
+	seen := make(map[string]bool, len(entries))
+	cleaned := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" || seen[entry] {
+			continue
+		}
+		if utf8.RuneCountInString(entry) > maxSocialNameEntryLen || strings.ContainsAny(entry, " \t\n") {
+			return nil, fmt.Errorf("%w: %s entries must be single words of at most %d characters", settingsErrors.ErrInvalidRequest, list, maxSocialNameEntryLen)
+		}
+		seen[entry] = true
+		cleaned = append(cleaned, entry)
+	}
+	return cleaned, nil
+}
+
+func (s *service) SocialNameRules(ctx context.Context) sharedInterfaces.SocialNameRules {
+	s.namesMu.Lock()
+	cached, fresh := s.names, s.now().Before(s.namesExpires)
+	s.namesMu.Unlock()
+	if fresh {
+		return cached
+	}
+
+	names, err := s.GetSocialNames(ctx)
+	if err != nil {
+		log.Warn("Failed to load social name rules: %v", err)
+		if !s.namesExpires.IsZero() {
+			// Keep checking against the last copy rather than the defaults
+			return cached
+		}
+		return sharedInterfaces.DefaultSocialNameRules
+	}
+	return s.cacheSocialNames(*names)
+}
+
+// cacheSocialNames keeps names as this instance's copy for socialNamesTTL
+func (s *service) cacheSocialNames(names models.SocialNames) sharedInterfaces.SocialNameRules {
+	s.namesMu.Lock()
+	defer s.namesMu.Unlock()
+	s.names = sharedInterfaces.SocialNameRules{Reserved: names.Reserved, Blocked: names.Blocked}
+	s.namesExpires = s.now().Add(socialNamesTTL)
+	return s.names
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	uuid "github.com/gofrs/uuid"
+	settingsErrors "github.com/qolzam/telar/apps/api/settings/errors"
+	"github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/settings/repository"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSocialNames(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.Must(uuid.NewV4())
+
+	t.Run("replaces the lists present and keeps the rest", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "social_names").
+			Return(`{"blocked":["badword"]}`, int64(5), nil).Once()
+		mockRepo.On("Put", ctx, repository.ScopeDeployment, "social_names", mock.MatchedBy(func(n *models.SocialNames) bool {
+			return assert.ObjectsAreEqual([]string{"admin", "events"}, n.Reserved) && assert.ObjectsAreEqual([]string{"badword"}, n.Blocked)
+		}), adminID, int64(1700000000000)).Return(nil).Once()
+
+		reserved := []string{" Admin ", "events", "admin", ""}
+		svc := newTestService(mockRepo)
+		names, err := svc.UpdateSocialNames(ctx, adminID, &models.UpdateSocialNamesRequest{Reserved: &reserved})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"admin", "events"}, names.Reserved)
+		mockRepo.AssertExpectations(t)
+
+		// This instance checks against the change at once, without reading it back
+		assert.Equal(t, []string{"admin", "events"}, svc.SocialNameRules(ctx).Reserved)
+		mockRepo.AssertNumberOfCalls(t, "Get", 1)
+	})
+
+	t.Run("rejects entries with spaces", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "social_names").Return("", int64(0), repository.ErrNotFound).Once()
+
+		blocked := []string{"two words"}
+		_, err := newTestService(mockRepo).UpdateSocialNames(ctx, adminID, &models.UpdateSocialNamesRequest{Blocked: &blocked})
+		assert.ErrorIs(t, err, settingsErrors.ErrInvalidRequest)
+		mockRepo.AssertNotCalled(t, "Put", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSocialNameRules(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("serves the defaults until saved", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "social_names").Return("", int64(0), repository.ErrNotFound).Once()
+
+		assert.Equal(t, sharedInterfaces.DefaultSocialNameRules, newTestService(mockRepo).SocialNameRules(ctx))
+	})
+
+	t.Run("keeps a saved empty list empty", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("Get", ctx, repository.ScopeDeployment, "social_names").Return(`{"blocked":[]}`, int64(5), nil).Once()
+
+		rules := newTestService(mockRepo).SocialNameRules(ctx)
+		assert.Empty(t, rules.Blocked)
+		assert.Equal(t, sharedInterfaces.DefaultSocialNameRules.Reserved, rules.Reserved)
+	})
+}
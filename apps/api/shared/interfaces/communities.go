@@ -0,0 +1,62 @@
+package interfaces
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofrs/uuid"
+)
+
+// CommunityChecker is the public interface for community membership.
+// Posts depend on it to let only members post in a community, anonymously only where the
+// community allows it, and moderators take posts out of it, comments to let only members
+// comment in communities that approve their members, and posts and comments to keep
+// archived communities read-only, without importing the communities module.
+type CommunityChecker interface {
+	// IsCommunityMember reports whether userID belongs to communityID, in any role.
+	IsCommunityMember(ctx context.Context, communityID, userID uuid.UUID) (bool, error)
+
+	// IsCommunityModerator reports whether userID owns or moderates communityID.
+	IsCommunityModerator(ctx context.Context, communityID, userID uuid.UUID) (bool, error)
+
+	// IsCommunityArchived reports whether communityID was archived and is read-only.
+	IsCommunityArchived(ctx context.Context, communityID uuid.UUID) (bool, error)
+
+	// AllowsAnonymousPosts reports whether members may post anonymously in communityID.
+	AllowsAnonymousPosts(ctx context.Context, communityID uuid.UUID) (bool, error)
+
+	// RequiresApproval reports whether communityID is active and joined through its
+	// approval queue.
+	RequiresApproval(ctx context.Context, communityID uuid.UUID) (bool, error)
+}
+
+// CommunityAdmitter is the public interface for joining communities that require approval.
+// Membership depends on it to take applications only for those communities, let their
+// owner and moderators review them and admit approved applicants, without importing the
+// communities module.
+type CommunityAdmitter interface {
+	CommunityChecker
+
+	// AdmitMember makes userID a member of communityID once their application is
+	// approved; admitting a member again changes nothing.
+	AdmitMember(ctx context.Context, communityID, userID uuid.UUID) error
+}
+
+// CommunityPostAuthor is who a post published on a community's behalf appears to be from
+type CommunityPostAuthor struct {
+	UserID      uuid.UUID
+	DisplayName string
+	SocialName  string
+	Avatar      string
+}
+
+// CommunityPoster is the public interface for the posts in a community. Communities
+// depend on it to tell how active a community is and to publish approved conversation
+// starters, without importing the posts module.
+type CommunityPoster interface {
+	// CountCommunityPosts returns how many live posts communityID has had since since.
+	CountCommunityPosts(ctx context.Context, communityID uuid.UUID, since time.Time) (int64, error)
+
+	// PublishCommunityPost posts body in communityID as author and returns the post ID.
+	PublishCommunityPost(ctx context.Context, communityID uuid.UUID, body string, author CommunityPostAuthor) (uuid.UUID, error)
+}
@@ -0,0 +1,44 @@
+package interfaces
+
+import "context"
+
+// ContentLimits are the largest posts, comments and profile bios a deployment accepts.
+// Lengths count characters, not bytes.
+type ContentLimits struct {
+	PostBody    int // Characters in a post body
+	PostTags    int // Tags on a post
+	TagLength   int // Characters in one tag
+	AlbumPhotos int // Photos in a post album
+	CommentText int // Characters in a comment
+	Bio         int // Characters in a profile tagline
+}
+
+// DefaultContentLimits apply until an admin changes them
+var DefaultContentLimits = ContentLimits{
+	PostBody:    10000,
+	PostTags:    10,
+	TagLength:   50,
+	AlbumPhotos: 20,
+	CommentText: 1000,
+	Bio:         500,
+}
+
+// MaxContentLimits are the highest limits an admin can set. Handlers reject larger
+// requests before they reach the services.
+var MaxContentLimits = ContentLimits{
+	PostBody:    50000,
+	PostTags:    30,
+	TagLength:   100,
+	AlbumPhotos: 50,
+	CommentText: 10000,
+	Bio:         2000,
+}
+
+// ContentLimitsProvider is the public interface for the deployment content limits.
+// Posts, comments and profiles depend on it to enforce them, without importing the
+// settings module.
+type ContentLimitsProvider interface {
+	// ContentLimits returns the current limits; it falls back to DefaultContentLimits
+	// when none were saved or they cannot be read, so callers never fail on it.
+	ContentLimits(ctx context.Context) ContentLimits
+}
@@ -0,0 +1,39 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// DelegationScopePostCreate lets a delegate publish posts as the account that granted it
+const DelegationScopePostCreate = "post:create"
+
+// ActingIdentity is the account a delegate acts as, with the profile fields written onto
+// the content it creates.
+type ActingIdentity struct {
+	UserID      uuid.UUID
+	GrantID     uuid.UUID // The active grant that authorized acting; audit entries cite it
+	DisplayName string
+	SocialName  string
+	Avatar      string
+}
+
+// DelegationAuthorizer is the public interface for delegated access.
+// Posts depends on it to publish on behalf of another account without importing the
+// delegations module.
+type DelegationAuthorizer interface {
+	// AuthorizeActingAs returns the identity delegateID may act as for scope, or nil when
+	// principalID has no active grant for that scope.
+	AuthorizeActingAs(ctx context.Context, principalID, delegateID uuid.UUID, scope string) (*ActingIdentity, error)
+
+	DelegationAuditor
+}
+
+// DelegationAuditor keeps the audit trail of actions delegates take as another account.
+type DelegationAuditor interface {
+	// RecordDelegatedAction writes an audit entry attributing an action taken as identity to
+	// the delegate who performed it, under the grant identity was authorized by. Callers run
+	// it in the transaction of the action so that neither commits without the other.
+	RecordDelegatedAction(ctx context.Context, identity *ActingIdentity, delegateID uuid.UUID, scope string, targetID uuid.UUID) error
+}
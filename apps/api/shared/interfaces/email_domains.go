@@ -0,0 +1,19 @@
+package interfaces
+
+import "context"
+
+// EmailDomainRules decide which email domains can sign up. A domain also matches its
+// subdomains, so "example.com" covers "mail.example.com".
+type EmailDomainRules struct {
+	Allow []string // When not empty, only these domains can sign up
+	Deny  []string // Domains that cannot sign up, even when allowed
+}
+
+// EmailDomainRulesProvider is the public interface for the deployment email domain
+// lists. Signup depends on it to screen new accounts, without importing the settings
+// module.
+type EmailDomainRulesProvider interface {
+	// EmailDomainRules returns the current lists; both are empty when none were saved
+	// or they cannot be read, so callers never fail on it.
+	EmailDomainRules(ctx context.Context) EmailDomainRules
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// ExperimentAssigner is the public interface for experiment bucketing.
+// Auth depends on it to embed a user's variants in the "experiments" JWT claim
+// without importing the experiments module.
+type ExperimentAssigner interface {
+	// AssignmentsForUser maps experiment keys to variants; it never fails and returns
+	// nil when assignments are unavailable.
+	AssignmentsForUser(ctx context.Context, userID uuid.UUID, role string) map[string]string
+}
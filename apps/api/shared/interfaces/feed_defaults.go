@@ -0,0 +1,32 @@
+package interfaces
+
+import "context"
+
+// Feed algorithms a deployment can list feeds with by default
+const (
+	FeedChronological = "chronological" // Newest posts first
+	FeedRanked        = "ranked"        // Highest scored posts first
+)
+
+// Comment orders a deployment can list root comments in by default
+const (
+	CommentSortNewest = "newest"
+	CommentSortOldest = "oldest"
+)
+
+// FeedDefaults are the listing and posting defaults an admin chose for the deployment.
+// Requests that name a sort or a visibility override them.
+type FeedDefaults struct {
+	FeedAlgorithm  string // FeedChronological or FeedRanked
+	CommentSort    string // CommentSortNewest or CommentSortOldest
+	PostVisibility string // Permission of posts created without one: Public, OnlyMe or Circles
+}
+
+// FeedDefaultsProvider is the public interface for the deployment feed defaults.
+// Posts and comments depend on it to replace their built-in defaults, without
+// importing the settings module.
+type FeedDefaultsProvider interface {
+	// FeedDefaults returns the current defaults; it falls back to the built-in ones
+	// when none were saved or they cannot be read, so callers never fail on it.
+	FeedDefaults(ctx context.Context) FeedDefaults
+}
@@ -0,0 +1,31 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// PostImageVariants are the URLs of the resized copies of an uploaded post image
+type PostImageVariants struct {
+	FullPath  string // Shown on the post page
+	Thumbnail string // Shown in feeds
+}
+
+// PostImageUpdater is the public interface for pointing posts at processed images.
+// The image pipeline calls it once an uploaded post image has been resized, without
+// importing the posts module.
+type PostImageUpdater interface {
+	// ApplyImageVariants replaces the image of ownerID's posts that show the upload
+	// stored at key with its variants and returns how many posts changed.
+	ApplyImageVariants(ctx context.Context, ownerID uuid.UUID, key string, variants PostImageVariants) (int, error)
+}
+
+// ImageVariantResolver is the public interface for looking up processed uploads.
+// Posts call it when a post is written with an image the pipeline already processed,
+// so the post does not keep the original.
+type ImageVariantResolver interface {
+	// PostImageVariants returns the variants of the upload imageURL points at; ok is
+	// false unless it is a processed post image uploaded by ownerID.
+	PostImageVariants(ctx context.Context, ownerID uuid.UUID, imageURL string) (variants PostImageVariants, ok bool)
+}
@@ -0,0 +1,33 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// MentionSource is a post body or comment whose text may @mention users
+type MentionSource struct {
+	PostID    uuid.UUID
+	CommentID *uuid.UUID // nil when the post body mentions
+	// AuthorID is the real author. It is never shown, and authors are not notified of
+	// their own mentions.
+	AuthorID uuid.UUID
+	// Actor fields are shown to the mentioned users; ActorUserID is nil on anonymous posts
+	ActorUserID      *uuid.UUID
+	ActorDisplayName string
+	ActorAvatar      string
+	Text             string
+	// HidePreview leaves the text out of notifications, as for supporter-only posts
+	HidePreview bool
+}
+
+// MentionRecorder is the public interface for @mentions.
+// Posts and comments call it after a write to record and notify mentioned users,
+// without importing the mentions module.
+type MentionRecorder interface {
+	// RecordMentions resolves the @socialName mentions in source, stores the ones not
+	// recorded yet and notifies those users. Failures are logged rather than returned,
+	// so a mention never fails the write.
+	RecordMentions(ctx context.Context, source MentionSource)
+}
@@ -0,0 +1,55 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"github.com/gofrs/uuid"
+)
+
+var (
+	// ErrContentNotFound is returned for reported content that does not exist or was deleted
+	ErrContentNotFound = errors.New("content not found")
+
+	// ErrProtectedAccount is returned when moderation would suspend an admin or moderator
+	ErrProtectedAccount = errors.New("admins and moderators cannot be suspended through moderation")
+)
+
+// ContentModerator looks up and hides content users report. Posts and comments each
+// provide one for their own content.
+type ContentModerator interface {
+	// ContentOwner returns who wrote the content, even when it was posted anonymously,
+	// or ErrContentNotFound
+	ContentOwner(ctx context.Context, contentID uuid.UUID) (uuid.UUID, error)
+
+	// HideContent removes the content from everyone's view, whoever wrote it
+	HideContent(ctx context.Context, contentID uuid.UUID) error
+}
+
+// UserSuspender suspends users on behalf of moderators
+type UserSuspender interface {
+	// SuspendUser stops the user from signing in and records who suspended them and
+	// why. Returns ErrProtectedAccount for admins and moderators.
+	SuspendUser(ctx context.Context, moderatorID, userID uuid.UUID, reason string) error
+}
+
+// Kinds of content a ContentScreener checks
+const (
+	ScreenedPost    = "post"
+	ScreenedComment = "comment"
+)
+
+// ScreenedContent is a new post or comment handed to a ContentScreener
+type ScreenedContent struct {
+	// TargetType is ScreenedPost or ScreenedComment
+	TargetType string
+	TargetID   uuid.UUID
+	AuthorID   uuid.UUID
+	Text       string
+}
+
+// ContentScreener checks new content for abuse and flags it for moderators
+type ContentScreener interface {
+	// ScreenContent queues the content for checking and returns without waiting
+	ScreenContent(ctx context.Context, content ScreenedContent)
+}
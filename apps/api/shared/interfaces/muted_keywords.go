@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// KeywordMatcher tests text against one user's muted keywords
+type KeywordMatcher interface {
+	// Match reports whether text contains a muted keyword or phrase as whole words, ignoring case.
+	Match(text string) bool
+}
+
+// KeywordMuter is the public interface for per-user muted keywords.
+// Posts and comments depend on it to drop content from a reader's feeds and
+// notifications, without importing the settings module.
+type KeywordMuter interface {
+	// MutedMatcher returns the user's matcher, or nil when the user muted nothing or the
+	// keywords cannot be read, so callers show everything rather than fail.
+	MutedMatcher(ctx context.Context, userID uuid.UUID) KeywordMatcher
+}
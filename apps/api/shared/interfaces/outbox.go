@@ -0,0 +1,109 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// Event types written to the outbox. Consumers in other services subscribe to them.
+const (
+	// OutboxCommentCreated is written with every new comment. Data: CommentCreatedEvent.
+	OutboxCommentCreated = "comment.created"
+	// OutboxCommentDeleted is written when root comments are deleted. Data: CommentDeletedEvent.
+	OutboxCommentDeleted = "comment.deleted"
+	// OutboxCommentUpdated is written when a comment is edited. Data: CommentChangedEvent.
+	OutboxCommentUpdated = "comment.updated"
+	// OutboxReplyDeleted is written when a reply is deleted. Data: CommentChangedEvent.
+	OutboxReplyDeleted = "comment.reply_deleted"
+	// OutboxBookmarkAdded is written when a user bookmarks a post. Data: BookmarkChangedEvent.
+	OutboxBookmarkAdded = "bookmark.added"
+	// OutboxBookmarkRemoved is written when a user removes a bookmark. Data: BookmarkChangedEvent.
+	OutboxBookmarkRemoved = "bookmark.removed"
+	// OutboxPostCreated is written with every new post. Data: PostChangedEvent.
+	OutboxPostCreated = "post.created"
+	// OutboxPostUpdated is written when a post is edited. Data: PostChangedEvent.
+	OutboxPostUpdated = "post.updated"
+	// OutboxPostDeleted is written when a post is deleted or hidden. Data: PostChangedEvent.
+	OutboxPostDeleted = "post.deleted"
+	// OutboxPostStatsChanged is written when a post's score or comment count changes. Data: PostChangedEvent.
+	OutboxPostStatsChanged = "post.stats_changed"
+	// OutboxUserSignedUp is written when a signup completes. Data: UserSignedUpEvent.
+	OutboxUserSignedUp = "user.signed_up"
+	// OutboxUserDeleted is written when an admin deletes an account. Data: UserDeletedEvent.
+	OutboxUserDeleted = "user.deleted"
+	// OutboxProfileUpdated is written when a user changes their name or avatar. Data: ProfileUpdatedEvent.
+	OutboxProfileUpdated = "profile.updated"
+)
+
+// EventOutbox is the public interface for the transactional outbox. Services append
+// events inside the transaction that makes the change, so the event is published if and
+// only if the change commits; a dispatcher publishes it to the message broker afterwards.
+type EventOutbox interface {
+	// Append records an event. It joins the transaction carried by ctx, if any. key names
+	// what the event is about, e.g. the post ID; Kafka partitions by it.
+	Append(ctx context.Context, eventType string, key string, data interface{}) error
+}
+
+// CommentCreatedEvent describes a new comment
+type CommentCreatedEvent struct {
+	CommentId       uuid.UUID  `json:"commentId"`
+	PostId          uuid.UUID  `json:"postId"`
+	AuthorUserId    uuid.UUID  `json:"authorUserId"`
+	ParentCommentId *uuid.UUID `json:"parentCommentId,omitempty"` // nil for root comments
+	ReplyToUserId   *uuid.UUID `json:"replyToUserId,omitempty"`   // The user a reply answers
+}
+
+// CommentDeletedEvent describes root comments deleted from a post, with their replies
+type CommentDeletedEvent struct {
+	PostId    uuid.UUID  `json:"postId"`
+	CommentId *uuid.UUID `json:"commentId,omitempty"` // nil when all comments of the post were deleted
+	RootCount int        `json:"rootCount"`           // Root comments deleted
+}
+
+// CommentChangedEvent names a comment that was edited, or a reply that was deleted
+type CommentChangedEvent struct {
+	CommentId uuid.UUID `json:"commentId"`
+	PostId    uuid.UUID `json:"postId"`
+}
+
+// BookmarkChangedEvent names a post a user bookmarked or stopped bookmarking
+type BookmarkChangedEvent struct {
+	UserId uuid.UUID `json:"userId"`
+	PostId uuid.UUID `json:"postId"`
+}
+
+// PostChangedEvent names a post that was created, edited or deleted. Consumers read the
+// post's current state rather than carrying it in the event; URLKey and Tags are what the
+// post had before the write, so the pages that showed it can be found once it is deleted
+// or retagged.
+type PostChangedEvent struct {
+	PostId uuid.UUID `json:"postId"`
+	URLKey string    `json:"urlKey,omitempty"`
+	Tags   []string  `json:"tags,omitempty"`
+}
+
+// UserSignedUpEvent describes a new account and its profile
+type UserSignedUpEvent struct {
+	UserId     uuid.UUID `json:"userId"`
+	Username   string    `json:"username"`
+	FullName   string    `json:"fullName"`
+	SocialName string    `json:"socialName"`
+}
+
+// UserDeletedEvent describes an account deleted with its profile, posts and comments
+type UserDeletedEvent struct {
+	UserId       uuid.UUID `json:"userId"`
+	Username     string    `json:"username"`
+	DeletedBy    uuid.UUID `json:"deletedBy"`    // The admin who deleted the account
+	PostsDeleted int64     `json:"postsDeleted"` // Posts deleted with the account
+}
+
+// ProfileUpdatedEvent carries the name and avatar a user changed to, for the services
+// that store them next to the user's content
+type ProfileUpdatedEvent struct {
+	UserId   uuid.UUID `json:"userId"`
+	FullName string    `json:"fullName"`
+	Avatar   string    `json:"avatar"`
+	Version  int64     `json:"version"` // Unix milliseconds of the change; older changes arriving late are skipped
+}
@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// RulesChecker is the public interface for community rules acknowledgment.
+// Posts and comments depend on it to keep users from writing in a community until they
+// have acknowledged its rules, without importing the rules module.
+type RulesChecker interface {
+	// HasAcknowledgedRules reports whether userID acknowledged communityID's rules, or it
+	// has none.
+	HasAcknowledgedRules(ctx context.Context, communityID, userID uuid.UUID) (bool, error)
+}
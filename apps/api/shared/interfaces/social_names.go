@@ -0,0 +1,35 @@
+package interfaces
+
+import "context"
+
+// SocialNameRules are the social names nobody can take and the words no social name
+// can contain. Names are compared after folding look-alike characters, so "adm1n" and
+// "аdmin" with a Cyrillic а both count as "admin".
+type SocialNameRules struct {
+	Reserved []string // Whole names, such as routes and system accounts
+	Blocked  []string // Words matched anywhere in a name, such as profanity
+}
+
+// DefaultSocialNameRules apply until an admin changes them. Reserved names cover the
+// API and web routes a profile URL could be mistaken for.
+var DefaultSocialNameRules = SocialNameRules{
+	Reserved: []string{
+		"admin", "administrator", "api", "auth", "login", "logout", "signup", "signin",
+		"register", "settings", "profile", "profiles", "posts", "comments", "votes",
+		"communities", "membership", "mentions", "notifications", "circles", "gallery",
+		"media", "search", "tags", "feed", "branding", "support", "help", "moderator",
+		"staff", "system", "root", "www", "mail", "ftp", "me", "null", "undefined",
+	},
+	Blocked: []string{
+		"fuck", "shit", "cunt", "bitch", "nigger", "faggot", "whore", "slut",
+	},
+}
+
+// SocialNameRulesProvider is the public interface for the deployment social name rules.
+// Profiles depend on it to check names at signup and rename, without importing the
+// settings module.
+type SocialNameRulesProvider interface {
+	// SocialNameRules returns the current rules; it falls back to DefaultSocialNameRules
+	// when none were saved or they cannot be read, so callers never fail on it.
+	SocialNameRules(ctx context.Context) SocialNameRules
+}
@@ -0,0 +1,18 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/gofrs/uuid"
+)
+
+// SupporterChecker is the public interface for supporter access.
+// Posts depends on it to gate supporter-only posts without importing the supporters module.
+type SupporterChecker interface {
+	// SupportedCreators reports which of creatorIDs supporterID currently supports.
+	// Creators missing from the map are not supported.
+	SupportedCreators(ctx context.Context, supporterID uuid.UUID, creatorIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+
+	// OffersTiers reports whether creatorID has at least one supporter tier open to new supporters.
+	OffersTiers(ctx context.Context, creatorID uuid.UUID) (bool, error)
+}
@@ -69,6 +69,27 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 		})
 	}
 
+	if errMsg == "upload not found in storage" {
+		return c.Status(http.StatusConflict).JSON(fiber.Map{
+			"error":   "UPLOAD_NOT_FOUND",
+			"message": errMsg,
+		})
+	}
+
+	if strings.Contains(errMsg, "uploaded file size does not match") {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error":   "UPLOAD_SIZE_MISMATCH",
+			"message": errMsg,
+		})
+	}
+
+	if strings.Contains(errMsg, "invalid purpose") {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
+			"error":   "INVALID_PURPOSE",
+			"message": errMsg,
+		})
+	}
+
 	if strings.Contains(errMsg, "invalid MIME type") {
 		return c.Status(http.StatusBadRequest).JSON(fiber.Map{
 			"error":   "INVALID_MIME_TYPE",
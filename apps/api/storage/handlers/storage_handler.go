@@ -112,13 +112,11 @@ func (h *StorageHandler) GetFileURL(c *fiber.Ctx) error {
 	}
 
 	// Get file URL
-	url, err := h.storageService.GetFileURL(c.Context(), fileID, user.UserID)
+	result, err := h.storageService.GetFileURL(c.Context(), fileID, user.UserID)
 	if err != nil {
 		return storageErrors.HandleServiceError(c, err)
 	}
 
-	return c.JSON(fiber.Map{
-		"url": url,
-	})
+	return c.JSON(result)
 }
 
@@ -0,0 +1,6 @@
+-- Image metadata recorded after an upload is confirmed
+-- Dimensions and the thumbnail key are filled in by the background thumbnail worker
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS width INT NOT NULL DEFAULT 0;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS height INT NOT NULL DEFAULT 0;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS thumbnail_path VARCHAR(512) NOT NULL DEFAULT '';
@@ -0,0 +1,15 @@
+-- Image pipeline state: uploads are resized into variants by purpose and stripped of
+-- EXIF metadata by a background worker that claims rows from this table
+
+ALTER TABLE files ADD COLUMN IF NOT EXISTS purpose VARCHAR(20) NOT NULL DEFAULT 'post';
+ALTER TABLE files ADD COLUMN IF NOT EXISTS variants JSONB NOT NULL DEFAULT '{}';
+
+-- Files uploaded before the pipeline keep their thumbnail and are not reprocessed
+ALTER TABLE files ADD COLUMN IF NOT EXISTS processing_status VARCHAR(20) NOT NULL DEFAULT 'done';
+ALTER TABLE files ALTER COLUMN processing_status SET DEFAULT 'pending';
+ALTER TABLE files ADD COLUMN IF NOT EXISTS processing_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE files ADD COLUMN IF NOT EXISTS processing_started_at TIMESTAMPTZ;
+
+-- Workers poll for confirmed uploads still waiting for (or stuck in) processing
+CREATE INDEX IF NOT EXISTS idx_files_processing ON files(created_at)
+    WHERE status = 'uploaded' AND processing_status IN ('pending', 'processing');
@@ -6,6 +6,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"time"
 
 	uuid "github.com/gofrs/uuid"
@@ -21,9 +24,63 @@ type File struct {
 	SizeBytes     int64     `db:"size_bytes" json:"sizeBytes"`
 	Provider      string    `db:"provider" json:"provider"`
 	Bucket        string    `db:"bucket" json:"bucket"`
-	Status        string    `db:"status" json:"status"` // pending, uploaded, deleted
-	CreatedAt     time.Time `db:"created_at" json:"createdAt"`
-	UpdatedAt     time.Time `db:"updated_at" json:"updatedAt"`
+	Status        string    `db:"status" json:"status"`                          // pending, uploaded, deleted
+	Purpose       string    `db:"purpose" json:"purpose"`                        // post or avatar; decides the image variants
+	Width         int       `db:"width" json:"width,omitempty"`                  // Pixels; set once an image is processed
+	Height        int       `db:"height" json:"height,omitempty"`                // Pixels; set once an image is processed
+	ThumbnailPath string    `db:"thumbnail_path" json:"thumbnailPath,omitempty"` // Storage key of the generated thumbnail
+	Variants      Variants  `db:"variants" json:"variants,omitempty"`            // Resized copies by variant name
+	// ProcessingStatus tracks the image pipeline: pending, processing, done, skipped or failed
+	ProcessingStatus   string    `db:"processing_status" json:"processingStatus"`
+	ProcessingAttempts int       `db:"processing_attempts" json:"-"`
+	CreatedAt          time.Time `db:"created_at" json:"createdAt"`
+	UpdatedAt          time.Time `db:"updated_at" json:"updatedAt"`
+}
+
+// File purposes; each has its own image variants
+const (
+	PurposePost   = "post"
+	PurposeAvatar = "avatar"
+)
+
+// Image pipeline states of a file
+const (
+	ProcessingPending    = "pending"
+	ProcessingInProgress = "processing"
+	ProcessingDone       = "done"
+	ProcessingSkipped    = "skipped" // not an image the pipeline can decode
+	ProcessingFailed     = "failed"
+)
+
+// Variant is a resized copy of an uploaded image
+type Variant struct {
+	Path   string `json:"path"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// Variants is stored as a JSONB object keyed by variant name (e.g. post_1080)
+type Variants map[string]Variant
+
+// Value implements driver.Valuer
+func (v Variants) Value() (driver.Value, error) {
+	if v == nil {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(v)
+}
+
+// Scan implements sql.Scanner
+func (v *Variants) Scan(value interface{}) error {
+	if value == nil {
+		*v = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+	return json.Unmarshal(bytes, v)
 }
 
 // UploadRequest represents the request payload for initializing an upload
@@ -31,6 +88,7 @@ type UploadRequest struct {
 	Name        string `json:"name" validate:"required,min=1,max=255"`
 	ContentType string `json:"contentType" validate:"required"`
 	Size        int64  `json:"size" validate:"required,min=1"`
+	Purpose     string `json:"purpose,omitempty"` // post (default) or avatar
 }
 
 // UploadResponse represents the response after initializing an upload
@@ -45,5 +103,12 @@ type ConfirmUploadRequest struct {
 	FileID uuid.UUID `json:"fileId" validate:"required"`
 }
 
-
-
+// FileURLResponse represents the URLs for viewing an uploaded file
+type FileURLResponse struct {
+	URL          string `json:"url"`
+	ThumbnailURL string `json:"thumbnailUrl,omitempty"` // Empty until the thumbnail is generated
+	Width        int    `json:"width,omitempty"`
+	Height       int    `json:"height,omitempty"`
+	// Variants holds the URL of each resized copy once the image pipeline has run
+	Variants map[string]string `json:"variants,omitempty"`
+}
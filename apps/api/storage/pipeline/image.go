@@ -0,0 +1,337 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package pipeline
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // register decoders for image.Decode
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// maxSourcePixels rejects images whose decoded size would exhaust memory even
+	// though the compressed file is within the upload size limit
+	maxSourcePixels = 40_000_000
+
+	variantQuality = 80
+
+	// uprightQuality is used when a rotated JPEG original has to be re-encoded
+	uprightQuality = 92
+)
+
+// errNotDecodable marks uploads the pipeline cannot read as an image
+var errNotDecodable = errors.New("not a decodable image")
+
+// decodableTypes are the uploads the standard library can decode; others (e.g.
+// image/webp) are stored as uploaded
+var decodableTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// sourceImage is a decoded upload, turned upright according to its EXIF orientation
+type sourceImage struct {
+	img         *image.RGBA
+	format      string // "jpeg", "png" or "gif"
+	orientation int    // EXIF orientation of the original; 1 when upright
+}
+
+// decodeImage decodes data, flattening transparency onto white since variants are
+// JPEGs, and applies the EXIF orientation of JPEGs
+func decodeImage(data []byte) (*sourceImage, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errNotDecodable, err)
+	}
+	if config.Width*config.Height > maxSourcePixels {
+		return nil, fmt.Errorf("%w: %dx%d is too large", errNotDecodable, config.Width, config.Height)
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errNotDecodable, err)
+	}
+
+	src := &sourceImage{img: flatten(decoded), format: format, orientation: 1}
+	if format == "jpeg" {
+		src.orientation = jpegOrientation(data)
+		src.img = orient(src.img, src.orientation)
+	}
+	return src, nil
+}
+
+// stripMetadata returns the original without EXIF and other embedded metadata, such
+// as camera details and GPS location. JPEG segments and PNG chunks are dropped
+// losslessly; a rotated JPEG is re-encoded upright instead, since dropping its EXIF
+// would drop the orientation too. changed is false when there was nothing to remove.
+func stripMetadata(data []byte, src *sourceImage) (stripped []byte, changed bool, err error) {
+	switch src.format {
+	case "jpeg":
+		if src.orientation != 1 {
+			stripped, err = encodeJPEG(src.img, uprightQuality)
+			return stripped, err == nil, err
+		}
+		stripped, changed = stripJPEGMetadata(data)
+		return stripped, changed, nil
+	case "png":
+		stripped, changed = stripPNGMetadata(data)
+		return stripped, changed, nil
+	default:
+		return data, false, nil
+	}
+}
+
+func encodeJPEG(img image.Image, quality int) ([]byte, error) {
+	var out bytes.Buffer
+	if err := jpeg.Encode(&out, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, fmt.Errorf("encode jpeg: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// flatten draws src onto a white RGBA canvas
+func flatten(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(dst, dst.Bounds(), src, bounds.Min, draw.Over)
+	return dst
+}
+
+// orient turns src upright according to an EXIF orientation (1-8)
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dstW, dstH := w, h
+	if orientation >= 5 {
+		dstW, dstH = h, w
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		for x := 0; x < dstW; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // mirrored
+				sx, sy = w-1-x, y
+			case 3: // rotated 180
+				sx, sy = w-1-x, h-1-y
+			case 4: // mirrored vertically
+				sx, sy = x, h-1-y
+			case 5: // transposed
+				sx, sy = y, x
+			case 6: // needs a clockwise turn
+				sx, sy = y, h-1-x
+			case 7: // transversed
+				sx, sy = w-1-y, h-1-x
+			case 8: // needs a counter-clockwise turn
+				sx, sy = w-1-y, x
+			}
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:sy*src.Stride+sx*4+4])
+		}
+	}
+	return dst
+}
+
+// resizeToFit scales src down so its longest side is at most size. Images already
+// small enough keep their size; nothing is scaled up.
+func resizeToFit(src *image.RGBA, size int) *image.RGBA {
+	srcW, srcH := src.Rect.Dx(), src.Rect.Dy()
+	if srcW <= size && srcH <= size {
+		return src
+	}
+	if srcW >= srcH {
+		return scale(src, size, max(1, srcH*size/srcW))
+	}
+	return scale(src, max(1, srcW*size/srcH), size)
+}
+
+// cropSquare cuts the centred square out of src and scales it to size, or to the
+// square's side when that is smaller
+func cropSquare(src *image.RGBA, size int) *image.RGBA {
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	side := min(w, h)
+	square := src.SubImage(image.Rect((w-side)/2, (h-side)/2, (w-side)/2+side, (h-side)/2+side)).(*image.RGBA)
+	return scale(square, min(size, side), min(size, side))
+}
+
+// scale resizes src to dstW x dstH by averaging the source pixels each output pixel covers
+func scale(src *image.RGBA, dstW, dstH int) *image.RGBA {
+	srcW, srcH := src.Rect.Dx(), src.Rect.Dy()
+	origin := src.PixOffset(src.Rect.Min.X, src.Rect.Min.Y)
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[origin+sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					r += uint64(row[sx*4])
+					g += uint64(row[sx*4+1])
+					b += uint64(row[sx*4+2])
+					n++
+				}
+			}
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = 0xff
+		}
+	}
+	return dst
+}
+
+// jpegMetadataMarkers are the JPEG segments dropped from originals
+var jpegMetadataMarkers = map[byte]bool{
+	0xE1: true, // APP1: EXIF and XMP
+	0xED: true, // APP13: IPTC
+	0xFE: true, // COM: comments
+}
+
+// walkJPEG calls visit with each marker segment ahead of the image data, including
+// its marker and length bytes. It returns the offset of the start-of-scan segment,
+// after which the compressed image data follows, or -1 when data is malformed.
+func walkJPEG(data []byte, visit func(marker byte, segment, payload []byte)) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return -1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return -1
+		}
+		marker := data[i+1]
+		switch {
+		case marker == 0xFF: // fill byte
+			i++
+			continue
+		case marker == 0xDA:
+			return i
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7): // no length
+			visit(marker, data[i:i+2], nil)
+			i += 2
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			return -1
+		}
+		visit(marker, data[i:i+2+length], data[i+4:i+2+length])
+		i += 2 + length
+	}
+	return -1
+}
+
+// stripJPEGMetadata drops the metadata segments from a JPEG without re-encoding it
+func stripJPEGMetadata(data []byte) ([]byte, bool) {
+	out := make([]byte, 2, len(data))
+	out[0], out[1] = 0xFF, 0xD8
+	removed := false
+	scan := walkJPEG(data, func(marker byte, segment, payload []byte) {
+		if jpegMetadataMarkers[marker] {
+			removed = true
+			return
+		}
+		out = append(out, segment...)
+	})
+	if scan < 0 || !removed {
+		return data, false
+	}
+	return append(out, data[scan:]...), true
+}
+
+// jpegOrientation reads the orientation tag from a JPEG's EXIF; 1 (upright) when absent
+func jpegOrientation(data []byte) int {
+	var exif []byte
+	walkJPEG(data, func(marker byte, segment, payload []byte) {
+		if marker == 0xE1 && exif == nil && bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			exif = payload[6:]
+		}
+	})
+	if len(exif) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(exif[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(exif[4:8]))
+	if ifd < 8 || ifd+2 > len(exif) {
+		return 1
+	}
+	entries := int(order.Uint16(exif[ifd:]))
+	for i := 0; i < entries; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(exif) {
+			break
+		}
+		if order.Uint16(exif[entry:]) == 0x0112 {
+			if orientation := int(order.Uint16(exif[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			break
+		}
+	}
+	return 1
+}
+
+var pngSignature = []byte("\x89PNG\r\n\x1a\n")
+
+// pngMetadataChunks are the PNG chunks dropped from originals
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// stripPNGMetadata drops the metadata chunks from a PNG without re-encoding it
+func stripPNGMetadata(data []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return data, false
+	}
+	out := make([]byte, 0, len(data))
+	out = append(out, pngSignature...)
+	removed := false
+	for i := len(pngSignature); i+12 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[i:]))
+		end := i + 12 + length
+		if length < 0 || end > len(data) {
+			return data, false
+		}
+		if pngMetadataChunks[string(data[i+4:i+8])] {
+			removed = true
+		} else {
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+	if !removed {
+		return data, false
+	}
+	return out, true
+}
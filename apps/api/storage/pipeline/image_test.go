@@ -0,0 +1,196 @@
+package pipeline
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func solidImage(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// jpegWithOrientation encodes img and inserts an EXIF segment with the given orientation
+func jpegWithOrientation(t *testing.T, img image.Image, orientation uint16) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, img, nil))
+	data := buf.Bytes()
+
+	// Little-endian TIFF header, then an IFD with the single orientation entry
+	tiff := []byte("II*\x00\x08\x00\x00\x00\x01\x00")
+	entry := make([]byte, 12)
+	binary.LittleEndian.PutUint16(entry[0:], 0x0112)
+	binary.LittleEndian.PutUint16(entry[2:], 3) // SHORT
+	binary.LittleEndian.PutUint32(entry[4:], 1)
+	binary.LittleEndian.PutUint16(entry[8:], orientation)
+	tiff = append(append(tiff, entry...), 0, 0, 0, 0)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+	return append(out, data[2:]...)
+}
+
+func TestVariantKey(t *testing.T) {
+	assert.Equal(t, "users/u/f_post_720.jpg", variantKey("users/u/f.png", PostThumbnailVariant))
+	assert.Equal(t, "users/u/f_thumb.jpg", variantKey("users/u/f", ThumbnailVariant))
+	assert.Equal(t, "users/u.v/f_thumb.jpg", variantKey("users/u.v/f", ThumbnailVariant))
+}
+
+func TestVariantSpecs(t *testing.T) {
+	names := func(specs []variantSpec) []string {
+		var out []string
+		for _, spec := range specs {
+			out = append(out, spec.name)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"avatar_128", "avatar_256", ThumbnailVariant}, names(variantSpecs("avatar", 320)))
+	assert.Equal(t, []string{PostThumbnailVariant, PostFullVariant, ThumbnailVariant}, names(variantSpecs("post", 320)))
+	assert.Equal(t, []string{ThumbnailVariant}, names(variantSpecs("", 320)))
+}
+
+func TestResizeToFit(t *testing.T) {
+	assert.Equal(t, image.Rect(0, 0, 320, 80), resizeToFit(image.NewRGBA(image.Rect(0, 0, 800, 200)), 320).Rect)
+	assert.Equal(t, image.Rect(0, 0, 3, 320), resizeToFit(image.NewRGBA(image.Rect(0, 0, 10, 1000)), 320).Rect)
+
+	// Small images are not scaled up
+	small := image.NewRGBA(image.Rect(0, 0, 100, 50))
+	assert.Same(t, small, resizeToFit(small, 320))
+}
+
+func TestCropSquare(t *testing.T) {
+	// Red left and right thirds around a blue centre: the square keeps only blue
+	src := solidImage(300, 100, color.RGBA{R: 255, A: 255})
+	for y := 0; y < 100; y++ {
+		for x := 100; x < 200; x++ {
+			src.SetRGBA(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+
+	square := cropSquare(src, 50)
+	assert.Equal(t, image.Rect(0, 0, 50, 50), square.Rect)
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, square.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{B: 255, A: 255}, square.RGBAAt(49, 49))
+
+	// Smaller sources are cropped but not scaled up
+	assert.Equal(t, image.Rect(0, 0, 100, 100), cropSquare(src, 256).Rect)
+}
+
+func TestOrient(t *testing.T) {
+	// 2x1 image: red on the left, blue on the right
+	src := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	red, blue := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	src.SetRGBA(0, 0, red)
+	src.SetRGBA(1, 0, blue)
+
+	mirrored := orient(src, 2)
+	assert.Equal(t, blue, mirrored.RGBAAt(0, 0))
+	assert.Equal(t, red, mirrored.RGBAAt(1, 0))
+
+	// Orientation 6 is stored turned counter-clockwise; upright, the left pixel is on top
+	clockwise := orient(src, 6)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), clockwise.Rect)
+	assert.Equal(t, red, clockwise.RGBAAt(0, 0))
+	assert.Equal(t, blue, clockwise.RGBAAt(0, 1))
+
+	counter := orient(src, 8)
+	assert.Equal(t, blue, counter.RGBAAt(0, 0))
+	assert.Equal(t, red, counter.RGBAAt(0, 1))
+
+	assert.Same(t, src, orient(src, 1))
+}
+
+func TestJPEGMetadata(t *testing.T) {
+	img := solidImage(40, 20, color.RGBA{R: 200, G: 40, B: 40, A: 255})
+
+	t.Run("upright JPEGs lose their EXIF losslessly", func(t *testing.T) {
+		data := jpegWithOrientation(t, img, 1)
+		assert.Equal(t, 1, jpegOrientation(data))
+
+		src, err := decodeImage(data)
+		require.NoError(t, err)
+		stripped, changed, err := stripMetadata(data, src)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.False(t, bytes.Contains(stripped, []byte("Exif\x00\x00")))
+
+		var original bytes.Buffer
+		require.NoError(t, jpeg.Encode(&original, img, nil))
+		assert.Equal(t, original.Bytes(), stripped)
+	})
+
+	t.Run("rotated JPEGs are re-encoded upright", func(t *testing.T) {
+		data := jpegWithOrientation(t, img, 6)
+		assert.Equal(t, 6, jpegOrientation(data))
+
+		src, err := decodeImage(data)
+		require.NoError(t, err)
+		assert.Equal(t, image.Rect(0, 0, 20, 40), src.img.Rect)
+
+		stripped, changed, err := stripMetadata(data, src)
+		require.NoError(t, err)
+		assert.True(t, changed)
+		assert.Equal(t, 1, jpegOrientation(stripped))
+		config, err := jpeg.DecodeConfig(bytes.NewReader(stripped))
+		require.NoError(t, err)
+		assert.Equal(t, [2]int{20, 40}, [2]int{config.Width, config.Height})
+	})
+
+	t.Run("JPEGs without metadata are unchanged", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, jpeg.Encode(&buf, img, nil))
+		_, changed := stripJPEGMetadata(buf.Bytes())
+		assert.False(t, changed)
+	})
+}
+
+func TestStripPNGMetadata(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, solidImage(4, 4, color.RGBA{G: 255, A: 255})))
+	plain := buf.Bytes()
+
+	// Insert a tEXt chunk after the IHDR chunk (8 byte signature + 25 byte chunk)
+	text := []byte("Comment\x00taken at home")
+	chunk := make([]byte, 8, 12+len(text))
+	binary.BigEndian.PutUint32(chunk, uint32(len(text)))
+	copy(chunk[4:], "tEXt")
+	chunk = append(append(chunk, text...), 0, 0, 0, 0)
+	withText := append(append(append([]byte{}, plain[:33]...), chunk...), plain[33:]...)
+
+	stripped, changed := stripPNGMetadata(withText)
+	assert.True(t, changed)
+	assert.Equal(t, plain, stripped)
+
+	_, changed = stripPNGMetadata(plain)
+	assert.False(t, changed)
+}
+
+func TestDecodeImageFlattensTransparency(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 2, 2))))
+
+	src, err := decodeImage(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 255, G: 255, B: 255, A: 255}, src.img.RGBAAt(0, 0))
+
+	_, err = decodeImage([]byte("not an image"))
+	assert.ErrorIs(t, err, errNotDecodable)
+}
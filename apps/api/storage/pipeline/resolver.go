@@ -0,0 +1,79 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package pipeline
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/qolzam/telar/apps/api/storage/models"
+	"github.com/qolzam/telar/apps/api/storage/provider"
+	storageRepository "github.com/qolzam/telar/apps/api/storage/repository"
+)
+
+// variantURLExpiry only applies without a public URL, where variant URLs are presigned
+const variantURLExpiry = 24 * time.Hour
+
+// uploadKeyPattern finds the key of an upload, users/{userID}/{fileID}, in a file URL
+var uploadKeyPattern = regexp.MustCompile(`users/([0-9a-fA-F-]{36})/([0-9a-fA-F-]{36})`)
+
+// VariantResolver looks up the variants of processed post images for the posts service.
+// Use it only when the storage has a public URL, since posts keep the URLs it returns.
+type VariantResolver struct {
+	repo     storageRepository.Repository
+	provider provider.BlobProvider
+}
+
+var _ sharedInterfaces.ImageVariantResolver = (*VariantResolver)(nil)
+
+// NewVariantResolver creates a resolver
+func NewVariantResolver(repo storageRepository.Repository, blobProvider provider.BlobProvider) *VariantResolver {
+	return &VariantResolver{repo: repo, provider: blobProvider}
+}
+
+// PostImageVariants returns the variants of the upload imageURL points at when it is a
+// post image ownerID uploaded and the pipeline has processed
+func (r *VariantResolver) PostImageVariants(ctx context.Context, ownerID uuid.UUID, imageURL string) (sharedInterfaces.PostImageVariants, bool) {
+	match := uploadKeyPattern.FindStringSubmatch(imageURL)
+	if match == nil {
+		return sharedInterfaces.PostImageVariants{}, false
+	}
+	fileID, err := uuid.FromString(match[2])
+	if err != nil {
+		return sharedInterfaces.PostImageVariants{}, false
+	}
+
+	file, err := r.repo.FindByID(ctx, fileID)
+	if err != nil || file.OwnerUserID != ownerID || file.Status != "uploaded" ||
+		file.Purpose != models.PurposePost || file.ProcessingStatus != models.ProcessingDone {
+		return sharedInterfaces.PostImageVariants{}, false
+	}
+	return postVariantURLs(ctx, r.provider, file)
+}
+
+// postVariantURLs returns the URLs of a processed post image's full size and thumbnail variants
+func postVariantURLs(ctx context.Context, blobProvider provider.BlobProvider, file *models.File) (sharedInterfaces.PostImageVariants, bool) {
+	full, hasFull := file.Variants[PostFullVariant]
+	thumbnail, hasThumbnail := file.Variants[PostThumbnailVariant]
+	if !hasFull || !hasThumbnail {
+		return sharedInterfaces.PostImageVariants{}, false
+	}
+
+	var variants sharedInterfaces.PostImageVariants
+	var err error
+	if variants.FullPath, err = blobProvider.GeneratePresignedDownloadURL(ctx, full.Path, variantURLExpiry); err == nil {
+		variants.Thumbnail, err = blobProvider.GeneratePresignedDownloadURL(ctx, thumbnail.Path, variantURLExpiry)
+	}
+	if err != nil {
+		log.Warn("Failed to build variant URLs for file %s: %v", file.ID.String(), err)
+		return sharedInterfaces.PostImageVariants{}, false
+	}
+	return variants, true
+}
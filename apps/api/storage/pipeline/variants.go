@@ -0,0 +1,64 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package pipeline
+
+import (
+	"image"
+	"strings"
+
+	"github.com/qolzam/telar/apps/api/storage/models"
+)
+
+const (
+	// ThumbnailVariant is made for every processed image; its key is also stored as the
+	// file's thumbnail path
+	ThumbnailVariant = "thumb"
+
+	// PostFullVariant and PostThumbnailVariant become a post's ImageFullPath and Thumbnail
+	PostFullVariant      = "post_1080"
+	PostThumbnailVariant = "post_720"
+)
+
+// variantSpec describes one resized copy
+type variantSpec struct {
+	name   string
+	size   int  // Longest side, or the side of a square
+	square bool // Centre-cropped to a square, as avatars are shown in circles
+}
+
+// purposeVariants are the copies made for each upload purpose, besides the thumbnail
+var purposeVariants = map[string][]variantSpec{
+	models.PurposeAvatar: {
+		{name: "avatar_128", size: 128, square: true},
+		{name: "avatar_256", size: 256, square: true},
+	},
+	models.PurposePost: {
+		{name: PostThumbnailVariant, size: 720},
+		{name: PostFullVariant, size: 1080},
+	},
+}
+
+// variantSpecs returns the copies to make of a file with the given purpose
+func variantSpecs(purpose string, thumbnailSize int) []variantSpec {
+	specs := append([]variantSpec{}, purposeVariants[purpose]...)
+	return append(specs, variantSpec{name: ThumbnailVariant, size: thumbnailSize})
+}
+
+func (v variantSpec) render(src *image.RGBA) *image.RGBA {
+	if v.square {
+		return cropSquare(src, v.size)
+	}
+	return resizeToFit(src, v.size)
+}
+
+// variantKey places a variant next to the original:
+// users/{userID}/{fileID}.png -> users/{userID}/{fileID}_post_720.jpg
+func variantKey(key, name string) string {
+	if dot := strings.LastIndex(key, "."); dot > strings.LastIndex(key, "/") {
+		key = key[:dot]
+	}
+	return key + "_" + name + ".jpg"
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+// Package pipeline processes confirmed image uploads in the background: it strips
+// EXIF metadata from the original, writes resized variants for the upload's purpose
+// (avatar or post) and points posts showing the original at the variants.
+//
+// The files table is the queue. Workers claim confirmed uploads with SKIP LOCKED, so
+// the pipeline can run inside the API server, as its own binary (cmd/services/media)
+// or both, and uploads confirmed while no worker runs are processed later.
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/qolzam/telar/apps/api/storage/models"
+	"github.com/qolzam/telar/apps/api/storage/provider"
+	storageRepository "github.com/qolzam/telar/apps/api/storage/repository"
+)
+
+const (
+	// fileTimeout bounds downloading, processing and uploading the variants of one file
+	fileTimeout = 2 * time.Minute
+
+	// staleAfter is how long a claim may last before another worker retries the file
+	staleAfter = 5 * time.Minute
+
+	// maxAttempts is how often a file is tried before it is marked failed
+	maxAttempts = 3
+)
+
+// Config tunes the worker
+type Config struct {
+	Workers       int           // Files processed concurrently
+	BatchSize     int           // Files claimed per poll
+	PollInterval  time.Duration // How often to look for files when not woken
+	ThumbnailSize int           // Longest side of the thumbnail variant
+	MaxBytes      int64         // Originals larger than this are not downloaded
+	// PublicURLs reports whether variant URLs are permanent CDN URLs. Posts are only
+	// pointed at variants then, since presigned URLs expire.
+	PublicURLs bool
+}
+
+// ConfigFromStorage derives the worker configuration from the storage configuration
+func ConfigFromStorage(cfg *platformconfig.StorageConfig) Config {
+	return Config{
+		Workers:       cfg.ImageWorkers,
+		BatchSize:     cfg.ImageBatchSize,
+		PollInterval:  cfg.ImagePollInterval,
+		ThumbnailSize: cfg.ThumbnailSize,
+		MaxBytes:      int64(cfg.MaxFileSizeMB) * 1024 * 1024,
+		PublicURLs:    cfg.PublicURL != "",
+	}
+}
+
+// Worker runs confirmed uploads through the image pipeline
+type Worker struct {
+	repo     storageRepository.Repository
+	provider provider.BlobProvider
+	posts    sharedInterfaces.PostImageUpdater // nil until SetPostImageUpdater; posts keep the originals
+	cfg      Config
+	wake     chan struct{}
+}
+
+// NewWorker creates a worker; call Run or Start to begin processing
+func NewWorker(repo storageRepository.Repository, blobProvider provider.BlobProvider, cfg Config) *Worker {
+	if cfg.Workers <= 0 {
+		cfg.Workers = 1
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 20
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 15 * time.Second
+	}
+	if cfg.ThumbnailSize <= 0 {
+		cfg.ThumbnailSize = 320
+	}
+	return &Worker{
+		repo:     repo,
+		provider: blobProvider,
+		cfg:      cfg,
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// SetPostImageUpdater points posts showing a processed upload at its variants
+func (w *Worker) SetPostImageUpdater(posts sharedInterfaces.PostImageUpdater) {
+	w.posts = posts
+}
+
+// Wake makes a running worker poll now rather than at the next interval. It never blocks.
+func (w *Worker) Wake() {
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Start runs the worker in the background until ctx is done
+func (w *Worker) Start(ctx context.Context) {
+	go w.Run(ctx)
+}
+
+// Run processes uploads until ctx is done, polling every PollInterval and whenever woken
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		// Drain the backlog before waiting again
+		for ctx.Err() == nil {
+			claimed, err := w.ProcessPending(ctx)
+			if err != nil {
+				log.Error("Image pipeline poll failed: %v", err)
+				break
+			}
+			if claimed < w.cfg.BatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.wake:
+		}
+	}
+}
+
+// ProcessPending claims one batch of uploads, processes them and returns how many it claimed
+func (w *Worker) ProcessPending(ctx context.Context) (int, error) {
+	files, err := w.repo.ClaimForProcessing(ctx, w.cfg.BatchSize, staleAfter, maxAttempts)
+	if err != nil {
+		return 0, err
+	}
+
+	jobs := make(chan *models.File)
+	var wg sync.WaitGroup
+	for i := 0; i < min(w.cfg.Workers, len(files)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				w.processFile(ctx, file)
+			}
+		}()
+	}
+	for _, file := range files {
+		jobs <- file
+	}
+	close(jobs)
+	wg.Wait()
+	return len(files), nil
+}
+
+// processFile runs one claimed file through the pipeline and records the outcome.
+// Failed files go back to pending until they have used up their attempts.
+func (w *Worker) processFile(ctx context.Context, file *models.File) {
+	fileCtx, cancel := context.WithTimeout(ctx, fileTimeout)
+	defer cancel()
+
+	err := w.process(fileCtx, file)
+	switch {
+	case err == nil:
+		file.ProcessingStatus = models.ProcessingDone
+	case errors.Is(err, errNotDecodable):
+		file.ProcessingStatus = models.ProcessingSkipped
+	case file.ProcessingAttempts < maxAttempts:
+		log.Warn("Image processing failed for file %s, will retry: %v", file.ID.String(), err)
+		file.ProcessingStatus = models.ProcessingPending
+	default:
+		log.Error("Image processing failed for file %s: %v", file.ID.String(), err)
+		file.ProcessingStatus = models.ProcessingFailed
+	}
+
+	if err := w.repo.CompleteProcessing(ctx, file); err != nil {
+		log.Error("Failed to record image processing for file %s: %v", file.ID.String(), err)
+		return
+	}
+	if file.ProcessingStatus == models.ProcessingDone {
+		w.updatePosts(ctx, file)
+	}
+}
+
+// process strips the original's metadata and writes its variants, recording the
+// new size, upright dimensions and variant keys on file
+func (w *Worker) process(ctx context.Context, file *models.File) error {
+	if !decodableTypes[strings.ToLower(file.MimeType)] {
+		return errNotDecodable
+	}
+
+	data, err := w.download(ctx, file.Path)
+	if err != nil {
+		return err
+	}
+	src, err := decodeImage(data)
+	if err != nil {
+		return err
+	}
+
+	stripped, changed, err := stripMetadata(data, src)
+	if err != nil {
+		return err
+	}
+	if changed {
+		if err := w.provider.PutObject(ctx, file.Path, file.MimeType, stripped); err != nil {
+			return err
+		}
+		file.SizeBytes = int64(len(stripped))
+	}
+
+	variants := make(models.Variants)
+	for _, spec := range variantSpecs(file.Purpose, w.cfg.ThumbnailSize) {
+		img := spec.render(src.img)
+		encoded, err := encodeJPEG(img, variantQuality)
+		if err != nil {
+			return err
+		}
+		key := variantKey(file.Path, spec.name)
+		if err := w.provider.PutObject(ctx, key, "image/jpeg", encoded); err != nil {
+			return err
+		}
+		variants[spec.name] = models.Variant{Path: key, Width: img.Rect.Dx(), Height: img.Rect.Dy()}
+	}
+
+	file.Width, file.Height = src.img.Rect.Dx(), src.img.Rect.Dy()
+	file.Variants = variants
+	file.ThumbnailPath = variants[ThumbnailVariant].Path
+	return nil
+}
+
+// download reads the original, refusing anything larger than the upload limit
+func (w *Worker) download(ctx context.Context, key string) ([]byte, error) {
+	body, err := w.provider.GetObject(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	reader := io.Reader(body)
+	if w.cfg.MaxBytes > 0 {
+		reader = io.LimitReader(body, w.cfg.MaxBytes+1)
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("read original: %w", err)
+	}
+	if w.cfg.MaxBytes > 0 && int64(len(data)) > w.cfg.MaxBytes {
+		return nil, fmt.Errorf("%w: original exceeds %d bytes", errNotDecodable, w.cfg.MaxBytes)
+	}
+	return data, nil
+}
+
+// updatePosts points the owner's posts showing a processed post image at its variants
+func (w *Worker) updatePosts(ctx context.Context, file *models.File) {
+	if w.posts == nil || !w.cfg.PublicURLs || file.Purpose != models.PurposePost {
+		return
+	}
+	variants, ok := postVariantURLs(ctx, w.provider, file)
+	if !ok {
+		return
+	}
+	updated, err := w.posts.ApplyImageVariants(ctx, file.OwnerUserID, file.Path, variants)
+	if err != nil {
+		log.Error("Failed to update posts with the variants of file %s: %v", file.ID.String(), err)
+		return
+	}
+	if updated > 0 {
+		log.Info("Pointed %d posts at the variants of file %s", updated, file.ID.String())
+	}
+}
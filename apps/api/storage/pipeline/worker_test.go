@@ -0,0 +1,280 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	"github.com/qolzam/telar/apps/api/storage/models"
+	storageRepository "github.com/qolzam/telar/apps/api/storage/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryProvider keeps objects in a map
+type memoryProvider struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
+
+func (p *memoryProvider) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, contentLength int64, expiresIn time.Duration) (string, error) {
+	return "https://upload.test/" + key, nil
+}
+
+func (p *memoryProvider) GeneratePresignedDownloadURL(ctx context.Context, key string, expiresIn time.Duration) (string, error) {
+	return "https://cdn.test/" + key, nil
+}
+
+func (p *memoryProvider) Delete(ctx context.Context, key string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.objects, key)
+	return nil
+}
+
+func (p *memoryProvider) GetMetadata(ctx context.Context, key string) (int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, ok := p.objects[key]
+	if !ok {
+		return 0, fmt.Errorf("not found: %s", key)
+	}
+	return int64(len(data)), nil
+}
+
+func (p *memoryProvider) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	data, ok := p.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("not found: %s", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (p *memoryProvider) PutObject(ctx context.Context, key string, contentType string, data []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.objects[key] = data
+	return nil
+}
+
+// fileRepo hands out its pending files once; methods the tests do not need panic via
+// the nil interface
+type fileRepo struct {
+	storageRepository.Repository
+	mu    sync.Mutex
+	files map[uuid.UUID]*models.File
+}
+
+func (r *fileRepo) FindByID(ctx context.Context, id uuid.UUID) (*models.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	file, ok := r.files[id]
+	if !ok {
+		return nil, fmt.Errorf("file not found")
+	}
+	copied := *file
+	return &copied, nil
+}
+
+func (r *fileRepo) ClaimForProcessing(ctx context.Context, limit int, staleAfter time.Duration, maxAttempts int) ([]*models.File, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var claimed []*models.File
+	for _, file := range r.files {
+		if file.ProcessingStatus == models.ProcessingPending && len(claimed) < limit {
+			file.ProcessingStatus = models.ProcessingInProgress
+			file.ProcessingAttempts++
+			copied := *file
+			claimed = append(claimed, &copied)
+		}
+	}
+	return claimed, nil
+}
+
+func (r *fileRepo) CompleteProcessing(ctx context.Context, file *models.File) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	copied := *file
+	r.files[file.ID] = &copied
+	return nil
+}
+
+// postUpdater records the images applied to posts
+type postUpdater struct {
+	mu      sync.Mutex
+	applied map[string]sharedInterfaces.PostImageVariants
+}
+
+func (u *postUpdater) ApplyImageVariants(ctx context.Context, ownerID uuid.UUID, key string, variants sharedInterfaces.PostImageVariants) (int, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.applied[key] = variants
+	return 1, nil
+}
+
+type pipelineFixture struct {
+	repo    *fileRepo
+	blobs   *memoryProvider
+	posts   *postUpdater
+	worker  *Worker
+	ownerID uuid.UUID
+}
+
+func newPipelineFixture(cfg Config) *pipelineFixture {
+	f := &pipelineFixture{
+		repo:    &fileRepo{files: map[uuid.UUID]*models.File{}},
+		blobs:   &memoryProvider{objects: map[string][]byte{}},
+		posts:   &postUpdater{applied: map[string]sharedInterfaces.PostImageVariants{}},
+		ownerID: uuid.Must(uuid.NewV4()),
+	}
+	f.worker = NewWorker(f.repo, f.blobs, cfg)
+	f.worker.SetPostImageUpdater(f.posts)
+	return f
+}
+
+// upload stores data as a confirmed upload waiting for the pipeline
+func (f *pipelineFixture) upload(purpose, mimeType, ext string, data []byte) *models.File {
+	fileID := uuid.Must(uuid.NewV4())
+	file := &models.File{
+		ID:               fileID,
+		OwnerUserID:      f.ownerID,
+		Path:             fmt.Sprintf("users/%s/%s%s", f.ownerID, fileID, ext),
+		MimeType:         mimeType,
+		SizeBytes:        int64(len(data)),
+		Status:           "uploaded",
+		Purpose:          purpose,
+		ProcessingStatus: models.ProcessingPending,
+	}
+	f.repo.files[fileID] = file
+	f.blobs.objects[file.Path] = data
+	return file
+}
+
+func (f *pipelineFixture) decodedSize(t *testing.T, key string) [2]int {
+	config, err := jpeg.DecodeConfig(bytes.NewReader(f.blobs.objects[key]))
+	require.NoError(t, err)
+	return [2]int{config.Width, config.Height}
+}
+
+func TestProcessPending(t *testing.T) {
+	ctx := context.Background()
+	f := newPipelineFixture(Config{Workers: 2, ThumbnailSize: 160, PublicURLs: true})
+
+	photo := solidImage(2000, 1000, color.RGBA{R: 200, G: 40, B: 40, A: 255})
+	post := f.upload(models.PurposePost, "image/jpeg", ".jpg", jpegWithOrientation(t, photo, 1))
+	var avatarPNG bytes.Buffer
+	require.NoError(t, png.Encode(&avatarPNG, solidImage(400, 300, color.RGBA{B: 255, A: 255})))
+	avatar := f.upload(models.PurposeAvatar, "image/png", ".png", avatarPNG.Bytes())
+	webp := f.upload(models.PurposePost, "image/webp", ".webp", []byte("RIFF"))
+
+	claimed, err := f.worker.ProcessPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, claimed)
+
+	t.Run("post images get post variants and lose their EXIF", func(t *testing.T) {
+		file := f.repo.files[post.ID]
+		assert.Equal(t, models.ProcessingDone, file.ProcessingStatus)
+		assert.Equal(t, [2]int{2000, 1000}, [2]int{file.Width, file.Height})
+		assert.False(t, bytes.Contains(f.blobs.objects[post.Path], []byte("Exif\x00\x00")))
+		assert.Equal(t, int64(len(f.blobs.objects[post.Path])), file.SizeBytes)
+
+		require.Len(t, file.Variants, 3)
+		assert.Equal(t, [2]int{1080, 540}, f.decodedSize(t, file.Variants[PostFullVariant].Path))
+		assert.Equal(t, [2]int{720, 360}, f.decodedSize(t, file.Variants[PostThumbnailVariant].Path))
+		assert.Equal(t, [2]int{160, 80}, f.decodedSize(t, file.ThumbnailPath))
+		assert.Equal(t, 1080, file.Variants[PostFullVariant].Width)
+	})
+
+	t.Run("avatars get square variants", func(t *testing.T) {
+		file := f.repo.files[avatar.ID]
+		assert.Equal(t, models.ProcessingDone, file.ProcessingStatus)
+		require.Len(t, file.Variants, 3)
+		assert.Equal(t, [2]int{128, 128}, f.decodedSize(t, file.Variants["avatar_128"].Path))
+		assert.Equal(t, [2]int{256, 256}, f.decodedSize(t, file.Variants["avatar_256"].Path))
+	})
+
+	t.Run("undecodable uploads are skipped", func(t *testing.T) {
+		file := f.repo.files[webp.ID]
+		assert.Equal(t, models.ProcessingSkipped, file.ProcessingStatus)
+		assert.Empty(t, file.Variants)
+	})
+
+	t.Run("only post images update posts", func(t *testing.T) {
+		assert.Equal(t, map[string]sharedInterfaces.PostImageVariants{
+			post.Path: {
+				FullPath:  "https://cdn.test/" + f.repo.files[post.ID].Variants[PostFullVariant].Path,
+				Thumbnail: "https://cdn.test/" + f.repo.files[post.ID].Variants[PostThumbnailVariant].Path,
+			},
+		}, f.posts.applied)
+	})
+
+	claimed, err = f.worker.ProcessPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, claimed)
+}
+
+func TestProcessPendingRetries(t *testing.T) {
+	ctx := context.Background()
+	f := newPipelineFixture(Config{})
+	file := f.upload(models.PurposePost, "image/png", ".png", nil)
+	delete(f.blobs.objects, file.Path)
+
+	for attempt := 1; attempt < maxAttempts; attempt++ {
+		_, err := f.worker.ProcessPending(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, models.ProcessingPending, f.repo.files[file.ID].ProcessingStatus)
+	}
+	_, err := f.worker.ProcessPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, models.ProcessingFailed, f.repo.files[file.ID].ProcessingStatus)
+}
+
+func TestPostsKeepOriginalsWithoutPublicURLs(t *testing.T) {
+	f := newPipelineFixture(Config{})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, solidImage(10, 10, color.RGBA{A: 255})))
+	f.upload(models.PurposePost, "image/png", ".png", buf.Bytes())
+
+	_, err := f.worker.ProcessPending(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, f.posts.applied)
+}
+
+func TestVariantResolver(t *testing.T) {
+	ctx := context.Background()
+	f := newPipelineFixture(Config{PublicURLs: true})
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, solidImage(10, 10, color.RGBA{A: 255})))
+	post := f.upload(models.PurposePost, "image/png", ".png", buf.Bytes())
+	avatar := f.upload(models.PurposeAvatar, "image/png", ".png", buf.Bytes())
+	resolver := NewVariantResolver(f.repo, f.blobs)
+
+	// Not processed yet
+	_, ok := resolver.PostImageVariants(ctx, f.ownerID, "https://cdn.test/"+post.Path)
+	assert.False(t, ok)
+
+	_, err := f.worker.ProcessPending(ctx)
+	require.NoError(t, err)
+
+	variants, ok := resolver.PostImageVariants(ctx, f.ownerID, "https://cdn.test/"+post.Path+"?sig=abc")
+	require.True(t, ok)
+	assert.Equal(t, "https://cdn.test/"+f.repo.files[post.ID].Variants[PostFullVariant].Path, variants.FullPath)
+
+	_, ok = resolver.PostImageVariants(ctx, uuid.Must(uuid.NewV4()), "https://cdn.test/"+post.Path)
+	assert.False(t, ok, "another user's upload")
+	_, ok = resolver.PostImageVariants(ctx, f.ownerID, "https://cdn.test/"+avatar.Path)
+	assert.False(t, ok, "an avatar")
+	_, ok = resolver.PostImageVariants(ctx, f.ownerID, "https://example.com/cat.png")
+	assert.False(t, ok, "not an upload")
+}
@@ -7,6 +7,7 @@ package provider
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -29,5 +30,11 @@ type BlobProvider interface {
 
 	// GetMetadata checks if file exists and returns its size (for validation)
 	GetMetadata(ctx context.Context, key string) (size int64, err error)
+
+	// GetObject opens a file for reading; the caller must close the body
+	GetObject(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// PutObject writes a file generated server-side (e.g. a thumbnail)
+	PutObject(ctx context.Context, key string, contentType string, data []byte) error
 }
 
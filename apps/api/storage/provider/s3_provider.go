@@ -0,0 +1,221 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+)
+
+// s3Provider implements BlobProvider for any S3-compatible store (Cloudflare R2,
+// AWS S3, MinIO) using the AWS S3 SDK
+type s3Provider struct {
+	s3Client  *s3.Client
+	bucket    string
+	publicURL string
+}
+
+// NewProvider creates the provider selected by cfg.Provider
+func NewProvider(cfg *platformconfig.StorageConfig) (BlobProvider, error) {
+	switch cfg.Provider {
+	case "", "r2":
+		return NewR2Provider(cfg)
+	case "s3", "minio":
+		return NewS3Provider(cfg)
+	default:
+		return nil, fmt.Errorf("unsupported storage provider %q", cfg.Provider)
+	}
+}
+
+// NewR2Provider creates a new R2 provider from configuration
+func NewR2Provider(cfg *platformconfig.StorageConfig) (BlobProvider, error) {
+	// Build custom endpoint for R2
+	// Format: https://<account-id>.r2.cloudflarestorage.com
+	endpoint := cfg.Endpoint
+	if endpoint == "" && cfg.AccountID != "" {
+		endpoint = fmt.Sprintf("https://%s.r2.cloudflarestorage.com", cfg.AccountID)
+	}
+	if endpoint == "" {
+		return nil, fmt.Errorf("R2_ENDPOINT or R2_ACCOUNT_ID is required")
+	}
+
+	// R2 requires path-style addressing
+	return newS3Provider(cfg, endpoint, cfg.Region, true)
+}
+
+// NewS3Provider creates a provider for AWS S3 or, with an endpoint, for MinIO and
+// other S3-compatible stores. MinIO always uses path-style addressing.
+func NewS3Provider(cfg *platformconfig.StorageConfig) (BlobProvider, error) {
+	if cfg.Provider == "minio" && cfg.Endpoint == "" {
+		return nil, fmt.Errorf("STORAGE_ENDPOINT is required for minio")
+	}
+
+	// "auto" is R2's region; S3 and MinIO need a real one to sign requests
+	region := cfg.Region
+	if region == "" || region == "auto" {
+		region = "us-east-1"
+	}
+	return newS3Provider(cfg, cfg.Endpoint, region, cfg.ForcePathStyle || cfg.Provider == "minio")
+}
+
+// newS3Provider builds the S3 client; an empty endpoint means AWS S3 itself
+func newS3Provider(cfg *platformconfig.StorageConfig, endpoint, region string, usePathStyle bool) (BlobProvider, error) {
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("STORAGE_ACCESS_KEY_ID and STORAGE_SECRET_ACCESS_KEY are required")
+	}
+	if cfg.BucketName == "" {
+		return nil, fmt.Errorf("STORAGE_BUCKET_NAME is required")
+	}
+
+	// Create AWS config with static credentials
+	awsCfg, err := awsconfig.LoadDefaultConfig(context.Background(),
+		awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			cfg.AccessKeyID,
+			cfg.SecretAccessKey,
+			"",
+		)),
+		awsconfig.WithRegion(region),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	s3Client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		o.UsePathStyle = usePathStyle
+	})
+
+	return &s3Provider{
+		s3Client:  s3Client,
+		bucket:    cfg.BucketName,
+		publicURL: cfg.PublicURL,
+	}, nil
+}
+
+// GeneratePresignedUploadURL generates a presigned URL for uploading a file
+// contentLength enforces the exact file size at the storage level (prevents size manipulation)
+func (p *s3Provider) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, contentLength int64, expiresIn time.Duration) (string, error) {
+	presignClient := s3.NewPresignClient(p.s3Client)
+
+	putObjectInput := &s3.PutObjectInput{
+		Bucket:        aws.String(p.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(contentLength), // Enforce exact size
+	}
+
+	req, err := presignClient.PresignPutObject(ctx, putObjectInput, func(opts *s3.PresignOptions) {
+		opts.Expires = expiresIn
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned upload URL: %w", err)
+	}
+
+	return req.URL, nil
+}
+
+// GeneratePresignedDownloadURL generates a presigned URL for downloading/viewing a file
+// CRITICAL: If publicURL (CDN) is configured, return the CDN URL to avoid Class B operations
+// Only use presigned URLs for private files or when CDN is not configured
+func (p *s3Provider) GeneratePresignedDownloadURL(ctx context.Context, key string, expiresIn time.Duration) (string, error) {
+	// If public CDN URL is configured, return it directly (avoids Class B operations)
+	// Format: https://media.telar.press/users/123/file.jpg
+	if p.publicURL != "" {
+		// Ensure publicURL doesn't end with /
+		publicBase := strings.TrimSuffix(p.publicURL, "/")
+		// Key already includes the path (e.g., users/123/file.jpg)
+		cdnURL := fmt.Sprintf("%s/%s", publicBase, key)
+		return cdnURL, nil
+	}
+
+	// Fallback: Generate presigned URL for private files or when CDN not configured
+	presignClient := s3.NewPresignClient(p.s3Client)
+
+	req, err := presignClient.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	}, func(opts *s3.PresignOptions) {
+		opts.Expires = expiresIn
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to generate presigned download URL: %w", err)
+	}
+
+	return req.URL, nil
+}
+
+// Delete deletes a file from the bucket
+func (p *s3Provider) Delete(ctx context.Context, key string) error {
+	_, err := p.s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to delete file from storage: %w", err)
+	}
+
+	return nil
+}
+
+// GetMetadata retrieves file metadata (size) from the bucket
+func (p *s3Provider) GetMetadata(ctx context.Context, key string) (int64, error) {
+	headOutput, err := p.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get file metadata: %w", err)
+	}
+
+	if headOutput.ContentLength == nil {
+		return 0, fmt.Errorf("content length is nil")
+	}
+
+	return *headOutput.ContentLength, nil
+}
+
+// GetObject opens a file for reading; the caller closes the returned body
+func (p *s3Provider) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := p.s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(p.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file: %w", err)
+	}
+	return output.Body, nil
+}
+
+// PutObject writes a file generated server-side, such as a thumbnail
+func (p *s3Provider) PutObject(ctx context.Context, key string, contentType string, data []byte) error {
+	_, err := p.s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(p.bucket),
+		Key:           aws.String(key),
+		ContentType:   aws.String(contentType),
+		ContentLength: aws.Int64(int64(len(data))),
+		Body:          bytes.NewReader(data),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put file: %w", err)
+	}
+	return nil
+}
@@ -17,6 +17,10 @@ import (
 	"github.com/qolzam/telar/apps/api/storage/models"
 )
 
+// fileColumns are the columns scanned into models.File
+const fileColumns = `id, owner_user_id, name, path, mime_type, size_bytes, provider, bucket, status, purpose,
+		width, height, thumbnail_path, variants, processing_status, processing_attempts, created_at, updated_at`
+
 type postgresRepository struct {
 	client *postgres.Client
 	schema string
@@ -51,15 +55,15 @@ func (r *postgresRepository) prefixSchema(query string) string {
 // Create inserts a new file record
 func (r *postgresRepository) Create(ctx context.Context, file *models.File) error {
 	query := `
-		INSERT INTO %sfiles (id, owner_user_id, name, path, mime_type, size_bytes, provider, bucket, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		INSERT INTO %sfiles (id, owner_user_id, name, path, mime_type, size_bytes, provider, bucket, status, purpose, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 	`
 
 	exec := r.getExecutor(ctx)
 	sqlStr := r.prefixSchema(query)
 	_, err := exec.ExecContext(ctx, sqlStr,
 		file.ID, file.OwnerUserID, file.Name, file.Path, file.MimeType, file.SizeBytes,
-		file.Provider, file.Bucket, file.Status, file.CreatedAt, file.UpdatedAt,
+		file.Provider, file.Bucket, file.Status, file.Purpose, file.CreatedAt, file.UpdatedAt,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
@@ -70,7 +74,7 @@ func (r *postgresRepository) Create(ctx context.Context, file *models.File) erro
 // FindByID retrieves a file by its ID
 func (r *postgresRepository) FindByID(ctx context.Context, id uuid.UUID) (*models.File, error) {
 	query := `
-		SELECT id, owner_user_id, name, path, mime_type, size_bytes, provider, bucket, status, created_at, updated_at
+		SELECT ` + fileColumns + `
 		FROM %sfiles
 		WHERE id = $1
 	`
@@ -91,7 +95,7 @@ func (r *postgresRepository) FindByID(ctx context.Context, id uuid.UUID) (*model
 // FindByOwner retrieves files owned by a user
 func (r *postgresRepository) FindByOwner(ctx context.Context, ownerID uuid.UUID, limit, offset int) ([]*models.File, error) {
 	query := `
-		SELECT id, owner_user_id, name, path, mime_type, size_bytes, provider, bucket, status, created_at, updated_at
+		SELECT ` + fileColumns + `
 		FROM %sfiles
 		WHERE owner_user_id = $1 AND status != 'deleted'
 		ORDER BY created_at DESC
@@ -124,6 +128,58 @@ func (r *postgresRepository) UpdateStatus(ctx context.Context, id uuid.UUID, sta
 	return nil
 }
 
+// ClaimForProcessing marks up to limit confirmed uploads as processing and returns them.
+// Uploads left in processing longer than staleAfter (a worker died) are claimed again,
+// until they have been attempted maxAttempts times. SKIP LOCKED lets several workers
+// poll at once without claiming the same file.
+func (r *postgresRepository) ClaimForProcessing(ctx context.Context, limit int, staleAfter time.Duration, maxAttempts int) ([]*models.File, error) {
+	query := `
+		UPDATE %sfiles
+		SET processing_status = 'processing', processing_started_at = NOW(),
+			processing_attempts = processing_attempts + 1
+		WHERE id IN (
+			SELECT id FROM %sfiles
+			WHERE status = 'uploaded' AND processing_attempts < $3
+				AND (processing_status = 'pending'
+					OR (processing_status = 'processing' AND processing_started_at < NOW() - make_interval(secs => $2)))
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + fileColumns
+
+	prefix := ""
+	if r.schema != "" {
+		prefix = r.schema + "."
+	}
+	sqlStr := fmt.Sprintf(query, prefix, prefix)
+	var files []*models.File
+	err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &files, sqlStr, limit, staleAfter.Seconds(), maxAttempts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim files for processing: %w", err)
+	}
+	return files, nil
+}
+
+// CompleteProcessing stores the outcome of the image pipeline for a claimed file
+func (r *postgresRepository) CompleteProcessing(ctx context.Context, file *models.File) error {
+	query := `
+		UPDATE %sfiles
+		SET processing_status = $1, size_bytes = $2, width = $3, height = $4,
+			thumbnail_path = $5, variants = $6, updated_at = $7
+		WHERE id = $8
+	`
+
+	exec := r.getExecutor(ctx)
+	sqlStr := r.prefixSchema(query)
+	_, err := exec.ExecContext(ctx, sqlStr, file.ProcessingStatus, file.SizeBytes, file.Width, file.Height,
+		file.ThumbnailPath, file.Variants, time.Now(), file.ID)
+	if err != nil {
+		return fmt.Errorf("failed to complete file processing: %w", err)
+	}
+	return nil
+}
+
 // Delete soft deletes a file (sets status to 'deleted')
 func (r *postgresRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.UpdateStatus(ctx, id, "deleted")
@@ -151,7 +207,7 @@ func (r *postgresRepository) GetTotalSize(ctx context.Context) (int64, error) {
 // Returns files ordered by created_at ASC, limited to the specified count
 func (r *postgresRepository) FindOldestFiles(ctx context.Context, limit int) ([]*models.File, error) {
 	query := `
-		SELECT id, owner_user_id, name, path, mime_type, size_bytes, provider, bucket, status, created_at, updated_at
+		SELECT ` + fileColumns + `
 		FROM %sfiles
 		WHERE status != 'deleted'
 		ORDER BY created_at ASC
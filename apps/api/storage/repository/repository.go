@@ -27,6 +27,14 @@ type Repository interface {
 	// UpdateStatus updates the status of a file
 	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
 
+	// ClaimForProcessing marks up to limit confirmed uploads waiting for the image pipeline
+	// as processing and returns them; stale claims are retried up to maxAttempts
+	ClaimForProcessing(ctx context.Context, limit int, staleAfter time.Duration, maxAttempts int) ([]*models.File, error)
+
+	// CompleteProcessing stores the processing status, size, dimensions, thumbnail and
+	// variants of a claimed file
+	CompleteProcessing(ctx context.Context, file *models.File) error
+
 	// Delete soft deletes a file (sets status to 'deleted')
 	Delete(ctx context.Context, id uuid.UUID) error
 
@@ -17,7 +17,8 @@ type StorageService interface {
 	// InitializeUpload creates a file record and returns a presigned URL for upload
 	InitializeUpload(ctx context.Context, req *models.UploadRequest, userID uuid.UUID) (*models.UploadResponse, error)
 
-	// ConfirmUpload marks a file as uploaded after the client completes the upload
+	// ConfirmUpload verifies the uploaded object and marks the file as uploaded, which
+	// queues it for the image pipeline
 	ConfirmUpload(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) error
 
 	// EnforceQuota enforces storage quota limits by deleting oldest files if necessary
@@ -26,8 +27,9 @@ type StorageService interface {
 	// DeleteFile deletes a file (soft delete + physical delete from storage)
 	DeleteFile(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) error
 
-	// GetFileURL returns the public CDN URL or presigned download URL for a file
+	// GetFileURL returns the public CDN URL or presigned download URL for a file,
+	// plus its thumbnail and variants once the image pipeline has processed it
 	// This is used by the frontend to display images without burning Class B operations
-	GetFileURL(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) (string, error)
+	GetFileURL(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) (*models.FileURLResponse, error)
 }
 
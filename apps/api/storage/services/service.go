@@ -13,6 +13,7 @@ import (
 	"time"
 
 	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
 	"github.com/qolzam/telar/apps/api/internal/pkg/log"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/storage/models"
@@ -41,6 +42,9 @@ var (
 	ErrInvalidMimeType     = fmt.Errorf("invalid MIME type: file type not allowed")
 	ErrDailyLimitReached   = fmt.Errorf("daily upload limit reached")
 	ErrGlobalLimitReached  = fmt.Errorf("system storage busy, try again later")
+	ErrUploadNotFound      = fmt.Errorf("upload not found in storage")
+	ErrUploadSizeMismatch  = fmt.Errorf("uploaded file size does not match")
+	ErrInvalidPurpose      = fmt.Errorf("invalid purpose: must be post or avatar")
 )
 
 type service struct {
@@ -73,6 +77,15 @@ func (s *service) InitializeUpload(ctx context.Context, req *models.UploadReques
 		return nil, fmt.Errorf("%w: %s", ErrInvalidMimeType, req.ContentType)
 	}
 
+	// The purpose decides which variants the image pipeline makes
+	purpose := req.Purpose
+	if purpose == "" {
+		purpose = models.PurposePost
+	}
+	if purpose != models.PurposePost && purpose != models.PurposeAvatar {
+		return nil, ErrInvalidPurpose
+	}
+
 	// 3. Quota Check: User Daily Limit (Postgres, NOT R2)
 	userCount, err := s.repo.IncrementDailyUploadCount(ctx, userID, req.Size)
 	if err != nil {
@@ -105,9 +118,10 @@ func (s *service) InitializeUpload(ctx context.Context, req *models.UploadReques
 		Path:        key,
 		MimeType:    req.ContentType,
 		SizeBytes:   req.Size,
-		Provider:    DefaultProvider,
+		Provider:    s.providerName(),
 		Bucket:      s.bucket,
 		Status:      "pending",
+		Purpose:     purpose,
 		CreatedAt:   now,
 		UpdatedAt:   now,
 	}
@@ -129,6 +143,14 @@ func (s *service) InitializeUpload(ctx context.Context, req *models.UploadReques
 	}, nil
 }
 
+// providerName is recorded with each file so it can be located after a provider switch
+func (s *service) providerName() string {
+	if s.config.Provider == "" {
+		return DefaultProvider
+	}
+	return s.config.Provider
+}
+
 // isMimeTypeAllowed checks if the MIME type is in the allowed list
 func (s *service) isMimeTypeAllowed(mimeType string) bool {
 	if len(s.config.AllowedMimeTypes) == 0 {
@@ -159,19 +181,35 @@ func (s *service) ConfirmUpload(ctx context.Context, fileID uuid.UUID, userID uu
 		return fmt.Errorf("file is not in pending status")
 	}
 
-	// 3. Optionally verify file exists in storage (metadata check)
-	// For now, we trust the client confirmation
-	// In production, you might want to verify:
-	// _, err = s.provider.GetMetadata(ctx, file.Path)
-	// if err != nil {
-	//     return fmt.Errorf("file not found in storage: %w", err)
-	// }
+	// 3. Verify the client actually uploaded the declared file
+	size, err := s.provider.GetMetadata(ctx, file.Path)
+	if err != nil {
+		log.Warn("Upload confirmation without object: fileID=%s, error=%v", fileID, err)
+		return ErrUploadNotFound
+	}
+	if size != file.SizeBytes {
+		return fmt.Errorf("%w: expected %d bytes, got %d", ErrUploadSizeMismatch, file.SizeBytes, size)
+	}
 
 	// 4. Update status to 'uploaded'
 	if err := s.repo.UpdateStatus(ctx, fileID, "uploaded"); err != nil {
 		return fmt.Errorf("failed to update file status: %w", err)
 	}
 
+	// 5. Wake the image pipeline, which resizes the upload and strips its metadata
+	if events.HasSubscribers() {
+		events.Publish(ctx, events.Event{
+			Type: events.TypeMediaUploaded,
+			Data: events.MediaUpload{
+				FileId:      file.ID.String(),
+				OwnerUserId: file.OwnerUserID.String(),
+				Purpose:     file.Purpose,
+				MimeType:    file.MimeType,
+			},
+			CreatedDate: time.Now().UnixMilli(),
+		})
+	}
+
 	return nil
 }
 
@@ -218,6 +256,7 @@ func (s *service) EnforceQuota(ctx context.Context) error {
 				log.Error("Failed to delete file from storage: error=%v, path=%s", err, file.Path)
 				// Continue with next file even if delete fails
 			}
+			s.deleteVariants(ctx, file)
 
 			// Delete from DB
 			if err := s.repo.HardDelete(ctx, file.ID); err != nil {
@@ -255,6 +294,7 @@ func (s *service) DeleteFile(ctx context.Context, fileID uuid.UUID, userID uuid.
 		log.Error("Failed to delete file from storage: error=%v, path=%s", err, file.Path)
 		// Continue with DB delete even if storage delete fails
 	}
+	s.deleteVariants(ctx, file)
 
 	// 3. Soft delete in DB
 	if err := s.repo.Delete(ctx, fileID); err != nil {
@@ -264,30 +304,63 @@ func (s *service) DeleteFile(ctx context.Context, fileID uuid.UUID, userID uuid.
 	return nil
 }
 
+// deleteVariants removes the resized copies the image pipeline made of the file
+func (s *service) deleteVariants(ctx context.Context, file *models.File) {
+	paths := make(map[string]bool, len(file.Variants)+1)
+	if file.ThumbnailPath != "" {
+		paths[file.ThumbnailPath] = true
+	}
+	for _, variant := range file.Variants {
+		paths[variant.Path] = true
+	}
+	for path := range paths {
+		if err := s.provider.Delete(ctx, path); err != nil {
+			log.Error("Failed to delete image variant from storage: error=%v, path=%s", err, path)
+		}
+	}
+}
+
 // GetFileURL returns the public CDN URL or presigned download URL for a file
-func (s *service) GetFileURL(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) (string, error) {
+func (s *service) GetFileURL(ctx context.Context, fileID uuid.UUID, userID uuid.UUID) (*models.FileURLResponse, error) {
 	// 1. Verify file exists and belongs to user
 	file, err := s.repo.FindByID(ctx, fileID)
 	if err != nil {
-		return "", ErrFileNotFound
+		return nil, ErrFileNotFound
 	}
 
 	if file.OwnerUserID != userID {
-		return "", fmt.Errorf("unauthorized: file does not belong to user")
+		return nil, fmt.Errorf("unauthorized: file does not belong to user")
 	}
 
 	// 2. Verify file is uploaded (not pending or deleted)
 	if file.Status != "uploaded" {
-		return "", fmt.Errorf("file is not available (status: %s)", file.Status)
+		return nil, fmt.Errorf("file is not available (status: %s)", file.Status)
 	}
 
 	// 3. Generate public CDN URL or presigned download URL
 	// The provider will return CDN URL if PublicURL is configured, otherwise presigned URL
 	url, err := s.provider.GeneratePresignedDownloadURL(ctx, file.Path, 24*time.Hour)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate file URL: %w", err)
+		return nil, fmt.Errorf("failed to generate file URL: %w", err)
+	}
+
+	resp := &models.FileURLResponse{URL: url, Width: file.Width, Height: file.Height}
+	if file.ThumbnailPath != "" {
+		resp.ThumbnailURL, err = s.provider.GeneratePresignedDownloadURL(ctx, file.ThumbnailPath, 24*time.Hour)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate thumbnail URL: %w", err)
+		}
+	}
+	if len(file.Variants) > 0 {
+		resp.Variants = make(map[string]string, len(file.Variants))
+		for name, variant := range file.Variants {
+			resp.Variants[name], err = s.provider.GeneratePresignedDownloadURL(ctx, variant.Path, 24*time.Hour)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate variant URL: %w", err)
+			}
+		}
 	}
 
-	return url, nil
+	return resp, nil
 }
 
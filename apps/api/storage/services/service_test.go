@@ -0,0 +1,197 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/storage/models"
+	storageRepository "github.com/qolzam/telar/apps/api/storage/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryProvider keeps objects in a map
+type memoryProvider struct {
+	objects map[string][]byte
+}
+
+func (p *memoryProvider) GeneratePresignedUploadURL(ctx context.Context, key string, contentType string, contentLength int64, expiresIn time.Duration) (string, error) {
+	return "https://upload.test/" + key, nil
+}
+
+func (p *memoryProvider) GeneratePresignedDownloadURL(ctx context.Context, key string, expiresIn time.Duration) (string, error) {
+	return "https://cdn.test/" + key, nil
+}
+
+func (p *memoryProvider) Delete(ctx context.Context, key string) error {
+	delete(p.objects, key)
+	return nil
+}
+
+func (p *memoryProvider) GetMetadata(ctx context.Context, key string) (int64, error) {
+	data, ok := p.objects[key]
+	if !ok {
+		return 0, fmt.Errorf("not found: %s", key)
+	}
+	return int64(len(data)), nil
+}
+
+func (p *memoryProvider) GetObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := p.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("not found: %s", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (p *memoryProvider) PutObject(ctx context.Context, key string, contentType string, data []byte) error {
+	p.objects[key] = data
+	return nil
+}
+
+// fileRepo stores files in a map; methods the tests do not need panic via the nil interface
+type fileRepo struct {
+	storageRepository.Repository
+	files map[uuid.UUID]*models.File
+}
+
+func (r *fileRepo) Create(ctx context.Context, file *models.File) error {
+	r.files[file.ID] = file
+	return nil
+}
+
+func (r *fileRepo) FindByID(ctx context.Context, id uuid.UUID) (*models.File, error) {
+	file, ok := r.files[id]
+	if !ok {
+		return nil, fmt.Errorf("file not found")
+	}
+	copied := *file
+	return &copied, nil
+}
+
+func (r *fileRepo) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	r.files[id].Status = status
+	return nil
+}
+
+func (r *fileRepo) IncrementDailyUploadCount(ctx context.Context, userID uuid.UUID, bytesUploaded int64) (int, error) {
+	return 1, nil
+}
+
+func (r *fileRepo) GetGlobalDailyUploadCount(ctx context.Context) (int, error) {
+	return 1, nil
+}
+
+func newTestService() (*service, *fileRepo, *memoryProvider) {
+	repo := &fileRepo{files: map[uuid.UUID]*models.File{}}
+	blobs := &memoryProvider{objects: map[string][]byte{}}
+	cfg := &platformconfig.StorageConfig{
+		MaxFileSizeMB:          2,
+		AllowedMimeTypes:       []string{"image/png"},
+		GlobalDailyUploadLimit: 100,
+		UserDailyUploadLimit:   10,
+	}
+	return NewStorageService(repo, blobs, "bucket", cfg).(*service), repo, blobs
+}
+
+func TestInitializeUploadPurpose(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	svc, repo, _ := newTestService()
+
+	resp, err := svc.InitializeUpload(ctx, &models.UploadRequest{Name: "a.png", ContentType: "image/png", Size: 10}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PurposePost, repo.files[resp.FileID].Purpose)
+
+	resp, err = svc.InitializeUpload(ctx, &models.UploadRequest{Name: "a.png", ContentType: "image/png", Size: 10, Purpose: models.PurposeAvatar}, userID)
+	require.NoError(t, err)
+	assert.Equal(t, models.PurposeAvatar, repo.files[resp.FileID].Purpose)
+
+	_, err = svc.InitializeUpload(ctx, &models.UploadRequest{Name: "a.png", ContentType: "image/png", Size: 10, Purpose: "banner"}, userID)
+	assert.ErrorIs(t, err, ErrInvalidPurpose)
+}
+
+func TestConfirmUpload(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	fileID := uuid.Must(uuid.NewV4())
+	key := fmt.Sprintf("users/%s/%s.png", userID, fileID)
+	data := []byte("not really a png")
+
+	newService := func(size int64) (*service, *fileRepo, *memoryProvider) {
+		svc, repo, blobs := newTestService()
+		repo.files[fileID] = &models.File{ID: fileID, OwnerUserID: userID, Path: key, MimeType: "image/png",
+			SizeBytes: size, Status: "pending", Purpose: models.PurposeAvatar}
+		return svc, repo, blobs
+	}
+
+	t.Run("refuses a confirmation without the uploaded object", func(t *testing.T) {
+		svc, repo, _ := newService(int64(len(data)))
+		assert.ErrorIs(t, svc.ConfirmUpload(ctx, fileID, userID), ErrUploadNotFound)
+		assert.Equal(t, "pending", repo.files[fileID].Status)
+	})
+
+	t.Run("refuses an object of a different size", func(t *testing.T) {
+		svc, _, blobs := newService(3)
+		blobs.objects[key] = data
+		assert.ErrorIs(t, svc.ConfirmUpload(ctx, fileID, userID), ErrUploadSizeMismatch)
+	})
+
+	t.Run("wakes the image pipeline", func(t *testing.T) {
+		var published []events.Event
+		unsubscribe := events.Subscribe(func(e events.Event) { published = append(published, e) })
+		defer unsubscribe()
+
+		svc, repo, blobs := newService(int64(len(data)))
+		blobs.objects[key] = data
+		require.NoError(t, svc.ConfirmUpload(ctx, fileID, userID))
+		assert.Equal(t, "uploaded", repo.files[fileID].Status)
+
+		require.Len(t, published, 1)
+		assert.Equal(t, events.TypeMediaUploaded, published[0].Type)
+		assert.Empty(t, published[0].Recipients)
+		assert.Equal(t, events.MediaUpload{
+			FileId:      fileID.String(),
+			OwnerUserId: userID.String(),
+			Purpose:     models.PurposeAvatar,
+			MimeType:    "image/png",
+		}, published[0].Data)
+	})
+}
+
+func TestGetFileURLIncludesVariants(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.Must(uuid.NewV4())
+	fileID := uuid.Must(uuid.NewV4())
+	svc, repo, blobs := newTestService()
+	repo.files[fileID] = &models.File{
+		ID: fileID, OwnerUserID: userID, Path: "users/u/f.png", Status: "uploaded",
+		Width: 640, Height: 480, ThumbnailPath: "users/u/f_thumb.jpg",
+		Variants: models.Variants{
+			"thumb":      {Path: "users/u/f_thumb.jpg", Width: 320, Height: 240},
+			"avatar_128": {Path: "users/u/f_avatar_128.jpg", Width: 128, Height: 128},
+		},
+	}
+
+	urls, err := svc.GetFileURL(ctx, fileID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.test/users/u/f_thumb.jpg", urls.ThumbnailURL)
+	assert.Equal(t, map[string]string{
+		"thumb":      "https://cdn.test/users/u/f_thumb.jpg",
+		"avatar_128": "https://cdn.test/users/u/f_avatar_128.jpg",
+	}, urls.Variants)
+
+	// Deleting the file removes its variants too
+	for _, variant := range repo.files[fileID].Variants {
+		blobs.objects[variant.Path] = []byte("x")
+	}
+	svc.deleteVariants(ctx, repo.files[fileID])
+	assert.Empty(t, blobs.objects)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/streaks/errors"
+	"github.com/qolzam/telar/apps/api/streaks/services"
+)
+
+type StreakHandler struct {
+	service services.Service
+}
+
+func NewStreakHandler(service services.Service) *StreakHandler {
+	return &StreakHandler{service: service}
+}
+
+// UserStreak returns a user's activity streak; whether it is at risk is only shown to the user.
+// Endpoint: GET /streaks/users/:userId
+func (h *StreakHandler) UserStreak(c *fiber.Ctx) error {
+	userID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+	resp, err := h.service.GetStreak(c.Context(), userID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	if user, ok := c.Locals(types.UserCtxName).(types.UserContext); !ok || user.UserID != userID {
+		resp.AtRisk = false
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
+
+// MyStreak returns the current user's activity streak.
+// Endpoint: GET /streaks/me
+func (h *StreakHandler) MyStreak(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	resp, err := h.service.GetStreak(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+	return c.Status(http.StatusOK).JSON(resp)
+}
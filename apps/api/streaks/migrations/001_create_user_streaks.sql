@@ -0,0 +1,18 @@
+-- Daily activity streaks, one row per user who has ever been active. A nightly job adds
+-- each completed UTC day of analytics events; a streak counts consecutive active days.
+CREATE TABLE IF NOT EXISTS user_streaks (
+    user_id UUID PRIMARY KEY,
+    current_streak INT NOT NULL DEFAULT 0,
+    longest_streak INT NOT NULL DEFAULT 0,
+    last_active_day DATE NOT NULL,
+    -- Day a streak reminder was last sent, so each user gets at most one a day
+    last_reminded_day DATE,
+    updated_date BIGINT NOT NULL
+);
+
+-- Reminder candidates: streaks that end unless the user is active today
+CREATE INDEX IF NOT EXISTS idx_user_streaks_last_active ON user_streaks(last_active_day, current_streak);
+
+-- The job reads each day's events by user, and reminders check a user's events today
+CREATE INDEX IF NOT EXISTS idx_analytics_events_user_occurred ON analytics_events(user_id, occurred_at)
+    WHERE user_id IS NOT NULL;
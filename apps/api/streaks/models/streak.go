@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	settingsModels "github.com/qolzam/telar/apps/api/settings/models"
+)
+
+// DayLayout formats streak days, which are UTC calendar days
+const DayLayout = "2006-01-02"
+
+// UserStreak is a user_streaks row as of the last day the nightly job recorded
+type UserStreak struct {
+	UserId        uuid.UUID `db:"user_id"`
+	CurrentStreak int       `db:"current_streak"`
+	LongestStreak int       `db:"longest_streak"`
+	LastActiveDay string    `db:"last_active_day"` // DayLayout
+	UpdatedDate   int64     `db:"updated_date"`
+}
+
+// ReminderCandidate is a user whose streak ends unless they are active today
+type ReminderCandidate struct {
+	UserId        uuid.UUID
+	CurrentStreak int
+	QuietHours    *settingsModels.QuietHours // nil when the user has none
+}
+
+// Streak is the GET /streaks response. Activity is counted once a day has ended, so
+// today's activity shows up after the next nightly run.
+type Streak struct {
+	UserId        string `json:"userId"`
+	Current       int    `json:"current"` // Consecutive active days ending yesterday; 0 once a day is missed
+	Longest       int    `json:"longest"`
+	LastActiveDay string `json:"lastActiveDay,omitempty"` // UTC date, e.g. "2026-10-17"
+	// AtRisk is set when the current streak ends unless the user is active today
+	AtRisk bool `json:"atRisk"`
+}
+
+// NewStreak builds the response for userID from its row, which is nil for users who
+// were never active, as seen on the UTC day today.
+func NewStreak(userID uuid.UUID, row *UserStreak, today time.Time) *Streak {
+	streak := &Streak{UserId: userID.String()}
+	if row == nil {
+		return streak
+	}
+	streak.Longest = row.LongestStreak
+	streak.LastActiveDay = row.LastActiveDay
+
+	yesterday := today.AddDate(0, 0, -1).Format(DayLayout)
+	// Days compare correctly as strings in DayLayout
+	if row.LastActiveDay >= yesterday {
+		streak.Current = row.CurrentStreak
+		streak.AtRisk = row.LastActiveDay == yesterday
+	}
+	return streak
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	settingsModels "github.com/qolzam/telar/apps/api/settings/models"
+	settingsRepository "github.com/qolzam/telar/apps/api/settings/repository"
+	"github.com/qolzam/telar/apps/api/streaks/models"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) StreakRepository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) StreakRepository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// RecordDay counts any event with a user, whatever its type, as activity. Days are
+// passed as text so the session time zone cannot shift them.
+func (r *postgresRepository) RecordDay(ctx context.Context, day time.Time, updatedDate int64) (int64, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]suser_streaks AS s (user_id, current_streak, longest_streak, last_active_day, updated_date)
+		SELECT DISTINCT user_id, 1, 1, $1::date, $4
+		FROM %[1]sanalytics_events
+		WHERE user_id IS NOT NULL AND occurred_at >= $2 AND occurred_at < $3
+		ON CONFLICT (user_id) DO UPDATE SET
+			current_streak = CASE WHEN s.last_active_day = EXCLUDED.last_active_day - 1 THEN s.current_streak + 1 ELSE 1 END,
+			longest_streak = GREATEST(s.longest_streak,
+				CASE WHEN s.last_active_day = EXCLUDED.last_active_day - 1 THEN s.current_streak + 1 ELSE 1 END),
+			last_active_day = EXCLUDED.last_active_day,
+			updated_date = EXCLUDED.updated_date
+		WHERE s.last_active_day < EXCLUDED.last_active_day
+	`, r.schemaPrefix())
+
+	day = day.UTC()
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, day.Format(models.DayLayout), day, day.AddDate(0, 0, 1), updatedDate)
+	if err != nil {
+		return 0, fmt.Errorf("record streak day: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *postgresRepository) LastRecordedDay(ctx context.Context) (string, error) {
+	query := fmt.Sprintf(`SELECT COALESCE(to_char(MAX(last_active_day), 'YYYY-MM-DD'), '') FROM %suser_streaks`, r.schemaPrefix())
+
+	var day string
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &day, query); err != nil {
+		return "", fmt.Errorf("read last streak day: %w", err)
+	}
+	return day, nil
+}
+
+func (r *postgresRepository) Get(ctx context.Context, userID uuid.UUID) (*models.UserStreak, error) {
+	query := fmt.Sprintf(`
+		SELECT user_id, current_streak, longest_streak,
+			to_char(last_active_day, 'YYYY-MM-DD') AS last_active_day, updated_date
+		FROM %suser_streaks
+		WHERE user_id = $1
+	`, r.schemaPrefix())
+
+	var row models.UserStreak
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get streak: %w", err)
+	}
+	return &row, nil
+}
+
+func (r *postgresRepository) ReminderCandidates(ctx context.Context, day time.Time, minDays int) ([]models.ReminderCandidate, error) {
+	query := fmt.Sprintf(`
+		SELECT s.user_id, s.current_streak, st.value->'quietHours' AS quiet_hours
+		FROM %[1]suser_streaks s
+		JOIN %[1]ssettings st ON st.scope = 'user:' || s.user_id::text AND st.key = $1
+		WHERE s.last_active_day = $2::date - 1
+			AND s.current_streak >= $3
+			AND (s.last_reminded_day IS NULL OR s.last_reminded_day < $2::date)
+			AND COALESCE((st.value->>'streakReminders')::boolean, FALSE)
+			AND NOT EXISTS (
+				SELECT 1 FROM %[1]sanalytics_events e
+				WHERE e.user_id = s.user_id AND e.occurred_at >= $4 AND e.occurred_at < $5
+			)
+	`, r.schemaPrefix())
+
+	day = day.UTC()
+	var rows []struct {
+		UserID        uuid.UUID `db:"user_id"`
+		CurrentStreak int       `db:"current_streak"`
+		QuietHours    []byte    `db:"quiet_hours"`
+	}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query,
+		settingsRepository.KeyNotifications, day.Format(models.DayLayout), minDays, day, day.AddDate(0, 0, 1)); err != nil {
+		return nil, fmt.Errorf("list streak reminder candidates: %w", err)
+	}
+
+	candidates := make([]models.ReminderCandidate, 0, len(rows))
+	for _, row := range rows {
+		candidate := models.ReminderCandidate{UserId: row.UserID, CurrentStreak: row.CurrentStreak}
+		if len(row.QuietHours) > 0 && string(row.QuietHours) != "null" {
+			var quiet settingsModels.QuietHours
+			if err := json.Unmarshal(row.QuietHours, &quiet); err == nil {
+				candidate.QuietHours = &quiet
+			}
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func (r *postgresRepository) ClaimReminder(ctx context.Context, userID uuid.UUID, day time.Time) (bool, error) {
+	query := fmt.Sprintf(`
+		UPDATE %suser_streaks SET last_reminded_day = $2::date
+		WHERE user_id = $1 AND (last_reminded_day IS NULL OR last_reminded_day < $2::date)
+	`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, userID, day.UTC().Format(models.DayLayout))
+	if err != nil {
+		return false, fmt.Errorf("claim streak reminder: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("claim streak reminder: %w", err)
+	}
+	return affected > 0, nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	query := fmt.Sprintf(`DELETE FROM %suser_streaks WHERE user_id = $1`, r.schemaPrefix())
+	if _, err := r.getExecutor(ctx).ExecContext(ctx, query, userID); err != nil {
+		return fmt.Errorf("delete streak of user: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/streaks/models"
+)
+
+// StreakRepository defines data access for activity streaks.
+type StreakRepository interface {
+	// RecordDay extends or restarts the streak of every user with analytics events on
+	// day, a UTC midnight. Recording a day again, or one older than a user's last active
+	// day, changes nothing. Returns the number of users active that day.
+	RecordDay(ctx context.Context, day time.Time, updatedDate int64) (int64, error)
+
+	// LastRecordedDay returns the newest active day of any streak in DayLayout, or ""
+	// when there are none.
+	LastRecordedDay(ctx context.Context) (string, error)
+
+	// Get returns userID's streak, or nil when the user was never active.
+	Get(ctx context.Context, userID uuid.UUID) (*models.UserStreak, error)
+
+	// ReminderCandidates returns users who opted in to streak reminders and whose streak
+	// of at least minDays ends unless they are active on day: they were active the day
+	// before, have no events on day yet, and were not reminded on day.
+	ReminderCandidates(ctx context.Context, day time.Time, minDays int) ([]models.ReminderCandidate, error)
+
+	// ClaimReminder marks userID as reminded on day; returns false when another run
+	// already did.
+	ClaimReminder(ctx context.Context, userID uuid.UUID, day time.Time) (bool, error)
+
+	// DeleteByUser deletes userID's streak.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
@@ -0,0 +1,36 @@
+package streaks
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/streaks/handlers"
+)
+
+type Handlers struct {
+	StreakHandler *handlers.StreakHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires the streak lookups.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/streaks", createDualAuthMiddleware(routerCfg))
+	group.Get("/me", handlers.StreakHandler.MyStreak)
+	group.Get("/users/:userId", handlers.StreakHandler.UserStreak)
+}
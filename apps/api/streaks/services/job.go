@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// StartJob records completed days and sends due reminders now and then every interval
+// until ctx is done. A non-positive interval disables the job.
+func StartJob(ctx context.Context, svc Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		run := func() {
+			if err := svc.RecordCompletedDays(ctx); err != nil {
+				log.Error("Streak recording failed: %v", err)
+				// Reminders rely on yesterday being recorded
+				return
+			}
+			if _, err := svc.SendReminders(ctx); err != nil {
+				log.Error("Streak reminders failed: %v", err)
+			}
+		}
+
+		run()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
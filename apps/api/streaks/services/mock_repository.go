@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/streaks/models"
+	"github.com/qolzam/telar/apps/api/streaks/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the streaks repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.StreakRepository = (*MockRepository)(nil)
+
+func (m *MockRepository) RecordDay(ctx context.Context, day time.Time, updatedDate int64) (int64, error) {
+	args := m.Called(ctx, day, updatedDate)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) LastRecordedDay(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockRepository) Get(ctx context.Context, userID uuid.UUID) (*models.UserStreak, error) {
+	args := m.Called(ctx, userID)
+	row, _ := args.Get(0).(*models.UserStreak)
+	return row, args.Error(1)
+}
+
+func (m *MockRepository) ReminderCandidates(ctx context.Context, day time.Time, minDays int) ([]models.ReminderCandidate, error) {
+	args := m.Called(ctx, day, minDays)
+	candidates, _ := args.Get(0).([]models.ReminderCandidate)
+	return candidates, args.Error(1)
+}
+
+func (m *MockRepository) ClaimReminder(ctx context.Context, userID uuid.UUID, day time.Time) (bool, error) {
+	args := m.Called(ctx, userID, day)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	streakErrors "github.com/qolzam/telar/apps/api/streaks/errors"
+	"github.com/qolzam/telar/apps/api/streaks/models"
+	"github.com/qolzam/telar/apps/api/streaks/repository"
+)
+
+// maxCatchUpDays bounds how many missed days one run records
+const maxCatchUpDays = 7
+
+// Service defines activity streak operations.
+type Service interface {
+	// GetStreak returns userID's streak as of the last recorded day.
+	GetStreak(ctx context.Context, userID uuid.UUID) (*models.Streak, error)
+
+	// RecordCompletedDays records every completed UTC day since the last recorded one,
+	// at most a week back.
+	RecordCompletedDays(ctx context.Context) error
+
+	// SendReminders notifies opted-in users whose streak ends unless they are active
+	// today, once the reminder window has started and outside their quiet hours.
+	// Returns the number of reminders sent.
+	SendReminders(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo repository.StreakRepository
+	cfg  platformconfig.StreaksConfig
+	now  func() time.Time
+
+	mu              sync.Mutex
+	recordedThrough time.Time // Newest day this instance recorded
+}
+
+// NewService constructs a streaks service.
+func NewService(repo repository.StreakRepository, cfg platformconfig.StreaksConfig) Service {
+	return &service{repo: repo, cfg: cfg, now: time.Now}
+}
+
+// today returns the start of the current UTC day
+func (s *service) today() time.Time {
+	return s.now().UTC().Truncate(24 * time.Hour)
+}
+
+func (s *service) GetStreak(ctx context.Context, userID uuid.UUID) (*models.Streak, error) {
+	if userID == uuid.Nil {
+		return nil, streakErrors.ErrInvalidRequest
+	}
+	row, err := s.repo.Get(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", streakErrors.ErrDatabaseOperation, err)
+	}
+	return models.NewStreak(userID, row, s.today()), nil
+}
+
+func (s *service) RecordCompletedDays(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	yesterday := s.today().AddDate(0, 0, -1)
+	if !s.recordedThrough.Before(yesterday) {
+		return nil
+	}
+
+	start := yesterday.AddDate(0, 0, 1-maxCatchUpDays)
+	last, err := s.repo.LastRecordedDay(ctx)
+	if err != nil {
+		return fmt.Errorf("%w: %v", streakErrors.ErrDatabaseOperation, err)
+	}
+	if last != "" {
+		lastDay, err := time.Parse(models.DayLayout, last)
+		if err != nil {
+			return fmt.Errorf("%w: last streak day %q: %v", streakErrors.ErrDatabaseOperation, last, err)
+		}
+		if next := lastDay.AddDate(0, 0, 1); next.After(start) {
+			start = next
+		}
+	}
+
+	updatedDate := s.now().UTC().UnixMilli()
+	for day := start; !day.After(yesterday); day = day.AddDate(0, 0, 1) {
+		if _, err := s.repo.RecordDay(ctx, day, updatedDate); err != nil {
+			return fmt.Errorf("%w: %v", streakErrors.ErrDatabaseOperation, err)
+		}
+	}
+	s.recordedThrough = yesterday
+	return nil
+}
+
+func (s *service) SendReminders(ctx context.Context) (int, error) {
+	// Reminders only reach connected clients
+	if !s.cfg.RemindersEnabled || !events.HasSubscribers() {
+		return 0, nil
+	}
+	now := s.now().UTC()
+	today := s.today()
+	if now.Before(today.Add(24*time.Hour - s.cfg.ReminderWindow)) {
+		return 0, nil
+	}
+
+	candidates, err := s.repo.ReminderCandidates(ctx, today, s.cfg.ReminderMinDays)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", streakErrors.ErrDatabaseOperation, err)
+	}
+
+	sent := 0
+	for _, candidate := range candidates {
+		// Held back, not skipped: a later run in the window may still remind them
+		if candidate.QuietHours != nil && candidate.QuietHours.Contains(now) {
+			continue
+		}
+		claimed, err := s.repo.ClaimReminder(ctx, candidate.UserId, today)
+		if err != nil {
+			return sent, fmt.Errorf("%w: %v", streakErrors.ErrDatabaseOperation, err)
+		}
+		// Another instance reminded them first
+		if !claimed {
+			continue
+		}
+		s.notify(ctx, candidate, now.UnixMilli())
+		sent++
+	}
+	return sent, nil
+}
+
+func (s *service) notify(ctx context.Context, candidate models.ReminderCandidate, now int64) {
+	events.Publish(ctx, events.Event{
+		Type: events.TypeNotification,
+		Data: events.Notification{
+			Kind:       events.NotificationStreak,
+			StreakDays: candidate.CurrentStreak,
+		},
+		CreatedDate: now,
+		Recipients:  []uuid.UUID{candidate.UserId},
+	})
+}
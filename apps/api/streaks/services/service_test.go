@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	settingsModels "github.com/qolzam/telar/apps/api/settings/models"
+	"github.com/qolzam/telar/apps/api/streaks/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = platformconfig.StreaksConfig{
+	JobInterval:      time.Hour,
+	RemindersEnabled: true,
+	ReminderWindow:   6 * time.Hour,
+	ReminderMinDays:  3,
+}
+
+func newTestService(repo *MockRepository, now time.Time) *service {
+	svc := NewService(repo, testConfig).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func day(s string) time.Time {
+	d, _ := time.Parse(models.DayLayout, s)
+	return d
+}
+
+func TestNewStreak(t *testing.T) {
+	userID := uuid.Must(uuid.NewV4())
+	today := day("2026-10-18")
+
+	assert.Equal(t, &models.Streak{UserId: userID.String()}, models.NewStreak(userID, nil, today))
+
+	row := &models.UserStreak{UserId: userID, CurrentStreak: 4, LongestStreak: 9, LastActiveDay: "2026-10-17"}
+	assert.Equal(t, &models.Streak{UserId: userID.String(), Current: 4, Longest: 9, LastActiveDay: "2026-10-17", AtRisk: true},
+		models.NewStreak(userID, row, today))
+
+	row.LastActiveDay = "2026-10-16"
+	streak := models.NewStreak(userID, row, today)
+	assert.Zero(t, streak.Current, "a missed day ends the streak")
+	assert.False(t, streak.AtRisk)
+	assert.Equal(t, 9, streak.Longest)
+}
+
+func TestRecordCompletedDays(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 0, 30, 0, 0, time.UTC)
+
+	t.Run("catches up from the last recorded day", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("LastRecordedDay", ctx).Return("2026-10-15", nil).Once()
+		repo.On("RecordDay", ctx, day("2026-10-16"), now.UnixMilli()).Return(int64(5), nil).Once()
+		repo.On("RecordDay", ctx, day("2026-10-17"), now.UnixMilli()).Return(int64(7), nil).Once()
+
+		svc := newTestService(repo, now)
+		require.NoError(t, svc.RecordCompletedDays(ctx))
+		require.NoError(t, svc.RecordCompletedDays(ctx), "a day is recorded once per instance")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("looks back at most a week", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("LastRecordedDay", ctx).Return("", nil).Once()
+		repo.On("RecordDay", ctx, mock.Anything, now.UnixMilli()).Return(int64(0), nil).Times(maxCatchUpDays)
+
+		require.NoError(t, newTestService(repo, now).RecordCompletedDays(ctx))
+		repo.AssertExpectations(t)
+		assert.Equal(t, day("2026-10-11"), repo.Calls[1].Arguments.Get(1))
+	})
+
+	t.Run("skips days another instance recorded", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("LastRecordedDay", ctx).Return("2026-10-17", nil).Once()
+
+		require.NoError(t, newTestService(repo, now).RecordCompletedDays(ctx))
+		repo.AssertNotCalled(t, "RecordDay", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSendReminders(t *testing.T) {
+	ctx := context.Background()
+	today := day("2026-10-18")
+	inWindow := today.Add(20 * time.Hour)
+
+	quiet := uuid.Must(uuid.NewV4())
+	due := uuid.Must(uuid.NewV4())
+	remindedElsewhere := uuid.Must(uuid.NewV4())
+
+	var notified []events.Event
+	unsubscribe := events.Subscribe(func(event events.Event) {
+		if event.Type == events.TypeNotification {
+			notified = append(notified, event)
+		}
+	})
+	defer unsubscribe()
+
+	t.Run("waits for the reminder window", func(t *testing.T) {
+		repo := new(MockRepository)
+		sent, err := newTestService(repo, today.Add(17*time.Hour)).SendReminders(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		repo.AssertNotCalled(t, "ReminderCandidates", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("respects quiet hours and claims before notifying", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ReminderCandidates", ctx, today, 3).Return([]models.ReminderCandidate{
+			// 20:00 UTC is 22:00 in Berlin
+			{UserId: quiet, CurrentStreak: 12, QuietHours: &settingsModels.QuietHours{Start: "21:30", End: "08:00", TimeZone: "Europe/Berlin"}},
+			{UserId: due, CurrentStreak: 5},
+			{UserId: remindedElsewhere, CurrentStreak: 3},
+		}, nil).Once()
+		repo.On("ClaimReminder", ctx, due, today).Return(true, nil).Once()
+		repo.On("ClaimReminder", ctx, remindedElsewhere, today).Return(false, nil).Once()
+
+		sent, err := newTestService(repo, inWindow).SendReminders(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, sent)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "ClaimReminder", ctx, quiet, today)
+
+		require.Len(t, notified, 1)
+		assert.Equal(t, []uuid.UUID{due}, notified[0].Recipients)
+		assert.Equal(t, events.Notification{Kind: events.NotificationStreak, StreakDays: 5}, notified[0].Data)
+	})
+
+	t.Run("does nothing when reminders are disabled", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := newTestService(repo, inWindow)
+		svc.cfg.RemindersEnabled = false
+		sent, err := svc.SendReminders(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, sent)
+		repo.AssertNotCalled(t, "ReminderCandidates", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/supporters/errors"
+	"github.com/qolzam/telar/apps/api/supporters/models"
+	"github.com/qolzam/telar/apps/api/supporters/services"
+)
+
+type SupporterHandler struct {
+	service services.Service
+}
+
+func NewSupporterHandler(service services.Service) *SupporterHandler {
+	return &SupporterHandler{service: service}
+}
+
+// CreateTier adds a supporter tier to the current user's offer.
+// Endpoint: POST /supporters/tiers
+func (h *SupporterHandler) CreateTier(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var req models.CreateTierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	tier, err := h.service.CreateTier(c.Context(), user.UserID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(tier)
+}
+
+// UpdateTier renames or redescribes one of the current user's tiers.
+// Endpoint: PUT /supporters/tiers/:tierId
+func (h *SupporterHandler) UpdateTier(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	tierID, err := uuid.FromString(c.Params("tierId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid tierId")
+	}
+
+	var req models.UpdateTierRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	tier, err := h.service.UpdateTier(c.Context(), user.UserID, tierID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(tier)
+}
+
+// ArchiveTier closes one of the current user's tiers to new supporters.
+// Endpoint: DELETE /supporters/tiers/:tierId
+func (h *SupporterHandler) ArchiveTier(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	tierID, err := uuid.FromString(c.Params("tierId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid tierId")
+	}
+
+	if err := h.service.ArchiveTier(c.Context(), user.UserID, tierID); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
+
+// CreatorTiers lists the tiers a creator offers.
+// Endpoint: GET /supporters/creators/:creatorId/tiers
+func (h *SupporterHandler) CreatorTiers(c *fiber.Ctx) error {
+	creatorID, err := uuid.FromString(c.Params("creatorId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid creatorId")
+	}
+
+	tiers, err := h.service.ListTiers(c.Context(), creatorID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"tiers": tiers})
+}
+
+// Supporting lists the creators the current user supports.
+// Endpoint: GET /supporters/me/supporting
+func (h *SupporterHandler) Supporting(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	subscriptions, err := h.service.ListSupporting(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{"subscriptions": subscriptions})
+}
+
+// Revenue summarizes the current user's supporter revenue, attributed to posts.
+// Endpoint: GET /supporters/me/revenue?days=30
+func (h *SupporterHandler) Revenue(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	days := 0
+	if raw := c.Query("days"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return errors.HandleValidationError(c, "days must be a positive integer")
+		}
+		days = parsed
+	}
+
+	summary, err := h.service.RevenueSummary(c.Context(), user.UserID, days)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(summary)
+}
+
+// BillingEvent applies a payment, cancellation or refund reported by billing.
+// Endpoint: POST /supporters/billing/events (HMAC)
+func (h *SupporterHandler) BillingEvent(c *fiber.Ctx) error {
+	var event models.BillingEvent
+	if err := c.BodyParser(&event); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	if err := h.service.HandleBillingEvent(c.Context(), &event); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
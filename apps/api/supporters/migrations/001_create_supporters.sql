@@ -0,0 +1,50 @@
+-- Supporter tiers are paid support levels a creator offers. Prices are in the minor unit
+-- of the currency; billing owns the actual plans and charges.
+CREATE TABLE IF NOT EXISTS supporter_tiers (
+    id UUID PRIMARY KEY,
+    creator_id UUID NOT NULL,
+    name VARCHAR(80) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    price_cents BIGINT NOT NULL CHECK (price_cents > 0),
+    currency CHAR(3) NOT NULL,
+    is_archived BOOLEAN NOT NULL DEFAULT FALSE,
+    created_date BIGINT NOT NULL,
+    last_updated BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_supporter_tiers_creator ON supporter_tiers(creator_id) WHERE is_archived = FALSE;
+
+-- One subscription per creator and supporter, kept up to date from billing events.
+-- Access lasts until period_end, also after a cancellation.
+CREATE TABLE IF NOT EXISTS supporter_subscriptions (
+    creator_id UUID NOT NULL,
+    supporter_id UUID NOT NULL,
+    tier_id UUID NOT NULL,
+    status TEXT NOT NULL,
+    period_end BIGINT NOT NULL,
+    billing_ref VARCHAR(128) NOT NULL DEFAULT '',
+    source_post_id UUID,
+    created_date BIGINT NOT NULL,
+    last_updated BIGINT NOT NULL,
+
+    PRIMARY KEY (creator_id, supporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_supporter_subscriptions_supporter ON supporter_subscriptions(supporter_id, period_end);
+
+-- Revenue attribution: every payment and refund, credited to the post the supporter
+-- subscribed from. event_id is billing's event ID, so replayed events are recorded once.
+CREATE TABLE IF NOT EXISTS supporter_revenue (
+    id UUID PRIMARY KEY,
+    event_id VARCHAR(128) NOT NULL UNIQUE,
+    kind TEXT NOT NULL,
+    creator_id UUID NOT NULL,
+    supporter_id UUID NOT NULL,
+    tier_id UUID NOT NULL,
+    source_post_id UUID,
+    amount_cents BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    occurred_date BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_supporter_revenue_creator ON supporter_revenue(creator_id, occurred_date DESC);
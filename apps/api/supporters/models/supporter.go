@@ -0,0 +1,132 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Tier is a paid support level a creator offers
+type Tier struct {
+	ObjectId    uuid.UUID `json:"objectId"`
+	CreatorId   uuid.UUID `json:"creatorId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	PriceCents  int64     `json:"priceCents"` // Per billing period, in the currency's minor unit
+	Currency    string    `json:"currency"`   // ISO 4217 code, e.g. "USD"
+	Archived    bool      `json:"archived,omitempty"`
+	CreatedDate int64     `json:"createdDate"`
+	LastUpdated int64     `json:"lastUpdated"`
+}
+
+// CreateTierRequest is the body of POST /supporters/tiers
+type CreateTierRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	PriceCents  int64  `json:"priceCents"`
+	Currency    string `json:"currency"`
+}
+
+// UpdateTierRequest is the body of PUT /supporters/tiers/:tierId; omitted fields keep
+// their value. Prices are fixed once supporters may be paying them: archive the tier
+// and create a new one instead.
+type UpdateTierRequest struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+}
+
+// SubscriptionStatus is the billing state of a supporter's subscription
+type SubscriptionStatus string
+
+const (
+	// StatusActive subscriptions renew at the end of each period
+	StatusActive SubscriptionStatus = "active"
+	// StatusCanceled subscriptions keep access until the paid period ends
+	StatusCanceled SubscriptionStatus = "canceled"
+	// StatusEnded subscriptions were refunded and grant nothing
+	StatusEnded SubscriptionStatus = "ended"
+)
+
+// Subscription makes SupporterId a supporter of CreatorId until PeriodEnd
+type Subscription struct {
+	CreatorId    uuid.UUID          `json:"creatorId"`
+	SupporterId  uuid.UUID          `json:"supporterId"`
+	TierId       uuid.UUID          `json:"tierId"`
+	Status       SubscriptionStatus `json:"status"`
+	PeriodEnd    int64              `json:"periodEnd"`              // Unix milliseconds
+	BillingRef   string             `json:"-"`                      // Billing's subscription ID
+	SourcePostId *uuid.UUID         `json:"sourcePostId,omitempty"` // Post the supporter subscribed from
+	CreatedDate  int64              `json:"createdDate"`
+	LastUpdated  int64              `json:"lastUpdated"`
+}
+
+// GrantsAccess reports whether the subscription unlocks supporter-only posts at now (Unix milliseconds)
+func (s *Subscription) GrantsAccess(now int64) bool {
+	return (s.Status == StatusActive || s.Status == StatusCanceled) && s.PeriodEnd > now
+}
+
+// Billing event types
+const (
+	// EventPayment starts or renews a subscription until the event's periodEnd
+	EventPayment = "payment"
+	// EventCancel stops renewal; access lasts until the paid period ends
+	EventCancel = "cancel"
+	// EventRefund returns a payment and ends access immediately
+	EventRefund = "refund"
+)
+
+// BillingEvent is the body billing posts to POST /supporters/billing/events
+type BillingEvent struct {
+	EventId         string     `json:"eventId"` // Unique per event; replays are ignored
+	Type            string     `json:"type"`    // EventPayment, EventCancel or EventRefund
+	CreatorId       uuid.UUID  `json:"creatorId"`
+	SupporterId     uuid.UUID  `json:"supporterId"`
+	TierId          uuid.UUID  `json:"tierId"`
+	SubscriptionRef string     `json:"subscriptionRef,omitempty"` // Billing's subscription ID
+	AmountCents     int64      `json:"amountCents,omitempty"`     // Payments and refunds, always positive
+	Currency        string     `json:"currency,omitempty"`
+	PeriodEnd       int64      `json:"periodEnd,omitempty"`    // Payments: Unix milliseconds the paid period ends
+	SourcePostId    *uuid.UUID `json:"sourcePostId,omitempty"` // First payment: post the supporter subscribed from
+	OccurredDate    int64      `json:"occurredDate"`           // Unix milliseconds
+}
+
+// Revenue record kinds
+const (
+	RevenuePayment = "payment"
+	RevenueRefund  = "refund"
+)
+
+// RevenueRecord attributes a payment, or a refund as a negative amount, to a creator and
+// to the post the supporter subscribed from
+type RevenueRecord struct {
+	ObjectId     uuid.UUID
+	EventId      string
+	Kind         string
+	CreatorId    uuid.UUID
+	SupporterId  uuid.UUID
+	TierId       uuid.UUID
+	SourcePostId *uuid.UUID
+	AmountCents  int64
+	Currency     string
+	OccurredDate int64
+}
+
+// Amount is a sum of money in one currency
+type Amount struct {
+	Currency    string `json:"currency" db:"currency"`
+	AmountCents int64  `json:"amountCents" db:"amount_cents"`
+}
+
+// PostRevenue is the revenue credited to one post in one currency
+type PostRevenue struct {
+	PostId      uuid.UUID `json:"postId" db:"post_id"`
+	Currency    string    `json:"currency" db:"currency"`
+	AmountCents int64     `json:"amountCents" db:"amount_cents"`
+	Supporters  int       `json:"supporters" db:"supporters"` // Distinct supporters who subscribed from the post
+}
+
+// RevenueSummary is the GET /supporters/me/revenue response
+type RevenueSummary struct {
+	Days             int           `json:"days"`
+	Totals           []Amount      `json:"totals"`           // Net of refunds, per currency
+	ActiveSupporters int           `json:"activeSupporters"` // Supporters with access now, whatever the window
+	ByPost           []PostRevenue `json:"byPost"`           // Highest earning posts first
+}
@@ -0,0 +1,335 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/supporters/models"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// tierRow mirrors the supporter_tiers table
+type tierRow struct {
+	ID          uuid.UUID `db:"id"`
+	CreatorID   uuid.UUID `db:"creator_id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	PriceCents  int64     `db:"price_cents"`
+	Currency    string    `db:"currency"`
+	IsArchived  bool      `db:"is_archived"`
+	CreatedDate int64     `db:"created_date"`
+	LastUpdated int64     `db:"last_updated"`
+}
+
+// subscriptionRow mirrors the supporter_subscriptions table
+type subscriptionRow struct {
+	CreatorID    uuid.UUID     `db:"creator_id"`
+	SupporterID  uuid.UUID     `db:"supporter_id"`
+	TierID       uuid.UUID     `db:"tier_id"`
+	Status       string        `db:"status"`
+	PeriodEnd    int64         `db:"period_end"`
+	BillingRef   string        `db:"billing_ref"`
+	SourcePostID uuid.NullUUID `db:"source_post_id"`
+	CreatedDate  int64         `db:"created_date"`
+	LastUpdated  int64         `db:"last_updated"`
+}
+
+const (
+	tierColumns         = `id, creator_id, name, description, price_cents, currency, is_archived, created_date, last_updated`
+	subscriptionColumns = `creator_id, supporter_id, tier_id, status, period_end, billing_ref, source_post_id, created_date, last_updated`
+)
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) SaveTier(ctx context.Context, tier *models.Tier) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %ssupporter_tiers (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description,
+			is_archived = EXCLUDED.is_archived, last_updated = EXCLUDED.last_updated
+	`, r.schemaPrefix(), tierColumns)
+
+	_, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		tier.ObjectId, tier.CreatorId, tier.Name, tier.Description, tier.PriceCents, tier.Currency,
+		tier.Archived, tier.CreatedDate, tier.LastUpdated)
+	if err != nil {
+		return fmt.Errorf("save supporter tier: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetTier(ctx context.Context, tierID uuid.UUID) (*models.Tier, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %ssupporter_tiers WHERE id = $1`, tierColumns, r.schemaPrefix())
+
+	var row tierRow
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, tierID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get supporter tier: %w", err)
+	}
+	return row.toModel(), nil
+}
+
+func (r *postgresRepository) ListTiers(ctx context.Context, creatorID uuid.UUID) ([]*models.Tier, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %ssupporter_tiers
+		WHERE creator_id = $1 AND is_archived = FALSE
+		ORDER BY price_cents ASC, created_date ASC
+	`, tierColumns, r.schemaPrefix())
+
+	var rows []tierRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, creatorID); err != nil {
+		return nil, fmt.Errorf("list supporter tiers: %w", err)
+	}
+	tiers := make([]*models.Tier, len(rows))
+	for i := range rows {
+		tiers[i] = rows[i].toModel()
+	}
+	return tiers, nil
+}
+
+func (r *postgresRepository) GetSubscription(ctx context.Context, creatorID, supporterID uuid.UUID) (*models.Subscription, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %ssupporter_subscriptions
+		WHERE creator_id = $1 AND supporter_id = $2
+	`, subscriptionColumns, r.schemaPrefix())
+
+	var row subscriptionRow
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, creatorID, supporterID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get supporter subscription: %w", err)
+	}
+	return row.toModel(), nil
+}
+
+func (r *postgresRepository) SaveSubscription(ctx context.Context, sub *models.Subscription) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %ssupporter_subscriptions (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (creator_id, supporter_id) DO UPDATE
+		SET tier_id = EXCLUDED.tier_id, status = EXCLUDED.status, period_end = EXCLUDED.period_end,
+			billing_ref = EXCLUDED.billing_ref, source_post_id = EXCLUDED.source_post_id,
+			last_updated = EXCLUDED.last_updated
+	`, r.schemaPrefix(), subscriptionColumns)
+
+	var sourcePostID uuid.NullUUID
+	if sub.SourcePostId != nil {
+		sourcePostID = uuid.NullUUID{UUID: *sub.SourcePostId, Valid: true}
+	}
+	_, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		sub.CreatorId, sub.SupporterId, sub.TierId, string(sub.Status), sub.PeriodEnd, sub.BillingRef,
+		sourcePostID, sub.CreatedDate, sub.LastUpdated)
+	if err != nil {
+		return fmt.Errorf("save supporter subscription: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) ListSubscriptions(ctx context.Context, supporterID uuid.UUID, now int64) ([]*models.Subscription, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %ssupporter_subscriptions
+		WHERE supporter_id = $1 AND status IN ($2, $3) AND period_end > $4
+		ORDER BY created_date DESC
+	`, subscriptionColumns, r.schemaPrefix())
+
+	var rows []subscriptionRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query,
+		supporterID, string(models.StatusActive), string(models.StatusCanceled), now); err != nil {
+		return nil, fmt.Errorf("list supporter subscriptions: %w", err)
+	}
+	subs := make([]*models.Subscription, len(rows))
+	for i := range rows {
+		subs[i] = rows[i].toModel()
+	}
+	return subs, nil
+}
+
+func (r *postgresRepository) SupportedCreators(ctx context.Context, supporterID uuid.UUID, creatorIDs []uuid.UUID, now int64) ([]uuid.UUID, error) {
+	if len(creatorIDs) == 0 {
+		return nil, nil
+	}
+	query := fmt.Sprintf(`
+		SELECT creator_id FROM %ssupporter_subscriptions
+		WHERE supporter_id = $1 AND creator_id = ANY($2::uuid[])
+			AND status IN ($3, $4) AND period_end > $5
+	`, r.schemaPrefix())
+
+	ids := make(pq.StringArray, len(creatorIDs))
+	for i, id := range creatorIDs {
+		ids[i] = id.String()
+	}
+	var supported []uuid.UUID
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &supported, query,
+		supporterID, ids, string(models.StatusActive), string(models.StatusCanceled), now); err != nil {
+		return nil, fmt.Errorf("check supported creators: %w", err)
+	}
+	return supported, nil
+}
+
+func (r *postgresRepository) RecordRevenue(ctx context.Context, record *models.RevenueRecord) (bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %ssupporter_revenue (id, event_id, kind, creator_id, supporter_id, tier_id,
+			source_post_id, amount_cents, currency, occurred_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (event_id) DO NOTHING
+	`, r.schemaPrefix())
+
+	var sourcePostID uuid.NullUUID
+	if record.SourcePostId != nil {
+		sourcePostID = uuid.NullUUID{UUID: *record.SourcePostId, Valid: true}
+	}
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		record.ObjectId, record.EventId, record.Kind, record.CreatorId, record.SupporterId, record.TierId,
+		sourcePostID, record.AmountCents, record.Currency, record.OccurredDate)
+	if err != nil {
+		return false, fmt.Errorf("record supporter revenue: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) RevenueSummary(ctx context.Context, creatorID uuid.UUID, since, now int64, postLimit int) (*models.RevenueSummary, error) {
+	summary := &models.RevenueSummary{Totals: []models.Amount{}, ByPost: []models.PostRevenue{}}
+	exec := r.getExecutor(ctx)
+
+	totalsQuery := fmt.Sprintf(`
+		SELECT currency, SUM(amount_cents) AS amount_cents
+		FROM %ssupporter_revenue
+		WHERE creator_id = $1 AND occurred_date >= $2
+		GROUP BY currency
+		ORDER BY currency
+	`, r.schemaPrefix())
+	if err := sqlx.SelectContext(ctx, exec, &summary.Totals, totalsQuery, creatorID, since); err != nil {
+		return nil, fmt.Errorf("sum supporter revenue: %w", err)
+	}
+
+	byPostQuery := fmt.Sprintf(`
+		SELECT source_post_id AS post_id, currency, SUM(amount_cents) AS amount_cents,
+			COUNT(DISTINCT supporter_id) AS supporters
+		FROM %ssupporter_revenue
+		WHERE creator_id = $1 AND occurred_date >= $2 AND source_post_id IS NOT NULL
+		GROUP BY source_post_id, currency
+		ORDER BY amount_cents DESC, post_id
+		LIMIT $3
+	`, r.schemaPrefix())
+	if err := sqlx.SelectContext(ctx, exec, &summary.ByPost, byPostQuery, creatorID, since, postLimit); err != nil {
+		return nil, fmt.Errorf("attribute supporter revenue: %w", err)
+	}
+
+	supportersQuery := fmt.Sprintf(`
+		SELECT COUNT(*) FROM %ssupporter_subscriptions
+		WHERE creator_id = $1 AND status IN ($2, $3) AND period_end > $4
+	`, r.schemaPrefix())
+	if err := sqlx.GetContext(ctx, exec, &summary.ActiveSupporters, supportersQuery,
+		creatorID, string(models.StatusActive), string(models.StatusCanceled), now); err != nil {
+		return nil, fmt.Errorf("count supporters: %w", err)
+	}
+	return summary, nil
+}
+
+func (r *postgresRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	queries := []string{
+		`DELETE FROM %ssupporter_subscriptions WHERE creator_id = $1 OR supporter_id = $1`,
+		`DELETE FROM %ssupporter_revenue WHERE creator_id = $1 OR supporter_id = $1`,
+		`DELETE FROM %ssupporter_tiers WHERE creator_id = $1`,
+	}
+	for _, query := range queries {
+		if _, err := r.getExecutor(ctx).ExecContext(ctx, fmt.Sprintf(query, r.schemaPrefix()), userID); err != nil {
+			return fmt.Errorf("delete supporters of user: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
+
+func (row *tierRow) toModel() *models.Tier {
+	return &models.Tier{
+		ObjectId:    row.ID,
+		CreatorId:   row.CreatorID,
+		Name:        row.Name,
+		Description: row.Description,
+		PriceCents:  row.PriceCents,
+		Currency:    row.Currency,
+		Archived:    row.IsArchived,
+		CreatedDate: row.CreatedDate,
+		LastUpdated: row.LastUpdated,
+	}
+}
+
+func (row *subscriptionRow) toModel() *models.Subscription {
+	sub := &models.Subscription{
+		CreatorId:   row.CreatorID,
+		SupporterId: row.SupporterID,
+		TierId:      row.TierID,
+		Status:      models.SubscriptionStatus(row.Status),
+		PeriodEnd:   row.PeriodEnd,
+		BillingRef:  row.BillingRef,
+		CreatedDate: row.CreatedDate,
+		LastUpdated: row.LastUpdated,
+	}
+	if row.SourcePostID.Valid {
+		id := row.SourcePostID.UUID
+		sub.SourcePostId = &id
+	}
+	return sub
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/supporters/models"
+)
+
+// ErrNotFound is returned when no tier or subscription matches
+var ErrNotFound = errors.New("not found")
+
+// Repository defines data access for supporter tiers, subscriptions and revenue.
+type Repository interface {
+	// SaveTier inserts or replaces a tier.
+	SaveTier(ctx context.Context, tier *models.Tier) error
+
+	// GetTier returns a tier by ID, archived or not, or ErrNotFound.
+	GetTier(ctx context.Context, tierID uuid.UUID) (*models.Tier, error)
+
+	// ListTiers returns the creator's tiers that are not archived, cheapest first.
+	ListTiers(ctx context.Context, creatorID uuid.UUID) ([]*models.Tier, error)
+
+	// GetSubscription returns the subscription of supporterID to creatorID or ErrNotFound.
+	GetSubscription(ctx context.Context, creatorID, supporterID uuid.UUID) (*models.Subscription, error)
+
+	// SaveSubscription inserts or replaces the subscription of a supporter to a creator.
+	SaveSubscription(ctx context.Context, sub *models.Subscription) error
+
+	// ListSubscriptions returns supporterID's subscriptions that grant access at now, newest first.
+	ListSubscriptions(ctx context.Context, supporterID uuid.UUID, now int64) ([]*models.Subscription, error)
+
+	// SupportedCreators returns which of creatorIDs supporterID has access to at now.
+	SupportedCreators(ctx context.Context, supporterID uuid.UUID, creatorIDs []uuid.UUID, now int64) ([]uuid.UUID, error)
+
+	// RecordRevenue stores a revenue record; returns false when its event was recorded before.
+	RecordRevenue(ctx context.Context, record *models.RevenueRecord) (bool, error)
+
+	// RevenueSummary sums creatorID's revenue since the given time and counts supporters with access at now.
+	RevenueSummary(ctx context.Context, creatorID uuid.UUID, since, now int64, postLimit int) (*models.RevenueSummary, error)
+
+	// WithTransaction runs fn in a transaction, joining the caller's when there is one.
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+
+	// DeleteByUser deletes userID's tiers and the subscriptions and revenue userID paid or received.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+}
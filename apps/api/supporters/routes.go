@@ -0,0 +1,47 @@
+package supporters
+
+import (
+	"github.com/gofiber/fiber/v2"
+	authhmac "github.com/qolzam/telar/apps/api/internal/middleware/authhmac"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/supporters/handlers"
+)
+
+type Handlers struct {
+	SupporterHandler *handlers.SupporterHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires supporter tier, subscription and revenue endpoints, and the
+// endpoint billing reports events to.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/supporters")
+
+	// Service-to-service route with HMAC auth
+	group.Post("/billing/events", authhmac.New(authhmac.Config{PayloadSecret: routerCfg.PayloadSecret}), handlers.SupporterHandler.BillingEvent)
+
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+	group.Post("/tiers", dualAuthMiddleware, handlers.SupporterHandler.CreateTier)
+	group.Put("/tiers/:tierId", dualAuthMiddleware, handlers.SupporterHandler.UpdateTier)
+	group.Delete("/tiers/:tierId", dualAuthMiddleware, handlers.SupporterHandler.ArchiveTier)
+	group.Get("/creators/:creatorId/tiers", dualAuthMiddleware, handlers.SupporterHandler.CreatorTiers)
+	group.Get("/me/supporting", dualAuthMiddleware, handlers.SupporterHandler.Supporting)
+	group.Get("/me/revenue", dualAuthMiddleware, handlers.SupporterHandler.Revenue)
+}
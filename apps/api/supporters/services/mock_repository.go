@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/supporters/models"
+	"github.com/qolzam/telar/apps/api/supporters/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the supporters repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) SaveTier(ctx context.Context, tier *models.Tier) error {
+	args := m.Called(ctx, tier)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetTier(ctx context.Context, tierID uuid.UUID) (*models.Tier, error) {
+	args := m.Called(ctx, tierID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tier), args.Error(1)
+}
+
+func (m *MockRepository) ListTiers(ctx context.Context, creatorID uuid.UUID) ([]*models.Tier, error) {
+	args := m.Called(ctx, creatorID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Tier), args.Error(1)
+}
+
+func (m *MockRepository) GetSubscription(ctx context.Context, creatorID, supporterID uuid.UUID) (*models.Subscription, error) {
+	args := m.Called(ctx, creatorID, supporterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Subscription), args.Error(1)
+}
+
+func (m *MockRepository) SaveSubscription(ctx context.Context, sub *models.Subscription) error {
+	args := m.Called(ctx, sub)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ListSubscriptions(ctx context.Context, supporterID uuid.UUID, now int64) ([]*models.Subscription, error) {
+	args := m.Called(ctx, supporterID, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.Subscription), args.Error(1)
+}
+
+func (m *MockRepository) SupportedCreators(ctx context.Context, supporterID uuid.UUID, creatorIDs []uuid.UUID, now int64) ([]uuid.UUID, error) {
+	args := m.Called(ctx, supporterID, creatorIDs, now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockRepository) RecordRevenue(ctx context.Context, record *models.RevenueRecord) (bool, error) {
+	args := m.Called(ctx, record)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) RevenueSummary(ctx context.Context, creatorID uuid.UUID, since, now int64, postLimit int) (*models.RevenueSummary, error) {
+	args := m.Called(ctx, creatorID, since, now, postLimit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RevenueSummary), args.Error(1)
+}
+
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
+	supporterErrors "github.com/qolzam/telar/apps/api/supporters/errors"
+	"github.com/qolzam/telar/apps/api/supporters/models"
+	"github.com/qolzam/telar/apps/api/supporters/repository"
+)
+
+const (
+	maxTierNameLength        = 80
+	maxTierDescriptionLength = 1000
+	defaultRevenueDays       = 30
+	maxRevenueDays           = 365
+	revenuePostLimit         = 20
+	maxBillingIDLength       = 128
+)
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Service defines supporter tier, subscription and revenue operations.
+type Service interface {
+	// CreateTier adds a tier to the creator's offer.
+	CreateTier(ctx context.Context, creatorID uuid.UUID, req *models.CreateTierRequest) (*models.Tier, error)
+
+	// UpdateTier renames or redescribes one of the creator's tiers.
+	UpdateTier(ctx context.Context, creatorID, tierID uuid.UUID, req *models.UpdateTierRequest) (*models.Tier, error)
+
+	// ArchiveTier closes a tier to new supporters; existing subscriptions keep renewing.
+	ArchiveTier(ctx context.Context, creatorID, tierID uuid.UUID) error
+
+	// ListTiers returns the tiers the creator offers, cheapest first.
+	ListTiers(ctx context.Context, creatorID uuid.UUID) ([]*models.Tier, error)
+
+	// ListSupporting returns the subscriptions that currently give supporterID access.
+	ListSupporting(ctx context.Context, supporterID uuid.UUID) ([]*models.Subscription, error)
+
+	// RevenueSummary returns the creator's revenue over the last days, attributed to posts.
+	RevenueSummary(ctx context.Context, creatorID uuid.UUID, days int) (*models.RevenueSummary, error)
+
+	// HandleBillingEvent applies a payment, cancellation or refund reported by billing.
+	// Replayed payments and refunds are ignored.
+	HandleBillingEvent(ctx context.Context, event *models.BillingEvent) error
+
+	sharedInterfaces.SupporterChecker
+}
+
+type service struct {
+	repo repository.Repository
+	cfg  platformconfig.SupportersConfig
+	now  func() time.Time
+}
+
+// NewService constructs a supporters service.
+func NewService(repo repository.Repository, cfg platformconfig.SupportersConfig) Service {
+	return &service{repo: repo, cfg: cfg, now: time.Now}
+}
+
+func (s *service) CreateTier(ctx context.Context, creatorID uuid.UUID, req *models.CreateTierRequest) (*models.Tier, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", supporterErrors.ErrInvalidRequest)
+	}
+	name := strings.TrimSpace(req.Name)
+	if err := validateTierText(name, req.Description); err != nil {
+		return nil, err
+	}
+	if req.PriceCents <= 0 {
+		return nil, fmt.Errorf("%w: priceCents must be positive", supporterErrors.ErrInvalidRequest)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if !currencyPattern.MatchString(currency) {
+		return nil, fmt.Errorf("%w: currency must be a three-letter ISO 4217 code", supporterErrors.ErrInvalidRequest)
+	}
+
+	existing, err := s.repo.ListTiers(ctx, creatorID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	if s.cfg.MaxTiers > 0 && len(existing) >= s.cfg.MaxTiers {
+		return nil, fmt.Errorf("%w: at most %d tiers", supporterErrors.ErrTooManyTiers, s.cfg.MaxTiers)
+	}
+
+	now := s.now().UTC().UnixMilli()
+	tier := &models.Tier{
+		ObjectId:    uuid.Must(uuid.NewV4()),
+		CreatorId:   creatorID,
+		Name:        name,
+		Description: strings.TrimSpace(req.Description),
+		PriceCents:  req.PriceCents,
+		Currency:    currency,
+		CreatedDate: now,
+		LastUpdated: now,
+	}
+	if err := s.repo.SaveTier(ctx, tier); err != nil {
+		return nil, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	return tier, nil
+}
+
+func (s *service) UpdateTier(ctx context.Context, creatorID, tierID uuid.UUID, req *models.UpdateTierRequest) (*models.Tier, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", supporterErrors.ErrInvalidRequest)
+	}
+	tier, err := s.ownedTier(ctx, creatorID, tierID)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != nil {
+		tier.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		tier.Description = strings.TrimSpace(*req.Description)
+	}
+	if err := validateTierText(tier.Name, tier.Description); err != nil {
+		return nil, err
+	}
+	tier.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.SaveTier(ctx, tier); err != nil {
+		return nil, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	return tier, nil
+}
+
+func (s *service) ArchiveTier(ctx context.Context, creatorID, tierID uuid.UUID) error {
+	tier, err := s.ownedTier(ctx, creatorID, tierID)
+	if err != nil {
+		return err
+	}
+	if tier.Archived {
+		return nil
+	}
+	tier.Archived = true
+	tier.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.SaveTier(ctx, tier); err != nil {
+		return fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+func (s *service) ListTiers(ctx context.Context, creatorID uuid.UUID) ([]*models.Tier, error) {
+	if creatorID == uuid.Nil {
+		return nil, fmt.Errorf("%w: creatorId is required", supporterErrors.ErrInvalidRequest)
+	}
+	tiers, err := s.repo.ListTiers(ctx, creatorID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	return tiers, nil
+}
+
+func (s *service) ListSupporting(ctx context.Context, supporterID uuid.UUID) ([]*models.Subscription, error) {
+	subs, err := s.repo.ListSubscriptions(ctx, supporterID, s.now().UTC().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	return subs, nil
+}
+
+func (s *service) RevenueSummary(ctx context.Context, creatorID uuid.UUID, days int) (*models.RevenueSummary, error) {
+	if days == 0 {
+		days = defaultRevenueDays
+	}
+	if days < 1 || days > maxRevenueDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", supporterErrors.ErrInvalidRequest, maxRevenueDays)
+	}
+	now := s.now().UTC()
+	summary, err := s.repo.RevenueSummary(ctx, creatorID, now.AddDate(0, 0, -days).UnixMilli(), now.UnixMilli(), revenuePostLimit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	summary.Days = days
+	return summary, nil
+}
+
+func (s *service) HandleBillingEvent(ctx context.Context, event *models.BillingEvent) error {
+	if err := validateBillingEvent(event); err != nil {
+		return err
+	}
+	return s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		sub, err := s.repo.GetSubscription(txCtx, event.CreatorId, event.SupporterId)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			sub = nil
+		}
+
+		switch event.Type {
+		case models.EventPayment:
+			return s.applyPayment(txCtx, event, sub)
+		case models.EventCancel:
+			return s.applyCancel(txCtx, event, sub)
+		default:
+			return s.applyRefund(txCtx, event, sub)
+		}
+	})
+}
+
+// applyPayment credits the payment to the post the subscription started from, then
+// extends access. The tier may be archived: archiving only closes it to new supporters.
+func (s *service) applyPayment(ctx context.Context, event *models.BillingEvent, sub *models.Subscription) error {
+	tier, err := s.repo.GetTier(ctx, event.TierId)
+	if errors.Is(err, repository.ErrNotFound) || (err == nil && tier.CreatorId != event.CreatorId) {
+		return supporterErrors.ErrTierNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	if tier.Archived && (sub == nil || sub.TierId != tier.ObjectId) {
+		return fmt.Errorf("%w: tier is closed to new supporters", supporterErrors.ErrInvalidRequest)
+	}
+
+	sourcePostID := event.SourcePostId
+	if sub != nil {
+		sourcePostID = sub.SourcePostId
+	}
+	created, err := s.repo.RecordRevenue(ctx, s.revenueRecord(event, models.RevenuePayment, event.AmountCents, sourcePostID))
+	if err != nil {
+		return fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	if !created {
+		return nil
+	}
+
+	now := s.now().UTC().UnixMilli()
+	if sub == nil {
+		sub = &models.Subscription{
+			CreatorId:    event.CreatorId,
+			SupporterId:  event.SupporterId,
+			SourcePostId: sourcePostID,
+			CreatedDate:  now,
+		}
+	}
+	sub.TierId = tier.ObjectId
+	sub.Status = models.StatusActive
+	if event.PeriodEnd > sub.PeriodEnd {
+		sub.PeriodEnd = event.PeriodEnd
+	}
+	if event.SubscriptionRef != "" {
+		sub.BillingRef = event.SubscriptionRef
+	}
+	sub.LastUpdated = now
+	return s.saveSubscription(ctx, sub)
+}
+
+// applyCancel stops renewal; the supporter keeps access for the period they paid for
+func (s *service) applyCancel(ctx context.Context, event *models.BillingEvent, sub *models.Subscription) error {
+	if sub == nil || sub.Status != models.StatusActive {
+		return nil
+	}
+	sub.Status = models.StatusCanceled
+	sub.LastUpdated = s.now().UTC().UnixMilli()
+	return s.saveSubscription(ctx, sub)
+}
+
+// applyRefund debits the refunded amount and ends access at once
+func (s *service) applyRefund(ctx context.Context, event *models.BillingEvent, sub *models.Subscription) error {
+	var sourcePostID *uuid.UUID
+	if sub != nil {
+		sourcePostID = sub.SourcePostId
+	}
+	created, err := s.repo.RecordRevenue(ctx, s.revenueRecord(event, models.RevenueRefund, -event.AmountCents, sourcePostID))
+	if err != nil {
+		return fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	if !created || sub == nil {
+		return nil
+	}
+	sub.Status = models.StatusEnded
+	if event.OccurredDate < sub.PeriodEnd {
+		sub.PeriodEnd = event.OccurredDate
+	}
+	sub.LastUpdated = s.now().UTC().UnixMilli()
+	return s.saveSubscription(ctx, sub)
+}
+
+func (s *service) saveSubscription(ctx context.Context, sub *models.Subscription) error {
+	if err := s.repo.SaveSubscription(ctx, sub); err != nil {
+		return fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+func (s *service) revenueRecord(event *models.BillingEvent, kind string, amountCents int64, sourcePostID *uuid.UUID) *models.RevenueRecord {
+	return &models.RevenueRecord{
+		ObjectId:     uuid.Must(uuid.NewV4()),
+		EventId:      event.EventId,
+		Kind:         kind,
+		CreatorId:    event.CreatorId,
+		SupporterId:  event.SupporterId,
+		TierId:       event.TierId,
+		SourcePostId: sourcePostID,
+		AmountCents:  amountCents,
+		Currency:     strings.ToUpper(event.Currency),
+		OccurredDate: event.OccurredDate,
+	}
+}
+
+func (s *service) SupportedCreators(ctx context.Context, supporterID uuid.UUID, creatorIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	supported := make(map[uuid.UUID]bool)
+	if supporterID == uuid.Nil || len(creatorIDs) == 0 {
+		return supported, nil
+	}
+	ids, err := s.repo.SupportedCreators(ctx, supporterID, creatorIDs, s.now().UTC().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	for _, id := range ids {
+		supported[id] = true
+	}
+	return supported, nil
+}
+
+func (s *service) OffersTiers(ctx context.Context, creatorID uuid.UUID) (bool, error) {
+	tiers, err := s.repo.ListTiers(ctx, creatorID)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	return len(tiers) > 0, nil
+}
+
+// ownedTier returns tierID when it belongs to creatorID; other creators' tiers are reported missing
+func (s *service) ownedTier(ctx context.Context, creatorID, tierID uuid.UUID) (*models.Tier, error) {
+	tier, err := s.repo.GetTier(ctx, tierID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, supporterErrors.ErrTierNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", supporterErrors.ErrDatabaseOperation, err)
+	}
+	if tier.CreatorId != creatorID {
+		return nil, supporterErrors.ErrTierNotFound
+	}
+	return tier, nil
+}
+
+func validateTierText(name, description string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name is required", supporterErrors.ErrInvalidRequest)
+	}
+	if utf8.RuneCountInString(name) > maxTierNameLength {
+		return fmt.Errorf("%w: name must be at most %d characters", supporterErrors.ErrInvalidRequest, maxTierNameLength)
+	}
+	if utf8.RuneCountInString(description) > maxTierDescriptionLength {
+		return fmt.Errorf("%w: description must be at most %d characters", supporterErrors.ErrInvalidRequest, maxTierDescriptionLength)
+	}
+	return nil
+}
+
+func validateBillingEvent(event *models.BillingEvent) error {
+	if event == nil {
+		return fmt.Errorf("%w: request body is required", supporterErrors.ErrInvalidRequest)
+	}
+	if strings.TrimSpace(event.EventId) == "" || len(event.EventId) > maxBillingIDLength {
+		return fmt.Errorf("%w: eventId is required and at most %d bytes", supporterErrors.ErrInvalidRequest, maxBillingIDLength)
+	}
+	if len(event.SubscriptionRef) > maxBillingIDLength {
+		return fmt.Errorf("%w: subscriptionRef must be at most %d bytes", supporterErrors.ErrInvalidRequest, maxBillingIDLength)
+	}
+	if event.CreatorId == uuid.Nil || event.SupporterId == uuid.Nil {
+		return fmt.Errorf("%w: creatorId and supporterId are required", supporterErrors.ErrInvalidRequest)
+	}
+	if event.OccurredDate <= 0 {
+		return fmt.Errorf("%w: occurredDate is required", supporterErrors.ErrInvalidRequest)
+	}
+	switch event.Type {
+	case models.EventPayment:
+		if event.PeriodEnd <= event.OccurredDate {
+			return fmt.Errorf("%w: periodEnd must be after occurredDate", supporterErrors.ErrInvalidRequest)
+		}
+		fallthrough
+	case models.EventRefund:
+		if event.TierId == uuid.Nil {
+			return fmt.Errorf("%w: tierId is required", supporterErrors.ErrInvalidRequest)
+		}
+		if event.AmountCents <= 0 {
+			return fmt.Errorf("%w: amountCents must be positive", supporterErrors.ErrInvalidRequest)
+		}
+		if !currencyPattern.MatchString(strings.ToUpper(event.Currency)) {
+			return fmt.Errorf("%w: currency must be a three-letter ISO 4217 code", supporterErrors.ErrInvalidRequest)
+		}
+	case models.EventCancel:
+	default:
+		return fmt.Errorf("%w: unknown event type %q", supporterErrors.ErrInvalidRequest, event.Type)
+	}
+	return nil
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	supporterErrors "github.com/qolzam/telar/apps/api/supporters/errors"
+	"github.com/qolzam/telar/apps/api/supporters/models"
+	"github.com/qolzam/telar/apps/api/supporters/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = platformconfig.SupportersConfig{Enabled: true, TeaserLength: 280, MaxTiers: 2}
+
+func newTestService(repo *MockRepository, now time.Time) *service {
+	svc := NewService(repo, testConfig).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestCreateTier(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	creatorID := uuid.Must(uuid.NewV4())
+
+	t.Run("normalizes and saves", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListTiers", ctx, creatorID).Return([]*models.Tier{}, nil).Once()
+		repo.On("SaveTier", ctx, mock.AnythingOfType("*models.Tier")).Return(nil).Once()
+
+		tier, err := newTestService(repo, now).CreateTier(ctx, creatorID, &models.CreateTierRequest{
+			Name: " Backstage ", PriceCents: 500, Currency: "eur",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Backstage", tier.Name)
+		assert.Equal(t, "EUR", tier.Currency)
+		assert.Equal(t, creatorID, tier.CreatorId)
+		assert.Equal(t, now.UnixMilli(), tier.CreatedDate)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid prices and currencies", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), now)
+		_, err := svc.CreateTier(ctx, creatorID, &models.CreateTierRequest{Name: "Free", PriceCents: 0, Currency: "USD"})
+		assert.ErrorIs(t, err, supporterErrors.ErrInvalidRequest)
+		_, err = svc.CreateTier(ctx, creatorID, &models.CreateTierRequest{Name: "Gold", PriceCents: 100, Currency: "dollars"})
+		assert.ErrorIs(t, err, supporterErrors.ErrInvalidRequest)
+	})
+
+	t.Run("enforces the tier limit", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ListTiers", ctx, creatorID).Return([]*models.Tier{{}, {}}, nil).Once()
+
+		_, err := newTestService(repo, now).CreateTier(ctx, creatorID, &models.CreateTierRequest{Name: "Third", PriceCents: 100, Currency: "USD"})
+		assert.ErrorIs(t, err, supporterErrors.ErrTooManyTiers)
+		repo.AssertNotCalled(t, "SaveTier", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateTier_OtherCreatorsTierIsNotFound(t *testing.T) {
+	ctx := context.Background()
+	tier := &models.Tier{ObjectId: uuid.Must(uuid.NewV4()), CreatorId: uuid.Must(uuid.NewV4()), Name: "Gold"}
+	repo := new(MockRepository)
+	repo.On("GetTier", ctx, tier.ObjectId).Return(tier, nil).Once()
+
+	name := "Mine now"
+	_, err := newTestService(repo, time.Now()).UpdateTier(ctx, uuid.Must(uuid.NewV4()), tier.ObjectId, &models.UpdateTierRequest{Name: &name})
+	assert.ErrorIs(t, err, supporterErrors.ErrTierNotFound)
+	repo.AssertNotCalled(t, "SaveTier", mock.Anything, mock.Anything)
+}
+
+func TestHandleBillingEvent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	creatorID := uuid.Must(uuid.NewV4())
+	supporterID := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+	tier := &models.Tier{ObjectId: uuid.Must(uuid.NewV4()), CreatorId: creatorID, PriceCents: 500, Currency: "USD"}
+	periodEnd := now.AddDate(0, 1, 0).UnixMilli()
+
+	payment := func(eventID string) *models.BillingEvent {
+		return &models.BillingEvent{
+			EventId: eventID, Type: models.EventPayment, CreatorId: creatorID, SupporterId: supporterID,
+			TierId: tier.ObjectId, AmountCents: 500, Currency: "usd", PeriodEnd: periodEnd,
+			SourcePostId: &postID, OccurredDate: now.UnixMilli(),
+		}
+	}
+
+	t.Run("first payment attributes revenue to the source post and grants access", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetSubscription", ctx, creatorID, supporterID).Return(nil, repository.ErrNotFound).Once()
+		repo.On("GetTier", ctx, tier.ObjectId).Return(tier, nil).Once()
+		var record *models.RevenueRecord
+		repo.On("RecordRevenue", ctx, mock.AnythingOfType("*models.RevenueRecord")).Return(true, nil).Once().
+			Run(func(args mock.Arguments) { record = args.Get(1).(*models.RevenueRecord) })
+		var saved *models.Subscription
+		repo.On("SaveSubscription", ctx, mock.AnythingOfType("*models.Subscription")).Return(nil).Once().
+			Run(func(args mock.Arguments) { saved = args.Get(1).(*models.Subscription) })
+
+		require.NoError(t, newTestService(repo, now).HandleBillingEvent(ctx, payment("evt_1")))
+		repo.AssertExpectations(t)
+
+		assert.Equal(t, int64(500), record.AmountCents)
+		assert.Equal(t, "USD", record.Currency)
+		assert.Equal(t, &postID, record.SourcePostId)
+		assert.Equal(t, models.StatusActive, saved.Status)
+		assert.Equal(t, periodEnd, saved.PeriodEnd)
+		assert.True(t, saved.GrantsAccess(now.UnixMilli()))
+	})
+
+	t.Run("replayed payments change nothing", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetSubscription", ctx, creatorID, supporterID).Return(nil, repository.ErrNotFound).Once()
+		repo.On("GetTier", ctx, tier.ObjectId).Return(tier, nil).Once()
+		repo.On("RecordRevenue", ctx, mock.Anything).Return(false, nil).Once()
+
+		require.NoError(t, newTestService(repo, now).HandleBillingEvent(ctx, payment("evt_1")))
+		repo.AssertNotCalled(t, "SaveSubscription", mock.Anything, mock.Anything)
+	})
+
+	t.Run("renewals keep the original attribution", func(t *testing.T) {
+		firstPost := uuid.Must(uuid.NewV4())
+		existing := &models.Subscription{CreatorId: creatorID, SupporterId: supporterID, TierId: tier.ObjectId,
+			Status: models.StatusCanceled, PeriodEnd: now.UnixMilli(), SourcePostId: &firstPost}
+		repo := new(MockRepository)
+		repo.On("GetSubscription", ctx, creatorID, supporterID).Return(existing, nil).Once()
+		repo.On("GetTier", ctx, tier.ObjectId).Return(tier, nil).Once()
+		repo.On("RecordRevenue", ctx, mock.MatchedBy(func(r *models.RevenueRecord) bool {
+			return *r.SourcePostId == firstPost
+		})).Return(true, nil).Once()
+		repo.On("SaveSubscription", ctx, existing).Return(nil).Once()
+
+		require.NoError(t, newTestService(repo, now).HandleBillingEvent(ctx, payment("evt_2")))
+		repo.AssertExpectations(t)
+		assert.Equal(t, models.StatusActive, existing.Status)
+		assert.Equal(t, periodEnd, existing.PeriodEnd)
+	})
+
+	t.Run("new supporters cannot join archived tiers", func(t *testing.T) {
+		archived := *tier
+		archived.Archived = true
+		repo := new(MockRepository)
+		repo.On("GetSubscription", ctx, creatorID, supporterID).Return(nil, repository.ErrNotFound).Once()
+		repo.On("GetTier", ctx, tier.ObjectId).Return(&archived, nil).Once()
+
+		err := newTestService(repo, now).HandleBillingEvent(ctx, payment("evt_3"))
+		assert.ErrorIs(t, err, supporterErrors.ErrInvalidRequest)
+		repo.AssertNotCalled(t, "RecordRevenue", mock.Anything, mock.Anything)
+	})
+
+	t.Run("cancellations keep access until the period ends", func(t *testing.T) {
+		existing := &models.Subscription{CreatorId: creatorID, SupporterId: supporterID, TierId: tier.ObjectId,
+			Status: models.StatusActive, PeriodEnd: periodEnd}
+		repo := new(MockRepository)
+		repo.On("GetSubscription", ctx, creatorID, supporterID).Return(existing, nil).Once()
+		repo.On("SaveSubscription", ctx, existing).Return(nil).Once()
+
+		require.NoError(t, newTestService(repo, now).HandleBillingEvent(ctx, &models.BillingEvent{
+			EventId: "evt_4", Type: models.EventCancel, CreatorId: creatorID, SupporterId: supporterID, OccurredDate: now.UnixMilli(),
+		}))
+		assert.Equal(t, models.StatusCanceled, existing.Status)
+		assert.True(t, existing.GrantsAccess(now.UnixMilli()))
+	})
+
+	t.Run("refunds debit revenue and end access", func(t *testing.T) {
+		existing := &models.Subscription{CreatorId: creatorID, SupporterId: supporterID, TierId: tier.ObjectId,
+			Status: models.StatusActive, PeriodEnd: periodEnd, SourcePostId: &postID}
+		repo := new(MockRepository)
+		repo.On("GetSubscription", ctx, creatorID, supporterID).Return(existing, nil).Once()
+		repo.On("RecordRevenue", ctx, mock.MatchedBy(func(r *models.RevenueRecord) bool {
+			return r.Kind == models.RevenueRefund && r.AmountCents == -500 && *r.SourcePostId == postID
+		})).Return(true, nil).Once()
+		repo.On("SaveSubscription", ctx, existing).Return(nil).Once()
+
+		require.NoError(t, newTestService(repo, now).HandleBillingEvent(ctx, &models.BillingEvent{
+			EventId: "evt_5", Type: models.EventRefund, CreatorId: creatorID, SupporterId: supporterID,
+			TierId: tier.ObjectId, AmountCents: 500, Currency: "USD", OccurredDate: now.UnixMilli(),
+		}))
+		repo.AssertExpectations(t)
+		assert.Equal(t, models.StatusEnded, existing.Status)
+		assert.False(t, existing.GrantsAccess(now.UnixMilli()))
+	})
+
+	t.Run("rejects malformed events", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), now)
+		bad := payment("evt_6")
+		bad.PeriodEnd = bad.OccurredDate
+		assert.ErrorIs(t, svc.HandleBillingEvent(ctx, bad), supporterErrors.ErrInvalidRequest)
+		bad = payment("")
+		assert.ErrorIs(t, svc.HandleBillingEvent(ctx, bad), supporterErrors.ErrInvalidRequest)
+		bad = payment("evt_7")
+		bad.Type = "chargeback"
+		assert.ErrorIs(t, svc.HandleBillingEvent(ctx, bad), supporterErrors.ErrInvalidRequest)
+	})
+}
+
+func TestSupportedCreators(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	supporterID := uuid.Must(uuid.NewV4())
+	supported := uuid.Must(uuid.NewV4())
+	other := uuid.Must(uuid.NewV4())
+
+	repo := new(MockRepository)
+	repo.On("SupportedCreators", ctx, supporterID, []uuid.UUID{supported, other}, now.UnixMilli()).Return([]uuid.UUID{supported}, nil).Once()
+
+	result, err := newTestService(repo, now).SupportedCreators(ctx, supporterID, []uuid.UUID{supported, other})
+	require.NoError(t, err)
+	assert.Equal(t, map[uuid.UUID]bool{supported: true}, result)
+
+	result, err = newTestService(repo, now).SupportedCreators(ctx, uuid.Nil, []uuid.UUID{supported})
+	require.NoError(t, err)
+	assert.Empty(t, result, "anonymous viewers support no one")
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,22 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/system/services"
+)
+
+type SystemHandler struct {
+	service services.Service
+}
+
+func NewSystemHandler(service services.Service) *SystemHandler {
+	return &SystemHandler{service: service}
+}
+
+// Info returns the build, enabled features, dependency versions and migration status.
+// Endpoint: GET /system/info
+func (h *SystemHandler) Info(c *fiber.Ctx) error {
+	return c.Status(http.StatusOK).JSON(h.service.Info(c.Context()))
+}
@@ -0,0 +1,46 @@
+package models
+
+// Dependency statuses
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDisabled = "disabled" // Not configured for this deployment
+)
+
+// SystemInfo is what GET /system/info reports
+type SystemInfo struct {
+	Service      ServiceInfo     `json:"service"`
+	Features     map[string]bool `json:"features"`
+	Dependencies []Dependency    `json:"dependencies"`
+	Migrations   MigrationStatus `json:"migrations"`
+}
+
+// ServiceInfo identifies the running build
+type ServiceInfo struct {
+	Name           string `json:"name"`
+	Version        string `json:"version"`
+	GitSHA         string `json:"gitSha,omitempty"`
+	GoVersion      string `json:"goVersion"`
+	DeploymentMode string `json:"deploymentMode"` // "monolith" or "microservices"
+	StartedAt      int64  `json:"startedAt"`      // Unix seconds
+}
+
+// Dependency is a connected service and the version it reports
+type Dependency struct {
+	Name    string            `json:"name"`
+	Status  string            `json:"status"` // StatusUp, StatusDown or StatusDisabled
+	Version string            `json:"version,omitempty"`
+	Details map[string]string `json:"details,omitempty"` // e.g. the model of an LLM provider
+	Error   string            `json:"error,omitempty"`
+}
+
+// MigrationStatus compares the applied schema migrations with what this build needs
+type MigrationStatus struct {
+	Known      bool   `json:"known"`      // False when schema_migrations does not exist
+	Applied    int    `json:"applied"`    // Newest applied migration
+	Required   int    `json:"required"`   // Newest migration this build depends on
+	Pending    int    `json:"pending"`    // Migrations this build needs that are not applied
+	Compatible bool   `json:"compatible"` // Whether this build can run on the schema
+	Reason     string `json:"reason,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
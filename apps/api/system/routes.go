@@ -0,0 +1,23 @@
+package system
+
+import (
+	"github.com/gofiber/fiber/v2"
+	adminmw "github.com/qolzam/telar/apps/api/internal/middleware/admin"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/system/handlers"
+)
+
+type Handlers struct {
+	SystemHandler *handlers.SystemHandler
+}
+
+// RegisterRoutes wires the admin dashboard of the running build and its dependencies.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	dualAuthMiddleware := dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	})
+
+	app.Get("/system/info", dualAuthMiddleware, adminmw.New(adminmw.Config{}), handlers.SystemHandler.Info)
+}
@@ -0,0 +1,231 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/buildinfo"
+	"github.com/qolzam/telar/apps/api/internal/database/schema"
+	"github.com/qolzam/telar/apps/api/internal/middleware/readonly"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/system/models"
+)
+
+const (
+	serviceName  = "telar-api"
+	probeTimeout = 3 * time.Second // How long one dependency may take to answer
+)
+
+// Service reports the running build and what it is connected to.
+type Service interface {
+	// Info returns the build, enabled features, the versions of connected dependencies
+	// and the migration status. A dependency that cannot be reached is reported as
+	// down, not as an error.
+	Info(ctx context.Context) *models.SystemInfo
+}
+
+// AIEngine reports the AI engine's build and connections
+type AIEngine interface {
+	SystemInfo(ctx context.Context) (*aiengine.SystemInfo, error)
+}
+
+// probe reads the version of one dependency; it may report several, like the AI
+// engine and the services behind it
+type probe func(ctx context.Context) []models.Dependency
+
+type service struct {
+	cfg        *platformconfig.Config
+	probes     []probe
+	migrations func(ctx context.Context) (*schema.Result, error)
+	startedAt  time.Time
+}
+
+// NewService reports on db, on redisClient and aiEngine when they are set (nil when
+// the deployment does not use them), and on the migrations applied to db.
+func NewService(cfg *platformconfig.Config, db sqlx.QueryerContext, redisClient redis.UniversalClient, aiEngine AIEngine) Service {
+	s := &service{
+		cfg: cfg,
+		migrations: func(ctx context.Context) (*schema.Result, error) {
+			return schema.Check(ctx, db, schema.BuiltVersion)
+		},
+		startedAt: time.Now(),
+	}
+	s.probes = []probe{postgresProbe(db), redisProbe(redisClient), aiEngineProbe(aiEngine)}
+	return s
+}
+
+func (s *service) Info(ctx context.Context) *models.SystemInfo {
+	info := &models.SystemInfo{
+		Service: models.ServiceInfo{
+			Name:           serviceName,
+			Version:        buildinfo.Version,
+			GitSHA:         buildinfo.Revision(),
+			GoVersion:      runtime.Version(),
+			DeploymentMode: deploymentMode(),
+			StartedAt:      s.startedAt.Unix(),
+		},
+		Features: features(s.cfg),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	// Dependencies are probed at once so one slow service does not hold up the rest
+	results := make([][]models.Dependency, len(s.probes))
+	var wg sync.WaitGroup
+	for i, p := range s.probes {
+		wg.Add(1)
+		go func(i int, p probe) {
+			defer wg.Done()
+			results[i] = p(ctx)
+		}(i, p)
+	}
+	wg.Wait()
+
+	info.Dependencies = []models.Dependency{}
+	for _, deps := range results {
+		info.Dependencies = append(info.Dependencies, deps...)
+	}
+	info.Migrations = migrationStatus(s.migrations(ctx))
+	return info
+}
+
+// deploymentMode mirrors how the binaries choose between direct calls and gRPC
+func deploymentMode() string {
+	if os.Getenv("DEPLOYMENT_MODE") == "microservices" {
+		return "microservices"
+	}
+	return "monolith"
+}
+
+// features lists the optional parts of the platform and whether they are on
+func features(cfg *platformconfig.Config) map[string]bool {
+	readOnly, _ := readonly.Status()
+	return map[string]bool{
+		"aiEngine":           cfg.AIEngine.URL != "",
+		"achievements":       cfg.Achievements.Enabled,
+		"analyticsEvents":    cfg.Analytics.EventsEnabled,
+		"cache":              cfg.Cache.Enabled,
+		"dataMigration":      len(cfg.DataMigration.DualWrite) > 0,
+		"duplicates":         cfg.Duplicates.Enabled,
+		"experiments":        cfg.Experiments.Enabled,
+		"grpcTls":            cfg.GRPC.TLSEnabled(),
+		"httpCache":          cfg.HTTPCache.Enabled,
+		"metrics":            cfg.Metrics.Enabled,
+		"moderationAutoFlag": cfg.Moderation.AutoFlagEnabled,
+		"outbox":             cfg.Outbox.Enabled,
+		"profileViews":       cfg.ProfileViews.Enabled,
+		"readOnly":           readOnly,
+		"realtime":           cfg.Realtime.Enabled,
+		"storage":            cfg.Storage.BucketName != "",
+		"supporters":         cfg.Supporters.Enabled,
+		"thanks":             cfg.Thanks.Enabled,
+		"tips":               cfg.Tips.Enabled,
+		"voteIntegrity":      cfg.VoteIntegrity.Enabled,
+	}
+}
+
+func postgresProbe(db sqlx.QueryerContext) probe {
+	return func(ctx context.Context) []models.Dependency {
+		dep := models.Dependency{Name: "postgres"}
+		if err := sqlx.GetContext(ctx, db, &dep.Version, `SHOW server_version`); err != nil {
+			return []models.Dependency{down(dep, err)}
+		}
+		dep.Status = models.StatusUp
+		return []models.Dependency{dep}
+	}
+}
+
+func redisProbe(client redis.UniversalClient) probe {
+	return func(ctx context.Context) []models.Dependency {
+		dep := models.Dependency{Name: "redis", Status: models.StatusDisabled}
+		if client == nil {
+			return []models.Dependency{dep}
+		}
+		server, err := client.Info(ctx, "server").Result()
+		if err != nil {
+			return []models.Dependency{down(dep, err)}
+		}
+		dep.Status = models.StatusUp
+		dep.Version = infoField(server, "redis_version")
+		return []models.Dependency{dep}
+	}
+}
+
+// aiEngineProbe reports the AI engine and, from what it returns, the vector store and
+// the LLM providers it uses
+func aiEngineProbe(client AIEngine) probe {
+	return func(ctx context.Context) []models.Dependency {
+		engine := models.Dependency{Name: "ai-engine", Status: models.StatusDisabled}
+		if client == nil {
+			return []models.Dependency{engine}
+		}
+		info, err := client.SystemInfo(ctx)
+		if err != nil {
+			return []models.Dependency{down(engine, err)}
+		}
+
+		engine.Status = models.StatusUp
+		engine.Version = info.Version
+		if info.GitSHA != "" {
+			engine.Details = map[string]string{"gitSha": info.GitSHA}
+		}
+		store := models.Dependency{
+			Name:    info.VectorStore.Name,
+			Status:  info.VectorStore.Status,
+			Version: info.VectorStore.Version,
+			Error:   info.VectorStore.Error,
+		}
+		completion := models.Dependency{
+			Name:    "llm",
+			Status:  models.StatusUp,
+			Details: map[string]string{"provider": info.Completion.Provider, "model": info.Completion.Model},
+		}
+		embedding := models.Dependency{
+			Name:    "embedding",
+			Status:  models.StatusUp,
+			Details: map[string]string{"provider": info.Embedding.Provider, "model": info.Embedding.Model},
+		}
+		return []models.Dependency{engine, store, completion, embedding}
+	}
+}
+
+func down(dep models.Dependency, err error) models.Dependency {
+	dep.Status = models.StatusDown
+	dep.Error = err.Error()
+	return dep
+}
+
+// infoField reads a field of a Redis INFO reply ("field:value" lines)
+func infoField(info, field string) string {
+	for _, line := range strings.Split(info, "\n") {
+		if value, ok := strings.CutPrefix(strings.TrimSpace(line), field+":"); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+func migrationStatus(result *schema.Result, err error) models.MigrationStatus {
+	status := models.MigrationStatus{Required: schema.BuiltVersion}
+	if err != nil {
+		status.Error = fmt.Sprintf("failed to read schema migrations: %v", err)
+		return status
+	}
+	status.Known = result.Known
+	status.Applied = result.Version
+	status.Compatible = result.Compatible
+	status.Reason = result.Reason
+	if result.Known && result.Version < status.Required {
+		status.Pending = status.Required - result.Version
+	}
+	return status
+}
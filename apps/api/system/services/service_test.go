@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/database/schema"
+	"github.com/qolzam/telar/apps/api/internal/platform/aiengine"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/system/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAIEngine struct {
+	info *aiengine.SystemInfo
+	err  error
+}
+
+func (f *fakeAIEngine) SystemInfo(ctx context.Context) (*aiengine.SystemInfo, error) {
+	return f.info, f.err
+}
+
+func TestInfo(t *testing.T) {
+	cfg := &platformconfig.Config{}
+	cfg.Outbox.Enabled = true
+	cfg.AIEngine.URL = "http://ai-engine:8000"
+
+	engine := &fakeAIEngine{info: &aiengine.SystemInfo{
+		Version:     "v1.0.0",
+		GitSHA:      "abc123",
+		Completion:  aiengine.ProviderInfo{Provider: "groq", Model: "llama-3.1-8b-instant"},
+		Embedding:   aiengine.ProviderInfo{Provider: "ollama", Model: "nomic-embed-text"},
+		VectorStore: aiengine.Dependency{Name: "weaviate", Status: "up", Version: "1.27.0"},
+	}}
+	s := &service{
+		cfg: cfg,
+		probes: []probe{
+			func(ctx context.Context) []models.Dependency {
+				return []models.Dependency{{Name: "postgres", Status: models.StatusUp, Version: "16.4"}}
+			},
+			redisProbe(nil),
+			aiEngineProbe(engine),
+		},
+		migrations: func(ctx context.Context) (*schema.Result, error) {
+			return &schema.Result{Version: schema.BuiltVersion - 2, Known: true, Reason: "older"}, nil
+		},
+		startedAt: time.Unix(1700000000, 0),
+	}
+
+	info := s.Info(context.Background())
+	assert.Equal(t, "telar-api", info.Service.Name)
+	assert.Equal(t, int64(1700000000), info.Service.StartedAt)
+	assert.True(t, info.Features["outbox"])
+	assert.True(t, info.Features["aiEngine"])
+	assert.False(t, info.Features["metrics"])
+
+	assert.Equal(t, []models.Dependency{
+		{Name: "postgres", Status: models.StatusUp, Version: "16.4"},
+		{Name: "redis", Status: models.StatusDisabled},
+		{Name: "ai-engine", Status: models.StatusUp, Version: "v1.0.0", Details: map[string]string{"gitSha": "abc123"}},
+		{Name: "weaviate", Status: "up", Version: "1.27.0"},
+		{Name: "llm", Status: models.StatusUp, Details: map[string]string{"provider": "groq", "model": "llama-3.1-8b-instant"}},
+		{Name: "embedding", Status: models.StatusUp, Details: map[string]string{"provider": "ollama", "model": "nomic-embed-text"}},
+	}, info.Dependencies)
+
+	assert.Equal(t, models.MigrationStatus{
+		Known:    true,
+		Applied:  schema.BuiltVersion - 2,
+		Required: schema.BuiltVersion,
+		Pending:  2,
+		Reason:   "older",
+	}, info.Migrations)
+}
+
+func TestAIEngineProbe_Down(t *testing.T) {
+	deps := aiEngineProbe(&fakeAIEngine{err: errors.New("connection refused")})(context.Background())
+	require.Len(t, deps, 1)
+	assert.Equal(t, models.StatusDown, deps[0].Status)
+	assert.Equal(t, "connection refused", deps[0].Error)
+
+	deps = aiEngineProbe(nil)(context.Background())
+	assert.Equal(t, []models.Dependency{{Name: "ai-engine", Status: models.StatusDisabled}}, deps)
+}
+
+func TestMigrationStatus_Unreadable(t *testing.T) {
+	status := migrationStatus(nil, errors.New("permission denied"))
+	assert.False(t, status.Compatible)
+	assert.Equal(t, schema.BuiltVersion, status.Required)
+	assert.Contains(t, status.Error, "permission denied")
+}
+
+func TestInfoField(t *testing.T) {
+	info := "# Server\r\nredis_version:7.2.4\r\nredis_git_sha1:00000000\r\n"
+	assert.Equal(t, "7.2.4", infoField(info, "redis_version"))
+	assert.Equal(t, "", infoField(info, "missing"))
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/thanks/errors"
+	"github.com/qolzam/telar/apps/api/thanks/services"
+)
+
+type ThanksHandler struct {
+	service services.Service
+}
+
+func NewThanksHandler(service services.Service) *ThanksHandler {
+	return &ThanksHandler{service: service}
+}
+
+// Thank spends one of the current user's weekly thanks on a post.
+// Endpoint: POST /thanks/posts/:postId
+func (h *ThanksHandler) Thank(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid postId")
+	}
+
+	resp, err := h.service.Thank(c.Context(), user.UserID, postID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(resp)
+}
+
+// PostThanks counts a post's thanks and whether the current user gave one.
+// Endpoint: GET /thanks/posts/:postId
+func (h *ThanksHandler) PostThanks(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid postId")
+	}
+
+	summary, err := h.service.PostThanks(c.Context(), user.UserID, postID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(summary)
+}
+
+// UserThanks counts the thanks a user received on their posts.
+// Endpoint: GET /thanks/users/:userId
+func (h *ThanksHandler) UserThanks(c *fiber.Ctx) error {
+	userID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+
+	summary, err := h.service.UserThanks(c.Context(), userID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(summary)
+}
+
+// Allowance returns what is left of the current user's thanks this week.
+// Endpoint: GET /thanks/me/allowance
+func (h *ThanksHandler) Allowance(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	allowance, err := h.service.Allowance(c.Context(), user.UserID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(allowance)
+}
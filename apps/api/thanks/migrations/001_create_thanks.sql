@@ -0,0 +1,23 @@
+-- Thanks: a money-free appreciation signal, separate from votes. Each user thanks a
+-- post at most once; post_owner_id is kept so received thanks can be counted per user.
+CREATE TABLE IF NOT EXISTS post_thanks (
+    post_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    post_owner_id UUID NOT NULL,
+    created_date BIGINT NOT NULL,
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_thanks_owner ON post_thanks(post_owner_id);
+
+-- Weekly allowance accounting, one row per user who has given thanks. used counts the
+-- thanks given in the week starting at week_start (Unix milliseconds, Monday 00:00 UTC);
+-- the reset job zeroes rows from earlier weeks.
+CREATE TABLE IF NOT EXISTS thanks_allowances (
+    user_id UUID PRIMARY KEY,
+    week_start BIGINT NOT NULL,
+    used INT NOT NULL CHECK (used >= 0),
+    updated_date BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_thanks_allowances_week ON thanks_allowances(week_start);
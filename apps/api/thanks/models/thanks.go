@@ -0,0 +1,47 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// Thanks is one user's thanks for a post
+type Thanks struct {
+	PostId      uuid.UUID `json:"postId" db:"post_id"`
+	UserId      uuid.UUID `json:"userId" db:"user_id"`
+	PostOwnerId uuid.UUID `json:"postOwnerId" db:"post_owner_id"`
+	CreatedDate int64     `json:"createdDate" db:"created_date"`
+}
+
+// AllowanceRow is a thanks_allowances row; used only counts for the week at WeekStart
+type AllowanceRow struct {
+	UserId    uuid.UUID `db:"user_id"`
+	WeekStart int64     `db:"week_start"`
+	Used      int       `db:"used"`
+}
+
+// Allowance is the GET /thanks/me/allowance response
+type Allowance struct {
+	Weekly    int   `json:"weekly"`
+	Used      int   `json:"used"`
+	Remaining int   `json:"remaining"`
+	ResetsAt  int64 `json:"resetsAt"` // Unix milliseconds; the next Monday 00:00 UTC
+}
+
+// PostThanks is the thanks summary of a post
+type PostThanks struct {
+	PostId      uuid.UUID `json:"postId"`
+	Count       int64     `json:"count"`
+	ThankedByMe bool      `json:"thankedByMe"`
+}
+
+// ThankResponse is the POST /thanks/posts/:postId response
+type ThankResponse struct {
+	Post      PostThanks `json:"post"`
+	Allowance Allowance  `json:"allowance"`
+}
+
+// UserThanks is the GET /thanks/users/:userId response
+type UserThanks struct {
+	UserId   uuid.UUID `json:"userId"`
+	Received int64     `json:"received"` // Thanks on all of the user's posts
+}
@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/thanks/models"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) InsertThanks(ctx context.Context, thanks *models.Thanks) (bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %spost_thanks (post_id, user_id, post_owner_id, created_date)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (post_id, user_id) DO NOTHING
+	`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, thanks.PostId, thanks.UserId, thanks.PostOwnerId, thanks.CreatedDate)
+	if err != nil {
+		return false, fmt.Errorf("insert thanks: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) HasThanked(ctx context.Context, postID, userID uuid.UUID) (bool, error) {
+	query := fmt.Sprintf(`SELECT EXISTS (SELECT 1 FROM %spost_thanks WHERE post_id = $1 AND user_id = $2)`, r.schemaPrefix())
+
+	var exists bool
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &exists, query, postID, userID); err != nil {
+		return false, fmt.Errorf("check thanks: %w", err)
+	}
+	return exists, nil
+}
+
+func (r *postgresRepository) CountForPost(ctx context.Context, postID uuid.UUID) (int64, error) {
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %spost_thanks WHERE post_id = $1`, r.schemaPrefix())
+
+	var count int64
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &count, query, postID); err != nil {
+		return 0, fmt.Errorf("count post thanks: %w", err)
+	}
+	return count, nil
+}
+
+func (r *postgresRepository) CountReceived(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM %spost_thanks WHERE post_owner_id = $1`, r.schemaPrefix())
+
+	var count int64
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &count, query, ownerID); err != nil {
+		return 0, fmt.Errorf("count received thanks: %w", err)
+	}
+	return count, nil
+}
+
+// ConsumeAllowance checks and spends the allowance in one statement, so concurrent
+// thanks from the same user cannot overspend it
+func (r *postgresRepository) ConsumeAllowance(ctx context.Context, userID uuid.UUID, weekStart int64, limit int, now int64) (bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %[1]sthanks_allowances AS a (user_id, week_start, used, updated_date)
+		VALUES ($1, $2, 1, $4)
+		ON CONFLICT (user_id) DO UPDATE
+		SET used = CASE WHEN a.week_start = EXCLUDED.week_start THEN a.used + 1 ELSE 1 END,
+			week_start = EXCLUDED.week_start,
+			updated_date = EXCLUDED.updated_date
+		WHERE a.week_start <> EXCLUDED.week_start OR a.used < $3
+	`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, userID, weekStart, limit, now)
+	if err != nil {
+		return false, fmt.Errorf("consume thanks allowance: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) GetAllowance(ctx context.Context, userID uuid.UUID) (*models.AllowanceRow, error) {
+	query := fmt.Sprintf(`SELECT user_id, week_start, used FROM %sthanks_allowances WHERE user_id = $1`, r.schemaPrefix())
+
+	var row models.AllowanceRow
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get thanks allowance: %w", err)
+	}
+	return &row, nil
+}
+
+func (r *postgresRepository) ResetAllowances(ctx context.Context, weekStart, now int64) (int64, error) {
+	query := fmt.Sprintf(`
+		UPDATE %sthanks_allowances
+		SET used = 0, week_start = $1, updated_date = $2
+		WHERE week_start < $1
+	`, r.schemaPrefix())
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, weekStart, now)
+	if err != nil {
+		return 0, fmt.Errorf("reset thanks allowances: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	queries := []string{
+		`DELETE FROM %spost_thanks WHERE user_id = $1 OR post_owner_id = $1`,
+		`DELETE FROM %sthanks_allowances WHERE user_id = $1`,
+	}
+	for _, query := range queries {
+		if _, err := r.getExecutor(ctx).ExecContext(ctx, fmt.Sprintf(query, r.schemaPrefix()), userID); err != nil {
+			return fmt.Errorf("delete thanks of user: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
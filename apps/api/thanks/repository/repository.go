@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/thanks/models"
+)
+
+// ErrNotFound is returned when a user has no allowance row
+var ErrNotFound = errors.New("not found")
+
+// Repository defines data access for thanks and weekly allowances.
+type Repository interface {
+	// InsertThanks stores a thanks; returns false when the user already thanked the post.
+	InsertThanks(ctx context.Context, thanks *models.Thanks) (bool, error)
+
+	// HasThanked reports whether userID thanked postID.
+	HasThanked(ctx context.Context, postID, userID uuid.UUID) (bool, error)
+
+	// CountForPost counts the thanks on postID.
+	CountForPost(ctx context.Context, postID uuid.UUID) (int64, error)
+
+	// CountReceived counts the thanks on all of ownerID's posts.
+	CountReceived(ctx context.Context, ownerID uuid.UUID) (int64, error)
+
+	// ConsumeAllowance spends one of userID's thanks for the week at weekStart, starting
+	// the count over when the row is from an earlier week. Returns false, changing
+	// nothing, when limit thanks were already given that week.
+	ConsumeAllowance(ctx context.Context, userID uuid.UUID, weekStart int64, limit int, now int64) (bool, error)
+
+	// GetAllowance returns userID's allowance row or ErrNotFound.
+	GetAllowance(ctx context.Context, userID uuid.UUID) (*models.AllowanceRow, error)
+
+	// ResetAllowances zeroes the rows from weeks before weekStart and returns how many changed.
+	ResetAllowances(ctx context.Context, weekStart, now int64) (int64, error)
+
+	// DeleteByUser deletes the thanks userID gave or received and userID's allowance.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+
+	// WithTransaction runs fn in a transaction, joining the caller's when there is one.
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
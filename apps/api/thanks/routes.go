@@ -0,0 +1,40 @@
+package thanks
+
+import (
+	"github.com/gofiber/fiber/v2"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/thanks/handlers"
+)
+
+type Handlers struct {
+	ThanksHandler *handlers.ThanksHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires thanks and weekly allowance endpoints.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/thanks")
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+
+	group.Post("/posts/:postId", dualAuthMiddleware, handlers.ThanksHandler.Thank)
+	group.Get("/posts/:postId", dualAuthMiddleware, handlers.ThanksHandler.PostThanks)
+	group.Get("/users/:userId", dualAuthMiddleware, handlers.ThanksHandler.UserThanks)
+	group.Get("/me/allowance", dualAuthMiddleware, handlers.ThanksHandler.Allowance)
+}
@@ -0,0 +1,37 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// StartJob resets allowances from earlier weeks now and then every interval until ctx
+// is done. A non-positive interval disables the job.
+func StartJob(ctx context.Context, svc Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		run := func() {
+			if reset, err := svc.ResetAllowances(ctx); err != nil {
+				log.Error("Thanks allowance reset failed: %v", err)
+			} else if reset > 0 {
+				log.Info("Reset %d weekly thanks allowances", reset)
+			}
+		}
+
+		run()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
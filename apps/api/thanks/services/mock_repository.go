@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/thanks/models"
+	"github.com/qolzam/telar/apps/api/thanks/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the thanks repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) InsertThanks(ctx context.Context, thanks *models.Thanks) (bool, error) {
+	args := m.Called(ctx, thanks)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) HasThanked(ctx context.Context, postID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, postID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) CountForPost(ctx context.Context, postID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, postID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) CountReceived(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) ConsumeAllowance(ctx context.Context, userID uuid.UUID, weekStart int64, limit int, now int64) (bool, error) {
+	args := m.Called(ctx, userID, weekStart, limit, now)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) GetAllowance(ctx context.Context, userID uuid.UUID) (*models.AllowanceRow, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.AllowanceRow), args.Error(1)
+}
+
+func (m *MockRepository) ResetAllowances(ctx context.Context, weekStart, now int64) (int64, error) {
+	args := m.Called(ctx, weekStart, now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	thanksErrors "github.com/qolzam/telar/apps/api/thanks/errors"
+	"github.com/qolzam/telar/apps/api/thanks/models"
+	"github.com/qolzam/telar/apps/api/thanks/repository"
+)
+
+// PostProvider finds thanked posts; the post service satisfies it.
+type PostProvider interface {
+	GetPost(ctx context.Context, postID uuid.UUID) (*postsModels.Post, error)
+}
+
+// Service defines thanks and weekly allowance operations.
+type Service interface {
+	// Thank spends one of userID's weekly thanks on postID.
+	Thank(ctx context.Context, userID, postID uuid.UUID) (*models.ThankResponse, error)
+
+	// PostThanks counts the thanks on postID and whether viewerID gave one.
+	PostThanks(ctx context.Context, viewerID, postID uuid.UUID) (*models.PostThanks, error)
+
+	// UserThanks counts the thanks userID received on their posts.
+	UserThanks(ctx context.Context, userID uuid.UUID) (*models.UserThanks, error)
+
+	// Allowance returns what is left of userID's thanks this week.
+	Allowance(ctx context.Context, userID uuid.UUID) (*models.Allowance, error)
+
+	// ResetAllowances starts the new week for allowances spent in earlier weeks.
+	// Returns the number of allowances reset.
+	ResetAllowances(ctx context.Context) (int64, error)
+}
+
+type service struct {
+	repo  repository.Repository
+	posts PostProvider
+	cfg   platformconfig.ThanksConfig
+	now   func() time.Time
+}
+
+// NewService constructs a thanks service.
+func NewService(repo repository.Repository, posts PostProvider, cfg platformconfig.ThanksConfig) Service {
+	return &service{repo: repo, posts: posts, cfg: cfg, now: time.Now}
+}
+
+// weekStart returns the start of the week containing t: Monday 00:00 UTC
+func weekStart(t time.Time) time.Time {
+	day := t.UTC().Truncate(24 * time.Hour)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+func (s *service) Thank(ctx context.Context, userID, postID uuid.UUID) (*models.ThankResponse, error) {
+	post, err := s.posts.GetPost(ctx, postID)
+	if errors.Is(err, postsErrors.ErrPostNotFound) || (err == nil && post.Deleted) {
+		return nil, thanksErrors.ErrPostNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", thanksErrors.ErrDatabaseOperation, err)
+	}
+	if post.OwnerUserId == userID {
+		return nil, fmt.Errorf("%w: you cannot thank your own post", thanksErrors.ErrInvalidRequest)
+	}
+	// Received counts would tell who wrote an anonymous post
+	if post.Anonymous {
+		return nil, fmt.Errorf("%w: anonymous posts cannot be thanked", thanksErrors.ErrInvalidRequest)
+	}
+	if s.cfg.WeeklyAllowance <= 0 {
+		return nil, thanksErrors.ErrAllowanceExhausted
+	}
+
+	now := s.now().UTC()
+	week := weekStart(now).UnixMilli()
+	err = s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		created, err := s.repo.InsertThanks(txCtx, &models.Thanks{
+			PostId:      postID,
+			UserId:      userID,
+			PostOwnerId: post.OwnerUserId,
+			CreatedDate: now.UnixMilli(),
+		})
+		if err != nil {
+			return fmt.Errorf("%w: %v", thanksErrors.ErrDatabaseOperation, err)
+		}
+		if !created {
+			return thanksErrors.ErrAlreadyThanked
+		}
+		spent, err := s.repo.ConsumeAllowance(txCtx, userID, week, s.cfg.WeeklyAllowance, now.UnixMilli())
+		if err != nil {
+			return fmt.Errorf("%w: %v", thanksErrors.ErrDatabaseOperation, err)
+		}
+		if !spent {
+			return thanksErrors.ErrAllowanceExhausted
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.PostThanks(ctx, userID, postID)
+	if err != nil {
+		return nil, err
+	}
+	allowance, err := s.Allowance(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &models.ThankResponse{Post: *summary, Allowance: *allowance}, nil
+}
+
+func (s *service) PostThanks(ctx context.Context, viewerID, postID uuid.UUID) (*models.PostThanks, error) {
+	count, err := s.repo.CountForPost(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", thanksErrors.ErrDatabaseOperation, err)
+	}
+	summary := &models.PostThanks{PostId: postID, Count: count}
+	if count > 0 && viewerID != uuid.Nil {
+		summary.ThankedByMe, err = s.repo.HasThanked(ctx, postID, viewerID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", thanksErrors.ErrDatabaseOperation, err)
+		}
+	}
+	return summary, nil
+}
+
+func (s *service) UserThanks(ctx context.Context, userID uuid.UUID) (*models.UserThanks, error) {
+	if userID == uuid.Nil {
+		return nil, fmt.Errorf("%w: userId is required", thanksErrors.ErrInvalidRequest)
+	}
+	received, err := s.repo.CountReceived(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", thanksErrors.ErrDatabaseOperation, err)
+	}
+	return &models.UserThanks{UserId: userID, Received: received}, nil
+}
+
+func (s *service) Allowance(ctx context.Context, userID uuid.UUID) (*models.Allowance, error) {
+	week := weekStart(s.now())
+	allowance := &models.Allowance{
+		Weekly:   s.cfg.WeeklyAllowance,
+		ResetsAt: week.AddDate(0, 0, 7).UnixMilli(),
+	}
+
+	row, err := s.repo.GetAllowance(ctx, userID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %v", thanksErrors.ErrDatabaseOperation, err)
+	}
+	// Rows from earlier weeks count as unspent even before the job resets them
+	if row != nil && row.WeekStart == week.UnixMilli() {
+		allowance.Used = row.Used
+	}
+	allowance.Remaining = allowance.Weekly - allowance.Used
+	if allowance.Remaining < 0 {
+		allowance.Remaining = 0
+	}
+	return allowance, nil
+}
+
+func (s *service) ResetAllowances(ctx context.Context) (int64, error) {
+	now := s.now().UTC()
+	reset, err := s.repo.ResetAllowances(ctx, weekStart(now).UnixMilli(), now.UnixMilli())
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", thanksErrors.ErrDatabaseOperation, err)
+	}
+	return reset, nil
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	thanksErrors "github.com/qolzam/telar/apps/api/thanks/errors"
+	"github.com/qolzam/telar/apps/api/thanks/models"
+	"github.com/qolzam/telar/apps/api/thanks/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = platformconfig.ThanksConfig{Enabled: true, WeeklyAllowance: 3}
+
+type stubPosts map[uuid.UUID]*postsModels.Post
+
+func (p stubPosts) GetPost(ctx context.Context, postID uuid.UUID) (*postsModels.Post, error) {
+	if post, ok := p[postID]; ok {
+		return post, nil
+	}
+	return nil, postsErrors.ErrPostNotFound
+}
+
+func newTestService(repo *MockRepository, posts stubPosts, now time.Time) *service {
+	svc := NewService(repo, posts, testConfig).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, weekStart(monday))
+	assert.Equal(t, monday, weekStart(time.Date(2026, 10, 18, 23, 59, 0, 0, time.UTC))) // Sunday
+	assert.Equal(t, monday.AddDate(0, 0, 7), weekStart(time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)))
+}
+
+func TestThank(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	week := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC).UnixMilli()
+	userID := uuid.Must(uuid.NewV4())
+	ownerID := uuid.Must(uuid.NewV4())
+	post := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: ownerID}
+	anonymous := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: ownerID, Anonymous: true}
+	posts := stubPosts{post.ObjectId: post, anonymous.ObjectId: anonymous}
+
+	t.Run("stores the thanks and spends one of the week's allowance", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("InsertThanks", ctx, &models.Thanks{PostId: post.ObjectId, UserId: userID, PostOwnerId: ownerID, CreatedDate: now.UnixMilli()}).
+			Return(true, nil).Once()
+		repo.On("ConsumeAllowance", ctx, userID, week, 3, now.UnixMilli()).Return(true, nil).Once()
+		repo.On("CountForPost", ctx, post.ObjectId).Return(int64(4), nil).Once()
+		repo.On("HasThanked", ctx, post.ObjectId, userID).Return(true, nil).Once()
+		repo.On("GetAllowance", ctx, userID).Return(&models.AllowanceRow{UserId: userID, WeekStart: week, Used: 1}, nil).Once()
+
+		resp, err := newTestService(repo, posts, now).Thank(ctx, userID, post.ObjectId)
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		assert.Equal(t, int64(4), resp.Post.Count)
+		assert.True(t, resp.Post.ThankedByMe)
+		assert.Equal(t, 2, resp.Allowance.Remaining)
+		assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC).UnixMilli(), resp.Allowance.ResetsAt)
+	})
+
+	t.Run("a post is thanked once per user", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("InsertThanks", ctx, mock.Anything).Return(false, nil).Once()
+
+		_, err := newTestService(repo, posts, now).Thank(ctx, userID, post.ObjectId)
+		assert.ErrorIs(t, err, thanksErrors.ErrAlreadyThanked)
+		repo.AssertNotCalled(t, "ConsumeAllowance", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails once the allowance is spent", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("InsertThanks", ctx, mock.Anything).Return(true, nil).Once()
+		repo.On("ConsumeAllowance", ctx, userID, week, 3, now.UnixMilli()).Return(false, nil).Once()
+
+		_, err := newTestService(repo, posts, now).Thank(ctx, userID, post.ObjectId)
+		assert.ErrorIs(t, err, thanksErrors.ErrAllowanceExhausted)
+	})
+
+	t.Run("rejects own, anonymous and missing posts", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), posts, now)
+		_, err := svc.Thank(ctx, ownerID, post.ObjectId)
+		assert.ErrorIs(t, err, thanksErrors.ErrInvalidRequest)
+		_, err = svc.Thank(ctx, userID, anonymous.ObjectId)
+		assert.ErrorIs(t, err, thanksErrors.ErrInvalidRequest)
+		_, err = svc.Thank(ctx, userID, uuid.Must(uuid.NewV4()))
+		assert.ErrorIs(t, err, thanksErrors.ErrPostNotFound)
+	})
+}
+
+func TestAllowance_EarlierWeeksCountAsUnspent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 20, 9, 0, 0, 0, time.UTC)
+	userID := uuid.Must(uuid.NewV4())
+	lastWeek := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC).UnixMilli()
+
+	repo := new(MockRepository)
+	repo.On("GetAllowance", ctx, userID).Return(&models.AllowanceRow{UserId: userID, WeekStart: lastWeek, Used: 3}, nil).Once()
+	allowance, err := newTestService(repo, stubPosts{}, now).Allowance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, allowance.Used)
+	assert.Equal(t, 3, allowance.Remaining)
+
+	repo = new(MockRepository)
+	repo.On("GetAllowance", ctx, userID).Return(nil, repository.ErrNotFound).Once()
+	allowance, err = newTestService(repo, stubPosts{}, now).Allowance(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, allowance.Remaining)
+}
+
+func TestResetAllowances_UsesCurrentWeek(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 0, 30, 0, 0, time.UTC)
+	repo := new(MockRepository)
+	repo.On("ResetAllowances", ctx, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC).UnixMilli(), now.UnixMilli()).Return(int64(12), nil).Once()
+
+	reset, err := newTestService(repo, stubPosts{}, now).ResetAllowances(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(12), reset)
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrTipNotFound        = errors.New("tip not found")
+	ErrRecipientNotFound  = errors.New("tip recipient not found")
+	ErrInvalidRequest     = errors.New("invalid request")
+	ErrInvalidAmount      = errors.New("invalid tip amount")
+	ErrTipState           = errors.New("tip is not in a state that allows this")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrMissingUserContext = errors.New("missing user context")
+	ErrDatabaseOperation  = errors.New("database operation failed")
+)
+
+const (
+	CodeTipNotFound       = "TIP_NOT_FOUND"
+	CodeRecipientNotFound = "RECIPIENT_NOT_FOUND"
+	CodeInvalidRequest    = "INVALID_REQUEST"
+	CodeInvalidAmount     = "INVALID_AMOUNT"
+	CodeTipState          = "TIP_STATE_CONFLICT"
+	CodePermissionDenied  = "PERMISSION_DENIED"
+	CodeMissingUserCtx    = "MISSING_USER_CONTEXT"
+	CodeDatabaseError     = "DATABASE_ERROR"
+	CodeInternalError     = "INTERNAL_ERROR"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func HandleServiceError(c *fiber.Ctx, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, ErrTipNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeTipNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrRecipientNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeRecipientNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidAmount):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidAmount, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrTipState):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeTipState, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrPermissionDenied):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{Code: CodePermissionDenied, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrMissingUserContext):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrDatabaseOperation):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeDatabaseError, Message: err.Error(), Details: err.Error()})
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Code: CodeInternalError, Message: "An unexpected error occurred", Details: err.Error()})
+	}
+}
+
+func HandleValidationError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: message, Details: message})
+}
+
+func HandleUserContextError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeMissingUserCtx, Message: message, Details: message})
+}
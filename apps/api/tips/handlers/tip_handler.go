@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/tips/errors"
+	"github.com/qolzam/telar/apps/api/tips/models"
+	"github.com/qolzam/telar/apps/api/tips/services"
+)
+
+type TipHandler struct {
+	service services.Service
+}
+
+func NewTipHandler(service services.Service) *TipHandler {
+	return &TipHandler{service: service}
+}
+
+// TipPost creates a pending tip to a post's owner; pay it through billing with the tip's objectId.
+// Endpoint: POST /tips/posts/:postId
+func (h *TipHandler) TipPost(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid postId")
+	}
+
+	var req models.CreateTipRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	tip, err := h.service.TipPost(c.Context(), user.UserID, postID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(tip)
+}
+
+// TipUser creates a pending tip to a user; pay it through billing with the tip's objectId.
+// Endpoint: POST /tips/users/:userId
+func (h *TipHandler) TipUser(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	recipientID, err := uuid.FromString(c.Params("userId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid userId")
+	}
+
+	var req models.CreateTipRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	tip, err := h.service.TipUser(c.Context(), user.UserID, recipientID, &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(tip)
+}
+
+// GetTip returns a tip the current user sent or received.
+// Endpoint: GET /tips/:tipId
+func (h *TipHandler) GetTip(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+	tipID, err := uuid.FromString(c.Params("tipId"))
+	if err != nil {
+		return errors.HandleValidationError(c, "invalid tipId")
+	}
+
+	tip, err := h.service.GetTip(c.Context(), user.UserID, tipID)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(tip)
+}
+
+// Ledger returns the current user's payout balances and ledger entries, newest first.
+// Endpoint: GET /tips/me/ledger?before=<occurredDate>&limit=50
+func (h *TipHandler) Ledger(c *fiber.Ctx) error {
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "invalid user context")
+	}
+
+	var before int64
+	if raw := c.Query("before"); raw != "" {
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || parsed < 0 {
+			return errors.HandleValidationError(c, "before must be a non-negative integer")
+		}
+		before = parsed
+	}
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 {
+			return errors.HandleValidationError(c, "limit must be a positive integer")
+		}
+		limit = parsed
+	}
+
+	ledger, err := h.service.Ledger(c.Context(), user.UserID, before, limit)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(ledger)
+}
+
+// BillingEvent applies a payment, failure, refund or payout reported by billing.
+// Endpoint: POST /tips/billing/events (HMAC)
+func (h *TipHandler) BillingEvent(c *fiber.Ctx) error {
+	var event models.BillingEvent
+	if err := c.BodyParser(&event); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	if err := h.service.HandleBillingEvent(c.Context(), &event); err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.SendStatus(http.StatusNoContent)
+}
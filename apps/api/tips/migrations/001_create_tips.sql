@@ -0,0 +1,38 @@
+-- A tip from one user to another, optionally for one of the recipient's posts. Tips
+-- start pending and are paid through billing with the tip's ID. Amounts are in the
+-- minor unit of the currency; fee_cents is the platform's share, fixed when the tip
+-- is created.
+CREATE TABLE IF NOT EXISTS tips (
+    id UUID PRIMARY KEY,
+    sender_id UUID NOT NULL,
+    recipient_id UUID NOT NULL,
+    post_id UUID,
+    amount_cents BIGINT NOT NULL CHECK (amount_cents > 0),
+    fee_cents BIGINT NOT NULL CHECK (fee_cents >= 0 AND fee_cents <= amount_cents),
+    currency CHAR(3) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL,
+    payment_ref VARCHAR(128) NOT NULL DEFAULT '',
+    created_date BIGINT NOT NULL,
+    last_updated BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tips_sender ON tips(sender_id, created_date DESC);
+
+-- Payout ledger: what each recipient is owed. Paid tips credit the amount net of the
+-- fee, refunds and payouts debit it. event_id is billing's event ID, so replayed events
+-- are recorded once.
+CREATE TABLE IF NOT EXISTS tip_ledger (
+    id UUID PRIMARY KEY,
+    event_id VARCHAR(128) NOT NULL UNIQUE,
+    recipient_id UUID NOT NULL,
+    tip_id UUID,
+    kind TEXT NOT NULL,
+    gross_cents BIGINT NOT NULL,
+    fee_cents BIGINT NOT NULL,
+    net_cents BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    occurred_date BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tip_ledger_recipient ON tip_ledger(recipient_id, occurred_date DESC);
@@ -0,0 +1,102 @@
+package models
+
+import (
+	uuid "github.com/gofrs/uuid"
+)
+
+// TipStatus is the billing state of a tip
+type TipStatus string
+
+const (
+	// StatusPending tips wait for the sender to pay them through billing
+	StatusPending TipStatus = "pending"
+	// StatusPaid tips are credited to the recipient's ledger
+	StatusPaid TipStatus = "paid"
+	// StatusFailed tips were not paid; the sender may retry the payment
+	StatusFailed TipStatus = "failed"
+	// StatusRefunded tips were paid, then returned to the sender
+	StatusRefunded TipStatus = "refunded"
+)
+
+// Tip is money one user sends another, optionally for one of the recipient's posts
+type Tip struct {
+	ObjectId    uuid.UUID  `json:"objectId"` // Pass to billing as the payment reference
+	SenderId    uuid.UUID  `json:"senderId"`
+	RecipientId uuid.UUID  `json:"recipientId"`
+	PostId      *uuid.UUID `json:"postId,omitempty"`
+	AmountCents int64      `json:"amountCents"` // What the sender pays, in the currency's minor unit
+	FeeCents    int64      `json:"feeCents"`    // Platform fee kept from the amount
+	NetCents    int64      `json:"netCents"`    // What the recipient is credited
+	Currency    string     `json:"currency"`    // ISO 4217 code, e.g. "USD"
+	Message     string     `json:"message,omitempty"`
+	Status      TipStatus  `json:"status"`
+	PaymentRef  string     `json:"-"` // Billing's payment ID
+	CreatedDate int64      `json:"createdDate"`
+	LastUpdated int64      `json:"lastUpdated"`
+}
+
+// CreateTipRequest is the body of POST /tips/posts/:postId and POST /tips/users/:userId
+type CreateTipRequest struct {
+	AmountCents int64  `json:"amountCents"`
+	Currency    string `json:"currency"`
+	Message     string `json:"message"`
+}
+
+// Billing event types
+const (
+	// EventPayment pays a pending or failed tip
+	EventPayment = "payment"
+	// EventFailed reports that paying a pending tip failed
+	EventFailed = "failed"
+	// EventRefund returns a paid tip to its sender
+	EventRefund = "refund"
+	// EventPayout reports money paid out to a recipient
+	EventPayout = "payout"
+)
+
+// BillingEvent is the body billing posts to POST /tips/billing/events
+type BillingEvent struct {
+	EventId      string    `json:"eventId"` // Unique per event; replays are ignored
+	Type         string    `json:"type"`    // EventPayment, EventFailed, EventRefund or EventPayout
+	TipId        uuid.UUID `json:"tipId,omitempty"`
+	RecipientId  uuid.UUID `json:"recipientId,omitempty"` // Payouts only
+	PaymentRef   string    `json:"paymentRef,omitempty"`  // Billing's payment ID
+	AmountCents  int64     `json:"amountCents,omitempty"` // Payments: the tip's amount; payouts: the amount paid out
+	Currency     string    `json:"currency,omitempty"`
+	OccurredDate int64     `json:"occurredDate"` // Unix milliseconds
+}
+
+// Ledger entry kinds
+const (
+	LedgerTip    = "tip"
+	LedgerRefund = "refund"
+	LedgerPayout = "payout"
+)
+
+// LedgerEntry credits or debits a recipient's payout balance. Refunds and payouts carry
+// negative amounts.
+type LedgerEntry struct {
+	ObjectId     uuid.UUID  `json:"objectId"`
+	EventId      string     `json:"-"`
+	RecipientId  uuid.UUID  `json:"recipientId"`
+	TipId        *uuid.UUID `json:"tipId,omitempty"`
+	Kind         string     `json:"kind"`
+	GrossCents   int64      `json:"grossCents"`
+	FeeCents     int64      `json:"feeCents"`
+	NetCents     int64      `json:"netCents"`
+	Currency     string     `json:"currency"`
+	OccurredDate int64      `json:"occurredDate"`
+}
+
+// Balance is what a recipient is owed in one currency
+type Balance struct {
+	Currency string `json:"currency" db:"currency"`
+	NetCents int64  `json:"netCents" db:"net_cents"`
+}
+
+// Ledger is the GET /tips/me/ledger response
+type Ledger struct {
+	Balances   []Balance      `json:"balances"` // Over the whole ledger, per currency
+	Entries    []*LedgerEntry `json:"entries"`  // Newest first
+	NextBefore int64          `json:"nextBefore,omitempty"`
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/tips/models"
+)
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// tipRow mirrors the tips table
+type tipRow struct {
+	ID          uuid.UUID     `db:"id"`
+	SenderID    uuid.UUID     `db:"sender_id"`
+	RecipientID uuid.UUID     `db:"recipient_id"`
+	PostID      uuid.NullUUID `db:"post_id"`
+	AmountCents int64         `db:"amount_cents"`
+	FeeCents    int64         `db:"fee_cents"`
+	Currency    string        `db:"currency"`
+	Message     string        `db:"message"`
+	Status      string        `db:"status"`
+	PaymentRef  string        `db:"payment_ref"`
+	CreatedDate int64         `db:"created_date"`
+	LastUpdated int64         `db:"last_updated"`
+}
+
+// entryRow mirrors the tip_ledger table
+type entryRow struct {
+	ID           uuid.UUID     `db:"id"`
+	EventID      string        `db:"event_id"`
+	RecipientID  uuid.UUID     `db:"recipient_id"`
+	TipID        uuid.NullUUID `db:"tip_id"`
+	Kind         string        `db:"kind"`
+	GrossCents   int64         `db:"gross_cents"`
+	FeeCents     int64         `db:"fee_cents"`
+	NetCents     int64         `db:"net_cents"`
+	Currency     string        `db:"currency"`
+	OccurredDate int64         `db:"occurred_date"`
+}
+
+const (
+	tipColumns   = `id, sender_id, recipient_id, post_id, amount_cents, fee_cents, currency, message, status, payment_ref, created_date, last_updated`
+	entryColumns = `id, event_id, recipient_id, tip_id, kind, gross_cents, fee_cents, net_cents, currency, occurred_date`
+)
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+// SaveTip only updates what billing events change; amounts are fixed at creation
+func (r *postgresRepository) SaveTip(ctx context.Context, tip *models.Tip) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %stips (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (id) DO UPDATE
+		SET status = EXCLUDED.status, payment_ref = EXCLUDED.payment_ref, last_updated = EXCLUDED.last_updated
+	`, r.schemaPrefix(), tipColumns)
+
+	_, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		tip.ObjectId, tip.SenderId, tip.RecipientId, nullUUID(tip.PostId), tip.AmountCents, tip.FeeCents,
+		tip.Currency, tip.Message, string(tip.Status), tip.PaymentRef, tip.CreatedDate, tip.LastUpdated)
+	if err != nil {
+		return fmt.Errorf("save tip: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetTip(ctx context.Context, tipID uuid.UUID) (*models.Tip, error) {
+	query := fmt.Sprintf(`SELECT %s FROM %stips WHERE id = $1`, tipColumns, r.schemaPrefix())
+
+	var row tipRow
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, tipID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get tip: %w", err)
+	}
+	return row.toModel(), nil
+}
+
+func (r *postgresRepository) RecordEntry(ctx context.Context, entry *models.LedgerEntry) (bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %stip_ledger (%s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (event_id) DO NOTHING
+	`, r.schemaPrefix(), entryColumns)
+
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query,
+		entry.ObjectId, entry.EventId, entry.RecipientId, nullUUID(entry.TipId), entry.Kind,
+		entry.GrossCents, entry.FeeCents, entry.NetCents, entry.Currency, entry.OccurredDate)
+	if err != nil {
+		return false, fmt.Errorf("record tip ledger entry: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("rows affected: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *postgresRepository) ListEntries(ctx context.Context, recipientID uuid.UUID, before int64, limit int) ([]*models.LedgerEntry, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM %stip_ledger
+		WHERE recipient_id = $1 AND ($2 = 0 OR occurred_date < $2)
+		ORDER BY occurred_date DESC, id
+		LIMIT $3
+	`, entryColumns, r.schemaPrefix())
+
+	var rows []entryRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, recipientID, before, limit); err != nil {
+		return nil, fmt.Errorf("list tip ledger entries: %w", err)
+	}
+	entries := make([]*models.LedgerEntry, 0, len(rows))
+	for i := range rows {
+		entries = append(entries, rows[i].toModel())
+	}
+	return entries, nil
+}
+
+func (r *postgresRepository) Balances(ctx context.Context, recipientID uuid.UUID) ([]models.Balance, error) {
+	query := fmt.Sprintf(`
+		SELECT currency, SUM(net_cents) AS net_cents
+		FROM %stip_ledger
+		WHERE recipient_id = $1
+		GROUP BY currency
+		ORDER BY currency
+	`, r.schemaPrefix())
+
+	balances := []models.Balance{}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &balances, query, recipientID); err != nil {
+		return nil, fmt.Errorf("sum tip ledger: %w", err)
+	}
+	return balances, nil
+}
+
+func (r *postgresRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	queries := []string{
+		`DELETE FROM %stips WHERE sender_id = $1 OR recipient_id = $1`,
+		`DELETE FROM %stip_ledger WHERE recipient_id = $1`,
+	}
+	for _, query := range queries {
+		if _, err := r.getExecutor(ctx).ExecContext(ctx, fmt.Sprintf(query, r.schemaPrefix()), userID); err != nil {
+			return fmt.Errorf("delete tips of user: %w", err)
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+	tx, err := r.client.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("transaction failed and rollback failed: %w (original error: %v)", rollbackErr, err)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
+
+func nullUUID(id *uuid.UUID) uuid.NullUUID {
+	if id == nil {
+		return uuid.NullUUID{}
+	}
+	return uuid.NullUUID{UUID: *id, Valid: true}
+}
+
+func (row *tipRow) toModel() *models.Tip {
+	tip := &models.Tip{
+		ObjectId:    row.ID,
+		SenderId:    row.SenderID,
+		RecipientId: row.RecipientID,
+		AmountCents: row.AmountCents,
+		FeeCents:    row.FeeCents,
+		NetCents:    row.AmountCents - row.FeeCents,
+		Currency:    row.Currency,
+		Message:     row.Message,
+		Status:      models.TipStatus(row.Status),
+		PaymentRef:  row.PaymentRef,
+		CreatedDate: row.CreatedDate,
+		LastUpdated: row.LastUpdated,
+	}
+	if row.PostID.Valid {
+		postID := row.PostID.UUID
+		tip.PostId = &postID
+	}
+	return tip
+}
+
+func (row *entryRow) toModel() *models.LedgerEntry {
+	entry := &models.LedgerEntry{
+		ObjectId:     row.ID,
+		EventId:      row.EventID,
+		RecipientId:  row.RecipientID,
+		Kind:         row.Kind,
+		GrossCents:   row.GrossCents,
+		FeeCents:     row.FeeCents,
+		NetCents:     row.NetCents,
+		Currency:     row.Currency,
+		OccurredDate: row.OccurredDate,
+	}
+	if row.TipID.Valid {
+		tipID := row.TipID.UUID
+		entry.TipId = &tipID
+	}
+	return entry
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/tips/models"
+)
+
+// ErrNotFound is returned when no tip matches
+var ErrNotFound = errors.New("not found")
+
+// Repository defines data access for tips and the payout ledger.
+type Repository interface {
+	// SaveTip inserts or replaces a tip.
+	SaveTip(ctx context.Context, tip *models.Tip) error
+
+	// GetTip returns a tip by ID or ErrNotFound.
+	GetTip(ctx context.Context, tipID uuid.UUID) (*models.Tip, error)
+
+	// RecordEntry stores a ledger entry; returns false when its event was recorded before.
+	RecordEntry(ctx context.Context, entry *models.LedgerEntry) (bool, error)
+
+	// ListEntries returns recipientID's ledger entries that occurred before the given
+	// time (0 for the newest), newest first.
+	ListEntries(ctx context.Context, recipientID uuid.UUID, before int64, limit int) ([]*models.LedgerEntry, error)
+
+	// Balances sums recipientID's whole ledger per currency.
+	Balances(ctx context.Context, recipientID uuid.UUID) ([]models.Balance, error)
+
+	// DeleteByUser deletes the tips userID sent or received and userID's ledger.
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
+
+	// WithTransaction runs fn in a transaction, joining the caller's when there is one.
+	WithTransaction(ctx context.Context, fn func(context.Context) error) error
+}
@@ -0,0 +1,45 @@
+package tips
+
+import (
+	"github.com/gofiber/fiber/v2"
+	authhmac "github.com/qolzam/telar/apps/api/internal/middleware/authhmac"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/tips/handlers"
+)
+
+type Handlers struct {
+	TipHandler *handlers.TipHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires tip and payout ledger endpoints, and the endpoint billing
+// reports events to.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/tips")
+
+	// Service-to-service route with HMAC auth
+	group.Post("/billing/events", authhmac.New(authhmac.Config{PayloadSecret: routerCfg.PayloadSecret}), handlers.TipHandler.BillingEvent)
+
+	dualAuthMiddleware := createDualAuthMiddleware(routerCfg)
+	group.Post("/posts/:postId", dualAuthMiddleware, handlers.TipHandler.TipPost)
+	group.Post("/users/:userId", dualAuthMiddleware, handlers.TipHandler.TipUser)
+	group.Get("/me/ledger", dualAuthMiddleware, handlers.TipHandler.Ledger)
+	group.Get("/:tipId", dualAuthMiddleware, handlers.TipHandler.GetTip)
+}
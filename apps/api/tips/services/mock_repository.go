@@ -0,0 +1,60 @@
+package services
+
+import (
+	"context"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/tips/models"
+	"github.com/qolzam/telar/apps/api/tips/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the tips repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) SaveTip(ctx context.Context, tip *models.Tip) error {
+	args := m.Called(ctx, tip)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetTip(ctx context.Context, tipID uuid.UUID) (*models.Tip, error) {
+	args := m.Called(ctx, tipID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Tip), args.Error(1)
+}
+
+func (m *MockRepository) RecordEntry(ctx context.Context, entry *models.LedgerEntry) (bool, error) {
+	args := m.Called(ctx, entry)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ListEntries(ctx context.Context, recipientID uuid.UUID, before int64, limit int) ([]*models.LedgerEntry, error) {
+	args := m.Called(ctx, recipientID, before, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LedgerEntry), args.Error(1)
+}
+
+func (m *MockRepository) Balances(ctx context.Context, recipientID uuid.UUID) ([]models.Balance, error) {
+	args := m.Called(ctx, recipientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Balance), args.Error(1)
+}
+
+func (m *MockRepository) WithTransaction(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+func (m *MockRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	tipErrors "github.com/qolzam/telar/apps/api/tips/errors"
+	"github.com/qolzam/telar/apps/api/tips/models"
+	"github.com/qolzam/telar/apps/api/tips/repository"
+)
+
+const (
+	maxMessageLength   = 280
+	maxBillingIDLength = 128
+	defaultLedgerLimit = 50
+	maxLedgerLimit     = 200
+)
+
+// PostProvider finds tipped posts and keeps their tip counts; the post service satisfies it.
+type PostProvider interface {
+	GetPost(ctx context.Context, postID uuid.UUID) (*postsModels.Post, error)
+	IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error
+}
+
+// ProfileLookup checks that a tipped user exists; the profile service satisfies it.
+type ProfileLookup interface {
+	GetProfile(ctx context.Context, userID uuid.UUID) (*profileModels.Profile, error)
+}
+
+// Service defines tipping and payout ledger operations.
+type Service interface {
+	// TipPost creates a pending tip to the owner of postID.
+	TipPost(ctx context.Context, senderID, postID uuid.UUID, req *models.CreateTipRequest) (*models.Tip, error)
+
+	// TipUser creates a pending tip to recipientID.
+	TipUser(ctx context.Context, senderID, recipientID uuid.UUID, req *models.CreateTipRequest) (*models.Tip, error)
+
+	// GetTip returns a tip to its sender or recipient.
+	GetTip(ctx context.Context, userID, tipID uuid.UUID) (*models.Tip, error)
+
+	// Ledger returns recipientID's balances and a page of ledger entries older than before.
+	Ledger(ctx context.Context, recipientID uuid.UUID, before int64, limit int) (*models.Ledger, error)
+
+	// HandleBillingEvent applies a payment, failure, refund or payout reported by billing.
+	// Replayed events are ignored.
+	HandleBillingEvent(ctx context.Context, event *models.BillingEvent) error
+}
+
+type service struct {
+	repo     repository.Repository
+	posts    PostProvider
+	profiles ProfileLookup
+	cfg      platformconfig.TipsConfig
+	now      func() time.Time
+}
+
+// NewService constructs a tips service.
+func NewService(repo repository.Repository, posts PostProvider, profiles ProfileLookup, cfg platformconfig.TipsConfig) Service {
+	return &service{repo: repo, posts: posts, profiles: profiles, cfg: cfg, now: time.Now}
+}
+
+func (s *service) TipPost(ctx context.Context, senderID, postID uuid.UUID, req *models.CreateTipRequest) (*models.Tip, error) {
+	post, err := s.posts.GetPost(ctx, postID)
+	if errors.Is(err, postsErrors.ErrPostNotFound) || (err == nil && post.Deleted) {
+		return nil, tipErrors.ErrRecipientNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", tipErrors.ErrDatabaseOperation, err)
+	}
+	// Tipping an anonymous post would tell the sender who wrote it
+	if post.Anonymous {
+		return nil, fmt.Errorf("%w: anonymous posts cannot be tipped", tipErrors.ErrInvalidRequest)
+	}
+	return s.createTip(ctx, senderID, post.OwnerUserId, &postID, req)
+}
+
+func (s *service) TipUser(ctx context.Context, senderID, recipientID uuid.UUID, req *models.CreateTipRequest) (*models.Tip, error) {
+	if profile, err := s.profiles.GetProfile(ctx, recipientID); err != nil || profile == nil {
+		return nil, tipErrors.ErrRecipientNotFound
+	}
+	return s.createTip(ctx, senderID, recipientID, nil, req)
+}
+
+func (s *service) createTip(ctx context.Context, senderID, recipientID uuid.UUID, postID *uuid.UUID, req *models.CreateTipRequest) (*models.Tip, error) {
+	if req == nil {
+		return nil, fmt.Errorf("%w: request body is required", tipErrors.ErrInvalidRequest)
+	}
+	if senderID == recipientID {
+		return nil, fmt.Errorf("%w: you cannot tip yourself", tipErrors.ErrInvalidRequest)
+	}
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+	if !s.acceptsCurrency(currency) {
+		return nil, fmt.Errorf("%w: currency must be one of %s", tipErrors.ErrInvalidRequest, strings.Join(s.cfg.Currencies, ", "))
+	}
+	if req.AmountCents <= 0 {
+		return nil, fmt.Errorf("%w: amountCents must be positive", tipErrors.ErrInvalidAmount)
+	}
+	if req.AmountCents < s.cfg.MinAmountCents || (s.cfg.MaxAmountCents > 0 && req.AmountCents > s.cfg.MaxAmountCents) {
+		return nil, fmt.Errorf("%w: amountCents must be between %d and %d", tipErrors.ErrInvalidAmount, s.cfg.MinAmountCents, s.cfg.MaxAmountCents)
+	}
+	message := strings.TrimSpace(req.Message)
+	if utf8.RuneCountInString(message) > maxMessageLength {
+		return nil, fmt.Errorf("%w: message must be at most %d characters", tipErrors.ErrInvalidRequest, maxMessageLength)
+	}
+
+	fee := s.fee(req.AmountCents)
+	now := s.now().UTC().UnixMilli()
+	tip := &models.Tip{
+		ObjectId:    uuid.Must(uuid.NewV4()),
+		SenderId:    senderID,
+		RecipientId: recipientID,
+		PostId:      postID,
+		AmountCents: req.AmountCents,
+		FeeCents:    fee,
+		NetCents:    req.AmountCents - fee,
+		Currency:    currency,
+		Message:     message,
+		Status:      models.StatusPending,
+		CreatedDate: now,
+		LastUpdated: now,
+	}
+	if err := s.repo.SaveTip(ctx, tip); err != nil {
+		return nil, fmt.Errorf("%w: %v", tipErrors.ErrDatabaseOperation, err)
+	}
+	return tip, nil
+}
+
+// fee is fixed when the tip is created so later config changes don't alter what the
+// sender agreed to; it never exceeds the amount
+func (s *service) fee(amountCents int64) int64 {
+	fee := amountCents*int64(s.cfg.FeeBasisPoints)/10000 + s.cfg.FeeFixedCents
+	if fee < 0 {
+		return 0
+	}
+	if fee > amountCents {
+		return amountCents
+	}
+	return fee
+}
+
+func (s *service) acceptsCurrency(currency string) bool {
+	for _, accepted := range s.cfg.Currencies {
+		if strings.EqualFold(accepted, currency) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *service) GetTip(ctx context.Context, userID, tipID uuid.UUID) (*models.Tip, error) {
+	tip, err := s.getTip(ctx, tipID)
+	if err != nil {
+		return nil, err
+	}
+	if tip.SenderId != userID && tip.RecipientId != userID {
+		return nil, tipErrors.ErrTipNotFound
+	}
+	return tip, nil
+}
+
+func (s *service) Ledger(ctx context.Context, recipientID uuid.UUID, before int64, limit int) (*models.Ledger, error) {
+	if before < 0 {
+		return nil, fmt.Errorf("%w: before must not be negative", tipErrors.ErrInvalidRequest)
+	}
+	if limit <= 0 {
+		limit = defaultLedgerLimit
+	}
+	if limit > maxLedgerLimit {
+		limit = maxLedgerLimit
+	}
+
+	balances, err := s.repo.Balances(ctx, recipientID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", tipErrors.ErrDatabaseOperation, err)
+	}
+	entries, err := s.repo.ListEntries(ctx, recipientID, before, limit)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", tipErrors.ErrDatabaseOperation, err)
+	}
+
+	ledger := &models.Ledger{Balances: balances, Entries: entries}
+	if len(entries) == limit {
+		ledger.NextBefore = entries[len(entries)-1].OccurredDate
+	}
+	return ledger, nil
+}
+
+func (s *service) HandleBillingEvent(ctx context.Context, event *models.BillingEvent) error {
+	if err := validateBillingEvent(event); err != nil {
+		return err
+	}
+	if event.Type == models.EventPayout {
+		_, err := s.record(ctx, &models.LedgerEntry{
+			RecipientId: event.RecipientId,
+			Kind:        models.LedgerPayout,
+			GrossCents:  -event.AmountCents,
+			NetCents:    -event.AmountCents,
+			Currency:    strings.ToUpper(event.Currency),
+		}, event)
+		return err
+	}
+
+	// The post's tip count changes in the same transaction as the ledger, so a failed
+	// count rolls the event back and its redelivery counts the tip once
+	return s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		tip, err := s.getTip(txCtx, event.TipId)
+		if err != nil {
+			return err
+		}
+
+		countDelta := 0
+		switch event.Type {
+		case models.EventPayment:
+			countDelta, err = s.applyPayment(txCtx, event, tip)
+		case models.EventFailed:
+			err = s.applyFailure(txCtx, tip)
+		default:
+			countDelta, err = s.applyRefund(txCtx, event, tip)
+		}
+		if err != nil || countDelta == 0 || tip.PostId == nil {
+			return err
+		}
+
+		// The post may have been deleted since it was tipped
+		if err := s.posts.IncrementTipCount(txCtx, *tip.PostId, countDelta); err != nil && !errors.Is(err, postsErrors.ErrPostNotFound) {
+			return fmt.Errorf("%w: %v", tipErrors.ErrDatabaseOperation, err)
+		}
+		return nil
+	})
+}
+
+// applyPayment credits the recipient and marks the tip paid. A failed tip may be paid
+// on retry.
+func (s *service) applyPayment(ctx context.Context, event *models.BillingEvent, tip *models.Tip) (int, error) {
+	if tip.Status == models.StatusPaid || tip.Status == models.StatusRefunded {
+		return 0, nil
+	}
+	if event.AmountCents != tip.AmountCents || !strings.EqualFold(event.Currency, tip.Currency) {
+		return 0, fmt.Errorf("%w: payment of %d %s does not match the tip", tipErrors.ErrInvalidRequest, event.AmountCents, event.Currency)
+	}
+	created, err := s.record(ctx, &models.LedgerEntry{
+		RecipientId: tip.RecipientId,
+		TipId:       &tip.ObjectId,
+		Kind:        models.LedgerTip,
+		GrossCents:  tip.AmountCents,
+		FeeCents:    tip.FeeCents,
+		NetCents:    tip.AmountCents - tip.FeeCents,
+		Currency:    tip.Currency,
+	}, event)
+	if err != nil || !created {
+		return 0, err
+	}
+	tip.Status = models.StatusPaid
+	tip.PaymentRef = event.PaymentRef
+	return 1, s.saveTip(ctx, tip)
+}
+
+func (s *service) applyFailure(ctx context.Context, tip *models.Tip) error {
+	if tip.Status != models.StatusPending {
+		return nil
+	}
+	tip.Status = models.StatusFailed
+	return s.saveTip(ctx, tip)
+}
+
+// applyRefund reverses the whole credit, fee included
+func (s *service) applyRefund(ctx context.Context, event *models.BillingEvent, tip *models.Tip) (int, error) {
+	if tip.Status == models.StatusRefunded {
+		return 0, nil
+	}
+	if tip.Status != models.StatusPaid {
+		return 0, fmt.Errorf("%w: only paid tips can be refunded", tipErrors.ErrTipState)
+	}
+	created, err := s.record(ctx, &models.LedgerEntry{
+		RecipientId: tip.RecipientId,
+		TipId:       &tip.ObjectId,
+		Kind:        models.LedgerRefund,
+		GrossCents:  -tip.AmountCents,
+		FeeCents:    -tip.FeeCents,
+		NetCents:    -(tip.AmountCents - tip.FeeCents),
+		Currency:    tip.Currency,
+	}, event)
+	if err != nil || !created {
+		return 0, err
+	}
+	tip.Status = models.StatusRefunded
+	return -1, s.saveTip(ctx, tip)
+}
+
+// record fills in the event ID and date of entry and stores it; false means the event was
+// applied before
+func (s *service) record(ctx context.Context, entry *models.LedgerEntry, event *models.BillingEvent) (bool, error) {
+	entry.ObjectId = uuid.Must(uuid.NewV4())
+	entry.EventId = event.EventId
+	entry.OccurredDate = event.OccurredDate
+	created, err := s.repo.RecordEntry(ctx, entry)
+	if err != nil {
+		return false, fmt.Errorf("%w: %v", tipErrors.ErrDatabaseOperation, err)
+	}
+	return created, nil
+}
+
+func (s *service) saveTip(ctx context.Context, tip *models.Tip) error {
+	tip.LastUpdated = s.now().UTC().UnixMilli()
+	if err := s.repo.SaveTip(ctx, tip); err != nil {
+		return fmt.Errorf("%w: %v", tipErrors.ErrDatabaseOperation, err)
+	}
+	return nil
+}
+
+func (s *service) getTip(ctx context.Context, tipID uuid.UUID) (*models.Tip, error) {
+	tip, err := s.repo.GetTip(ctx, tipID)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, tipErrors.ErrTipNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", tipErrors.ErrDatabaseOperation, err)
+	}
+	return tip, nil
+}
+
+func validateBillingEvent(event *models.BillingEvent) error {
+	if event == nil {
+		return fmt.Errorf("%w: request body is required", tipErrors.ErrInvalidRequest)
+	}
+	if strings.TrimSpace(event.EventId) == "" || len(event.EventId) > maxBillingIDLength {
+		return fmt.Errorf("%w: eventId is required and at most %d bytes", tipErrors.ErrInvalidRequest, maxBillingIDLength)
+	}
+	if len(event.PaymentRef) > maxBillingIDLength {
+		return fmt.Errorf("%w: paymentRef must be at most %d bytes", tipErrors.ErrInvalidRequest, maxBillingIDLength)
+	}
+	if event.OccurredDate <= 0 {
+		return fmt.Errorf("%w: occurredDate is required", tipErrors.ErrInvalidRequest)
+	}
+	switch event.Type {
+	case models.EventPayout:
+		if event.RecipientId == uuid.Nil {
+			return fmt.Errorf("%w: recipientId is required", tipErrors.ErrInvalidRequest)
+		}
+		if event.AmountCents <= 0 || len(event.Currency) != 3 {
+			return fmt.Errorf("%w: payouts need a positive amountCents and a currency", tipErrors.ErrInvalidRequest)
+		}
+	case models.EventPayment:
+		if event.AmountCents <= 0 || len(event.Currency) != 3 {
+			return fmt.Errorf("%w: payments need a positive amountCents and a currency", tipErrors.ErrInvalidRequest)
+		}
+		fallthrough
+	case models.EventFailed, models.EventRefund:
+		if event.TipId == uuid.Nil {
+			return fmt.Errorf("%w: tipId is required", tipErrors.ErrInvalidRequest)
+		}
+	default:
+		return fmt.Errorf("%w: unknown event type %q", tipErrors.ErrInvalidRequest, event.Type)
+	}
+	return nil
+}
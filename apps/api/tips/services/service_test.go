@@ -0,0 +1,259 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	postsErrors "github.com/qolzam/telar/apps/api/posts/errors"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	tipErrors "github.com/qolzam/telar/apps/api/tips/errors"
+	"github.com/qolzam/telar/apps/api/tips/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = platformconfig.TipsConfig{
+	Enabled:        true,
+	Currencies:     []string{"USD", "EUR"},
+	MinAmountCents: 100,
+	MaxAmountCents: 10000,
+	FeeBasisPoints: 500,
+	FeeFixedCents:  30,
+}
+
+type stubPosts struct {
+	posts  map[uuid.UUID]*postsModels.Post
+	counts map[uuid.UUID]int
+	err    error // Returned by IncrementTipCount
+}
+
+func (p *stubPosts) GetPost(ctx context.Context, postID uuid.UUID) (*postsModels.Post, error) {
+	if post, ok := p.posts[postID]; ok {
+		return post, nil
+	}
+	return nil, postsErrors.ErrPostNotFound
+}
+
+func (p *stubPosts) IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.counts[postID] += delta
+	return nil
+}
+
+type stubProfiles map[uuid.UUID]bool
+
+func (p stubProfiles) GetProfile(ctx context.Context, userID uuid.UUID) (*profileModels.Profile, error) {
+	if !p[userID] {
+		return nil, nil
+	}
+	return &profileModels.Profile{ObjectId: userID}, nil
+}
+
+func newTestService(repo *MockRepository, posts *stubPosts, now time.Time) *service {
+	svc := NewService(repo, posts, stubProfiles{}, testConfig).(*service)
+	svc.now = func() time.Time { return now }
+	return svc
+}
+
+func TestTipPost(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	senderID := uuid.Must(uuid.NewV4())
+	ownerID := uuid.Must(uuid.NewV4())
+	post := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: ownerID}
+	anonymous := &postsModels.Post{ObjectId: uuid.Must(uuid.NewV4()), OwnerUserId: ownerID, Anonymous: true}
+	posts := &stubPosts{posts: map[uuid.UUID]*postsModels.Post{post.ObjectId: post, anonymous.ObjectId: anonymous}}
+
+	t.Run("creates a pending tip to the post owner with the fee fixed", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("SaveTip", ctx, mock.AnythingOfType("*models.Tip")).Return(nil).Once()
+
+		tip, err := newTestService(repo, posts, now).TipPost(ctx, senderID, post.ObjectId, &models.CreateTipRequest{
+			AmountCents: 1000, Currency: "usd", Message: " thanks! ",
+		})
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		assert.Equal(t, ownerID, tip.RecipientId)
+		assert.Equal(t, &post.ObjectId, tip.PostId)
+		assert.Equal(t, models.StatusPending, tip.Status)
+		assert.Equal(t, "USD", tip.Currency)
+		assert.Equal(t, "thanks!", tip.Message)
+		assert.Equal(t, int64(80), tip.FeeCents) // 5% + 30
+		assert.Equal(t, int64(920), tip.NetCents)
+	})
+
+	t.Run("enforces amount limits and currencies", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), posts, now)
+		_, err := svc.TipPost(ctx, senderID, post.ObjectId, &models.CreateTipRequest{AmountCents: 99, Currency: "USD"})
+		assert.ErrorIs(t, err, tipErrors.ErrInvalidAmount)
+		_, err = svc.TipPost(ctx, senderID, post.ObjectId, &models.CreateTipRequest{AmountCents: 10001, Currency: "USD"})
+		assert.ErrorIs(t, err, tipErrors.ErrInvalidAmount)
+		_, err = svc.TipPost(ctx, senderID, post.ObjectId, &models.CreateTipRequest{AmountCents: 500, Currency: "GBP"})
+		assert.ErrorIs(t, err, tipErrors.ErrInvalidRequest)
+	})
+
+	t.Run("rejects self, anonymous and missing posts", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), posts, now)
+		req := &models.CreateTipRequest{AmountCents: 500, Currency: "USD"}
+		_, err := svc.TipPost(ctx, ownerID, post.ObjectId, req)
+		assert.ErrorIs(t, err, tipErrors.ErrInvalidRequest)
+		_, err = svc.TipPost(ctx, senderID, anonymous.ObjectId, req)
+		assert.ErrorIs(t, err, tipErrors.ErrInvalidRequest)
+		_, err = svc.TipPost(ctx, senderID, uuid.Must(uuid.NewV4()), req)
+		assert.ErrorIs(t, err, tipErrors.ErrRecipientNotFound)
+	})
+}
+
+func TestFee_NeverExceedsAmount(t *testing.T) {
+	svc := &service{cfg: platformconfig.TipsConfig{FeeBasisPoints: 500, FeeFixedCents: 300}}
+	assert.Equal(t, int64(200), svc.fee(200))
+	assert.Equal(t, int64(350), svc.fee(1000))
+}
+
+func TestHandleBillingEvent(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	recipientID := uuid.Must(uuid.NewV4())
+	postID := uuid.Must(uuid.NewV4())
+
+	newTip := func(status models.TipStatus) *models.Tip {
+		return &models.Tip{ObjectId: uuid.Must(uuid.NewV4()), SenderId: uuid.Must(uuid.NewV4()), RecipientId: recipientID,
+			PostId: &postID, AmountCents: 1000, FeeCents: 80, Currency: "USD", Status: status}
+	}
+	payment := func(tip *models.Tip, eventID string) *models.BillingEvent {
+		return &models.BillingEvent{EventId: eventID, Type: models.EventPayment, TipId: tip.ObjectId, PaymentRef: "pay_1",
+			AmountCents: 1000, Currency: "usd", OccurredDate: now.UnixMilli()}
+	}
+
+	t.Run("payment credits the recipient and counts the tip on the post", func(t *testing.T) {
+		tip := newTip(models.StatusPending)
+		posts := &stubPosts{counts: map[uuid.UUID]int{}}
+		repo := new(MockRepository)
+		repo.On("GetTip", ctx, tip.ObjectId).Return(tip, nil).Once()
+		var entry *models.LedgerEntry
+		repo.On("RecordEntry", ctx, mock.AnythingOfType("*models.LedgerEntry")).Return(true, nil).Once().
+			Run(func(args mock.Arguments) { entry = args.Get(1).(*models.LedgerEntry) })
+		repo.On("SaveTip", ctx, tip).Return(nil).Once()
+
+		require.NoError(t, newTestService(repo, posts, now).HandleBillingEvent(ctx, payment(tip, "evt_1")))
+		repo.AssertExpectations(t)
+		assert.Equal(t, models.LedgerTip, entry.Kind)
+		assert.Equal(t, int64(1000), entry.GrossCents)
+		assert.Equal(t, int64(80), entry.FeeCents)
+		assert.Equal(t, int64(920), entry.NetCents)
+		assert.Equal(t, "evt_1", entry.EventId)
+		assert.Equal(t, models.StatusPaid, tip.Status)
+		assert.Equal(t, "pay_1", tip.PaymentRef)
+		assert.Equal(t, 1, posts.counts[postID])
+	})
+
+	t.Run("replayed payments change nothing", func(t *testing.T) {
+		tip := newTip(models.StatusPending)
+		posts := &stubPosts{counts: map[uuid.UUID]int{}}
+		repo := new(MockRepository)
+		repo.On("GetTip", ctx, tip.ObjectId).Return(tip, nil).Once()
+		repo.On("RecordEntry", ctx, mock.Anything).Return(false, nil).Once()
+
+		require.NoError(t, newTestService(repo, posts, now).HandleBillingEvent(ctx, payment(tip, "evt_1")))
+		repo.AssertNotCalled(t, "SaveTip", mock.Anything, mock.Anything)
+		assert.Zero(t, posts.counts[postID])
+	})
+
+	t.Run("a failed tip count fails the event so it is redelivered", func(t *testing.T) {
+		tip := newTip(models.StatusPending)
+		posts := &stubPosts{counts: map[uuid.UUID]int{}, err: errors.New("connection reset")}
+		repo := new(MockRepository)
+		repo.On("GetTip", ctx, tip.ObjectId).Return(tip, nil).Once()
+		repo.On("RecordEntry", ctx, mock.Anything).Return(true, nil).Once()
+		repo.On("SaveTip", ctx, tip).Return(nil).Once()
+
+		err := newTestService(repo, posts, now).HandleBillingEvent(ctx, payment(tip, "evt_1"))
+		assert.ErrorIs(t, err, tipErrors.ErrDatabaseOperation)
+	})
+
+	t.Run("payments must match the tip", func(t *testing.T) {
+		tip := newTip(models.StatusPending)
+		repo := new(MockRepository)
+		repo.On("GetTip", ctx, tip.ObjectId).Return(tip, nil).Once()
+		event := payment(tip, "evt_2")
+		event.AmountCents = 500
+
+		assert.ErrorIs(t, newTestService(repo, &stubPosts{}, now).HandleBillingEvent(ctx, event), tipErrors.ErrInvalidRequest)
+		repo.AssertNotCalled(t, "RecordEntry", mock.Anything, mock.Anything)
+	})
+
+	t.Run("refunds reverse the credit and the count", func(t *testing.T) {
+		tip := newTip(models.StatusPaid)
+		posts := &stubPosts{counts: map[uuid.UUID]int{postID: 1}}
+		repo := new(MockRepository)
+		repo.On("GetTip", ctx, tip.ObjectId).Return(tip, nil).Once()
+		repo.On("RecordEntry", ctx, mock.MatchedBy(func(e *models.LedgerEntry) bool {
+			return e.Kind == models.LedgerRefund && e.GrossCents == -1000 && e.FeeCents == -80 && e.NetCents == -920
+		})).Return(true, nil).Once()
+		repo.On("SaveTip", ctx, tip).Return(nil).Once()
+
+		require.NoError(t, newTestService(repo, posts, now).HandleBillingEvent(ctx, &models.BillingEvent{
+			EventId: "evt_3", Type: models.EventRefund, TipId: tip.ObjectId, OccurredDate: now.UnixMilli(),
+		}))
+		repo.AssertExpectations(t)
+		assert.Equal(t, models.StatusRefunded, tip.Status)
+		assert.Zero(t, posts.counts[postID])
+	})
+
+	t.Run("unpaid tips cannot be refunded", func(t *testing.T) {
+		tip := newTip(models.StatusPending)
+		repo := new(MockRepository)
+		repo.On("GetTip", ctx, tip.ObjectId).Return(tip, nil).Once()
+
+		err := newTestService(repo, &stubPosts{}, now).HandleBillingEvent(ctx, &models.BillingEvent{
+			EventId: "evt_4", Type: models.EventRefund, TipId: tip.ObjectId, OccurredDate: now.UnixMilli(),
+		})
+		assert.ErrorIs(t, err, tipErrors.ErrTipState)
+	})
+
+	t.Run("payouts debit the recipient's balance", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("RecordEntry", ctx, mock.MatchedBy(func(e *models.LedgerEntry) bool {
+			return e.Kind == models.LedgerPayout && e.RecipientId == recipientID && e.NetCents == -5000 && e.Currency == "EUR" && e.TipId == nil
+		})).Return(true, nil).Once()
+
+		require.NoError(t, newTestService(repo, &stubPosts{}, now).HandleBillingEvent(ctx, &models.BillingEvent{
+			EventId: "evt_5", Type: models.EventPayout, RecipientId: recipientID, AmountCents: 5000, Currency: "eur", OccurredDate: now.UnixMilli(),
+		}))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects malformed events", func(t *testing.T) {
+		svc := newTestService(new(MockRepository), &stubPosts{}, now)
+		tip := newTip(models.StatusPending)
+		assert.ErrorIs(t, svc.HandleBillingEvent(ctx, payment(tip, "")), tipErrors.ErrInvalidRequest)
+		bad := payment(tip, "evt_6")
+		bad.Type = "chargeback"
+		assert.ErrorIs(t, svc.HandleBillingEvent(ctx, bad), tipErrors.ErrInvalidRequest)
+		assert.ErrorIs(t, svc.HandleBillingEvent(ctx, &models.BillingEvent{
+			EventId: "evt_7", Type: models.EventPayout, AmountCents: 100, Currency: "USD", OccurredDate: now.UnixMilli(),
+		}), tipErrors.ErrInvalidRequest)
+	})
+}
+
+func TestLedger_PagesByOccurredDate(t *testing.T) {
+	ctx := context.Background()
+	recipientID := uuid.Must(uuid.NewV4())
+	entries := []*models.LedgerEntry{{OccurredDate: 300}, {OccurredDate: 200}}
+	repo := new(MockRepository)
+	repo.On("Balances", ctx, recipientID).Return([]models.Balance{{Currency: "USD", NetCents: 920}}, nil).Once()
+	repo.On("ListEntries", ctx, recipientID, int64(0), 2).Return(entries, nil).Once()
+
+	ledger, err := newTestService(repo, &stubPosts{}, time.Now()).Ledger(ctx, recipientID, 0, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), ledger.NextBefore)
+	assert.Len(t, ledger.Balances, 1)
+}
@@ -25,6 +25,8 @@ var (
 	ErrMissingUserContext    = errors.New("missing user context")
 	ErrValidationFailed      = errors.New("validation failed")
 	ErrDatabaseOperation     = errors.New("database operation failed")
+	ErrFlagNotFound          = errors.New("vote flag not found")
+	ErrNotPostAuthor         = errors.New("only the post author can view its vote analytics")
 )
 
 // Error codes
@@ -38,6 +40,8 @@ const (
 	CodeMissingUserContext = "MISSING_USER_CONTEXT"
 	CodeValidationFailed = "VALIDATION_FAILED"
 	CodeDatabaseError    = "DATABASE_ERROR"
+	CodeFlagNotFound     = "FLAG_NOT_FOUND"
+	CodeNotPostAuthor    = "NOT_POST_AUTHOR"
 )
 
 // ErrorResponse represents the standardized error response format
@@ -60,6 +64,24 @@ func HandleServiceError(c *fiber.Ctx, err error) error {
 			Message: "Post not found",
 			Details: err.Error(),
 		})
+	case errors.Is(err, ErrFlagNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{
+			Code:    CodeFlagNotFound,
+			Message: "Vote flag not found",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrNotPostAuthor):
+		return c.Status(http.StatusForbidden).JSON(ErrorResponse{
+			Code:    CodeNotPostAuthor,
+			Message: "Forbidden",
+			Details: err.Error(),
+		})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{
+			Code:    CodeInvalidRequest,
+			Message: "Invalid request",
+			Details: err.Error(),
+		})
 	case errors.Is(err, ErrVoteNotFound):
 		return c.Status(http.StatusNotFound).JSON(ErrorResponse{
 			Code:    CodeVoteNotFound,
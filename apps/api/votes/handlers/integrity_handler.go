@@ -0,0 +1,72 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	"github.com/qolzam/telar/apps/api/votes/errors"
+	"github.com/qolzam/telar/apps/api/votes/models"
+	"github.com/qolzam/telar/apps/api/votes/services"
+)
+
+// IntegrityHandler exposes the suspicious voting flags to moderators
+type IntegrityHandler struct {
+	integrityService services.IntegrityService
+}
+
+// NewIntegrityHandler creates a new IntegrityHandler with injected dependencies
+func NewIntegrityHandler(integrityService services.IntegrityService) *IntegrityHandler {
+	return &IntegrityHandler{integrityService: integrityService}
+}
+
+// ListFlags returns vote manipulation flags, open ones by default
+// Endpoint: GET /votes/flags?status=open&limit=20&offset=0
+func (h *IntegrityHandler) ListFlags(c *fiber.Ctx) error {
+	status := c.Query("status", models.FlagStatusOpen)
+	if status == "all" {
+		status = ""
+	}
+
+	flags, err := h.integrityService.ListFlags(c.Context(), status, c.QueryInt("limit", 20), c.QueryInt("offset", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"flags": flags,
+	})
+}
+
+// ReviewFlag records a moderator decision on a flag
+// Endpoint: PUT /votes/flags/:flagId
+// Body: {"status": "confirmed"}
+func (h *IntegrityHandler) ReviewFlag(c *fiber.Ctx) error {
+	flagID, err := uuid.FromString(c.Params("flagId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "flagId")
+	}
+
+	var req models.ReviewFlagRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	flag, err := h.integrityService.ReviewFlag(c.Context(), flagID, user.UserID, req.Status)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(flag)
+}
@@ -7,12 +7,15 @@ package handlers
 
 import (
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	uuid "github.com/gofrs/uuid"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/internal/types"
 	"github.com/qolzam/telar/apps/api/votes/errors"
+	"github.com/qolzam/telar/apps/api/votes/models"
 	"github.com/qolzam/telar/apps/api/votes/services"
 )
 
@@ -79,3 +82,152 @@ func (h *VoteHandler) Vote(c *fiber.Ctx) error {
 	})
 }
 
+// maxVelocityHours bounds the velocity window to one week
+const maxVelocityHours = 168
+
+// GetVelocity returns hourly vote activity on a post for its author
+// Endpoint: GET /votes/posts/:postId/velocity?hours=24
+func (h *VoteHandler) GetVelocity(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	hours := c.QueryInt("hours", 24)
+	if hours < 1 || hours > maxVelocityHours {
+		return errors.HandleValidationError(c, "hours must be between 1 and 168")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	velocity, err := h.voteService.GetVelocity(c.Context(), postID, user, time.Duration(hours)*time.Hour)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(velocity)
+}
+
+// voteTypes maps the vote names used by the post routes to vote types
+var voteTypes = map[string]int{
+	"up":     models.VoteTypeUp,
+	"down":   models.VoteTypeDown,
+	"remove": models.VoteTypeNone,
+}
+
+// SetVoteRequest represents the request body for setting a vote on a post
+type SetVoteRequest struct {
+	Vote string `json:"vote"` // up, down or remove
+}
+
+// SetVote sets the caller's vote on a post. Unlike Vote it does not toggle: sending the
+// vote the caller already has changes nothing.
+// Endpoint: POST /posts/:postId/vote
+// Body: {"vote": "up" | "down" | "remove"}
+func (h *VoteHandler) SetVote(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	var req SetVoteRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleInvalidRequestError(c, "Invalid request body")
+	}
+	voteType, ok := voteTypes[req.Vote]
+	if !ok {
+		return errors.HandleValidationError(c, "vote must be up, down or remove")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	change, err := h.voteService.SetVote(c.Context(), postID, user.UserID, voteType)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(change)
+}
+
+// ListVoters lists a post's voters with cursor pagination. Without a type only up votes
+// are listed; type=down and type=all are for the post author.
+// Endpoint: GET /posts/:postId/voters?type=up&cursor=...&limit=...
+func (h *VoteHandler) ListVoters(c *fiber.Ctx) error {
+	postID, err := uuid.FromString(c.Params("postId"))
+	if err != nil {
+		return errors.HandleUUIDError(c, "postId")
+	}
+
+	voteType := models.VoteTypeUp
+	switch c.Query("type", "up") {
+	case "up":
+	case "down":
+		voteType = models.VoteTypeDown
+	case "all":
+		voteType = models.VoteTypeNone
+	default:
+		return errors.HandleValidationError(c, "type must be up, down or all")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	voters, err := h.voteService.ListVoters(c.Context(), postID, user, voteType, c.Query("cursor"), c.QueryInt("limit", 0))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(voters)
+}
+
+// maxBulkPosts bounds how many posts one bulk vote lookup may ask about
+const maxBulkPosts = 100
+
+// GetMyVotes returns the caller's votes on several posts at once
+// Endpoint: GET /votes/mine?postIds=uuid,uuid
+// Response: {"votes": {"<postId>": 0 | 1 | 2}}
+func (h *VoteHandler) GetMyVotes(c *fiber.Ctx) error {
+	raw := strings.Split(c.Query("postIds"), ",")
+	postIDs := make([]uuid.UUID, 0, len(raw))
+	for _, value := range raw {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		postID, err := uuid.FromString(value)
+		if err != nil {
+			return errors.HandleUUIDError(c, "postIds")
+		}
+		postIDs = append(postIDs, postID)
+	}
+	if len(postIDs) == 0 {
+		return errors.HandleValidationError(c, "postIds is required")
+	}
+	if len(postIDs) > maxBulkPosts {
+		return errors.HandleValidationError(c, "postIds accepts at most 100 posts")
+	}
+
+	user, ok := c.Locals(types.UserCtxName).(types.UserContext)
+	if !ok {
+		return errors.HandleUserContextError(c, "Invalid user context")
+	}
+
+	votes, err := h.voteService.GetVotes(c.Context(), user.UserID, postIDs)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	byPost := make(map[string]int, len(votes))
+	for postID, voteType := range votes {
+		byPost[postID.String()] = voteType
+	}
+	return c.Status(http.StatusOK).JSON(fiber.Map{"votes": byPost})
+}
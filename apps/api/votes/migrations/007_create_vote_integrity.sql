@@ -0,0 +1,53 @@
+-- Migration: 007_create_vote_integrity.sql
+-- Description: Append-only vote history and moderator flags for suspicious voting
+-- Dependencies: Requires votes table (006_create_votes_table.sql)
+
+-- Table: vote_events
+-- Purpose: Every cast, switch and retract of a vote. Rows are never updated or deleted,
+-- so flip-flopping and burst voting can be reconstructed after the fact.
+CREATE TABLE IF NOT EXISTS vote_events (
+    id BIGSERIAL PRIMARY KEY,
+    post_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('cast', 'switch', 'retract')),
+    previous_type SMALLINT NOT NULL DEFAULT 0, -- 0=none, 1=UpVote, 2=DownVote
+    vote_type SMALLINT NOT NULL DEFAULT 0,     -- 0 after a retract
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_vote_events_post_created ON vote_events(post_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_vote_events_user_created ON vote_events(user_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_vote_events_created ON vote_events(created_at);
+
+-- Enforce append-only at the database level
+CREATE OR REPLACE FUNCTION vote_events_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'vote_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_vote_events_append_only ON vote_events;
+CREATE TRIGGER trg_vote_events_append_only
+    BEFORE UPDATE OR DELETE ON vote_events
+    FOR EACH ROW EXECUTE FUNCTION vote_events_append_only();
+
+-- Table: vote_flags
+-- Purpose: Suspicious voting found by the integrity monitor, reviewed by moderators.
+-- fingerprint identifies the same finding across runs so it is flagged once.
+CREATE TABLE IF NOT EXISTS vote_flags (
+    id UUID PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('flip_flop', 'voting_ring')),
+    fingerprint TEXT NOT NULL,
+    author_id UUID,
+    post_ids UUID[] NOT NULL DEFAULT '{}',
+    user_ids UUID[] NOT NULL DEFAULT '{}',
+    event_count INT NOT NULL DEFAULT 0,
+    status VARCHAR(12) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'dismissed', 'confirmed')),
+    reviewed_by UUID,
+    reviewed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_seen_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_vote_flags_fingerprint ON vote_flags(fingerprint);
+CREATE INDEX IF NOT EXISTS idx_vote_flags_status_created ON vote_flags(status, created_at DESC);
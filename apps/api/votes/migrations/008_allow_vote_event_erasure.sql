@@ -0,0 +1,15 @@
+-- Migration: 008_allow_vote_event_erasure.sql
+-- Description: Lets the deletion of a user erase their vote history, which stays
+-- append-only for everything else
+-- Dependencies: Requires vote_events (007_create_vote_integrity.sql)
+
+-- A delete passes only for the rows of the user named by the transaction-local
+-- telar.erase_user setting; updates and every other delete are still refused
+CREATE OR REPLACE FUNCTION vote_events_append_only() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' AND OLD.user_id::text = current_setting('telar.erase_user', true) THEN
+        RETURN OLD;
+    END IF;
+    RAISE EXCEPTION 'vote_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,105 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package models
+
+import (
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+)
+
+// Vote event actions recorded in the append-only vote history
+const (
+	VoteActionCast    = "cast"    // First vote on a post
+	VoteActionSwitch  = "switch"  // Up to down or down to up
+	VoteActionRetract = "retract" // Vote toggled off
+)
+
+// VoteEvent is one change of a user's vote on a post
+type VoteEvent struct {
+	ID           int64     `db:"id" json:"id"`
+	PostID       uuid.UUID `db:"post_id" json:"postId"`
+	UserID       uuid.UUID `db:"user_id" json:"userId"`
+	Action       string    `db:"action" json:"action"`
+	PreviousType int       `db:"previous_type" json:"previousType"` // 0 when there was no vote
+	VoteType     int       `db:"vote_type" json:"voteType"`         // 0 after a retract
+	CreatedAt    time.Time `db:"created_at" json:"createdAt"`
+}
+
+// Flag kinds raised by the vote integrity monitor
+const (
+	FlagKindFlipFlop   = "flip_flop"   // One account repeatedly changing its vote on a post
+	FlagKindVotingRing = "voting_ring" // Accounts that keep voting together on one author's posts
+)
+
+// Flag review states
+const (
+	FlagStatusOpen      = "open"
+	FlagStatusDismissed = "dismissed"
+	FlagStatusConfirmed = "confirmed"
+)
+
+// IsValidFlagReview reports whether status is a state a moderator can set
+func IsValidFlagReview(status string) bool {
+	return status == FlagStatusDismissed || status == FlagStatusConfirmed || status == FlagStatusOpen
+}
+
+// VoteFlag is suspicious voting surfaced to moderators
+type VoteFlag struct {
+	ID          uuid.UUID   `json:"objectId"`
+	Kind        string      `json:"kind"`
+	Fingerprint string      `json:"-"`
+	AuthorID    *uuid.UUID  `json:"authorId,omitempty"` // Owner of the targeted posts
+	PostIDs     []uuid.UUID `json:"postIds"`
+	UserIDs     []uuid.UUID `json:"userIds"`
+	EventCount  int         `json:"eventCount"` // Vote changes (flip-flop) or posts voted on together (ring)
+	Status      string      `json:"status"`
+	ReviewedBy  *uuid.UUID  `json:"reviewedBy,omitempty"`
+	ReviewedAt  *time.Time  `json:"reviewedAt,omitempty"`
+	CreatedAt   time.Time   `json:"createdAt"`
+	LastSeenAt  time.Time   `json:"lastSeenAt"`
+}
+
+// FlipFlop is a user who changed their vote on a post many times in the lookback window
+type FlipFlop struct {
+	PostID  uuid.UUID `db:"post_id"`
+	UserID  uuid.UUID `db:"user_id"`
+	Changes int       `db:"changes"`
+}
+
+// CoVotingPair is two accounts that cast the same vote close together on several
+// posts of one author
+type CoVotingPair struct {
+	AuthorID    uuid.UUID   `db:"author_id"`
+	UserA       uuid.UUID   `db:"user_a"`
+	UserB       uuid.UUID   `db:"user_b"`
+	SharedPosts int         `db:"shared_posts"`
+	PostIDs     []uuid.UUID `db:"-"`
+}
+
+// ReviewFlagRequest is the PUT /votes/flags/:flagId request body
+type ReviewFlagRequest struct {
+	Status string `json:"status"` // "dismissed", "confirmed" or "open"
+}
+
+// VelocityBucket counts vote activity on a post in one hour
+type VelocityBucket struct {
+	Start     int64 `db:"-" json:"start"` // Unix milliseconds
+	Up        int   `db:"up" json:"up"`
+	Down      int   `db:"down" json:"down"`
+	Switched  int   `db:"switched" json:"switched"`
+	Retracted int   `db:"retracted" json:"retracted"`
+}
+
+// VoteVelocity is the per-post vote activity shown in author analytics
+type VoteVelocity struct {
+	PostID       uuid.UUID        `json:"postId"`
+	Since        int64            `json:"since"` // Unix milliseconds
+	Buckets      []VelocityBucket `json:"buckets"`
+	TotalVotes   int              `json:"totalVotes"`   // Votes cast in the window
+	VotesPerHour float64          `json:"votesPerHour"` // Average over the window
+	PeakPerHour  int              `json:"peakPerHour"`
+}
@@ -23,10 +23,37 @@ type Vote struct {
 
 // VoteType constants
 const (
+	VoteTypeNone = 0 // No vote; setting it removes the user's vote
 	VoteTypeUp   = 1 // UpVote (+1 score)
 	VoteTypeDown = 2 // DownVote (-1 score)
 )
 
+// VoteChange is the outcome of setting a vote: the user's vote before and after
+// (VoteTypeNone when absent) and the post's score once the change is applied
+type VoteChange struct {
+	PostID       uuid.UUID `json:"postId"`
+	PreviousType int       `json:"previousTypeId"`
+	VoteType     int       `json:"typeId"`
+	Score        int64     `json:"score"`
+}
+
+// Voter is a user listed as having voted on a post
+type Voter struct {
+	UserId     string `json:"userId"`
+	FullName   string `json:"fullName"`
+	SocialName string `json:"socialName"`
+	Avatar     string `json:"avatar"`
+	TypeId     int    `json:"typeId"`
+	VotedDate  int64  `json:"votedDate"` // Unix milliseconds
+}
+
+// VoterListResponse is a page of a post's voters, newest first
+type VoterListResponse struct {
+	Voters     []Voter `json:"voters"`
+	NextCursor string  `json:"nextCursor,omitempty"`
+	HasNext    bool    `json:"hasNext"`
+}
+
 // GetScoreValue returns the score delta for a vote type
 // 1 = Up (+1), 2 = Down (-1)
 func GetScoreValue(voteTypeID int) int {
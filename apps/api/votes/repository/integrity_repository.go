@@ -0,0 +1,39 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/votes/models"
+)
+
+// ErrFlagNotFound is returned when a vote flag does not exist
+var ErrFlagNotFound = errors.New("vote flag not found")
+
+// IntegrityRepository defines data access for vote manipulation detection:
+// heuristics over the vote history and the moderator flag queue.
+type IntegrityRepository interface {
+	// FindFlipFlops returns users with at least minChanges vote events on one post since the given time
+	FindFlipFlops(ctx context.Context, since time.Time, minChanges int) ([]models.FlipFlop, error)
+
+	// FindCoVotingPairs returns account pairs that cast the same vote within burstWindow of
+	// each other on at least minSharedPosts posts of the same author since the given time
+	FindCoVotingPairs(ctx context.Context, since time.Time, burstWindow time.Duration, minSharedPosts int) ([]models.CoVotingPair, error)
+
+	// UpsertFlag stores a flag, or refreshes the counts of the flag with the same fingerprint
+	// without touching its review state. Returns true when the flag is new.
+	UpsertFlag(ctx context.Context, flag *models.VoteFlag) (bool, error)
+
+	// ListFlags returns flags newest first; an empty status returns all
+	ListFlags(ctx context.Context, status string, limit, offset int) ([]*models.VoteFlag, error)
+
+	// UpdateFlagStatus records a moderator review; returns ErrFlagNotFound for unknown flags
+	UpdateFlagStatus(ctx context.Context, flagID uuid.UUID, status string, reviewerID uuid.UUID) (*models.VoteFlag, error)
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/votes/models"
+)
+
+// postgresIntegrityRepository implements IntegrityRepository using raw SQL queries
+type postgresIntegrityRepository struct {
+	client *postgres.Client
+}
+
+// NewPostgresIntegrityRepository creates a new PostgreSQL repository for vote integrity
+func NewPostgresIntegrityRepository(client *postgres.Client) IntegrityRepository {
+	return &postgresIntegrityRepository{client: client}
+}
+
+// flagRow is a vote_flags row
+type flagRow struct {
+	ID          uuid.UUID      `db:"id"`
+	Kind        string         `db:"kind"`
+	Fingerprint string         `db:"fingerprint"`
+	AuthorID    *uuid.UUID     `db:"author_id"`
+	PostIDs     pq.StringArray `db:"post_ids"`
+	UserIDs     pq.StringArray `db:"user_ids"`
+	EventCount  int            `db:"event_count"`
+	Status      string         `db:"status"`
+	ReviewedBy  *uuid.UUID     `db:"reviewed_by"`
+	ReviewedAt  *time.Time     `db:"reviewed_at"`
+	CreatedAt   time.Time      `db:"created_at"`
+	LastSeenAt  time.Time      `db:"last_seen_at"`
+}
+
+const flagColumns = `id, kind, fingerprint, author_id, post_ids::text[] AS post_ids, user_ids::text[] AS user_ids,
+	event_count, status, reviewed_by, reviewed_at, created_at, last_seen_at`
+
+func (row flagRow) toModel() *models.VoteFlag {
+	return &models.VoteFlag{
+		ID:          row.ID,
+		Kind:        row.Kind,
+		Fingerprint: row.Fingerprint,
+		AuthorID:    row.AuthorID,
+		PostIDs:     parseUUIDs(row.PostIDs),
+		UserIDs:     parseUUIDs(row.UserIDs),
+		EventCount:  row.EventCount,
+		Status:      row.Status,
+		ReviewedBy:  row.ReviewedBy,
+		ReviewedAt:  row.ReviewedAt,
+		CreatedAt:   row.CreatedAt,
+		LastSeenAt:  row.LastSeenAt,
+	}
+}
+
+// FindFlipFlops returns users who changed their vote on one post many times
+func (r *postgresIntegrityRepository) FindFlipFlops(ctx context.Context, since time.Time, minChanges int) ([]models.FlipFlop, error) {
+	query := `
+		SELECT post_id, user_id, COUNT(*) AS changes
+		FROM vote_events
+		WHERE created_at >= $1
+		GROUP BY post_id, user_id
+		HAVING COUNT(*) >= $2
+		ORDER BY changes DESC
+	`
+
+	var results []models.FlipFlop
+	if err := sqlx.SelectContext(ctx, r.client.DB(), &results, query, since, minChanges); err != nil {
+		return nil, fmt.Errorf("failed to find flip-flop voting: %w", err)
+	}
+
+	return results, nil
+}
+
+// FindCoVotingPairs returns account pairs that repeatedly vote together on one author's posts
+func (r *postgresIntegrityRepository) FindCoVotingPairs(ctx context.Context, since time.Time, burstWindow time.Duration, minSharedPosts int) ([]models.CoVotingPair, error) {
+	query := `
+		SELECT
+			p.owner_user_id AS author_id,
+			a.user_id AS user_a,
+			b.user_id AS user_b,
+			COUNT(DISTINCT a.post_id) AS shared_posts,
+			array_agg(DISTINCT a.post_id)::text[] AS post_ids
+		FROM vote_events a
+		JOIN vote_events b
+			ON b.post_id = a.post_id
+			AND b.user_id > a.user_id
+			AND b.action = 'cast'
+			AND b.vote_type = a.vote_type
+			AND b.created_at BETWEEN a.created_at - make_interval(secs => $2) AND a.created_at + make_interval(secs => $2)
+		JOIN posts p ON p.id = a.post_id
+		WHERE a.action = 'cast'
+		  AND a.created_at >= $1
+		  AND b.created_at >= $1
+		  AND a.user_id <> p.owner_user_id
+		  AND b.user_id <> p.owner_user_id
+		GROUP BY p.owner_user_id, a.user_id, b.user_id
+		HAVING COUNT(DISTINCT a.post_id) >= $3
+	`
+
+	var rows []struct {
+		models.CoVotingPair
+		PostIDs pq.StringArray `db:"post_ids"`
+	}
+	if err := sqlx.SelectContext(ctx, r.client.DB(), &rows, query, since, burstWindow.Seconds(), minSharedPosts); err != nil {
+		return nil, fmt.Errorf("failed to find co-voting accounts: %w", err)
+	}
+
+	pairs := make([]models.CoVotingPair, len(rows))
+	for i, row := range rows {
+		pairs[i] = row.CoVotingPair
+		pairs[i].PostIDs = parseUUIDs(row.PostIDs)
+	}
+
+	return pairs, nil
+}
+
+// UpsertFlag stores a flag or refreshes an existing one with the same fingerprint
+func (r *postgresIntegrityRepository) UpsertFlag(ctx context.Context, flag *models.VoteFlag) (bool, error) {
+	query := `
+		INSERT INTO vote_flags (id, kind, fingerprint, author_id, post_ids, user_ids, event_count, status, created_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5::uuid[], $6::uuid[], $7, 'open', NOW(), NOW())
+		ON CONFLICT (fingerprint) DO UPDATE SET
+			post_ids = EXCLUDED.post_ids,
+			event_count = GREATEST(vote_flags.event_count, EXCLUDED.event_count),
+			last_seen_at = NOW()
+		RETURNING (xmax = 0) AS created
+	`
+
+	var created bool
+	err := sqlx.GetContext(ctx, r.client.DB(), &created, query,
+		flag.ID, flag.Kind, flag.Fingerprint, flag.AuthorID,
+		pq.Array(uuidStrings(flag.PostIDs)), pq.Array(uuidStrings(flag.UserIDs)), flag.EventCount)
+	if err != nil {
+		return false, fmt.Errorf("failed to upsert vote flag: %w", err)
+	}
+
+	return created, nil
+}
+
+// ListFlags returns flags newest first, optionally filtered by status
+func (r *postgresIntegrityRepository) ListFlags(ctx context.Context, status string, limit, offset int) ([]*models.VoteFlag, error) {
+	query := `SELECT ` + flagColumns + `
+		FROM vote_flags
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	var rows []flagRow
+	if err := sqlx.SelectContext(ctx, r.client.DB(), &rows, query, status, limit, offset); err != nil {
+		return nil, fmt.Errorf("failed to list vote flags: %w", err)
+	}
+
+	flags := make([]*models.VoteFlag, len(rows))
+	for i, row := range rows {
+		flags[i] = row.toModel()
+	}
+
+	return flags, nil
+}
+
+// UpdateFlagStatus records a moderator review of a flag
+func (r *postgresIntegrityRepository) UpdateFlagStatus(ctx context.Context, flagID uuid.UUID, status string, reviewerID uuid.UUID) (*models.VoteFlag, error) {
+	query := `
+		UPDATE vote_flags
+		SET status = $2, reviewed_by = $3, reviewed_at = NOW()
+		WHERE id = $1
+		RETURNING ` + flagColumns
+
+	var row flagRow
+	if err := sqlx.GetContext(ctx, r.client.DB(), &row, query, flagID, status, reviewerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFlagNotFound
+		}
+		return nil, fmt.Errorf("failed to update vote flag: %w", err)
+	}
+
+	return row.toModel(), nil
+}
+
+func parseUUIDs(values []string) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(values))
+	for _, v := range values {
+		if id, err := uuid.FromString(v); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func uuidStrings(ids []uuid.UUID) []string {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
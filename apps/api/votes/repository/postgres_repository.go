@@ -8,8 +8,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
@@ -246,3 +249,254 @@ func (r *postgresVoteRepository) Delete(ctx context.Context, postID, userID uuid
 	return true, previousVoteType, nil // deleted=true, previous vote existed
 }
 
+// RecordEvent appends a vote change to the vote history
+func (r *postgresVoteRepository) RecordEvent(ctx context.Context, event *models.VoteEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	query := `
+		INSERT INTO vote_events (post_id, user_id, action, previous_type, vote_type, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+
+	err := sqlx.GetContext(ctx, r.getExecutor(ctx), &event.ID, query,
+		event.PostID, event.UserID, event.Action, event.PreviousType, event.VoteType, event.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record vote event: %w", err)
+	}
+
+	return nil
+}
+
+// GetVelocity returns hourly vote activity on a post since the given time
+func (r *postgresVoteRepository) GetVelocity(ctx context.Context, postID uuid.UUID, since time.Time) ([]models.VelocityBucket, error) {
+	query := `
+		SELECT
+			date_trunc('hour', created_at) AS bucket,
+			COUNT(*) FILTER (WHERE action = 'cast' AND vote_type = 1) AS up,
+			COUNT(*) FILTER (WHERE action = 'cast' AND vote_type = 2) AS down,
+			COUNT(*) FILTER (WHERE action = 'switch') AS switched,
+			COUNT(*) FILTER (WHERE action = 'retract') AS retracted
+		FROM vote_events
+		WHERE post_id = $1 AND created_at >= $2
+		GROUP BY bucket
+		ORDER BY bucket
+	`
+
+	var rows []struct {
+		Bucket time.Time `db:"bucket"`
+		models.VelocityBucket
+	}
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query, postID, since); err != nil {
+		return nil, fmt.Errorf("failed to get vote velocity: %w", err)
+	}
+
+	buckets := make([]models.VelocityBucket, len(rows))
+	for i, row := range rows {
+		buckets[i] = row.VelocityBucket
+		buckets[i].Start = row.Bucket.UnixMilli()
+	}
+
+	return buckets, nil
+}
+
+// SetVote applies a user's vote, its history entry and the score change in one transaction
+func (r *postgresVoteRepository) SetVote(ctx context.Context, postID, userID uuid.UUID, voteType int) (*models.VoteChange, error) {
+	change := &models.VoteChange{PostID: postID, VoteType: voteType}
+
+	err := r.inTransaction(ctx, func(txCtx context.Context) error {
+		exec := r.getExecutor(txCtx)
+
+		// Lock the post so concurrent votes on it see each other's changes
+		err := sqlx.GetContext(txCtx, exec, &change.Score,
+			`SELECT score FROM posts WHERE id = $1 AND is_deleted = FALSE FOR UPDATE`, postID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return ErrPostNotFound
+			}
+			return fmt.Errorf("failed to lock post: %w", err)
+		}
+
+		err = sqlx.GetContext(txCtx, exec, &change.PreviousType,
+			`SELECT vote_type_id FROM votes WHERE post_id = $1 AND owner_user_id = $2`, postID, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("failed to find vote: %w", err)
+		}
+		if change.PreviousType == voteType {
+			return nil
+		}
+
+		event := &models.VoteEvent{PostID: postID, UserID: userID, PreviousType: change.PreviousType, VoteType: voteType}
+		switch {
+		case voteType == models.VoteTypeNone:
+			event.Action = models.VoteActionRetract
+			_, err = exec.ExecContext(txCtx, `DELETE FROM votes WHERE post_id = $1 AND owner_user_id = $2`, postID, userID)
+		case change.PreviousType == models.VoteTypeNone:
+			event.Action = models.VoteActionCast
+			voteID, idErr := uuid.NewV4()
+			if idErr != nil {
+				return fmt.Errorf("failed to generate vote ID: %w", idErr)
+			}
+			_, err = exec.ExecContext(txCtx, `
+				INSERT INTO votes (id, post_id, owner_user_id, vote_type_id, created_at)
+				VALUES ($1, $2, $3, $4, NOW())
+			`, voteID, postID, userID, voteType)
+		default:
+			event.Action = models.VoteActionSwitch
+			_, err = exec.ExecContext(txCtx, `UPDATE votes SET vote_type_id = $1 WHERE post_id = $2 AND owner_user_id = $3`, voteType, postID, userID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to %s vote: %w", event.Action, err)
+		}
+
+		if err := r.RecordEvent(txCtx, event); err != nil {
+			return err
+		}
+
+		delta := models.GetScoreValue(voteType) - models.GetScoreValue(change.PreviousType)
+		err = sqlx.GetContext(txCtx, exec, &change.Score, `
+			UPDATE posts
+			SET score = score + $1,
+			    updated_at = NOW(),
+			    last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT
+			WHERE id = $2
+			RETURNING score
+		`, delta, postID)
+		if err != nil {
+			return fmt.Errorf("failed to update post score: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return change, nil
+}
+
+// ListVoters pages through the votes on a post, ordered by created_at and voter, newest first
+func (r *postgresVoteRepository) ListVoters(ctx context.Context, postID uuid.UUID, voteType int, cursor string, limit int) ([]models.Vote, string, error) {
+	query := `SELECT id, post_id, owner_user_id, vote_type_id, created_at FROM votes WHERE post_id = $1`
+	args := []interface{}{postID}
+
+	if voteType != models.VoteTypeNone {
+		args = append(args, voteType)
+		query += fmt.Sprintf(` AND vote_type_id = $%d`, len(args))
+	}
+	if cursor != "" {
+		createdAt, userID, err := decodeVoterCursor(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+		}
+		args = append(args, createdAt, userID)
+		query += fmt.Sprintf(` AND (created_at, owner_user_id) < ($%d, $%d)`, len(args)-1, len(args))
+	}
+
+	args = append(args, limit+1) // Fetch one extra to determine if there's a next page
+	query += fmt.Sprintf(` ORDER BY created_at DESC, owner_user_id DESC LIMIT $%d`, len(args))
+
+	var votes []models.Vote
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &votes, query, args...); err != nil {
+		return nil, "", fmt.Errorf("failed to list voters: %w", err)
+	}
+
+	nextCursor := ""
+	if len(votes) > limit {
+		votes = votes[:limit]
+		last := votes[len(votes)-1]
+		nextCursor = encodeVoterCursor(last.CreatedAt, last.OwnerUserID)
+	}
+	return votes, nextCursor, nil
+}
+
+// DeleteByUser erases the user's voting. vote_events only accepts the deletion of the
+// rows of the user named by telar.erase_user, which is set for this transaction only.
+func (r *postgresVoteRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	return r.inTransaction(ctx, func(txCtx context.Context) error {
+		exec := r.getExecutor(txCtx)
+		steps := []struct {
+			action string
+			query  string
+			args   []interface{}
+		}{
+			{"take votes out of scores", `
+				UPDATE posts p
+				SET score = p.score - v.delta,
+				    updated_at = NOW(),
+				    last_updated = EXTRACT(EPOCH FROM NOW())::BIGINT
+				FROM (
+					SELECT post_id, SUM(CASE vote_type_id WHEN 1 THEN 1 WHEN 2 THEN -1 ELSE 0 END) AS delta
+					FROM votes WHERE owner_user_id = $1 GROUP BY post_id
+				) v
+				WHERE p.id = v.post_id`, []interface{}{userID}},
+			{"delete votes", `DELETE FROM votes WHERE owner_user_id = $1`, []interface{}{userID}},
+			{"allow erasing vote history", `SELECT set_config('telar.erase_user', $1, true)`, []interface{}{userID.String()}},
+			{"delete vote history", `DELETE FROM vote_events WHERE user_id = $1`, []interface{}{userID}},
+			{"close vote history", `SELECT set_config('telar.erase_user', '', true)`, nil},
+			{"delete vote flags", `DELETE FROM vote_flags WHERE author_id = $1`, []interface{}{userID}},
+			{"remove user from vote flags", `UPDATE vote_flags SET user_ids = array_remove(user_ids, $1) WHERE $1 = ANY(user_ids)`, []interface{}{userID}},
+			{"forget vote flag reviewer", `UPDATE vote_flags SET reviewed_by = NULL WHERE reviewed_by = $1`, []interface{}{userID}},
+		}
+		for _, step := range steps {
+			if _, err := exec.ExecContext(txCtx, step.query, step.args...); err != nil {
+				return fmt.Errorf("failed to %s: %w", step.action, err)
+			}
+		}
+		return nil
+	})
+}
+
+// inTransaction runs fn in the transaction carried by ctx, or in a new one
+func (r *postgresVoteRepository) inTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if _, ok := ctx.Value("tx").(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.client.DB().BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	if r.schema != "" {
+		if _, err := tx.ExecContext(ctx, fmt.Sprintf(`SET search_path TO %s`, r.schema)); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("failed to set search_path in transaction (schema=%s): %w", r.schema, err)
+		}
+	}
+
+	if err := fn(context.WithValue(ctx, "tx", tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("transaction error: %w, rollback error: %v", err, rbErr)
+		}
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// encodeVoterCursor packs the last vote of a page as base64(created_at micros:uuid)
+func encodeVoterCursor(createdAt time.Time, userID uuid.UUID) string {
+	return base64.URLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", createdAt.UnixMicro(), userID.String())))
+}
+
+func decodeVoterCursor(cursor string) (time.Time, uuid.UUID, error) {
+	decoded, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	parts := strings.Split(string(decoded), ":")
+	if len(parts) != 2 {
+		return time.Time{}, uuid.Nil, fmt.Errorf("expected created_at:uuid")
+	}
+	micros, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	userID, err := uuid.FromString(parts[1])
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+	return time.UnixMicro(micros), userID, nil
+}
@@ -7,11 +7,19 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	uuid "github.com/gofrs/uuid"
 	"github.com/qolzam/telar/apps/api/votes/models"
 )
 
+// Errors returned by SetVote and ListVoters
+var (
+	ErrPostNotFound  = errors.New("post not found")
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
 // VoteRepository defines the interface for vote-specific database operations
 // This is a domain-specific repository that knows exactly what a "Vote" is
 // and how to execute optimized SQL queries for that specific domain.
@@ -38,5 +46,28 @@ type VoteRepository interface {
 	// Returns a map of postID -> voteTypeID (0 if no vote exists)
 	// This avoids N+1 queries when enriching post lists with vote status
 	GetVotesForPosts(ctx context.Context, postIDs []uuid.UUID, userID uuid.UUID) (map[uuid.UUID]int, error)
+
+	// RecordEvent appends a vote change to the vote history (vote_events)
+	// Call it in the same transaction as the vote change so history and votes agree
+	RecordEvent(ctx context.Context, event *models.VoteEvent) error
+
+	// GetVelocity returns hourly vote activity on a post since the given time, oldest first
+	// Hours without activity are absent
+	GetVelocity(ctx context.Context, postID uuid.UUID, since time.Time) ([]models.VelocityBucket, error)
+
+	// SetVote makes voteType the user's vote on a post (VoteTypeNone removes it), records
+	// the change in the vote history and moves posts.score by the difference, all in one
+	// transaction. The post row is locked first, so concurrent votes on a post apply one
+	// at a time. Returns ErrPostNotFound for a missing or deleted post.
+	SetVote(ctx context.Context, postID, userID uuid.UUID, voteType int) (*models.VoteChange, error)
+
+	// ListVoters pages through the votes on a post, newest first, optionally limited to
+	// one vote type (VoteTypeNone lists both). Returns the page and the next cursor,
+	// which is empty on the last page.
+	ListVoters(ctx context.Context, postID uuid.UUID, voteType int, cursor string, limit int) ([]models.Vote, string, error)
+
+	// DeleteByUser takes the user's votes back out of the posts' scores and deletes them,
+	// their history and the user from vote flags, all in one transaction
+	DeleteByUser(ctx context.Context, userID uuid.UUID) error
 }
 
@@ -7,6 +7,8 @@ package votes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	adminmw "github.com/qolzam/telar/apps/api/internal/middleware/admin"
+	"github.com/qolzam/telar/apps/api/internal/middleware/constraints"
 	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
 	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
 	"github.com/qolzam/telar/apps/api/votes/handlers"
@@ -14,7 +16,8 @@ import (
 
 // VotesHandlers holds all the handlers this router needs
 type VotesHandlers struct {
-	VoteHandler *handlers.VoteHandler
+	VoteHandler      *handlers.VoteHandler
+	IntegrityHandler *handlers.IntegrityHandler
 }
 
 // RouterConfig holds the configuration needed for the router's middleware
@@ -49,5 +52,24 @@ func RegisterRoutes(app *fiber.App, handlers *VotesHandlers, cfg *platformconfig
 
 	// Vote endpoint: POST /votes
 	userGroup.Post("/", handlers.VoteHandler.Vote)
+
+	// The caller's votes on several posts: GET /votes/mine?postIds=...
+	userGroup.Get("/mine", handlers.VoteHandler.GetMyVotes)
+
+	// Vote velocity for the post author: GET /votes/posts/:postId/velocity
+	userGroup.Get("/posts/:postId/velocity", handlers.VoteHandler.GetVelocity)
+
+	// --- Post Sub-Resource Routes (Dual Auth) ---
+	// Registered per route: a /posts group middleware would also run on the posts module's
+	// public routes.
+	app.Post("/posts/:postId/vote", dualAuthMiddleware, constraints.RequireUUID("postId"), handlers.VoteHandler.SetVote)
+	app.Get("/posts/:postId/voters", dualAuthMiddleware, constraints.RequireUUID("postId"), handlers.VoteHandler.ListVoters)
+
+	// --- Moderator Routes (Admin) ---
+	if handlers.IntegrityHandler != nil {
+		adminGroup := group.Group("/flags", dualAuthMiddleware, adminmw.New(adminmw.Config{}))
+		adminGroup.Get("/", handlers.IntegrityHandler.ListFlags)
+		adminGroup.Put("/:flagId", handlers.IntegrityHandler.ReviewFlag)
+	}
 }
 
@@ -0,0 +1,212 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	voteErrors "github.com/qolzam/telar/apps/api/votes/errors"
+	"github.com/qolzam/telar/apps/api/votes/models"
+	voteRepository "github.com/qolzam/telar/apps/api/votes/repository"
+)
+
+// IntegrityService runs vote manipulation heuristics over the vote history and
+// manages the flags they raise for moderators
+type IntegrityService interface {
+	// Scan runs every heuristic once and returns how many new flags it raised
+	Scan(ctx context.Context) (int, error)
+
+	// Start runs Scan every configured interval until ctx is done; a no-op when disabled
+	Start(ctx context.Context)
+
+	// ListFlags returns flags newest first; an empty status returns all
+	ListFlags(ctx context.Context, status string, limit, offset int) ([]*models.VoteFlag, error)
+
+	// ReviewFlag records a moderator decision on a flag
+	ReviewFlag(ctx context.Context, flagID, reviewerID uuid.UUID, status string) (*models.VoteFlag, error)
+}
+
+type integrityService struct {
+	repo voteRepository.IntegrityRepository
+	cfg  platformconfig.VoteIntegrityConfig
+	now  func() time.Time
+}
+
+// NewIntegrityService creates the vote integrity service
+func NewIntegrityService(repo voteRepository.IntegrityRepository, cfg platformconfig.VoteIntegrityConfig) IntegrityService {
+	return &integrityService{repo: repo, cfg: cfg, now: time.Now}
+}
+
+func (s *integrityService) Start(ctx context.Context) {
+	if !s.cfg.Enabled || s.cfg.Interval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(s.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := s.Scan(ctx); err != nil {
+					log.Error("Vote integrity scan failed: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *integrityService) Scan(ctx context.Context) (int, error) {
+	since := s.now().Add(-s.cfg.Lookback)
+	var flags []*models.VoteFlag
+
+	flipFlops, err := s.repo.FindFlipFlops(ctx, since, s.cfg.FlipFlopMinChanges)
+	if err != nil {
+		return 0, err
+	}
+	for _, ff := range flipFlops {
+		flags = append(flags, &models.VoteFlag{
+			Kind:        models.FlagKindFlipFlop,
+			Fingerprint: fmt.Sprintf("%s:%s:%s", models.FlagKindFlipFlop, ff.PostID, ff.UserID),
+			PostIDs:     []uuid.UUID{ff.PostID},
+			UserIDs:     []uuid.UUID{ff.UserID},
+			EventCount:  ff.Changes,
+		})
+	}
+
+	pairs, err := s.repo.FindCoVotingPairs(ctx, since, s.cfg.BurstWindow, s.cfg.RingMinSharedPosts)
+	if err != nil {
+		return 0, err
+	}
+	flags = append(flags, votingRings(pairs, s.cfg.RingMinAccounts)...)
+
+	raised := 0
+	for _, flag := range flags {
+		flag.ID = uuid.Must(uuid.NewV4())
+		created, err := s.repo.UpsertFlag(ctx, flag)
+		if err != nil {
+			return raised, err
+		}
+		if created {
+			raised++
+			log.Warn("Suspicious voting flagged for moderators: kind=%s accounts=%d posts=%d", flag.Kind, len(flag.UserIDs), len(flag.PostIDs))
+		}
+	}
+	return raised, nil
+}
+
+func (s *integrityService) ListFlags(ctx context.Context, status string, limit, offset int) ([]*models.VoteFlag, error) {
+	if status != "" && !models.IsValidFlagReview(status) {
+		return nil, fmt.Errorf("%w: unknown status %q", voteErrors.ErrInvalidRequest, status)
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	flags, err := s.repo.ListFlags(ctx, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", voteErrors.ErrDatabaseOperation, err)
+	}
+	return flags, nil
+}
+
+func (s *integrityService) ReviewFlag(ctx context.Context, flagID, reviewerID uuid.UUID, status string) (*models.VoteFlag, error) {
+	if !models.IsValidFlagReview(status) {
+		return nil, fmt.Errorf("%w: status must be open, dismissed or confirmed", voteErrors.ErrInvalidRequest)
+	}
+	flag, err := s.repo.UpdateFlagStatus(ctx, flagID, status, reviewerID)
+	if err != nil {
+		if errors.Is(err, voteRepository.ErrFlagNotFound) {
+			return nil, voteErrors.ErrFlagNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", voteErrors.ErrDatabaseOperation, err)
+	}
+	return flag, nil
+}
+
+// votingRings groups co-voting pairs of each author into connected sets of accounts
+// and returns a flag for every set of at least minAccounts
+func votingRings(pairs []models.CoVotingPair, minAccounts int) []*models.VoteFlag {
+	byAuthor := map[uuid.UUID][]models.CoVotingPair{}
+	for _, pair := range pairs {
+		byAuthor[pair.AuthorID] = append(byAuthor[pair.AuthorID], pair)
+	}
+
+	var flags []*models.VoteFlag
+	for authorID, authorPairs := range byAuthor {
+		parent := map[uuid.UUID]uuid.UUID{}
+		var find func(uuid.UUID) uuid.UUID
+		find = func(id uuid.UUID) uuid.UUID {
+			if p, ok := parent[id]; ok && p != id {
+				root := find(p)
+				parent[id] = root
+				return root
+			}
+			parent[id] = id
+			return id
+		}
+		for _, pair := range authorPairs {
+			parent[find(pair.UserA)] = find(pair.UserB)
+		}
+
+		members := map[uuid.UUID]map[uuid.UUID]bool{}
+		posts := map[uuid.UUID]map[uuid.UUID]bool{}
+		for _, pair := range authorPairs {
+			root := find(pair.UserA)
+			if members[root] == nil {
+				members[root] = map[uuid.UUID]bool{}
+				posts[root] = map[uuid.UUID]bool{}
+			}
+			members[root][pair.UserA] = true
+			members[root][pair.UserB] = true
+			for _, postID := range pair.PostIDs {
+				posts[root][postID] = true
+			}
+		}
+
+		for root, accounts := range members {
+			if len(accounts) < minAccounts {
+				continue
+			}
+			userIDs := sortedIDs(accounts)
+			postIDs := sortedIDs(posts[root])
+			author := authorID
+			keys := make([]string, len(userIDs))
+			for i, id := range userIDs {
+				keys[i] = id.String()
+			}
+			flags = append(flags, &models.VoteFlag{
+				Kind:        models.FlagKindVotingRing,
+				Fingerprint: fmt.Sprintf("%s:%s:%s", models.FlagKindVotingRing, authorID, strings.Join(keys, ",")),
+				AuthorID:    &author,
+				PostIDs:     postIDs,
+				UserIDs:     userIDs,
+				EventCount:  len(postIDs),
+			})
+		}
+	}
+	return flags
+}
+
+func sortedIDs(set map[uuid.UUID]bool) []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids
+}
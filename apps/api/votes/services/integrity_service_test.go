@@ -0,0 +1,100 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	voteErrors "github.com/qolzam/telar/apps/api/votes/errors"
+	"github.com/qolzam/telar/apps/api/votes/models"
+	voteRepository "github.com/qolzam/telar/apps/api/votes/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func testIntegrityConfig() platformconfig.VoteIntegrityConfig {
+	return platformconfig.VoteIntegrityConfig{
+		Enabled:            true,
+		Interval:           time.Minute,
+		Lookback:           24 * time.Hour,
+		FlipFlopMinChanges: 6,
+		BurstWindow:        10 * time.Minute,
+		RingMinAccounts:    3,
+		RingMinSharedPosts: 3,
+	}
+}
+
+func TestIntegrityService_Scan(t *testing.T) {
+	ctx := context.Background()
+	author := uuid.Must(uuid.NewV4())
+	otherAuthor := uuid.Must(uuid.NewV4())
+	a, b, c, d := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+	post1, post2 := uuid.Must(uuid.NewV4()), uuid.Must(uuid.NewV4())
+
+	repo := new(MockIntegrityRepository)
+	service := NewIntegrityService(repo, testIntegrityConfig())
+
+	repo.On("FindFlipFlops", ctx, mock.AnythingOfType("time.Time"), 6).Return([]models.FlipFlop{
+		{PostID: post1, UserID: d, Changes: 8},
+	}, nil)
+	// a-b and b-c form a ring of three on one author; the lone pair on another author does not
+	repo.On("FindCoVotingPairs", ctx, mock.AnythingOfType("time.Time"), 10*time.Minute, 3).Return([]models.CoVotingPair{
+		{AuthorID: author, UserA: a, UserB: b, SharedPosts: 3, PostIDs: []uuid.UUID{post1}},
+		{AuthorID: author, UserA: b, UserB: c, SharedPosts: 3, PostIDs: []uuid.UUID{post2}},
+		{AuthorID: otherAuthor, UserA: a, UserB: d, SharedPosts: 4, PostIDs: []uuid.UUID{post1}},
+	}, nil)
+
+	var ring *models.VoteFlag
+	repo.On("UpsertFlag", ctx, mock.MatchedBy(func(flag *models.VoteFlag) bool {
+		return flag.Kind == models.FlagKindFlipFlop && flag.EventCount == 8
+	})).Return(false, nil)
+	repo.On("UpsertFlag", ctx, mock.MatchedBy(func(flag *models.VoteFlag) bool {
+		return flag.Kind == models.FlagKindVotingRing
+	})).Return(true, nil).Run(func(args mock.Arguments) {
+		ring = args.Get(1).(*models.VoteFlag)
+	})
+
+	raised, err := service.Scan(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, raised, "only the new ring counts; the flip-flop flag already existed")
+	if assert.NotNil(t, ring) {
+		assert.Equal(t, author, *ring.AuthorID)
+		assert.ElementsMatch(t, []uuid.UUID{a, b, c}, ring.UserIDs)
+		assert.ElementsMatch(t, []uuid.UUID{post1, post2}, ring.PostIDs)
+	}
+	repo.AssertNumberOfCalls(t, "UpsertFlag", 2)
+}
+
+func TestIntegrityService_ReviewFlag(t *testing.T) {
+	ctx := context.Background()
+	flagID := uuid.Must(uuid.NewV4())
+	reviewer := uuid.Must(uuid.NewV4())
+
+	t.Run("Invalid status", func(t *testing.T) {
+		repo := new(MockIntegrityRepository)
+		service := NewIntegrityService(repo, testIntegrityConfig())
+
+		_, err := service.ReviewFlag(ctx, flagID, reviewer, "deleted")
+
+		assert.ErrorIs(t, err, voteErrors.ErrInvalidRequest)
+		repo.AssertNotCalled(t, "UpdateFlagStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown flag", func(t *testing.T) {
+		repo := new(MockIntegrityRepository)
+		service := NewIntegrityService(repo, testIntegrityConfig())
+		repo.On("UpdateFlagStatus", ctx, flagID, models.FlagStatusConfirmed, reviewer).Return(nil, voteRepository.ErrFlagNotFound)
+
+		_, err := service.ReviewFlag(ctx, flagID, reviewer, models.FlagStatusConfirmed)
+
+		assert.ErrorIs(t, err, voteErrors.ErrFlagNotFound)
+	})
+}
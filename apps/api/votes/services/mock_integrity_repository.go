@@ -0,0 +1,66 @@
+// Copyright (c) 2024 Telar Social
+//
+// This software is released under the MIT License.
+// https://opensource.org/licenses/MIT
+
+package services
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/votes/models"
+	voteRepository "github.com/qolzam/telar/apps/api/votes/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockIntegrityRepository is a mock implementation of IntegrityRepository for testing
+type MockIntegrityRepository struct {
+	mock.Mock
+}
+
+// Ensure MockIntegrityRepository implements IntegrityRepository
+var _ voteRepository.IntegrityRepository = (*MockIntegrityRepository)(nil)
+
+// FindFlipFlops mocks the FindFlipFlops method
+func (m *MockIntegrityRepository) FindFlipFlops(ctx context.Context, since time.Time, minChanges int) ([]models.FlipFlop, error) {
+	args := m.Called(ctx, since, minChanges)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.FlipFlop), args.Error(1)
+}
+
+// FindCoVotingPairs mocks the FindCoVotingPairs method
+func (m *MockIntegrityRepository) FindCoVotingPairs(ctx context.Context, since time.Time, burstWindow time.Duration, minSharedPosts int) ([]models.CoVotingPair, error) {
+	args := m.Called(ctx, since, burstWindow, minSharedPosts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CoVotingPair), args.Error(1)
+}
+
+// UpsertFlag mocks the UpsertFlag method
+func (m *MockIntegrityRepository) UpsertFlag(ctx context.Context, flag *models.VoteFlag) (bool, error) {
+	args := m.Called(ctx, flag)
+	return args.Bool(0), args.Error(1)
+}
+
+// ListFlags mocks the ListFlags method
+func (m *MockIntegrityRepository) ListFlags(ctx context.Context, status string, limit, offset int) ([]*models.VoteFlag, error) {
+	args := m.Called(ctx, status, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.VoteFlag), args.Error(1)
+}
+
+// UpdateFlagStatus mocks the UpdateFlagStatus method
+func (m *MockIntegrityRepository) UpdateFlagStatus(ctx context.Context, flagID uuid.UUID, status string, reviewerID uuid.UUID) (*models.VoteFlag, error) {
+	args := m.Called(ctx, flagID, status, reviewerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VoteFlag), args.Error(1)
+}
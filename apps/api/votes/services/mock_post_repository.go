@@ -102,6 +102,37 @@ func (m *MockPostRepositoryForVotes) UpdateOwnerProfile(ctx context.Context, own
 	return args.Error(0)
 }
 
+func (m *MockPostRepositoryForVotes) ReplaceImage(ctx context.Context, ownerID uuid.UUID, key, fullPath, thumbnail string) ([]uuid.UUID, error) {
+	args := m.Called(ctx, ownerID, key, fullPath, thumbnail)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) UpdateMediaText(ctx context.Context, postID uuid.UUID, mediaText string) error {
+	args := m.Called(ctx, postID, mediaText)
+	return args.Error(0)
+}
+
+func (m *MockPostRepositoryForVotes) ArchiveExpired(ctx context.Context, now int64, limit int) (int64, error) {
+	args := m.Called(ctx, now, limit)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) GetReadMarkers(ctx context.Context, userID uuid.UUID, feedKeys []string) (map[string]int64, error) {
+	args := m.Called(ctx, userID, feedKeys)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) SaveReadMarker(ctx context.Context, userID uuid.UUID, feedKey string, lastReadDate int64) error {
+	args := m.Called(ctx, userID, feedKey, lastReadDate)
+	return args.Error(0)
+}
+
 func (m *MockPostRepositoryForVotes) SetCommentDisabled(ctx context.Context, postID uuid.UUID, disabled bool, ownerID uuid.UUID) error {
 	args := m.Called(ctx, postID, disabled, ownerID)
 	return args.Error(0)
@@ -117,6 +148,11 @@ func (m *MockPostRepositoryForVotes) IncrementViewCount(ctx context.Context, pos
 	return args.Error(0)
 }
 
+func (m *MockPostRepositoryForVotes) IncrementTipCount(ctx context.Context, postID uuid.UUID, delta int) error {
+	args := m.Called(ctx, postID, delta)
+	return args.Error(0)
+}
+
 func (m *MockPostRepositoryForVotes) IncrementCommentCount(ctx context.Context, postID uuid.UUID, delta int) error {
 	args := m.Called(ctx, postID, delta)
 	return args.Error(0)
@@ -127,12 +163,38 @@ func (m *MockPostRepositoryForVotes) Delete(ctx context.Context, id uuid.UUID) e
 	return args.Error(0)
 }
 
-func (m *MockPostRepositoryForVotes) Search(ctx context.Context, query string, limit int) ([]*models.Post, error) {
-	args := m.Called(ctx, query, limit)
+func (m *MockPostRepositoryForVotes) DeleteByOwner(ctx context.Context, ownerID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, ownerID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) FullTextSearch(ctx context.Context, query models.PostSearchQuery) ([]repository.SearchHit, bool, error) {
+	args := m.Called(ctx, query)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, args.Bool(1), args.Error(2)
 	}
-	return args.Get(0).([]*models.Post), args.Error(1)
+	return args.Get(0).([]repository.SearchHit), args.Bool(1), args.Error(2)
+}
+
+func (m *MockPostRepositoryForVotes) FindByTag(ctx context.Context, tag string, within models.TimeRange, after *models.TagCursor, limit int) ([]*models.Post, bool, error) {
+	args := m.Called(ctx, tag, within, after, limit)
+	if args.Get(0) == nil {
+		return nil, args.Bool(1), args.Error(2)
+	}
+	return args.Get(0).([]*models.Post), args.Bool(1), args.Error(2)
+}
+
+func (m *MockPostRepositoryForVotes) RefreshTrendingTags(ctx context.Context, window string, since, computedDate int64, limit int) error {
+	args := m.Called(ctx, window, since, computedDate, limit)
+	return args.Error(0)
+}
+
+func (m *MockPostRepositoryForVotes) ListTrendingTags(ctx context.Context, window string) ([]models.TrendingTag, int64, error) {
+	args := m.Called(ctx, window)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]models.TrendingTag), args.Get(1).(int64), args.Error(2)
 }
 
 func (m *MockPostRepositoryForVotes) GetByIDs(ctx context.Context, ids []uuid.UUID) ([]*models.Post, error) {
@@ -142,3 +204,119 @@ func (m *MockPostRepositoryForVotes) GetByIDs(ctx context.Context, ids []uuid.UU
 	}
 	return args.Get(0).([]*models.Post), args.Error(1)
 }
+
+func (m *MockPostRepositoryForVotes) AddCoauthor(ctx context.Context, coauthor *models.PostCoauthor) (bool, error) {
+	args := m.Called(ctx, coauthor)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) GetCoauthor(ctx context.Context, postID, userID uuid.UUID) (*models.PostCoauthor, error) {
+	args := m.Called(ctx, postID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.PostCoauthor), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) AcceptCoauthor(ctx context.Context, postID, userID uuid.UUID, displayName, avatar string, respondedDate int64) (bool, error) {
+	args := m.Called(ctx, postID, userID, displayName, avatar, respondedDate)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) RemoveCoauthor(ctx context.Context, postID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, postID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) DeleteReadMarkersByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockPostRepositoryForVotes) DeleteCoauthorsByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockPostRepositoryForVotes) AddCommunityPlacement(ctx context.Context, placement *models.CommunityPlacement) (bool, error) {
+	args := m.Called(ctx, placement)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) RemoveCommunityPlacement(ctx context.Context, postID, communityID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, postID, communityID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) ListCommunityPlacements(ctx context.Context, postID uuid.UUID) ([]models.CommunityPlacement, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.CommunityPlacement), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) ListCoauthors(ctx context.Context, postIDs []uuid.UUID, status string) (map[uuid.UUID][]models.PostCoauthor, error) {
+	args := m.Called(ctx, postIDs, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]models.PostCoauthor), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) ListPendingCoauthorInvites(ctx context.Context, userID uuid.UUID) ([]models.PostCoauthor, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PostCoauthor), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) SetAcceptedAnswer(ctx context.Context, postID uuid.UUID, commentID *uuid.UUID, ownerID uuid.UUID) error {
+	args := m.Called(ctx, postID, commentID, ownerID)
+	return args.Error(0)
+}
+
+func (m *MockPostRepositoryForVotes) ClearAcceptedAnswer(ctx context.Context, commentID uuid.UUID) error {
+	args := m.Called(ctx, commentID)
+	return args.Error(0)
+}
+
+func (m *MockPostRepositoryForVotes) SaveLiveThread(ctx context.Context, thread *models.LiveThread) error {
+	args := m.Called(ctx, thread)
+	return args.Error(0)
+}
+
+func (m *MockPostRepositoryForVotes) GetLiveThread(ctx context.Context, postID uuid.UUID) (*models.LiveThread, error) {
+	args := m.Called(ctx, postID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.LiveThread), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) DeleteLiveThread(ctx context.Context, postID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, postID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) CloseEndedLiveThreads(ctx context.Context, now int64, limit int) ([]*models.LiveThread, error) {
+	args := m.Called(ctx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LiveThread), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) ListUnsummarizedLiveThreads(ctx context.Context, maxAttempts, limit int) ([]*models.LiveThread, error) {
+	args := m.Called(ctx, maxAttempts, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.LiveThread), args.Error(1)
+}
+
+func (m *MockPostRepositoryForVotes) SaveLiveThreadSummary(ctx context.Context, postID uuid.UUID, summary string, keyPoints []string) error {
+	args := m.Called(ctx, postID, summary, keyPoints)
+	return args.Error(0)
+}
@@ -7,6 +7,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	uuid "github.com/gofrs/uuid"
 	"github.com/stretchr/testify/mock"
@@ -52,3 +53,40 @@ func (m *MockVoteRepository) GetVotesForPosts(ctx context.Context, postIDs []uui
 	return args.Get(0).(map[uuid.UUID]int), args.Error(1)
 }
 
+// RecordEvent mocks the RecordEvent method
+func (m *MockVoteRepository) RecordEvent(ctx context.Context, event *models.VoteEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// GetVelocity mocks the GetVelocity method
+func (m *MockVoteRepository) GetVelocity(ctx context.Context, postID uuid.UUID, since time.Time) ([]models.VelocityBucket, error) {
+	args := m.Called(ctx, postID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.VelocityBucket), args.Error(1)
+}
+
+// SetVote mocks the SetVote method
+func (m *MockVoteRepository) SetVote(ctx context.Context, postID, userID uuid.UUID, voteType int) (*models.VoteChange, error) {
+	args := m.Called(ctx, postID, userID, voteType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VoteChange), args.Error(1)
+}
+
+// ListVoters mocks the ListVoters method
+func (m *MockVoteRepository) ListVoters(ctx context.Context, postID uuid.UUID, voteType int, cursor string, limit int) ([]models.Vote, string, error) {
+	args := m.Called(ctx, postID, voteType, cursor, limit)
+	if args.Get(0) == nil {
+		return nil, args.String(1), args.Error(2)
+	}
+	return args.Get(0).([]models.Vote), args.String(1), args.Error(2)
+}
+
+func (m *MockVoteRepository) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
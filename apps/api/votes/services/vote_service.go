@@ -10,9 +10,15 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/events"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postModels "github.com/qolzam/telar/apps/api/posts/models"
 	"github.com/qolzam/telar/apps/api/posts/repository"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	sharedInterfaces "github.com/qolzam/telar/apps/api/shared/interfaces"
 	voteErrors "github.com/qolzam/telar/apps/api/votes/errors"
 	"github.com/qolzam/telar/apps/api/votes/models"
 	voteRepository "github.com/qolzam/telar/apps/api/votes/repository"
@@ -23,22 +29,75 @@ type VoteService interface {
 	// Vote creates, updates, or deletes a vote on a post
 	// This is the main voting operation that handles all vote transitions atomically
 	Vote(ctx context.Context, postID, userID uuid.UUID, voteType int) error
+
+	// GetVelocity returns hourly vote activity on a post over the last window
+	// Only the post author (or an admin) may read it
+	GetVelocity(ctx context.Context, postID uuid.UUID, user types.UserContext, window time.Duration) (*models.VoteVelocity, error)
+
+	// SetVote makes voteType the user's vote on a post; VoteTypeNone removes it.
+	// Setting the vote the user already has changes nothing. Posts the user may not
+	// read are reported as not found.
+	SetVote(ctx context.Context, postID, userID uuid.UUID, voteType int) (*models.VoteChange, error)
+
+	// ListVoters returns a page of a post's voters, newest first. Down votes are listed
+	// only to the post author (or an admin). Posts the user may not read are reported as
+	// not found, and the author of an anonymous post is never listed.
+	ListVoters(ctx context.Context, postID uuid.UUID, user types.UserContext, voteType int, cursor string, limit int) (*models.VoterListResponse, error)
+
+	// GetVotes returns the user's vote on each of the posts (VoteTypeNone where absent)
+	GetVotes(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]int, error)
+
+	// SetEventOutbox writes a post stats event to the outbox in the transaction that
+	// changes a post's score
+	SetEventOutbox(outbox sharedInterfaces.EventOutbox)
+}
+
+// ProfileLookup resolves the profiles shown in voter lists; the profile service satisfies it.
+type ProfileLookup interface {
+	GetProfilesByIds(ctx context.Context, userIds []uuid.UUID) ([]*profileModels.Profile, error)
 }
 
+// Voter list bounds
+const (
+	defaultVoterLimit = 20
+	maxVoterLimit     = 100
+)
+
 // voteService implements the VoteService interface
 type voteService struct {
 	voteRepo voteRepository.VoteRepository
 	postRepo repository.PostRepository
+	profiles ProfileLookup                // nil lists voters by ID only
+	outbox   sharedInterfaces.EventOutbox // nil until SetEventOutbox; score changes are not announced
 }
 
 // NewVoteService creates a new instance of the vote service
 func NewVoteService(voteRepo voteRepository.VoteRepository, postRepo repository.PostRepository) VoteService {
+	return NewVoteServiceWithProfiles(voteRepo, postRepo, nil)
+}
+
+// NewVoteServiceWithProfiles creates a vote service that lists voters with their profiles
+func NewVoteServiceWithProfiles(voteRepo voteRepository.VoteRepository, postRepo repository.PostRepository, profiles ProfileLookup) VoteService {
 	return &voteService{
 		voteRepo: voteRepo,
 		postRepo: postRepo,
+		profiles: profiles,
 	}
 }
 
+// SetEventOutbox makes score changes announce themselves through the transactional outbox
+func (s *voteService) SetEventOutbox(outbox sharedInterfaces.EventOutbox) {
+	s.outbox = outbox
+}
+
+// recordScoreChanged appends a post stats event when the outbox is enabled
+func (s *voteService) recordScoreChanged(txCtx context.Context, postID uuid.UUID) error {
+	if s.outbox == nil {
+		return nil
+	}
+	return s.outbox.Append(txCtx, sharedInterfaces.OutboxPostStatsChanged, postID.String(), sharedInterfaces.PostChangedEvent{PostId: postID})
+}
+
 // Vote creates, updates, or deletes a vote on a post
 // This method handles all vote transitions atomically:
 // - New Vote: Creates vote and increments score
@@ -52,7 +111,8 @@ func (s *voteService) Vote(ctx context.Context, postID, userID uuid.UUID, voteTy
 
 	// Use PostRepository's WithTransaction to ensure atomicity
 	// This ensures that both the vote table and posts.score are updated atomically
-	return s.postRepo.WithTransaction(ctx, func(txCtx context.Context) error {
+	upvoted := false
+	err := s.postRepo.WithTransaction(ctx, func(txCtx context.Context) error {
 		// 1. Check existing vote
 		existing, err := s.voteRepo.FindByUserAndPost(txCtx, userID, postID)
 		if err != nil {
@@ -67,6 +127,7 @@ func (s *voteService) Vote(ctx context.Context, postID, userID uuid.UUID, voteTy
 		}
 
 		delta := 0
+		var event *models.VoteEvent
 
 		if existing == nil {
 			// New Vote: Create vote and increment score
@@ -91,6 +152,7 @@ func (s *voteService) Vote(ctx context.Context, postID, userID uuid.UUID, voteTy
 			}
 
 			delta = models.GetScoreValue(voteType)
+			event = &models.VoteEvent{Action: models.VoteActionCast, VoteType: voteType}
 		} else if existing.VoteTypeID == voteType {
 			// Toggle Off: Delete vote and reverse the score
 			deleted, previousType, err := s.voteRepo.Delete(txCtx, postID, userID)
@@ -105,6 +167,7 @@ func (s *voteService) Vote(ctx context.Context, postID, userID uuid.UUID, voteTy
 			}
 
 			delta = -models.GetScoreValue(voteType) // Reverse the score
+			event = &models.VoteEvent{Action: models.VoteActionRetract, PreviousType: previousType}
 		} else {
 			// Switch Vote: Update vote type and calculate delta
 			// Create a copy to avoid mutating the original object (important for tests)
@@ -121,9 +184,17 @@ func (s *voteService) Vote(ctx context.Context, postID, userID uuid.UUID, voteTy
 			// Calculate delta: new value - old value
 			// e.g., Up(+1) to Down(-1) = -1 - 1 = -2
 			delta = models.GetScoreValue(voteType) - models.GetScoreValue(previousType)
+			event = &models.VoteEvent{Action: models.VoteActionSwitch, PreviousType: previousType, VoteType: voteType}
 		}
 
-		// 2. Atomic Score Update on Post
+		// 2. Append the change to the vote history in the same transaction
+		event.PostID = postID
+		event.UserID = userID
+		if err := s.voteRepo.RecordEvent(txCtx, event); err != nil {
+			return fmt.Errorf("failed to record vote event: %w", err)
+		}
+
+		// 3. Atomic Score Update on Post
 		if delta != 0 {
 			if err := s.postRepo.IncrementScore(txCtx, postID, delta); err != nil {
 				if err.Error() == "post not found" || errors.Is(err, sql.ErrNoRows) {
@@ -131,9 +202,231 @@ func (s *voteService) Vote(ctx context.Context, postID, userID uuid.UUID, voteTy
 				}
 				return fmt.Errorf("failed to increment post score: %w", err)
 			}
+			if err := s.recordScoreChanged(txCtx, postID); err != nil {
+				return err
+			}
 		}
 
+		upvoted = event.Action != models.VoteActionRetract && voteType == models.VoteTypeUp
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	if upvoted && events.HasSubscribers() {
+		events.Publish(ctx, events.Event{
+			Type:        events.TypeVoteCast,
+			Data:        events.Vote{PostId: postID.String(), VoterUserId: userID.String()},
+			CreatedDate: time.Now().UTC().UnixMilli(),
+		})
+	}
+	return nil
+}
+
+// GetVelocity returns hourly vote activity on a post for its author
+func (s *voteService) GetVelocity(ctx context.Context, postID uuid.UUID, user types.UserContext, window time.Duration) (*models.VoteVelocity, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || err.Error() == "post not found" {
+			return nil, voteErrors.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", voteErrors.ErrDatabaseOperation, err)
+	}
+	if post.OwnerUserId != user.UserID && user.SystemRole != "admin" {
+		return nil, voteErrors.ErrNotPostAuthor
+	}
+
+	since := time.Now().Add(-window).Truncate(time.Hour)
+	buckets, err := s.voteRepo.GetVelocity(ctx, postID, since)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", voteErrors.ErrDatabaseOperation, err)
+	}
+
+	velocity := &models.VoteVelocity{
+		PostID:  postID,
+		Since:   since.UnixMilli(),
+		Buckets: buckets,
+	}
+	for _, bucket := range buckets {
+		cast := bucket.Up + bucket.Down
+		velocity.TotalVotes += cast
+		if cast > velocity.PeakPerHour {
+			velocity.PeakPerHour = cast
+		}
+	}
+	if hours := window.Hours(); hours > 0 {
+		velocity.VotesPerHour = float64(velocity.TotalVotes) / hours
+	}
+
+	return velocity, nil
+}
+
+// SetVote applies the vote through a single transactional repository call
+func (s *voteService) SetVote(ctx context.Context, postID, userID uuid.UUID, voteType int) (*models.VoteChange, error) {
+	if voteType != models.VoteTypeNone && !models.IsValidVoteType(voteType) {
+		return nil, voteErrors.ErrInvalidVoteType
+	}
+	if _, err := s.findReadablePost(ctx, postID, types.UserContext{UserID: userID}); err != nil {
+		return nil, err
+	}
+
+	var change *models.VoteChange
+	err := s.inTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		change, err = s.voteRepo.SetVote(txCtx, postID, userID, voteType)
+		if err != nil || change.PreviousType == voteType {
+			return err
+		}
+		return s.recordScoreChanged(txCtx, postID)
+	})
+	if err != nil {
+		if errors.Is(err, voteRepository.ErrPostNotFound) {
+			return nil, voteErrors.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", voteErrors.ErrDatabaseOperation, err)
+	}
+
+	if change.VoteType == models.VoteTypeUp && change.PreviousType != models.VoteTypeUp && events.HasSubscribers() {
+		events.Publish(ctx, events.Event{
+			Type:        events.TypeVoteCast,
+			Data:        events.Vote{PostId: postID.String(), VoterUserId: userID.String()},
+			CreatedDate: time.Now().UTC().UnixMilli(),
+		})
+	}
+	return change, nil
+}
+
+// inTransaction runs fn in a transaction when score changes are announced, so the
+// repository joins it and the event commits with the vote
+func (s *voteService) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if s.outbox == nil {
+		return fn(ctx)
+	}
+	return s.postRepo.WithTransaction(ctx, fn)
 }
 
+// ListVoters returns a page of a post's voters with their profiles
+func (s *voteService) ListVoters(ctx context.Context, postID uuid.UUID, user types.UserContext, voteType int, cursor string, limit int) (*models.VoterListResponse, error) {
+	if voteType != models.VoteTypeNone && !models.IsValidVoteType(voteType) {
+		return nil, voteErrors.ErrInvalidVoteType
+	}
+
+	post, err := s.findReadablePost(ctx, postID, user)
+	if err != nil {
+		return nil, err
+	}
+	// Down votes are not public; others only see who voted a post up
+	if voteType != models.VoteTypeUp && post.OwnerUserId != user.UserID && user.SystemRole != "admin" {
+		return nil, voteErrors.ErrNotPostAuthor
+	}
+
+	if limit <= 0 {
+		limit = defaultVoterLimit
+	}
+	if limit > maxVoterLimit {
+		limit = maxVoterLimit
+	}
+
+	votes, nextCursor, err := s.voteRepo.ListVoters(ctx, postID, voteType, cursor, limit)
+	if err != nil {
+		if errors.Is(err, voteRepository.ErrInvalidCursor) {
+			return nil, fmt.Errorf("%w: %v", voteErrors.ErrInvalidRequest, err)
+		}
+		return nil, fmt.Errorf("%w: %v", voteErrors.ErrDatabaseOperation, err)
+	}
+	if post.Anonymous {
+		// Listing the author's own vote would name them
+		kept := votes[:0]
+		for _, vote := range votes {
+			if vote.OwnerUserID != post.OwnerUserId {
+				kept = append(kept, vote)
+			}
+		}
+		votes = kept
+	}
+	return s.hydrateVoters(ctx, votes, nextCursor)
+}
+
+// findReadablePost returns a post the user may read. Deleted and expired posts, and
+// posts that are not public and not the user's own, are reported as not found, so
+// their existence is not revealed. Admins read every live post.
+func (s *voteService) findReadablePost(ctx context.Context, postID uuid.UUID, user types.UserContext) (*postModels.Post, error) {
+	post, err := s.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || err.Error() == "post not found" {
+			return nil, voteErrors.ErrPostNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", voteErrors.ErrDatabaseOperation, err)
+	}
+	if post.Deleted {
+		return nil, voteErrors.ErrPostNotFound
+	}
+	if post.OwnerUserId == user.UserID || user.SystemRole == "admin" {
+		return post, nil
+	}
+	if post.VisibleUntil > 0 && post.VisibleUntil <= time.Now().UTC().UnixMilli() {
+		return nil, voteErrors.ErrPostNotFound
+	}
+	if post.Permission != "" && post.Permission != "Public" {
+		return nil, voteErrors.ErrPostNotFound
+	}
+	return post, nil
+}
+
+// hydrateVoters turns a page of votes into the voters' profiles, in vote order. Voters
+// whose profile is gone are skipped.
+func (s *voteService) hydrateVoters(ctx context.Context, votes []models.Vote, nextCursor string) (*models.VoterListResponse, error) {
+	resp := &models.VoterListResponse{
+		Voters:     make([]models.Voter, 0, len(votes)),
+		NextCursor: nextCursor,
+		HasNext:    nextCursor != "",
+	}
+	if len(votes) == 0 {
+		return resp, nil
+	}
+
+	var profiles map[uuid.UUID]*profileModels.Profile
+	if s.profiles != nil {
+		ids := make([]uuid.UUID, 0, len(votes))
+		for _, vote := range votes {
+			ids = append(ids, vote.OwnerUserID)
+		}
+		found, err := s.profiles.GetProfilesByIds(ctx, ids)
+		if err != nil {
+			return nil, fmt.Errorf("get profiles: %w", err)
+		}
+		profiles = make(map[uuid.UUID]*profileModels.Profile, len(found))
+		for _, p := range found {
+			profiles[p.ObjectId] = p
+		}
+	}
+
+	for _, vote := range votes {
+		voter := models.Voter{
+			UserId:    vote.OwnerUserID.String(),
+			TypeId:    vote.VoteTypeID,
+			VotedDate: vote.CreatedAt.UnixMilli(),
+		}
+		if profiles != nil {
+			profile, ok := profiles[vote.OwnerUserID]
+			if !ok {
+				continue
+			}
+			voter.FullName = profile.FullName
+			voter.SocialName = profile.SocialName
+			voter.Avatar = profile.Avatar
+		}
+		resp.Voters = append(resp.Voters, voter)
+	}
+	return resp, nil
+}
+
+// GetVotes returns the user's votes on a set of posts in one query
+func (s *voteService) GetVotes(ctx context.Context, userID uuid.UUID, postIDs []uuid.UUID) (map[uuid.UUID]int, error) {
+	votes, err := s.voteRepo.GetVotesForPosts(ctx, postIDs, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", voteErrors.ErrDatabaseOperation, err)
+	}
+	return votes, nil
+}
@@ -15,7 +15,12 @@ import (
 	uuid "github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/qolzam/telar/apps/api/internal/types"
+	postsModels "github.com/qolzam/telar/apps/api/posts/models"
+	profileModels "github.com/qolzam/telar/apps/api/profile/models"
+	voteErrors "github.com/qolzam/telar/apps/api/votes/errors"
 	"github.com/qolzam/telar/apps/api/votes/models"
+	voteRepository "github.com/qolzam/telar/apps/api/votes/repository"
 )
 
 func TestVoteService_Vote(t *testing.T) {
@@ -35,6 +40,9 @@ func TestVoteService_Vote(t *testing.T) {
 		mockVoteRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(vote *models.Vote) bool {
 			return vote.PostID == postID && vote.OwnerUserID == userID && vote.VoteTypeID == models.VoteTypeUp
 		})).Return(true, 0, nil) // created=true, no previous vote
+		mockVoteRepo.On("RecordEvent", mock.Anything, mock.MatchedBy(func(event *models.VoteEvent) bool {
+			return event.PostID == postID && event.UserID == userID && event.Action == models.VoteActionCast
+		})).Return(nil)
 		mockPostRepo.On("IncrementScore", mock.Anything, postID, 1).Return(nil) // delta = +1 for Up vote
 		mockPostRepo.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
 			fn := args.Get(1).(func(context.Context) error)
@@ -66,6 +74,9 @@ func TestVoteService_Vote(t *testing.T) {
 		mockVoteRepo.On("Upsert", mock.Anything, mock.MatchedBy(func(vote *models.Vote) bool {
 			return vote.PostID == postID && vote.OwnerUserID == userID && vote.VoteTypeID == models.VoteTypeDown
 		})).Return(true, 0, nil) // created=true, no previous vote
+		mockVoteRepo.On("RecordEvent", mock.Anything, mock.MatchedBy(func(event *models.VoteEvent) bool {
+			return event.PostID == postID && event.UserID == userID && event.Action == models.VoteActionCast
+		})).Return(nil)
 		mockPostRepo.On("IncrementScore", mock.Anything, postID, -1).Return(nil) // delta = -1 for Down vote
 		mockPostRepo.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
 			fn := args.Get(1).(func(context.Context) error)
@@ -104,6 +115,9 @@ func TestVoteService_Vote(t *testing.T) {
 		// Service uses txCtx (transaction context), so we must use mock.Anything for context
 		mockVoteRepo.On("FindByUserAndPost", mock.Anything, userID, postID).Return(existingVote, nil)
 		mockVoteRepo.On("Delete", mock.Anything, postID, userID).Return(true, models.VoteTypeUp, nil) // deleted=true, previousType=Up
+		mockVoteRepo.On("RecordEvent", mock.Anything, mock.MatchedBy(func(event *models.VoteEvent) bool {
+			return event.PostID == postID && event.UserID == userID && event.Action == models.VoteActionRetract
+		})).Return(nil)
 		mockPostRepo.On("IncrementScore", mock.Anything, postID, -1).Return(nil) // delta = -1 (reverse the Up vote)
 		mockPostRepo.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
 			fn := args.Get(1).(func(context.Context) error)
@@ -148,6 +162,9 @@ func TestVoteService_Vote(t *testing.T) {
 		})).Return(false, models.VoteTypeUp, nil) // created=false (updated), previousType=Up
 		
 		// Delta calculation: Down(-1) - Up(+1) = -2
+		mockVoteRepo.On("RecordEvent", mock.Anything, mock.MatchedBy(func(event *models.VoteEvent) bool {
+			return event.PostID == postID && event.UserID == userID && event.Action == models.VoteActionSwitch
+		})).Return(nil)
 		mockPostRepo.On("IncrementScore", mock.Anything, postID, -2).Return(nil)
 		mockPostRepo.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
 			fn := args.Get(1).(func(context.Context) error)
@@ -189,6 +206,9 @@ func TestVoteService_Vote(t *testing.T) {
 			return vote.PostID == postID && vote.OwnerUserID == userID && vote.VoteTypeID == models.VoteTypeUp
 		})).Return(false, models.VoteTypeDown, nil) // created=false (updated), previousType=Down
 		// Delta calculation: Up(+1) - Down(-1) = +2
+		mockVoteRepo.On("RecordEvent", mock.Anything, mock.MatchedBy(func(event *models.VoteEvent) bool {
+			return event.PostID == postID && event.UserID == userID && event.Action == models.VoteActionSwitch
+		})).Return(nil)
 		mockPostRepo.On("IncrementScore", mock.Anything, postID, 2).Return(nil)
 		mockPostRepo.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
 			fn := args.Get(1).(func(context.Context) error)
@@ -234,6 +254,9 @@ func TestVoteService_Vote(t *testing.T) {
 		// Service uses txCtx (transaction context), so we must use mock.Anything for context
 		mockVoteRepo.On("FindByUserAndPost", mock.Anything, userID, postID).Return(nil, sql.ErrNoRows)
 		mockVoteRepo.On("Upsert", mock.Anything, mock.Anything).Return(true, 0, nil)
+		mockVoteRepo.On("RecordEvent", mock.Anything, mock.MatchedBy(func(event *models.VoteEvent) bool {
+			return event.PostID == postID && event.UserID == userID && event.Action == models.VoteActionCast
+		})).Return(nil)
 		mockPostRepo.On("IncrementScore", mock.Anything, postID, 1).Return(nil)
 		mockPostRepo.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil).Run(func(args mock.Arguments) {
 			fn := args.Get(1).(func(context.Context) error)
@@ -254,3 +277,252 @@ func TestVoteService_Vote(t *testing.T) {
 	})
 }
 
+func TestVoteService_GetVelocity(t *testing.T) {
+	ctx := context.Background()
+	postID := uuid.Must(uuid.NewV4())
+	authorID := uuid.Must(uuid.NewV4())
+	post := &postsModels.Post{ObjectId: postID, OwnerUserId: authorID}
+
+	t.Run("Author sees hourly buckets", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		service := NewVoteService(mockVoteRepo, mockPostRepo)
+
+		mockPostRepo.On("FindByID", ctx, postID).Return(post, nil)
+		mockVoteRepo.On("GetVelocity", ctx, postID, mock.AnythingOfType("time.Time")).Return([]models.VelocityBucket{
+			{Start: 1, Up: 3, Down: 1},
+			{Start: 2, Up: 10, Down: 2, Switched: 4},
+		}, nil)
+
+		velocity, err := service.GetVelocity(ctx, postID, types.UserContext{UserID: authorID}, 4*time.Hour)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 16, velocity.TotalVotes)
+		assert.Equal(t, 12, velocity.PeakPerHour)
+		assert.Equal(t, 4.0, velocity.VotesPerHour)
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("Other users are rejected", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		service := NewVoteService(mockVoteRepo, mockPostRepo)
+
+		mockPostRepo.On("FindByID", ctx, postID).Return(post, nil)
+
+		_, err := service.GetVelocity(ctx, postID, types.UserContext{UserID: uuid.Must(uuid.NewV4())}, time.Hour)
+
+		assert.ErrorIs(t, err, voteErrors.ErrNotPostAuthor)
+		mockVoteRepo.AssertNotCalled(t, "GetVelocity", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestVoteService_SetVote(t *testing.T) {
+	ctx := context.Background()
+	postID := uuid.Must(uuid.NewV4())
+	userID := uuid.Must(uuid.NewV4())
+	post := &postsModels.Post{ObjectId: postID, OwnerUserId: uuid.Must(uuid.NewV4()), Permission: "Public"}
+
+	// postRepo finds the post the user votes on
+	postRepo := func() *MockPostRepositoryForVotes {
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		mockPostRepo.On("FindByID", ctx, postID).Return(post, nil)
+		return mockPostRepo
+	}
+
+	t.Run("Applies the vote in one repository call", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		service := NewVoteService(mockVoteRepo, postRepo())
+
+		change := &models.VoteChange{PostID: postID, PreviousType: models.VoteTypeUp, VoteType: models.VoteTypeDown, Score: 3}
+		mockVoteRepo.On("SetVote", ctx, postID, userID, models.VoteTypeDown).Return(change, nil)
+
+		got, err := service.SetVote(ctx, postID, userID, models.VoteTypeDown)
+
+		assert.NoError(t, err)
+		assert.Equal(t, change, got)
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("Announces the score change in the vote's transaction", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := postRepo()
+		service := NewVoteService(mockVoteRepo, mockPostRepo)
+		outbox := &recordingOutbox{}
+		service.SetEventOutbox(outbox)
+
+		change := &models.VoteChange{PostID: postID, VoteType: models.VoteTypeUp, Score: 1}
+		mockVoteRepo.On("SetVote", ctx, postID, userID, models.VoteTypeUp).Return(change, nil)
+		mockPostRepo.On("WithTransaction", ctx, mock.AnythingOfType("func(context.Context) error")).Return(nil)
+
+		_, err := service.SetVote(ctx, postID, userID, models.VoteTypeUp)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"post.stats_changed " + postID.String()}, outbox.events)
+		mockPostRepo.AssertExpectations(t)
+	})
+
+	t.Run("Remove is a valid vote", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		service := NewVoteService(mockVoteRepo, postRepo())
+
+		mockVoteRepo.On("SetVote", ctx, postID, userID, models.VoteTypeNone).Return(&models.VoteChange{PostID: postID}, nil)
+
+		_, err := service.SetVote(ctx, postID, userID, models.VoteTypeNone)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Unknown vote types are rejected", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		service := NewVoteService(mockVoteRepo, new(MockPostRepositoryForVotes))
+
+		_, err := service.SetVote(ctx, postID, userID, 3)
+
+		assert.ErrorIs(t, err, voteErrors.ErrInvalidVoteType)
+		mockVoteRepo.AssertNotCalled(t, "SetVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Missing post", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		service := NewVoteService(mockVoteRepo, postRepo())
+
+		mockVoteRepo.On("SetVote", ctx, postID, userID, models.VoteTypeUp).Return(nil, voteRepository.ErrPostNotFound)
+
+		_, err := service.SetVote(ctx, postID, userID, models.VoteTypeUp)
+
+		assert.ErrorIs(t, err, voteErrors.ErrPostNotFound)
+	})
+
+	t.Run("Posts the user cannot read are not found", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		service := NewVoteService(mockVoteRepo, mockPostRepo)
+
+		private := &postsModels.Post{ObjectId: postID, OwnerUserId: uuid.Must(uuid.NewV4()), Permission: "OnlyMe"}
+		mockPostRepo.On("FindByID", ctx, postID).Return(private, nil)
+
+		_, err := service.SetVote(ctx, postID, userID, models.VoteTypeUp)
+
+		assert.ErrorIs(t, err, voteErrors.ErrPostNotFound)
+		mockVoteRepo.AssertNotCalled(t, "SetVote", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// recordingOutbox records the events appended to it
+type recordingOutbox struct {
+	events []string
+}
+
+func (o *recordingOutbox) Append(ctx context.Context, eventType string, key string, data interface{}) error {
+	o.events = append(o.events, eventType+" "+key)
+	return nil
+}
+
+// stubProfiles returns the profiles it holds, in any order
+type stubProfiles []*profileModels.Profile
+
+func (p stubProfiles) GetProfilesByIds(ctx context.Context, userIds []uuid.UUID) ([]*profileModels.Profile, error) {
+	return p, nil
+}
+
+func TestVoteService_ListVoters(t *testing.T) {
+	ctx := context.Background()
+	postID := uuid.Must(uuid.NewV4())
+	authorID := uuid.Must(uuid.NewV4())
+	post := &postsModels.Post{ObjectId: postID, OwnerUserId: authorID}
+
+	t.Run("Lists up voters with their profiles", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		kept := uuid.Must(uuid.NewV4())
+		gone := uuid.Must(uuid.NewV4())
+		service := NewVoteServiceWithProfiles(mockVoteRepo, mockPostRepo, stubProfiles{
+			{ObjectId: kept, FullName: "Kept Voter", SocialName: "kept"},
+		})
+
+		votedAt := time.UnixMilli(1700000000000)
+		mockPostRepo.On("FindByID", ctx, postID).Return(post, nil)
+		mockVoteRepo.On("ListVoters", ctx, postID, models.VoteTypeUp, "", maxVoterLimit).Return([]models.Vote{
+			{PostID: postID, OwnerUserID: gone, VoteTypeID: models.VoteTypeUp, CreatedAt: votedAt},
+			{PostID: postID, OwnerUserID: kept, VoteTypeID: models.VoteTypeUp, CreatedAt: votedAt},
+		}, "next", nil)
+
+		resp, err := service.ListVoters(ctx, postID, types.UserContext{UserID: uuid.Must(uuid.NewV4())}, models.VoteTypeUp, "", 500)
+
+		assert.NoError(t, err)
+		assert.True(t, resp.HasNext)
+		assert.Equal(t, "next", resp.NextCursor)
+		if assert.Len(t, resp.Voters, 1) {
+			assert.Equal(t, kept.String(), resp.Voters[0].UserId)
+			assert.Equal(t, "Kept Voter", resp.Voters[0].FullName)
+			assert.Equal(t, votedAt.UnixMilli(), resp.Voters[0].VotedDate)
+		}
+		mockVoteRepo.AssertExpectations(t)
+	})
+
+	t.Run("Down voters are for the author", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		service := NewVoteService(mockVoteRepo, mockPostRepo)
+
+		mockPostRepo.On("FindByID", ctx, postID).Return(post, nil)
+		mockVoteRepo.On("ListVoters", ctx, postID, models.VoteTypeDown, "", defaultVoterLimit).Return([]models.Vote{}, "", nil)
+
+		_, err := service.ListVoters(ctx, postID, types.UserContext{UserID: uuid.Must(uuid.NewV4())}, models.VoteTypeDown, "", 0)
+		assert.ErrorIs(t, err, voteErrors.ErrNotPostAuthor)
+
+		resp, err := service.ListVoters(ctx, postID, types.UserContext{UserID: authorID}, models.VoteTypeDown, "", 0)
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Voters)
+		assert.False(t, resp.HasNext)
+	})
+
+	t.Run("Bad cursors are invalid requests", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		service := NewVoteService(mockVoteRepo, mockPostRepo)
+
+		mockPostRepo.On("FindByID", ctx, postID).Return(post, nil)
+		mockVoteRepo.On("ListVoters", ctx, postID, models.VoteTypeUp, "bogus", defaultVoterLimit).Return(nil, "", voteRepository.ErrInvalidCursor)
+
+		_, err := service.ListVoters(ctx, postID, types.UserContext{UserID: authorID}, models.VoteTypeUp, "bogus", 0)
+		assert.ErrorIs(t, err, voteErrors.ErrInvalidRequest)
+	})
+	t.Run("Posts the user cannot read are not found", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		service := NewVoteService(mockVoteRepo, mockPostRepo)
+
+		private := &postsModels.Post{ObjectId: postID, OwnerUserId: authorID, Permission: "OnlyMe"}
+		mockPostRepo.On("FindByID", ctx, postID).Return(private, nil)
+		mockVoteRepo.On("ListVoters", ctx, postID, models.VoteTypeUp, "", defaultVoterLimit).Return([]models.Vote{}, "", nil)
+
+		_, err := service.ListVoters(ctx, postID, types.UserContext{UserID: uuid.Must(uuid.NewV4())}, models.VoteTypeUp, "", 0)
+		assert.ErrorIs(t, err, voteErrors.ErrPostNotFound)
+
+		_, err = service.ListVoters(ctx, postID, types.UserContext{UserID: authorID}, models.VoteTypeUp, "", 0)
+		assert.NoError(t, err)
+	})
+
+	t.Run("The author of an anonymous post is not listed", func(t *testing.T) {
+		mockVoteRepo := new(MockVoteRepository)
+		mockPostRepo := new(MockPostRepositoryForVotes)
+		service := NewVoteService(mockVoteRepo, mockPostRepo)
+
+		voter := uuid.Must(uuid.NewV4())
+		anonymous := &postsModels.Post{ObjectId: postID, OwnerUserId: authorID, Anonymous: true}
+		mockPostRepo.On("FindByID", ctx, postID).Return(anonymous, nil)
+		mockVoteRepo.On("ListVoters", ctx, postID, models.VoteTypeUp, "", defaultVoterLimit).Return([]models.Vote{
+			{PostID: postID, OwnerUserID: authorID, VoteTypeID: models.VoteTypeUp},
+			{PostID: postID, OwnerUserID: voter, VoteTypeID: models.VoteTypeUp},
+		}, "", nil)
+
+		resp, err := service.ListVoters(ctx, postID, types.UserContext{UserID: voter}, models.VoteTypeUp, "", 0)
+
+		assert.NoError(t, err)
+		if assert.Len(t, resp.Voters, 1) {
+			assert.Equal(t, voter.String(), resp.Voters[0].UserId)
+		}
+	})
+}
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrWebhookNotConfigured = errors.New("webhook is no longer configured")
+	ErrSecretNotConfigured  = errors.New("no webhook secret is configured")
+	ErrInvalidRequest       = errors.New("invalid request")
+	ErrDatabaseOperation    = errors.New("database operation failed")
+)
+
+const (
+	CodeDeliveryNotFound     = "DELIVERY_NOT_FOUND"
+	CodeWebhookNotConfigured = "WEBHOOK_NOT_CONFIGURED"
+	CodeSecretNotConfigured  = "WEBHOOK_SECRET_NOT_CONFIGURED"
+	CodeInvalidRequest       = "INVALID_REQUEST"
+	CodeDatabaseError        = "DATABASE_ERROR"
+	CodeInternalError        = "INTERNAL_ERROR"
+)
+
+type ErrorResponse struct {
+	Code    string      `json:"code"`
+	Message string      `json:"message"`
+	Details interface{} `json:"details,omitempty"`
+}
+
+func HandleServiceError(c *fiber.Ctx, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch {
+	case errors.Is(err, ErrDeliveryNotFound):
+		return c.Status(http.StatusNotFound).JSON(ErrorResponse{Code: CodeDeliveryNotFound, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrWebhookNotConfigured):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeWebhookNotConfigured, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrSecretNotConfigured):
+		return c.Status(http.StatusConflict).JSON(ErrorResponse{Code: CodeSecretNotConfigured, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrInvalidRequest):
+		return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: err.Error(), Details: err.Error()})
+	case errors.Is(err, ErrDatabaseOperation):
+		return c.Status(http.StatusServiceUnavailable).JSON(ErrorResponse{Code: CodeDatabaseError, Message: err.Error(), Details: err.Error()})
+	default:
+		return c.Status(http.StatusInternalServerError).JSON(ErrorResponse{Code: CodeInternalError, Message: "An unexpected error occurred", Details: err.Error()})
+	}
+}
+
+func HandleValidationError(c *fiber.Ctx, message string) error {
+	return c.Status(http.StatusBadRequest).JSON(ErrorResponse{Code: CodeInvalidRequest, Message: message, Details: message})
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/qolzam/telar/apps/api/webhooks/errors"
+	"github.com/qolzam/telar/apps/api/webhooks/models"
+	"github.com/qolzam/telar/apps/api/webhooks/services"
+)
+
+type WebhookHandler struct {
+	service services.Service
+}
+
+func NewWebhookHandler(service services.Service) *WebhookHandler {
+	return &WebhookHandler{service: service}
+}
+
+// ListWebhooks returns the configured webhooks and their IDs.
+// Endpoint: GET /webhooks
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"webhooks": h.service.ListWebhooks(c.Context()),
+	})
+}
+
+// ListDeliveries returns recorded deliveries, newest first, optionally filtered by
+// webhookId, point and status (succeeded or failed).
+// Endpoint: GET /webhooks/deliveries
+func (h *WebhookHandler) ListDeliveries(c *fiber.Ctx) error {
+	filter := models.DeliveryFilter{
+		WebhookID: c.Query("webhookId"),
+		Point:     c.Query("point"),
+		Status:    c.Query("status"),
+		Limit:     c.QueryInt("limit", 0),
+		Offset:    c.QueryInt("offset", 0),
+	}
+
+	deliveries, err := h.service.ListDeliveries(c.Context(), filter)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(fiber.Map{
+		"deliveries": deliveries,
+	})
+}
+
+// GetDelivery returns a delivery with its request headers and body and the response.
+// Endpoint: GET /webhooks/deliveries/:deliveryId
+func (h *WebhookHandler) GetDelivery(c *fiber.Ctx) error {
+	delivery, err := h.service.GetDelivery(c.Context(), c.Params("deliveryId"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(delivery)
+}
+
+// Redeliver sends a recorded delivery to its webhook again and returns the new delivery.
+// Endpoint: POST /webhooks/:id/redeliver (id is the delivery ID)
+func (h *WebhookHandler) Redeliver(c *fiber.Ctx) error {
+	delivery, err := h.service.Redeliver(c.Context(), c.Params("id"))
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusCreated).JSON(delivery)
+}
+
+// VerifySignature checks a sample payload and signature against the configured secret.
+// Endpoint: POST /webhooks/signature/verify
+func (h *WebhookHandler) VerifySignature(c *fiber.Ctx) error {
+	var req models.VerifySignatureRequest
+	if err := c.BodyParser(&req); err != nil {
+		return errors.HandleValidationError(c, "invalid request body")
+	}
+
+	result, err := h.service.VerifySignature(c.Context(), &req)
+	if err != nil {
+		return errors.HandleServiceError(c, err)
+	}
+
+	return c.Status(http.StatusOK).JSON(result)
+}
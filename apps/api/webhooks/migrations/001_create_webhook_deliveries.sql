@@ -0,0 +1,20 @@
+-- Webhook deliveries: every request made to a webhook configured with HOOKS_WEBHOOKS,
+-- with the webhook's answer. Admins inspect and redeliver them; rows older than
+-- HOOKS_DELIVERY_RETENTION are purged. request_body is kept whole for redelivery.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY,
+    webhook_id TEXT NOT NULL,
+    point TEXT NOT NULL,
+    url TEXT NOT NULL,
+    request_headers JSONB NOT NULL DEFAULT '{}',
+    request_body TEXT NOT NULL,
+    response_status INT NOT NULL DEFAULT 0,
+    response_body TEXT NOT NULL DEFAULT '',
+    error TEXT NOT NULL DEFAULT '',
+    duration_ms BIGINT NOT NULL DEFAULT 0,
+    redelivery_of UUID,
+    created_date BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_created ON webhook_deliveries(created_date DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook ON webhook_deliveries(webhook_id, created_date DESC);
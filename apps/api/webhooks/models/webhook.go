@@ -0,0 +1,48 @@
+package models
+
+import "github.com/qolzam/telar/apps/api/internal/hooks"
+
+// Delivery statuses accepted by the deliveries filter
+const (
+	StatusSucceeded = "succeeded"
+	StatusFailed    = "failed"
+)
+
+// DeliveryFilter narrows GET /webhooks/deliveries; empty fields match everything
+type DeliveryFilter struct {
+	WebhookID string
+	Point     string
+	Status    string // StatusSucceeded or StatusFailed
+	Limit     int
+	Offset    int
+}
+
+// DeliverySummary is a delivery in the list, without headers and bodies
+type DeliverySummary struct {
+	ID             string      `json:"id"`
+	WebhookID      string      `json:"webhookId"`
+	Point          hooks.Point `json:"point"`
+	URL            string      `json:"url"`
+	ResponseStatus int         `json:"responseStatus"`
+	Error          string      `json:"error,omitempty"`
+	DurationMs     int64       `json:"durationMs"`
+	RedeliveryOf   string      `json:"redeliveryOf,omitempty"`
+	CreatedDate    int64       `json:"createdDate"`
+}
+
+// VerifySignatureRequest is a sample webhook request to check against the configured
+// secret. Payload is the raw request body, exactly as received.
+type VerifySignatureRequest struct {
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"` // X-Telar-Signature value
+	Timestamp string `json:"timestamp"` // X-Telar-Timestamp value; now when empty
+}
+
+// VerifySignatureResponse tells whether the sample's signature is valid and shows the
+// signature Telar sends for the payload at the timestamp
+type VerifySignatureResponse struct {
+	Valid             bool   `json:"valid"`
+	Reason            string `json:"reason,omitempty"`
+	Timestamp         string `json:"timestamp"`
+	ExpectedSignature string `json:"expectedSignature"`
+}
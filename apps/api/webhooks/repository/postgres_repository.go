@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/qolzam/telar/apps/api/internal/database/postgres"
+	"github.com/qolzam/telar/apps/api/internal/hooks"
+	"github.com/qolzam/telar/apps/api/webhooks/models"
+)
+
+const summaryColumns = `id::text AS id, webhook_id, point, url, response_status, error, duration_ms,
+	COALESCE(redelivery_of::text, '') AS redelivery_of, created_date`
+
+// deliveryRow is a webhook_deliveries row
+type deliveryRow struct {
+	ID             string `db:"id"`
+	WebhookID      string `db:"webhook_id"`
+	Point          string `db:"point"`
+	URL            string `db:"url"`
+	RequestHeaders []byte `db:"request_headers"`
+	RequestBody    string `db:"request_body"`
+	ResponseStatus int    `db:"response_status"`
+	ResponseBody   string `db:"response_body"`
+	Error          string `db:"error"`
+	DurationMs     int64  `db:"duration_ms"`
+	RedeliveryOf   string `db:"redelivery_of"`
+	CreatedDate    int64  `db:"created_date"`
+}
+
+type postgresRepository struct {
+	client *postgres.Client
+	schema string
+}
+
+// NewPostgresRepository creates a repository using the default schema.
+func NewPostgresRepository(client *postgres.Client) Repository {
+	return &postgresRepository{client: client, schema: ""}
+}
+
+// NewPostgresRepositoryWithSchema creates a repository using a specific schema.
+func NewPostgresRepositoryWithSchema(client *postgres.Client, schema string) Repository {
+	return &postgresRepository{client: client, schema: schema}
+}
+
+func (r *postgresRepository) getExecutor(ctx context.Context) sqlx.ExtContext {
+	if txVal := ctx.Value("tx"); txVal != nil {
+		if tx, ok := txVal.(*sqlx.Tx); ok {
+			return tx
+		}
+	}
+	return r.client.DB()
+}
+
+func (r *postgresRepository) Record(ctx context.Context, delivery *hooks.Delivery) error {
+	headers, err := json.Marshal(delivery.RequestHeaders)
+	if err != nil {
+		return fmt.Errorf("encode webhook request headers: %w", err)
+	}
+	var redeliveryOf interface{}
+	if delivery.RedeliveryOf != "" {
+		redeliveryOf = delivery.RedeliveryOf
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO %swebhook_deliveries (id, webhook_id, point, url, request_headers, request_body,
+			response_status, response_body, error, duration_ms, redelivery_of, created_date)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, r.schemaPrefix())
+	_, err = r.getExecutor(ctx).ExecContext(ctx, query,
+		delivery.ID, delivery.WebhookID, string(delivery.Point), delivery.URL, headers, delivery.RequestBody,
+		delivery.ResponseStatus, delivery.ResponseBody, delivery.Error, delivery.DurationMs, redeliveryOf, delivery.CreatedDate)
+	if err != nil {
+		return fmt.Errorf("record webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *postgresRepository) GetDelivery(ctx context.Context, id string) (*hooks.Delivery, error) {
+	query := fmt.Sprintf(`
+		SELECT %s, request_headers, request_body, response_body
+		FROM %swebhook_deliveries
+		WHERE id = $1
+	`, summaryColumns, r.schemaPrefix())
+
+	var row deliveryRow
+	if err := sqlx.GetContext(ctx, r.getExecutor(ctx), &row, query, id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("get webhook delivery: %w", err)
+	}
+
+	delivery := &hooks.Delivery{
+		ID:             row.ID,
+		WebhookID:      row.WebhookID,
+		Point:          hooks.Point(row.Point),
+		URL:            row.URL,
+		RequestBody:    row.RequestBody,
+		ResponseStatus: row.ResponseStatus,
+		ResponseBody:   row.ResponseBody,
+		Error:          row.Error,
+		DurationMs:     row.DurationMs,
+		RedeliveryOf:   row.RedeliveryOf,
+		CreatedDate:    row.CreatedDate,
+	}
+	if err := json.Unmarshal(row.RequestHeaders, &delivery.RequestHeaders); err != nil {
+		return nil, fmt.Errorf("decode webhook request headers: %w", err)
+	}
+	return delivery, nil
+}
+
+func (r *postgresRepository) ListDeliveries(ctx context.Context, filter models.DeliveryFilter) ([]*models.DeliverySummary, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %swebhook_deliveries
+		WHERE ($1 = '' OR webhook_id = $1)
+			AND ($2 = '' OR point = $2)
+			AND ($3 = ''
+				OR ($3 = 'succeeded' AND error = '' AND response_status BETWEEN 200 AND 299)
+				OR ($3 = 'failed' AND NOT (error = '' AND response_status BETWEEN 200 AND 299)))
+		ORDER BY created_date DESC, id
+		LIMIT $4 OFFSET $5
+	`, summaryColumns, r.schemaPrefix())
+
+	var rows []deliveryRow
+	if err := sqlx.SelectContext(ctx, r.getExecutor(ctx), &rows, query,
+		filter.WebhookID, filter.Point, filter.Status, filter.Limit, filter.Offset); err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*models.DeliverySummary, len(rows))
+	for i, row := range rows {
+		deliveries[i] = &models.DeliverySummary{
+			ID:             row.ID,
+			WebhookID:      row.WebhookID,
+			Point:          hooks.Point(row.Point),
+			URL:            row.URL,
+			ResponseStatus: row.ResponseStatus,
+			Error:          row.Error,
+			DurationMs:     row.DurationMs,
+			RedeliveryOf:   row.RedeliveryOf,
+			CreatedDate:    row.CreatedDate,
+		}
+	}
+	return deliveries, nil
+}
+
+func (r *postgresRepository) Purge(ctx context.Context, before int64) (int64, error) {
+	query := fmt.Sprintf(`DELETE FROM %swebhook_deliveries WHERE created_date < $1`, r.schemaPrefix())
+	result, err := r.getExecutor(ctx).ExecContext(ctx, query, before)
+	if err != nil {
+		return 0, fmt.Errorf("purge webhook deliveries: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+func (r *postgresRepository) schemaPrefix() string {
+	if r.schema == "" {
+		return ""
+	}
+	return r.schema + "."
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/qolzam/telar/apps/api/internal/hooks"
+	"github.com/qolzam/telar/apps/api/webhooks/models"
+)
+
+// ErrNotFound is returned when a delivery does not exist
+var ErrNotFound = errors.New("not found")
+
+// Repository stores webhook deliveries. It is the hooks delivery log.
+type Repository interface {
+	hooks.DeliveryLog
+
+	// GetDelivery returns a delivery with its headers and bodies, or ErrNotFound.
+	GetDelivery(ctx context.Context, id string) (*hooks.Delivery, error)
+
+	// ListDeliveries returns the deliveries matching filter, newest first.
+	ListDeliveries(ctx context.Context, filter models.DeliveryFilter) ([]*models.DeliverySummary, error)
+
+	// Purge deletes deliveries created before (unix milliseconds) and returns how many.
+	Purge(ctx context.Context, before int64) (int64, error)
+}
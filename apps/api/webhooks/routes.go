@@ -0,0 +1,42 @@
+package webhooks
+
+import (
+	"github.com/gofiber/fiber/v2"
+	adminmw "github.com/qolzam/telar/apps/api/internal/middleware/admin"
+	dualauth "github.com/qolzam/telar/apps/api/internal/middleware/dualauth"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	"github.com/qolzam/telar/apps/api/webhooks/handlers"
+)
+
+type Handlers struct {
+	WebhookHandler *handlers.WebhookHandler
+}
+
+type RouterConfig struct {
+	PayloadSecret string
+	PublicKey     string
+}
+
+func createDualAuthMiddleware(cfg *RouterConfig) fiber.Handler {
+	return dualauth.CreateDualAuthMiddleware(dualauth.Config{
+		PayloadSecret: cfg.PayloadSecret,
+		PublicKey:     cfg.PublicKey,
+	})
+}
+
+// RegisterRoutes wires the admin tooling for outgoing webhooks: delivery inspection,
+// redelivery and the signature check.
+func RegisterRoutes(app *fiber.App, handlers *Handlers, cfg *platformconfig.Config) {
+	routerCfg := &RouterConfig{
+		PayloadSecret: cfg.HMAC.Secret,
+		PublicKey:     cfg.JWT.PublicKey,
+	}
+
+	group := app.Group("/webhooks", createDualAuthMiddleware(routerCfg), adminmw.New(adminmw.Config{}))
+
+	group.Get("/", handlers.WebhookHandler.ListWebhooks)
+	group.Get("/deliveries", handlers.WebhookHandler.ListDeliveries)
+	group.Get("/deliveries/:deliveryId", handlers.WebhookHandler.GetDelivery)
+	group.Post("/signature/verify", handlers.WebhookHandler.VerifySignature)
+	group.Post("/:id/redeliver", handlers.WebhookHandler.Redeliver)
+}
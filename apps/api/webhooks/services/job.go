@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/pkg/log"
+)
+
+// StartJob purges webhook deliveries past their retention every interval until ctx is done.
+func StartJob(ctx context.Context, svc Service, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		run := func() {
+			if purged, err := svc.Purge(ctx); err != nil {
+				log.Error("Webhook delivery purge failed: %v", err)
+			} else if purged > 0 {
+				log.Info("Purged %d webhook deliveries", purged)
+			}
+		}
+
+		run()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				run()
+			}
+		}
+	}()
+}
@@ -0,0 +1,43 @@
+package services
+
+import (
+	"context"
+
+	"github.com/qolzam/telar/apps/api/internal/hooks"
+	"github.com/qolzam/telar/apps/api/webhooks/models"
+	"github.com/qolzam/telar/apps/api/webhooks/repository"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRepository is a test double for the webhook deliveries repository.
+type MockRepository struct {
+	mock.Mock
+}
+
+var _ repository.Repository = (*MockRepository)(nil)
+
+func (m *MockRepository) Record(ctx context.Context, delivery *hooks.Delivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockRepository) GetDelivery(ctx context.Context, id string) (*hooks.Delivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*hooks.Delivery), args.Error(1)
+}
+
+func (m *MockRepository) ListDeliveries(ctx context.Context, filter models.DeliveryFilter) ([]*models.DeliverySummary, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*models.DeliverySummary), args.Error(1)
+}
+
+func (m *MockRepository) Purge(ctx context.Context, before int64) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
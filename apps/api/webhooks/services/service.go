@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	uuid "github.com/gofrs/uuid"
+	"github.com/qolzam/telar/apps/api/internal/hooks"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	webhookErrors "github.com/qolzam/telar/apps/api/webhooks/errors"
+	"github.com/qolzam/telar/apps/api/webhooks/models"
+	"github.com/qolzam/telar/apps/api/webhooks/repository"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// Service defines the admin tooling for outgoing webhooks.
+type Service interface {
+	// ListWebhooks returns the configured webhooks.
+	ListWebhooks(ctx context.Context) []*hooks.Webhook
+
+	// ListDeliveries returns recorded deliveries matching filter, newest first.
+	ListDeliveries(ctx context.Context, filter models.DeliveryFilter) ([]*models.DeliverySummary, error)
+
+	// GetDelivery returns a delivery with its request and response.
+	GetDelivery(ctx context.Context, deliveryID string) (*hooks.Delivery, error)
+
+	// Redeliver sends a recorded delivery's request body to its webhook again, freshly
+	// signed, and returns the new delivery. A webhook that fails again is reported in
+	// the delivery, not as an error.
+	Redeliver(ctx context.Context, deliveryID string) (*hooks.Delivery, error)
+
+	// VerifySignature checks a sample payload and signature against the configured
+	// secret. Old samples are accepted: the timestamp is not checked against the clock.
+	VerifySignature(ctx context.Context, req *models.VerifySignatureRequest) (*models.VerifySignatureResponse, error)
+
+	// Purge deletes deliveries older than the retention and returns how many.
+	Purge(ctx context.Context) (int64, error)
+}
+
+type service struct {
+	repo        repository.Repository
+	cfg         platformconfig.HooksConfig
+	findWebhook func(id string) (*hooks.Webhook, bool)
+	webhooks    func() []*hooks.Webhook
+	now         func() time.Time
+}
+
+// NewService constructs the webhooks service over the process-wide hook registry.
+func NewService(repo repository.Repository, cfg platformconfig.HooksConfig) Service {
+	return &service{repo: repo, cfg: cfg, findWebhook: hooks.FindWebhook, webhooks: hooks.Webhooks, now: time.Now}
+}
+
+func (s *service) ListWebhooks(ctx context.Context) []*hooks.Webhook {
+	return s.webhooks()
+}
+
+func (s *service) ListDeliveries(ctx context.Context, filter models.DeliveryFilter) ([]*models.DeliverySummary, error) {
+	if filter.Status != "" && filter.Status != models.StatusSucceeded && filter.Status != models.StatusFailed {
+		return nil, fmt.Errorf("%w: status must be %s or %s", webhookErrors.ErrInvalidRequest, models.StatusSucceeded, models.StatusFailed)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultListLimit
+	}
+	if filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+	if filter.Offset < 0 {
+		filter.Offset = 0
+	}
+
+	deliveries, err := s.repo.ListDeliveries(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", webhookErrors.ErrDatabaseOperation, err)
+	}
+	return deliveries, nil
+}
+
+func (s *service) GetDelivery(ctx context.Context, deliveryID string) (*hooks.Delivery, error) {
+	if _, err := uuid.FromString(deliveryID); err != nil {
+		return nil, fmt.Errorf("%w: invalid delivery ID", webhookErrors.ErrInvalidRequest)
+	}
+	delivery, err := s.repo.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, webhookErrors.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("%w: %v", webhookErrors.ErrDatabaseOperation, err)
+	}
+	return delivery, nil
+}
+
+func (s *service) Redeliver(ctx context.Context, deliveryID string) (*hooks.Delivery, error) {
+	delivery, err := s.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	webhook, ok := s.findWebhook(delivery.WebhookID)
+	if !ok {
+		return nil, webhookErrors.ErrWebhookNotConfigured
+	}
+
+	if s.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.cfg.Timeout)
+		defer cancel()
+	}
+	return webhook.Redeliver(ctx, []byte(delivery.RequestBody), delivery.ID)
+}
+
+func (s *service) VerifySignature(ctx context.Context, req *models.VerifySignatureRequest) (*models.VerifySignatureResponse, error) {
+	if s.cfg.WebhookSecret == "" {
+		return nil, webhookErrors.ErrSecretNotConfigured
+	}
+
+	timestamp := strings.TrimSpace(req.Timestamp)
+	if timestamp == "" {
+		timestamp = strconv.FormatInt(s.now().Unix(), 10)
+	}
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timestamp must be unix seconds", webhookErrors.ErrInvalidRequest)
+	}
+
+	body := []byte(req.Payload)
+	result := &models.VerifySignatureResponse{
+		Timestamp:         timestamp,
+		ExpectedSignature: hooks.Sign(s.cfg.WebhookSecret, ts, body),
+	}
+	if err := hooks.VerifySignature(s.cfg.WebhookSecret, req.Signature, timestamp, body, 0, s.now()); err != nil {
+		result.Reason = err.Error()
+	} else {
+		result.Valid = true
+	}
+	return result, nil
+}
+
+func (s *service) Purge(ctx context.Context) (int64, error) {
+	if s.cfg.DeliveryRetention <= 0 {
+		return 0, nil
+	}
+	return s.repo.Purge(ctx, s.now().Add(-s.cfg.DeliveryRetention).UnixMilli())
+}
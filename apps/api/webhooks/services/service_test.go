@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/qolzam/telar/apps/api/internal/hooks"
+	platformconfig "github.com/qolzam/telar/apps/api/internal/platform/config"
+	webhookErrors "github.com/qolzam/telar/apps/api/webhooks/errors"
+	"github.com/qolzam/telar/apps/api/webhooks/models"
+	"github.com/qolzam/telar/apps/api/webhooks/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const deliveryID = "8d0b1c1e-3f4a-4b5c-9d6e-7f8091a2b3c4"
+
+var testConfig = platformconfig.HooksConfig{Timeout: time.Second, WebhookSecret: "secret", DeliveryRetention: 24 * time.Hour}
+
+func newTestService(repo *MockRepository, webhooks ...*hooks.Webhook) *service {
+	svc := NewService(repo, testConfig).(*service)
+	svc.webhooks = func() []*hooks.Webhook { return webhooks }
+	svc.findWebhook = func(id string) (*hooks.Webhook, bool) {
+		for _, w := range webhooks {
+			if w.ID == id {
+				return w, true
+			}
+		}
+		return nil, false
+	}
+	svc.now = func() time.Time { return time.Unix(1_800_000_000, 0) }
+	return svc
+}
+
+func TestListDeliveries(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	repo.On("ListDeliveries", ctx, models.DeliveryFilter{Status: models.StatusFailed, Limit: maxListLimit}).
+		Return([]*models.DeliverySummary{{ID: deliveryID}}, nil).Once()
+	svc := newTestService(repo)
+
+	deliveries, err := svc.ListDeliveries(ctx, models.DeliveryFilter{Status: models.StatusFailed, Limit: 500, Offset: -1})
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+
+	_, err = svc.ListDeliveries(ctx, models.DeliveryFilter{Status: "pending"})
+	assert.ErrorIs(t, err, webhookErrors.ErrInvalidRequest)
+	repo.AssertExpectations(t)
+}
+
+func TestGetDelivery(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	repo.On("GetDelivery", ctx, deliveryID).Return(nil, repository.ErrNotFound).Once()
+	svc := newTestService(repo)
+
+	_, err := svc.GetDelivery(ctx, deliveryID)
+	assert.ErrorIs(t, err, webhookErrors.ErrDeliveryNotFound)
+
+	_, err = svc.GetDelivery(ctx, "not-a-uuid")
+	assert.ErrorIs(t, err, webhookErrors.ErrInvalidRequest)
+	repo.AssertExpectations(t)
+}
+
+func TestRedeliver(t *testing.T) {
+	ctx := context.Background()
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		received = string(body)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	webhook := &hooks.Webhook{ID: "wh_1", Point: hooks.CommentAfterCreate, URL: server.URL}
+	original := &hooks.Delivery{ID: deliveryID, WebhookID: "wh_1", RequestBody: `{"point":"comment.afterCreate"}`}
+
+	t.Run("sends the recorded body again", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetDelivery", ctx, deliveryID).Return(original, nil).Once()
+
+		delivery, err := newTestService(repo, webhook).Redeliver(ctx, deliveryID)
+		require.NoError(t, err)
+		assert.Equal(t, original.RequestBody, received)
+		assert.Equal(t, deliveryID, delivery.RedeliveryOf)
+		assert.Equal(t, http.StatusServiceUnavailable, delivery.ResponseStatus)
+		assert.False(t, delivery.Succeeded())
+	})
+
+	t.Run("refuses webhooks that are no longer configured", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetDelivery", ctx, deliveryID).Return(original, nil).Once()
+
+		_, err := newTestService(repo).Redeliver(ctx, deliveryID)
+		assert.ErrorIs(t, err, webhookErrors.ErrWebhookNotConfigured)
+	})
+}
+
+func TestVerifySignature(t *testing.T) {
+	ctx := context.Background()
+	payload := `{"point":"user.afterSignup","payload":{}}`
+	signature := hooks.Sign("secret", 1_700_000_000, []byte(payload))
+	svc := newTestService(new(MockRepository))
+
+	result, err := svc.VerifySignature(ctx, &models.VerifySignatureRequest{Payload: payload, Signature: signature, Timestamp: "1700000000"})
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, signature, result.ExpectedSignature)
+
+	result, err = svc.VerifySignature(ctx, &models.VerifySignatureRequest{Payload: payload + " ", Signature: signature, Timestamp: "1700000000"})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, hooks.ErrSignatureMismatch.Error(), result.Reason)
+
+	result, err = svc.VerifySignature(ctx, &models.VerifySignatureRequest{Payload: payload})
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, "1800000000", result.Timestamp)
+	assert.Equal(t, hooks.Sign("secret", 1_800_000_000, []byte(payload)), result.ExpectedSignature)
+
+	_, err = svc.VerifySignature(ctx, &models.VerifySignatureRequest{Payload: payload, Timestamp: "yesterday"})
+	assert.ErrorIs(t, err, webhookErrors.ErrInvalidRequest)
+
+	svc.cfg.WebhookSecret = ""
+	_, err = svc.VerifySignature(ctx, &models.VerifySignatureRequest{Payload: payload})
+	assert.ErrorIs(t, err, webhookErrors.ErrSecretNotConfigured)
+}
+
+func TestPurge(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	repo.On("Purge", ctx, time.Unix(1_800_000_000, 0).Add(-24*time.Hour).UnixMilli()).Return(int64(3), nil).Once()
+
+	purged, err := newTestService(repo).Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	repo.AssertExpectations(t)
+}
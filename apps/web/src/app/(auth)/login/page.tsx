@@ -10,7 +10,7 @@ export const metadata: Metadata = {
 export default function LoginPage() {
   return (
     <AuthLayout
-      title="Welcome Back to Telar"
+      title="Welcome Back to {siteName}"
       subtitle="Connect with friends, share your moments, and discover new experiences in our vibrant community."
     >
       <LoginForm />
@@ -101,7 +101,7 @@ export default function SignupPage() {
 
   return (
     <AuthLayout
-      title="Welcome to Telar Social"
+      title="Welcome to {siteName}"
       subtitle="Connect with friends, share your moments, and discover new experiences in our vibrant community."
       illustration={signupIllustration}
     >
@@ -5,7 +5,7 @@ import { useParams } from 'next/navigation';
 import { Container, CircularProgress, Alert, Box } from '@mui/material';
 import { sdk } from '@/lib/sdk';
 import type { Post } from '@telar/sdk';
-import { PostCard } from '@/features/posts/components';
+import { PostCard, PostAttribution } from '@/features/posts/components';
 
 export default function SharedPostPage() {
   const params = useParams<{ urlKey: string }>();
@@ -61,6 +61,7 @@ export default function SharedPostPage() {
     <Container maxWidth="md" sx={{ py: 4 }}>
       <Box>
         <PostCard post={post} />
+        <PostAttribution post={post} seo />
       </Box>
     </Container>
   );
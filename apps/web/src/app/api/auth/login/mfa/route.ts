@@ -0,0 +1,81 @@
+import { NextRequest, NextResponse } from 'next/server';
+import { apiRequest, ApiError } from '@/lib/api';
+import { createSessionCookie } from '@/lib/auth/cookies';
+import type { MFALoginRequest, GoApiLoginResponse } from '@telar/sdk';
+
+export async function POST(request: NextRequest) {
+  try {
+    const body = await request.json() as MFALoginRequest;
+
+    if (!body.challengeToken || !body.code) {
+      return NextResponse.json(
+        { error: 'Challenge token and code are required' },
+        { status: 400 }
+      );
+    }
+
+    if (typeof body.challengeToken !== 'string' || typeof body.code !== 'string') {
+      return NextResponse.json(
+        { error: 'Invalid input format' },
+        { status: 400 }
+      );
+    }
+
+    const loginResponse = await apiRequest<GoApiLoginResponse>('/auth/login/mfa', {
+      method: 'POST',
+      body: JSON.stringify({
+        challengeToken: body.challengeToken,
+        code: body.code,
+      }),
+    });
+
+    if (!loginResponse.accessToken) {
+      return NextResponse.json(
+        { error: 'Authentication failed' },
+        { status: 500 }
+      );
+    }
+
+    const response = NextResponse.json(
+      {
+        success: true,
+        user: {
+          id: loginResponse.user.objectId,
+          displayName: loginResponse.user.fullName,
+          socialName: loginResponse.user.socialName,
+          email: loginResponse.user.email,
+        }
+      },
+      { status: 200 }
+    );
+
+    response.headers.set('Set-Cookie', createSessionCookie(loginResponse.accessToken));
+
+    return response;
+
+  } catch (error) {
+    if (error instanceof ApiError) {
+      console.error('[Login MFA] API error:', error.message, error.statusCode);
+      return NextResponse.json(
+        { error: error.message },
+        { status: error.statusCode }
+      );
+    }
+
+    console.error('[Login MFA] Unexpected error:', error);
+    return NextResponse.json(
+      { error: 'Internal server error' },
+      { status: 500 }
+    );
+  }
+}
+
+export async function OPTIONS() {
+  return new NextResponse(null, {
+    status: 204,
+    headers: {
+      'Access-Control-Allow-Methods': 'POST, OPTIONS',
+      'Access-Control-Allow-Headers': 'Content-Type',
+    },
+  });
+}
@@ -1,7 +1,7 @@
 import { NextRequest, NextResponse } from 'next/server';
 import { apiRequest, ApiError } from '@/lib/api';
 import { createSessionCookie } from '@/lib/auth/cookies';
-import type { LoginRequest, GoApiLoginResponse } from '@telar/sdk';
+import type { LoginRequest, GoApiLoginResponse, GoApiMFAChallengeResponse } from '@telar/sdk';
 
 export async function POST(request: NextRequest) {
   try {
@@ -21,7 +21,7 @@ export async function POST(request: NextRequest) {
       );
     }
 
-    const loginResponse = await apiRequest<GoApiLoginResponse>('/auth/login', {
+    const loginResponse = await apiRequest<GoApiLoginResponse | GoApiMFAChallengeResponse>('/auth/login', {
       method: 'POST',
       body: JSON.stringify({
         username: body.username,
@@ -29,7 +29,20 @@ export async function POST(request: NextRequest) {
       }),
     });
 
-    if (!loginResponse.accessToken) {
+    // Accounts with a second factor get a challenge instead of a session;
+    // the client completes it at /api/auth/login/mfa
+    if ('mfaRequired' in loginResponse && loginResponse.mfaRequired) {
+      return NextResponse.json(
+        {
+          mfaRequired: true,
+          challengeToken: loginResponse.challengeToken,
+          expiresIn: Number(loginResponse.expires_in),
+        },
+        { status: 200 }
+      );
+    }
+
+    if (!('accessToken' in loginResponse) || !loginResponse.accessToken) {
       return NextResponse.json(
         { error: 'Authentication failed' },
         { status: 500 }
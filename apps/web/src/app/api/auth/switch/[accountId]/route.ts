@@ -0,0 +1,61 @@
+import { NextRequest, NextResponse } from 'next/server';
+import { apiRequest, ApiError } from '@/lib/api';
+import { getAuthHeaders } from '@/lib/auth-helper';
+import { createSessionCookie } from '@/lib/auth/cookies';
+import type { GoApiLoginResponse } from '@telar/sdk';
+
+export async function POST(
+  request: NextRequest,
+  { params }: { params: Promise<{ accountId: string }> }
+) {
+  try {
+    const { accountId } = await params;
+
+    const switchResponse = await apiRequest<GoApiLoginResponse>(
+      `/auth/switch/${encodeURIComponent(accountId)}`,
+      {
+        method: 'POST',
+        headers: getAuthHeaders(request),
+      }
+    );
+
+    if (!switchResponse.accessToken) {
+      return NextResponse.json(
+        { error: 'Account switch failed' },
+        { status: 500 }
+      );
+    }
+
+    const response = NextResponse.json(
+      {
+        success: true,
+        user: {
+          id: switchResponse.user.objectId,
+          displayName: switchResponse.user.fullName,
+          socialName: switchResponse.user.socialName,
+          email: switchResponse.user.email,
+        }
+      },
+      { status: 200 }
+    );
+
+    response.headers.set('Set-Cookie', createSessionCookie(switchResponse.accessToken));
+
+    return response;
+
+  } catch (error) {
+    if (error instanceof ApiError) {
+      console.error('[Switch] API error:', error.message, error.statusCode);
+      return NextResponse.json(
+        { error: error.message },
+        { status: error.statusCode }
+      );
+    }
+
+    console.error('[Switch] Unexpected error:', error);
+    return NextResponse.json(
+      { error: 'Internal server error' },
+      { status: 500 }
+    );
+  }
+}
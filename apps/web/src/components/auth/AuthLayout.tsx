@@ -3,11 +3,12 @@
 
 import React from 'react';
 import { Box, Typography, useTheme, useMediaQuery } from '@mui/material';
+import { useBrandedText } from '@/features/branding/client';
 
 export interface AuthLayoutProps {
   /** Content to render in the right panel (form area) */
   children: React.ReactNode;
-  /** Title to display in the left panel */
+  /** Title to display in the left panel; `{siteName}` is replaced with the community name */
   title: string;
   /** Subtitle/description to display in the left panel */
   subtitle: string;
@@ -22,6 +23,7 @@ const AuthLayout: React.FC<AuthLayoutProps> = ({
   illustration
 }) => {
   const theme = useTheme();
+  const brandedTitle = useBrandedText(title);
   const isMobile = useMediaQuery(theme.breakpoints.down('md'));
 
   const defaultIllustration = (
@@ -148,7 +150,7 @@ const AuthLayout: React.FC<AuthLayoutProps> = ({
                   fontSize: { md: '3rem', lg: '3.5rem' },
                 }}
               >
-                {title}
+                {brandedTitle}
               </Typography>
               <Typography
                 variant="h5"
@@ -13,6 +13,8 @@ import { useState } from 'react';
 import { sdk } from '@/lib/sdk';
 import type {
   LoginRequest,
+  LoginResult,
+  MFALoginRequest,
   SignupRequest,
   SignupResponse,
   ForgotPasswordRequest,
@@ -88,31 +90,54 @@ export function useSession() {
 
 /**
  * Hook to login user
+ * Accounts with a second factor stop at mfaChallenge until verifyMfa succeeds
  */
 export function useLogin() {
   const router = useRouter();
   const { invalidateSession } = useSession();
+  const [mfaChallenge, setMfaChallenge] = useState<string | null>(null);
+
+  const completeLogin = () => {
+    setMfaChallenge(null);
+    invalidateSession();
+
+    const searchParams = new URLSearchParams(window.location.search);
+    const from = searchParams.get('from') || '/dashboard';
+    router.push(from);
+  };
 
   const mutation = useMutation({
     mutationFn: (credentials: LoginRequest) => sdk.auth.login(credentials),
-    onSuccess: () => {
-      invalidateSession();
-      
-      const searchParams = new URLSearchParams(window.location.search);
-      const from = searchParams.get('from') || '/dashboard';
-      router.push(from);
+    onSuccess: (result: LoginResult) => {
+      if (result?.mfaRequired && result.challengeToken) {
+        setMfaChallenge(result.challengeToken);
+        return;
+      }
+      completeLogin();
     },
     onError: (error: Error) => {
       console.error('[Login] Login failed:', error.message);
     },
   });
 
+  const mfaMutation = useMutation({
+    mutationFn: (data: MFALoginRequest) => sdk.auth.verifyMfa(data),
+    onSuccess: completeLogin,
+    onError: (error: Error) => {
+      console.error('[Login] Second factor failed:', error.message);
+    },
+  });
+
   return {
     login: mutation.mutate,
     loginAsync: mutation.mutateAsync,
-    isLoading: mutation.isPending,
-    error: mutation.error?.message || null,
-    isError: mutation.isError,
+    mfaChallenge,
+    verifyMfaAsync: (code: string) =>
+      mfaMutation.mutateAsync({ challengeToken: mfaChallenge ?? '', code }),
+    cancelMfa: () => setMfaChallenge(null),
+    isLoading: mutation.isPending || mfaMutation.isPending,
+    error: mutation.error?.message || mfaMutation.error?.message || null,
+    isError: mutation.isError || mfaMutation.isError,
     isSuccess: mutation.isSuccess,
     reset: mutation.reset,
   };
@@ -1,6 +1,6 @@
 'use client';
 
-import { useState, Suspense } from 'react';
+import { useState, Suspense, type FormEvent } from 'react';
 import { useSearchParams } from 'next/navigation';
 import Link from 'next/link';
 import { useForm } from 'react-hook-form';
@@ -27,11 +27,86 @@ import { useLogin } from '@/features/auth/client';
 import SocialLoginButtons from '@/features/auth/components/SocialLoginButtons';
 import { mapAuthError } from '@/features/auth/utils/errorMapper';
 
+interface MfaStepProps {
+  verifyMfaAsync: (code: string) => Promise<void>;
+  cancelMfa: () => void;
+}
+
+function MfaStep({ verifyMfaAsync, cancelMfa }: MfaStepProps) {
+  const { t } = useTranslation(['auth']);
+  const [code, setCode] = useState('');
+  const [error, setError] = useState<string | null>(null);
+  const [isSubmitting, setIsSubmitting] = useState(false);
+
+  const onSubmit = async (event: FormEvent) => {
+    event.preventDefault();
+    if (!code.trim()) {
+      return;
+    }
+    try {
+      setError(null);
+      setIsSubmitting(true);
+      await verifyMfaAsync(code.trim());
+    } catch (err: unknown) {
+      console.error('[Login] Second factor failed:', err);
+      setError(mapAuthError(err, 'login'));
+    } finally {
+      setIsSubmitting(false);
+    }
+  };
+
+  return (
+    <Box component="form" sx={{ width: '100%' }} autoComplete="off" noValidate onSubmit={onSubmit}>
+      <Box sx={{ mb: 4, textAlign: 'center' }}>
+        <Typography variant="h4" component="h1" gutterBottom>
+          {t('login.mfa.title')}
+        </Typography>
+        <Typography variant="body1" color="text.secondary">
+          {t('login.mfa.subtitle')}
+        </Typography>
+      </Box>
+
+      {error && (
+        <Alert severity="error" sx={{ mb: 3 }} onClose={() => setError(null)}>
+          {error}
+        </Alert>
+      )}
+
+      <TextField
+        fullWidth
+        autoFocus
+        autoComplete="one-time-code"
+        label={t('login.mfa.code')}
+        value={code}
+        onChange={(e) => setCode(e.target.value)}
+        disabled={isSubmitting}
+        variant="outlined"
+        margin="normal"
+      />
+
+      <Button
+        fullWidth
+        size="large"
+        type="submit"
+        variant="contained"
+        disabled={isSubmitting || !code.trim()}
+        sx={{ mt: 2, mb: 2, py: 1.5 }}
+      >
+        {isSubmitting ? t('login.mfa.submitting') : t('login.mfa.submit')}
+      </Button>
+
+      <Button fullWidth onClick={cancelMfa} disabled={isSubmitting}>
+        {t('login.mfa.back')}
+      </Button>
+    </Box>
+  );
+}
+
 function LoginFormContent() {
   const { t } = useTranslation(['auth', 'validation']);
   const searchParams = useSearchParams();
   const theme = useTheme();
-  const { loginAsync } = useLogin();
+  const { loginAsync, mfaChallenge, verifyMfaAsync, cancelMfa } = useLogin();
   const [showPassword, setShowPassword] = useState(false);
   const urlError = searchParams.get('error');
   const urlMessage = searchParams.get('message');
@@ -91,6 +166,10 @@ function LoginFormContent() {
 
   const enabledOAuthLogin = false; // TODO: Move to config
 
+  if (mfaChallenge) {
+    return <MfaStep verifyMfaAsync={verifyMfaAsync} cancelMfa={cancelMfa} />;
+  }
+
   return (
     <Box 
       component="form" 
@@ -0,0 +1,45 @@
+'use client';
+
+import { useQuery } from '@tanstack/react-query';
+import { sdk } from '@/lib/sdk';
+import type { Branding } from '@telar/sdk';
+
+/**
+ * Site name used until branding has loaded or when the API is unreachable
+ */
+export const DEFAULT_SITE_NAME = 'Telar';
+
+const defaultBranding: Branding = {
+  siteName: DEFAULT_SITE_NAME,
+  colors: {},
+  footerLinks: [],
+};
+
+/**
+ * Query keys for branding
+ */
+export const brandingKeys = {
+  all: ['branding'] as const,
+};
+
+/**
+ * Community branding, read once at boot and shared across the app.
+ * Always returns usable branding: defaults are used while loading or on error.
+ */
+export function useBranding(): Branding {
+  const { data } = useQuery({
+    queryKey: brandingKeys.all,
+    queryFn: () => sdk.branding.getBranding(),
+    staleTime: 5 * 60 * 1000,
+    retry: 1,
+  });
+  return data ?? defaultBranding;
+}
+
+/**
+ * Replaces `{siteName}` in a string with the community's site name
+ */
+export function useBrandedText(text: string): string {
+  const { siteName } = useBranding();
+  return text.split('{siteName}').join(siteName || DEFAULT_SITE_NAME);
+}
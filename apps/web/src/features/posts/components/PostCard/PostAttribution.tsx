@@ -0,0 +1,74 @@
+'use client';
+
+import { Link, Typography } from '@mui/material';
+import type { Post, PostLicense } from '@telar/sdk';
+
+/**
+ * Deed URLs for each license
+ * @see Go: apps/api/posts/models/license.go - licenseURLs
+ */
+const LICENSE_URLS: Record<PostLicense, string> = {
+  'CC0-1.0': 'https://creativecommons.org/publicdomain/zero/1.0/',
+  'CC-BY-4.0': 'https://creativecommons.org/licenses/by/4.0/',
+  'CC-BY-SA-4.0': 'https://creativecommons.org/licenses/by-sa/4.0/',
+  'CC-BY-NC-4.0': 'https://creativecommons.org/licenses/by-nc/4.0/',
+  'CC-BY-ND-4.0': 'https://creativecommons.org/licenses/by-nd/4.0/',
+  'CC-BY-NC-SA-4.0': 'https://creativecommons.org/licenses/by-nc-sa/4.0/',
+  'CC-BY-NC-ND-4.0': 'https://creativecommons.org/licenses/by-nc-nd/4.0/',
+  'all-rights-reserved': '',
+};
+
+interface PostAttributionProps {
+  post: Post;
+  /**
+   * Also emit rel="license" and rel="canonical" links into the document head,
+   * for standalone post pages that search engines index
+   */
+  seo?: boolean;
+}
+
+/**
+ * License and original-source line shown under a post
+ */
+export function PostAttribution({ post, seo = false }: PostAttributionProps) {
+  if (!post.license && !post.canonicalUrl) {
+    return null;
+  }
+
+  const licenseUrl = post.license ? LICENSE_URLS[post.license] : '';
+  let sourceHost = '';
+  if (post.canonicalUrl) {
+    try {
+      sourceHost = new URL(post.canonicalUrl).host;
+    } catch {
+      sourceHost = post.canonicalUrl;
+    }
+  }
+
+  return (
+    <>
+      {seo && licenseUrl && <link rel="license" href={licenseUrl} />}
+      {seo && post.canonicalUrl && <link rel="canonical" href={post.canonicalUrl} />}
+      <Typography variant="caption" color="text.secondary" component="p" sx={{ mt: 1 }}>
+        {post.license === 'all-rights-reserved' && 'All rights reserved'}
+        {post.license && post.license !== 'all-rights-reserved' && (
+          <>
+            Licensed under{' '}
+            <Link href={licenseUrl} target="_blank" rel="license noopener noreferrer">
+              {post.license}
+            </Link>
+          </>
+        )}
+        {post.license && post.canonicalUrl && ' · '}
+        {post.canonicalUrl && (
+          <>
+            Originally published at{' '}
+            <Link href={post.canonicalUrl} target="_blank" rel="noopener noreferrer">
+              {sourceHost}
+            </Link>
+          </>
+        )}
+      </Typography>
+    </>
+  );
+}
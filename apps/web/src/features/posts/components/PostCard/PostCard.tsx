@@ -59,6 +59,8 @@ export function PostCard({ post }: PostCardProps) {
   const deletePost = useDeletePostMutation();
   
   const isOwner = user?.id === post.ownerUserId;
+  // Accepted co-authors can edit the post; deleting stays with the owner
+  const canEdit = isOwner || (post.coauthors ?? []).some((coauthor) => coauthor.userId === user?.id);
   
   // Use session user's avatar if this is the current user's post, otherwise use post.ownerAvatar
   const displayAvatar = user?.id === post.ownerUserId && user?.avatar 
@@ -67,6 +69,10 @@ export function PostCard({ post }: PostCardProps) {
   const displayName = user?.id === post.ownerUserId && user?.displayName
     ? user.displayName
     : post.ownerDisplayName;
+  // Author block: owner first, then accepted co-authors
+  const authorNames = [displayName, ...(post.coauthors ?? []).map((coauthor) => coauthor.displayName)]
+    .filter(Boolean)
+    .join(' & ');
   
 
   // Use post prop directly as it comes from API with commentCounter field
@@ -119,7 +125,7 @@ export function PostCard({ post }: PostCardProps) {
               color: `var(--mui-palette-text-primary, ${textPrimary})`
             }}
           >
-            {displayName}
+            {authorNames}
           </Typography>
         }
         subheader={
@@ -137,14 +143,14 @@ export function PostCard({ post }: PostCardProps) {
           </Typography>
         }
         action={
-          isOwner ? (
+          canEdit ? (
             <PostMenu
               postId={post.objectId}
               onEdit={() => {
                 setIsEditing(true);
                 setEditBody(post.body);
               }}
-              onDelete={() => setDeleteDialogOpen(true)}
+              onDelete={isOwner ? () => setDeleteDialogOpen(true) : undefined}
             />
           ) : null
         }
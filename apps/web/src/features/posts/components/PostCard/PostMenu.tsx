@@ -7,7 +7,8 @@ import { MoreVert, Edit, Delete } from '@mui/icons-material';
 interface PostMenuProps {
   postId: string;
   onEdit: () => void;
-  onDelete: () => void;
+  /** Omitted for co-authors, who can edit but not delete */
+  onDelete?: () => void;
 }
 
 export function PostMenu({ postId, onEdit, onDelete }: PostMenuProps) {
@@ -30,7 +31,7 @@ export function PostMenu({ postId, onEdit, onDelete }: PostMenuProps) {
 
   const handleDelete = () => {
     handleClose();
-    onDelete();
+    onDelete?.();
   };
 
   return (
@@ -56,10 +57,12 @@ export function PostMenu({ postId, onEdit, onDelete }: PostMenuProps) {
           <Edit sx={{ mr: 1, fontSize: 18 }} />
           Edit
         </MenuItem>
-        <MenuItem onClick={handleDelete} sx={{ color: '#EF4444' }}>
-          <Delete sx={{ mr: 1, fontSize: 18 }} />
-          Delete
-        </MenuItem>
+        {onDelete && (
+          <MenuItem onClick={handleDelete} sx={{ color: '#EF4444' }}>
+            <Delete sx={{ mr: 1, fontSize: 18 }} />
+            Delete
+          </MenuItem>
+        )}
       </Menu>
     </>
   );
@@ -1,5 +1,6 @@
 export { PostCard } from './PostCard';
 export { PostCardSkeleton } from './PostCardSkeleton';
 export { PostMenu } from './PostMenu';
+export { PostAttribution } from './PostAttribution';
 
 
@@ -1,6 +1,6 @@
 export { PostForm } from "./PostForm";
 export { PostDialog } from "./PostDialog";
-export { PostCard, PostCardSkeleton } from "./PostCard";
+export { PostCard, PostCardSkeleton, PostAttribution } from "./PostCard";
 export { PostList } from "./PostList";
 export { PostDetails } from "./PostDetails";
 export { FollowingStories } from "./FollowingStories";
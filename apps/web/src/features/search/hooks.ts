@@ -29,7 +29,7 @@ export const useGlobalSearch = (query: string) => {
 
   const posts = useQuery({
     queryKey: ['search', 'posts', debouncedQuery],
-    queryFn: (): Promise<Post[]> => sdk.posts.searchPosts(debouncedQuery),
+    queryFn: async (): Promise<Post[]> => (await sdk.posts.searchPosts(debouncedQuery, { limit: 5 })).results,
     enabled,
     staleTime: 60_000,
   });
@@ -0,0 +1,75 @@
+/**
+ * Achievements SDK Module
+ *
+ * Badge catalog and the badges users have earned. Badges are granted by the server as
+ * users post, comment and receive upvotes; new ones arrive as 'badge' notifications.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * Badge definition
+ * @see Go: apps/api/achievements/models/badge.go - Badge
+ */
+export interface Badge {
+  id: string;
+  name: string;
+  description: string;
+  /** Rule that earns the badge, e.g. "upvotes >= 100" */
+  rule: string;
+}
+
+/**
+ * Badge a user earned
+ */
+export interface UserBadge extends Badge {
+  /** When the badge was granted (Unix milliseconds) */
+  grantedDate: number;
+}
+
+/**
+ * A user's badges, newest first
+ */
+export interface UserBadgesResponse {
+  userId: string;
+  badges: UserBadge[];
+}
+
+/**
+ * Achievements API interface
+ */
+export interface IAchievementsApi {
+  /**
+   * Every badge that can be earned
+   */
+  getBadges(): Promise<Badge[]>;
+
+  /**
+   * Badges a user earned, newest first
+   */
+  getUserBadges(userId: string): Promise<UserBadgesResponse>;
+
+  /**
+   * The current user's badges, newest first
+   */
+  getMyBadges(): Promise<UserBadgesResponse>;
+}
+
+/**
+ * Create Achievements API instance
+ */
+export const achievementsApi = (client: ApiClient): IAchievementsApi => ({
+  getBadges: async (): Promise<Badge[]> => {
+    const response = await client.get<{ badges: Badge[] }>(ENDPOINTS.ACHIEVEMENTS.BADGES);
+    return response.badges;
+  },
+
+  getUserBadges: async (userId: string): Promise<UserBadgesResponse> => {
+    return client.get<UserBadgesResponse>(ENDPOINTS.ACHIEVEMENTS.USER_BADGES(userId));
+  },
+
+  getMyBadges: async (): Promise<UserBadgesResponse> => {
+    return client.get<UserBadgesResponse>(ENDPOINTS.ACHIEVEMENTS.MY_BADGES);
+  },
+});
@@ -9,6 +9,11 @@ import { ApiClient } from './client';
 import { ENDPOINTS } from './config';
 import type {
   LoginRequest,
+  LoginResult,
+  MFALoginRequest,
+  MFAStatus,
+  MFASetupResponse,
+  MFARecoveryCodesResponse,
   SignupRequest,
   SignupResponse,
   ForgotPasswordRequest,
@@ -17,6 +22,9 @@ import type {
   VerifyEmailRequest,
   ResendVerificationRequest,
   SessionData,
+  LinkAccountRequest,
+  LinkedAccount,
+  AuthSession,
 } from './types';
 
 /**
@@ -25,9 +33,16 @@ import type {
 export interface IAuthApi {
   /**
    * Login with username and password
+   * Sets httpOnly session cookie on success, unless the account has a second
+   * factor: then mfaRequired is set and the login is finished with verifyMfa
+   */
+  login(credentials: LoginRequest): Promise<LoginResult>;
+
+  /**
+   * Finish a login held back for a second factor
    * Sets httpOnly session cookie on success
    */
-  login(credentials: LoginRequest): Promise<void>;
+  verifyMfa(data: MFALoginRequest): Promise<void>;
 
   /**
    * Logout current user
@@ -71,14 +86,70 @@ export interface IAuthApi {
    * Returns user info if authenticated
    */
   getSession(): Promise<SessionData>;
+
+  /**
+   * Link another account using its credentials
+   */
+  linkAccount(data: LinkAccountRequest): Promise<LinkedAccount>;
+
+  /**
+   * List accounts linked to the current user
+   */
+  getLinkedAccounts(): Promise<LinkedAccount[]>;
+
+  /**
+   * Remove a linked account
+   */
+  unlinkAccount(accountId: string): Promise<void>;
+
+  /**
+   * Switch to a linked account without re-entering credentials
+   * Replaces the httpOnly session cookie on success
+   */
+  switchAccount(accountId: string): Promise<void>;
+
+  /**
+   * List sessions with the identity each one uses
+   */
+  getSessions(): Promise<AuthSession[]>;
+
+  /**
+   * Get the current user's second factor state
+   */
+  getMfaStatus(): Promise<MFAStatus>;
+
+  /**
+   * Start second factor enrollment with a new secret
+   */
+  setupMfa(): Promise<MFASetupResponse>;
+
+  /**
+   * Confirm enrollment with a code from the new secret
+   * Returns the recovery codes, which are shown only once
+   */
+  enableMfa(code: string): Promise<MFARecoveryCodesResponse>;
+
+  /**
+   * Turn the second factor off; takes a current code
+   */
+  disableMfa(code: string): Promise<void>;
+
+  /**
+   * Replace the recovery codes; takes a current code
+   */
+  regenerateRecoveryCodes(code: string): Promise<MFARecoveryCodesResponse>;
 }
 
 /**
  * Create Auth API instance
  */
 export const authApi = (client: ApiClient): IAuthApi => ({
-  login: async (credentials: LoginRequest): Promise<void> => {
-    await client.post(ENDPOINTS.AUTH.LOGIN, credentials);
+  login: async (credentials: LoginRequest): Promise<LoginResult> => {
+    return client.post<LoginResult>(ENDPOINTS.AUTH.LOGIN, credentials);
+  },
+
+  verifyMfa: async (data: MFALoginRequest): Promise<void> => {
+    await client.post(ENDPOINTS.AUTH.LOGIN_MFA, data);
   },
 
   logout: async (): Promise<void> => {
@@ -112,5 +183,47 @@ export const authApi = (client: ApiClient): IAuthApi => ({
   getSession: async (): Promise<SessionData> => {
     return client.get<SessionData>(ENDPOINTS.AUTH.SESSION);
   },
+
+  linkAccount: async (data: LinkAccountRequest): Promise<LinkedAccount> => {
+    return client.post<LinkedAccount>(ENDPOINTS.ACCOUNTS.LINK, data);
+  },
+
+  getLinkedAccounts: async (): Promise<LinkedAccount[]> => {
+    const response = await client.get<{ accounts: LinkedAccount[] }>(ENDPOINTS.ACCOUNTS.LIST);
+    return response.accounts;
+  },
+
+  unlinkAccount: async (accountId: string): Promise<void> => {
+    await client.delete(ENDPOINTS.ACCOUNTS.UNLINK(accountId));
+  },
+
+  switchAccount: async (accountId: string): Promise<void> => {
+    await client.post(ENDPOINTS.AUTH.SWITCH(accountId));
+  },
+
+  getSessions: async (): Promise<AuthSession[]> => {
+    const response = await client.get<{ sessions: AuthSession[] }>(ENDPOINTS.ACCOUNTS.SESSIONS);
+    return response.sessions;
+  },
+
+  getMfaStatus: async (): Promise<MFAStatus> => {
+    return client.get<MFAStatus>(ENDPOINTS.MFA.STATUS);
+  },
+
+  setupMfa: async (): Promise<MFASetupResponse> => {
+    return client.post<MFASetupResponse>(ENDPOINTS.MFA.SETUP);
+  },
+
+  enableMfa: async (code: string): Promise<MFARecoveryCodesResponse> => {
+    return client.post<MFARecoveryCodesResponse>(ENDPOINTS.MFA.ENABLE, { code });
+  },
+
+  disableMfa: async (code: string): Promise<void> => {
+    await client.post(ENDPOINTS.MFA.DISABLE, { code });
+  },
+
+  regenerateRecoveryCodes: async (code: string): Promise<MFARecoveryCodesResponse> => {
+    return client.post<MFARecoveryCodesResponse>(ENDPOINTS.MFA.RECOVERY_CODES, { code });
+  },
 });
 
@@ -16,6 +16,24 @@ export interface ToggleBookmarkResponse {
   isBookmarked: boolean;
 }
 
+/**
+ * A named group of the user's bookmarks
+ */
+export interface BookmarkCollection {
+  objectId: string;
+  ownerUserId: string;
+  name: string;
+  bookmarkCount: number;
+  createdAt: string;
+}
+
+/**
+ * Bookmark list parameters; `collection` restricts the list to one collection
+ */
+export interface BookmarkQueryParams extends CursorQueryParams {
+  collection?: string;
+}
+
 /**
  * Bookmarks API interface
  */
@@ -29,10 +47,36 @@ export interface IBookmarksApi {
 
   /**
    * Get paginated list of bookmarked posts
-   * @param params - Optional cursor query parameters (cursor, limit)
+   * @param params - Optional cursor query parameters (cursor, limit) and collection ID
    * @returns Paginated posts response
    */
-  getBookmarks(params?: CursorQueryParams): Promise<PostsResponse>;
+  getBookmarks(params?: BookmarkQueryParams): Promise<PostsResponse>;
+
+  /**
+   * List the user's bookmark collections with their bookmark counts
+   */
+  getCollections(): Promise<BookmarkCollection[]>;
+
+  /**
+   * Create a collection (names are 1-60 characters, unique per user ignoring case)
+   */
+  createCollection(name: string): Promise<BookmarkCollection>;
+
+  /**
+   * Rename a collection
+   */
+  renameCollection(collectionId: string, name: string): Promise<void>;
+
+  /**
+   * Delete a collection; its bookmarks are kept
+   */
+  deleteCollection(collectionId: string): Promise<void>;
+
+  /**
+   * Bookmark a post and file it in exactly the given collections.
+   * An empty list takes it out of every collection and keeps the bookmark.
+   */
+  setPostCollections(postId: string, collectionIds: string[]): Promise<void>;
 }
 
 /**
@@ -43,15 +87,37 @@ export const bookmarksApi = (client: ApiClient): IBookmarksApi => ({
     return client.post<ToggleBookmarkResponse>(ENDPOINTS.BOOKMARKS.TOGGLE(postId));
   },
 
-  getBookmarks: async (params?: CursorQueryParams): Promise<PostsResponse> => {
+  getBookmarks: async (params?: BookmarkQueryParams): Promise<PostsResponse> => {
     const queryParams = new URLSearchParams();
     if (params?.limit) queryParams.append('limit', params.limit.toString());
     if (params?.cursor) queryParams.append('cursor', params.cursor);
+    if (params?.collection) queryParams.append('collection', params.collection);
     
     const url = queryParams.toString() 
       ? `${ENDPOINTS.BOOKMARKS.LIST}?${queryParams}` 
       : ENDPOINTS.BOOKMARKS.LIST;
     return client.get<PostsResponse>(url);
   },
+
+  getCollections: async (): Promise<BookmarkCollection[]> => {
+    const response = await client.get<{ collections: BookmarkCollection[] }>(ENDPOINTS.BOOKMARKS.COLLECTIONS);
+    return response.collections;
+  },
+
+  createCollection: async (name: string): Promise<BookmarkCollection> => {
+    return client.post<BookmarkCollection>(ENDPOINTS.BOOKMARKS.COLLECTIONS, { name });
+  },
+
+  renameCollection: async (collectionId: string, name: string): Promise<void> => {
+    await client.put(ENDPOINTS.BOOKMARKS.COLLECTION(collectionId), { name });
+  },
+
+  deleteCollection: async (collectionId: string): Promise<void> => {
+    await client.delete(ENDPOINTS.BOOKMARKS.COLLECTION(collectionId));
+  },
+
+  setPostCollections: async (postId: string, collectionIds: string[]): Promise<void> => {
+    await client.put(ENDPOINTS.BOOKMARKS.POST_COLLECTIONS(postId), { collectionIds });
+  },
 });
 
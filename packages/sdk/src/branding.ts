@@ -0,0 +1,76 @@
+/**
+ * Branding SDK Module
+ * 
+ * Provides community branding (site name, logo, colors, footer links).
+ * Reading branding is public so the web app can load it at boot;
+ * updating it requires an admin session.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * Brand colors as CSS hex values; missing colors keep the theme default
+ */
+export interface BrandColors {
+  primary?: string;
+  secondary?: string;
+  background?: string;
+  text?: string;
+}
+
+/**
+ * Custom footer link
+ */
+export interface FooterLink {
+  label: string;
+  url: string;
+}
+
+/**
+ * Community branding
+ */
+export interface Branding {
+  siteName: string;
+  logoUrl?: string;
+  faviconUrl?: string;
+  colors: BrandColors;
+  footerLinks: FooterLink[];
+  lastUpdated?: number;
+}
+
+/**
+ * Branding update request; replaces the stored branding
+ */
+export type UpdateBrandingRequest = Omit<Branding, 'lastUpdated'>;
+
+/**
+ * Branding API interface
+ */
+export interface IBrandingApi {
+  /**
+   * Get community branding
+   * @returns Branding with deployment defaults filled in
+   */
+  getBranding(): Promise<Branding>;
+
+  /**
+   * Replace community branding (admin only)
+   * @param data - New branding
+   * @returns Stored branding
+   */
+  updateBranding(data: UpdateBrandingRequest): Promise<Branding>;
+}
+
+/**
+ * Create Branding API instance
+ */
+export const brandingApi = (client: ApiClient): IBrandingApi => ({
+  getBranding: async (): Promise<Branding> => {
+    return client.get<Branding>(ENDPOINTS.BRANDING);
+  },
+
+  updateBranding: async (data: UpdateBrandingRequest): Promise<Branding> => {
+    return client.put<Branding>(ENDPOINTS.BRANDING, data);
+  },
+});
@@ -0,0 +1,183 @@
+/**
+ * Calendar SDK Module
+ *
+ * Post owners can attach a date, time and place to a post, making it an event.
+ * The calendar lists public events per day of a month, and each member can get a
+ * personal iCal URL to subscribe from their calendar app. Served only when the
+ * deployment enables the calendar.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * Date, time and place attached to a post
+ * @see Go: apps/api/calendar/models/calendar.go - Event
+ */
+export interface CalendarEvent {
+  postId: string;
+  ownerUserId: string;
+  title: string;
+  /** Unix milliseconds */
+  startsAt: number;
+  /** Unix milliseconds; equals startsAt for events without a length */
+  endsAt: number;
+  /** Whole UTC days: startsAt is a midnight, endsAt the midnight after the last day */
+  allDay: boolean;
+  location?: string;
+  createdDate: number;
+  lastUpdated: number;
+  /** The post's URL key, for links */
+  urlKey?: string;
+}
+
+/**
+ * Body for making a post an event or rescheduling it
+ * @see Go: apps/api/calendar/models/calendar.go - SetEventRequest
+ */
+export interface SetEventRequest {
+  /** Defaults to the first line of the post */
+  title?: string;
+  /** Unix milliseconds */
+  startsAt: number;
+  /** Unix milliseconds; omit for events without a length */
+  endsAt?: number;
+  allDay?: boolean;
+  location?: string;
+}
+
+/**
+ * Events on one day of a month
+ */
+export interface CalendarDay {
+  /** YYYY-MM-DD in the calendar's time zone */
+  date: string;
+  events: CalendarEvent[];
+}
+
+/**
+ * A month of events; days without events are left out
+ * @see Go: apps/api/calendar/models/calendar.go - Calendar
+ */
+export interface CalendarMonth {
+  /** YYYY-MM */
+  month: string;
+  timeZone: string;
+  days: CalendarDay[];
+}
+
+/**
+ * Personal or community iCal feed URL; anyone holding it can read the feed
+ */
+export interface CalendarSubscription {
+  url: string;
+  createdDate: number;
+}
+
+/**
+ * Calendar API interface
+ */
+export interface ICalendarApi {
+  /**
+   * Make one of the current user's posts an event, or reschedule it
+   */
+  setEvent(postId: string, data: SetEventRequest): Promise<CalendarEvent>;
+
+  /**
+   * Event attached to a post; fails with EVENT_NOT_FOUND for plain posts
+   */
+  getEvent(postId: string): Promise<CalendarEvent>;
+
+  /**
+   * Turn an event post back into a plain post
+   */
+  deleteEvent(postId: string): Promise<void>;
+
+  /**
+   * Events of a month per day
+   * @param options.month - YYYY-MM; defaults to the current month
+   * @param options.timeZone - IANA name such as Europe/Berlin; defaults to UTC
+   */
+  getCalendar(options?: { month?: string; timeZone?: string }): Promise<CalendarMonth>;
+
+  /**
+   * Issue a new iCal feed URL for the current user, revoking the previous one
+   */
+  subscribe(): Promise<CalendarSubscription>;
+
+  /**
+   * Revoke the current user's iCal feed URL
+   */
+  unsubscribe(): Promise<void>;
+
+  /**
+   * Events of a month per day among the posts in a community
+   * @param options.month - YYYY-MM; defaults to the current month
+   * @param options.timeZone - IANA name such as Europe/Berlin; defaults to UTC
+   */
+  getCommunityCalendar(communityId: string, options?: { month?: string; timeZone?: string }): Promise<CalendarMonth>;
+
+  /**
+   * Issue the current member a new iCal feed URL for a community, revoking the
+   * previous one; fails with MEMBERSHIP_REQUIRED for non-members. The feed stops
+   * working once they leave the community.
+   */
+  subscribeCommunity(communityId: string): Promise<CalendarSubscription>;
+
+  /**
+   * Revoke the current user's iCal feed URL for a community
+   */
+  unsubscribeCommunity(communityId: string): Promise<void>;
+}
+
+/**
+ * month and tz query parameters of a calendar request
+ */
+const monthQuery = (options?: { month?: string; timeZone?: string }): string => {
+  const params = new URLSearchParams();
+  if (options?.month) params.set('month', options.month);
+  if (options?.timeZone) params.set('tz', options.timeZone);
+  const query = params.toString();
+  return query ? `?${query}` : '';
+};
+
+/**
+ * Create Calendar API instance
+ */
+export const calendarApi = (client: ApiClient): ICalendarApi => ({
+  setEvent: async (postId: string, data: SetEventRequest): Promise<CalendarEvent> => {
+    return client.put<CalendarEvent>(ENDPOINTS.CALENDAR.POST(postId), data);
+  },
+
+  getEvent: async (postId: string): Promise<CalendarEvent> => {
+    return client.get<CalendarEvent>(ENDPOINTS.CALENDAR.POST(postId));
+  },
+
+  deleteEvent: async (postId: string): Promise<void> => {
+    await client.delete<void>(ENDPOINTS.CALENDAR.POST(postId));
+  },
+
+  getCalendar: async (options?: { month?: string; timeZone?: string }): Promise<CalendarMonth> => {
+    return client.get<CalendarMonth>(`${ENDPOINTS.CALENDAR.MONTH}${monthQuery(options)}`);
+  },
+
+  subscribe: async (): Promise<CalendarSubscription> => {
+    return client.post<CalendarSubscription>(ENDPOINTS.CALENDAR.SUBSCRIPTION);
+  },
+
+  unsubscribe: async (): Promise<void> => {
+    await client.delete<void>(ENDPOINTS.CALENDAR.SUBSCRIPTION);
+  },
+
+  getCommunityCalendar: async (communityId: string, options?: { month?: string; timeZone?: string }): Promise<CalendarMonth> => {
+    return client.get<CalendarMonth>(`${ENDPOINTS.CALENDAR.COMMUNITY(communityId)}${monthQuery(options)}`);
+  },
+
+  subscribeCommunity: async (communityId: string): Promise<CalendarSubscription> => {
+    return client.post<CalendarSubscription>(ENDPOINTS.CALENDAR.COMMUNITY_SUBSCRIPTION(communityId));
+  },
+
+  unsubscribeCommunity: async (communityId: string): Promise<void> => {
+    await client.delete<void>(ENDPOINTS.CALENDAR.COMMUNITY_SUBSCRIPTION(communityId));
+  },
+});
@@ -29,6 +29,16 @@ export interface RequestOptions extends Omit<RequestInit, 'body'> {
   timeout?: number;
 }
 
+/**
+ * Rate limit budget reported by the last response that carried one
+ */
+export interface RateLimitInfo {
+  limit: number;
+  remaining: number;
+  resetAt: number; // Unix milliseconds when the window resets
+  policy?: string; // See rateLimits.getPolicies()
+}
+
 /**
  * API Client configuration
  */
@@ -48,6 +58,7 @@ export class ApiClient {
   private apiBaseUrl: string;
   private bffBaseUrl: string;
   private timeout: number;
+  private lastRateLimit: RateLimitInfo | null = null;
 
   constructor(config: ApiClientConfig = {}) {
     this.apiBaseUrl = config.apiBaseUrl || '';
@@ -55,6 +66,26 @@ export class ApiClient {
     this.timeout = config.timeout || 10000;
   }
 
+  /**
+   * Rate limit budget from the last response that reported one, or null
+   */
+  get rateLimit(): RateLimitInfo | null {
+    return this.lastRateLimit;
+  }
+
+  /**
+   * Milliseconds to wait before the next request: until the window resets once the
+   * budget is used up, otherwise 0. The soft API limit never rejects, so waiting is
+   * up to the caller.
+   */
+  rateLimitDelay(): number {
+    const info = this.lastRateLimit;
+    if (!info || info.remaining > 0) {
+      return 0;
+    }
+    return Math.max(0, info.resetAt - Date.now());
+  }
+
   /**
    * Make a GET request
    */
@@ -131,6 +162,7 @@ export class ApiClient {
       });
 
       clearTimeout(timeoutId);
+      this.recordRateLimit(response);
 
       if (!response.ok) {
         await this.handleErrorResponse(response);
@@ -158,6 +190,35 @@ export class ApiClient {
     }
   }
 
+  /**
+   * Remember the X-RateLimit-* headers of a response; a 429 without them uses up the
+   * budget until Retry-After
+   */
+  private recordRateLimit(response: Response): void {
+    const limit = Number(response.headers.get('X-RateLimit-Limit'));
+    const remaining = Number(response.headers.get('X-RateLimit-Remaining'));
+    const reset = Number(response.headers.get('X-RateLimit-Reset'));
+    if (response.headers.has('X-RateLimit-Limit') && !isNaN(limit) && !isNaN(remaining) && !isNaN(reset)) {
+      this.lastRateLimit = {
+        limit,
+        remaining,
+        resetAt: Date.now() + reset * 1000,
+        policy: response.headers.get('X-RateLimit-Policy') || undefined,
+      };
+      return;
+    }
+
+    const retryAfter = Number(response.headers.get('Retry-After'));
+    if (response.status === 429 && retryAfter > 0) {
+      this.lastRateLimit = {
+        limit: this.lastRateLimit?.limit ?? 0,
+        remaining: 0,
+        resetAt: Date.now() + retryAfter * 1000,
+        policy: this.lastRateLimit?.policy,
+      };
+    }
+  }
+
   /**
    * Handle error responses from the API
    */
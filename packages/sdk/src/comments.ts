@@ -12,7 +12,11 @@ import type {
   UpdateCommentRequest,
   CommentQueryFilter,
   CommentsListResponse,
+  CommentContext,
+  CommentReactionType,
+  CommentReactionSummary,
 } from './types';
+import type { CommentSort } from './feed-defaults';
 
 export interface ICommentsApi {
   createComment(data: CreateCommentRequest): Promise<Comment>;
@@ -21,15 +25,25 @@ export interface ICommentsApi {
     postId: string,
     cursor?: string,
     limit?: number,
+    sort?: CommentSort, // Defaults to the deployment's comment sort
   ): Promise<CommentsListResponse>;
   getComment(commentId: string): Promise<Comment>;
   deleteComment(commentId: string, postId: string): Promise<void>;
   toggleLike(commentId: string): Promise<Comment>;
+  addReaction(
+    commentId: string,
+    type: CommentReactionType,
+  ): Promise<CommentReactionSummary>;
+  removeReaction(
+    commentId: string,
+    type: CommentReactionType,
+  ): Promise<CommentReactionSummary>;
   getCommentReplies(
     parentCommentId: string,
     cursor?: string,
     limit?: number,
   ): Promise<CommentsListResponse>;
+  getCommentContext(commentId: string, limit?: number): Promise<CommentContext>;
 }
 
 export const commentsApi = (client: ApiClient): ICommentsApi => ({
@@ -45,6 +59,7 @@ export const commentsApi = (client: ApiClient): ICommentsApi => ({
     postId: string,
     cursor?: string,
     limit?: number,
+    sort?: CommentSort,
   ): Promise<CommentsListResponse> {
     const params = new URLSearchParams();
     params.append('postId', postId);
@@ -58,6 +73,10 @@ export const commentsApi = (client: ApiClient): ICommentsApi => ({
       params.append('limit', limit.toString());
     }
 
+    if (sort) {
+      params.append('sort', sort);
+    }
+
     const url =
       ENDPOINTS.COMMENTS.GET_BY_POST +
       (params.toString() ? `?${params.toString()}` : '');
@@ -80,6 +99,25 @@ export const commentsApi = (client: ApiClient): ICommentsApi => ({
     return client.post<Comment>(url, {});
   },
 
+  async addReaction(
+    commentId: string,
+    type: CommentReactionType,
+  ): Promise<CommentReactionSummary> {
+    return client.post<CommentReactionSummary>(
+      ENDPOINTS.COMMENTS.REACTIONS(commentId),
+      { type },
+    );
+  },
+
+  async removeReaction(
+    commentId: string,
+    type: CommentReactionType,
+  ): Promise<CommentReactionSummary> {
+    return client.delete<CommentReactionSummary>(
+      ENDPOINTS.COMMENTS.REACTION(commentId, type),
+    );
+  },
+
   async getCommentReplies(
     parentCommentId: string,
     cursor?: string,
@@ -98,6 +136,14 @@ export const commentsApi = (client: ApiClient): ICommentsApi => ({
     }`;
     return client.get<CommentsListResponse>(url);
   },
-});
-
 
+  async getCommentContext(
+    commentId: string,
+    limit?: number,
+  ): Promise<CommentContext> {
+    const url = `${ENDPOINTS.COMMENTS.GET_CONTEXT(commentId)}${
+      limit ? `?limit=${limit}` : ''
+    }`;
+    return client.get<CommentContext>(url);
+  },
+});
@@ -39,6 +39,7 @@ export const ENDPOINTS = {
    */
   AUTH: {
     LOGIN: '/api/auth/login',
+    LOGIN_MFA: '/api/auth/login/mfa',
     LOGOUT: '/api/auth/logout',
     SIGNUP: '/api/auth/signup',
     SESSION: '/api/auth/session',
@@ -47,6 +48,28 @@ export const ENDPOINTS = {
     CHANGE_PASSWORD: '/api/auth/change-password',
     VERIFY_EMAIL: '/api/auth/verify',
     RESEND_VERIFICATION: '/api/auth/signup/resend',
+    SWITCH: (accountId: string) => `/api/auth/switch/${accountId}`,
+  },
+
+  /**
+   * Linked accounts and sessions (direct Go API calls)
+   */
+  ACCOUNTS: {
+    LIST: '/auth/accounts',
+    LINK: '/auth/accounts/link',
+    UNLINK: (accountId: string) => `/auth/accounts/${accountId}`,
+    SESSIONS: '/auth/sessions',
+  },
+
+  /**
+   * Second factor enrollment (direct Go API calls)
+   */
+  MFA: {
+    STATUS: '/auth/mfa',
+    SETUP: '/auth/mfa/setup',
+    ENABLE: '/auth/mfa/enable',
+    DISABLE: '/auth/mfa/disable',
+    RECOVERY_CODES: '/auth/mfa/recovery-codes',
   },
 
   /**
@@ -61,6 +84,12 @@ export const ENDPOINTS = {
     BY_IDS: '/profile/ids',
     QUERY: '/profile',
     SEARCH: '/profile/search',
+    ACTIVITY: (socialName: string) => `/profile/${socialName}/activity`,
+    MY_VIEWS: '/profile/my/views',
+    PRIVACY: '/settings/privacy',
+    NOTIFICATIONS: '/settings/notifications',
+    MUTED_KEYWORDS: '/settings/muted-keywords',
+    MUTED_KEYWORD: (keywordId: string) => `/settings/muted-keywords/${keywordId}`,
   },
 
   /**
@@ -69,6 +98,14 @@ export const ENDPOINTS = {
   POSTS: {
     SEARCH: '/posts/search',
   },
+
+  /**
+   * Hashtag endpoints (public)
+   */
+  TAGS: {
+    TRENDING: '/tags/trending', // GET ?window=1h|24h|7d
+    POSTS: (tag: string) => `/tags/${encodeURIComponent(tag)}/posts`, // GET ?cursor=&limit=
+  },
   /**
    * Comments endpoints (direct Go API calls)
    * Mirrors Go API routes in apps/api/comments/routes.go
@@ -79,9 +116,13 @@ export const ENDPOINTS = {
     GET_BY_POST: '/comments/',
     GET_BY_ID: (commentId: string) => `/comments/${commentId}`,
     GET_REPLIES: (commentId: string) => `/comments/${commentId}/replies`,
+    GET_CONTEXT: (commentId: string) => `/comments/${commentId}/context`,
     DELETE: (commentId: string, postId: string) =>
       `/comments/id/${commentId}/post/${postId}`,
     TOGGLE_LIKE: (commentId: string) => `/comments/${commentId}/like`,
+    REACTIONS: (commentId: string) => `/comments/${commentId}/reactions`,
+    REACTION: (commentId: string, type: string) =>
+      `/comments/${commentId}/reactions/${type}`,
     SCORE: '/comments/score', // Legacy endpoint (deprecated)
   },
 
@@ -91,6 +132,10 @@ export const ENDPOINTS = {
    */
   VOTES: {
     VOTE: '/votes', // POST /votes with { postId, typeId }
+    VELOCITY: (postId: string) => `/votes/posts/${postId}/velocity`, // GET, post author only
+    MINE: '/votes/mine', // GET ?postIds=a,b (up to 100)
+    SET: (postId: string) => `/posts/${postId}/vote`, // POST { vote: 'up' | 'down' | 'remove' }
+    VOTERS: (postId: string) => `/posts/${postId}/voters`, // GET ?type=up|down|all&cursor=&limit=
   },
 
   /**
@@ -100,6 +145,9 @@ export const ENDPOINTS = {
   BOOKMARKS: {
     TOGGLE: (postId: string) => `/bookmarks/${postId}/toggle`,
     LIST: '/bookmarks',
+    COLLECTIONS: '/bookmarks/collections',
+    COLLECTION: (collectionId: string) => `/bookmarks/collections/${collectionId}`,
+    POST_COLLECTIONS: (postId: string) => `/bookmarks/${postId}/collections`,
   },
 
   /**
@@ -112,5 +160,153 @@ export const ENDPOINTS = {
     GET_URL: (fileId: string) => `/storage/files/${fileId}/url`,
     DELETE: (fileId: string) => `/storage/files/${fileId}`,
   },
+
+  /**
+   * Rate limit policies endpoint (direct Go API calls)
+   * Mirrors the Go API route in apps/api/internal/bootstrap/server.go
+   */
+  RATE_LIMITS: '/rate-limits',
+
+  /**
+   * Branding endpoint (direct Go API calls)
+   * Mirrors Go API routes in apps/api/settings/routes.go
+   */
+  BRANDING: '/branding',
+
+  /**
+   * Feed defaults endpoint (direct Go API calls)
+   * Mirrors Go API routes in apps/api/settings/routes.go
+   */
+  FEED_DEFAULTS: '/feed-defaults',
+
+  /**
+   * Delegation endpoints (direct Go API calls)
+   * Mirrors Go API routes in apps/api/delegations/routes.go
+   */
+  DELEGATIONS: {
+    CREATE: '/delegations',
+    GRANTED: '/delegations/granted',
+    RECEIVED: '/delegations/received',
+    AUDIT: '/delegations/audit',
+    ACCEPT: (grantId: string) => `/delegations/${grantId}/accept`,
+    DECLINE: (grantId: string) => `/delegations/${grantId}/decline`,
+    REVOKE: (grantId: string) => `/delegations/${grantId}`,
+  },
+
+  /**
+   * Follow graph endpoints
+   */
+  FOLLOWS: {
+    USER: (userId: string) => `/follow/${userId}`,
+    FOLLOWERS: (userId: string) => `/follow/${userId}/followers`,
+    FOLLOWING: (userId: string) => `/follow/${userId}/following`,
+  },
+
+  /**
+   * Mention endpoints
+   * Mirrors Go API routes in apps/api/mentions/routes.go
+   */
+  MENTIONS: {
+    LIST: '/mentions',
+  },
+
+  /**
+   * Badge endpoints
+   * Mirrors Go API routes in apps/api/achievements/routes.go
+   */
+  ACHIEVEMENTS: {
+    BADGES: '/achievements/badges',
+    MY_BADGES: '/achievements/me/badges',
+    USER_BADGES: (userId: string) => `/achievements/users/${userId}/badges`,
+  },
+
+  /**
+   * Leaderboard endpoints
+   * Mirrors Go API routes in apps/api/leaderboards/routes.go
+   */
+  LEADERBOARDS: {
+    GET: (scope: string, period: string) => `/leaderboards/${scope}/${period}`,
+  },
+
+  /**
+   * Streak endpoints
+   * Mirrors Go API routes in apps/api/streaks/routes.go
+   */
+  STREAKS: {
+    ME: '/streaks/me',
+    USER: (userId: string) => `/streaks/users/${userId}`,
+  },
+
+  /**
+   * Supporter tier, subscription and revenue endpoints
+   * Mirrors Go API routes in apps/api/supporters/routes.go
+   */
+  SUPPORTERS: {
+    TIERS: '/supporters/tiers',
+    TIER: (tierId: string) => `/supporters/tiers/${tierId}`,
+    CREATOR_TIERS: (creatorId: string) => `/supporters/creators/${creatorId}/tiers`,
+    SUPPORTING: '/supporters/me/supporting',
+    REVENUE: '/supporters/me/revenue',
+  },
+
+  /**
+   * Tip and payout ledger endpoints
+   * Mirrors Go API routes in apps/api/tips/routes.go
+   */
+  TIPS: {
+    POST: (postId: string) => `/tips/posts/${postId}`,
+    USER: (userId: string) => `/tips/users/${userId}`,
+    TIP: (tipId: string) => `/tips/${tipId}`,
+    LEDGER: '/tips/me/ledger',
+  },
+
+  /**
+   * Thanks and weekly allowance endpoints
+   * Mirrors Go API routes in apps/api/thanks/routes.go
+   */
+  THANKS: {
+    POST: (postId: string) => `/thanks/posts/${postId}`,
+    USER: (userId: string) => `/thanks/users/${userId}`,
+    ALLOWANCE: '/thanks/me/allowance',
+  },
+
+  /**
+   * Event posts, the calendar and iCal subscription endpoints
+   * Mirrors Go API routes in apps/api/calendar/routes.go
+   */
+  CALENDAR: {
+    MONTH: '/calendar',
+    POST: (postId: string) => `/calendar/posts/${postId}`,
+    SUBSCRIPTION: '/calendar/subscription',
+    COMMUNITY: (communityId: string) => `/communities/${communityId}/calendar`,
+    COMMUNITY_SUBSCRIPTION: (communityId: string) => `/communities/${communityId}/calendar/subscription`,
+  },
+
+  /**
+   * Join questions and the approval queue of each community
+   * Mirrors Go API routes in apps/api/membership/routes.go
+   */
+  MEMBERSHIP: {
+    QUESTIONS: (communityId: string) => `/communities/${communityId}/questions`,
+    APPLICATIONS: (communityId: string) => `/communities/${communityId}/applications`,
+    MY_APPLICATION: (communityId: string) => `/communities/${communityId}/applications/me`,
+    APPLICATION: (communityId: string, applicationId: string) => `/communities/${communityId}/applications/${applicationId}`,
+  },
+
+  /**
+   * The rules of each community and their acknowledgment
+   * Mirrors Go API routes in apps/api/rules/routes.go
+   */
+  RULES: {
+    BASE: (communityId: string) => `/communities/${communityId}/rules`,
+    ACKNOWLEDGMENT: (communityId: string) => `/communities/${communityId}/rules/acknowledgment`,
+  },
+
+  /**
+   * Realtime gateway (WebSocket on the Go API)
+   */
+  REALTIME: {
+    WS: '/realtime/ws',
+  },
 } as const;
 
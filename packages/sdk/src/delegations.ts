@@ -0,0 +1,135 @@
+/**
+ * Delegations SDK Module
+ *
+ * Lets an account grant another user rights to act as it (e.g. a social media
+ * manager posting as a brand). Grants take effect once the delegate accepts them;
+ * posts created with `actingAs` are recorded in the owner's audit trail under the
+ * delegate who wrote them.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * Rights a grant can carry
+ */
+export type DelegationScope = 'post:create';
+
+/**
+ * Grant lifecycle: pending until the delegate answers, active until revoked
+ */
+export type DelegationStatus = 'pending' | 'active' | 'declined' | 'revoked';
+
+/**
+ * Delegation grant
+ * @see Go: apps/api/delegations/models/delegation.go - Grant
+ */
+export interface DelegationGrant {
+  objectId: string;
+  ownerUserId: string;
+  delegateUserId: string;
+  scopes: DelegationScope[];
+  status: DelegationStatus;
+  createdDate: number;
+  respondedDate?: number;
+  lastUpdated: number;
+}
+
+/**
+ * Request to grant another user rights on the current account
+ */
+export interface CreateDelegationRequest {
+  delegateUserId: string;
+  scopes: DelegationScope[];
+}
+
+/**
+ * Action a delegate took as the owner
+ * @see Go: apps/api/delegations/models/delegation.go - AuditEntry
+ */
+export interface DelegationAuditEntry {
+  objectId: string;
+  grantId: string;
+  ownerUserId: string;
+  delegateUserId: string;
+  scope: DelegationScope;
+  targetId?: string;
+  createdDate: number;
+}
+
+/**
+ * Delegations API interface
+ */
+export interface IDelegationsApi {
+  /**
+   * Offer a user rights on the current account; the grant starts pending
+   */
+  createGrant(data: CreateDelegationRequest): Promise<DelegationGrant>;
+
+  /**
+   * Grants the current user has given
+   */
+  getGranted(): Promise<DelegationGrant[]>;
+
+  /**
+   * Grants offered to the current user
+   */
+  getReceived(): Promise<DelegationGrant[]>;
+
+  /**
+   * Accept a pending grant offered to the current user
+   */
+  acceptGrant(grantId: string): Promise<DelegationGrant>;
+
+  /**
+   * Decline a pending grant offered to the current user
+   */
+  declineGrant(grantId: string): Promise<DelegationGrant>;
+
+  /**
+   * Revoke a grant (owner) or give it up (delegate)
+   */
+  revokeGrant(grantId: string): Promise<void>;
+
+  /**
+   * Actions delegates took as the current user, newest first
+   */
+  getAudit(limit?: number): Promise<DelegationAuditEntry[]>;
+}
+
+/**
+ * Create Delegations API instance
+ */
+export const delegationsApi = (client: ApiClient): IDelegationsApi => ({
+  createGrant: async (data: CreateDelegationRequest): Promise<DelegationGrant> => {
+    return client.post<DelegationGrant>(ENDPOINTS.DELEGATIONS.CREATE, data);
+  },
+
+  getGranted: async (): Promise<DelegationGrant[]> => {
+    const response = await client.get<{ grants: DelegationGrant[] }>(ENDPOINTS.DELEGATIONS.GRANTED);
+    return response.grants;
+  },
+
+  getReceived: async (): Promise<DelegationGrant[]> => {
+    const response = await client.get<{ grants: DelegationGrant[] }>(ENDPOINTS.DELEGATIONS.RECEIVED);
+    return response.grants;
+  },
+
+  acceptGrant: async (grantId: string): Promise<DelegationGrant> => {
+    return client.post<DelegationGrant>(ENDPOINTS.DELEGATIONS.ACCEPT(grantId));
+  },
+
+  declineGrant: async (grantId: string): Promise<DelegationGrant> => {
+    return client.post<DelegationGrant>(ENDPOINTS.DELEGATIONS.DECLINE(grantId));
+  },
+
+  revokeGrant: async (grantId: string): Promise<void> => {
+    await client.delete(ENDPOINTS.DELEGATIONS.REVOKE(grantId));
+  },
+
+  getAudit: async (limit?: number): Promise<DelegationAuditEntry[]> => {
+    const query = limit ? `?limit=${limit}` : '';
+    const response = await client.get<{ entries: DelegationAuditEntry[] }>(`${ENDPOINTS.DELEGATIONS.AUDIT}${query}`);
+    return response.entries;
+  },
+});
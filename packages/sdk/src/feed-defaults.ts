@@ -0,0 +1,60 @@
+/**
+ * Feed Defaults SDK Module
+ *
+ * Provides the deployment's default feed algorithm, root comment order and
+ * visibility of new posts. Feed and comment requests that name no sort follow
+ * them; updating them requires an admin session.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+export type FeedAlgorithm = 'chronological' | 'ranked';
+export type CommentSort = 'newest' | 'oldest';
+export type PostVisibility = 'Public' | 'OnlyMe' | 'Circles';
+
+/**
+ * Deployment feed defaults
+ */
+export interface FeedDefaults {
+  feedAlgorithm: FeedAlgorithm;
+  commentSort: CommentSort;
+  postVisibility: PostVisibility; // Permission of posts created without one
+  lastUpdated?: number;
+}
+
+/**
+ * Feed defaults update request; omitted fields keep their value
+ */
+export type UpdateFeedDefaultsRequest = Partial<Omit<FeedDefaults, 'lastUpdated'>>;
+
+/**
+ * Feed Defaults API interface
+ */
+export interface IFeedDefaultsApi {
+  /**
+   * Get the deployment feed defaults
+   * @returns Feed defaults with built-in ones filled in
+   */
+  getFeedDefaults(): Promise<FeedDefaults>;
+
+  /**
+   * Change the deployment feed defaults (admin only)
+   * @param data - Fields to change
+   * @returns Stored feed defaults
+   */
+  updateFeedDefaults(data: UpdateFeedDefaultsRequest): Promise<FeedDefaults>;
+}
+
+/**
+ * Create Feed Defaults API instance
+ */
+export const feedDefaultsApi = (client: ApiClient): IFeedDefaultsApi => ({
+  getFeedDefaults: async (): Promise<FeedDefaults> => {
+    return client.get<FeedDefaults>(ENDPOINTS.FEED_DEFAULTS);
+  },
+
+  updateFeedDefaults: async (data: UpdateFeedDefaultsRequest): Promise<FeedDefaults> => {
+    return client.put<FeedDefaults>(ENDPOINTS.FEED_DEFAULTS, data);
+  },
+});
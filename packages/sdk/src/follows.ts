@@ -0,0 +1,102 @@
+/**
+ * Follows SDK Module
+ *
+ * Server-side follow graph: follow and unfollow users, and page through anyone's
+ * followers or the users they follow.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * User listed as a follower or followee
+ * @see Go: apps/api/follows/models/follow.go - FollowUser
+ */
+export interface FollowUser {
+  userId: string;
+  fullName: string;
+  socialName: string;
+  avatar: string;
+  /** When the follow started (Unix milliseconds) */
+  followedDate: number;
+}
+
+/**
+ * Page of followers or followees
+ */
+export interface FollowListResponse {
+  users: FollowUser[];
+  nextCursor?: string;
+  hasNext: boolean;
+}
+
+/**
+ * Cursor pagination for follow lists
+ */
+export interface FollowListParams {
+  cursor?: string;
+  limit?: number;
+}
+
+/**
+ * Follows API interface
+ */
+export interface IFollowsApi {
+  /**
+   * Follow a user; following twice is not an error
+   */
+  follow(userId: string): Promise<void>;
+
+  /**
+   * Stop following a user
+   */
+  unfollow(userId: string): Promise<void>;
+
+  /**
+   * Whether the current user follows a user
+   */
+  isFollowing(userId: string): Promise<boolean>;
+
+  /**
+   * Users following a user, newest first
+   */
+  getFollowers(userId: string, params?: FollowListParams): Promise<FollowListResponse>;
+
+  /**
+   * Users a user follows, newest first
+   */
+  getFollowing(userId: string, params?: FollowListParams): Promise<FollowListResponse>;
+}
+
+const listQuery = (params?: FollowListParams): string => {
+  const queryParams = new URLSearchParams();
+  if (params?.cursor) queryParams.append('cursor', params.cursor);
+  if (params?.limit) queryParams.append('limit', params.limit.toString());
+  return queryParams.toString() ? `?${queryParams}` : '';
+};
+
+/**
+ * Create Follows API instance
+ */
+export const followsApi = (client: ApiClient): IFollowsApi => ({
+  follow: async (userId: string): Promise<void> => {
+    await client.post<{ following: boolean }>(ENDPOINTS.FOLLOWS.USER(userId));
+  },
+
+  unfollow: async (userId: string): Promise<void> => {
+    await client.delete<{ following: boolean }>(ENDPOINTS.FOLLOWS.USER(userId));
+  },
+
+  isFollowing: async (userId: string): Promise<boolean> => {
+    const response = await client.get<{ following: boolean }>(ENDPOINTS.FOLLOWS.USER(userId));
+    return response.following;
+  },
+
+  getFollowers: async (userId: string, params?: FollowListParams): Promise<FollowListResponse> => {
+    return client.get<FollowListResponse>(`${ENDPOINTS.FOLLOWS.FOLLOWERS(userId)}${listQuery(params)}`);
+  },
+
+  getFollowing: async (userId: string, params?: FollowListParams): Promise<FollowListResponse> => {
+    return client.get<FollowListResponse>(`${ENDPOINTS.FOLLOWS.FOLLOWING(userId)}${listQuery(params)}`);
+  },
+});
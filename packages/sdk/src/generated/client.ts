@@ -0,0 +1,278 @@
+// Code generated by sdkgen from the Go API models. DO NOT EDIT.
+// Regenerate with "make sdk-generate"; see apps/api/cmd/sdkgen.
+
+import { ApiError } from '../client';
+import type { ApiErrorResponse } from '../types';
+import type {
+  CommentResponse,
+  CommentsListResponse,
+  CreateCommentRequest,
+  CreatePostRequest,
+  CreatedPost,
+  LoginRequest,
+  MFALoginModel,
+  PostResponse,
+  PostsListResponse,
+  Profile,
+  Session,
+  SetVoteRequest,
+  UpdateCommentRequest,
+  UpdatePostRequest,
+  UpdateProfileRequest,
+  VoteChange,
+  VoterListResponse,
+} from './models';
+
+/** Options of a client created with createClient */
+export interface ClientOptions {
+  /** API base URL, e.g. "https://api.example.com"; empty for the same origin */
+  baseUrl: string;
+  /** Returns the access token sent as a bearer token, called before every request */
+  getToken?: () => string | null | undefined | Promise<string | null | undefined>;
+  /** Headers sent with every request */
+  headers?: Record<string, string>;
+  /** Whether to send cookies, e.g. 'include' for the session cookie of the web app */
+  credentials?: RequestCredentials;
+  /** fetch implementation; defaults to the global fetch */
+  fetch?: typeof fetch;
+}
+
+type QueryValue = string | number | boolean | string[] | undefined;
+
+async function send<T>(
+  options: ClientOptions,
+  method: string,
+  path: string,
+  query?: Record<string, QueryValue>,
+  body?: unknown
+): Promise<T> {
+  const params = new URLSearchParams();
+  for (const [key, value] of Object.entries(query ?? {})) {
+    if (value === undefined || value === '' || (Array.isArray(value) && value.length === 0)) {
+      continue;
+    }
+    params.set(key, Array.isArray(value) ? value.join(',') : String(value));
+  }
+  const search = params.toString();
+  const url = options.baseUrl.replace(/\/+$/, '') + path + (search ? '?' + search : '');
+
+  const headers: Record<string, string> = { Accept: 'application/json', ...options.headers };
+  if (body !== undefined) {
+    headers['Content-Type'] = 'application/json';
+  }
+  const token = await options.getToken?.();
+  if (token) {
+    headers.Authorization = 'Bearer ' + token;
+  }
+
+  let response: Response;
+  try {
+    response = await (options.fetch ?? fetch)(url, {
+      method,
+      headers,
+      credentials: options.credentials,
+      body: body === undefined ? undefined : JSON.stringify(body),
+    });
+  } catch (error) {
+    throw new ApiError('Network error', 500, 'NETWORK_ERROR', error);
+  }
+
+  if (!response.ok) {
+    const text = await response.text();
+    let message = text || 'API request failed';
+    let code: string | undefined;
+    try {
+      const error: ApiErrorResponse = JSON.parse(text);
+      message = (typeof error.details === 'string' && error.details) || error.message || error.error || message;
+      code = error.code;
+    } catch {
+      // Not a JSON error body; keep the text
+    }
+    throw new ApiError(message, response.status, code);
+  }
+
+  if (!(response.headers.get('content-type') ?? '').includes('application/json')) {
+    return undefined as T;
+  }
+  return (await response.json()) as T;
+}
+
+/** Creates a client for the API served at options.baseUrl */
+export function createClient(options: ClientOptions) {
+  return {
+    auth: {
+      /**
+       * Signs in with a username (email) and password
+       *
+       * POST /auth/login
+       */
+      login: (body: LoginRequest) =>
+        send<Session>(options, 'POST', '/auth/login', undefined, body),
+      /**
+       * Completes a sign-in held back for a second factor
+       *
+       * POST /auth/login/mfa
+       */
+      verifyMfa: (body: MFALoginModel) =>
+        send<Session>(options, 'POST', '/auth/login/mfa', undefined, body),
+    },
+    posts: {
+      /**
+       * Publishes a post as the signed-in user
+       *
+       * POST /posts/
+       */
+      create: (body: CreatePostRequest) =>
+        send<CreatedPost>(options, 'POST', '/posts/', undefined, body),
+      /**
+       * Reads a post
+       *
+       * GET /posts/:postId
+       */
+      get: (postId: string) =>
+        send<PostResponse>(options, 'GET', `/posts/${encodeURIComponent(postId)}`),
+      /**
+       * Reads a post by its URL key
+       *
+       * GET /posts/urlkey/:urlkey
+       */
+      getByUrlKey: (urlkey: string) =>
+        send<PostResponse>(options, 'GET', `/posts/urlkey/${encodeURIComponent(urlkey)}`),
+      /**
+       * Pages through posts, newest first unless sorted otherwise
+       *
+       * GET /posts/
+       */
+      list: (query: { cursor?: string; snapshot?: string; limit?: number; owner?: string; postType?: number; tags?: string[]; search?: string; sortBy?: string; sortOrder?: string } = {}) =>
+        send<PostsListResponse>(options, 'GET', '/posts/', query),
+      /**
+       * Changes the fields set in the request on a post
+       *
+       * PUT /posts/
+       */
+      update: (body: UpdatePostRequest) =>
+        send<void>(options, 'PUT', '/posts/', undefined, body),
+      /**
+       * Deletes a post
+       *
+       * DELETE /posts/:postId
+       */
+      delete: (postId: string) =>
+        send<void>(options, 'DELETE', `/posts/${encodeURIComponent(postId)}`),
+    },
+    comments: {
+      /**
+       * Comments on a post, or replies to a comment when parentCommentId is set
+       *
+       * POST /comments/
+       */
+      create: (body: CreateCommentRequest) =>
+        send<CommentResponse>(options, 'POST', '/comments/', undefined, body),
+      /**
+       * Reads a comment
+       *
+       * GET /comments/:commentId
+       */
+      get: (commentId: string) =>
+        send<CommentResponse>(options, 'GET', `/comments/${encodeURIComponent(commentId)}`),
+      /**
+       * Pages through the root comments of a post
+       *
+       * GET /comments/
+       */
+      listByPost: (query: { postId: string; cursor?: string; limit?: number; sort?: string }) =>
+        send<CommentsListResponse>(options, 'GET', '/comments/', query),
+      /**
+       * Pages through the replies to a comment
+       *
+       * GET /comments/:commentId/replies
+       */
+      listReplies: (commentId: string, query: { cursor?: string; limit?: number } = {}) =>
+        send<CommentsListResponse>(options, 'GET', `/comments/${encodeURIComponent(commentId)}/replies`, query),
+      /**
+       * Edits the text of a comment
+       *
+       * PUT /comments/
+       */
+      update: (body: UpdateCommentRequest) =>
+        send<CommentResponse>(options, 'PUT', '/comments/', undefined, body),
+      /**
+       * Deletes a comment and its replies
+       *
+       * DELETE /comments/id/:commentId/post/:postId
+       */
+      delete: (commentId: string, postId: string) =>
+        send<void>(options, 'DELETE', `/comments/id/${encodeURIComponent(commentId)}/post/${encodeURIComponent(postId)}`),
+      /**
+       * Likes a comment, or takes the like back
+       *
+       * POST /comments/:commentId/like
+       */
+      toggleLike: (commentId: string) =>
+        send<CommentResponse>(options, 'POST', `/comments/${encodeURIComponent(commentId)}/like`),
+    },
+    profiles: {
+      /**
+       * Reads the signed-in user's profile
+       *
+       * GET /profile/my
+       */
+      me: () =>
+        send<Profile>(options, 'GET', '/profile/my'),
+      /**
+       * Reads a user's profile
+       *
+       * GET /profile/id/:userId
+       */
+      get: (userId: string) =>
+        send<Profile>(options, 'GET', `/profile/id/${encodeURIComponent(userId)}`),
+      /**
+       * Reads a profile by social name
+       *
+       * GET /profile/social/:name
+       */
+      getBySocialName: (name: string) =>
+        send<Profile>(options, 'GET', `/profile/social/${encodeURIComponent(name)}`),
+      /**
+       * Reads the profiles of several users; unknown users are left out
+       *
+       * POST /profile/ids
+       */
+      getMany: (body: string[]) =>
+        send<Profile[]>(options, 'POST', '/profile/ids', undefined, body),
+      /**
+       * Finds profiles by name
+       *
+       * GET /profile/search
+       */
+      search: (query: { q: string; limit?: number }) =>
+        send<Profile[]>(options, 'GET', '/profile/search', query),
+      /**
+       * Changes the fields set in the request on the signed-in user's profile
+       *
+       * PUT /profile/
+       */
+      update: (body: UpdateProfileRequest) =>
+        send<void>(options, 'PUT', '/profile/', undefined, body),
+    },
+    votes: {
+      /**
+       * Sets the signed-in user's vote on a post
+       *
+       * POST /posts/:postId/vote
+       */
+      set: (postId: string, body: SetVoteRequest) =>
+        send<VoteChange>(options, 'POST', `/posts/${encodeURIComponent(postId)}/vote`, undefined, body),
+      /**
+       * Pages through the voters of a post
+       *
+       * GET /posts/:postId/voters
+       */
+      listVoters: (postId: string, query: { type?: string; cursor?: string; limit?: number } = {}) =>
+        send<VoterListResponse>(options, 'GET', `/posts/${encodeURIComponent(postId)}/voters`, query),
+    },
+  };
+}
+
+/** Client returned by createClient */
+export type Client = ReturnType<typeof createClient>;
@@ -0,0 +1,5 @@
+// Code generated by sdkgen from the Go API models. DO NOT EDIT.
+// Regenerate with "make sdk-generate"; see apps/api/cmd/sdkgen.
+
+export * from './models';
+export * from './client';
@@ -0,0 +1,447 @@
+// Code generated by sdkgen from the Go API models. DO NOT EDIT.
+// Regenerate with "make sdk-generate"; see apps/api/cmd/sdkgen.
+
+/**
+ * Album represents the post album structure
+ *
+ * @see Go: posts/models.Album
+ */
+export interface Album {
+  count: number;
+  cover: string;
+  coverId: string;
+  photos: string[];
+  title: string;
+}
+
+/**
+ * CommentPreview is a lightweight view of a comment for feed previews
+ *
+ * @see Go: posts/models.CommentPreview
+ */
+export interface CommentPreview {
+  objectId: string;
+  ownerUserId: string;
+  ownerDisplayName: string;
+  ownerAvatar: string;
+  text: string;
+  createdDate: number;
+}
+
+/**
+ * CommentResponse represents the response format for comment data
+ *
+ * @see Go: comments/models.CommentResponse
+ */
+export interface CommentResponse {
+  objectId: string;
+  score: number;
+  ownerUserId: string;
+  ownerDisplayName: string;
+  ownerAvatar: string;
+  postId: string;
+  /** Comment this one replies under (or nil for root comments) */
+  parentCommentId?: string;
+  /** User being addressed (for UI display "Replying to @John") */
+  replyToUserId?: string;
+  /** Optional: Display name of user being replied to (joined in handler) */
+  replyToDisplayName?: string;
+  replyCount: number;
+  text: string;
+  deleted: boolean;
+  deletedDate?: number;
+  createdDate: number;
+  lastUpdated?: number;
+  /** Whether the current user has liked this comment */
+  isLiked: boolean;
+  /** Count per reaction type */
+  reactions?: Record<string, number>;
+  /** Reaction types the current user added */
+  myReactions?: string[];
+  /** Whether the comment is its question's accepted answer */
+  isAcceptedAnswer?: boolean;
+}
+
+/**
+ * CommentsListResponse represents the response for listing comments
+ *
+ * @see Go: comments/models.CommentsListResponse
+ */
+export interface CommentsListResponse {
+  comments: CommentResponse[];
+  /** Cursor-based pagination (preferred) */
+  nextCursor?: string;
+  hasNext: boolean;
+  /** Legacy pagination (deprecated but maintained for backward compatibility) */
+  count?: number;
+  page?: number;
+  limit?: number;
+  /** Owners of the listed comments, set when the list was requested with ?owners=ids */
+  ownerIds?: string[];
+}
+
+/**
+ * CreateCommentRequest represents the request payload for creating a comment
+ *
+ * @see Go: comments/models.CreateCommentRequest
+ */
+export interface CreateCommentRequest {
+  postId: string;
+  text: string;
+  parentCommentId?: string;
+}
+
+/**
+ * CreatePostRequest represents the request payload for creating a post
+ *
+ * @see Go: posts/models.CreatePostRequest
+ */
+export interface CreatePostRequest {
+  /** Optional, will be generated if not provided */
+  objectId?: string;
+  postTypeId: number;
+  body: string;
+  image?: string;
+  imageFullPath?: string;
+  video?: string;
+  thumbnail?: string;
+  tags?: string[];
+  album?: Album;
+  disableComments?: boolean;
+  disableSharing?: boolean;
+  accessUserList?: string[];
+  permission?: string;
+  version?: string;
+  /** Admins only: post despite the "block" duplicate policy */
+  allowDuplicate?: boolean;
+  /** Unix milliseconds; the post is archived and leaves feeds after this time */
+  visibleUntil?: number;
+  /** One of the accepted license identifiers */
+  license?: string;
+  /** Absolute http(s) URL of the original source */
+  canonicalUrl?: string;
+  /** Publish as this account under its post:create delegation grant */
+  actingAs?: string;
+  /** Hide the author behind a per-thread pseudonym (needs a community that allows it) */
+  anonymous?: boolean;
+  /** Show non-supporters a teaser only (needs SUPPORTERS_ENABLED and a supporter tier) */
+  supporterOnly?: boolean;
+  /** Post in this community; the author must be a member */
+  communityId?: string;
+  /** Legacy compatibility fields */
+  score?: number;
+  viewCount?: number;
+  commentCounter?: number;
+  deleted?: boolean;
+  deletedDate?: number;
+  lastUpdated?: number;
+}
+
+/**
+ * CreatedPost identifies a new post. DuplicateWarning is set when the deployment lets
+ * posts resembling an existing one through with a warning.
+ *
+ * @see Go: pkg/client.CreatedPost
+ */
+export interface CreatedPost {
+  objectId: string;
+  duplicateWarning?: DuplicateMatch;
+}
+
+/**
+ * DuplicateMatch describes an existing post that a new post appears to duplicate
+ *
+ * @see Go: posts/models.DuplicateMatch
+ */
+export interface DuplicateMatch {
+  /** Empty when the matching post belongs to someone else and is not public */
+  postId?: string;
+  /** 1 for exact content matches */
+  similarity: number;
+  /** "exact" or "similar" */
+  reason: string;
+}
+
+/** @see Go: auth/login.LoginRequest */
+export interface LoginRequest {
+  username: string;
+  password: string;
+}
+
+/**
+ * MFALoginModel is the request body of POST /auth/login/mfa
+ *
+ * @see Go: auth/login.MFALoginModel
+ */
+export interface MFALoginModel {
+  challengeToken: string;
+  code: string;
+}
+
+/**
+ * PostAuthor is an accepted co-author as shown in a post's author block
+ *
+ * @see Go: posts/models.PostAuthor
+ */
+export interface PostAuthor {
+  userId: string;
+  displayName: string;
+  avatar?: string;
+}
+
+/**
+ * PostResponse represents the API response for a post
+ *
+ * @see Go: posts/models.PostResponse
+ */
+export interface PostResponse {
+  objectId: string;
+  postTypeId: number;
+  score: number;
+  /** 0=None, 1=Up, 2=Down (current user's vote) */
+  voteType: number;
+  votes: Record<string, string>;
+  viewCount: number;
+  isBookmarked: boolean;
+  /** Viewer's bookmark collections holding the post */
+  bookmarkCollections?: string[];
+  /** Created after the viewer's read marker for this feed */
+  isNew: boolean;
+  body: string;
+  /** Feed items only: first paragraph, cut at a word boundary */
+  bodyPreview?: string;
+  /** bodyPreview leaves part of the body out */
+  isTruncated: boolean;
+  ownerUserId: string;
+  ownerDisplayName: string;
+  ownerAvatar: string;
+  tags: string[];
+  commentCounter: number;
+  image?: string;
+  imageFullPath?: string;
+  video?: string;
+  thumbnail?: string;
+  urlKey: string;
+  album?: Album;
+  disableComments: boolean;
+  disableSharing: boolean;
+  deleted: boolean;
+  deletedDate?: number;
+  createdDate: number;
+  lastUpdated?: number;
+  permission: string;
+  version?: string;
+  mediaText?: string;
+  visibleUntil?: number;
+  archived: boolean;
+  license?: string;
+  canonicalUrl?: string;
+  /** Owner fields carry the thread pseudonym, never the real author */
+  anonymous?: boolean;
+  supporterOnly?: boolean;
+  communityId?: string;
+  /** Supporter-only post the viewer does not support: body is a teaser, media removed */
+  locked?: boolean;
+  /** Only when TIPS_SHOW_COUNTS is set */
+  tipCount?: number;
+  acceptedAnswerId?: string;
+  /** Question posts whose author accepted an answer */
+  answered?: boolean;
+  coauthors?: PostAuthor[];
+  latestComments?: CommentPreview[];
+}
+
+/**
+ * PostsListResponse represents the response for listing posts
+ *
+ * @see Go: posts/models.PostsListResponse
+ */
+export interface PostsListResponse {
+  posts: PostResponse[];
+  /** Cursor-based pagination (new) */
+  nextCursor?: string;
+  prevCursor?: string;
+  hasNext: boolean;
+  hasPrev: boolean;
+  /** Pass back with later pages (or when restoring a position) to keep results stable */
+  snapshot?: string;
+  /** Legacy pagination (deprecated but maintained for backward compatibility) */
+  totalCount?: number;
+  page?: number;
+  limit: number;
+}
+
+/**
+ * Profile represents the complete profile entity in the database
+ * Updated for relational schema: columns are explicit, arrays for access_user_list
+ *
+ * @see Go: profile/models.Profile
+ */
+export interface Profile {
+  /** Primary key - maps to 'user_id' in the new schema */
+  objectId: string;
+  /** Core fields */
+  fullName: string;
+  socialName: string;
+  email: string;
+  avatar: string;
+  banner: string;
+  tagLine: string;
+  /** Timestamps - both Unix timestamps and TIMESTAMPTZ for compatibility */
+  createdDate: number;
+  lastUpdated: number;
+  lastSeen: number;
+  createdAt?: string;
+  updatedAt?: string;
+  /** Optional fields */
+  birthday: number;
+  webUrl: string;
+  companyName: string;
+  country: string;
+  address: string;
+  phone: string;
+  /** Count fields */
+  voteCount: number;
+  shareCount: number;
+  followCount: number;
+  followerCount: number;
+  postCount: number;
+  /** Social IDs */
+  facebookId: string;
+  instagramId: string;
+  twitterId: string;
+  linkedInId: string;
+  /** Access control */
+  accessUserList: string[];
+  permission: string;
+  /** Streak is attached on profile reads when streaks are enabled; it is not stored with the profile */
+  streak?: ProfileStreak;
+}
+
+/**
+ * ProfileStreak is the activity streak shown on a profile
+ *
+ * @see Go: profile/models.ProfileStreak
+ */
+export interface ProfileStreak {
+  current: number;
+  longest: number;
+  /** Only set on the caller's own profile */
+  atRisk?: boolean;
+}
+
+/**
+ * Session is the outcome of a sign-in. When MFARequired is set no token was issued
+ * yet: pass ChallengeToken and a TOTP or recovery code to VerifyMFA.
+ *
+ * @see Go: pkg/client.Session
+ */
+export interface Session {
+  user: Profile;
+  accessToken: string;
+  tokenType: string;
+  mfaRequired: boolean;
+  challengeToken: string;
+}
+
+/**
+ * SetVoteRequest represents the request body for setting a vote on a post
+ *
+ * @see Go: votes/handlers.SetVoteRequest
+ */
+export interface SetVoteRequest {
+  /** up, down or remove */
+  vote: string;
+}
+
+/**
+ * UpdateCommentRequest represents the request payload for updating a comment
+ *
+ * @see Go: comments/models.UpdateCommentRequest
+ */
+export interface UpdateCommentRequest {
+  objectId: string;
+  text: string;
+}
+
+/**
+ * UpdatePostRequest represents the request payload for updating a post
+ *
+ * @see Go: posts/models.UpdatePostRequest
+ */
+export interface UpdatePostRequest {
+  /** Post ID to update */
+  objectId?: string;
+  body?: string;
+  image?: string;
+  imageFullPath?: string;
+  video?: string;
+  thumbnail?: string;
+  tags?: string[];
+  album?: Album;
+  disableComments?: boolean;
+  disableSharing?: boolean;
+  accessUserList?: string[];
+  permission?: string;
+  version?: string;
+  /** Unix milliseconds; 0 removes the expiry */
+  visibleUntil?: number;
+  /** Empty string clears the license */
+  license?: string;
+  /** Empty string clears the canonical source */
+  canonicalUrl?: string;
+  supporterOnly?: boolean;
+}
+
+/** @see Go: profile/models.UpdateProfileRequest */
+export interface UpdateProfileRequest {
+  fullName?: string;
+  avatar?: string;
+  banner?: string;
+  tagLine?: string;
+  socialName?: string;
+  webUrl?: string;
+  companyName?: string;
+  facebookId?: string;
+  instagramId?: string;
+  twitterId?: string;
+}
+
+/**
+ * VoteChange is the outcome of setting a vote: the user's vote before and after
+ * (VoteTypeNone when absent) and the post's score once the change is applied
+ *
+ * @see Go: votes/models.VoteChange
+ */
+export interface VoteChange {
+  postId: string;
+  previousTypeId: number;
+  typeId: number;
+  score: number;
+}
+
+/**
+ * Voter is a user listed as having voted on a post
+ *
+ * @see Go: votes/models.Voter
+ */
+export interface Voter {
+  userId: string;
+  fullName: string;
+  socialName: string;
+  avatar: string;
+  typeId: number;
+  /** Unix milliseconds */
+  votedDate: number;
+}
+
+/**
+ * VoterListResponse is a page of a post's voters, newest first
+ *
+ * @see Go: votes/models.VoterListResponse
+ */
+export interface VoterListResponse {
+  voters: Voter[];
+  nextCursor?: string;
+  hasNext: boolean;
+}
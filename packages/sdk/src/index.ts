@@ -21,7 +21,7 @@
 export * from './types';
 export * from './config';
 export { ApiClient, ApiError } from './client';
-export type { RequestOptions, ApiClientConfig } from './client';
+export type { RequestOptions, ApiClientConfig, RateLimitInfo } from './client';
 export { authApi } from './auth';
 export type { IAuthApi } from './auth';
 export { profileApi } from './profile';
@@ -32,10 +32,10 @@ export { commentsApi } from './comments';
 export type { ICommentsApi } from './comments';
 export { votesApi } from './votes';
 export type { IVotesApi } from './votes';
-export type { VoteRequest } from './votes';
+export type { VoteRequest, VoteChoice, VoteChange, Voter, VoterListResponse, ListVotersOptions } from './votes';
 export { bookmarksApi } from './bookmarks';
 export type { IBookmarksApi } from './bookmarks';
-export type { ToggleBookmarkResponse } from './bookmarks';
+export type { ToggleBookmarkResponse, BookmarkCollection, BookmarkQueryParams } from './bookmarks';
 export { storageApi } from './storage';
 export type { IStorageApi } from './storage';
 export type { UploadRequest, UploadResponse, ConfirmUploadRequest, FileURLResponse } from './storage';
@@ -43,6 +43,55 @@ export { compressImage, validateFile, uploadFileWithCompression } from './storag
 export { adminApi } from './admin';
 export type { IAdminApi } from './admin';
 export type { AdminMember, MembersListResponse } from './admin';
+export { brandingApi } from './branding';
+export type { IBrandingApi } from './branding';
+export type { Branding, BrandColors, FooterLink, UpdateBrandingRequest } from './branding';
+export { feedDefaultsApi } from './feed-defaults';
+export type { IFeedDefaultsApi } from './feed-defaults';
+export type { FeedDefaults, FeedAlgorithm, CommentSort, PostVisibility, UpdateFeedDefaultsRequest } from './feed-defaults';
+export { delegationsApi } from './delegations';
+export type { IDelegationsApi } from './delegations';
+export type { DelegationScope, DelegationStatus, DelegationGrant, CreateDelegationRequest, DelegationAuditEntry } from './delegations';
+export { followsApi } from './follows';
+export type { IFollowsApi } from './follows';
+export type { FollowUser, FollowListResponse, FollowListParams } from './follows';
+export { mentionsApi } from './mentions';
+export type { IMentionsApi } from './mentions';
+export type { Mention, MentionListResponse, MentionListParams } from './mentions';
+export { achievementsApi } from './achievements';
+export type { IAchievementsApi } from './achievements';
+export type { Badge, UserBadge, UserBadgesResponse } from './achievements';
+export { leaderboardsApi } from './leaderboards';
+export type { ILeaderboardsApi } from './leaderboards';
+export type { LeaderboardScope, LeaderboardPeriod, LeaderboardEntry, LeaderboardResponse } from './leaderboards';
+export { streaksApi } from './streaks';
+export type { IStreaksApi } from './streaks';
+export type { Streak } from './streaks';
+export { supportersApi } from './supporters';
+export type { ISupportersApi } from './supporters';
+export type { SupporterTier, CreateSupporterTierRequest, UpdateSupporterTierRequest, SupporterSubscriptionStatus, SupporterSubscription, SupporterAmount, PostRevenue, SupporterRevenueSummary } from './supporters';
+export { tipsApi } from './tips';
+export type { ITipsApi } from './tips';
+export type { TipStatus, Tip, CreateTipRequest, TipLedgerEntry, TipBalance, TipLedger } from './tips';
+export { thanksApi } from './thanks';
+export type { IThanksApi } from './thanks';
+export type { PostThanks, ThanksAllowance, ThankResponse, UserThanks } from './thanks';
+export { calendarApi } from './calendar';
+export type { ICalendarApi } from './calendar';
+export type { CalendarEvent, SetEventRequest, CalendarDay, CalendarMonth, CalendarSubscription } from './calendar';
+export { membershipApi } from './membership';
+export type { IMembershipApi } from './membership';
+export type { MembershipQuestion, MembershipQuestionInput, MembershipStatus, MembershipAnswer, MembershipApplication, MembershipApplyRequest, ReviewApplicationRequest } from './membership';
+export { rulesApi } from './rules';
+export type { IRulesApi } from './rules';
+export type { CommunityRule, RuleSet, CommunityRuleInput, RulesAcknowledgment } from './rules';
+export { realtimeApi } from './realtime';
+export type { IRealtimeApi } from './realtime';
+export type { RealtimeChannel, RealtimeNotification, RealtimeEvent, RealtimeConnectOptions, RealtimeConnection } from './realtime';
+export { rateLimitsApi } from './rate-limits';
+export type { IRateLimitsApi, RateLimitPolicy, RateLimitPolicies } from './rate-limits';
+// Types and client generated from the Go API models (make sdk-generate)
+export * as api from './generated';
 
 import { ApiClient } from './client';
 import { SDK_CONFIG } from './config';
@@ -54,6 +103,22 @@ import { votesApi, IVotesApi } from './votes';
 import { bookmarksApi, IBookmarksApi } from './bookmarks';
 import { storageApi, IStorageApi } from './storage';
 import { adminApi, IAdminApi } from './admin';
+import { brandingApi, IBrandingApi } from './branding';
+import { feedDefaultsApi, IFeedDefaultsApi } from './feed-defaults';
+import { delegationsApi, IDelegationsApi } from './delegations';
+import { followsApi, IFollowsApi } from './follows';
+import { mentionsApi, IMentionsApi } from './mentions';
+import { achievementsApi, IAchievementsApi } from './achievements';
+import { leaderboardsApi, ILeaderboardsApi } from './leaderboards';
+import { streaksApi, IStreaksApi } from './streaks';
+import { supportersApi, ISupportersApi } from './supporters';
+import { tipsApi, ITipsApi } from './tips';
+import { thanksApi, IThanksApi } from './thanks';
+import { calendarApi, ICalendarApi } from './calendar';
+import { membershipApi, IMembershipApi } from './membership';
+import { rulesApi, IRulesApi } from './rules';
+import { realtimeApi, IRealtimeApi } from './realtime';
+import { rateLimitsApi, IRateLimitsApi } from './rate-limits';
 
 /**
  * Telar SDK interface
@@ -98,6 +163,86 @@ export interface ITelarSDK {
    * Admin API
    */
   admin: IAdminApi;
+
+  /**
+   * Branding API
+   */
+  branding: IBrandingApi;
+
+  /**
+   * Feed Defaults API
+   */
+  feedDefaults: IFeedDefaultsApi;
+
+  /**
+   * Delegations API
+   */
+  delegations: IDelegationsApi;
+
+  /**
+   * Follows API
+   */
+  follows: IFollowsApi;
+
+  /**
+   * Mentions API
+   */
+  mentions: IMentionsApi;
+
+  /**
+   * Achievements API
+   */
+  achievements: IAchievementsApi;
+
+  /**
+   * Leaderboards API
+   */
+  leaderboards: ILeaderboardsApi;
+
+  /**
+   * Streaks API
+   */
+  streaks: IStreaksApi;
+
+  /**
+   * Supporters API
+   */
+  supporters: ISupportersApi;
+
+  /**
+   * Tips API
+   */
+  tips: ITipsApi;
+
+  /**
+   * Thanks API
+   */
+  thanks: IThanksApi;
+
+  /**
+   * Calendar API
+   */
+  calendar: ICalendarApi;
+
+  /**
+   * Membership API
+   */
+  membership: IMembershipApi;
+
+  /**
+   * Rules API
+   */
+  rules: IRulesApi;
+
+  /**
+   * Realtime gateway
+   */
+  realtime: IRealtimeApi;
+
+  /**
+   * Rate limit policies
+   */
+  rateLimits: IRateLimitsApi;
 }
 
 /**
@@ -130,6 +275,22 @@ export const createTelarSDK = (): ITelarSDK => {
     bookmarks: bookmarksApi(apiClient), // uses direct Go API (performance)
     storage: storageApi(apiClient),     // uses direct Go API (performance)
     admin: adminApi(apiClient),         // uses direct Go API (performance)
+    branding: brandingApi(apiClient),   // uses direct Go API (performance)
+    feedDefaults: feedDefaultsApi(apiClient), // uses direct Go API (performance)
+    delegations: delegationsApi(apiClient), // uses direct Go API (performance)
+    follows: followsApi(apiClient),     // uses direct Go API (performance)
+    mentions: mentionsApi(apiClient),   // uses direct Go API (performance)
+    achievements: achievementsApi(apiClient), // uses direct Go API (performance)
+    leaderboards: leaderboardsApi(apiClient), // uses direct Go API (performance)
+    streaks: streaksApi(apiClient),     // uses direct Go API (performance)
+    supporters: supportersApi(apiClient), // uses direct Go API (performance)
+    tips: tipsApi(apiClient),           // uses direct Go API (performance)
+    thanks: thanksApi(apiClient),       // uses direct Go API (performance)
+    calendar: calendarApi(apiClient),   // uses direct Go API (performance)
+    membership: membershipApi(apiClient), // uses direct Go API (performance)
+    rules: rulesApi(apiClient),         // uses direct Go API (performance)
+    realtime: realtimeApi(SDK_CONFIG.GO_API_BASE_URL), // WebSocket to the Go API
+    rateLimits: rateLimitsApi(apiClient), // uses direct Go API (performance)
   };
 };
 
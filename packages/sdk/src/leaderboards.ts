@@ -0,0 +1,65 @@
+/**
+ * Leaderboards SDK Module
+ *
+ * Top posters and most helpful commenters over the last day or week. Boards are
+ * recomputed by the server periodically; computedDate tells when.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * Leaderboard scope; only the site-wide scope exists today
+ */
+export type LeaderboardScope = 'site';
+
+/**
+ * Rolling window a leaderboard covers
+ */
+export type LeaderboardPeriod = 'daily' | 'weekly';
+
+/**
+ * Ranked user on a board
+ * @see Go: apps/api/leaderboards/models/leaderboard.go - Entry
+ */
+export interface LeaderboardEntry {
+  rank: number;
+  userId: string;
+  /** Posts written, or score received on comments, in the period */
+  score: number;
+  fullName: string;
+  socialName: string;
+  avatar: string;
+}
+
+/**
+ * Every board of a scope and period
+ * @see Go: apps/api/leaderboards/models/leaderboard.go - LeaderboardResponse
+ */
+export interface LeaderboardResponse {
+  scope: LeaderboardScope;
+  period: LeaderboardPeriod;
+  /** When the boards were last refreshed (Unix milliseconds); 0 before the first refresh */
+  computedDate: number;
+  topPosters: LeaderboardEntry[];
+  helpfulCommenters: LeaderboardEntry[];
+}
+
+/**
+ * Leaderboards API interface
+ */
+export interface ILeaderboardsApi {
+  /**
+   * Leaderboards of a scope and period
+   */
+  getLeaderboard(period: LeaderboardPeriod, scope?: LeaderboardScope): Promise<LeaderboardResponse>;
+}
+
+/**
+ * Create Leaderboards API instance
+ */
+export const leaderboardsApi = (client: ApiClient): ILeaderboardsApi => ({
+  getLeaderboard: async (period: LeaderboardPeriod, scope: LeaderboardScope = 'site'): Promise<LeaderboardResponse> => {
+    return client.get<LeaderboardResponse>(ENDPOINTS.LEADERBOARDS.GET(scope, period));
+  },
+});
@@ -0,0 +1,152 @@
+/**
+ * Membership SDK Module
+ *
+ * Join questions and the approval queue of communities that require approval.
+ * Joining such a community fails with APPROVAL_REQUIRED; users answer its join
+ * questions instead and become members once its owner or a moderator approves
+ * their application.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * A join question, asked in position order
+ * @see Go: apps/api/membership/models/membership.go - Question
+ */
+export interface MembershipQuestion {
+  objectId: string;
+  communityId: string;
+  position: number;
+  prompt: string;
+  required: boolean;
+  createdDate: number;
+}
+
+/**
+ * A join question as written by a moderator
+ */
+export interface MembershipQuestionInput {
+  prompt: string;
+  required: boolean;
+}
+
+export type MembershipStatus = 'pending' | 'approved' | 'rejected';
+
+/**
+ * An answer kept with the prompt it was given to
+ */
+export interface MembershipAnswer {
+  questionId: string;
+  prompt: string;
+  answer: string;
+}
+
+/**
+ * A user's application and its review
+ * @see Go: apps/api/membership/models/membership.go - Application
+ */
+export interface MembershipApplication {
+  objectId: string;
+  communityId: string;
+  userId: string;
+  answers: MembershipAnswer[];
+  status: MembershipStatus;
+  reviewerId: string | null;
+  /** Shown to the applicant; the welcome message on approval */
+  reviewNote?: string;
+  createdDate: number;
+  reviewedDate?: number;
+}
+
+/**
+ * Answers to submit, by question ID
+ */
+export interface MembershipApplyRequest {
+  answers: { questionId: string; answer: string }[];
+}
+
+/**
+ * A moderator's decision on a pending application
+ */
+export interface ReviewApplicationRequest {
+  status: 'approved' | 'rejected';
+  note?: string;
+}
+
+/**
+ * Membership API interface
+ */
+export interface IMembershipApi {
+  /**
+   * The community's join questions
+   */
+  getQuestions(communityId: string): Promise<MembershipQuestion[]>;
+
+  /**
+   * Apply to the community with answers to its join questions; a reviewed
+   * application may be resubmitted, a pending one may not
+   */
+  apply(communityId: string, request: MembershipApplyRequest): Promise<MembershipApplication>;
+
+  /**
+   * The current user's application to the community
+   */
+  getMyApplication(communityId: string): Promise<MembershipApplication>;
+
+  /**
+   * Replace the community's join questions (owner and moderators only)
+   */
+  setQuestions(communityId: string, questions: MembershipQuestionInput[]): Promise<MembershipQuestion[]>;
+
+  /**
+   * The community's approval queue, oldest first (owner and moderators only);
+   * pending applications by default
+   */
+  listApplications(communityId: string, options?: { status?: MembershipStatus | 'all'; limit?: number; offset?: number }): Promise<MembershipApplication[]>;
+
+  /**
+   * Approve or reject a pending application (owner and moderators only);
+   * approved applicants join the community
+   */
+  reviewApplication(communityId: string, applicationId: string, request: ReviewApplicationRequest): Promise<MembershipApplication>;
+}
+
+/**
+ * Create Membership API instance
+ */
+export const membershipApi = (client: ApiClient): IMembershipApi => ({
+  getQuestions: async (communityId: string): Promise<MembershipQuestion[]> => {
+    const response = await client.get<{ questions: MembershipQuestion[] }>(ENDPOINTS.MEMBERSHIP.QUESTIONS(communityId));
+    return response.questions;
+  },
+
+  apply: async (communityId: string, request: MembershipApplyRequest): Promise<MembershipApplication> => {
+    return client.post<MembershipApplication>(ENDPOINTS.MEMBERSHIP.APPLICATIONS(communityId), request);
+  },
+
+  getMyApplication: async (communityId: string): Promise<MembershipApplication> => {
+    return client.get<MembershipApplication>(ENDPOINTS.MEMBERSHIP.MY_APPLICATION(communityId));
+  },
+
+  setQuestions: async (communityId: string, questions: MembershipQuestionInput[]): Promise<MembershipQuestion[]> => {
+    const response = await client.put<{ questions: MembershipQuestion[] }>(ENDPOINTS.MEMBERSHIP.QUESTIONS(communityId), { questions });
+    return response.questions;
+  },
+
+  listApplications: async (communityId: string, options?: { status?: MembershipStatus | 'all'; limit?: number; offset?: number }): Promise<MembershipApplication[]> => {
+    const params = new URLSearchParams();
+    if (options?.status) params.set('status', options.status);
+    if (options?.limit) params.set('limit', String(options.limit));
+    if (options?.offset) params.set('offset', String(options.offset));
+    const query = params.toString();
+    const response = await client.get<{ applications: MembershipApplication[] }>(
+      `${ENDPOINTS.MEMBERSHIP.APPLICATIONS(communityId)}${query ? `?${query}` : ''}`
+    );
+    return response.applications;
+  },
+
+  reviewApplication: async (communityId: string, applicationId: string, request: ReviewApplicationRequest): Promise<MembershipApplication> => {
+    return client.put<MembershipApplication>(ENDPOINTS.MEMBERSHIP.APPLICATION(communityId, applicationId), request);
+  },
+});
@@ -0,0 +1,68 @@
+/**
+ * Mentions SDK Module
+ *
+ * Posts and comments that @mentioned the current user. Mentions are recorded when
+ * public posts and comments are written; new ones also arrive as realtime
+ * notifications of kind "mention".
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * A post body or comment that mentioned the current user
+ * @see Go: apps/api/mentions/models/mention.go - Mention
+ */
+export interface Mention {
+  postId: string;
+  /** Set when a comment mentioned the user */
+  commentId?: string;
+  /** Unset on anonymous posts */
+  actorUserId?: string;
+  actorDisplayName: string;
+  actorAvatar?: string;
+  /** Start of the text; unset for supporter-only posts */
+  preview?: string;
+  /** When the mention was recorded (Unix milliseconds) */
+  createdDate: number;
+}
+
+/**
+ * Page of mentions, newest first
+ */
+export interface MentionListResponse {
+  mentions: Mention[];
+  nextCursor?: string;
+  hasNext: boolean;
+}
+
+/**
+ * Cursor pagination for mentions
+ */
+export interface MentionListParams {
+  cursor?: string;
+  limit?: number;
+}
+
+/**
+ * Mentions API interface
+ */
+export interface IMentionsApi {
+  /**
+   * Posts and comments that mentioned the current user, newest first
+   */
+  getMentions(params?: MentionListParams): Promise<MentionListResponse>;
+}
+
+/**
+ * Create Mentions API instance
+ */
+export const mentionsApi = (client: ApiClient): IMentionsApi => ({
+  getMentions: async (params?: MentionListParams): Promise<MentionListResponse> => {
+    const queryParams = new URLSearchParams();
+    if (params?.cursor) queryParams.append('cursor', params.cursor);
+    if (params?.limit) queryParams.append('limit', params.limit.toString());
+    const query = queryParams.toString() ? `?${queryParams}` : '';
+    return client.get<MentionListResponse>(`${ENDPOINTS.MENTIONS.LIST}${query}`);
+  },
+});
@@ -6,6 +6,7 @@
  */
 
 import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
 import type {
   Post,
   CreatePostRequest,
@@ -13,6 +14,18 @@ import type {
   UpdatePostRequest,
   PostsResponse,
   CursorQueryParams,
+  TimeRangeParams,
+  SearchPostsParams,
+  FullTextSearchParams,
+  PostSearchResponse,
+  TrendingTagsResponse,
+  TrendingWindow,
+  GetPostOptions,
+  PostCoauthor,
+  CoauthorInvitation,
+  LiveThread,
+  ScheduleLiveThreadRequest,
+  CommentExportRow,
 } from './types';
 
 /**
@@ -32,11 +45,11 @@ export interface IPostsApi {
   /**
    * Get a single post by id
    */
-  getById(postId: string): Promise<Post>;
+  getById(postId: string, options?: GetPostOptions): Promise<Post>;
   /**
    * Get a post by its URL key (for shareable links)
    */
-  getByUrlKey(urlKey: string): Promise<Post>;
+  getByUrlKey(urlKey: string, options?: GetPostOptions): Promise<Post>;
   /**
    * Update an existing post
    */
@@ -47,16 +60,102 @@ export interface IPostsApi {
   deletePost(postId: string): Promise<void>;
 
   /**
-   * Search posts for autocomplete
+   * Full-text search ranked by relevance with highlighted excerpts. The query accepts
+   * "quoted phrases", OR and -exclusions.
    */
-  searchPosts(query: string): Promise<Post[]>;
+  searchPosts(query: string, params?: FullTextSearchParams): Promise<PostSearchResponse>;
+
+  /**
+   * Full search with cursor pagination; reusable limits results to openly licensed posts
+   */
+  searchPostsWithCursor(query: string, params?: SearchPostsParams): Promise<PostsResponse>;
+
+  /**
+   * Public posts carrying a tag (hashtags in bodies count), newest first
+   */
+  getTagPosts(tag: string, params?: TimeRangeParams & { cursor?: string; limit?: number }): Promise<PostsResponse>;
+
+  /**
+   * Most used tags in the window (default 24h)
+   */
+  getTrendingTags(window?: TrendingWindow): Promise<TrendingTagsResponse>;
 
   /**
    * Generate a shareable URL key for a post
    */
   generateUrlKey(postId: string): Promise<{ urlKey: string }>;
+
+  /**
+   * Invite a user to co-author a post (owner only)
+   */
+  inviteCoauthor(postId: string, userId: string): Promise<PostCoauthor>;
+
+  /**
+   * Co-authors of a post; the owner also sees pending invitations
+   */
+  getCoauthors(postId: string): Promise<PostCoauthor[]>;
+
+  /**
+   * Accept an invitation to co-author a post
+   */
+  acceptCoauthorInvite(postId: string): Promise<void>;
+
+  /**
+   * Decline an invitation to co-author a post
+   */
+  declineCoauthorInvite(postId: string): Promise<void>;
+
+  /**
+   * Remove a co-author (owner) or step down as co-author (pass your own id)
+   */
+  removeCoauthor(postId: string, userId: string): Promise<void>;
+
+  /**
+   * Pending co-author invitations for the current user
+   */
+  getCoauthorInvitations(): Promise<CoauthorInvitation[]>;
+
+  /**
+   * Accept a top-level comment as the answer to your question
+   */
+  acceptAnswer(postId: string, commentId: string): Promise<void>;
+
+  /**
+   * Mark your question as unanswered again
+   */
+  clearAcceptedAnswer(postId: string): Promise<void>;
+
+  /**
+   * Every live comment and reply on your post, oldest first. For a CSV download, link
+   * to /posts/:postId/comments/export instead. Rate limited (10 per hour by default).
+   */
+  exportComments(postId: string): Promise<CommentExportRow[]>;
+
+  /**
+   * Schedule or move a live thread on your post (needs LIVE_THREADS_ENABLED)
+   */
+  scheduleLiveThread(postId: string, data: ScheduleLiveThreadRequest): Promise<LiveThread>;
+
+  /**
+   * Get a post's live thread, including its summary once it has ended
+   */
+  getLiveThread(postId: string): Promise<LiveThread>;
+
+  /**
+   * Cancel a live thread that has not ended
+   */
+  cancelLiveThread(postId: string): Promise<void>;
 }
 
+const postQuery = (options?: GetPostOptions): string =>
+  options?.includeComments ? `?includeComments=${options.includeComments}` : '';
+
+const appendTimeRange = (queryParams: URLSearchParams, range?: TimeRangeParams): void => {
+  if (range?.period) queryParams.append('period', range.period);
+  if (range?.since !== undefined) queryParams.append('since', String(range.since));
+  if (range?.until !== undefined) queryParams.append('until', String(range.until));
+};
+
 /**
  * Create Posts API instance
  */
@@ -70,17 +169,21 @@ export const postsApi = (client: ApiClient): IPostsApi => ({
     if (params?.limit) queryParams.append('limit', params.limit.toString());
     if (params?.cursor) queryParams.append('cursor', params.cursor);
     if (params?.owner) queryParams.append('owner', params.owner);
+    if (params?.answered !== undefined) queryParams.append('answered', String(params.answered));
+    if (params?.feed) queryParams.append('feed', params.feed);
+    if (params?.hideInteracted) queryParams.append('hideInteracted', 'true');
+    appendTimeRange(queryParams, params);
     
     const url = `/posts/queries/cursor${queryParams.toString() ? `?${queryParams}` : ''}`;
     return client.get<PostsResponse>(url);
   },
 
-  getById: async (postId: string): Promise<Post> => {
-    return client.get<Post>(`/posts/${postId}`);
+  getById: async (postId: string, options?: GetPostOptions): Promise<Post> => {
+    return client.get<Post>(`/posts/${postId}${postQuery(options)}`);
   },
 
-  getByUrlKey: async (urlKey: string): Promise<Post> => {
-    return client.get<Post>(`/posts/urlkey/${urlKey}`);
+  getByUrlKey: async (urlKey: string, options?: GetPostOptions): Promise<Post> => {
+    return client.get<Post>(`/posts/urlkey/${urlKey}${postQuery(options)}`);
   },
 
   updatePost: async (data: UpdatePostRequest): Promise<void> => {
@@ -91,16 +194,93 @@ export const postsApi = (client: ApiClient): IPostsApi => ({
     await client.delete<void>(`/posts/${postId}`);
   },
 
-  searchPosts: async (query: string): Promise<Post[]> => {
-    const params = new URLSearchParams();
-    params.append('q', query);
-    params.append('limit', '5');
-    const endpoint = `/posts/search?${params.toString()}`;
-    return client.get<Post[]>(endpoint);
+  searchPosts: async (query: string, params?: FullTextSearchParams): Promise<PostSearchResponse> => {
+    const queryParams = new URLSearchParams();
+    queryParams.append('q', query);
+    if (params?.tags?.length) queryParams.append('tags', params.tags.join(','));
+    if (params?.limit) queryParams.append('limit', params.limit.toString());
+    if (params?.cursor) queryParams.append('cursor', params.cursor);
+    return client.get<PostSearchResponse>(`/posts/search?${queryParams}`);
+  },
+
+  searchPostsWithCursor: async (query: string, params?: SearchPostsParams): Promise<PostsResponse> => {
+    const queryParams = new URLSearchParams();
+    queryParams.append('q', query);
+    if (params?.limit) queryParams.append('limit', params.limit.toString());
+    if (params?.cursor) queryParams.append('cursor', params.cursor);
+    if (params?.owner) queryParams.append('owner', params.owner);
+    if (params?.answered !== undefined) queryParams.append('answered', String(params.answered));
+    if (params?.reusable) queryParams.append('reusable', 'true');
+    return client.get<PostsResponse>(`/posts/queries/search/cursor?${queryParams}`);
+  },
+
+  getTagPosts: async (tag: string, params?: TimeRangeParams & { cursor?: string; limit?: number }): Promise<PostsResponse> => {
+    const queryParams = new URLSearchParams();
+    if (params?.limit) queryParams.append('limit', params.limit.toString());
+    if (params?.cursor) queryParams.append('cursor', params.cursor);
+    appendTimeRange(queryParams, params);
+    const query = queryParams.toString();
+    return client.get<PostsResponse>(`${ENDPOINTS.TAGS.POSTS(tag)}${query ? `?${query}` : ''}`);
+  },
+
+  getTrendingTags: async (window?: TrendingWindow): Promise<TrendingTagsResponse> => {
+    const query = window ? `?window=${window}` : '';
+    return client.get<TrendingTagsResponse>(`${ENDPOINTS.TAGS.TRENDING}${query}`);
   },
 
   generateUrlKey: async (postId: string): Promise<{ urlKey: string }> => {
     return client.put<{ urlKey: string }>(`/posts/urlkey/${postId}`);
   },
+
+  inviteCoauthor: async (postId: string, userId: string): Promise<PostCoauthor> => {
+    return client.post<PostCoauthor>(`/posts/${postId}/coauthors`, { userId });
+  },
+
+  getCoauthors: async (postId: string): Promise<PostCoauthor[]> => {
+    const response = await client.get<{ coauthors: PostCoauthor[] }>(`/posts/${postId}/coauthors`);
+    return response.coauthors;
+  },
+
+  acceptCoauthorInvite: async (postId: string): Promise<void> => {
+    await client.post<void>(`/posts/${postId}/coauthors/accept`);
+  },
+
+  declineCoauthorInvite: async (postId: string): Promise<void> => {
+    await client.post<void>(`/posts/${postId}/coauthors/decline`);
+  },
+
+  removeCoauthor: async (postId: string, userId: string): Promise<void> => {
+    await client.delete<void>(`/posts/${postId}/coauthors/${userId}`);
+  },
+
+  getCoauthorInvitations: async (): Promise<CoauthorInvitation[]> => {
+    const response = await client.get<{ invitations: CoauthorInvitation[] }>('/posts/coauthors/invitations');
+    return response.invitations;
+  },
+
+  acceptAnswer: async (postId: string, commentId: string): Promise<void> => {
+    await client.put<void>(`/posts/${postId}/accepted-answer`, { commentId });
+  },
+
+  clearAcceptedAnswer: async (postId: string): Promise<void> => {
+    await client.delete<void>(`/posts/${postId}/accepted-answer`);
+  },
+
+  exportComments: async (postId: string): Promise<CommentExportRow[]> => {
+    // Large threads take a while to stream
+    return client.get<CommentExportRow[]>(`/posts/${postId}/comments/export?format=json`, { timeout: 120000 });
+  },
+
+  scheduleLiveThread: async (postId: string, data: ScheduleLiveThreadRequest): Promise<LiveThread> => {
+    return client.put<LiveThread>(`/posts/${postId}/live`, data);
+  },
+
+  getLiveThread: async (postId: string): Promise<LiveThread> => {
+    return client.get<LiveThread>(`/posts/${postId}/live`);
+  },
+
+  cancelLiveThread: async (postId: string): Promise<void> => {
+    await client.delete<void>(`/posts/${postId}/live`);
+  },
 });
 
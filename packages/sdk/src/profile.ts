@@ -12,6 +12,15 @@ import type {
   UpdateProfileRequest,
   ProfileQueryFilter,
   ProfilesResponse,
+  ActivityQuery,
+  ActivityPage,
+  ProfileViews,
+  PrivacySettings,
+  UpdatePrivacyRequest,
+  NotificationSettings,
+  UpdateNotificationsRequest,
+  MutedKeyword,
+  MutedKeywords,
 } from './types';
 
 /**
@@ -52,6 +61,56 @@ export interface IProfileApi {
    * Search profiles for autocomplete
    */
   searchProfiles(query: string): Promise<UserProfileModel[]>;
+
+  /**
+   * Get a user's public activity timeline (posts and comments), newest first
+   */
+  getActivity(socialName: string, query?: ActivityQuery): Promise<ActivityPage>;
+
+  /**
+   * Get views of the current user's profile over the last days (default 30)
+   */
+  getMyViews(days?: number): Promise<ProfileViews>;
+
+  /**
+   * Get the current user's privacy settings
+   */
+  getPrivacy(): Promise<PrivacySettings>;
+
+  /**
+   * Update the current user's privacy settings
+   */
+  updatePrivacy(data: UpdatePrivacyRequest): Promise<PrivacySettings>;
+
+  /**
+   * Get the current user's notification settings
+   */
+  getNotificationSettings(): Promise<NotificationSettings>;
+
+  /**
+   * Update the current user's notification settings, such as streak reminders and quiet hours
+   */
+  updateNotificationSettings(data: UpdateNotificationsRequest): Promise<NotificationSettings>;
+
+  /**
+   * List the current user's muted keywords
+   */
+  getMutedKeywords(): Promise<MutedKeywords>;
+
+  /**
+   * Mute a word or phrase; matching is case-insensitive on whole words
+   */
+  addMutedKeyword(phrase: string): Promise<MutedKeyword>;
+
+  /**
+   * Change the phrase of a muted keyword
+   */
+  updateMutedKeyword(keywordId: string, phrase: string): Promise<MutedKeyword>;
+
+  /**
+   * Unmute a keyword
+   */
+  deleteMutedKeyword(keywordId: string): Promise<void>;
 }
 
 /**
@@ -97,7 +156,52 @@ export const profileApi = (client: ApiClient): IProfileApi => ({
     const endpoint = `${ENDPOINTS.PROFILE.SEARCH}?${params.toString()}`;
     return client.get<UserProfileModel[]>(endpoint);
   },
-});
 
+  getActivity: async (socialName: string, query?: ActivityQuery): Promise<ActivityPage> => {
+    const params = new URLSearchParams();
+    if (query?.cursor) params.append('cursor', query.cursor);
+    if (query?.limit) params.append('limit', query.limit.toString());
+    if (query?.types?.length) params.append('types', query.types.join(','));
+
+    const queryString = params.toString();
+    const endpoint = ENDPOINTS.PROFILE.ACTIVITY(socialName);
+    return client.get<ActivityPage>(queryString ? `${endpoint}?${queryString}` : endpoint);
+  },
+
+  getMyViews: async (days?: number): Promise<ProfileViews> => {
+    const endpoint = days ? `${ENDPOINTS.PROFILE.MY_VIEWS}?days=${days}` : ENDPOINTS.PROFILE.MY_VIEWS;
+    return client.get<ProfileViews>(endpoint);
+  },
+
+  getPrivacy: async (): Promise<PrivacySettings> => {
+    return client.get<PrivacySettings>(ENDPOINTS.PROFILE.PRIVACY);
+  },
+
+  updatePrivacy: async (data: UpdatePrivacyRequest): Promise<PrivacySettings> => {
+    return client.put<PrivacySettings>(ENDPOINTS.PROFILE.PRIVACY, data);
+  },
+
+  getNotificationSettings: async (): Promise<NotificationSettings> => {
+    return client.get<NotificationSettings>(ENDPOINTS.PROFILE.NOTIFICATIONS);
+  },
 
+  updateNotificationSettings: async (data: UpdateNotificationsRequest): Promise<NotificationSettings> => {
+    return client.put<NotificationSettings>(ENDPOINTS.PROFILE.NOTIFICATIONS, data);
+  },
+
+  getMutedKeywords: async (): Promise<MutedKeywords> => {
+    return client.get<MutedKeywords>(ENDPOINTS.PROFILE.MUTED_KEYWORDS);
+  },
 
+  addMutedKeyword: async (phrase: string): Promise<MutedKeyword> => {
+    return client.post<MutedKeyword>(ENDPOINTS.PROFILE.MUTED_KEYWORDS, { phrase });
+  },
+
+  updateMutedKeyword: async (keywordId: string, phrase: string): Promise<MutedKeyword> => {
+    return client.put<MutedKeyword>(ENDPOINTS.PROFILE.MUTED_KEYWORD(keywordId), { phrase });
+  },
+
+  deleteMutedKeyword: async (keywordId: string): Promise<void> => {
+    await client.delete<void>(ENDPOINTS.PROFILE.MUTED_KEYWORD(keywordId));
+  },
+});
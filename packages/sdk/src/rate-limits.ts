@@ -0,0 +1,53 @@
+/**
+ * Rate Limits SDK Module
+ *
+ * Lists the rate limit policies the API applies. The budget left under a policy
+ * is reported on every authenticated response; see ApiClient.rateLimit.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * A rate limit the API applies
+ */
+export interface RateLimitPolicy {
+  name: string; // Matches the X-RateLimit-Policy header
+  limit: number; // Requests allowed per window
+  windowSeconds: number;
+  scope: 'route' | 'user'; // Per route and caller, or all of a user's requests
+  enforced: boolean; // false for soft limits, which report the budget but never reject
+}
+
+/**
+ * Rate limit policies and the headers reporting a caller's budget
+ */
+export interface RateLimitPolicies {
+  policies: RateLimitPolicy[];
+  headers: {
+    limit: string;
+    remaining: string;
+    reset: string;
+    policy: string;
+  };
+}
+
+/**
+ * Rate Limits API interface
+ */
+export interface IRateLimitsApi {
+  /**
+   * Get the rate limit policies (public)
+   * @returns Policies of the limits the API applies
+   */
+  getPolicies(): Promise<RateLimitPolicies>;
+}
+
+/**
+ * Create Rate Limits API instance
+ */
+export const rateLimitsApi = (client: ApiClient): IRateLimitsApi => ({
+  getPolicies: async (): Promise<RateLimitPolicies> => {
+    return client.get<RateLimitPolicies>(ENDPOINTS.RATE_LIMITS);
+  },
+});
@@ -0,0 +1,94 @@
+/**
+ * Realtime SDK Module
+ *
+ * Opens the WebSocket gateway at /realtime/ws to receive new public posts and
+ * comments ("feed" channel), the current user's notifications ("user" channel) and
+ * the comments and live thread events of one public post ("post:<postId>") as they
+ * happen. Browsers authenticate with the access_token cookie, so the Go API
+ * must be reachable on the same site as the web app.
+ */
+
+import { ENDPOINTS } from './config';
+import type { Comment, LiveThread, Post } from './types';
+
+/**
+ * Channels a connection can follow; at most 20 post channels per connection
+ */
+export type RealtimeChannel = 'feed' | 'user' | `post:${string}`;
+
+/**
+ * Notification pushed to the user it concerns
+ * @see Go: apps/api/internal/events/events.go - Notification
+ */
+export interface RealtimeNotification {
+  kind: 'comment' | 'reply' | 'badge' | 'streak' | 'mention';
+  /** Set for comment, reply and mention notifications; commentId is unset when a post body mentions */
+  postId?: string;
+  commentId?: string;
+  /** Earned badge; preview carries its name */
+  badgeId?: string;
+  /** Streak about to end, for streak reminders */
+  streakDays?: number;
+  actorUserId?: string;
+  actorDisplayName: string;
+  actorAvatar?: string;
+  preview?: string;
+}
+
+/**
+ * Event received from the gateway
+ */
+export type RealtimeEvent =
+  | { type: 'post.created'; data: Post; createdDate: number }
+  | { type: 'comment.created'; data: Comment; createdDate: number }
+  | { type: 'notification'; data: RealtimeNotification; createdDate: number }
+  | { type: 'live.ended'; data: LiveThread; createdDate: number };
+
+export interface RealtimeConnectOptions {
+  /** Channels to follow; feed and user when omitted */
+  channels?: RealtimeChannel[];
+  onEvent: (event: RealtimeEvent) => void;
+  /** Called when the connection closes; the server closes slow clients with code 1013 */
+  onClose?: (code: number, reason: string) => void;
+}
+
+export interface RealtimeConnection {
+  close: () => void;
+}
+
+/**
+ * Realtime API interface
+ */
+export interface IRealtimeApi {
+  /**
+   * Open a gateway connection. Reconnecting after onClose is left to the caller.
+   */
+  connect(options: RealtimeConnectOptions): RealtimeConnection;
+}
+
+/**
+ * Create Realtime API instance
+ *
+ * @param apiBaseUrl - HTTP base URL of the Go API; the WebSocket URL is derived from it
+ */
+export const realtimeApi = (apiBaseUrl: string): IRealtimeApi => ({
+  connect(options: RealtimeConnectOptions): RealtimeConnection {
+    const url = new URL(ENDPOINTS.REALTIME.WS, apiBaseUrl);
+    url.protocol = url.protocol === 'https:' ? 'wss:' : 'ws:';
+    if (options.channels?.length) {
+      url.searchParams.set('channels', options.channels.join(','));
+    }
+
+    const socket = new WebSocket(url.toString());
+    socket.onmessage = (message) => {
+      try {
+        options.onEvent(JSON.parse(message.data) as RealtimeEvent);
+      } catch {
+        // Ignore frames that are not events
+      }
+    };
+    socket.onclose = (event) => options.onClose?.(event.code, event.reason);
+
+    return { close: () => socket.close() };
+  },
+});
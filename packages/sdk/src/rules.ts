@@ -0,0 +1,99 @@
+/**
+ * Rules SDK Module
+ *
+ * The rules of each community, which users acknowledge before their first post
+ * or comment in it; until then those calls fail with RULES_NOT_ACKNOWLEDGED.
+ * Communities without rules gate nobody.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * A community rule, shown in position order
+ * @see Go: apps/api/rules/models/rules.go - Rule
+ */
+export interface CommunityRule {
+  objectId: string;
+  communityId: string;
+  position: number;
+  title: string;
+  body: string;
+}
+
+/**
+ * A community's current rules and the version its members acknowledge
+ */
+export interface RuleSet {
+  rules: CommunityRule[];
+  /** Unix milliseconds the rules were published; 0 while there are none */
+  version: number;
+}
+
+/**
+ * A rule as written by a community's owner or moderators
+ */
+export interface CommunityRuleInput {
+  title: string;
+  body?: string;
+}
+
+/**
+ * Whether the current user may post and comment in the community
+ * @see Go: apps/api/rules/models/rules.go - AcknowledgmentStatus
+ */
+export interface RulesAcknowledgment {
+  acknowledged: boolean;
+  /** 0 when the user never acknowledged */
+  acknowledgedVersion: number;
+  /** Differs from acknowledgedVersion once the rules change */
+  currentVersion: number;
+  acknowledgedDate?: number;
+}
+
+/**
+ * Rules API interface
+ */
+export interface IRulesApi {
+  /**
+   * The community's rules and their version
+   */
+  getRules(communityId: string): Promise<RuleSet>;
+
+  /**
+   * Whether the current user acknowledged the community's rules
+   */
+  getAcknowledgment(communityId: string): Promise<RulesAcknowledgment>;
+
+  /**
+   * Acknowledge the version of the community's rules the user read; fails with
+   * RULES_CHANGED when they were edited since
+   */
+  acknowledge(communityId: string, version: number): Promise<RulesAcknowledgment>;
+
+  /**
+   * Replace the community's rules, publishing a new version (owner and moderators)
+   */
+  setRules(communityId: string, rules: CommunityRuleInput[]): Promise<RuleSet>;
+}
+
+/**
+ * Create Rules API instance
+ */
+export const rulesApi = (client: ApiClient): IRulesApi => ({
+  getRules: async (communityId: string): Promise<RuleSet> => {
+    return client.get<RuleSet>(ENDPOINTS.RULES.BASE(communityId));
+  },
+
+  getAcknowledgment: async (communityId: string): Promise<RulesAcknowledgment> => {
+    return client.get<RulesAcknowledgment>(ENDPOINTS.RULES.ACKNOWLEDGMENT(communityId));
+  },
+
+  acknowledge: async (communityId: string, version: number): Promise<RulesAcknowledgment> => {
+    return client.post<RulesAcknowledgment>(ENDPOINTS.RULES.ACKNOWLEDGMENT(communityId), { version });
+  },
+
+  setRules: async (communityId: string, rules: CommunityRuleInput[]): Promise<RuleSet> => {
+    return client.put<RuleSet>(ENDPOINTS.RULES.BASE(communityId), { rules });
+  },
+});
@@ -15,6 +15,7 @@ export interface UploadRequest {
   name: string;
   contentType: string;
   size: number; // Size in bytes (after compression)
+  purpose?: 'post' | 'avatar'; // Decides which resized variants are made; defaults to 'post'
 }
 
 /**
@@ -38,6 +39,10 @@ export interface ConfirmUploadRequest {
  */
 export interface FileURLResponse {
   url: string;
+  thumbnailUrl?: string; // Set once the background thumbnail is generated
+  width?: number;
+  height?: number;
+  variants?: Record<string, string>; // Resized copies by name, e.g. post_1080 or avatar_128
 }
 
 /**
@@ -51,7 +56,8 @@ export interface IStorageApi {
   initializeUpload(request: UploadRequest): Promise<UploadResponse>;
 
   /**
-   * Confirm upload completion (marks file as uploaded)
+   * Confirm upload completion (verifies the object, marks file as uploaded
+   * and schedules its thumbnail)
    */
   confirmUpload(request: ConfirmUploadRequest): Promise<{ message: string }>;
 
@@ -0,0 +1,52 @@
+/**
+ * Streaks SDK Module
+ *
+ * Daily activity streaks. Days are UTC calendar days and are counted by a
+ * server job once they end, so today's activity shows up tomorrow.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * A user's activity streak
+ * @see Go: apps/api/streaks/models/streak.go - Streak
+ */
+export interface Streak {
+  userId: string;
+  /** Consecutive active days ending yesterday; 0 once a day is missed */
+  current: number;
+  longest: number;
+  /** Last active UTC date, e.g. "2026-10-17" */
+  lastActiveDay?: string;
+  /** The streak ends unless the user is active today; only set for the current user */
+  atRisk: boolean;
+}
+
+/**
+ * Streaks API interface
+ */
+export interface IStreaksApi {
+  /**
+   * Current user's streak
+   */
+  getMyStreak(): Promise<Streak>;
+
+  /**
+   * Another user's streak
+   */
+  getUserStreak(userId: string): Promise<Streak>;
+}
+
+/**
+ * Create Streaks API instance
+ */
+export const streaksApi = (client: ApiClient): IStreaksApi => ({
+  getMyStreak: async (): Promise<Streak> => {
+    return client.get<Streak>(ENDPOINTS.STREAKS.ME);
+  },
+
+  getUserStreak: async (userId: string): Promise<Streak> => {
+    return client.get<Streak>(ENDPOINTS.STREAKS.USER(userId));
+  },
+});
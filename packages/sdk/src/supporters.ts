@@ -0,0 +1,170 @@
+/**
+ * Supporters SDK Module
+ *
+ * Creators offer paid supporter tiers and mark posts supporter-only; other
+ * readers get those posts `locked`, with a teaser instead of the body. Payments
+ * go through billing, which reports them to the API; this module manages tiers
+ * and reads subscriptions and revenue.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * Paid support level a creator offers
+ * @see Go: apps/api/supporters/models/supporter.go - Tier
+ */
+export interface SupporterTier {
+  objectId: string;
+  creatorId: string;
+  name: string;
+  description?: string;
+  /** Per billing period, in the currency's minor unit */
+  priceCents: number;
+  /** ISO 4217 code, e.g. "USD" */
+  currency: string;
+  /** Closed to new supporters; existing subscriptions keep renewing */
+  archived?: boolean;
+  createdDate: number;
+  lastUpdated: number;
+}
+
+/**
+ * Request to add a tier to the current user's offer
+ */
+export interface CreateSupporterTierRequest {
+  name: string;
+  description?: string;
+  priceCents: number;
+  currency: string;
+}
+
+/**
+ * Tier changes; prices are fixed, so archive the tier and create a new one instead
+ */
+export interface UpdateSupporterTierRequest {
+  name?: string;
+  description?: string;
+}
+
+/**
+ * active renews; canceled keeps access until periodEnd; ended was refunded
+ */
+export type SupporterSubscriptionStatus = 'active' | 'canceled' | 'ended';
+
+/**
+ * Subscription of the current user to a creator
+ * @see Go: apps/api/supporters/models/supporter.go - Subscription
+ */
+export interface SupporterSubscription {
+  creatorId: string;
+  supporterId: string;
+  tierId: string;
+  status: SupporterSubscriptionStatus;
+  /** Unix milliseconds; access ends then */
+  periodEnd: number;
+  /** Post the supporter subscribed from */
+  sourcePostId?: string;
+  createdDate: number;
+  lastUpdated: number;
+}
+
+/**
+ * Sum of money in one currency
+ */
+export interface SupporterAmount {
+  currency: string;
+  amountCents: number;
+}
+
+/**
+ * Revenue credited to a post in one currency
+ */
+export interface PostRevenue {
+  postId: string;
+  currency: string;
+  amountCents: number;
+  /** Distinct supporters who subscribed from the post */
+  supporters: number;
+}
+
+/**
+ * Current user's supporter revenue over a window
+ * @see Go: apps/api/supporters/models/supporter.go - RevenueSummary
+ */
+export interface SupporterRevenueSummary {
+  days: number;
+  /** Net of refunds, per currency */
+  totals: SupporterAmount[];
+  /** Supporters with access now, whatever the window */
+  activeSupporters: number;
+  /** Highest earning posts first */
+  byPost: PostRevenue[];
+}
+
+/**
+ * Supporters API interface
+ */
+export interface ISupportersApi {
+  /**
+   * Add a tier to the current user's offer
+   */
+  createTier(data: CreateSupporterTierRequest): Promise<SupporterTier>;
+
+  /**
+   * Rename or redescribe one of the current user's tiers
+   */
+  updateTier(tierId: string, data: UpdateSupporterTierRequest): Promise<SupporterTier>;
+
+  /**
+   * Close one of the current user's tiers to new supporters
+   */
+  archiveTier(tierId: string): Promise<void>;
+
+  /**
+   * Tiers a creator offers, cheapest first
+   */
+  getCreatorTiers(creatorId: string): Promise<SupporterTier[]>;
+
+  /**
+   * Subscriptions that currently give the current user access
+   */
+  getSupporting(): Promise<SupporterSubscription[]>;
+
+  /**
+   * Current user's revenue over the last days (default 30, at most 365)
+   */
+  getRevenue(days?: number): Promise<SupporterRevenueSummary>;
+}
+
+/**
+ * Create Supporters API instance
+ */
+export const supportersApi = (client: ApiClient): ISupportersApi => ({
+  createTier: async (data: CreateSupporterTierRequest): Promise<SupporterTier> => {
+    return client.post<SupporterTier>(ENDPOINTS.SUPPORTERS.TIERS, data);
+  },
+
+  updateTier: async (tierId: string, data: UpdateSupporterTierRequest): Promise<SupporterTier> => {
+    return client.put<SupporterTier>(ENDPOINTS.SUPPORTERS.TIER(tierId), data);
+  },
+
+  archiveTier: async (tierId: string): Promise<void> => {
+    await client.delete(ENDPOINTS.SUPPORTERS.TIER(tierId));
+  },
+
+  getCreatorTiers: async (creatorId: string): Promise<SupporterTier[]> => {
+    const response = await client.get<{ tiers: SupporterTier[] }>(ENDPOINTS.SUPPORTERS.CREATOR_TIERS(creatorId));
+    return response.tiers;
+  },
+
+  getSupporting: async (): Promise<SupporterSubscription[]> => {
+    const response = await client.get<{ subscriptions: SupporterSubscription[] }>(ENDPOINTS.SUPPORTERS.SUPPORTING);
+    return response.subscriptions;
+  },
+
+  getRevenue: async (days?: number): Promise<SupporterRevenueSummary> => {
+    const query = days ? `?days=${days}` : '';
+    return client.get<SupporterRevenueSummary>(`${ENDPOINTS.SUPPORTERS.REVENUE}${query}`);
+  },
+});
@@ -0,0 +1,95 @@
+/**
+ * Thanks SDK Module
+ *
+ * A money-free appreciation signal, separate from votes: each user thanks a post
+ * at most once and has a limited number of thanks per week (weeks start Monday
+ * 00:00 UTC). Served only when the deployment enables thanks.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * Thanks summary of a post
+ * @see Go: apps/api/thanks/models/thanks.go - PostThanks
+ */
+export interface PostThanks {
+  postId: string;
+  count: number;
+  thankedByMe: boolean;
+}
+
+/**
+ * What is left of the current user's weekly thanks
+ * @see Go: apps/api/thanks/models/thanks.go - Allowance
+ */
+export interface ThanksAllowance {
+  weekly: number;
+  used: number;
+  remaining: number;
+  /** Unix milliseconds; the next Monday 00:00 UTC */
+  resetsAt: number;
+}
+
+/**
+ * Result of thanking a post
+ */
+export interface ThankResponse {
+  post: PostThanks;
+  allowance: ThanksAllowance;
+}
+
+/**
+ * Thanks a user received on all of their posts
+ */
+export interface UserThanks {
+  userId: string;
+  received: number;
+}
+
+/**
+ * Thanks API interface
+ */
+export interface IThanksApi {
+  /**
+   * Spend one of the current user's weekly thanks on a post; fails with
+   * ALREADY_THANKED or ALLOWANCE_EXHAUSTED
+   */
+  thankPost(postId: string): Promise<ThankResponse>;
+
+  /**
+   * Thanks count of a post and whether the current user gave one
+   */
+  getPostThanks(postId: string): Promise<PostThanks>;
+
+  /**
+   * Thanks a user received on their posts
+   */
+  getUserThanks(userId: string): Promise<UserThanks>;
+
+  /**
+   * What is left of the current user's thanks this week
+   */
+  getAllowance(): Promise<ThanksAllowance>;
+}
+
+/**
+ * Create Thanks API instance
+ */
+export const thanksApi = (client: ApiClient): IThanksApi => ({
+  thankPost: async (postId: string): Promise<ThankResponse> => {
+    return client.post<ThankResponse>(ENDPOINTS.THANKS.POST(postId));
+  },
+
+  getPostThanks: async (postId: string): Promise<PostThanks> => {
+    return client.get<PostThanks>(ENDPOINTS.THANKS.POST(postId));
+  },
+
+  getUserThanks: async (userId: string): Promise<UserThanks> => {
+    return client.get<UserThanks>(ENDPOINTS.THANKS.USER(userId));
+  },
+
+  getAllowance: async (): Promise<ThanksAllowance> => {
+    return client.get<ThanksAllowance>(ENDPOINTS.THANKS.ALLOWANCE);
+  },
+});
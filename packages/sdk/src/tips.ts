@@ -0,0 +1,137 @@
+/**
+ * Tips SDK Module
+ *
+ * Users tip a post's author or another user directly. Creating a tip returns it
+ * pending; pay it through billing with the tip's objectId, and billing reports
+ * the payment to the API, which credits the recipient's payout ledger.
+ */
+
+import { ApiClient } from './client';
+import { ENDPOINTS } from './config';
+
+/**
+ * pending waits for payment; failed may be paid again; refunded was returned to the sender
+ */
+export type TipStatus = 'pending' | 'paid' | 'failed' | 'refunded';
+
+/**
+ * Money one user sends another
+ * @see Go: apps/api/tips/models/tip.go - Tip
+ */
+export interface Tip {
+  /** Pass to billing as the payment reference */
+  objectId: string;
+  senderId: string;
+  recipientId: string;
+  /** Set when the tip was for a post */
+  postId?: string;
+  /** What the sender pays, in the currency's minor unit */
+  amountCents: number;
+  /** Platform fee kept from the amount */
+  feeCents: number;
+  /** What the recipient is credited */
+  netCents: number;
+  /** ISO 4217 code, e.g. "USD" */
+  currency: string;
+  message?: string;
+  status: TipStatus;
+  createdDate: number;
+  lastUpdated: number;
+}
+
+/**
+ * Request to tip a post's author or a user; limits and currencies are set by the server
+ */
+export interface CreateTipRequest {
+  amountCents: number;
+  currency: string;
+  /** At most 280 characters */
+  message?: string;
+}
+
+/**
+ * Credit or debit on a recipient's payout balance; refunds and payouts are negative
+ * @see Go: apps/api/tips/models/tip.go - LedgerEntry
+ */
+export interface TipLedgerEntry {
+  objectId: string;
+  recipientId: string;
+  tipId?: string;
+  kind: 'tip' | 'refund' | 'payout';
+  grossCents: number;
+  feeCents: number;
+  netCents: number;
+  currency: string;
+  occurredDate: number;
+}
+
+/**
+ * What the current user is owed in one currency
+ */
+export interface TipBalance {
+  currency: string;
+  netCents: number;
+}
+
+/**
+ * Current user's payout ledger
+ * @see Go: apps/api/tips/models/tip.go - Ledger
+ */
+export interface TipLedger {
+  /** Over the whole ledger, per currency */
+  balances: TipBalance[];
+  /** Newest first */
+  entries: TipLedgerEntry[];
+  /** Pass as `before` for the next page; absent on the last page */
+  nextBefore?: number;
+}
+
+/**
+ * Tips API interface
+ */
+export interface ITipsApi {
+  /**
+   * Tip a post's author; returns the pending tip to pay through billing
+   */
+  tipPost(postId: string, data: CreateTipRequest): Promise<Tip>;
+
+  /**
+   * Tip a user; returns the pending tip to pay through billing
+   */
+  tipUser(userId: string, data: CreateTipRequest): Promise<Tip>;
+
+  /**
+   * Tip the current user sent or received
+   */
+  getTip(tipId: string): Promise<Tip>;
+
+  /**
+   * Current user's payout balances and ledger entries older than `before`
+   */
+  getLedger(options?: { before?: number; limit?: number }): Promise<TipLedger>;
+}
+
+/**
+ * Create Tips API instance
+ */
+export const tipsApi = (client: ApiClient): ITipsApi => ({
+  tipPost: async (postId: string, data: CreateTipRequest): Promise<Tip> => {
+    return client.post<Tip>(ENDPOINTS.TIPS.POST(postId), data);
+  },
+
+  tipUser: async (userId: string, data: CreateTipRequest): Promise<Tip> => {
+    return client.post<Tip>(ENDPOINTS.TIPS.USER(userId), data);
+  },
+
+  getTip: async (tipId: string): Promise<Tip> => {
+    return client.get<Tip>(ENDPOINTS.TIPS.TIP(tipId));
+  },
+
+  getLedger: async (options?: { before?: number; limit?: number }): Promise<TipLedger> => {
+    const params = new URLSearchParams();
+    if (options?.before) params.set('before', String(options.before));
+    if (options?.limit) params.set('limit', String(options.limit));
+    const query = params.toString();
+    return client.get<TipLedger>(`${ENDPOINTS.TIPS.LEDGER}${query ? `?${query}` : ''}`);
+  },
+});
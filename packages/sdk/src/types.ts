@@ -99,6 +99,19 @@ export interface UserProfileModel {
   twitterId: string;
   accessUserList: string[];
   permission: UserPermissionType;
+  /** Activity streak, when streaks are enabled */
+  streak?: ProfileStreak;
+}
+
+/**
+ * Activity streak shown on a profile
+ * @see Go: apps/api/profile/models/profile.go - ProfileStreak
+ */
+export interface ProfileStreak {
+  current: number;
+  longest: number;
+  /** Only set on the current user's own profile */
+  atRisk?: boolean;
 }
 
 /**
@@ -139,6 +152,38 @@ export interface GoApiLoginResponse {
   expires_in: string;
 }
 
+/**
+ * Login response from Go API for accounts with a second factor
+ * @see Go: apps/api/auth/login/handler.go - Handle
+ */
+export interface GoApiMFAChallengeResponse {
+  mfaRequired: true;
+  challengeToken: string;
+  expires_in: string;
+}
+
+/**
+ * Result of a login through the BFF. When mfaRequired is set no session was
+ * created; complete the login with the challenge token and a code.
+ */
+export interface LoginResult {
+  success?: boolean;
+  mfaRequired?: boolean;
+  challengeToken?: string;
+  /** Seconds the challenge token stays valid */
+  expiresIn?: number;
+}
+
+/**
+ * Second step of a login held back for a second factor
+ * @see Go: apps/api/auth/login/model.go - MFALoginModel
+ */
+export interface MFALoginRequest {
+  challengeToken: string;
+  /** 6-digit authenticator code or an unused recovery code */
+  code: string;
+}
+
 /**
  * Signup request payload
  * @see Go: apps/api/auth/signup/model.go
@@ -197,6 +242,79 @@ export interface ResendVerificationRequest {
   verificationId: string;
 }
 
+/**
+ * Credentials of the account to link to the current one
+ * @see Go: apps/api/auth/accounts/model.go - LinkAccountModel
+ */
+export interface LinkAccountRequest {
+  username: string;
+  password: string;
+  /** Second factor code, required when the linked account has MFA enabled */
+  code?: string;
+}
+
+/**
+ * Public identity of an account
+ * @see Go: apps/api/auth/accounts/model.go - AccountSummary
+ */
+export interface AccountSummary {
+  userId: string;
+  fullName: string;
+  socialName: string;
+  avatar: string;
+}
+
+/**
+ * Account the current user can switch to
+ * @see Go: apps/api/auth/accounts/model.go - LinkedAccount
+ */
+export interface LinkedAccount extends AccountSummary {
+  linkedDate: number;
+}
+
+/**
+ * Issued session and the identity it acts as
+ * @see Go: apps/api/auth/accounts/model.go - Session
+ */
+export interface AuthSession {
+  objectId: string;
+  identity: AccountSummary;
+  loginUserId: string;
+  /** True when the session acts as a linked account rather than the signed-in one */
+  switched: boolean;
+  current: boolean;
+  userAgent: string;
+  ipAddress: string;
+  createdDate: number;
+}
+
+/**
+ * Second factor state of the current user
+ * @see Go: apps/api/auth/mfa/model.go - Status
+ */
+export interface MFAStatus {
+  enabled: boolean;
+  enabledDate?: number;
+  recoveryCodesRemaining: number;
+}
+
+/**
+ * New secret to add to an authenticator app, usually shown as a QR code of otpauthUrl
+ * @see Go: apps/api/auth/mfa/model.go - SetupResult
+ */
+export interface MFASetupResponse {
+  secret: string;
+  otpauthUrl: string;
+}
+
+/**
+ * Recovery codes; they are shown only once
+ * @see Go: apps/api/auth/mfa/model.go - RecoveryCodesResult
+ */
+export interface MFARecoveryCodesResponse {
+  recoveryCodes: string[];
+}
+
 /**
  * JWKS key structure for ES256 (ECDSA)
  * @see Go: apps/api/auth/jwks/handler.go
@@ -270,6 +388,7 @@ export enum PostType {
   Video = 2,
   PhotoGallery = 3,
   Album = 4,
+  Question = 5,
 }
 
 /**
@@ -283,6 +402,99 @@ export interface PostAlbum {
   title: string;
 }
 
+/**
+ * Content licenses a post can carry (SPDX identifiers)
+ * @see Go: apps/api/posts/models/license.go
+ */
+export type PostLicense =
+  | 'CC0-1.0'
+  | 'CC-BY-4.0'
+  | 'CC-BY-SA-4.0'
+  | 'CC-BY-NC-4.0'
+  | 'CC-BY-ND-4.0'
+  | 'CC-BY-NC-SA-4.0'
+  | 'CC-BY-NC-ND-4.0'
+  | 'all-rights-reserved';
+
+/**
+ * Co-author as shown in a post's author block
+ */
+export interface PostAuthor {
+  userId: string;
+  displayName: string;
+  avatar?: string;
+}
+
+/**
+ * One comment or reply in a post owner's comments export
+ * @see Go: apps/api/posts/models/comment_export.go - CommentExportRow
+ */
+export interface CommentExportRow {
+  commentId: string;
+  /** Set on replies */
+  parentCommentId?: string;
+  authorUserId: string;
+  authorDisplayName: string;
+  text: string;
+  score: number;
+  /** Unix milliseconds */
+  createdDate: number;
+  lastUpdated: number;
+}
+
+/**
+ * Co-author invitation on a post
+ * @see Go: apps/api/posts/models/coauthor.go - PostCoauthor
+ */
+export interface PostCoauthor {
+  postId: string;
+  userId: string;
+  invitedBy: string;
+  status: 'pending' | 'accepted';
+  displayName?: string;
+  avatar?: string;
+  createdDate: number;
+  respondedDate?: number;
+}
+
+/**
+ * Pending co-author invitation with the post it is for
+ */
+export interface CoauthorInvitation {
+  invitation: PostCoauthor;
+  post: Post;
+}
+
+/**
+ * Live thread on a post: comments stream in real time and are slow-moded during the
+ * window, then the post is frozen and summarized
+ * @see Go: apps/api/posts/models/live.go - LiveThread
+ */
+export interface LiveThread {
+  postId: string;
+  /** Unix milliseconds */
+  startsAt: number;
+  endsAt: number;
+  /** Seconds each user waits between comments while live; 0 is off */
+  slowModeSeconds: number;
+  state: 'scheduled' | 'live' | 'ended';
+  closedDate?: number;
+  /** Filled in by the AI engine some time after the thread ends */
+  summary?: string;
+  keyPoints?: string[];
+  createdDate: number;
+}
+
+/**
+ * Body of PUT /posts/:postId/live
+ */
+export interface ScheduleLiveThreadRequest {
+  startsAt: number;
+  endsAt: number;
+  /** Defaults to the community's setting */
+  slowModeSeconds?: number;
+}
+
 /**
  * Post model
  * @see Go: Post struct
@@ -293,6 +505,10 @@ export interface Post {
   ownerDisplayName: string;
   ownerAvatar: string;
   body: string;
+  /** Feed items only: first paragraph of the body, cut at a word boundary */
+  bodyPreview?: string;
+  /** True when bodyPreview leaves part of the body out; body may then be empty on feed items */
+  isTruncated?: boolean;
   image?: string;
   imageFullPath?: string;
   video?: string;
@@ -304,6 +520,7 @@ export interface Post {
   votes: Record<string, string>;
   voteType: 0 | 1 | 2; // 0=None, 1=Up, 2=Down (New field from Backend)
   isBookmarked: boolean;
+  bookmarkCollections?: string[]; // Viewer's bookmark collections holding the post
   tags: string[];
   postTypeId: PostType;
   permission: UserPermissionType;
@@ -316,6 +533,50 @@ export interface Post {
   version?: string;
   createdDate: number;
   lastUpdated?: number;
+  /** Unix milliseconds after which the post leaves feeds */
+  visibleUntil?: number;
+  /** True once the post expired and was archived */
+  archived?: boolean;
+  /** Content license, e.g. 'CC-BY-4.0' */
+  license?: PostLicense;
+  /** Original source when the post republishes content from elsewhere */
+  canonicalUrl?: string;
+  /** Author hidden: ownerDisplayName is the thread pseudonym and ownerUserId is empty */
+  anonymous?: boolean;
+  /** Accepted co-authors, shown with the owner in the author block */
+  coauthors?: PostAuthor[];
+  /** Newest comments, present when requested with includeComments: 'preview' */
+  latestComments?: CommentPreview[];
+  /** Question posts: the comment the asker accepted as the answer */
+  acceptedAnswerId?: string;
+  /** Question posts: true once an answer is accepted */
+  answered?: boolean;
+  /** Only the author's supporters read the full post */
+  supporterOnly?: boolean;
+  /** Supporter-only post the viewer does not support: body is a teaser and media is removed */
+  locked?: boolean;
+  /** Paid tips, present when the server shows tip counts */
+  tipCount?: number;
+}
+
+/**
+ * Lightweight comment inlined on a post (includeComments=preview)
+ */
+export interface CommentPreview {
+  objectId: string;
+  ownerUserId: string;
+  ownerDisplayName: string;
+  ownerAvatar: string;
+  text: string;
+  createdDate: number;
+}
+
+/**
+ * Options for reading a single post
+ */
+export interface GetPostOptions {
+  /** 'preview' inlines the latest comments as latestComments */
+  includeComments?: 'preview';
 }
 
 /**
@@ -370,6 +631,10 @@ export interface Comment {
   replyToDisplayName?: string; // Display name of user being replied to
   replyCount?: number;
   isLiked: boolean;
+  /** Pinned at the top of the first page as its question's accepted answer */
+  isAcceptedAnswer?: boolean;
+  reactions?: Partial<Record<CommentReactionType, number>>; // Absent when nobody reacted
+  myReactions?: CommentReactionType[];
   text: string;
   deleted: boolean;
   deletedDate?: number;
@@ -377,6 +642,47 @@ export interface Comment {
   lastUpdated?: number;
 }
 
+export type CommentReactionType =
+  | 'like'
+  | 'love'
+  | 'laugh'
+  | 'wow'
+  | 'sad'
+  | 'angry';
+
+/** Reactions on one comment after adding or removing one */
+export interface CommentReactionSummary {
+  reactions: Partial<Record<CommentReactionType, number>>;
+  myReactions: CommentReactionType[];
+}
+
+export interface CommentPostSummary {
+  objectId: string;
+  ownerUserId: string;
+  ownerDisplayName: string;
+  ownerAvatar: string;
+  urlKey: string;
+  bodyPreview: string;
+  commentCounter: number;
+  disableComments: boolean;
+  createdDate: number;
+}
+
+export interface ThreadLocation {
+  limit: number;
+  rootCursor: string; // Cursor for the root comments page holding the thread
+  rootPosition: number;
+  replyCursor?: string; // Cursor for the replies page holding the comment
+  replyPosition?: number;
+}
+
+export interface CommentContext {
+  comment: Comment;
+  parents: Comment[]; // Root first
+  post: CommentPostSummary;
+  location: ThreadLocation;
+}
+
 export interface CreateCommentRequest {
   postId: string;
   text: string;
@@ -446,6 +752,99 @@ export interface ProfilesResponse {
   total: number;
 }
 
+export interface ProfileViewer {
+  userId: string;
+  fullName: string;
+  socialName: string;
+  avatar: string;
+  lastViewed: number;
+}
+
+/**
+ * Views of the current user's profile. Viewers are only listed when both the
+ * owner and the viewer turned on shareProfileViews.
+ */
+export interface ProfileViews {
+  days: number;
+  views: number; // Viewer-days in the window
+  viewers: number; // Distinct viewers in the window
+  shareProfileViews: boolean;
+  recentViewers: ProfileViewer[];
+  hiddenViewers: number;
+}
+
+export interface PrivacySettings {
+  shareProfileViews: boolean;
+  lastUpdated?: number;
+}
+
+export type UpdatePrivacyRequest = Partial<Omit<PrivacySettings, 'lastUpdated'>>;
+
+/**
+ * Daily window, in timeZone, during which no reminders are sent. end may be
+ * earlier than start for a window that spans midnight.
+ */
+export interface QuietHours {
+  start: string; // "22:00"
+  end: string; // "07:00"
+  timeZone?: string; // IANA name such as "Europe/Berlin"; UTC when empty
+}
+
+export interface NotificationSettings {
+  streakReminders: boolean;
+  quietHours?: QuietHours;
+  lastUpdated?: number;
+}
+
+/**
+ * Omitted fields keep their value; quietHours with empty start and end clears them
+ */
+export interface UpdateNotificationsRequest {
+  streakReminders?: boolean;
+  quietHours?: QuietHours;
+}
+
+/**
+ * A muted word or phrase; posts and comments containing it are left out of the user's
+ * feeds and notifications
+ */
+export interface MutedKeyword {
+  id: string;
+  phrase: string;
+  createdDate: number;
+}
+
+export interface MutedKeywords {
+  keywords: MutedKeyword[];
+  lastUpdated?: number;
+}
+
+export type ActivityType = 'post' | 'comment' | 'badge';
+
+export interface ActivityItem {
+  type: ActivityType;
+  objectId: string;
+  createdDate: number;
+  postId?: string; // Owning post of a comment
+  urlKey?: string; // Post URL key
+  preview?: string; // Badge name for badge items
+  locked?: boolean; // Supporter-only post; preview is its teaser
+  score: number;
+  commentCounter?: number;
+}
+
+export interface ActivityQuery {
+  cursor?: string;
+  limit?: number;
+  types?: ActivityType[];
+}
+
+export interface ActivityPage {
+  items: ActivityItem[];
+  nextCursor?: string;
+  hasNext: boolean;
+}
+
 // ============================================================================
 // Posts Request/Response Types (MVP)
 // ============================================================================
@@ -458,6 +857,17 @@ export interface CreatePostRequest {
   body: string;
   permission?: string;
   imageFullPath?: string;
+  /** Unix milliseconds after which the post is archived and leaves feeds */
+  visibleUntil?: number;
+  license?: PostLicense;
+  /** Absolute http(s) URL of the original source */
+  canonicalUrl?: string;
+  /** Publish as this account; requires an active post:create delegation grant from it */
+  actingAs?: string;
+  /** Hide the author behind a per-thread pseudonym; rejected unless the community enables it */
+  anonymous?: boolean;
+  /** Show non-supporters a teaser; requires a public post and a supporter tier */
+  supporterOnly?: boolean;
 }
 
 /**
@@ -486,18 +896,50 @@ export interface UpdatePostRequest {
   accessUserList?: string[];
   permission?: string;
   version?: string;
+  /** Unix milliseconds; 0 removes the expiry */
+  visibleUntil?: number;
+  /** Empty string clears the license */
+  license?: PostLicense | '';
+  /** Empty string clears the canonical source */
+  canonicalUrl?: string;
+  supporterOnly?: boolean;
 }
 
 /**
  * Cursor query parameters for pagination
  */
-export interface CursorQueryParams {
+/**
+ * Time range filter for feeds and tag pages. since/until take Unix milliseconds or an
+ * RFC 3339 string; period (UTC) replaces since. Anonymous callers must bound the range.
+ */
+export interface TimeRangeParams {
+  since?: number | string;
+  /** Exclusive upper bound */
+  until?: number | string;
+  period?: 'today' | 'week' | 'month';
+}
+
+export interface CursorQueryParams extends TimeRangeParams {
   limit?: number;
   cursor?: string;
   /**
    * Filter posts by owner user ID (UUID string)
    */
   owner?: string;
+  /** Only question posts with (true) or without (false) an accepted answer */
+  answered?: boolean;
+  /** 'following' limits the feed to posts from users the caller follows */
+  feed?: 'following';
+  /** Drop posts the caller has voted on, commented on or bookmarked */
+  hideInteracted?: boolean;
+}
+
+/**
+ * Cursor search parameters
+ */
+export interface SearchPostsParams extends CursorQueryParams {
+  /** Only posts under a license that allows republishing */
+  reusable?: boolean;
 }
 
 /**
@@ -509,3 +951,53 @@ export interface PostsResponse {
   hasNext?: boolean;
 }
 
+/**
+ * Full-text search parameters
+ */
+export interface FullTextSearchParams {
+  /** Keep posts carrying any of these tags */
+  tags?: string[];
+  limit?: number;
+  cursor?: string;
+}
+
+/**
+ * Post matched by full-text search
+ */
+export interface PostSearchResult extends Post {
+  rank: number;
+  /** Escaped HTML excerpt with matched terms wrapped in <mark> */
+  highlight: string;
+}
+
+/**
+ * Full-text search page, best match first
+ */
+export interface PostSearchResponse {
+  results: PostSearchResult[];
+  nextCursor?: string;
+  hasNext: boolean;
+}
+
+/**
+ * Window trending tags are counted over
+ */
+export type TrendingWindow = '1h' | '24h' | '7d';
+
+/**
+ * Tag with the number of public posts that used it in the window
+ */
+export interface TrendingTag {
+  rank: number;
+  tag: string;
+  postCount: number;
+}
+
+/**
+ * Most used tags of a window; computedDate is 0 until the first count
+ */
+export interface TrendingTagsResponse {
+  window: TrendingWindow;
+  computedDate: number;
+  tags: TrendingTag[];
+}
@@ -24,6 +24,74 @@ export interface VoteResponse {
   message: string;
 }
 
+/**
+ * One hour of vote activity on a post
+ */
+export interface VelocityBucket {
+  start: number; // Unix milliseconds
+  up: number;
+  down: number;
+  switched: number;
+  retracted: number;
+}
+
+/**
+ * Hourly vote activity on a post, visible to its author
+ */
+export interface VoteVelocity {
+  postId: string;
+  since: number; // Unix milliseconds
+  buckets: VelocityBucket[];
+  totalVotes: number;
+  votesPerHour: number;
+  peakPerHour: number;
+}
+
+/**
+ * Vote set on a post; 'remove' clears the caller's vote
+ */
+export type VoteChoice = 'up' | 'down' | 'remove';
+
+/**
+ * The caller's vote before and after a change (0 = none) and the post's new score
+ */
+export interface VoteChange {
+  postId: string;
+  previousTypeId: 0 | 1 | 2;
+  typeId: 0 | 1 | 2;
+  score: number;
+}
+
+/**
+ * A user who voted on a post
+ */
+export interface Voter {
+  userId: string;
+  fullName: string;
+  socialName: string;
+  avatar: string;
+  typeId: 1 | 2;
+  votedDate: number; // Unix milliseconds
+}
+
+/**
+ * A page of a post's voters, newest first
+ */
+export interface VoterListResponse {
+  voters: Voter[];
+  nextCursor?: string;
+  hasNext: boolean;
+}
+
+/**
+ * Options for listing voters
+ */
+export interface ListVotersOptions {
+  type?: 'up' | 'down' | 'all'; // down and all are for the post author
+  cursor?: string;
+  limit?: number;
+}
+
 /**
  * Votes API interface
  */
@@ -36,6 +104,33 @@ export interface IVotesApi {
    * @todo Backend should return updated Post object for better UX
    */
   vote(postId: string, typeId: 1 | 2): Promise<VoteResponse>;
+
+  /**
+   * Get hourly vote velocity for a post (post author only)
+   * @param postId - The post ID
+   * @param hours - Window size in hours, 1-168 (default 24)
+   */
+  getVelocity(postId: string, hours?: number): Promise<VoteVelocity>;
+
+  /**
+   * Set the caller's vote on a post; unlike vote() this never toggles
+   * @param postId - The post ID
+   * @param vote - 'up', 'down' or 'remove'
+   */
+  setVote(postId: string, vote: VoteChoice): Promise<VoteChange>;
+
+  /**
+   * List a post's voters with cursor pagination
+   * @param postId - The post ID
+   * @param options - Vote type (default 'up'), cursor and page size
+   */
+  listVoters(postId: string, options?: ListVotersOptions): Promise<VoterListResponse>;
+
+  /**
+   * Get the caller's votes on several posts (0 = none)
+   * @param postIds - Up to 100 post IDs
+   */
+  getMyVotes(postIds: string[]): Promise<Record<string, 0 | 1 | 2>>;
 }
 
 /**
@@ -48,5 +143,29 @@ export const votesApi = (client: ApiClient): IVotesApi => ({
       typeId,
     });
   },
+
+  getVelocity: async (postId: string, hours?: number): Promise<VoteVelocity> => {
+    const query = hours ? `?hours=${hours}` : '';
+    return client.get<VoteVelocity>(`${ENDPOINTS.VOTES.VELOCITY(postId)}${query}`);
+  },
+
+  setVote: async (postId: string, vote: VoteChoice): Promise<VoteChange> => {
+    return client.post<VoteChange>(ENDPOINTS.VOTES.SET(postId), { vote });
+  },
+
+  listVoters: async (postId: string, options: ListVotersOptions = {}): Promise<VoterListResponse> => {
+    const params = new URLSearchParams();
+    if (options.type) params.set('type', options.type);
+    if (options.cursor) params.set('cursor', options.cursor);
+    if (options.limit) params.set('limit', String(options.limit));
+    const query = params.toString();
+    return client.get<VoterListResponse>(`${ENDPOINTS.VOTES.VOTERS(postId)}${query ? `?${query}` : ''}`);
+  },
+
+  getMyVotes: async (postIds: string[]): Promise<Record<string, 0 | 1 | 2>> => {
+    const query = new URLSearchParams({ postIds: postIds.join(',') }).toString();
+    const response = await client.get<{ votes: Record<string, 0 | 1 | 2> }>(`${ENDPOINTS.VOTES.MINE}?${query}`);
+    return response.votes;
+  },
 });
 
@@ -469,6 +469,94 @@ func (x *GetProfilesByIdsResponse) GetProfiles() []*Profile {
 	return nil
 }
 
+type GetProfilesBySocialNamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SocialNames   []string               `protobuf:"bytes,1,rep,name=social_names,json=socialNames,proto3" json:"social_names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfilesBySocialNamesRequest) Reset() {
+	*x = GetProfilesBySocialNamesRequest{}
+	mi := &file_protos_profile_v1_profile_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfilesBySocialNamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfilesBySocialNamesRequest) ProtoMessage() {}
+
+func (x *GetProfilesBySocialNamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_profile_v1_profile_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfilesBySocialNamesRequest.ProtoReflect.Descriptor instead.
+func (*GetProfilesBySocialNamesRequest) Descriptor() ([]byte, []int) {
+	return file_protos_profile_v1_profile_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetProfilesBySocialNamesRequest) GetSocialNames() []string {
+	if x != nil {
+		return x.SocialNames
+	}
+	return nil
+}
+
+type GetProfilesBySocialNamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Profiles      []*Profile             `protobuf:"bytes,1,rep,name=profiles,proto3" json:"profiles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetProfilesBySocialNamesResponse) Reset() {
+	*x = GetProfilesBySocialNamesResponse{}
+	mi := &file_protos_profile_v1_profile_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetProfilesBySocialNamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetProfilesBySocialNamesResponse) ProtoMessage() {}
+
+func (x *GetProfilesBySocialNamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_protos_profile_v1_profile_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetProfilesBySocialNamesResponse.ProtoReflect.Descriptor instead.
+func (*GetProfilesBySocialNamesResponse) Descriptor() ([]byte, []int) {
+	return file_protos_profile_v1_profile_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetProfilesBySocialNamesResponse) GetProfiles() []*Profile {
+	if x != nil {
+		return x.Profiles
+	}
+	return nil
+}
+
 type Profile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ObjectId      string                 `protobuf:"bytes,1,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"`
@@ -487,7 +575,7 @@ type Profile struct {
 
 func (x *Profile) Reset() {
 	*x = Profile{}
-	mi := &file_protos_profile_v1_profile_proto_msgTypes[8]
+	mi := &file_protos_profile_v1_profile_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +587,7 @@ func (x *Profile) String() string {
 func (*Profile) ProtoMessage() {}
 
 func (x *Profile) ProtoReflect() protoreflect.Message {
-	mi := &file_protos_profile_v1_profile_proto_msgTypes[8]
+	mi := &file_protos_profile_v1_profile_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,7 +600,7 @@ func (x *Profile) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Profile.ProtoReflect.Descriptor instead.
 func (*Profile) Descriptor() ([]byte, []int) {
-	return file_protos_profile_v1_profile_proto_rawDescGZIP(), []int{8}
+	return file_protos_profile_v1_profile_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Profile) GetObjectId() string {
@@ -627,6 +715,10 @@ const file_protos_profile_v1_profile_proto_rawDesc = "" +
 	"\n" +
 	"object_ids\x18\x01 \x03(\tR\tobjectIds\"K\n" +
 	"\x18GetProfilesByIdsResponse\x12/\n" +
+	"\bprofiles\x18\x01 \x03(\v2\x13.profile.v1.ProfileR\bprofiles\"D\n" +
+	"\x1fGetProfilesBySocialNamesRequest\x12!\n" +
+	"\fsocial_names\x18\x01 \x03(\tR\vsocialNames\"S\n" +
+	" GetProfilesBySocialNamesResponse\x12/\n" +
 	"\bprofiles\x18\x01 \x03(\v2\x13.profile.v1.ProfileR\bprofiles\"\xa8\x02\n" +
 	"\aProfile\x12\x1b\n" +
 	"\tobject_id\x18\x01 \x01(\tR\bobjectId\x12\x1b\n" +
@@ -640,13 +732,14 @@ const file_protos_profile_v1_profile_proto_rawDesc = "" +
 	"\fcreated_date\x18\b \x01(\x03R\vcreatedDate\x12!\n" +
 	"\flast_updated\x18\t \x01(\x03R\vlastUpdated\x12\x1b\n" +
 	"\tlast_seen\x18\n" +
-	" \x01(\x03R\blastSeen2\xf0\x02\n" +
+	" \x01(\x03R\blastSeen2\xe9\x03\n" +
 	"\x0eProfileService\x12V\n" +
 	"\rCreateProfile\x12 .profile.v1.CreateProfileRequest\x1a!.profile.v1.CreateProfileResponse\"\x00\x12V\n" +
 	"\rUpdateProfile\x12 .profile.v1.UpdateProfileRequest\x1a!.profile.v1.UpdateProfileResponse\"\x00\x12M\n" +
 	"\n" +
 	"GetProfile\x12\x1d.profile.v1.GetProfileRequest\x1a\x1e.profile.v1.GetProfileResponse\"\x00\x12_\n" +
-	"\x10GetProfilesByIds\x12#.profile.v1.GetProfilesByIdsRequest\x1a$.profile.v1.GetProfilesByIdsResponse\"\x00B1Z/github.com/qolzam/telar/protos/gen/go/profilepbb\x06proto3"
+	"\x10GetProfilesByIds\x12#.profile.v1.GetProfilesByIdsRequest\x1a$.profile.v1.GetProfilesByIdsResponse\"\x00\x12w\n" +
+	"\x18GetProfilesBySocialNames\x12+.profile.v1.GetProfilesBySocialNamesRequest\x1a,.profile.v1.GetProfilesBySocialNamesResponse\"\x00B1Z/github.com/qolzam/telar/protos/gen/go/profilepbb\x06proto3"
 
 var (
 	file_protos_profile_v1_profile_proto_rawDescOnce sync.Once
@@ -660,34 +753,39 @@ func file_protos_profile_v1_profile_proto_rawDescGZIP() []byte {
 	return file_protos_profile_v1_profile_proto_rawDescData
 }
 
-var file_protos_profile_v1_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_protos_profile_v1_profile_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_protos_profile_v1_profile_proto_goTypes = []any{
-	(*CreateProfileRequest)(nil),     // 0: profile.v1.CreateProfileRequest
-	(*CreateProfileResponse)(nil),    // 1: profile.v1.CreateProfileResponse
-	(*UpdateProfileRequest)(nil),     // 2: profile.v1.UpdateProfileRequest
-	(*UpdateProfileResponse)(nil),    // 3: profile.v1.UpdateProfileResponse
-	(*GetProfileRequest)(nil),        // 4: profile.v1.GetProfileRequest
-	(*GetProfileResponse)(nil),       // 5: profile.v1.GetProfileResponse
-	(*GetProfilesByIdsRequest)(nil),  // 6: profile.v1.GetProfilesByIdsRequest
-	(*GetProfilesByIdsResponse)(nil), // 7: profile.v1.GetProfilesByIdsResponse
-	(*Profile)(nil),                  // 8: profile.v1.Profile
+	(*CreateProfileRequest)(nil),             // 0: profile.v1.CreateProfileRequest
+	(*CreateProfileResponse)(nil),            // 1: profile.v1.CreateProfileResponse
+	(*UpdateProfileRequest)(nil),             // 2: profile.v1.UpdateProfileRequest
+	(*UpdateProfileResponse)(nil),            // 3: profile.v1.UpdateProfileResponse
+	(*GetProfileRequest)(nil),                // 4: profile.v1.GetProfileRequest
+	(*GetProfileResponse)(nil),               // 5: profile.v1.GetProfileResponse
+	(*GetProfilesByIdsRequest)(nil),          // 6: profile.v1.GetProfilesByIdsRequest
+	(*GetProfilesByIdsResponse)(nil),         // 7: profile.v1.GetProfilesByIdsResponse
+	(*GetProfilesBySocialNamesRequest)(nil),  // 8: profile.v1.GetProfilesBySocialNamesRequest
+	(*GetProfilesBySocialNamesResponse)(nil), // 9: profile.v1.GetProfilesBySocialNamesResponse
+	(*Profile)(nil),                          // 10: profile.v1.Profile
 }
 var file_protos_profile_v1_profile_proto_depIdxs = []int32{
-	8, // 0: profile.v1.GetProfileResponse.profile:type_name -> profile.v1.Profile
-	8, // 1: profile.v1.GetProfilesByIdsResponse.profiles:type_name -> profile.v1.Profile
-	0, // 2: profile.v1.ProfileService.CreateProfile:input_type -> profile.v1.CreateProfileRequest
-	2, // 3: profile.v1.ProfileService.UpdateProfile:input_type -> profile.v1.UpdateProfileRequest
-	4, // 4: profile.v1.ProfileService.GetProfile:input_type -> profile.v1.GetProfileRequest
-	6, // 5: profile.v1.ProfileService.GetProfilesByIds:input_type -> profile.v1.GetProfilesByIdsRequest
-	1, // 6: profile.v1.ProfileService.CreateProfile:output_type -> profile.v1.CreateProfileResponse
-	3, // 7: profile.v1.ProfileService.UpdateProfile:output_type -> profile.v1.UpdateProfileResponse
-	5, // 8: profile.v1.ProfileService.GetProfile:output_type -> profile.v1.GetProfileResponse
-	7, // 9: profile.v1.ProfileService.GetProfilesByIds:output_type -> profile.v1.GetProfilesByIdsResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	10, // 0: profile.v1.GetProfileResponse.profile:type_name -> profile.v1.Profile
+	10, // 1: profile.v1.GetProfilesByIdsResponse.profiles:type_name -> profile.v1.Profile
+	10, // 2: profile.v1.GetProfilesBySocialNamesResponse.profiles:type_name -> profile.v1.Profile
+	0,  // 3: profile.v1.ProfileService.CreateProfile:input_type -> profile.v1.CreateProfileRequest
+	2,  // 4: profile.v1.ProfileService.UpdateProfile:input_type -> profile.v1.UpdateProfileRequest
+	4,  // 5: profile.v1.ProfileService.GetProfile:input_type -> profile.v1.GetProfileRequest
+	6,  // 6: profile.v1.ProfileService.GetProfilesByIds:input_type -> profile.v1.GetProfilesByIdsRequest
+	8,  // 7: profile.v1.ProfileService.GetProfilesBySocialNames:input_type -> profile.v1.GetProfilesBySocialNamesRequest
+	1,  // 8: profile.v1.ProfileService.CreateProfile:output_type -> profile.v1.CreateProfileResponse
+	3,  // 9: profile.v1.ProfileService.UpdateProfile:output_type -> profile.v1.UpdateProfileResponse
+	5,  // 10: profile.v1.ProfileService.GetProfile:output_type -> profile.v1.GetProfileResponse
+	7,  // 11: profile.v1.ProfileService.GetProfilesByIds:output_type -> profile.v1.GetProfilesByIdsResponse
+	9,  // 12: profile.v1.ProfileService.GetProfilesBySocialNames:output_type -> profile.v1.GetProfilesBySocialNamesResponse
+	8,  // [8:13] is the sub-list for method output_type
+	3,  // [3:8] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_protos_profile_v1_profile_proto_init() }
@@ -702,7 +800,7 @@ func file_protos_profile_v1_profile_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_protos_profile_v1_profile_proto_rawDesc), len(file_protos_profile_v1_profile_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
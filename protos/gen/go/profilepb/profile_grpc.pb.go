@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProfileService_CreateProfile_FullMethodName    = "/profile.v1.ProfileService/CreateProfile"
-	ProfileService_UpdateProfile_FullMethodName    = "/profile.v1.ProfileService/UpdateProfile"
-	ProfileService_GetProfile_FullMethodName       = "/profile.v1.ProfileService/GetProfile"
-	ProfileService_GetProfilesByIds_FullMethodName = "/profile.v1.ProfileService/GetProfilesByIds"
+	ProfileService_CreateProfile_FullMethodName            = "/profile.v1.ProfileService/CreateProfile"
+	ProfileService_UpdateProfile_FullMethodName            = "/profile.v1.ProfileService/UpdateProfile"
+	ProfileService_GetProfile_FullMethodName               = "/profile.v1.ProfileService/GetProfile"
+	ProfileService_GetProfilesByIds_FullMethodName         = "/profile.v1.ProfileService/GetProfilesByIds"
+	ProfileService_GetProfilesBySocialNames_FullMethodName = "/profile.v1.ProfileService/GetProfilesBySocialNames"
 )
 
 // ProfileServiceClient is the client API for ProfileService service.
@@ -33,6 +34,7 @@ type ProfileServiceClient interface {
 	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*UpdateProfileResponse, error)
 	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*GetProfileResponse, error)
 	GetProfilesByIds(ctx context.Context, in *GetProfilesByIdsRequest, opts ...grpc.CallOption) (*GetProfilesByIdsResponse, error)
+	GetProfilesBySocialNames(ctx context.Context, in *GetProfilesBySocialNamesRequest, opts ...grpc.CallOption) (*GetProfilesBySocialNamesResponse, error)
 }
 
 type profileServiceClient struct {
@@ -83,6 +85,16 @@ func (c *profileServiceClient) GetProfilesByIds(ctx context.Context, in *GetProf
 	return out, nil
 }
 
+func (c *profileServiceClient) GetProfilesBySocialNames(ctx context.Context, in *GetProfilesBySocialNamesRequest, opts ...grpc.CallOption) (*GetProfilesBySocialNamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProfilesBySocialNamesResponse)
+	err := c.cc.Invoke(ctx, ProfileService_GetProfilesBySocialNames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProfileServiceServer is the server API for ProfileService service.
 // All implementations must embed UnimplementedProfileServiceServer
 // for forward compatibility.
@@ -91,6 +103,7 @@ type ProfileServiceServer interface {
 	UpdateProfile(context.Context, *UpdateProfileRequest) (*UpdateProfileResponse, error)
 	GetProfile(context.Context, *GetProfileRequest) (*GetProfileResponse, error)
 	GetProfilesByIds(context.Context, *GetProfilesByIdsRequest) (*GetProfilesByIdsResponse, error)
+	GetProfilesBySocialNames(context.Context, *GetProfilesBySocialNamesRequest) (*GetProfilesBySocialNamesResponse, error)
 	mustEmbedUnimplementedProfileServiceServer()
 }
 
@@ -113,6 +126,9 @@ func (UnimplementedProfileServiceServer) GetProfile(context.Context, *GetProfile
 func (UnimplementedProfileServiceServer) GetProfilesByIds(context.Context, *GetProfilesByIdsRequest) (*GetProfilesByIdsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProfilesByIds not implemented")
 }
+func (UnimplementedProfileServiceServer) GetProfilesBySocialNames(context.Context, *GetProfilesBySocialNamesRequest) (*GetProfilesBySocialNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfilesBySocialNames not implemented")
+}
 func (UnimplementedProfileServiceServer) mustEmbedUnimplementedProfileServiceServer() {}
 func (UnimplementedProfileServiceServer) testEmbeddedByValue()                        {}
 
@@ -206,6 +222,24 @@ func _ProfileService_GetProfilesByIds_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProfileService_GetProfilesBySocialNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProfilesBySocialNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServiceServer).GetProfilesBySocialNames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProfileService_GetProfilesBySocialNames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServiceServer).GetProfilesBySocialNames(ctx, req.(*GetProfilesBySocialNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProfileService_ServiceDesc is the grpc.ServiceDesc for ProfileService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,6 +263,10 @@ var ProfileService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProfilesByIds",
 			Handler:    _ProfileService_GetProfilesByIds_Handler,
 		},
+		{
+			MethodName: "GetProfilesBySocialNames",
+			Handler:    _ProfileService_GetProfilesBySocialNames_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protos/profile/v1/profile.proto",
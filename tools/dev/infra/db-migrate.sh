@@ -29,6 +29,51 @@ DB_NAME=$(grep -E "^POSTGRES_DATABASE=|^POSTGRES_DB=|^DB_NAME=" "$ENV_FILE" | he
 
 export PGPASSWORD="$DB_PASSWORD"
 
+# Value of a key in the .env file, or empty
+env_value() {
+    grep -E "^$1=" "$ENV_FILE" | head -1 | cut -d'=' -f2- | tr -d '"' | tr -d "'" || true
+}
+
+# Modules that can keep their tables in a schema of their own (POSTGRES_<MODULE>_SCHEMA)
+# and connect as a role of their own (POSTGRES_<MODULE>_USERNAME/_PASSWORD); see
+# PostgresModules in apps/api/internal/platform/config
+MODULES=(auth posts comments profile)
+declare -A MODULE_SCHEMA MODULE_USER MODULE_PASSWORD
+for module in "${MODULES[@]}"; do
+    upper=$(echo "$module" | tr '[:lower:]' '[:upper:]')
+    MODULE_SCHEMA[$module]=$(env_value "POSTGRES_${upper}_SCHEMA")
+    MODULE_USER[$module]=$(env_value "POSTGRES_${upper}_USERNAME")
+    MODULE_PASSWORD[$module]=$(env_value "POSTGRES_${upper}_PASSWORD")
+done
+
+# Other module schemas a module's role may write to besides its own: signup creates the
+# profile and deleting an account deletes its posts and comments, deleting a post
+# soft-deletes its comments, and comments keep the comment counts and accepted answers
+# of posts
+declare -A MODULE_WRITES=([auth]="profile posts comments" [posts]="comments" [comments]="posts")
+
+# Other module schemas a module's role may only read: posts and comments show their
+# authors' profiles and activity timelines show posts and comments. Every other module
+# schema is closed to it, except the user_token_states view of auth through which every
+# binary checks access tokens.
+declare -A MODULE_READS=([posts]="profile" [comments]="profile" [profile]="posts comments")
+
+# Tables in public every binary writes: the settings behind the read-only switch,
+# webhook deliveries, the outbox and how far profile changes got
+PUBLIC_SHARED_TABLES="settings webhook_deliveries outbox_events outbox_consumed profile_propagation"
+
+# Tables in public the modules served by each module's binary write, and read
+declare -A MODULE_PUBLIC_WRITES=(
+    [auth]="follows delegation_grants delegation_audit tips tip_ledger post_thanks thanks_allowances mentions communities community_members votes vote_events vote_flags calendar_tokens community_calendar_tokens user_streaks badge_grants leaderboard_entries analytics_events membership_applications rules_acknowledgments supporter_tiers supporter_subscriptions supporter_revenue moderation_reports"
+    [posts]="mentions delegation_grants delegation_audit supporter_tiers supporter_subscriptions supporter_revenue tips tip_ledger communities community_members community_starter_drafts community_starter_settings membership_questions membership_applications community_rules rules_acknowledgments"
+    [comments]="mentions"
+)
+declare -A MODULE_PUBLIC_READS=(
+    [posts]="votes bookmarks bookmark_collections bookmark_collection_items follows analytics_events files"
+    [comments]="community_rules rules_acknowledgments communities community_members"
+    [profile]="badge_grants"
+)
+
 log_info "Applying database migrations..."
 log_info "  Host: $DB_HOST"
 log_info "  Port: $DB_PORT"
@@ -57,9 +102,25 @@ for i in {1..10}; do
     sleep 1
 done
 
+# Run a SQL statement against the database
+run_sql() {
+    if [[ "$USE_DOCKER_EXEC" == "true" ]]; then
+        docker exec -i telar-postgres psql -v ON_ERROR_STOP=1 -U "$DB_USER" -d "$DB_NAME" -c "$1" > /dev/null
+    else
+        PGPASSWORD="$DB_PASSWORD" psql -v ON_ERROR_STOP=1 -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" -c "$1" > /dev/null
+    fi
+}
+
+# Schema versions are positions in this list, so only ever append to it, and bump
+# schema.BuiltVersion (apps/api/internal/database/schema) to the new length.
+# A migration that old binaries cannot run against (dropped/renamed columns) must
+# contain a "-- schema:breaking" line; binaries built before it refuse to start.
 MIGRATIONS=(
     "${API_DIR}/posts/migrations/001_create_posts_table.sql"
     "${API_DIR}/posts/migrations/002_add_search_index.sql"
+    "${API_DIR}/posts/migrations/003_add_media_text.sql"
+    "${API_DIR}/posts/migrations/004_add_content_hash.sql"
+    "${API_DIR}/posts/migrations/005_create_post_read_markers.sql"
     "${API_DIR}/auth/migrations/003_create_auth_tables.sql"
     "${API_DIR}/profile/migrations/002_create_profiles_table.sql"
     "${API_DIR}/profile/migrations/003_add_search_index.sql"
@@ -71,19 +132,111 @@ MIGRATIONS=(
     "${API_DIR}/bookmarks/migrations/001_create_bookmarks_table.sql"
     "${API_DIR}/storage/migrations/001_create_storage_tables.sql"
     "${API_DIR}/storage/migrations/002_add_usage_tracking.sql"
+    "${API_DIR}/analytics/migrations/001_create_analytics_events.sql"
+    "${API_DIR}/analytics/migrations/002_add_experiment_exposures.sql"
+    "${API_DIR}/experiments/migrations/001_create_experiments_table.sql"
+    "${API_DIR}/settings/migrations/001_create_settings_table.sql"
+    "${API_DIR}/votes/migrations/007_create_vote_integrity.sql"
+    "${API_DIR}/posts/migrations/006_add_post_expiry.sql"
+    "${API_DIR}/profile/migrations/004_create_profile_views.sql"
+    "${API_DIR}/auth/migrations/005_create_linked_accounts.sql"
+    "${API_DIR}/delegations/migrations/001_create_delegations.sql"
+    "${API_DIR}/posts/migrations/007_add_post_license.sql"
+    "${API_DIR}/posts/migrations/008_create_post_coauthors.sql"
+    "${API_DIR}/posts/migrations/009_add_post_anonymous.sql"
+    "${API_DIR}/posts/migrations/010_add_accepted_answers.sql"
+    "${API_DIR}/follows/migrations/001_create_follows.sql"
+    "${API_DIR}/achievements/migrations/001_create_badge_grants.sql"
+    "${API_DIR}/auth/migrations/006_create_mfa.sql"
+    "${API_DIR}/leaderboards/migrations/001_create_leaderboard_entries.sql"
+    "${API_DIR}/streaks/migrations/001_create_user_streaks.sql"
+    "${API_DIR}/supporters/migrations/001_create_supporters.sql"
+    "${API_DIR}/posts/migrations/011_add_supporter_only.sql"
+    "${API_DIR}/posts/migrations/012_add_tip_count.sql"
+    "${API_DIR}/tips/migrations/001_create_tips.sql"
+    "${API_DIR}/thanks/migrations/001_create_thanks.sql"
+    "${API_DIR}/calendar/migrations/001_create_calendar.sql"
+    "${API_DIR}/posts/migrations/013_create_live_threads.sql"
+    "${API_DIR}/membership/migrations/001_create_membership.sql"
+    "${API_DIR}/rules/migrations/001_create_rules.sql"
+    "${API_DIR}/bookmarks/migrations/002_create_bookmark_collections.sql"
+    "${API_DIR}/comments/migrations/009_add_comment_reply_count.sql"
+    "${API_DIR}/comments/migrations/010_create_comment_reactions.sql"
+    "${API_DIR}/posts/migrations/014_create_post_tags.sql"
+    "${API_DIR}/posts/migrations/015_add_public_created_index.sql"
+    "${API_DIR}/mentions/migrations/001_create_mentions.sql"
+    "${API_DIR}/storage/migrations/003_add_image_metadata.sql"
+    "${API_DIR}/storage/migrations/004_add_image_pipeline.sql"
+    "${API_DIR}/internal/outbox/migrations/001_create_outbox.sql"
+    "${API_DIR}/webhooks/migrations/001_create_webhook_deliveries.sql"
+    "${API_DIR}/auth/migrations/007_add_user_suspension.sql"
+    "${API_DIR}/auth/migrations/008_add_admin_log_request_id.sql"
+    "${API_DIR}/moderation/migrations/001_create_moderation.sql"
+    "${API_DIR}/internal/database/jsonbmigrate/migrations/001_create_checkpoints.sql"
+    "${API_DIR}/internal/outbox/migrations/002_add_seq_index.sql"
+    "${API_DIR}/profile/migrations/005_add_updated_at_index.sql"
+    "${API_DIR}/internal/propagation/migrations/001_create_profile_propagation.sql"
+    "${API_DIR}/mentions/migrations/002_add_actor_index.sql"
+    "${API_DIR}/communities/migrations/001_create_communities.sql"
+    "${API_DIR}/posts/migrations/016_add_community_id.sql"
+    "${API_DIR}/communities/migrations/002_create_community_starters.sql"
+    "${API_DIR}/auth/migrations/009_add_user_tokens_valid_after.sql"
+    "${API_DIR}/posts/migrations/017_create_community_placements.sql"
+    "${API_DIR}/communities/migrations/003_add_archived_date.sql"
+    "${API_DIR}/auth/migrations/010_create_user_token_states.sql"
+    "${API_DIR}/votes/migrations/008_allow_vote_event_erasure.sql"
+    "${API_DIR}/communities/migrations/004_add_allow_anonymous.sql"
+    "${API_DIR}/communities/migrations/005_add_requires_approval.sql"
+    "${API_DIR}/membership/migrations/002_scope_to_communities.sql"
+    "${API_DIR}/rules/migrations/002_scope_to_communities.sql"
+    "${API_DIR}/calendar/migrations/002_create_community_calendar_tokens.sql"
 )
 
+# search_path for a migration of the given module: its schema first, or public for a
+# module without one, so the tables it creates land there, then the other module
+# schemas so references resolve
+search_path_for() {
+    local schemas=() schema
+    for schema in "${MODULE_SCHEMA[$1]:-public}" $(for m in "${MODULES[@]}"; do echo "${MODULE_SCHEMA[$m]}"; done) public; do
+        if [[ -n "$schema" && ! " ${schemas[*]} " =~ " ${schema} " ]]; then
+            schemas+=("$schema")
+        fi
+    done
+    (IFS=,; echo "${schemas[*]}")
+}
+
+for module in "${MODULES[@]}"; do
+    if [[ -n "${MODULE_SCHEMA[$module]}" ]]; then
+        log_info "Module $module uses schema ${MODULE_SCHEMA[$module]}"
+        run_sql "CREATE SCHEMA IF NOT EXISTS \"${MODULE_SCHEMA[$module]}\";"
+    fi
+done
+
+run_sql "CREATE TABLE IF NOT EXISTS public.schema_migrations (
+    version INT PRIMARY KEY,
+    name TEXT NOT NULL,
+    breaking BOOLEAN NOT NULL DEFAULT FALSE,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);"
+
+version=0
 for migration_file in "${MIGRATIONS[@]}"; do
+    version=$((version + 1))
     if [[ ! -f "$migration_file" ]]; then
         log_warning "Migration file not found: $migration_file (skipping)"
         continue
     fi
     
+    # The first directory under apps/api names the module that owns the migration
+    module="${migration_file#"${API_DIR}/"}"
+    module="${module%%/*}"
+    search_path=$(search_path_for "$module")
+
     log_info "Applying: $(basename "$migration_file")"
     
     if [[ "$USE_DOCKER_EXEC" == "true" ]]; then
         if docker exec -i telar-postgres psql -U "$DB_USER" -d "$DB_NAME" <<EOF > /dev/null 2>&1; then
-SET search_path TO public;
+SET search_path TO ${search_path};
 $(cat "$migration_file")
 EOF
             log_info "  ✓ Success"
@@ -94,7 +247,7 @@ EOF
             exit 1
         fi
     else
-        if (echo "SET search_path TO public;" && cat "$migration_file") | PGPASSWORD="$DB_PASSWORD" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" > /dev/null 2>&1; then
+        if (echo "SET search_path TO ${search_path};" && cat "$migration_file") | PGPASSWORD="$DB_PASSWORD" psql -h "$DB_HOST" -p "$DB_PORT" -U "$DB_USER" -d "$DB_NAME" > /dev/null 2>&1; then
             log_info "  ✓ Success"
         else
             log_error "  ✗ Failed to apply $(basename "$migration_file")"
@@ -103,6 +256,96 @@ EOF
             exit 1
         fi
     fi
+
+    breaking=false
+    if grep -q "^-- schema:breaking" "$migration_file"; then
+        breaking=true
+    fi
+    run_sql "INSERT INTO public.schema_migrations (version, name, breaking)
+        VALUES (${version}, '$(basename "$migration_file")', ${breaking})
+        ON CONFLICT (version) DO UPDATE SET name = EXCLUDED.name, breaking = EXCLUDED.breaking;"
+done
+
+# Tables the migrations of a module create, for granting them when it has no schema
+module_tables() {
+    grep -hoiE "create (table|view)( if not exists)? [a-z_]+" "${API_DIR}/$1"/migrations/*.sql | awk '{print $NF}' | sort -u
+}
+
+# grant_tables role schema privileges table...: grants the tables that exist
+grant_tables() {
+    local role=$1 schema=$2 privileges=$3
+    shift 3
+    if [[ $# -eq 0 ]]; then
+        return
+    fi
+    run_sql "GRANT USAGE ON SCHEMA \"${schema}\" TO \"${role}\";
+    DO \$\$ DECLARE t TEXT; BEGIN
+        FOREACH t IN ARRAY ARRAY['$(echo "$*" | sed "s/ /','/g")'] LOOP
+            IF to_regclass(format('%I.%I', '${schema}', t)) IS NOT NULL THEN
+                EXECUTE format('GRANT ${privileges} ON %I.%I TO %I', '${schema}', t, '${role}');
+            END IF;
+        END LOOP;
+    END \$\$;"
+}
+
+# grant_module role module privileges: every table of the module, in its schema or,
+# for a module without one, in public
+grant_module() {
+    local role=$1 module=$2 privileges=$3 schema="${MODULE_SCHEMA[$2]:-}"
+    if [[ -z "$schema" ]]; then
+        grant_tables "$role" public "$privileges" $(module_tables "$module")
+        return
+    fi
+    run_sql "GRANT USAGE ON SCHEMA \"${schema}\" TO \"${role}\";
+        GRANT ${privileges} ON ALL TABLES IN SCHEMA \"${schema}\" TO \"${role}\";
+        ALTER DEFAULT PRIVILEGES IN SCHEMA \"${schema}\" GRANT ${privileges} ON TABLES TO \"${role}\";"
+    if [[ "$privileges" != "SELECT" ]]; then
+        run_sql "GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA \"${schema}\" TO \"${role}\";
+            ALTER DEFAULT PRIVILEGES IN SCHEMA \"${schema}\" GRANT USAGE, SELECT ON SEQUENCES TO \"${role}\";"
+    fi
+}
+
+# Least-privilege roles for service binaries that run a single module: full DML on the
+# module's own tables and the module tables listed in MODULE_WRITES, read access to
+# those in MODULE_READS, and in public only the tables the binary's modules use.
+# Grants from earlier runs are revoked first, so narrowing a list takes effect.
+# Migrations keep running as the owner configured above.
+DML="SELECT, INSERT, UPDATE, DELETE"
+for module in "${MODULES[@]}"; do
+    role="${MODULE_USER[$module]}"
+    if [[ -z "$role" ]]; then
+        continue
+    fi
+    log_info "Granting module $module to role $role"
+    run_sql "DO \$\$ BEGIN
+        IF NOT EXISTS (SELECT FROM pg_roles WHERE rolname = '${role}') THEN
+            CREATE ROLE \"${role}\" LOGIN PASSWORD '${MODULE_PASSWORD[$module]}';
+        END IF;
+    END \$\$;"
+
+    for schema in $(search_path_for "$module" | tr ',' ' '); do
+        run_sql "REVOKE ALL ON ALL TABLES IN SCHEMA \"${schema}\" FROM \"${role}\";
+            ALTER DEFAULT PRIVILEGES IN SCHEMA \"${schema}\" REVOKE ALL ON TABLES FROM \"${role}\";
+            ALTER DEFAULT PRIVILEGES IN SCHEMA \"${schema}\" REVOKE ALL ON SEQUENCES FROM \"${role}\";"
+        if [[ "$schema" != "public" ]]; then
+            run_sql "REVOKE USAGE ON SCHEMA \"${schema}\" FROM \"${role}\";"
+        fi
+    done
+
+    grant_module "$role" "$module" "$DML"
+    for other in ${MODULE_WRITES[$module]:-}; do
+        grant_module "$role" "$other" "$DML"
+    done
+    for other in ${MODULE_READS[$module]:-}; do
+        grant_module "$role" "$other" SELECT
+    done
+    if [[ "$module" != "auth" ]]; then
+        grant_tables "$role" "${MODULE_SCHEMA[auth]:-public}" SELECT user_token_states
+    fi
+
+    grant_tables "$role" public "$DML" $PUBLIC_SHARED_TABLES ${MODULE_PUBLIC_WRITES[$module]:-}
+    grant_tables "$role" public SELECT schema_migrations ${MODULE_PUBLIC_READS[$module]:-}
+    run_sql "GRANT USAGE, SELECT ON ALL SEQUENCES IN SCHEMA public TO \"${role}\";"
 done
 
 log_info ""